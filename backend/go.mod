@@ -9,4 +9,6 @@ require (
 	gopkg.in/yaml.v3 v3.0.1
 )
 
+require golang.org/x/crypto v0.37.0 // indirect
+
 replace csd-devtrack/cli => ../cli
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"os"
@@ -14,7 +15,13 @@ import (
 	"csd-devtrack/cli/modules/commands"
 	"csd-devtrack/cli/modules/platform/config"
 	"csd-devtrack/cli/modules/platform/daemon"
+	"csd-devtrack/cli/modules/platform/eventbridge"
+	"csd-devtrack/cli/modules/platform/eventbus"
 	"csd-devtrack/cli/modules/platform/logger"
+	"csd-devtrack/cli/modules/platform/startup"
+	"csd-devtrack/cli/modules/platform/tracing"
+	"csd-devtrack/cli/modules/platform/webui"
+	uicore "csd-devtrack/cli/modules/ui/core"
 )
 
 func main() {
@@ -26,10 +33,24 @@ func main() {
 
 	// Parse global flags
 	args := os.Args[1:]
+
+	// Shell completion: the words typed so far, flags included, are not parsed
+	if len(args) > 0 && args[0] == commands.CompleteCommand {
+		commands.Complete(args[1:])
+		return
+	}
+
+	// Terminal recording: output piped by tmux pipe-pane
+	if len(args) > 0 && args[0] == commands.RecordCommand {
+		commands.Record(args[1:])
+		return
+	}
 	configPath := ""
 	verbose := false
 	noDaemon := false
+	readOnly := false
 	instanceName := ""
+	remote := daemon.Remote{Token: os.Getenv("CSD_DEVTRACK_TOKEN")}
 
 	// Extract global flags
 	var cmdArgs []string
@@ -48,6 +69,30 @@ func main() {
 			verbose = true
 		case arg == "--no-daemon":
 			noDaemon = true
+		case arg == "--read-only":
+			readOnly = true
+		case arg == "--remote" || arg == "--token" || arg == "--fingerprint":
+			if i+1 < len(args) {
+				switch arg {
+				case "--remote":
+					remote.Address = args[i+1]
+				case "--token":
+					remote.Token = args[i+1]
+				case "--fingerprint":
+					remote.Fingerprint = args[i+1]
+				}
+				i++
+			}
+		case strings.HasPrefix(arg, "--remote="):
+			remote.Address = strings.TrimPrefix(arg, "--remote=")
+		case strings.HasPrefix(arg, "--token="):
+			remote.Token = strings.TrimPrefix(arg, "--token=")
+		case strings.HasPrefix(arg, "--fingerprint="):
+			remote.Fingerprint = strings.TrimPrefix(arg, "--fingerprint=")
+		case arg == "--profile-startup":
+			// Measured in this process: the presenter is not run by a daemon
+			startup.Enable()
+			noDaemon = true
 		case arg == "--name" || arg == "-n":
 			if i+1 < len(args) {
 				instanceName = args[i+1]
@@ -159,11 +204,16 @@ func main() {
 		configPath = config.FindConfigFile()
 	}
 
+	doneConfig := startup.Track("config")
 	if err := config.LoadGlobalWithCreate(configPath, explicitConfig); err != nil {
 		if verbose {
 			fmt.Fprintf(os.Stderr, "Warning: Failed to load config: %v\n", err)
 		}
 	}
+	if report := config.LastMigration(); report != nil {
+		fmt.Fprintln(os.Stderr, report)
+	}
+	doneConfig()
 
 	// Initialize command registry
 	commands.InitRegistry()
@@ -200,6 +250,28 @@ func main() {
 		commands.SetDaemonMode(true)
 	}
 
+	// Read-only (observer) mode only applies to the TUI
+	if readOnly {
+		if cmdName != "ui" {
+			fmt.Fprintf(os.Stderr, "Error: --read-only only applies to the ui command\n")
+			os.Exit(1)
+		}
+		commands.SetReadOnly(true)
+	}
+
+	// Team mode: the TUI attaches to a shared daemon over the network
+	if remote.Address != "" {
+		if cmdName != "ui" || noDaemon {
+			fmt.Fprintf(os.Stderr, "Error: --remote only applies to the ui command with a daemon\n")
+			os.Exit(1)
+		}
+		if remote.Token == "" {
+			fmt.Fprintf(os.Stderr, "Error: --remote requires --token (or CSD_DEVTRACK_TOKEN)\n")
+			os.Exit(1)
+		}
+		commands.SetRemote(&remote)
+	}
+
 	// Look up command in registry
 	cmd := commands.GetCommand(cmdName)
 	if cmd == nil {
@@ -209,7 +281,11 @@ func main() {
 	}
 
 	// Execute command
-	if err := cmd.Handler(cmdRemainingArgs); err != nil {
+	err := cmd.Handler(cmdRemainingArgs)
+	if startup.Enabled() {
+		fmt.Print(startup.Report())
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -286,8 +362,9 @@ func runAsDaemon() {
 
 	// Parse log level from config
 	logLevel := logger.ParseLevel(loggerCfg.Level)
-	log := logger.NewLogger(logLevel, logOutputs, "csd-devtrack")
+	log := logger.NewLogger(logLevel, logOutputs, uicore.LogSourceDevTrack)
 	logger.SetGlobalLogger(log)
+	presenter.SetLogger(log)
 
 	// Create daemon server
 	server := daemon.NewServer(presenter)
@@ -309,6 +386,9 @@ func runAsDaemon() {
 	}
 
 	log.Info("Daemon starting...")
+	if report := config.LastMigration(); report != nil {
+		log.Warn("%s", report)
+	}
 
 	// Start server FIRST so socket/PID exist (client can connect)
 	if err := server.Start(); err != nil {
@@ -318,10 +398,97 @@ func runAsDaemon() {
 
 	log.Info("Daemon started successfully")
 
+	// WebSocket log stream for external tools (before the presenter logs)
+	if cfg.Settings != nil {
+		if streamCfg := cfg.Settings.GetLogStreamConfig(); streamCfg.Enabled {
+			if err := streamCfg.ResolveSecrets(cfg); err != nil {
+				log.Warn("Log stream not started: %v", err)
+			} else if err := server.StartLogStream(streamCfg); err != nil {
+				log.Warn("Log stream not started: %v", err)
+			} else {
+				log.Info("Log stream on %s", server.LogStreamURL())
+			}
+		}
+	}
+
+	// Team mode: teammates attach over the network
+	if cfg.Settings != nil {
+		if teamCfg := cfg.Settings.GetTeamConfig(); teamCfg.Enabled {
+			if err := teamCfg.ResolveSecrets(cfg); err != nil {
+				log.Warn("Team mode not started: %v", err)
+			} else if err := server.StartTeam(teamCfg); err != nil {
+				log.Warn("Team mode not started: %v", err)
+			} else {
+				log.Info("Team mode on %s (TLS fingerprint %s)", server.TeamAddress(), server.TeamFingerprint())
+			}
+		}
+	}
+
+	// Domain events published to NATS or Redis
+	var bridge *eventbridge.Bridge
+	if cfg.Settings != nil {
+		if bridgeCfg := cfg.Settings.GetEventBridgeConfig(); bridgeCfg.Enabled {
+			var err error
+			if err = bridgeCfg.ResolveSecrets(cfg); err != nil {
+				log.Warn("Event bridge not started: %v", err)
+			} else if bridge, err = eventbridge.New(eventbus.Global(), bridgeCfg); err != nil {
+				log.Warn("Event bridge not started: %v", err)
+			} else {
+				bridge.Start()
+				log.Info("Event bridge to %s", bridge.Target())
+			}
+		}
+	}
+
+	// Read-only web dashboard
+	var dashboard *webui.Server
+	if cfg.Settings != nil {
+		if dashboardCfg := cfg.Settings.GetDashboardConfig(); dashboardCfg.Enabled {
+			dashboard = webui.NewServer(presenter, dashboardCfg)
+			err := dashboardCfg.ResolveSecrets(cfg)
+			if err == nil && cfg.Settings.DashboardTLS() {
+				// Beyond localhost in team mode: TLS with the team certificate
+				teamCfg := cfg.Settings.GetTeamConfig()
+				if err = teamCfg.ResolveSecrets(cfg); err == nil {
+					var cert tls.Certificate
+					if cert, err = daemon.TeamCertificate(teamCfg); err == nil {
+						dashboard.SetTeam(teamCfg, cert)
+					}
+				}
+			}
+			if err == nil {
+				err = dashboard.Start()
+			}
+			if err != nil {
+				log.Warn("Web dashboard not started: %v", err)
+				dashboard = nil
+			} else {
+				log.Info("Web dashboard on %s", dashboard.URL())
+			}
+		}
+	}
+
+	// OpenTelemetry spans of the daemon operations, exported over OTLP
+	if cfg.Settings != nil {
+		if tracingCfg := cfg.Settings.GetTracingConfig(); tracingCfg.Enabled {
+			if err := tracingCfg.ResolveSecrets(cfg); err != nil {
+				log.Warn("Tracing not enabled: %v", err)
+			} else {
+				tracing.Enable(tracingCfg, func(err error) {
+					log.Warn("Tracing: %v", err)
+				})
+				log.Info("Tracing to %s", tracing.Endpoint())
+			}
+		}
+	}
+
 	// NOW initialize presenter (this does slow git operations)
 	// Client can already connect while this runs
 	ctx := context.Background()
-	if err := presenter.Initialize(ctx); err != nil {
+	_, span := tracing.Start(ctx, "presenter.initialize") // Not the parent of the presenter spans
+	err := presenter.Initialize(ctx)
+	span.End()
+	if err != nil {
 		log.Error("Failed to initialize presenter: %v", err)
 		// Don't exit - server is running, just log the error
 	}
@@ -417,8 +584,15 @@ func runAsDaemon() {
 	<-sigCh
 
 	// Graceful shutdown
+	if bridge != nil {
+		bridge.Stop()
+	}
+	if dashboard != nil {
+		dashboard.Stop()
+	}
 	server.Stop()
 	presenter.Shutdown()
+	tracing.Shutdown()
 }
 
 // handleDaemonCommand handles daemon subcommands
@@ -520,6 +694,11 @@ func printHelp() {
 	fmt.Println("  -V, --version          Print version")
 	fmt.Println("  -h, --help             Print help")
 	fmt.Println("      --no-daemon        Run without daemon mode")
+	fmt.Println("      --read-only        Attach the TUI in observation mode (no actions)")
+	fmt.Println("      --remote <addr>    Attach the TUI to a shared daemon (team mode, host:port)")
+	fmt.Println("      --token <token>    Team token for --remote (or CSD_DEVTRACK_TOKEN)")
+	fmt.Println("      --fingerprint <fp> Pin the self-signed certificate of the shared daemon")
+	fmt.Println("      --profile-startup  Print the time spent initializing each module (runs without daemon)")
 	fmt.Println()
 	fmt.Println("Daemon Management:")
 	fmt.Println("      --names            List all daemon instances")
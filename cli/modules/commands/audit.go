@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"csd-devtrack/cli/modules/platform/audit"
+	"csd-devtrack/cli/modules/platform/config"
+)
+
+// openAuditLog opens the audit log shared with the TUI and daemon (nil if disabled)
+func openAuditLog() *audit.Service {
+	auditConfig := config.DefaultAuditConfig()
+	if cfg := config.GetGlobal(); cfg != nil && cfg.Settings != nil {
+		auditConfig = cfg.Settings.GetAuditConfig()
+	}
+	if auditConfig.Disabled {
+		return nil
+	}
+	dataDir, err := config.GetDataDir()
+	if err != nil {
+		return nil
+	}
+	return audit.NewService(filepath.Join(dataDir, "audit.jsonl"), auditConfig.MaxEntries)
+}
+
+// auditValueFlags are the flags of audited commands that take a value
+var auditValueFlags = map[string]bool{"--name": true, "--profile": true}
+
+// audited wraps a state-changing command so that each run is recorded in the audit log.
+// Positional arguments are recorded as project and component.
+func audited(action string, handler CommandHandler) CommandHandler {
+	return func(args []string) error {
+		err := handler(args)
+
+		log := openAuditLog()
+		if log == nil {
+			return err
+		}
+		entry := audit.Entry{
+			Action: action,
+			Origin: audit.OriginCLI,
+		}
+		var positional, flags []string
+		for i := 0; i < len(args); i++ {
+			switch {
+			case auditValueFlags[args[i]] && i+1 < len(args):
+				flags = append(flags, args[i]+" "+args[i+1])
+				i++
+			case strings.HasPrefix(args[i], "-"):
+				flags = append(flags, args[i])
+			default:
+				positional = append(positional, args[i])
+			}
+		}
+		entry.Details = strings.Join(flags, " ")
+		if len(positional) > 0 {
+			entry.ProjectID = positional[0]
+		}
+		if len(positional) > 1 {
+			entry.Component = positional[1]
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		log.Record(entry)
+		return err
+	}
+}
+
+// auditCommand handles the 'audit' command
+func auditCommand(args []string) error {
+	filter := audit.Filter{Limit: 50}
+	exportPath := ""
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--project", "-p":
+			if i+1 < len(args) {
+				i++
+				filter.ProjectID = args[i]
+			}
+		case "--limit", "-n":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil {
+					return fmt.Errorf("invalid limit: %s", args[i])
+				}
+				filter.Limit = n
+			}
+		case "--export", "-o":
+			if i+1 < len(args) {
+				i++
+				exportPath = args[i]
+			}
+		case "--json":
+			filter.Limit = 0
+			exportPath = "-"
+		default:
+			return fmt.Errorf("unknown option: %s\nUsage: csd-devtrack audit [--project <id>] [--limit <n>] [--export <file.csv|file.json>] [--json]", args[i])
+		}
+	}
+
+	log := openAuditLog()
+	if log == nil {
+		return fmt.Errorf("audit log is disabled (audit.disabled in config)")
+	}
+
+	switch exportPath {
+	case "":
+	case "-":
+		return audit.WriteJSON(os.Stdout, log.List(filter))
+	default:
+		// Exports contain the whole history unless a limit is given
+		if !containsArg(args, "--limit", "-n") {
+			filter.Limit = 0
+		}
+		count, err := log.Export(exportPath, filter)
+		if err != nil {
+			return fmt.Errorf("export failed: %w", err)
+		}
+		fmt.Printf("Exported %d entries to %s\n", count, exportPath)
+		return nil
+	}
+
+	entries := log.List(filter)
+	if len(entries) == 0 {
+		fmt.Println("No actions recorded.")
+		return nil
+	}
+
+	// Oldest first, like a log
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		subject := e.ProjectID
+		if e.Component != "" {
+			subject += "/" + e.Component
+		}
+		if subject == "" {
+			subject = e.Target
+		}
+		status := "✓"
+		if e.Error != "" {
+			status = "✗"
+		}
+		fmt.Printf("%s %s %-18s %-24s %-10s %s\n",
+			e.Time.Format("2006-01-02 15:04:05"), status, e.Action, subject, e.Origin, e.User)
+		if e.Error != "" {
+			fmt.Printf("    error: %s\n", e.Error)
+		}
+	}
+	fmt.Printf("\nLog: %s\n", log.File())
+	return nil
+}
+
+// containsArg returns true if any of the names is in args
+func containsArg(args []string, names ...string) bool {
+	for _, arg := range args {
+		for _, name := range names {
+			if arg == name {
+				return true
+			}
+		}
+	}
+	return false
+}
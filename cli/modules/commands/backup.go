@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/audit"
+	"csd-devtrack/cli/modules/platform/backup"
+	"csd-devtrack/cli/modules/platform/daemon"
+	"csd-devtrack/cli/modules/platform/storage"
+)
+
+// backupCommand handles the 'backup' command
+func backupCommand(args []string) error {
+	var opts backup.Options
+	archivePath := ""
+	for _, arg := range args {
+		switch {
+		case arg == "--full":
+			opts.Full = true
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown option: %s\nUsage: csd-devtrack backup [file.tar.gz] [--full]", arg)
+		default:
+			archivePath = arg
+		}
+	}
+	if archivePath == "" {
+		archivePath = backup.DefaultFileName()
+	}
+
+	loc, err := backup.DefaultLocations()
+	if err != nil {
+		return err
+	}
+	manifest, err := backup.Create(archivePath, loc, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Backed up %d files (%s) to %s\n", manifest.Files, storage.FormatBytes(manifest.Size), archivePath)
+	if !opts.Full {
+		fmt.Println("  Recordings and captured traffic not included (--full to include them)")
+	}
+	fmt.Println("  The archive holds tokens and keys: keep it private")
+	return nil
+}
+
+// restoreCommand handles the 'restore' command
+func restoreCommand(args []string) error {
+	assumeYes := false
+	archivePath := ""
+	for _, arg := range args {
+		switch {
+		case arg == "--yes" || arg == "-y":
+			assumeYes = true
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown option: %s\nUsage: csd-devtrack restore <file.tar.gz> [--yes]", arg)
+		default:
+			archivePath = arg
+		}
+	}
+	if archivePath == "" {
+		return fmt.Errorf("usage: csd-devtrack restore <file.tar.gz> [--yes]")
+	}
+	if daemon.IsRunning() {
+		return fmt.Errorf("the daemon is running and would overwrite the restored data: stop it first (csd-devtrack --kill)")
+	}
+
+	manifest, err := backup.Inspect(archivePath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Backup of %s, %s (DevTrack %s): %d files, %s\n",
+		manifest.Hostname, manifest.CreatedAt.Format("2006-01-02 15:04"), manifest.AppVersion,
+		manifest.Files, storage.FormatBytes(manifest.Size))
+
+	if !assumeYes {
+		if !canPrompt() {
+			return fmt.Errorf("confirmation required: run again with --yes")
+		}
+		fmt.Print("Replace the current DevTrack data with this backup? [y/N] ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Restore cancelled.")
+			return nil
+		}
+	}
+
+	loc, err := backup.DefaultLocations()
+	if err != nil {
+		return err
+	}
+
+	// The current data is kept, to undo the restore
+	safety := filepath.Join(loc.BackupsDir, "pre-restore-"+time.Now().Format("20060102-150405")+".tar.gz")
+	if _, err := backup.Create(safety, loc, backup.Options{}); err != nil {
+		return fmt.Errorf("failed to back up the current data: %w", err)
+	}
+
+	result, err := backup.Restore(archivePath, loc)
+	if log := openAuditLog(); log != nil {
+		entry := audit.Entry{
+			Action:  "restore",
+			Target:  archivePath,
+			Origin:  audit.OriginCLI,
+			Details: fmt.Sprintf("backup of %s (%s)", manifest.Hostname, manifest.CreatedAt.Format("2006-01-02 15:04")),
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		log.Record(entry)
+	}
+	if err != nil {
+		return fmt.Errorf("restore failed (previous data in %s): %w", safety, err)
+	}
+
+	fmt.Printf("✓ Restored %d files\n", result.Files)
+	if result.Migrated {
+		fmt.Printf("  Migrated from backup schema %d to %d\n", manifest.Schema, backup.SchemaVersion)
+	}
+	if result.Relocated != "" {
+		home, _ := os.UserHomeDir()
+		fmt.Printf("  Paths under %s rewritten to %s\n", result.Relocated, home)
+	}
+	fmt.Printf("  Previous data saved in %s\n", safety)
+	return nil
+}
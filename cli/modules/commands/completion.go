@@ -0,0 +1,297 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/config"
+)
+
+// CompleteCommand is the hidden command the shell completion scripts call
+// with the words typed so far (the last one being completed)
+const CompleteCommand = "__complete"
+
+// completionShells are the shells a completion script can be generated for
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// globalFlags are completed before the command name
+var globalFlags = []string{"--config", "--name", "--verbose", "--version", "--help",
+	"--no-daemon", "--read-only", "--names", "--kill", "--kill-force", "--wipe"}
+
+// globalValueFlags are the global flags followed by a value
+var globalValueFlags = map[string]bool{"-c": true, "--config": true, "-n": true, "--name": true}
+
+// commandFlags are the flags completed for each command
+var commandFlags = map[string][]string{
+	"add":         {"--name"},
+	"list":        {"--json"},
+	"status":      {"--format"},
+	"build":       {"--profile"},
+	"kill":        {"--force"},
+	"logs":        {"--follow", "--lines"},
+	"audit":       {"--project", "--limit", "--export", "--json"},
+	"server":      {"--port"},
+	"self-update": {"--check", "--channel", "--yes"},
+	"backup":      {"--full"},
+	"restore":     {"--yes"},
+	"secrets":     {"--yes", "--source"},
+}
+
+// commandValueFlags are the command flags followed by a value
+var commandValueFlags = map[string]bool{"--name": true, "--profile": true, "--lines": true, "-n": true,
+	"--project": true, "--limit": true, "--export": true, "--port": true, "--channel": true, "--format": true, "--source": true}
+
+// Positional argument kinds
+const (
+	argProject   = "project"
+	argComponent = "component"
+	argSub       = "sub" // Sub-command of the command
+)
+
+// commandArgs are the positional arguments completed for each command
+var commandArgs = map[string][]string{
+	"remove":     {argProject},
+	"status":     {argProject},
+	"refresh":    {argProject},
+	"build":      {argProject, argComponent},
+	"run":        {argProject, argComponent},
+	"stop":       {argProject, argComponent},
+	"restart":    {argProject, argComponent},
+	"kill":       {argProject, argComponent},
+	"logs":       {argProject, argComponent},
+	"git":        {argSub, argProject},
+	"config":     {argSub},
+	"plugins":    {argSub},
+	"secrets":    {argSub},
+	"completion": {argSub},
+}
+
+// completionCommand handles the 'completion' command
+func completionCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("shell is required\nUsage: csd-devtrack completion <%s>", strings.Join(completionShells, "|"))
+	}
+
+	switch args[0] {
+	case "bash":
+		fmt.Print(bashCompletion)
+	case "zsh":
+		fmt.Print(zshCompletion)
+	case "fish":
+		fmt.Print(fishCompletion)
+	default:
+		return fmt.Errorf("unsupported shell: %s (supported: %s)", args[0], strings.Join(completionShells, ", "))
+	}
+	return nil
+}
+
+// Complete prints the candidates for the last word of a command line
+// (the arguments following csd-devtrack). Called by the completion scripts.
+func Complete(words []string) {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current := words[len(words)-1]
+	typed := words[:len(words)-1]
+
+	// Global flags come first; the config they name is used for the candidates
+	configPath := ""
+	i := 0
+	for i < len(typed) && strings.HasPrefix(typed[i], "-") {
+		if globalValueFlags[typed[i]] && i+1 < len(typed) {
+			if typed[i] == "-c" || typed[i] == "--config" {
+				configPath = typed[i+1]
+			}
+			i++
+		}
+		i++
+	}
+	if configPath == "" {
+		configPath = config.FindConfigFile()
+	}
+	_ = config.LoadGlobal(configPath)
+	InitRegistry()
+
+	for _, candidate := range filterPrefix(completionCandidates(typed[i:], current), current) {
+		fmt.Println(candidate)
+	}
+}
+
+// completionCandidates returns the candidates of the current word after the
+// command words (global flags removed)
+func completionCandidates(typed []string, current string) []string {
+	if len(typed) == 0 {
+		if strings.HasPrefix(current, "-") {
+			return globalFlags
+		}
+		return append(GetCommandNames(), "help", "version", "daemon")
+	}
+
+	name := typed[0]
+	switch name {
+	case "help":
+		if len(typed) == 1 {
+			return GetCommandNames()
+		}
+		return nil
+	case "daemon":
+		if len(typed) == 1 {
+			return []string{"status", "start", "stop"}
+		}
+		return nil
+	}
+	cmd := GetCommand(name)
+	if cmd == nil {
+		return nil
+	}
+
+	// Value of a flag
+	args := typed[1:]
+	if len(args) > 0 {
+		switch args[len(args)-1] {
+		case "--profile":
+			return buildProfileNames()
+		case "--project":
+			return projectIDs()
+		case "--channel":
+			return []string{"stable", "beta"}
+		case "--format":
+			return statusBarFormats
+		}
+		if commandValueFlags[args[len(args)-1]] {
+			return nil
+		}
+	}
+	if strings.HasPrefix(current, "-") {
+		return commandFlags[cmd.Name]
+	}
+
+	// Positional arguments typed so far
+	var positional []string
+	for j := 0; j < len(args); j++ {
+		switch {
+		case commandValueFlags[args[j]]:
+			j++
+		case strings.HasPrefix(args[j], "-"):
+		default:
+			positional = append(positional, args[j])
+		}
+	}
+
+	kinds := commandArgs[cmd.Name]
+	if len(positional) >= len(kinds) {
+		return nil
+	}
+	switch kinds[len(positional)] {
+	case argProject:
+		ids := projectIDs()
+		if cmd.Name == "build" {
+			ids = append([]string{"all"}, ids...)
+		}
+		return ids
+	case argComponent:
+		return componentNames(positional[len(positional)-1])
+	case argSub:
+		subs := make([]string, 0, len(cmd.SubCommands))
+		for _, sub := range cmd.SubCommands {
+			subs = append(subs, sub.Name)
+		}
+		return subs
+	}
+	return nil
+}
+
+// projectIDs returns the IDs of the configured projects
+func projectIDs() []string {
+	if err := InitContext(); err != nil {
+		return nil
+	}
+	var ids []string
+	for _, p := range GetContext().ProjectService.ListProjects() {
+		ids = append(ids, p.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// componentNames returns the enabled components of a project,
+// or every component type if the project is unknown
+func componentNames(projectID string) []string {
+	if err := InitContext(); err == nil {
+		if p, err := GetContext().ProjectService.GetProject(projectID); err == nil {
+			var names []string
+			for _, comp := range p.GetEnabledComponents() {
+				names = append(names, string(comp.Type))
+			}
+			return names
+		}
+	}
+
+	var names []string
+	for _, ct := range projects.AllComponentTypes() {
+		names = append(names, string(ct))
+	}
+	return names
+}
+
+// buildProfileNames returns the build profiles of the config
+func buildProfileNames() []string {
+	cfg := config.GetGlobal()
+	profiles := config.DefaultBuildProfiles()
+	if cfg != nil && len(cfg.BuildProfiles) > 0 {
+		profiles = cfg.BuildProfiles
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// filterPrefix returns the unique candidates starting with prefix
+func filterPrefix(candidates []string, prefix string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, prefix) && !seen[c] {
+			seen[c] = true
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+const bashCompletion = `# csd-devtrack bash completion
+# Install: csd-devtrack completion bash > /etc/bash_completion.d/csd-devtrack
+#      or: source <(csd-devtrack completion bash)
+_csd_devtrack() {
+    local IFS=$'\n'
+    COMPREPLY=($(csd-devtrack __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _csd_devtrack csd-devtrack
+`
+
+const zshCompletion = `#compdef csd-devtrack
+# csd-devtrack zsh completion
+# Install: csd-devtrack completion zsh > "${fpath[1]}/_csd-devtrack"
+#      or: source <(csd-devtrack completion zsh)
+_csd_devtrack() {
+    local -a candidates
+    candidates=(${(f)"$(csd-devtrack __complete "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+    compadd -a candidates
+}
+compdef _csd_devtrack csd-devtrack
+`
+
+const fishCompletion = `# csd-devtrack fish completion
+# Install: csd-devtrack completion fish > ~/.config/fish/completions/csd-devtrack.fish
+function __csd_devtrack_complete
+    set -l tokens (commandline -opc)
+    set -l current (commandline -ct)
+    csd-devtrack __complete $tokens[2..-1] "$current" 2>/dev/null
+end
+complete -c csd-devtrack -f -a '(__csd_devtrack_complete)'
+`
@@ -1,10 +1,16 @@
 package commands
 
 import (
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/daemon"
 	uicore "csd-devtrack/cli/modules/ui/core"
 )
 
-var daemonMode bool
+var (
+	daemonMode bool
+	readOnly   bool
+	remote     *daemon.Remote
+)
 
 // SetDaemonMode sets whether the UI should run in daemon mode
 func SetDaemonMode(enabled bool) {
@@ -16,8 +22,31 @@ func IsDaemonMode() bool {
 	return daemonMode
 }
 
+// SetReadOnly sets whether the UI is attached in read-only (observer) mode.
+// The config file is never written in this mode.
+func SetReadOnly(enabled bool) {
+	readOnly = enabled
+	config.SetReadOnly(enabled)
+}
+
+// IsReadOnly returns whether read-only mode is enabled
+func IsReadOnly() bool {
+	return readOnly
+}
+
+// SetRemote attaches the UI to a shared daemon (team mode) instead of the
+// local one
+func SetRemote(r *daemon.Remote) {
+	remote = r
+}
+
+// GetRemote returns the shared daemon the UI attaches to, nil for the local one
+func GetRemote() *daemon.Remote {
+	return remote
+}
+
 // CreatePresenter creates a presenter for the daemon
 // This initializes the full presenter with all services
-func CreatePresenter(appCtx *AppContext) uicore.Presenter {
-	return uicore.NewPresenter(appCtx.ProjectService, appCtx.Config)
+func CreatePresenter(appCtx *AppContext) *uicore.AppPresenter {
+	return uicore.NewAppPresenter(appCtx.ProjectService, appCtx.Config)
 }
@@ -15,8 +15,12 @@ import (
 	"csd-devtrack/cli/modules/core/projects"
 	"csd-devtrack/cli/modules/platform/builder"
 	"csd-devtrack/cli/modules/platform/daemon"
+	"csd-devtrack/cli/modules/platform/config"
 	"csd-devtrack/cli/modules/platform/git"
+	"csd-devtrack/cli/modules/platform/logger"
 	"csd-devtrack/cli/modules/platform/server"
+	"csd-devtrack/cli/modules/platform/services"
+	"csd-devtrack/cli/modules/platform/startup"
 	"csd-devtrack/cli/modules/platform/supervisor"
 	uicore "csd-devtrack/cli/modules/ui/core"
 	"csd-devtrack/cli/modules/ui/tui"
@@ -155,6 +159,16 @@ func removeCommand(args []string) error {
 
 // statusCommand handles the 'status' command
 func statusCommand(args []string) error {
+	// Status bar summary of the running daemon (no project loading)
+	for i, arg := range args {
+		if arg == "--format" {
+			if i+1 >= len(args) {
+				return fmt.Errorf("--format requires a value (%s)", strings.Join(statusBarFormats, ", "))
+			}
+			return statusBarCommand(args[i+1])
+		}
+	}
+
 	if err := InitContext(); err != nil {
 		return fmt.Errorf("failed to initialize: %w", err)
 	}
@@ -258,6 +272,29 @@ func buildCommand(args []string) error {
 	}
 
 	ctx := GetContext()
+
+	// Build profile: its environment variables are passed to the builds
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		if args[i] != "--profile" {
+			positional = append(positional, args[i])
+			continue
+		}
+		if i+1 >= len(args) {
+			return fmt.Errorf("--profile requires a profile name")
+		}
+		i++
+		profile, ok := ctx.Config.BuildProfiles[args[i]]
+		if !ok {
+			return fmt.Errorf("unknown build profile: %s", args[i])
+		}
+		for name, value := range profile.EnvVars {
+			os.Setenv(name, value)
+		}
+		fmt.Printf("Profile: %s\n", args[i])
+	}
+	args = positional
+
 	orchestrator := builder.NewOrchestrator(ctx.ProjectService, ctx.Config.Settings.ParallelBuilds)
 
 	// Set up event handler for real-time output
@@ -377,6 +414,7 @@ func getProcessManager() (*processes.Service, *supervisor.Manager) {
 		ctx := GetContext()
 		globalProcessService = processes.NewService(ctx.ProjectService)
 		globalSupervisor = supervisor.NewManager(globalProcessService)
+		globalProcessService.SetDependencies(newServiceManager(ctx.Config))
 
 		// Set up event handler
 		globalProcessService.SetEventHandler(func(event processes.ProcessEvent) {
@@ -402,6 +440,21 @@ func getProcessManager() (*processes.Service, *supervisor.Manager) {
 	return globalProcessService, globalSupervisor
 }
 
+// newServiceManager returns the manager of the services of the catalog the
+// components depend on, their output printed like the processes'
+func newServiceManager(cfg *config.Config) *services.Manager {
+	mgr := services.NewConfiguredManager(cfg)
+	mgr.SetOutputHandler(func(name, line string, isError bool) {
+		timestamp := time.Now().Format("15:04:05")
+		if isError {
+			fmt.Printf("[%s] service:%s | ERROR: %s\n", timestamp, name, line)
+		} else {
+			fmt.Printf("[%s] service:%s | %s\n", timestamp, name, line)
+		}
+	})
+	return mgr
+}
+
 // runCommand handles the 'run' command
 func runCommand(args []string) error {
 	if err := InitContext(); err != nil {
@@ -944,9 +997,11 @@ func isTUICapable() bool {
 
 // uiCommand handles the 'ui' command
 func uiCommand(args []string) error {
+	doneContext := startup.Track("context")
 	if err := InitContext(); err != nil {
 		return fmt.Errorf("failed to initialize: %w", err)
 	}
+	doneContext()
 
 	// Check if terminal supports TUI
 	if !isTUICapable() {
@@ -966,12 +1021,26 @@ func uiCommand(args []string) error {
 	return uiCommandDirect(ctx)
 }
 
+// tuiLogger creates the logger of the TUI process: DevTrack diagnostics go
+// to the Logs view (devtrack source) instead of the terminal
+func tuiLogger(broadcaster logger.LogBroadcaster) *logger.Logger {
+	level := config.DefaultLoggerConfig().Level
+	if cfg := config.GetGlobal(); cfg != nil && cfg.Settings != nil {
+		level = cfg.Settings.GetLoggerConfig().Level
+	}
+	log := logger.NewLogger(logger.ParseLevel(level), nil, uicore.LogSourceDevTrack)
+	log.SetBroadcaster(broadcaster)
+	logger.SetGlobalLogger(log)
+	return log
+}
+
 // uiCommandDirect runs the TUI directly without daemon
 func uiCommandDirect(ctx context.Context) error {
 	appCtx := GetContext()
 
 	// Create the presenter with services
-	presenter := uicore.NewPresenter(appCtx.ProjectService, appCtx.Config)
+	presenter := uicore.NewAppPresenter(appCtx.ProjectService, appCtx.Config)
+	presenter.SetLogger(tuiLogger(presenter))
 
 	// Initialize presenter
 	if err := presenter.Initialize(ctx); err != nil {
@@ -981,9 +1050,12 @@ func uiCommandDirect(ctx context.Context) error {
 
 	// Create and run TUI view
 	tuiView := tui.NewTUIView()
+	tuiView.SetReadOnly(IsReadOnly())
+	doneTUI := startup.Track("tui")
 	if err := tuiView.Initialize(presenter); err != nil {
 		return fmt.Errorf("failed to initialize TUI: %w", err)
 	}
+	doneTUI()
 
 	// Run the TUI (blocking)
 	if err := tuiView.Run(ctx); err != nil {
@@ -995,25 +1067,35 @@ func uiCommandDirect(ctx context.Context) error {
 
 // uiCommandWithDaemon runs the TUI as a client connecting to daemon
 func uiCommandWithDaemon(ctx context.Context) error {
-	// Ensure daemon is running
-	started, err := daemon.EnsureDaemon()
-	if err != nil {
-		return fmt.Errorf("failed to start daemon: %w", err)
-	}
-	if started {
-		fmt.Println("Daemon started in background")
-		// Give daemon a moment to fully initialize
-		time.Sleep(100 * time.Millisecond)
+	remote := GetRemote()
+	client := daemon.NewClient()
+	if remote != nil {
+		// Shared daemon of the team: started on its box, never from here
+		client = daemon.NewRemoteClient(*remote)
+	} else {
+		// Ensure daemon is running
+		started, err := daemon.EnsureDaemon()
+		if err != nil {
+			return fmt.Errorf("failed to start daemon: %w", err)
+		}
+		if started {
+			fmt.Println("Daemon started in background")
+			// Give daemon a moment to fully initialize
+			time.Sleep(100 * time.Millisecond)
+		}
 	}
 
 	// Connect to daemon
-	client := daemon.NewClient()
 	if err := client.Connect(); err != nil {
 		return fmt.Errorf("failed to connect to daemon: %w", err)
 	}
+	if client.Role() == config.TeamRoleViewer {
+		SetReadOnly(true)
+	}
 
 	// Create client presenter
 	presenter := daemon.NewClientPresenter(client)
+	tuiLogger(presenter)
 	if err := presenter.Initialize(ctx); err != nil {
 		client.Disconnect()
 		return fmt.Errorf("failed to initialize presenter: %w", err)
@@ -1022,6 +1104,7 @@ func uiCommandWithDaemon(ctx context.Context) error {
 	// Create and run TUI view
 	tuiView := tui.NewTUIView()
 	tuiView.SetDetachable(true) // Enable Ctrl+D detach
+	tuiView.SetReadOnly(IsReadOnly())
 	if err := tuiView.Initialize(presenter); err != nil {
 		presenter.Disconnect()
 		return fmt.Errorf("failed to initialize TUI: %w", err)
@@ -1033,7 +1116,27 @@ func uiCommandWithDaemon(ctx context.Context) error {
 	})
 
 	// Run the TUI (blocking until quit or detach)
-	err = tuiView.Run(ctx)
+	err := tuiView.Run(ctx)
+
+	// A teammate never stops the shared daemon nor overwrites its saved TUI state
+	if remote != nil {
+		presenter.Disconnect()
+		fmt.Printf("Disconnected from %s. Shared daemon still running.\n", remote.Address)
+		if err != nil {
+			return fmt.Errorf("TUI error: %w", err)
+		}
+		return nil
+	}
+
+	// An observer never stops the daemon nor overwrites the saved TUI state
+	if IsReadOnly() {
+		presenter.Disconnect()
+		fmt.Println("Read-only session closed. Daemon still running.")
+		if err != nil {
+			return fmt.Errorf("TUI error: %w", err)
+		}
+		return nil
+	}
 
 	// Check if we detached (not quit)
 	if tuiView.WasDetached() {
@@ -58,7 +58,7 @@ func registerCoreCommands() {
 			"csd-devtrack add ../csd-stocks --name 'CSD Stocks'",
 			"csd-devtrack add . --name 'Current Project'",
 		},
-		Handler: addCommand,
+		Handler: audited("add_project", addCommand),
 		Order:   10,
 	})
 
@@ -88,7 +88,7 @@ func registerCoreCommands() {
 			"csd-devtrack remove csd-core",
 			"csd-devtrack rm csd-stocks",
 		},
-		Handler: removeCommand,
+		Handler: withProjectPrompt(false, audited("remove_project", removeCommand)),
 		Order:   12,
 	})
 
@@ -98,10 +98,12 @@ func registerCoreCommands() {
 		Aliases:     []string{"st"},
 		Category:    "Project Management",
 		Description: "Show status of all projects",
-		Usage:       "csd-devtrack status [project-id]",
+		Usage:       "csd-devtrack status [project-id] [--format text|json|tmux|waybar]",
 		Examples: []string{
 			"csd-devtrack status",
 			"csd-devtrack st csd-core",
+			"csd-devtrack status --format tmux",
+			"csd-devtrack status --format waybar",
 		},
 		Handler: statusCommand,
 		Order:   13,
@@ -116,7 +118,7 @@ func registerCoreCommands() {
 		Examples: []string{
 			"csd-devtrack refresh csd-core",
 		},
-		Handler: refreshCommand,
+		Handler: withProjectPrompt(false, refreshCommand),
 		Order:   14,
 	})
 }
@@ -128,17 +130,18 @@ func registerBuildCommands() {
 		Aliases:     []string{"b"},
 		Category:    "Build",
 		Description: "Build a project or component",
-		Usage:       "csd-devtrack build [project] [component]",
+		Usage:       "csd-devtrack build [project] [component] [--profile <name>]",
 		Examples: []string{
 			"csd-devtrack build",
 			"csd-devtrack build csd-core",
 			"csd-devtrack build csd-core backend",
+			"csd-devtrack build csd-core --profile prod",
 			"csd-devtrack b all",
 		},
 		SubCommands: []SubCommand{
 			{Name: "all", Description: "Build all projects"},
 		},
-		Handler: buildCommand,
+		Handler: withProjectPrompt(true, audited("start_build", buildCommand)),
 		Order:   20,
 	})
 }
@@ -156,7 +159,7 @@ func registerRunCommands() {
 			"csd-devtrack run csd-core backend",
 			"csd-devtrack start csd-stocks frontend",
 		},
-		Handler: runCommand,
+		Handler: withProjectPrompt(false, audited("start_process", runCommand)),
 		Order:   30,
 	})
 
@@ -169,7 +172,7 @@ func registerRunCommands() {
 			"csd-devtrack stop csd-core",
 			"csd-devtrack stop csd-core backend",
 		},
-		Handler: stopCommand,
+		Handler: withProjectPrompt(false, audited("stop_process", stopCommand)),
 		Order:   31,
 	})
 
@@ -182,7 +185,7 @@ func registerRunCommands() {
 			"csd-devtrack restart csd-core",
 			"csd-devtrack restart csd-core backend",
 		},
-		Handler: restartCommand,
+		Handler: withProjectPrompt(false, audited("restart_process", restartCommand)),
 		Order:   32,
 	})
 
@@ -195,7 +198,7 @@ func registerRunCommands() {
 			"csd-devtrack kill csd-core",
 			"csd-devtrack kill csd-core --force",
 		},
-		Handler: killCommand,
+		Handler: withProjectPrompt(false, audited("kill_process", killCommand)),
 		Order:   33,
 	})
 
@@ -209,7 +212,7 @@ func registerRunCommands() {
 			"csd-devtrack logs csd-core",
 			"csd-devtrack logs csd-core backend -f",
 		},
-		Handler: logsCommand,
+		Handler: withProjectPrompt(false, logsCommand),
 		Order:   34,
 	})
 }
@@ -259,6 +262,101 @@ func registerConfigCommands() {
 		Handler: configCommand,
 		Order:   50,
 	})
+
+	RegisterCommand(&Command{
+		Name:        "audit",
+		Category:    "Configuration",
+		Description: "Show or export the log of state-changing actions",
+		Usage:       "csd-devtrack audit [--project <id>] [--limit <n>] [--export <file.csv|file.json>] [--json]",
+		Examples: []string{
+			"csd-devtrack audit",
+			"csd-devtrack audit --project csd-core --limit 20",
+			"csd-devtrack audit --export audit.csv",
+		},
+		Handler: auditCommand,
+		Order:   51,
+	})
+
+	RegisterCommand(&Command{
+		Name:        "plugins",
+		Aliases:     []string{"plugin"},
+		Category:    "Configuration",
+		Description: "List plugins, show their views and run their actions",
+		Usage:       "csd-devtrack plugins [list | view <plugin> <view> | run <plugin> <action> [project]]",
+		SubCommands: []SubCommand{
+			{Name: "list", Description: "List plugins with their views, actions and data providers"},
+			{Name: "view", Description: "Print the content of a plugin view"},
+			{Name: "run", Description: "Run a plugin action (on a project if given)"},
+		},
+		Examples: []string{
+			"csd-devtrack plugins",
+			"csd-devtrack plugins view deploy-status environments",
+			"csd-devtrack plugins run deploy-status promote csd-core",
+		},
+		Handler: pluginsCommand,
+		Order:   52,
+	})
+
+	RegisterCommand(&Command{
+		Name:        "self-update",
+		Aliases:     []string{"update"},
+		Category:    "Configuration",
+		Description: "Update csd-devtrack to the latest release of its channel",
+		Usage:       "csd-devtrack self-update [--check] [--channel stable|beta] [--yes]",
+		Examples: []string{
+			"csd-devtrack self-update --check",
+			"csd-devtrack self-update",
+			"csd-devtrack self-update --channel beta --yes",
+		},
+		Handler: selfUpdateCommand,
+		Order:   53,
+	})
+
+	RegisterCommand(&Command{
+		Name:        "backup",
+		Category:    "Configuration",
+		Description: "Archive the config and DevTrack data (names, notes, histories, trash) into a tarball",
+		Usage:       "csd-devtrack backup [file.tar.gz] [--full]",
+		Examples: []string{
+			"csd-devtrack backup",
+			"csd-devtrack backup ~/devtrack.tar.gz --full",
+		},
+		Handler: backupCommand,
+		Order:   54,
+	})
+
+	RegisterCommand(&Command{
+		Name:        "restore",
+		Category:    "Configuration",
+		Description: "Restore the config and DevTrack data from a backup (daemon stopped)",
+		Usage:       "csd-devtrack restore <file.tar.gz> [--yes]",
+		Examples: []string{
+			"csd-devtrack restore devtrack-backup-laptop-20260102-150405.tar.gz",
+		},
+		Handler: restoreCommand,
+		Order:   55,
+	})
+
+	RegisterCommand(&Command{
+		Name:        "secrets",
+		Aliases:     []string{"secret"},
+		Category:    "Configuration",
+		Description: "Manage the encrypted secrets referenced from config values as ${secret:NAME}",
+		Usage:       secretsUsage,
+		SubCommands: []SubCommand{
+			{Name: "list", Description: "List the secrets (names only) and whether the config references them"},
+			{Name: "set", Description: "Add or replace a secret (value read from the terminal or stdin)"},
+			{Name: "delete", Description: "Delete a secret"},
+			{Name: "rotate", Description: "Re-encrypt the secrets with a new key (optionally of another source)"},
+		},
+		Examples: []string{
+			"csd-devtrack secrets set DASHBOARD_TOKEN",
+			"pass show work/redis | csd-devtrack secrets set REDIS_URL",
+			"csd-devtrack secrets rotate --source keyring",
+		},
+		Handler: secretsCommand,
+		Order:   56,
+	})
 }
 
 // registerUICommands registers UI-related commands
@@ -302,4 +400,23 @@ func registerUICommands() {
 		Handler: serverCommand,
 		Order:   62,
 	})
+
+	RegisterCommand(&Command{
+		Name:        "completion",
+		Category:    "Interface",
+		Description: "Print the shell completion script",
+		Usage:       "csd-devtrack completion <bash|zsh|fish>",
+		Examples: []string{
+			"source <(csd-devtrack completion bash)",
+			"csd-devtrack completion zsh > \"${fpath[1]}/_csd-devtrack\"",
+			"csd-devtrack completion fish > ~/.config/fish/completions/csd-devtrack.fish",
+		},
+		SubCommands: []SubCommand{
+			{Name: "bash", Description: "Bash completion script"},
+			{Name: "zsh", Description: "Zsh completion script"},
+			{Name: "fish", Description: "Fish completion script"},
+		},
+		Handler: completionCommand,
+		Order:   63,
+	})
 }
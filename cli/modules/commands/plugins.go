@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/audit"
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/plugins"
+)
+
+// openPlugins discovers the plugin executables configured for DevTrack
+func openPlugins(ctx context.Context) (*plugins.Service, error) {
+	pluginsConfig := config.DefaultPluginsConfig()
+	if cfg := config.GetGlobal(); cfg != nil && cfg.Settings != nil {
+		pluginsConfig = cfg.Settings.GetPluginsConfig()
+	}
+	dir, err := config.GetPluginsDir(pluginsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := plugins.NewService(dir, pluginsConfig.Disabled,
+		time.Duration(pluginsConfig.Timeout)*time.Second,
+		time.Duration(pluginsConfig.ActionTimeout)*time.Second)
+	if err := svc.Discover(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load plugins: %w", err)
+	}
+	return svc, nil
+}
+
+// pluginsCommand handles the 'plugins' command
+func pluginsCommand(args []string) error {
+	ctx := context.Background()
+	svc, err := openPlugins(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 || args[0] == "list" {
+		return listPlugins(svc)
+	}
+
+	switch args[0] {
+	case "view":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: csd-devtrack plugins view <plugin> <view>")
+		}
+		items, err := svc.View(ctx, args[1], args[2])
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			line := pluginStatusIcon(item.Status) + " " + item.Label
+			if item.Value != "" {
+				line += ": " + item.Value
+			}
+			fmt.Println(line)
+			if item.Detail != "" {
+				fmt.Printf("    %s\n", item.Detail)
+			}
+		}
+		return nil
+
+	case "run":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: csd-devtrack plugins run <plugin> <action> [project]")
+		}
+		return runPluginAction(ctx, svc, args[1], args[2], args[3:])
+
+	default:
+		return fmt.Errorf("unknown subcommand: %s\nUsage: csd-devtrack plugins [list|view|run]", args[0])
+	}
+}
+
+// listPlugins prints the plugins with their views, actions and data providers
+func listPlugins(svc *plugins.Service) error {
+	list := svc.GetPlugins()
+	if len(list) == 0 {
+		fmt.Printf("No plugins in %s\n", svc.Dir())
+		return nil
+	}
+
+	for _, p := range list {
+		if p.Error != "" {
+			fmt.Printf("✗ %s (%s)\n    error: %s\n", p.Name, p.Path, p.Error)
+			continue
+		}
+		fmt.Printf("● %s", p.Name)
+		if p.Manifest.Title != "" {
+			fmt.Printf(" - %s", p.Manifest.Title)
+		}
+		fmt.Println()
+		if p.Manifest.Description != "" {
+			fmt.Printf("    %s\n", p.Manifest.Description)
+		}
+		for _, v := range p.Manifest.Views {
+			fmt.Printf("    view     %-20s %s\n", v.ID, v.Title)
+		}
+		for _, a := range p.Manifest.Actions {
+			scope := ""
+			if a.Project {
+				scope = " (project)"
+			}
+			fmt.Printf("    action   %-20s %s%s\n", a.ID, a.Title, scope)
+		}
+		for _, d := range p.Manifest.Providers {
+			fmt.Printf("    data     %-20s %s\n", d.ID, d.Title)
+		}
+	}
+	fmt.Printf("\nDirectory: %s\n", svc.Dir())
+	return nil
+}
+
+// runPluginAction runs a plugin action and records it in the audit log
+func runPluginAction(ctx context.Context, svc *plugins.Service, plugin, action string, rest []string) error {
+	var project *plugins.ProjectInfo
+	if len(rest) > 0 {
+		if err := InitContext(); err != nil {
+			return fmt.Errorf("failed to initialize: %w", err)
+		}
+		proj, err := GetContext().ProjectService.GetProject(rest[0])
+		if err != nil {
+			return err
+		}
+		project = &plugins.ProjectInfo{ID: proj.ID, Name: proj.Name, Path: proj.Path}
+	}
+
+	result := svc.RunAction(ctx, plugin, action, project)
+
+	if log := openAuditLog(); log != nil {
+		entry := audit.Entry{
+			Action:    "plugin_action",
+			ProjectID: result.ProjectID,
+			Target:    plugin + "/" + action,
+			Origin:    audit.OriginCLI,
+			Error:     result.Error,
+		}
+		log.Record(entry)
+	}
+
+	if result.Error != "" {
+		return fmt.Errorf("%s failed: %s", result.Title, result.Error)
+	}
+	if result.Message != "" {
+		fmt.Println(result.Message)
+	} else {
+		fmt.Printf("✓ %s done\n", result.Title)
+	}
+	return nil
+}
+
+// pluginStatusIcon returns the console icon of a plugin item status
+func pluginStatusIcon(status string) string {
+	switch status {
+	case plugins.StatusOK:
+		return "✓"
+	case plugins.StatusWarning:
+		return "!"
+	case plugins.StatusError:
+		return "✗"
+	default:
+		return "·"
+	}
+}
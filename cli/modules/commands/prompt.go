@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"golang.org/x/term"
+)
+
+// errNoPrompt is returned when the terminal cannot show a prompt
+var errNoPrompt = errors.New("not an interactive terminal")
+
+// maxPromptChoices is the number of choices listed at once
+const maxPromptChoices = 20
+
+// canPrompt returns true if the user can answer an interactive prompt
+func canPrompt() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// withProjectPrompt asks for the project when a command is run without
+// positional arguments on a terminal. allowAll adds "all" as the first choice.
+// Without a terminal the handler reports the missing argument itself.
+func withProjectPrompt(allowAll bool, handler CommandHandler) CommandHandler {
+	return func(args []string) error {
+		if hasPositional(args) || !canPrompt() {
+			return handler(args)
+		}
+
+		choices := projectIDs()
+		if len(choices) == 0 {
+			return handler(args)
+		}
+		if allowAll {
+			choices = append([]string{"all"}, choices...)
+		}
+
+		projectID, err := promptChoice("Project", choices)
+		if err != nil {
+			return err
+		}
+		return handler(append([]string{projectID}, args...))
+	}
+}
+
+// hasPositional returns true if args contain a non-flag argument
+func hasPositional(args []string) bool {
+	for i := 0; i < len(args); i++ {
+		switch {
+		case commandValueFlags[args[i]]:
+			i++
+		case !strings.HasPrefix(args[i], "-"):
+			return true
+		}
+	}
+	return false
+}
+
+// promptChoice lets the user pick one of choices: by number, by name (Tab
+// completes), or by typing a fuzzy filter that narrows the list until one
+// choice is left. An empty answer cancels.
+func promptChoice(title string, choices []string) (string, error) {
+	if !canPrompt() {
+		return "", errNoPrompt
+	}
+
+	items := make([]readline.PrefixCompleterInterface, 0, len(choices))
+	for _, c := range choices {
+		items = append(items, readline.PcItem(c))
+	}
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          fmt.Sprintf("\033[32m%s>\033[0m ", strings.ToLower(title)),
+		AutoComplete:    readline.NewPrefixCompleter(items...),
+		InterruptPrompt: "^C",
+	})
+	if err != nil {
+		return "", err
+	}
+	defer rl.Close()
+
+	fmt.Printf("%s (number, name or filter, Tab completes, empty cancels):\n", title)
+	listed := choices
+	printChoices(listed)
+	for {
+		line, err := rl.Readline()
+		if err != nil {
+			return "", fmt.Errorf("cancelled")
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return "", fmt.Errorf("cancelled")
+		}
+
+		if n, err := strconv.Atoi(line); err == nil && n >= 1 && n <= len(listed) && n <= maxPromptChoices {
+			return listed[n-1], nil
+		}
+		for _, c := range choices {
+			if c == line {
+				return c, nil
+			}
+		}
+
+		matches := fuzzyFilter(choices, line)
+		switch len(matches) {
+		case 0:
+			fmt.Printf("No match for %q\n", line)
+		case 1:
+			fmt.Printf("→ %s\n", matches[0])
+			return matches[0], nil
+		default:
+			listed = matches
+			printChoices(listed)
+		}
+	}
+}
+
+// printChoices lists the numbered choices
+func printChoices(choices []string) {
+	for i, c := range choices {
+		if i == maxPromptChoices {
+			fmt.Printf("  ... %d more (type to filter)\n", len(choices)-maxPromptChoices)
+			break
+		}
+		fmt.Printf("  %2d) %s\n", i+1, c)
+	}
+}
+
+// fuzzyFilter returns the choices containing the characters of filter in
+// order (case-insensitive)
+func fuzzyFilter(choices []string, filter string) []string {
+	filter = strings.ToLower(filter)
+	var result []string
+	for _, c := range choices {
+		rest := strings.ToLower(c)
+		matched := true
+		for _, r := range filter {
+			idx := strings.IndexRune(rest, r)
+			if idx < 0 {
+				matched = false
+				break
+			}
+			rest = rest[idx+len(string(r)):]
+		}
+		if matched {
+			result = append(result, c)
+		}
+	}
+	return result
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"csd-devtrack/cli/modules/platform/recording"
+)
+
+// RecordCommand is the hidden command tmux pipe-pane runs to record the
+// output of a terminal
+const RecordCommand = recording.Command
+
+// Record writes the terminal output piped on stdin to a recording file
+func Record(args []string) {
+	if len(args) < 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s <file> <width> <height> [title]\n", RecordCommand)
+		os.Exit(1)
+	}
+	width, _ := strconv.Atoi(args[1])
+	height, _ := strconv.Atoi(args[2])
+	title := ""
+	if len(args) > 3 {
+		title = args[3]
+	}
+	if err := recording.Record(os.Stdin, args[0], width, height, title); err != nil {
+		fmt.Fprintf(os.Stderr, "Recording failed: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,246 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"csd-devtrack/cli/modules/platform/audit"
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/secrets"
+
+	"golang.org/x/term"
+)
+
+// secretsUsage is the usage of the 'secrets' command
+const secretsUsage = "csd-devtrack secrets [list | set <name> | delete <name> [--yes] | rotate [--source file|keyring|passphrase]]"
+
+// secretsCommand handles the 'secrets' command
+func secretsCommand(args []string) error {
+	cfg := config.GetGlobal()
+	if len(args) == 0 || args[0] == "list" {
+		return listSecrets(cfg)
+	}
+
+	if config.IsReadOnly() {
+		return fmt.Errorf("read-only mode: secrets cannot be changed")
+	}
+	if err := unlockSecrets(cfg); err != nil {
+		return err
+	}
+
+	var err error
+	entry := audit.Entry{Origin: audit.OriginCLI}
+	switch args[0] {
+	case "set":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: csd-devtrack secrets set <name> (value read from the terminal or stdin)")
+		}
+		entry.Action, entry.Target = "secret_set", args[1]
+		err = setSecret(cfg, args[1])
+	case "delete", "rm":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: csd-devtrack secrets delete <name> [--yes]")
+		}
+		entry.Action, entry.Target = "secret_delete", args[1]
+		err = deleteSecret(cfg, args[1], len(args) > 2 && (args[2] == "--yes" || args[2] == "-y"))
+	case "rotate":
+		source := ""
+		if len(args) > 2 && args[1] == "--source" {
+			source = args[2]
+		} else if len(args) > 1 {
+			return fmt.Errorf("unknown option: %s\nUsage: %s", args[1], secretsUsage)
+		}
+		entry.Action, entry.Details = "secrets_rotate", source
+		err = rotateSecretsKey(cfg, source)
+	default:
+		return fmt.Errorf("unknown subcommand: %s\nUsage: %s", args[0], secretsUsage)
+	}
+	if log := openAuditLog(); log != nil {
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		log.Record(entry)
+	}
+	return err
+}
+
+// listSecrets prints the names of the secrets (never their values) and
+// whether the config references them
+func listSecrets(cfg *config.Config) error {
+	keyCfg := cfg.Settings.GetSecretsConfig()
+	source := keyCfg.KeySource
+	if source == secrets.SourceFile {
+		source += " " + keyCfg.KeyFile
+	}
+	fmt.Printf("Key: %s\n", source)
+
+	names := cfg.SecretNames()
+	if len(names) == 0 {
+		fmt.Println("No secrets. Add one with: csd-devtrack secrets set <name>")
+		return nil
+	}
+	referenced := make(map[string]bool)
+	for _, name := range cfg.SecretReferences() {
+		referenced[name] = true
+	}
+	for _, name := range names {
+		usage := "unused"
+		if referenced[name] {
+			usage = "referenced"
+		}
+		fmt.Printf("  %-30s %s\n", name, usage)
+	}
+	fmt.Println("Reference a secret in a config value as ${secret:NAME}")
+	return nil
+}
+
+// setSecret reads the value of a secret and saves it encrypted
+func setSecret(cfg *config.Config, name string) error {
+	if !config.ValidSecretName(name) {
+		return fmt.Errorf("invalid secret name %q: use letters, digits, '_', '.' and '-'", name)
+	}
+	value, err := readSecretValue(fmt.Sprintf("Value of %s: ", name))
+	if err != nil {
+		return err
+	}
+	if err := cfg.SetSecret(name, value); err != nil {
+		return err
+	}
+	if err := config.SaveGlobal(); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Secret %s saved: reference it as ${secret:%s}\n", name, name)
+	return nil
+}
+
+// deleteSecret removes a secret, after confirmation when it is referenced
+func deleteSecret(cfg *config.Config, name string, assumeYes bool) error {
+	referenced := false
+	for _, ref := range cfg.SecretReferences() {
+		referenced = referenced || ref == name
+	}
+	if referenced && !assumeYes {
+		if !canPrompt() {
+			return fmt.Errorf("%s is referenced by the config: run again with --yes", name)
+		}
+		fmt.Printf("%s is referenced by the config. Delete it anyway? [y/N] ", name)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Delete cancelled.")
+			return nil
+		}
+	}
+	if !cfg.DeleteSecret(name) {
+		return fmt.Errorf("unknown secret: %s", name)
+	}
+	if err := config.SaveGlobal(); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Secret %s deleted\n", name)
+	return nil
+}
+
+// rotateSecretsKey re-encrypts the secrets with a new key, optionally of
+// another source
+func rotateSecretsKey(cfg *config.Config, source string) error {
+	passphrase := ""
+	if source == secrets.SourcePassphrase || source == "" && cfg.Settings.GetSecretsConfig().KeySource == secrets.SourcePassphrase {
+		var err error
+		if passphrase, err = readNewPassphrase(); err != nil {
+			return err
+		}
+	}
+	if err := cfg.RotateSecretsKey(source, passphrase); err != nil {
+		return err
+	}
+	if err := config.SaveGlobal(); err != nil {
+		return fmt.Errorf("secrets re-encrypted but the config was not saved (previous key file kept as .old): %w", err)
+	}
+	fmt.Printf("✓ %d secrets re-encrypted with a new %s key\n", len(cfg.SecretNames()), cfg.Settings.GetSecretsConfig().KeySource)
+	if cfg.Settings.GetSecretsConfig().KeySource == secrets.SourcePassphrase {
+		fmt.Printf("  The daemon reads the passphrase from %s\n", secrets.PassphraseEnv)
+	}
+	return nil
+}
+
+// unlockSecrets asks the passphrase of the secrets when it is needed and
+// not in the environment (twice for the first secret: it is chosen now)
+func unlockSecrets(cfg *config.Config) error {
+	if !cfg.NeedsSecretsPassphrase() {
+		return nil
+	}
+	if !canPrompt() {
+		return fmt.Errorf("the secrets are encrypted with a passphrase: set %s", secrets.PassphraseEnv)
+	}
+	var passphrase string
+	var err error
+	if cfg.Settings.GetSecretsConfig().Salt == "" {
+		passphrase, err = readNewPassphrase()
+	} else {
+		passphrase, err = readPassword("Passphrase: ")
+	}
+	if err != nil {
+		return err
+	}
+	config.SetSecretsPassphrase(passphrase)
+	return nil
+}
+
+// readNewPassphrase asks a new passphrase twice, or reads it from
+// CSD_DEVTRACK_PASSPHRASE without a terminal
+func readNewPassphrase() (string, error) {
+	if !canPrompt() {
+		if passphrase := os.Getenv(secrets.PassphraseEnv); passphrase != "" {
+			return passphrase, nil
+		}
+		return "", fmt.Errorf("a new passphrase is required: set %s", secrets.PassphraseEnv)
+	}
+	passphrase, err := readPassword("New passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	confirm, err := readPassword("Repeat the passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase != confirm {
+		return "", fmt.Errorf("the passphrases differ")
+	}
+	return passphrase, nil
+}
+
+// readSecretValue reads a secret value without echo on a terminal, or the
+// whole stdin (piped from a password manager) otherwise. Values are never
+// taken from the arguments, which end up in the shell history.
+func readSecretValue(prompt string) (string, error) {
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return readPassword(prompt)
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the value: %w", err)
+	}
+	value := strings.TrimRight(string(data), "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("empty secret value")
+	}
+	return value, nil
+}
+
+// readPassword reads a line on the terminal without echo
+func readPassword(prompt string) (string, error) {
+	fmt.Print(prompt)
+	data, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the terminal: %w", err)
+	}
+	if len(data) == 0 {
+		return "", fmt.Errorf("empty value")
+	}
+	return string(data), nil
+}
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules"
+	"csd-devtrack/cli/modules/platform/audit"
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/daemon"
+	"csd-devtrack/cli/modules/platform/selfupdate"
+)
+
+// maxChangelogLines is the number of release notes lines previewed per release
+const maxChangelogLines = 15
+
+// selfUpdateCommand handles the 'self-update' command
+func selfUpdateCommand(args []string) error {
+	updateConfig := config.DefaultUpdateConfig()
+	if cfg := config.GetGlobal(); cfg != nil && cfg.Settings != nil {
+		updateConfig = cfg.Settings.GetUpdateConfig()
+	}
+
+	channel := updateConfig.Channel
+	checkOnly := false
+	assumeYes := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--check":
+			checkOnly = true
+		case "--yes", "-y":
+			assumeYes = true
+		case "--channel":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--channel requires stable or beta")
+			}
+			channel = args[i+1]
+			i++
+		default:
+			return fmt.Errorf("unknown argument: %s\nUsage: csd-devtrack self-update [--check] [--channel stable|beta] [--yes]", args[i])
+		}
+	}
+
+	updater, err := selfupdate.NewUpdater(updateConfig.Repository, channel, updateConfig.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	fmt.Printf("Checking %s releases of %s...\n", channel, updateConfig.Repository)
+	releases, err := updater.Releases(ctx, modules.AppVersion)
+	if err != nil {
+		return fmt.Errorf("failed to check releases: %w", err)
+	}
+	if len(releases) == 0 {
+		fmt.Printf("Already up to date (%s)\n", modules.AppVersion)
+		return nil
+	}
+
+	latest := releases[0]
+	fmt.Printf("\nUpdate available: %s → %s\n\n", modules.AppVersion, latest.Version())
+	printChangelog(releases)
+
+	if checkOnly {
+		return nil
+	}
+	if !assumeYes {
+		if !canPrompt() {
+			return fmt.Errorf("confirmation required: run again with --yes")
+		}
+		fmt.Printf("Install %s? [y/N] ", latest.Version())
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Update cancelled.")
+			return nil
+		}
+	}
+
+	fmt.Printf("Downloading %s...\n", selfupdate.BinaryName())
+	tmp, err := updater.Download(ctx, latest)
+	if err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+	if updateConfig.PublicKey != "" {
+		fmt.Println("✓ Signature and checksum verified")
+	} else {
+		fmt.Println("✓ Checksum verified")
+	}
+
+	err = updater.Apply(tmp)
+	if log := openAuditLog(); log != nil {
+		entry := audit.Entry{
+			Action:  "self_update",
+			Target:  latest.Version(),
+			Origin:  audit.OriginCLI,
+			Details: fmt.Sprintf("from %s (%s channel)", modules.AppVersion, channel),
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		log.Record(entry)
+	}
+	if err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+	fmt.Printf("✓ Updated to %s\n", latest.Version())
+
+	if daemon.IsRunning() {
+		fmt.Println("\nThe daemon still runs the previous version. Restart it with:")
+		fmt.Println("  csd-devtrack --kill && csd-devtrack")
+	}
+	return nil
+}
+
+// printChangelog prints the notes of the releases, newest first
+func printChangelog(releases []selfupdate.Release) {
+	for _, r := range releases {
+		title := r.Tag
+		if r.Name != "" && r.Name != r.Tag {
+			title += " - " + r.Name
+		}
+		if r.Prerelease {
+			title += " (beta)"
+		}
+		if !r.PublishedAt.IsZero() {
+			title += r.PublishedAt.Format(" (2006-01-02)")
+		}
+		fmt.Println(title)
+
+		lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(r.Notes, "\r\n", "\n")), "\n")
+		for i, line := range lines {
+			if i == maxChangelogLines {
+				fmt.Printf("    ... %d more lines\n", len(lines)-maxChangelogLines)
+				break
+			}
+			if strings.TrimSpace(line) != "" {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+		fmt.Println()
+	}
+}
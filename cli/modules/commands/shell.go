@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -323,7 +324,7 @@ func (s *Shell) getHistoryPath() string {
 	if err != nil {
 		return historyFile
 	}
-	return homeDir + "/" + historyFile
+	return filepath.Join(homeDir, historyFile)
 }
 
 // buildCompleter builds the readline completer
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/daemon"
+)
+
+// statusBarTimeout bounds a status bar query: a stuck daemon must not
+// freeze the bar
+const statusBarTimeout = 500 * time.Millisecond
+
+// statusBarFormats are the values of status --format
+var statusBarFormats = []string{"text", "json", "tmux", "waybar"}
+
+// statusBarCommand prints a one-line summary of the running daemon for
+// status bars (tmux status-right, i3blocks, waybar custom modules). A
+// daemon that is not running is reported, not an error: bars poll it.
+func statusBarCommand(format string) error {
+	status, err := daemon.QueryStatus(statusBarTimeout)
+
+	switch format {
+	case "text":
+		fmt.Println(statusBarText(status, false))
+	case "tmux":
+		fmt.Println(statusBarText(status, true))
+	case "json":
+		out := struct {
+			Daemon bool `json:"daemon"`
+			*daemon.StatusPayload
+		}{Daemon: err == nil, StatusPayload: status}
+		return json.NewEncoder(os.Stdout).Encode(out)
+	case "waybar":
+		// Waybar custom module (return-type: json)
+		return json.NewEncoder(os.Stdout).Encode(map[string]string{
+			"text":    statusBarText(status, false),
+			"tooltip": statusBarTooltip(status),
+			"class":   statusBarClass(status),
+		})
+	default:
+		return fmt.Errorf("unknown format: %s (%s)", format, strings.Join(statusBarFormats, ", "))
+	}
+	return nil
+}
+
+// statusBarText returns the compact summary: ▶ running, ✗ crashed,
+// ⚙ build in progress, ± dirty repositories (tmux colors them)
+func statusBarText(status *daemon.StatusPayload, tmux bool) string {
+	if status == nil {
+		return "devtrack off"
+	}
+	color := func(text, fg string) string {
+		if !tmux {
+			return text
+		}
+		return "#[fg=" + fg + "]" + text + "#[default]"
+	}
+
+	parts := []string{fmt.Sprintf("▶%d", status.Running)}
+	if len(status.Crashed) > 0 {
+		parts = append(parts, color(fmt.Sprintf("✗%d", len(status.Crashed)), "red"))
+	}
+	if status.Building != "" {
+		parts = append(parts, color("⚙ "+status.Building, "yellow"))
+	}
+	if len(status.Dirty) > 0 {
+		parts = append(parts, color(fmt.Sprintf("±%d", len(status.Dirty)), "cyan"))
+	}
+	return strings.Join(parts, " ")
+}
+
+// statusBarTooltip details the summary, one line per item
+func statusBarTooltip(status *daemon.StatusPayload) string {
+	if status == nil {
+		return "DevTrack daemon not running"
+	}
+	lines := []string{fmt.Sprintf("Running: %d", status.Running)}
+	if len(status.Crashed) > 0 {
+		lines = append(lines, "Crashed: "+strings.Join(status.Crashed, ", "))
+	}
+	if status.Building != "" {
+		lines = append(lines, "Building: "+status.Building)
+	}
+	if len(status.Dirty) > 0 {
+		lines = append(lines, "Dirty: "+strings.Join(status.Dirty, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// statusBarClass returns the CSS class of the waybar module, the most
+// urgent state first
+func statusBarClass(status *daemon.StatusPayload) string {
+	switch {
+	case status == nil:
+		return "off"
+	case len(status.Crashed) > 0:
+		return "crashed"
+	case status.Building != "":
+		return "building"
+	case status.Running > 0:
+		return "running"
+	default:
+		return "idle"
+	}
+}
@@ -34,9 +34,17 @@ type Build struct {
 	Warnings      []string               `json:"warnings"`
 	ExitCode      int                    `json:"exit_code"`
 	Artifact      string                 `json:"artifact,omitempty"`
+	Steps         []BuildStep            `json:"steps,omitempty"`
 	outputHandler BuildOutputHandler     `json:"-"`
 }
 
+// BuildStep is a timed step of a build
+type BuildStep struct {
+	Name      string        `json:"name"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+}
+
 // NewBuild creates a new build
 func NewBuild(projectID string, component projects.ComponentType) *Build {
 	return &Build{
@@ -56,9 +64,24 @@ func (b *Build) Start() {
 	b.StartedAt = time.Now()
 }
 
+// StartStep starts a named step of the build, ending the current one
+func (b *Build) StartStep(name string) {
+	now := time.Now()
+	b.endStep(now)
+	b.Steps = append(b.Steps, BuildStep{Name: name, StartedAt: now})
+}
+
+// endStep ends the running step, if any
+func (b *Build) endStep(now time.Time) {
+	if n := len(b.Steps); n > 0 && b.Steps[n-1].Duration == 0 {
+		b.Steps[n-1].Duration = now.Sub(b.Steps[n-1].StartedAt)
+	}
+}
+
 // Finish marks the build as finished
 func (b *Build) Finish(exitCode int) {
 	now := time.Now()
+	b.endStep(now)
 	b.FinishedAt = &now
 	b.ExitCode = exitCode
 	b.Duration = now.Sub(b.StartedAt)
@@ -73,6 +96,7 @@ func (b *Build) Finish(exitCode int) {
 // Cancel marks the build as canceled
 func (b *Build) Cancel() {
 	now := time.Now()
+	b.endStep(now)
 	b.FinishedAt = &now
 	b.Status = BuildStatusCanceled
 	if !b.StartedAt.IsZero() {
@@ -0,0 +1,73 @@
+package builds
+
+import (
+	"fmt"
+
+	"csd-devtrack/cli/modules/core/projects"
+)
+
+// PlannedCommand is a command a build would run (dry run)
+type PlannedCommand struct {
+	Step    string   `json:"step"`
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+	Dir     string   `json:"dir"`
+	Env     []string `json:"env,omitempty"`     // Set on top of DevTrack's environment
+	Warning string   `json:"warning,omitempty"` // Why the command would fail, e.g. a missing directory
+}
+
+// Planner is implemented by the builders able to tell what a build would
+// run without running it
+type Planner interface {
+	Plan(project *projects.Project, component *projects.Component) []PlannedCommand
+}
+
+// PlanComponent returns the commands a build of a component would run
+func (s *Service) PlanComponent(projectID string, componentType projects.ComponentType) ([]PlannedCommand, error) {
+	project, err := s.projectService.GetProject(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %s", projectID)
+	}
+
+	component := project.GetComponent(componentType)
+	if component == nil {
+		return nil, fmt.Errorf("component not found: %s", componentType)
+	}
+
+	if !component.Enabled {
+		return nil, fmt.Errorf("component is disabled: %s", componentType)
+	}
+
+	planner, ok := s.builders[componentType].(Planner)
+	if !ok {
+		return nil, fmt.Errorf("no builder registered for: %s", componentType)
+	}
+	return planner.Plan(project, component), nil
+}
+
+// PlanProject returns the commands a build of all components of a project
+// would run, in build order
+func (s *Service) PlanProject(projectID string) ([]PlannedCommand, error) {
+	project, err := s.projectService.GetProject(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %s", projectID)
+	}
+
+	components := project.GetEnabledComponents()
+	if len(components) == 0 {
+		return nil, fmt.Errorf("no enabled components in project: %s", projectID)
+	}
+
+	var plan []PlannedCommand
+	for _, comp := range components {
+		commands, err := s.PlanComponent(projectID, comp.Type)
+		if err != nil {
+			return nil, err
+		}
+		for _, command := range commands {
+			command.Step = fmt.Sprintf("%s: %s", comp.Type, command.Step)
+			plan = append(plan, command)
+		}
+	}
+	return plan, nil
+}
@@ -74,6 +74,12 @@ func (s *Service) BuildProject(ctx context.Context, projectID string) ([]*BuildR
 
 // BuildComponent builds a specific component
 func (s *Service) BuildComponent(ctx context.Context, projectID string, componentType projects.ComponentType) *BuildResult {
+	return s.BuildComponentWithCommand(ctx, projectID, componentType, "")
+}
+
+// BuildComponentWithCommand builds a component with a build command replacing
+// the configured or detected one, for this build only (empty = no override)
+func (s *Service) BuildComponentWithCommand(ctx context.Context, projectID string, componentType projects.ComponentType, buildCmd string) *BuildResult {
 	project, err := s.projectService.GetProject(projectID)
 	if err != nil {
 		return &BuildResult{Error: fmt.Errorf("project not found: %s", projectID)}
@@ -88,6 +94,12 @@ func (s *Service) BuildComponent(ctx context.Context, projectID string, componen
 		return &BuildResult{Error: fmt.Errorf("component is disabled: %s", componentType)}
 	}
 
+	if buildCmd != "" {
+		override := *component
+		override.BuildCmd = buildCmd
+		component = &override
+	}
+
 	builder := s.builders[componentType]
 	if builder == nil {
 		return &BuildResult{Error: fmt.Errorf("no builder registered for: %s", componentType)}
@@ -125,7 +137,11 @@ func (s *Service) BuildComponent(ctx context.Context, projectID string, componen
 	err = builder.Build(ctx, project, component, build)
 
 	if err != nil {
-		build.Finish(1)
+		if ctx.Err() != nil {
+			build.Cancel()
+		} else {
+			build.Finish(1)
+		}
 		build.AddError(err.Error())
 
 		s.emitEvent(BuildEvent{
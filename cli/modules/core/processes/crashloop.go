@@ -0,0 +1,58 @@
+package processes
+
+import (
+	"time"
+)
+
+// stderrTailSize is the number of stderr lines kept for the crash details
+const stderrTailSize = 20
+
+// RestartPolicy is the automatic restart of crashed processes
+type RestartPolicy struct {
+	MaxCrashes int           // Crashes within Window before restarts stop (crash loop)
+	Window     time.Duration // Period the crashes are counted over
+	Backoff    time.Duration // Delay before the first restart, doubled at each crash within Window
+	MaxBackoff time.Duration // Longest delay before a restart
+}
+
+// RecordCrash records a crash of a process and returns the delay before its
+// automatic restart. False is returned once it crashed more than MaxCrashes
+// times within Window: the process is marked crash-looping and left stopped.
+func (s *Service) RecordCrash(processID string, policy RestartPolicy) (time.Duration, bool) {
+	now := time.Now()
+
+	s.mu.Lock()
+	var recent []time.Time
+	for _, t := range s.crashes[processID] {
+		if now.Sub(t) < policy.Window {
+			recent = append(recent, t)
+		}
+	}
+	recent = append(recent, now)
+	s.crashes[processID] = recent
+	s.mu.Unlock()
+
+	if len(recent) > policy.MaxCrashes {
+		if proc := s.GetProcess(processID); proc != nil {
+			proc.SetCrashLoop(true)
+		}
+		return 0, false
+	}
+
+	delay := policy.Backoff
+	for i := 1; i < len(recent) && delay < policy.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > policy.MaxBackoff {
+		delay = policy.MaxBackoff
+	}
+	return delay, true
+}
+
+// resetCrashes forgets the crashes of a process: started again by hand, it
+// is restarted automatically again
+func (s *Service) resetCrashes(processID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.crashes, processID)
+}
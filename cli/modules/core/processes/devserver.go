@@ -0,0 +1,168 @@
+package processes
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Hot module replacement states of a dev server
+const (
+	HMRCompiling = "compiling" // Compiling or applying a change
+	HMRReady     = "ready"     // Compiled, changes are applied live
+	HMRError     = "error"     // Compile error (shown in the browser overlay)
+)
+
+// devServerErrorLines is the number of lines kept of a compile error
+const devServerErrorLines = 12
+
+// DevServer is what the output of a frontend dev server (Vite, webpack,
+// Next.js, Create React App, Angular) tells about it
+type DevServer struct {
+	Tool       string    `json:"tool,omitempty"` // vite, webpack, next, ...
+	LocalURL   string    `json:"local_url,omitempty"`
+	NetworkURL string    `json:"network_url,omitempty"`
+	HMR        string    `json:"hmr,omitempty"`         // compiling, ready, error
+	LastUpdate string    `json:"last_update,omitempty"` // Last hot-updated module
+	UpdatedAt  time.Time `json:"updated_at,omitempty"`  // Last compile or hot update
+	Errors     []string  `json:"errors,omitempty"`      // Current compile error (error overlay)
+
+	collecting bool // The lines following an error start belong to it
+}
+
+// Detected returns true if the output looked like a dev server's
+func (d *DevServer) Detected() bool {
+	return d.Tool != "" || d.LocalURL != "" || d.HMR != ""
+}
+
+var (
+	ansiEscape   = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+	urlPattern   = regexp.MustCompile(`https?://[^\s'"<>]+`)
+	localHosts   = []string{"://localhost", "://127.0.0.1", "://0.0.0.0", "://[::1]", "://[::]"}
+	devServerIDs = []struct{ marker, tool string }{
+		{"vite", "vite"},
+		{"webpack", "webpack"},
+		{"next.js", "next"},
+		{"angular live development server", "angular"},
+		{"you can now view", "create-react-app"},
+	}
+	// Compile errors: line starts, then markers anywhere in the line
+	// (lowercased, Vite prefixes its lines with the time)
+	errorStarts  = []string{"error in ", "failed to compile", "✘ [error]", "⨯ "}
+	errorMarkers = []string{"[vite] internal server error", "[vite] pre-transform error"}
+	// Successful compiles and hot updates
+	compileDone = []string{
+		"compiled successfully",
+		"compiled with ", // Warnings: errors are matched first
+		"✓ compiled",
+		"ready in ",
+		"hmr update ",
+		"page reload ",
+	}
+	compileStart = []string{"compiling...", "compiling /", "○ compiling", "rebuilding..."}
+	// Lines announcing where the server listens, for tools that do not label
+	// their local URL
+	listenMarkers = []string{"running at", "listening", "open your browser", "available on", "started server on"}
+)
+
+// ParseLine updates the dev server state from an output line and returns
+// true if it changed
+func (d *DevServer) ParseLine(line string) bool {
+	line = strings.TrimSpace(ansiEscape.ReplaceAllString(line, ""))
+	lower := strings.ToLower(line)
+
+	if d.collecting {
+		if line == "" || len(d.Errors) >= devServerErrorLines {
+			d.collecting = false
+			return false
+		}
+		if !containsAny(lower, compileDone) {
+			d.Errors = append(d.Errors, line)
+			return true
+		}
+		d.collecting = false
+	}
+	if line == "" {
+		return false
+	}
+
+	changed := false
+	if d.Tool == "" {
+		for _, id := range devServerIDs {
+			if strings.Contains(lower, id.marker) {
+				d.Tool = id.tool
+				changed = true
+				break
+			}
+		}
+	}
+	if url := urlPattern.FindString(line); url != "" {
+		url = strings.TrimRight(url, ".,;)*")
+		switch {
+		case containsAny(lower, []string{"network:", "on your network"}):
+			if d.NetworkURL != url {
+				d.NetworkURL = url
+				changed = true
+			}
+		case containsAny(lower, []string{"local:", "loopback:"}) || d.LocalURL == "" && isLocalURL(url) && containsAny(lower, listenMarkers):
+			if d.LocalURL != url {
+				d.LocalURL = url
+				changed = true
+			}
+		}
+	}
+
+	switch {
+	case hasAnyPrefix(lower, errorStarts) || containsAny(lower, errorMarkers) ||
+		strings.Contains(lower, "compiled with") && strings.Contains(lower, "error"):
+		d.HMR = HMRError
+		d.Errors = []string{line}
+		d.collecting = true
+		d.UpdatedAt = time.Now()
+		return true
+	case containsAny(lower, compileDone):
+		d.HMR = HMRReady
+		d.Errors = nil
+		d.UpdatedAt = time.Now()
+		if i := strings.Index(lower, "hmr update "); i >= 0 {
+			d.LastUpdate = strings.TrimSpace(line[i+len("hmr update "):])
+		}
+		return true
+	case containsAny(lower, compileStart) && d.HMR != HMRError:
+		if d.HMR != HMRCompiling {
+			d.HMR = HMRCompiling
+			changed = true
+		}
+	}
+	return changed
+}
+
+// isLocalURL returns true if a URL points to this machine
+func isLocalURL(url string) bool {
+	for _, host := range localHosts {
+		if strings.Contains(url, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAnyPrefix returns true if s starts with one of the prefixes
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny returns true if s contains one of the substrings
+func containsAny(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
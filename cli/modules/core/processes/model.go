@@ -36,24 +36,32 @@ type Process struct {
 	Command     string                 `json:"command"`
 	Args        []string               `json:"args"`
 	Port        int                    `json:"port,omitempty"`
+	CrashLoop   bool                   `json:"crash_loop,omitempty"`  // Crashed too often: no longer restarted
+	Termination *Termination           `json:"termination,omitempty"` // How it ended (nil while it runs)
 
 	// Runtime fields (not serialized)
-	cmd       *exec.Cmd  `json:"-"`
-	logBuffer *RingBuffer `json:"-"`
-	mu        sync.RWMutex `json:"-"`
+	cmd        *exec.Cmd     `json:"-"`
+	logBuffer  *RingBuffer   `json:"-"`
+	stderrTail *RingBuffer   `json:"-"` // Last stderr lines (shown when it crashes)
+	exited     chan struct{} `json:"-"` // Closed once the process exited and its termination is recorded
+	peakRSS    uint64        `json:"-"` // Peak resident memory sampled (bytes)
+	devServer  DevServer     `json:"-"` // Parsed from the output of frontend components
+	mu         sync.RWMutex  `json:"-"`
 }
 
 // NewProcess creates a new process
 func NewProcess(projectID string, component projects.ComponentType, workDir, command string, args []string) *Process {
 	return &Process{
-		ID:        generateProcessID(projectID, component),
-		ProjectID: projectID,
-		Component: component,
-		State:     ProcessStateStopped,
-		WorkDir:   workDir,
-		Command:   command,
-		Args:      args,
-		logBuffer: NewRingBuffer(10000),
+		ID:         generateProcessID(projectID, component),
+		ProjectID:  projectID,
+		Component:  component,
+		State:      ProcessStateStopped,
+		WorkDir:    workDir,
+		Command:    command,
+		Args:       args,
+		logBuffer:  NewRingBuffer(10000),
+		stderrTail: NewRingBuffer(stderrTailSize),
+		exited:     make(chan struct{}),
 	}
 }
 
@@ -102,6 +110,78 @@ func (p *Process) AppendLog(line string) {
 	p.logBuffer.Write(line)
 }
 
+// AppendStderr appends a stderr line to the log buffer and the stderr tail
+func (p *Process) AppendStderr(line string) {
+	p.logBuffer.Write(line)
+	p.stderrTail.Write(line)
+}
+
+// GetStderrTail returns the last stderr lines
+func (p *Process) GetStderrTail() []string {
+	return p.stderrTail.ReadAll()
+}
+
+// MarkExited signals the process exit to Exited waiters
+func (p *Process) MarkExited() {
+	close(p.exited)
+}
+
+// Exited returns a channel closed once the process exited
+func (p *Process) Exited() <-chan struct{} {
+	return p.exited
+}
+
+// UpdatePeakRSS records a memory sample of the process tree, keeping the peak
+func (p *Process) UpdatePeakRSS(rss uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if rss > p.peakRSS {
+		p.peakRSS = rss
+	}
+}
+
+// PeakRSS returns the peak resident memory sampled during the run
+func (p *Process) PeakRSS() uint64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.peakRSS
+}
+
+// ParseDevServerOutput updates the dev server state from an output line and
+// returns true if it changed
+func (p *Process) ParseDevServerOutput(line string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.devServer.ParseLine(line)
+}
+
+// GetDevServer returns a copy of the dev server state, nil if the output
+// does not look like a dev server's
+func (p *Process) GetDevServer() *DevServer {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if !p.devServer.Detected() {
+		return nil
+	}
+	devServer := p.devServer
+	devServer.Errors = append([]string(nil), p.devServer.Errors...)
+	return &devServer
+}
+
+// SetCrashLoop marks the process as crash-looping
+func (p *Process) SetCrashLoop(crashLoop bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.CrashLoop = crashLoop
+}
+
+// IsCrashLooping returns true if the process crashed too often to be restarted
+func (p *Process) IsCrashLooping() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.CrashLoop
+}
+
 // GetLogs returns the last n log lines
 func (p *Process) GetLogs(n int) []string {
 	return p.logBuffer.Read(n)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"csd-devtrack/cli/modules/core/projects"
 )
@@ -15,6 +16,10 @@ type ProcessHandler func(event ProcessEvent)
 type Service struct {
 	projectService *projects.Service
 	processes      map[string]*Process
+	crashes        map[string][]time.Time   // Recent crashes per process (crash loop detection)
+	terminations   map[string][]Termination // Run history per process, newest first
+	store          TerminationStore         // Persisted run history (nil = memory only)
+	dependencies   Dependencies             // Services started before the components (nil = none)
 	mu             sync.RWMutex
 	eventHandler   ProcessHandler
 }
@@ -24,6 +29,8 @@ func NewService(projectService *projects.Service) *Service {
 	return &Service{
 		projectService: projectService,
 		processes:      make(map[string]*Process),
+		crashes:        make(map[string][]time.Time),
+		terminations:   make(map[string][]Termination),
 	}
 }
 
@@ -32,6 +39,11 @@ func (s *Service) SetEventHandler(handler ProcessHandler) {
 	s.eventHandler = handler
 }
 
+// SetDependencies sets what starts the services the components depend on
+func (s *Service) SetDependencies(deps Dependencies) {
+	s.dependencies = deps
+}
+
 // GetProcess returns a process by ID
 func (s *Service) GetProcess(id string) *Process {
 	s.mu.RLock()
@@ -117,6 +129,13 @@ func (s *Service) StartProject(ctx context.Context, projectID string, supervisor
 
 // StartComponent starts a specific component
 func (s *Service) StartComponent(ctx context.Context, projectID string, component projects.ComponentType, supervisor Supervisor) error {
+	return s.StartComponentWithCommand(ctx, projectID, component, "", supervisor)
+}
+
+// StartComponentWithCommand starts a component with a run command replacing
+// the configured or default one, for this run only (empty = no override).
+// A restart goes back to the configured command.
+func (s *Service) StartComponentWithCommand(ctx context.Context, projectID string, component projects.ComponentType, runCmd string, supervisor Supervisor) error {
 	project, err := s.projectService.GetProject(projectID)
 	if err != nil {
 		return fmt.Errorf("project not found: %s", projectID)
@@ -126,6 +145,11 @@ func (s *Service) StartComponent(ctx context.Context, projectID string, componen
 	if comp == nil {
 		return fmt.Errorf("component not found: %s", component)
 	}
+	if runCmd != "" {
+		override := *comp
+		override.RunCmd = runCmd
+		comp = &override
+	}
 
 	// Check if already running
 	existing := s.GetProcessForComponent(projectID, component)
@@ -133,12 +157,17 @@ func (s *Service) StartComponent(ctx context.Context, projectID string, componen
 		return fmt.Errorf("component is already running: %s/%s", projectID, component)
 	}
 
+	if err := s.startDependencies(ctx, projectID, comp); err != nil {
+		return err
+	}
+
 	// Start the process
 	proc, err := supervisor.Start(ctx, project, comp)
 	if err != nil {
 		return err
 	}
 
+	s.resetCrashes(proc.ID)
 	s.RegisterProcess(proc)
 	return nil
 }
@@ -174,6 +203,18 @@ func (s *Service) StopProcess(ctx context.Context, processID string, supervisor
 
 // RestartProcess restarts a process
 func (s *Service) RestartProcess(ctx context.Context, processID string, supervisor Supervisor) error {
+	s.resetCrashes(processID)
+	return s.restartProcess(ctx, processID, supervisor)
+}
+
+// AutoRestart restarts a crashed process, keeping its crash history (see
+// RecordCrash)
+func (s *Service) AutoRestart(ctx context.Context, processID string, supervisor Supervisor) error {
+	return s.restartProcess(ctx, processID, supervisor)
+}
+
+// restartProcess stops a process if it runs and starts it again
+func (s *Service) restartProcess(ctx context.Context, processID string, supervisor Supervisor) error {
 	proc := s.GetProcess(processID)
 	if proc == nil {
 		return fmt.Errorf("process not found: %s", processID)
@@ -197,6 +238,10 @@ func (s *Service) RestartProcess(ctx context.Context, processID string, supervis
 		return fmt.Errorf("component not found: %s", proc.Component)
 	}
 
+	if err := s.startDependencies(ctx, proc.ProjectID, comp); err != nil {
+		return err
+	}
+
 	// Start again
 	newProc, err := supervisor.Start(ctx, project, comp)
 	if err != nil {
@@ -209,6 +254,18 @@ func (s *Service) RestartProcess(ctx context.Context, processID string, supervis
 	return nil
 }
 
+// startDependencies starts the services a component depends on, if not
+// running, and waits for them to be ready
+func (s *Service) startDependencies(ctx context.Context, projectID string, comp *projects.Component) error {
+	if s.dependencies == nil || len(comp.Services) == 0 {
+		return nil
+	}
+	if err := s.dependencies.Ensure(ctx, comp.Services); err != nil {
+		return fmt.Errorf("dependencies of %s/%s: %w", projectID, comp.Type, err)
+	}
+	return nil
+}
+
 // KillProcess forcefully kills a process
 func (s *Service) KillProcess(processID string, supervisor Supervisor) error {
 	proc := s.GetProcess(processID)
@@ -250,6 +307,13 @@ func (s *Service) EmitEvent(event ProcessEvent) {
 	}
 }
 
+// Dependencies starts the services of the catalog (databases, brokers...)
+// the components depend on, see Component.Services
+type Dependencies interface {
+	// Ensure starts the services not running and waits until all are ready
+	Ensure(ctx context.Context, names []string) error
+}
+
 // Supervisor interface for process supervision
 type Supervisor interface {
 	Start(ctx context.Context, project *projects.Project, component *projects.Component) (*Process, error)
@@ -0,0 +1,135 @@
+package processes
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+)
+
+// RunHistorySize is the number of runs kept per process (run history)
+const RunHistorySize = 20
+
+// Termination reasons
+const (
+	ExitReasonExited  = "exited"  // Exited by itself
+	ExitReasonStopped = "stopped" // Stopped or killed from DevTrack
+	ExitReasonSignal  = "signal"  // Killed by a signal DevTrack did not send
+	ExitReasonOOM     = "oom"     // Killed by the kernel OOM killer
+)
+
+// Termination records how a process ended: one run of the run history
+type Termination struct {
+	StartedAt time.Time     `json:"started_at"`
+	At        time.Time     `json:"at"`
+	Reason    string        `json:"reason"`
+	ExitCode  int           `json:"exit_code"`        // -1 if killed by a signal
+	Signal    string        `json:"signal,omitempty"` // Signal that killed it (or its child: exit code 128+n)
+	Uptime    time.Duration `json:"uptime"`
+	PeakRSS   uint64        `json:"peak_rss,omitempty"` // Peak resident memory of the process tree (sampled)
+}
+
+// TerminationStore persists the terminations of the processes, so the run
+// history of a component survives DevTrack restarts
+type TerminationStore interface {
+	Append(processID string, t Termination) error
+	Load() map[string][]Termination // Oldest first
+}
+
+// Describe returns the cause of the termination, e.g. "exit code 1",
+// "killed by SIGSEGV", "killed by the OOM killer"
+func (t *Termination) Describe() string {
+	cause := fmt.Sprintf("exit code %d", t.ExitCode)
+	if t.Signal != "" {
+		if t.ExitCode >= 0 {
+			cause = fmt.Sprintf("exit code %d (%s)", t.ExitCode, t.Signal)
+		} else {
+			cause = "killed by " + t.Signal
+		}
+	}
+
+	switch t.Reason {
+	case ExitReasonOOM:
+		return "killed by the OOM killer (" + cause + ")"
+	case ExitReasonStopped:
+		return "stopped, " + cause
+	}
+	return cause
+}
+
+// signalNames names the signals a process commonly dies of
+var signalNames = map[syscall.Signal]string{
+	syscall.SIGHUP:  "SIGHUP",
+	syscall.SIGINT:  "SIGINT",
+	syscall.SIGQUIT: "SIGQUIT",
+	syscall.SIGILL:  "SIGILL",
+	syscall.SIGTRAP: "SIGTRAP",
+	syscall.SIGABRT: "SIGABRT",
+	syscall.SIGBUS:  "SIGBUS",
+	syscall.SIGFPE:  "SIGFPE",
+	syscall.SIGKILL: "SIGKILL",
+	syscall.SIGSEGV: "SIGSEGV",
+	syscall.SIGPIPE: "SIGPIPE",
+	syscall.SIGALRM: "SIGALRM",
+	syscall.SIGTERM: "SIGTERM",
+}
+
+// SignalName returns the name of a signal, e.g. SIGKILL
+func SignalName(sig syscall.Signal) string {
+	if name, ok := signalNames[sig]; ok {
+		return name
+	}
+	return fmt.Sprintf("signal %d", int(sig))
+}
+
+// SetTermination records how the process ended
+func (p *Process) SetTermination(t Termination) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Termination = &t
+}
+
+// GetTermination returns how the process ended (nil while it runs)
+func (p *Process) GetTermination() *Termination {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.Termination
+}
+
+// SetTerminationStore persists the run history and loads the recorded one
+func (s *Service) SetTerminationStore(store TerminationStore) {
+	loaded := store.Load()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store = store
+	for processID, runs := range loaded {
+		var history []Termination
+		for i := len(runs) - 1; i >= 0 && len(history) < RunHistorySize; i-- {
+			history = append(history, runs[i])
+		}
+		s.terminations[processID] = history
+	}
+}
+
+// RecordTermination adds a termination to the run history of a process
+func (s *Service) RecordTermination(processID string, t Termination) {
+	s.mu.Lock()
+	history := append([]Termination{t}, s.terminations[processID]...)
+	if len(history) > RunHistorySize {
+		history = history[:RunHistorySize]
+	}
+	s.terminations[processID] = history
+	store := s.store
+	s.mu.Unlock()
+
+	if store != nil {
+		store.Append(processID, t) // Best effort: the history stays in memory
+	}
+}
+
+// GetTerminations returns the last terminations of a process, newest first
+func (s *Service) GetTerminations(processID string) []Termination {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Termination(nil), s.terminations[processID]...)
+}
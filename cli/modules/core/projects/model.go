@@ -47,11 +47,96 @@ type Component struct {
 	Port       int           `yaml:"port" json:"port"`               // Port if applicable
 	Enabled    bool          `yaml:"enabled" json:"enabled"`
 
+	// API served by the component, browsed by the API explorer
+	API *APIDescriptor `yaml:"api,omitempty" json:"api,omitempty"`
+
+	// Services of the catalog (settings "services:", e.g. postgres) started
+	// and ready before the component
+	Services []string `yaml:"services,omitempty" json:"services,omitempty"`
+
+	// Local TLS certificate for HTTPS in development
+	TLS *TLSConfig `yaml:"tls,omitempty" json:"tls,omitempty"`
+
+	// Dev hostnames served by the component (e.g. app.test, acme.app.test,
+	// *.app.test), mapped to the loopback by the hosts file helper
+	Hostnames []string `yaml:"hostnames,omitempty" json:"hostnames,omitempty"`
+
+	// Outbound HTTP(S) requests recorded by the capture proxy (HTTP_PROXY
+	// set when it runs)
+	Capture bool `yaml:"capture,omitempty" json:"capture,omitempty"`
+
 	// Runtime state (not persisted)
 	LastBuildTime   *time.Time `yaml:"-" json:"last_build_time,omitempty"`
 	LastBuildStatus string     `yaml:"-" json:"last_build_status,omitempty"`
 }
 
+// APIDescriptor describes the API a component serves, for the API explorer.
+// One of OpenAPI or Proto is set.
+type APIDescriptor struct {
+	OpenAPI string `yaml:"openapi,omitempty" json:"openapi,omitempty"`   // OpenAPI/Swagger file (YAML or JSON), relative to the component
+	Proto   string `yaml:"proto,omitempty" json:"proto,omitempty"`       // .proto file of a gRPC service, relative to the component
+	BaseURL string `yaml:"base_url,omitempty" json:"base_url,omitempty"` // Server address (default: localhost on the component port)
+}
+
+// TLSConfig is the local TLS certificate of a component serving HTTPS in
+// development. DevTrack generates it (mkcert, self-signed without it), stores
+// its paths here and passes them to the component in the environment.
+type TLSConfig struct {
+	Hosts   []string `yaml:"hosts,omitempty" json:"hosts,omitempty"`       // Names of the certificate (default: localhost, 127.0.0.1, ::1)
+	Cert    string   `yaml:"cert,omitempty" json:"cert,omitempty"`         // Certificate file (PEM), set when generated
+	Key     string   `yaml:"key,omitempty" json:"key,omitempty"`           // Private key file (PEM), set when generated
+	CertEnv string   `yaml:"cert_env,omitempty" json:"cert_env,omitempty"` // Variable of the certificate path (default: TLS_CERT_FILE)
+	KeyEnv  string   `yaml:"key_env,omitempty" json:"key_env,omitempty"`   // Variable of the key path (default: TLS_KEY_FILE)
+}
+
+// Environment returns the variables passing the certificate to the
+// component, none until it is generated
+func (t *TLSConfig) Environment() []string {
+	if t == nil || t.Cert == "" || t.Key == "" {
+		return nil
+	}
+	certEnv, keyEnv := t.CertEnv, t.KeyEnv
+	if certEnv == "" {
+		certEnv = "TLS_CERT_FILE"
+	}
+	if keyEnv == "" {
+		keyEnv = "TLS_KEY_FILE"
+	}
+	return []string{certEnv + "=" + t.Cert, keyEnv + "=" + t.Key}
+}
+
+// DeployTarget is a deployment environment of a project (e.g. staging, production).
+// Either Command (run by the shell) or Script (executed directly) is set.
+type DeployTarget struct {
+	Name    string            `yaml:"name" json:"name"`                           // Environment name
+	Command string            `yaml:"command,omitempty" json:"command,omitempty"` // Shell command
+	Script  string            `yaml:"script,omitempty" json:"script,omitempty"`   // Script path, relative to the project
+	Dir     string            `yaml:"dir,omitempty" json:"dir,omitempty"`         // Working directory, relative to the project
+	Env     map[string]string `yaml:"env,omitempty" json:"env,omitempty"`         // Extra environment variables
+}
+
+// DatabaseCommands are the commands resetting and seeding the development
+// databases of a project. They run in the shell, with DEVTRACK_DATABASE_URL
+// and DEVTRACK_DATABASE_NAME set to the database selected in the Database view.
+type DatabaseCommands struct {
+	Reset string            `yaml:"reset,omitempty" json:"reset,omitempty"` // Drop and recreate the database (e.g. "make db-reset")
+	Seed  string            `yaml:"seed,omitempty" json:"seed,omitempty"`   // Load seed data
+	Dir   string            `yaml:"dir,omitempty" json:"dir,omitempty"`     // Working directory, relative to the project
+	Env   map[string]string `yaml:"env,omitempty" json:"env,omitempty"`     // Extra environment variables
+}
+
+// ImageConfig configures the container image build of a project (docker or
+// podman build). Without it, a Dockerfile at the project root is built as
+// <project id>:<profile>.
+type ImageConfig struct {
+	Name       string              `yaml:"name,omitempty" json:"name,omitempty"`             // Repository, e.g. "ghcr.io/acme/api" (default: project ID)
+	Dockerfile string              `yaml:"dockerfile,omitempty" json:"dockerfile,omitempty"` // Relative to the project (default: Dockerfile)
+	Context    string              `yaml:"context,omitempty" json:"context,omitempty"`       // Build context, relative to the project (default: the Dockerfile directory)
+	Target     string              `yaml:"target,omitempty" json:"target,omitempty"`         // Stage of a multi-stage build
+	Args       map[string]string   `yaml:"args,omitempty" json:"args,omitempty"`             // Build arguments
+	Tags       map[string][]string `yaml:"tags,omitempty" json:"tags,omitempty"`             // Tags per build profile, {git} = short commit hash
+}
+
 // Project represents a managed project
 type Project struct {
 	ID         string                   `yaml:"id" json:"id"`
@@ -60,6 +145,12 @@ type Project struct {
 	Type       ProjectType              `yaml:"type" json:"type"`
 	Self       bool                     `yaml:"-" json:"self,omitempty"` // Computed: is this csd-devtrack itself?
 	Components map[ComponentType]*Component `yaml:"components" json:"components"`
+	Deploy     []DeployTarget           `yaml:"deploy,omitempty" json:"deploy,omitempty"` // Deploy targets (environments)
+	Database   *DatabaseCommands        `yaml:"database,omitempty" json:"database,omitempty"` // Reset/seed commands (Database view)
+	Image      *ImageConfig             `yaml:"image,omitempty" json:"image,omitempty"`       // Container image build
+	LargeRepo  bool                     `yaml:"large_repo,omitempty" json:"large_repo,omitempty"` // Git status by git itself, without untracked files, refreshed less often
+	GitHide    []string                 `yaml:"git_hide,omitempty" json:"git_hide,omitempty"`     // Changed files hidden in the Git view (gitignore patterns: generated code, vendored trees)
+	Artifacts  []string                 `yaml:"artifacts,omitempty" json:"artifacts,omitempty"`   // Other build output folders cleaned from the Storage view (relative to the project path)
 
 	// Git info (computed, not persisted)
 	GitBranch  string `yaml:"-" json:"git_branch,omitempty"`
@@ -69,6 +160,16 @@ type Project struct {
 	GitRemote  string `yaml:"-" json:"git_remote,omitempty"`
 }
 
+// GetDeployTarget returns the deploy target with the given name, or nil
+func (p *Project) GetDeployTarget(name string) *DeployTarget {
+	for i := range p.Deploy {
+		if p.Deploy[i].Name == name {
+			return &p.Deploy[i]
+		}
+	}
+	return nil
+}
+
 // GetEnabledComponents returns all enabled components in build order
 func (p *Project) GetEnabledComponents() []*Component {
 	var components []*Component
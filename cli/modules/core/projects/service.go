@@ -49,7 +49,22 @@ func (s *Service) AddProject(path string) (*Project, error) {
 
 	// Check if project already exists
 	if s.repo.Exists(project.ID) {
-		// Update existing project
+		// Update existing project (deploy targets, database commands,
+		// service dependencies, certificates, hostnames and request
+		// capture are not detected: keep them)
+		if existing, err := s.repo.GetByID(project.ID); err == nil {
+			project.Deploy = existing.Deploy
+			project.Database = existing.Database
+			project.Image = existing.Image
+			for ct, comp := range project.Components {
+				if previous := existing.GetComponent(ct); previous != nil && comp != nil {
+					comp.Services = previous.Services
+					comp.TLS = previous.TLS
+					comp.Hostnames = previous.Hostnames
+					comp.Capture = previous.Capture
+				}
+			}
+		}
 		if err := s.repo.Update(project); err != nil {
 			return nil, fmt.Errorf("failed to update project: %w", err)
 		}
@@ -0,0 +1,46 @@
+package adhoc
+
+import (
+	"time"
+)
+
+// State is the state of an ad-hoc command run
+type State string
+
+const (
+	StateRunning  State = "running"
+	StateSuccess  State = "success"
+	StateFailed   State = "failed"
+	StateCanceled State = "canceled"
+)
+
+// Request describes a command to run in a component directory
+type Request struct {
+	ProjectID string
+	Component string   // Empty = project directory
+	Command   string   // Shell command
+	Dir       string   // Working directory
+	Env       []string // Extra environment variables (KEY=value)
+}
+
+// Run is a run of an ad-hoc command
+type Run struct {
+	ID         string    `json:"id"`
+	ProjectID  string    `json:"project_id"`
+	Component  string    `json:"component,omitempty"`
+	Command    string    `json:"command"`
+	Dir        string    `json:"dir"`
+	State      State     `json:"state"`
+	ExitCode   int       `json:"exit_code"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+}
+
+// Duration returns how long the command ran (so far if running)
+func (r Run) Duration() time.Duration {
+	if r.FinishedAt.IsZero() {
+		return time.Since(r.StartedAt)
+	}
+	return r.FinishedAt.Sub(r.StartedAt)
+}
@@ -0,0 +1,19 @@
+// +build !windows
+
+package adhoc
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setupProcessGroup runs the command in its own process group, killed as a
+// whole on cancel: the shell does not outlive its children
+func setupProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
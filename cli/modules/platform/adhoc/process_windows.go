@@ -0,0 +1,8 @@
+package adhoc
+
+import (
+	"os/exec"
+)
+
+// setupProcessGroup is a no-op on Windows (cancel kills the shell)
+func setupProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,201 @@
+package adhoc
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxRuns is the number of runs kept in memory
+const maxRuns = 20
+
+// Service runs ad-hoc shell commands in project or component directories,
+// one at a time per component, and keeps the recent runs.
+type Service struct {
+	mu      sync.RWMutex
+	runs    []*Run                        // Most recent last
+	cancels map[string]context.CancelFunc // Key: run ID, while running
+}
+
+// NewService creates an ad-hoc command service
+func NewService() *Service {
+	return &Service{cancels: make(map[string]context.CancelFunc)}
+}
+
+// GetRuns returns a copy of the recent runs, most recent last
+func (s *Service) GetRuns() []Run {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Run, len(s.runs))
+	for i, r := range s.runs {
+		result[i] = *r
+	}
+	return result
+}
+
+// running returns the running command of a component, nil if none.
+// The caller holds the lock.
+func (s *Service) running(projectID, component string) *Run {
+	for _, r := range s.runs {
+		if r.ProjectID == projectID && r.Component == component && r.State == StateRunning {
+			return r
+		}
+	}
+	return nil
+}
+
+// Start runs a command in the background. onOutput is called for each output
+// line, onUpdate with a copy of the run when it starts and finishes.
+func (s *Service) Start(ctx context.Context, req Request, onOutput func(r Run, line string, isError bool), onUpdate func(Run)) (*Run, error) {
+	if strings.TrimSpace(req.Command) == "" {
+		return nil, fmt.Errorf("no command to run")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/c", req.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", req.Command)
+	}
+	cmd.Dir = req.Dir
+	cmd.Env = append(os.Environ(), req.Env...)
+	setupProcessGroup(cmd)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	r := &Run{
+		ID:        strconv.FormatInt(time.Now().UnixNano(), 36),
+		ProjectID: req.ProjectID,
+		Component: req.Component,
+		Command:   req.Command,
+		Dir:       req.Dir,
+		State:     StateRunning,
+		StartedAt: time.Now(),
+	}
+	s.mu.Lock()
+	if existing := s.running(req.ProjectID, req.Component); existing != nil {
+		s.mu.Unlock()
+		cancel()
+		return nil, fmt.Errorf("already running: %s", existing.Command)
+	}
+	s.runs = append(s.runs, r)
+	if len(s.runs) > maxRuns {
+		s.runs = s.runs[len(s.runs)-maxRuns:]
+	}
+	s.cancels[r.ID] = cancel
+	s.mu.Unlock()
+
+	if err := cmd.Start(); err != nil {
+		s.finish(r, err, "", nil)
+		return nil, fmt.Errorf("failed to start command: %w", err)
+	}
+
+	go func() {
+		var wg sync.WaitGroup
+		var lastErrLine string
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.readOutput(r, stdout, false, onOutput)
+		}()
+		go func() {
+			defer wg.Done()
+			lastErrLine = s.readOutput(r, stderr, true, onOutput)
+		}()
+		// Pipes must be drained before Wait closes them
+		wg.Wait()
+		err := cmd.Wait()
+		s.finish(r, err, lastErrLine, onUpdate)
+	}()
+
+	return r, nil
+}
+
+// Cancel kills the running command of a component, false if none runs
+func (s *Service) Cancel(projectID, component string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.running(projectID, component)
+	if r == nil {
+		return false
+	}
+	r.State = StateCanceled
+	s.cancels[r.ID]()
+	return true
+}
+
+// readOutput forwards the output lines of a run and returns the last non-empty line
+func (s *Service) readOutput(r *Run, rd io.Reader, isError bool, onOutput func(Run, string, bool)) string {
+	last := ""
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		last = line
+		if onOutput != nil {
+			s.mu.RLock()
+			snapshot := *r
+			s.mu.RUnlock()
+			onOutput(snapshot, line, isError)
+		}
+	}
+	return last
+}
+
+// finish records the result of a run
+func (s *Service) finish(r *Run, err error, lastErrLine string, onUpdate func(Run)) {
+	s.mu.Lock()
+	r.FinishedAt = time.Now()
+	if cancel, ok := s.cancels[r.ID]; ok {
+		cancel()
+		delete(s.cancels, r.ID)
+	}
+	switch {
+	case r.State == StateCanceled:
+		r.ExitCode = -1
+	case err != nil:
+		r.State = StateFailed
+		r.ExitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			r.ExitCode = exitErr.ExitCode()
+		}
+		r.Error = err.Error()
+		// The last line of stderr explains the failure better than the exit code
+		if lastErrLine != "" {
+			r.Error = strings.TrimSpace(lastErrLine)
+		}
+	default:
+		r.State = StateSuccess
+	}
+	snapshot := *r
+	s.mu.Unlock()
+
+	if onUpdate != nil {
+		onUpdate(snapshot)
+	}
+}
@@ -0,0 +1,49 @@
+package apiexplorer
+
+import (
+	"time"
+)
+
+// Kind is the protocol of an endpoint
+type Kind string
+
+const (
+	KindHTTP Kind = "http" // Route of an OpenAPI (Swagger) descriptor
+	KindGRPC Kind = "grpc" // Method of a gRPC service (.proto descriptor)
+)
+
+// Endpoint is an operation of a component API
+type Endpoint struct {
+	Kind    Kind   `json:"kind"`
+	Method  string `json:"method"`            // HTTP method, "RPC" for gRPC
+	Path    string `json:"path"`              // Route (/users/{id}) or full method (pkg.Service/Method)
+	Summary string `json:"summary,omitempty"` // Description from the descriptor
+	Body    string `json:"body,omitempty"`    // Example JSON body built from the request schema
+}
+
+// API is the list of endpoints read from a descriptor
+type API struct {
+	Descriptor string     // Descriptor file
+	BaseURL    string     // Server declared by the descriptor (OpenAPI), empty if none
+	Endpoints  []Endpoint // Sorted by path, then method
+}
+
+// Request is a test request to fire at an endpoint
+type Request struct {
+	Kind   Kind
+	Method string
+	Target string // HTTP base URL, or host:port of the gRPC server
+	Path   string // Route with its query string, or full gRPC method
+	Body   string // JSON body (empty = none)
+	Proto  string // .proto file describing the gRPC method
+}
+
+// Response is the result of a test request
+type Response struct {
+	Status   string        `json:"status"`          // "200 OK", "OK" (gRPC), empty on transport errors
+	OK       bool          `json:"ok"`              // 2xx or gRPC OK
+	Duration time.Duration `json:"duration"`        // Round trip
+	Headers  []string      `json:"headers"`         // "Name: value", sorted
+	Body     string        `json:"body"`            // Pretty-printed when JSON, truncated when large
+	Error    string        `json:"error,omitempty"` // Transport error (connection refused, timeout...)
+}
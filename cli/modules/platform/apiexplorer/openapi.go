@@ -0,0 +1,262 @@
+package apiexplorer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// httpMethods are the operations of an OpenAPI path item, in display order
+var httpMethods = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// maxSchemaDepth bounds the examples built from deeply nested schemas
+const maxSchemaDepth = 6
+
+// LoadOpenAPI reads the routes of an OpenAPI 3 or Swagger 2 descriptor
+// (YAML or JSON)
+func LoadOpenAPI(path string) (*API, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// YAML is a superset of JSON: one parser reads both
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid descriptor %s: %w", path, err)
+	}
+	paths, _ := doc["paths"].(map[string]interface{})
+	if paths == nil {
+		return nil, fmt.Errorf("no paths in %s", path)
+	}
+
+	api := &API{Descriptor: path, BaseURL: openAPIServer(doc)}
+	for route, item := range paths {
+		operations, _ := item.(map[string]interface{})
+		for _, method := range httpMethods {
+			op, ok := operations[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			endpoint := Endpoint{
+				Kind:    KindHTTP,
+				Method:  strings.ToUpper(method),
+				Path:    route,
+				Summary: operationSummary(op),
+			}
+			if schema := requestSchema(op); schema != nil {
+				example := schemaExample(doc, schema, nil, 0)
+				if body, err := json.MarshalIndent(example, "", "  "); err == nil {
+					endpoint.Body = string(body)
+				}
+			}
+			api.Endpoints = append(api.Endpoints, endpoint)
+		}
+	}
+
+	order := make(map[string]int, len(httpMethods))
+	for i, method := range httpMethods {
+		order[strings.ToUpper(method)] = i
+	}
+	sort.Slice(api.Endpoints, func(i, j int) bool {
+		a, b := api.Endpoints[i], api.Endpoints[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return order[a.Method] < order[b.Method]
+	})
+	return api, nil
+}
+
+// openAPIServer returns the first server of the descriptor: servers[0].url
+// (OpenAPI 3) or host + basePath (Swagger 2). Relative URLs are returned
+// as is, to be joined to the component's address.
+func openAPIServer(doc map[string]interface{}) string {
+	if servers, ok := doc["servers"].([]interface{}); ok && len(servers) > 0 {
+		if server, ok := servers[0].(map[string]interface{}); ok {
+			url, _ := server["url"].(string)
+			return strings.TrimSuffix(url, "/")
+		}
+	}
+	basePath, _ := doc["basePath"].(string)
+	if host, _ := doc["host"].(string); host != "" {
+		scheme := "http"
+		if schemes, ok := doc["schemes"].([]interface{}); ok && len(schemes) > 0 {
+			if s, ok := schemes[0].(string); ok {
+				scheme = s
+			}
+		}
+		return scheme + "://" + host + strings.TrimSuffix(basePath, "/")
+	}
+	return strings.TrimSuffix(basePath, "/")
+}
+
+// operationSummary returns the summary of an operation, else its ID
+func operationSummary(op map[string]interface{}) string {
+	if summary, _ := op["summary"].(string); summary != "" {
+		return strings.TrimSpace(summary)
+	}
+	id, _ := op["operationId"].(string)
+	return id
+}
+
+// requestSchema returns the JSON schema of an operation's body:
+// requestBody.content["application/json"] (OpenAPI 3) or the "in: body"
+// parameter (Swagger 2). Nil if the operation takes no JSON body.
+func requestSchema(op map[string]interface{}) map[string]interface{} {
+	if requestBody, ok := op["requestBody"].(map[string]interface{}); ok {
+		content, _ := requestBody["content"].(map[string]interface{})
+		for mediaType, media := range content {
+			if !strings.Contains(mediaType, "json") {
+				continue
+			}
+			if m, ok := media.(map[string]interface{}); ok {
+				schema, _ := m["schema"].(map[string]interface{})
+				return schema
+			}
+		}
+		return nil
+	}
+	params, _ := op["parameters"].([]interface{})
+	for _, p := range params {
+		param, _ := p.(map[string]interface{})
+		if in, _ := param["in"].(string); in == "body" {
+			schema, _ := param["schema"].(map[string]interface{})
+			return schema
+		}
+	}
+	return nil
+}
+
+// resolveRef returns the schema a local $ref (#/components/schemas/User,
+// #/definitions/User) points to, nil if it cannot be resolved
+func resolveRef(doc map[string]interface{}, ref string) map[string]interface{} {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil // External references are not followed
+	}
+	var node interface{} = doc
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		node = m[part]
+	}
+	schema, _ := node.(map[string]interface{})
+	return schema
+}
+
+// schemaExample builds an example value for a schema: its example or
+// default if any, else a skeleton with placeholder values. refs holds the
+// references being expanded: a recursive one (User.parent) is left null.
+func schemaExample(doc, schema map[string]interface{}, refs []string, depth int) interface{} {
+	if schema == nil || depth > maxSchemaDepth {
+		return nil
+	}
+	if ref, _ := schema["$ref"].(string); ref != "" {
+		for _, r := range refs {
+			if r == ref {
+				return nil
+			}
+		}
+		return schemaExample(doc, resolveRef(doc, ref), append(refs, ref), depth+1)
+	}
+	if example, ok := schema["example"]; ok {
+		return jsonValue(example)
+	}
+	if def, ok := schema["default"]; ok {
+		return jsonValue(def)
+	}
+	if values, ok := schema["enum"].([]interface{}); ok && len(values) > 0 {
+		return jsonValue(values[0])
+	}
+	for _, key := range []string{"allOf", "oneOf", "anyOf"} {
+		variants, ok := schema[key].([]interface{})
+		if !ok || len(variants) == 0 {
+			continue
+		}
+		if key != "allOf" {
+			variant, _ := variants[0].(map[string]interface{})
+			return schemaExample(doc, variant, refs, depth+1)
+		}
+		// allOf: merge the properties of the parts
+		merged := make(map[string]interface{})
+		for _, v := range variants {
+			part, _ := v.(map[string]interface{})
+			if obj, ok := schemaExample(doc, part, refs, depth+1).(map[string]interface{}); ok {
+				for name, value := range obj {
+					merged[name] = value
+				}
+			}
+		}
+		return merged
+	}
+
+	typ, _ := schema["type"].(string)
+	if typ == "" {
+		if _, ok := schema["properties"]; ok {
+			typ = "object"
+		}
+	}
+	switch typ {
+	case "object":
+		obj := make(map[string]interface{})
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, p := range properties {
+			property, _ := p.(map[string]interface{})
+			obj[name] = schemaExample(doc, property, refs, depth+1)
+		}
+		return obj
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		if item := schemaExample(doc, items, refs, depth+1); item != nil {
+			return []interface{}{item}
+		}
+		return []interface{}{}
+	case "string":
+		switch schema["format"] {
+		case "date-time":
+			return "2024-01-01T00:00:00Z"
+		case "date":
+			return "2024-01-01"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "email":
+			return "user@example.com"
+		}
+		return ""
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	}
+	return nil
+}
+
+// jsonValue converts a YAML value to one encoding/json can marshal (YAML
+// maps may have non-string keys)
+func jsonValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			value[key] = jsonValue(item)
+		}
+		return value
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			obj[fmt.Sprint(key)] = jsonValue(item)
+		}
+		return obj
+	case []interface{}:
+		for i, item := range value {
+			value[i] = jsonValue(item)
+		}
+		return value
+	}
+	return v
+}
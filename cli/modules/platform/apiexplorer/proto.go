@@ -0,0 +1,206 @@
+package apiexplorer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	protoBlockComment = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	protoLineComment  = regexp.MustCompile(`//[^\n]*`)
+	protoPackage      = regexp.MustCompile(`(?m)^\s*package\s+([\w.]+)\s*;`)
+	protoBlock        = regexp.MustCompile(`\b(service|message|enum)\s+(\w+)\s*\{`)
+	protoRPC          = regexp.MustCompile(`\brpc\s+(\w+)\s*\(\s*(stream\s+)?([\w.]+)\s*\)\s*returns\s*\(\s*(stream\s+)?([\w.]+)\s*\)`)
+	protoField        = regexp.MustCompile(`^\s*(?:repeated\s+|optional\s+|required\s+)?([\w.]+)\s+(\w+)\s*=\s*\d+`)
+	protoRepeated     = regexp.MustCompile(`^\s*repeated\s`)
+	protoMapField     = regexp.MustCompile(`^\s*map\s*<\s*\w+\s*,\s*([\w.]+)\s*>\s*(\w+)\s*=\s*\d+`)
+	protoEnumValue    = regexp.MustCompile(`(?m)^\s*(\w+)\s*=\s*-?\d+`)
+	protoOneof        = regexp.MustCompile(`\boneof\s+\w+\s*\{`)
+)
+
+// protoMessageField is a field of a message, used to build example bodies
+type protoMessageField struct {
+	name     string
+	typ      string
+	repeated bool
+	isMap    bool
+}
+
+// protoFile is what the explorer needs from a .proto file
+type protoFile struct {
+	pkg      string
+	messages map[string][]protoMessageField // Key: message name (nested ones unqualified)
+	enums    map[string]string              // Key: enum name, value: its first value
+}
+
+// LoadProto reads the gRPC methods of a .proto file. The parser is
+// deliberately simple: services, RPCs and message fields declared in the
+// file are read, imported files are not.
+func LoadProto(path string) (*API, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	src := protoBlockComment.ReplaceAllString(string(data), "")
+	src = protoLineComment.ReplaceAllString(src, "")
+
+	file := &protoFile{
+		messages: make(map[string][]protoMessageField),
+		enums:    make(map[string]string),
+	}
+	if match := protoPackage.FindStringSubmatch(src); match != nil {
+		file.pkg = match[1]
+	}
+
+	type service struct{ name, body string }
+	var services []service
+	for _, loc := range protoBlock.FindAllStringSubmatchIndex(src, -1) {
+		kind, name := src[loc[2]:loc[3]], src[loc[4]:loc[5]]
+		body := protoBlockBody(src, loc[1])
+		switch kind {
+		case "service":
+			services = append(services, service{name, body})
+		case "message":
+			file.messages[name] = protoFields(body)
+		case "enum":
+			if match := protoEnumValue.FindStringSubmatch(body); match != nil {
+				file.enums[name] = match[1]
+			}
+		}
+	}
+	if len(services) == 0 {
+		return nil, fmt.Errorf("no service in %s", path)
+	}
+
+	api := &API{Descriptor: path}
+	for _, svc := range services {
+		fullService := svc.name
+		if file.pkg != "" {
+			fullService = file.pkg + "." + svc.name
+		}
+		for _, rpc := range protoRPC.FindAllStringSubmatch(svc.body, -1) {
+			summary := fmt.Sprintf("%s%s → %s%s", rpc[2], rpc[3], rpc[4], rpc[5])
+			endpoint := Endpoint{
+				Kind:    KindGRPC,
+				Method:  "RPC",
+				Path:    fullService + "/" + rpc[1],
+				Summary: summary,
+			}
+			if body, err := json.MarshalIndent(file.example(rpc[3], 0), "", "  "); err == nil {
+				endpoint.Body = string(body)
+			}
+			api.Endpoints = append(api.Endpoints, endpoint)
+		}
+	}
+	sort.Slice(api.Endpoints, func(i, j int) bool {
+		return api.Endpoints[i].Path < api.Endpoints[j].Path
+	})
+	return api, nil
+}
+
+// protoBlockBody returns the body of the block opened just before start,
+// up to its matching closing brace
+func protoBlockBody(src string, start int) string {
+	depth := 1
+	for i := start; i < len(src); i++ {
+		switch src[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return src[start:i]
+			}
+		}
+	}
+	return src[start:]
+}
+
+// protoFields reads the fields declared at the top level of a message body
+// (nested messages and enums are read as blocks of their own)
+func protoFields(body string) []protoMessageField {
+	// The fields of a oneof are fields of the message
+	for loc := protoOneof.FindStringIndex(body); loc != nil; loc = protoOneof.FindStringIndex(body) {
+		inner := protoBlockBody(body, loc[1])
+		end := loc[1] + len(inner)
+		if end < len(body) {
+			end++ // Closing brace
+		}
+		body = body[:loc[0]] + inner + body[end:]
+	}
+
+	var fields []protoMessageField
+	depth := 0
+	for _, statement := range strings.Split(body, ";") {
+		// Skip the statements inside nested blocks
+		opened := strings.Count(statement, "{")
+		closed := strings.Count(statement, "}")
+		if i := strings.LastIndexAny(statement, "{}"); i >= 0 {
+			depth += opened - closed
+			statement = statement[i+1:]
+		}
+		if depth > 0 {
+			continue
+		}
+		statement = strings.TrimSpace(statement) + ";"
+		if match := protoMapField.FindStringSubmatch(statement); match != nil {
+			fields = append(fields, protoMessageField{name: match[2], typ: match[1], isMap: true})
+			continue
+		}
+		match := protoField.FindStringSubmatch(statement)
+		if match == nil || match[1] == "option" || match[1] == "reserved" {
+			continue
+		}
+		fields = append(fields, protoMessageField{
+			name:     match[2],
+			typ:      match[1],
+			repeated: protoRepeated.MatchString(statement),
+		})
+	}
+	return fields
+}
+
+// example builds an example JSON value of a message or scalar type
+func (f *protoFile) example(typ string, depth int) interface{} {
+	switch typ {
+	case "string":
+		return ""
+	case "bytes":
+		return "" // base64
+	case "bool":
+		return false
+	case "int32", "int64", "uint32", "uint64", "sint32", "sint64",
+		"fixed32", "fixed64", "sfixed32", "sfixed64", "double", "float":
+		return 0
+	case "google.protobuf.Timestamp":
+		return "2024-01-01T00:00:00Z"
+	case "google.protobuf.Duration":
+		return "1s"
+	}
+	// Qualified names (pkg.Message, Outer.Inner) are looked up by their last part
+	name := typ[strings.LastIndex(typ, ".")+1:]
+	if value, ok := f.enums[name]; ok {
+		return value
+	}
+	fields, ok := f.messages[name]
+	if !ok || depth > maxSchemaDepth {
+		return map[string]interface{}{}
+	}
+	obj := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		value := f.example(field.typ, depth+1)
+		switch {
+		case field.isMap:
+			obj[field.name] = map[string]interface{}{}
+		case field.repeated:
+			obj[field.name] = []interface{}{value}
+		default:
+			obj[field.name] = value
+		}
+	}
+	return obj
+}
@@ -0,0 +1,183 @@
+package apiexplorer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// requestTimeout bounds a test request
+	requestTimeout = 30 * time.Second
+	// maxBodySize is the part of a response body kept for display
+	maxBodySize = 256 * 1024
+)
+
+// Service loads API descriptors and fires test requests: HTTP ones with
+// net/http, gRPC ones through grpcurl.
+type Service struct {
+	mu          sync.RWMutex
+	grpcurlPath string
+	client      *http.Client
+}
+
+// NewService creates an API explorer service
+func NewService() *Service {
+	return &Service{
+		grpcurlPath: "grpcurl",
+		client:      &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// SetGrpcurlPath sets the grpcurl executable used for gRPC requests
+func (s *Service) SetGrpcurlPath(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if path != "" {
+		s.grpcurlPath = path
+	}
+}
+
+// Load reads the endpoints of a descriptor: a .proto file, else an OpenAPI
+// (Swagger) document
+func (s *Service) Load(path string) (*API, error) {
+	if strings.EqualFold(filepath.Ext(path), ".proto") {
+		return LoadProto(path)
+	}
+	return LoadOpenAPI(path)
+}
+
+// Send fires a test request
+func (s *Service) Send(ctx context.Context, req Request) *Response {
+	if req.Kind == KindGRPC {
+		return s.sendGRPC(ctx, req)
+	}
+	return s.sendHTTP(ctx, req)
+}
+
+// sendHTTP fires an HTTP request, with the body sent as JSON
+func (s *Service) sendHTTP(ctx context.Context, req Request) *Response {
+	resp := &Response{}
+	var body io.Reader
+	if strings.TrimSpace(req.Body) != "" {
+		body = strings.NewReader(req.Body)
+	}
+	url := strings.TrimSuffix(req.Target, "/") + "/" + strings.TrimPrefix(req.Path, "/")
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, url, body)
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	start := time.Now()
+	httpResp, err := s.client.Do(httpReq)
+	if err != nil {
+		resp.Duration = time.Since(start)
+		resp.Error = err.Error()
+		return resp
+	}
+	defer httpResp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(httpResp.Body, maxBodySize+1))
+	resp.Duration = time.Since(start)
+	if err != nil {
+		resp.Error = err.Error()
+	}
+
+	resp.Status = httpResp.Status
+	resp.OK = httpResp.StatusCode >= 200 && httpResp.StatusCode < 300
+	for name, values := range httpResp.Header {
+		resp.Headers = append(resp.Headers, name+": "+strings.Join(values, ", "))
+	}
+	sort.Strings(resp.Headers)
+	resp.Body = formatBody(data)
+	return resp
+}
+
+// sendGRPC calls a gRPC method with grpcurl, describing the method with the
+// .proto file (the server needs no reflection)
+func (s *Service) sendGRPC(ctx context.Context, req Request) *Response {
+	s.mu.RLock()
+	grpcurl := s.grpcurlPath
+	s.mu.RUnlock()
+
+	resp := &Response{}
+	if _, err := exec.LookPath(grpcurl); err != nil {
+		resp.Error = "grpcurl not found (install it from the Capabilities view)"
+		return resp
+	}
+
+	args := []string{"-plaintext", "-max-time", fmt.Sprint(int(requestTimeout.Seconds()))}
+	if req.Proto != "" {
+		args = append(args, "-import-path", filepath.Dir(req.Proto), "-proto", filepath.Base(req.Proto))
+	}
+	if strings.TrimSpace(req.Body) != "" {
+		args = append(args, "-d", req.Body)
+	}
+	target := strings.TrimPrefix(strings.TrimPrefix(req.Target, "http://"), "https://")
+	args = append(args, strings.TrimSuffix(target, "/"), req.Path)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, grpcurl, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	start := time.Now()
+	err := cmd.Run()
+	resp.Duration = time.Since(start)
+
+	if err == nil {
+		resp.Status = "OK"
+		resp.OK = true
+		resp.Body = formatBody(stdout.Bytes())
+		return resp
+	}
+	if _, isExit := err.(*exec.ExitError); !isExit {
+		resp.Error = err.Error()
+		return resp
+	}
+	// grpcurl prints the status of failed calls on stderr:
+	//   ERROR:
+	//     Code: NotFound
+	//     Message: user not found
+	msg := strings.TrimSpace(stderr.String())
+	for _, line := range strings.Split(msg, "\n") {
+		if code, ok := strings.CutPrefix(strings.TrimSpace(line), "Code: "); ok {
+			resp.Status = code
+		}
+	}
+	if resp.Status == "" {
+		resp.Error = msg // Connection or descriptor error
+		return resp
+	}
+	resp.Body = msg
+	return resp
+}
+
+// formatBody pretty-prints JSON bodies and truncates large ones
+func formatBody(data []byte) string {
+	truncated := len(data) > maxBodySize
+	if truncated {
+		data = data[:maxBodySize]
+	}
+	var pretty bytes.Buffer
+	if !truncated && json.Indent(&pretty, bytes.TrimSpace(data), "", "  ") == nil {
+		return pretty.String()
+	}
+	body := string(data)
+	if truncated {
+		body += fmt.Sprintf("\n… (truncated at %d KB)", maxBodySize/1024)
+	}
+	return body
+}
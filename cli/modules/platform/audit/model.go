@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"time"
+)
+
+// Origins of actions not started from a TUI view
+const (
+	OriginCLI    = "cli"    // csd-devtrack command line
+	OriginRemote = "remote" // Event without a view (daemon or web client)
+)
+
+// Entry is a state-changing action performed by DevTrack
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"` // Event type, e.g. restart_process
+	ProjectID string    `json:"project_id,omitempty"`
+	Component string    `json:"component,omitempty"`
+	Target    string    `json:"target,omitempty"`
+	Details   string    `json:"details,omitempty"`
+	Origin    string    `json:"origin"` // View the action came from, or cli/remote
+	User      string    `json:"user,omitempty"`
+	PID       int       `json:"pid"` // DevTrack process that performed the action
+	Error     string    `json:"error,omitempty"`
+}
+
+// Filter selects entries when listing the audit log
+type Filter struct {
+	ProjectID string // Empty = all projects
+	Limit     int    // 0 = no limit
+}
+
+// Matches returns true if the entry is selected by the filter
+func (f Filter) Matches(e Entry) bool {
+	return f.ProjectID == "" || e.ProjectID == f.ProjectID
+}
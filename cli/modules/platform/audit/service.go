@@ -0,0 +1,130 @@
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/jsonl"
+)
+
+// Service appends state-changing actions to a JSON Lines file.
+// Appending keeps entries from several DevTrack processes (daemon, TUI, CLI) in one log.
+type Service struct {
+	store *jsonl.Store[Entry]
+	user  string
+}
+
+// NewService creates an audit service writing to file, keeping maxEntries entries
+func NewService(file string, maxEntries int) *Service {
+	// A long-running daemon keeps appending: trim the log now and then
+	s := &Service{store: jsonl.NewStore(file, maxEntries/10, jsonl.KeepLast[Entry](maxEntries))}
+	if u, err := user.Current(); err == nil {
+		s.user = u.Username
+	}
+	return s
+}
+
+// File returns the path of the audit log
+func (s *Service) File() string {
+	return s.store.File()
+}
+
+// Record appends an entry to the log (time, user and PID are filled in)
+func (s *Service) Record(e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.User == "" {
+		e.User = s.user
+	}
+	if e.PID == 0 {
+		e.PID = os.Getpid()
+	}
+	return s.store.Append(e)
+}
+
+// List returns the entries matching the filter, most recent first
+func (s *Service) List(filter Filter) []Entry {
+	entries := s.store.Load()
+
+	var result []Entry
+	for i := len(entries) - 1; i >= 0; i-- {
+		if !filter.Matches(entries[i]) {
+			continue
+		}
+		result = append(result, entries[i])
+		if filter.Limit > 0 && len(result) >= filter.Limit {
+			break
+		}
+	}
+	return result
+}
+
+// Export writes the entries matching the filter to path, oldest first.
+// The format follows the extension: .csv or JSON otherwise.
+func (s *Service) Export(path string, filter Filter) (int, error) {
+	entries := s.List(filter)
+	// Chronological order reads better in an exported file
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		err = WriteCSV(f, entries)
+	} else {
+		err = WriteJSON(f, entries)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// WriteJSON writes entries as an indented JSON array
+func WriteJSON(w io.Writer, entries []Entry) error {
+	if entries == nil {
+		entries = []Entry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+// WriteCSV writes entries as CSV with a header row
+func WriteCSV(w io.Writer, entries []Entry) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"time", "action", "project", "component", "target", "details", "origin", "user", "pid", "error"})
+	for _, e := range entries {
+		cw.Write([]string{
+			e.Time.Format(time.RFC3339),
+			e.Action,
+			e.ProjectID,
+			e.Component,
+			e.Target,
+			e.Details,
+			e.Origin,
+			e.User,
+			strconv.Itoa(e.PID),
+			e.Error,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
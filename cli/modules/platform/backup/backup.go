@@ -0,0 +1,269 @@
+// Package backup archives DevTrack's own data (config, cockpit profiles,
+// session names, notes, histories, trash...) into a single tarball, to move
+// to another machine or recover from a lost home directory
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules"
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/fileutil"
+)
+
+// SchemaVersion is the layout version of the archives written by Create.
+// Restore migrates older archives (see migrations) and refuses newer ones.
+const SchemaVersion = 1
+
+// ManifestName is the first entry of an archive
+const ManifestName = "manifest.json"
+
+// Archive roots, one per location of the backed-up machine
+const (
+	RootConfig = "config" // Config file (projects, settings, cockpit profiles)
+	RootHome   = "home"   // ~/.csd-devtrack: session names, Claude names and forks
+	RootData   = "data"   // Data directory: histories, notes, trash, certificates...
+)
+
+// largeDataDirs are the data subdirectories only archived with Options.Full
+var largeDataDirs = map[string]bool{
+	"recordings": true, // Terminal recordings
+	"capture":    true, // Captured HTTP traffic
+}
+
+// Manifest describes an archive
+type Manifest struct {
+	Schema     int       `json:"schema"`
+	AppVersion string    `json:"app_version"`
+	CreatedAt  time.Time `json:"created_at"`
+	Hostname   string    `json:"hostname"`
+	Home       string    `json:"home"` // Home directory of the backed-up machine
+	Full       bool      `json:"full,omitempty"`
+	Files      int       `json:"files"`
+	Size       int64     `json:"size"` // Bytes before compression
+}
+
+// Options selects what Create archives
+type Options struct {
+	Full bool // Also the recordings and captured traffic (large)
+}
+
+// Locations are where DevTrack keeps its data on a machine
+type Locations struct {
+	ConfigFile string
+	HomeDir    string // ~/.csd-devtrack
+	DataDir    string
+	BackupsDir string // Archives created from the TUI and before a restore (never archived)
+}
+
+// DefaultLocations returns the locations of the current user
+func DefaultLocations() (Locations, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return Locations{}, err
+	}
+	dataDir, err := config.GetDataDir()
+	if err != nil {
+		return Locations{}, err
+	}
+	configFile := config.GetGlobalPath()
+	if configFile == "" {
+		configFile = config.FindConfigFile()
+	}
+	return Locations{
+		ConfigFile: configFile,
+		HomeDir:    filepath.Join(home, ".csd-devtrack"),
+		DataDir:    dataDir,
+		BackupsDir: filepath.Join(dataDir, "backups"),
+	}, nil
+}
+
+// DefaultFileName returns the name of a new archive, e.g.
+// devtrack-backup-laptop-20260102-150405.tar.gz
+func DefaultFileName() string {
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "local"
+	}
+	return fmt.Sprintf("devtrack-backup-%s-%s.tar.gz", hostname, time.Now().Format("20060102-150405"))
+}
+
+// file is a file to archive
+type file struct {
+	path string // On disk
+	name string // In the archive
+	info os.FileInfo
+}
+
+// Create writes an archive of the data of loc to path. The archive holds
+// tokens and keys: it is only readable by its owner.
+func Create(path string, loc Locations, opts Options) (*Manifest, error) {
+	files, err := collect(loc, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, _ := os.Hostname()
+	home, _ := os.UserHomeDir()
+	manifest := &Manifest{
+		Schema:     SchemaVersion,
+		AppVersion: modules.AppVersion,
+		CreatedAt:  time.Now(),
+		Hostname:   hostname,
+		Home:       home,
+		Full:       opts.Full,
+		Files:      len(files),
+	}
+	for _, f := range files {
+		manifest.Size += f.info.Size()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	err = fileutil.WriteAtomic(path, 0600, func(w io.Writer) error {
+		return write(w, manifest, files)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	return manifest, nil
+}
+
+// write writes the manifest then the files, gzip compressed
+func write(w io.Writer, manifest *Manifest, files []file) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    ManifestName,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: manifest.CreatedAt,
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if err := writeFile(tw, f); err != nil {
+			return fmt.Errorf("%s: %w", f.path, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// writeFile appends a file to the archive
+func writeFile(tw *tar.Writer, f file) error {
+	in, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	header, err := tar.FileInfoHeader(f.info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = f.name
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	// The header size is the one of the walk: a file growing meanwhile
+	// (logs, histories) is cut there
+	_, err = io.CopyN(tw, in, header.Size)
+	return err
+}
+
+// collect lists the files to archive, sorted by archive name
+func collect(loc Locations, opts Options) ([]file, error) {
+	var files []file
+	if info, err := os.Stat(loc.ConfigFile); err == nil && info.Mode().IsRegular() {
+		files = append(files, file{path: loc.ConfigFile, name: RootConfig + "/" + filepath.Base(loc.ConfigFile), info: info})
+	}
+
+	// ~/.csd-devtrack without the config file (archived above), the daemon
+	// sockets, PID files and logs
+	homeFiles, err := walk(loc.HomeDir, RootHome, func(rel string, info os.FileInfo) bool {
+		if info.IsDir() {
+			return true
+		}
+		name := info.Name()
+		return filepath.Join(loc.HomeDir, rel) != loc.ConfigFile &&
+			!strings.HasSuffix(name, ".pid") && !strings.Contains(name, ".log")
+	})
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, homeFiles...)
+
+	dataFiles, err := walk(loc.DataDir, RootData, func(rel string, info os.FileInfo) bool {
+		if !info.IsDir() {
+			return true
+		}
+		if filepath.Join(loc.DataDir, rel) == loc.BackupsDir {
+			return false
+		}
+		return opts.Full || !largeDataDirs[rel]
+	})
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, dataFiles...)
+
+	sort.Slice(files, func(i, j int) bool { return files[i].name < files[j].name })
+	return files, nil
+}
+
+// walk lists the regular files of dir accepted by keep (called with the path
+// relative to dir; directories rejected are skipped). A missing dir is empty.
+func walk(dir, root string, keep func(rel string, info os.FileInfo) bool) ([]file, error) {
+	var files []file
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if !keep(rel, info) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() {
+			files = append(files, file{path: path, name: root + "/" + filepath.ToSlash(rel), info: info})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	return files, nil
+}
@@ -0,0 +1,191 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"csd-devtrack/cli/modules/platform/fileutil"
+)
+
+// entry is a file read from an archive, before it is written to disk
+type entry struct {
+	name string // Archive name (<root>/<path>)
+	mode os.FileMode
+	data []byte
+}
+
+// migrations convert the entries of an archive of schema N to schema N+1,
+// applied in turn up to SchemaVersion. A migration returning a nil entry
+// drops it.
+var migrations = map[int]func(*entry) *entry{}
+
+// relocatedExts are the files whose paths are rewritten when the archive
+// comes from another home directory (config, names, tasks...)
+var relocatedExts = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// Result summarizes a restore
+type Result struct {
+	Manifest  *Manifest
+	Files     int    // Files written
+	Migrated  bool   // Archive of an older schema
+	Relocated string // Home directory of the archive rewritten to the current one (empty if the same)
+}
+
+// Inspect returns the manifest of an archive
+func Inspect(archivePath string) (*Manifest, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tr, closeGzip, err := openArchive(f)
+	if err != nil {
+		return nil, err
+	}
+	defer closeGzip()
+	return readManifest(tr)
+}
+
+// Restore writes the files of an archive to loc, overwriting the existing
+// ones (files absent from the archive are kept). Archives of an older
+// schema are migrated; paths under the home directory of the backed-up
+// machine are rewritten to the current home directory.
+func Restore(archivePath string, loc Locations) (*Result, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tr, closeGzip, err := openArchive(f)
+	if err != nil {
+		return nil, err
+	}
+	defer closeGzip()
+	manifest, err := readManifest(tr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Manifest: manifest, Migrated: manifest.Schema < SchemaVersion}
+	home, _ := os.UserHomeDir()
+	if manifest.Home != "" && home != "" && manifest.Home != home {
+		result.Relocated = manifest.Home
+	}
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to read backup: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return result, fmt.Errorf("failed to read backup: %w", err)
+		}
+
+		e := &entry{name: header.Name, mode: os.FileMode(header.Mode).Perm(), data: data}
+		for schema := manifest.Schema; schema < SchemaVersion && e != nil; schema++ {
+			if migrate := migrations[schema]; migrate != nil {
+				e = migrate(e)
+			}
+		}
+		if e == nil {
+			continue
+		}
+		if result.Relocated != "" && relocatedExts[path.Ext(e.name)] {
+			e.data = relocate(e.data, manifest.Home, home)
+		}
+
+		target, err := loc.target(e.name)
+		if err != nil {
+			return result, err
+		}
+		if err := writeAtomic(target, e.data, e.mode); err != nil {
+			return result, fmt.Errorf("failed to restore %s: %w", target, err)
+		}
+		os.Chtimes(target, header.ModTime, header.ModTime)
+		result.Files++
+	}
+	return result, nil
+}
+
+// openArchive returns the tar reader of a gzip compressed archive
+func openArchive(r io.Reader) (*tar.Reader, func(), error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a DevTrack backup: %w", err)
+	}
+	return tar.NewReader(gz), func() { gz.Close() }, nil
+}
+
+// readManifest reads the first entry of an archive and checks its schema
+func readManifest(tr *tar.Reader) (*Manifest, error) {
+	header, err := tr.Next()
+	if err != nil || header.Name != ManifestName {
+		return nil, fmt.Errorf("not a DevTrack backup: %s missing", ManifestName)
+	}
+	var manifest Manifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid backup manifest: %w", err)
+	}
+	if manifest.Schema < 1 {
+		return nil, fmt.Errorf("invalid backup manifest: schema %d", manifest.Schema)
+	}
+	if manifest.Schema > SchemaVersion {
+		return nil, fmt.Errorf("backup created by a newer DevTrack (%s, schema %d): update before restoring it", manifest.AppVersion, manifest.Schema)
+	}
+	return &manifest, nil
+}
+
+// target returns where an archive entry is restored, refusing names
+// escaping their root
+func (loc Locations) target(name string) (string, error) {
+	root, rel, ok := strings.Cut(name, "/")
+	if !ok || !filepath.IsLocal(filepath.FromSlash(rel)) {
+		return "", fmt.Errorf("invalid backup entry: %s", name)
+	}
+	switch root {
+	case RootConfig:
+		return loc.ConfigFile, nil
+	case RootHome:
+		return filepath.Join(loc.HomeDir, filepath.FromSlash(rel)), nil
+	case RootData:
+		return filepath.Join(loc.DataDir, filepath.FromSlash(rel)), nil
+	}
+	return "", fmt.Errorf("invalid backup entry: %s", name)
+}
+
+// relocate rewrites the paths under the old home directory
+func relocate(data []byte, oldHome, newHome string) []byte {
+	for _, sep := range []string{"/", `\`, `\\`} {
+		data = bytes.ReplaceAll(data, []byte(oldHome+sep), []byte(newHome+sep))
+	}
+	return data
+}
+
+// writeAtomic writes a file through a temporary file, so an interrupted
+// restore does not leave a truncated file
+func writeAtomic(target string, data []byte, mode os.FileMode) error {
+	if mode == 0 {
+		mode = 0600
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(target, data, mode)
+}
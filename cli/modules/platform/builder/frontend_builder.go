@@ -99,6 +99,7 @@ func (b *FrontendBuilder) Build(ctx context.Context, project *projects.Project,
 	// First, check if node_modules exists, if not run npm install
 	nodeModulesPath := filepath.Join(workDir, "node_modules")
 	if _, err := os.Stat(nodeModulesPath); os.IsNotExist(err) {
+		build.StartStep("npm install")
 		build.AddOutput("Installing dependencies (npm install)...")
 		if err := b.runNpmCommand(ctx, workDir, []string{"install"}, build); err != nil {
 			return fmt.Errorf("npm install failed: %w", err)
@@ -106,6 +107,7 @@ func (b *FrontendBuilder) Build(ctx context.Context, project *projects.Project,
 	}
 
 	// Run build
+	build.StartStep("npm run " + buildScript)
 	build.AddOutput(fmt.Sprintf("Running build script: %s", buildScript))
 	if err := b.runNpmCommand(ctx, workDir, []string{"run", buildScript}, build); err != nil {
 		return fmt.Errorf("build failed: %w", err)
@@ -122,6 +124,33 @@ func (b *FrontendBuilder) Build(ctx context.Context, project *projects.Project,
 	return nil
 }
 
+// Plan returns the commands Build would run, without running them
+func (b *FrontendBuilder) Plan(project *projects.Project, component *projects.Component) []builds.PlannedCommand {
+	workDir := filepath.Join(project.Path, component.Path)
+
+	warning := ""
+	if _, err := os.Stat(workDir); os.IsNotExist(err) {
+		warning = fmt.Sprintf("frontend directory not found: %s", workDir)
+	} else if _, err := os.Stat(filepath.Join(workDir, "package.json")); os.IsNotExist(err) {
+		warning = fmt.Sprintf("package.json not found in: %s", workDir)
+	}
+
+	if component.BuildCmd != "" {
+		command := planCustomBuildCommand(workDir, component.BuildCmd)
+		command.Warning = warning
+		return []builds.PlannedCommand{command}
+	}
+
+	var plan []builds.PlannedCommand
+	if _, err := os.Stat(filepath.Join(workDir, "node_modules")); os.IsNotExist(err) {
+		plan = append(plan, builds.PlannedCommand{Step: "npm install", Command: b.npmPath, Args: []string{"install"}, Dir: workDir})
+	}
+	buildScript := b.detectBuildScript(workDir)
+	plan = append(plan, builds.PlannedCommand{Step: "npm run " + buildScript, Command: b.npmPath, Args: []string{"run", buildScript}, Dir: workDir})
+	plan[0].Warning = warning
+	return plan
+}
+
 // detectBuildScript detects the appropriate build script
 func (b *FrontendBuilder) detectBuildScript(workDir string) string {
 	packageJSONPath := filepath.Join(workDir, "package.json")
@@ -218,6 +247,7 @@ func (b *FrontendBuilder) runNpmCommand(ctx context.Context, workDir string, arg
 
 // runCustomBuildCommand runs a custom build command
 func (b *FrontendBuilder) runCustomBuildCommand(ctx context.Context, workDir, buildCmd string, build *builds.Build) error {
+	build.StartStep(buildCmd)
 	build.AddOutput(fmt.Sprintf("Running custom build command: %s", buildCmd))
 
 	var cmd *exec.Cmd
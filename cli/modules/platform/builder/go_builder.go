@@ -56,50 +56,18 @@ func findGo() string {
 
 // Build builds a Go component
 func (b *GoBuilder) Build(ctx context.Context, project *projects.Project, component *projects.Component, build *builds.Build) error {
-	// Determine working directory
-	workDir := filepath.Join(project.Path, component.Path)
-	if component.Path == "" {
-		workDir = project.Path
-	}
+	workDir, outputPath, args := b.buildArgs(project, component)
 
 	// Check if workDir exists
 	if _, err := os.Stat(workDir); os.IsNotExist(err) {
 		return fmt.Errorf("component directory not found: %s", workDir)
 	}
 
-	// Determine output binary path
-	outputDir := filepath.Join(project.Path, "targets")
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
+	// Create output directory
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	binaryName := component.Binary
-	if binaryName == "" {
-		binaryName = strings.TrimSuffix(component.EntryPoint, ".go")
-	}
-
-	// Add .exe on Windows
-	if runtime.GOOS == "windows" {
-		binaryName += ".exe"
-	}
-
-	outputPath := filepath.Join(outputDir, binaryName)
-
-	// Build command
-	args := []string{"build"}
-
-	if b.ldflags != "" {
-		args = append(args, fmt.Sprintf("-ldflags=%s", b.ldflags))
-	}
-
-	args = append(args, "-o", outputPath)
-
-	if component.EntryPoint != "" {
-		args = append(args, "./"+component.EntryPoint)
-	} else {
-		args = append(args, ".")
-	}
-
 	// Use custom build command if specified
 	if component.BuildCmd != "" {
 		return b.runCustomBuildCommand(ctx, workDir, component.BuildCmd, build)
@@ -110,11 +78,10 @@ func (b *GoBuilder) Build(ctx context.Context, project *projects.Project, compon
 	build.AddOutput(fmt.Sprintf("Working directory: %s", workDir))
 
 	// Create command
+	build.StartStep("go build")
 	cmd := exec.CommandContext(ctx, b.goPath, args...)
 	cmd.Dir = workDir
-	cmd.Env = append(os.Environ(),
-		"CGO_ENABLED=0", // Disable CGO for static builds
-	)
+	cmd.Env = append(os.Environ(), goBuildEnv...)
 
 	// Capture output
 	stdout, err := cmd.StdoutPipe()
@@ -171,8 +138,68 @@ func (b *GoBuilder) Build(ctx context.Context, project *projects.Project, compon
 	return nil
 }
 
+// goBuildEnv is the environment of go build on top of DevTrack's
+var goBuildEnv = []string{
+	"CGO_ENABLED=0", // Disable CGO for static builds
+}
+
+// buildArgs returns the working directory, output binary and go build
+// arguments of a component
+func (b *GoBuilder) buildArgs(project *projects.Project, component *projects.Component) (string, string, []string) {
+	// Determine working directory
+	workDir := filepath.Join(project.Path, component.Path)
+	if component.Path == "" {
+		workDir = project.Path
+	}
+
+	// Determine output binary path
+	binaryName := component.Binary
+	if binaryName == "" {
+		binaryName = strings.TrimSuffix(component.EntryPoint, ".go")
+	}
+
+	// Add .exe on Windows
+	if runtime.GOOS == "windows" {
+		binaryName += ".exe"
+	}
+
+	outputPath := filepath.Join(project.Path, "targets", binaryName)
+
+	// Build command
+	args := []string{"build"}
+
+	if b.ldflags != "" {
+		args = append(args, fmt.Sprintf("-ldflags=%s", b.ldflags))
+	}
+
+	args = append(args, "-o", outputPath)
+
+	if component.EntryPoint != "" {
+		args = append(args, "./"+component.EntryPoint)
+	} else {
+		args = append(args, ".")
+	}
+
+	return workDir, outputPath, args
+}
+
+// Plan returns the commands Build would run, without running them
+func (b *GoBuilder) Plan(project *projects.Project, component *projects.Component) []builds.PlannedCommand {
+	workDir, _, args := b.buildArgs(project, component)
+
+	command := builds.PlannedCommand{Step: "go build", Command: b.goPath, Args: args, Dir: workDir, Env: goBuildEnv}
+	if component.BuildCmd != "" {
+		command = planCustomBuildCommand(workDir, component.BuildCmd)
+	}
+	if _, err := os.Stat(workDir); os.IsNotExist(err) {
+		command.Warning = fmt.Sprintf("component directory not found: %s", workDir)
+	}
+	return []builds.PlannedCommand{command}
+}
+
 // runCustomBuildCommand runs a custom build command
 func (b *GoBuilder) runCustomBuildCommand(ctx context.Context, workDir, buildCmd string, build *builds.Build) error {
+	build.StartStep(buildCmd)
 	build.AddOutput(fmt.Sprintf("Running custom build command: %s", buildCmd))
 
 	var cmd *exec.Cmd
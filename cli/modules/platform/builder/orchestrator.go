@@ -59,6 +59,11 @@ func (o *Orchestrator) BuildComponent(ctx context.Context, projectID string, com
 	return o.buildService.BuildComponent(ctx, projectID, component)
 }
 
+// BuildComponentWithCommand builds a component with a one-off build command
+func (o *Orchestrator) BuildComponentWithCommand(ctx context.Context, projectID string, component projects.ComponentType, buildCmd string) *builds.BuildResult {
+	return o.buildService.BuildComponentWithCommand(ctx, projectID, component, buildCmd)
+}
+
 // BuildAll builds all projects
 func (o *Orchestrator) BuildAll(ctx context.Context) (map[string][]*builds.BuildResult, error) {
 	return o.buildService.BuildAll(ctx)
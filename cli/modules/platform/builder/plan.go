@@ -0,0 +1,29 @@
+package builder
+
+import (
+	"runtime"
+
+	"csd-devtrack/cli/modules/core/builds"
+	"csd-devtrack/cli/modules/core/projects"
+)
+
+// PlanComponent returns the commands a build of a component would run,
+// without running them (dry run)
+func (o *Orchestrator) PlanComponent(projectID string, component projects.ComponentType) ([]builds.PlannedCommand, error) {
+	return o.buildService.PlanComponent(projectID, component)
+}
+
+// PlanProject returns the commands a build of a project would run
+func (o *Orchestrator) PlanProject(projectID string) ([]builds.PlannedCommand, error) {
+	return o.buildService.PlanProject(projectID)
+}
+
+// planCustomBuildCommand returns how runCustomBuildCommand runs a custom
+// build command
+func planCustomBuildCommand(workDir, buildCmd string) builds.PlannedCommand {
+	command := builds.PlannedCommand{Step: buildCmd, Command: "sh", Args: []string{"-c", buildCmd}, Dir: workDir}
+	if runtime.GOOS == "windows" {
+		command.Command, command.Args = "cmd", []string{"/c", buildCmd}
+	}
+	return command
+}
@@ -0,0 +1,78 @@
+package buildhistory
+
+import (
+	"time"
+)
+
+// DefaultMaxRecords is the number of builds kept in the history file
+const DefaultMaxRecords = 2000
+
+// Step is a timed step of a build (npm install, go build...)
+type Step struct {
+	Name       string `json:"name"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// Record is a finished build
+type Record struct {
+	Time       time.Time `json:"time"` // Build start
+	BuildID    string    `json:"build_id"`
+	ProjectID  string    `json:"project_id"`
+	Component  string    `json:"component"`
+	Profile    string    `json:"profile,omitempty"`
+	Status     string    `json:"status"` // success, failed
+	DurationMs int64     `json:"duration_ms"`
+	ExitCode   int       `json:"exit_code"`
+	GitHash    string    `json:"git_hash,omitempty"` // Short HEAD hash when built
+	Steps      []Step    `json:"steps,omitempty"`
+}
+
+// Duration returns the build duration
+func (r Record) Duration() time.Duration {
+	return time.Duration(r.DurationMs) * time.Millisecond
+}
+
+// Success returns true if the build succeeded
+func (r Record) Success() bool {
+	return r.Status == "success"
+}
+
+// ComponentStats aggregates the builds of one component of a project
+type ComponentStats struct {
+	ProjectID  string
+	Component  string
+	Builds     int
+	Failures   int
+	AvgMs      int64   // Average duration of successful builds
+	LastMs     int64   // Duration of the last successful build
+	Trend      []int64 // Durations of the latest successful builds, oldest first
+	Regression float64 // Last successful build vs the median of the previous ones (0.3 = 30% slower)
+	LastBuild  time.Time
+}
+
+// FailureRate returns the share of failed builds (0-1)
+func (c ComponentStats) FailureRate() float64 {
+	if c.Builds == 0 {
+		return 0
+	}
+	return float64(c.Failures) / float64(c.Builds)
+}
+
+// StepStats aggregates the runs of one build step
+type StepStats struct {
+	ProjectID string
+	Component string
+	Name      string
+	Runs      int
+	AvgMs     int64
+	MaxMs     int64
+}
+
+// Analytics summarizes the build history
+type Analytics struct {
+	Builds       int
+	Failures     int
+	Since        time.Time // Oldest build analyzed
+	Components   []ComponentStats
+	SlowestSteps []StepStats
+}
@@ -0,0 +1,154 @@
+package buildhistory
+
+import (
+	"sort"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/jsonl"
+)
+
+// Analytics limits
+const (
+	trendSize        = 20 // Successful builds shown in a duration trend
+	regressionWindow = 10 // Previous successful builds the last one is compared to
+	slowestSteps     = 10 // Steps listed in the analytics
+)
+
+// Service appends finished builds to a JSON Lines file.
+// Appending keeps builds from several DevTrack processes in one history.
+type Service struct {
+	store *jsonl.Store[Record]
+}
+
+// NewService creates a build history writing to file, keeping maxRecords builds
+func NewService(file string, maxRecords int) *Service {
+	return &Service{store: jsonl.NewStore(file, maxRecords/10, jsonl.KeepLast[Record](maxRecords))}
+}
+
+// File returns the path of the history file
+func (s *Service) File() string {
+	return s.store.File()
+}
+
+// Record appends a finished build to the history
+func (s *Service) Record(r Record) error {
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+	return s.store.Append(r)
+}
+
+// List returns the recorded builds, most recent first (limit 0 = all)
+func (s *Service) List(limit int) []Record {
+	records := s.store.Load()
+
+	var result []Record
+	for i := len(records) - 1; i >= 0; i-- {
+		result = append(result, records[i])
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result
+}
+
+// Analyze summarizes the whole history
+func (s *Service) Analyze() *Analytics {
+	return Analyze(s.store.Load())
+}
+
+// Analyze summarizes builds given oldest first: failure rate and duration
+// trend per component, slowest steps
+func Analyze(records []Record) *Analytics {
+	a := &Analytics{}
+	components := make(map[string]*ComponentStats)
+	successes := make(map[string][]int64)
+	steps := make(map[string]*StepStats)
+	stepTotals := make(map[string]int64)
+
+	for _, r := range records {
+		a.Builds++
+		if a.Since.IsZero() || r.Time.Before(a.Since) {
+			a.Since = r.Time
+		}
+		key := r.ProjectID + "/" + r.Component
+		c := components[key]
+		if c == nil {
+			c = &ComponentStats{ProjectID: r.ProjectID, Component: r.Component}
+			components[key] = c
+		}
+		c.Builds++
+		c.LastBuild = r.Time
+		if !r.Success() {
+			a.Failures++
+			c.Failures++
+			continue
+		}
+		successes[key] = append(successes[key], r.DurationMs)
+
+		for _, step := range r.Steps {
+			stepKey := key + "/" + step.Name
+			st := steps[stepKey]
+			if st == nil {
+				st = &StepStats{ProjectID: r.ProjectID, Component: r.Component, Name: step.Name}
+				steps[stepKey] = st
+			}
+			st.Runs++
+			stepTotals[stepKey] += step.DurationMs
+			st.MaxMs = max(st.MaxMs, step.DurationMs)
+		}
+	}
+
+	for key, c := range components {
+		durations := successes[key]
+		if len(durations) == 0 {
+			a.Components = append(a.Components, *c)
+			continue
+		}
+		var total int64
+		for _, d := range durations {
+			total += d
+		}
+		c.AvgMs = total / int64(len(durations))
+		c.LastMs = durations[len(durations)-1]
+		c.Trend = durations[max(len(durations)-trendSize, 0):]
+
+		// The last build against the median of the ones before it
+		previous := durations[max(len(durations)-1-regressionWindow, 0) : len(durations)-1]
+		if len(previous) >= 3 {
+			if median := medianMs(previous); median > 0 {
+				c.Regression = float64(c.LastMs-median) / float64(median)
+			}
+		}
+		a.Components = append(a.Components, *c)
+	}
+	sort.Slice(a.Components, func(i, j int) bool {
+		if a.Components[i].ProjectID != a.Components[j].ProjectID {
+			return a.Components[i].ProjectID < a.Components[j].ProjectID
+		}
+		return a.Components[i].Component < a.Components[j].Component
+	})
+
+	for key, st := range steps {
+		st.AvgMs = stepTotals[key] / int64(st.Runs)
+		a.SlowestSteps = append(a.SlowestSteps, *st)
+	}
+	sort.Slice(a.SlowestSteps, func(i, j int) bool {
+		return a.SlowestSteps[i].AvgMs > a.SlowestSteps[j].AvgMs
+	})
+	if len(a.SlowestSteps) > slowestSteps {
+		a.SlowestSteps = a.SlowestSteps[:slowestSteps]
+	}
+	return a
+}
+
+// medianMs returns the median of durations
+func medianMs(durations []int64) int64 {
+	sorted := append([]int64(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
@@ -9,13 +9,13 @@ import (
 	"time"
 )
 
-// detect checks if a capability is available (auto-detection only)
-func detect(cap Capability) *CapabilityInfo {
-	return detectWithConfig(cap, "")
-}
-
 // detectWithConfig checks if a capability is available, using configured path if provided
 func detectWithConfig(cap Capability, configuredPath string) *CapabilityInfo {
+	// PTY is an OS feature, not a binary
+	if cap == CapPTY {
+		return detectPTY()
+	}
+
 	config, ok := capabilityConfigs[cap]
 	if !ok {
 		return &CapabilityInfo{
@@ -81,7 +81,7 @@ func detectWithConfig(cap Capability, configuredPath string) *CapabilityInfo {
 // resolveConfiguredPath resolves a configured path (can be just a name like "zsh" or full path "/usr/bin/zsh")
 func resolveConfiguredPath(configured string) string {
 	// If it's an absolute path, return as-is
-	if strings.HasPrefix(configured, "/") || strings.HasPrefix(configured, "C:") || strings.HasPrefix(configured, "c:") {
+	if strings.HasPrefix(configured, "/") || filepath.IsAbs(configured) {
 		return configured
 	}
 	// Otherwise, try to find it in PATH
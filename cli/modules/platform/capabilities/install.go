@@ -0,0 +1,107 @@
+package capabilities
+
+import "runtime"
+
+// packageManager is a system package manager and its install command
+type packageManager struct {
+	binary  string
+	install string
+}
+
+// packageManagers are tried in order, the first one found is suggested
+var packageManagers = []packageManager{
+	{"apt-get", "sudo apt-get install -y"},
+	{"dnf", "sudo dnf install -y"},
+	{"pacman", "sudo pacman -S --noconfirm"},
+	{"zypper", "sudo zypper install -y"},
+	{"apk", "sudo apk add"},
+	{"brew", "brew install"},
+	{"winget", "winget install -e --id"},
+}
+
+// systemPackages are the packages providing a tool, per package manager
+// (a missing manager means the tool is not packaged there)
+var systemPackages = map[Capability]map[string]string{
+	CapTmux: {
+		"apt-get": "tmux", "dnf": "tmux", "pacman": "tmux", "zypper": "tmux", "apk": "tmux", "brew": "tmux",
+	},
+	CapSudo: {
+		"apt-get": "sudo", "dnf": "sudo", "pacman": "sudo", "zypper": "sudo", "apk": "sudo",
+	},
+	CapSSH: {
+		"apt-get": "openssh-client", "dnf": "openssh-clients", "pacman": "openssh", "zypper": "openssh-clients",
+		"apk": "openssh-client", "brew": "openssh", "winget": "Microsoft.OpenSSH.Beta",
+	},
+	CapSCP: {
+		"apt-get": "openssh-client", "dnf": "openssh-clients", "pacman": "openssh", "zypper": "openssh-clients",
+		"apk": "openssh-client", "brew": "openssh", "winget": "Microsoft.OpenSSH.Beta",
+	},
+	CapRsync: {
+		"apt-get": "rsync", "dnf": "rsync", "pacman": "rsync", "zypper": "rsync", "apk": "rsync", "brew": "rsync",
+	},
+	CapPsql: {
+		"apt-get": "postgresql-client", "dnf": "postgresql", "pacman": "postgresql", "zypper": "postgresql",
+		"apk": "postgresql-client", "brew": "libpq", "winget": "PostgreSQL.PostgreSQL",
+	},
+	CapMysql: {
+		"apt-get": "default-mysql-client", "dnf": "mysql", "pacman": "mariadb-clients", "zypper": "mariadb-client",
+		"apk": "mariadb-client", "brew": "mysql-client", "winget": "Oracle.MySQL",
+	},
+	CapSqlite: {
+		"apt-get": "sqlite3", "dnf": "sqlite", "pacman": "sqlite", "zypper": "sqlite3", "apk": "sqlite",
+		"brew": "sqlite", "winget": "SQLite.SQLite",
+	},
+	CapGit: {
+		"apt-get": "git", "dnf": "git", "pacman": "git", "zypper": "git", "apk": "git", "brew": "git",
+		"winget": "Git.Git",
+	},
+	CapGo: {
+		"apt-get": "golang-go", "dnf": "golang", "pacman": "go", "zypper": "go", "apk": "go", "brew": "go",
+		"winget": "GoLang.Go",
+	},
+	CapNode: {
+		"apt-get": "nodejs", "dnf": "nodejs", "pacman": "nodejs", "zypper": "nodejs", "apk": "nodejs",
+		"brew": "node", "winget": "OpenJS.NodeJS",
+	},
+	CapNpm: {
+		"apt-get": "npm", "dnf": "npm", "pacman": "npm", "zypper": "npm", "apk": "npm", "brew": "node",
+		"winget": "OpenJS.NodeJS",
+	},
+	CapDocker: {
+		"apt-get": "docker.io", "dnf": "podman", "pacman": "docker", "zypper": "docker", "apk": "docker",
+		"brew": "docker", "winget": "Docker.DockerDesktop",
+	},
+}
+
+// toolInstallCommands install tools distributed outside system packages
+var toolInstallCommands = map[Capability]string{
+	CapClaude:      "npm install -g @anthropic-ai/claude-code",
+	CapCodex:       "npm install -g @openai/codex",
+	CapGovulncheck: "go install golang.org/x/vuln/cmd/govulncheck@latest",
+	CapGrpcurl:     "go install github.com/fullstorydev/grpcurl/cmd/grpcurl@latest",
+}
+
+// InstallCommand returns a command installing a tool on this system, empty
+// if there is no known way (the PTY is part of the OS, shells are always
+// present)
+func InstallCommand(cap Capability) string {
+	if command, ok := toolInstallCommands[cap]; ok {
+		return command
+	}
+
+	packages, ok := systemPackages[cap]
+	if !ok {
+		return ""
+	}
+	for _, manager := range packageManagers {
+		if runtime.GOOS == "windows" && manager.binary != "winget" {
+			continue
+		}
+		pkg, ok := packages[manager.binary]
+		if !ok || findBinary(manager.binary) == "" {
+			continue
+		}
+		return manager.install + " " + pkg
+	}
+	return ""
+}
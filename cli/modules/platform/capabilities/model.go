@@ -8,10 +8,14 @@ type Capability string
 // Available capabilities
 const (
 	CapTmux   Capability = "tmux"   // Terminal multiplexer (for Claude/Database views)
+	CapPTY    Capability = "pty"    // Native pseudo terminal (Unix PTY or Windows ConPTY)
 	CapClaude Capability = "claude" // Claude CLI
 	CapCodex  Capability = "codex"  // OpenAI Codex CLI
 	CapShell  Capability = "shell"  // Bash/sh shell
 	CapSudo   Capability = "sudo"   // Sudo for root access
+	CapSSH    Capability = "ssh"    // OpenSSH client (remote shells)
+	CapRsync  Capability = "rsync"  // File transfers to remote hosts (preferred)
+	CapSCP    Capability = "scp"    // File transfers to remote hosts (fallback)
 	CapPsql   Capability = "psql"   // PostgreSQL client
 	CapMysql  Capability = "mysql"  // MySQL client
 	CapSqlite Capability = "sqlite" // SQLite client
@@ -19,15 +23,23 @@ const (
 	CapGo     Capability = "go"     // Go compiler
 	CapNode   Capability = "node"   // Node.js runtime
 	CapNpm    Capability = "npm"    // Node package manager
+
+	CapGovulncheck Capability = "govulncheck" // Go vulnerability scanner
+	CapGrpcurl     Capability = "grpcurl"     // gRPC client (API explorer)
+	CapDocker      Capability = "docker"      // Container engine: Docker, else Podman
 )
 
 // AllCapabilities lists all capabilities to detect
 var AllCapabilities = []Capability{
 	CapTmux,
+	CapPTY,
 	CapClaude,
 	CapCodex,
 	CapShell,
 	CapSudo,
+	CapSSH,
+	CapRsync,
+	CapSCP,
 	CapPsql,
 	CapMysql,
 	CapSqlite,
@@ -35,6 +47,9 @@ var AllCapabilities = []Capability{
 	CapGo,
 	CapNode,
 	CapNpm,
+	CapGovulncheck,
+	CapGrpcurl,
+	CapDocker,
 }
 
 // CapabilityInfo holds information about a detected capability
@@ -88,6 +103,24 @@ var capabilityConfigs = map[Capability]capabilityConfig{
 		versionArg: "",
 		verify:     false, // Don't verify - just check existence
 	},
+	CapSSH: {
+		name:       CapSSH,
+		binaries:   []string{"ssh"},
+		versionArg: "",
+		verify:     false, // ssh -V prints to stderr - just check existence
+	},
+	CapRsync: {
+		name:       CapRsync,
+		binaries:   []string{"rsync"},
+		versionArg: "--version",
+		verify:     true,
+	},
+	CapSCP: {
+		name:       CapSCP,
+		binaries:   []string{"scp"},
+		versionArg: "",
+		verify:     false, // scp has no version flag - just check existence
+	},
 	CapPsql: {
 		name:       CapPsql,
 		binaries:   []string{"psql"},
@@ -130,4 +163,22 @@ var capabilityConfigs = map[Capability]capabilityConfig{
 		versionArg: "--version",
 		verify:     true,
 	},
+	CapGovulncheck: {
+		name:       CapGovulncheck,
+		binaries:   []string{"govulncheck"},
+		versionArg: "-version",
+		verify:     true,
+	},
+	CapGrpcurl: {
+		name:       CapGrpcurl,
+		binaries:   []string{"grpcurl"},
+		versionArg: "-version",
+		verify:     true,
+	},
+	CapDocker: {
+		name:       CapDocker,
+		binaries:   []string{"docker", "podman"},
+		versionArg: "--version",
+		verify:     true,
+	},
 }
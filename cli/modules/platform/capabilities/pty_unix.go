@@ -0,0 +1,21 @@
+// +build !windows
+
+package capabilities
+
+import (
+	"os"
+	"time"
+)
+
+// detectPTY checks if Unix pseudo terminals can be allocated
+func detectPTY() *CapabilityInfo {
+	info := &CapabilityInfo{
+		Name:      CapPTY,
+		CheckedAt: time.Now(),
+	}
+	if _, err := os.Stat("/dev/ptmx"); err == nil {
+		info.Available = true
+		info.Path = "/dev/ptmx"
+	}
+	return info
+}
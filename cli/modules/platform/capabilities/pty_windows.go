@@ -0,0 +1,23 @@
+// +build windows
+
+package capabilities
+
+import (
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// detectPTY checks if the pseudo console API (ConPTY) is available (Windows 10 1809+)
+func detectPTY() *CapabilityInfo {
+	info := &CapabilityInfo{
+		Name:      CapPTY,
+		CheckedAt: time.Now(),
+	}
+	kernel32 := windows.NewLazySystemDLL("kernel32.dll")
+	if kernel32.NewProc("CreatePseudoConsole").Find() == nil {
+		info.Available = true
+		info.Path = "kernel32.dll"
+	}
+	return info
+}
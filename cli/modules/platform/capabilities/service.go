@@ -20,6 +20,13 @@ type ConfiguredPaths struct {
 	Npm    string
 	Tmux   string
 	Sudo   string
+	SSH    string
+	Rsync  string
+	SCP    string
+
+	Govulncheck string
+	Grpcurl     string
+	Docker      string
 }
 
 // Service manages capability detection and caching
@@ -78,6 +85,18 @@ func (s *Service) getConfiguredPath(cap Capability) string {
 		return s.configuredPaths.Tmux
 	case CapSudo:
 		return s.configuredPaths.Sudo
+	case CapSSH:
+		return s.configuredPaths.SSH
+	case CapRsync:
+		return s.configuredPaths.Rsync
+	case CapSCP:
+		return s.configuredPaths.SCP
+	case CapGovulncheck:
+		return s.configuredPaths.Govulncheck
+	case CapGrpcurl:
+		return s.configuredPaths.Grpcurl
+	case CapDocker:
+		return s.configuredPaths.Docker
 	default:
 		return ""
 	}
@@ -159,7 +178,7 @@ func (s *Service) RefreshOne(cap Capability) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.capabilities[cap] = detect(cap)
+	s.capabilities[cap] = detectWithConfig(cap, s.getConfiguredPath(cap))
 }
 
 // GetMissing returns a list of capabilities that are not available
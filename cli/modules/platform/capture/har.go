@@ -0,0 +1,169 @@
+package capture
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// HAR 1.2 (HTTP Archive), read by the browser devtools and most HTTP tools
+type harLog struct {
+	Log struct {
+		Version string     `json:"version"`
+		Creator harCreator `json:"creator"`
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"` // Milliseconds
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"` // Source component and error
+}
+
+type harRequest struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	HTTPVersion string      `json:"httpVersion"`
+	Cookies     []harPair   `json:"cookies"`
+	Headers     []harPair   `json:"headers"`
+	QueryString []harPair   `json:"queryString"`
+	PostData    *harContent `json:"postData,omitempty"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int64       `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int        `json:"status"`
+	StatusText  string     `json:"statusText"`
+	HTTPVersion string     `json:"httpVersion"`
+	Cookies     []harPair  `json:"cookies"`
+	Headers     []harPair  `json:"headers"`
+	Content     harContent `json:"content"`
+	RedirectURL string     `json:"redirectURL"`
+	HeadersSize int        `json:"headersSize"`
+	BodySize    int64      `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"` // base64 for binary bodies
+}
+
+type harPair struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// WriteHAR exports requests to a HAR file, opened by the browser devtools
+func WriteHAR(path string, entries []Entry, version string) error {
+	var har harLog
+	har.Log.Version = "1.2"
+	har.Log.Creator = harCreator{Name: "csd-devtrack", Version: version}
+	har.Log.Entries = make([]harEntry, 0, len(entries))
+	for _, e := range entries {
+		har.Log.Entries = append(har.Log.Entries, toHAR(e))
+	}
+
+	data, err := json.MarshalIndent(har, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600) // Headers may hold credentials
+}
+
+// toHAR converts a recorded request to a HAR entry
+func toHAR(e Entry) harEntry {
+	ms := float64(e.Duration) / float64(time.Millisecond)
+	entry := harEntry{
+		StartedDateTime: e.Started.Format(time.RFC3339Nano),
+		Time:            ms,
+		Request: harRequest{
+			Method:      e.Method,
+			URL:         e.URL,
+			HTTPVersion: e.Protocol,
+			Cookies:     []harPair{},
+			Headers:     harHeaders(e.RequestHeaders),
+			QueryString: []harPair{},
+			HeadersSize: -1,
+			BodySize:    e.RequestSize,
+		},
+		Response: harResponse{
+			Status:      e.Status,
+			StatusText:  http.StatusText(e.Status),
+			HTTPVersion: e.Protocol,
+			Cookies:     []harPair{},
+			Headers:     harHeaders(e.ResponseHeaders),
+			Content:     harBody(e.ResponseBody, e.ResponseSize, e.ResponseHeaders),
+			RedirectURL: e.ResponseHeaders.Get("Location"),
+			HeadersSize: -1,
+			BodySize:    e.ResponseSize,
+		},
+		Timings: harTimings{Wait: ms},
+		Comment: e.Source,
+	}
+	if e.Error != "" {
+		if entry.Comment != "" {
+			entry.Comment += ": "
+		}
+		entry.Comment += e.Error
+	}
+	if u, err := url.Parse(e.URL); err == nil {
+		for name, values := range u.Query() {
+			for _, value := range values {
+				entry.Request.QueryString = append(entry.Request.QueryString, harPair{name, value})
+			}
+		}
+	}
+	if e.RequestSize > 0 {
+		body := harBody(e.RequestBody, e.RequestSize, e.RequestHeaders)
+		entry.Request.PostData = &body
+	}
+	return entry
+}
+
+// harHeaders converts headers to sorted HAR pairs
+func harHeaders(header http.Header) []harPair {
+	pairs := []harPair{}
+	for name, values := range header {
+		for _, value := range values {
+			pairs = append(pairs, harPair{name, value})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Name < pairs[j].Name })
+	return pairs
+}
+
+// harBody converts a body to HAR content, base64 when it is not text
+func harBody(body []byte, size int64, header http.Header) harContent {
+	content := harContent{Size: size, MimeType: header.Get("Content-Type")}
+	if utf8.Valid(body) {
+		content.Text = string(body)
+	} else {
+		content.Text = base64.StdEncoding.EncodeToString(body)
+		content.Encoding = "base64"
+	}
+	return content
+}
@@ -0,0 +1,93 @@
+package capture
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxBody is the part of a request or response body kept, larger bodies are
+// truncated (their full size is still counted)
+const MaxBody = 256 * 1024
+
+// Entry is a request recorded by the capture proxy
+type Entry struct {
+	ID       int64         `json:"id"`
+	Source   string        `json:"source,omitempty"` // project/component that sent it (proxy credentials), empty if unknown
+	Method   string        `json:"method"`
+	URL      string        `json:"url"`
+	Host     string        `json:"host"`
+	Protocol string        `json:"protocol,omitempty"` // HTTP version of the request
+	Status   int           `json:"status,omitempty"`   // 0 = no response (see Error)
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"` // Upstream unreachable, TLS refused...
+
+	// HTTPS tunneled without decryption (TLS interception off): only the host
+	// and the bytes exchanged are known
+	Tunnel bool `json:"tunnel,omitempty"`
+
+	RequestSize  int64 `json:"request_size"`  // Full body size
+	ResponseSize int64 `json:"response_size"` // Full body size
+
+	// Headers and bodies (up to MaxBody), not in summaries
+	RequestHeaders  http.Header `json:"request_headers,omitempty"`
+	RequestBody     []byte      `json:"request_body,omitempty"`
+	ResponseHeaders http.Header `json:"response_headers,omitempty"`
+	ResponseBody    []byte      `json:"response_body,omitempty"`
+}
+
+// Summary returns the entry without its headers and bodies
+func (e Entry) Summary() Entry {
+	e.RequestHeaders, e.RequestBody = nil, nil
+	e.ResponseHeaders, e.ResponseBody = nil, nil
+	return e
+}
+
+// Failed returns true if the request got no response or a 5xx
+func (e Entry) Failed() bool {
+	return e.Error != "" || e.Status >= 500
+}
+
+// Match returns true if an entry matches all the words of a filter: a word
+// matches the method, URL, source or status (substring, case insensitive);
+// "4xx" and "5xx" match a status class and "err" the failed requests
+func Match(e Entry, filter string) bool {
+	for _, word := range strings.Fields(strings.ToLower(filter)) {
+		if !matchWord(e, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchWord returns true if an entry matches a word of a filter
+func matchWord(e Entry, word string) bool {
+	if len(word) == 3 && strings.HasSuffix(word, "xx") && word[0] >= '1' && word[0] <= '5' {
+		return e.Status/100 == int(word[0]-'0')
+	}
+	if word == "err" && e.Failed() {
+		return true
+	}
+	for _, field := range []string{e.Method, e.URL, e.Source, strconv.Itoa(e.Status)} {
+		if strings.Contains(strings.ToLower(field), word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the entries matching a filter (see Match)
+func Filter(entries []Entry, filter string) []Entry {
+	if strings.TrimSpace(filter) == "" {
+		return entries
+	}
+	var matched []Entry
+	for _, e := range entries {
+		if Match(e, filter) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,466 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// hopHeaders are the headers of a proxy connection, not forwarded
+var hopHeaders = []string{
+	"Connection", "Proxy-Connection", "Keep-Alive", "Proxy-Authenticate",
+	"Proxy-Authorization", "Te", "Trailer", "Transfer-Encoding", "Upgrade",
+}
+
+// Server is the capture proxy: an HTTP proxy on localhost recording the
+// requests it forwards. HTTPS is decrypted with the CA when set, tunneled
+// otherwise.
+type Server struct {
+	transport *http.Transport
+
+	mu         sync.Mutex
+	server     *http.Server // nil = stopped
+	port       int
+	ca         *CA     // nil = HTTPS tunneled
+	entries    []Entry // Oldest first
+	maxEntries int
+	nextID     int64
+	onEntry    func() // Called after each recorded request (live panel)
+}
+
+// NewServer creates a stopped capture proxy
+func NewServer() *Server {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // Never through another proxy of the environment
+	transport.DisableCompression = true
+	return &Server{transport: transport}
+}
+
+// SetEntryHandler sets the function called after each recorded request
+func (s *Server) SetEntryHandler(fn func()) {
+	s.mu.Lock()
+	s.onEntry = fn
+	s.mu.Unlock()
+}
+
+// Start listens on localhost at port, keeping the last maxEntries requests.
+// ca decrypts HTTPS (nil = tunneled).
+func (s *Server) Start(port, maxEntries int, ca *CA) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server != nil {
+		return fmt.Errorf("capture proxy already running on port %d", s.port)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return fmt.Errorf("capture proxy: %w", err)
+	}
+	s.port = port
+	s.ca = ca
+	s.maxEntries = maxEntries
+	server := &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	s.server = server
+	go server.Serve(listener)
+	return nil
+}
+
+// Stop closes the proxy and its connections. The entries are kept.
+func (s *Server) Stop() error {
+	s.mu.Lock()
+	server := s.server
+	s.server = nil
+	s.mu.Unlock()
+	if server == nil {
+		return nil
+	}
+	return server.Close()
+}
+
+// URL returns the address of the running proxy, empty when stopped
+func (s *Server) URL() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server == nil {
+		return ""
+	}
+	return fmt.Sprintf("http://127.0.0.1:%d", s.port)
+}
+
+// CA returns the CA of the running proxy, nil when HTTPS is tunneled
+func (s *Server) CA() *CA {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ca
+}
+
+// Entries returns the summaries of the recorded requests, oldest first
+func (s *Server) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	summaries := make([]Entry, len(s.entries))
+	for i, e := range s.entries {
+		summaries[i] = e.Summary()
+	}
+	return summaries
+}
+
+// Entry returns a recorded request with its headers and bodies, nil if it
+// was dropped
+func (s *Server) Entry(id int64) *Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.entries {
+		if s.entries[i].ID == id {
+			entry := s.entries[i]
+			return &entry
+		}
+	}
+	return nil
+}
+
+// All returns the recorded requests with their headers and bodies
+func (s *Server) All() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Entry(nil), s.entries...)
+}
+
+// Clear drops the recorded requests
+func (s *Server) Clear() {
+	s.mu.Lock()
+	s.entries = nil
+	onEntry := s.onEntry
+	s.mu.Unlock()
+	if onEntry != nil {
+		onEntry()
+	}
+}
+
+// Environment returns the variables pointing a component at the proxy: its
+// source is sent as the proxy user name. With a CA, the runtimes also trust
+// it (Node.js adds it, OpenSSL, Python and curl use the bundle).
+func Environment(proxyURL, source string, ca *CA) []string {
+	withSource := strings.Replace(proxyURL, "://", "://"+url.User(source).String()+"@", 1)
+	env := []string{
+		"HTTP_PROXY=" + withSource,
+		"HTTPS_PROXY=" + withSource,
+		"http_proxy=" + withSource,
+		"https_proxy=" + withSource,
+		"NODE_USE_ENV_PROXY=1", // Node.js 24+ fetch and http honor the variables
+	}
+	if ca != nil {
+		env = append(env, "NODE_EXTRA_CA_CERTS="+ca.CertFile)
+		if ca.Bundle != "" {
+			env = append(env,
+				"SSL_CERT_FILE="+ca.Bundle,
+				"REQUESTS_CA_BUNDLE="+ca.Bundle,
+				"CURL_CA_BUNDLE="+ca.Bundle,
+			)
+		}
+	}
+	return env
+}
+
+// ServeHTTP forwards a proxied request, or opens the tunnel of an HTTPS one
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	source := proxySource(r)
+	if r.Method == http.MethodConnect {
+		s.connect(w, r, source)
+		return
+	}
+	if !r.URL.IsAbs() {
+		http.Error(w, "DevTrack capture proxy: use it as HTTP_PROXY", http.StatusBadRequest)
+		return
+	}
+	s.forward(w, r, source)
+}
+
+// forward sends a request upstream and copies the response back, recording
+// both
+func (s *Server) forward(w http.ResponseWriter, r *http.Request, source string) {
+	entry := Entry{
+		Source:         source,
+		Method:         r.Method,
+		URL:            r.URL.String(),
+		Host:           r.URL.Host,
+		Protocol:       r.Proto,
+		Started:        time.Now(),
+		RequestHeaders: r.Header.Clone(),
+	}
+	for _, h := range hopHeaders {
+		entry.RequestHeaders.Del(h)
+	}
+
+	requestBody := &limitedBuffer{}
+	out := r.Clone(r.Context())
+	out.RequestURI = ""
+	out.Header = entry.RequestHeaders.Clone()
+	if r.Body != nil && r.Body != http.NoBody {
+		out.Body = io.NopCloser(io.TeeReader(r.Body, requestBody))
+	}
+
+	resp, err := s.transport.RoundTrip(out)
+	entry.RequestBody, entry.RequestSize = requestBody.Bytes(), requestBody.size
+	if err != nil {
+		entry.Error = err.Error()
+		entry.Duration = time.Since(entry.Started)
+		s.record(entry)
+		http.Error(w, fmt.Sprintf("DevTrack capture proxy: %v", err), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	entry.Status = resp.StatusCode
+	entry.ResponseHeaders = resp.Header.Clone()
+	for _, h := range hopHeaders {
+		resp.Header.Del(h)
+	}
+	for name, values := range resp.Header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(resp.StatusCode)
+
+	// Streamed responses (server-sent events) are flushed as they come
+	responseBody := &limitedBuffer{}
+	flusher, _ := w.(http.Flusher)
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			responseBody.Write(buf[:n])
+			if _, err := w.Write(buf[:n]); err != nil {
+				entry.Error = err.Error()
+				break
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if readErr != nil {
+			if readErr != io.EOF {
+				entry.Error = readErr.Error()
+			}
+			break
+		}
+	}
+	entry.ResponseBody, entry.ResponseSize = responseBody.Bytes(), responseBody.size
+	entry.Duration = time.Since(entry.Started)
+	s.record(entry)
+}
+
+// connect handles the CONNECT of an HTTPS request: decrypted with the CA,
+// tunneled without
+func (s *Server) connect(w http.ResponseWriter, r *http.Request, source string) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "DevTrack capture proxy: CONNECT not supported", http.StatusInternalServerError)
+		return
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	if _, err := io.WriteString(conn, "HTTP/1.1 200 Connection Established\r\n\r\n"); err != nil {
+		conn.Close()
+		return
+	}
+
+	if ca := s.CA(); ca != nil {
+		s.intercept(conn, r.Host, source, ca)
+	} else {
+		s.tunnel(conn, r.Host, source)
+	}
+}
+
+// intercept decrypts the requests of an HTTPS connection with a certificate
+// of its host signed by the CA, then forwards them
+func (s *Server) intercept(conn net.Conn, hostPort, source string, ca *CA) {
+	host, _, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		host = hostPort
+	}
+	tlsConn := tls.Server(conn, &tls.Config{
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			if hello.ServerName != "" {
+				return ca.certificate(hello.ServerName)
+			}
+			return ca.certificate(host)
+		},
+		NextProtos: []string{"http/1.1"},
+	})
+
+	started := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	err = tlsConn.HandshakeContext(ctx)
+	cancel()
+	if err != nil {
+		conn.Close()
+		s.record(Entry{
+			Source:   source,
+			Method:   http.MethodConnect,
+			URL:      "https://" + hostPort,
+			Host:     hostPort,
+			Started:  started,
+			Duration: time.Since(started),
+			Error:    fmt.Sprintf("TLS handshake: %v (does the component trust the capture CA?)", err),
+		})
+		return
+	}
+
+	// The decrypted connection is served like the proxy's, with its
+	// requests made absolute
+	listener := newConnListener(tlsConn)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.URL.Scheme = "https"
+			r.URL.Host = r.Host
+			if r.URL.Host == "" {
+				r.URL.Host = hostPort
+			}
+			s.forward(w, r, source)
+		}),
+		ReadHeaderTimeout: 30 * time.Second,
+		ConnState: func(_ net.Conn, state http.ConnState) {
+			if state == http.StateClosed || state == http.StateHijacked {
+				listener.Close()
+			}
+		},
+	}
+	server.Serve(listener)
+}
+
+// tunnel copies the bytes of an HTTPS connection to its host without
+// decrypting them
+func (s *Server) tunnel(conn net.Conn, hostPort, source string) {
+	defer conn.Close()
+	entry := Entry{
+		Source:  source,
+		Method:  http.MethodConnect,
+		URL:     "https://" + hostPort,
+		Host:    hostPort,
+		Started: time.Now(),
+		Tunnel:  true,
+	}
+	upstream, err := net.DialTimeout("tcp", hostPort, 10*time.Second)
+	if err != nil {
+		entry.Error = err.Error()
+		entry.Duration = time.Since(entry.Started)
+		s.record(entry)
+		return
+	}
+	defer upstream.Close()
+
+	done := make(chan int64, 1)
+	go func() {
+		n, _ := io.Copy(upstream, conn)
+		upstream.Close()
+		done <- n
+	}()
+	entry.ResponseSize, _ = io.Copy(conn, upstream)
+	conn.Close()
+	entry.RequestSize = <-done
+	entry.Status = http.StatusOK
+	entry.Duration = time.Since(entry.Started)
+	s.record(entry)
+}
+
+// record adds a request, dropping the oldest beyond the maximum
+func (s *Server) record(entry Entry) {
+	s.mu.Lock()
+	s.nextID++
+	entry.ID = s.nextID
+	s.entries = append(s.entries, entry)
+	if s.maxEntries > 0 && len(s.entries) > s.maxEntries {
+		s.entries = append([]Entry(nil), s.entries[len(s.entries)-s.maxEntries:]...)
+	}
+	onEntry := s.onEntry
+	s.mu.Unlock()
+
+	if onEntry != nil {
+		onEntry()
+	}
+}
+
+// proxySource returns the user name of the proxy credentials of a request:
+// the project/component set by Environment
+func proxySource(r *http.Request) string {
+	auth := r.Header.Get("Proxy-Authorization")
+	encoded, ok := strings.CutPrefix(auth, "Basic ")
+	if !ok {
+		return ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return ""
+	}
+	user, _, _ := strings.Cut(string(decoded), ":")
+	if unescaped, err := url.PathUnescape(user); err == nil {
+		return unescaped
+	}
+	return user
+}
+
+// limitedBuffer keeps the first MaxBody bytes written and counts them all
+type limitedBuffer struct {
+	buf  bytes.Buffer
+	size int64
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.size += int64(len(p))
+	if room := MaxBody - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+// Bytes returns the bytes kept, nil without any
+func (b *limitedBuffer) Bytes() []byte {
+	if b.buf.Len() == 0 {
+		return nil
+	}
+	return b.buf.Bytes()
+}
+
+// connListener serves a single connection with an http.Server
+type connListener struct {
+	conn   net.Conn
+	once   sync.Once
+	closed chan struct{}
+	accept chan net.Conn
+}
+
+func newConnListener(conn net.Conn) *connListener {
+	l := &connListener{conn: conn, closed: make(chan struct{}), accept: make(chan net.Conn, 1)}
+	l.accept <- conn
+	return l
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.accept:
+		return conn, nil
+	case <-l.closed:
+		return nil, errors.New("connection closed")
+	}
+}
+
+func (l *connListener) Close() error {
+	l.once.Do(func() { close(l.closed) })
+	return nil
+}
+
+func (l *connListener) Addr() net.Addr {
+	return l.conn.LocalAddr()
+}
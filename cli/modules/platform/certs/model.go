@@ -0,0 +1,35 @@
+package certs
+
+import (
+	"time"
+)
+
+// Generator is the tool a certificate was generated with
+type Generator string
+
+const (
+	GeneratorMkcert     Generator = "mkcert"      // Signed by the local CA of mkcert (trusted by the browsers)
+	GeneratorSelfSigned Generator = "self-signed" // Generated by DevTrack (mkcert not installed)
+)
+
+// ExpiryWarning is how long before its expiry a certificate is reported
+const ExpiryWarning = 30 * 24 * time.Hour
+
+// DefaultHosts are the names of a certificate without configured hosts
+var DefaultHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+// Certificate is a local TLS certificate
+type Certificate struct {
+	CertFile  string    `json:"cert_file"`
+	KeyFile   string    `json:"key_file"`
+	Hosts     []string  `json:"hosts"`     // DNS names and IP addresses
+	Issuer    string    `json:"issuer"`    // Common name or organization of the issuer
+	NotAfter  time.Time `json:"not_after"` // Expiry
+	Generator Generator `json:"generator,omitempty"`
+}
+
+// ExpiresIn returns the time left before the certificate expires (negative
+// once expired)
+func (c *Certificate) ExpiresIn() time.Duration {
+	return time.Until(c.NotAfter)
+}
@@ -0,0 +1,164 @@
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+)
+
+// selfSignedValidity is the validity of the self-signed certificates (the
+// browsers refuse more than 398 days)
+const selfSignedValidity = 365 * 24 * time.Hour
+
+// Dir returns the directory the certificates are generated in
+func Dir() (string, error) {
+	dataDir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "certs"), nil
+}
+
+// MkcertPath returns the path of mkcert, empty if it is not installed
+func MkcertPath() string {
+	path, err := exec.LookPath("mkcert")
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// Generate writes the certificate <name>.pem and its key <name>-key.pem in
+// dir for hosts (DefaultHosts if empty), with mkcert when installed and
+// self-signed otherwise
+func Generate(ctx context.Context, dir, name string, hosts []string) (*Certificate, error) {
+	if len(hosts) == 0 {
+		hosts = DefaultHosts
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	certFile := filepath.Join(dir, name+".pem")
+	keyFile := filepath.Join(dir, name+"-key.pem")
+
+	generator := GeneratorSelfSigned
+	if mkcert := MkcertPath(); mkcert != "" {
+		args := append([]string{"-cert-file", certFile, "-key-file", keyFile}, hosts...)
+		cmd := exec.CommandContext(ctx, mkcert, args...)
+		var output bytes.Buffer
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("mkcert failed: %v: %s", err, strings.TrimSpace(output.String()))
+		}
+		generator = GeneratorMkcert
+	} else if err := selfSigned(certFile, keyFile, hosts); err != nil {
+		return nil, err
+	}
+
+	cert, err := Inspect(certFile)
+	if err != nil {
+		return nil, err
+	}
+	cert.KeyFile = keyFile
+	cert.Generator = generator
+	return cert, nil
+}
+
+// Inspect reads the names, issuer and expiry of a PEM certificate
+func Inspect(certFile string) (*Certificate, error) {
+	data, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("%s: no PEM certificate", certFile)
+	}
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", certFile, err)
+	}
+
+	cert := &Certificate{
+		CertFile: certFile,
+		Hosts:    append([]string(nil), parsed.DNSNames...),
+		Issuer:   parsed.Issuer.CommonName,
+		NotAfter: parsed.NotAfter,
+	}
+	for _, ip := range parsed.IPAddresses {
+		cert.Hosts = append(cert.Hosts, ip.String())
+	}
+	if cert.Issuer == "" && len(parsed.Issuer.Organization) > 0 {
+		cert.Issuer = parsed.Issuer.Organization[0]
+	}
+	switch {
+	case strings.Contains(cert.Issuer, "mkcert"):
+		cert.Generator = GeneratorMkcert
+	case bytes.Equal(parsed.RawIssuer, parsed.RawSubject):
+		cert.Generator = GeneratorSelfSigned
+	}
+	return cert, nil
+}
+
+// selfSigned writes a self-signed ECDSA certificate for hosts and its key
+func selfSigned(certFile, keyFile string, hosts []string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate the key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return fmt.Errorf("failed to generate the serial number: %w", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   hosts[0],
+			Organization: []string{"csd-devtrack development certificate"},
+		},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return fmt.Errorf("failed to create the certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode the key: %w", err)
+	}
+
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		return err
+	}
+	return os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644)
+}
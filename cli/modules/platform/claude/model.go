@@ -35,6 +35,7 @@ type Session struct {
 	ProjectID    string       `json:"project_id"`     // Associated project in csd-devtrack
 	ProjectName  string       `json:"project_name"`   // For display
 	WorkDir      string       `json:"work_dir"`       // Working directory for Claude
+	GitBranch    string       `json:"git_branch"`     // Git branch the session started on
 	State        SessionState `json:"state"`
 	Messages     []Message    `json:"messages"`       // Loaded from Claude CLI JSONL (lazy loaded)
 	MessageCount int          `json:"message_count"`  // Count without loading all messages
@@ -49,6 +50,10 @@ type Session struct {
 	IsRealSession  bool   `json:"is_real_session"`  // True if from ~/.claude/projects/
 	SessionFile    string `json:"session_file"`     // Path to JSONL file
 	MessagesLoaded bool   `json:"-"`                // True if messages have been fully loaded
+
+	// Incremental reading of SessionFile (see refreshSession)
+	scan   *sessionScan   // Metadata read so far
+	reader *MessageReader // Messages read so far (once loaded)
 }
 
 // GenerateSessionID generates a new UUID for a Claude session
@@ -64,6 +69,16 @@ func (s *Session) DisplayName() string {
 	return s.Name
 }
 
+// FileEdit is the last change of a file by a Claude session (Edit, MultiEdit,
+// Write or NotebookEdit tool use)
+type FileEdit struct {
+	Path        string    `json:"path"` // Absolute path
+	SessionID   string    `json:"session_id"`
+	SessionName string    `json:"session_name"`
+	Tool        string    `json:"tool"`
+	Time        time.Time `json:"time"`
+}
+
 // SessionSummary is a lightweight version for listing
 type SessionSummary struct {
 	ID               string       `json:"id"`
@@ -72,6 +87,8 @@ type SessionSummary struct {
 	ProjectName      string       `json:"project_name"`
 	WorkDir          string       `json:"work_dir"`
 	ClaudeProjectDir string       `json:"claude_project_dir"` // Raw Claude project directory name
+	ParentID         string       `json:"parent_id,omitempty"` // Session it was forked from
+	GitBranch        string       `json:"git_branch,omitempty"`
 	State            SessionState `json:"state"`
 	MessageCount     int          `json:"message_count"`
 	CreatedAt        time.Time    `json:"created_at"`
@@ -99,6 +116,7 @@ func (s *Session) ToSummary() SessionSummary {
 		ProjectID:        s.ProjectID,
 		ProjectName:      s.ProjectName,
 		WorkDir:          s.WorkDir,
+		GitBranch:        s.GitBranch,
 		ClaudeProjectDir: claudeProjectDir,
 		State:            s.State,
 		MessageCount:     msgCount,
@@ -0,0 +1,245 @@
+package claude
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// readAppendedLines calls fn for each line of a file from an offset and
+// returns the offset to read from next time. A last line without newline is
+// only consumed if it is complete JSON (Claude may be writing it).
+func readAppendedLines(path string, offset int64, fn func(line []byte)) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return offset, err
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return offset, err
+	}
+
+	reader := bufio.NewReaderSize(file, 64*1024)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(bytes.TrimSpace(line)) == 0 || !json.Valid(line) {
+				return offset, nil
+			}
+		} else if err != nil {
+			return offset, err
+		}
+		offset += int64(len(line))
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			fn(line)
+		}
+		if err == io.EOF {
+			return offset, nil
+		}
+	}
+}
+
+// fileSize returns the size of a file
+func fileSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// sessionScan is the metadata read from a session file, kept with the
+// offset reached so a refresh only parses the appended lines
+type sessionScan struct {
+	offset         int64 // Bytes of the file parsed
+	slug           string
+	workDir        string
+	gitBranch      string
+	firstTimestamp time.Time
+	lastTimestamp  time.Time
+	messageCount   int
+	customTitle    string // Name of the last custom-title entry (see writeCustomTitle)
+}
+
+// read parses the lines appended to a session file since the last read.
+// Returns false if there was no new line. The file is read again from the
+// start if it shrank (rewritten).
+func (sc *sessionScan) read(path string) (bool, error) {
+	size, err := fileSize(path)
+	if err != nil {
+		return false, err
+	}
+	if size < sc.offset {
+		*sc = sessionScan{}
+	}
+	if size == sc.offset {
+		return false, nil
+	}
+
+	start := sc.offset
+	sc.offset, err = readAppendedLines(path, sc.offset, sc.parseLine)
+	return sc.offset != start, err
+}
+
+// complete returns true once the metadata read from the first lines is known
+func (sc *sessionScan) complete() bool {
+	return sc.workDir != "" && sc.slug != "" && sc.messageCount > 0 && !sc.firstTimestamp.IsZero()
+}
+
+// parseLine reads the metadata of a JSONL entry. Once the metadata is
+// known, lines are not decoded anymore: messages are counted and the last
+// timestamp extracted from the raw text.
+func (sc *sessionScan) parseLine(line []byte) {
+	lineStr := string(line)
+	if strings.Contains(lineStr, `"type":"user"`) || strings.Contains(lineStr, `"type":"assistant"`) {
+		sc.messageCount++
+	}
+	if strings.Contains(lineStr, `"type":"custom-title"`) {
+		var entry struct {
+			CustomTitle string `json:"customTitle"`
+		}
+		if err := json.Unmarshal(line, &entry); err == nil {
+			sc.customTitle = entry.CustomTitle
+		}
+		return
+	}
+
+	if sc.complete() {
+		if idx := strings.Index(lineStr, `"timestamp":"`); idx > 0 {
+			start := idx + 13
+			if end := strings.Index(lineStr[start:], `"`); end > 0 {
+				if t, err := time.Parse(time.RFC3339, lineStr[start:start+end]); err == nil {
+					sc.lastTimestamp = t
+				}
+			}
+		}
+		return
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return
+	}
+	if cwd, ok := entry["cwd"].(string); ok && sc.workDir == "" {
+		sc.workDir = cwd
+	}
+	if branch, ok := entry["gitBranch"].(string); ok && sc.gitBranch == "" {
+		sc.gitBranch = branch
+	}
+	if slug, ok := entry["slug"].(string); ok && sc.slug == "" {
+		sc.slug = slug
+	}
+	if ts, ok := entry["timestamp"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, ts); err == nil {
+			if sc.firstTimestamp.IsZero() {
+				sc.firstTimestamp = t
+			}
+			sc.lastTimestamp = t
+		}
+	}
+}
+
+// MessageReader reads the messages of a Claude CLI JSONL session file
+// incrementally: each Read parses only the lines appended since the last one
+type MessageReader struct {
+	path          string
+	sessionID     string
+	offset        int64     // Bytes of the file parsed
+	lastTimestamp time.Time // Timestamp of the last entry parsed
+	messages      []Message
+}
+
+// NewMessageReader creates a reader of a session file (nothing read yet)
+func NewMessageReader(path, sessionID string) *MessageReader {
+	return &MessageReader{path: path, sessionID: sessionID, messages: make([]Message, 0)}
+}
+
+// Read parses the lines appended to the file and returns all the messages
+// read so far (changed is false if there was no new line). The file is read
+// again from the start if it shrank (rewritten).
+func (r *MessageReader) Read() (messages []Message, changed bool, err error) {
+	size, err := fileSize(r.path)
+	if err != nil {
+		return r.Messages(), false, err
+	}
+	if size < r.offset {
+		r.offset, r.lastTimestamp, r.messages = 0, time.Time{}, make([]Message, 0)
+	}
+	if size != r.offset {
+		start := r.offset
+		r.offset, err = readAppendedLines(r.path, r.offset, r.parseLine)
+		changed = r.offset != start
+	}
+	return r.Messages(), changed, err
+}
+
+// Messages returns the messages read so far. The slice is capped so that
+// appending to it does not write into the reader.
+func (r *MessageReader) Messages() []Message {
+	return r.messages[:len(r.messages):len(r.messages)]
+}
+
+// parseLine reads the message of a JSONL entry: the text blocks of user and
+// assistant messages, and their tool uses formatted for display
+func (r *MessageReader) parseLine(line []byte) {
+	var entry map[string]interface{}
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return
+	}
+
+	if ts, ok := entry["timestamp"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, ts); err == nil {
+			r.lastTimestamp = t
+		}
+	}
+
+	entryType, _ := entry["type"].(string)
+	if entryType != "user" && entryType != "assistant" {
+		return
+	}
+	msg, ok := entry["message"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	role, _ := msg["role"].(string)
+
+	var contentBuilder strings.Builder
+	if contentStr, ok := msg["content"].(string); ok {
+		contentBuilder.WriteString(contentStr)
+	} else if c, ok := msg["content"].([]interface{}); ok {
+		for _, item := range c {
+			block, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			blockType, _ := block["type"].(string)
+			switch blockType {
+			case "text":
+				if text, ok := block["text"].(string); ok {
+					contentBuilder.WriteString(text)
+				}
+			case "tool_use":
+				toolName, _ := block["name"].(string)
+				input, _ := block["input"].(map[string]interface{})
+				contentBuilder.WriteString("\n")
+				contentBuilder.WriteString(formatToolUseForDisplay(toolName, input))
+			}
+		}
+	}
+
+	content := contentBuilder.String()
+	if role != "" && content != "" {
+		r.messages = append(r.messages, Message{
+			ID:        fmt.Sprintf("%s-%d", r.sessionID, len(r.messages)),
+			Role:      role,
+			Content:   content,
+			Timestamp: r.lastTimestamp,
+		})
+	}
+}
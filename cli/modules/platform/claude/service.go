@@ -2,6 +2,7 @@ package claude
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
@@ -12,9 +13,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
+
+	"csd-devtrack/cli/modules/platform/tracing"
 )
 
 // generateID generates a unique ID for messages
@@ -32,8 +36,9 @@ func claudeProjectsDir() string {
 
 // workDirToClaudeProject converts a work directory to Claude project folder name
 // e.g., /data/devel/infra/csd-devtrack -> -data-devel-infra-csd-devtrack
+// (C:\devel\csd-devtrack -> C--devel-csd-devtrack on Windows)
 func workDirToClaudeProject(workDir string) string {
-	return strings.ReplaceAll(workDir, "/", "-")
+	return strings.NewReplacer("/", "-", "\\", "-", ":", "-").Replace(workDir)
 }
 
 // isValidUUID checks if a string is a valid UUID
@@ -465,30 +470,60 @@ type Service struct {
 	mu              sync.RWMutex
 	sessions        map[string]*Session
 	customNames     map[string]string // sessionID -> custom name
+	parents         map[string]string // sessionID -> session it was forked from
 	dataDir         string
 	claudePath      string
 	isInstalled     bool
 	activeProcs     map[string]*exec.Cmd
 	persistentProcs map[string]*persistentProcess // Persistent processes per session
 	outputChans     map[string]chan ClaudeOutput
+	sessionsLoaded  bool // Sessions read from disk (see LoadSessions)
+	syncNames       bool // Custom names also written to the session files (see SetSyncNames)
+
+	editsMu   sync.Mutex
+	editCache map[string]*sessionEdits // Session file -> files it edited
+}
+
+// sessionEdits caches the files edited by a session, read from its file
+type sessionEdits struct {
+	modTime time.Time
+	size    int64
+	edits   map[string]FileEdit // Absolute path -> last edit
 }
 
-// NewService creates a new Claude service
+// NewService creates a new Claude service.
+// Sessions are not read until LoadSessions: parsing a large Claude history
+// takes seconds and is only needed once the sessions are shown.
 func NewService(dataDir string) *Service {
 	s := &Service{
 		sessions:        make(map[string]*Session),
 		customNames:     make(map[string]string),
+		parents:         make(map[string]string),
 		dataDir:         dataDir,
 		activeProcs:     make(map[string]*exec.Cmd),
 		persistentProcs: make(map[string]*persistentProcess),
 		outputChans:     make(map[string]chan ClaudeOutput),
+		editCache:       make(map[string]*sessionEdits),
 	}
 	s.detectClaude()
 	s.loadCustomNames()
-	s.loadSessions()
+	s.loadSessionParents()
 	return s
 }
 
+// LoadSessions reads the sessions from disk the first time it is called
+func (s *Service) LoadSessions() {
+	s.mu.Lock()
+	if s.sessionsLoaded {
+		s.mu.Unlock()
+		return
+	}
+	s.sessionsLoaded = true
+	s.mu.Unlock()
+
+	s.loadSessions()
+}
+
 // IsInstalled returns true if Claude Code CLI is available
 func (s *Service) IsInstalled() bool {
 	s.mu.RLock()
@@ -576,9 +611,41 @@ func (s *Service) saveCustomNames() error {
 	return os.WriteFile(s.customNamesFile(), data, 0644)
 }
 
+// sessionParentsFile returns the path of the session fork links
+func (s *Service) sessionParentsFile() string {
+	return filepath.Join(s.dataDir, "claude-session-parents.json")
+}
+
+// loadSessionParents loads the session fork links from local storage
+func (s *Service) loadSessionParents() {
+	data, err := os.ReadFile(s.sessionParentsFile())
+	if err != nil {
+		return // No forks yet
+	}
+	if err := json.Unmarshal(data, &s.parents); err != nil || s.parents == nil {
+		s.parents = make(map[string]string)
+	}
+}
+
+// saveSessionParents saves the session fork links to local storage.
+// The caller holds s.mu.
+func (s *Service) saveSessionParents() error {
+	data, err := json.MarshalIndent(s.parents, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dataDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.sessionParentsFile(), data, 0644)
+}
+
 // loadSessions loads sessions from Claude CLI's ~/.claude/projects/ directory
 // Uses parallel loading for faster startup
 func (s *Service) loadSessions() {
+	_, span := tracing.Start(context.Background(), "claude.load_sessions")
+	defer span.End()
+
 	projectsDir := claudeProjectsDir()
 
 	// Read all project directories
@@ -621,6 +688,7 @@ func (s *Service) loadSessions() {
 	}
 
 	wg.Wait()
+	span.SetAttr(tracing.Int("projects", len(dirs)), tracing.Int("sessions", len(allSessions)))
 
 	// Copy to service sessions
 	s.mu.Lock()
@@ -652,38 +720,19 @@ func (s *Service) loadProjectSessionsParallel(projectPath, projectName string) m
 			continue
 		}
 
-		sessionFile := filepath.Join(projectPath, entry.Name())
-		info, err := entry.Info()
-		if err != nil {
+		// Already loaded (refreshed by refreshSessions)
+		s.mu.RLock()
+		_, known := s.sessions[sessionID]
+		s.mu.RUnlock()
+		if known {
 			continue
 		}
 
-		var session *Session
-		if info.Size() == 0 {
-			session = &Session{
-				ID:             sessionID,
-				Name:           sessionID[:8],
-				ProjectName:    projectName,
-				State:          SessionIdle,
-				Messages:       make([]Message, 0),
-				MessageCount:   0,
-				CreatedAt:      info.ModTime(),
-				LastActiveAt:   info.ModTime(),
-				IsRealSession:  true,
-				SessionFile:    sessionFile,
-				MessagesLoaded: true,
-			}
-		} else {
-			session = s.parseSessionMetadata(sessionID, sessionFile)
+		info, err := entry.Info()
+		if err != nil {
+			continue
 		}
-
-		if session != nil && session.MessageCount > 0 {
-			// Apply custom name (read-only access to customNames is safe)
-			s.mu.RLock()
-			if customName, ok := s.customNames[sessionID]; ok {
-				session.CustomName = customName
-			}
-			s.mu.RUnlock()
+		if session := s.readSessionFile(sessionID, filepath.Join(projectPath, entry.Name()), projectName, info); session != nil {
 			sessions[sessionID] = session
 		}
 	}
@@ -691,6 +740,40 @@ func (s *Service) loadProjectSessionsParallel(projectPath, projectName string) m
 	return sessions
 }
 
+// readSessionFile reads the metadata of a session file not loaded yet.
+// Returns nil for files without messages. Thread-safe.
+func (s *Service) readSessionFile(sessionID, sessionFile, projectName string, info os.FileInfo) *Session {
+	var session *Session
+	if info.Size() == 0 {
+		session = &Session{
+			ID:             sessionID,
+			Name:           sessionID[:8],
+			ProjectName:    projectName,
+			State:          SessionIdle,
+			Messages:       make([]Message, 0),
+			MessageCount:   0,
+			CreatedAt:      info.ModTime(),
+			LastActiveAt:   info.ModTime(),
+			IsRealSession:  true,
+			SessionFile:    sessionFile,
+			MessagesLoaded: true,
+		}
+	} else {
+		session = s.parseSessionMetadata(sessionID, sessionFile)
+	}
+	if session == nil || session.MessageCount == 0 {
+		return nil
+	}
+
+	// Apply custom name (read-only access to customNames is safe)
+	s.mu.RLock()
+	if customName, ok := s.customNames[sessionID]; ok {
+		session.CustomName = customName
+	}
+	s.mu.RUnlock()
+	return session
+}
+
 // loadProjectSessions loads sessions from a specific Claude project directory
 // Uses lazy loading - only metadata is loaded, messages are loaded on demand
 func (s *Service) loadProjectSessions(projectPath, projectName string) {
@@ -762,11 +845,10 @@ func (s *Service) loadProjectSessions(projectPath, projectName string) {
 // parseSessionMetadata reads only metadata from a JSONL file (fast, for listing)
 // Does not load message content - use loadSessionMessages for that
 func (s *Service) parseSessionMetadata(sessionID, filePath string) *Session {
-	file, err := os.Open(filePath)
-	if err != nil {
+	scan := &sessionScan{}
+	if _, err := scan.read(filePath); err != nil {
 		return nil
 	}
-	defer file.Close()
 
 	session := &Session{
 		ID:             sessionID,
@@ -775,81 +857,12 @@ func (s *Service) parseSessionMetadata(sessionID, filePath string) *Session {
 		IsRealSession:  true,
 		SessionFile:    filePath,
 		MessagesLoaded: false, // Messages not loaded yet
+		scan:           scan,
 	}
-
-	scanner := bufio.NewScanner(file)
-	// Smaller buffer for metadata scan
-	buf := make([]byte, 0, 16*1024)
-	scanner.Buffer(buf, 256*1024)
-
-	var firstTimestamp, lastTimestamp time.Time
-	var sessionName string
-	var workDir string
-	messageCount := 0
-
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
-		}
-
-		// Quick check for message types without full parse
-		lineStr := string(line)
-		if strings.Contains(lineStr, `"type":"user"`) || strings.Contains(lineStr, `"type":"assistant"`) {
-			messageCount++
-		}
-
-		// Only parse first few lines for metadata (optimization)
-		if workDir != "" && sessionName != "" && messageCount > 0 && !firstTimestamp.IsZero() {
-			// Got all metadata, just count remaining messages quickly
-			for scanner.Scan() {
-				l := string(scanner.Bytes())
-				if strings.Contains(l, `"type":"user"`) || strings.Contains(l, `"type":"assistant"`) {
-					messageCount++
-				}
-				// Update last timestamp from line if present
-				if idx := strings.Index(l, `"timestamp":"`); idx > 0 {
-					// Extract timestamp quickly
-					start := idx + 13
-					if end := strings.Index(l[start:], `"`); end > 0 {
-						if t, err := time.Parse(time.RFC3339, l[start:start+end]); err == nil {
-							lastTimestamp = t
-						}
-					}
-				}
-			}
-			break
-		}
-
-		var entry map[string]interface{}
-		if err := json.Unmarshal(line, &entry); err != nil {
-			continue
-		}
-
-		// Get working directory from cwd field
-		if cwd, ok := entry["cwd"].(string); ok && workDir == "" {
-			workDir = cwd
-		}
-
-		// Get session name from slug
-		if slug, ok := entry["slug"].(string); ok && sessionName == "" {
-			sessionName = slug
-		}
-
-		// Get timestamp
-		if ts, ok := entry["timestamp"].(string); ok {
-			if t, err := time.Parse(time.RFC3339, ts); err == nil {
-				if firstTimestamp.IsZero() {
-					firstTimestamp = t
-				}
-				lastTimestamp = t
-			}
-		}
-	}
-
-	session.MessageCount = messageCount
+	session.MessageCount = scan.messageCount
 
 	// Set working directory and extract project info
+	workDir := scan.workDir
 	if workDir == "" {
 		dir := filepath.Dir(filePath)
 		projectDirName := filepath.Base(dir)
@@ -858,17 +871,16 @@ func (s *Service) parseSessionMetadata(sessionID, filePath string) *Session {
 		}
 	}
 	session.WorkDir = workDir
+	session.GitBranch = scan.gitBranch
+	session.CustomName = scan.customTitle // Overridden by the names stored by DevTrack
 	if workDir != "" {
-		parts := strings.Split(workDir, "/")
-		if len(parts) > 0 {
-			session.ProjectName = parts[len(parts)-1]
-			session.ProjectID = session.ProjectName
-		}
+		session.ProjectName = filepath.Base(workDir)
+		session.ProjectID = session.ProjectName
 	}
 
 	// Set session name
-	if sessionName != "" {
-		session.Name = sessionName
+	if scan.slug != "" {
+		session.Name = scan.slug
 	} else if len(sessionID) >= 8 {
 		session.Name = sessionID[:8]
 	} else {
@@ -876,15 +888,15 @@ func (s *Service) parseSessionMetadata(sessionID, filePath string) *Session {
 	}
 
 	// Set timestamps
-	if !firstTimestamp.IsZero() {
-		session.CreatedAt = firstTimestamp
+	if !scan.firstTimestamp.IsZero() {
+		session.CreatedAt = scan.firstTimestamp
 	} else {
 		if info, err := os.Stat(filePath); err == nil {
 			session.CreatedAt = info.ModTime()
 		}
 	}
-	if !lastTimestamp.IsZero() {
-		session.LastActiveAt = lastTimestamp
+	if !scan.lastTimestamp.IsZero() {
+		session.LastActiveAt = scan.lastTimestamp
 	} else {
 		session.LastActiveAt = session.CreatedAt
 	}
@@ -892,90 +904,207 @@ func (s *Service) parseSessionMetadata(sessionID, filePath string) *Session {
 	return session
 }
 
+// refreshSession parses the lines appended to the file of a known session
+// since its last read, instead of reading the whole file again. Messages
+// are only followed once loaded. Returns true if the session changed.
+// Must be called with s.mu held.
+func (s *Service) refreshSession(session *Session) bool {
+	// Messages streamed by DevTrack are only in memory until the run ends
+	if session.SessionFile == "" || session.State == SessionRunning {
+		return false
+	}
+	if session.scan == nil {
+		session.scan = &sessionScan{}
+	}
+	changed, err := session.scan.read(session.SessionFile)
+	if err != nil || !changed {
+		return false
+	}
+
+	scan := session.scan
+	session.MessageCount = scan.messageCount
+	if scan.lastTimestamp.After(session.LastActiveAt) {
+		session.LastActiveAt = scan.lastTimestamp
+	}
+	if session.Name == "" {
+		session.Name = scan.slug
+	}
+	if session.GitBranch == "" {
+		session.GitBranch = scan.gitBranch
+	}
+	if _, named := s.customNames[session.ID]; !named {
+		session.CustomName = scan.customTitle // Renamed in Claude CLI
+	}
+
+	if session.MessagesLoaded && session.reader != nil {
+		if messages, _, err := session.reader.Read(); err == nil {
+			session.Messages = messages
+			session.MessageCount = len(messages)
+		}
+	}
+	return true
+}
+
 // loadSessionMessages loads full message content for a session (lazy loading)
 func (s *Service) loadSessionMessages(session *Session) {
 	if session == nil || session.MessagesLoaded || session.SessionFile == "" {
 		return
 	}
 
-	file, err := os.Open(session.SessionFile)
+	// The reader is kept to parse only the appended messages on refresh
+	reader := NewMessageReader(session.SessionFile, session.ID)
+	messages, _, err := reader.Read()
 	if err != nil {
 		return
 	}
+
+	session.reader = reader
+	session.Messages = messages
+	session.MessageCount = len(session.Messages)
+	session.MessagesLoaded = true
+}
+
+// ReadSessionMessages reads the user and assistant messages of a Claude CLI
+// JSONL session file (text blocks, tool uses formatted for display).
+// Use a MessageReader to follow a file being written.
+func ReadSessionMessages(path, sessionID string) ([]Message, error) {
+	messages, _, err := NewMessageReader(path, sessionID).Read()
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// fileEditTools are the tools writing files, with their path parameter
+var fileEditTools = map[string]string{
+	"Edit":         "file_path",
+	"MultiEdit":    "file_path",
+	"Write":        "file_path",
+	"NotebookEdit": "notebook_path",
+}
+
+// ReadSessionEdits reads the files written by the tool uses of a Claude CLI
+// JSONL session file, with the tool and time of their last change. Relative
+// paths are resolved against the working directory of the session.
+func ReadSessionEdits(path string) (map[string]FileEdit, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
 	defer file.Close()
 
 	scanner := bufio.NewScanner(file)
 	buf := make([]byte, 0, 64*1024)
-	scanner.Buffer(buf, 1024*1024)
-
-	session.Messages = make([]Message, 0)
-	var lastTimestamp time.Time
+	scanner.Buffer(buf, 16*1024*1024) // Write inputs hold whole files
 
+	edits := make(map[string]FileEdit)
 	for scanner.Scan() {
 		line := scanner.Bytes()
-		if len(line) == 0 {
+		// Quick check before the full parse
+		if !strings.Contains(string(line), `"tool_use"`) {
 			continue
 		}
 
-		var entry map[string]interface{}
-		if err := json.Unmarshal(line, &entry); err != nil {
+		var entry struct {
+			Type      string `json:"type"`
+			Cwd       string `json:"cwd"`
+			Timestamp string `json:"timestamp"`
+			Message   struct {
+				Content []struct {
+					Type  string                 `json:"type"`
+					Name  string                 `json:"name"`
+					Input map[string]interface{} `json:"input"`
+				} `json:"content"`
+			} `json:"message"`
+		}
+		if json.Unmarshal(line, &entry) != nil || entry.Type != "assistant" {
 			continue
 		}
-
-		// Get timestamp
-		if ts, ok := entry["timestamp"].(string); ok {
-			if t, err := time.Parse(time.RFC3339, ts); err == nil {
-				lastTimestamp = t
+		editTime, _ := time.Parse(time.RFC3339, entry.Timestamp)
+		for _, block := range entry.Message.Content {
+			param, ok := fileEditTools[block.Name]
+			if block.Type != "tool_use" || !ok {
+				continue
+			}
+			filePath, _ := block.Input[param].(string)
+			if filePath == "" {
+				continue
 			}
+			if !filepath.IsAbs(filePath) && entry.Cwd != "" {
+				filePath = filepath.Join(entry.Cwd, filePath)
+			}
+			filePath = filepath.Clean(filePath)
+			edits[filePath] = FileEdit{Path: filePath, Tool: block.Name, Time: editTime}
 		}
+	}
+	return edits, scanner.Err()
+}
 
-		// Extract messages
-		if entryType, ok := entry["type"].(string); ok {
-			if entryType == "user" || entryType == "assistant" {
-				if msg, ok := entry["message"].(map[string]interface{}); ok {
-					role := ""
-					if r, ok := msg["role"].(string); ok {
-						role = r
-					}
-
-					var contentBuilder strings.Builder
-					if contentStr, ok := msg["content"].(string); ok {
-						contentBuilder.WriteString(contentStr)
-					} else if c, ok := msg["content"].([]interface{}); ok {
-						for _, item := range c {
-							if block, ok := item.(map[string]interface{}); ok {
-								blockType, _ := block["type"].(string)
-								switch blockType {
-								case "text":
-									if text, ok := block["text"].(string); ok {
-										contentBuilder.WriteString(text)
-									}
-								case "tool_use":
-									toolName, _ := block["name"].(string)
-									input, _ := block["input"].(map[string]interface{})
-									contentBuilder.WriteString("\n")
-									contentBuilder.WriteString(formatToolUseForDisplay(toolName, input))
-								}
-							}
-						}
-					}
+// FileEdits returns the files under dir written by Claude sessions, keyed by
+// absolute path, with the edits of each session (most recent first).
+// Session files are read again only when they changed.
+func (s *Service) FileEdits(dir string) map[string][]FileEdit {
+	dir = filepath.Clean(dir)
+	s.mu.RLock()
+	sessions := make([]*Session, 0)
+	for _, sess := range s.sessions {
+		if sess.SessionFile != "" && (isWithinDir(sess.WorkDir, dir) || isWithinDir(dir, sess.WorkDir)) {
+			sessions = append(sessions, sess)
+		}
+	}
+	names := make(map[string]string, len(sessions))
+	for _, sess := range sessions {
+		names[sess.ID] = sess.DisplayName()
+	}
+	s.mu.RUnlock()
 
-					content := contentBuilder.String()
-					if role != "" && content != "" {
-						session.Messages = append(session.Messages, Message{
-							ID:        fmt.Sprintf("%s-%d", session.ID, len(session.Messages)),
-							Role:      role,
-							Content:   content,
-							Timestamp: lastTimestamp,
-						})
-					}
-				}
+	result := make(map[string][]FileEdit)
+	for _, sess := range sessions {
+		for path, edit := range s.sessionFileEdits(sess.SessionFile) {
+			if !isWithinDir(path, dir) {
+				continue
 			}
+			edit.SessionID = sess.ID
+			edit.SessionName = names[sess.ID]
+			result[path] = append(result[path], edit)
 		}
 	}
+	for _, edits := range result {
+		sort.Slice(edits, func(i, j int) bool {
+			return edits[i].Time.After(edits[j].Time)
+		})
+	}
+	return result
+}
 
-	session.MessageCount = len(session.Messages)
-	session.MessagesLoaded = true
+// sessionFileEdits returns the files edited by a session file, from the
+// cache while the file is unchanged
+func (s *Service) sessionFileEdits(sessionFile string) map[string]FileEdit {
+	info, err := os.Stat(sessionFile)
+	if err != nil {
+		return nil
+	}
+	s.editsMu.Lock()
+	defer s.editsMu.Unlock()
+	cached := s.editCache[sessionFile]
+	if cached != nil && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.edits
+	}
+	edits, err := ReadSessionEdits(sessionFile)
+	if err != nil && len(edits) == 0 {
+		return nil
+	}
+	s.editCache[sessionFile] = &sessionEdits{modTime: info.ModTime(), size: info.Size(), edits: edits}
+	return edits
+}
+
+// isWithinDir returns true if path is dir or below it
+func isWithinDir(path, dir string) bool {
+	if path == "" {
+		return false
+	}
+	rel, err := filepath.Rel(dir, filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
 }
 
 // parseSessionFile reads a Claude CLI JSONL session file and extracts all data
@@ -988,14 +1117,195 @@ func (s *Service) parseSessionFile(sessionID, filePath string) *Session {
 	return session
 }
 
-// RefreshSessions reloads sessions from Claude CLI directory
+// RefreshSessions updates the sessions from Claude CLI directory: sessions
+// whose file was deleted are dropped, new ones are read, and known ones
+// only parse the lines appended since the last refresh
 func (s *Service) RefreshSessions() {
 	s.mu.Lock()
-	// Clear existing sessions
-	s.sessions = make(map[string]*Session)
+	s.sessionsLoaded = true
+	for id, sess := range s.sessions {
+		if sess.SessionFile == "" {
+			continue
+		}
+		if _, err := os.Stat(sess.SessionFile); os.IsNotExist(err) {
+			delete(s.sessions, id)
+		}
+	}
 	s.mu.Unlock()
 
 	s.loadSessions()
+	s.refreshSessions()
+}
+
+// refreshSessions parses the lines appended to the files of the known
+// sessions. Returns the number of sessions that changed.
+func (s *Service) refreshSessions() int {
+	_, span := tracing.Start(context.Background(), "claude.refresh_sessions")
+	defer span.End()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := 0
+	for _, sess := range s.sessions {
+		if s.refreshSession(sess) {
+			changed++
+		}
+	}
+	span.SetAttr(tracing.Int("sessions", len(s.sessions)), tracing.Int("changed", changed))
+	return changed
+}
+
+// DiscoverSessions picks up sessions created outside DevTrack since the last
+// scan, and the lines appended to the known ones. Returns the number of new
+// or changed sessions.
+func (s *Service) DiscoverSessions() int {
+	s.mu.RLock()
+	before := len(s.sessions)
+	loaded := s.sessionsLoaded
+	s.mu.RUnlock()
+	if !loaded {
+		return 0 // Not loaded yet: all sessions are read on first use
+	}
+
+	s.loadSessions()
+	s.mu.RLock()
+	added := len(s.sessions) - before
+	s.mu.RUnlock()
+
+	return added + s.refreshSessions()
+}
+
+// WatchDirs returns the directories where Claude CLI writes session files:
+// the projects directory first, then one directory per project
+func (s *Service) WatchDirs() []string {
+	projectsDir := claudeProjectsDir()
+	entries, err := os.ReadDir(projectsDir)
+	if err != nil {
+		return nil
+	}
+
+	dirs := []string{projectsDir}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, filepath.Join(projectsDir, entry.Name()))
+		}
+	}
+	return dirs
+}
+
+// SessionChanges lists the sessions changed by ApplyFileChanges
+type SessionChanges struct {
+	Added   []string // New sessions
+	Updated []string // Sessions with appended lines
+	Removed []string // Sessions whose file was deleted
+	Dirs    []string // New project directories, to watch as well
+}
+
+// Empty returns true if no session changed
+func (c SessionChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Updated) == 0 && len(c.Removed) == 0
+}
+
+// ApplyFileChanges updates the sessions from the changed paths of the
+// Claude CLI directories (see WatchDirs): only the files of these paths are
+// read, instead of scanning every project as DiscoverSessions does.
+func (s *Service) ApplyFileChanges(paths []string) SessionChanges {
+	var changes SessionChanges
+	s.mu.RLock()
+	loaded := s.sessionsLoaded
+	s.mu.RUnlock()
+	if !loaded {
+		return changes // Not loaded yet: all sessions are read on first use
+	}
+
+	projectsDir := claudeProjectsDir()
+	for _, path := range paths {
+		switch filepath.Dir(path) {
+		case projectsDir:
+			s.applyProjectDirChange(path, &changes)
+		default:
+			if filepath.Dir(filepath.Dir(path)) == projectsDir {
+				s.applySessionFileChange(path, &changes)
+			}
+		}
+	}
+	return changes
+}
+
+// applyProjectDirChange reads the sessions of a new project directory, or
+// drops the sessions of a deleted one
+func (s *Service) applyProjectDirChange(dir string, changes *SessionChanges) {
+	info, err := os.Stat(dir)
+	if err == nil && info.IsDir() {
+		changes.Dirs = append(changes.Dirs, dir)
+		sessions := s.loadProjectSessionsParallel(dir, filepath.Base(dir))
+		s.mu.Lock()
+		for id, sess := range sessions {
+			if _, known := s.sessions[id]; !known {
+				s.sessions[id] = sess
+				changes.Added = append(changes.Added, id)
+			}
+		}
+		s.mu.Unlock()
+		return
+	}
+	if !os.IsNotExist(err) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, sess := range s.sessions {
+		if sess.SessionFile != "" && filepath.Dir(sess.SessionFile) == dir && sess.State != SessionRunning {
+			delete(s.sessions, id)
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+}
+
+// applySessionFileChange reads a new session file, parses the lines
+// appended to a known one, or drops the session of a deleted one
+func (s *Service) applySessionFileChange(path string, changes *SessionChanges) {
+	name := filepath.Base(path)
+	if !strings.HasSuffix(name, ".jsonl") || strings.HasPrefix(name, "agent-") {
+		return
+	}
+	sessionID := strings.TrimSuffix(name, ".jsonl")
+	if !isValidUUID(sessionID) {
+		return
+	}
+
+	info, statErr := os.Stat(path)
+	s.mu.Lock()
+	sess, known := s.sessions[sessionID]
+	switch {
+	case statErr != nil:
+		if os.IsNotExist(statErr) && known && sess.SessionFile == path && sess.State != SessionRunning {
+			delete(s.sessions, sessionID)
+			changes.Removed = append(changes.Removed, sessionID)
+		}
+		s.mu.Unlock()
+		return
+	case known:
+		if s.refreshSession(sess) {
+			changes.Updated = append(changes.Updated, sessionID)
+		}
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+
+	session := s.readSessionFile(sessionID, path, filepath.Base(filepath.Dir(path)), info)
+	if session == nil {
+		return // No message yet: read again on its next write
+	}
+	s.mu.Lock()
+	if _, known := s.sessions[sessionID]; !known {
+		s.sessions[sessionID] = session
+		changes.Added = append(changes.Added, sessionID)
+	}
+	s.mu.Unlock()
 }
 
 // saveSessions is now a no-op since Claude CLI manages its own sessions
@@ -1674,12 +1984,56 @@ func (s *Service) ListSessions(projectID string) []SessionSummary {
 	var summaries []SessionSummary
 	for _, sess := range s.sessions {
 		if projectID == "" || sess.ProjectID == projectID {
-			summaries = append(summaries, sess.ToSummary())
+			summary := sess.ToSummary()
+			summary.ParentID = s.parents[sess.ID]
+			summaries = append(summaries, summary)
 		}
 	}
 	return summaries
 }
 
+// GetSessionSummary returns the summary of a session, false if unknown
+func (s *Service) GetSessionSummary(sessionID string) (SessionSummary, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sess, ok := s.sessions[sessionID]
+	if !ok {
+		return SessionSummary{}, false
+	}
+	summary := sess.ToSummary()
+	summary.ParentID = s.parents[sess.ID]
+	return summary, true
+}
+
+// StaleSessions returns the sessions a cleanup would remove, oldest first:
+// idle sessions inactive since before cutoff, with fewer than minMessages
+// messages (0 = any count), and without a custom name unless includeNamed
+func (s *Service) StaleSessions(cutoff time.Time, minMessages int, includeNamed bool) []SessionSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var stale []SessionSummary
+	for _, sess := range s.sessions {
+		if _, running := s.persistentProcs[sess.ID]; running || sess.State == SessionRunning || sess.State == SessionWaiting {
+			continue
+		}
+		if !sess.LastActiveAt.Before(cutoff) || (sess.CustomName != "" && !includeNamed) {
+			continue
+		}
+		if minMessages > 0 && sess.MessageCount >= minMessages {
+			continue
+		}
+		summary := sess.ToSummary()
+		summary.ParentID = s.parents[sess.ID]
+		stale = append(stale, summary)
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].LastActiveAt.Before(stale[j].LastActiveAt)
+	})
+	return stale
+}
+
 // ListSessionsForProject returns sessions for a specific project
 func (s *Service) ListSessionsForProject(projectID string) []*Session {
 	s.mu.RLock()
@@ -1695,38 +2049,85 @@ func (s *Service) ListSessionsForProject(projectID string) []*Session {
 }
 
 // RenameSession sets a custom name for a session
-// Note: Claude CLI doesn't have a native rename command, so we store custom names locally
-// Claude auto-generates summaries based on conversation content, but we can override with custom names
+// Custom names are stored locally and override the name Claude generates from the conversation.
+// With SetSyncNames, the name is also written to the session file.
 func (s *Service) RenameSession(sessionID, newName string) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	sess, ok := s.sessions[sessionID]
 	if !ok {
+		s.mu.Unlock()
 		return fmt.Errorf("session not found: %s", sessionID)
 	}
 
 	sess.CustomName = newName
 	s.customNames[sessionID] = newName
+	sessionFile, syncNames := sess.SessionFile, s.syncNames
+	s.mu.Unlock()
 
 	go s.saveCustomNames()
+	if syncNames {
+		return writeCustomTitle(sessionFile, sessionID, newName)
+	}
 	return nil
 }
 
 // ClearSessionCustomName removes the custom name for a session
 func (s *Service) ClearSessionCustomName(sessionID string) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	sess, ok := s.sessions[sessionID]
 	if !ok {
+		s.mu.Unlock()
 		return fmt.Errorf("session not found: %s", sessionID)
 	}
 
 	sess.CustomName = ""
 	delete(s.customNames, sessionID)
+	sessionFile, syncNames := sess.SessionFile, s.syncNames
+	s.mu.Unlock()
 
 	go s.saveCustomNames()
+	if syncNames {
+		return writeCustomTitle(sessionFile, sessionID, "")
+	}
+	return nil
+}
+
+// SetSyncNames enables writing the custom names to the session files, so
+// they survive the loss of DevTrack's data directory and show in Claude CLI
+func (s *Service) SetSyncNames(enabled bool) {
+	s.mu.Lock()
+	s.syncNames = enabled
+	s.mu.Unlock()
+}
+
+// writeCustomTitle appends a custom-title entry to a session file, the
+// entry Claude CLI writes when a session is renamed (an empty name clears
+// it). Appending a single line is safe while Claude CLI writes the file.
+// Sessions without a file yet (no message sent) keep the name locally.
+func writeCustomTitle(sessionFile, sessionID, name string) error {
+	if sessionFile == "" {
+		return nil
+	}
+	line, err := json.Marshal(struct {
+		Type        string `json:"type"`
+		CustomTitle string `json:"customTitle"`
+		SessionID   string `json:"sessionId"`
+	}{"custom-title", name, sessionID})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(sessionFile, os.O_WRONLY|os.O_APPEND, 0)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write name to session file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write name to session file: %w", err)
+	}
 	return nil
 }
 
@@ -1759,6 +2160,27 @@ func (s *Service) DeleteSession(sessionID string) error {
 	// Remove custom name if any
 	delete(s.customNames, sessionID)
 
+	// Forks of the session now descend from its parent
+	if len(s.parents) > 0 {
+		changed := false
+		for child, parent := range s.parents {
+			if parent == sessionID {
+				s.parents[child] = s.parents[sessionID]
+				if s.parents[child] == "" {
+					delete(s.parents, child)
+				}
+				changed = true
+			}
+		}
+		if _, ok := s.parents[sessionID]; ok {
+			delete(s.parents, sessionID)
+			changed = true
+		}
+		if changed {
+			s.saveSessionParents()
+		}
+	}
+
 	// Remove from in-memory map
 	delete(s.sessions, sessionID)
 
@@ -1768,6 +2190,91 @@ func (s *Service) DeleteSession(sessionID string) error {
 	return nil
 }
 
+// ForkSession duplicates a session into a new one resuming the same
+// conversation, so an alternative can be explored without changing the
+// original. The fork is linked to its parent.
+func (s *Service) ForkSession(sessionID, name string) (*Session, error) {
+	s.mu.RLock()
+	parent, ok := s.sessions[sessionID]
+	var parentFile, parentName, projectID, projectName string
+	if ok {
+		parentFile, parentName = parent.SessionFile, parent.DisplayName()
+		projectID, projectName = parent.ProjectID, parent.ProjectName
+	}
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	data, err := os.ReadFile(parentFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("session has no messages to fork")
+	}
+
+	// Same conversation under a new session ID, next to the original
+	forkID := GenerateSessionID()
+	data = bytes.ReplaceAll(data, []byte(`"sessionId":"`+sessionID+`"`), []byte(`"sessionId":"`+forkID+`"`))
+	forkFile := filepath.Join(filepath.Dir(parentFile), forkID+".jsonl")
+	if err := os.WriteFile(forkFile, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to create session file: %w", err)
+	}
+	fork := s.parseSessionMetadata(forkID, forkFile)
+	if fork == nil {
+		os.Remove(forkFile)
+		return nil, fmt.Errorf("failed to read session file: %s", forkFile)
+	}
+	if name == "" {
+		name = parentName + " (fork)"
+	}
+	fork.ProjectID, fork.ProjectName = projectID, projectName
+	fork.CustomName = name
+	fork.CreatedAt, fork.LastActiveAt = time.Now(), time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[forkID] = fork
+	s.customNames[forkID] = name
+	s.parents[forkID] = sessionID
+	go s.saveCustomNames()
+	if s.syncNames {
+		// Replaces the name copied with the parent's entries
+		if err := writeCustomTitle(forkFile, forkID, name); err != nil {
+			return fork, err
+		}
+	}
+	if err := s.saveSessionParents(); err != nil {
+		return fork, fmt.Errorf("failed to save the fork link: %w", err)
+	}
+	return fork, nil
+}
+
+// RestoreSession registers a session again from its JSONL file (undo of DeleteSession)
+func (s *Service) RestoreSession(sessionFile, customName string) (*Session, error) {
+	sessionID := strings.TrimSuffix(filepath.Base(sessionFile), ".jsonl")
+	session := s.parseSessionMetadata(sessionID, sessionFile)
+	if session == nil {
+		return nil, fmt.Errorf("failed to read session file: %s", sessionFile)
+	}
+	if session.ProjectName == "" {
+		session.ProjectName = filepath.Base(filepath.Dir(sessionFile))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if customName != "" {
+		session.CustomName = customName
+		s.customNames[sessionID] = customName
+		go s.saveCustomNames()
+	}
+	s.sessions[sessionID] = session
+
+	return session, nil
+}
+
 // SendMessage sends a message to Claude in a session
 func (s *Service) SendMessage(ctx context.Context, sessionID, message string, outputChan chan<- ClaudeOutput) error {
 	s.mu.Lock()
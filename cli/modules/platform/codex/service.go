@@ -1,6 +1,7 @@
 package codex
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -8,6 +9,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"csd-devtrack/cli/modules/platform/tracing"
 )
 
 // Service manages Codex sessions
@@ -159,6 +162,9 @@ func (s *Service) Refresh() {
 
 // discoverSessions discovers existing sessions from ~/.codex/
 func (s *Service) discoverSessions() {
+	_, span := tracing.Start(context.Background(), "codex.discover_sessions")
+	defer span.End()
+
 	// Check if base directory exists
 	if _, err := os.Stat(s.baseDir); os.IsNotExist(err) {
 		return
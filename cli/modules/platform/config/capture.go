@@ -0,0 +1,45 @@
+package config
+
+// CaptureConfig is the capture proxy: a local HTTP proxy the components
+// marked capture: true are pointed at (HTTP_PROXY), recording their outbound
+// requests for the Requests panel
+type CaptureConfig struct {
+	Enabled      bool  `yaml:"enabled" json:"enabled"`                                 // Started with DevTrack
+	Port         int   `yaml:"port,omitempty" json:"port,omitempty"`                   // Listening port on localhost (default: 8899)
+	InterceptTLS *bool `yaml:"intercept_tls,omitempty" json:"intercept_tls,omitempty"` // Decrypt HTTPS with a local CA (default: true)
+	MaxEntries   int   `yaml:"max_entries,omitempty" json:"max_entries,omitempty"`     // Requests kept, oldest dropped first (default: 500)
+}
+
+// DefaultCaptureConfig returns the default capture proxy configuration
+func DefaultCaptureConfig() *CaptureConfig {
+	intercept := true
+	return &CaptureConfig{
+		Port:         8899,
+		InterceptTLS: &intercept,
+		MaxEntries:   500,
+	}
+}
+
+// GetCaptureConfig returns the capture proxy config, applying defaults
+func (s *Settings) GetCaptureConfig() *CaptureConfig {
+	cfg := DefaultCaptureConfig()
+	if s.Capture == nil {
+		return cfg
+	}
+	cfg.Enabled = s.Capture.Enabled
+	if s.Capture.Port > 0 {
+		cfg.Port = s.Capture.Port
+	}
+	if s.Capture.InterceptTLS != nil {
+		cfg.InterceptTLS = s.Capture.InterceptTLS
+	}
+	if s.Capture.MaxEntries > 0 {
+		cfg.MaxEntries = s.Capture.MaxEntries
+	}
+	return cfg
+}
+
+// InterceptsTLS returns true if HTTPS requests are decrypted
+func (c *CaptureConfig) InterceptsTLS() bool {
+	return c.InterceptTLS == nil || *c.InterceptTLS
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"net"
+)
+
+// DashboardConfig is the web dashboard served by the daemon: project status,
+// processes, builds and logs, read-only, for a glance from a browser or a
+// phone
+type DashboardConfig struct {
+	Enabled bool   `yaml:"enabled" json:"enabled"`                   // Started with the daemon
+	Port    int    `yaml:"port,omitempty" json:"port,omitempty"`     // Listening port (default: 9096)
+	Listen  string `yaml:"listen,omitempty" json:"listen,omitempty"` // Listening address (default: 127.0.0.1, 0.0.0.0 for phones on the LAN)
+	Token   string `yaml:"token,omitempty" json:"token,omitempty"`   // Required as ?token= when set, and when listening beyond localhost (team tokens also accepted over TLS in team mode)
+}
+
+// DefaultDashboardConfig returns the default web dashboard configuration
+func DefaultDashboardConfig() *DashboardConfig {
+	return &DashboardConfig{
+		Port:   9096,
+		Listen: "127.0.0.1",
+	}
+}
+
+// GetDashboardConfig returns the web dashboard config, applying defaults
+func (s *Settings) GetDashboardConfig() *DashboardConfig {
+	cfg := DefaultDashboardConfig()
+	if s.Dashboard == nil {
+		return cfg
+	}
+	cfg.Enabled = s.Dashboard.Enabled
+	if s.Dashboard.Port > 0 {
+		cfg.Port = s.Dashboard.Port
+	}
+	if s.Dashboard.Listen != "" {
+		cfg.Listen = s.Dashboard.Listen
+	}
+	cfg.Token = s.Dashboard.Token
+	return cfg
+}
+
+// ResolveSecrets resolves the secret reference of the token
+func (c *DashboardConfig) ResolveSecrets(cfg *Config) error {
+	token, err := cfg.ResolveSecrets(c.Token)
+	if err != nil {
+		return fmt.Errorf("dashboard.token: %w", err)
+	}
+	c.Token = token
+	return nil
+}
+
+// Local returns true if the dashboard only listens on the loopback interface
+func (c *DashboardConfig) Local() bool {
+	if c.Listen == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(c.Listen)
+	return ip != nil && ip.IsLoopback()
+}
+
+// DashboardTLS returns true if the dashboard is served over TLS: beyond
+// localhost in team mode, with the team certificate. Team tokens are only
+// accepted over TLS, never in cleartext.
+func (s *Settings) DashboardTLS() bool {
+	return !s.GetDashboardConfig().Local() && s.GetTeamConfig().Enabled
+}
+
+// validateDashboard checks the port of the dashboard and that it does not
+// show the projects to the network without a token: its own, or the team's
+// over TLS
+func (c *Config) validateDashboard() []string {
+	if c.Settings == nil || c.Settings.Dashboard == nil {
+		return nil
+	}
+	var errors []string
+	if c.Settings.Dashboard.Port < 0 || c.Settings.Dashboard.Port > 65535 {
+		errors = append(errors, "dashboard.port must be between 1 and 65535")
+	}
+	if cfg := c.Settings.GetDashboardConfig(); !cfg.Local() && cfg.Token == "" && !c.Settings.DashboardTLS() {
+		errors = append(errors, "dashboard.token (or team mode, served over TLS) is required when dashboard.listen is not a loopback address")
+	}
+	return errors
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // GetDefaultConfigPath returns the default config file path
@@ -46,6 +47,48 @@ func GetDataDir() (string, error) {
 	return filepath.Join(homeDir, ".local", "share", "csd-devtrack"), nil
 }
 
+// GetPluginsDir returns the directory scanned for plugin executables
+// (configured directory with ~ expanded, default: <data dir>/plugins)
+func GetPluginsDir(cfg *PluginsConfig) (string, error) {
+	if cfg != nil && cfg.Dir != "" {
+		if strings.HasPrefix(cfg.Dir, "~/") {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			return filepath.Join(homeDir, cfg.Dir[2:]), nil
+		}
+		return cfg.Dir, nil
+	}
+
+	dataDir, err := GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "plugins"), nil
+}
+
+// GetRecordingsDir returns the directory of the terminal recordings
+// (configured directory with ~ expanded, default: <data dir>/recordings)
+func GetRecordingsDir(cfg *RecordingConfig) (string, error) {
+	if cfg != nil && cfg.Dir != "" {
+		if strings.HasPrefix(cfg.Dir, "~/") {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			return filepath.Join(homeDir, cfg.Dir[2:]), nil
+		}
+		return cfg.Dir, nil
+	}
+
+	dataDir, err := GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "recordings"), nil
+}
+
 // EnsureDirectories creates all necessary directories
 func EnsureDirectories() error {
 	dirs := []func() (string, error){
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// EventBridgeConfig is the bridge publishing the domain events of DevTrack
+// (build finished, process crashed, git changed...) to NATS or Redis
+// pub/sub, for external automation
+type EventBridgeConfig struct {
+	Enabled bool     `yaml:"enabled" json:"enabled"`                   // Started with the daemon
+	URL     string   `yaml:"url" json:"url"`                           // nats://[token@]host:4222, redis://[:password@]host:6379 or rediss:// (TLS)
+	Prefix  string   `yaml:"prefix,omitempty" json:"prefix,omitempty"` // Subject or channel prefix (default: devtrack, e.g. devtrack.build_finished)
+	Events  []string `yaml:"events,omitempty" json:"events,omitempty"` // Published events (default: all the domain events)
+}
+
+// DefaultEventBridgeConfig returns the default event bridge configuration
+func DefaultEventBridgeConfig() *EventBridgeConfig {
+	return &EventBridgeConfig{
+		Prefix: "devtrack",
+	}
+}
+
+// GetEventBridgeConfig returns the event bridge config, applying defaults
+func (s *Settings) GetEventBridgeConfig() *EventBridgeConfig {
+	cfg := DefaultEventBridgeConfig()
+	if s.EventBridge == nil {
+		return cfg
+	}
+	cfg.Enabled = s.EventBridge.Enabled
+	cfg.URL = s.EventBridge.URL
+	if s.EventBridge.Prefix != "" {
+		cfg.Prefix = s.EventBridge.Prefix
+	}
+	cfg.Events = s.EventBridge.Events
+	return cfg
+}
+
+// ResolveSecrets resolves the secret references of the URL
+func (c *EventBridgeConfig) ResolveSecrets(cfg *Config) error {
+	u, err := cfg.ResolveSecrets(c.URL)
+	if err != nil {
+		return fmt.Errorf("event_bridge.url: %w", err)
+	}
+	c.URL = u
+	return nil
+}
+
+// validateEventBridge checks the URL of the event bridge
+func (c *Config) validateEventBridge() []string {
+	if c.Settings == nil || c.Settings.EventBridge == nil {
+		return nil
+	}
+	bridge := c.Settings.EventBridge
+	if bridge.URL == "" {
+		if bridge.Enabled {
+			return []string{"event_bridge.url is required"}
+		}
+		return nil
+	}
+	if HasSecretRefs(bridge.URL) {
+		// Checked when the bridge connects
+		return nil
+	}
+	u, err := url.Parse(bridge.URL)
+	if err != nil {
+		return []string{fmt.Sprintf("event_bridge.url: %v", err)}
+	}
+	switch u.Scheme {
+	case "nats", "redis", "rediss":
+	default:
+		return []string{"event_bridge.url must start with nats://, redis:// or rediss://"}
+	}
+	if u.Host == "" {
+		return []string{"event_bridge.url: host is required"}
+	}
+	return nil
+}
@@ -23,11 +23,14 @@ var (
 	globalConfigPath string
 	// configMutex protects config access
 	configMutex sync.RWMutex
+	// readOnly prevents SaveGlobal from writing the config file (observer mode)
+	readOnly bool
 )
 
 // Loader handles configuration loading and saving
 type Loader struct {
 	configPath string
+	migration  *MigrationReport // Migrations applied by the last load
 }
 
 // NewLoader creates a new config loader
@@ -64,6 +67,12 @@ func (l *Loader) LoadWithCreate(createIfMissing bool) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	original := data
+	data, l.migration, err = migrateConfig(data)
+	if err != nil {
+		return nil, err
+	}
+
 	var config Config
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
@@ -89,9 +98,27 @@ func (l *Loader) LoadWithCreate(createIfMissing bool) (*Config, error) {
 		config.BuildProfiles = DefaultBuildProfiles()
 	}
 
+	// Migrated config saved, the original kept next to it. If the file
+	// cannot be written, the config is migrated again on each load.
+	if l.migration != nil && !l.migration.Newer {
+		l.migration.Backup, err = backupConfigFile(l.configPath, original, l.migration.From)
+		if err == nil {
+			err = l.Save(&config)
+		}
+		if err != nil {
+			l.migration.SaveError = err.Error()
+		}
+	}
+
 	return &config, nil
 }
 
+// Migration returns the migrations applied by the last load, nil if the
+// config was current
+func (l *Loader) Migration() *MigrationReport {
+	return l.migration
+}
+
 // Save saves configuration to file
 func (l *Loader) Save(config *Config) error {
 	// Ensure directory exists
@@ -199,6 +226,10 @@ func LoadGlobalWithCreate(configPath string, createIfMissing bool) error {
 
 	globalConfig = config
 	globalConfigPath = configPath
+	lastMigration = loader.Migration()
+	if lastMigration != nil && lastMigration.Newer {
+		readOnly = true // Saving would drop the settings this version does not know
+	}
 
 	return nil
 }
@@ -274,11 +305,29 @@ func GetGlobalPath() string {
 	return globalConfigPath
 }
 
+// SetReadOnly enables or disables read-only mode (SaveGlobal returns an error)
+func SetReadOnly(enabled bool) {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+	readOnly = enabled
+}
+
+// IsReadOnly returns true if the config file must not be written
+func IsReadOnly() bool {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return readOnly
+}
+
 // SaveGlobal saves the global configuration
 func SaveGlobal() error {
 	configMutex.Lock()
 	defer configMutex.Unlock()
 
+	if readOnly {
+		return fmt.Errorf("read-only mode: config not saved")
+	}
+
 	if globalConfig == nil {
 		return fmt.Errorf("no global config loaded")
 	}
@@ -0,0 +1,45 @@
+package config
+
+import "fmt"
+
+// LogStreamConfig is the log stream of the daemon: a WebSocket endpoint on
+// localhost streaming the unified log feed to external tools, browser
+// extensions or a web UI, without attaching the TUI
+type LogStreamConfig struct {
+	Enabled        bool     `yaml:"enabled" json:"enabled"`                                     // Started with the daemon
+	Port           int      `yaml:"port,omitempty" json:"port,omitempty"`                       // Listening port on localhost (default: 9097)
+	Token          string   `yaml:"token,omitempty" json:"token,omitempty"`                     // Required as ?token= or a Bearer token when set
+	AllowedOrigins []string `yaml:"allowed_origins,omitempty" json:"allowed_origins,omitempty"` // Browser origins allowed besides localhost, e.g. chrome-extension://ID
+}
+
+// DefaultLogStreamConfig returns the default log stream configuration
+func DefaultLogStreamConfig() *LogStreamConfig {
+	return &LogStreamConfig{
+		Port: 9097,
+	}
+}
+
+// GetLogStreamConfig returns the log stream config, applying defaults
+func (s *Settings) GetLogStreamConfig() *LogStreamConfig {
+	cfg := DefaultLogStreamConfig()
+	if s.LogStream == nil {
+		return cfg
+	}
+	cfg.Enabled = s.LogStream.Enabled
+	if s.LogStream.Port > 0 {
+		cfg.Port = s.LogStream.Port
+	}
+	cfg.Token = s.LogStream.Token
+	cfg.AllowedOrigins = s.LogStream.AllowedOrigins
+	return cfg
+}
+
+// ResolveSecrets resolves the secret reference of the token
+func (c *LogStreamConfig) ResolveSecrets(cfg *Config) error {
+	token, err := cfg.ResolveSecrets(c.Token)
+	if err != nil {
+		return fmt.Errorf("log_stream.token: %w", err)
+	}
+	c.Token = token
+	return nil
+}
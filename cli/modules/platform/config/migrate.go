@@ -0,0 +1,140 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentSchema is the config format written by this version. Configs of an
+// older schema are migrated on load; bump it with each format change and
+// add the migration from the previous schema to configMigrations.
+const CurrentSchema = 1
+
+// configMigration upgrades the raw config of schema From to From+1
+type configMigration struct {
+	From        int
+	Description string
+	Apply       func(raw map[string]interface{}) error
+}
+
+// configMigrations are applied in turn from the schema of the file.
+// Configs written before schema versioning have schema 0.
+var configMigrations = []configMigration{
+	{
+		From:        0,
+		Description: "settings.log_level and settings.log_buffer_size moved to settings.logger",
+		Apply:       migrateLegacyLogger,
+	},
+}
+
+// MigrationReport describes the migrations applied to the loaded config
+type MigrationReport struct {
+	From, To  int
+	Applied   []string // Descriptions of the applied migrations
+	Backup    string   // Copy of the original file
+	Newer     bool     // Written by a newer DevTrack: loaded read-only, not migrated
+	SaveError string   // The migrated config could not be saved
+}
+
+// String summarizes the report, one line per migration
+func (r *MigrationReport) String() string {
+	if r.Newer {
+		return fmt.Sprintf("Config schema %d is newer than this version supports (%d): config loaded read-only", r.From, r.To)
+	}
+	summary := fmt.Sprintf("Config migrated from schema %d to %d (original saved as %s)", r.From, r.To, r.Backup)
+	if r.SaveError != "" {
+		summary = fmt.Sprintf("Config migrated from schema %d to %d in memory only: %s", r.From, r.To, r.SaveError)
+	}
+	for _, applied := range r.Applied {
+		summary += "\n  - " + applied
+	}
+	return summary
+}
+
+// lastMigration is the report of the last load of the global config (nil
+// if the config was current)
+var lastMigration *MigrationReport
+
+// LastMigration returns the migrations applied when the global config was
+// loaded, nil if it was already current
+func LastMigration() *MigrationReport {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return lastMigration
+}
+
+// migrateConfig upgrades raw config data to CurrentSchema. Returns the data
+// unchanged and a nil report if the config is current.
+func migrateConfig(data []byte) ([]byte, *MigrationReport, error) {
+	raw := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	schema := 0
+	if value, ok := raw["schema"].(int); ok {
+		schema = value
+	}
+	if schema == CurrentSchema {
+		return data, nil, nil
+	}
+	report := &MigrationReport{From: schema, To: CurrentSchema}
+	if schema > CurrentSchema {
+		report.Newer = true
+		return data, report, nil
+	}
+
+	for _, migration := range configMigrations {
+		if migration.From < schema {
+			continue
+		}
+		if err := migration.Apply(raw); err != nil {
+			return nil, nil, fmt.Errorf("config migration from schema %d failed: %w", migration.From, err)
+		}
+		report.Applied = append(report.Applied, migration.Description)
+	}
+	raw["schema"] = CurrentSchema
+
+	migrated, err := yaml.Marshal(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal migrated config: %w", err)
+	}
+	return migrated, report, nil
+}
+
+// backupConfigFile copies the original config before its migration, next to
+// it: csd-devtrack.yaml.schema0.bak
+func backupConfigFile(configPath string, data []byte, schema int) (string, error) {
+	backupPath := fmt.Sprintf("%s.schema%d.bak", configPath, schema)
+	if err := os.WriteFile(backupPath, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to back up config before migration: %w", err)
+	}
+	return backupPath, nil
+}
+
+// migrateLegacyLogger moves the legacy logging settings into the logger
+// section (unless it exists: it took precedence already)
+func migrateLegacyLogger(raw map[string]interface{}) error {
+	settings, ok := raw["settings"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	level, hasLevel := settings["log_level"]
+	bufferSize, hasBufferSize := settings["log_buffer_size"]
+	delete(settings, "log_level")
+	delete(settings, "log_buffer_size")
+	if _, exists := settings["logger"]; exists || (!hasLevel && !hasBufferSize) {
+		return nil
+	}
+
+	logger := DefaultLoggerConfig()
+	if value, ok := level.(string); hasLevel && ok && value != "" {
+		logger.Level = value
+	}
+	if size, ok := bufferSize.(int); hasBufferSize && ok && size > 0 {
+		logger.BufferSize = size
+	}
+	settings["logger"] = logger
+	return nil
+}
@@ -1,16 +1,23 @@
 package config
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"csd-devtrack/cli/modules/core/projects"
 )
 
 // Config represents the main configuration
 type Config struct {
 	Version        string                    `yaml:"version"`
+	Schema         int                       `yaml:"schema"` // Format version, migrated on load (see CurrentSchema)
 	Settings       *Settings                 `yaml:"settings"`
 	Projects       []projects.Project        `yaml:"projects"`
 	BuildProfiles  map[string]*BuildProfile  `yaml:"build_profiles,omitempty"`
 	WidgetProfiles map[string]*WidgetProfile `yaml:"widget_profiles,omitempty"`
+	Services       map[string]*ServiceConfig `yaml:"services,omitempty"` // Infrastructure the components depend on
+	Secrets        map[string]string         `yaml:"secrets,omitempty"`  // Encrypted values, referenced as ${secret:NAME}
 }
 
 // WidgetType represents the type of widget
@@ -102,6 +109,9 @@ type Settings struct {
 	// Logger configuration
 	Logger *LoggerConfig `yaml:"logger,omitempty" json:"logger,omitempty"`
 
+	// Throughput limit of chatty log sources (log storms)
+	LogRate *LogRateConfig `yaml:"log_rate,omitempty" json:"log_rate,omitempty"`
+
 	// Web server
 	WebEnabled    bool `yaml:"web_enabled" json:"web_enabled"`
 	WebPort       int  `yaml:"web_port" json:"web_port"`
@@ -113,9 +123,17 @@ type Settings struct {
 	CSDCoreFederation bool   `yaml:"csd_core_federation" json:"csd_core_federation"`
 
 	// UI settings
-	Theme          string `yaml:"theme" json:"theme"` // dark, light, auto
-	RefreshRate    int    `yaml:"refresh_rate" json:"refresh_rate"` // ms
-	ShowTimestamps bool   `yaml:"show_timestamps" json:"show_timestamps"`
+	Theme                  string `yaml:"theme" json:"theme"`               // dark, light, auto
+	RefreshRate            int    `yaml:"refresh_rate" json:"refresh_rate"` // ms (UI tick: header events, current view)
+	ShowTimestamps         bool   `yaml:"show_timestamps" json:"show_timestamps"`
+	ScreenReader           bool   `yaml:"screen_reader,omitempty" json:"screen_reader,omitempty"`                       // Plain-text rendering (no colors, borders or emoji)
+	DisableSyntaxHighlight bool   `yaml:"disable_syntax_highlight,omitempty" json:"disable_syntax_highlight,omitempty"` // Plain diffs and previews (slow terminals)
+	VimKeys                bool   `yaml:"vim_keys,omitempty" json:"vim_keys,omitempty"`                                 // Vim-style navigation (h/l, gg/G, : command mode)
+	WindowTitle            string `yaml:"window_title,omitempty" json:"window_title,omitempty"`                         // Window title format: {status} {view} {running} {crashed}, "off" to leave it (default "devtrack: {status}")
+	Hyperlinks             string `yaml:"hyperlinks,omitempty" json:"hyperlinks,omitempty"`                             // Clickable URLs (OSC 8): auto (default, known terminals), on, off
+
+	// Order of the Projects and Dashboard trees (favorites pinned first)
+	ProjectOrder *ProjectOrderConfig `yaml:"project_order,omitempty" json:"project_order,omitempty"`
 
 	// Browser settings
 	BrowserPath string `yaml:"browser_path,omitempty" json:"browser_path,omitempty"` // Default path for file browser (default: home directory)
@@ -126,6 +144,66 @@ type Settings struct {
 	// External executables configuration
 	Executables *ExecutablesConfig `yaml:"executables,omitempty" json:"executables,omitempty"`
 
+	// Security scanning (govulncheck, npm audit)
+	Security *SecurityConfig `yaml:"security,omitempty" json:"security,omitempty"`
+
+	// Trash for destructive actions (undo)
+	Trash *TrashConfig `yaml:"trash,omitempty" json:"trash,omitempty"`
+
+	// Audit log of state-changing actions
+	Audit *AuditConfig `yaml:"audit,omitempty" json:"audit,omitempty"`
+
+	// Plugins (external executables adding views, actions and project data)
+	Plugins *PluginsConfig `yaml:"plugins,omitempty" json:"plugins,omitempty"`
+
+	// Recording of the embedded terminals (tmux backend)
+	Recording *RecordingConfig `yaml:"recording,omitempty" json:"recording,omitempty"`
+
+	// Auto-suspend of idle embedded terminals (tmux backend)
+	Suspend *SuspendConfig `yaml:"suspend,omitempty" json:"suspend,omitempty"`
+
+	// Automatic restart of crashed processes (crash-loop protection)
+	Restart *RestartConfig `yaml:"restart,omitempty" json:"restart,omitempty"`
+
+	// Shell commands run on build, process and git events
+	Hooks []HookConfig `yaml:"hooks,omitempty" json:"hooks,omitempty"`
+
+	// Self-update from GitHub releases
+	Update *UpdateConfig `yaml:"update,omitempty" json:"update,omitempty"`
+
+	// Built-in reverse proxy: one local entrypoint for the components
+	Proxy *ProxyConfig `yaml:"proxy,omitempty" json:"proxy,omitempty"`
+
+	// Capture proxy recording the outbound requests of the components
+	Capture *CaptureConfig `yaml:"capture,omitempty" json:"capture,omitempty"`
+
+	// WebSocket endpoint of the daemon streaming the logs
+	LogStream *LogStreamConfig `yaml:"log_stream,omitempty" json:"log_stream,omitempty"`
+
+	// Read-only web dashboard served by the daemon
+	Dashboard *DashboardConfig `yaml:"dashboard,omitempty" json:"dashboard,omitempty"`
+
+	// Team mode: the daemon shared over the network with teammates
+	Team *TeamConfig `yaml:"team,omitempty" json:"team,omitempty"`
+
+	// Domain events published to NATS or Redis for external automation
+	EventBridge *EventBridgeConfig `yaml:"event_bridge,omitempty" json:"event_bridge,omitempty"`
+
+	// OpenTelemetry tracing of DevTrack's own operations
+	Tracing *TracingConfig `yaml:"tracing,omitempty" json:"tracing,omitempty"`
+
+	// Key of the encrypted secrets section
+	Secrets *SecretsConfig `yaml:"secrets,omitempty" json:"secrets,omitempty"`
+
+	// Confirmation dialogs (expert mode and per-action overrides)
+	Confirmations *ConfirmationsConfig `yaml:"confirmations,omitempty" json:"confirmations,omitempty"`
+
+	// Refresh intervals per subsystem (git, processes, metrics, Claude sessions)
+	Polling *PollingConfig `yaml:"polling,omitempty" json:"polling,omitempty"`
+
+	// Remote shell targets for the Shell view
+	SSH *SSHConfig `yaml:"ssh,omitempty" json:"ssh,omitempty"`
+
 	// Widgets view
 	ActiveWidgetProfile string `yaml:"active_widget_profile,omitempty" json:"active_widget_profile,omitempty"`
 }
@@ -151,9 +229,27 @@ type ExecutablesConfig struct {
 	Node string `yaml:"node,omitempty" json:"node,omitempty"`
 	Npm  string `yaml:"npm,omitempty" json:"npm,omitempty"`
 
+	// Security scanners
+	Govulncheck string `yaml:"govulncheck,omitempty" json:"govulncheck,omitempty"`
+
+	// API clients
+	Grpcurl string `yaml:"grpcurl,omitempty" json:"grpcurl,omitempty"`
+
+	// Container engine: docker or podman
+	Docker string `yaml:"docker,omitempty" json:"docker,omitempty"`
+
 	// System tools
 	Tmux string `yaml:"tmux,omitempty" json:"tmux,omitempty"`
 	Sudo string `yaml:"sudo,omitempty" json:"sudo,omitempty"`
+	SSH  string `yaml:"ssh,omitempty" json:"ssh,omitempty"`
+
+	// Terminal emulator for popped-out sessions: a command, {cmd} standing
+	// for the tmux attach command (empty = $TERMINAL, then auto-detect)
+	Terminal string `yaml:"terminal,omitempty" json:"terminal,omitempty"`
+
+	// File transfers
+	Rsync string `yaml:"rsync,omitempty" json:"rsync,omitempty"`
+	SCP   string `yaml:"scp,omitempty" json:"scp,omitempty"`
 }
 
 // ClaudeConfig represents Claude AI integration settings
@@ -178,6 +274,56 @@ type ClaudeConfig struct {
 
 	// Sessions data directory (for storing session history)
 	SessionsDir string `yaml:"sessions_dir,omitempty" json:"sessions_dir,omitempty"`
+
+	// Write custom session names to the session files too (the entry Claude
+	// CLI writes on rename): names survive the loss of DevTrack's data
+	// directory and show in Claude CLI and other tools reading the files
+	SyncNames bool `yaml:"sync_names,omitempty" json:"sync_names,omitempty"`
+
+	// Notify when a session that was processing goes idle while another view is shown
+	NotifyOnIdle bool `yaml:"notify_on_idle,omitempty" json:"notify_on_idle,omitempty"`
+
+	// Key sending a chat message: enter (default, Shift+Enter inserts a
+	// newline) or alt+enter (Enter inserts a newline)
+	SendKey string `yaml:"send_key,omitempty" json:"send_key,omitempty"`
+
+	// Cleanup of old sessions (nil = defaults, applied on request only)
+	Retention *ClaudeRetentionConfig `yaml:"retention,omitempty" json:"retention,omitempty"`
+}
+
+// ClaudeRetentionConfig selects the stale Claude sessions removed by a cleanup
+type ClaudeRetentionConfig struct {
+	// Remove the stale sessions at startup (otherwise only from the preview)
+	Auto bool `yaml:"auto,omitempty" json:"auto,omitempty"`
+
+	// Sessions inactive for this many days are stale
+	MaxAgeDays int `yaml:"max_age_days,omitempty" json:"max_age_days,omitempty"`
+
+	// Only sessions with fewer messages are stale (0 = any count)
+	MinMessages int `yaml:"min_messages,omitempty" json:"min_messages,omitempty"`
+
+	// Also remove sessions with a custom name
+	IncludeNamed bool `yaml:"include_named,omitempty" json:"include_named,omitempty"`
+}
+
+// DefaultClaudeRetentionConfig returns the default session cleanup policy
+func DefaultClaudeRetentionConfig() *ClaudeRetentionConfig {
+	return &ClaudeRetentionConfig{
+		MaxAgeDays:  30,
+		MinMessages: 5,
+	}
+}
+
+// GetClaudeRetentionConfig returns the session cleanup policy, applying defaults
+func (s *Settings) GetClaudeRetentionConfig() *ClaudeRetentionConfig {
+	if s.Claude == nil || s.Claude.Retention == nil {
+		return DefaultClaudeRetentionConfig()
+	}
+	retention := *s.Claude.Retention
+	if retention.MaxAgeDays <= 0 {
+		retention.MaxAgeDays = DefaultClaudeRetentionConfig().MaxAgeDays
+	}
+	return &retention
 }
 
 // DefaultClaudeConfig returns default Claude configuration
@@ -193,6 +339,578 @@ func DefaultClaudeConfig() *ClaudeConfig {
 	}
 }
 
+// SecurityConfig represents vulnerability scanning settings
+type SecurityConfig struct {
+	// Run a scan automatically after each successful build
+	ScanAfterBuild bool `yaml:"scan_after_build" json:"scan_after_build"`
+
+	// Scan timeout per component in seconds
+	TimeoutSec int `yaml:"timeout_sec,omitempty" json:"timeout_sec,omitempty"`
+}
+
+// DefaultSecurityConfig returns default security scanning configuration
+func DefaultSecurityConfig() *SecurityConfig {
+	return &SecurityConfig{
+		ScanAfterBuild: false,
+		TimeoutSec:     300,
+	}
+}
+
+// GetSecurityConfig returns the security config, applying defaults
+func (s *Settings) GetSecurityConfig() *SecurityConfig {
+	if s.Security == nil {
+		return DefaultSecurityConfig()
+	}
+	if s.Security.TimeoutSec <= 0 {
+		cfg := *s.Security
+		cfg.TimeoutSec = DefaultSecurityConfig().TimeoutSec
+		return &cfg
+	}
+	return s.Security
+}
+
+// TrashConfig represents retention settings for deleted items
+type TrashConfig struct {
+	// Number of days a deleted item stays recoverable
+	RetentionDays int `yaml:"retention_days,omitempty" json:"retention_days,omitempty"`
+}
+
+// DefaultTrashConfig returns default trash configuration
+func DefaultTrashConfig() *TrashConfig {
+	return &TrashConfig{
+		RetentionDays: 7,
+	}
+}
+
+// GetTrashConfig returns the trash config, applying defaults
+func (s *Settings) GetTrashConfig() *TrashConfig {
+	if s.Trash == nil || s.Trash.RetentionDays <= 0 {
+		return DefaultTrashConfig()
+	}
+	return s.Trash
+}
+
+// AuditConfig represents the audit log settings
+type AuditConfig struct {
+	// Disable recording of state-changing actions
+	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+
+	// Number of entries kept (older entries are dropped)
+	MaxEntries int `yaml:"max_entries,omitempty" json:"max_entries,omitempty"`
+}
+
+// DefaultAuditConfig returns default audit configuration
+func DefaultAuditConfig() *AuditConfig {
+	return &AuditConfig{
+		MaxEntries: 10000,
+	}
+}
+
+// GetAuditConfig returns the audit config, applying defaults
+func (s *Settings) GetAuditConfig() *AuditConfig {
+	if s.Audit == nil {
+		return DefaultAuditConfig()
+	}
+	if s.Audit.MaxEntries <= 0 {
+		cfg := *s.Audit
+		cfg.MaxEntries = DefaultAuditConfig().MaxEntries
+		return &cfg
+	}
+	return s.Audit
+}
+
+// PluginsConfig represents the plugin executables settings
+type PluginsConfig struct {
+	// Directory scanned for plugin executables (default: <data dir>/plugins)
+	Dir string `yaml:"dir,omitempty" json:"dir,omitempty"`
+
+	// Plugins not loaded (file name without extension)
+	Disabled []string `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+
+	// Timeout of describe, view and data requests (seconds)
+	Timeout int `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+
+	// Timeout of actions (seconds)
+	ActionTimeout int `yaml:"action_timeout,omitempty" json:"action_timeout,omitempty"`
+}
+
+// DefaultPluginsConfig returns default plugins configuration
+func DefaultPluginsConfig() *PluginsConfig {
+	return &PluginsConfig{
+		Timeout:       10,
+		ActionTimeout: 300,
+	}
+}
+
+// GetPluginsConfig returns the plugins config, applying defaults
+func (s *Settings) GetPluginsConfig() *PluginsConfig {
+	cfg := DefaultPluginsConfig()
+	if s.Plugins == nil {
+		return cfg
+	}
+	cfg.Dir = s.Plugins.Dir
+	cfg.Disabled = s.Plugins.Disabled
+	if s.Plugins.Timeout > 0 {
+		cfg.Timeout = s.Plugins.Timeout
+	}
+	if s.Plugins.ActionTimeout > 0 {
+		cfg.ActionTimeout = s.Plugins.ActionTimeout
+	}
+	return cfg
+}
+
+// RecordingConfig represents the terminal recording settings
+type RecordingConfig struct {
+	// Record the Claude and Codex terminals when they start
+	AutoRecord bool `yaml:"auto_record,omitempty" json:"auto_record,omitempty"`
+
+	// Directory of the recordings (default: <data dir>/recordings)
+	Dir string `yaml:"dir,omitempty" json:"dir,omitempty"`
+}
+
+// DefaultRecordingConfig returns default recording configuration
+func DefaultRecordingConfig() *RecordingConfig {
+	return &RecordingConfig{}
+}
+
+// GetRecordingConfig returns the recording config, applying defaults
+func (s *Settings) GetRecordingConfig() *RecordingConfig {
+	if s.Recording == nil {
+		return DefaultRecordingConfig()
+	}
+	return s.Recording
+}
+
+// Suspend actions of idle terminals
+const (
+	SuspendDetach = "detach" // Stop mirroring, the tmux session keeps running
+	SuspendStop   = "stop"   // Also end the Claude and Codex sessions (resumed with their conversation)
+)
+
+// SuspendConfig represents the auto-suspend of idle terminals
+type SuspendConfig struct {
+	// Suspend terminals without output for this many minutes (0 = never)
+	IdleMinutes int `yaml:"idle_minutes,omitempty" json:"idle_minutes,omitempty"`
+
+	// Action: detach or stop (shells and database clients are always detached)
+	Action string `yaml:"action,omitempty" json:"action,omitempty"`
+}
+
+// DefaultSuspendConfig returns default auto-suspend configuration
+func DefaultSuspendConfig() *SuspendConfig {
+	return &SuspendConfig{Action: SuspendDetach}
+}
+
+// GetSuspendConfig returns the auto-suspend config, applying defaults
+func (s *Settings) GetSuspendConfig() *SuspendConfig {
+	cfg := DefaultSuspendConfig()
+	if s.Suspend == nil {
+		return cfg
+	}
+	cfg.IdleMinutes = s.Suspend.IdleMinutes
+	if s.Suspend.Action == SuspendStop {
+		cfg.Action = SuspendStop
+	}
+	return cfg
+}
+
+// RestartConfig represents the automatic restart of crashed processes
+type RestartConfig struct {
+	// Restart crashed processes automatically
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// Crash loop: more than MaxCrashes crashes within WindowMinutes stop the restarts
+	MaxCrashes    int `yaml:"max_crashes,omitempty" json:"max_crashes,omitempty"`
+	WindowMinutes int `yaml:"window_minutes,omitempty" json:"window_minutes,omitempty"`
+
+	// Delay before the first restart (seconds), doubled at each crash within the window
+	BackoffSeconds int `yaml:"backoff_seconds,omitempty" json:"backoff_seconds,omitempty"`
+}
+
+// DefaultRestartConfig returns default automatic restart configuration
+func DefaultRestartConfig() *RestartConfig {
+	return &RestartConfig{
+		MaxCrashes:     5,
+		WindowMinutes:  5,
+		BackoffSeconds: 1,
+	}
+}
+
+// GetRestartConfig returns the automatic restart config, applying defaults
+func (s *Settings) GetRestartConfig() *RestartConfig {
+	cfg := DefaultRestartConfig()
+	if s.Restart == nil {
+		return cfg
+	}
+	cfg.Enabled = s.Restart.Enabled
+	if s.Restart.MaxCrashes > 0 {
+		cfg.MaxCrashes = s.Restart.MaxCrashes
+	}
+	if s.Restart.WindowMinutes > 0 {
+		cfg.WindowMinutes = s.Restart.WindowMinutes
+	}
+	if s.Restart.BackoffSeconds > 0 {
+		cfg.BackoffSeconds = s.Restart.BackoffSeconds
+	}
+	return cfg
+}
+
+// Hook events
+const (
+	HookBuildSuccess      = "on-build-success"
+	HookBuildFailure      = "on-build-failure"
+	HookProcessCrash      = "on-process-crash"
+	HookGitDirtyThreshold = "on-git-dirty-threshold"
+)
+
+// HookEvents lists the events a hook can be attached to
+var HookEvents = []string{HookBuildSuccess, HookBuildFailure, HookProcessCrash, HookGitDirtyThreshold}
+
+// HookConfig is a shell command run when a DevTrack event occurs.
+// The event context is passed in DEVTRACK_* environment variables.
+type HookConfig struct {
+	// Event triggering the hook (on-build-success, on-build-failure,
+	// on-process-crash, on-git-dirty-threshold)
+	Event string `yaml:"event" json:"event"`
+
+	// Shell command (sh -c, cmd /c on Windows), run in the project directory
+	Command string `yaml:"command" json:"command"`
+
+	// Only for this project / component (empty = all)
+	Project   string `yaml:"project,omitempty" json:"project,omitempty"`
+	Component string `yaml:"component,omitempty" json:"component,omitempty"`
+
+	// Number of changed files firing on-git-dirty-threshold (default: 20)
+	Threshold int `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+
+	// Timeout (seconds, default: 60)
+	Timeout int `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+
+	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+}
+
+// UpdateConfig represents the self-update settings
+type UpdateConfig struct {
+	// Release channel: stable (default) or beta (pre-releases included)
+	Channel string `yaml:"channel,omitempty" json:"channel,omitempty"`
+
+	// GitHub repository publishing the releases (owner/name)
+	Repository string `yaml:"repository,omitempty" json:"repository,omitempty"`
+
+	// Base64 ed25519 public key checking the checksums signature, for the
+	// releases of another repository (empty = key embedded by make release)
+	PublicKey string `yaml:"public_key,omitempty" json:"public_key,omitempty"`
+}
+
+// DefaultUpdateConfig returns default self-update configuration
+func DefaultUpdateConfig() *UpdateConfig {
+	return &UpdateConfig{
+		Channel:    "stable",
+		Repository: "obusalli/csd-devtrack",
+	}
+}
+
+// GetUpdateConfig returns the self-update config, applying defaults
+func (s *Settings) GetUpdateConfig() *UpdateConfig {
+	cfg := DefaultUpdateConfig()
+	if s.Update == nil {
+		return cfg
+	}
+	if s.Update.Channel != "" {
+		cfg.Channel = s.Update.Channel
+	}
+	if s.Update.Repository != "" {
+		cfg.Repository = s.Update.Repository
+	}
+	cfg.PublicKey = s.Update.PublicKey
+	return cfg
+}
+
+// Confirmation action classes (dialogs that can be skipped)
+const (
+	ConfirmKillProcess   = "kill_process"   // Kill a process
+	ConfirmDeleteSession = "delete_session" // Delete a Claude or shell session
+	ConfirmRemoveProject = "remove_project" // Remove a project from config
+	ConfirmDeleteProfile = "delete_profile" // Delete a cockpit profile
+	ConfirmStorageClean  = "storage_clean"  // Clean build artifacts, caches or logs
+	ConfirmTrashDelete   = "trash_delete"   // Permanently delete trash items
+	ConfirmDeploy        = "deploy"         // Run a project deploy target
+	ConfirmMigration     = "migration"      // Apply or roll back database migrations
+	ConfirmDatabaseReset = "database_reset" // Run the reset or seed command of a database
+	ConfirmPluginAction  = "plugin_action"  // Run a plugin action that asks for confirmation
+	ConfirmInternalsKill = "internals_kill" // Kill tmux sessions or processes from the Internals view
+	ConfirmLargePaste    = "large_paste"    // Paste a large text into a terminal or the Claude input
+	ConfirmBatch         = "batch"          // Run an action on the items marked in a tree
+)
+
+// ConfirmAction describes a confirmation action class for the settings UI
+type ConfirmAction struct {
+	Key   string
+	Label string
+}
+
+// ConfirmActions lists the action classes in display order
+var ConfirmActions = []ConfirmAction{
+	{ConfirmKillProcess, "Kill process"},
+	{ConfirmDeleteSession, "Delete session"},
+	{ConfirmRemoveProject, "Remove project"},
+	{ConfirmDeleteProfile, "Delete cockpit profile"},
+	{ConfirmStorageClean, "Clean storage"},
+	{ConfirmTrashDelete, "Delete from trash"},
+	{ConfirmDeploy, "Deploy project"},
+	{ConfirmMigration, "Database migration"},
+	{ConfirmDatabaseReset, "Database reset / seed"},
+	{ConfirmPluginAction, "Plugin action"},
+	{ConfirmInternalsKill, "Kill internals"},
+	{ConfirmLargePaste, "Large paste"},
+	{ConfirmBatch, "Batch action"},
+}
+
+// ConfirmationsConfig controls which actions ask for confirmation
+type ConfirmationsConfig struct {
+	// Expert mode skips all confirmations unless overridden per action
+	ExpertMode bool `yaml:"expert_mode" json:"expert_mode"`
+
+	// Per-action overrides: true = always ask, false = never ask
+	Actions map[string]bool `yaml:"actions,omitempty" json:"actions,omitempty"`
+}
+
+// ShouldConfirm returns true if the action class needs a confirmation dialog
+func (c *ConfirmationsConfig) ShouldConfirm(action string) bool {
+	if ask, ok := c.Actions[action]; ok {
+		return ask
+	}
+	return !c.ExpertMode
+}
+
+// GetConfirmationsConfig returns the confirmations config (all dialogs shown by default)
+func (s *Settings) GetConfirmationsConfig() *ConfirmationsConfig {
+	if s.Confirmations == nil {
+		return &ConfirmationsConfig{}
+	}
+	return s.Confirmations
+}
+
+// Polling subsystems (refreshed independently)
+const (
+	PollGit       = "git"       // Git status of all projects
+	PollProcesses = "processes" // Process state
+	PollMetrics   = "metrics"   // System metrics (CPU, memory, load)
+	PollClaude    = "claude"    // Claude session discovery
+	PollPlugins   = "plugins"   // Plugin views and project data
+	PollDocker    = "docker"    // Containers of the projects and their resource usage
+)
+
+// PollSubsystem describes a polling subsystem for the settings UI
+type PollSubsystem struct {
+	Key   string
+	Label string
+}
+
+// PollSubsystems lists the polling subsystems in display order
+var PollSubsystems = []PollSubsystem{
+	{PollGit, "Git status"},
+	{PollProcesses, "Processes"},
+	{PollMetrics, "System metrics"},
+	{PollClaude, "Claude sessions"},
+	{PollPlugins, "Plugins"},
+	{PollDocker, "Containers"},
+}
+
+// PollingConfig holds the refresh interval of each subsystem (ms, 0 = default)
+type PollingConfig struct {
+	Git       int `yaml:"git,omitempty" json:"git,omitempty"`
+	Processes int `yaml:"processes,omitempty" json:"processes,omitempty"`
+	Metrics   int `yaml:"metrics,omitempty" json:"metrics,omitempty"`
+	Claude    int `yaml:"claude,omitempty" json:"claude,omitempty"`
+	Plugins   int `yaml:"plugins,omitempty" json:"plugins,omitempty"`
+	Docker    int `yaml:"docker,omitempty" json:"docker,omitempty"`
+
+	// Disable inotify-driven refresh (git repositories, Claude sessions)
+	DisableWatch bool `yaml:"disable_watch,omitempty" json:"disable_watch,omitempty"`
+}
+
+// DefaultPollingConfig returns default polling intervals
+func DefaultPollingConfig() *PollingConfig {
+	return &PollingConfig{
+		Git:       30000, // File watching catches most changes earlier
+		Processes: 1000,
+		Metrics:   2000,
+		Claude:    30000,
+		Plugins:   30000,
+		Docker:    5000, // docker stats samples for about a second
+	}
+}
+
+// MinPollInterval is the shortest allowed polling interval (ms)
+const MinPollInterval = 500
+
+// Get returns the interval of a subsystem in ms (0 if unknown)
+func (c *PollingConfig) Get(subsystem string) int {
+	switch subsystem {
+	case PollGit:
+		return c.Git
+	case PollProcesses:
+		return c.Processes
+	case PollMetrics:
+		return c.Metrics
+	case PollClaude:
+		return c.Claude
+	case PollPlugins:
+		return c.Plugins
+	case PollDocker:
+		return c.Docker
+	}
+	return 0
+}
+
+// Set sets the interval of a subsystem in ms
+func (c *PollingConfig) Set(subsystem string, ms int) {
+	switch subsystem {
+	case PollGit:
+		c.Git = ms
+	case PollProcesses:
+		c.Processes = ms
+	case PollMetrics:
+		c.Metrics = ms
+	case PollClaude:
+		c.Claude = ms
+	case PollPlugins:
+		c.Plugins = ms
+	case PollDocker:
+		c.Docker = ms
+	}
+}
+
+// Interval returns the interval of a subsystem as a duration
+func (c *PollingConfig) Interval(subsystem string) time.Duration {
+	ms := c.Get(subsystem)
+	if ms < MinPollInterval {
+		ms = MinPollInterval
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// GetPollingConfig returns the polling config, applying defaults to unset intervals
+func (s *Settings) GetPollingConfig() *PollingConfig {
+	cfg := DefaultPollingConfig()
+	if s.Polling == nil {
+		return cfg
+	}
+	for _, sub := range PollSubsystems {
+		if ms := s.Polling.Get(sub.Key); ms > 0 {
+			cfg.Set(sub.Key, ms)
+		}
+	}
+	cfg.DisableWatch = s.Polling.DisableWatch
+	return cfg
+}
+
+// SSHHostConfig is a remote shell target configured in the config file
+type SSHHostConfig struct {
+	Name         string `yaml:"name" json:"name"`                                       // Display name (and ssh alias if Host is empty)
+	Host         string `yaml:"host,omitempty" json:"host,omitempty"`                   // Hostname or IP (empty = Name)
+	User         string `yaml:"user,omitempty" json:"user,omitempty"`                   // Remote user (empty = ssh default)
+	Port         int    `yaml:"port,omitempty" json:"port,omitempty"`                   // Port (0 = ssh default)
+	IdentityFile string `yaml:"identity_file,omitempty" json:"identity_file,omitempty"` // Private key (empty = ssh default)
+	Group        string `yaml:"group,omitempty" json:"group,omitempty"`                 // Tree group (empty = "SSH")
+}
+
+// SSHConfig holds the SSH targets of the Shell view
+type SSHConfig struct {
+	Hosts []SSHHostConfig `yaml:"hosts,omitempty" json:"hosts,omitempty"`
+
+	// Skip importing the Host entries of ~/.ssh/config
+	DisableSSHConfig bool `yaml:"disable_ssh_config,omitempty" json:"disable_ssh_config,omitempty"`
+}
+
+// GetSSHConfig returns the SSH config (no hosts, ~/.ssh/config imported by default)
+func (s *Settings) GetSSHConfig() *SSHConfig {
+	if s.SSH == nil {
+		return &SSHConfig{}
+	}
+	return s.SSH
+}
+
+// Project ordering modes
+const (
+	ProjectOrderName   = "name"   // Alphabetical (default)
+	ProjectOrderCustom = "custom" // Manual order
+	ProjectOrderRecent = "recent" // Most recently used first
+)
+
+// ProjectOrderConfig holds the favorite projects and the manual order of the
+// project trees
+type ProjectOrderConfig struct {
+	Mode      string   `yaml:"mode,omitempty" json:"mode,omitempty"`           // name (default), custom, recent
+	Favorites []string `yaml:"favorites,omitempty" json:"favorites,omitempty"` // Project IDs pinned to the top
+	Order     []string `yaml:"order,omitempty" json:"order,omitempty"`         // Project IDs in custom order (others last, by name)
+}
+
+// IsFavorite returns true if a project is pinned to the top
+func (c *ProjectOrderConfig) IsFavorite(projectID string) bool {
+	for _, id := range c.Favorites {
+		if id == projectID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetProjectOrderConfig returns the project order config (by name, no favorites by default)
+func (s *Settings) GetProjectOrderConfig() *ProjectOrderConfig {
+	if s.ProjectOrder == nil {
+		return &ProjectOrderConfig{}
+	}
+	return s.ProjectOrder
+}
+
+// Log rate limiting modes
+const (
+	LogRateCoalesce = "coalesce" // Keep the first lines of each second
+	LogRateSample   = "sample"   // Keep one line in N beyond the limit
+)
+
+// DefaultLogRate is the default number of lines kept per source and second
+const DefaultLogRate = 200
+
+// LogRateConfig limits the lines a log source adds to the Logs view per
+// second. Lines over the limit are replaced by a "lines suppressed" marker.
+type LogRateConfig struct {
+	Disabled       bool           `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+	LinesPerSecond int            `yaml:"lines_per_second,omitempty" json:"lines_per_second,omitempty"` // 0 = DefaultLogRate
+	Mode           string         `yaml:"mode,omitempty" json:"mode,omitempty"`                         // coalesce (default), sample
+	Sources        map[string]int `yaml:"sources,omitempty" json:"sources,omitempty"`                   // Per "project/component" or "project" (0 = unlimited)
+}
+
+// Limit returns the lines per second allowed for a source (0 = unlimited)
+func (c *LogRateConfig) Limit(source string) int {
+	if c.Disabled {
+		return 0
+	}
+	source = strings.TrimPrefix(source, "build:")
+	if limit, ok := c.Sources[source]; ok {
+		return max(limit, 0)
+	}
+	if project, _, found := strings.Cut(source, "/"); found {
+		if limit, ok := c.Sources[project]; ok {
+			return max(limit, 0)
+		}
+	}
+	if c.LinesPerSecond > 0 {
+		return c.LinesPerSecond
+	}
+	return DefaultLogRate
+}
+
+// GetLogRateConfig returns the log rate config (DefaultLogRate for all sources by default)
+func (s *Settings) GetLogRateConfig() *LogRateConfig {
+	if s.LogRate == nil {
+		return &LogRateConfig{}
+	}
+	return s.LogRate
+}
+
 // GetLoggerConfig returns the logger config, applying defaults and legacy field migration
 func (s *Settings) GetLoggerConfig() *LoggerConfig {
 	if s.Logger != nil {
@@ -287,6 +1005,7 @@ func DefaultWidgetProfiles() map[string]*WidgetProfile {
 func DefaultConfig() *Config {
 	return &Config{
 		Version:        "1.0",
+		Schema:         CurrentSchema,
 		Settings:       DefaultSettings(),
 		Projects:       []projects.Project{},
 		BuildProfiles:  DefaultBuildProfiles(),
@@ -323,6 +1042,33 @@ func (c *Config) Validate() []string {
 		}
 	}
 
+	errors = append(errors, c.validateServices()...)
+	errors = append(errors, c.validateProxy()...)
+	errors = append(errors, c.validateDashboard()...)
+	errors = append(errors, c.validateTeam()...)
+	errors = append(errors, c.validateEventBridge()...)
+	errors = append(errors, c.validateTracing()...)
+	errors = append(errors, c.validateSecrets()...)
+	if c.Settings != nil && c.Settings.Capture != nil && (c.Settings.Capture.Port < 0 || c.Settings.Capture.Port > 65535) {
+		errors = append(errors, "capture.port must be between 1 and 65535")
+	}
+	if c.Settings != nil && c.Settings.LogStream != nil && (c.Settings.LogStream.Port < 0 || c.Settings.LogStream.Port > 65535) {
+		errors = append(errors, "log_stream.port must be between 1 and 65535")
+	}
+
+	for i, hook := range c.Settings.Hooks {
+		known := false
+		for _, event := range HookEvents {
+			known = known || hook.Event == event
+		}
+		if !known {
+			errors = append(errors, fmt.Sprintf("hooks[%d]: unknown event %q", i, hook.Event))
+		}
+		if strings.TrimSpace(hook.Command) == "" {
+			errors = append(errors, fmt.Sprintf("hooks[%d]: command is required", i))
+		}
+	}
+
 	return errors
 }
 
@@ -343,4 +1089,8 @@ func (c *Config) Merge(other *Config) {
 	if len(other.Projects) > 0 {
 		c.Projects = other.Projects
 	}
+
+	if len(other.Services) > 0 {
+		c.Services = other.Services
+	}
 }
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProxyConfig is the built-in reverse proxy: one local entrypoint
+// (http://localhost:PORT) routing paths or hostnames to the components
+type ProxyConfig struct {
+	Enabled bool         `yaml:"enabled" json:"enabled"`                   // Started with DevTrack
+	Port    int          `yaml:"port,omitempty" json:"port,omitempty"`     // Listening port on localhost (default: 8000)
+	Routes  []ProxyRoute `yaml:"routes,omitempty" json:"routes,omitempty"` // The most specific match wins (host, then longest path)
+}
+
+// ProxyRoute routes the requests matching a path prefix and/or a hostname to
+// a component of a project (its port, or the URL of its dev server), or to a
+// fixed URL
+type ProxyRoute struct {
+	Path        string `yaml:"path,omitempty" json:"path,omitempty"`                 // Path prefix, e.g. /app-a (default: all paths)
+	Host        string `yaml:"host,omitempty" json:"host,omitempty"`                 // Hostname, e.g. app-a.localhost (default: any)
+	Project     string `yaml:"project,omitempty" json:"project,omitempty"`           // Project ID of the component
+	Component   string `yaml:"component,omitempty" json:"component,omitempty"`       // Component type, e.g. frontend
+	Target      string `yaml:"target,omitempty" json:"target,omitempty"`             // Fixed URL instead of a component, e.g. http://localhost:9000
+	StripPrefix bool   `yaml:"strip_prefix,omitempty" json:"strip_prefix,omitempty"` // Remove the path prefix before forwarding
+}
+
+// Name returns how a route is displayed: host and path prefix
+func (r ProxyRoute) Name() string {
+	path := r.Path
+	if path == "" {
+		path = "/"
+	}
+	return r.Host + path
+}
+
+// DefaultProxyConfig returns the default reverse proxy configuration
+func DefaultProxyConfig() *ProxyConfig {
+	return &ProxyConfig{
+		Port: 8000,
+	}
+}
+
+// GetProxyConfig returns the reverse proxy config, applying defaults
+func (s *Settings) GetProxyConfig() *ProxyConfig {
+	cfg := DefaultProxyConfig()
+	if s.Proxy == nil {
+		return cfg
+	}
+	cfg.Enabled = s.Proxy.Enabled
+	if s.Proxy.Port > 0 {
+		cfg.Port = s.Proxy.Port
+	}
+	cfg.Routes = s.Proxy.Routes
+	return cfg
+}
+
+// validateProxy checks the routes of the reverse proxy
+func (c *Config) validateProxy() []string {
+	if c.Settings == nil || c.Settings.Proxy == nil {
+		return nil
+	}
+	proxy := c.Settings.Proxy
+
+	var errors []string
+	if proxy.Port < 0 || proxy.Port > 65535 {
+		errors = append(errors, "proxy.port must be between 1 and 65535")
+	}
+	projectIDs := make(map[string]bool)
+	for _, p := range c.Projects {
+		projectIDs[p.ID] = true
+	}
+	for i, route := range proxy.Routes {
+		switch {
+		case route.Target == "" && (route.Project == "" || route.Component == ""):
+			errors = append(errors, fmt.Sprintf("proxy.routes[%d]: project and component, or target, are required", i))
+		case route.Target != "" && route.Project != "":
+			errors = append(errors, fmt.Sprintf("proxy.routes[%d]: target and project are exclusive", i))
+		case route.Target != "" && !strings.HasPrefix(route.Target, "http://") && !strings.HasPrefix(route.Target, "https://"):
+			errors = append(errors, fmt.Sprintf("proxy.routes[%d]: target must be an http(s) URL", i))
+		case route.Project != "" && !projectIDs[route.Project]:
+			errors = append(errors, fmt.Sprintf("proxy.routes[%d]: unknown project %q", i, route.Project))
+		}
+		if route.Path != "" && !strings.HasPrefix(route.Path, "/") {
+			errors = append(errors, fmt.Sprintf("proxy.routes[%d]: path must start with /", i))
+		}
+	}
+	return errors
+}
@@ -0,0 +1,408 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+
+	"csd-devtrack/cli/modules/platform/secrets"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SecretsConfig selects the key encrypting the secrets section of the
+// config. Config values reference the secrets as ${secret:NAME}.
+type SecretsConfig struct {
+	KeySource string `yaml:"key_source,omitempty" json:"key_source,omitempty"` // file (default), keyring or passphrase (CSD_DEVTRACK_PASSPHRASE)
+	KeyFile   string `yaml:"key_file,omitempty" json:"key_file,omitempty"`     // Key of the file source (default: ~/.csd-devtrack/secrets.key)
+	Salt      string `yaml:"salt,omitempty" json:"salt,omitempty"`             // scrypt salt of the passphrase source (written on first use)
+}
+
+// DefaultSecretsConfig returns the default secrets configuration
+func DefaultSecretsConfig() *SecretsConfig {
+	keyFile := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		keyFile = filepath.Join(home, ".csd-devtrack", "secrets.key")
+	}
+	return &SecretsConfig{
+		KeySource: secrets.SourceFile,
+		KeyFile:   keyFile,
+	}
+}
+
+// GetSecretsConfig returns the secrets config, applying defaults
+func (s *Settings) GetSecretsConfig() *SecretsConfig {
+	cfg := DefaultSecretsConfig()
+	if s == nil || s.Secrets == nil {
+		return cfg
+	}
+	if s.Secrets.KeySource != "" {
+		cfg.KeySource = s.Secrets.KeySource
+	}
+	if s.Secrets.KeyFile != "" {
+		cfg.KeyFile = s.Secrets.KeyFile
+	}
+	cfg.Salt = s.Secrets.Salt
+	return cfg
+}
+
+// secretRefPattern matches the references to secrets in config values
+var secretRefPattern = regexp.MustCompile(`\$\{secret:([^}]*)\}`)
+
+// secretNamePattern is the format of secret names
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+var (
+	// secretsMu protects the secrets sections and the key cache
+	secretsMu sync.Mutex
+
+	// secretsKey is the key loaded for secretsKeyID (source and location)
+	secretsKey   *secrets.Key
+	secretsKeyID string
+
+	// secretsPassphrase is the passphrase entered in the terminal, used
+	// instead of CSD_DEVTRACK_PASSPHRASE
+	secretsPassphrase string
+)
+
+// SetSecretsPassphrase sets the passphrase of the passphrase key source for
+// this process (entered in the terminal)
+func SetSecretsPassphrase(passphrase string) {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	secretsPassphrase = passphrase
+	secretsKey, secretsKeyID = nil, ""
+}
+
+// NeedsSecretsPassphrase returns true if the secrets are encrypted with a
+// passphrase that was neither set nor given in CSD_DEVTRACK_PASSPHRASE
+func (c *Config) NeedsSecretsPassphrase() bool {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	return c.Settings.GetSecretsConfig().KeySource == secrets.SourcePassphrase &&
+		secretsPassphrase == "" && os.Getenv(secrets.PassphraseEnv) == ""
+}
+
+// HasSecretRefs returns true if value references secrets
+func HasSecretRefs(value string) bool {
+	return secretRefPattern.MatchString(value)
+}
+
+// ValidSecretName returns true if name can name a secret: letters, digits,
+// '_', '.' and '-'
+func ValidSecretName(name string) bool {
+	return secretNamePattern.MatchString(name)
+}
+
+// SecretNames returns the names of the secrets, sorted
+func (c *Config) SecretNames() []string {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	names := make([]string, 0, len(c.Secrets))
+	for name := range c.Secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetSecret encrypts a secret, adding or replacing it. The key is created on
+// first use (the salt of the passphrase source is written to the settings):
+// the config must be saved.
+func (c *Config) SetSecret(name, value string) error {
+	if !ValidSecretName(name) {
+		return fmt.Errorf("invalid secret name %q: use letters, digits, '_', '.' and '-'", name)
+	}
+	if value == "" {
+		return fmt.Errorf("empty secret value")
+	}
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	key, err := c.loadSecretsKey(true)
+	if err != nil {
+		return err
+	}
+	sealed, err := secrets.Seal(key, value)
+	if err != nil {
+		return err
+	}
+	if c.Secrets == nil {
+		c.Secrets = make(map[string]string)
+	}
+	c.Secrets[name] = sealed
+	return nil
+}
+
+// DeleteSecret removes a secret, false if it does not exist
+func (c *Config) DeleteSecret(name string) bool {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	if _, ok := c.Secrets[name]; !ok {
+		return false
+	}
+	delete(c.Secrets, name)
+	return true
+}
+
+// SecretReferences returns the names of the secrets referenced by the config
+// values, sorted
+func (c *Config) SecretReferences() []string {
+	// The secrets section holds base64 only: it matches nothing
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, match := range secretRefPattern.FindAllStringSubmatch(string(data), -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveSecrets replaces the ${secret:NAME} references of value with the
+// decrypted secrets. Values without references are returned as is, without
+// loading the key.
+func (c *Config) ResolveSecrets(value string) (string, error) {
+	if !HasSecretRefs(value) {
+		return value, nil
+	}
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	key, err := c.loadSecretsKey(false)
+	if err != nil {
+		return "", err
+	}
+	var resolveErr error
+	resolved := secretRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		name := secretRefPattern.FindStringSubmatch(ref)[1]
+		sealed, ok := c.Secrets[name]
+		if !ok {
+			if resolveErr == nil {
+				resolveErr = fmt.Errorf("unknown secret %q", name)
+			}
+			return ""
+		}
+		plain, err := secrets.Open(key, sealed)
+		if err != nil && resolveErr == nil {
+			resolveErr = fmt.Errorf("secret %q: %w", name, err)
+		}
+		return plain
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return resolved, nil
+}
+
+// ResolveSecretsMap returns a copy of values with the secret references
+// resolved (environment variables, headers)
+func (c *Config) ResolveSecretsMap(values map[string]string) (map[string]string, error) {
+	if values == nil {
+		return nil, nil
+	}
+	resolved := make(map[string]string, len(values))
+	for k, v := range values {
+		value, err := c.ResolveSecrets(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		resolved[k] = value
+	}
+	return resolved, nil
+}
+
+// ResolveSecrets resolves the secret references of value with the global
+// config
+func ResolveSecrets(value string) (string, error) {
+	return GetGlobal().ResolveSecrets(value)
+}
+
+// ResolveSecretsMap resolves the secret references of values with the global
+// config
+func ResolveSecretsMap(values map[string]string) (map[string]string, error) {
+	return GetGlobal().ResolveSecretsMap(values)
+}
+
+// RotateSecretsKey re-encrypts the secrets with a new key of source (the
+// current source if empty): a new random key for the file and keyring
+// sources, a new salt and the given passphrase for the passphrase source.
+// The new key is stored (the previous key file is kept as .old); the config
+// must be saved.
+func (c *Config) RotateSecretsKey(source, passphrase string) error {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+
+	// Decrypt with the current key first: nothing changes if it fails
+	plain := make(map[string]string, len(c.Secrets))
+	if len(c.Secrets) > 0 {
+		key, err := c.loadSecretsKey(false)
+		if err != nil {
+			return err
+		}
+		for name, sealed := range c.Secrets {
+			value, err := secrets.Open(key, sealed)
+			if err != nil {
+				return fmt.Errorf("secret %q: %w", name, err)
+			}
+			plain[name] = value
+		}
+	}
+
+	cfg := c.Settings.GetSecretsConfig()
+	if source == "" {
+		source = cfg.KeySource
+	}
+	var key *secrets.Key
+	var err error
+	switch source {
+	case secrets.SourceFile, secrets.SourceKeyring:
+		key, err = secrets.NewKey()
+		cfg.Salt = ""
+	case secrets.SourcePassphrase:
+		if passphrase == "" {
+			return fmt.Errorf("a new passphrase is required")
+		}
+		if cfg.Salt, err = secrets.NewSalt(); err == nil {
+			key, err = secrets.DeriveKey(passphrase, cfg.Salt)
+		}
+	default:
+		return fmt.Errorf("unknown key source %q: use file, keyring or passphrase", source)
+	}
+	if err != nil {
+		return err
+	}
+
+	sealed := make(map[string]string, len(plain))
+	for name, value := range plain {
+		if sealed[name], err = secrets.Seal(key, value); err != nil {
+			return err
+		}
+	}
+	switch source {
+	case secrets.SourceFile:
+		err = secrets.StoreFileKey(cfg.KeyFile, key)
+	case secrets.SourceKeyring:
+		err = secrets.StoreKeyringKey(key)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.Settings == nil {
+		c.Settings = DefaultSettings()
+	}
+	if c.Settings.Secrets == nil {
+		c.Settings.Secrets = &SecretsConfig{}
+	}
+	c.Settings.Secrets.KeySource = source
+	c.Settings.Secrets.Salt = cfg.Salt
+	if len(sealed) > 0 {
+		c.Secrets = sealed
+	}
+	if source == secrets.SourcePassphrase {
+		secretsPassphrase = passphrase
+	}
+	secretsKey, secretsKeyID = key, c.secretsKeyID()
+	return nil
+}
+
+// secretsKeyID identifies the key of the secrets settings, to reload it when
+// they change
+func (c *Config) secretsKeyID() string {
+	cfg := c.Settings.GetSecretsConfig()
+	return cfg.KeySource + "|" + cfg.KeyFile + "|" + cfg.Salt
+}
+
+// loadSecretsKey returns the key of the secrets, loaded once. With create,
+// a missing key is created. The key is checked against an existing secret,
+// so a wrong passphrase is reported rather than mixing keys.
+// secretsMu must be held.
+func (c *Config) loadSecretsKey(create bool) (*secrets.Key, error) {
+	if secretsKey != nil && secretsKeyID == c.secretsKeyID() {
+		return secretsKey, nil
+	}
+
+	cfg := c.Settings.GetSecretsConfig()
+	var key *secrets.Key
+	var err error
+	switch cfg.KeySource {
+	case secrets.SourceFile:
+		key, err = secrets.LoadFileKey(cfg.KeyFile, create && len(c.Secrets) == 0)
+	case secrets.SourceKeyring:
+		key, err = secrets.LoadKeyringKey(create && len(c.Secrets) == 0)
+	case secrets.SourcePassphrase:
+		passphrase := secretsPassphrase
+		if passphrase == "" {
+			passphrase = os.Getenv(secrets.PassphraseEnv)
+		}
+		if passphrase == "" {
+			return nil, fmt.Errorf("the secrets are encrypted with a passphrase: set %s", secrets.PassphraseEnv)
+		}
+		if cfg.Salt == "" {
+			if !create {
+				return nil, fmt.Errorf("secrets.salt is missing")
+			}
+			if cfg.Salt, err = secrets.NewSalt(); err != nil {
+				return nil, err
+			}
+			if c.Settings == nil {
+				c.Settings = DefaultSettings()
+			}
+			if c.Settings.Secrets == nil {
+				c.Settings.Secrets = &SecretsConfig{KeySource: secrets.SourcePassphrase}
+			}
+			c.Settings.Secrets.Salt = cfg.Salt
+		}
+		key, err = secrets.DeriveKey(passphrase, cfg.Salt)
+	default:
+		return nil, fmt.Errorf("unknown secrets.key_source %q: use file, keyring or passphrase", cfg.KeySource)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sealed := range c.Secrets {
+		// One secret is enough to check the key
+		if _, err := secrets.Open(key, sealed); err == secrets.ErrWrongKey {
+			return nil, err
+		}
+		break
+	}
+	secretsKey, secretsKeyID = key, c.secretsKeyID()
+	return key, nil
+}
+
+// validateSecrets checks the secrets section, its key source and the
+// references of the config values
+func (c *Config) validateSecrets() []string {
+	var errors []string
+	if c.Settings != nil && c.Settings.Secrets != nil {
+		switch c.Settings.Secrets.KeySource {
+		case "", secrets.SourceFile, secrets.SourceKeyring, secrets.SourcePassphrase:
+		default:
+			errors = append(errors, "secrets.key_source must be file, keyring or passphrase")
+		}
+	}
+	for _, name := range c.SecretNames() {
+		if !ValidSecretName(name) {
+			errors = append(errors, fmt.Sprintf("secrets: invalid name %q", name))
+		}
+		if !secrets.IsSealed(c.Secrets[name]) {
+			errors = append(errors, fmt.Sprintf("secrets.%s is not an encrypted value: set it with csd-devtrack secrets set %s", name, name))
+		}
+	}
+	for _, name := range c.SecretReferences() {
+		if _, ok := c.Secrets[name]; !ok {
+			errors = append(errors, fmt.Sprintf("unknown secret referenced: ${secret:%s}", name))
+		}
+	}
+	return errors
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ServiceConfig describes an infrastructure service the components depend
+// on (database, cache, message broker...), started and stopped by DevTrack.
+// It runs in a container (docker or podman) when Image is set, else Command
+// runs it from a local binary.
+type ServiceConfig struct {
+	Description string            `yaml:"description,omitempty" json:"description,omitempty"`
+	Image       string            `yaml:"image,omitempty" json:"image,omitempty"`     // Container image, e.g. "postgres:16"
+	Command     string            `yaml:"command,omitempty" json:"command,omitempty"` // Local binary run by the shell, e.g. "redis-server --port 6379"
+	Dir         string            `yaml:"dir,omitempty" json:"dir,omitempty"`         // Working directory of Command
+	Ports       []string          `yaml:"ports,omitempty" json:"ports,omitempty"`     // "host:container" or a single port published as is
+	Volumes     []string          `yaml:"volumes,omitempty" json:"volumes,omitempty"` // Container volumes, e.g. "devtrack-pg:/var/lib/postgresql/data"
+	Env         map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+	Health      *HealthCheck      `yaml:"health,omitempty" json:"health,omitempty"`
+}
+
+// HealthCheck tells when a service is ready to be used. Without a command,
+// the service is ready once its port (default: the first published port)
+// accepts connections.
+type HealthCheck struct {
+	Command string `yaml:"command,omitempty" json:"command,omitempty"` // Succeeds once ready, run in the container for images (e.g. "pg_isready")
+	Port    int    `yaml:"port,omitempty" json:"port,omitempty"`       // Port accepting connections on localhost
+	Timeout int    `yaml:"timeout,omitempty" json:"timeout,omitempty"` // Seconds to wait for the service (default: 60)
+}
+
+// BuiltinServices returns the services available without configuration:
+// components may depend on them by name, the configuration overrides them
+func BuiltinServices() map[string]*ServiceConfig {
+	return map[string]*ServiceConfig{
+		"postgres": {
+			Description: "PostgreSQL (user and password: postgres)",
+			Image:       "postgres:16",
+			Ports:       []string{"5432:5432"},
+			Volumes:     []string{"devtrack-postgres:/var/lib/postgresql/data"},
+			Env:         map[string]string{"POSTGRES_PASSWORD": "postgres"},
+			Health:      &HealthCheck{Command: "pg_isready -U postgres"},
+		},
+		"redis": {
+			Description: "Redis",
+			Image:       "redis:7",
+			Ports:       []string{"6379:6379"},
+			Volumes:     []string{"devtrack-redis:/data"},
+			Health:      &HealthCheck{Command: "redis-cli ping"},
+		},
+		"kafka": {
+			Description: "Apache Kafka, single KRaft node",
+			Image:       "apache/kafka:3.8.0",
+			Ports:       []string{"9092:9092"},
+			Health:      &HealthCheck{Port: 9092, Timeout: 120},
+		},
+	}
+}
+
+// ServiceCatalog returns the configured services, with the built-in ones
+// the components depend on
+func (c *Config) ServiceCatalog() map[string]*ServiceConfig {
+	catalog := make(map[string]*ServiceConfig, len(c.Services))
+	for name, svc := range c.Services {
+		if svc != nil {
+			catalog[name] = svc
+		}
+	}
+	builtin := BuiltinServices()
+	for _, name := range c.ServiceDependencies() {
+		if _, ok := catalog[name]; !ok && builtin[name] != nil {
+			catalog[name] = builtin[name]
+		}
+	}
+	return catalog
+}
+
+// ServiceDependencies returns the services the components of the projects
+// depend on, sorted
+func (c *Config) ServiceDependencies() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, project := range c.Projects {
+		for _, comp := range project.Components {
+			if comp == nil {
+				continue
+			}
+			for _, name := range comp.Services {
+				if !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateServices checks the service catalog and the dependencies of the
+// components on it
+func (c *Config) validateServices() []string {
+	var errors []string
+
+	names := make([]string, 0, len(c.Services))
+	for name := range c.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		svc := c.Services[name]
+		switch {
+		case svc == nil:
+			errors = append(errors, fmt.Sprintf("services.%s: image or command is required", name))
+		case svc.Image == "" && strings.TrimSpace(svc.Command) == "":
+			errors = append(errors, fmt.Sprintf("services.%s: image or command is required", name))
+		case svc.Image != "" && svc.Command != "":
+			errors = append(errors, fmt.Sprintf("services.%s: image and command are exclusive", name))
+		}
+	}
+
+	catalog := c.ServiceCatalog()
+	for _, project := range c.Projects {
+		for _, comp := range project.Components {
+			if comp == nil {
+				continue
+			}
+			for _, name := range comp.Services {
+				if catalog[name] == nil {
+					errors = append(errors, fmt.Sprintf("%s/%s: unknown service %q", project.ID, comp.Type, name))
+				}
+			}
+		}
+	}
+	return errors
+}
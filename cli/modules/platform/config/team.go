@@ -0,0 +1,131 @@
+package config
+
+import (
+	"crypto/subtle"
+	"fmt"
+)
+
+// Team roles: an operator acts on the shared daemon, a viewer only looks
+const (
+	TeamRoleOperator = "operator"
+	TeamRoleViewer   = "viewer"
+)
+
+// teamTokenMinLength is the shortest token accepted on the network
+const teamTokenMinLength = 16
+
+// TeamConfig is team mode: the daemon also listens on a network address
+// (TLS), so teammates attach their TUI to a shared dev or staging box with
+// csd-devtrack --remote HOST:PORT
+type TeamConfig struct {
+	Enabled  bool        `yaml:"enabled" json:"enabled"`                         // Listen with the daemon
+	Listen   string      `yaml:"listen,omitempty" json:"listen,omitempty"`       // Network address (default: :9095)
+	CertFile string      `yaml:"cert_file,omitempty" json:"cert_file,omitempty"` // TLS certificate (default: generated in the data directory)
+	KeyFile  string      `yaml:"key_file,omitempty" json:"key_file,omitempty"`   // Key of the TLS certificate
+	Tokens   []TeamToken `yaml:"tokens,omitempty" json:"tokens,omitempty"`       // One per teammate
+}
+
+// TeamToken authenticates a teammate and gives its role
+type TeamToken struct {
+	Name  string `yaml:"name" json:"name"`                     // Teammate, shown in the logs and the audit log
+	Token string `yaml:"token" json:"token"`                   // Secret given with --token (at least 16 characters)
+	Role  string `yaml:"role,omitempty" json:"role,omitempty"` // operator or viewer (default: viewer)
+}
+
+// DefaultTeamConfig returns the default team mode configuration
+func DefaultTeamConfig() *TeamConfig {
+	return &TeamConfig{
+		Listen: ":9095",
+	}
+}
+
+// GetTeamConfig returns the team mode config, applying defaults
+func (s *Settings) GetTeamConfig() *TeamConfig {
+	cfg := DefaultTeamConfig()
+	if s.Team == nil {
+		return cfg
+	}
+	cfg.Enabled = s.Team.Enabled
+	if s.Team.Listen != "" {
+		cfg.Listen = s.Team.Listen
+	}
+	cfg.CertFile = s.Team.CertFile
+	cfg.KeyFile = s.Team.KeyFile
+	cfg.Tokens = s.Team.Tokens
+	return cfg
+}
+
+// ResolveSecrets resolves the secret references of the tokens, on a copy of
+// the tokens of the settings
+func (c *TeamConfig) ResolveSecrets(cfg *Config) error {
+	tokens := make([]TeamToken, len(c.Tokens))
+	for i, t := range c.Tokens {
+		token, err := cfg.ResolveSecrets(t.Token)
+		if err != nil {
+			return fmt.Errorf("team.tokens[%d]: %w", i, err)
+		}
+		if len(token) < teamTokenMinLength {
+			return fmt.Errorf("team.tokens[%d]: token must be at least %d characters", i, teamTokenMinLength)
+		}
+		t.Token = token
+		tokens[i] = t
+	}
+	c.Tokens = tokens
+	return nil
+}
+
+// Authenticate returns the teammate of a token, nil if it is unknown
+func (c *TeamConfig) Authenticate(token string) *TeamToken {
+	if token == "" {
+		return nil
+	}
+	var found *TeamToken
+	for i := range c.Tokens {
+		if subtle.ConstantTimeCompare([]byte(c.Tokens[i].Token), []byte(token)) == 1 {
+			found = &c.Tokens[i]
+		}
+	}
+	return found
+}
+
+// GetRole returns the role of a teammate, viewer by default
+func (t *TeamToken) GetRole() string {
+	if t.Role == "" {
+		return TeamRoleViewer
+	}
+	return t.Role
+}
+
+// validateTeam checks the tokens and the certificate of team mode
+func (c *Config) validateTeam() []string {
+	if c.Settings == nil || c.Settings.Team == nil {
+		return nil
+	}
+	team := c.Settings.Team
+
+	var errors []string
+	if team.Enabled && len(team.Tokens) == 0 {
+		errors = append(errors, "team.tokens: at least one token is required")
+	}
+	if (team.CertFile == "") != (team.KeyFile == "") {
+		errors = append(errors, "team.cert_file and team.key_file go together")
+	}
+	tokens := make(map[string]bool)
+	for i, t := range team.Tokens {
+		switch {
+		case t.Name == "":
+			errors = append(errors, fmt.Sprintf("team.tokens[%d]: name is required", i))
+		case HasSecretRefs(t.Token):
+			// Length checked once resolved
+		case len(t.Token) < teamTokenMinLength:
+			errors = append(errors, fmt.Sprintf("team.tokens[%d]: token must be at least %d characters", i, teamTokenMinLength))
+		case tokens[t.Token]:
+			errors = append(errors, fmt.Sprintf("team.tokens[%d]: token already used", i))
+		}
+		tokens[t.Token] = true
+		if t.Role != "" && t.Role != TeamRoleOperator && t.Role != TeamRoleViewer {
+			errors = append(errors, fmt.Sprintf("team.tokens[%d]: role must be operator or viewer", i))
+		}
+	}
+	return errors
+}
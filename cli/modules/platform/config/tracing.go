@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+)
+
+// TracingConfig is the OpenTelemetry tracing of DevTrack's own operations
+// (builds, git scans, process launches, presenter events, session parsing),
+// exported over OTLP/HTTP
+type TracingConfig struct {
+	Enabled     bool              `yaml:"enabled" json:"enabled"`                               // Record and export spans
+	Endpoint    string            `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`         // OTLP/HTTP endpoint (default: OTEL_EXPORTER_OTLP_ENDPOINT, or http://localhost:4318)
+	ServiceName string            `yaml:"service_name,omitempty" json:"service_name,omitempty"` // service.name of the spans (default: csd-devtrack)
+	Headers     map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`           // Sent with the exports, e.g. the API key of a hosted backend
+}
+
+// DefaultTracingConfig returns the default tracing configuration
+func DefaultTracingConfig() *TracingConfig {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "http://localhost:4318"
+	}
+	return &TracingConfig{
+		Endpoint:    endpoint,
+		ServiceName: "csd-devtrack",
+	}
+}
+
+// GetTracingConfig returns the tracing config, applying defaults
+func (s *Settings) GetTracingConfig() *TracingConfig {
+	cfg := DefaultTracingConfig()
+	if s.Tracing == nil {
+		return cfg
+	}
+	cfg.Enabled = s.Tracing.Enabled
+	if s.Tracing.Endpoint != "" {
+		cfg.Endpoint = s.Tracing.Endpoint
+	}
+	if s.Tracing.ServiceName != "" {
+		cfg.ServiceName = s.Tracing.ServiceName
+	}
+	cfg.Headers = s.Tracing.Headers
+	return cfg
+}
+
+// ResolveSecrets resolves the secret references of the headers, on a copy of
+// the headers of the settings
+func (c *TracingConfig) ResolveSecrets(cfg *Config) error {
+	headers, err := cfg.ResolveSecretsMap(c.Headers)
+	if err != nil {
+		return fmt.Errorf("tracing.headers: %w", err)
+	}
+	c.Headers = headers
+	return nil
+}
+
+// validateTracing checks the endpoint of the tracing exporter
+func (c *Config) validateTracing() []string {
+	if c.Settings == nil || c.Settings.Tracing == nil || c.Settings.Tracing.Endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(c.Settings.Tracing.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return []string{fmt.Sprintf("tracing.endpoint must be an http(s) URL: %s", c.Settings.Tracing.Endpoint)}
+	}
+	return nil
+}
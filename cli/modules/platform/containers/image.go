@@ -0,0 +1,395 @@
+package containers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/fileutil"
+)
+
+// maxImageBuilds is the number of image builds kept in the history
+const maxImageBuilds = 100
+
+// BuildState is the state of an image build
+type BuildState string
+
+const (
+	BuildRunning  BuildState = "running"
+	BuildSuccess  BuildState = "success"
+	BuildFailed   BuildState = "failed"
+	BuildCanceled BuildState = "canceled"
+)
+
+// StepState is the state of a step (layer) of an image build
+type StepState string
+
+const (
+	StepRunning  StepState = "running"
+	StepDone     StepState = "done"
+	StepCached   StepState = "cached"
+	StepFailed   StepState = "failed"
+	StepCanceled StepState = "canceled"
+)
+
+// BuildStep is a Dockerfile instruction of an image build
+type BuildStep struct {
+	Instruction string    `json:"instruction"` // "RUN go build ./..."
+	State       StepState `json:"state"`
+	DurationMs  int64     `json:"duration_ms,omitempty"`
+}
+
+// BuildRequest describes an image build to start
+type BuildRequest struct {
+	ProjectID  string
+	Profile    string            // Build profile the tags belong to (dev, test, prod)
+	Dockerfile string            // Absolute path
+	Context    string            // Build context directory
+	Target     string            // Stage of a multi-stage build (empty = last)
+	Tags       []string          // Full image references ("myapp:dev")
+	Args       map[string]string // --build-arg values
+}
+
+// ImageBuild is a run of docker (or podman) build
+type ImageBuild struct {
+	ID         string      `json:"id"`
+	ProjectID  string      `json:"project_id"`
+	Profile    string      `json:"profile,omitempty"`
+	Engine     string      `json:"engine"`
+	Tags       []string    `json:"tags"`
+	Dockerfile string      `json:"dockerfile"`
+	State      BuildState  `json:"state"`
+	ExitCode   int         `json:"exit_code"`
+	Error      string      `json:"error,omitempty"`
+	Steps      []BuildStep `json:"steps,omitempty"`
+	Total      int         `json:"total"`          // Steps of all the stages (known once each stage started)
+	Size       int64       `json:"size,omitempty"` // Image size in bytes, successful builds
+	StartedAt  time.Time   `json:"started_at"`
+	FinishedAt time.Time   `json:"finished_at,omitempty"`
+}
+
+// Image returns the first tag of the build
+func (b ImageBuild) Image() string {
+	if len(b.Tags) == 0 {
+		return ""
+	}
+	return b.Tags[0]
+}
+
+// Duration returns how long the build ran (so far if running)
+func (b ImageBuild) Duration() time.Duration {
+	if b.FinishedAt.IsZero() {
+		return time.Since(b.StartedAt)
+	}
+	return b.FinishedAt.Sub(b.StartedAt)
+}
+
+// Progress returns the share of finished steps (0-100)
+func (b ImageBuild) Progress() int {
+	if b.Total == 0 {
+		return 0
+	}
+	finished := 0
+	for _, step := range b.Steps {
+		if step.State != StepRunning {
+			finished++
+		}
+	}
+	return min(finished*100/b.Total, 100)
+}
+
+// Cached returns the number of steps served from the layer cache
+func (b ImageBuild) Cached() int {
+	cached := 0
+	for _, step := range b.Steps {
+		if step.State == StepCached {
+			cached++
+		}
+	}
+	return cached
+}
+
+// ImageBuilder runs image builds with the engine of a container service and
+// keeps their history. Finished builds are persisted with their steps and
+// image size to follow the size of the images over time.
+type ImageBuilder struct {
+	engine *Service
+	file   string // History file (empty = not persisted)
+
+	mu      sync.RWMutex
+	builds  []*ImageBuild                 // Most recent last
+	cancels map[string]context.CancelFunc // Project ID -> its running build
+}
+
+// NewImageBuilder creates an image builder persisting its history in file
+func NewImageBuilder(engine *Service, file string) *ImageBuilder {
+	b := &ImageBuilder{
+		engine:  engine,
+		file:    file,
+		cancels: make(map[string]context.CancelFunc),
+	}
+	b.builds = b.load()
+	return b
+}
+
+// History returns a copy of the image builds, most recent last
+func (b *ImageBuilder) History() []ImageBuild {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	result := make([]ImageBuild, len(b.builds))
+	for i, build := range b.builds {
+		result[i] = *build
+		result[i].Steps = append([]BuildStep(nil), build.Steps...)
+	}
+	return result
+}
+
+// Running returns true while an image of the project is being built
+func (b *ImageBuilder) Running(projectID string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, ok := b.cancels[projectID]
+	return ok
+}
+
+// Cancel stops the running image build of a project, false if none
+func (b *ImageBuilder) Cancel(projectID string) bool {
+	b.mu.RLock()
+	cancel := b.cancels[projectID]
+	b.mu.RUnlock()
+	if cancel == nil {
+		return false
+	}
+	cancel()
+	return true
+}
+
+// Start runs an image build in the background. onOutput is called for each
+// output line with a copy of the build (its steps parsed so far), onUpdate
+// when it finishes.
+func (b *ImageBuilder) Start(ctx context.Context, req BuildRequest, onOutput func(ImageBuild, string), onUpdate func(ImageBuild)) (*ImageBuild, error) {
+	if len(req.Tags) == 0 {
+		return nil, fmt.Errorf("no image tag")
+	}
+	if _, err := os.Stat(req.Dockerfile); err != nil {
+		return nil, fmt.Errorf("dockerfile not found: %s", req.Dockerfile)
+	}
+
+	b.mu.Lock()
+	if _, running := b.cancels[req.ProjectID]; running {
+		b.mu.Unlock()
+		return nil, fmt.Errorf("an image of %s is already being built", req.ProjectID)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	b.cancels[req.ProjectID] = cancel
+	build := &ImageBuild{
+		ID:         strconv.FormatInt(time.Now().UnixNano(), 36),
+		ProjectID:  req.ProjectID,
+		Profile:    req.Profile,
+		Engine:     b.engine.Engine(),
+		Tags:       req.Tags,
+		Dockerfile: req.Dockerfile,
+		State:      BuildRunning,
+		StartedAt:  time.Now(),
+	}
+	b.builds = append(b.builds, build)
+	if len(b.builds) > maxImageBuilds {
+		b.builds = b.builds[len(b.builds)-maxImageBuilds:]
+	}
+	b.mu.Unlock()
+
+	b.engine.mu.RLock()
+	path := b.engine.path
+	b.engine.mu.RUnlock()
+	cmd := exec.CommandContext(ctx, path, buildArgs(req, build.Engine)...)
+	cmd.Dir = req.Context
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	if err := cmd.Start(); err != nil {
+		b.finish(ctx, build, newProgressParser(), err, "", nil)
+		return nil, fmt.Errorf("failed to start the image build: %w", err)
+	}
+
+	go func() {
+		done := make(chan error, 1)
+		go func() {
+			done <- cmd.Wait()
+			writer.Close()
+		}()
+
+		parser := newProgressParser()
+		last := ""
+		scanLines(reader, func(line string) {
+			if strings.TrimSpace(line) == "" {
+				return
+			}
+			last = line
+			b.mu.Lock()
+			if parser.parse(line, time.Now()) {
+				build.Steps = append([]BuildStep(nil), parser.steps...)
+				build.Total = parser.total()
+			}
+			snapshot := *build
+			b.mu.Unlock()
+			if onOutput != nil {
+				onOutput(snapshot, line)
+			}
+		})
+		b.finish(ctx, build, parser, <-done, last, onUpdate)
+	}()
+
+	b.mu.RLock()
+	snapshot := *build
+	b.mu.RUnlock()
+	return &snapshot, nil
+}
+
+// buildArgs returns the arguments of the build command. Docker is asked
+// for plain BuildKit progress, one line per event, which the steps are
+// parsed from.
+func buildArgs(req BuildRequest, engine string) []string {
+	args := []string{"build", "-f", req.Dockerfile}
+	if engine == "docker" {
+		args = append(args, "--progress=plain")
+	}
+	for _, tag := range req.Tags {
+		args = append(args, "-t", tag)
+	}
+	if req.Target != "" {
+		args = append(args, "--target", req.Target)
+	}
+	keys := make([]string, 0, len(req.Args))
+	for k := range req.Args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "--build-arg", k+"="+req.Args[k])
+	}
+	return append(args, req.Context)
+}
+
+// finish records the result of a build, with the size of the image built,
+// and persists the history
+func (b *ImageBuilder) finish(ctx context.Context, build *ImageBuild, parser *progressParser, err error, lastLine string, onUpdate func(ImageBuild)) {
+	var size int64
+	if err == nil {
+		size, _ = b.engine.ImageSize(context.Background(), build.Image())
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	parser.finish(err == nil, now)
+	build.Steps = append([]BuildStep(nil), parser.steps...)
+	build.Total = parser.total()
+	build.FinishedAt = now
+	switch {
+	case err == nil:
+		build.State = BuildSuccess
+		build.Size = size
+	case ctx.Err() == context.Canceled:
+		build.State = BuildCanceled
+		build.ExitCode = -1
+	default:
+		build.State = BuildFailed
+		build.ExitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			build.ExitCode = exitErr.ExitCode()
+		}
+		build.Error = err.Error()
+		// The last output line explains the failure better than the exit code
+		if lastLine != "" {
+			build.Error = strings.TrimSpace(lastLine)
+		}
+	}
+	if cancel := b.cancels[build.ProjectID]; cancel != nil {
+		cancel()
+		delete(b.cancels, build.ProjectID)
+	}
+	snapshot := *build
+	b.mu.Unlock()
+
+	b.save()
+
+	if onUpdate != nil {
+		onUpdate(snapshot)
+	}
+}
+
+// ImageSize returns the size of an image in bytes
+func (s *Service) ImageSize(ctx context.Context, image string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+	out, err := s.output(ctx, "image", "inspect", "--format", "{{.Size}}", image)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+}
+
+// load reads the history from disk. Builds left running by a previous
+// process can't be followed anymore: they are marked failed.
+func (b *ImageBuilder) load() []*ImageBuild {
+	if b.file == "" {
+		return nil
+	}
+	data, err := os.ReadFile(b.file)
+	if err != nil {
+		return nil // No history yet
+	}
+
+	var builds []*ImageBuild
+	if err := json.Unmarshal(data, &builds); err != nil {
+		return nil
+	}
+	for _, build := range builds {
+		if build.State == BuildRunning {
+			build.State = BuildFailed
+			build.Error = "interrupted"
+		}
+	}
+	return builds
+}
+
+// save writes the finished builds to disk atomically
+func (b *ImageBuilder) save() error {
+	if b.file == "" {
+		return nil
+	}
+
+	// Held while writing: concurrent saves share the temporary file
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	finished := make([]*ImageBuild, 0, len(b.builds))
+	for _, build := range b.builds {
+		if build.State != BuildRunning {
+			finished = append(finished, build)
+		}
+	}
+	data, err := json.MarshalIndent(finished, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.file), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(b.file, data, 0600); err != nil {
+		return fmt.Errorf("failed to write image build history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package containers
+
+// Action is a lifecycle action on a container
+type Action string
+
+const (
+	ActionStart   Action = "start"
+	ActionStop    Action = "stop"
+	ActionRestart Action = "restart"
+)
+
+// Labels set by docker compose (and podman-compose) on the containers of a
+// compose project
+const (
+	LabelComposeProject    = "com.docker.compose.project"
+	LabelComposeService    = "com.docker.compose.service"
+	LabelComposeWorkingDir = "com.docker.compose.project.working_dir"
+)
+
+// Container is a container known to the Docker (or Podman) engine
+type Container struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Image  string `json:"image"`
+	State  string `json:"state"`  // running, exited, paused, created, restarting...
+	Status string `json:"status"` // "Up 2 hours", "Exited (0) 3 minutes ago"
+	Ports  string `json:"ports,omitempty"`
+
+	// Compose project of the container, if started by docker compose
+	ComposeProject string `json:"compose_project,omitempty"`
+	ComposeService string `json:"compose_service,omitempty"`
+	WorkingDir     string `json:"working_dir,omitempty"` // Directory of the compose file
+
+	Stats *Stats `json:"stats,omitempty"` // Resource usage, running containers only
+}
+
+// Running returns true if the container is running
+func (c *Container) Running() bool {
+	return c.State == "running"
+}
+
+// Stats is the resource usage of a running container, as reported by
+// "docker stats"
+type Stats struct {
+	CPUPercent string `json:"cpu_percent"` // "0.52%"
+	MemUsage   string `json:"mem_usage"`   // "45.3MiB / 7.6GiB"
+	MemPercent string `json:"mem_percent"` // "0.58%"
+	NetIO      string `json:"net_io"`      // "1.2kB / 648B"
+	BlockIO    string `json:"block_io"`    // "0B / 0B"
+	PIDs       string `json:"pids"`
+}
+
+// Project identifies a registered project for matching containers
+type Project struct {
+	ID   string
+	Name string
+	Path string
+}
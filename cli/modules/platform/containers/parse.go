@@ -0,0 +1,211 @@
+package containers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// decodeRecords decodes the JSON output of "ps" or "stats": one object per
+// line (docker) or an array of objects (podman). Keys are normalized to
+// lowercase without underscores so both spellings read the same
+// ("CPUPerc" / "cpu_percent" give "cpuperc" / "cpupercent").
+func decodeRecords(data []byte) ([]map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var records []map[string]any
+	for {
+		var value any
+		if err := dec.Decode(&value); err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return nil, err
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			records = append(records, normalizeKeys(v))
+		case []any:
+			for _, item := range v {
+				if record, ok := item.(map[string]any); ok {
+					records = append(records, normalizeKeys(record))
+				}
+			}
+		}
+	}
+}
+
+// normalizeKeys lowercases the keys of a record and drops their underscores
+func normalizeKeys(record map[string]any) map[string]any {
+	normalized := make(map[string]any, len(record))
+	for k, v := range record {
+		normalized[strings.ReplaceAll(strings.ToLower(k), "_", "")] = v
+	}
+	return normalized
+}
+
+// stringField returns the first of the keys present in a record as a
+// string: lists are joined with commas
+func stringField(record map[string]any, keys ...string) string {
+	for _, key := range keys {
+		switch v := record[key].(type) {
+		case nil:
+			continue
+		case string:
+			return v
+		case []any:
+			parts := make([]string, 0, len(v))
+			for _, item := range v {
+				parts = append(parts, fmt.Sprint(item))
+			}
+			return strings.Join(parts, ",")
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+// parseContainer reads a container from a "ps" record
+func parseContainer(record map[string]any) Container {
+	c := Container{
+		ID:     shortID(stringField(record, "id")),
+		Image:  stringField(record, "image"),
+		State:  strings.ToLower(stringField(record, "state")),
+		Status: stringField(record, "status"),
+		Ports:  parsePorts(record["ports"]),
+	}
+	// docker: "web,alias"; podman: ["web"]
+	c.Name, _, _ = strings.Cut(stringField(record, "names"), ",")
+	if c.Name == "" {
+		c.Name = c.ID
+	}
+	labels := parseLabels(record["labels"])
+	c.ComposeProject = labels[LabelComposeProject]
+	c.ComposeService = labels[LabelComposeService]
+	c.WorkingDir = labels[LabelComposeWorkingDir]
+	return c
+}
+
+// parseLabels reads the labels of a container: "k=v,k=v" (docker) or an
+// object (podman)
+func parseLabels(value any) map[string]string {
+	labels := make(map[string]string)
+	switch v := value.(type) {
+	case string:
+		for _, pair := range strings.Split(v, ",") {
+			if k, val, ok := strings.Cut(pair, "="); ok {
+				labels[k] = val
+			}
+		}
+	case map[string]any:
+		for k, val := range v {
+			labels[k] = fmt.Sprint(val)
+		}
+	}
+	return labels
+}
+
+// parsePorts reads the published ports of a container: a string
+// (docker) or a list of port mappings (podman), rendered like docker does
+// ("0.0.0.0:8080->80/tcp")
+func parsePorts(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []any:
+		var ports []string
+		for _, item := range v {
+			mapping, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			mapping = normalizeKeys(mapping)
+			port := stringField(mapping, "containerport") + "/" + stringField(mapping, "protocol")
+			if host := stringField(mapping, "hostport"); host != "" && host != "0" {
+				ip := stringField(mapping, "hostip")
+				if ip == "" {
+					ip = "0.0.0.0"
+				}
+				port = ip + ":" + host + "->" + port
+			}
+			ports = append(ports, port)
+		}
+		return strings.Join(ports, ", ")
+	}
+	return ""
+}
+
+// MatchProject returns the ID of the project a container belongs to, empty
+// if none: the compose file lives in the project, the compose project is
+// named after it, or else the image is (myapp, registry/myapp-api:dev)
+func MatchProject(c Container, projects []Project) string {
+	if c.WorkingDir != "" {
+		best, bestLen := "", 0
+		for _, p := range projects {
+			if p.Path != "" && isWithin(c.WorkingDir, p.Path) && len(p.Path) > bestLen {
+				best, bestLen = p.ID, len(p.Path)
+			}
+		}
+		if best != "" {
+			return best
+		}
+	}
+	if c.ComposeProject != "" {
+		for _, p := range projects {
+			if sameName(c.ComposeProject, p.ID, p.Name, filepath.Base(p.Path)) {
+				return p.ID
+			}
+		}
+	}
+
+	repo := imageRepository(c.Image)
+	for _, p := range projects {
+		for _, name := range []string{p.ID, p.Name} {
+			if key := composeName(name); len(key) >= 3 && strings.Contains(composeName(repo), key) {
+				return p.ID
+			}
+		}
+	}
+	return ""
+}
+
+// isWithin returns true if dir is root or one of its subdirectories
+func isWithin(dir, root string) bool {
+	dir, root = filepath.Clean(dir), filepath.Clean(root)
+	return dir == root || strings.HasPrefix(dir, root+string(filepath.Separator))
+}
+
+// sameName returns true if a compose project name is one of the names
+// (compose lowercases the name and drops other characters than [a-z0-9_-])
+func sameName(composeProject string, names ...string) bool {
+	for _, name := range names {
+		if name != "" && composeName(name) == composeName(composeProject) {
+			return true
+		}
+	}
+	return false
+}
+
+// composeName normalizes a name the way compose names projects
+func composeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// imageRepository returns the repository name of an image without registry,
+// namespace, tag or digest: "ghcr.io/acme/api:1.2" gives "api"
+func imageRepository(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		image = image[i+1:]
+	}
+	image, _, _ = strings.Cut(image, ":")
+	return image
+}
@@ -0,0 +1,146 @@
+package containers
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// BuildKit plain progress: "#7 [builder 2/5] RUN go build ./..."
+	buildkitStep  = regexp.MustCompile(`^#(\d+) \[(?:(\S+) )?(\d+)/(\d+)\] (.+)$`)
+	buildkitDone  = regexp.MustCompile(`^#(\d+) DONE (\d+(?:\.\d+)?)s$`)
+	buildkitState = regexp.MustCompile(`^#(\d+) (CACHED|ERROR|CANCELED)\b`)
+	// Legacy docker builder ("Step 2/5 : RUN ...") and podman/buildah
+	// ("[1/2] STEP 2/5: RUN ...", multi-stage builds prefix the stage)
+	classicStep = regexp.MustCompile(`^(?:\[(\d+)/\d+\] )?(?:Step|STEP) (\d+)/(\d+) ?: (.+)$`)
+	usingCache  = regexp.MustCompile(`^\s*-{2,3}> Using cache`)
+)
+
+// progressParser follows the steps (layers) of an image build from its
+// output, BuildKit plain progress as well as the legacy docker and podman
+// formats
+type progressParser struct {
+	steps   []BuildStep
+	index   map[string]int // Step key -> index in steps
+	totals  map[string]int // Stage -> number of steps
+	current int            // Running step of the classic formats, -1 if none
+	started map[int]time.Time
+}
+
+func newProgressParser() *progressParser {
+	return &progressParser{
+		index:   make(map[string]int),
+		totals:  make(map[string]int),
+		current: -1,
+		started: make(map[int]time.Time),
+	}
+}
+
+// parse reads an output line, returns true if the steps changed
+func (p *progressParser) parse(line string, now time.Time) bool {
+	line = strings.TrimSpace(line)
+
+	if m := buildkitStep.FindStringSubmatch(line); m != nil {
+		total, _ := strconv.Atoi(m[4])
+		p.totals[m[2]] = total
+		if _, known := p.index["#"+m[1]]; known {
+			return false
+		}
+		p.add("#"+m[1], m[5], now)
+		return true
+	}
+	if m := buildkitDone.FindStringSubmatch(line); m != nil {
+		if i, ok := p.index["#"+m[1]]; ok && p.steps[i].State == StepRunning {
+			seconds, _ := strconv.ParseFloat(m[2], 64)
+			p.steps[i].State = StepDone
+			p.steps[i].DurationMs = int64(seconds * 1000)
+			return true
+		}
+		return false
+	}
+	if m := buildkitState.FindStringSubmatch(line); m != nil {
+		i, ok := p.index["#"+m[1]]
+		if !ok {
+			return false
+		}
+		switch m[2] {
+		case "CACHED":
+			p.steps[i].State = StepCached
+		case "ERROR":
+			p.steps[i].State = StepFailed
+		default:
+			p.steps[i].State = StepCanceled
+		}
+		p.steps[i].DurationMs = now.Sub(p.started[i]).Milliseconds()
+		return true
+	}
+
+	if m := classicStep.FindStringSubmatch(line); m != nil {
+		p.finishCurrent(now)
+		total, _ := strconv.Atoi(m[3])
+		p.totals[m[1]] = total
+		p.add(m[1]+"/"+m[2], m[4], now)
+		p.current = len(p.steps) - 1
+		return true
+	}
+	if p.current >= 0 && usingCache.MatchString(line) {
+		p.steps[p.current].State = StepCached
+		return true
+	}
+	return false
+}
+
+// add starts a step
+func (p *progressParser) add(key, instruction string, now time.Time) {
+	p.index[key] = len(p.steps)
+	p.started[len(p.steps)] = now
+	p.steps = append(p.steps, BuildStep{
+		Instruction: instruction,
+		State:       StepRunning,
+	})
+}
+
+// finishCurrent ends the running step of the classic formats (a step ends
+// when the next one starts)
+func (p *progressParser) finishCurrent(now time.Time) {
+	if p.current < 0 {
+		return
+	}
+	step := &p.steps[p.current]
+	if step.State == StepRunning {
+		step.State = StepDone
+	}
+	step.DurationMs = now.Sub(p.started[p.current]).Milliseconds()
+	p.current = -1
+}
+
+// finish ends the build: the steps still running succeeded with it, or were
+// interrupted by its failure (the running step of the classic formats is
+// the one that failed)
+func (p *progressParser) finish(success bool, now time.Time) {
+	if !success && p.current >= 0 && p.steps[p.current].State == StepRunning {
+		p.steps[p.current].State = StepFailed
+	}
+	p.finishCurrent(now)
+	for i := range p.steps {
+		if p.steps[i].State != StepRunning {
+			continue
+		}
+		p.steps[i].State = StepCanceled
+		if success {
+			p.steps[i].State = StepDone
+		}
+		p.steps[i].DurationMs = now.Sub(p.started[i]).Milliseconds()
+	}
+}
+
+// total returns the number of steps of all the stages seen so far
+func (p *progressParser) total() int {
+	total := 0
+	for _, n := range p.totals {
+		total += n
+	}
+	return max(total, len(p.steps))
+}
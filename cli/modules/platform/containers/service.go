@@ -0,0 +1,350 @@
+package containers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// listTimeout bounds "ps" and "stats" (stats samples usage for a second)
+	listTimeout = 20 * time.Second
+	// actionTimeout bounds start, stop and restart (stop waits for the
+	// container to exit, 10s by default)
+	actionTimeout = 60 * time.Second
+	// logTail is the number of past log lines shown when following logs
+	logTail = "200"
+	// runTimeout bounds "run", which pulls the image the first time
+	runTimeout = 10 * time.Minute
+)
+
+// RunSpec describes a container run in the background
+type RunSpec struct {
+	Name    string
+	Image   string
+	Ports   []string          // -p values ("5432:5432")
+	Volumes []string          // -v values ("pgdata:/var/lib/postgresql/data")
+	Env     map[string]string // -e values
+	Labels  map[string]string
+}
+
+// Service lists the containers of the Docker or Podman engine, reads their
+// resource usage and starts, stops and restarts them. Both engines share
+// the commands; their JSON output differs and is normalized.
+type Service struct {
+	mu        sync.RWMutex
+	path      string                        // docker or podman executable
+	followers map[string]context.CancelFunc // Container ID -> its logs follower
+}
+
+// NewService creates a container service
+func NewService() *Service {
+	return &Service{
+		path:      "docker",
+		followers: make(map[string]context.CancelFunc),
+	}
+}
+
+// DetectPath returns the engine executable where capabilities are not
+// detected: the configured one, else docker or podman found in the PATH
+func DetectPath(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	for _, name := range []string{"docker", "podman"} {
+		if path, err := exec.LookPath(name); err == nil {
+			return path
+		}
+	}
+	return "docker"
+}
+
+// SetPath sets the docker (or podman) executable
+func (s *Service) SetPath(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if path != "" {
+		s.path = path
+	}
+}
+
+// Engine returns the name of the container engine: docker or podman
+func (s *Service) Engine() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if strings.HasPrefix(filepath.Base(s.path), "podman") {
+		return "podman"
+	}
+	return "docker"
+}
+
+// jsonFormat returns the --format value printing JSON: one object per line
+// for docker, an array for podman
+func (s *Service) jsonFormat() string {
+	if s.Engine() == "podman" {
+		return "json"
+	}
+	return "{{json .}}"
+}
+
+// List returns all the containers (running or not), with the resource usage
+// of the running ones
+func (s *Service) List(ctx context.Context) ([]Container, error) {
+	ctx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	out, err := s.output(ctx, "ps", "-a", "--format", s.jsonFormat())
+	if err != nil {
+		return nil, err
+	}
+	records, err := decodeRecords(out)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected %s ps output: %w", s.Engine(), err)
+	}
+
+	containers := make([]Container, 0, len(records))
+	var running []string
+	for _, r := range records {
+		c := parseContainer(r)
+		if c.ID == "" {
+			continue
+		}
+		containers = append(containers, c)
+		if c.Running() {
+			running = append(running, c.ID)
+		}
+	}
+	sort.Slice(containers, func(i, j int) bool { return containers[i].Name < containers[j].Name })
+
+	// Resource usage is a bonus: the list is returned without it on error
+	if len(running) > 0 {
+		if stats, err := s.stats(ctx, running); err == nil {
+			for i := range containers {
+				if st, ok := stats[shortID(containers[i].ID)]; ok {
+					containers[i].Stats = st
+				}
+			}
+		}
+	}
+	return containers, nil
+}
+
+// stats returns the resource usage of running containers by short ID
+func (s *Service) stats(ctx context.Context, ids []string) (map[string]*Stats, error) {
+	args := append([]string{"stats", "--no-stream", "--format", s.jsonFormat()}, ids...)
+	out, err := s.output(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	records, err := decodeRecords(out)
+	if err != nil {
+		return nil, err
+	}
+	stats := make(map[string]*Stats, len(records))
+	for _, r := range records {
+		id := shortID(stringField(r, "id", "containerid"))
+		if id == "" {
+			continue
+		}
+		stats[id] = &Stats{
+			CPUPercent: stringField(r, "cpuperc", "cpupercent", "cpu"),
+			MemUsage:   stringField(r, "memusage"),
+			MemPercent: stringField(r, "memperc", "mempercent"),
+			NetIO:      stringField(r, "netio"),
+			BlockIO:    stringField(r, "blockio"),
+			PIDs:       stringField(r, "pids"),
+		}
+	}
+	return stats, nil
+}
+
+// Do starts, stops or restarts a container
+func (s *Service) Do(ctx context.Context, id string, action Action) error {
+	switch action {
+	case ActionStart, ActionStop, ActionRestart:
+	default:
+		return fmt.Errorf("unknown container action: %s", action)
+	}
+	ctx, cancel := context.WithTimeout(ctx, actionTimeout)
+	defer cancel()
+	_, err := s.output(ctx, string(action), id)
+	return err
+}
+
+// Run creates and starts a container in the background, replacing a
+// stopped container of the same name (its named volumes are kept)
+func (s *Service) Run(ctx context.Context, spec RunSpec) error {
+	ctx, cancel := context.WithTimeout(ctx, runTimeout)
+	defer cancel()
+
+	s.output(ctx, "rm", "-f", spec.Name) // Not found is fine
+
+	args := []string{"run", "-d", "--name", spec.Name}
+	for _, port := range spec.Ports {
+		args = append(args, "-p", port)
+	}
+	for _, volume := range spec.Volumes {
+		args = append(args, "-v", volume)
+	}
+	for _, pairs := range []struct {
+		flag   string
+		values map[string]string
+	}{{"-e", spec.Env}, {"--label", spec.Labels}} {
+		keys := make([]string, 0, len(pairs.values))
+		for k := range pairs.values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			args = append(args, pairs.flag, k+"="+pairs.values[k])
+		}
+	}
+	_, err := s.output(ctx, append(args, spec.Image)...)
+	return err
+}
+
+// State returns the state of a container by ID or name ("running",
+// "exited"...), empty if there is no such container
+func (s *Service) State(ctx context.Context, id string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+	out, err := s.output(ctx, "inspect", "--format", "{{.State.Status}}", id)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "no such") {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Exec runs a shell command in a running container, an error if it fails
+func (s *Service) Exec(ctx context.Context, id, command string) error {
+	_, err := s.output(ctx, "exec", id, "sh", "-c", command)
+	return err
+}
+
+// Following returns true while the logs of a container are followed
+func (s *Service) Following(id string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.followers[id]
+	return ok
+}
+
+// FollowLogs streams the last lines, then the new lines of the logs of a
+// container to onLine (isError for its stderr) until the container stops or
+// ctx is canceled; onEnd is then called. It returns at once, false if the
+// logs of the container are already followed.
+func (s *Service) FollowLogs(ctx context.Context, id string, onLine func(line string, isError bool), onEnd func(error)) (bool, error) {
+	s.mu.Lock()
+	if _, ok := s.followers[id]; ok {
+		s.mu.Unlock()
+		return false, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s.followers[id] = cancel
+	path := s.path
+	s.mu.Unlock()
+
+	stop := func() {
+		cancel()
+		s.mu.Lock()
+		delete(s.followers, id)
+		s.mu.Unlock()
+	}
+
+	cmd := exec.CommandContext(ctx, path, "logs", "-f", "--tail", logTail, id)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		stop()
+		return false, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		stop()
+		return false, err
+	}
+	if err := cmd.Start(); err != nil {
+		stop()
+		return false, err
+	}
+
+	go func() {
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() { defer wg.Done(); scanLines(stdout, func(line string) { onLine(line, false) }) }()
+		go func() { defer wg.Done(); scanLines(stderr, func(line string) { onLine(line, true) }) }()
+		wg.Wait()
+		err := cmd.Wait()
+		if ctx.Err() != nil {
+			err = nil // Stopped on purpose
+		}
+		stop()
+		if onEnd != nil {
+			onEnd(err)
+		}
+	}()
+	return true, nil
+}
+
+// StopLogs stops following the logs of a container
+func (s *Service) StopLogs(id string) {
+	s.mu.RLock()
+	cancel := s.followers[id]
+	s.mu.RUnlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// output runs an engine command and returns its stdout. On failure the
+// error carries the first line of stderr ("Cannot connect to the Docker
+// daemon...").
+func (s *Service) output(ctx context.Context, args ...string) ([]byte, error) {
+	s.mu.RLock()
+	path := s.path
+	s.mu.RUnlock()
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("%s %s timed out", filepath.Base(path), args[0])
+		}
+		if msg, _, _ := strings.Cut(strings.TrimSpace(stderr.String()), "\n"); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// scanLines calls fn with each line read from r
+func scanLines(r io.Reader, fn func(string)) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fn(strings.TrimRight(scanner.Text(), "\r"))
+	}
+	io.Copy(io.Discard, r) // Unblock the command after an over-long line
+}
+
+// shortID returns the 12-character form of a container ID
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -2,10 +2,12 @@ package daemon
 
 import (
 	"bufio"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"os"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,10 +15,18 @@ import (
 	"csd-devtrack/cli/modules/ui/core"
 )
 
+// Remote is a shared daemon in team mode, attached over the network
+type Remote struct {
+	Address     string // host:port of the team listener
+	Token       string // Token of the teammate
+	Fingerprint string // SHA-256 fingerprint of a self-signed certificate (pinned)
+}
+
 // Client connects to the daemon server
 type Client struct {
 	conn   net.Conn
 	reader *bufio.Reader
+	remote *Remote // nil for the local daemon
 
 	// Callbacks
 	onState          func(*core.AppState)
@@ -29,6 +39,7 @@ type Client struct {
 	// Server version info (populated after handshake)
 	serverVersion string
 	serverHash    string
+	role          string // Role of the teammate on a remote daemon
 
 	// Buffered messages (received before handlers are set)
 	pendingLogs  []core.LogLineVM
@@ -48,48 +59,133 @@ func NewClient() *Client {
 	}
 }
 
+// NewRemoteClient creates a client of a shared daemon (team mode)
+func NewRemoteClient(remote Remote) *Client {
+	return &Client{
+		remote: &remote,
+		done:   make(chan struct{}),
+	}
+}
+
 // Connect connects to the daemon server
 func (c *Client) Connect() error {
-	socketPath := GetSocketPath()
+	if c.remote != nil {
+		return c.connectRemote()
+	}
+
+	conn, err := dialDaemon(5 * time.Second)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+	c.connected = true
+	c.mu.Unlock()
+
+	// Start message receiver
+	c.wg.Add(1)
+	go c.receiveLoop()
 
-	var conn net.Conn
-	var err error
+	// Send handshake with our version
+	if err := c.sendHandshake(); err != nil {
+		// Non-fatal, just log and continue
+	}
+
+	return nil
+}
+
+// dialDaemon opens a connection to the daemon socket (a TCP address read
+// from a file on Windows)
+func dialDaemon(timeout time.Duration) (net.Conn, error) {
+	socketPath := GetSocketPath()
 
 	if runtime.GOOS == "windows" {
-		// Read TCP address from file
 		addrData, err := os.ReadFile(socketPath + ".addr")
 		if err != nil {
-			return fmt.Errorf("daemon not running: %w", err)
+			return nil, fmt.Errorf("daemon not running: %w", err)
 		}
-		conn, err = net.DialTimeout("tcp", string(addrData), 5*time.Second)
+		conn, err := net.DialTimeout("tcp", string(addrData), timeout)
 		if err != nil {
-			return fmt.Errorf("failed to connect to daemon: %w", err)
+			return nil, fmt.Errorf("failed to connect to daemon: %w", err)
 		}
-	} else {
-		conn, err = net.DialTimeout("unix", socketPath, 5*time.Second)
-		if err != nil {
-			return fmt.Errorf("failed to connect to daemon: %w", err)
+		return conn, nil
+	}
+
+	conn, err := net.DialTimeout("unix", socketPath, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	return conn, nil
+}
+
+// connectRemote dials a shared daemon over TLS and authenticates with the
+// token: the handshake is answered before the receive loop starts
+func (c *Client) connectRemote() error {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if host, _, err := net.SplitHostPort(c.remote.Address); err == nil {
+		tlsConfig.ServerName = host
+	}
+	if pinned := normalizeFingerprint(c.remote.Fingerprint); pinned != "" {
+		// Self-signed certificate: trusted by its fingerprint, not by a CA
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 ||
+				normalizeFingerprint(Fingerprint(state.PeerCertificates[0].Raw)) != pinned {
+				return fmt.Errorf("certificate fingerprint mismatch")
+			}
+			return nil
 		}
 	}
 
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", c.remote.Address, tlsConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", c.remote.Address, err)
+	}
+
 	c.mu.Lock()
 	c.conn = conn
 	c.reader = bufio.NewReader(conn)
 	c.connected = true
 	c.mu.Unlock()
 
-	// Start message receiver
-	c.wg.Add(1)
-	go c.receiveLoop()
-
-	// Send handshake with our version
 	if err := c.sendHandshake(); err != nil {
-		// Non-fatal, just log and continue
+		conn.Close()
+		return err
 	}
 
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	line, err := c.reader.ReadBytes('\n')
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("no handshake from %s: %w", c.remote.Address, err)
+	}
+	msg, err := DecodeMessage(line)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	if msg.Type == MsgError {
+		var payload ErrorPayload
+		msg.Decode(&payload)
+		conn.Close()
+		return fmt.Errorf("%s", payload.Message)
+	}
+	c.handleMessage(msg)
+
+	c.wg.Add(1)
+	go c.receiveLoop()
 	return nil
 }
 
+// normalizeFingerprint returns a fingerprint in upper case without separators
+func normalizeFingerprint(fingerprint string) string {
+	fingerprint = strings.NewReplacer(":", "", " ", "", "-", "").Replace(fingerprint)
+	return strings.ToUpper(fingerprint)
+}
+
 // sendHandshake sends version handshake to the server
 func (c *Client) sendHandshake() error {
 	c.mu.Lock()
@@ -104,6 +200,9 @@ func (c *Client) sendHandshake() error {
 		BuildHash: modules.BuildHash(),
 		Version:   modules.AppVersion,
 	}
+	if c.remote != nil {
+		payload.Token = c.remote.Token
+	}
 	msg, err := NewMessage(MsgHandshake, payload)
 	if err != nil {
 		return err
@@ -125,6 +224,14 @@ func (c *Client) GetServerVersion() (version, hash string) {
 	return c.serverVersion, c.serverHash
 }
 
+// Role returns the role of the teammate on a remote daemon (empty for the
+// local one)
+func (c *Client) Role() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.role
+}
+
 // SetVersionMismatchHandler sets the callback for version mismatch
 func (c *Client) SetVersionMismatchHandler(handler func(serverVersion, serverHash string)) {
 	c.mu.Lock()
@@ -437,6 +544,7 @@ func (c *Client) handleMessage(msg *Message) {
 		c.mu.Lock()
 		c.serverVersion = payload.Version
 		c.serverHash = payload.BuildHash
+		c.role = payload.Role
 		handler := c.onVersionMismatch
 		c.mu.Unlock()
 
@@ -78,11 +78,7 @@ func StartDaemon() (int, error) {
 	cmd.Env = append(os.Environ(), EnvDaemonMode+"=1")
 
 	// Detach from terminal
-	if runtime.GOOS != "windows" {
-		cmd.SysProcAttr = &syscall.SysProcAttr{
-			Setsid: true, // Create new session
-		}
-	}
+	cmd.SysProcAttr = detachedProcAttr()
 
 	// Redirect stdio to null
 	cmd.Stdin = nil
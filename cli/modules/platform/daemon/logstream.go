@@ -0,0 +1,420 @@
+package daemon
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/logger"
+	"csd-devtrack/cli/modules/ui/core"
+
+	"github.com/gorilla/websocket"
+)
+
+// logStreamHistory is the number of lines kept for the history= replay
+const logStreamHistory = 1000
+
+// logStreamQueue is the number of lines queued per client before they are
+// dropped (client too slow)
+const logStreamQueue = 512
+
+// logSource is the presenter side of the stream: the lines of the unified
+// log feed appended since a line number
+type logSource interface {
+	LogsSince(seq int64) ([]core.LogLineVM, int64)
+}
+
+// LogStream streams the unified log feed (component, build and container
+// output, and DevTrack's diagnostics) over WebSocket, one JSON line per
+// message, filtered by the query parameters of each client
+type LogStream struct {
+	cfg      *config.LogStreamConfig
+	server   *http.Server
+	listener net.Listener
+	upgrader websocket.Upgrader
+
+	pullMu sync.Mutex // Held while reading the presenter logs, before mu
+	seq    int64      // Last presenter line streamed
+
+	mu      sync.Mutex
+	history []core.LogLineVM
+	clients map[*logStreamClient]bool
+	stopped bool
+}
+
+// logStreamClient is a connected client with its filter
+type logStreamClient struct {
+	conn    *websocket.Conn
+	filter  *logFilter
+	send    chan core.LogLineVM
+	dropped int // Lines dropped since the last one sent
+}
+
+// logFilter selects the streamed lines, from the query parameters
+type logFilter struct {
+	sources   []string       // source=: exact sources, or prefixes ending with *
+	project   string         // project=: project ID of the source
+	component string         // component=: component type of the source
+	level     logger.Level   // level=: minimum level
+	query     string         // q=: case-insensitive text
+	pattern   *regexp.Regexp // regex=: regular expression on the message
+}
+
+// NewLogStream creates the log stream of the daemon
+func NewLogStream(cfg *config.LogStreamConfig) *LogStream {
+	s := &LogStream{
+		cfg:     cfg,
+		clients: make(map[*logStreamClient]bool),
+	}
+	s.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 4096,
+		CheckOrigin:     s.checkOrigin,
+	}
+	return s
+}
+
+// Start listens on localhost
+func (s *LogStream) Start() error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", s.cfg.Port))
+	if err != nil {
+		return fmt.Errorf("log stream: %w", err)
+	}
+	s.listener = listener
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/logs", s.serveWS)
+	s.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go s.server.Serve(listener)
+	return nil
+}
+
+// Stop closes the endpoint and disconnects the clients
+func (s *LogStream) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	for c := range s.clients {
+		close(c.send)
+		delete(s.clients, c)
+	}
+	s.mu.Unlock()
+
+	if s.server != nil {
+		s.server.Close()
+	}
+}
+
+// URL returns the WebSocket URL of the stream
+func (s *LogStream) URL() string {
+	if s.listener == nil {
+		return ""
+	}
+	return "ws://" + s.listener.Addr().String() + "/logs"
+}
+
+// Publish streams a line to the clients whose filter matches it
+func (s *LogStream) Publish(line core.LogLineVM) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publish(line)
+}
+
+// pull streams the lines the presenter appended since the last pull. The
+// presenter lock is not taken under mu: the presenter logs while holding it.
+func (s *LogStream) pull(source logSource) {
+	s.pullMu.Lock()
+	defer s.pullMu.Unlock()
+	lines, seq := source.LogsSince(s.seq)
+	s.seq = seq
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, line := range lines {
+		s.publish(line)
+	}
+}
+
+// publish keeps a line in the history and queues it for the clients. The
+// caller holds s.mu.
+func (s *LogStream) publish(line core.LogLineVM) {
+	if s.stopped {
+		return
+	}
+	if len(s.history) >= logStreamHistory {
+		s.history = s.history[1:]
+	}
+	s.history = append(s.history, line)
+
+	for c := range s.clients {
+		if !c.filter.match(line) {
+			continue
+		}
+		if c.dropped > 0 {
+			select {
+			case c.send <- droppedMarker(c.dropped):
+				c.dropped = 0
+			default:
+				c.dropped++
+				continue
+			}
+		}
+		select {
+		case c.send <- line:
+		default:
+			c.dropped++
+		}
+	}
+}
+
+// droppedMarker is the line reporting the lines a slow client missed
+func droppedMarker(n int) core.LogLineVM {
+	now := time.Now()
+	return core.LogLineVM{
+		Timestamp: now,
+		TimeStr:   now.Format("15:04:05"),
+		Source:    core.LogSourceDevTrack,
+		Level:     "warn",
+		Message:   fmt.Sprintf("⋯ %d lines dropped (log stream client too slow)", n),
+	}
+}
+
+// checkOrigin accepts the clients outside a browser (no Origin), the pages
+// served from localhost and the configured origins: any web page could
+// otherwise read the logs
+func (s *LogStream) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range s.cfg.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return strings.HasSuffix(u.Hostname(), ".localhost")
+}
+
+// authorized checks the token of the request when one is configured
+func (s *LogStream) authorized(r *http.Request) bool {
+	if s.cfg.Token == "" {
+		return true
+	}
+	token := r.URL.Query().Get("token")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		token = bearer
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.Token)) == 1
+}
+
+// serveWS upgrades a request to the stream: the last history= lines
+// matching the filter are sent first, then the live ones
+func (s *LogStream) serveWS(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+	query := r.URL.Query()
+	filter, err := parseLogFilter(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	history := 0
+	if value := query.Get("history"); value != "" {
+		if history, err = strconv.Atoi(value); err != nil || history < 0 {
+			http.Error(w, "history must be a number of lines", http.StatusBadRequest)
+			return
+		}
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // The upgrader answered the request
+	}
+	client := &logStreamClient{
+		conn:   conn,
+		filter: filter,
+		send:   make(chan core.LogLineVM, logStreamQueue+logStreamHistory),
+	}
+
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		conn.Close()
+		return
+	}
+	var replay []core.LogLineVM
+	for i := len(s.history) - 1; i >= 0 && len(replay) < history; i-- {
+		if filter.match(s.history[i]) {
+			replay = append(replay, s.history[i])
+		}
+	}
+	for i := len(replay) - 1; i >= 0; i-- {
+		client.send <- replay[i]
+	}
+	s.clients[client] = true
+	s.mu.Unlock()
+
+	logger.Debug("Log stream client connected: %s", r.RemoteAddr)
+	go s.writePump(client)
+	go s.readPump(client)
+}
+
+// writePump sends the queued lines and keeps the connection alive
+func (s *LogStream) writePump(c *logStreamClient) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case line, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "daemon stopped"))
+				return
+			}
+			data, err := json.Marshal(line)
+			if err != nil {
+				continue
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readPump waits for the client to go away (it sends nothing but pongs and
+// the close message), then forgets it
+func (s *LogStream) readPump(c *logStreamClient) {
+	defer func() {
+		s.mu.Lock()
+		if s.clients[c] {
+			delete(s.clients, c)
+			close(c.send)
+		}
+		s.mu.Unlock()
+		c.conn.Close()
+		logger.Debug("Log stream client disconnected")
+	}()
+
+	c.conn.SetReadLimit(4096)
+	c.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+		return nil
+	})
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// parseLogFilter reads the filter of a client: source (comma-separated,
+// trailing * for a prefix), project, component, level (minimum), q (text)
+// and regex
+func parseLogFilter(query url.Values) (*logFilter, error) {
+	f := &logFilter{
+		project:   query.Get("project"),
+		component: query.Get("component"),
+		level:     logger.DEBUG,
+		query:     strings.ToLower(query.Get("q")),
+	}
+	for _, source := range strings.Split(query.Get("source"), ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			f.sources = append(f.sources, source)
+		}
+	}
+	if level := query.Get("level"); level != "" {
+		switch strings.ToLower(level) {
+		case "debug", "info", "warn", "warning", "error":
+			f.level = logger.ParseLevel(level)
+		default:
+			return nil, fmt.Errorf("unknown level: %s (debug, info, warn, error)", level)
+		}
+	}
+	if pattern := query.Get("regex"); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex: %w", err)
+		}
+		f.pattern = re
+	}
+	return f, nil
+}
+
+// match returns true if a line passes the filter
+func (f *logFilter) match(line core.LogLineVM) bool {
+	if len(f.sources) > 0 {
+		found := false
+		for _, source := range f.sources {
+			if prefix, ok := strings.CutSuffix(source, "*"); ok {
+				found = strings.HasPrefix(line.Source, prefix)
+			} else {
+				found = line.Source == source
+			}
+			if found {
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.project != "" || f.component != "" {
+		project, component := splitLogSource(line.Source)
+		if f.project != "" && project != f.project {
+			return false
+		}
+		if f.component != "" && component != f.component {
+			return false
+		}
+	}
+	if f.level > logger.DEBUG && logger.ParseLevel(line.Level) < f.level {
+		return false
+	}
+	if f.query != "" && !strings.Contains(strings.ToLower(line.Message), f.query) {
+		return false
+	}
+	if f.pattern != nil && !f.pattern.MatchString(line.Message) {
+		return false
+	}
+	return true
+}
+
+// splitLogSource returns the project and component of a source such as
+// project/component or build:project/component
+func splitLogSource(source string) (string, string) {
+	if i := strings.Index(source, ":"); i >= 0 {
+		source = source[i+1:]
+	}
+	project, component, _ := strings.Cut(source, "/")
+	return project, component
+}
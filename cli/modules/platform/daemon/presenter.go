@@ -1,7 +1,9 @@
 package daemon
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 
@@ -21,6 +23,10 @@ type ClientPresenter struct {
 	tuiStateCallback      func(*TUIState) // Called when TUI state should be restored
 	pendingTUIState       *TUIState       // Buffered if received before callback is set
 
+	// Differential updates
+	lastViewModels map[core.ViewModelType][]byte // Last notified view models (JSON) to skip unchanged ones
+	updateBatcher  *core.UpdateBatcher           // Coalesces log lines received from the daemon
+
 	// Lifecycle
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -28,12 +34,15 @@ type ClientPresenter struct {
 
 // NewClientPresenter creates a presenter that communicates with the daemon
 func NewClientPresenter(client *Client) *ClientPresenter {
-	return &ClientPresenter{
+	p := &ClientPresenter{
 		client:                client,
 		state:                 core.NewAppState(),
 		stateCallbacks:        make([]func(core.StateUpdate), 0),
 		notificationCallbacks: make([]func(*core.Notification), 0),
+		lastViewModels:        make(map[core.ViewModelType][]byte),
 	}
+	p.updateBatcher = core.NewUpdateBatcher(core.StateBatchInterval, p.deliverStateUpdate)
+	return p
 }
 
 // Initialize sets up the client presenter
@@ -126,6 +135,14 @@ func (p *ClientPresenter) GetViewModel(viewType core.ViewModelType) (core.ViewMo
 		return p.state.Database, nil
 	case core.VMCockpit:
 		return p.state.Cockpit, nil
+	case core.VMStorage:
+		return p.state.Storage, nil
+	case core.VMTrash:
+		return p.state.Trash, nil
+	case core.VMAudit:
+		return p.state.Audit, nil
+	case core.VMPlugins:
+		return p.state.Plugins, nil
 	default:
 		return nil, fmt.Errorf("unknown view type: %s", viewType)
 	}
@@ -155,6 +172,10 @@ func (p *ClientPresenter) Subscribe(callback func(core.StateUpdate)) {
 			{core.VMClaude, state.Claude},
 			{core.VMDatabase, state.Database},
 			{core.VMCockpit, state.Cockpit},
+			{core.VMStorage, state.Storage},
+			{core.VMTrash, state.Trash},
+			{core.VMAudit, state.Audit},
+			{core.VMPlugins, state.Plugins},
 		}
 		for _, v := range viewModels {
 			if v.vm != nil {
@@ -216,11 +237,8 @@ func (p *ClientPresenter) handleStateUpdate(state *core.AppState) {
 	p.state = state
 	p.mu.Unlock()
 
-	p.mu.RLock()
-	callbacks := p.stateCallbacks
-	p.mu.RUnlock()
-
-	// Notify with ALL view models since this is a full state sync
+	// The daemon always sends the full state: only notify the view models
+	// that actually changed since the last sync
 	viewModels := []struct {
 		viewType core.ViewModelType
 		vm       core.ViewModel
@@ -235,21 +253,49 @@ func (p *ClientPresenter) handleStateUpdate(state *core.AppState) {
 		{core.VMClaude, state.Claude},
 		{core.VMDatabase, state.Database},
 		{core.VMCockpit, state.Cockpit},
+		{core.VMStorage, state.Storage},
+		{core.VMTrash, state.Trash},
+		{core.VMAudit, state.Audit},
+		{core.VMPlugins, state.Plugins},
 	}
 
 	for _, v := range viewModels {
-		if v.vm != nil {
-			update := core.StateUpdate{
+		if v.vm != nil && p.viewModelChanged(v.viewType, v.vm) {
+			p.deliverStateUpdate(core.StateUpdate{
 				ViewType:  v.viewType,
 				ViewModel: v.vm,
-			}
-			for _, cb := range callbacks {
-				cb(update)
-			}
+			})
 		}
 	}
 }
 
+// viewModelChanged returns true if vm differs from the last notified version
+func (p *ClientPresenter) viewModelChanged(viewType core.ViewModelType, vm core.ViewModel) bool {
+	data, err := json.Marshal(vm)
+	if err != nil {
+		return true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if bytes.Equal(p.lastViewModels[viewType], data) {
+		return false
+	}
+	p.lastViewModels[viewType] = data
+	return true
+}
+
+// deliverStateUpdate sends an update to all subscribers
+func (p *ClientPresenter) deliverStateUpdate(update core.StateUpdate) {
+	p.mu.RLock()
+	callbacks := p.stateCallbacks
+	p.mu.RUnlock()
+
+	for _, cb := range callbacks {
+		cb(update)
+	}
+}
+
 // handleNotification processes a notification from the daemon
 func (p *ClientPresenter) handleNotification(n *core.Notification) {
 	p.mu.Lock()
@@ -266,25 +312,21 @@ func (p *ClientPresenter) handleNotification(n *core.Notification) {
 }
 
 // handleLogLine processes a log line from the daemon
+// BroadcastLog adds a diagnostics line of the TUI process to the logs
+func (p *ClientPresenter) BroadcastLog(line core.LogLineVM) {
+	p.handleLogLine(line)
+}
+
 func (p *ClientPresenter) handleLogLine(line core.LogLineVM) {
 	p.mu.Lock()
-	p.state.Logs.Lines = append(p.state.Logs.Lines, line)
-	if len(p.state.Logs.Lines) > p.state.Logs.MaxLines {
-		p.state.Logs.Lines = p.state.Logs.Lines[1:]
-	}
+	p.state.Logs.Append(line)
+	logs := p.state.Logs
 	p.mu.Unlock()
 
-	// Notify logs view
-	update := core.StateUpdate{
+	// Notify logs view (batched: the daemon streams logs line by line)
+	p.updateBatcher.Add(core.StateUpdate{
 		ViewType:  core.VMLogs,
-		ViewModel: p.state.Logs,
-	}
-
-	p.mu.RLock()
-	callbacks := p.stateCallbacks
-	p.mu.RUnlock()
-
-	for _, cb := range callbacks {
-		cb(update)
-	}
+		ViewModel: logs,
+		Appended:  1,
+	})
 }
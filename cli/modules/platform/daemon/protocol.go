@@ -18,6 +18,7 @@ const (
 	MsgPing         MessageType = "ping"           // Keepalive
 	MsgSaveTUIState MessageType = "save_tui_state" // Save TUI state on detach
 	MsgHandshake    MessageType = "handshake"      // Version handshake
+	MsgGetStatus    MessageType = "get_status"     // Status summary (one-shot, does not attach)
 
 	// Server -> Client
 	MsgState         MessageType = "state"          // Full state update
@@ -28,6 +29,7 @@ const (
 	MsgError         MessageType = "error"          // Error response
 	MsgTUIState      MessageType = "tui_state"      // Saved TUI state on reconnect
 	MsgHandshakeResp MessageType = "handshake_resp" // Version handshake response
+	MsgStatus        MessageType = "status"         // Status summary response
 )
 
 // Message is the envelope for all daemon messages
@@ -103,6 +105,7 @@ type SubscribePayload struct {
 type HandshakePayload struct {
 	BuildHash string `json:"build_hash"` // 8-char build hash
 	Version   string `json:"version"`    // Semantic version (e.g., "0.1.0")
+	Token     string `json:"token,omitempty"` // Team token (remote clients)
 }
 
 // HandshakeRespPayload contains server version information
@@ -111,6 +114,16 @@ type HandshakeRespPayload struct {
 	Version     string `json:"version"`       // Server's version
 	Compatible  bool   `json:"compatible"`    // True if client is compatible
 	RestartHint bool   `json:"restart_hint"`  // True if daemon should be restarted
+	Name        string `json:"name,omitempty"` // Teammate of the token (remote clients)
+	Role        string `json:"role,omitempty"` // operator or viewer (remote clients)
+}
+
+// StatusPayload is the summary of the daemon state shown by status bars
+type StatusPayload struct {
+	Running  int      `json:"running"`            // Processes running
+	Crashed  []string `json:"crashed,omitempty"`  // Crashed processes (project/component)
+	Building string   `json:"building,omitempty"` // Build in progress (project/component)
+	Dirty    []string `json:"dirty,omitempty"`    // Projects with uncommitted changes
 }
 
 // Encode serializes a message to JSON with newline delimiter
@@ -14,6 +14,8 @@ import (
 	"time"
 
 	"csd-devtrack/cli/modules"
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/logger"
 	"csd-devtrack/cli/modules/ui/core"
 )
 
@@ -35,6 +37,15 @@ type Server struct {
 	logBuffer     []core.LogLineVM
 	logBufferSize int
 
+	// WebSocket log stream for external tools (nil when disabled)
+	logStream *LogStream
+
+	// Team mode: teammates attached over the network (nil when disabled)
+	team            *config.TeamConfig
+	teamListener    net.Listener
+	teamFingerprint string
+	remotes         map[net.Conn]*remoteClient
+
 	// Shutdown
 	done     chan struct{}
 	wg       sync.WaitGroup
@@ -209,6 +220,9 @@ func (s *Server) Start() error {
 		return fmt.Errorf("failed to write PID file: %w", err)
 	}
 
+	// No one looks at the state until a client attaches
+	s.setLowPower(true)
+
 	// Start accepting connections
 	s.wg.Add(1)
 	go s.acceptLoop()
@@ -216,20 +230,64 @@ func (s *Server) Start() error {
 	return nil
 }
 
+// StartLogStream starts the WebSocket log stream, fed with the logs of the
+// presenter and the daemon diagnostics
+func (s *Server) StartLogStream(cfg *config.LogStreamConfig) error {
+	stream := NewLogStream(cfg)
+	if err := stream.Start(); err != nil {
+		return err
+	}
+	s.clientMu.Lock()
+	s.logStream = stream
+	s.clientMu.Unlock()
+
+	if source, ok := s.presenter.(logSource); ok {
+		s.presenter.Subscribe(func(update core.StateUpdate) {
+			if update.Affects(core.VMLogs) {
+				stream.pull(source)
+			}
+		})
+	}
+	return nil
+}
+
+// LogStreamURL returns the URL of the log stream, empty when it is off
+func (s *Server) LogStreamURL() string {
+	s.clientMu.Lock()
+	defer s.clientMu.Unlock()
+	if s.logStream == nil {
+		return ""
+	}
+	return s.logStream.URL()
+}
+
 // Stop stops the daemon server
 func (s *Server) Stop() {
 	close(s.done)
 
+	s.clientMu.Lock()
+	stream := s.logStream
+	s.clientMu.Unlock()
+	if stream != nil {
+		stream.Stop()
+	}
+
 	// Close listener
 	if s.listener != nil {
 		s.listener.Close()
 	}
 
-	// Close current client
+	// Close current client and teammates
 	s.clientMu.Lock()
 	if s.client != nil {
 		s.client.Close()
 	}
+	if s.teamListener != nil {
+		s.teamListener.Close()
+	}
+	for conn := range s.remotes {
+		conn.Close()
+	}
 	s.clientMu.Unlock()
 
 	// Wait for goroutines
@@ -258,31 +316,61 @@ func (s *Server) acceptLoop() {
 			}
 		}
 
-		// Only allow one client at a time
-		s.clientMu.Lock()
-		if s.client != nil {
-			// Disconnect previous client
-			s.client.Close()
-			if s.clientDone != nil {
-				<-s.clientDone // Wait for previous client handler to finish
-			}
-		}
-		s.client = conn
-		s.clientDone = make(chan struct{})
-		s.clientMu.Unlock()
-
 		s.wg.Add(1)
-		go s.handleClient(conn, s.clientDone)
+		go s.serveConn(conn)
 	}
 }
 
-// handleClient handles a connected client
-func (s *Server) handleClient(conn net.Conn, done chan struct{}) {
+// serveConn reads the first message of a connection: a status query is
+// answered without attaching (status bars poll it while a TUI is attached),
+// anything else makes the connection the client
+func (s *Server) serveConn(conn net.Conn) {
 	defer s.wg.Done()
+
+	reader := bufio.NewReader(conn)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		conn.Close() // Connectivity check
+		return
+	}
+	msg, err := DecodeMessage(line)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	if msg.Type == MsgGetStatus {
+		s.sendStatus(conn)
+		conn.Close()
+		return
+	}
+
+	// Only allow one client at a time
+	s.clientMu.Lock()
+	if s.client != nil {
+		// Disconnect previous client
+		s.client.Close()
+		if s.clientDone != nil {
+			<-s.clientDone // Wait for previous client handler to finish
+		}
+	}
+	s.client = conn
+	done := make(chan struct{})
+	s.clientDone = done
+	s.clientMu.Unlock()
+
+	s.wg.Add(1)
+	go s.handleClient(conn, reader, msg, done)
+}
+
+// handleClient handles a connected client, starting with its first message
+func (s *Server) handleClient(conn net.Conn, reader *bufio.Reader, first *Message, done chan struct{}) {
+	defer s.wg.Done()
+	defer s.detachClient(conn) // After done: a kicked client is replaced under clientMu
 	defer close(done)
 	defer conn.Close()
 
-	reader := bufio.NewReader(conn)
+	s.handleMessage(conn, first)
 
 	// Handle messages from client
 	// Note: Initial state is sent after receiving handshake (not immediately on connect)
@@ -302,6 +390,7 @@ func (s *Server) handleClient(conn net.Conn, done chan struct{}) {
 			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
 				continue
 			}
+			logger.Debug("Client connection closed")
 			return // Client disconnected
 		}
 
@@ -324,6 +413,7 @@ func (s *Server) handleMessage(conn net.Conn, msg *Message) {
 			return
 		}
 		if payload.Event != nil && s.presenter != nil {
+			logger.Debug("Client event: %s", payload.Event.Type)
 			s.presenter.HandleEvent(payload.Event)
 		}
 
@@ -347,6 +437,7 @@ func (s *Server) handleMessage(conn net.Conn, msg *Message) {
 		s.clientMu.Lock()
 		s.tuiState = payload.TUIState
 		s.clientMu.Unlock()
+		logger.Info("Client detaching, TUI state saved")
 
 	case MsgHandshake:
 		// Client is sending its version info - this confirms it's a real client
@@ -355,14 +446,17 @@ func (s *Server) handleMessage(conn net.Conn, msg *Message) {
 			s.sendError(conn, "invalid handshake payload")
 			return
 		}
-		s.sendHandshakeResp(conn, payload.BuildHash)
+		logger.Info("Client attached (build %s)", payload.BuildHash)
+		s.setLowPower(false)
+		s.sendHandshakeResp(conn, payload.BuildHash, "", "")
 		// Send initial state after handshake (real client, not just a connectivity check)
 		s.sendState(conn)
 	}
 }
 
-// sendHandshakeResp sends a handshake response to the client
-func (s *Server) sendHandshakeResp(conn net.Conn, clientHash string) {
+// sendHandshakeResp sends a handshake response to the client (with the
+// teammate and its role in team mode)
+func (s *Server) sendHandshakeResp(conn net.Conn, clientHash, name, role string) {
 	serverHash := modules.BuildHash()
 	compatible := clientHash == serverHash
 	restartHint := !compatible && clientHash != "000000-dev00000" && serverHash != "000000-dev00000"
@@ -372,6 +466,8 @@ func (s *Server) sendHandshakeResp(conn net.Conn, clientHash string) {
 		Version:     modules.AppVersion,
 		Compatible:  compatible,
 		RestartHint: restartHint,
+		Name:        name,
+		Role:        role,
 	}
 	msg, err := NewMessage(MsgHandshakeResp, payload)
 	if err != nil {
@@ -496,7 +592,7 @@ func (s *Server) BroadcastState(state *core.AppState) {
 	s.clientMu.Lock()
 	defer s.clientMu.Unlock()
 
-	if s.client == nil {
+	if s.client == nil && len(s.remotes) == 0 {
 		return
 	}
 
@@ -511,7 +607,10 @@ func (s *Server) BroadcastState(state *core.AppState) {
 		return
 	}
 
-	s.client.Write(data)
+	if s.client != nil {
+		s.client.Write(data)
+	}
+	s.broadcastRemotes(data)
 }
 
 // BroadcastLog sends a log line to connected client
@@ -525,9 +624,12 @@ func (s *Server) BroadcastLog(line core.LogLineVM) {
 		s.logBuffer = s.logBuffer[1:]
 	}
 	s.logBuffer = append(s.logBuffer, line)
+	if s.logStream != nil {
+		s.logStream.Publish(line)
+	}
 
-	// Send to client if connected
-	if s.client == nil {
+	// Send to client and teammates if connected
+	if s.client == nil && len(s.remotes) == 0 {
 		return
 	}
 
@@ -542,7 +644,10 @@ func (s *Server) BroadcastLog(line core.LogLineVM) {
 		return
 	}
 
-	s.client.Write(data)
+	if s.client != nil {
+		s.client.Write(data)
+	}
+	s.broadcastRemotes(data)
 }
 
 // BroadcastNotification sends a notification to connected client
@@ -550,7 +655,7 @@ func (s *Server) BroadcastNotification(notification *core.Notification) {
 	s.clientMu.Lock()
 	defer s.clientMu.Unlock()
 
-	if s.client == nil {
+	if s.client == nil && len(s.remotes) == 0 {
 		return
 	}
 
@@ -565,7 +670,36 @@ func (s *Server) BroadcastNotification(notification *core.Notification) {
 		return
 	}
 
-	s.client.Write(data)
+	if s.client != nil {
+		s.client.Write(data)
+	}
+	s.broadcastRemotes(data)
+}
+
+// detachClient forgets the client once its connection is closed, unless
+// another client replaced it
+func (s *Server) detachClient(conn net.Conn) {
+	s.clientMu.Lock()
+	if s.client != conn {
+		s.clientMu.Unlock()
+		return
+	}
+	s.client = nil
+	idle := len(s.remotes) == 0
+	s.clientMu.Unlock()
+	if idle {
+		s.setLowPower(true)
+	}
+}
+
+// setLowPower slows the presenter polling down while no client is attached
+func (s *Server) setLowPower(on bool) {
+	if s.presenter == nil {
+		return
+	}
+	event := core.NewEvent(core.EventLowPower)
+	event.Value = on
+	s.presenter.HandleEvent(event)
 }
 
 // HasClient returns true if a client is connected
@@ -660,10 +794,8 @@ func cleanupStaleSocket(socketPath string) {
 			return
 		}
 		// Check if we own the socket
-		if stat, ok := info.Sys().(*syscall.Stat_t); ok {
-			if stat.Uid != uint32(os.Getuid()) {
-				return // Not our socket, don't touch
-			}
+		if !isOwnFile(info) {
+			return // Not our socket, don't touch
 		}
 		os.Remove(socketPath)
 	} else {
@@ -676,10 +808,8 @@ func cleanupStaleFiles(pidPath, socketPath string) {
 	// Verify we own these files before removing
 	if runtime.GOOS != "windows" {
 		if info, err := os.Stat(pidPath); err == nil {
-			if stat, ok := info.Sys().(*syscall.Stat_t); ok {
-				if stat.Uid != uint32(os.Getuid()) {
-					return // Not our files
-				}
+			if !isOwnFile(info) {
+				return // Not our files
 			}
 		}
 	}
@@ -0,0 +1,104 @@
+package daemon
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"time"
+
+	"csd-devtrack/cli/modules/core/processes"
+	"csd-devtrack/cli/modules/ui/core"
+)
+
+// statusSummary summarizes the state for status bars
+func statusSummary(state *core.AppState) StatusPayload {
+	var status StatusPayload
+	if state == nil {
+		return status
+	}
+
+	if state.Processes != nil {
+		for _, p := range state.Processes.Processes {
+			switch p.State {
+			case processes.ProcessStateRunning:
+				status.Running++
+			case processes.ProcessStateCrashed:
+				status.Crashed = append(status.Crashed, fmt.Sprintf("%s/%s", p.ProjectID, p.Component))
+			}
+		}
+	}
+	if b := state.Builds; b != nil && b.IsBuilding && b.CurrentBuild != nil {
+		status.Building = b.CurrentBuild.ProjectID
+		if b.CurrentBuild.Component != "" {
+			status.Building += "/" + string(b.CurrentBuild.Component)
+		}
+	}
+	if state.Git != nil {
+		for _, g := range state.Git.Projects {
+			if !g.IsClean {
+				status.Dirty = append(status.Dirty, g.ProjectID)
+			}
+		}
+	}
+	return status
+}
+
+// sendStatus answers a status query with the current state, without the
+// refresh a full state request does (status bars poll it every second)
+func (s *Server) sendStatus(conn net.Conn) {
+	var state *core.AppState
+	if s.presenter != nil {
+		state = s.presenter.GetState()
+	}
+
+	msg, err := NewMessage(MsgStatus, statusSummary(state))
+	if err != nil {
+		return
+	}
+	data, err := msg.Encode()
+	if err != nil {
+		return
+	}
+	conn.Write(data)
+}
+
+// QueryStatus asks the running daemon for its status summary. The query
+// does not attach: a TUI attached to the daemon stays connected.
+func QueryStatus(timeout time.Duration) (*StatusPayload, error) {
+	conn, err := dialDaemon(timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	msg, err := NewMessage(MsgGetStatus, nil)
+	if err != nil {
+		return nil, err
+	}
+	data, err := msg.Encode()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to query the daemon: %w", err)
+	}
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("no answer from the daemon: %w", err)
+	}
+	reply, err := DecodeMessage(line)
+	if err != nil {
+		return nil, err
+	}
+	if reply.Type != MsgStatus {
+		return nil, fmt.Errorf("unexpected answer from the daemon: %s", reply.Type)
+	}
+
+	var status StatusPayload
+	if err := reply.Decode(&status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
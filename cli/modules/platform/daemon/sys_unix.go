@@ -0,0 +1,23 @@
+// +build !windows
+
+package daemon
+
+import (
+	"os"
+	"syscall"
+)
+
+// detachedProcAttr returns the process attributes that detach the daemon from the terminal
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		Setsid: true, // Create new session
+	}
+}
+
+// isOwnFile returns true if the file belongs to the current user
+func isOwnFile(info os.FileInfo) bool {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return stat.Uid == uint32(os.Getuid())
+	}
+	return true
+}
@@ -0,0 +1,23 @@
+// +build windows
+
+package daemon
+
+import (
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// detachedProcAttr returns the process attributes that detach the daemon from the console
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		CreationFlags: windows.CREATE_NEW_PROCESS_GROUP | windows.DETACHED_PROCESS,
+	}
+}
+
+// isOwnFile returns true if the file belongs to the current user
+// (daemon files live in the user profile on Windows)
+func isOwnFile(info os.FileInfo) bool {
+	return true
+}
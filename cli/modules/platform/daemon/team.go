@@ -0,0 +1,287 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/certs"
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/logger"
+	"csd-devtrack/cli/modules/ui/core"
+)
+
+// remoteQueue is the number of broadcast messages queued per teammate
+// before they are dropped (slow network)
+const remoteQueue = 1024
+
+// remoteClient is a teammate attached over the network (team mode)
+type remoteClient struct {
+	conn net.Conn
+	name string // Teammate of the token
+	role string // operator or viewer
+	send chan []byte
+}
+
+// StartTeam listens on the network address of team mode (TLS): teammates
+// attach with a token, as operators or viewers
+func (s *Server) StartTeam(cfg *config.TeamConfig) error {
+	cert, err := TeamCertificate(cfg)
+	if err != nil {
+		return err
+	}
+	listener, err := tls.Listen("tcp", cfg.Listen, &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	})
+	if err != nil {
+		return fmt.Errorf("team mode: %w", err)
+	}
+
+	s.clientMu.Lock()
+	s.team = cfg
+	s.teamListener = listener
+	s.teamFingerprint = Fingerprint(cert.Certificate[0])
+	s.clientMu.Unlock()
+
+	s.wg.Add(1)
+	go s.acceptRemotes(listener)
+	return nil
+}
+
+// TeamAddress returns the network address of team mode, empty when it is off
+func (s *Server) TeamAddress() string {
+	s.clientMu.Lock()
+	defer s.clientMu.Unlock()
+	if s.teamListener == nil {
+		return ""
+	}
+	return s.teamListener.Addr().String()
+}
+
+// TeamFingerprint returns the SHA-256 fingerprint of the team certificate,
+// given to teammates with --fingerprint
+func (s *Server) TeamFingerprint() string {
+	s.clientMu.Lock()
+	defer s.clientMu.Unlock()
+	return s.teamFingerprint
+}
+
+// TeamCertificate loads the configured certificate, or the one generated in
+// the data directory (again when it expires soon). Also serves the web
+// dashboard over TLS in team mode.
+func TeamCertificate(cfg *config.TeamConfig) (tls.Certificate, error) {
+	if cfg.CertFile != "" {
+		return tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	}
+
+	dataDir, err := config.GetDataDir()
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	dir := filepath.Join(dataDir, "team")
+	certFile := filepath.Join(dir, "daemon.pem")
+	keyFile := filepath.Join(dir, "daemon-key.pem")
+	if cert, err := certs.Inspect(certFile); err != nil || cert.ExpiresIn() < certs.ExpiryWarning {
+		hosts := append([]string{}, certs.DefaultHosts...)
+		if hostname, err := os.Hostname(); err == nil {
+			hosts = append([]string{hostname}, hosts...)
+		}
+		if host, _, err := net.SplitHostPort(cfg.Listen); err == nil && host != "" {
+			if ip := net.ParseIP(host); ip == nil || !ip.IsUnspecified() {
+				hosts = append(hosts, host)
+			}
+		}
+		if _, err := certs.Generate(context.Background(), dir, "daemon", hosts); err != nil {
+			return tls.Certificate{}, fmt.Errorf("team certificate: %w", err)
+		}
+	}
+	return tls.LoadX509KeyPair(certFile, keyFile)
+}
+
+// Fingerprint returns the SHA-256 fingerprint of a DER certificate
+// (AB:CD:...)
+func Fingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// acceptRemotes accepts the connections of teammates
+func (s *Server) acceptRemotes(listener net.Listener) {
+	defer s.wg.Done()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-s.done:
+				return
+			default:
+			}
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				continue
+			}
+			return
+		}
+
+		s.wg.Add(1)
+		go s.serveRemote(conn)
+	}
+}
+
+// serveRemote authenticates a teammate (its handshake carries the token),
+// then serves it alongside the local client
+func (s *Server) serveRemote(conn net.Conn) {
+	defer s.wg.Done()
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return
+	}
+	msg, err := DecodeMessage(line)
+	if err != nil {
+		return
+	}
+	var handshake HandshakePayload
+	if msg.Type != MsgHandshake || msg.Decode(&handshake) != nil {
+		s.sendError(conn, "handshake expected")
+		return
+	}
+
+	s.clientMu.Lock()
+	team := s.team
+	s.clientMu.Unlock()
+	token := team.Authenticate(handshake.Token)
+	if token == nil {
+		logger.Warn("Team: authentication failed from %s", conn.RemoteAddr())
+		time.Sleep(time.Second) // Slows token guessing down
+		s.sendError(conn, "authentication failed: invalid token")
+		return
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	remote := &remoteClient{
+		conn: conn,
+		name: token.Name,
+		role: token.GetRole(),
+		send: make(chan []byte, remoteQueue),
+	}
+	s.clientMu.Lock()
+	if s.remotes == nil {
+		s.remotes = make(map[net.Conn]*remoteClient)
+	}
+	s.remotes[conn] = remote
+	s.clientMu.Unlock()
+	defer s.detachRemote(remote)
+
+	logger.Info("Team: %s attached as %s from %s", remote.name, remote.role, conn.RemoteAddr())
+	s.setLowPower(false)
+	go remote.writeLoop()
+
+	s.sendHandshakeResp(conn, handshake.BuildHash, remote.name, remote.role)
+	s.sendStateOnly(conn)
+	s.sendBufferedLogs(conn)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		msg, err := DecodeMessage(line)
+		if err != nil {
+			continue
+		}
+		s.handleRemoteMessage(remote, msg)
+	}
+}
+
+// handleRemoteMessage processes a message from a teammate: a viewer only
+// sends the events of read-only mode, and nobody saves the TUI state of the
+// local client
+func (s *Server) handleRemoteMessage(remote *remoteClient, msg *Message) {
+	switch msg.Type {
+	case MsgEvent:
+		var payload EventPayload
+		if err := msg.Decode(&payload); err != nil || payload.Event == nil {
+			s.sendError(remote.conn, "invalid event payload")
+			return
+		}
+		event := payload.Event
+		switch {
+		case event.Type == core.EventLowPower:
+			// Follows the attached clients, not the focus of one of them
+		case remote.role != config.TeamRoleOperator && !core.ReadOnlyEvents[event.Type]:
+			logger.Warn("Team: %s (viewer) tried %s", remote.name, event.Type)
+			s.sendError(remote.conn, fmt.Sprintf("viewer role: %s not allowed", event.Type))
+		default:
+			event.User = remote.name
+			logger.Debug("Team event from %s: %s", remote.name, event.Type)
+			s.presenter.HandleEvent(event)
+		}
+
+	case MsgGetState:
+		s.sendStateOnly(remote.conn)
+
+	case MsgSubscribe:
+		s.sendStateOnly(remote.conn)
+		s.sendBufferedLogs(remote.conn)
+
+	case MsgGetStatus:
+		s.sendStatus(remote.conn)
+
+	case MsgPing:
+		s.sendPong(remote.conn)
+	}
+}
+
+// detachRemote forgets a teammate once its connection is closed
+func (s *Server) detachRemote(remote *remoteClient) {
+	s.clientMu.Lock()
+	delete(s.remotes, remote.conn)
+	close(remote.send)
+	idle := s.client == nil && len(s.remotes) == 0
+	s.clientMu.Unlock()
+
+	logger.Info("Team: %s detached", remote.name)
+	if idle {
+		s.setLowPower(true)
+	}
+}
+
+// broadcastRemotes queues a message for the teammates, dropped for those
+// whose connection cannot keep up. The caller holds clientMu.
+func (s *Server) broadcastRemotes(data []byte) {
+	for _, remote := range s.remotes {
+		select {
+		case remote.send <- data:
+		default:
+		}
+	}
+}
+
+// writeLoop sends the broadcast messages to a teammate
+func (r *remoteClient) writeLoop() {
+	for data := range r.send {
+		r.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+		if _, err := r.conn.Write(data); err != nil {
+			r.conn.Close() // Ends serveRemote
+			for range r.send {
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+)
+
+// recordingPresenter records the events handed to the presenter
+type recordingPresenter struct {
+	core.Presenter
+	events []*core.Event
+}
+
+func (p *recordingPresenter) HandleEvent(event *core.Event) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestViewerCannotReloadPlugins(t *testing.T) {
+	team := &config.TeamConfig{Tokens: []config.TeamToken{
+		{Name: "alice", Token: "viewer-token-0123456789"},
+		{Name: "bob", Token: "operator-token-0123456789", Role: config.TeamRoleOperator},
+	}}
+
+	for _, tt := range []struct {
+		token   string
+		allowed bool
+	}{
+		{"viewer-token-0123456789", false},
+		{"operator-token-0123456789", true},
+	} {
+		token := team.Authenticate(tt.token)
+		if token == nil {
+			t.Fatalf("token %s not authenticated", tt.token)
+		}
+		presenter := &recordingPresenter{}
+		s := &Server{presenter: presenter}
+
+		conn, peer := net.Pipe()
+		replies := make(chan string, 1)
+		go func() {
+			line, _ := bufio.NewReader(peer).ReadString('\n')
+			replies <- line
+		}()
+
+		msg, err := NewMessage(MsgEvent, EventPayload{Event: core.NewEvent(core.EventPluginReload)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.handleRemoteMessage(&remoteClient{conn: conn, name: token.Name, role: token.GetRole()}, msg)
+		conn.Close()
+		reply := <-replies
+		peer.Close()
+
+		if tt.allowed {
+			if len(presenter.events) != 1 {
+				t.Errorf("%s: plugin reload not handled", token.Name)
+			}
+			continue
+		}
+		if len(presenter.events) != 0 {
+			t.Errorf("%s: viewer reloaded the plugins", token.Name)
+		}
+		if !strings.Contains(reply, "not allowed") {
+			t.Errorf("%s: no error sent to the viewer: %q", token.Name, reply)
+		}
+	}
+}
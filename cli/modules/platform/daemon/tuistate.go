@@ -32,6 +32,7 @@ type TUIState struct {
 	LogSearchText   string `json:"log_search_text"`
 	LogScrollOffset int    `json:"log_scroll_offset"`
 	LogAutoScroll   bool   `json:"log_auto_scroll"`
+	LogFollow       bool   `json:"log_follow,omitempty"`
 
 	// Git view state
 	GitShowDiff bool `json:"git_show_diff"`
@@ -0,0 +1,45 @@
+package deploy
+
+import (
+	"time"
+)
+
+// State is the state of a deployment
+type State string
+
+const (
+	StateRunning State = "running"
+	StateSuccess State = "success"
+	StateFailed  State = "failed"
+)
+
+// Request describes a deployment to start
+type Request struct {
+	ProjectID string
+	Target    string   // Deploy target (environment) name
+	Command   string   // Shell command (empty if Script is set)
+	Script    string   // Absolute path of the script to execute
+	Dir       string   // Working directory
+	Env       []string // Extra environment variables (KEY=value)
+}
+
+// Deployment is a run of a project deploy target
+type Deployment struct {
+	ID         string    `json:"id"`
+	ProjectID  string    `json:"project_id"`
+	Target     string    `json:"target"`
+	Command    string    `json:"command"` // Command or script that was run
+	State      State     `json:"state"`
+	ExitCode   int       `json:"exit_code"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+}
+
+// Duration returns how long the deployment ran (so far if running)
+func (d Deployment) Duration() time.Duration {
+	if d.FinishedAt.IsZero() {
+		return time.Since(d.StartedAt)
+	}
+	return d.FinishedAt.Sub(d.StartedAt)
+}
@@ -0,0 +1,242 @@
+package deploy
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/fileutil"
+)
+
+// maxHistory is the number of deployments kept in the history
+const maxHistory = 50
+
+// Service runs project deploy targets and keeps their history.
+// Finished deployments are persisted so the status per target survives restarts.
+type Service struct {
+	file string // History file (empty = not persisted)
+
+	mu          sync.RWMutex
+	deployments []*Deployment // Most recent last
+}
+
+// NewService creates a deploy service persisting its history in file
+func NewService(file string) *Service {
+	s := &Service{file: file}
+	s.deployments = s.load()
+	return s
+}
+
+// GetDeployments returns a copy of the deployments, most recent last
+func (s *Service) GetDeployments() []Deployment {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Deployment, len(s.deployments))
+	for i, d := range s.deployments {
+		result[i] = *d
+	}
+	return result
+}
+
+// IsRunning returns true if the target of the project is being deployed
+func (s *Service) IsRunning(projectID, target string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, d := range s.deployments {
+		if d.ProjectID == projectID && d.Target == target && d.State == StateRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// Start runs a deployment in the background. onOutput is called for each
+// output line, onUpdate with a copy of the deployment when it starts and finishes.
+func (s *Service) Start(ctx context.Context, req Request, onOutput func(d Deployment, line string, isError bool), onUpdate func(Deployment)) (*Deployment, error) {
+	if req.Command == "" && req.Script == "" {
+		return nil, fmt.Errorf("deploy target %s has no command or script", req.Target)
+	}
+	if s.IsRunning(req.ProjectID, req.Target) {
+		return nil, fmt.Errorf("%s is already being deployed", req.Target)
+	}
+
+	var cmd *exec.Cmd
+	command := req.Command
+	switch {
+	case req.Script != "":
+		command = req.Script
+		cmd = exec.CommandContext(ctx, req.Script)
+	case runtime.GOOS == "windows":
+		cmd = exec.CommandContext(ctx, "cmd", "/c", req.Command)
+	default:
+		cmd = exec.CommandContext(ctx, "sh", "-c", req.Command)
+	}
+	cmd.Dir = req.Dir
+	cmd.Env = append(os.Environ(), req.Env...)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Deployment{
+		ID:        strconv.FormatInt(time.Now().UnixNano(), 36),
+		ProjectID: req.ProjectID,
+		Target:    req.Target,
+		Command:   command,
+		State:     StateRunning,
+		StartedAt: time.Now(),
+	}
+	s.mu.Lock()
+	s.deployments = append(s.deployments, d)
+	if len(s.deployments) > maxHistory {
+		s.deployments = s.deployments[len(s.deployments)-maxHistory:]
+	}
+	s.mu.Unlock()
+
+	if err := cmd.Start(); err != nil {
+		s.finish(d, err, "", nil)
+		return nil, fmt.Errorf("failed to start deployment: %w", err)
+	}
+
+	go func() {
+		var wg sync.WaitGroup
+		var lastErrLine string
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.readOutput(d, stdout, false, onOutput)
+		}()
+		go func() {
+			defer wg.Done()
+			lastErrLine = s.readOutput(d, stderr, true, onOutput)
+		}()
+		// Pipes must be drained before Wait closes them
+		wg.Wait()
+		err := cmd.Wait()
+		s.finish(d, err, lastErrLine, onUpdate)
+	}()
+
+	return d, nil
+}
+
+// readOutput forwards the output lines of a deployment and returns the last non-empty line
+func (s *Service) readOutput(d *Deployment, r io.Reader, isError bool, onOutput func(Deployment, string, bool)) string {
+	last := ""
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		last = line
+		if onOutput != nil {
+			s.mu.RLock()
+			snapshot := *d
+			s.mu.RUnlock()
+			onOutput(snapshot, line, isError)
+		}
+	}
+	return last
+}
+
+// finish records the result of a deployment and persists the history
+func (s *Service) finish(d *Deployment, err error, lastErrLine string, onUpdate func(Deployment)) {
+	s.mu.Lock()
+	d.FinishedAt = time.Now()
+	if err != nil {
+		d.State = StateFailed
+		d.ExitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			d.ExitCode = exitErr.ExitCode()
+		}
+		d.Error = err.Error()
+		// The last line of stderr explains the failure better than the exit code
+		if lastErrLine != "" {
+			d.Error = strings.TrimSpace(lastErrLine)
+		}
+	} else {
+		d.State = StateSuccess
+	}
+	snapshot := *d
+	s.mu.Unlock()
+
+	s.save()
+
+	if onUpdate != nil {
+		onUpdate(snapshot)
+	}
+}
+
+// load reads the history from disk. Deployments left running by a
+// previous process can't be followed anymore: they are marked failed.
+func (s *Service) load() []*Deployment {
+	if s.file == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		return nil // No history yet
+	}
+
+	var deployments []*Deployment
+	if err := json.Unmarshal(data, &deployments); err != nil {
+		return nil
+	}
+	for _, d := range deployments {
+		if d.State == StateRunning {
+			d.State = StateFailed
+			d.Error = "interrupted"
+		}
+	}
+	return deployments
+}
+
+// save writes the finished deployments to disk atomically
+func (s *Service) save() error {
+	if s.file == "" {
+		return nil
+	}
+
+	// Held while writing: concurrent saves share the temporary file
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	finished := make([]*Deployment, 0, len(s.deployments))
+	for _, d := range s.deployments {
+		if d.State != StateRunning {
+			finished = append(finished, d)
+		}
+	}
+	data, err := json.MarshalIndent(finished, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.file), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(s.file, data, 0600); err != nil {
+		return fmt.Errorf("failed to write deploy history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package eventbridge
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/eventbus"
+	"csd-devtrack/cli/modules/platform/logger"
+)
+
+// queueSize is the number of events kept while the broker is unreachable
+const queueSize = 1024
+
+// maxBackoff is the longest wait between two connection attempts
+const maxBackoff = 30 * time.Second
+
+// publisher is a connection to a broker
+type publisher interface {
+	// publish sends a message to a subject (NATS) or channel (Redis)
+	publish(subject string, data []byte) error
+	// close closes the connection
+	close()
+}
+
+// Bridge publishes the domain events of the event bus to NATS or Redis:
+// one JSON message per event on <prefix>.<event type>, e.g.
+// devtrack.build_finished. Events are queued while the broker is
+// unreachable, then dropped once the queue is full.
+type Bridge struct {
+	cfg    *config.EventBridgeConfig
+	url    *url.URL
+	bus    *eventbus.Bus
+	events []eventbus.EventType
+
+	subscription string
+	queue        chan *eventbus.Event
+	dropped      int64 // Events dropped since the last report (queue full)
+	done         chan struct{}
+	stopOnce     sync.Once
+	wg           sync.WaitGroup
+}
+
+// New creates the bridge of a bus, checking the URL and the events
+func New(bus *eventbus.Bus, cfg *config.EventBridgeConfig) (*Bridge, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("event bridge: %w", err)
+	}
+	switch u.Scheme {
+	case "nats", "redis", "rediss":
+	default:
+		return nil, fmt.Errorf("event bridge: unsupported URL %s (nats://, redis:// or rediss://)", redact(u))
+	}
+
+	events := eventbus.DomainEvents
+	if len(cfg.Events) > 0 {
+		events = nil
+		for _, name := range cfg.Events {
+			event := eventbus.EventType(name)
+			if !(&eventbus.Event{Type: event}).IsDomain() {
+				return nil, fmt.Errorf("event bridge: unknown event %q", name)
+			}
+			events = append(events, event)
+		}
+	}
+
+	return &Bridge{
+		cfg:    cfg,
+		url:    u,
+		bus:    bus,
+		events: events,
+		queue:  make(chan *eventbus.Event, queueSize),
+		done:   make(chan struct{}),
+	}, nil
+}
+
+// Start subscribes to the bus and connects to the broker in the background
+func (b *Bridge) Start() {
+	b.subscription = b.bus.Subscribe(b.events, b.enqueue)
+	b.wg.Add(1)
+	go b.run()
+}
+
+// Stop unsubscribes from the bus and closes the connection (the queued
+// events are not sent)
+func (b *Bridge) Stop() {
+	b.stopOnce.Do(func() {
+		b.bus.Unsubscribe(b.subscription)
+		close(b.done)
+	})
+	b.wg.Wait()
+}
+
+// Target returns the broker URL without its credentials
+func (b *Bridge) Target() string {
+	return redact(b.url)
+}
+
+// redact hides the credentials of a URL, a token included (NATS)
+func redact(u *url.URL) string {
+	if u.User == nil {
+		return u.String()
+	}
+	redacted := *u
+	redacted.User = url.User("xxxxx")
+	return redacted.String()
+}
+
+// enqueue queues an event of the bus, dropped when the queue is full
+func (b *Bridge) enqueue(event *eventbus.Event) {
+	select {
+	case <-b.done:
+	case b.queue <- event:
+	default:
+		atomic.AddInt64(&b.dropped, 1)
+	}
+}
+
+// run connects to the broker and publishes the queued events, reconnecting
+// with a growing delay when the connection is lost
+func (b *Bridge) run() {
+	defer b.wg.Done()
+
+	var conn publisher
+	defer func() {
+		if conn != nil {
+			conn.close()
+		}
+	}()
+	backoff := time.Second
+	failing := false
+
+	for {
+		if conn == nil {
+			var err error
+			conn, err = b.dial()
+			if err != nil {
+				if !failing {
+					logger.Warn("Event bridge: %s unreachable: %v", b.Target(), err)
+					failing = true
+				}
+				select {
+				case <-b.done:
+					return
+				case <-time.After(backoff):
+				}
+				backoff = min(backoff*2, maxBackoff)
+				continue
+			}
+			logger.Info("Event bridge connected to %s", b.Target())
+			backoff = time.Second
+			failing = false
+		}
+
+		select {
+		case <-b.done:
+			return
+		case event := <-b.queue:
+			if dropped := atomic.SwapInt64(&b.dropped, 0); dropped > 0 {
+				logger.Warn("Event bridge: %d events dropped (broker too slow or unreachable)", dropped)
+			}
+			data, err := event.JSON()
+			if err != nil {
+				continue
+			}
+			if err := conn.publish(b.subject(event), data); err != nil {
+				logger.Warn("Event bridge: connection to %s lost: %v", b.Target(), err)
+				conn.close()
+				conn = nil
+				failing = true
+				// The event is retried once connected again
+				select {
+				case b.queue <- event:
+				default:
+					atomic.AddInt64(&b.dropped, 1)
+				}
+			}
+		}
+	}
+}
+
+// subject returns the subject (NATS) or channel (Redis) of an event
+func (b *Bridge) subject(event *eventbus.Event) string {
+	return b.cfg.Prefix + "." + string(event.Type)
+}
+
+// dial connects to the broker of the URL
+func (b *Bridge) dial() (publisher, error) {
+	if b.url.Scheme == "nats" {
+		return dialNATS(b.url)
+	}
+	return dialRedis(b.url)
+}
@@ -0,0 +1,161 @@
+package eventbridge
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules"
+)
+
+// natsConn publishes to a NATS server with its text protocol (INFO,
+// CONNECT, PUB, PING/PONG)
+type natsConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	mu  sync.Mutex // Serializes the writes (PUB and the PONG replies)
+	err error      // -ERR sent by the server, the connection is then closed
+}
+
+// natsInfo is the part of the INFO message of the server that matters here
+type natsInfo struct {
+	TLSRequired  bool `json:"tls_required"`
+	AuthRequired bool `json:"auth_required"`
+}
+
+// natsConnect is the CONNECT message of the client
+type natsConnect struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+// dialNATS connects to nats://[user:password@|token@]host[:4222]
+func dialNATS(u *url.URL) (publisher, error) {
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	conn, err := net.DialTimeout("tcp", host, 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	c := &natsConn{conn: conn, reader: bufio.NewReader(conn)}
+
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	infoJSON, ok := strings.CutPrefix(strings.TrimSpace(line), "INFO ")
+	if !ok {
+		conn.Close()
+		return nil, fmt.Errorf("not a NATS server")
+	}
+	var info natsInfo
+	json.Unmarshal([]byte(infoJSON), &info)
+	if info.TLSRequired {
+		conn.Close()
+		return nil, fmt.Errorf("the server requires TLS (not supported)")
+	}
+
+	connect := natsConnect{Name: modules.AppName, Lang: "go", Version: modules.AppVersion}
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			connect.User, connect.Pass = u.User.Username(), password
+		} else {
+			connect.Token = u.User.Username()
+		}
+	}
+	data, _ := json.Marshal(connect)
+	// The PONG of this PING confirms the CONNECT (-ERR when unauthorized)
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", data); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "PONG" {
+			break
+		}
+		if strings.HasPrefix(line, "-ERR") {
+			conn.Close()
+			return nil, fmt.Errorf("%s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+	conn.SetDeadline(time.Time{})
+
+	go c.readLoop()
+	return c, nil
+}
+
+// publish sends a PUB message
+func (c *natsConn) publish(subject string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	msg := make([]byte, 0, len(subject)+len(data)+32)
+	msg = fmt.Appendf(msg, "PUB %s %d\r\n", subject, len(data))
+	msg = append(msg, data...)
+	msg = append(msg, "\r\n"...)
+	_, err := c.conn.Write(msg)
+	return err
+}
+
+// readLoop answers the PINGs of the server (it closes silent clients) and
+// keeps its errors
+func (c *natsConn) readLoop() {
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			c.fail(err)
+			return
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PING":
+			c.mu.Lock()
+			c.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+			c.conn.Write([]byte("PONG\r\n"))
+			c.mu.Unlock()
+		case strings.HasPrefix(line, "-ERR"):
+			c.fail(fmt.Errorf("%s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR"))))
+			c.conn.Close()
+			return
+		}
+	}
+}
+
+// fail keeps the first error of the connection
+func (c *natsConn) fail(err error) {
+	c.mu.Lock()
+	if c.err == nil {
+		c.err = err
+	}
+	c.mu.Unlock()
+}
+
+// close closes the connection
+func (c *natsConn) close() {
+	c.conn.Close()
+}
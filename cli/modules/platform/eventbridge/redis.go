@@ -0,0 +1,119 @@
+package eventbridge
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redisConn publishes to Redis with its RESP protocol (AUTH, PUBLISH)
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	mu  sync.Mutex
+	err error // Error reply or read error, the connection is then unusable
+}
+
+// dialRedis connects to redis://[user:password@]host[:6379], or rediss://
+// over TLS
+func dialRedis(u *url.URL) (publisher, error) {
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	var conn net.Conn
+	var err error
+	if u.Scheme == "rediss" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", host, &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12})
+	} else {
+		conn, err = dialer.Dial("tcp", host)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+
+	if u.User != nil {
+		args := []string{"AUTH"}
+		password, ok := u.User.Password()
+		switch {
+		case ok && u.User.Username() != "":
+			args = append(args, u.User.Username(), password) // ACL user
+		case ok:
+			args = append(args, password)
+		default:
+			args = append(args, u.User.Username())
+		}
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if _, err := conn.Write(redisCommand(args...)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		if strings.HasPrefix(line, "-") {
+			conn.Close()
+			return nil, fmt.Errorf("%s", strings.TrimSpace(line[1:]))
+		}
+		conn.SetDeadline(time.Time{})
+	}
+
+	go c.readLoop()
+	return c, nil
+}
+
+// redisCommand encodes a command as a RESP array of bulk strings
+func redisCommand(args ...string) []byte {
+	cmd := fmt.Appendf(nil, "*%d\r\n", len(args))
+	for _, arg := range args {
+		cmd = fmt.Appendf(cmd, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return cmd
+}
+
+// publish sends a PUBLISH command (its reply, the number of subscribers, is
+// read by readLoop)
+func (c *redisConn) publish(subject string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	_, err := c.conn.Write(redisCommand("PUBLISH", subject, string(data)))
+	return err
+}
+
+// readLoop reads the replies, keeping the first error
+func (c *redisConn) readLoop() {
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err == nil && strings.HasPrefix(line, "-") {
+			err = fmt.Errorf("%s", strings.TrimSpace(line[1:]))
+		}
+		if err != nil {
+			c.mu.Lock()
+			if c.err == nil {
+				c.err = err
+			}
+			c.mu.Unlock()
+			c.conn.Close()
+			return
+		}
+	}
+}
+
+// close closes the connection
+func (c *redisConn) close() {
+	c.conn.Close()
+}
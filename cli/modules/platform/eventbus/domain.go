@@ -0,0 +1,92 @@
+package eventbus
+
+import "encoding/json"
+
+// DomainEvents are the events of what happens to the projects (builds,
+// processes, git), as opposed to the state refreshes of the views
+var DomainEvents = []EventType{
+	EventBuildStarted,
+	EventBuildFinished,
+	EventProcessStarted,
+	EventProcessStopped,
+	EventProcessCrashed,
+	EventGitUpdated,
+}
+
+// BuildData is the payload of build_started and build_finished
+type BuildData struct {
+	ProjectID string  `json:"project_id"`
+	Component string  `json:"component"`
+	BuildID   string  `json:"build_id"`
+	Status    string  `json:"status"`
+	Duration  float64 `json:"duration_seconds,omitempty"` // Finished builds
+	ExitCode  int     `json:"exit_code,omitempty"`
+	Error     string  `json:"error,omitempty"` // Last error line of a failed build
+	Artifact  string  `json:"artifact,omitempty"`
+}
+
+// ProcessData is the payload of process_started, process_stopped and
+// process_crashed
+type ProcessData struct {
+	ProjectID string `json:"project_id"`
+	Component string `json:"component"`
+	ProcessID string `json:"process_id"`
+	PID       int    `json:"pid,omitempty"`
+	ExitCode  *int   `json:"exit_code,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// GitData is the payload of git_updated: the branch or the working tree of
+// a project changed
+type GitData struct {
+	ProjectID string `json:"project_id"`
+	Branch    string `json:"branch"`
+	Dirty     bool   `json:"dirty"`
+	Changes   int    `json:"changes"` // Staged, modified, untracked and deleted files
+	Ahead     int    `json:"ahead"`
+	Behind    int    `json:"behind"`
+}
+
+// NewTypedEvent creates an event whose data is a typed payload (BuildData,
+// ProcessData, GitData), read back with Decode
+func NewTypedEvent(eventType EventType, source string, payload interface{}) *Event {
+	event := NewEvent(eventType).WithSource(source)
+	if data, err := json.Marshal(payload); err == nil {
+		json.Unmarshal(data, &event.Data)
+	}
+	return event
+}
+
+// NewBuildEvent creates a build event of a project component
+func NewBuildEvent(eventType EventType, data BuildData) *Event {
+	return NewTypedEvent(eventType, data.ProjectID+"/"+data.Component, data)
+}
+
+// NewProcessEvent creates a process event of a project component
+func NewProcessEvent(eventType EventType, data ProcessData) *Event {
+	return NewTypedEvent(eventType, data.ProjectID+"/"+data.Component, data)
+}
+
+// NewGitEvent creates the git event of a project
+func NewGitEvent(data GitData) *Event {
+	return NewTypedEvent(EventGitUpdated, data.ProjectID, data)
+}
+
+// Decode reads the data of the event into a typed payload
+func (e *Event) Decode(payload interface{}) error {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, payload)
+}
+
+// IsDomain returns true for the events of DomainEvents
+func (e *Event) IsDomain() bool {
+	for _, t := range DomainEvents {
+		if e.Type == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+// Package fileutil holds the file helpers shared by the platform services
+package fileutil
+
+import (
+	"io"
+	"os"
+)
+
+// WriteFileAtomic writes data to path through a temporary file synced to disk
+// then renamed over path: a crash leaves the previous file or the new one,
+// never a truncated one. perm is applied even when path already exists.
+// Writers of one path must be serialized (they share the temporary file).
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	return WriteAtomic(path, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// WriteAtomic is WriteFileAtomic for a content streamed by write (archives
+// too large to be held in memory)
+func WriteAtomic(path string, perm os.FileMode, write func(w io.Writer) error) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	err = write(f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		// A temporary file left by a crash keeps its mode when reopened
+		err = os.Chmod(tmp, perm)
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+package git
+
+import (
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// HideFiles splits the changed files of a status between the files shown and
+// the files matching display-level ignore patterns (gitignore syntax relative
+// to the repository root: "vendor/", "**/*.pb.go", "!keep.go"), for
+// generated code and vendored trees that are committed but not reviewed.
+// .gitignore is not involved and status is not modified: shown is a copy
+// with its flags recomputed, hidden holds the hidden files (nil if none).
+func HideFiles(status *Status, patterns []string) (shown *Status, hidden *Status) {
+	if len(patterns) == 0 {
+		return status, nil
+	}
+	parsed := make([]gitignore.Pattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern = strings.TrimSpace(pattern); pattern != "" && !strings.HasPrefix(pattern, "#") {
+			parsed = append(parsed, gitignore.ParsePattern(pattern, nil))
+		}
+	}
+	matcher := gitignore.NewMatcher(parsed)
+
+	copied := *status
+	shown, hidden = &copied, &Status{}
+	split := func(files []string) (kept, removed []string) {
+		kept = []string{}
+		for _, file := range files {
+			if matcher.Match(strings.Split(file, "/"), false) {
+				removed = append(removed, file)
+			} else {
+				kept = append(kept, file)
+			}
+		}
+		return kept, removed
+	}
+	shown.Staged, hidden.Staged = split(status.Staged)
+	shown.Modified, hidden.Modified = split(status.Modified)
+	shown.Deleted, hidden.Deleted = split(status.Deleted)
+	shown.Untracked, hidden.Untracked = split(status.Untracked)
+	if hidden.ChangeCount() == 0 {
+		return status, nil
+	}
+
+	shown.HasStaged = len(shown.Staged) > 0
+	shown.HasModified = len(shown.Modified)+len(shown.Deleted) > 0
+	shown.HasUntracked = len(shown.Untracked) > 0
+	shown.IsClean = shown.ChangeCount() == 0 && len(shown.Conflicts) == 0
+	return shown, hidden
+}
@@ -0,0 +1,60 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// CommitHooks are the client hooks run by git commit and git push, in the
+// order they run
+var CommitHooks = []string{"pre-commit", "prepare-commit-msg", "commit-msg", "post-commit", "pre-push"}
+
+// GetHooksDir returns the hooks directory of the repository: core.hooksPath
+// when set (pre-commit, husky, lefthook), .git/hooks otherwise. Worktrees
+// share the hooks of the main repository.
+func (r *Repository) GetHooksDir() (string, error) {
+	r.hooksMu.Lock()
+	defer r.hooksMu.Unlock()
+	if r.hooksDir != "" && time.Since(r.hooksTime) < gitConfigCacheTTL {
+		return r.hooksDir, nil
+	}
+
+	cmd := exec.Command("git", "rev-parse", "--git-path", "hooks")
+	cmd.Dir = r.path
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	dir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(r.path, dir)
+	}
+	r.hooksDir, r.hooksTime = dir, time.Now()
+	return dir, nil
+}
+
+// GetHooks returns the commit and push hooks installed in the repository
+// (samples and hooks git would not run are ignored)
+func (r *Repository) GetHooks() ([]string, error) {
+	dir, err := r.GetHooksDir()
+	if err != nil {
+		return nil, err
+	}
+	var hooks []string
+	for _, name := range CommitHooks {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil || info.IsDir() {
+			continue
+		}
+		// Git ignores hooks that are not executable, except on Windows
+		if runtime.GOOS != "windows" && info.Mode().Perm()&0111 == 0 {
+			continue
+		}
+		hooks = append(hooks, name)
+	}
+	return hooks, nil
+}
@@ -0,0 +1,125 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LargeRepoStatusTTL is how long the status of a large repository is reused
+// by polling. Changes under .git (commits, staging, checkouts) still refresh
+// it at once through the file watcher.
+const LargeRepoStatusTTL = 5 * time.Minute
+
+// GetStatusPorcelain returns the status computed by the git command rather
+// than go-git, for large repositories: porcelain v2, untracked files not
+// scanned, and the builtin fsmonitor daemon where git has one (macOS,
+// Windows; elsewhere the core.fsmonitor of the repository applies). Ahead
+// and behind are filled from the upstream branch.
+func (r *Repository) GetStatusPorcelain() (*Status, error) {
+	args := []string{"status", "--porcelain=v2", "--branch", "-z", "--untracked-files=no"}
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		args = append([]string{"-c", "core.fsmonitor=true"}, args...)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.path
+	// Do not refresh the index: the write would wake the .git watcher
+	cmd.Env = append(os.Environ(), "GIT_OPTIONAL_LOCKS=0")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to get status: %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return parsePorcelainV2(stdout.String()), nil
+}
+
+// parsePorcelainV2 parses the output of git status --porcelain=v2 --branch -z
+func parsePorcelainV2(output string) *Status {
+	status := &Status{
+		IsClean:   true,
+		Untracked: []string{},
+		Modified:  []string{},
+		Staged:    []string{},
+		Deleted:   []string{},
+	}
+
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if entry == "" {
+			continue
+		}
+		switch entry[0] {
+		case '#':
+			parsePorcelainHeader(status, entry)
+		case '1', '2':
+			// 1 XY sub mH mI mW hH hI path
+			// 2 XY sub mH mI mW hH hI Xscore path, then the original path
+			fieldCount := 9
+			if entry[0] == '2' {
+				fieldCount = 10
+				i++ // Skip the original path
+			}
+			fields := strings.SplitN(entry, " ", fieldCount)
+			if len(fields) < fieldCount || len(fields[1]) != 2 {
+				continue
+			}
+			status.IsClean = false
+			path := fields[fieldCount-1]
+			if fields[1][0] != '.' {
+				status.Staged = append(status.Staged, path)
+				status.HasStaged = true
+			}
+			switch fields[1][1] {
+			case 'M', 'T':
+				status.Modified = append(status.Modified, path)
+				status.HasModified = true
+			case 'D':
+				status.Deleted = append(status.Deleted, path)
+				status.HasModified = true
+			}
+		case 'u':
+			// u XY sub m1 m2 m3 mW h1 h2 h3 path
+			if fields := strings.SplitN(entry, " ", 11); len(fields) == 11 {
+				status.IsClean = false
+				status.Conflicts = append(status.Conflicts, fields[10])
+			}
+		case '?':
+			status.Untracked = append(status.Untracked, entry[2:])
+			status.HasUntracked = true
+		}
+	}
+
+	sort.Strings(status.Staged)
+	sort.Strings(status.Modified)
+	sort.Strings(status.Untracked)
+	sort.Strings(status.Deleted)
+	return status
+}
+
+// parsePorcelainHeader parses a "# branch.*" header line
+func parsePorcelainHeader(status *Status, line string) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return
+	}
+	switch fields[1] {
+	case "branch.head":
+		if fields[2] != "(detached)" {
+			status.Branch = fields[2]
+		}
+	case "branch.upstream":
+		status.Remote, _, _ = strings.Cut(fields[2], "/")
+	case "branch.ab":
+		if len(fields) == 4 {
+			status.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[2], "+"))
+			status.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[3], "-"))
+		}
+	}
+}
@@ -39,7 +39,8 @@ type Commit struct {
 	AuthorEmail string    `json:"author_email"`
 	Date        time.Time `json:"date"`
 	Message     string    `json:"message"`
-	Subject     string    `json:"subject"` // First line of message
+	Subject     string    `json:"subject"`             // First line of message
+	Signature   string    `json:"signature,omitempty"` // Signature status (SignatureGood...), empty if unknown
 }
 
 // FileDiff represents a diff for a single file
@@ -1,7 +1,11 @@
 package git
 
 import (
+	"bytes"
 	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -23,6 +27,16 @@ type Repository struct {
 	statusCacheTime time.Time
 	statusCacheTTL  time.Duration
 	statusMu        sync.RWMutex
+
+	// Cache for the signing configuration (read with the git command)
+	signing     *SigningConfig
+	signingTime time.Time
+	signingMu   sync.Mutex
+
+	// Cache for the hooks directory (core.hooksPath or .git/hooks)
+	hooksDir  string
+	hooksTime time.Time
+	hooksMu   sync.Mutex
 }
 
 // OpenRepository opens a git repository at the given path
@@ -82,6 +96,58 @@ func (r *Repository) InvalidateCache() {
 	r.statusMu.Unlock()
 }
 
+// Signature returns a cheap fingerprint of the repository state.
+// It changes when HEAD moves, the index is written or a file of the
+// worktree is added, removed or modified, so an unchanged signature means
+// the (expensive) worktree status can be reused.
+func (r *Repository) Signature() (string, error) {
+	var sb strings.Builder
+
+	// HEAD branch and commit
+	if head, err := r.repo.Head(); err == nil {
+		fmt.Fprintf(&sb, "%s@%s", head.Name(), head.Hash())
+	}
+
+	// Index (staging area)
+	if info, err := os.Stat(filepath.Join(r.path, ".git", "index")); err == nil {
+		fmt.Fprintf(&sb, "|%d:%d", info.ModTime().UnixNano(), info.Size())
+	}
+
+	// Worktree: newest mtime and entry count (count catches deletions)
+	var latest int64
+	var count int
+	err := filepath.WalkDir(r.path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && path != r.path && signatureSkipDirs[d.Name()] {
+			return filepath.SkipDir
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if mtime := info.ModTime().UnixNano(); mtime > latest {
+			latest = mtime
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to scan worktree: %w", err)
+	}
+	fmt.Fprintf(&sb, "|%d:%d", latest, count)
+
+	return sb.String(), nil
+}
+
+// signatureSkipDirs are directories not scanned by Signature
+// (git internals and dependency trees that are ignored in practice)
+var signatureSkipDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+}
+
 // IsRepository checks if a path is a git repository
 func IsRepository(path string) bool {
 	_, err := git.PlainOpen(path)
@@ -199,6 +265,13 @@ func (r *Repository) GetLog(opts LogOptions) ([]Commit, error) {
 		return nil, fmt.Errorf("failed to iterate log: %w", err)
 	}
 
+	// Signature status, when the git command is available
+	if signatures, err := r.getSignatures(opts); err == nil {
+		for i := range commits {
+			commits[i].Signature = signatures[commits[i].Hash]
+		}
+	}
+
 	return commits, nil
 }
 
@@ -342,3 +415,22 @@ func (r *Repository) GetHead() (*Commit, error) {
 func (r *Repository) Path() string {
 	return r.path
 }
+
+// Stage adds files to the index: changes, new files and deletions (git add
+// -A). The git command is used: it runs the clean/smudge filters (LFS) that
+// go-git does not.
+func (r *Repository) Stage(paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	cmd := exec.Command("git", append([]string{"add", "-A", "--"}, paths...)...)
+	cmd.Dir = r.path
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	r.InvalidateCache()
+	if err != nil {
+		return fmt.Errorf("git add failed: %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
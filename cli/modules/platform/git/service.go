@@ -1,23 +1,38 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
 
 	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/tracing"
 )
 
+// DefaultStatusWorkers is the number of repositories scanned in parallel
+const DefaultStatusWorkers = 4
+
 // statusCacheEntry holds cached git status with expiry
 type statusCacheEntry struct {
 	status    *Status
 	timestamp time.Time
+	signature string // Repository signature the status was computed for
+}
+
+// StatusResult is the status of one project, delivered by StatusAll
+type StatusResult struct {
+	ProjectID string
+	Status    *Status
+	Err       error
 }
 
 // Service provides git operations for projects
 type Service struct {
 	projectService *projects.Service
 	repos          map[string]*Repository
+	reposMu        sync.Mutex
+	workers        int // Parallel status computations
 
 	// Status cache with TTL
 	statusCache   map[string]*statusCacheEntry
@@ -32,11 +47,15 @@ func NewService(projectService *projects.Service) *Service {
 		repos:          make(map[string]*Repository),
 		statusCache:    make(map[string]*statusCacheEntry),
 		statusTTL:      2 * time.Second, // Cache status for 2 seconds
+		workers:        DefaultStatusWorkers,
 	}
 }
 
 // GetRepository returns the git repository for a project
 func (s *Service) GetRepository(projectID string) (*Repository, error) {
+	s.reposMu.Lock()
+	defer s.reposMu.Unlock()
+
 	// Check cache
 	if repo, ok := s.repos[projectID]; ok {
 		return repo, nil
@@ -60,38 +79,73 @@ func (s *Service) GetRepository(projectID string) (*Repository, error) {
 	return repo, nil
 }
 
-// GetStatus returns the git status for a project (with caching)
+// GetStatus returns the git status for a project (with caching).
+// Within the TTL the cached status is returned as is; after that it is
+// reused as long as the repository signature (HEAD, index, worktree
+// mtimes) has not changed. Large repositories skip the signature, which
+// walks the worktree: their status is computed by git and kept longer.
 func (s *Service) GetStatus(projectID string) (*Status, error) {
+	large := s.isLargeRepo(projectID)
+	ttl := s.statusTTL
+	if large {
+		ttl = LargeRepoStatusTTL
+	}
+
 	// Check cache first
 	s.statusCacheMu.RLock()
-	if entry, ok := s.statusCache[projectID]; ok {
-		if time.Since(entry.timestamp) < s.statusTTL {
-			s.statusCacheMu.RUnlock()
-			return entry.status, nil
-		}
-	}
+	entry := s.statusCache[projectID]
 	s.statusCacheMu.RUnlock()
+	if entry != nil && time.Since(entry.timestamp) < ttl {
+		return entry.status, nil
+	}
 
-	// Cache miss or expired - fetch fresh status
 	repo, err := s.GetRepository(projectID)
 	if err != nil {
 		return nil, err
 	}
 
+	if large {
+		status, err := repo.GetStatusPorcelain()
+		if err != nil {
+			return nil, err
+		}
+		s.storeStatus(projectID, status, "")
+		return status, nil
+	}
+
+	// Expired - reuse the status if the repository did not change
+	signature, err := repo.Signature()
+	if err == nil && entry != nil && entry.signature == signature {
+		s.storeStatus(projectID, entry.status, signature)
+		return entry.status, nil
+	}
+
+	// Changed or unknown - fetch fresh status
+	repo.InvalidateCache()
 	status, err := repo.GetStatus()
 	if err != nil {
 		return nil, err
 	}
 
-	// Update cache
+	s.storeStatus(projectID, status, signature)
+	return status, nil
+}
+
+// isLargeRepo returns true if the project is in large repository mode
+func (s *Service) isLargeRepo(projectID string) bool {
+	project, err := s.projectService.GetProject(projectID)
+	return err == nil && project.LargeRepo
+}
+
+// storeStatus updates the status cache of a project
+func (s *Service) storeStatus(projectID string, status *Status, signature string) {
 	s.statusCacheMu.Lock()
 	s.statusCache[projectID] = &statusCacheEntry{
 		status:    status,
 		timestamp: time.Now(),
+		signature: signature,
 	}
 	s.statusCacheMu.Unlock()
-
-	return status, nil
 }
 
 // InvalidateStatusCache clears the status cache for a project
@@ -119,6 +173,38 @@ func (s *Service) GetLog(projectID string, opts LogOptions) ([]Commit, error) {
 	return repo.GetLog(opts)
 }
 
+// GetSigningConfig returns the commit signing configuration of a project
+func (s *Service) GetSigningConfig(projectID string) (*SigningConfig, error) {
+	repo, err := s.GetRepository(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.GetSigningConfig()
+}
+
+// GetHooks returns the commit and push hooks installed in a project
+func (s *Service) GetHooks(projectID string) ([]string, error) {
+	repo, err := s.GetRepository(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.GetHooks()
+}
+
+// Stage adds files of a project to the index (paths relative to the project)
+func (s *Service) Stage(projectID string, paths []string) error {
+	repo, err := s.GetRepository(projectID)
+	if err != nil {
+		return err
+	}
+
+	err = repo.Stage(paths)
+	s.InvalidateStatusCache(projectID)
+	return err
+}
+
 // GetDiff returns the git diff for a project
 func (s *Service) GetDiff(projectID string, opts DiffOptions) (*Diff, error) {
 	repo, err := s.GetRepository(projectID)
@@ -129,46 +215,102 @@ func (s *Service) GetDiff(projectID string, opts DiffOptions) (*Diff, error) {
 	return repo.GetDiff(opts)
 }
 
+// StatusAll computes the git status of all projects with a worker pool.
+// onResult is called once per project as soon as its status is known, in
+// completion order. Calls are serialized on the caller goroutine and
+// StatusAll returns when all projects are done.
+func (s *Service) StatusAll(onResult func(result StatusResult)) {
+	s.StatusAllContext(context.Background(), onResult)
+}
+
+// StatusAllContext is StatusAll with the status of each project traced as a
+// child of the span of ctx
+func (s *Service) StatusAllContext(ctx context.Context, onResult func(result StatusResult)) {
+	allProjects := s.projectService.ListProjects()
+	if len(allProjects) == 0 {
+		return
+	}
+
+	workers := s.workers
+	if workers > len(allProjects) {
+		workers = len(allProjects)
+	}
+
+	jobs := make(chan string)
+	results := make(chan StatusResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for projectID := range jobs {
+				_, span := tracing.Start(ctx, "git.status", tracing.String("project", projectID))
+				status, err := s.GetStatus(projectID)
+				span.SetError(err)
+				span.End()
+				results <- StatusResult{ProjectID: projectID, Status: status, Err: err}
+			}
+		}()
+	}
+
+	go func() {
+		for _, project := range allProjects {
+			jobs <- project.ID
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		onResult(result)
+	}
+}
+
 // GetAllStatus returns git status for all projects
 func (s *Service) GetAllStatus() map[string]*Status {
 	results := make(map[string]*Status)
 
-	for _, project := range s.projectService.ListProjects() {
-		status, err := s.GetStatus(project.ID)
-		if err == nil {
-			results[project.ID] = status
+	s.StatusAll(func(result StatusResult) {
+		if result.Err == nil {
+			results[result.ProjectID] = result.Status
 		}
-	}
+	})
 
 	return results
 }
 
 // EnrichProject adds git information to a project
 func (s *Service) EnrichProject(project *projects.Project) error {
-	repo, err := s.GetRepository(project.ID)
+	status, err := s.GetStatus(project.ID)
 	if err != nil {
 		return err
 	}
 
-	status, err := repo.GetStatus()
-	if err != nil {
-		return err
-	}
+	ApplyStatus(project, status)
+	return nil
+}
 
+// EnrichAllProjects adds git information to all projects
+func (s *Service) EnrichAllProjects() {
+	s.StatusAll(func(result StatusResult) {
+		if result.Err != nil {
+			return
+		}
+		if project, err := s.projectService.GetProject(result.ProjectID); err == nil {
+			ApplyStatus(project, result.Status)
+		}
+	})
+}
+
+// ApplyStatus copies the git status fields to a project
+func ApplyStatus(project *projects.Project, status *Status) {
 	project.GitBranch = status.Branch
 	project.GitDirty = !status.IsClean || status.HasUntracked
 	project.GitAhead = status.Ahead
 	project.GitBehind = status.Behind
 	project.GitRemote = status.Remote
-
-	return nil
-}
-
-// EnrichAllProjects adds git information to all projects
-func (s *Service) EnrichAllProjects() {
-	for _, project := range s.projectService.ListProjects() {
-		s.EnrichProject(project)
-	}
 }
 
 // IsGitRepository checks if a project is a git repository
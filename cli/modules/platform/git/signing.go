@@ -0,0 +1,193 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Signature formats (gpg.format)
+const (
+	SigningOpenPGP = "openpgp" // GnuPG, passphrase asked by pinentry (default)
+	SigningSSH     = "ssh"     // SSH key, passphrase kept by ssh-agent
+	SigningX509    = "x509"    // S/MIME certificate (gpgsm)
+)
+
+// Signature status of a commit, as reported by git log %G?
+const (
+	SignatureGood        = "G" // Good signature
+	SignatureUnknown     = "U" // Good signature, unknown validity of the key
+	SignatureExpired     = "X" // Good signature that has expired
+	SignatureExpiredKey  = "Y" // Good signature made by an expired key
+	SignatureRevokedKey  = "R" // Good signature made by a revoked key
+	SignatureBad         = "B" // Bad signature
+	SignatureCannotCheck = "E" // Cannot be checked (missing key or allowed signers)
+	SignatureNone        = "N" // Not signed
+)
+
+// gitConfigCacheTTL is how long the signing configuration and the hooks
+// directory of a repository are cached (they are read on every status refresh)
+const gitConfigCacheTTL = 30 * time.Second
+
+// SigningConfig is the commit signing configuration of a repository, merged
+// from the system, global and repository git config (includes followed)
+type SigningConfig struct {
+	SignCommits bool   `json:"sign_commits"`      // commit.gpgsign
+	SignTags    bool   `json:"sign_tags"`         // tag.gpgsign
+	Format      string `json:"format"`            // gpg.format
+	Key         string `json:"key,omitempty"`     // user.signingkey (default key of the user id when empty)
+	Program     string `json:"program,omitempty"` // gpg.<format>.program
+}
+
+// Enabled returns true if commits or tags are signed
+func (s *SigningConfig) Enabled() bool {
+	return s != nil && (s.SignCommits || s.SignTags)
+}
+
+// Warning describes a configuration with which signing will likely fail or
+// block in a terminal UI, empty if none
+func (s *SigningConfig) Warning() string {
+	if !s.Enabled() {
+		return ""
+	}
+	switch s.Format {
+	case SigningSSH:
+		if s.Key == "" {
+			return "user.signingkey is not set: ssh signing needs a key"
+		}
+		if os.Getenv("SSH_AUTH_SOCK") == "" {
+			return "no ssh-agent: the key passphrase is asked on each commit"
+		}
+	default:
+		if os.Getenv("GPG_TTY") == "" && os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			return "GPG_TTY is not set: pinentry cannot ask the passphrase in the terminal"
+		}
+	}
+	return ""
+}
+
+// Summary describes the configuration in a few words
+func (s *SigningConfig) Summary() string {
+	if !s.Enabled() {
+		return "off"
+	}
+	var what []string
+	if s.SignCommits {
+		what = append(what, "commits")
+	}
+	if s.SignTags {
+		what = append(what, "tags")
+	}
+	summary := s.Format + " (" + strings.Join(what, ", ") + ")"
+	if s.Key != "" {
+		summary += " key " + s.Key
+	}
+	return summary
+}
+
+// GetSigningConfig returns the signing configuration of the repository. The
+// git command is used rather than go-git, which ignores includes and does not
+// merge the global config into the repository config.
+func (r *Repository) GetSigningConfig() (*SigningConfig, error) {
+	r.signingMu.Lock()
+	defer r.signingMu.Unlock()
+	if r.signing != nil && time.Since(r.signingTime) < gitConfigCacheTTL {
+		return r.signing, nil
+	}
+
+	cmd := exec.Command("git", "config", "--get-regexp",
+		`^(commit\.gpgsign|tag\.gpgsign|gpg\.format|user\.signingkey|gpg\..*\.program|gpg\.program)$`)
+	cmd.Dir = r.path
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 && stderr.Len() == 0 {
+		err = nil // No matching key
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git config: %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	values := make(map[string]string)
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		if key != "" {
+			values[key] = value // Last one wins, as in git
+		}
+	}
+	signing := &SigningConfig{
+		SignCommits: configBool(values["commit.gpgsign"]),
+		SignTags:    configBool(values["tag.gpgsign"]),
+		Format:      strings.ToLower(values["gpg.format"]),
+		Key:         values["user.signingkey"],
+	}
+	if signing.Format == "" {
+		signing.Format = SigningOpenPGP
+	}
+	signing.Program = values["gpg."+signing.Format+".program"]
+	if signing.Program == "" && signing.Format == SigningOpenPGP {
+		signing.Program = values["gpg.program"]
+	}
+
+	r.signing, r.signingTime = signing, time.Now()
+	return signing, nil
+}
+
+// getSignatures returns the signature status of the commits of the log, by
+// full hash. Verifying needs gpg (or ssh-keygen and gpg.ssh.allowedSignersFile)
+// but never a passphrase, so it does not prompt.
+func (r *Repository) getSignatures(opts LogOptions) (map[string]string, error) {
+	args := []string{"log", "-n", strconv.Itoa(opts.MaxCount), "--format=%H %G?"}
+	if opts.Path != "" {
+		args = append(args, "--", opts.Path)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.path
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify signatures: %w", err)
+	}
+	signatures := make(map[string]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		if hash, status, ok := strings.Cut(line, " "); ok {
+			signatures[hash] = status
+		}
+	}
+	return signatures, nil
+}
+
+// SignatureLabel describes a signature status of git log %G?
+func SignatureLabel(status string) string {
+	switch status {
+	case SignatureGood:
+		return "good signature"
+	case SignatureUnknown:
+		return "good signature, unknown key validity"
+	case SignatureExpired:
+		return "expired signature"
+	case SignatureExpiredKey:
+		return "signed with an expired key"
+	case SignatureRevokedKey:
+		return "signed with a revoked key"
+	case SignatureBad:
+		return "bad signature"
+	case SignatureCannotCheck:
+		return "signature cannot be checked"
+	case SignatureNone, "":
+		return "not signed"
+	}
+	return "unknown signature status " + status
+}
+
+// configBool parses a git config boolean
+func configBool(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "yes", "on", "1":
+		return true
+	}
+	return false
+}
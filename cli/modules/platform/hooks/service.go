@@ -0,0 +1,221 @@
+package hooks
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Hook is a shell command attached to an event
+type Hook struct {
+	Event     string
+	Command   string
+	Project   string // Empty = all projects
+	Component string // Empty = all components
+	Threshold int    // Changed files (git dirty threshold events)
+	Timeout   time.Duration
+}
+
+// Matches returns true if the hook applies to an event of a project component
+func (h Hook) Matches(event, projectID, component string) bool {
+	if h.Event != event {
+		return false
+	}
+	if h.Project != "" && h.Project != projectID {
+		return false
+	}
+	if h.Component != "" && component != "" && h.Component != component {
+		return false
+	}
+	return true
+}
+
+// Context is the event a hook is run for
+type Context struct {
+	Event     string
+	ProjectID string
+	Component string
+	Dir       string            // Working directory (project path)
+	Vars      map[string]string // Extra DEVTRACK_* variables (without prefix)
+}
+
+// Result is the outcome of a hook run
+type Result struct {
+	Hook     Hook
+	Context  Context
+	Error    string
+	Duration time.Duration
+}
+
+// Service runs the hooks matching DevTrack events.
+// Threshold hooks fire once when the value crosses the threshold, and are
+// re-armed when it goes back below.
+type Service struct {
+	mu      sync.Mutex
+	hooks   []Hook
+	crossed map[string]bool // Key: hook index/project ID
+}
+
+// NewService creates a hook service
+func NewService(hooks []Hook) *Service {
+	return &Service{hooks: hooks, crossed: make(map[string]bool)}
+}
+
+// HasHooks returns true if at least one hook is attached to the event
+func (s *Service) HasHooks(event string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, h := range s.hooks {
+		if h.Event == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Fire runs the hooks matching the event in the background.
+// onOutput is called for each output line, onDone when a hook exits.
+func (s *Service) Fire(ctx context.Context, hc Context, onOutput func(h Hook, line string, isError bool), onDone func(Result)) {
+	for _, h := range s.Matching(hc.Event, hc.ProjectID, hc.Component) {
+		go s.run(ctx, h, hc, onOutput, onDone)
+	}
+}
+
+// FireThreshold runs the threshold hooks of the event whose threshold was just
+// crossed by value
+func (s *Service) FireThreshold(ctx context.Context, hc Context, value int, onOutput func(h Hook, line string, isError bool), onDone func(Result)) {
+	s.mu.Lock()
+	var toRun []Hook
+	for i, h := range s.hooks {
+		if !h.Matches(hc.Event, hc.ProjectID, hc.Component) {
+			continue
+		}
+		key := fmt.Sprintf("%d/%s", i, hc.ProjectID)
+		above := value >= h.Threshold
+		if above && !s.crossed[key] {
+			toRun = append(toRun, h)
+		}
+		s.crossed[key] = above
+	}
+	s.mu.Unlock()
+
+	for _, h := range toRun {
+		hc := hc
+		hc.Vars = withVar(hc.Vars, "THRESHOLD", fmt.Sprintf("%d", h.Threshold))
+		go s.run(ctx, h, hc, onOutput, onDone)
+	}
+}
+
+// Matching returns the hooks of an event applying to a project component
+func (s *Service) Matching(event, projectID, component string) []Hook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []Hook
+	for _, h := range s.hooks {
+		if h.Matches(event, projectID, component) {
+			result = append(result, h)
+		}
+	}
+	return result
+}
+
+// run executes a hook and streams its output
+func (s *Service) run(ctx context.Context, h Hook, hc Context, onOutput func(Hook, string, bool), onDone func(Result)) {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, h.Timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/c", h.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", h.Command)
+	}
+	cmd.Dir = hc.Dir
+	cmd.Env = append(os.Environ(), environment(hc)...)
+
+	result := Result{Hook: h, Context: hc}
+	err := func() error {
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return err
+		}
+		stderr, err := cmd.StderrPipe()
+		if err != nil {
+			return err
+		}
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			readOutput(h, stdout, false, onOutput)
+		}()
+		go func() {
+			defer wg.Done()
+			readOutput(h, stderr, true, onOutput)
+		}()
+		// Pipes must be drained before Wait closes them
+		wg.Wait()
+		return cmd.Wait()
+	}()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			err = fmt.Errorf("timed out after %s", h.Timeout)
+		}
+		result.Error = err.Error()
+	}
+	result.Duration = time.Since(start)
+
+	if onDone != nil {
+		onDone(result)
+	}
+}
+
+// readOutput forwards the non-empty output lines of a hook
+func readOutput(h Hook, r io.Reader, isError bool, onOutput func(Hook, string, bool)) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || onOutput == nil {
+			continue
+		}
+		onOutput(h, line, isError)
+	}
+}
+
+// environment returns the DEVTRACK_* variables describing the event
+func environment(hc Context) []string {
+	env := []string{
+		"DEVTRACK_EVENT=" + hc.Event,
+		"DEVTRACK_PROJECT=" + hc.ProjectID,
+		"DEVTRACK_PROJECT_PATH=" + hc.Dir,
+		"DEVTRACK_COMPONENT=" + hc.Component,
+	}
+	for name, value := range hc.Vars {
+		env = append(env, "DEVTRACK_"+name+"="+value)
+	}
+	return env
+}
+
+// withVar returns a copy of vars with name set to value
+func withVar(vars map[string]string, name, value string) map[string]string {
+	result := make(map[string]string, len(vars)+1)
+	for k, v := range vars {
+		result[k] = v
+	}
+	result[name] = value
+	return result
+}
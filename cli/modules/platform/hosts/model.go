@@ -0,0 +1,37 @@
+package hosts
+
+import (
+	"errors"
+	"strings"
+)
+
+// Markers of the block of the hosts file managed by DevTrack
+const (
+	BeginMarker = "# BEGIN csd-devtrack (managed: hostnames of the components)"
+	EndMarker   = "# END csd-devtrack"
+)
+
+// LoopbackIP is the address the dev hostnames are mapped to
+const LoopbackIP = "127.0.0.1"
+
+// ErrSudoPassword is returned when writing the hosts file needs the sudo
+// password (the write is to be done interactively, see SudoCopyCommand)
+var ErrSudoPassword = errors.New("sudo needs a password")
+
+// Entry maps a dev hostname to the component serving it
+type Entry struct {
+	Host   string // e.g. app.test, acme.app.test, *.app.test
+	Target string // project/component, written as a comment
+}
+
+// Wildcard returns true for *.domain hostnames, which a hosts file cannot map
+func (e Entry) Wildcard() bool {
+	return strings.HasPrefix(e.Host, "*.")
+}
+
+// Localhost returns true for the *.localhost hostnames, resolved to the
+// loopback by the browsers (and systemd-resolved) without any entry
+func (e Entry) Localhost() bool {
+	host := strings.TrimSuffix(strings.ToLower(e.Host), ".")
+	return host == "localhost" || strings.HasSuffix(host, ".localhost")
+}
@@ -0,0 +1,212 @@
+package hosts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// resolveTimeout bounds the resolution of a hostname
+const resolveTimeout = 2 * time.Second
+
+// Path returns the path of the hosts file of the system
+func Path() string {
+	if runtime.GOOS == "windows" {
+		root := os.Getenv("SystemRoot")
+		if root == "" {
+			root = `C:\Windows`
+		}
+		return filepath.Join(root, "System32", "drivers", "etc", "hosts")
+	}
+	return "/etc/hosts"
+}
+
+// Read returns the content of a hosts file
+func Read(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Block returns the managed block mapping the entries to the loopback,
+// empty without any (wildcards cannot be mapped and are skipped)
+func Block(entries []Entry) string {
+	var lines []string
+	for _, e := range entries {
+		if e.Wildcard() {
+			continue
+		}
+		line := LoopbackIP + " " + e.Host
+		if e.Target != "" {
+			line += " # " + e.Target
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return BeginMarker + "\n" + strings.Join(lines, "\n") + "\n" + EndMarker
+}
+
+// Managed returns the hostnames of the managed block of a hosts file
+func Managed(content string) []string {
+	var names []string
+	inBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == BeginMarker:
+			inBlock = true
+		case line == EndMarker:
+			inBlock = false
+		case inBlock:
+			if comment := strings.Index(line, "#"); comment >= 0 {
+				line = line[:comment]
+			}
+			if fields := strings.Fields(line); len(fields) > 1 {
+				names = append(names, fields[1:]...)
+			}
+		}
+	}
+	return names
+}
+
+// Current returns the managed block of a hosts file, empty without one
+func Current(content string) string {
+	var lines []string
+	inBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == BeginMarker {
+			inBlock = true
+		}
+		if inBlock {
+			lines = append(lines, line)
+		}
+		if line == EndMarker {
+			inBlock = false
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Update returns the content of a hosts file with its managed block replaced
+// by the block of the entries, or removed without any. The line endings of
+// the file are kept.
+func Update(content string, entries []Entry) string {
+	newline := "\n"
+	if strings.Contains(content, "\r\n") {
+		newline = "\r\n"
+	}
+
+	var lines []string
+	inBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case strings.TrimSpace(line) == BeginMarker:
+			inBlock = true
+		case strings.TrimSpace(line) == EndMarker:
+			inBlock = false
+		case !inBlock:
+			lines = append(lines, line)
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if block := Block(entries); block != "" {
+		lines = append(lines, "")
+		lines = append(lines, strings.Split(block, "\n")...)
+	}
+	return strings.Join(lines, newline) + newline
+}
+
+// Writable returns true if a hosts file can be written without sudo
+func Writable(path string) bool {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
+
+// Write replaces the content of a hosts file: directly when writable, else
+// with "sudo -n tee" (sudoPath empty = no sudo). ErrSudoPassword is returned
+// when sudo asks a password.
+func Write(ctx context.Context, path, content, sudoPath string) error {
+	if Writable(path) {
+		return os.WriteFile(path, []byte(content), 0644)
+	}
+	if runtime.GOOS == "windows" {
+		return fmt.Errorf("%s is not writable: run DevTrack as administrator", path)
+	}
+	if sudoPath == "" {
+		return fmt.Errorf("%s is not writable and sudo is not available", path)
+	}
+
+	cmd := exec.CommandContext(ctx, sudoPath, "-n", "tee", path)
+	cmd.Stdin = strings.NewReader(content)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if strings.Contains(stderr.String(), "password") {
+			return ErrSudoPassword
+		}
+		return fmt.Errorf("sudo tee %s: %v: %s", path, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// Stage writes the new content of a hosts file in a temporary file, copied by
+// SudoCopyCommand
+func Stage(content string) (string, error) {
+	f, err := os.CreateTemp("", "devtrack-hosts-*")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// SudoCopyCommand returns the command copying a staged hosts file over the
+// hosts file, run in the terminal for sudo to ask its password
+func SudoCopyCommand(sudoPath, staged, path string) *exec.Cmd {
+	return exec.Command(sudoPath, "cp", staged, path)
+}
+
+// Resolves returns true if a hostname resolves to a loopback address
+func Resolves(ctx context.Context, host string) bool {
+	ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return false
+	}
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil && ip.IsLoopback() {
+			return true
+		}
+	}
+	return false
+}
+
+// DNSHint returns the dnsmasq line resolving a domain and all its subdomains
+// to the loopback (*.app.test gives address=/app.test/127.0.0.1)
+func DNSHint(host string) string {
+	return "address=/" + strings.TrimPrefix(host, "*.") + "/" + LoopbackIP
+}
@@ -0,0 +1,144 @@
+// Package jsonl is the append-only JSON Lines file behind the audit log and
+// the build and run histories: appending keeps the records of several
+// DevTrack processes (daemon, TUI, CLI) in one file, and the file is
+// compacted now and then so a long-running daemon does not grow it forever.
+package jsonl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"csd-devtrack/cli/modules/platform/fileutil"
+)
+
+// Store appends records of type T to a JSON Lines file
+type Store[T any] struct {
+	file    string
+	every   int                   // Appends between two compactions
+	compact func(records []T) []T // Records kept by a compaction (nil = never compacted)
+
+	mu       sync.Mutex
+	appended int // Records appended since the last compaction
+}
+
+// NewStore creates a store writing to file. compact returns the records kept
+// from the ones on disk (oldest first); it runs when the store is created and
+// then every `every` appends. A nil compact keeps every record.
+func NewStore[T any](file string, every int, compact func(records []T) []T) *Store[T] {
+	s := &Store[T]{
+		file:    file,
+		every:   every,
+		compact: compact,
+	}
+	s.mu.Lock()
+	s.compactLocked()
+	s.mu.Unlock()
+	return s
+}
+
+// KeepLast returns a compaction keeping the last n records (nil when n is
+// not positive: every record is kept)
+func KeepLast[T any](n int) func(records []T) []T {
+	if n <= 0 {
+		return nil
+	}
+	return func(records []T) []T {
+		if len(records) <= n {
+			return records
+		}
+		return records[len(records)-n:]
+	}
+}
+
+// File returns the path of the file
+func (s *Store[T]) File() string {
+	return s.file
+}
+
+// Append appends a record to the file
+func (s *Store[T]) Append(record T) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.file), 0755); err != nil {
+		return fmt.Errorf("failed to create directory of %s: %w", filepath.Base(s.file), err)
+	}
+	f, err := os.OpenFile(s.file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", filepath.Base(s.file), err)
+	}
+	_, err = f.Write(append(data, '\n'))
+	f.Close()
+
+	s.appended++
+	if s.compact != nil && s.appended >= s.every {
+		s.appended = 0
+		s.compactLocked()
+	}
+	return err
+}
+
+// Load returns the records of the file, oldest first (invalid lines are
+// skipped)
+func (s *Store[T]) Load() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// load reads the records from disk. The caller holds the lock.
+func (s *Store[T]) load() []T {
+	f, err := os.Open(s.file)
+	if err != nil {
+		return nil // Nothing recorded yet
+	}
+	defer f.Close()
+
+	var records []T
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		var r T
+		if len(bytes.TrimSpace(line)) > 0 && json.Unmarshal(line, &r) == nil {
+			records = append(records, r)
+		}
+		if err != nil {
+			return records
+		}
+	}
+}
+
+// compactLocked rewrites the file with the records kept by the compaction,
+// when it drops some. The caller holds the lock.
+func (s *Store[T]) compactLocked() {
+	if s.compact == nil {
+		return
+	}
+
+	records := s.load()
+	kept := s.compact(records)
+	if len(kept) == len(records) {
+		return
+	}
+
+	var buf bytes.Buffer
+	for _, r := range kept {
+		data, err := json.Marshal(r)
+		if err != nil {
+			continue
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	fileutil.WriteFileAtomic(s.file, buf.Bytes(), 0600)
+}
@@ -0,0 +1,42 @@
+package jsonl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type record struct {
+	ID int `json:"id"`
+}
+
+func TestAppendCompactsToLastRecords(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "history.jsonl")
+	s := NewStore(file, 2, KeepLast[record](3))
+	for i := 1; i <= 6; i++ {
+		if err := s.Append(record{ID: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records := s.Load()
+	if len(records) != 3 || records[0].ID != 4 || records[2].ID != 6 {
+		t.Fatalf("unexpected records after compaction: %v", records)
+	}
+	if info, err := os.Stat(file); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("history not private: %v %v", info.Mode(), err)
+	}
+}
+
+func TestLoadSkipsInvalidLines(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "history.jsonl")
+	data := "{\"id\":1}\nnot json\n\n{\"id\":2}\n{\"id\":3}"
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	records := NewStore[record](file, 0, nil).Load()
+	if len(records) != 3 || records[2].ID != 3 {
+		t.Fatalf("unexpected records: %v", records)
+	}
+}
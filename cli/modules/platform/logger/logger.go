@@ -91,6 +91,11 @@ func (l *Logger) SetLevel(level Level) {
 	l.level = level
 }
 
+// SetLevelName sets the log level from its name (debug, info, warn, error)
+func (l *Logger) SetLevelName(level string) {
+	l.SetLevel(ParseLevel(level))
+}
+
 func (l *Logger) log(level Level, message string) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
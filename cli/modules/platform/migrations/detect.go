@@ -0,0 +1,137 @@
+package migrations
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// migrationDirs are where migration files are usually kept, relative to a
+// project or component directory
+var migrationDirs = []string{
+	"migrations",
+	"db/migrations",
+	"database/migrations",
+	"sql/migrations",
+	"internal/db/migrations",
+	"internal/database/migrations",
+	"migrate",
+}
+
+var (
+	atlasDirPattern  = regexp.MustCompile(`dir\s*=\s*"file://([^"]+)"`)
+	prismaURLPattern = regexp.MustCompile(`url\s*=\s*env\(\s*"([^"]+)"\s*\)`)
+	upFilePattern    = regexp.MustCompile(`^\d+_.+\.up\.sql$`)
+	gooseFilePattern = regexp.MustCompile(`^\d+_.+\.(sql|go)$`)
+)
+
+// Detect returns the migration setups of a project: in its directory and in
+// its first-level subdirectories (backend/, cli/...), where components live
+func Detect(projectPath string) []Setup {
+	roots := []string{projectPath}
+	if entries, err := os.ReadDir(projectPath); err == nil {
+		for _, e := range entries {
+			name := e.Name()
+			if !e.IsDir() || strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor" {
+				continue
+			}
+			roots = append(roots, filepath.Join(projectPath, name))
+		}
+	}
+
+	var setups []Setup
+	seen := make(map[string]bool) // Migration directories
+	add := func(setup Setup) {
+		if !seen[setup.Dir] {
+			seen[setup.Dir] = true
+			setups = append(setups, setup)
+		}
+	}
+	for _, root := range roots {
+		if setup, ok := detectPrisma(root); ok {
+			add(setup)
+		}
+		if setup, ok := detectAtlas(root); ok {
+			add(setup)
+		}
+		for _, dir := range migrationDirs {
+			path := filepath.Join(root, dir)
+			if seen[path] {
+				continue
+			}
+			if tool := detectFiles(path); tool != "" {
+				add(Setup{Tool: tool, Dir: path, WorkDir: root})
+			}
+		}
+	}
+	return setups
+}
+
+// detectPrisma finds prisma/schema.prisma, and the environment variable its
+// datasource URL is read from
+func detectPrisma(root string) (Setup, bool) {
+	schema := filepath.Join(root, "prisma", "schema.prisma")
+	data, err := os.ReadFile(schema)
+	if err != nil {
+		return Setup{}, false
+	}
+	setup := Setup{
+		Tool:    ToolPrisma,
+		Dir:     filepath.Join(root, "prisma", "migrations"),
+		WorkDir: root,
+		URLEnv:  "DATABASE_URL",
+	}
+	if match := prismaURLPattern.FindSubmatch(data); match != nil {
+		setup.URLEnv = string(match[1])
+	}
+	return setup, true
+}
+
+// detectAtlas finds atlas.hcl and the migration directory it declares
+// (migrations/ by default)
+func detectAtlas(root string) (Setup, bool) {
+	data, err := os.ReadFile(filepath.Join(root, "atlas.hcl"))
+	if err != nil {
+		return Setup{}, false
+	}
+	dir := "migrations"
+	if match := atlasDirPattern.FindSubmatch(data); match != nil {
+		dir = string(match[1])
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	return Setup{Tool: ToolAtlas, Dir: filepath.Clean(dir), WorkDir: root}, true
+}
+
+// detectFiles tells the tool of a migration directory from its files:
+// .up.sql/.down.sql pairs for golang-migrate, goose annotations for goose.
+// Empty if the directory holds neither.
+func detectFiles(dir string) Tool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		if upFilePattern.MatchString(name) {
+			return ToolMigrate
+		}
+		if !gooseFilePattern.MatchString(name) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		if bytes.Contains(data, []byte("+goose Up")) || bytes.Contains(data, []byte("goose.AddMigration")) {
+			return ToolGoose
+		}
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"time"
+)
+
+// Tool is a database migration tool
+type Tool string
+
+const (
+	ToolGoose   Tool = "goose"   // pressly/goose: NNN_name.sql with -- +goose Up/Down
+	ToolMigrate Tool = "migrate" // golang-migrate: NNN_name.up.sql / NNN_name.down.sql
+	ToolAtlas   Tool = "atlas"   // Atlas versioned migrations (atlas.hcl)
+	ToolPrisma  Tool = "prisma"  // Prisma Migrate (prisma/schema.prisma)
+)
+
+// Action is a migration action
+type Action string
+
+const (
+	ActionUp   Action = "up"   // Apply all pending migrations
+	ActionDown Action = "down" // Roll back the last applied migration
+	ActionRedo Action = "redo" // Roll back the last migration and apply it again
+)
+
+// Setup is a migration tool found in a project
+type Setup struct {
+	Tool    Tool   `json:"tool"`
+	Dir     string `json:"dir"`               // Migrations directory
+	WorkDir string `json:"work_dir"`          // Directory the tool runs in
+	URLEnv  string `json:"url_env,omitempty"` // Environment variable of the database URL (prisma)
+}
+
+// Target is the database migrations are applied to
+type Target struct {
+	Type string // postgres, mysql or sqlite
+	URL  string // Connection URL, as configured for the project
+}
+
+// Migration is a migration file
+type Migration struct {
+	Version string `json:"version"`
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+}
+
+// Status is the migration state of a database
+type Status struct {
+	Setup      Setup       `json:"setup"`
+	Current    string      `json:"current"`         // Version of the last applied migration, empty if none
+	Dirty      bool        `json:"dirty,omitempty"` // A migration failed halfway (golang-migrate)
+	Migrations []Migration `json:"migrations"`      // Oldest first
+	Pending    int         `json:"pending"`
+	Error      string      `json:"error,omitempty"` // Tool missing, database unreachable...
+	CheckedAt  time.Time   `json:"checked_at"`
+}
+
+// Result is the outcome of a migration action
+type Result struct {
+	Action   Action        `json:"action"`
+	Output   []string      `json:"output"` // Output of the tool, stdout and stderr merged
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
@@ -0,0 +1,398 @@
+package migrations
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// statusTimeout bounds the status commands (the database may be down)
+const statusTimeout = 30 * time.Second
+
+// installHints tell how to install a missing tool
+var installHints = map[Tool]string{
+	ToolGoose:   "go install github.com/pressly/goose/v3/cmd/goose@latest",
+	ToolMigrate: "go install -tags 'postgres mysql sqlite3' github.com/golang-migrate/migrate/v4/cmd/migrate@latest",
+	ToolAtlas:   "curl -sSf https://atlasgo.sh | sh",
+	ToolPrisma:  "npm install --save-dev prisma",
+}
+
+var (
+	versionedFile   = regexp.MustCompile(`^(\d+)_(.+?)(\.up)?\.(sql|go)$`)
+	atlasFile       = regexp.MustCompile(`^(\d+)(?:_(.+))?\.sql$`)
+	prismaDir       = regexp.MustCompile(`^(\d+)_(.+)$`)
+	gooseVersion    = regexp.MustCompile(`version:?\s+(\d+)`)
+	migrateVersion  = regexp.MustCompile(`(?m)^(\d+)( \(dirty\))?\s*$`)
+	atlasCurrent    = regexp.MustCompile(`Current Version:\s*(\S+)`)
+	prismaPendingAt = regexp.MustCompile(`(?i)not yet been applied:?\s*$`)
+)
+
+// Service reads the migration state of databases and applies migrations
+// with the project's migration tool
+type Service struct{}
+
+// NewService creates a migration service
+func NewService() *Service {
+	return &Service{}
+}
+
+// Status lists the migrations of a setup and asks the tool which one the
+// database is at
+func (s *Service) Status(ctx context.Context, setup Setup, target Target) *Status {
+	status := &Status{Setup: setup, CheckedAt: time.Now()}
+	migrations, err := listMigrations(setup)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Migrations = migrations
+
+	ctx, cancel := context.WithTimeout(ctx, statusTimeout)
+	defer cancel()
+	args, err := statusArgs(setup, target)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	output, runErr := s.run(ctx, setup, target, args, nil)
+	text := strings.Join(output, "\n")
+
+	var pending map[string]bool // prisma: names of the pending migrations
+	switch setup.Tool {
+	case ToolGoose:
+		if match := gooseVersion.FindStringSubmatch(text); match != nil && runErr == nil {
+			status.Current = normalizeVersion(match[1])
+		}
+	case ToolMigrate:
+		if match := migrateVersion.FindStringSubmatch(text); match != nil {
+			status.Current = normalizeVersion(match[1])
+			status.Dirty = match[2] != ""
+		} else if strings.Contains(text, "no migration") {
+			runErr = nil // Nothing applied yet
+		}
+	case ToolAtlas:
+		if match := atlasCurrent.FindStringSubmatch(text); match != nil {
+			status.Current = match[1]
+		} else if strings.Contains(text, "No migration applied yet") {
+			runErr = nil
+		}
+	case ToolPrisma:
+		// Exits non-zero when migrations are pending: the output tells
+		pending, runErr = prismaPending(output, runErr)
+	}
+	if runErr != nil {
+		status.Error = lastLines(output, runErr)
+		return status
+	}
+
+	for i := range status.Migrations {
+		m := &status.Migrations[i]
+		if setup.Tool == ToolPrisma {
+			m.Applied = !pending[m.Version+"_"+m.Name]
+			if m.Applied {
+				status.Current = m.Version
+			}
+		} else {
+			m.Applied = status.Current != "" && compareVersions(m.Version, status.Current) <= 0
+		}
+		if !m.Applied {
+			status.Pending++
+		}
+	}
+	return status
+}
+
+// Run applies a migration action. onOutput is called for each output line.
+func (s *Service) Run(ctx context.Context, setup Setup, target Target, action Action, onOutput func(line string)) *Result {
+	start := time.Now()
+	result := &Result{Action: action}
+	steps, err := actionArgs(setup, target, action)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	for _, args := range steps {
+		output, err := s.run(ctx, setup, target, args, onOutput)
+		result.Output = append(result.Output, output...)
+		if err != nil {
+			result.Error = err.Error()
+			break
+		}
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// run runs the tool of a setup and returns its output lines, stdout and
+// stderr merged in order
+func (s *Service) run(ctx context.Context, setup Setup, target Target, args []string, onOutput func(string)) ([]string, error) {
+	binary, args, err := toolCommand(setup, args)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = setup.WorkDir
+	cmd.Env = os.Environ()
+	if setup.Tool == ToolPrisma {
+		url, err := prismaURL(target)
+		if err != nil {
+			return nil, err
+		}
+		cmd.Env = append(cmd.Env, setup.URLEnv+"="+url)
+	}
+
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+		writer.Close()
+	}()
+
+	var output []string
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		output = append(output, line)
+		if onOutput != nil {
+			onOutput(line)
+		}
+	}
+	io.Copy(io.Discard, reader) // Unblock the writer after an over-long line
+	return output, <-done
+}
+
+// toolCommand returns the executable and arguments running a tool. Prisma
+// runs from the project's node_modules, else through npx.
+func toolCommand(setup Setup, args []string) (string, []string, error) {
+	if setup.Tool == ToolPrisma {
+		local := filepath.Join(setup.WorkDir, "node_modules", ".bin", "prisma")
+		if _, err := os.Stat(local); err == nil {
+			return local, args, nil
+		}
+		if npx, err := exec.LookPath("npx"); err == nil {
+			return npx, append([]string{"--no-install", "prisma"}, args...), nil
+		}
+	} else if path, err := exec.LookPath(string(setup.Tool)); err == nil {
+		return path, args, nil
+	}
+	return "", nil, fmt.Errorf("%s not found (install: %s)", setup.Tool, installHints[setup.Tool])
+}
+
+// statusArgs returns the arguments reading the current version
+func statusArgs(setup Setup, target Target) ([]string, error) {
+	switch setup.Tool {
+	case ToolPrisma:
+		return []string{"migrate", "status"}, nil
+	case ToolAtlas:
+		return atlasArgs(setup, target, "migrate", "status")
+	}
+	return toolArgs(setup, target, "version")
+}
+
+// actionArgs returns the commands of an action, run in sequence
+func actionArgs(setup Setup, target Target, action Action) ([][]string, error) {
+	var steps [][]string
+	add := func(args []string, err error) error {
+		steps = append(steps, args)
+		return err
+	}
+
+	var err error
+	switch setup.Tool {
+	case ToolGoose:
+		err = add(toolArgs(setup, target, string(action)))
+	case ToolMigrate:
+		switch action {
+		case ActionUp:
+			err = add(toolArgs(setup, target, "up"))
+		case ActionDown:
+			err = add(toolArgs(setup, target, "down", "1"))
+		case ActionRedo:
+			if err = add(toolArgs(setup, target, "down", "1")); err == nil {
+				err = add(toolArgs(setup, target, "up", "1"))
+			}
+		}
+	case ToolAtlas:
+		switch action {
+		case ActionUp:
+			err = add(atlasArgs(setup, target, "migrate", "apply"))
+		case ActionDown:
+			err = add(atlasArgs(setup, target, "migrate", "down"))
+		case ActionRedo:
+			if err = add(atlasArgs(setup, target, "migrate", "down")); err == nil {
+				err = add(atlasArgs(setup, target, "migrate", "apply", "1"))
+			}
+		}
+	case ToolPrisma:
+		if action != ActionUp {
+			return nil, fmt.Errorf("prisma has no %s migrations (prisma migrate reset drops the database)", action)
+		}
+		steps = append(steps, []string{"migrate", "deploy"})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("unknown migration action: %s", action)
+	}
+	return steps, nil
+}
+
+// toolArgs returns the arguments of a goose or golang-migrate command
+func toolArgs(setup Setup, target Target, command ...string) ([]string, error) {
+	switch setup.Tool {
+	case ToolGoose:
+		driver, dsn, err := gooseDSN(target)
+		if err != nil {
+			return nil, err
+		}
+		return append([]string{"-dir", setup.Dir, driver, dsn}, command...), nil
+	case ToolMigrate:
+		url, err := migrateURL(target)
+		if err != nil {
+			return nil, err
+		}
+		return append([]string{"-path", setup.Dir, "-database", url}, command...), nil
+	}
+	return nil, fmt.Errorf("unsupported migration tool: %s", setup.Tool)
+}
+
+// atlasArgs returns the arguments of an atlas command on the migration
+// directory and the database
+func atlasArgs(setup Setup, target Target, command ...string) ([]string, error) {
+	url, err := atlasURL(target)
+	if err != nil {
+		return nil, err
+	}
+	return append(command, "--dir", "file://"+filepath.ToSlash(setup.Dir), "--url", url), nil
+}
+
+// listMigrations lists the migration files of a setup, oldest first
+func listMigrations(setup Setup) ([]Migration, error) {
+	entries, err := os.ReadDir(setup.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // No migration created yet
+		}
+		return nil, err
+	}
+
+	var migrations []Migration
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		name := e.Name()
+		var m Migration
+		switch setup.Tool {
+		case ToolPrisma:
+			match := prismaDir.FindStringSubmatch(name)
+			if !e.IsDir() || match == nil {
+				continue
+			}
+			m = Migration{Version: match[1], Name: match[2]}
+		case ToolAtlas:
+			match := atlasFile.FindStringSubmatch(name)
+			if e.IsDir() || match == nil {
+				continue
+			}
+			m = Migration{Version: match[1], Name: match[2]}
+		default:
+			match := versionedFile.FindStringSubmatch(name)
+			if e.IsDir() || match == nil || strings.HasSuffix(name, ".down.sql") {
+				continue
+			}
+			if setup.Tool == ToolMigrate && match[3] == "" {
+				continue // Not an up migration
+			}
+			m = Migration{Version: normalizeVersion(match[1]), Name: match[2]}
+		}
+		if !seen[m.Version] {
+			seen[m.Version] = true
+			migrations = append(migrations, m)
+		}
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return compareVersions(migrations[i].Version, migrations[j].Version) < 0
+	})
+	return migrations, nil
+}
+
+// prismaPending reads the migrations listed as not applied by prisma
+// migrate status. The command fails when migrations are pending: that is
+// not an error.
+func prismaPending(output []string, err error) (map[string]bool, error) {
+	pending := make(map[string]bool)
+	listing := false
+	for _, line := range output {
+		line = strings.TrimSpace(line)
+		if prismaPendingAt.MatchString(line) {
+			listing = true
+			continue
+		}
+		if listing {
+			if line == "" {
+				if len(pending) > 0 {
+					listing = false
+				}
+				continue
+			}
+			pending[line] = true
+		}
+	}
+	if len(pending) > 0 {
+		return pending, nil
+	}
+	return pending, err
+}
+
+// normalizeVersion drops the leading zeros of a numeric version (00002 = 2)
+func normalizeVersion(version string) string {
+	if n, err := strconv.ParseInt(version, 10, 64); err == nil {
+		return strconv.FormatInt(n, 10)
+	}
+	return version
+}
+
+// compareVersions compares two versions numerically, else as strings
+func compareVersions(a, b string) int {
+	na, errA := strconv.ParseInt(a, 10, 64)
+	nb, errB := strconv.ParseInt(b, 10, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case na < nb:
+			return -1
+		case na > nb:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// lastLines returns the end of a failed command's output, as its error
+func lastLines(output []string, err error) string {
+	var lines []string
+	for i := len(output) - 1; i >= 0 && len(lines) < 3; i-- {
+		if line := strings.TrimSpace(output[i]); line != "" {
+			lines = append([]string{line}, lines...)
+		}
+	}
+	if len(lines) == 0 {
+		return err.Error()
+	}
+	return strings.Join(lines, " ")
+}
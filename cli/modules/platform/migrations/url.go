@@ -0,0 +1,111 @@
+package migrations
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Database types of a target
+const (
+	typePostgres = "postgres"
+	typeMySQL    = "mysql"
+	typeSQLite   = "sqlite"
+)
+
+// databaseType returns the type of a target, from its URL if not set
+func (t Target) databaseType() string {
+	switch t.Type {
+	case typePostgres, typeMySQL, typeSQLite:
+		return t.Type
+	}
+	switch {
+	case strings.HasPrefix(t.URL, "postgres://"), strings.HasPrefix(t.URL, "postgresql://"):
+		return typePostgres
+	case strings.HasPrefix(t.URL, "mysql://"):
+		return typeMySQL
+	case strings.HasPrefix(t.URL, "sqlite"), strings.HasSuffix(t.URL, ".db"), strings.HasSuffix(t.URL, ".sqlite"):
+		return typeSQLite
+	}
+	return ""
+}
+
+// sqlitePath returns the file of a SQLite URL
+func sqlitePath(dbURL string) string {
+	for _, prefix := range []string{"sqlite3://", "sqlite://", "file:"} {
+		dbURL = strings.TrimPrefix(dbURL, prefix)
+	}
+	return dbURL
+}
+
+// mysqlDSN converts a mysql:// URL to the Go driver's DSN:
+// user:password@tcp(host:port)/database?params
+func mysqlDSN(dbURL string) (string, error) {
+	u, err := url.Parse(dbURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid MySQL URL: %w", err)
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host += ":3306"
+	}
+	dsn := ""
+	if u.User != nil {
+		dsn = u.User.String() + "@"
+	}
+	dsn += "tcp(" + host + ")" + u.Path
+	if u.RawQuery != "" {
+		dsn += "?" + u.RawQuery
+	}
+	return dsn, nil
+}
+
+// gooseDSN returns the goose driver and connection string of a target
+func gooseDSN(t Target) (string, string, error) {
+	switch t.databaseType() {
+	case typePostgres:
+		return "postgres", t.URL, nil
+	case typeMySQL:
+		dsn, err := mysqlDSN(t.URL)
+		return "mysql", dsn, err
+	case typeSQLite:
+		return "sqlite3", sqlitePath(t.URL), nil
+	}
+	return "", "", fmt.Errorf("unsupported database: %s", t.URL)
+}
+
+// migrateURL returns the golang-migrate URL of a target
+func migrateURL(t Target) (string, error) {
+	switch t.databaseType() {
+	case typePostgres:
+		return t.URL, nil
+	case typeMySQL:
+		dsn, err := mysqlDSN(t.URL)
+		return "mysql://" + dsn, err
+	case typeSQLite:
+		return "sqlite3://" + sqlitePath(t.URL), nil
+	}
+	return "", fmt.Errorf("unsupported database: %s", t.URL)
+}
+
+// atlasURL returns the Atlas URL of a target
+func atlasURL(t Target) (string, error) {
+	switch t.databaseType() {
+	case typePostgres, typeMySQL:
+		return t.URL, nil
+	case typeSQLite:
+		return "sqlite://" + sqlitePath(t.URL), nil
+	}
+	return "", fmt.Errorf("unsupported database: %s", t.URL)
+}
+
+// prismaURL returns the datasource URL Prisma expects for a target
+func prismaURL(t Target) (string, error) {
+	switch t.databaseType() {
+	case typePostgres, typeMySQL:
+		return t.URL, nil
+	case typeSQLite:
+		return "file:" + sqlitePath(t.URL), nil
+	}
+	return "", fmt.Errorf("unsupported database: %s", t.URL)
+}
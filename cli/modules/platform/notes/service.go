@@ -0,0 +1,82 @@
+package notes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Service stores per-project markdown notes.
+// Layout: <dir>/<project-id>.md
+type Service struct {
+	dir string
+}
+
+// Note is the content of a project notes file
+type Note struct {
+	Content  string
+	Modified time.Time
+	Size     int64
+}
+
+// NewService creates a notes service storing its files in dir
+func NewService(dir string) *Service {
+	return &Service{dir: dir}
+}
+
+// Path returns the notes file of a project (it may not exist yet)
+func (s *Service) Path(projectID string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, projectID)
+	if strings.Trim(name, ".") == "" {
+		name = "_"
+	}
+	return filepath.Join(s.dir, name+".md")
+}
+
+// Load reads the notes of a project, nil if there are none
+func (s *Service) Load(projectID string) (*Note, error) {
+	if s.dir == "" {
+		return nil, nil
+	}
+	path := s.Path(projectID)
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notes: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notes: %w", err)
+	}
+	return &Note{Content: string(data), Modified: info.ModTime(), Size: info.Size()}, nil
+}
+
+// Ensure creates the notes file of a project from a template if it does not
+// exist yet, and returns its path
+func (s *Service) Ensure(projectID, projectName string) (string, error) {
+	if s.dir == "" {
+		return "", fmt.Errorf("notes directory not available")
+	}
+	path := s.Path(projectID)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create notes directory: %w", err)
+	}
+	template := fmt.Sprintf("# %s\n\n", projectName)
+	if err := os.WriteFile(path, []byte(template), 0644); err != nil {
+		return "", fmt.Errorf("failed to create notes: %w", err)
+	}
+	return path, nil
+}
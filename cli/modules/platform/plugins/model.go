@@ -0,0 +1,143 @@
+package plugins
+
+import (
+	"time"
+)
+
+// ProtocolVersion is the version of the plugin protocol sent with each request
+const ProtocolVersion = 1
+
+// Methods of the plugin protocol.
+// A plugin is run once per request: it reads one JSON Request on stdin and
+// writes one JSON Response on stdout. A non-zero exit code is an error.
+const (
+	MethodDescribe = "describe" // Returns the Manifest
+	MethodView     = "view"     // Returns the items of a view
+	MethodData     = "data"     // Returns the items of a data provider for a project
+	MethodAction   = "action"   // Runs an action, returns a message
+)
+
+// Item statuses (colors in the UI)
+const (
+	StatusOK      = "ok"
+	StatusWarning = "warning"
+	StatusError   = "error"
+	StatusInfo    = "info"
+)
+
+// Manifest describes what a plugin provides (response to "describe")
+type Manifest struct {
+	Title       string         `json:"title,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Views       []ViewSpec     `json:"views,omitempty"`
+	Actions     []ActionSpec   `json:"actions,omitempty"`
+	Providers   []ProviderSpec `json:"providers,omitempty"`
+}
+
+// ViewSpec is a custom panel shown in the Plugins view
+type ViewSpec struct {
+	ID    string `json:"id"`
+	Title string `json:"title,omitempty"`
+}
+
+// ActionSpec is a command the user can run from the UI or the CLI
+type ActionSpec struct {
+	ID          string `json:"id"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Project     bool   `json:"project,omitempty"` // Runs on a project (project info is sent)
+	Confirm     bool   `json:"confirm,omitempty"` // Ask for confirmation before running
+}
+
+// ProviderSpec is a data provider adding fields to the project details
+type ProviderSpec struct {
+	ID    string `json:"id"`
+	Title string `json:"title,omitempty"`
+}
+
+// ProjectInfo is the project sent with project-scoped requests
+type ProjectInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// Request is the JSON document written to the plugin stdin
+type Request struct {
+	Version  int          `json:"version"`
+	Method   string       `json:"method"`
+	View     string       `json:"view,omitempty"`
+	Action   string       `json:"action,omitempty"`
+	Provider string       `json:"provider,omitempty"`
+	Project  *ProjectInfo `json:"project,omitempty"`
+}
+
+// Item is a line of a view or a data provider
+type Item struct {
+	Label  string `json:"label"`
+	Value  string `json:"value,omitempty"`
+	Status string `json:"status,omitempty"` // ok, warning, error, info
+	Detail string `json:"detail,omitempty"`
+}
+
+// Response is the JSON document read from the plugin stdout
+type Response struct {
+	Manifest
+	Items   []Item `json:"items,omitempty"`   // view, data
+	Message string `json:"message,omitempty"` // action
+	Error   string `json:"error,omitempty"`   // Any method
+}
+
+// Plugin is a discovered plugin executable
+type Plugin struct {
+	Name     string   `json:"name"` // File name without extension
+	Path     string   `json:"path"`
+	Manifest Manifest `json:"manifest"`
+	Error    string   `json:"error,omitempty"` // Describe failure
+}
+
+// Title returns the display name of the plugin
+func (p Plugin) Title() string {
+	if p.Manifest.Title != "" {
+		return p.Manifest.Title
+	}
+	return p.Name
+}
+
+// Action returns an action of the plugin by ID
+func (p Plugin) Action(id string) (ActionSpec, bool) {
+	for _, a := range p.Manifest.Actions {
+		if a.ID == id {
+			return a, true
+		}
+	}
+	return ActionSpec{}, false
+}
+
+// ViewResult is the last content of a plugin view
+type ViewResult struct {
+	Items     []Item    `json:"items"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DataGroup is the content of a data provider for a project
+type DataGroup struct {
+	Plugin   string `json:"plugin"`
+	Provider string `json:"provider"`
+	Title    string `json:"title"`
+	Items    []Item `json:"items"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ActionResult is the outcome of an action run
+type ActionResult struct {
+	Plugin     string    `json:"plugin"`
+	Action     string    `json:"action"`
+	Title      string    `json:"title"`
+	ProjectID  string    `json:"project_id,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
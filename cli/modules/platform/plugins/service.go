@@ -0,0 +1,358 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxResults is the number of action results kept
+const maxResults = 20
+
+// maxOutput is the maximum size of a plugin response (bytes)
+const maxOutput = 1 << 20
+
+// Service discovers the plugin executables of a directory and runs their
+// requests. View and data provider contents are cached between refreshes.
+type Service struct {
+	dir           string
+	disabled      map[string]bool
+	timeout       time.Duration // describe, view, data
+	actionTimeout time.Duration
+
+	mu      sync.RWMutex
+	plugins []Plugin
+	views   map[string]ViewResult  // Key: plugin/view
+	data    map[string][]DataGroup // Key: project ID
+	results []ActionResult         // Most recent last
+	running map[string]bool        // Key: plugin/action
+}
+
+// NewService creates a plugin service for the executables in dir
+func NewService(dir string, disabled []string, timeout, actionTimeout time.Duration) *Service {
+	s := &Service{
+		dir:           dir,
+		disabled:      make(map[string]bool),
+		timeout:       timeout,
+		actionTimeout: actionTimeout,
+		views:         make(map[string]ViewResult),
+		data:          make(map[string][]DataGroup),
+		running:       make(map[string]bool),
+	}
+	for _, name := range disabled {
+		s.disabled[name] = true
+	}
+	return s
+}
+
+// Dir returns the plugins directory
+func (s *Service) Dir() string {
+	return s.dir
+}
+
+// Discover scans the plugins directory and asks each executable for its
+// manifest. A missing directory means no plugins.
+func (s *Service) Discover(ctx context.Context) error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	var found []Plugin
+	for _, entry := range entries {
+		path := filepath.Join(s.dir, entry.Name())
+		if !isExecutable(path) {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if s.disabled[name] {
+			continue
+		}
+
+		plugin := Plugin{Name: name, Path: path}
+		resp, err := s.call(ctx, path, Request{Method: MethodDescribe}, s.timeout)
+		if err != nil {
+			plugin.Error = err.Error()
+		} else {
+			plugin.Manifest = resp.Manifest
+		}
+		found = append(found, plugin)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Name < found[j].Name })
+
+	s.mu.Lock()
+	s.plugins = found
+	s.mu.Unlock()
+	return nil
+}
+
+// GetPlugins returns the discovered plugins
+func (s *Service) GetPlugins() []Plugin {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Plugin, len(s.plugins))
+	copy(result, s.plugins)
+	return result
+}
+
+// GetPlugin returns a plugin by name
+func (s *Service) GetPlugin(name string) (Plugin, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, p := range s.plugins {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Plugin{}, false
+}
+
+// RefreshViews fetches the content of every plugin view
+func (s *Service) RefreshViews(ctx context.Context) {
+	for _, p := range s.GetPlugins() {
+		if p.Error != "" {
+			continue
+		}
+		for _, v := range p.Manifest.Views {
+			items, err := s.View(ctx, p.Name, v.ID)
+			result := ViewResult{Items: items, UpdatedAt: time.Now()}
+			if err != nil {
+				result.Error = err.Error()
+			}
+			s.mu.Lock()
+			s.views[p.Name+"/"+v.ID] = result
+			s.mu.Unlock()
+		}
+	}
+}
+
+// GetView returns the cached content of a plugin view
+func (s *Service) GetView(plugin, view string) (ViewResult, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result, ok := s.views[plugin+"/"+view]
+	return result, ok
+}
+
+// View fetches the items of a plugin view
+func (s *Service) View(ctx context.Context, plugin, view string) ([]Item, error) {
+	p, ok := s.GetPlugin(plugin)
+	if !ok {
+		return nil, fmt.Errorf("unknown plugin: %s", plugin)
+	}
+	resp, err := s.call(ctx, p.Path, Request{Method: MethodView, View: view}, s.timeout)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// RefreshData runs every data provider for each project
+func (s *Service) RefreshData(ctx context.Context, projects []ProjectInfo) {
+	data := make(map[string][]DataGroup)
+	for _, p := range s.GetPlugins() {
+		if p.Error != "" {
+			continue
+		}
+		for _, provider := range p.Manifest.Providers {
+			title := provider.Title
+			if title == "" {
+				title = p.Title()
+			}
+			for _, proj := range projects {
+				proj := proj
+				group := DataGroup{Plugin: p.Name, Provider: provider.ID, Title: title}
+				resp, err := s.call(ctx, p.Path, Request{Method: MethodData, Provider: provider.ID, Project: &proj}, s.timeout)
+				if err != nil {
+					group.Error = err.Error()
+				} else if len(resp.Items) == 0 {
+					continue // Nothing to show for this project
+				} else {
+					group.Items = resp.Items
+				}
+				data[proj.ID] = append(data[proj.ID], group)
+			}
+		}
+	}
+
+	s.mu.Lock()
+	s.data = data
+	s.mu.Unlock()
+}
+
+// GetProjectData returns the cached data provider contents of a project
+func (s *Service) GetProjectData(projectID string) []DataGroup {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data[projectID]
+}
+
+// RunAction runs a plugin action and records its result.
+// project is required for project-scoped actions.
+func (s *Service) RunAction(ctx context.Context, plugin, action string, project *ProjectInfo) ActionResult {
+	result := ActionResult{Plugin: plugin, Action: action, Title: action, StartedAt: time.Now()}
+	if project != nil {
+		result.ProjectID = project.ID
+	}
+
+	key := plugin + "/" + action
+	s.mu.Lock()
+	s.running[key] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, key)
+		s.mu.Unlock()
+	}()
+
+	err := func() error {
+		p, ok := s.GetPlugin(plugin)
+		if !ok {
+			return fmt.Errorf("unknown plugin: %s", plugin)
+		}
+		spec, ok := p.Action(action)
+		if !ok {
+			return fmt.Errorf("plugin %s has no action %s", plugin, action)
+		}
+		if spec.Title != "" {
+			result.Title = spec.Title
+		}
+		if spec.Project && project == nil {
+			return fmt.Errorf("action %s requires a project", action)
+		}
+		resp, err := s.call(ctx, p.Path, Request{Method: MethodAction, Action: action, Project: project}, s.actionTimeout)
+		if err != nil {
+			return err
+		}
+		result.Message = resp.Message
+		return nil
+	}()
+	if err != nil {
+		result.Error = err.Error()
+	}
+	result.FinishedAt = time.Now()
+
+	s.mu.Lock()
+	s.results = append(s.results, result)
+	if len(s.results) > maxResults {
+		s.results = s.results[len(s.results)-maxResults:]
+	}
+	s.mu.Unlock()
+	return result
+}
+
+// IsRunning returns true if the action of the plugin is running
+func (s *Service) IsRunning(plugin, action string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.running[plugin+"/"+action]
+}
+
+// GetRunning returns the running actions ("plugin/action")
+func (s *Service) GetRunning() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]string, 0, len(s.running))
+	for key := range s.running {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// GetResults returns the recent action results, most recent last
+func (s *Service) GetResults() []ActionResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]ActionResult, len(s.results))
+	copy(result, s.results)
+	return result
+}
+
+// call runs a plugin executable with a request and decodes its response
+func (s *Service) call(ctx context.Context, path string, req Request, timeout time.Duration) (*Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req.Version = ProtocolVersion
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Dir = s.dir
+	cmd.Env = append(os.Environ(), fmt.Sprintf("DEVTRACK_PLUGIN_PROTOCOL=%d", ProtocolVersion))
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &limitedBuffer{buf: &stdout, limit: maxOutput}
+	cmd.Stderr = &limitedBuffer{buf: &stderr, limit: maxOutput}
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("timed out after %s", timeout)
+		}
+		// The last line of stderr explains the failure better than the exit code
+		if lines := strings.Split(strings.TrimSpace(stderr.String()), "\n"); lines[len(lines)-1] != "" {
+			return nil, errors.New(strings.TrimSpace(lines[len(lines)-1]))
+		}
+		return nil, err
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return &resp, nil
+}
+
+// limitedBuffer drops the output written past its limit
+type limitedBuffer struct {
+	buf   *bytes.Buffer
+	limit int
+}
+
+// Write implements io.Writer
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room > 0 {
+		if len(p) > room {
+			b.buf.Write(p[:room])
+		} else {
+			b.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+// isExecutable returns true if path is a file the plugin service can run
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".exe", ".bat", ".cmd":
+			return true
+		}
+		return false
+	}
+	return info.Mode()&0111 != 0
+}
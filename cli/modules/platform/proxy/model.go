@@ -0,0 +1,28 @@
+package proxy
+
+import (
+	"time"
+)
+
+// Resolver returns the upstream URL of a component (e.g.
+// http://localhost:3000), or why it cannot be reached (not running, no port)
+type Resolver func(projectID, component string) (string, error)
+
+// RouteStatus is the live status of a route of the proxy
+type RouteStatus struct {
+	Name        string        `json:"name"`                   // Host and path prefix, e.g. app.localhost/api
+	Target      string        `json:"target"`                 // project/component, or the fixed URL
+	Upstream    string        `json:"upstream,omitempty"`     // Resolved URL, empty when unreachable
+	Error       string        `json:"error,omitempty"`        // Why the upstream is unreachable
+	Requests    int64         `json:"requests"`               // Forwarded since the proxy started
+	Failures    int64         `json:"failures"`               // 5xx and upstream errors
+	LastStatus  int           `json:"last_status,omitempty"`  // HTTP status of the last request (502 = upstream down)
+	LastLatency time.Duration `json:"last_latency,omitempty"` // Duration of the last request
+	LastRequest time.Time     `json:"last_request,omitempty"`
+	LastPath    string        `json:"last_path,omitempty"` // Method and path of the last request
+}
+
+// Up returns true if the upstream of the route is reachable
+func (s RouteStatus) Up() bool {
+	return s.Upstream != "" && s.Error == ""
+}
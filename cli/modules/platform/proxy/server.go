@@ -0,0 +1,249 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+)
+
+// Server is the built-in reverse proxy, listening on localhost
+type Server struct {
+	resolve   Resolver
+	transport *http.Transport
+
+	mu        sync.Mutex
+	server    *http.Server        // nil = stopped
+	port      int                 // Listening port
+	routes    []config.ProxyRoute // Routes of the running proxy
+	stats     []RouteStatus       // Counters per route
+	onRequest func()              // Called after each request (live status)
+}
+
+// NewServer creates a stopped proxy forwarding to the upstreams resolve
+// returns for the component routes
+func NewServer(resolve Resolver) *Server {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// The upstreams are local dev servers, with self-signed certificates
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	return &Server{
+		resolve:   resolve,
+		transport: transport,
+	}
+}
+
+// SetRequestHandler sets the function called after each forwarded request
+func (s *Server) SetRequestHandler(fn func()) {
+	s.mu.Lock()
+	s.onRequest = fn
+	s.mu.Unlock()
+}
+
+// Start listens on localhost at the configured port and forwards the
+// requests matching the routes
+func (s *Server) Start(cfg *config.ProxyConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server != nil {
+		return fmt.Errorf("proxy already running on port %d", s.port)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", cfg.Port))
+	if err != nil {
+		return fmt.Errorf("proxy: %w", err)
+	}
+	s.port = cfg.Port
+	s.routes = append([]config.ProxyRoute(nil), cfg.Routes...)
+	s.stats = make([]RouteStatus, len(s.routes))
+	for i, route := range s.routes {
+		s.stats[i] = RouteStatus{Name: route.Name(), Target: routeTarget(route)}
+	}
+	server := &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	s.server = server
+	go server.Serve(listener)
+	return nil
+}
+
+// Stop closes the proxy and its connections (websockets included)
+func (s *Server) Stop() error {
+	s.mu.Lock()
+	server := s.server
+	s.server = nil
+	s.mu.Unlock()
+	if server == nil {
+		return nil
+	}
+	return server.Close()
+}
+
+// URL returns the entrypoint of the running proxy, empty when stopped
+func (s *Server) URL() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server == nil {
+		return ""
+	}
+	return fmt.Sprintf("http://localhost:%d", s.port)
+}
+
+// Statuses returns the routes of the running proxy with their counters and
+// their upstream resolved now
+func (s *Server) Statuses() []RouteStatus {
+	s.mu.Lock()
+	routes := s.routes
+	statuses := append([]RouteStatus(nil), s.stats...)
+	s.mu.Unlock()
+
+	for i := range statuses {
+		upstream, err := s.upstream(routes[i])
+		statuses[i].Upstream = upstream
+		if err != nil {
+			statuses[i].Error = err.Error()
+		}
+	}
+	return statuses
+}
+
+// ServeHTTP forwards a request to the upstream of its route
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	index := matchRoute(s.routes, r)
+	var route config.ProxyRoute
+	if index >= 0 {
+		route = s.routes[index]
+	}
+	routes := s.routes
+	s.mu.Unlock()
+
+	if index < 0 {
+		notFound(w, r, routes)
+		return
+	}
+
+	start := time.Now()
+	upstream, err := s.upstream(route)
+	var target *url.URL
+	if err == nil {
+		target, err = url.Parse(upstream)
+	}
+	if err != nil {
+		s.record(index, r, http.StatusBadGateway, start)
+		http.Error(w, fmt.Sprintf("DevTrack proxy: %s → %s: %v", route.Name(), routeTarget(route), err), http.StatusBadGateway)
+		return
+	}
+
+	forward := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			if route.StripPrefix && route.Path != "" {
+				path := strings.TrimPrefix(pr.Out.URL.Path, strings.TrimSuffix(route.Path, "/"))
+				if !strings.HasPrefix(path, "/") {
+					path = "/" + path
+				}
+				pr.Out.URL.Path, pr.Out.URL.RawPath = path, ""
+			}
+			pr.SetURL(target)
+			pr.SetXForwarded()
+		},
+		Transport:     s.transport,
+		FlushInterval: -1, // Server-sent events and streamed responses as they come
+		ModifyResponse: func(resp *http.Response) error {
+			s.record(index, r, resp.StatusCode, start)
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			s.record(index, r, http.StatusBadGateway, start)
+			http.Error(w, fmt.Sprintf("DevTrack proxy: %s → %s: %v", route.Name(), upstream, err), http.StatusBadGateway)
+		},
+	}
+	forward.ServeHTTP(w, r)
+}
+
+// upstream returns the URL a route forwards to
+func (s *Server) upstream(route config.ProxyRoute) (string, error) {
+	if route.Target != "" {
+		return strings.TrimSuffix(route.Target, "/"), nil
+	}
+	return s.resolve(route.Project, route.Component)
+}
+
+// record counts a request of a route, then notifies the request handler
+func (s *Server) record(index int, r *http.Request, status int, start time.Time) {
+	s.mu.Lock()
+	if index >= len(s.stats) {
+		s.mu.Unlock()
+		return // Restarted with other routes meanwhile
+	}
+	stat := &s.stats[index]
+	stat.Requests++
+	if status >= 500 {
+		stat.Failures++
+	}
+	stat.LastStatus = status
+	stat.LastLatency = time.Since(start)
+	stat.LastRequest = time.Now()
+	stat.LastPath = r.Method + " " + r.URL.RequestURI()
+	onRequest := s.onRequest
+	s.mu.Unlock()
+
+	if onRequest != nil {
+		onRequest()
+	}
+}
+
+// matchRoute returns the index of the most specific route of a request:
+// a route of its host before a route of any host, then the longest path
+// prefix. -1 if none matches.
+func matchRoute(routes []config.ProxyRoute, r *http.Request) int {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	best, bestScore := -1, -1
+	for i, route := range routes {
+		if route.Host != "" && !strings.EqualFold(route.Host, host) {
+			continue
+		}
+		prefix := strings.TrimSuffix(route.Path, "/")
+		if prefix != "" && r.URL.Path != prefix && !strings.HasPrefix(r.URL.Path, prefix+"/") {
+			continue
+		}
+		score := len(prefix)
+		if route.Host != "" {
+			score += 1 << 16
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+// notFound answers a request matching no route with the list of the routes
+func notFound(w http.ResponseWriter, r *http.Request, routes []config.ProxyRoute) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "DevTrack proxy: no route for %s%s\n\nRoutes:\n", r.Host, r.URL.Path)
+	for _, route := range routes {
+		fmt.Fprintf(&b, "  %-30s → %s\n", route.Name(), routeTarget(route))
+	}
+	if len(routes) == 0 {
+		b.WriteString("  none (settings proxy.routes in the configuration)\n")
+	}
+	http.Error(w, b.String(), http.StatusNotFound)
+}
+
+// routeTarget describes where a route forwards: project/component, or the
+// fixed URL
+func routeTarget(route config.ProxyRoute) string {
+	if route.Target != "" {
+		return route.Target
+	}
+	return route.Project + "/" + route.Component
+}
@@ -0,0 +1,49 @@
+package recording
+
+import "time"
+
+// Command is the hidden command of the executable tmux pipe-pane runs to
+// record the output of a terminal: __record <file> <width> <height> <title>
+const Command = "__record"
+
+// Extension of the recording files (asciicast v2, playable with asciinema)
+const Extension = ".cast"
+
+// Header is the first line of a recording
+type Header struct {
+	Version   int    `json:"version"` // Always 2
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp"` // Unix start time
+	Title     string `json:"title,omitempty"`
+}
+
+// Event is terminal output at a time of a recording
+type Event struct {
+	Time float64 // Seconds since the start
+	Data string
+}
+
+// Cast is a loaded recording
+type Cast struct {
+	Header Header
+	Events []Event
+}
+
+// Duration returns the time of the last output
+func (c *Cast) Duration() time.Duration {
+	if len(c.Events) == 0 {
+		return 0
+	}
+	return time.Duration(c.Events[len(c.Events)-1].Time * float64(time.Second))
+}
+
+// Recording is a recording file
+type Recording struct {
+	Path    string
+	Title   string
+	Started time.Time
+	Size    int64
+	Width   int
+	Height  int
+}
@@ -0,0 +1,198 @@
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Record writes the terminal output read from r (a tmux pipe-pane) to a new
+// recording file until r is closed
+func Record(r io.Reader, path string, width, height int, title string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create recordings directory: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create recording: %w", err)
+	}
+	defer f.Close()
+
+	start := time.Now()
+	w := bufio.NewWriter(f)
+	header, _ := json.Marshal(Header{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: start.Unix(),
+		Title:     title,
+	})
+	w.Write(header)
+	w.WriteByte('\n')
+	w.Flush()
+
+	buf := make([]byte, 32*1024)
+	var pending []byte // Incomplete UTF-8 sequence of the previous read
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			data := append(pending, buf[:n]...)
+			cut := validPrefix(data)
+			pending = append([]byte(nil), data[cut:]...)
+			if cut > 0 {
+				writeEvent(w, time.Since(start), string(data[:cut]))
+				w.Flush() // Keeps the file usable if the recorder is killed
+			}
+		}
+		if readErr != nil {
+			if len(pending) > 0 {
+				writeEvent(w, time.Since(start), strings.ToValidUTF8(string(pending), "�"))
+			}
+			if readErr == io.EOF {
+				readErr = nil
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			return readErr
+		}
+	}
+}
+
+// validPrefix returns the length of data without a trailing incomplete UTF-8
+// sequence (invalid bytes elsewhere are kept)
+func validPrefix(data []byte) int {
+	// A rune is at most 4 bytes: only the last 3 can start an incomplete one
+	for i := len(data) - 1; i >= 0 && i >= len(data)-3; i-- {
+		if !utf8.RuneStart(data[i]) {
+			continue
+		}
+		if !utf8.FullRune(data[i:]) {
+			return i
+		}
+		break
+	}
+	return len(data)
+}
+
+// writeEvent writes an output event line
+func writeEvent(w *bufio.Writer, elapsed time.Duration, data string) {
+	line, _ := json.Marshal([]interface{}{elapsed.Seconds(), "o", data})
+	w.Write(line)
+	w.WriteByte('\n')
+}
+
+// Load reads a recording file
+func Load(path string) (*Cast, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("empty recording: %s", filepath.Base(path))
+	}
+	cast := &Cast{}
+	if err := json.Unmarshal(scanner.Bytes(), &cast.Header); err != nil || cast.Header.Version != 2 {
+		return nil, fmt.Errorf("not an asciicast v2 recording: %s", filepath.Base(path))
+	}
+
+	for scanner.Scan() {
+		var fields []interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &fields); err != nil || len(fields) < 3 {
+			continue // Truncated last line of an interrupted recording
+		}
+		t, _ := fields[0].(float64)
+		kind, _ := fields[1].(string)
+		data, _ := fields[2].(string)
+		if kind != "o" {
+			continue
+		}
+		cast.Events = append(cast.Events, Event{Time: t, Data: data})
+	}
+	return cast, scanner.Err()
+}
+
+// List returns the recordings of a directory, most recent first
+func List(dir string) ([]Recording, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var recordings []Recording
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != Extension {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		rec := Recording{
+			Path:    path,
+			Title:   strings.TrimSuffix(entry.Name(), Extension),
+			Started: info.ModTime(),
+			Size:    info.Size(),
+		}
+		if header, err := readHeader(path); err == nil {
+			if header.Title != "" {
+				rec.Title = header.Title
+			}
+			rec.Started = time.Unix(header.Timestamp, 0)
+			rec.Width, rec.Height = header.Width, header.Height
+		}
+		recordings = append(recordings, rec)
+	}
+
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i].Started.After(recordings[j].Started)
+	})
+	return recordings, nil
+}
+
+// readHeader reads the header line of a recording
+func readHeader(path string) (Header, error) {
+	var header Header
+	f, err := os.Open(path)
+	if err != nil {
+		return header, err
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil && len(line) == 0 {
+		return header, err
+	}
+	err = json.Unmarshal(line, &header)
+	return header, err
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// FileName returns the file name of a recording started at t
+func FileName(title string, t time.Time) string {
+	name := strings.Trim(unsafeFileChars.ReplaceAllString(title, "-"), "-.")
+	if len(name) > 60 {
+		name = name[:60]
+	}
+	if name == "" {
+		name = "terminal"
+	}
+	return t.Format("20060102-150405") + "-" + name + Extension
+}
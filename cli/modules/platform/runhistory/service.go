@@ -0,0 +1,70 @@
+// Package runhistory persists the runs of the managed processes (start,
+// duration, exit reason, peak memory) so intermittent failures of a
+// component can be spotted across DevTrack restarts.
+package runhistory
+
+import (
+	"csd-devtrack/cli/modules/core/processes"
+	"csd-devtrack/cli/modules/platform/jsonl"
+)
+
+// Record is a finished run of a process
+type Record struct {
+	ProcessID string `json:"process_id"` // project/component
+	processes.Termination
+}
+
+// Service appends finished runs to a JSON Lines file, keeping the last
+// perProcess runs of each process. It implements processes.TerminationStore.
+type Service struct {
+	store *jsonl.Store[Record]
+}
+
+// NewService creates a run history writing to file
+func NewService(file string, perProcess int) *Service {
+	var compact func([]Record) []Record
+	if perProcess > 0 {
+		compact = func(records []Record) []Record {
+			return keepLastRuns(records, perProcess)
+		}
+	}
+	return &Service{store: jsonl.NewStore(file, perProcess*10, compact)}
+}
+
+// Append records a finished run
+func (s *Service) Append(processID string, t processes.Termination) error {
+	return s.store.Append(Record{ProcessID: processID, Termination: t})
+}
+
+// Load returns the recorded runs of each process, oldest first
+func (s *Service) Load() map[string][]processes.Termination {
+	runs := make(map[string][]processes.Termination)
+	for _, r := range s.store.Load() {
+		if r.ProcessID != "" {
+			runs[r.ProcessID] = append(runs[r.ProcessID], r.Termination)
+		}
+	}
+	return runs
+}
+
+// keepLastRuns drops the runs beyond the last perProcess ones of each
+// process, and the invalid records
+func keepLastRuns(records []Record, perProcess int) []Record {
+	kept := make([]bool, len(records))
+	counts := make(map[string]int)
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].ProcessID == "" {
+			continue
+		}
+		counts[records[i].ProcessID]++
+		kept[i] = counts[records[i].ProcessID] <= perProcess
+	}
+
+	var result []Record
+	for i, r := range records {
+		if kept[i] {
+			result = append(result, r)
+		}
+	}
+	return result
+}
@@ -0,0 +1,101 @@
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Keyring entry of the key
+const (
+	keyringService = "csd-devtrack"
+	keyringAccount = "secrets-key"
+)
+
+// LoadKeyringKey reads the key from the OS keyring: the Secret Service
+// (secret-tool) on Linux, the Keychain on macOS. A missing entry is created
+// with a new key when create is set.
+func LoadKeyringKey(create bool) (*Key, error) {
+	data, found, err := keyringLookup()
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if !create {
+			return nil, fmt.Errorf("no DevTrack key in the keyring")
+		}
+		key, err := NewKey()
+		if err != nil {
+			return nil, err
+		}
+		return key, StoreKeyringKey(key)
+	}
+	return decodeKey(data)
+}
+
+// StoreKeyringKey writes the key to the OS keyring, replacing the previous one
+func StoreKeyringKey(key *Key) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.Command("secret-tool", "store", "--label=DevTrack secrets key",
+			"service", keyringService, "account", keyringAccount)
+		cmd.Stdin = strings.NewReader(encodeKey(key))
+	case "darwin":
+		// The key must not be a command-line argument (readable with ps):
+		// security -i reads the command from stdin. A bare -w would prompt
+		// on the terminal of the TUI rather than read stdin. -U updates the
+		// existing entry. Errors of the command are only reported on stderr.
+		cmd = exec.Command("security", "-i")
+		cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %q -a %q -w %q\n",
+			keyringService, keyringAccount, encodeKey(key)))
+	default:
+		return fmt.Errorf("keyring not supported on %s: use the file or passphrase key source", runtime.GOOS)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil || (runtime.GOOS == "darwin" && stderr.Len() > 0) {
+		return fmt.Errorf("failed to store the key in the keyring: %s", commandError(err, &stderr))
+	}
+	return nil
+}
+
+// keyringLookup returns the key stored in the keyring, false if there is none
+func keyringLookup() (string, bool, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.Command("secret-tool", "lookup", "service", keyringService, "account", keyringAccount)
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", keyringService, "-a", keyringAccount, "-w")
+	default:
+		return "", false, fmt.Errorf("keyring not supported on %s: use the file or passphrase key source", runtime.GOOS)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	if _, exited := err.(*exec.ExitError); exited {
+		// secret-tool exits 1 silently when nothing matches, security
+		// reports the item could not be found
+		if stderr.Len() == 0 || strings.Contains(stderr.String(), "could not be found") {
+			return "", false, nil
+		}
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read the keyring: %s", commandError(err, &stderr))
+	}
+	return strings.TrimSpace(stdout.String()), true, nil
+}
+
+// commandError describes the failure of a keyring command
+func commandError(err error, stderr *bytes.Buffer) string {
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return msg
+	}
+	if _, ok := err.(*exec.Error); ok {
+		return err.Error() + " (install libsecret-tools, or use the file or passphrase key source)"
+	}
+	return err.Error()
+}
@@ -0,0 +1,174 @@
+// Package secrets encrypts the secret values of the config (tokens,
+// passwords, webhook URLs) with NaCl secretbox, so they are not stored in
+// plaintext YAML. The key comes from a key file, the OS keyring or a
+// passphrase.
+package secrets
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"csd-devtrack/cli/modules/platform/fileutil"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KeySize is the size of a secretbox key
+const KeySize = 32
+
+// sealedPrefix versions the sealed values: v1 is base64(nonce | secretbox)
+const sealedPrefix = "v1:"
+
+// nonceSize is the size of the random nonce of each sealed value
+const nonceSize = 24
+
+// Key sources
+const (
+	SourceFile       = "file"       // Random key in a 0600 file (default)
+	SourceKeyring    = "keyring"    // Random key in the OS keyring (Secret Service, macOS Keychain)
+	SourcePassphrase = "passphrase" // Derived from a passphrase with scrypt
+)
+
+// PassphraseEnv is the environment variable holding the passphrase of the
+// passphrase source (the daemon cannot prompt)
+const PassphraseEnv = "CSD_DEVTRACK_PASSPHRASE"
+
+// ErrWrongKey is returned when a value cannot be decrypted with the key
+var ErrWrongKey = errors.New("cannot decrypt: wrong key or passphrase")
+
+// Key is a secretbox key
+type Key [KeySize]byte
+
+// NewKey returns a random key
+func NewKey() (*Key, error) {
+	var key Key
+	if _, err := io.ReadFull(rand.Reader, key[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return &key, nil
+}
+
+// Seal encrypts a value with key
+func Seal(key *Key, value string) (string, error) {
+	var nonce [nonceSize]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	box := secretbox.Seal(nonce[:], []byte(value), &nonce, (*[KeySize]byte)(key))
+	return sealedPrefix + base64.StdEncoding.EncodeToString(box), nil
+}
+
+// Open decrypts a value sealed with key
+func Open(key *Key, sealed string) (string, error) {
+	data, ok := strings.CutPrefix(sealed, sealedPrefix)
+	if !ok {
+		return "", fmt.Errorf("not an encrypted value")
+	}
+	box, err := base64.StdEncoding.DecodeString(data)
+	if err != nil || len(box) < nonceSize+secretbox.Overhead {
+		return "", fmt.Errorf("corrupted encrypted value")
+	}
+	var nonce [nonceSize]byte
+	copy(nonce[:], box[:nonceSize])
+	value, ok := secretbox.Open(nil, box[nonceSize:], &nonce, (*[KeySize]byte)(key))
+	if !ok {
+		return "", ErrWrongKey
+	}
+	return string(value), nil
+}
+
+// IsSealed returns true if value has the format of a sealed value
+func IsSealed(value string) bool {
+	data, ok := strings.CutPrefix(value, sealedPrefix)
+	if !ok {
+		return false
+	}
+	box, err := base64.StdEncoding.DecodeString(data)
+	return err == nil && len(box) >= nonceSize+secretbox.Overhead
+}
+
+// LoadFileKey reads the key of a key file. A missing file is created with a
+// new key when create is set.
+func LoadFileKey(path string, create bool) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && create {
+		key, err := NewKey()
+		if err != nil {
+			return nil, err
+		}
+		return key, StoreFileKey(path, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	return decodeKey(strings.TrimSpace(string(data)))
+}
+
+// StoreFileKey writes a key file, only readable by its owner. The previous
+// key is kept as <path>.old until the next rotation.
+func StoreFileKey(path string, key *Key) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create key directory: %w", err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		if err := os.Rename(path, path+".old"); err != nil {
+			return fmt.Errorf("failed to keep the previous key: %w", err)
+		}
+	}
+	if err := fileutil.WriteFileAtomic(path, []byte(encodeKey(key)+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+	return nil
+}
+
+// NewSalt returns a random salt for DeriveKey, base64 encoded
+func NewSalt() (string, error) {
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(salt), nil
+}
+
+// DeriveKey derives a key from a passphrase and a base64 salt (scrypt,
+// about 100ms)
+func DeriveKey(passphrase, salt string) (*Key, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("empty passphrase")
+	}
+	rawSalt, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil || len(rawSalt) == 0 {
+		return nil, fmt.Errorf("invalid salt")
+	}
+	derived, err := scrypt.Key([]byte(passphrase), rawSalt, 1<<15, 8, 1, KeySize)
+	if err != nil {
+		return nil, err
+	}
+	var key Key
+	copy(key[:], derived)
+	return &key, nil
+}
+
+// encodeKey returns the base64 form of a key, stored in key files and the
+// keyring
+func encodeKey(key *Key) string {
+	return base64.StdEncoding.EncodeToString(key[:])
+}
+
+// decodeKey parses the base64 form of a key
+func decodeKey(data string) (*Key, error) {
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil || len(raw) != KeySize {
+		return nil, fmt.Errorf("invalid key: expected %d base64 encoded bytes", KeySize)
+	}
+	var key Key
+	copy(key[:], raw)
+	return &key, nil
+}
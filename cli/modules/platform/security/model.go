@@ -0,0 +1,77 @@
+package security
+
+import (
+	"time"
+
+	"csd-devtrack/cli/modules/core/projects"
+)
+
+// Severity represents the severity of a vulnerability
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityModerate Severity = "moderate"
+	SeverityLow      Severity = "low"
+	SeverityInfo     Severity = "info"
+)
+
+// AllSeverities lists severities from most to least severe
+var AllSeverities = []Severity{
+	SeverityCritical,
+	SeverityHigh,
+	SeverityModerate,
+	SeverityLow,
+	SeverityInfo,
+}
+
+// Rank returns a sortable rank (lower = more severe)
+func (s Severity) Rank() int {
+	for i, sev := range AllSeverities {
+		if sev == s {
+			return i
+		}
+	}
+	return len(AllSeverities)
+}
+
+// Scanner identifies the tool that produced a finding
+type Scanner string
+
+const (
+	ScannerGovulncheck Scanner = "govulncheck"
+	ScannerNpmAudit    Scanner = "npm-audit"
+)
+
+// Finding represents a single vulnerability finding
+type Finding struct {
+	ID           string   `json:"id"`                      // GO-2024-1234, GHSA-xxxx, npm advisory
+	Title        string   `json:"title"`                   // Short summary
+	Severity     Severity `json:"severity"`                // critical, high, moderate, low, info
+	Package      string   `json:"package"`                 // Affected module or npm package
+	Version      string   `json:"version,omitempty"`       // Installed version (or vulnerable range)
+	FixedVersion string   `json:"fixed_version,omitempty"` // First fixed version (empty = no fix)
+	Paths        []string `json:"paths,omitempty"`         // Affected paths (call stack or node_modules path)
+	URL          string   `json:"url,omitempty"`           // Advisory URL
+}
+
+// ScanResult holds the findings for a single component scan
+type ScanResult struct {
+	ProjectID string                 `json:"project_id"`
+	Component projects.ComponentType `json:"component"`
+	Scanner   Scanner                `json:"scanner"`
+	Findings  []Finding              `json:"findings"`
+	ScannedAt time.Time              `json:"scanned_at"`
+	Duration  time.Duration          `json:"duration"`
+	Error     string                 `json:"error,omitempty"`
+}
+
+// CountBySeverity returns the number of findings per severity
+func (r *ScanResult) CountBySeverity() map[Severity]int {
+	counts := make(map[Severity]int)
+	for _, f := range r.Findings {
+		counts[f.Severity]++
+	}
+	return counts
+}
@@ -0,0 +1,453 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules/core/projects"
+)
+
+// maxPathsPerFinding limits the number of affected paths kept per finding
+const maxPathsPerFinding = 5
+
+// Service runs vulnerability scanners on project components and caches results
+type Service struct {
+	projectService *projects.Service
+
+	mu              sync.RWMutex
+	govulncheckPath string                   // Empty = Go scanning unavailable
+	npmPath         string                   // Empty = npm audit unavailable
+	results         map[string][]*ScanResult // projectID -> latest result per component
+	scanning        map[string]bool          // projectID -> scan in progress
+}
+
+// NewService creates a new security scan service
+func NewService(projectService *projects.Service, govulncheckPath, npmPath string) *Service {
+	return &Service{
+		projectService:  projectService,
+		govulncheckPath: govulncheckPath,
+		npmPath:         npmPath,
+		results:         make(map[string][]*ScanResult),
+		scanning:        make(map[string]bool),
+	}
+}
+
+// SetScannerPaths updates the scanner paths after a new capability detection
+func (s *Service) SetScannerPaths(govulncheckPath, npmPath string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.govulncheckPath, s.npmPath = govulncheckPath, npmPath
+}
+
+// scannerPaths returns the govulncheck and npm paths
+func (s *Service) scannerPaths() (govulncheckPath, npmPath string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.govulncheckPath, s.npmPath
+}
+
+// CanScan returns true if at least one scanner is available for the component
+func (s *Service) CanScan(ct projects.ComponentType) bool {
+	govulncheckPath, npmPath := s.scannerPaths()
+	if projects.IsGoComponent(ct) {
+		return govulncheckPath != ""
+	}
+	if projects.IsFrontendComponent(ct) {
+		return npmPath != ""
+	}
+	return false
+}
+
+// IsScanning returns true if a scan is running for the project
+func (s *Service) IsScanning(projectID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scanning[projectID]
+}
+
+// ScanProject scans all enabled components of a project. Each component scan
+// has its own timeout, so a slow scanner cannot starve the next ones.
+func (s *Service) ScanProject(ctx context.Context, projectID string, timeout time.Duration) ([]*ScanResult, error) {
+	project, err := s.projectService.GetProject(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %s", projectID)
+	}
+
+	s.mu.Lock()
+	if s.scanning[projectID] {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("scan already running for %s", projectID)
+	}
+	s.scanning[projectID] = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.scanning, projectID)
+		s.mu.Unlock()
+	}()
+
+	var results []*ScanResult
+	for _, comp := range project.GetEnabledComponents() {
+		if !s.CanScan(comp.Type) {
+			continue
+		}
+		scanCtx, cancel := context.WithTimeout(ctx, timeout)
+		results = append(results, s.ScanComponent(scanCtx, project, comp))
+		cancel()
+		if ctx.Err() != nil {
+			return results, ctx.Err()
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no scanner available for %s (install govulncheck or npm)", projectID)
+	}
+
+	s.mu.Lock()
+	s.results[projectID] = results
+	s.mu.Unlock()
+
+	return results, nil
+}
+
+// ScanComponent scans a single component with the matching scanner
+func (s *Service) ScanComponent(ctx context.Context, project *projects.Project, component *projects.Component) *ScanResult {
+	workDir := filepath.Join(project.Path, component.Path)
+	if component.Path == "" {
+		workDir = project.Path
+	}
+
+	result := &ScanResult{
+		ProjectID: project.ID,
+		Component: component.Type,
+		ScannedAt: time.Now(),
+	}
+
+	var err error
+	switch {
+	case projects.IsGoComponent(component.Type):
+		result.Scanner = ScannerGovulncheck
+		result.Findings, err = s.runGovulncheck(ctx, workDir)
+	case projects.IsFrontendComponent(component.Type):
+		result.Scanner = ScannerNpmAudit
+		result.Findings, err = s.runNpmAudit(ctx, workDir)
+	default:
+		err = fmt.Errorf("no scanner for component type %s", component.Type)
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+	sortFindings(result.Findings)
+	result.Duration = time.Since(result.ScannedAt)
+
+	return result
+}
+
+// GetResults returns the latest scan results for a project
+func (s *Service) GetResults(projectID string) []*ScanResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.results[projectID]
+}
+
+// GetAllResults returns the latest scan results for all projects
+func (s *Service) GetAllResults() map[string][]*ScanResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	all := make(map[string][]*ScanResult, len(s.results))
+	for k, v := range s.results {
+		all[k] = v
+	}
+	return all
+}
+
+// runScanner runs a scanner and returns its stdout.
+// Scanners exit non-zero when vulnerabilities are found, so a non-zero exit
+// with output on stdout is not treated as an error.
+func runScanner(ctx context.Context, workDir, binary string, args ...string) ([]byte, error) {
+	if _, err := os.Stat(workDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("component directory not found: %s", workDir)
+	}
+
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = workDir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil && stdout.Len() == 0 {
+		msg := strings.TrimSpace(stderr.String())
+		if idx := strings.Index(msg, "\n"); idx > 0 {
+			msg = msg[:idx]
+		}
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("%s failed: %s", filepath.Base(binary), msg)
+	}
+	return stdout.Bytes(), nil
+}
+
+// ============================================
+// govulncheck
+// ============================================
+
+// govulncheckMessage is one entry of the govulncheck -json stream
+type govulncheckMessage struct {
+	OSV *struct {
+		ID               string `json:"id"`
+		Summary          string `json:"summary"`
+		DatabaseSpecific *struct {
+			URL string `json:"url"`
+		} `json:"database_specific"`
+	} `json:"osv"`
+	Finding *struct {
+		OSV          string `json:"osv"`
+		FixedVersion string `json:"fixed_version"`
+		Trace        []struct {
+			Module   string `json:"module"`
+			Version  string `json:"version"`
+			Package  string `json:"package"`
+			Function string `json:"function"`
+			Receiver string `json:"receiver"`
+		} `json:"trace"`
+	} `json:"finding"`
+}
+
+// runGovulncheck scans a Go module and parses the JSON stream
+func (s *Service) runGovulncheck(ctx context.Context, workDir string) ([]Finding, error) {
+	govulncheckPath, _ := s.scannerPaths()
+	output, err := runScanner(ctx, workDir, govulncheckPath, "-json", "./...")
+	if err != nil {
+		return nil, err
+	}
+	return parseGovulncheck(output)
+}
+
+// parseGovulncheck converts govulncheck JSON output into findings.
+// The Go vulnerability database carries no CVSS score, so severity reflects
+// reachability: called symbols are high, imported packages low, and
+// module-only requirements informational.
+func parseGovulncheck(output []byte) ([]Finding, error) {
+	type osvInfo struct {
+		summary string
+		url     string
+	}
+	osvs := make(map[string]osvInfo)
+	byID := make(map[string]*Finding)
+	var order []string
+
+	dec := json.NewDecoder(bytes.NewReader(output))
+	for {
+		var msg govulncheckMessage
+		if err := dec.Decode(&msg); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse govulncheck output: %w", err)
+		}
+
+		if msg.OSV != nil {
+			info := osvInfo{summary: msg.OSV.Summary}
+			if msg.OSV.DatabaseSpecific != nil {
+				info.url = msg.OSV.DatabaseSpecific.URL
+			}
+			osvs[msg.OSV.ID] = info
+			continue
+		}
+
+		if msg.Finding == nil || len(msg.Finding.Trace) == 0 {
+			continue
+		}
+
+		vuln := msg.Finding.Trace[0]
+		severity := SeverityInfo
+		if vuln.Function != "" {
+			severity = SeverityHigh
+		} else if vuln.Package != "" {
+			severity = SeverityLow
+		}
+
+		f, ok := byID[msg.Finding.OSV]
+		if !ok {
+			f = &Finding{
+				ID:           msg.Finding.OSV,
+				Severity:     severity,
+				Package:      vuln.Module,
+				Version:      vuln.Version,
+				FixedVersion: msg.Finding.FixedVersion,
+			}
+			byID[msg.Finding.OSV] = f
+			order = append(order, msg.Finding.OSV)
+		} else if severity.Rank() < f.Severity.Rank() {
+			f.Severity = severity
+		}
+
+		// Format trace as "entry → ... → vulnerable symbol"
+		if vuln.Function != "" && len(f.Paths) < maxPathsPerFinding {
+			var frames []string
+			for i := len(msg.Finding.Trace) - 1; i >= 0; i-- {
+				frame := msg.Finding.Trace[i]
+				name := frame.Function
+				if frame.Receiver != "" {
+					name = strings.TrimPrefix(frame.Receiver, "*") + "." + name
+				}
+				frames = append(frames, filepath.Base(frame.Package)+"."+name)
+			}
+			f.Paths = append(f.Paths, strings.Join(frames, " → "))
+		} else if vuln.Package != "" && len(f.Paths) == 0 {
+			f.Paths = append(f.Paths, vuln.Package)
+		}
+	}
+
+	findings := make([]Finding, 0, len(order))
+	for _, id := range order {
+		f := byID[id]
+		if info, ok := osvs[id]; ok {
+			f.Title = info.summary
+			f.URL = info.url
+		}
+		if f.URL == "" {
+			f.URL = "https://pkg.go.dev/vuln/" + id
+		}
+		findings = append(findings, *f)
+	}
+	return findings, nil
+}
+
+// ============================================
+// npm audit
+// ============================================
+
+// npmAuditReport is the npm audit --json report (npm 7+)
+type npmAuditReport struct {
+	Error *struct {
+		Code    string `json:"code"`
+		Summary string `json:"summary"`
+	} `json:"error"`
+	Vulnerabilities map[string]struct {
+		Name         string            `json:"name"`
+		Severity     string            `json:"severity"`
+		Via          []json.RawMessage `json:"via"`
+		Range        string            `json:"range"`
+		Nodes        []string          `json:"nodes"`
+		FixAvailable json.RawMessage   `json:"fixAvailable"`
+	} `json:"vulnerabilities"`
+}
+
+// npmAdvisory is an advisory entry in the "via" list
+type npmAdvisory struct {
+	Source int    `json:"source"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+}
+
+// runNpmAudit audits a frontend package and parses the JSON report
+func (s *Service) runNpmAudit(ctx context.Context, workDir string) ([]Finding, error) {
+	_, npmPath := s.scannerPaths()
+	output, err := runScanner(ctx, workDir, npmPath, "audit", "--json")
+	if err != nil {
+		return nil, err
+	}
+	return parseNpmAudit(output)
+}
+
+// parseNpmAudit converts an npm audit report into findings
+func parseNpmAudit(output []byte) ([]Finding, error) {
+	var report npmAuditReport
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse npm audit output: %w", err)
+	}
+	if report.Error != nil {
+		return nil, fmt.Errorf("npm audit: %s", report.Error.Summary)
+	}
+
+	var findings []Finding
+	for name, vuln := range report.Vulnerabilities {
+		if vuln.Name != "" {
+			name = vuln.Name
+		}
+		f := Finding{
+			ID:       "npm:" + name,
+			Severity: Severity(vuln.Severity),
+			Package:  name,
+			Version:  vuln.Range,
+		}
+
+		// "via" mixes advisories (objects) and transitive package names (strings)
+		var viaPkgs []string
+		for _, raw := range vuln.Via {
+			var adv npmAdvisory
+			if err := json.Unmarshal(raw, &adv); err == nil && adv.Title != "" {
+				if f.Title == "" {
+					f.Title = adv.Title
+					f.URL = adv.URL
+					if idx := strings.LastIndex(adv.URL, "/"); idx >= 0 && idx < len(adv.URL)-1 {
+						f.ID = adv.URL[idx+1:]
+					}
+				}
+				continue
+			}
+			var pkg string
+			if err := json.Unmarshal(raw, &pkg); err == nil {
+				viaPkgs = append(viaPkgs, pkg)
+			}
+		}
+		if f.Title == "" && len(viaPkgs) > 0 {
+			f.Title = "Depends on vulnerable " + strings.Join(viaPkgs, ", ")
+		}
+
+		// fixAvailable is either a bool or {name, version, isSemVerMajor}
+		var fix struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		}
+		var fixBool bool
+		if err := json.Unmarshal(vuln.FixAvailable, &fix); err == nil && fix.Version != "" {
+			if fix.Name != "" && fix.Name != name {
+				f.FixedVersion = fix.Name + "@" + fix.Version
+			} else {
+				f.FixedVersion = fix.Version
+			}
+		} else if err := json.Unmarshal(vuln.FixAvailable, &fixBool); err == nil && fixBool {
+			f.FixedVersion = "npm audit fix"
+		}
+
+		for i, node := range vuln.Nodes {
+			if i >= maxPathsPerFinding {
+				break
+			}
+			f.Paths = append(f.Paths, node)
+		}
+
+		findings = append(findings, f)
+	}
+	return findings, nil
+}
+
+// sortFindings orders findings by severity, then by package and ID
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity.Rank() != findings[j].Severity.Rank() {
+			return findings[i].Severity.Rank() < findings[j].Severity.Rank()
+		}
+		if findings[i].Package != findings[j].Package {
+			return findings[i].Package < findings[j].Package
+		}
+		return findings[i].ID < findings[j].ID
+	})
+}
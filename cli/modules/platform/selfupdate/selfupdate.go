@@ -0,0 +1,365 @@
+package selfupdate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Release channels
+const (
+	ChannelStable = "stable" // Published releases only
+	ChannelBeta   = "beta"   // Pre-releases included
+)
+
+// Release assets besides the binaries
+const (
+	ChecksumsAsset = "checksums.txt"     // sha256sum output for every binary
+	SignatureAsset = "checksums.txt.sig" // Base64 ed25519 signature of checksums.txt
+)
+
+// maxBinarySize is the maximum size of a downloaded binary
+const maxBinarySize = 200 << 20
+
+// ReleasePublicKey is the base64 ed25519 key checking the checksums signature
+// of the releases, set at build time by "make release":
+// -ldflags "-X csd-devtrack/cli/modules/platform/selfupdate.ReleasePublicKey=..."
+var ReleasePublicKey string
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+// Release is a GitHub release
+type Release struct {
+	Tag         string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Notes       string    `json:"body"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []Asset   `json:"assets"`
+}
+
+// Version returns the release version without the "v" prefix
+func (r Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// Asset returns an asset of the release by name
+func (r Release) Asset(name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// BinaryName returns the release asset name of the binary for this platform
+// (as produced by "make release")
+func BinaryName() string {
+	name := fmt.Sprintf("csd-devtrack-%s-%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Updater checks the releases of a GitHub repository and replaces the
+// running binary with the one of a newer release
+type Updater struct {
+	repository string // owner/name
+	channel    string
+	publicKey  ed25519.PublicKey // nil = updates cannot be verified, Download fails
+	client     *http.Client
+}
+
+// NewUpdater creates an updater for a repository and channel.
+// publicKey is the base64 ed25519 key checking the checksums signature, for
+// the releases of another repository (empty = key embedded in the build).
+func NewUpdater(repository, channel, publicKey string) (*Updater, error) {
+	if channel != ChannelStable && channel != ChannelBeta {
+		return nil, fmt.Errorf("unknown channel: %s (use %s or %s)", channel, ChannelStable, ChannelBeta)
+	}
+	u := &Updater{
+		repository: repository,
+		channel:    channel,
+		client:     &http.Client{Timeout: 5 * time.Minute},
+	}
+	if publicKey == "" {
+		publicKey = ReleasePublicKey
+	}
+	if publicKey != "" {
+		key, err := base64.StdEncoding.DecodeString(publicKey)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid update public key")
+		}
+		u.publicKey = key
+	}
+	return u, nil
+}
+
+// Channel returns the release channel
+func (u *Updater) Channel() string {
+	return u.channel
+}
+
+// Releases returns the releases of the channel newer than current, newest first
+func (u *Updater) Releases(ctx context.Context, current string) ([]Release, error) {
+	var all []Release
+	url := fmt.Sprintf("https://api.github.com/repos/%s/releases?per_page=30", u.repository)
+	if err := u.getJSON(ctx, url, &all); err != nil {
+		return nil, err
+	}
+
+	var result []Release
+	for _, r := range all {
+		if r.Draft || (r.Prerelease && u.channel != ChannelBeta) {
+			continue
+		}
+		if CompareVersions(r.Version(), current) > 0 {
+			result = append(result, r)
+		}
+	}
+	// The API lists releases by creation date: order by version instead
+	for i := 1; i < len(result); i++ {
+		for j := i; j > 0 && CompareVersions(result[j].Version(), result[j-1].Version()) > 0; j-- {
+			result[j], result[j-1] = result[j-1], result[j]
+		}
+	}
+	return result, nil
+}
+
+// Download fetches the binary of a release for this platform into a
+// temporary file next to the running binary and verifies its checksum. The
+// checksums are downloaded from the same origin as the binary, so they must
+// be signed: a release without a valid signature is refused.
+func (u *Updater) Download(ctx context.Context, release Release) (string, error) {
+	if u.publicKey == nil {
+		return "", errors.New("no release public key in this build (not built by make release): the update cannot be verified")
+	}
+	name := BinaryName()
+	binary, ok := release.Asset(name)
+	if !ok {
+		return "", fmt.Errorf("release %s has no binary for %s/%s", release.Tag, runtime.GOOS, runtime.GOARCH)
+	}
+	checksumsAsset, ok := release.Asset(ChecksumsAsset)
+	if !ok {
+		return "", fmt.Errorf("release %s has no %s", release.Tag, ChecksumsAsset)
+	}
+
+	checksums, err := u.get(ctx, checksumsAsset.URL, 1<<20)
+	if err != nil {
+		return "", fmt.Errorf("failed to download checksums: %w", err)
+	}
+	sigAsset, ok := release.Asset(SignatureAsset)
+	if !ok {
+		return "", fmt.Errorf("release %s is not signed", release.Tag)
+	}
+	sig, err := u.get(ctx, sigAsset.URL, 4096)
+	if err != nil {
+		return "", fmt.Errorf("failed to download signature: %w", err)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil || !ed25519.Verify(u.publicKey, checksums, decoded) {
+		return "", errors.New("invalid checksums signature")
+	}
+	expected, err := findChecksum(checksums, name)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := u.get(ctx, binary.URL, maxBinarySize)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != expected {
+		return "", fmt.Errorf("checksum mismatch for %s", name)
+	}
+
+	executable, err := currentExecutable()
+	if err != nil {
+		return "", err
+	}
+	tmp := executable + ".new"
+	if err := os.WriteFile(tmp, data, 0755); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+	return tmp, nil
+}
+
+// Apply replaces the running binary with a downloaded one.
+// The rename is atomic on Unix; on Windows the running binary is moved aside first.
+func (u *Updater) Apply(tmp string) error {
+	executable, err := currentExecutable()
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(executable); err == nil {
+		os.Chmod(tmp, info.Mode())
+	}
+
+	if runtime.GOOS == "windows" {
+		old := executable + ".old"
+		os.Remove(old)
+		if err := os.Rename(executable, old); err != nil {
+			return fmt.Errorf("failed to move current binary: %w", err)
+		}
+		if err := os.Rename(tmp, executable); err != nil {
+			os.Rename(old, executable)
+			return fmt.Errorf("failed to install new binary: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.Rename(tmp, executable); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to install new binary: %w", err)
+	}
+	return nil
+}
+
+// getJSON decodes the JSON document at url
+func (u *Updater) getJSON(ctx context.Context, url string, v interface{}) error {
+	data, err := u.get(ctx, url, 10<<20)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// get downloads url, failing past limit bytes
+func (u *Updater) get(ctx context.Context, url string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "csd-devtrack")
+	if strings.HasPrefix(url, "https://api.github.com/") {
+		req.Header.Set("Accept", "application/vnd.github+json")
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s: response too large", url)
+	}
+	return data, nil
+}
+
+// findChecksum returns the sha256 of name in a sha256sum output
+func findChecksum(checksums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("no checksum for %s", name)
+}
+
+// currentExecutable returns the path of the running binary, symlinks resolved
+func currentExecutable() (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(executable)
+}
+
+// CompareVersions compares two versions (1.2.3, 1.2.3-beta.1):
+// -1 if a < b, 0 if equal, 1 if a > b. A pre-release is lower than its release.
+func CompareVersions(a, b string) int {
+	a, b = strings.TrimPrefix(a, "v"), strings.TrimPrefix(b, "v")
+	aCore, aPre, _ := strings.Cut(a, "-")
+	bCore, bPre, _ := strings.Cut(b, "-")
+
+	if c := compareDotted(aCore, bCore); c != 0 {
+		return c
+	}
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	return compareDotted(aPre, bPre)
+}
+
+// compareDotted compares dot-separated identifiers, numerically when both are numbers
+func compareDotted(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var x, y string
+		if i < len(aParts) {
+			x = aParts[i]
+		}
+		if i < len(bParts) {
+			y = bParts[i]
+		}
+		// A missing number is 0 (1.2 == 1.2.0)
+		if x == "" && isNumber(y) {
+			x = "0"
+		}
+		if y == "" && isNumber(x) {
+			y = "0"
+		}
+		xn, xErr := strconv.Atoi(x)
+		yn, yErr := strconv.Atoi(y)
+		switch {
+		case xErr == nil && yErr == nil:
+			if xn != yn {
+				if xn < yn {
+					return -1
+				}
+				return 1
+			}
+		case x != y:
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// isNumber returns true if s is a decimal number
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
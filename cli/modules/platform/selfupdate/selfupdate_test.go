@@ -0,0 +1,98 @@
+package selfupdate
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// testRelease serves a release whose assets are the given files
+func testRelease(t *testing.T, files map[string][]byte) Release {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	release := Release{Tag: "v9.9.9"}
+	for name := range files {
+		release.Assets = append(release.Assets, Asset{Name: name, URL: server.URL + "/" + name})
+	}
+	return release
+}
+
+func TestDownloadVerifiesSignedChecksums(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary := []byte("new binary")
+	sum := sha256.Sum256(binary)
+	checksums := []byte(hex.EncodeToString(sum[:]) + "  " + BinaryName() + "\n")
+	sign := func(data []byte) []byte {
+		return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, data)))
+	}
+	tampered := []byte(strings.Repeat("0", 64) + "  " + BinaryName() + "\n")
+
+	tests := []struct {
+		name  string
+		files map[string][]byte
+		ok    bool
+	}{
+		{"signed", map[string][]byte{BinaryName(): binary, ChecksumsAsset: checksums, SignatureAsset: sign(checksums)}, true},
+		{"unsigned", map[string][]byte{BinaryName(): binary, ChecksumsAsset: checksums}, false},
+		{"tampered checksums", map[string][]byte{BinaryName(): binary, ChecksumsAsset: tampered, SignatureAsset: sign(checksums)}, false},
+		{"invalid signature", map[string][]byte{BinaryName(): binary, ChecksumsAsset: checksums, SignatureAsset: []byte("bm90IGEgc2lnbmF0dXJl")}, false},
+	}
+
+	updater, err := NewUpdater("owner/repo", ChannelStable, base64.StdEncoding.EncodeToString(publicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmp, err := updater.Download(context.Background(), testRelease(t, tt.files))
+			if tmp != "" {
+				os.Remove(tmp)
+			}
+			if tt.ok && err != nil {
+				t.Fatalf("valid release refused: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("release accepted")
+			}
+		})
+	}
+}
+
+func TestDownloadRequiresPublicKey(t *testing.T) {
+	if ReleasePublicKey != "" {
+		t.Skip("release public key embedded")
+	}
+	updater, err := NewUpdater("owner/repo", ChannelStable, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary := []byte("new binary")
+	sum := sha256.Sum256(binary)
+	release := testRelease(t, map[string][]byte{
+		BinaryName():   binary,
+		ChecksumsAsset: []byte(hex.EncodeToString(sum[:]) + "  " + BinaryName() + "\n"),
+	})
+	if tmp, err := updater.Download(context.Background(), release); err == nil {
+		os.Remove(tmp)
+		t.Fatal("release accepted without public key")
+	}
+}
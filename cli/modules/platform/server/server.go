@@ -14,6 +14,7 @@ import (
 	"csd-devtrack/cli/modules/platform/config"
 	"csd-devtrack/cli/modules/platform/eventbus"
 	"csd-devtrack/cli/modules/platform/git"
+	"csd-devtrack/cli/modules/platform/services"
 	"csd-devtrack/cli/modules/platform/supervisor"
 )
 
@@ -86,6 +87,8 @@ func (s *Server) Initialize() error {
 	// Initialize services
 	s.processService = processes.NewService(s.projectService)
 	s.processMgr = supervisor.NewManager(s.processService)
+	// Services of the catalog, started before the components depending on them
+	s.processService.SetDependencies(services.NewConfiguredManager(s.config))
 	s.gitService = git.NewService(s.projectService)
 
 	parallelBuilds := 4
@@ -0,0 +1,541 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/containers"
+)
+
+const (
+	// defaultReadyTimeout is how long a service may take to be ready
+	defaultReadyTimeout = 60 * time.Second
+	// checkTimeout bounds a health check command
+	checkTimeout = 10 * time.Second
+	// stopTimeout is how long a local service may take to exit once asked
+	stopTimeout = 15 * time.Second
+)
+
+// Manager starts and stops the services of the catalog. Container services
+// are named after the service (see ContainerName) and outlive DevTrack;
+// local services are its children, stopped with it.
+type Manager struct {
+	engine *containers.Service
+
+	mu       sync.RWMutex
+	catalog  map[string]*config.ServiceConfig
+	tracked  map[string]*tracked    // Service name -> state seen by DevTrack
+	listed   []containers.Container // Containers of the last Statuses listing them
+	starts   map[string]*sync.Mutex // Serializes the start of each service
+	onOutput func(service, line string, isError bool)
+	onChange func()
+}
+
+// tracked is the state of a service started or stopped by DevTrack
+type tracked struct {
+	state State
+	err   string
+	since time.Time
+
+	// Local services, while running
+	cancel   context.CancelFunc
+	done     chan struct{} // Closed when the process exited
+	stopping bool
+}
+
+// NewManager creates a service manager running the container services with
+// the engine of a container service
+func NewManager(engine *containers.Service) *Manager {
+	return &Manager{
+		engine:  engine,
+		catalog: make(map[string]*config.ServiceConfig),
+		tracked: make(map[string]*tracked),
+		starts:  make(map[string]*sync.Mutex),
+	}
+}
+
+// NewConfiguredManager creates a manager of the catalog of a configuration
+// where the capabilities are not detected (command line, web server)
+func NewConfiguredManager(cfg *config.Config) *Manager {
+	configured := ""
+	if cfg != nil && cfg.Settings != nil && cfg.Settings.Executables != nil {
+		configured = cfg.Settings.Executables.Docker
+	}
+	engine := containers.NewService()
+	engine.SetPath(containers.DetectPath(configured))
+	m := NewManager(engine)
+	if cfg != nil {
+		m.SetCatalog(cfg.ServiceCatalog())
+	}
+	return m
+}
+
+// SetCatalog sets the services that can be started
+func (m *Manager) SetCatalog(catalog map[string]*config.ServiceConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.catalog = catalog
+}
+
+// SetOutputHandler sets the function receiving the output of the services
+// and the messages of their start
+func (m *Manager) SetOutputHandler(fn func(service, line string, isError bool)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onOutput = fn
+}
+
+// SetChangeHandler sets the function called when a service changes state
+func (m *Manager) SetChangeHandler(fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onChange = fn
+}
+
+// Names returns the services of the catalog, sorted
+func (m *Manager) Names() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.catalog))
+	for name := range m.catalog {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Statuses returns the services of the catalog with their state. The state
+// of the container services is read from the containers of the engine
+// (listed false if not listed: the last ones listed are used).
+func (m *Manager) Statuses(list []containers.Container, listed bool) []Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if listed {
+		m.listed = list
+	} else if m.listed != nil {
+		list, listed = m.listed, true
+	}
+	byName := make(map[string]*containers.Container, len(list))
+	for i := range list {
+		byName[list[i].Name] = &list[i]
+	}
+
+	statuses := make([]Status, 0, len(m.catalog))
+	for name, svc := range m.catalog {
+		st := Status{
+			Name:        name,
+			Kind:        kindOf(svc),
+			Description: svc.Description,
+			Target:      svc.Image,
+			Ports:       svc.Ports,
+			State:       StateStopped,
+		}
+		if st.Kind == KindLocal {
+			st.Target = svc.Command
+		}
+		if t := m.tracked[name]; t != nil {
+			st.State, st.Error, st.Since = t.state, t.err, t.since
+		}
+		if st.Kind == KindContainer && listed && st.State != StateStarting {
+			// Started before DevTrack, or stopped outside of it
+			c := byName[ContainerName(name)]
+			running := c != nil && c.Running()
+			switch {
+			case running && st.State != StateUnhealthy:
+				st.State, st.Error = StateRunning, ""
+			case !running && (st.State == StateRunning || st.State == StateUnhealthy):
+				st.State = StateStopped
+			}
+			if c != nil {
+				st.Container = c.Status
+			}
+		}
+		statuses = append(statuses, st)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// Ensure starts the services not running, one after the other, and waits
+// until each is ready (see processes.Dependencies)
+func (m *Manager) Ensure(ctx context.Context, names []string) error {
+	for _, name := range names {
+		if err := m.ensure(ctx, name); err != nil {
+			return fmt.Errorf("service %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Start starts a service if not running and waits until it is ready
+func (m *Manager) Start(ctx context.Context, name string) error {
+	return m.Ensure(ctx, []string{name})
+}
+
+// Restart stops a service and starts it again
+func (m *Manager) Restart(ctx context.Context, name string) error {
+	if err := m.Stop(ctx, name); err != nil {
+		return err
+	}
+	return m.Start(ctx, name)
+}
+
+// ensure starts a service if not running and waits until it is ready
+func (m *Manager) ensure(ctx context.Context, name string) error {
+	svc, lock, err := m.lookup(name)
+	if err != nil {
+		return err
+	}
+	// A service needed by several components starting together starts once
+	lock.Lock()
+	defer lock.Unlock()
+
+	running, err := m.running(ctx, name, svc)
+	if err != nil {
+		m.setState(name, StateFailed, err.Error())
+		return err
+	}
+	if !running {
+		m.setState(name, StateStarting, "")
+		m.output(name, "Starting "+name+"...", false)
+		if err := m.start(ctx, name, svc); err != nil {
+			m.setState(name, StateFailed, err.Error())
+			m.output(name, "Start failed: "+err.Error(), true)
+			return err
+		}
+	}
+
+	if err := m.waitReady(ctx, name, svc); err != nil {
+		m.setState(name, StateUnhealthy, err.Error())
+		m.output(name, "Not ready: "+err.Error(), true)
+		return err
+	}
+	if !running {
+		m.output(name, name+" is ready", false)
+	}
+	m.setState(name, StateRunning, "")
+	return nil
+}
+
+// lookup returns a service of the catalog and the lock of its start
+func (m *Manager) lookup(name string) (*config.ServiceConfig, *sync.Mutex, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	svc := m.catalog[name]
+	if svc == nil {
+		return nil, nil, fmt.Errorf("not in the catalog (services: in the configuration)")
+	}
+	lock := m.starts[name]
+	if lock == nil {
+		lock = &sync.Mutex{}
+		m.starts[name] = lock
+	}
+	return svc, lock, nil
+}
+
+// running returns true if a service is started
+func (m *Manager) running(ctx context.Context, name string, svc *config.ServiceConfig) (bool, error) {
+	if kindOf(svc) == KindLocal {
+		return m.localRunning(name), nil
+	}
+	state, err := m.engine.State(ctx, ContainerName(name))
+	return state == "running", err
+}
+
+// localRunning returns true while the process of a local service runs
+func (m *Manager) localRunning(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	t := m.tracked[name]
+	if t == nil || t.done == nil {
+		return false
+	}
+	select {
+	case <-t.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// start starts a service in the background
+func (m *Manager) start(ctx context.Context, name string, svc *config.ServiceConfig) error {
+	env, err := config.ResolveSecretsMap(svc.Env)
+	if err != nil {
+		return fmt.Errorf("env: %w", err)
+	}
+	if kindOf(svc) == KindLocal {
+		return m.startLocal(ctx, name, svc, env)
+	}
+
+	spec := containers.RunSpec{
+		Name:    ContainerName(name),
+		Image:   svc.Image,
+		Volumes: svc.Volumes,
+		Env:     env,
+		Labels:  map[string]string{LabelService: name},
+	}
+	for _, port := range svc.Ports {
+		spec.Ports = append(spec.Ports, publishPort(port))
+	}
+	if err := m.engine.Run(ctx, spec); err != nil {
+		return err
+	}
+	m.FollowLogs(ctx, name)
+	return nil
+}
+
+// FollowLogs streams the logs of a container service to the output handler
+// until it stops; false if it is not a container service or its logs are
+// already followed
+func (m *Manager) FollowLogs(ctx context.Context, name string) bool {
+	m.mu.RLock()
+	svc := m.catalog[name]
+	m.mu.RUnlock()
+	if svc == nil || kindOf(svc) != KindContainer {
+		return false
+	}
+	started, _ := m.engine.FollowLogs(ctx, ContainerName(name),
+		func(line string, isError bool) { m.output(name, line, isError) }, nil)
+	return started
+}
+
+// startLocal runs the command of a local service, with the extra environment
+// variables env (secrets resolved)
+func (m *Manager) startLocal(ctx context.Context, name string, svc *config.ServiceConfig, env map[string]string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/c", svc.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", svc.Command)
+	}
+	cmd.Dir = svc.Dir
+	cmd.Env = os.Environ()
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	setupProcessGroup(cmd)
+	cmd.WaitDelay = stopTimeout
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		cancel()
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return err
+	}
+
+	t := &tracked{state: StateStarting, since: time.Now(), cancel: cancel, done: make(chan struct{})}
+	m.mu.Lock()
+	m.tracked[name] = t
+	m.mu.Unlock()
+
+	go func() {
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() { defer wg.Done(); m.readOutput(name, stdout, false) }()
+		go func() { defer wg.Done(); m.readOutput(name, stderr, true) }()
+		wg.Wait()
+		err := cmd.Wait()
+		cancel()
+
+		// Recorded before done is closed: a restart may track the service again
+		m.mu.Lock()
+		t.since = time.Now()
+		switch {
+		case t.stopping:
+			t.state, t.err = StateStopped, ""
+		case err != nil:
+			t.state, t.err = StateFailed, "exited: "+err.Error()
+		default:
+			t.state, t.err = StateFailed, "exited"
+		}
+		stopped, onChange := t.stopping, m.onChange
+		close(t.done)
+		m.mu.Unlock()
+		if !stopped {
+			m.output(name, name+" "+t.err, true)
+		}
+		if onChange != nil {
+			onChange()
+		}
+	}()
+	return nil
+}
+
+// readOutput forwards the output lines of a local service
+func (m *Manager) readOutput(name string, r io.Reader, isError bool) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); strings.TrimSpace(line) != "" {
+			m.output(name, line, isError)
+		}
+	}
+	io.Copy(io.Discard, r) // Unblock the process after an over-long line
+}
+
+// waitReady waits until the health check of a service passes
+func (m *Manager) waitReady(ctx context.Context, name string, svc *config.ServiceConfig) error {
+	timeout := defaultReadyTimeout
+	if svc.Health != nil && svc.Health.Timeout > 0 {
+		timeout = time.Duration(svc.Health.Timeout) * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		err := m.check(ctx, name, svc)
+		if err == nil {
+			return nil
+		}
+		// Exited while starting: no use waiting
+		if running, _ := m.running(ctx, name, svc); !running {
+			return fmt.Errorf("exited while starting (see its logs)")
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("not ready after %s: %w", timeout, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// check runs the health check of a service: its command, else a connection
+// to its port, else it is ready once started
+func (m *Manager) check(ctx context.Context, name string, svc *config.ServiceConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	if svc.Health != nil && svc.Health.Command != "" {
+		if kindOf(svc) == KindContainer {
+			return m.engine.Exec(ctx, ContainerName(name), svc.Health.Command)
+		}
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.CommandContext(ctx, "cmd", "/c", svc.Health.Command)
+		} else {
+			cmd = exec.CommandContext(ctx, "sh", "-c", svc.Health.Command)
+		}
+		cmd.Dir = svc.Dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			if msg, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n"); msg != "" {
+				return fmt.Errorf("%s", msg)
+			}
+			return err
+		}
+		return nil
+	}
+
+	port := ""
+	if svc.Health != nil && svc.Health.Port > 0 {
+		port = fmt.Sprint(svc.Health.Port)
+	} else if len(svc.Ports) > 0 {
+		port = hostPort(svc.Ports[0])
+	}
+	if port != "" {
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort("localhost", port), time.Second)
+		if err != nil {
+			return fmt.Errorf("port %s not open", port)
+		}
+		conn.Close()
+	}
+	return nil
+}
+
+// Stop stops a service: its container, or its local process
+func (m *Manager) Stop(ctx context.Context, name string) error {
+	svc, lock, err := m.lookup(name)
+	if err != nil {
+		return fmt.Errorf("service %s: %w", name, err)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	if kindOf(svc) == KindContainer {
+		m.engine.StopLogs(ContainerName(name))
+		state, err := m.engine.State(ctx, ContainerName(name))
+		if err == nil && state == "running" {
+			err = m.engine.Do(ctx, ContainerName(name), containers.ActionStop)
+		}
+		if err != nil {
+			return fmt.Errorf("service %s: %w", name, err)
+		}
+		m.setState(name, StateStopped, "")
+		return nil
+	}
+
+	m.mu.Lock()
+	t := m.tracked[name]
+	if t == nil || t.done == nil {
+		m.mu.Unlock()
+		return nil // Not started
+	}
+	t.stopping = true
+	m.mu.Unlock()
+	t.cancel()
+	select {
+	case <-t.done:
+	case <-time.After(stopTimeout + time.Second):
+		return fmt.Errorf("service %s: did not exit", name)
+	}
+	m.setState(name, StateStopped, "") // Also after it failed
+	return nil
+}
+
+// setState records the state of a service and notifies the change
+func (m *Manager) setState(name string, state State, errMsg string) {
+	m.mu.Lock()
+	t := m.tracked[name]
+	if t == nil {
+		t = &tracked{}
+		m.tracked[name] = t
+	}
+	if t.state != state {
+		t.since = time.Now()
+	}
+	t.state, t.err = state, errMsg
+	onChange := m.onChange
+	m.mu.Unlock()
+	if onChange != nil {
+		onChange()
+	}
+}
+
+// output sends a line to the output handler
+func (m *Manager) output(name, line string, isError bool) {
+	m.mu.RLock()
+	fn := m.onOutput
+	m.mu.RUnlock()
+	if fn != nil {
+		fn(name, line, isError)
+	}
+}
+
+// kindOf returns how a service runs
+func kindOf(svc *config.ServiceConfig) Kind {
+	if svc.Image != "" {
+		return KindContainer
+	}
+	return KindLocal
+}
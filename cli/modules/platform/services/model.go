@@ -0,0 +1,79 @@
+// Package services starts and stops the infrastructure services of the
+// catalog (databases, caches, message brokers...) the components depend on,
+// in containers or from local binaries, and tells when they are ready.
+package services
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// ContainerPrefix prefixes the names of the service containers
+	ContainerPrefix = "devtrack-"
+	// LabelService labels the service containers with the service name
+	LabelService = "devtrack.service"
+)
+
+// Kind is how a service runs
+type Kind string
+
+const (
+	KindContainer Kind = "container" // docker or podman
+	KindLocal     Kind = "local"     // Local binary, child of DevTrack
+)
+
+// State is the state of a service
+type State string
+
+const (
+	StateStopped   State = "stopped"
+	StateStarting  State = "starting"  // Started, waiting for its health check
+	StateRunning   State = "running"   // Ready
+	StateUnhealthy State = "unhealthy" // Started, health check failed
+	StateFailed    State = "failed"    // Did not start, or exited
+)
+
+// Status is a service of the catalog and its state
+type Status struct {
+	Name        string    `json:"name"`
+	Kind        Kind      `json:"kind"`
+	Description string    `json:"description,omitempty"`
+	Target      string    `json:"target"` // Image or command
+	Ports       []string  `json:"ports,omitempty"`
+	State       State     `json:"state"`
+	Error       string    `json:"error,omitempty"`
+	Since       time.Time `json:"since,omitempty"`     // Last state change seen by DevTrack
+	Container   string    `json:"container,omitempty"` // Status of its container ("Up 2 hours")
+}
+
+// Running returns true if the service is started (ready or not)
+func (s Status) Running() bool {
+	return s.State == StateRunning || s.State == StateStarting || s.State == StateUnhealthy
+}
+
+// ContainerName returns the name of the container of a service
+func ContainerName(service string) string {
+	return ContainerPrefix + service
+}
+
+// publishPort returns the -p value of a port: a single port is published on
+// the same host port ("9092/tcp" gives "9092:9092/tcp")
+func publishPort(port string) string {
+	if strings.Contains(port, ":") {
+		return port
+	}
+	number, _, _ := strings.Cut(port, "/")
+	return number + ":" + port
+}
+
+// hostPort returns the host side of a port ("127.0.0.1:5432:5432/tcp" and
+// "5432" give "5432")
+func hostPort(port string) string {
+	port, _, _ = strings.Cut(port, "/")
+	parts := strings.Split(port, ":")
+	if len(parts) == 3 {
+		return parts[1]
+	}
+	return parts[0]
+}
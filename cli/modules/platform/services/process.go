@@ -0,0 +1,19 @@
+// +build !windows
+
+package services
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setupProcessGroup runs a local service in its own process group, asked to
+// terminate as a whole when stopped: the shell does not outlive the service
+func setupProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	}
+}
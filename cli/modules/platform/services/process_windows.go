@@ -0,0 +1,8 @@
+package services
+
+import (
+	"os/exec"
+)
+
+// setupProcessGroup is a no-op on Windows (stop kills the shell)
+func setupProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,60 @@
+package sessiontasks
+
+import "time"
+
+// Tool identifies the AI tool a session belongs to
+type Tool string
+
+const (
+	ToolClaude Tool = "claude"
+	ToolCodex  Tool = "codex"
+)
+
+// SessionRef is a session of an AI tool
+type SessionRef struct {
+	Tool Tool   `json:"tool"`
+	ID   string `json:"id"`
+}
+
+// Task groups the sessions of different AI tools working on the same task
+// (e.g. planning with Claude, coding with Codex). Sessions are linked
+// manually; a task created from a git branch also groups the Claude sessions
+// of that branch in its directory.
+type Task struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Branch    string       `json:"branch,omitempty"`   // Git branch grouping Claude sessions
+	WorkDir   string       `json:"work_dir,omitempty"` // Directory of the branch
+	Sessions  []SessionRef `json:"sessions,omitempty"` // Linked sessions
+	Excluded  []SessionRef `json:"excluded,omitempty"` // Branch sessions unlinked from the task
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// Has reports whether a session is linked to the task
+func (t *Task) Has(ref SessionRef) bool {
+	return containsRef(t.Sessions, ref)
+}
+
+// Excludes reports whether a branch session was unlinked from the task
+func (t *Task) Excludes(ref SessionRef) bool {
+	return containsRef(t.Excluded, ref)
+}
+
+func containsRef(refs []SessionRef, ref SessionRef) bool {
+	for _, r := range refs {
+		if r == ref {
+			return true
+		}
+	}
+	return false
+}
+
+func removeRef(refs []SessionRef, ref SessionRef) []SessionRef {
+	kept := refs[:0]
+	for _, r := range refs {
+		if r != ref {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,167 @@
+package sessiontasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/fileutil"
+
+	"github.com/google/uuid"
+)
+
+// Service keeps the tasks linking AI sessions, persisted in a JSON file
+type Service struct {
+	file string // Tasks file (empty = not persisted)
+
+	mu    sync.RWMutex
+	tasks []*Task // Creation order
+}
+
+// NewService creates a task service persisting its tasks in file
+func NewService(file string) *Service {
+	s := &Service{file: file}
+	s.tasks = s.load()
+	return s
+}
+
+// List returns a copy of the tasks, in creation order
+func (s *Service) List() []Task {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Task, len(s.tasks))
+	for i, t := range s.tasks {
+		result[i] = *t
+		result[i].Sessions = append([]SessionRef(nil), t.Sessions...)
+		result[i].Excluded = append([]SessionRef(nil), t.Excluded...)
+	}
+	return result
+}
+
+// Link links a session to the task with a name (case-insensitive), creating
+// it if needed, and unlinks it from its previous task. A new task records the
+// branch and directory given to also group the Claude sessions of the branch.
+func (s *Service) Link(name string, ref SessionRef, branch, workDir string) (*Task, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("task name required")
+	}
+
+	s.mu.Lock()
+	var task *Task
+	for _, t := range s.tasks {
+		if strings.EqualFold(t.Name, name) {
+			task = t
+			break
+		}
+	}
+	if task == nil {
+		task = &Task{ID: uuid.New().String(), Name: name, CreatedAt: time.Now()}
+		if branch != "" {
+			task.Branch, task.WorkDir = branch, workDir
+		}
+		s.tasks = append(s.tasks, task)
+	}
+	s.unlinkLocked(ref, task)
+	task.Excluded = removeRef(task.Excluded, ref)
+	if !task.Has(ref) {
+		task.Sessions = append(task.Sessions, ref)
+	}
+	linked := *task
+	s.mu.Unlock()
+
+	return &linked, s.save()
+}
+
+// Unlink removes a session from its tasks. Tasks left without sessions nor
+// branch are deleted.
+func (s *Service) Unlink(ref SessionRef) error {
+	s.mu.Lock()
+	s.unlinkLocked(ref, nil)
+	s.mu.Unlock()
+	return s.save()
+}
+
+// unlinkLocked removes a session from the tasks other than keep; it stays
+// excluded from the branch tasks
+func (s *Service) unlinkLocked(ref SessionRef, keep *Task) {
+	kept := s.tasks[:0]
+	for _, t := range s.tasks {
+		if t != keep {
+			t.Sessions = removeRef(t.Sessions, ref)
+			if t.Branch != "" && ref.Tool == ToolClaude && !t.Excludes(ref) {
+				t.Excluded = append(t.Excluded, ref)
+			}
+			if len(t.Sessions) == 0 && t.Branch == "" {
+				continue
+			}
+		}
+		kept = append(kept, t)
+	}
+	s.tasks = kept
+}
+
+// Delete deletes a task, its sessions are kept
+func (s *Service) Delete(taskID string) error {
+	s.mu.Lock()
+	found := false
+	for i, t := range s.tasks {
+		if t.ID == taskID {
+			s.tasks = append(s.tasks[:i], s.tasks[i+1:]...)
+			found = true
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if !found {
+		return fmt.Errorf("task not found: %s", taskID)
+	}
+	return s.save()
+}
+
+// load reads the tasks file
+func (s *Service) load() []*Task {
+	if s.file == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		return nil // No tasks yet
+	}
+
+	var tasks []*Task
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return nil
+	}
+	return tasks
+}
+
+// save writes the tasks to disk atomically
+func (s *Service) save() error {
+	if s.file == "" {
+		return nil
+	}
+
+	// Held while writing: concurrent saves share the temporary file
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(s.tasks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.file), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(s.file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write session tasks: %w", err)
+	}
+	return nil
+}
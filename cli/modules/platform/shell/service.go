@@ -2,6 +2,7 @@ package shell
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
@@ -239,6 +240,25 @@ func (s *Service) DeleteSession(id string) error {
 	return nil
 }
 
+// RestoreSession adds a previously deleted session back
+func (s *Service) RestoreSession(session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.sessions[session.ID]; exists {
+		return fmt.Errorf("session already exists: %s", session.ID)
+	}
+
+	session.State = SessionIdle
+	s.sessions[session.ID] = session
+	if session.CustomName != "" {
+		s.customNames[session.ID] = session.CustomName
+		s.saveCustomNames()
+	}
+
+	return nil
+}
+
 // RenameSession renames a session
 func (s *Service) RenameSession(id, newName string) error {
 	s.mu.Lock()
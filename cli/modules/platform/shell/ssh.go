@@ -0,0 +1,179 @@
+package shell
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SSH host sources
+const (
+	SSHSourceConfig    = "config"     // Configured in csd-devtrack config
+	SSHSourceSSHConfig = "ssh_config" // Host entry of ~/.ssh/config
+)
+
+// DefaultSSHGroup is the tree group of hosts without a group
+const DefaultSSHGroup = "SSH"
+
+// SSHHost represents a remote shell target
+type SSHHost struct {
+	Name         string // Display name (ssh alias for ~/.ssh/config hosts)
+	HostName     string // Hostname or IP (empty = Name)
+	User         string // Remote user (empty = ssh default)
+	Port         int    // Port (0 = ssh default)
+	IdentityFile string // Private key (empty = ssh default)
+	Group        string // Tree group
+	Source       string // SSHSourceConfig or SSHSourceSSHConfig
+}
+
+// Target returns the user@host[:port] shown for the host
+func (h SSHHost) Target() string {
+	host := h.HostName
+	if host == "" {
+		host = h.Name
+	}
+	if h.User != "" {
+		host = h.User + "@" + host
+	}
+	if h.Port > 0 {
+		host += ":" + strconv.Itoa(h.Port)
+	}
+	return host
+}
+
+// Args returns the ssh arguments to open a shell on the host.
+// Keepalives make ssh exit when the connection drops, so it can be reconnected.
+func (h SSHHost) Args() []string {
+	args := []string{"-o", "ServerAliveInterval=30", "-o", "ServerAliveCountMax=3"}
+
+	// ~/.ssh/config hosts: let ssh apply the Host entry itself
+	if h.Source == SSHSourceSSHConfig {
+		return append(args, h.Name)
+	}
+
+	if h.User != "" {
+		args = append(args, "-l", h.User)
+	}
+	if h.Port > 0 {
+		args = append(args, "-p", strconv.Itoa(h.Port))
+	}
+	if h.IdentityFile != "" {
+		args = append(args, "-i", h.IdentityFile)
+	}
+	host := h.HostName
+	if host == "" {
+		host = h.Name
+	}
+	return append(args, host)
+}
+
+// SSHHosts returns the configured hosts followed by the ~/.ssh/config hosts
+// (if importSSHConfig). Configured hosts win over ssh_config hosts with the same name.
+func (s *Service) SSHHosts(configured []SSHHost, importSSHConfig bool) []SSHHost {
+	hosts := make([]SSHHost, 0, len(configured))
+	seen := make(map[string]bool)
+	for _, h := range configured {
+		if h.Name == "" || seen[h.Name] {
+			continue
+		}
+		if h.Group == "" {
+			h.Group = DefaultSSHGroup
+		}
+		h.Source = SSHSourceConfig
+		hosts = append(hosts, h)
+		seen[h.Name] = true
+	}
+
+	if !importSSHConfig || s.homeDir == "" {
+		return hosts
+	}
+	imported, err := ParseSSHConfig(filepath.Join(s.homeDir, ".ssh", "config"))
+	if err != nil {
+		return hosts
+	}
+	for _, h := range imported {
+		if !seen[h.Name] {
+			hosts = append(hosts, h)
+			seen[h.Name] = true
+		}
+	}
+	return hosts
+}
+
+// ParseSSHConfig reads the Host entries of an OpenSSH client config file.
+// As in ssh, the first value of a keyword wins. Wildcard patterns are
+// skipped; Include and Match are not followed.
+func ParseSSHConfig(path string) ([]SSHHost, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var hosts []SSHHost
+	var current []int // Indices in hosts of the current Host block
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// "Keyword value", "Keyword=value" or "Keyword = value"
+		i := strings.IndexAny(line, " \t=")
+		if i < 0 {
+			continue
+		}
+		key := strings.ToLower(line[:i])
+		value := strings.TrimPrefix(strings.TrimSpace(line[i+1:]), "=")
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+
+		switch key {
+		case "host":
+			current = nil
+			for _, pattern := range strings.Fields(value) {
+				if strings.ContainsAny(pattern, "*?!") {
+					continue
+				}
+				current = append(current, len(hosts))
+				hosts = append(hosts, SSHHost{
+					Name:   pattern,
+					Group:  DefaultSSHGroup,
+					Source: SSHSourceSSHConfig,
+				})
+			}
+		case "match":
+			current = nil
+		case "hostname":
+			for _, i := range current {
+				if hosts[i].HostName == "" {
+					hosts[i].HostName = value
+				}
+			}
+		case "user":
+			for _, i := range current {
+				if hosts[i].User == "" {
+					hosts[i].User = value
+				}
+			}
+		case "port":
+			port, _ := strconv.Atoi(value)
+			for _, i := range current {
+				if hosts[i].Port == 0 {
+					hosts[i].Port = port
+				}
+			}
+		case "identityfile":
+			for _, i := range current {
+				if hosts[i].IdentityFile == "" {
+					hosts[i].IdentityFile = value
+				}
+			}
+		}
+	}
+
+	return hosts, scanner.Err()
+}
@@ -0,0 +1,131 @@
+// Package startup measures the time spent initializing each module when
+// DevTrack starts (--profile-startup)
+package startup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Step is a measured initialization step
+type Step struct {
+	Name     string
+	Offset   time.Duration // Start time since the process start
+	Duration time.Duration // Zero for marks
+}
+
+var (
+	mu      sync.Mutex
+	enabled bool
+	begin   = time.Now() // Package initialization, close to the process start
+	steps   []Step
+)
+
+// Enable turns the profiling on (steps are not recorded until then)
+func Enable() {
+	mu.Lock()
+	defer mu.Unlock()
+	enabled = true
+}
+
+// Enabled returns true if the startup is profiled
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return enabled
+}
+
+// Track starts measuring a step and returns the function that ends it:
+//
+//	defer startup.Track("config")()
+func Track(name string) func() {
+	if !Enabled() {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		record(Step{Name: name, Offset: start.Sub(begin), Duration: time.Since(start)})
+	}
+}
+
+// Mark records a point of the startup (e.g. the first frame rendered),
+// only the first mark of a name is kept
+func Mark(name string) {
+	if !Enabled() {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, step := range steps {
+		if step.Name == name {
+			return
+		}
+	}
+	steps = append(steps, Step{Name: name, Offset: time.Since(begin)})
+}
+
+// record adds a measured step
+func record(step Step) {
+	mu.Lock()
+	defer mu.Unlock()
+	steps = append(steps, step)
+}
+
+// Steps returns the recorded steps in start order
+func Steps() []Step {
+	mu.Lock()
+	result := make([]Step, len(steps))
+	copy(result, steps)
+	mu.Unlock()
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Offset < result[j].Offset
+	})
+	return result
+}
+
+// Report formats the recorded steps as a table, with a bar showing the
+// share of each step in the total startup time
+func Report() string {
+	recorded := Steps()
+	if len(recorded) == 0 {
+		return "Startup profile: no step recorded\n"
+	}
+
+	var total time.Duration
+	for _, step := range recorded {
+		if end := step.Offset + step.Duration; end > total {
+			total = end
+		}
+	}
+
+	const barWidth = 20
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Startup profile (%s)\n", round(total))
+	fmt.Fprintf(&sb, "  %9s  %9s  %-*s  %s\n", "at", "took", barWidth, "", "step")
+	for _, step := range recorded {
+		if step.Duration == 0 {
+			fmt.Fprintf(&sb, "  %9s  %9s  %-*s  %s\n", round(step.Offset), "-", barWidth, "", step.Name)
+			continue
+		}
+		bar := 0
+		if total > 0 {
+			bar = int(float64(barWidth) * float64(step.Duration) / float64(total))
+		}
+		// Padded by hand: the bar characters are wider than one byte
+		fmt.Fprintf(&sb, "  %9s  %9s  %s%s  %s\n", round(step.Offset), round(step.Duration),
+			strings.Repeat("█", bar), strings.Repeat(" ", barWidth-bar), step.Name)
+	}
+	return sb.String()
+}
+
+// round rounds a duration for display
+func round(d time.Duration) time.Duration {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond)
+	}
+	return d.Round(100 * time.Microsecond)
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Category identifies a kind of reclaimable disk usage
+type Category string
+
+const (
+	CategoryArtifacts   Category = "artifacts"    // Build outputs (targets/, dist/)
+	CategoryNodeModules Category = "node_modules" // npm dependencies
+	CategoryGoCache     Category = "go_cache"     // Go build cache (shared across projects)
+	CategoryLogs        Category = "logs"         // Log files
+)
+
+// AllCategories lists categories in display order
+var AllCategories = []Category{
+	CategoryArtifacts,
+	CategoryNodeModules,
+	CategoryGoCache,
+	CategoryLogs,
+}
+
+// Label returns a human-readable label for the category
+func (c Category) Label() string {
+	switch c {
+	case CategoryArtifacts:
+		return "Build artifacts"
+	case CategoryNodeModules:
+		return "node_modules"
+	case CategoryGoCache:
+		return "Go build cache"
+	case CategoryLogs:
+		return "Logs"
+	default:
+		return string(c)
+	}
+}
+
+// Entry holds the disk usage of one category
+type Entry struct {
+	Category Category `json:"category"`
+	Paths    []string `json:"paths"` // Absolute paths counted in this entry
+	Bytes    int64    `json:"bytes"`
+	Files    int      `json:"files"`
+}
+
+// ProjectUsage holds the disk usage breakdown of a project
+type ProjectUsage struct {
+	ProjectID   string    `json:"project_id"`
+	ProjectName string    `json:"project_name"`
+	ProjectPath string    `json:"project_path"`
+	Entries     []Entry   `json:"entries"`
+	TotalBytes  int64     `json:"total_bytes"`
+	ScannedAt   time.Time `json:"scanned_at"`
+}
+
+// Report holds disk usage for all projects plus shared locations
+type Report struct {
+	Projects   []ProjectUsage `json:"projects"`
+	Shared     []Entry        `json:"shared"` // Go build cache, DevTrack logs
+	TotalBytes int64          `json:"total_bytes"`
+	ScannedAt  time.Time      `json:"scanned_at"`
+	Duration   time.Duration  `json:"duration"`
+}
+
+// FormatBytes formats a byte count as a human-readable size
+func FormatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
+}
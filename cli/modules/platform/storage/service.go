@@ -0,0 +1,434 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/trash"
+)
+
+// goArtifactDirs are the output directories of Go builds, at the project root
+var goArtifactDirs = []string{"targets"}
+
+// frontendArtifactDirs are the output directories of frontend builds, in the
+// component directory (same list as the frontend builder)
+var frontendArtifactDirs = []string{"dist", "build", "out", ".next"}
+
+// skipDirs are never descended into when looking for log files
+var skipDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+	"targets":      true,
+	"dist":         true,
+}
+
+// Service computes per-project disk usage and performs cleanups
+type Service struct {
+	projectService *projects.Service
+
+	mu       sync.RWMutex
+	goPath   string         // Go binary (for GOCACHE lookup and go clean)
+	trash    *trash.Service // Artifacts and node_modules are moved there (nil = no cleanup)
+	report   *Report
+	scanning bool
+}
+
+// NewService creates a new storage service
+func NewService(projectService *projects.Service, goPath string) *Service {
+	return &Service{
+		projectService: projectService,
+		goPath:         goPath,
+	}
+}
+
+// IsScanning returns true if a scan is in progress
+func (s *Service) IsScanning() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scanning
+}
+
+// GetReport returns the latest report (nil if never scanned)
+func (s *Service) GetReport() *Report {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.report
+}
+
+// Scan measures disk usage for all projects and shared locations
+func (s *Service) Scan(ctx context.Context) (*Report, error) {
+	s.mu.Lock()
+	if s.scanning {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("storage scan already running")
+	}
+	s.scanning = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.scanning = false
+		s.mu.Unlock()
+	}()
+
+	start := time.Now()
+	report := &Report{ScannedAt: start}
+
+	for _, project := range s.projectService.ListProjects() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		usage := s.scanProject(ctx, project)
+		report.Projects = append(report.Projects, usage)
+		report.TotalBytes += usage.TotalBytes
+	}
+
+	if dir := s.goCacheDir(); dir != "" {
+		entry := Entry{Category: CategoryGoCache}
+		s.addPath(ctx, &entry, dir)
+		report.Shared = append(report.Shared, entry)
+		report.TotalBytes += entry.Bytes
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		entry := Entry{Category: CategoryLogs}
+		matches, _ := filepath.Glob(filepath.Join(home, ".csd-devtrack", "*.log"))
+		for _, path := range matches {
+			s.addPath(ctx, &entry, path)
+		}
+		if len(entry.Paths) > 0 {
+			report.Shared = append(report.Shared, entry)
+			report.TotalBytes += entry.Bytes
+		}
+	}
+
+	report.Duration = time.Since(start)
+
+	s.mu.Lock()
+	s.report = report
+	s.mu.Unlock()
+
+	return report, nil
+}
+
+// scanProject measures the disk usage of a single project
+func (s *Service) scanProject(ctx context.Context, project *projects.Project) ProjectUsage {
+	usage := ProjectUsage{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		ProjectPath: project.Path,
+		ScannedAt:   time.Now(),
+	}
+
+	// Directories to inspect: project root + each component directory
+	roots := []string{project.Path}
+	for _, comp := range project.GetEnabledComponents() {
+		if comp.Path == "" {
+			continue
+		}
+		dir := filepath.Join(project.Path, comp.Path)
+		if !containsString(roots, dir) {
+			roots = append(roots, dir)
+		}
+	}
+
+	// Folders holding files tracked by git are sources, whatever their name
+	artifacts := Entry{Category: CategoryArtifacts}
+	for _, path := range artifactPaths(project) {
+		if exists(path) && !isTracked(ctx, project.Path, path) {
+			s.addPath(ctx, &artifacts, path)
+		}
+	}
+	nodeModules := Entry{Category: CategoryNodeModules}
+	for _, root := range roots {
+		if path := filepath.Join(root, "node_modules"); exists(path) && !isTracked(ctx, project.Path, path) {
+			s.addPath(ctx, &nodeModules, path)
+		}
+	}
+
+	logs := Entry{Category: CategoryLogs}
+	filepath.WalkDir(project.Path, func(path string, d fs.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return filepath.SkipAll
+		}
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+		if d.IsDir() {
+			if path != project.Path && skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(d.Name(), ".log") {
+			if info, err := d.Info(); err == nil {
+				logs.Paths = append(logs.Paths, path)
+				logs.Bytes += info.Size()
+				logs.Files++
+			}
+		}
+		return nil
+	})
+
+	for _, entry := range []Entry{artifacts, nodeModules, logs} {
+		usage.Entries = append(usage.Entries, entry)
+		usage.TotalBytes += entry.Bytes
+	}
+	return usage
+}
+
+// artifactPaths returns the build output folders of a project: the output
+// folders of its component types, and the artifacts folders of its config
+func artifactPaths(project *projects.Project) []string {
+	var paths []string
+	add := func(dir string, names ...string) {
+		for _, name := range names {
+			if path := filepath.Join(dir, name); !containsString(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+	for _, comp := range project.GetEnabledComponents() {
+		switch {
+		case projects.IsGoComponent(comp.Type):
+			add(project.Path, goArtifactDirs...)
+		case projects.IsFrontendComponent(comp.Type):
+			add(filepath.Join(project.Path, comp.Path), frontendArtifactDirs...)
+		}
+	}
+	add(project.Path, project.Artifacts...)
+	return paths
+}
+
+// exists returns true if the path exists
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// isTracked returns true if git tracks files under path (committed output
+// folder, Gradle build/ scripts...). When git cannot tell, the folder is
+// considered tracked if the project is a repository, so it is left alone.
+func isTracked(ctx context.Context, root, path string) bool {
+	cmd := exec.CommandContext(ctx, "git", "ls-files", "-z", "--", ".")
+	cmd.Dir = path
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && strings.Contains(stderr.String(), "not a git repository") {
+			return false
+		}
+		return exists(filepath.Join(root, ".git"))
+	}
+	return stdout.Len() > 0
+}
+
+// addPath adds a file or directory to an entry if it exists
+func (s *Service) addPath(ctx context.Context, entry *Entry, path string) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return
+	}
+	if !info.IsDir() {
+		entry.Paths = append(entry.Paths, path)
+		entry.Bytes += info.Size()
+		entry.Files++
+		return
+	}
+
+	bytes, files := dirSize(ctx, path)
+	entry.Paths = append(entry.Paths, path)
+	entry.Bytes += bytes
+	entry.Files += files
+}
+
+// dirSize returns the total size and file count of a directory tree
+func dirSize(ctx context.Context, root string) (int64, int) {
+	var total int64
+	var files int
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+				files++
+			}
+		}
+		return nil
+	})
+	return total, files
+}
+
+// SetTrash sets the trash the artifacts and node_modules are moved to
+func (s *Service) SetTrash(t *trash.Service) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trash = t
+}
+
+// SetGoPath updates the Go binary after a new capability detection
+func (s *Service) SetGoPath(goPath string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.goPath = goPath
+}
+
+// goBinary returns the Go binary, empty if not available
+func (s *Service) goBinary() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.goPath
+}
+
+// goCacheDir returns the Go build cache directory
+func (s *Service) goCacheDir() string {
+	if dir := os.Getenv("GOCACHE"); dir != "" && dir != "off" {
+		return dir
+	}
+	goPath := s.goBinary()
+	if goPath == "" {
+		return ""
+	}
+	out, err := exec.Command(goPath, "env", "GOCACHE").Output()
+	if err != nil {
+		return ""
+	}
+	dir := strings.TrimSpace(string(out))
+	if dir == "off" {
+		return ""
+	}
+	return dir
+}
+
+// Clean removes the reclaimable data of a category for a project.
+// An empty projectID targets the shared entries (Go build cache, DevTrack logs).
+// Only paths recorded by the last scan are touched; logs are truncated rather
+// than deleted so running processes keep valid file handles. Artifacts and
+// node_modules are moved to the trash, folders tracked by git since the scan
+// are skipped.
+func (s *Service) Clean(ctx context.Context, projectID string, category Category) (int64, error) {
+	s.mu.RLock()
+	report, trashService := s.report, s.trash
+	s.mu.RUnlock()
+	if report == nil {
+		return 0, fmt.Errorf("no storage scan available")
+	}
+
+	entry, usage := findEntry(report, projectID, category)
+	if entry == nil {
+		return 0, fmt.Errorf("nothing to clean for %s", category.Label())
+	}
+
+	switch category {
+	case CategoryGoCache:
+		goPath := s.goBinary()
+		if goPath == "" {
+			return 0, fmt.Errorf("go not found")
+		}
+		if out, err := exec.CommandContext(ctx, goPath, "clean", "-cache").CombinedOutput(); err != nil {
+			return 0, fmt.Errorf("go clean -cache failed: %s", strings.TrimSpace(string(out)))
+		}
+		return entry.Bytes, nil
+
+	case CategoryLogs:
+		for _, path := range entry.Paths {
+			if err := os.Truncate(path, 0); err != nil && !os.IsNotExist(err) {
+				return 0, fmt.Errorf("failed to truncate %s: %w", path, err)
+			}
+		}
+		return entry.Bytes, nil
+
+	case CategoryArtifacts, CategoryNodeModules:
+		if trashService == nil {
+			return 0, fmt.Errorf("trash not available")
+		}
+		freed := entry.Bytes
+		var paths []string
+		for _, path := range entry.Paths {
+			if !isInside(usage.ProjectPath, path) {
+				return 0, fmt.Errorf("refusing to delete %s (outside project)", path)
+			}
+			if isTracked(ctx, usage.ProjectPath, path) {
+				size, _ := dirSize(ctx, path)
+				freed -= size
+				continue
+			}
+			paths = append(paths, path)
+		}
+		if len(paths) == 0 {
+			return 0, fmt.Errorf("nothing to clean for %s (tracked by git)", category.Label())
+		}
+		label := fmt.Sprintf("%s: %s", usage.ProjectName, category.Label())
+		if _, err := trashService.Put(trash.KindBuildOutput, label, nil, paths); err != nil {
+			return 0, err
+		}
+		return freed, nil
+	}
+
+	return 0, fmt.Errorf("unknown category: %s", category)
+}
+
+// findEntry returns the report entry for a project/category and the usage of
+// the project (nil for shared entries)
+func findEntry(report *Report, projectID string, category Category) (*Entry, *ProjectUsage) {
+	if projectID == "" {
+		for i := range report.Shared {
+			if report.Shared[i].Category == category && len(report.Shared[i].Paths) > 0 {
+				return &report.Shared[i], nil
+			}
+		}
+		return nil, nil
+	}
+
+	for i := range report.Projects {
+		usage := &report.Projects[i]
+		if usage.ProjectID != projectID {
+			continue
+		}
+		for j := range usage.Entries {
+			if usage.Entries[j].Category == category && len(usage.Entries[j].Paths) > 0 {
+				return &usage.Entries[j], usage
+			}
+		}
+	}
+	return nil, nil
+}
+
+// isInside returns true if path is strictly inside root
+func isInside(root, path string) bool {
+	if root == "" {
+		return false
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != "." && !strings.HasPrefix(rel, "..")
+}
+
+// containsString returns true if the slice contains the string
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
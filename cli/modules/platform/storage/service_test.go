@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/trash"
+)
+
+func TestArtifactPathsByComponentType(t *testing.T) {
+	project := &projects.Project{
+		Path: "/p",
+		Components: map[projects.ComponentType]*projects.Component{
+			projects.ComponentBackend:  {Type: projects.ComponentBackend, Enabled: true},
+			projects.ComponentFrontend: {Type: projects.ComponentFrontend, Path: "web", Enabled: true},
+		},
+		Artifacts: []string{"reports"},
+	}
+
+	paths := artifactPaths(project)
+	for _, want := range []string{"/p/targets", "/p/web/dist", "/p/web/build", "/p/reports"} {
+		if !containsString(paths, want) {
+			t.Errorf("%s missing from %v", want, paths)
+		}
+	}
+	for _, unwanted := range []string{"/p/build", "/p/out", "/p/dist"} {
+		if containsString(paths, unwanted) {
+			t.Errorf("%s of a Go project counted as artifacts", unwanted)
+		}
+	}
+}
+
+func TestIsTracked(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v %s", args, err, out)
+		}
+	}
+	for _, file := range []string{"build/build.gradle", "out/app.js"} {
+		path := filepath.Join(root, file)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q")
+	git("add", "build")
+
+	ctx := context.Background()
+	if !isTracked(ctx, root, filepath.Join(root, "build")) {
+		t.Error("folder with tracked files not reported as tracked")
+	}
+	if isTracked(ctx, root, filepath.Join(root, "out")) {
+		t.Error("folder without tracked files reported as tracked")
+	}
+	if isTracked(ctx, root, t.TempDir()) {
+		t.Error("folder outside any repository reported as tracked")
+	}
+}
+
+func TestCleanMovesArtifactsToTrash(t *testing.T) {
+	root := t.TempDir()
+	dist := filepath.Join(root, "dist")
+	os.MkdirAll(dist, 0755)
+	if err := os.WriteFile(filepath.Join(dist, "app.js"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	trashService := trash.NewService(t.TempDir(), 30)
+	s := NewService(nil, "")
+	s.report = &Report{Projects: []ProjectUsage{{
+		ProjectID:   "p",
+		ProjectName: "p",
+		ProjectPath: root,
+		Entries:     []Entry{{Category: CategoryArtifacts, Paths: []string{dist}, Bytes: 1, Files: 1}},
+	}}}
+
+	if _, err := s.Clean(context.Background(), "p", CategoryArtifacts); err == nil {
+		t.Fatal("Clean succeeded without trash")
+	}
+	s.SetTrash(trashService)
+	if _, err := s.Clean(context.Background(), "p", CategoryArtifacts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dist); !os.IsNotExist(err) {
+		t.Fatalf("artifacts not moved: %v", err)
+	}
+	items := trashService.List()
+	if len(items) != 1 || items[0].Kind != trash.KindBuildOutput {
+		t.Fatalf("artifacts not recorded in the trash: %v", items)
+	}
+	if _, err := trashService.Restore(items[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dist, "app.js")); err != nil {
+		t.Fatalf("artifacts not restored: %v", err)
+	}
+}
@@ -14,6 +14,8 @@ import (
 
 	"csd-devtrack/cli/modules/core/processes"
 	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/system"
+	"csd-devtrack/cli/modules/platform/tracing"
 )
 
 // Manager supervises processes
@@ -21,28 +23,52 @@ type Manager struct {
 	processService *processes.Service
 	mu             sync.RWMutex
 	stopTimeout    time.Duration
+	jobs           map[int]uintptr // Windows job object per PID (kills the whole process tree)
+	sampling       sync.Once       // Starts the memory sampling of the processes
+	environment    EnvironmentFunc // Variables added by DevTrack's services (nil = none)
 }
 
+// EnvironmentFunc returns variables DevTrack's services add to the
+// environment of a component (e.g. the capture proxy)
+type EnvironmentFunc func(project *projects.Project, component *projects.Component) []string
+
+// memorySampleInterval is how often the memory of the running processes is
+// sampled (peak memory of the run history)
+const memorySampleInterval = 5 * time.Second
+
 // NewManager creates a new process manager
 func NewManager(processService *processes.Service) *Manager {
 	return &Manager{
 		processService: processService,
 		stopTimeout:    30 * time.Second,
+		jobs:           make(map[int]uintptr),
 	}
 }
 
+// SetEnvironment sets the variables added to the environment of the
+// components
+func (m *Manager) SetEnvironment(fn EnvironmentFunc) {
+	m.mu.Lock()
+	m.environment = fn
+	m.mu.Unlock()
+}
+
 // Start starts a component process
-func (m *Manager) Start(ctx context.Context, project *projects.Project, component *projects.Component) (*processes.Process, error) {
-	workDir := filepath.Join(project.Path, component.Path)
-	if component.Path == "" {
-		workDir = project.Path
-	}
+func (m *Manager) Start(ctx context.Context, project *projects.Project, component *projects.Component) (proc *processes.Process, err error) {
+	_, span := tracing.Start(ctx, "process.launch",
+		tracing.String("project", project.ID), tracing.String("component", string(component.Type)))
+	defer func() {
+		if proc != nil && proc.PID > 0 {
+			span.SetAttr(tracing.Int("pid", proc.PID))
+		}
+		span.SetError(err)
+		span.End()
+	}()
 
-	// Determine command and args
-	command, args := m.buildCommand(project, component)
+	launch := m.PlanStart(project, component)
 
 	// Create process
-	proc := processes.NewProcess(project.ID, component.Type, workDir, command, args)
+	proc = processes.NewProcess(project.ID, component.Type, launch.Dir, launch.Command, launch.Args)
 	proc.Port = component.Port
 	proc.SetState(processes.ProcessStateStarting)
 
@@ -57,9 +83,9 @@ func (m *Manager) Start(ctx context.Context, project *projects.Project, componen
 	})
 
 	// Create exec.Cmd
-	cmd := exec.CommandContext(ctx, command, args...)
-	cmd.Dir = workDir
-	cmd.Env = m.buildEnvironment(project, component)
+	cmd := exec.CommandContext(ctx, launch.Command, launch.Args...)
+	cmd.Dir = launch.Dir
+	cmd.Env = append(os.Environ(), launch.Env...)
 
 	// Set up process group for proper signal handling
 	m.setupProcessGroup(cmd)
@@ -82,6 +108,15 @@ func (m *Manager) Start(ctx context.Context, project *projects.Project, componen
 		return proc, fmt.Errorf("failed to start process: %w", err)
 	}
 
+	// Track child processes (job object on Windows)
+	if err := m.attachProcess(cmd); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		proc.SetState(processes.ProcessStateCrashed)
+		proc.LastError = err.Error()
+		return proc, fmt.Errorf("failed to start process: %w", err)
+	}
+
 	proc.SetCmd(cmd)
 	proc.SetPID(cmd.Process.Pid)
 	proc.StartedAt = time.Now()
@@ -103,6 +138,7 @@ func (m *Manager) Start(ctx context.Context, project *projects.Project, componen
 
 	// Monitor process
 	go m.monitor(proc)
+	m.sampling.Do(func() { go m.sampleMemory() })
 
 	return proc, nil
 }
@@ -145,21 +181,66 @@ func (m *Manager) buildCommand(project *projects.Project, component *projects.Co
 	return "", nil
 }
 
-// buildEnvironment builds the environment for a component
-func (m *Manager) buildEnvironment(project *projects.Project, component *projects.Component) []string {
-	env := os.Environ()
-
-	// Add component-specific environment
-	env = append(env, fmt.Sprintf("CSD_PROJECT=%s", project.ID))
-	env = append(env, fmt.Sprintf("CSD_COMPONENT=%s", component.Type))
+// componentEnvironment returns the environment of a component on top of
+// DevTrack's
+func (m *Manager) componentEnvironment(project *projects.Project, component *projects.Component) []string {
+	env := []string{
+		fmt.Sprintf("CSD_PROJECT=%s", project.ID),
+		fmt.Sprintf("CSD_COMPONENT=%s", component.Type),
+	}
 
 	if component.Port > 0 {
 		env = append(env, fmt.Sprintf("PORT=%d", component.Port))
 	}
 
+	// Local TLS certificate (HTTPS in development)
+	env = append(env, component.TLS.Environment()...)
+
+	m.mu.RLock()
+	environment := m.environment
+	m.mu.RUnlock()
+	if environment != nil {
+		env = append(env, environment(project, component)...)
+	}
+
 	return env
 }
 
+// Launch is how a component process is started
+type Launch struct {
+	Command string
+	Args    []string
+	Dir     string
+	Env     []string // Set on top of DevTrack's environment
+}
+
+// PlanStart returns how Start launches a component, without launching it
+// (dry run)
+func (m *Manager) PlanStart(project *projects.Project, component *projects.Component) Launch {
+	workDir := filepath.Join(project.Path, component.Path)
+	if component.Path == "" {
+		workDir = project.Path
+	}
+
+	// Determine command and args
+	command, args := m.buildCommand(project, component)
+
+	return Launch{
+		Command: command,
+		Args:    args,
+		Dir:     workDir,
+		Env:     m.componentEnvironment(project, component),
+	}
+}
+
+// PlanStop describes how Stop stops a process, without stopping it
+func (m *Manager) PlanStop(proc *processes.Process) string {
+	if runtime.GOOS == "windows" {
+		return fmt.Sprintf("Terminate the process tree of PID %d (job object)", proc.PID)
+	}
+	return fmt.Sprintf("Send SIGTERM to the process group of PID %d, SIGKILL after %s", proc.PID, m.stopTimeout)
+}
+
 // Stop stops a process gracefully
 func (m *Manager) Stop(ctx context.Context, proc *processes.Process, force bool) error {
 	if !proc.IsRunning() {
@@ -193,28 +274,14 @@ func (m *Manager) Stop(ctx context.Context, proc *processes.Process, force bool)
 		return fmt.Errorf("failed to send SIGTERM: %w", err)
 	}
 
-	// Wait for graceful shutdown with timeout
-	done := make(chan error, 1)
-	go func() {
-		done <- cmd.Wait()
-	}()
-
+	// Wait for graceful shutdown with timeout (monitor records the exit)
 	select {
 	case <-time.After(m.stopTimeout):
 		// Timeout - force kill
 		return m.Kill(proc)
-	case err := <-done:
-		now := time.Now()
-		proc.StoppedAt = &now
+	case <-proc.Exited():
 		proc.SetState(processes.ProcessStateStopped)
 
-		if err != nil {
-			if exitErr, ok := err.(*exec.ExitError); ok {
-				exitCode := exitErr.ExitCode()
-				proc.ExitCode = &exitCode
-			}
-		}
-
 		// Emit stopped event
 		m.emitEvent(processes.ProcessEvent{
 			Type:      processes.ProcessEventStopped,
@@ -268,7 +335,7 @@ func (m *Manager) Signal(proc *processes.Process, sig int) error {
 	return m.signalProcess(cmd, syscall.Signal(sig))
 }
 
-// Pause pauses a running process using SIGSTOP
+// Pause pauses a running process (SIGSTOP on Unix, suspended threads on Windows)
 func (m *Manager) Pause(proc *processes.Process) error {
 	cmd := proc.GetCmd()
 	if cmd == nil || cmd.Process == nil {
@@ -279,7 +346,7 @@ func (m *Manager) Pause(proc *processes.Process) error {
 		return nil // Already paused
 	}
 
-	if err := m.signalProcess(cmd, syscall.SIGSTOP); err != nil {
+	if err := m.pauseProcess(cmd); err != nil {
 		return fmt.Errorf("failed to pause process: %w", err)
 	}
 
@@ -298,7 +365,7 @@ func (m *Manager) Pause(proc *processes.Process) error {
 	return nil
 }
 
-// Resume resumes a paused process using SIGCONT
+// Resume resumes a paused process (SIGCONT on Unix, resumed threads on Windows)
 func (m *Manager) Resume(proc *processes.Process) error {
 	cmd := proc.GetCmd()
 	if cmd == nil || cmd.Process == nil {
@@ -309,7 +376,7 @@ func (m *Manager) Resume(proc *processes.Process) error {
 		return nil // Not paused
 	}
 
-	if err := m.signalProcess(cmd, syscall.SIGCONT); err != nil {
+	if err := m.resumeProcess(cmd); err != nil {
 		return fmt.Errorf("failed to resume process: %w", err)
 	}
 
@@ -342,14 +409,29 @@ func (m *Manager) monitor(proc *processes.Process) {
 	if cmd == nil {
 		return
 	}
+	defer proc.MarkExited()
+
+	oomKills := oomCounter(cmd.Process.Pid)
+	oomBefore, oomKnown := oomKills()
 
 	// Wait for process to exit
 	err := cmd.Wait()
+	m.releaseProcess(cmd)
 
 	// Process has exited
 	now := time.Now()
 	proc.StoppedAt = &now
 
+	term := m.termination(proc, cmd)
+	if oomAfter, ok := oomKills(); ok && oomKnown && oomAfter > oomBefore && term.Signal == "SIGKILL" &&
+		term.Reason != processes.ExitReasonStopped {
+		term.Reason = processes.ExitReasonOOM
+	}
+	proc.SetTermination(term)
+	if m.processService != nil {
+		m.processService.RecordTermination(proc.ID, term)
+	}
+
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			exitCode := exitErr.ExitCode()
@@ -358,7 +440,7 @@ func (m *Manager) monitor(proc *processes.Process) {
 			// If we weren't stopping it intentionally, it crashed
 			if proc.GetState() == processes.ProcessStateRunning {
 				proc.SetState(processes.ProcessStateCrashed)
-				proc.LastError = fmt.Sprintf("Process exited with code %d", exitCode)
+				proc.LastError = "Process ended: " + term.Describe()
 
 				// Emit crashed event
 				m.emitEvent(processes.ProcessEvent{
@@ -366,7 +448,7 @@ func (m *Manager) monitor(proc *processes.Process) {
 					ProcessID: proc.ID,
 					ProjectID: proc.ProjectID,
 					Component: string(proc.Component),
-					Message:   fmt.Sprintf("Crashed %s (exit code: %d)", proc.ID, exitCode),
+					Message:   fmt.Sprintf("Crashed %s (%s)", proc.ID, term.Describe()),
 					Timestamp: time.Now(),
 				})
 			}
@@ -378,12 +460,75 @@ func (m *Manager) monitor(proc *processes.Process) {
 	}
 }
 
+// termination describes how a process ended from its wait status: exit
+// code, and the signal that killed it (or the child of its shell)
+func (m *Manager) termination(proc *processes.Process, cmd *exec.Cmd) processes.Termination {
+	term := processes.Termination{
+		At:       time.Now(),
+		Reason:   processes.ExitReasonExited,
+		ExitCode: -1,
+	}
+	if !proc.StartedAt.IsZero() {
+		term.StartedAt = proc.StartedAt
+		term.Uptime = term.At.Sub(proc.StartedAt).Round(time.Second)
+	}
+	term.PeakRSS = proc.PeakRSS()
+
+	if ps := cmd.ProcessState; ps != nil {
+		term.ExitCode = ps.ExitCode()
+		if status, ok := ps.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			term.Signal = processes.SignalName(status.Signal())
+			term.Reason = processes.ExitReasonSignal
+		} else if code := term.ExitCode; runtime.GOOS != "windows" && code > 128 && code <= 128+64 {
+			// sh -c reports the signal its command died of as 128+n
+			term.Signal = processes.SignalName(syscall.Signal(code - 128))
+		}
+	}
+
+	if state := proc.GetState(); state == processes.ProcessStateStopping || state == processes.ProcessStateStopped {
+		term.Reason = processes.ExitReasonStopped
+	}
+	return term
+}
+
+// sampleMemory samples the memory of the running process trees for the
+// peak memory of their runs (one process table read for all of them)
+func (m *Manager) sampleMemory() {
+	for range time.Tick(memorySampleInterval) {
+		if m.processService == nil {
+			return
+		}
+		running := m.processService.GetRunningProcesses()
+		if len(running) == 0 {
+			continue
+		}
+		table, err := system.ReadProcessTable()
+		if err != nil {
+			continue
+		}
+		for _, proc := range running {
+			if usage, ok := table.Usage(proc.PID); ok {
+				proc.UpdatePeakRSS(usage.RSS)
+			}
+		}
+	}
+}
+
 // readOutput reads output from a pipe and stores it in the process log buffer
 func (m *Manager) readOutput(proc *processes.Process, pipe interface{ Read([]byte) (int, error) }, isStderr bool) {
+	devServer := projects.IsFrontendComponent(proc.Component)
 	scanner := bufio.NewScanner(pipe)
 	for scanner.Scan() {
 		line := scanner.Text()
-		proc.AppendLog(line)
+		if isStderr {
+			proc.AppendStderr(line)
+		} else {
+			proc.AppendLog(line)
+		}
+		if devServer {
+			// URLs, HMR status and compile errors of the dev server
+			proc.ParseDevServerOutput(line)
+		}
 
 		// Emit output event
 		eventType := processes.ProcessEventOutput
@@ -0,0 +1,45 @@
+package supervisor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// oomCounter returns a reader of the kernel OOM kill counter covering a
+// process: the one of its cgroup (cgroup v2 memory.events), else the
+// system-wide one (/proc/vmstat). Read when the process starts and when it
+// dies, an increase tells an OOM kill. Resolved while the process is alive.
+func oomCounter(pid int) func() (int64, bool) {
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid)); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			cgroup, ok := strings.CutPrefix(line, "0::")
+			if !ok {
+				continue
+			}
+			events := filepath.Join("/sys/fs/cgroup", cgroup, "memory.events")
+			if _, ok := readKernelCounter(events, "oom_kill"); ok {
+				return func() (int64, bool) { return readKernelCounter(events, "oom_kill") }
+			}
+		}
+	}
+	return func() (int64, bool) { return readKernelCounter("/proc/vmstat", "oom_kill") }
+}
+
+// readKernelCounter reads a "name value" line of a kernel counters file
+func readKernelCounter(path, name string) (int64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == name {
+			n, err := strconv.ParseInt(fields[1], 10, 64)
+			return n, err == nil
+		}
+	}
+	return 0, false
+}
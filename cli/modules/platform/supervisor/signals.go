@@ -36,3 +36,19 @@ func (m *Manager) signalProcess(cmd *exec.Cmd, sig syscall.Signal) error {
 func (m *Manager) killProcess(cmd *exec.Cmd) error {
 	return m.signalProcess(cmd, syscall.SIGKILL)
 }
+
+// attachProcess is a no-op on Unix (the process group is set up before start)
+func (m *Manager) attachProcess(cmd *exec.Cmd) error { return nil }
+
+// releaseProcess is a no-op on Unix
+func (m *Manager) releaseProcess(cmd *exec.Cmd) {}
+
+// pauseProcess suspends the process group (SIGSTOP)
+func (m *Manager) pauseProcess(cmd *exec.Cmd) error {
+	return m.signalProcess(cmd, syscall.SIGSTOP)
+}
+
+// resumeProcess resumes the process group (SIGCONT)
+func (m *Manager) resumeProcess(cmd *exec.Cmd) error {
+	return m.signalProcess(cmd, syscall.SIGCONT)
+}
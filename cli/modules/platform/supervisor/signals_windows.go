@@ -3,16 +3,139 @@
 package supervisor
 
 import (
+	"fmt"
 	"os/exec"
 	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ntdll process suspension (not exposed by kernel32)
+var (
+	ntdll                = windows.NewLazySystemDLL("ntdll.dll")
+	procNtSuspendProcess = ntdll.NewProc("NtSuspendProcess")
+	procNtResumeProcess  = ntdll.NewProc("NtResumeProcess")
 )
 
 // setupProcessGroup sets up process handling for Windows
 func (m *Manager) setupProcessGroup(cmd *exec.Cmd) {
-	// On Windows, we use CREATE_NEW_PROCESS_GROUP
+	// On Windows, we use CREATE_NEW_PROCESS_GROUP. The process is created
+	// suspended so that it is in its job object before it can start children
+	// (resumed by attachProcess).
 	cmd.SysProcAttr = &syscall.SysProcAttr{
-		CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP,
+		CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | windows.CREATE_SUSPENDED,
+	}
+}
+
+// attachProcess puts a started process in a job object so that stopping it
+// also stops its children (Windows has no process groups to signal), then
+// resumes it. A process that cannot be resumed is returned as an error.
+func (m *Manager) attachProcess(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	m.assignJob(cmd)
+	return resumeMainThread(uint32(cmd.Process.Pid))
+}
+
+// assignJob puts a suspended process in a new job object. Without a job
+// object, the process still runs but its children are not tracked.
+func (m *Manager) assignJob(cmd *exec.Cmd) {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return
+	}
+
+	// Kill the remaining processes if DevTrack exits without stopping them
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{
+		BasicLimitInformation: windows.JOBOBJECT_BASIC_LIMIT_INFORMATION{
+			LimitFlags: windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE,
+		},
+	}
+	if _, err := windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+		windows.CloseHandle(job)
+		return
+	}
+
+	process, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(cmd.Process.Pid))
+	if err != nil {
+		windows.CloseHandle(job)
+		return
+	}
+	defer windows.CloseHandle(process)
+
+	if err := windows.AssignProcessToJobObject(job, process); err != nil {
+		windows.CloseHandle(job)
+		return
 	}
+
+	m.mu.Lock()
+	m.jobs[cmd.Process.Pid] = uintptr(job)
+	m.mu.Unlock()
+}
+
+// resumeMainThread resumes a process created with CREATE_SUSPENDED: its main
+// thread is its only thread (the thread handle is not kept by os/exec)
+func resumeMainThread(pid uint32) error {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPTHREAD, 0)
+	if err != nil {
+		return fmt.Errorf("failed to list threads: %w", err)
+	}
+	defer windows.CloseHandle(snapshot)
+
+	entry := windows.ThreadEntry32{Size: uint32(unsafe.Sizeof(windows.ThreadEntry32{}))}
+	resumed := false
+	for err = windows.Thread32First(snapshot, &entry); err == nil; err = windows.Thread32Next(snapshot, &entry) {
+		if entry.OwnerProcessID != pid {
+			continue
+		}
+		thread, err := windows.OpenThread(windows.THREAD_SUSPEND_RESUME, false, entry.ThreadID)
+		if err != nil {
+			return fmt.Errorf("failed to open thread %d: %w", entry.ThreadID, err)
+		}
+		_, err = windows.ResumeThread(thread)
+		windows.CloseHandle(thread)
+		if err != nil {
+			return fmt.Errorf("failed to resume thread %d: %w", entry.ThreadID, err)
+		}
+		resumed = true
+	}
+	if !resumed {
+		return fmt.Errorf("no thread found for PID %d", pid)
+	}
+	return nil
+}
+
+// releaseProcess closes the job object of an exited process
+func (m *Manager) releaseProcess(cmd *exec.Cmd) {
+	if job, ok := m.takeJob(cmd); ok {
+		windows.CloseHandle(job)
+	}
+}
+
+// job returns the job object of a process
+func (m *Manager) job(cmd *exec.Cmd) (windows.Handle, bool) {
+	if cmd.Process == nil {
+		return 0, false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	job, ok := m.jobs[cmd.Process.Pid]
+	return windows.Handle(job), ok
+}
+
+// takeJob returns and forgets the job object of a process
+func (m *Manager) takeJob(cmd *exec.Cmd) (windows.Handle, bool) {
+	if cmd.Process == nil {
+		return 0, false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[cmd.Process.Pid]
+	delete(m.jobs, cmd.Process.Pid)
+	return windows.Handle(job), ok
 }
 
 // signalProcess sends a signal to a process on Windows
@@ -22,15 +145,80 @@ func (m *Manager) signalProcess(cmd *exec.Cmd, sig syscall.Signal) error {
 		return nil
 	}
 
-	// On Windows, we can only really kill the process
+	// On Windows, we can only really kill the process tree
 	// SIGTERM is simulated as a kill
-	return cmd.Process.Kill()
+	return m.killProcess(cmd)
 }
 
-// killProcess forcefully kills a process on Windows
+// killProcess forcefully kills a process and its children on Windows
 func (m *Manager) killProcess(cmd *exec.Cmd) error {
 	if cmd.Process == nil {
 		return nil
 	}
+	if job, ok := m.job(cmd); ok {
+		return windows.TerminateJobObject(job, 1)
+	}
 	return cmd.Process.Kill()
 }
+
+// pauseProcess suspends all processes of the job (or the process alone)
+func (m *Manager) pauseProcess(cmd *exec.Cmd) error {
+	return m.forEachJobProcess(cmd, procNtSuspendProcess)
+}
+
+// resumeProcess resumes all processes of the job (or the process alone)
+func (m *Manager) resumeProcess(cmd *exec.Cmd) error {
+	return m.forEachJobProcess(cmd, procNtResumeProcess)
+}
+
+// forEachJobProcess calls an ntdll process function on each process of the job
+func (m *Manager) forEachJobProcess(cmd *exec.Cmd, proc *windows.LazyProc) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	if err := proc.Find(); err != nil {
+		return fmt.Errorf("%s not available: %w", proc.Name, err)
+	}
+
+	pids := []uint32{uint32(cmd.Process.Pid)}
+	if job, ok := m.job(cmd); ok {
+		if jobPids, err := jobProcessIDs(job); err == nil && len(jobPids) > 0 {
+			pids = jobPids
+		}
+	}
+
+	for _, pid := range pids {
+		handle, err := windows.OpenProcess(windows.PROCESS_SUSPEND_RESUME, false, pid)
+		if err != nil {
+			continue // Exited in the meantime
+		}
+		status, _, _ := proc.Call(uintptr(handle))
+		windows.CloseHandle(handle)
+		if status != 0 {
+			return fmt.Errorf("%s failed for PID %d: NTSTATUS 0x%x", proc.Name, pid, status)
+		}
+	}
+	return nil
+}
+
+// jobProcessIDList mirrors JOBOBJECT_BASIC_PROCESS_ID_LIST with room for 256 processes
+type jobProcessIDList struct {
+	NumberOfAssignedProcesses uint32
+	NumberOfProcessIdsInList  uint32
+	ProcessIdList             [256]uintptr
+}
+
+// jobProcessIDs lists the processes of a job object
+func jobProcessIDs(job windows.Handle) ([]uint32, error) {
+	var list jobProcessIDList
+	if err := windows.QueryInformationJobObject(job, windows.JobObjectBasicProcessIdList,
+		uintptr(unsafe.Pointer(&list)), uint32(unsafe.Sizeof(list)), nil); err != nil {
+		return nil, err
+	}
+
+	pids := make([]uint32, 0, list.NumberOfProcessIdsInList)
+	for i := uint32(0); i < list.NumberOfProcessIdsInList; i++ {
+		pids = append(pids, uint32(list.ProcessIdList[i]))
+	}
+	return pids, nil
+}
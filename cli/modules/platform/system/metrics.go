@@ -58,12 +58,9 @@ func (mc *MetricsCollector) Start() {
 	mc.collect()
 
 	go func() {
-		ticker := time.NewTicker(mc.refreshRate)
-		defer ticker.Stop()
-
 		for {
 			select {
-			case <-ticker.C:
+			case <-time.After(mc.interval()):
 				mc.collect()
 			case <-mc.stopCh:
 				return
@@ -72,6 +69,23 @@ func (mc *MetricsCollector) Start() {
 	}()
 }
 
+// SetRefreshRate changes the collection interval (applies from the next cycle)
+func (mc *MetricsCollector) SetRefreshRate(refreshRate time.Duration) {
+	if refreshRate < time.Second {
+		refreshRate = time.Second
+	}
+	mc.mu.Lock()
+	mc.refreshRate = refreshRate
+	mc.mu.Unlock()
+}
+
+// interval returns the current collection interval
+func (mc *MetricsCollector) interval() time.Duration {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	return mc.refreshRate
+}
+
 // Stop stops the metrics collection
 func (mc *MetricsCollector) Stop() {
 	mc.mu.Lock()
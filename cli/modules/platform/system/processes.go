@@ -0,0 +1,108 @@
+package system
+
+import (
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// ProcessUsage is the resource usage of a process and its descendants
+type ProcessUsage struct {
+	PID     int
+	Name    string    // Executable name of the process
+	Command string    // Command line of the process
+	Started time.Time // Start time of the process
+	Procs   int       // Processes in the tree
+	RSS     uint64    // Resident memory of the tree in bytes
+	CPUTime float64   // CPU seconds used by the tree (user + system)
+}
+
+// ProcessTable is a snapshot of the process hierarchy, to sum the usage of
+// process trees without listing the processes once per tree
+type ProcessTable struct {
+	procs    map[int]*process.Process
+	children map[int][]int // PID -> child PIDs
+}
+
+// ReadProcessTable reads the current processes and their parents
+func ReadProcessTable() (*ProcessTable, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	table := &ProcessTable{
+		procs:    make(map[int]*process.Process, len(procs)),
+		children: make(map[int][]int),
+	}
+	for _, p := range procs {
+		pid := int(p.Pid)
+		table.procs[pid] = p
+		if ppid, err := p.Ppid(); err == nil && int(ppid) != pid {
+			table.children[int(ppid)] = append(table.children[int(ppid)], pid)
+		}
+	}
+	return table, nil
+}
+
+// Exists returns true if a process was running when the table was read
+func (t *ProcessTable) Exists(pid int) bool {
+	_, ok := t.procs[pid]
+	return ok
+}
+
+// Children returns the direct children of a process
+func (t *ProcessTable) Children(pid int) []int {
+	return t.children[pid]
+}
+
+// Usage returns the usage of a process and all its descendants
+// (ok is false if the process is gone)
+func (t *ProcessTable) Usage(pid int) (ProcessUsage, bool) {
+	p, ok := t.procs[pid]
+	if !ok {
+		return ProcessUsage{PID: pid}, false
+	}
+
+	usage := ProcessUsage{PID: pid}
+	usage.Name, _ = p.Name()
+	usage.Command, _ = p.Cmdline()
+	if created, err := p.CreateTime(); err == nil {
+		usage.Started = time.UnixMilli(created)
+	}
+
+	// Descendants are walked breadth first (visited guards against PID reuse loops)
+	visited := map[int]bool{}
+	queue := []int{pid}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		proc, ok := t.procs[current]
+		if !ok {
+			continue
+		}
+		usage.Procs++
+		if mem, err := proc.MemoryInfo(); err == nil {
+			usage.RSS += mem.RSS
+		}
+		if times, err := proc.Times(); err == nil {
+			usage.CPUTime += times.User + times.System
+		}
+		queue = append(queue, t.children[current]...)
+	}
+	return usage, true
+}
+
+// TerminateProcess asks a process to exit (SIGTERM, killed on Windows)
+func TerminateProcess(pid int) error {
+	p, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return err
+	}
+	return p.Terminate()
+}
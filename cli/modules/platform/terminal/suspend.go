@@ -0,0 +1,149 @@
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/fileutil"
+)
+
+// SuspendedSession is an embedded terminal suspended while idle, with what
+// is needed to resume it
+type SuspendedSession struct {
+	ID          string    `json:"id"`                 // Terminal session ID
+	Label       string    `json:"label,omitempty"`    // Name shown (e.g. SSH host)
+	TmuxName    string    `json:"tmux_name"`          // Tmux session
+	Command     string    `json:"command,omitempty"`  // Custom command (empty = Claude)
+	Args        []string  `json:"args,omitempty"`     // Arguments of the custom command
+	WorkDir     string    `json:"work_dir,omitempty"` // Working directory
+	Stopped     bool      `json:"stopped,omitempty"`  // Tmux session ended (else detached)
+	SuspendedAt time.Time `json:"suspended_at"`
+}
+
+// PrefixOf returns the prefix of a tmux session name, "" if not a DevTrack one
+func PrefixOf(tmuxName string) string {
+	for _, p := range AllPrefixes() {
+		if strings.HasPrefix(tmuxName, p) {
+			return p
+		}
+	}
+	return ""
+}
+
+// SuspendStore keeps the suspended terminals, persisted in a JSON file
+type SuspendStore struct {
+	file string // Store file (empty = not persisted)
+
+	mu       sync.RWMutex
+	sessions map[string]SuspendedSession // ID -> session
+}
+
+// NewSuspendStore creates a store persisting the suspended terminals in file
+func NewSuspendStore(file string) *SuspendStore {
+	s := &SuspendStore{file: file, sessions: make(map[string]SuspendedSession)}
+	s.load()
+	return s
+}
+
+// Get returns a suspended terminal
+func (s *SuspendStore) Get(id string) (SuspendedSession, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sess, ok := s.sessions[id]
+	return sess, ok
+}
+
+// List returns the suspended terminals, most recently suspended first
+func (s *SuspendStore) List() []SuspendedSession {
+	s.mu.RLock()
+	result := make([]SuspendedSession, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		result = append(result, sess)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].SuspendedAt.After(result[j].SuspendedAt)
+	})
+	return result
+}
+
+// Add records a suspended terminal
+func (s *SuspendStore) Add(sess SuspendedSession) error {
+	s.mu.Lock()
+	s.sessions[sess.ID] = sess
+	s.mu.Unlock()
+	return s.save()
+}
+
+// Remove forgets suspended terminals (resumed or gone)
+func (s *SuspendStore) Remove(ids ...string) error {
+	s.mu.Lock()
+	removed := false
+	for _, id := range ids {
+		if _, ok := s.sessions[id]; ok {
+			delete(s.sessions, id)
+			removed = true
+		}
+	}
+	s.mu.Unlock()
+
+	if !removed {
+		return nil
+	}
+	return s.save()
+}
+
+// load reads the store file
+func (s *SuspendStore) load() {
+	if s.file == "" {
+		return
+	}
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		return // Nothing suspended yet
+	}
+
+	var sessions []SuspendedSession
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return
+	}
+	for _, sess := range sessions {
+		s.sessions[sess.ID] = sess
+	}
+}
+
+// save writes the store to disk atomically
+func (s *SuspendStore) save() error {
+	if s.file == "" {
+		return nil
+	}
+
+	// Held while writing: concurrent saves share the temporary file
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions := make([]SuspendedSession, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		sessions = append(sessions, sess)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID < sessions[j].ID })
+	data, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.file), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(s.file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write suspended terminals: %w", err)
+	}
+	return nil
+}
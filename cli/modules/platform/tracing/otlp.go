@@ -0,0 +1,288 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules"
+	"csd-devtrack/cli/modules/platform/config"
+)
+
+// queueSize is the number of ended spans waiting for export, dropped beyond
+const queueSize = 4096
+
+// batchSize is the number of spans sent at most per export request
+const batchSize = 512
+
+// flushInterval is the longest time an ended span waits for its export
+const flushInterval = 5 * time.Second
+
+// otlpExporter sends the ended spans in batches to an OTLP/HTTP endpoint,
+// JSON encoded (POST /v1/traces)
+type otlpExporter struct {
+	url      string
+	headers  map[string]string
+	resource otlpResource
+	client   *http.Client
+	onError  func(error)
+
+	queue   chan *Span
+	done    chan struct{}
+	stopped sync.WaitGroup
+}
+
+// Enable starts recording spans and exporting them to the configured
+// endpoint. onError reports the failed exports, once until one succeeds.
+func Enable(cfg *config.TracingConfig, onError func(error)) {
+	url := strings.TrimRight(cfg.Endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	hostname, _ := os.Hostname()
+
+	e := &otlpExporter{
+		url:     url,
+		headers: cfg.Headers,
+		resource: otlpResource{Attributes: otlpAttributes([]Attr{
+			String("service.name", cfg.ServiceName),
+			String("service.version", modules.AppVersion),
+			String("host.name", hostname),
+			Int("process.pid", os.Getpid()),
+		})},
+		client:  &http.Client{Timeout: 10 * time.Second},
+		onError: onError,
+		queue:   make(chan *Span, queueSize),
+		done:    make(chan struct{}),
+	}
+	e.stopped.Add(1)
+	go e.run()
+
+	if previous := exporter.Swap(e); previous != nil {
+		previous.stop()
+	}
+}
+
+// Shutdown stops recording spans and exports the pending ones
+func Shutdown() {
+	if e := exporter.Swap(nil); e != nil {
+		e.stop()
+	}
+}
+
+// Endpoint returns the URL the spans are exported to, empty when disabled
+func Endpoint() string {
+	if e := exporter.Load(); e != nil {
+		return e.url
+	}
+	return ""
+}
+
+// enqueue queues an ended span, dropped when the queue is full
+func (e *otlpExporter) enqueue(span *Span) {
+	select {
+	case e.queue <- span:
+	default:
+	}
+}
+
+// stop exports the queued spans and ends the export loop
+func (e *otlpExporter) stop() {
+	close(e.done)
+	e.stopped.Wait()
+}
+
+// run exports the spans when a batch is full or every flushInterval
+func (e *otlpExporter) run() {
+	defer e.stopped.Done()
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	failing := false
+	var batch []*Span
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		err := e.export(batch)
+		batch = batch[:0]
+		if err != nil && !failing && e.onError != nil {
+			e.onError(err)
+		}
+		failing = err != nil
+	}
+
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.done:
+			for {
+				select {
+				case span := <-e.queue:
+					batch = append(batch, span)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// export sends a batch of spans
+func (e *otlpExporter) export(spans []*Span) error {
+	otlpSpans := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		otlpSpans = append(otlpSpans, span.otlp())
+	}
+	body, err := json.Marshal(otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: e.resource,
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "csd-devtrack", Version: modules.AppVersion},
+			Spans: otlpSpans,
+		}},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range e.headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("tracing export: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("tracing export: %s returned %s", e.url, resp.Status)
+	}
+	return nil
+}
+
+// OTLP/JSON encoding of the trace export request (64-bit integers as
+// strings, trace and span IDs in hex)
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"` // 2 = error
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+// otlp encodes an ended span
+func (s *Span) otlp() otlpSpan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	span := otlpSpan{
+		TraceID:           hex.EncodeToString(s.traceID[:]),
+		SpanID:            hex.EncodeToString(s.spanID[:]),
+		Name:              s.name,
+		Kind:              1, // Internal
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		Attributes:        otlpAttributes(s.attrs),
+	}
+	if s.parentID != [8]byte{} {
+		span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+	if s.err != "" {
+		span.Status = &otlpStatus{Code: 2, Message: s.err}
+	}
+	return span
+}
+
+// otlpAttributes encodes attributes, by the type of their value
+func otlpAttributes(attrs []Attr) []otlpAttribute {
+	result := make([]otlpAttribute, 0, len(attrs))
+	for _, attr := range attrs {
+		var value otlpValue
+		switch v := attr.Value.(type) {
+		case string:
+			value.StringValue = &v
+		case int64:
+			s := strconv.FormatInt(v, 10)
+			value.IntValue = &s
+		case int:
+			s := strconv.Itoa(v)
+			value.IntValue = &s
+		case float64:
+			value.DoubleValue = &v
+		case bool:
+			value.BoolValue = &v
+		default:
+			s := fmt.Sprint(v)
+			value.StringValue = &s
+		}
+		result = append(result, otlpAttribute{Key: attr.Key, Value: value})
+	}
+	return result
+}
@@ -0,0 +1,122 @@
+// Package tracing records OpenTelemetry spans of DevTrack's own operations
+// (builds, git scans, process launches, presenter events, session parsing)
+// and exports them over OTLP/HTTP, to analyze where DevTrack spends its time
+// with standard tooling (Jaeger, Tempo, an OpenTelemetry collector...)
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Attr is an attribute of a span (string, int, int64, float64 or bool value)
+type Attr struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute
+func String(key, value string) Attr {
+	return Attr{Key: key, Value: value}
+}
+
+// Int returns an integer attribute
+func Int(key string, value int) Attr {
+	return Attr{Key: key, Value: int64(value)}
+}
+
+// Bool returns a boolean attribute
+func Bool(key string, value bool) Attr {
+	return Attr{Key: key, Value: value}
+}
+
+// Span is a timed operation. A nil span (tracing disabled) ignores every
+// call, so the instrumented code does not check whether tracing is on.
+type Span struct {
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte // Zero for a root span
+	name     string
+	start    time.Time
+
+	mu    sync.Mutex
+	end   time.Time
+	attrs []Attr
+	err   string
+}
+
+// spanKey is the context key of the current span
+type spanKey struct{}
+
+// exporter is the running exporter, nil while tracing is disabled
+var exporter atomic.Pointer[otlpExporter]
+
+// Enabled returns true if spans are recorded
+func Enabled() bool {
+	return exporter.Load() != nil
+}
+
+// Start starts a span, child of the span of ctx if any, and returns the
+// context carrying it:
+//
+//	ctx, span := tracing.Start(ctx, "git.poll")
+//	defer span.End()
+func Start(ctx context.Context, name string, attrs ...Attr) (context.Context, *Span) {
+	if !Enabled() {
+		return ctx, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	span := &Span{name: name, start: time.Now(), attrs: attrs}
+	rand.Read(span.spanID[:])
+	if parent, ok := ctx.Value(spanKey{}).(*Span); ok {
+		span.traceID = parent.traceID
+		span.parentID = parent.spanID
+	} else {
+		rand.Read(span.traceID[:])
+	}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SetAttr adds attributes to the span
+func (s *Span) SetAttr(attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.attrs = append(s.attrs, attrs...)
+	s.mu.Unlock()
+}
+
+// SetError marks the span as failed (nil errors are ignored)
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	s.err = err.Error()
+	s.mu.Unlock()
+}
+
+// End ends the span and queues it for export (only the first call counts)
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if !s.end.IsZero() {
+		s.mu.Unlock()
+		return
+	}
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	if e := exporter.Load(); e != nil {
+		e.enqueue(s)
+	}
+}
@@ -0,0 +1,51 @@
+package transfer
+
+import (
+	"time"
+
+	"csd-devtrack/cli/modules/platform/shell"
+)
+
+// Direction is the direction of a transfer
+type Direction string
+
+const (
+	DirectionPush Direction = "push" // Local project -> remote host
+	DirectionPull Direction = "pull" // Remote host -> local project
+)
+
+// State is the state of a transfer
+type State string
+
+const (
+	StateRunning State = "running"
+	StateDone    State = "done"
+	StateFailed  State = "failed"
+)
+
+// Request describes a transfer to start
+type Request struct {
+	ProjectID  string
+	Direction  Direction
+	Host       shell.SSHHost
+	LocalPath  string // Absolute local file or directory
+	RemotePath string // Path on the host (relative = home directory)
+}
+
+// Transfer is a file transfer between a local project and a remote host
+type Transfer struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"project_id"`
+	Direction   Direction `json:"direction"`
+	HostName    string    `json:"host_name"`
+	Source      string    `json:"source"`      // Source as passed to the tool
+	Destination string    `json:"destination"` // Destination as passed to the tool
+	Tool        string    `json:"tool"`        // rsync or scp
+	State       State     `json:"state"`
+	Percent     int       `json:"percent"`     // 0-100, -1 if the tool reports no progress
+	Transferred string    `json:"transferred"` // Bytes transferred so far (rsync)
+	Speed       string    `json:"speed"`       // Current speed (rsync)
+	Error       string    `json:"error,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+	FinishedAt  time.Time `json:"finished_at,omitempty"`
+}
@@ -0,0 +1,266 @@
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/shell"
+	"csd-devtrack/cli/modules/platform/storage"
+)
+
+// maxHistory is the number of finished transfers kept
+const maxHistory = 20
+
+// rsyncProgress matches an rsync --info=progress2 line: "1,234,567  42%  1.23MB/s  0:00:05"
+var rsyncProgress = regexp.MustCompile(`^\s*([\d,]+)\s+(\d+)%\s+(\S+/s)`)
+
+// Service runs file transfers with rsync (preferred) or scp over ssh.
+// Transfers are non-interactive: the host must accept key-based authentication.
+type Service struct {
+	mu        sync.RWMutex
+	rsyncPath string
+	scpPath   string
+	transfers []*Transfer // Most recent last
+	nextID    int
+}
+
+// NewService creates a new transfer service (empty paths = tool not available)
+func NewService(rsyncPath, scpPath string) *Service {
+	return &Service{
+		rsyncPath: rsyncPath,
+		scpPath:   scpPath,
+	}
+}
+
+// SetToolPaths updates the tool paths after a new capability detection
+func (s *Service) SetToolPaths(rsyncPath, scpPath string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rsyncPath, s.scpPath = rsyncPath, scpPath
+}
+
+// toolPaths returns the rsync and scp paths
+func (s *Service) toolPaths() (rsyncPath, scpPath string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rsyncPath, s.scpPath
+}
+
+// IsAvailable returns true if a transfer tool is available
+func (s *Service) IsAvailable() bool {
+	rsyncPath, scpPath := s.toolPaths()
+	return rsyncPath != "" || scpPath != ""
+}
+
+// GetTransfers returns a copy of the transfers, most recent last
+func (s *Service) GetTransfers() []Transfer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Transfer, len(s.transfers))
+	for i, t := range s.transfers {
+		result[i] = *t
+	}
+	return result
+}
+
+// Start starts a transfer in the background. onUpdate is called with a copy
+// of the transfer on each progress change and when it finishes.
+func (s *Service) Start(ctx context.Context, req Request, onUpdate func(Transfer)) (*Transfer, error) {
+	if !s.IsAvailable() {
+		return nil, fmt.Errorf("neither rsync nor scp is available")
+	}
+	if req.LocalPath == "" || req.RemotePath == "" {
+		return nil, fmt.Errorf("local and remote paths are required")
+	}
+
+	remote := remoteSpec(req.Host, req.RemotePath)
+	source, destination := req.LocalPath, remote
+	if req.Direction == DirectionPull {
+		source, destination = remote, req.LocalPath
+	}
+
+	var cmd *exec.Cmd
+	tool := "rsync"
+	if rsyncPath, scpPath := s.toolPaths(); rsyncPath != "" {
+		cmd = exec.CommandContext(ctx, rsyncPath, rsyncArgs(req.Host, source, destination)...)
+	} else {
+		tool = "scp"
+		cmd = exec.CommandContext(ctx, scpPath, scpArgs(req.Host, source, destination)...)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	s.mu.Lock()
+	s.nextID++
+	t := &Transfer{
+		ID:          strconv.Itoa(s.nextID),
+		ProjectID:   req.ProjectID,
+		Direction:   req.Direction,
+		HostName:    req.Host.Name,
+		Source:      source,
+		Destination: destination,
+		Tool:        tool,
+		State:       StateRunning,
+		Percent:     -1,
+		StartedAt:   time.Now(),
+	}
+	if tool == "rsync" {
+		t.Percent = 0
+	}
+	s.transfers = append(s.transfers, t)
+	if len(s.transfers) > maxHistory {
+		s.transfers = s.transfers[len(s.transfers)-maxHistory:]
+	}
+	s.mu.Unlock()
+
+	if err := cmd.Start(); err != nil {
+		s.finish(t, err, "", nil)
+		return nil, fmt.Errorf("failed to start %s: %w", tool, err)
+	}
+
+	go func() {
+		s.readProgress(t, stdout, onUpdate)
+		err := cmd.Wait()
+		s.finish(t, err, stderr.String(), onUpdate)
+	}()
+
+	return t, nil
+}
+
+// readProgress parses the progress output of rsync until EOF
+func (s *Service) readProgress(t *Transfer, r io.Reader, onUpdate func(Transfer)) {
+	scanner := bufio.NewScanner(r)
+	// rsync rewrites its progress line with \r
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	})
+
+	lastUpdate := time.Time{}
+	for scanner.Scan() {
+		match := rsyncProgress.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		percent, _ := strconv.Atoi(match[2])
+
+		s.mu.Lock()
+		t.Percent = percent
+		if n, err := strconv.ParseInt(strings.ReplaceAll(match[1], ",", ""), 10, 64); err == nil {
+			t.Transferred = storage.FormatBytes(n)
+		}
+		t.Speed = match[3]
+		snapshot := *t
+		s.mu.Unlock()
+
+		// Throttle UI updates
+		if onUpdate != nil && time.Since(lastUpdate) >= 500*time.Millisecond {
+			lastUpdate = time.Now()
+			onUpdate(snapshot)
+		}
+	}
+}
+
+// finish records the result of a transfer
+func (s *Service) finish(t *Transfer, err error, stderr string, onUpdate func(Transfer)) {
+	s.mu.Lock()
+	t.FinishedAt = time.Now()
+	if err != nil {
+		t.State = StateFailed
+		t.Error = err.Error()
+		// The last line of stderr explains the failure better than the exit code
+		if lines := strings.Split(strings.TrimSpace(stderr), "\n"); lines[len(lines)-1] != "" {
+			t.Error = strings.TrimSpace(lines[len(lines)-1])
+		}
+	} else {
+		t.State = StateDone
+		t.Percent = 100
+	}
+	snapshot := *t
+	s.mu.Unlock()
+
+	if onUpdate != nil {
+		onUpdate(snapshot)
+	}
+}
+
+// remoteSpec returns the [user@]host:path argument for a host
+func remoteSpec(host shell.SSHHost, path string) string {
+	if host.Source == shell.SSHSourceSSHConfig {
+		return host.Name + ":" + path
+	}
+	target := host.HostName
+	if target == "" {
+		target = host.Name
+	}
+	if host.User != "" {
+		target = host.User + "@" + target
+	}
+	return target + ":" + path
+}
+
+// sshOptions returns the ssh options shared by rsync and scp.
+// BatchMode fails instead of prompting for a password (there is no terminal).
+func sshOptions() []string {
+	return []string{"-o", "BatchMode=yes", "-o", "ConnectTimeout=15"}
+}
+
+// rsyncArgs returns the rsync arguments for a transfer
+func rsyncArgs(host shell.SSHHost, source, destination string) []string {
+	ssh := append([]string{"ssh"}, sshOptions()...)
+	if host.Source != shell.SSHSourceSSHConfig {
+		if host.Port > 0 {
+			ssh = append(ssh, "-p", strconv.Itoa(host.Port))
+		}
+		if host.IdentityFile != "" {
+			ssh = append(ssh, "-i", shellQuote(host.IdentityFile))
+		}
+	}
+	return []string{
+		"-az", "--partial", "--info=progress2", "--no-inc-recursive",
+		"-e", strings.Join(ssh, " "),
+		source, destination,
+	}
+}
+
+// scpArgs returns the scp arguments for a transfer
+func scpArgs(host shell.SSHHost, source, destination string) []string {
+	args := append([]string{"-r", "-p", "-q"}, sshOptions()...)
+	if host.Source != shell.SSHSourceSSHConfig {
+		if host.Port > 0 {
+			args = append(args, "-P", strconv.Itoa(host.Port))
+		}
+		if host.IdentityFile != "" {
+			args = append(args, "-i", host.IdentityFile)
+		}
+	}
+	return append(args, source, destination)
+}
+
+// shellQuote quotes a word for the command line rsync passes to its shell
+func shellQuote(s string) string {
+	if !strings.ContainsAny(s, " \t'\"\\") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,61 @@
+package trash
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Kind identifies the type of a deleted item
+type Kind string
+
+const (
+	KindProject        Kind = "project"         // Project removed from config
+	KindClaudeSession  Kind = "claude_session"  // Claude Code session (JSONL file)
+	KindCockpitProfile Kind = "cockpit_profile" // Cockpit widget profile
+	KindShellSession   Kind = "shell_session"   // Shell session
+	KindBuildOutput    Kind = "build_output"    // Build artifacts or node_modules cleaned from the Storage view
+)
+
+// Label returns a human-readable label for the kind
+func (k Kind) Label() string {
+	switch k {
+	case KindProject:
+		return "Project"
+	case KindClaudeSession:
+		return "Claude session"
+	case KindCockpitProfile:
+		return "Cockpit profile"
+	case KindShellSession:
+		return "Shell session"
+	case KindBuildOutput:
+		return "Build output"
+	default:
+		return string(k)
+	}
+}
+
+// File is a file moved into the trash
+type File struct {
+	Original string `json:"original"` // Path the file is restored to
+	Stored   string `json:"stored"`   // Path inside the trash directory
+}
+
+// Item is a recoverable record of a destructive action
+type Item struct {
+	ID        string          `json:"id"`
+	Kind      Kind            `json:"kind"`
+	Label     string          `json:"label"` // Display name (project name, session name...)
+	DeletedAt time.Time       `json:"deleted_at"`
+	Payload   json.RawMessage `json:"payload,omitempty"` // Kind-specific data needed to restore
+	Files     []File          `json:"files,omitempty"`
+}
+
+// DecodePayload unmarshals the item payload into v
+func (i *Item) DecodePayload(v interface{}) error {
+	return json.Unmarshal(i.Payload, v)
+}
+
+// ExpiresAt returns when the item is purged for the given retention
+func (i *Item) ExpiresAt(retention time.Duration) time.Time {
+	return i.DeletedAt.Add(retention)
+}
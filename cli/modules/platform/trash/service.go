@@ -0,0 +1,351 @@
+package trash
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/fileutil"
+)
+
+// Service keeps recoverable records of destructive actions on disk.
+// Layout: <dir>/index.json holds the items, <dir>/files/<id>/ holds moved files.
+type Service struct {
+	dir       string
+	retention time.Duration
+
+	mu sync.Mutex
+}
+
+// NewService creates a trash service storing its data in dir
+func NewService(dir string, retentionDays int) *Service {
+	return &Service{
+		dir:       dir,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+	}
+}
+
+// Retention returns how long items stay recoverable
+func (s *Service) Retention() time.Duration {
+	return s.retention
+}
+
+// Put records a deleted item. Files are moved into the trash so they can be restored.
+func (s *Service) Put(kind Kind, label string, payload interface{}, files []string) (*Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := Item{
+		ID:        generateID(),
+		Kind:      kind,
+		Label:     label,
+		DeletedAt: time.Now(),
+	}
+
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode payload: %w", err)
+		}
+		item.Payload = data
+	}
+
+	filesDir := filepath.Join(s.dir, "files", item.ID)
+	for _, path := range files {
+		if _, err := os.Stat(path); err != nil {
+			continue // Nothing to keep
+		}
+		if err := os.MkdirAll(filesDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create trash directory: %w", err)
+		}
+		stored := filepath.Join(filesDir, fmt.Sprintf("%d-%s", len(item.Files), filepath.Base(path)))
+		if err := moveFile(path, stored); err != nil {
+			putBack(&item, filesDir)
+			return nil, fmt.Errorf("failed to move %s to trash: %w", path, err)
+		}
+		item.Files = append(item.Files, File{Original: path, Stored: stored})
+	}
+
+	// Files moved but not recorded in the index could not be restored:
+	// put them back if the index cannot be saved
+	items := s.load()
+	items = append(items, item)
+	if err := s.save(items); err != nil {
+		putBack(&item, filesDir)
+		return nil, err
+	}
+	return &item, nil
+}
+
+// putBack moves the files of an item that could not be recorded back to
+// their original location. Files that cannot be moved back stay in the
+// trash directory rather than being lost.
+func putBack(item *Item, filesDir string) {
+	restored := true
+	for _, f := range item.Files {
+		if err := moveFile(f.Stored, f.Original); err != nil {
+			restored = false
+		}
+	}
+	if restored {
+		os.RemoveAll(filesDir)
+	}
+}
+
+// List returns all items, most recently deleted first
+func (s *Service) List() []Item {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.load()
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return items
+}
+
+// Latest returns the most recently deleted item (nil if trash is empty)
+func (s *Service) Latest() *Item {
+	items := s.List()
+	if len(items) == 0 {
+		return nil
+	}
+	return &items[0]
+}
+
+// Restore moves the item files back and removes the item from the trash.
+// The returned item carries the payload the caller uses to rebuild state.
+func (s *Service) Restore(id string) (*Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.load()
+	idx := indexOf(items, id)
+	if idx < 0 {
+		return nil, fmt.Errorf("trash item not found: %s", id)
+	}
+	item := items[idx]
+
+	// Never overwrite something created since the deletion
+	for _, f := range item.Files {
+		if _, err := os.Stat(f.Original); err == nil {
+			return nil, fmt.Errorf("cannot restore: %s already exists", f.Original)
+		}
+	}
+	for _, f := range item.Files {
+		if err := os.MkdirAll(filepath.Dir(f.Original), 0755); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", f.Original, err)
+		}
+		if err := moveFile(f.Stored, f.Original); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", f.Original, err)
+		}
+	}
+	os.RemoveAll(filepath.Join(s.dir, "files", item.ID))
+
+	items = append(items[:idx], items[idx+1:]...)
+	if err := s.save(items); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Delete permanently removes an item from the trash
+func (s *Service) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.load()
+	idx := indexOf(items, id)
+	if idx < 0 {
+		return fmt.Errorf("trash item not found: %s", id)
+	}
+	os.RemoveAll(filepath.Join(s.dir, "files", id))
+
+	items = append(items[:idx], items[idx+1:]...)
+	return s.save(items)
+}
+
+// Empty permanently removes all items and returns how many were removed
+func (s *Service) Empty() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.load()
+	os.RemoveAll(filepath.Join(s.dir, "files"))
+	return len(items), s.save(nil)
+}
+
+// Purge permanently removes items older than the retention period
+func (s *Service) Purge() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.load()
+	kept := make([]Item, 0, len(items))
+	now := time.Now()
+	for _, item := range items {
+		if s.retention > 0 && now.After(item.ExpiresAt(s.retention)) {
+			os.RemoveAll(filepath.Join(s.dir, "files", item.ID))
+			continue
+		}
+		kept = append(kept, item)
+	}
+
+	purged := len(items) - len(kept)
+	if purged == 0 {
+		return 0, nil
+	}
+	return purged, s.save(kept)
+}
+
+// indexFile returns the path of the trash index
+func (s *Service) indexFile() string {
+	return filepath.Join(s.dir, "index.json")
+}
+
+// load reads the index from disk (re-read on every operation so that
+// several DevTrack processes sharing the data dir stay consistent)
+func (s *Service) load() []Item {
+	data, err := os.ReadFile(s.indexFile())
+	if err != nil {
+		return nil // No trash yet
+	}
+
+	var items []Item
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil
+	}
+	return items
+}
+
+// save writes the index atomically
+func (s *Service) save(items []Item) error {
+	if items == nil {
+		items = []Item{}
+	}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create trash directory: %w", err)
+	}
+
+	if err := fileutil.WriteFileAtomic(s.indexFile(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write trash index: %w", err)
+	}
+	return nil
+}
+
+// indexOf returns the index of the item with the given ID (-1 if not found)
+func indexOf(items []Item, id string) int {
+	for i, item := range items {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// moveFile renames a file or directory, falling back to copy+remove across
+// filesystems
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		if err := copyDir(src, dst); err != nil {
+			os.RemoveAll(dst)
+			return err
+		}
+		return os.RemoveAll(src)
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		if err := os.Symlink(target, dst); err != nil {
+			return err
+		}
+		return os.Remove(src)
+	}
+
+	if err := copyFile(src, dst, info.Mode().Perm()); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	return os.Remove(src)
+}
+
+// copyDir copies a directory tree (files, directories and symlinks)
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		}
+		return nil // Sockets, devices: nothing to keep
+	})
+}
+
+// copyFile copies a regular file
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// generateID generates a short random item ID
+func generateID() string {
+	bytes := make([]byte, 8)
+	rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
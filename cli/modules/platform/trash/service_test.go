@@ -0,0 +1,60 @@
+package trash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPutKeepsFilesWhenIndexSaveFails(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(t.TempDir(), "session.jsonl")
+	if err := os.WriteFile(src, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A directory in place of the temporary index makes the save fail
+	s := NewService(dir, 30)
+	if err := os.MkdirAll(s.indexFile()+".tmp", 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Put(KindClaudeSession, "session", nil, []string{src}); err == nil {
+		t.Fatal("Put succeeded although the index could not be saved")
+	}
+	data, err := os.ReadFile(src)
+	if err != nil || string(data) != "data" {
+		t.Fatalf("file not put back after failed Put: %v", err)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(dir, "files")); len(entries) > 0 {
+		t.Fatalf("files left in the trash without index entry: %v", entries)
+	}
+	if items := s.List(); len(items) != 0 {
+		t.Fatalf("unexpected items: %v", items)
+	}
+}
+
+func TestPutRestore(t *testing.T) {
+	s := NewService(t.TempDir(), 30)
+	src := filepath.Join(t.TempDir(), "session.jsonl")
+	if err := os.WriteFile(src, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := s.Put(KindClaudeSession, "session", nil, []string{src})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Fatalf("file not moved to the trash: %v", err)
+	}
+	if _, err := s.Restore(item.ID); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(src); err != nil || string(data) != "data" {
+		t.Fatalf("file not restored: %v", err)
+	}
+	if items := s.List(); len(items) != 0 {
+		t.Fatalf("item still in the trash after restore: %v", items)
+	}
+}
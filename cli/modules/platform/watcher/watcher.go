@@ -0,0 +1,123 @@
+// Package watcher reports file system changes so polled data can be
+// refreshed as soon as it changes instead of waiting for the next poll.
+// It uses inotify on Linux; on other platforms New returns ErrUnsupported
+// and callers rely on polling only.
+package watcher
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrUnsupported is returned by New when file watching is not available
+var ErrUnsupported = errors.New("file watching not supported on this platform")
+
+// DefaultDebounce groups the bursts of events of a single operation
+// (git commit, editor save) into one notification
+const DefaultDebounce = 300 * time.Millisecond
+
+// Watcher watches directories (non-recursive) and calls onChange with the
+// key of a directory and the changed paths once its events settle for the
+// debounce delay. Several directories can share the same key.
+type Watcher struct {
+	mu       sync.Mutex
+	keys     map[int]string             // Watch descriptor -> key
+	dirs     map[int]string             // Watch descriptor -> directory
+	timers   map[string]*time.Timer     // Pending notification per key
+	paths    map[string]map[string]bool // Changed paths per pending key
+	lost     map[string]bool            // Keys whose events were lost
+	debounce time.Duration
+	onChange ChangeFunc
+	closed   bool
+
+	platform // Platform-specific state
+}
+
+// ChangeFunc is called with the key of changed directories and the paths of
+// the entries created, modified, deleted or renamed in them, sorted. paths
+// is nil when events were lost: everything under the key may have changed.
+type ChangeFunc func(key string, paths []string)
+
+// newWatcher creates the platform-independent part of a watcher
+func newWatcher(debounce time.Duration, onChange ChangeFunc) *Watcher {
+	return &Watcher{
+		keys:     make(map[int]string),
+		dirs:     make(map[int]string),
+		timers:   make(map[string]*time.Timer),
+		paths:    make(map[string]map[string]bool),
+		lost:     make(map[string]bool),
+		debounce: debounce,
+		onChange: onChange,
+	}
+}
+
+// notify records a changed path of a key (empty if unknown) and schedules
+// onChange, restarting the debounce delay
+func (w *Watcher) notify(key, path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return
+	}
+	if path == "" {
+		w.lost[key] = true
+	} else {
+		if w.paths[key] == nil {
+			w.paths[key] = make(map[string]bool)
+		}
+		w.paths[key][path] = true
+	}
+
+	if timer, ok := w.timers[key]; ok {
+		timer.Reset(w.debounce)
+		return
+	}
+	w.timers[key] = time.AfterFunc(w.debounce, func() {
+		w.mu.Lock()
+		delete(w.timers, key)
+		closed := w.closed
+		var paths []string
+		if !w.lost[key] {
+			paths = make([]string, 0, len(w.paths[key]))
+			for path := range w.paths[key] {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+		}
+		delete(w.paths, key)
+		delete(w.lost, key)
+		w.mu.Unlock()
+		if !closed {
+			w.onChange(key, paths)
+		}
+	})
+}
+
+// notifyAll schedules onChange for every key (events were lost)
+func (w *Watcher) notifyAll() {
+	w.mu.Lock()
+	keys := make(map[string]bool)
+	for _, key := range w.keys {
+		keys[key] = true
+	}
+	w.mu.Unlock()
+
+	for key := range keys {
+		w.notify(key, "")
+	}
+}
+
+// stopTimers cancels the pending notifications
+func (w *Watcher) stopTimers() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closed = true
+	for key, timer := range w.timers {
+		timer.Stop()
+		delete(w.timers, key)
+	}
+}
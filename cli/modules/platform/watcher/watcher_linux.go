@@ -0,0 +1,93 @@
+package watcher
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// watchMask selects the events that signal a content change
+const watchMask = syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_MODIFY |
+	syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO
+
+// platform holds the inotify instance
+type platform struct {
+	fd   int
+	file *os.File // Non-blocking fd wrapped for the runtime poller (Close unblocks Read)
+}
+
+// New creates a watcher backed by inotify
+func New(debounce time.Duration, onChange ChangeFunc) (*Watcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init inotify: %w", err)
+	}
+
+	w := newWatcher(debounce, onChange)
+	w.fd = fd
+	w.file = os.NewFile(uintptr(fd), "inotify")
+
+	go w.readEvents()
+	return w, nil
+}
+
+// Add watches a directory and reports its changes under key
+func (w *Watcher) Add(key, dir string) error {
+	wd, err := syscall.InotifyAddWatch(w.fd, dir, watchMask)
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+
+	w.mu.Lock()
+	w.keys[wd] = key
+	w.dirs[wd] = dir
+	w.mu.Unlock()
+	return nil
+}
+
+// Close stops watching and cancels pending notifications
+func (w *Watcher) Close() error {
+	w.stopTimers()
+	return w.file.Close()
+}
+
+// readEvents reads inotify events until the watcher is closed
+func (w *Watcher) readEvents() {
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			return // Closed
+		}
+
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameStart := offset + syscall.SizeofInotifyEvent
+			offset = nameStart + int(event.Len)
+			name := string(bytes.TrimRight(buf[nameStart:offset], "\x00"))
+
+			switch {
+			case event.Mask&syscall.IN_Q_OVERFLOW != 0:
+				w.notifyAll()
+			case event.Mask&syscall.IN_IGNORED != 0:
+				// Watched directory removed
+				w.mu.Lock()
+				delete(w.keys, int(event.Wd))
+				delete(w.dirs, int(event.Wd))
+				w.mu.Unlock()
+			default:
+				w.mu.Lock()
+				key, ok := w.keys[int(event.Wd)]
+				dir := w.dirs[int(event.Wd)]
+				w.mu.Unlock()
+				if ok {
+					w.notify(key, filepath.Join(dir, name))
+				}
+			}
+		}
+	}
+}
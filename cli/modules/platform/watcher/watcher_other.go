@@ -0,0 +1,23 @@
+// +build !linux
+
+package watcher
+
+import "time"
+
+// platform has no state where file watching is not supported
+type platform struct{}
+
+// New returns ErrUnsupported: changes are only picked up by polling
+func New(debounce time.Duration, onChange ChangeFunc) (*Watcher, error) {
+	return nil, ErrUnsupported
+}
+
+// Add is a no-op on unsupported platforms
+func (w *Watcher) Add(key, dir string) error {
+	return ErrUnsupported
+}
+
+// Close is a no-op on unsupported platforms
+func (w *Watcher) Close() error {
+	return nil
+}
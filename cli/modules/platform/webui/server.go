@@ -0,0 +1,241 @@
+package webui
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules"
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+)
+
+// index is the dashboard bundle: a single page polling the API
+//
+//go:embed index.html
+var index []byte
+
+// maxLogLines is the number of log lines returned at once
+const maxLogLines = 500
+
+// maxBuilds is the number of past builds returned
+const maxBuilds = 20
+
+// logSource reads the unified log feed of the presenter
+type logSource interface {
+	LogsSince(seq int64) ([]core.LogLineVM, int64)
+}
+
+// Server serves the read-only web dashboard of the daemon: the page and the
+// JSON API it polls, built from the presenter state
+type Server struct {
+	presenter core.Presenter
+	cfg       *config.DashboardConfig
+	team      *config.TeamConfig // Tokens of the teammates in team mode (nil otherwise)
+	cert      *tls.Certificate   // Team certificate, the dashboard is served over TLS (nil = HTTP)
+	server    *http.Server
+	listener  net.Listener
+}
+
+// Snapshot is the state shown by the dashboard
+type Snapshot struct {
+	Version       string    `json:"version"`
+	Time          time.Time `json:"time"`
+	Initializing  bool      `json:"initializing"`
+	ProjectCount  int       `json:"project_count"`
+	RunningCount  int       `json:"running_count"`
+	BuildingCount int       `json:"building_count"`
+	ErrorCount    int       `json:"error_count"`
+
+	Projects     []core.ProjectVM `json:"projects"`
+	Processes    []core.ProcessVM `json:"processes"`
+	IsBuilding   bool             `json:"is_building"`
+	CurrentBuild *core.BuildVM    `json:"current_build,omitempty"`
+	Builds       []core.BuildVM   `json:"builds"` // Most recent first, without their output
+}
+
+// LogsResponse is a page of the log feed: the lines after the requested line
+// number, and the number of the last one to ask from next
+type LogsResponse struct {
+	Lines []core.LogLineVM `json:"lines"`
+	Seq   int64            `json:"seq"`
+}
+
+// NewServer creates the dashboard server
+func NewServer(presenter core.Presenter, cfg *config.DashboardConfig) *Server {
+	return &Server{presenter: presenter, cfg: cfg}
+}
+
+// SetTeam serves the dashboard over TLS with the team certificate and also
+// accepts the tokens of the teammates (team mode, see DashboardTLS): they
+// never travel in cleartext
+func (s *Server) SetTeam(team *config.TeamConfig, cert tls.Certificate) {
+	s.team = team
+	s.cert = &cert
+}
+
+// Start listens on the configured address
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.cfg.Listen, strconv.Itoa(s.cfg.Port)))
+	if err != nil {
+		return fmt.Errorf("dashboard: %w", err)
+	}
+	if s.cert != nil {
+		listener = tls.NewListener(listener, &tls.Config{
+			Certificates: []tls.Certificate{*s.cert},
+			MinVersion:   tls.VersionTLS12,
+		})
+	}
+	s.listener = listener
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.HandleFunc("GET /api/state", s.authorize(s.handleState))
+	mux.HandleFunc("GET /api/logs", s.authorize(s.handleLogs))
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      15 * time.Second,
+	}
+	go s.server.Serve(listener)
+	return nil
+}
+
+// Stop closes the dashboard server
+func (s *Server) Stop() error {
+	if s.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+// URL returns the local address of the dashboard (without the token)
+func (s *Server) URL() string {
+	if s.listener == nil {
+		return ""
+	}
+	host := s.cfg.Listen
+	if !s.cfg.Local() {
+		host = "localhost" // Also reachable from the LAN
+	}
+	scheme := "http://"
+	if s.cert != nil {
+		scheme = "https://"
+	}
+	return scheme + net.JoinHostPort(host, strconv.Itoa(s.cfg.Port)) + "/"
+}
+
+// authorize checks the token of the API requests when one is configured:
+// the dashboard token or, in team mode over TLS, the token of a teammate
+func (s *Server) authorize(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.cfg.Token != "" || s.team != nil {
+			token := r.URL.Query().Get("token")
+			if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+				token = bearer
+			}
+			valid := s.cfg.Token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.Token)) == 1
+			if !valid && (s.team == nil || r.TLS == nil || s.team.Authenticate(token) == nil) {
+				http.Error(w, "invalid token", http.StatusUnauthorized)
+				return
+			}
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		next(w, r)
+	}
+}
+
+// handleIndex serves the dashboard page (it holds no data: the token is
+// checked by the API)
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(index)
+}
+
+// handleState returns the projects, processes and builds
+func (s *Server) handleState(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.snapshot())
+}
+
+// handleLogs returns the lines of the log feed after ?since= (the last ones
+// without it)
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
+	source, ok := s.presenter.(logSource)
+	if !ok {
+		http.Error(w, "logs unavailable", http.StatusNotImplemented)
+		return
+	}
+	since, _ := strconv.ParseInt(r.URL.Query().Get("since"), 10, 64)
+	lines, seq := source.LogsSince(since)
+	if len(lines) > maxLogLines {
+		lines = lines[len(lines)-maxLogLines:]
+	}
+	if lines == nil {
+		lines = []core.LogLineVM{}
+	}
+	writeJSON(w, LogsResponse{Lines: lines, Seq: seq})
+}
+
+// snapshot copies what the dashboard shows from the presenter state
+func (s *Server) snapshot() Snapshot {
+	snap := Snapshot{
+		Version:   modules.AppVersion,
+		Time:      time.Now(),
+		Projects:  []core.ProjectVM{},
+		Processes: []core.ProcessVM{},
+		Builds:    []core.BuildVM{},
+	}
+	state := s.presenter.GetState()
+	if state == nil {
+		return snap
+	}
+	snap.Initializing = state.Initializing
+
+	if d := state.Dashboard; d != nil {
+		snap.ProjectCount = d.ProjectCount
+		snap.RunningCount = d.RunningCount
+		snap.BuildingCount = d.BuildingCount
+		snap.ErrorCount = d.ErrorCount
+	}
+	if p := state.Projects; p != nil {
+		snap.Projects = append(snap.Projects, p.Projects...)
+	}
+	if p := state.Processes; p != nil {
+		for _, proc := range p.Processes {
+			proc.LogLines = nil // In the logs
+			snap.Processes = append(snap.Processes, proc)
+		}
+	}
+	if b := state.Builds; b != nil {
+		snap.IsBuilding = b.IsBuilding
+		if b.CurrentBuild != nil {
+			current := *b.CurrentBuild
+			current.Output = nil
+			snap.CurrentBuild = &current
+		}
+		for _, build := range b.BuildHistory {
+			if len(snap.Builds) == maxBuilds {
+				break
+			}
+			build.Output = nil
+			snap.Builds = append(snap.Builds, build)
+		}
+	}
+	return snap
+}
+
+// writeJSON answers with a JSON document
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/adhoc"
+)
+
+// handleRunCommand runs an ad-hoc shell command in the directory of a
+// component (of the project without one), with the component's environment.
+// The output goes to the logs under "cmd:<project>/<component>".
+func (p *AppPresenter) handleRunCommand(event *Event) error {
+	command, _ := event.Value.(string)
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return fmt.Errorf("no command to run")
+	}
+	project, err := p.projectService.GetProject(event.ProjectID)
+	if err != nil {
+		return fmt.Errorf("project not found: %s", event.ProjectID)
+	}
+
+	req := adhoc.Request{
+		ProjectID: project.ID,
+		Component: string(event.Component),
+		Command:   command,
+		Dir:       project.Path,
+		Env:       []string{"CSD_PROJECT=" + project.ID},
+	}
+	if event.Component != "" {
+		component := project.GetComponent(event.Component)
+		if component == nil {
+			return fmt.Errorf("component not found: %s", event.Component)
+		}
+		launch := p.processMgr.PlanStart(project, component)
+		req.Dir, req.Env = launch.Dir, launch.Env
+	}
+
+	r, err := p.adhocService.Start(p.ctx, req, p.onCommandOutput, p.onCommandUpdate)
+	if err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Command failed: %v", err))
+		return err
+	}
+	p.onCommandUpdate(*r)
+	return nil
+}
+
+// handleCancelCommand kills the running ad-hoc command of a component
+func (p *AppPresenter) handleCancelCommand(event *Event) error {
+	if !p.adhocService.Cancel(event.ProjectID, string(event.Component)) {
+		p.setHeaderEvent(HeaderEventWarning, "No command running")
+	}
+	return nil
+}
+
+// commandLabel returns the project/component an ad-hoc command runs in
+func commandLabel(r adhoc.Run) string {
+	if r.Component == "" {
+		return r.ProjectID
+	}
+	return r.ProjectID + "/" + r.Component
+}
+
+// onCommandOutput streams ad-hoc command output into the logs
+func (p *AppPresenter) onCommandOutput(r adhoc.Run, line string, isError bool) {
+	now := time.Now()
+	logLine := LogLineVM{
+		Timestamp: now,
+		TimeStr:   now.Format("15:04:05"),
+		Source:    "cmd:" + commandLabel(r),
+		Level:     "info",
+		Message:   line,
+	}
+	if isError {
+		logLine.Level = "error"
+	}
+
+	p.mu.Lock()
+	p.state.Logs.Append(logLine)
+	p.mu.Unlock()
+
+	p.notifyStateChange(StateUpdate{ViewType: VMLogs, ViewModel: p.state.Logs, Appended: 1})
+}
+
+// onCommandUpdate reports ad-hoc command status in the header and the
+// projects view model
+func (p *AppPresenter) onCommandUpdate(r adhoc.Run) {
+	label := commandLabel(r)
+	switch r.State {
+	case adhoc.StateRunning:
+		p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("Running in %s: %s", label, r.Command))
+	case adhoc.StateSuccess:
+		p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Command done in %s (%s)", label, r.Duration().Round(time.Second)))
+	case adhoc.StateFailed:
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Command failed in %s (exit %d): %s", label, r.ExitCode, r.Error))
+	case adhoc.StateCanceled:
+		p.setHeaderEvent(HeaderEventWarning, fmt.Sprintf("Command canceled in %s", label))
+	}
+
+	p.refreshCommandRuns()
+	p.notifyStateUpdate(VMProjects, p.state.Projects)
+}
+
+// refreshCommandRuns copies the recent ad-hoc commands into the projects view
+// model
+func (p *AppPresenter) refreshCommandRuns() {
+	runs := p.adhocService.GetRuns()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state.Projects.CommandRuns = make([]CommandRunVM, len(runs))
+	for i, r := range runs {
+		p.state.Projects.CommandRuns[i] = CommandRunVM{
+			ID:         r.ID,
+			ProjectID:  r.ProjectID,
+			Component:  r.Component,
+			Command:    r.Command,
+			Dir:        r.Dir,
+			State:      string(r.State),
+			ExitCode:   r.ExitCode,
+			Error:      r.Error,
+			StartedAt:  r.StartedAt,
+			FinishedAt: r.FinishedAt,
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/apiexplorer"
+)
+
+// apiTarget is where the API of a component is served, and how
+type apiTarget struct {
+	kind       apiexplorer.Kind
+	descriptor string // Absolute path of the descriptor
+	address    string // HTTP base URL, or host:port of the gRPC server
+	configured bool   // address is the configured api.base_url
+}
+
+// resolveAPI returns the descriptor and address of a component's API, from
+// its api config: base_url, else localhost on the component port (with the
+// server path of the OpenAPI descriptor, if any)
+func (p *AppPresenter) resolveAPI(projectID string, componentType projects.ComponentType) (*apiTarget, error) {
+	project, err := p.projectService.GetProject(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %s", projectID)
+	}
+	component := project.GetComponent(componentType)
+	if component == nil {
+		return nil, fmt.Errorf("component not found: %s", componentType)
+	}
+	desc := component.API
+	if desc == nil || (desc.OpenAPI == "" && desc.Proto == "") {
+		return nil, fmt.Errorf("no API descriptor configured for %s (set api.openapi or api.proto)", componentType)
+	}
+
+	target := &apiTarget{kind: apiexplorer.KindHTTP, descriptor: desc.OpenAPI}
+	if desc.Proto != "" {
+		target.kind, target.descriptor = apiexplorer.KindGRPC, desc.Proto
+	}
+	if !filepath.IsAbs(target.descriptor) {
+		target.descriptor = filepath.Join(project.Path, component.Path, target.descriptor)
+	}
+
+	target.address = strings.TrimSuffix(desc.BaseURL, "/")
+	target.configured = target.address != ""
+	if !target.configured {
+		if component.Port <= 0 {
+			return target, fmt.Errorf("no api.base_url or port configured for %s", componentType)
+		}
+		target.address = fmt.Sprintf("localhost:%d", component.Port)
+		if target.kind == apiexplorer.KindHTTP {
+			target.address = "http://" + target.address
+		}
+	}
+	return target, nil
+}
+
+// handleAPIExplore opens the API explorer of a component: the endpoints of
+// its descriptor and where requests are sent
+func (p *AppPresenter) handleAPIExplore(event *Event) error {
+	explorer := &APIExplorerVM{
+		ProjectID: event.ProjectID,
+		Component: event.Component,
+	}
+
+	target, err := p.resolveAPI(event.ProjectID, event.Component)
+	if target != nil {
+		explorer.Kind = target.kind
+		explorer.Descriptor = target.descriptor
+		explorer.Target = target.address
+		api, loadErr := p.apiService.Load(target.descriptor)
+		if loadErr != nil {
+			err = loadErr
+		} else {
+			explorer.Endpoints = api.Endpoints
+			// Without a base_url, the path of the descriptor's server (/api/v1) is
+			// kept on the local address: its host is usually a deployed one
+			if target.kind == apiexplorer.KindHTTP && !target.configured && api.BaseURL != "" {
+				if u, parseErr := url.Parse(api.BaseURL); parseErr == nil && u.Path != "" {
+					explorer.Target += strings.TrimSuffix(u.Path, "/")
+				}
+			}
+		}
+	}
+	if err != nil {
+		explorer.Error = err.Error()
+	}
+
+	p.mu.Lock()
+	p.state.Projects.APIExplorer = explorer
+	p.mu.Unlock()
+
+	p.notifyStateUpdate(VMProjects, p.state.Projects)
+	return nil
+}
+
+// handleAPIRequest fires a test request at the API of the component open in
+// the explorer. Data: "method", "path" (with its query string) and "body".
+// The response is shown in the explorer when it arrives.
+func (p *AppPresenter) handleAPIRequest(event *Event) error {
+	p.mu.Lock()
+	current := p.state.Projects.APIExplorer
+	if current == nil || current.ProjectID != event.ProjectID || current.Component != event.Component {
+		p.mu.Unlock()
+		return fmt.Errorf("API explorer not open for %s/%s", event.ProjectID, event.Component)
+	}
+	if current.Sending {
+		p.mu.Unlock()
+		p.setHeaderEvent(HeaderEventWarning, "A request is already in flight")
+		return nil
+	}
+	req := apiexplorer.Request{
+		Kind:   current.Kind,
+		Method: event.Data["method"],
+		Target: current.Target,
+		Path:   event.Data["path"],
+		Body:   event.Data["body"],
+	}
+	if req.Kind == apiexplorer.KindGRPC {
+		req.Proto = current.Descriptor
+	}
+	// The view model is replaced, not modified: the views may be rendering it
+	sending := *current
+	sending.Sending = true
+	sending.Request = strings.TrimSpace(req.Method + " " + req.Path)
+	sending.Response = nil
+	p.state.Projects.APIExplorer = &sending
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMProjects, p.state.Projects)
+
+	go func() {
+		resp := p.apiService.Send(p.ctx, req)
+
+		p.mu.Lock()
+		// The explorer may have been opened on another component meanwhile
+		if p.state.Projects.APIExplorer == &sending {
+			done := sending
+			done.Sending = false
+			done.Response = resp
+			p.state.Projects.APIExplorer = &done
+		}
+		p.mu.Unlock()
+		p.notifyStateUpdate(VMProjects, p.state.Projects)
+	}()
+	return nil
+}
@@ -0,0 +1,30 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"csd-devtrack/cli/modules/platform/backup"
+	"csd-devtrack/cli/modules/platform/storage"
+)
+
+// handleBackupCreate archives the config and DevTrack data to the backups
+// directory (restored with csd-devtrack restore, daemon stopped)
+func (p *AppPresenter) handleBackupCreate(event *Event) error {
+	loc, err := backup.DefaultLocations()
+	if err != nil {
+		return err
+	}
+	archivePath := filepath.Join(loc.BackupsDir, backup.DefaultFileName())
+	p.setHeaderEvent(HeaderEventInfo, "Backing up DevTrack data...")
+
+	manifest, err := backup.Create(archivePath, loc, backup.Options{})
+	if err != nil {
+		p.notify(NotifyError, "Backup Failed", err.Error())
+		return err
+	}
+	event.Target = archivePath // Recorded in the audit log
+	p.notify(NotifySuccess, "Backup Created",
+		fmt.Sprintf("%d files (%s) in %s", manifest.Files, storage.FormatBytes(manifest.Size), archivePath))
+	return nil
+}
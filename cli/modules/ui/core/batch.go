@@ -0,0 +1,71 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// StateBatchInterval is how long high-frequency updates are accumulated before
+// being delivered (log append, build output)
+const StateBatchInterval = 50 * time.Millisecond
+
+// UpdateBatcher coalesces high-frequency state updates.
+// Updates of the same view model added within the interval are merged (latest
+// view model, union of diffs) and delivered once, in first-seen order.
+type UpdateBatcher struct {
+	interval time.Duration
+	deliver  func(StateUpdate)
+
+	mu      sync.Mutex
+	pending map[ViewModelType]*StateUpdate
+	order   []ViewModelType
+	timer   *time.Timer
+}
+
+// NewUpdateBatcher creates a batcher delivering merged updates to deliver
+func NewUpdateBatcher(interval time.Duration, deliver func(StateUpdate)) *UpdateBatcher {
+	return &UpdateBatcher{
+		interval: interval,
+		deliver:  deliver,
+		pending:  make(map[ViewModelType]*StateUpdate),
+	}
+}
+
+// Add queues an update, delivered at the end of the current interval
+func (b *UpdateBatcher) Add(update StateUpdate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if existing, ok := b.pending[update.ViewType]; ok {
+		existing.merge(update)
+	} else {
+		queued := update
+		b.pending[update.ViewType] = &queued
+		b.order = append(b.order, update.ViewType)
+	}
+
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.interval, b.Flush)
+	}
+}
+
+// Flush delivers the pending updates immediately
+func (b *UpdateBatcher) Flush() {
+	b.mu.Lock()
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	updates := make([]StateUpdate, 0, len(b.order))
+	for _, viewType := range b.order {
+		updates = append(updates, *b.pending[viewType])
+	}
+	b.pending = make(map[ViewModelType]*StateUpdate)
+	b.order = nil
+	b.mu.Unlock()
+
+	// Deliver outside the lock (callbacks may trigger new updates)
+	for _, update := range updates {
+		b.deliver(update)
+	}
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"fmt"
+
+	"csd-devtrack/cli/modules/platform/capabilities"
+	"csd-devtrack/cli/modules/platform/shell"
+)
+
+// handleCapabilitiesRefresh detects the external tools again, so a tool
+// installed while DevTrack runs is used without a restart
+func (p *AppPresenter) handleCapabilitiesRefresh(event *Event) error {
+	if p.capService == nil {
+		return nil
+	}
+	_, missingBefore := p.capService.GetSummary()
+	p.capService.Refresh()
+	p.refreshCapabilities()
+	p.applyCapabilities()
+
+	// Stamped so daemon clients see a change and sync the capabilities
+	p.mu.Lock()
+	p.state.Config.UpdatedAt = p.state.Capabilities.CheckedAt
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMConfig, p.state.Config)
+
+	var found []string
+	for _, name := range missingBefore {
+		if p.capService.IsAvailable(capabilities.Capability(name)) {
+			found = append(found, name)
+		}
+	}
+	if len(found) > 0 {
+		p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Tools detected: %s", capabilities.FormatList(found)))
+	} else {
+		p.setHeaderEvent(HeaderEventInfo, "Tools detected again, no new tool")
+	}
+	return nil
+}
+
+// applyCapabilities passes the detected tool paths to the services started
+// with them
+func (p *AppPresenter) applyCapabilities() {
+	if p.claudeService != nil {
+		p.claudeService.RefreshInstallStatus()
+	}
+	if p.codexService != nil {
+		// Initializing again would discover the sessions again
+		if codexPath := p.capService.GetPath(capabilities.CapCodex); codexPath != "" && codexPath != p.codexService.GetPath() {
+			p.codexService.Initialize(codexPath)
+		}
+	}
+	if p.shellService != nil {
+		if shellPath := p.capService.GetPath(capabilities.CapShell); shellPath != "" {
+			var shells []shell.ShellInfo
+			for _, sh := range p.capService.GetAvailableShells() {
+				shells = append(shells, shell.ShellInfo{Name: sh.Name, Path: sh.Path})
+			}
+			p.shellService.Initialize(shellPath, p.capService.IsAvailable(capabilities.CapSudo), shells)
+		}
+	}
+	if p.securityService != nil {
+		p.securityService.SetScannerPaths(p.capService.GetPath(capabilities.CapGovulncheck), p.capService.GetPath(capabilities.CapNpm))
+	}
+	if p.storageService != nil {
+		p.storageService.SetGoPath(p.capService.GetPath(capabilities.CapGo))
+	}
+	if p.apiService != nil {
+		p.apiService.SetGrpcurlPath(p.capService.GetPath(capabilities.CapGrpcurl))
+	}
+	if p.dockerService != nil {
+		p.dockerService.SetPath(p.capService.GetPath(capabilities.CapDocker))
+	}
+	if p.transferService != nil {
+		p.transferService.SetToolPaths(p.capService.GetPath(capabilities.CapRsync), p.capService.GetPath(capabilities.CapSCP))
+	}
+	p.refreshClaude()
+	p.refreshCodex()
+	p.refreshShell()
+}
@@ -0,0 +1,323 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules"
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/capture"
+	"csd-devtrack/cli/modules/platform/config"
+)
+
+// captureConfig returns the capture proxy config
+func (p *AppPresenter) captureConfig() *config.CaptureConfig {
+	if p.config == nil || p.config.Settings == nil {
+		return config.DefaultCaptureConfig()
+	}
+	return p.config.Settings.GetCaptureConfig()
+}
+
+// captureEnvironment points the components marked capture: true at the
+// capture proxy while it runs
+func (p *AppPresenter) captureEnvironment(project *projects.Project, component *projects.Component) []string {
+	if !component.Capture || p.captureServer == nil {
+		return nil
+	}
+	url := p.captureServer.URL()
+	if url == "" {
+		return nil
+	}
+	return capture.Environment(url, project.ID+"/"+string(component.Type), p.captureServer.CA())
+}
+
+// captureComponents returns the components marked capture: true
+func (p *AppPresenter) captureComponents() []string {
+	var sources []string
+	for _, project := range p.projectService.ListProjects() {
+		for _, comp := range project.GetEnabledComponents() {
+			if comp.Capture {
+				sources = append(sources, project.ID+"/"+string(comp.Type))
+			}
+		}
+	}
+	return sources
+}
+
+// refreshCapture updates the requests recorded by the capture proxy
+func (p *AppPresenter) refreshCapture() {
+	if p.captureServer == nil {
+		return
+	}
+	cfg := p.captureConfig()
+	vm := &CaptureVM{
+		URL:          p.captureServer.URL(),
+		Port:         cfg.Port,
+		Enabled:      cfg.Enabled,
+		InterceptTLS: cfg.InterceptsTLS(),
+		Components:   p.captureComponents(),
+		Entries:      p.captureServer.Entries(),
+	}
+	if ca := p.captureServer.CA(); ca != nil {
+		vm.CAFile = ca.CertFile
+	}
+
+	p.mu.Lock()
+	if previous := p.state.Processes.Capture; previous != nil {
+		vm.Detail = previous.Detail
+		if vm.URL == "" {
+			vm.Error = previous.Error // Why it could not start
+		}
+	}
+	p.state.Processes.Capture = vm
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMProcesses, p.state.Processes)
+}
+
+// pollCapture refreshes the Requests panel when requests were recorded since
+// the last refresh
+func (p *AppPresenter) pollCapture() {
+	if p.captureDirty.Swap(false) {
+		p.refreshCapture()
+	}
+}
+
+// startCapture starts the capture proxy, with the CA decrypting HTTPS unless
+// TLS interception is off
+func (p *AppPresenter) startCapture() error {
+	cfg := p.captureConfig()
+	var ca *capture.CA
+	var err error
+	if cfg.InterceptsTLS() {
+		var dir string
+		if dir, err = capture.Dir(); err == nil {
+			ca, err = capture.LoadCA(dir)
+		}
+	}
+	if err == nil {
+		err = p.captureServer.Start(cfg.Port, cfg.MaxEntries, ca)
+	}
+
+	p.mu.Lock()
+	if p.state.Processes.Capture == nil {
+		p.state.Processes.Capture = &CaptureVM{}
+	}
+	captureVM := *p.state.Processes.Capture
+	captureVM.Error = ""
+	if err != nil {
+		captureVM.Error = err.Error()
+	}
+	p.state.Processes.Capture = &captureVM
+	p.mu.Unlock()
+
+	p.refreshCapture()
+	return err
+}
+
+// handleCaptureToggle starts ("start") or stops ("stop") the capture proxy,
+// or toggles it without a value. The choice is kept in the config for the
+// next launches.
+func (p *AppPresenter) handleCaptureToggle(event *Event) error {
+	action, _ := event.Value.(string)
+	running := p.captureServer.URL() != ""
+	if action == "" {
+		action = "start"
+		if running {
+			action = "stop"
+		}
+	}
+
+	switch action {
+	case "start":
+		if running {
+			return nil
+		}
+		if err := p.startCapture(); err != nil {
+			p.setHeaderEvent(HeaderEventError, err.Error())
+			return nil
+		}
+		message := fmt.Sprintf("Capture proxy on %s", p.captureServer.URL())
+		if len(p.captureComponents()) == 0 {
+			message += ": mark components with capture: true, then start them"
+		} else {
+			message += ": restart the components to capture to point them at it"
+		}
+		p.setHeaderEvent(HeaderEventSuccess, message)
+	case "stop":
+		if err := p.captureServer.Stop(); err != nil {
+			p.setHeaderEvent(HeaderEventWarning, fmt.Sprintf("Capture proxy stopped: %v", err))
+		} else {
+			p.setHeaderEvent(HeaderEventInfo, "Capture proxy stopped: the components still pointed at it fail until restarted")
+		}
+		p.refreshCapture()
+	default:
+		return fmt.Errorf("unknown capture action: %s", action)
+	}
+	return p.saveCaptureEnabled(action == "start")
+}
+
+// saveCaptureEnabled stores whether the capture proxy starts with DevTrack
+func (p *AppPresenter) saveCaptureEnabled(enabled bool) error {
+	cfg := config.GetGlobal()
+	if cfg.Settings == nil {
+		cfg.Settings = config.DefaultConfig().Settings
+	}
+	if cfg.Settings.Capture == nil {
+		if !enabled {
+			return nil
+		}
+		cfg.Settings.Capture = &config.CaptureConfig{}
+	}
+	if cfg.Settings.Capture.Enabled == enabled {
+		return nil
+	}
+	cfg.Settings.Capture.Enabled = enabled
+	if err := config.SaveGlobal(); err != nil {
+		return err
+	}
+	p.refreshCapture()
+	return nil
+}
+
+// handleCaptureComponent marks or unmarks a component for capture (saved in
+// its config); it is pointed at the proxy on its next start
+func (p *AppPresenter) handleCaptureComponent(event *Event) error {
+	project, err := p.projectService.GetProject(event.ProjectID)
+	if err != nil {
+		return fmt.Errorf("project not found: %s", event.ProjectID)
+	}
+	comp := project.GetComponent(event.Component)
+	if comp == nil {
+		return fmt.Errorf("component not found: %s/%s", event.ProjectID, event.Component)
+	}
+	enabled := !comp.Capture
+	if err := p.saveComponentCapture(project.ID, comp.Type, enabled); err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Capture not saved: %v", err))
+		return err
+	}
+	p.refreshProjects()
+	p.refreshCapture()
+
+	name := project.Name + "/" + string(comp.Type)
+	running := false
+	if proc := p.processService.GetProcessForComponent(project.ID, comp.Type); proc != nil && proc.IsRunning() {
+		running = true
+	}
+	switch {
+	case !enabled && running:
+		p.setHeaderEvent(HeaderEventInfo, fmt.Sprintf("Requests of %s no longer captured: restart it", name))
+	case !enabled:
+		p.setHeaderEvent(HeaderEventInfo, fmt.Sprintf("Requests of %s no longer captured", name))
+	case p.captureServer.URL() == "":
+		p.setHeaderEvent(HeaderEventWarning, fmt.Sprintf("Requests of %s captured once the capture proxy runs (^G n, s)", name))
+	case running:
+		p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Requests of %s captured: restart it to point it at the proxy", name))
+	default:
+		p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Requests of %s captured from its next start", name))
+	}
+	return nil
+}
+
+// saveComponentCapture stores the capture flag of a component in the config
+func (p *AppPresenter) saveComponentCapture(projectID string, ct projects.ComponentType, enabled bool) error {
+	cfg := config.GetGlobal()
+	for i := range cfg.Projects {
+		if cfg.Projects[i].ID != projectID {
+			continue
+		}
+		comp := cfg.Projects[i].GetComponent(ct)
+		if comp == nil {
+			break
+		}
+		comp.Capture = enabled
+		if err := config.SaveGlobal(); err != nil {
+			return err
+		}
+		return p.projectService.Load()
+	}
+	return fmt.Errorf("%s/%s is not in the configuration", projectID, ct)
+}
+
+// handleCaptureDetail opens a recorded request with its headers and bodies
+// (Target = entry ID, empty = close)
+func (p *AppPresenter) handleCaptureDetail(event *Event) error {
+	var detail *capture.Entry
+	if event.Target != "" {
+		id, err := strconv.ParseInt(event.Target, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid request ID: %s", event.Target)
+		}
+		if detail = p.captureServer.Entry(id); detail == nil {
+			return fmt.Errorf("request %d was dropped", id)
+		}
+	}
+
+	p.mu.Lock()
+	if p.state.Processes.Capture == nil {
+		p.state.Processes.Capture = &CaptureVM{}
+	}
+	captureVM := *p.state.Processes.Capture
+	captureVM.Detail = detail
+	p.state.Processes.Capture = &captureVM
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMProcesses, p.state.Processes)
+	return nil
+}
+
+// handleCaptureClear drops the recorded requests
+func (p *AppPresenter) handleCaptureClear(event *Event) error {
+	p.captureServer.Clear()
+	p.mu.Lock()
+	if previous := p.state.Processes.Capture; previous != nil {
+		captureVM := *previous
+		captureVM.Detail = nil
+		p.state.Processes.Capture = &captureVM
+	}
+	p.mu.Unlock()
+	p.refreshCapture()
+	return nil
+}
+
+// handleCaptureExport writes the recorded requests matching a filter (Value)
+// to a HAR file: Target, or a new file in the capture directory
+func (p *AppPresenter) handleCaptureExport(event *Event) error {
+	filter, _ := event.Value.(string)
+	var entries []capture.Entry
+	for _, e := range p.captureServer.All() {
+		if capture.Match(e, filter) {
+			entries = append(entries, e)
+		}
+	}
+	if len(entries) == 0 {
+		p.setHeaderEvent(HeaderEventWarning, "No requests to export")
+		return nil
+	}
+
+	path := event.Target
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if path == "" {
+		dir, err := capture.Dir()
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+		path = filepath.Join(dir, "requests-"+time.Now().Format("20060102-150405")+".har")
+	}
+
+	if err := capture.WriteHAR(path, entries, modules.AppVersion); err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("HAR export failed: %v", err))
+		return err
+	}
+	p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Exported %d requests to %s", len(entries), path))
+	return nil
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"fmt"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/certs"
+	"csd-devtrack/cli/modules/platform/config"
+)
+
+// certificateVM inspects the certificate of a component
+func certificateVM(tls *projects.TLSConfig) *CertificateVM {
+	vm := &CertificateVM{}
+	vm.Hosts = tls.Hosts
+	if tls.Cert == "" {
+		vm.Error = "not generated"
+		return vm
+	}
+	cert, err := certs.Inspect(tls.Cert)
+	if err != nil {
+		vm.CertFile = tls.Cert
+		vm.Error = err.Error()
+		return vm
+	}
+	vm.Certificate = *cert
+	vm.KeyFile = tls.Key
+	return vm
+}
+
+// certWarnings lists the certificates of the components that are expired,
+// expire within certs.ExpiryWarning, or are missing
+func certWarnings(vms []ProjectVM) []string {
+	var warnings []string
+	for _, project := range vms {
+		for _, comp := range project.Components {
+			cert := comp.Certificate
+			if cert == nil {
+				continue
+			}
+			name := project.Name + "/" + string(comp.Type)
+			left := cert.ExpiresIn()
+			switch {
+			case cert.Error != "":
+				warnings = append(warnings, fmt.Sprintf("%s: TLS certificate %s", name, cert.Error))
+			case left <= 0:
+				warnings = append(warnings, fmt.Sprintf("%s: TLS certificate expired on %s", name, cert.NotAfter.Format("2006-01-02")))
+			case left < certs.ExpiryWarning:
+				warnings = append(warnings, fmt.Sprintf("%s: TLS certificate expires in %d days", name, int(left.Hours()/24)+1))
+			}
+		}
+	}
+	return warnings
+}
+
+// handleGenerateCert generates the local TLS certificate of a component in
+// the background (mkcert, self-signed without it) and stores its paths in the
+// component config
+func (p *AppPresenter) handleGenerateCert(event *Event) error {
+	project, err := p.projectService.GetProject(event.ProjectID)
+	if err != nil {
+		return fmt.Errorf("project not found: %s", event.ProjectID)
+	}
+	comp := project.GetComponent(event.Component)
+	if comp == nil {
+		return fmt.Errorf("component not found: %s/%s", event.ProjectID, event.Component)
+	}
+	dir, err := certs.Dir()
+	if err != nil {
+		return err
+	}
+
+	tls := projects.TLSConfig{}
+	if comp.TLS != nil {
+		tls = *comp.TLS
+	}
+	name := project.Name + "/" + string(comp.Type)
+	generator := certs.GeneratorSelfSigned
+	if certs.MkcertPath() != "" {
+		generator = certs.GeneratorMkcert
+	}
+	p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("Generating the TLS certificate of %s (%s)...", name, generator))
+
+	go func() {
+		cert, err := certs.Generate(p.ctx, dir, project.ID+"-"+string(comp.Type), tls.Hosts)
+		if err != nil {
+			p.setHeaderEvent(HeaderEventError, fmt.Sprintf("TLS certificate of %s: %v", name, err))
+			return
+		}
+		tls.Cert, tls.Key = cert.CertFile, cert.KeyFile
+		if err := p.saveComponentTLS(project.ID, comp.Type, &tls); err != nil {
+			p.setHeaderEvent(HeaderEventError, fmt.Sprintf("TLS certificate of %s generated, config not saved: %v", name, err))
+			return
+		}
+		p.refreshProjects()
+		p.refreshDashboard()
+		p.notifyStateUpdate(VMDashboard, p.state.Dashboard)
+
+		message := fmt.Sprintf("TLS certificate of %s valid until %s (%s)", name, cert.NotAfter.Format("2006-01-02"), cert.Generator)
+		if cert.Generator == certs.GeneratorSelfSigned {
+			message += ", install mkcert for one trusted by the browsers"
+		}
+		if proc := p.processService.GetProcessForComponent(project.ID, comp.Type); proc != nil && proc.IsRunning() {
+			message += ": restart it to use it"
+		}
+		p.setHeaderEvent(HeaderEventSuccess, message)
+	}()
+	return nil
+}
+
+// saveComponentTLS stores the certificate of a component in the config
+func (p *AppPresenter) saveComponentTLS(projectID string, ct projects.ComponentType, tls *projects.TLSConfig) error {
+	cfg := config.GetGlobal()
+	for i := range cfg.Projects {
+		if cfg.Projects[i].ID != projectID {
+			continue
+		}
+		comp := cfg.Projects[i].GetComponent(ct)
+		if comp == nil {
+			break
+		}
+		comp.TLS = tls
+		if err := config.SaveGlobal(); err != nil {
+			return err
+		}
+		return p.projectService.Load()
+	}
+	return fmt.Errorf("%s/%s is not in the configuration", projectID, ct)
+}
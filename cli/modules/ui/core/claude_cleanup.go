@@ -0,0 +1,130 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+)
+
+// claudeRetention returns the cleanup policy of the Claude sessions
+func claudeRetention() *config.ClaudeRetentionConfig {
+	cfg := config.GetGlobal()
+	if cfg == nil || cfg.Settings == nil {
+		return config.DefaultClaudeRetentionConfig()
+	}
+	return cfg.Settings.GetClaudeRetentionConfig()
+}
+
+// staleClaudeSessions returns the sessions removed by a cleanup with a policy
+func (p *AppPresenter) staleClaudeSessions(retention *config.ClaudeRetentionConfig) []ClaudeSessionVM {
+	cutoff := time.Now().AddDate(0, 0, -retention.MaxAgeDays)
+	stale := p.claudeService.StaleSessions(cutoff, retention.MinMessages, retention.IncludeNamed)
+	vms := make([]ClaudeSessionVM, len(stale))
+	for i, s := range stale {
+		vms[i] = ClaudeSessionVM{
+			ID:               s.ID,
+			Name:             s.Name,
+			ProjectID:        s.ProjectID,
+			ProjectName:      s.ProjectName,
+			WorkDir:          s.WorkDir,
+			ClaudeProjectDir: s.ClaudeProjectDir,
+			State:            string(s.State),
+			MessageCount:     s.MessageCount,
+			CreatedAt:        s.CreatedAt,
+			LastActive:       s.LastActiveAt.Format("2006-01-02 15:04"),
+			LastActiveAt:     s.LastActiveAt,
+			ParentID:         s.ParentID,
+		}
+	}
+	return vms
+}
+
+// handleClaudeCleanupPreview lists the sessions a cleanup would remove,
+// without removing them
+func (p *AppPresenter) handleClaudeCleanupPreview(event *Event) error {
+	if p.claudeService == nil {
+		return fmt.Errorf("claude service not available")
+	}
+	retention := claudeRetention()
+	preview := &ClaudeCleanupVM{
+		MaxAgeDays:   retention.MaxAgeDays,
+		MinMessages:  retention.MinMessages,
+		IncludeNamed: retention.IncludeNamed,
+		Sessions:     p.staleClaudeSessions(retention),
+	}
+
+	p.mu.Lock()
+	p.state.Claude.Cleanup = preview
+	p.mu.Unlock()
+
+	p.notifyStateUpdate(VMClaude, p.state.Claude)
+	return nil
+}
+
+// handleClaudeCleanupSessions removes the stale sessions of the preview that
+// are still stale, keeping them in the trash
+func (p *AppPresenter) handleClaudeCleanupSessions(event *Event) error {
+	if p.claudeService == nil {
+		return fmt.Errorf("claude service not available")
+	}
+	p.mu.RLock()
+	previewed := make(map[string]bool)
+	if p.state.Claude.Cleanup != nil {
+		for _, sess := range p.state.Claude.Cleanup.Sessions {
+			previewed[sess.ID] = true
+		}
+	}
+	p.mu.RUnlock()
+
+	var ids []string
+	for _, sess := range p.staleClaudeSessions(claudeRetention()) {
+		if previewed[sess.ID] {
+			ids = append(ids, sess.ID)
+		}
+	}
+	removed, err := p.removeClaudeSessions(ids)
+
+	p.mu.Lock()
+	p.state.Claude.Cleanup = nil
+	p.mu.Unlock()
+
+	if err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Cleanup stopped after %d session(s): %v", removed, err))
+	} else {
+		p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Moved %d stale session(s) to the trash", removed))
+	}
+	p.refreshClaude()
+	return err
+}
+
+// autoCleanupClaudeSessions removes the stale sessions at startup when the
+// cleanup is automatic
+func (p *AppPresenter) autoCleanupClaudeSessions() {
+	retention := claudeRetention()
+	if !retention.Auto || p.claudeService == nil {
+		return
+	}
+	var ids []string
+	for _, sess := range p.staleClaudeSessions(retention) {
+		ids = append(ids, sess.ID)
+	}
+	p.removeClaudeSessions(ids)
+}
+
+// removeClaudeSessions moves sessions to the trash, returning how many were
+// removed
+func (p *AppPresenter) removeClaudeSessions(ids []string) (int, error) {
+	removed := 0
+	for _, id := range ids {
+		if _, err := p.trashClaudeSession(id); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	if removed > 0 {
+		p.refreshTrash()
+		p.notifyStateUpdate(VMTrash, p.state.Trash)
+	}
+	return removed, nil
+}
@@ -0,0 +1,40 @@
+package core
+
+import (
+	"csd-devtrack/cli/modules/platform/claude"
+)
+
+// claudeMessagePage is the number of messages of a Claude session loaded
+// into the view model at a time: giant sessions would copy thousands of
+// messages on every state update
+const claudeMessagePage = 200
+
+// setClaudeMessages loads the last messages of the active session into the
+// view model, older ones are loaded on demand (handleClaudeLoadOlder).
+// Must be called with p.mu held.
+func (p *AppPresenter) setClaudeMessages(messages []claude.Message) {
+	limit := p.claudeMessageLimit
+	if limit <= 0 {
+		limit = claudeMessagePage
+	}
+	start := max(len(messages)-limit, 0)
+	p.state.Claude.Messages = p.messagesToVM(messages[start:])
+	p.state.Claude.OlderMessages = start
+}
+
+// handleClaudeLoadOlder loads the previous page of messages of the active
+// session (the chat panel was scrolled to the top)
+func (p *AppPresenter) handleClaudeLoadOlder(event *Event) error {
+	p.mu.Lock()
+	sessionID := p.state.Claude.ActiveSessionID
+	if sessionID == "" || p.state.Claude.OlderMessages == 0 {
+		p.mu.Unlock()
+		return nil
+	}
+	p.claudeMessageLimit = len(p.state.Claude.Messages) + claudeMessagePage
+	p.mu.Unlock()
+
+	p.refreshClaudeMessages(sessionID)
+	p.notifyStateUpdate(VMClaude, p.state.Claude)
+	return nil
+}
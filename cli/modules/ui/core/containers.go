@@ -0,0 +1,172 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/capabilities"
+	"csd-devtrack/cli/modules/platform/containers"
+)
+
+// pollContainers lists the containers of the projects with their resource
+// usage, when docker or podman is installed
+func (p *AppPresenter) pollContainers() {
+	if p.dockerService == nil || p.capService == nil || !p.capService.IsAvailable(capabilities.CapDocker) {
+		return
+	}
+	p.refreshContainers()
+}
+
+// refreshContainers lists the containers of the engine and keeps those
+// matched to a registered project
+func (p *AppPresenter) refreshContainers() {
+	list, err := p.dockerService.List(p.ctx)
+
+	var refs []containers.Project
+	names := make(map[string]string)
+	for _, project := range p.projectService.ListProjects() {
+		refs = append(refs, containers.Project{ID: project.ID, Name: project.Name, Path: project.Path})
+		names[project.ID] = project.Name
+	}
+	var vms []ContainerVM
+	for _, c := range list {
+		if p.serviceContainer(c) {
+			continue // Listed with the services
+		}
+		projectID := containers.MatchProject(c, refs)
+		if projectID == "" {
+			continue
+		}
+		vms = append(vms, ContainerVM{
+			Container:   c,
+			ProjectID:   projectID,
+			ProjectName: names[projectID],
+			Following:   p.dockerService.Following(c.ID),
+		})
+	}
+	sort.SliceStable(vms, func(i, j int) bool { return vms[i].ProjectName < vms[j].ProjectName })
+	var serviceVMs []ServiceVM
+	if p.serviceMgr != nil {
+		serviceVMs = p.serviceVMs(list, err == nil)
+	}
+
+	p.mu.Lock()
+	if err != nil {
+		// Keep the last known containers: the daemon may be restarting
+		p.state.Processes.ContainersError = err.Error()
+	} else {
+		p.state.Processes.Containers = vms
+		p.state.Processes.ContainersError = ""
+	}
+	p.state.Processes.ContainerEngine = p.dockerService.Engine()
+	p.state.Processes.Services = serviceVMs
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMProcesses, p.state.Processes)
+}
+
+// findContainer returns a container of the Processes view by ID
+func (p *AppPresenter) findContainer(id string) (ContainerVM, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, c := range p.state.Processes.Containers {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return ContainerVM{}, false
+}
+
+// handleContainerAction starts, stops or restarts a container of a project
+// in the background, then lists the containers again
+func (p *AppPresenter) handleContainerAction(event *Event) error {
+	action, _ := event.Value.(string)
+	c, ok := p.findContainer(event.Target)
+	if !ok {
+		return fmt.Errorf("container not found: %s", event.Target)
+	}
+
+	verbs := map[containers.Action][2]string{
+		containers.ActionStart:   {"Starting", "started"},
+		containers.ActionStop:    {"Stopping", "stopped"},
+		containers.ActionRestart: {"Restarting", "restarted"},
+	}
+	verb, known := verbs[containers.Action(action)]
+	if !known {
+		return fmt.Errorf("unknown container action: %s", action)
+	}
+	p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("%s container %s...", verb[0], c.Name))
+
+	go func() {
+		start := time.Now()
+		if err := p.dockerService.Do(p.ctx, c.ID, containers.Action(action)); err != nil {
+			p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Container %s: %s failed: %v", c.Name, action, err))
+		} else {
+			p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Container %s %s (%s)", c.Name, verb[1], time.Since(start).Round(100*time.Millisecond)))
+		}
+		p.refreshContainers()
+	}()
+	return nil
+}
+
+// handleContainerLogs streams the logs of a container into the Logs view
+// (source "docker:<name>") until it stops; the logs already followed are
+// left as is
+func (p *AppPresenter) handleContainerLogs(event *Event) error {
+	c, ok := p.findContainer(event.Target)
+	if !ok {
+		return fmt.Errorf("container not found: %s", event.Target)
+	}
+	source := c.LogsSource()
+	started, err := p.dockerService.FollowLogs(p.ctx, c.ID,
+		func(line string, isError bool) { p.onContainerOutput(source, line, isError) },
+		func(err error) {
+			if err != nil {
+				p.onContainerOutput(source, "logs ended: "+err.Error(), true)
+			}
+			p.setFollowing(c.ID, false)
+		})
+	if err != nil {
+		return fmt.Errorf("cannot follow the logs of %s: %w", c.Name, err)
+	}
+	if started {
+		p.setFollowing(c.ID, true)
+	}
+	return nil
+}
+
+// setFollowing marks whether the logs of a container are followed
+func (p *AppPresenter) setFollowing(id string, following bool) {
+	p.mu.Lock()
+	// The list is replaced, not modified: the views may be rendering it
+	updated := append([]ContainerVM(nil), p.state.Processes.Containers...)
+	for i := range updated {
+		if updated[i].ID == id {
+			updated[i].Following = following
+		}
+	}
+	p.state.Processes.Containers = updated
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMProcesses, p.state.Processes)
+}
+
+// onContainerOutput adds a log line of a container to the Logs view
+func (p *AppPresenter) onContainerOutput(source, line string, isError bool) {
+	now := time.Now()
+	logLine := LogLineVM{
+		Timestamp: now,
+		TimeStr:   now.Format("15:04:05"),
+		Source:    source,
+		Level:     "info",
+		Message:   line,
+	}
+	if isError {
+		logLine.Level = "error"
+	}
+
+	p.mu.Lock()
+	p.state.Logs.Append(logLine)
+	p.mu.Unlock()
+
+	p.notifyStateChange(StateUpdate{ViewType: VMLogs, ViewModel: p.state.Logs, Appended: 1})
+}
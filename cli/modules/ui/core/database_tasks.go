@@ -0,0 +1,195 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/adhoc"
+	"csd-devtrack/cli/modules/platform/config"
+)
+
+// Database tasks: the reset and seed commands of a project
+const (
+	DatabaseTaskReset = "reset"
+	DatabaseTaskSeed  = "seed"
+)
+
+// DatabaseTaskComponent is the ad-hoc command key of database tasks: one runs
+// at a time per project, canceled with EventCancelCommand like other commands
+const DatabaseTaskComponent = "database"
+
+// maxDatabaseTaskOutput bounds the output kept in the task panel (the whole
+// output goes to the logs)
+const maxDatabaseTaskOutput = 500
+
+// handleDatabaseTask runs the reset or seed command of the project of a
+// database, with the database URL in its environment. The output goes to the
+// logs and the task panel of the Database view.
+func (p *AppPresenter) handleDatabaseTask(event *Event, action string) error {
+	if p.databaseService == nil {
+		return fmt.Errorf("database service not initialized")
+	}
+	db := p.databaseService.GetDatabase(event.Target)
+	if db == nil {
+		return fmt.Errorf("database not found: %s", event.Target)
+	}
+	project, err := p.projectService.GetProject(db.ProjectID)
+	if err != nil {
+		return fmt.Errorf("project not found: %s", db.ProjectID)
+	}
+	cmds := project.Database
+	command := ""
+	if cmds != nil {
+		command = cmds.Reset
+		if action == DatabaseTaskSeed {
+			command = cmds.Seed
+		}
+	}
+	if command == "" {
+		p.setHeaderEvent(HeaderEventWarning, fmt.Sprintf("No database.%s command configured for %s", action, project.Name))
+		return nil
+	}
+
+	// Paths are relative to the project
+	dir := project.Path
+	if cmds.Dir != "" {
+		dir = cmds.Dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(project.Path, dir)
+		}
+	}
+	cmdsEnv, err := config.ResolveSecretsMap(cmds.Env)
+	if err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Database %s failed: env %v", action, err))
+		return err
+	}
+	env := []string{
+		"DEVTRACK_PROJECT=" + project.ID,
+		"DEVTRACK_DATABASE_URL=" + db.URL,
+		"DEVTRACK_DATABASE_NAME=" + db.DatabaseName,
+	}
+	for k, v := range cmdsEnv {
+		env = append(env, k+"="+v)
+	}
+
+	req := adhoc.Request{
+		ProjectID: project.ID,
+		Component: DatabaseTaskComponent,
+		Command:   command,
+		Dir:       dir,
+		Env:       env,
+	}
+	task := DatabaseTaskVM{
+		DatabaseID:   db.ID,
+		DatabaseName: db.DatabaseName,
+		ProjectID:    project.ID,
+		Action:       action,
+		Command:      command,
+		Dir:          dir,
+		State:        string(adhoc.StateRunning),
+		StartedAt:    time.Now(),
+	}
+	// Shown before the command starts: its first lines may come before Start
+	// returns the run ID
+	p.mu.Lock()
+	if current := p.state.Database.Task; current != nil && current.State == string(adhoc.StateRunning) {
+		p.mu.Unlock()
+		p.setHeaderEvent(HeaderEventWarning, fmt.Sprintf("Database %s of %s still running", current.Action, current.DatabaseName))
+		return nil
+	}
+	p.state.Database.Task = &task
+	p.mu.Unlock()
+
+	r, err := p.adhocService.Start(p.ctx, req,
+		func(r adhoc.Run, line string, isError bool) {
+			p.onCommandOutput(r, line, isError)
+			p.onDatabaseTaskOutput(r, line)
+		},
+		func(r adhoc.Run) { p.onDatabaseTaskUpdate(task, r) })
+	if err != nil {
+		failed := task
+		failed.State = string(adhoc.StateFailed)
+		failed.Error = err.Error()
+		failed.FinishedAt = time.Now()
+		p.mu.Lock()
+		p.state.Database.Task = &failed
+		p.mu.Unlock()
+		p.notifyStateUpdate(VMDatabase, p.state.Database)
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Database %s failed: %v", action, err))
+		return err
+	}
+	p.onDatabaseTaskUpdate(task, *r)
+	return nil
+}
+
+// onDatabaseTaskUpdate reports a database task in the header and its panel
+func (p *AppPresenter) onDatabaseTaskUpdate(task DatabaseTaskVM, r adhoc.Run) {
+	p.mu.Lock()
+	current := p.state.Database.Task
+	// The start of a run is reported after Start returns: the run may have
+	// finished already
+	if !current.isRun(r) || (current.RunID != "" && current.State != string(adhoc.StateRunning)) {
+		p.mu.Unlock()
+		return
+	}
+	// The view model is replaced, not modified: the views may be rendering it
+	updated := *current
+	updated.RunID = r.ID
+	updated.Command = r.Command
+	updated.Dir = r.Dir
+	updated.State = string(r.State)
+	updated.ExitCode = r.ExitCode
+	updated.Error = r.Error
+	updated.StartedAt = r.StartedAt
+	updated.FinishedAt = r.FinishedAt
+	p.state.Database.Task = &updated
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMDatabase, p.state.Database)
+
+	label := fmt.Sprintf("%s %s", task.Action, task.DatabaseName)
+	switch r.State {
+	case adhoc.StateRunning:
+		p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("Running %s: %s", label, r.Command))
+	case adhoc.StateSuccess:
+		p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Database %s done (%s)", label, r.Duration().Round(time.Second)))
+	case adhoc.StateFailed:
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Database %s failed (exit %d): %s", label, r.ExitCode, r.Error))
+	case adhoc.StateCanceled:
+		p.setHeaderEvent(HeaderEventWarning, fmt.Sprintf("Database %s canceled", label))
+	}
+
+	// Also listed with the ad-hoc commands of the project
+	p.refreshCommandRuns()
+	p.notifyStateUpdate(VMProjects, p.state.Projects)
+}
+
+// onDatabaseTaskOutput adds an output line to the panel of a database task
+func (p *AppPresenter) onDatabaseTaskOutput(r adhoc.Run, line string) {
+	p.mu.Lock()
+	current := p.state.Database.Task
+	if !current.isRun(r) {
+		p.mu.Unlock()
+		return
+	}
+	updated := *current
+	updated.Output = append(append([]string(nil), current.Output...), line)
+	if len(updated.Output) > maxDatabaseTaskOutput {
+		updated.Output = updated.Output[len(updated.Output)-maxDatabaseTaskOutput:]
+	}
+	p.state.Database.Task = &updated
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMDatabase, p.state.Database)
+}
+
+// isRun returns true if the task is the run r, or the task whose run was
+// starting when r started
+func (t *DatabaseTaskVM) isRun(r adhoc.Run) bool {
+	if t == nil {
+		return false
+	}
+	if t.RunID == "" {
+		return t.ProjectID == r.ProjectID && t.State == string(adhoc.StateRunning)
+	}
+	return t.RunID == r.ID
+}
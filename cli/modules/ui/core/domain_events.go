@@ -0,0 +1,73 @@
+package core
+
+import (
+	"csd-devtrack/cli/modules/core/builds"
+	"csd-devtrack/cli/modules/core/processes"
+	"csd-devtrack/cli/modules/platform/eventbus"
+	"csd-devtrack/cli/modules/platform/git"
+)
+
+// publishBuildEvent publishes the start or the end of a build on the event bus
+func (p *AppPresenter) publishBuildEvent(event builds.BuildEvent) {
+	data := eventbus.BuildData{
+		ProjectID: event.ProjectID,
+		Component: event.Component,
+		BuildID:   event.BuildID,
+		Status:    string(builds.BuildStatusRunning),
+	}
+	eventType := eventbus.EventBuildStarted
+	if event.Type == builds.BuildEventFinished {
+		eventType = eventbus.EventBuildFinished
+		if build := p.buildOrch.GetBuild(event.BuildID); build != nil {
+			data.Status = string(build.Status)
+			data.Duration = build.Duration.Seconds()
+			data.ExitCode = build.ExitCode
+			data.Artifact = build.Artifact
+			if !build.IsSuccess() && len(build.Errors) > 0 {
+				data.Error = build.Errors[len(build.Errors)-1]
+			}
+		}
+	}
+	eventbus.Global().Publish(eventbus.NewBuildEvent(eventType, data))
+}
+
+// publishProcessEvent publishes the start, stop or crash of a process on
+// the event bus
+func (p *AppPresenter) publishProcessEvent(event processes.ProcessEvent) {
+	var eventType eventbus.EventType
+	switch event.Type {
+	case processes.ProcessEventStarted:
+		eventType = eventbus.EventProcessStarted
+	case processes.ProcessEventStopped:
+		eventType = eventbus.EventProcessStopped
+	case processes.ProcessEventCrashed:
+		eventType = eventbus.EventProcessCrashed
+	default:
+		return
+	}
+
+	data := eventbus.ProcessData{
+		ProjectID: event.ProjectID,
+		Component: event.Component,
+		ProcessID: event.ProcessID,
+		Message:   event.Message,
+	}
+	if proc := p.processService.GetProcess(event.ProcessID); proc != nil {
+		data.PID = proc.PID
+		data.ExitCode = proc.ExitCode
+	}
+	eventbus.Global().Publish(eventbus.NewProcessEvent(eventType, data))
+}
+
+// publishGitEvent publishes a change of the branch or the working tree of a
+// project on the event bus
+func (p *AppPresenter) publishGitEvent(projectID string, status *git.Status) {
+	eventbus.Global().Publish(eventbus.NewGitEvent(eventbus.GitData{
+		ProjectID: projectID,
+		Branch:    status.Branch,
+		Dirty:     !status.IsClean || status.HasUntracked,
+		Changes:   len(status.Staged) + len(status.Modified) + len(status.Untracked) + len(status.Deleted),
+		Ahead:     status.Ahead,
+		Behind:    status.Behind,
+	}))
+}
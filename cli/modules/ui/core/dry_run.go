@@ -0,0 +1,157 @@
+package core
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"csd-devtrack/cli/modules/platform/config"
+)
+
+// Dry run actions (Event.Target of EventDryRun)
+const (
+	DryRunBuild = "build"
+	DryRunRun   = "run"
+	DryRunStop  = "stop"
+)
+
+// handleDryRun shows what a build, run or stop of a project component would
+// execute: commands, working directories, environment and hooks. Nothing is
+// executed.
+func (p *AppPresenter) handleDryRun(event *Event) error {
+	dryRun := &DryRunVM{
+		Action:    event.Target,
+		ProjectID: event.ProjectID,
+		Component: string(event.Component),
+	}
+
+	var err error
+	switch event.Target {
+	case DryRunBuild:
+		err = p.planBuild(dryRun, event)
+	case DryRunRun:
+		err = p.planRun(dryRun, event)
+	case DryRunStop:
+		err = p.planStop(dryRun, event)
+	default:
+		return fmt.Errorf("unknown dry run action: %s", event.Target)
+	}
+	if err != nil {
+		dryRun.Error = err.Error()
+	}
+
+	p.mu.Lock()
+	p.state.Projects.DryRun = dryRun
+	p.mu.Unlock()
+
+	p.notifyStateUpdate(VMProjects, p.state.Projects)
+	return nil
+}
+
+// planBuild lists the build commands of a component (all enabled components
+// without one) and the hooks fired when the build ends
+func (p *AppPresenter) planBuild(dryRun *DryRunVM, event *Event) error {
+	plan, err := p.buildOrch.PlanProject(event.ProjectID)
+	if event.Component != "" {
+		plan, err = p.buildOrch.PlanComponent(event.ProjectID, event.Component)
+	}
+	if err != nil {
+		return err
+	}
+	for _, c := range plan {
+		dryRun.Commands = append(dryRun.Commands, dryRunCommand(c.Step, c.Command, c.Args, c.Dir, c.Env, c.Warning))
+	}
+	dryRun.Hooks = p.dryRunHooks(event, config.HookBuildSuccess, config.HookBuildFailure)
+	return nil
+}
+
+// planRun lists the command starting a component and what happens when it
+// crashes
+func (p *AppPresenter) planRun(dryRun *DryRunVM, event *Event) error {
+	project, err := p.projectService.GetProject(event.ProjectID)
+	if err != nil {
+		return fmt.Errorf("project not found: %s", event.ProjectID)
+	}
+	component := project.GetComponent(event.Component)
+	if component == nil {
+		return fmt.Errorf("component not found: %s", event.Component)
+	}
+
+	launch := p.processMgr.PlanStart(project, component)
+	if launch.Command == "" {
+		return fmt.Errorf("no run command for %s components (set run_cmd)", component.Type)
+	}
+	command := dryRunCommand("start", launch.Command, launch.Args, launch.Dir, launch.Env, "")
+	if proc := p.processService.GetProcessForComponent(event.ProjectID, event.Component); proc != nil && proc.IsRunning() {
+		command.Warning = fmt.Sprintf("already running (PID %d): the start would fail", proc.PID)
+	}
+	dryRun.Commands = append(dryRun.Commands, command)
+	dryRun.Hooks = p.dryRunHooks(event, config.HookProcessCrash)
+
+	if restart := p.restartConfig(); restart.Enabled {
+		dryRun.Notes = append(dryRun.Notes, fmt.Sprintf("Restarted after a crash (back-off %ds, stops after %d crashes in %d min)",
+			restart.BackoffSeconds, restart.MaxCrashes, restart.WindowMinutes))
+	}
+	return nil
+}
+
+// planStop describes how the running process of a component would be stopped
+func (p *AppPresenter) planStop(dryRun *DryRunVM, event *Event) error {
+	proc := p.processService.GetProcessForComponent(event.ProjectID, event.Component)
+	if proc == nil || !proc.IsRunning() {
+		dryRun.Notes = append(dryRun.Notes, "Not running: nothing to stop")
+		return nil
+	}
+	dryRun.Commands = append(dryRun.Commands, DryRunCommandVM{
+		Step:    "stop",
+		Command: p.processMgr.PlanStop(proc),
+	})
+	return nil
+}
+
+// dryRunHooks lists the hooks of the events an action would fire
+func (p *AppPresenter) dryRunHooks(event *Event, hookEvents ...string) []DryRunHookVM {
+	if p.hookService == nil {
+		return nil
+	}
+	var result []DryRunHookVM
+	for _, hookEvent := range hookEvents {
+		hc := p.hookContext(hookEvent, event.ProjectID, string(event.Component), nil)
+		for _, h := range p.hookService.Matching(hookEvent, event.ProjectID, string(event.Component)) {
+			result = append(result, DryRunHookVM{Event: h.Event, Command: h.Command, Dir: hc.Dir})
+		}
+	}
+	return result
+}
+
+// dryRunCommand converts a planned command, warning when its executable is
+// not found
+func dryRunCommand(step, command string, args []string, dir string, env []string, warning string) DryRunCommandVM {
+	if _, err := exec.LookPath(command); err != nil && warning == "" {
+		warning = fmt.Sprintf("%s not found in PATH", command)
+	}
+	return DryRunCommandVM{
+		Step:    step,
+		Command: shellJoin(append([]string{command}, args...)),
+		Dir:     dir,
+		Env:     env,
+		Warning: warning,
+	}
+}
+
+// shellJoin joins a command line, quoting the arguments a shell would split
+func shellJoin(argv []string) string {
+	quoted := make([]string, len(argv))
+	for i, arg := range argv {
+		quoted[i] = ShellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// ShellQuote quotes a word for sh when it contains special characters
+func ShellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n\"'\\$`|&;<>()*?") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -21,12 +21,20 @@ const (
 	EventAddProject      EventType = "add_project"
 	EventRemoveProject   EventType = "remove_project"
 	EventRefreshProject  EventType = "refresh_project"
+	EventToggleFavorite  EventType = "toggle_favorite"   // Pin/unpin ProjectID at the top of the trees
+	EventMoveProject     EventType = "move_project"      // Value "up"/"down": custom order
+	EventSetProjectOrder EventType = "set_project_order" // Value: name, custom, recent
 
 	// Build events
 	EventStartBuild      EventType = "start_build"
 	EventCancelBuild     EventType = "cancel_build"
-	EventBuildAll        EventType = "build_all"
+	EventBuildAll        EventType = "build_all" // Data projects: only these project IDs (one per line)
 	EventSelectComponent EventType = "select_component"
+	EventSecurityScan    EventType = "security_scan"
+
+	// Container image builds
+	EventImageBuild       EventType = "image_build"        // ProjectID, Data profile: docker/podman build of its Dockerfile
+	EventCancelImageBuild EventType = "cancel_image_build" // ProjectID: stop its running image build
 
 	// Process events
 	EventStartProcess    EventType = "start_process"
@@ -35,11 +43,41 @@ const (
 	EventKillProcess     EventType = "kill_process"
 	EventPauseProcess    EventType = "pause_process"
 	EventViewLogs        EventType = "view_logs"
+	EventDryRun          EventType = "dry_run"        // Target = build, run or stop: what it would execute, without executing it
+	EventRunCommand      EventType = "run_command"    // ProjectID, Component (optional), Value = shell command run in its directory
+	EventCancelCommand   EventType = "cancel_command" // ProjectID, Component: kill its running ad-hoc command
+	EventAPIExplore      EventType = "api_explore"    // ProjectID, Component: list the endpoints of its API descriptor
+	EventAPIRequest      EventType = "api_request"    // ProjectID, Component, Data method/path/body: fire a test request at its API
+
+	// Local TLS certificates
+	EventGenerateCert EventType = "generate_cert" // ProjectID, Component: generate its certificate (mkcert, self-signed without it)
+
+	// Dev hostnames (hosts file)
+	EventHostsCheck EventType = "hosts_check" // Hostnames of the components: managed block of the hosts file, resolution
+	EventHostsApply EventType = "hosts_apply" // Value apply/remove: write the managed block of the hosts file
+
+	// Built-in reverse proxy
+	EventProxyToggle EventType = "proxy_toggle" // Value start/stop (empty = toggle)
+
+	// Capture proxy (outbound requests of the components)
+	EventCaptureToggle    EventType = "capture_toggle"    // Value start/stop (empty = toggle)
+	EventCaptureComponent EventType = "capture_component" // ProjectID, Component: mark or unmark it for capture
+	EventCaptureDetail    EventType = "capture_detail"    // Target request ID (empty = close): headers and bodies
+	EventCaptureClear     EventType = "capture_clear"     // Drop the recorded requests
+	EventCaptureExport    EventType = "capture_export"    // Value filter, Target HAR path (empty = capture directory)
+
+	// Container events (Docker or Podman)
+	EventContainerAction EventType = "container_action" // Target container ID, Value start/stop/restart
+	EventContainerLogs   EventType = "container_logs"   // Target container ID: follow its logs in the Logs view
+	EventServiceAction   EventType = "service_action"   // Target service of the catalog, Value start/stop/restart
+	EventServicesUp      EventType = "services_up"      // ProjectID (empty = all): start the services its components depend on
+	EventServiceLogs     EventType = "service_logs"     // Target service: follow its container logs in the Logs view
 
 	// Git events
 	EventGitStatus       EventType = "git_status"
 	EventGitDiff         EventType = "git_diff"
 	EventGitLog          EventType = "git_log"
+	EventGitStage        EventType = "git_stage" // ProjectID, Data paths (one per line): git add of these files
 
 	// Config events
 	EventSaveConfig      EventType = "save_config"
@@ -50,6 +88,12 @@ const (
 	EventClaudeSelectSession    EventType = "claude_select_session"
 	EventClaudeDeleteSession    EventType = "claude_delete_session"
 	EventClaudeRenameSession    EventType = "claude_rename_session"
+	EventClaudeForkSession      EventType = "claude_fork_session"
+	EventClaudeCleanupPreview   EventType = "claude_cleanup_preview"
+	EventClaudeCleanupSessions  EventType = "claude_cleanup_sessions"
+	EventSessionLinkTask        EventType = "session_link_task"
+	EventSessionDeleteTask      EventType = "session_delete_task"
+	EventClaudeLoadOlder        EventType = "claude_load_older"
 	EventClaudeSendMessage      EventType = "claude_send_message"
 	EventClaudeStopSession      EventType = "claude_stop_session"
 	EventClaudeClearHistory     EventType = "claude_clear_history"
@@ -66,6 +110,10 @@ const (
 	EventDatabaseRenameSession  EventType = "database_rename_session"
 	EventDatabaseStopSession    EventType = "database_stop_session"
 	EventDatabaseRefresh        EventType = "database_refresh"
+	EventDatabaseMigrations     EventType = "database_migrations" // Target database ID, Data dir: migration status
+	EventDatabaseMigrate        EventType = "database_migrate"    // Target database ID, Value action, Data dir: apply or roll back
+	EventDatabaseReset          EventType = "database_reset"      // Target database ID: run the project's database.reset command
+	EventDatabaseSeed           EventType = "database_seed"       // Target database ID: run the project's database.seed command
 
 	// Shell events
 	EventShellCreateSession EventType = "shell_create_session"
@@ -74,6 +122,40 @@ const (
 	EventShellCycleShell    EventType = "shell_cycle_shell"
 	EventShellRefresh       EventType = "shell_refresh"
 
+	// Capabilities events
+	EventCapabilitiesRefresh EventType = "capabilities_refresh" // Detect the external tools again
+
+	// Backup events
+	EventBackupCreate EventType = "backup_create" // Archive the config and data to the backups directory
+
+	// Storage events
+	EventStorageScan  EventType = "storage_scan"
+	EventStorageClean EventType = "storage_clean"
+
+	// Transfer events
+	EventTransferStart EventType = "transfer_start" // ProjectID, Data: host, direction, local, remote
+
+	// Deploy events
+	EventDeploy EventType = "deploy" // ProjectID, Target = deploy target name
+
+	// Trash events
+	EventTrashPut     EventType = "trash_put"     // Record an item deleted by the UI (Target = kind)
+	EventTrashRestore EventType = "trash_restore" // Value = item ID ("" = most recent)
+	EventTrashDelete  EventType = "trash_delete"  // Value = item ID
+	EventTrashEmpty   EventType = "trash_empty"
+
+	// Audit events
+	EventAuditRecord EventType = "audit_record" // Record an action performed by the UI (Target = action, Value = details)
+	EventAuditExport EventType = "audit_export" // Value = export path (.csv or .json), ProjectID = filter
+
+	// Plugin events
+	EventPluginAction EventType = "plugin_action" // Target = "plugin/action", ProjectID for project actions
+	EventPluginReload EventType = "plugin_reload" // Rediscover plugins and refresh their views
+
+	// Diagnostics events
+	EventSetLogLevel EventType = "set_log_level" // Value = debug, info, warn or error
+	EventLowPower    EventType = "low_power"     // Value = true while no one looks at DevTrack (slower polling)
+
 	// UI state events
 	EventFilter          EventType = "filter"
 	EventSort            EventType = "sort"
@@ -89,6 +171,41 @@ type Event struct {
 	Component projects.ComponentType `json:"component,omitempty"`
 	Value     interface{}            `json:"value,omitempty"`     // Generic payload
 	Data      map[string]string      `json:"data,omitempty"`      // Additional data
+	Origin    string                 `json:"origin,omitempty"`    // View the event was sent from (audit log)
+	User      string                 `json:"user,omitempty"`      // Teammate who sent it to a shared daemon (audit log)
+}
+
+// ReadOnlyEvents are the events an observer (read-only TUI, viewer of a
+// shared daemon) may send: they only change what is displayed, never the
+// state of projects, processes or sessions
+var ReadOnlyEvents = map[EventType]bool{
+	EventNavigate:              true,
+	EventBack:                  true,
+	EventRefresh:               true,
+	EventSelectProject:         true,
+	EventSelectComponent:       true,
+	EventViewLogs:              true,
+	EventDryRun:                true,
+	EventAPIExplore:            true,
+	EventHostsCheck:            true,
+	EventCaptureDetail:         true,
+	EventGitStatus:             true,
+	EventGitDiff:               true,
+	EventGitLog:                true,
+	EventClaudeSelectSession:   true,
+	EventClaudeCleanupPreview:  true,
+	EventClaudeLoadOlder:       true,
+	EventDatabaseSelectSession: true,
+	EventDatabaseRefresh:       true,
+	EventDatabaseMigrations:    true,
+	EventShellRefresh:          true,
+	EventStorageScan:           true,
+	EventCapabilitiesRefresh:   true,
+	EventLowPower:              true,
+	EventFilter:                true,
+	EventSort:                  true,
+	EventToggle:                true,
+	EventScroll:                true,
 }
 
 // NewEvent creates a new event
@@ -123,6 +240,12 @@ func (e *Event) WithValue(value interface{}) *Event {
 	return e
 }
 
+// WithOrigin sets the originating view
+func (e *Event) WithOrigin(origin string) *Event {
+	e.Origin = origin
+	return e
+}
+
 // WithData adds data key-value pairs
 func (e *Event) WithData(key, value string) *Event {
 	if e.Data == nil {
@@ -238,6 +361,47 @@ type StateUpdate struct {
 	ViewType   ViewModelType `json:"view_type"`
 	ViewModel  ViewModel     `json:"view_model"`
 	Partial    bool          `json:"partial"` // If true, merge with existing state
+
+	// Diff: what changed in the view model (both empty = anything may have changed)
+	ChangedIDs []string `json:"changed_ids,omitempty"` // IDs of changed items (builds, processes...)
+	Appended   int      `json:"appended,omitempty"`    // Number of items appended at the end (logs)
+}
+
+// Affects returns true if the update concerns the given view model
+func (u StateUpdate) Affects(viewType ViewModelType) bool {
+	return u.ViewType == "" || u.ViewType == viewType
+}
+
+// merge folds a newer update of the same view model into u
+func (u *StateUpdate) merge(newer StateUpdate) {
+	u.ViewModel = newer.ViewModel
+	if u.full() || newer.full() {
+		// A full update absorbs the diffs
+		u.ChangedIDs = nil
+		u.Appended = 0
+		return
+	}
+	u.Appended += newer.Appended
+	for _, id := range newer.ChangedIDs {
+		if !containsString(u.ChangedIDs, id) {
+			u.ChangedIDs = append(u.ChangedIDs, id)
+		}
+	}
+}
+
+// full returns true if the update carries no diff information
+func (u StateUpdate) full() bool {
+	return len(u.ChangedIDs) == 0 && u.Appended == 0
+}
+
+// containsString returns true if s is in list
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
 }
 
 // ============================================
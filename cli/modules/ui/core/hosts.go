@@ -0,0 +1,186 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+
+	"csd-devtrack/cli/modules/platform/capabilities"
+	"csd-devtrack/cli/modules/platform/hosts"
+)
+
+// hostMappings returns the dev hostnames of the enabled components, sorted
+func (p *AppPresenter) hostMappings() []HostMappingVM {
+	var mappings []HostMappingVM
+	for _, project := range p.projectService.ListProjects() {
+		for _, comp := range project.GetEnabledComponents() {
+			for _, host := range comp.Hostnames {
+				entry := hosts.Entry{Host: host}
+				m := HostMappingVM{
+					Host:     host,
+					Target:   project.ID + "/" + string(comp.Type),
+					Wildcard: entry.Wildcard(),
+					Builtin:  entry.Localhost(),
+				}
+				if comp.Port > 0 && !m.Wildcard {
+					scheme := "http"
+					if comp.TLS != nil && comp.TLS.Cert != "" {
+						scheme = "https"
+					}
+					m.URL = fmt.Sprintf("%s://%s:%d/", scheme, host, comp.Port)
+				}
+				mappings = append(mappings, m)
+			}
+		}
+	}
+	sort.SliceStable(mappings, func(i, j int) bool { return mappings[i].Host < mappings[j].Host })
+	return mappings
+}
+
+// hostEntries returns the entries of the managed block of the hostnames
+func hostEntries(mappings []HostMappingVM) []hosts.Entry {
+	entries := make([]hosts.Entry, len(mappings))
+	for i, m := range mappings {
+		entries[i] = hosts.Entry{Host: m.Host, Target: m.Target}
+	}
+	return entries
+}
+
+// hostsSudo returns the sudo writing the hosts file, empty without it
+func (p *AppPresenter) hostsSudo() string {
+	if runtime.GOOS == "windows" || !p.capService.IsAvailable(capabilities.CapSudo) {
+		return ""
+	}
+	return p.capService.GetPath(capabilities.CapSudo)
+}
+
+// hostsVM compares the hostnames of the components with the managed block
+// of the hosts file (without resolving them)
+func (p *AppPresenter) hostsVM() *HostsVM {
+	path := hosts.Path()
+	vm := &HostsVM{
+		Path:     path,
+		Mappings: p.hostMappings(),
+		Writable: hosts.Writable(path),
+		Sudo:     p.hostsSudo(),
+	}
+	entries := hostEntries(vm.Mappings)
+	vm.Block = hosts.Block(entries)
+
+	content, err := hosts.Read(path)
+	if err != nil {
+		vm.Error = err.Error()
+		return vm
+	}
+	managed := make(map[string]bool)
+	for _, host := range hosts.Managed(content) {
+		managed[host] = true
+	}
+	for i := range vm.Mappings {
+		vm.Mappings[i].Managed = managed[vm.Mappings[i].Host]
+	}
+	vm.UpToDate = hosts.Current(content) == vm.Block
+	return vm
+}
+
+// handleHostsCheck refreshes the dev hostnames panel, then resolves the
+// hostnames in the background
+func (p *AppPresenter) handleHostsCheck(event *Event) error {
+	vm := p.hostsVM()
+	vm.Checking = len(vm.Mappings) > 0
+
+	p.mu.Lock()
+	if previous := p.state.Projects.Hosts; previous != nil && previous.Staged != "" {
+		os.Remove(previous.Staged) // Copied by sudo, or abandoned
+	}
+	p.state.Projects.Hosts = vm
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMProjects, p.state.Projects)
+	if !vm.Checking {
+		return nil
+	}
+
+	go func() {
+		mappings := make([]HostMappingVM, len(vm.Mappings))
+		copy(mappings, vm.Mappings)
+		for i := range mappings {
+			host := mappings[i].Host
+			if mappings[i].Wildcard {
+				host = "devtrack-check." + host[2:] // Any subdomain
+			}
+			mappings[i].Resolves = hosts.Resolves(p.ctx, host)
+		}
+
+		p.mu.Lock()
+		current := p.state.Projects.Hosts
+		if current == nil || !current.Checking || len(current.Mappings) != len(mappings) {
+			p.mu.Unlock()
+			return // Closed, or the hostnames changed meanwhile
+		}
+		updated := *current
+		updated.Mappings = mappings
+		updated.Checking = false
+		p.state.Projects.Hosts = &updated
+		p.mu.Unlock()
+		p.notifyStateUpdate(VMProjects, p.state.Projects)
+	}()
+	return nil
+}
+
+// handleHostsApply writes the managed block of the hosts file ("apply") or
+// removes it ("remove"). When sudo asks a password, the new hosts file is
+// staged for the UI to copy it with sudo in the terminal.
+func (p *AppPresenter) handleHostsApply(event *Event) error {
+	action, _ := event.Value.(string)
+	path := hosts.Path()
+	content, err := hosts.Read(path)
+	if err != nil {
+		return err
+	}
+	var entries []hosts.Entry
+	if action != "remove" {
+		entries = hostEntries(p.hostMappings())
+	}
+	updated := hosts.Update(content, entries)
+	if hosts.Current(updated) == hosts.Current(content) {
+		p.setHeaderEvent(HeaderEventInfo, path+" is up to date")
+		return nil
+	}
+
+	done := fmt.Sprintf("%s: %d hostnames mapped to %s", path, len(hosts.Managed(updated)), hosts.LoopbackIP)
+	if action == "remove" {
+		done = path + ": DevTrack block removed"
+	}
+	p.setPersistentHeaderEvent(HeaderEventInfo, "Updating "+path+"...")
+
+	go func() {
+		err := hosts.Write(p.ctx, path, updated, p.hostsSudo())
+		if errors.Is(err, hosts.ErrSudoPassword) {
+			staged, stageErr := hosts.Stage(updated)
+			if stageErr != nil {
+				p.setHeaderEvent(HeaderEventError, stageErr.Error())
+				return
+			}
+			p.mu.Lock()
+			vm := HostsVM{Path: path}
+			if p.state.Projects.Hosts != nil {
+				vm = *p.state.Projects.Hosts
+			}
+			vm.Staged = staged
+			p.state.Projects.Hosts = &vm
+			p.mu.Unlock()
+			p.notifyStateUpdate(VMProjects, p.state.Projects)
+			p.setHeaderEvent(HeaderEventWarning, "sudo needs your password: press S in the hostnames panel")
+			return
+		}
+		if err != nil {
+			p.setHeaderEvent(HeaderEventError, err.Error())
+			return
+		}
+		p.setHeaderEvent(HeaderEventSuccess, done)
+		p.handleHostsCheck(event)
+	}()
+	return nil
+}
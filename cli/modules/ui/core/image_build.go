@@ -0,0 +1,214 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/capabilities"
+	"csd-devtrack/cli/modules/platform/containers"
+)
+
+// maxImageBuildOutput bounds the output kept for the Build view
+const maxImageBuildOutput = 300
+
+// defaultImageTags are the tags of each build profile when the project does
+// not configure them ({git} = short commit hash)
+var defaultImageTags = map[string][]string{
+	"dev":  {"dev"},
+	"test": {"test"},
+	"prod": {"latest", "{git}"},
+}
+
+// imageDockerfile returns the Dockerfile of a project
+func imageDockerfile(project *projects.Project) string {
+	dockerfile := "Dockerfile"
+	if project.Image != nil && project.Image.Dockerfile != "" {
+		dockerfile = project.Image.Dockerfile
+	}
+	if filepath.IsAbs(dockerfile) {
+		return dockerfile
+	}
+	return filepath.Join(project.Path, dockerfile)
+}
+
+// imageRequest returns the image build of a project for a build profile
+func imageRequest(project *projects.Project, profile, gitHash string) containers.BuildRequest {
+	cfg := project.Image
+	if cfg == nil {
+		cfg = &projects.ImageConfig{}
+	}
+	req := containers.BuildRequest{
+		ProjectID:  project.ID,
+		Profile:    profile,
+		Dockerfile: imageDockerfile(project),
+		Target:     cfg.Target,
+		Args:       cfg.Args,
+	}
+	req.Context = filepath.Dir(req.Dockerfile)
+	if cfg.Context != "" {
+		req.Context = cfg.Context
+		if !filepath.IsAbs(req.Context) {
+			req.Context = filepath.Join(project.Path, req.Context)
+		}
+	}
+
+	name := cfg.Name
+	if name == "" {
+		name = strings.ToLower(project.ID) // Repositories are lowercase
+	}
+	tags := cfg.Tags[profile]
+	if len(tags) == 0 {
+		tags = defaultImageTags[profile]
+	}
+	if len(tags) == 0 {
+		tags = []string{profile}
+	}
+	for _, tag := range tags {
+		if strings.Contains(tag, "{git}") {
+			if gitHash == "" {
+				continue // Not a repository
+			}
+			tag = strings.ReplaceAll(tag, "{git}", gitHash)
+		}
+		tag = strings.ReplaceAll(tag, "{profile}", profile)
+		// A full reference ("registry/app:tag") is kept as is
+		if !strings.Contains(tag[strings.LastIndex(tag, "/")+1:], ":") {
+			tag = name + ":" + tag
+		}
+		req.Tags = append(req.Tags, tag)
+	}
+	return req
+}
+
+// handleImageBuild builds the container image of a project with docker or
+// podman, tagged for the build profile. The steps are followed in the Build
+// view; the image size is kept in the history.
+func (p *AppPresenter) handleImageBuild(event *Event) error {
+	if p.imageBuilder == nil || !p.capService.IsAvailable(capabilities.CapDocker) {
+		p.setHeaderEvent(HeaderEventError, "Image build: docker or podman not found")
+		return nil
+	}
+	project, err := p.projectService.GetProject(event.ProjectID)
+	if err != nil {
+		return fmt.Errorf("project not found: %s", event.ProjectID)
+	}
+	profile := event.Data["profile"]
+	if profile == "" {
+		profile = "dev"
+	}
+	gitHash := ""
+	if head, err := p.gitService.GetHead(project.ID); err == nil && head != nil {
+		gitHash = head.ShortHash
+	}
+	req := imageRequest(project, profile, gitHash)
+
+	started, err := p.imageBuilder.Start(p.ctx, req,
+		func(b containers.ImageBuild, line string) { p.onImageBuildOutput(b, line) },
+		func(b containers.ImageBuild) { p.onImageBuildDone(b) })
+	if err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Image build of %s: %v", project.Name, err))
+		return nil
+	}
+
+	p.mu.Lock()
+	p.state.Builds.ImageBuild = &ImageBuildVM{ImageBuild: *started, ProjectName: project.Name}
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMBuild, p.state.Builds)
+	p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("Building image %s [%s]...", started.Image(), strings.ToUpper(profile)))
+	return nil
+}
+
+// handleCancelImageBuild stops the running image build of a project
+func (p *AppPresenter) handleCancelImageBuild(event *Event) error {
+	if p.imageBuilder == nil || !p.imageBuilder.Cancel(event.ProjectID) {
+		p.setHeaderEvent(HeaderEventWarning, "No image build running")
+	}
+	return nil
+}
+
+// onImageBuildOutput adds an output line to the image build of the Build
+// view, with the steps parsed so far
+func (p *AppPresenter) onImageBuildOutput(b containers.ImageBuild, line string) {
+	p.mu.Lock()
+	current := p.state.Builds.ImageBuild
+	if current == nil || current.ID != b.ID {
+		p.mu.Unlock()
+		return
+	}
+	// The view model is replaced, not modified: the views may be rendering it
+	updated := *current
+	updated.ImageBuild = b
+	updated.Output = append(append([]string(nil), current.Output...), line)
+	if len(updated.Output) > maxImageBuildOutput {
+		updated.Output = updated.Output[len(updated.Output)-maxImageBuildOutput:]
+	}
+	p.state.Builds.ImageBuild = &updated
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMBuild, p.state.Builds)
+}
+
+// onImageBuildDone reports the end of an image build
+func (p *AppPresenter) onImageBuildDone(b containers.ImageBuild) {
+	p.refreshImageHistory()
+
+	p.mu.Lock()
+	if current := p.state.Builds.ImageBuild; current != nil && current.ID == b.ID {
+		updated := *current
+		updated.ImageBuild = b
+		for _, h := range p.state.Builds.ImageHistory {
+			if h.ID == b.ID {
+				updated.SizeDelta = h.SizeDelta
+			}
+		}
+		p.state.Builds.ImageBuild = &updated
+	}
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMBuild, p.state.Builds)
+
+	duration := b.Duration().Round(time.Second)
+	switch b.State {
+	case containers.BuildSuccess:
+		p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Image %s built in %s (%d/%d steps cached)", b.Image(), duration, b.Cached(), len(b.Steps)))
+	case containers.BuildCanceled:
+		p.setHeaderEvent(HeaderEventWarning, fmt.Sprintf("Image build of %s canceled", b.Image()))
+	default:
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Image build of %s failed: %s", b.Image(), b.Error))
+	}
+}
+
+// refreshImageHistory loads the image builds, most recent first, each
+// successful one compared to the previous one of its project and profile
+func (p *AppPresenter) refreshImageHistory() {
+	if p.imageBuilder == nil {
+		return
+	}
+	builds := p.imageBuilder.History()
+
+	lastSize := make(map[string]int64) // Project and profile -> size of its previous image
+	names := make(map[string]string)
+	history := make([]ImageBuildVM, len(builds))
+	for i, b := range builds {
+		vm := ImageBuildVM{ImageBuild: b, ProjectName: b.ProjectID}
+		if name, ok := names[b.ProjectID]; ok {
+			vm.ProjectName = name
+		} else if project, err := p.projectService.GetProject(b.ProjectID); err == nil {
+			vm.ProjectName = project.Name
+			names[b.ProjectID] = project.Name
+		}
+		if b.State == containers.BuildSuccess && b.Size > 0 {
+			key := b.ProjectID + "/" + b.Profile
+			if previous, ok := lastSize[key]; ok {
+				vm.SizeDelta = b.Size - previous
+			}
+			lastSize[key] = b.Size
+		}
+		history[len(builds)-1-i] = vm
+	}
+
+	p.mu.Lock()
+	p.state.Builds.ImageHistory = history
+	p.mu.Unlock()
+}
@@ -57,6 +57,21 @@ type Presenter interface {
 	GetState() *AppState
 }
 
+// LogSourceDevTrack is the log source of DevTrack's own diagnostics
+const LogSourceDevTrack = "devtrack"
+
+// Logger receives DevTrack's own diagnostics (daemon events, poll timings,
+// terminal lifecycle). The platform logger implements it.
+type Logger interface {
+	Debug(format string, args ...interface{})
+	Info(format string, args ...interface{})
+	Warn(format string, args ...interface{})
+	Error(format string, args ...interface{})
+
+	// SetLevelName changes the minimum level (debug, info, warn, error)
+	SetLevelName(level string)
+}
+
 // ViewFactory creates views of different types
 type ViewFactory interface {
 	// CreateView creates a view of the specified type
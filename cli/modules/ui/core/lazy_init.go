@@ -0,0 +1,58 @@
+package core
+
+import (
+	"strings"
+
+	"csd-devtrack/cli/modules/platform/startup"
+)
+
+// ensureEventLoaded loads the data of a view before an event that needs it:
+// navigating to the view, or acting on its items (the shell or a restored
+// TUI state can send events before the view was ever opened)
+func (p *AppPresenter) ensureEventLoaded(event *Event) {
+	if event.Type == EventNavigate {
+		p.ensureViewLoaded(ViewModelType(event.Target))
+		return
+	}
+	switch eventType := string(event.Type); {
+	case strings.HasPrefix(eventType, "claude_"), strings.HasPrefix(eventType, "session_"):
+		p.ensureViewLoaded(VMClaude)
+	case strings.HasPrefix(eventType, "database_"):
+		p.ensureViewLoaded(VMDatabase)
+	}
+}
+
+// ensureViewLoaded initializes the data of a view the first time it is
+// opened. Parsing the Claude history or detecting databases in every
+// project is slow and not needed to show the other views at startup.
+func (p *AppPresenter) ensureViewLoaded(view ViewModelType) {
+	switch view {
+	case VMClaude:
+		p.claudeLoad.Do(p.loadClaude)
+	case VMDatabase:
+		p.databaseLoad.Do(p.loadDatabase)
+	case VMCockpit:
+		// Widgets show the Claude sessions and the databases
+		p.claudeLoad.Do(p.loadClaude)
+		p.databaseLoad.Do(p.loadDatabase)
+	}
+}
+
+// loadClaude reads the Claude sessions, then removes the stale ones when
+// the cleanup is automatic
+func (p *AppPresenter) loadClaude() {
+	if p.claudeService == nil {
+		return
+	}
+	defer startup.Track("claude sessions (first open)")()
+	p.claudeService.LoadSessions()
+	p.autoCleanupClaudeSessions()
+	p.refreshClaude()
+	p.log().Info("Claude sessions loaded: %d", len(p.claudeService.ListSessions("")))
+}
+
+// loadDatabase detects the databases of the projects
+func (p *AppPresenter) loadDatabase() {
+	defer startup.Track("databases (first open)")()
+	p.refreshDatabase()
+}
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+)
+
+// logRateWindow counts the lines of a log source in the current second
+type logRateWindow struct {
+	start      time.Time
+	limit      int // Lines kept per second when the window started
+	lines      int // Lines received in the window
+	suppressed int // Lines not kept
+	every      int // Sample mode: one line kept in every beyond the limit
+}
+
+// logRateLimiter caps the lines kept per log source and second, so a log
+// storm does not flood the buffer (and the TUI redrawing it)
+type logRateLimiter struct {
+	windows map[string]*logRateWindow
+}
+
+// newLogRateLimiter creates a limiter without sources
+func newLogRateLimiter() *logRateLimiter {
+	return &logRateLimiter{windows: make(map[string]*logRateWindow)}
+}
+
+// allow returns true if a line of the source is kept, and the marker of the
+// lines suppressed in its previous window (nil if none)
+func (l *logRateLimiter) allow(source string, now time.Time, limit int, mode string) (bool, *LogLineVM) {
+	w := l.windows[source]
+	if w == nil {
+		if limit <= 0 {
+			return true, nil
+		}
+		w = &logRateWindow{start: now, limit: limit, every: 2}
+		l.windows[source] = w
+	}
+
+	var marker *LogLineVM
+	if now.Sub(w.start) >= time.Second {
+		marker = w.marker(source)
+		// Sampling keeps about as many lines beyond the limit as within it
+		w.every = max((w.lines+w.limit-1)/max(w.limit, 1), 2)
+		w.start, w.limit, w.lines, w.suppressed = now, limit, 0, 0
+	}
+	w.lines++
+	if limit <= 0 || w.lines <= limit {
+		return true, marker
+	}
+	if mode == config.LogRateSample && (w.lines-limit)%w.every == 0 {
+		return true, marker
+	}
+	w.suppressed++
+	return false, marker
+}
+
+// flush returns the markers of the windows ended before now and forgets the
+// sources that went quiet
+func (l *logRateLimiter) flush(now time.Time) []LogLineVM {
+	var markers []LogLineVM
+	for source, w := range l.windows {
+		if now.Sub(w.start) < time.Second {
+			continue
+		}
+		if marker := w.marker(source); marker != nil {
+			markers = append(markers, *marker)
+		}
+		delete(l.windows, source)
+	}
+	return markers
+}
+
+// marker returns the line reporting the suppressed lines of the window, nil
+// if all were kept
+func (w *logRateWindow) marker(source string) *LogLineVM {
+	if w.suppressed == 0 {
+		return nil
+	}
+	end := w.start.Add(time.Second)
+	return &LogLineVM{
+		Timestamp: end,
+		TimeStr:   end.Format("15:04:05"),
+		Source:    source,
+		Level:     "warn",
+		Message:   fmt.Sprintf("⋯ %d lines suppressed (%d lines/s, over the %d lines/s limit)", w.suppressed, w.lines, w.limit),
+	}
+}
+
+// logRateConfig returns the log rate limits from config
+func (p *AppPresenter) logRateConfig() *config.LogRateConfig {
+	if p.config == nil || p.config.Settings == nil {
+		return &config.LogRateConfig{}
+	}
+	return p.config.Settings.GetLogRateConfig()
+}
+
+// appendLog adds a line to the logs within the rate limit of its source and
+// returns the number of lines added (suppressed errors are still counted in
+// their group). The caller holds p.mu.
+func (p *AppPresenter) appendLog(line LogLineVM) int {
+	cfg := p.logRateConfig()
+	keep, marker := p.logLimiter.allow(line.Source, time.Now(), cfg.Limit(line.Source), cfg.Mode)
+	added := 0
+	if marker != nil {
+		p.state.Logs.Append(*marker)
+		added++
+	}
+	if keep {
+		p.state.Logs.Append(line)
+		added++
+	} else if line.Level == "error" {
+		p.state.Logs.CountError(line)
+	}
+	return added
+}
+
+// flushLogRates adds the markers of the sources that stopped being chatty,
+// every second until shutdown
+func (p *AppPresenter) flushLogRates() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case now := <-ticker.C:
+			p.mu.Lock()
+			markers := p.logLimiter.flush(now)
+			for _, marker := range markers {
+				p.state.Logs.Append(marker)
+			}
+			p.mu.Unlock()
+			if len(markers) > 0 {
+				p.notifyStateChange(StateUpdate{ViewType: VMLogs, ViewModel: p.state.Logs, Appended: len(markers)})
+			}
+		}
+	}
+}
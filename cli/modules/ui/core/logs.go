@@ -0,0 +1,184 @@
+package core
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxLogErrorGroups is the number of error groups kept, least recently seen dropped first
+const MaxLogErrorGroups = 200
+
+// Message parts masked when grouping errors, most specific first
+var logErrorMasks = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`), ""},
+	{regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`), "<id>"},
+	{regexp.MustCompile(`0x[0-9a-fA-F]+`), "<hex>"},
+	{regexp.MustCompile(`\b[0-9a-f]{7,}\b`), "<id>"}, // Hashes and long numbers
+	{regexp.MustCompile(`\d+(?:\.\d+)?`), "<n>"},
+	{regexp.MustCompile(`\s+`), " "},
+}
+
+var (
+	// logLineStamp matches a timestamp starting a log message: ISO 8601 / RFC
+	// 3339, Go log (2006/01/02 15:04:05) or time only, optionally bracketed
+	logLineStamp = regexp.MustCompile(`^\[?(?:(\d{4})[-/](\d{2})[-/](\d{2})[T ])?(\d{2}):(\d{2}):(\d{2})(?:[.,](\d{1,9}))?(Z|[+-]\d{2}:?\d{2})?\]?\s*`)
+	// jsonLogStamp matches the time field of a JSON log line
+	jsonLogStamp = regexp.MustCompile(`"(?:time|timestamp|ts|@timestamp)":\s*"(\d{4}-\d{2}-\d{2}T[^"]+)"`)
+)
+
+// maxLogStampSkew is the largest gap between a printed timestamp and the
+// capture time for the timestamp to be trusted
+const maxLogStampSkew = 24 * time.Hour
+
+// Time returns when the line was emitted: the timestamp printed by the
+// process if any, else the capture time
+func (l *LogLineVM) Time() time.Time {
+	if !l.EmittedAt.IsZero() {
+		return l.EmittedAt
+	}
+	return l.Timestamp
+}
+
+// Text returns the message without the timestamp printed by the process
+func (l *LogLineVM) Text() string {
+	if l.StampLen > 0 && l.StampLen <= len(l.Message) {
+		return l.Message[l.StampLen:]
+	}
+	return l.Message
+}
+
+// ParseLogTimestamp returns the timestamp printed by a process in a log
+// message and its length at the start of the message (0 for a JSON field).
+// Times without a date or zone are taken in the day and zone of the capture.
+func ParseLogTimestamp(message string, captured time.Time) (time.Time, int) {
+	if match := logLineStamp.FindStringSubmatchIndex(message); match != nil {
+		group := func(n int) string {
+			if match[2*n] < 0 {
+				return ""
+			}
+			return message[match[2*n]:match[2*n+1]]
+		}
+		atoi := func(n int) int {
+			v, _ := strconv.Atoi(group(n))
+			return v
+		}
+
+		loc := captured.Location()
+		switch zone := group(8); {
+		case zone == "Z":
+			loc = time.UTC
+		case zone != "":
+			offset := strings.ReplaceAll(zone[1:], ":", "")
+			hours, _ := strconv.Atoi(offset[:2])
+			minutes, _ := strconv.Atoi(offset[2:])
+			seconds := hours*3600 + minutes*60
+			if zone[0] == '-' {
+				seconds = -seconds
+			}
+			loc = time.FixedZone(zone, seconds)
+		}
+		nanos := 0
+		if frac := group(7); frac != "" {
+			nanos, _ = strconv.Atoi((frac + "000000000")[:9])
+		}
+
+		local := captured.In(loc)
+		year, month, day := local.Year(), local.Month(), local.Day()
+		dated := group(1) != ""
+		if dated {
+			year, month, day = atoi(1), time.Month(atoi(2)), atoi(3)
+		}
+		if month < 1 || month > 12 || day < 1 || day > 31 || atoi(4) > 23 || atoi(5) > 59 || atoi(6) > 60 {
+			return time.Time{}, 0 // A version, a duration...
+		}
+		t := time.Date(year, month, day, atoi(4), atoi(5), atoi(6), nanos, loc)
+		if !dated {
+			// A time only: the day closest to the capture (lines around midnight)
+			if d := t.Sub(captured); d > 12*time.Hour {
+				t = t.AddDate(0, 0, -1)
+			} else if d < -12*time.Hour {
+				t = t.AddDate(0, 0, 1)
+			}
+		}
+		if trustedLogStamp(t, captured) {
+			return t, match[1]
+		}
+		return time.Time{}, 0
+	}
+
+	if strings.HasPrefix(message, "{") {
+		if match := jsonLogStamp.FindStringSubmatch(message); match != nil {
+			if t, err := time.Parse(time.RFC3339Nano, match[1]); err == nil && trustedLogStamp(t, captured) {
+				return t, 0
+			}
+		}
+	}
+	return time.Time{}, 0
+}
+
+// trustedLogStamp returns true if a printed timestamp is close enough to the
+// capture time to be the time of the line
+func trustedLogStamp(t, captured time.Time) bool {
+	d := t.Sub(captured)
+	return d < maxLogStampSkew && d > -maxLogStampSkew
+}
+
+// Append adds a line to the logs, dropping the oldest beyond MaxLines and
+// counting error lines in their group. The caller holds the state lock.
+func (vm *LogsVM) Append(line LogLineVM) {
+	if line.EmittedAt.IsZero() {
+		line.EmittedAt, line.StampLen = ParseLogTimestamp(line.Message, line.Timestamp)
+	}
+	vm.Lines = append(vm.Lines, line)
+	vm.Total++
+	if len(vm.Lines) > vm.MaxLines {
+		vm.Lines = vm.Lines[1:]
+	}
+	if line.Level == "error" {
+		vm.CountError(line)
+	}
+}
+
+// CountError counts an error line in its group (also for lines not kept in
+// the buffer). The groups are replaced, not updated in place: the TUI may be
+// reading the previous ones.
+func (vm *LogsVM) CountError(line LogLineVM) {
+	pattern := NormalizeLogMessage(line.Message)
+	if pattern == "" {
+		return
+	}
+	group := LogErrorGroupVM{Source: line.Source, Pattern: pattern, FirstSeen: line.Timestamp}
+	groups := make([]LogErrorGroupVM, 1, min(len(vm.ErrorGroups)+1, MaxLogErrorGroups))
+	for _, g := range vm.ErrorGroups {
+		if g.Source == line.Source && g.Pattern == pattern {
+			group = g
+			continue
+		}
+		if len(groups) < MaxLogErrorGroups {
+			groups = append(groups, g)
+		}
+	}
+	group.Message = line.Message
+	group.Count++
+	group.LastSeen = line.Timestamp
+	groups[0] = group
+	vm.ErrorGroups = groups
+}
+
+// NormalizeLogMessage masks the parts of a log message that vary between
+// repetitions of the same error: numbers, ids, addresses, colors
+func NormalizeLogMessage(message string) string {
+	for _, mask := range logErrorMasks {
+		message = mask.re.ReplaceAllString(message, mask.replacement)
+	}
+	message = strings.TrimSpace(message)
+	if len(message) > 300 {
+		message = message[:300]
+	}
+	return message
+}
@@ -0,0 +1,217 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/migrations"
+)
+
+// maxMigrationOutput bounds the output kept in the migrations panel (the
+// whole output goes to the logs)
+const maxMigrationOutput = 500
+
+// handleDatabaseMigrations opens the migrations of a database: the migration
+// setups of its project and the status of one of them, the setup of
+// Data["dir"] or else the one closest to the database config file
+func (p *AppPresenter) handleDatabaseMigrations(event *Event) error {
+	if p.databaseService == nil {
+		return fmt.Errorf("database service not initialized")
+	}
+	db := p.databaseService.GetDatabase(event.Target)
+	if db == nil {
+		return fmt.Errorf("database not found: %s", event.Target)
+	}
+
+	vm := &MigrationsVM{
+		DatabaseID:   db.ID,
+		DatabaseName: db.DatabaseName,
+		ProjectName:  db.ProjectName,
+	}
+	project, err := p.projectService.GetProject(db.ProjectID)
+	if err != nil {
+		vm.Error = fmt.Sprintf("project not found: %s", db.ProjectID)
+	} else {
+		vm.Setups = migrations.Detect(project.Path)
+		if len(vm.Setups) == 0 {
+			vm.Error = "No goose, golang-migrate, Atlas or Prisma migrations found in " + project.Path
+		}
+		vm.Setup = selectMigrationSetup(vm.Setups, event.Data["dir"], filepath.Dir(db.ConfigFile))
+	}
+
+	p.mu.Lock()
+	// Keep the output of the last action when switching setups
+	if current := p.state.Database.Migrations; current != nil && current.DatabaseID == vm.DatabaseID {
+		vm.Running, vm.Output, vm.Result = current.Running, current.Output, current.Result
+	}
+	vm.Checking = vm.SelectedSetup() != nil
+	p.state.Database.Migrations = vm
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMDatabase, p.state.Database)
+
+	if vm.Checking {
+		p.checkMigrations(vm, *vm.SelectedSetup(), migrations.Target{Type: string(db.Type), URL: db.URL})
+	}
+	return nil
+}
+
+// selectMigrationSetup returns the index of the setup of dir if set, else of
+// the setup whose working directory holds the database config file (the
+// backend/ of a backend database), else 0
+func selectMigrationSetup(setups []migrations.Setup, dir, configDir string) int {
+	if dir != "" {
+		for i, setup := range setups {
+			if setup.Dir == dir {
+				return i
+			}
+		}
+	}
+	best, bestLen := 0, -1
+	for i, setup := range setups {
+		if strings.HasPrefix(configDir+string(filepath.Separator), setup.WorkDir+string(filepath.Separator)) && len(setup.WorkDir) > bestLen {
+			best, bestLen = i, len(setup.WorkDir)
+		}
+	}
+	return best
+}
+
+// checkMigrations reads the migration status of a database in the background
+// and shows it if the panel still shows that database
+func (p *AppPresenter) checkMigrations(vm *MigrationsVM, setup migrations.Setup, target migrations.Target) {
+	go func() {
+		status := p.migrateService.Status(p.ctx, setup, target)
+
+		p.mu.Lock()
+		current := p.state.Database.Migrations
+		if current == nil || current.DatabaseID != vm.DatabaseID || current.Setup != vm.Setup {
+			p.mu.Unlock()
+			return
+		}
+		// The view model is replaced, not modified: the views may be rendering it
+		checked := *current
+		checked.Status = status
+		checked.Checking = false
+		p.state.Database.Migrations = &checked
+		p.mu.Unlock()
+		p.notifyStateUpdate(VMDatabase, p.state.Database)
+	}()
+}
+
+// handleDatabaseMigrate applies (up), rolls back (down) or re-applies (redo)
+// migrations of the database open in the migrations panel. The output goes to
+// the panel and the logs; the status is read again afterwards.
+func (p *AppPresenter) handleDatabaseMigrate(event *Event) error {
+	action, _ := event.Value.(string)
+	switch migrations.Action(action) {
+	case migrations.ActionUp, migrations.ActionDown, migrations.ActionRedo:
+	default:
+		return fmt.Errorf("unknown migration action: %s", action)
+	}
+	if p.databaseService == nil {
+		return fmt.Errorf("database service not initialized")
+	}
+	db := p.databaseService.GetDatabase(event.Target)
+	if db == nil {
+		return fmt.Errorf("database not found: %s", event.Target)
+	}
+
+	p.mu.Lock()
+	current := p.state.Database.Migrations
+	if current == nil || current.DatabaseID != db.ID {
+		p.mu.Unlock()
+		return fmt.Errorf("migrations not open for database %s", db.ID)
+	}
+	if current.Running != "" {
+		p.mu.Unlock()
+		p.setHeaderEvent(HeaderEventWarning, "A migration is already running")
+		return nil
+	}
+	running := *current
+	if dir := event.Data["dir"]; dir != "" {
+		running.Setup = selectMigrationSetup(running.Setups, dir, "")
+	}
+	setup := running.SelectedSetup()
+	if setup == nil {
+		p.mu.Unlock()
+		return fmt.Errorf("no migration setup for database %s", db.ID)
+	}
+	running.Running = migrations.Action(action)
+	running.Output = nil
+	running.Result = nil
+	p.state.Database.Migrations = &running
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMDatabase, p.state.Database)
+
+	label := fmt.Sprintf("%s %s on %s", setup.Tool, action, db.DatabaseName)
+	p.setPersistentHeaderEvent(HeaderEventInfo, "Running "+label+"...")
+	target := migrations.Target{Type: string(db.Type), URL: db.URL}
+	source := "migrate:" + db.DatabaseName
+
+	go func(setup migrations.Setup) {
+		result := p.migrateService.Run(p.ctx, setup, target, migrations.Action(action), func(line string) {
+			p.onMigrationOutput(db.ID, source, line)
+		})
+
+		p.mu.Lock()
+		if current := p.state.Database.Migrations; current != nil && current.DatabaseID == db.ID {
+			done := *current
+			done.Running = ""
+			done.Result = result
+			done.Checking = true
+			p.state.Database.Migrations = &done
+		}
+		p.mu.Unlock()
+		p.notifyStateUpdate(VMDatabase, p.state.Database)
+
+		if result.Error != "" {
+			p.setHeaderEvent(HeaderEventError, fmt.Sprintf("%s failed: %s", label, result.Error))
+		} else {
+			p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("%s done in %s", label, result.Duration.Round(time.Millisecond)))
+		}
+
+		// Another setup may have been selected meanwhile: its status is read
+		p.mu.Lock()
+		current := p.state.Database.Migrations
+		p.mu.Unlock()
+		if current != nil && current.DatabaseID == db.ID {
+			if selected := current.SelectedSetup(); selected != nil {
+				p.checkMigrations(current, *selected, target)
+			}
+		}
+	}(*setup)
+	return nil
+}
+
+// onMigrationOutput streams the output of a migration into the logs and the
+// migrations panel
+func (p *AppPresenter) onMigrationOutput(databaseID, source, line string) {
+	now := time.Now()
+	logLine := LogLineVM{
+		Timestamp: now,
+		TimeStr:   now.Format("15:04:05"),
+		Source:    source,
+		Level:     "info",
+		Message:   line,
+	}
+	lower := strings.ToLower(line)
+	if strings.Contains(lower, "error") || strings.Contains(lower, "failed") {
+		logLine.Level = "error"
+	}
+
+	p.mu.Lock()
+	p.state.Logs.Append(logLine)
+	if current := p.state.Database.Migrations; current != nil && current.DatabaseID == databaseID && current.Running != "" {
+		updated := *current
+		updated.Output = append(append([]string(nil), current.Output...), line)
+		if len(updated.Output) > maxMigrationOutput {
+			updated.Output = updated.Output[len(updated.Output)-maxMigrationOutput:]
+		}
+		p.state.Database.Migrations = &updated
+	}
+	p.mu.Unlock()
+
+	p.notifyStateChange(StateUpdate{ViewType: VMLogs, ViewModel: p.state.Logs, Appended: 1})
+	p.notifyStateUpdate(VMDatabase, p.state.Database)
+}
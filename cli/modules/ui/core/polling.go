@@ -0,0 +1,223 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/watcher"
+)
+
+// Watch keys (a key groups the directories of one refresh target)
+const (
+	watchKeyGitPrefix = "git:"   // git:<projectID> - repository of a project
+	watchKeyClaude    = "claude" // Claude CLI session directories
+)
+
+// startPolling starts the periodic refresh of each subsystem and the file
+// watcher that refreshes git repositories and Claude sessions on change.
+// System metrics are collected by the TUI (see system.MetricsCollector).
+func (p *AppPresenter) startPolling() {
+	go p.poll(config.PollGit, p.pollGit)
+	go p.poll(config.PollProcesses, p.refreshProcesses)
+	go p.poll(config.PollClaude, p.pollClaude)
+	go p.poll(config.PollPlugins, p.pollPlugins)
+	go p.poll(config.PollDocker, p.pollContainers)
+	go p.poll(config.PollProcesses, p.pollProxy)
+	go p.poll(config.PollProcesses, p.pollCapture)
+
+	p.startWatcher()
+}
+
+// pollingConfig returns the polling intervals from config
+func (p *AppPresenter) pollingConfig() *config.PollingConfig {
+	if p.config == nil || p.config.Settings == nil {
+		return config.DefaultPollingConfig()
+	}
+	return p.config.Settings.GetPollingConfig()
+}
+
+// poll runs fn at the interval of the subsystem until shutdown.
+// The interval is read each cycle so Settings changes apply without restart.
+func (p *AppPresenter) poll(subsystem string, fn func()) {
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-time.After(p.pollInterval(subsystem)):
+			fn()
+		}
+	}
+}
+
+// lowPowerPollInterval is the shortest polling interval in low-power mode.
+// Processes keep their interval: crashes are still noticed at once.
+const lowPowerPollInterval = 30 * time.Second
+
+// setLowPower slows polling down while no one looks at DevTrack: terminal
+// unfocused, or no client attached to the daemon
+func (p *AppPresenter) setLowPower(on bool) {
+	if p.lowPower.Swap(on) == on {
+		return
+	}
+	if on {
+		p.log().Debug("Low-power mode: polling slowed down")
+	} else {
+		p.log().Debug("Low-power mode off")
+	}
+}
+
+// pollInterval returns the polling interval of a subsystem
+func (p *AppPresenter) pollInterval(subsystem string) time.Duration {
+	interval := p.pollingConfig().Interval(subsystem)
+	if p.lowPower.Load() && subsystem != config.PollProcesses {
+		interval = max(interval, lowPowerPollInterval)
+	}
+	return interval
+}
+
+// pollGit refreshes the git status of all projects (unchanged repos are cached)
+func (p *AppPresenter) pollGit() {
+	p.mu.RLock()
+	loading := p.state.GitLoading
+	p.mu.RUnlock()
+	if loading {
+		return // Initial load in progress
+	}
+
+	start := time.Now()
+	p.refreshGitStatus()
+	p.refreshDashboard()
+	p.log().Debug("Git poll: %d projects in %s", len(p.projectService.ListProjects()), time.Since(start).Round(time.Millisecond))
+}
+
+// pollClaude picks up Claude sessions created outside DevTrack and the
+// messages appended to the known ones (only the new lines are parsed).
+// Skipped while the session directories are watched.
+func (p *AppPresenter) pollClaude() {
+	if p.claudeService == nil || p.claudeWatched.Load() {
+		return
+	}
+	p.rescanClaude()
+}
+
+// rescanClaude scans every Claude project directory for session changes
+func (p *AppPresenter) rescanClaude() {
+	if p.claudeService.DiscoverSessions() == 0 {
+		return
+	}
+	p.mu.RLock()
+	activeID := p.state.Claude.ActiveSessionID
+	p.mu.RUnlock()
+	p.refreshClaudeMessages(activeID)
+	p.refreshClaude()
+}
+
+// startWatcher watches git repositories and Claude session directories.
+// Without file watching support (non-Linux) only polling is used.
+func (p *AppPresenter) startWatcher() {
+	if p.pollingConfig().DisableWatch {
+		return
+	}
+	w, err := watcher.New(watcher.DefaultDebounce, p.handleWatchEvent)
+	if err != nil {
+		p.log().Debug("File watching unavailable, polling only: %v", err)
+		return
+	}
+	p.watcher = w
+
+	for _, project := range p.projectService.ListProjects() {
+		p.watchProject(project)
+	}
+	p.watchClaude()
+}
+
+// watchProject watches the repository of a project.
+// The .git directory catches commits, checkouts and staging; the project
+// root catches top-level file changes. Deeper edits are left to polling.
+// Large repositories only watch .git: each refresh is costly.
+func (p *AppPresenter) watchProject(project *projects.Project) {
+	if p.watcher == nil {
+		return
+	}
+	key := watchKeyGitPrefix + project.ID
+	if err := p.watcher.Add(key, filepath.Join(project.Path, ".git")); err != nil {
+		return // Not a git repository
+	}
+	if !project.LargeRepo {
+		p.watcher.Add(key, project.Path)
+	}
+}
+
+// watchClaude watches the Claude CLI session directories. Once the projects
+// directory is watched, periodic scans stop: sessions follow file changes.
+func (p *AppPresenter) watchClaude() {
+	if p.watcher == nil || p.claudeService == nil {
+		return
+	}
+	for i, dir := range p.claudeService.WatchDirs() {
+		if err := p.watcher.Add(watchKeyClaude, dir); err == nil && i == 0 {
+			p.claudeWatched.Store(true)
+		}
+	}
+}
+
+// handleClaudeChanges applies the changed session files, or rescans every
+// project when the changes are unknown (events lost)
+func (p *AppPresenter) handleClaudeChanges(paths []string) {
+	if p.claudeService == nil {
+		return
+	}
+	if paths == nil {
+		p.rescanClaude()
+		p.watchClaude() // Pick up new project directories
+		return
+	}
+
+	changes := p.claudeService.ApplyFileChanges(paths)
+	for _, dir := range changes.Dirs {
+		p.watcher.Add(watchKeyClaude, dir)
+	}
+	if changes.Empty() {
+		return
+	}
+	p.log().Debug("Claude sessions: %d added, %d updated, %d removed",
+		len(changes.Added), len(changes.Updated), len(changes.Removed))
+
+	p.mu.RLock()
+	activeID := p.state.Claude.ActiveSessionID
+	p.mu.RUnlock()
+	for _, id := range changes.Updated {
+		if id == activeID {
+			p.refreshClaudeMessages(activeID)
+			break
+		}
+	}
+	p.updateClaudeSessions(changes)
+}
+
+// handleWatchEvent refreshes the target of a watch key after a file change
+func (p *AppPresenter) handleWatchEvent(key string, paths []string) {
+	if key == watchKeyClaude {
+		p.handleClaudeChanges(paths)
+		return
+	}
+
+	projectID := strings.TrimPrefix(key, watchKeyGitPrefix)
+	p.log().Debug("File change in %s: refreshing git status", projectID)
+	p.mu.RLock()
+	loading := p.state.GitLoading
+	p.mu.RUnlock()
+	if loading {
+		return // Initial load will pick it up
+	}
+
+	p.gitService.InvalidateStatusCache(projectID)
+	status, err := p.gitService.GetStatus(projectID)
+	if err != nil {
+		return
+	}
+	p.applyGitStatus(projectID, status)
+}
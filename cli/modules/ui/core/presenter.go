@@ -6,23 +6,47 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"csd-devtrack/cli/modules/core/builds"
 	"csd-devtrack/cli/modules/core/processes"
 	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/adhoc"
+	"csd-devtrack/cli/modules/platform/apiexplorer"
+	"csd-devtrack/cli/modules/platform/audit"
 	"csd-devtrack/cli/modules/platform/builder"
+	"csd-devtrack/cli/modules/platform/buildhistory"
 	"csd-devtrack/cli/modules/platform/capabilities"
+	"csd-devtrack/cli/modules/platform/capture"
 	"csd-devtrack/cli/modules/platform/claude"
 	"csd-devtrack/cli/modules/platform/codex"
 	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/containers"
 	"csd-devtrack/cli/modules/platform/database"
+	"csd-devtrack/cli/modules/platform/deploy"
 	"csd-devtrack/cli/modules/platform/git"
+	"csd-devtrack/cli/modules/platform/hooks"
+	"csd-devtrack/cli/modules/platform/migrations"
+	"csd-devtrack/cli/modules/platform/plugins"
+	"csd-devtrack/cli/modules/platform/proxy"
+	"csd-devtrack/cli/modules/platform/runhistory"
+	"csd-devtrack/cli/modules/platform/security"
+	"csd-devtrack/cli/modules/platform/services"
+	"csd-devtrack/cli/modules/platform/sessiontasks"
 	"csd-devtrack/cli/modules/platform/shell"
+	"csd-devtrack/cli/modules/platform/startup"
+	"csd-devtrack/cli/modules/platform/storage"
 	"csd-devtrack/cli/modules/platform/supervisor"
+	"csd-devtrack/cli/modules/platform/tracing"
+	"csd-devtrack/cli/modules/platform/transfer"
+	"csd-devtrack/cli/modules/platform/trash"
+	"csd-devtrack/cli/modules/platform/watcher"
 )
 
 // AppPresenter is the main presenter implementation
@@ -32,22 +56,57 @@ type AppPresenter struct {
 	// Services
 	projectService  *projects.Service
 	buildOrch       *builder.Orchestrator
+	buildHistory    *buildhistory.Service // Nil without a data dir
 	processService  *processes.Service
 	processMgr      *supervisor.Manager
 	gitService      *git.Service
 	claudeService   *claude.Service
 	codexService    *codex.Service
+	taskService     *sessiontasks.Service // Tasks linking Claude and Codex sessions
 	shellService    *shell.Service
 	databaseService *database.Service
+	migrateService  *migrations.Service
+	securityService *security.Service
+	storageService  *storage.Service
+	transferService *transfer.Service
+	deployService   *deploy.Service
+	adhocService    *adhoc.Service // Ad-hoc commands run in component directories
+	apiService      *apiexplorer.Service
+	dockerService   *containers.Service // Docker or Podman containers of the projects
+	serviceMgr      *services.Manager   // Services of the catalog the components depend on
+	proxyServer     *proxy.Server       // Built-in reverse proxy (unified local entrypoint)
+	captureServer   *capture.Server     // Capture proxy recording the outbound requests
+	imageBuilder    *containers.ImageBuilder
+	trashService    *trash.Service
+	auditService    *audit.Service // Nil if the audit log is disabled
+	pluginService   *plugins.Service
+	hookService     *hooks.Service
+	logger          Logger // DevTrack diagnostics (nil = discarded)
 	capService      *capabilities.Service
 	config          *config.Config
+	watcher         *watcher.Watcher // File change notifications (nil if unsupported or disabled)
+	claudeWatched   atomic.Bool      // Claude sessions follow file changes, not polling
+	lowPower        atomic.Bool      // Polling slowed down (see setLowPower)
+	proxyDirty      atomic.Bool      // Requests forwarded since the last proxy refresh
+	captureDirty    atomic.Bool      // Requests recorded since the last capture refresh
+
+	// Lazy initialization (data loaded when its view is first opened)
+	claudeLoad   sync.Once
+	databaseLoad sync.Once
 
 	// State
-	state *AppState
+	state        *AppState
+	logLimiter   *logRateLimiter      // Lines kept per log source and second
+	projectUsage map[string]time.Time // Last use of each project (recent order)
+	usageFile    string               // Where projectUsage is kept (empty = not saved)
+	usageMu      sync.Mutex           // Serializes usageFile writes
+
+	claudeMessageLimit int // Messages of the active Claude session in the view model (paged)
 
 	// Callbacks
 	stateCallbacks        []func(StateUpdate)
 	notificationCallbacks []func(*Notification)
+	updateBatcher         *UpdateBatcher // Coalesces high-frequency updates (log append, build output)
 
 	// Context
 	ctx    context.Context
@@ -57,6 +116,8 @@ type AppPresenter struct {
 	buildCtx    context.Context
 	buildCancel context.CancelFunc
 
+	buildProfile string // Profile of the running build (recorded in the history)
+
 	// Self process tracking
 	startTime time.Time // When csd-devtrack started
 
@@ -73,14 +134,17 @@ func NewAppPresenter(
 	projectService *projects.Service,
 	cfg *config.Config,
 ) *AppPresenter {
-	return &AppPresenter{
+	p := &AppPresenter{
 		projectService:        projectService,
 		config:                cfg,
 		state:                 NewAppState(),
 		stateCallbacks:        make([]func(StateUpdate), 0),
 		notificationCallbacks: make([]func(*Notification), 0),
 		startTime:             time.Now(), // Track when we started
+		logLimiter:            newLogRateLimiter(),
 	}
+	p.updateBatcher = NewUpdateBatcher(StateBatchInterval, p.deliverStateUpdate)
+	return p
 }
 
 // NewPresenter is a convenience constructor that returns the Presenter interface
@@ -94,8 +158,10 @@ func NewPresenter(
 // Initialize sets up the presenter
 func (p *AppPresenter) Initialize(ctx context.Context) error {
 	p.ctx, p.cancel = context.WithCancel(ctx)
+	defer startup.Track("presenter")()
 
 	// Initialize capabilities detection (before other services)
+	done := startup.Track("presenter: capabilities")
 	// Use configured paths from config if available
 	var configuredPaths *capabilities.ConfiguredPaths
 	if p.config != nil && p.config.Settings != nil && p.config.Settings.Executables != nil {
@@ -113,6 +179,13 @@ func (p *AppPresenter) Initialize(ctx context.Context) error {
 			Npm:    exec.Npm,
 			Tmux:   exec.Tmux,
 			Sudo:   exec.Sudo,
+			SSH:    exec.SSH,
+			Rsync:  exec.Rsync,
+			SCP:    exec.SCP,
+
+			Govulncheck: exec.Govulncheck,
+			Grpcurl:     exec.Grpcurl,
+			Docker:      exec.Docker,
 		}
 	}
 	if configuredPaths != nil {
@@ -121,22 +194,164 @@ func (p *AppPresenter) Initialize(ctx context.Context) error {
 		p.capService = capabilities.NewService()
 	}
 	p.refreshCapabilities()
+	done()
 
 	// Initialize build orchestrator
+	done = startup.Track("presenter: services")
 	parallelBuilds := 4
 	if p.config != nil && p.config.Settings != nil {
 		parallelBuilds = p.config.Settings.ParallelBuilds
 	}
 	p.buildOrch = builder.NewOrchestrator(p.projectService, parallelBuilds)
 
+	// Initialize build history (finished builds kept in the data dir for analytics)
+	if dataDir, err := config.GetDataDir(); err == nil {
+		p.buildHistory = buildhistory.NewService(filepath.Join(dataDir, "build-history.jsonl"), buildhistory.DefaultMaxRecords)
+	}
+	p.refreshBuildHistory()
+
+	// Load project usage (most recently used order of the project trees)
+	if dataDir, err := config.GetDataDir(); err == nil {
+		p.usageFile = filepath.Join(dataDir, "project-usage.json")
+	}
+	p.projectUsage = loadProjectUsage(p.usageFile)
+
 	// Initialize process service and manager
 	p.processService = processes.NewService(p.projectService)
 	p.processMgr = supervisor.NewManager(p.processService)
 
+	// Keep the run history of the processes in the data dir across restarts
+	if dataDir, err := config.GetDataDir(); err == nil {
+		p.processService.SetTerminationStore(runhistory.NewService(filepath.Join(dataDir, "run-history.jsonl"), processes.RunHistorySize))
+	}
+
 	// Initialize git service
 	p.gitService = git.NewService(p.projectService)
 
-	// Initialize Claude service
+	// Initialize security scan service (govulncheck / npm audit)
+	p.securityService = security.NewService(
+		p.projectService,
+		p.capService.GetPath(capabilities.CapGovulncheck),
+		p.capService.GetPath(capabilities.CapNpm),
+	)
+
+	// Initialize storage service (disk usage / cleanup)
+	p.storageService = storage.NewService(p.projectService, p.capService.GetPath(capabilities.CapGo))
+
+	// Initialize transfer service (push/pull files to remote hosts)
+	p.transferService = transfer.NewService(
+		p.capService.GetPath(capabilities.CapRsync),
+		p.capService.GetPath(capabilities.CapSCP),
+	)
+
+	// Initialize deploy service (deploy targets, history kept in the data dir)
+	deployHistory := ""
+	if dataDir, err := config.GetDataDir(); err == nil {
+		deployHistory = filepath.Join(dataDir, "deployments.json")
+	}
+	p.deployService = deploy.NewService(deployHistory)
+	p.refreshDeployments()
+	p.adhocService = adhoc.NewService()
+	p.apiService = apiexplorer.NewService()
+	p.apiService.SetGrpcurlPath(p.capService.GetPath(capabilities.CapGrpcurl))
+	p.dockerService = containers.NewService()
+	p.dockerService.SetPath(p.capService.GetPath(capabilities.CapDocker))
+	imageHistory := ""
+	if dataDir, err := config.GetDataDir(); err == nil {
+		imageHistory = filepath.Join(dataDir, "image-builds.json")
+	}
+	p.imageBuilder = containers.NewImageBuilder(p.dockerService, imageHistory)
+	p.refreshImageHistory()
+
+	// Services of the catalog, started before the components depending on them
+	p.serviceMgr = services.NewManager(p.dockerService)
+	if p.config != nil {
+		p.serviceMgr.SetCatalog(p.config.ServiceCatalog())
+	}
+	p.serviceMgr.SetOutputHandler(func(name, line string, isError bool) {
+		p.onContainerOutput(ServiceVM{Status: services.Status{Name: name}}.LogsSource(), line, isError)
+	})
+	p.serviceMgr.SetChangeHandler(func() { p.refreshServices(nil, false) })
+	p.processService.SetDependencies(p.serviceMgr)
+	p.refreshServices(nil, false)
+
+	// Built-in reverse proxy, started with DevTrack when enabled
+	p.proxyServer = proxy.NewServer(p.proxyUpstream)
+	p.proxyServer.SetRequestHandler(func() { p.proxyDirty.Store(true) })
+	if p.proxyConfig().Enabled {
+		if err := p.startProxy(); err != nil {
+			p.log().Warn("Reverse proxy not started: %v", err)
+		}
+	} else {
+		p.refreshProxy()
+	}
+
+	// Capture proxy, the components marked capture: true are pointed at it
+	p.captureServer = capture.NewServer()
+	p.captureServer.SetEntryHandler(func() { p.captureDirty.Store(true) })
+	p.processMgr.SetEnvironment(p.captureEnvironment)
+	if p.captureConfig().Enabled {
+		if err := p.startCapture(); err != nil {
+			p.log().Warn("Capture proxy not started: %v", err)
+		}
+	} else {
+		p.refreshCapture()
+	}
+	done()
+
+	// Initialize trash service (undo for destructive actions)
+	retentionDays := config.DefaultTrashConfig().RetentionDays
+	if p.config != nil && p.config.Settings != nil {
+		retentionDays = p.config.Settings.GetTrashConfig().RetentionDays
+	}
+	done = startup.Track("presenter: trash")
+	if dataDir, err := config.GetDataDir(); err == nil {
+		p.trashService = trash.NewService(filepath.Join(dataDir, "trash"), retentionDays)
+		p.trashService.Purge()
+		p.storageService.SetTrash(p.trashService)
+	}
+	p.refreshTrash()
+	done()
+
+	// Initialize audit log (state-changing actions, shared with the CLI)
+	auditConfig := config.DefaultAuditConfig()
+	if p.config != nil && p.config.Settings != nil {
+		auditConfig = p.config.Settings.GetAuditConfig()
+	}
+	done = startup.Track("presenter: audit")
+	if dataDir, err := config.GetDataDir(); err == nil && !auditConfig.Disabled {
+		p.auditService = audit.NewService(filepath.Join(dataDir, "audit.jsonl"), auditConfig.MaxEntries)
+	}
+	p.refreshAudit()
+	done()
+
+	// Initialize plugins (external executables, discovered in background)
+	pluginsConfig := config.DefaultPluginsConfig()
+	if p.config != nil && p.config.Settings != nil {
+		pluginsConfig = p.config.Settings.GetPluginsConfig()
+	}
+	if pluginsDir, err := config.GetPluginsDir(pluginsConfig); err == nil {
+		p.pluginService = plugins.NewService(pluginsDir, pluginsConfig.Disabled,
+			time.Duration(pluginsConfig.Timeout)*time.Second,
+			time.Duration(pluginsConfig.ActionTimeout)*time.Second)
+	}
+	p.refreshPlugins()
+
+	// Initialize hooks (shell commands run on build, process and git events)
+	if p.config != nil && p.config.Settings != nil {
+		p.hookService = hooks.NewService(hooksFromConfig(p.config.Settings.Hooks))
+	}
+
+	// Initialize session tasks (before the Claude and Codex sessions they group)
+	tasksFile := ""
+	if dataDir, err := config.GetDataDir(); err == nil {
+		tasksFile = filepath.Join(dataDir, "session-tasks.json")
+	}
+	p.taskService = sessiontasks.NewService(tasksFile)
+
+	// Initialize Claude service (sessions are read when the Claude view is
+	// first opened, see ensureViewLoaded)
+	done = startup.Track("presenter: claude")
 	claudeDataDir := ""
 	if p.config != nil && p.config.Settings != nil && p.config.Settings.Claude != nil {
 		claudeDataDir = p.config.Settings.Claude.SessionsDir
@@ -148,16 +363,22 @@ func (p *AppPresenter) Initialize(ctx context.Context) error {
 		}
 	}
 	p.claudeService = claude.NewService(claudeDataDir)
+	if p.config != nil && p.config.Settings != nil && p.config.Settings.Claude != nil {
+		p.claudeService.SetSyncNames(p.config.Settings.Claude.SyncNames)
+	}
 
 	// Initialize Claude state
 	p.refreshClaude()
+	done()
 
 	// Initialize Codex service
+	done = startup.Track("presenter: codex")
 	p.codexService = codex.NewService()
 	if codexPath := p.capService.GetPath(capabilities.CapCodex); codexPath != "" {
 		p.codexService.Initialize(codexPath)
 	}
 	p.refreshCodex()
+	done()
 
 	// Initialize Shell service
 	p.shellService = shell.NewService()
@@ -187,16 +408,20 @@ func (p *AppPresenter) Initialize(ctx context.Context) error {
 		return result
 	})
 
-	// Discover databases from project configs
-	p.refreshDatabase()
+	p.migrateService = migrations.NewService()
+
+	// Databases are discovered from project configs when the Database view
+	// is first opened (see ensureViewLoaded)
 
 	// Set up event handlers
 	p.setupEventHandlers()
 
 	// FAST: Load projects without git info first
+	done = startup.Track("presenter: projects")
 	p.refreshProjectsWithoutGit()
 	p.refreshProcesses()
 	p.refreshDashboard()
+	done()
 
 	// Mark initialization as complete (projects are ready, UI can display)
 	p.mu.Lock()
@@ -209,18 +434,24 @@ func (p *AppPresenter) Initialize(ctx context.Context) error {
 
 	// SLOW: Start git operations in background
 	go p.loadGitInBackground()
+	go p.reloadPlugins()
+
+	// Periodic refresh per subsystem and file watching
+	p.startPolling()
+	go p.flushLogRates()
 
 	return nil
 }
 
-// loadGitInBackground loads git info for all projects in background
+// loadGitInBackground loads git info for all projects in background.
+// Repositories are scanned in parallel and each one is published as soon as
+// its status is known, so the views fill in progressively.
 func (p *AppPresenter) loadGitInBackground() {
+	defer startup.Track("git status (background)")()
 	p.setPersistentHeaderEvent(HeaderEventInfo, "Loading git info...")
+	start := time.Now()
 
-	// Enrich all projects with git info (slow operation)
-	p.gitService.EnrichAllProjects()
-
-	// Refresh git status
+	// Compute git status and enrich projects (streamed per project)
 	p.refreshGitStatus()
 
 	// Update projects with git info
@@ -236,6 +467,7 @@ func (p *AppPresenter) loadGitInBackground() {
 	p.mu.Unlock()
 
 	p.setHeaderEvent(HeaderEventSuccess, "Git info loaded")
+	p.log().Info("Git info loaded: %d projects in %s", len(p.projectService.ListProjects()), time.Since(start).Round(time.Millisecond))
 
 	// Broadcast full state update
 	p.broadcastFullState()
@@ -248,7 +480,7 @@ func (p *AppPresenter) broadcastFullState() {
 	p.mu.RUnlock()
 
 	// Notify all view updates to refresh the entire UI
-	for _, viewType := range []ViewModelType{VMDashboard, VMProjects, VMBuild, VMProcesses, VMLogs, VMGit, VMConfig, VMClaude, VMDatabase} {
+	for _, viewType := range []ViewModelType{VMDashboard, VMProjects, VMBuild, VMProcesses, VMLogs, VMGit, VMConfig, VMClaude, VMDatabase, VMStorage, VMTrash, VMAudit, VMPlugins} {
 		vm, _ := p.GetViewModel(viewType)
 		if vm != nil {
 			update := StateUpdate{
@@ -277,6 +509,24 @@ func (p *AppPresenter) setupEventHandlers() {
 
 // HandleEvent processes a user event
 func (p *AppPresenter) HandleEvent(event *Event) error {
+	_, span := tracing.Start(p.ctx, "presenter."+string(event.Type),
+		tracing.String("project", event.ProjectID), tracing.String("component", string(event.Component)))
+	defer span.End()
+
+	p.ensureEventLoaded(event)
+	err := p.dispatchEvent(event)
+	span.SetError(err)
+	if auditedEvents[event.Type] {
+		p.recordAudit(event, err)
+		if err == nil && event.ProjectID != "" {
+			p.touchProject(event.ProjectID)
+		}
+	}
+	return err
+}
+
+// dispatchEvent routes an event to its handler
+func (p *AppPresenter) dispatchEvent(event *Event) error {
 	switch event.Type {
 	// Navigation
 	case EventNavigate:
@@ -293,6 +543,12 @@ func (p *AppPresenter) HandleEvent(event *Event) error {
 		return p.handleAddProject(event)
 	case EventRemoveProject:
 		return p.handleRemoveProject(event)
+	case EventToggleFavorite:
+		return p.handleToggleFavorite(event)
+	case EventMoveProject:
+		return p.handleMoveProject(event)
+	case EventSetProjectOrder:
+		return p.handleSetProjectOrder(event)
 
 	// Build events
 	case EventStartBuild:
@@ -301,6 +557,8 @@ func (p *AppPresenter) HandleEvent(event *Event) error {
 		return p.handleBuildAll(event)
 	case EventCancelBuild:
 		return p.handleCancelBuild(event)
+	case EventSecurityScan:
+		return p.handleSecurityScan(event)
 
 	// Process events
 	case EventStartProcess:
@@ -309,6 +567,48 @@ func (p *AppPresenter) HandleEvent(event *Event) error {
 		return p.handleStopProcess(event)
 	case EventRestartProcess:
 		return p.handleRestartProcess(event)
+	case EventDryRun:
+		return p.handleDryRun(event)
+	case EventRunCommand:
+		return p.handleRunCommand(event)
+	case EventCancelCommand:
+		return p.handleCancelCommand(event)
+	case EventAPIExplore:
+		return p.handleAPIExplore(event)
+	case EventAPIRequest:
+		return p.handleAPIRequest(event)
+	case EventContainerAction:
+		return p.handleContainerAction(event)
+	case EventContainerLogs:
+		return p.handleContainerLogs(event)
+	case EventServiceAction:
+		return p.handleServiceAction(event)
+	case EventServicesUp:
+		return p.handleServicesUp(event)
+	case EventServiceLogs:
+		return p.handleServiceLogs(event)
+	case EventGenerateCert:
+		return p.handleGenerateCert(event)
+	case EventHostsCheck:
+		return p.handleHostsCheck(event)
+	case EventHostsApply:
+		return p.handleHostsApply(event)
+	case EventProxyToggle:
+		return p.handleProxyToggle(event)
+	case EventCaptureToggle:
+		return p.handleCaptureToggle(event)
+	case EventCaptureComponent:
+		return p.handleCaptureComponent(event)
+	case EventCaptureDetail:
+		return p.handleCaptureDetail(event)
+	case EventCaptureClear:
+		return p.handleCaptureClear(event)
+	case EventCaptureExport:
+		return p.handleCaptureExport(event)
+	case EventImageBuild:
+		return p.handleImageBuild(event)
+	case EventCancelImageBuild:
+		return p.handleCancelImageBuild(event)
 	case EventKillProcess:
 		return p.handleKillProcess(event)
 	case EventPauseProcess:
@@ -321,6 +621,8 @@ func (p *AppPresenter) HandleEvent(event *Event) error {
 		return p.handleGitDiff(event)
 	case EventGitLog:
 		return p.handleGitLog(event)
+	case EventGitStage:
+		return p.handleGitStage(event)
 
 	// Filter/sort
 	case EventFilter:
@@ -335,6 +637,18 @@ func (p *AppPresenter) HandleEvent(event *Event) error {
 		return p.handleClaudeDeleteSession(event)
 	case EventClaudeRenameSession:
 		return p.handleClaudeRenameSession(event)
+	case EventClaudeForkSession:
+		return p.handleClaudeForkSession(event)
+	case EventClaudeCleanupPreview:
+		return p.handleClaudeCleanupPreview(event)
+	case EventClaudeCleanupSessions:
+		return p.handleClaudeCleanupSessions(event)
+	case EventSessionLinkTask:
+		return p.handleSessionLinkTask(event)
+	case EventSessionDeleteTask:
+		return p.handleSessionDeleteTask(event)
+	case EventClaudeLoadOlder:
+		return p.handleClaudeLoadOlder(event)
 	case EventClaudeSendMessage:
 		return p.handleClaudeSendMessage(event)
 	case EventClaudeStopSession:
@@ -365,6 +679,14 @@ func (p *AppPresenter) HandleEvent(event *Event) error {
 		return p.handleDatabaseStopSession(event)
 	case EventDatabaseRefresh:
 		return p.handleDatabaseRefresh(event)
+	case EventDatabaseMigrations:
+		return p.handleDatabaseMigrations(event)
+	case EventDatabaseMigrate:
+		return p.handleDatabaseMigrate(event)
+	case EventDatabaseReset:
+		return p.handleDatabaseTask(event, DatabaseTaskReset)
+	case EventDatabaseSeed:
+		return p.handleDatabaseTask(event, DatabaseTaskSeed)
 
 	// Shell events
 	case EventShellCreateSession:
@@ -378,6 +700,62 @@ func (p *AppPresenter) HandleEvent(event *Event) error {
 	case EventShellRefresh:
 		return p.handleShellRefresh(event)
 
+	// Capabilities events
+	case EventCapabilitiesRefresh:
+		return p.handleCapabilitiesRefresh(event)
+
+	// Backup events
+	case EventBackupCreate:
+		return p.handleBackupCreate(event)
+
+	// Storage events
+	case EventStorageScan:
+		return p.handleStorageScan(event)
+	case EventStorageClean:
+		return p.handleStorageClean(event)
+
+	// Transfer events
+	case EventTransferStart:
+		return p.handleTransferStart(event)
+
+	// Deploy events
+	case EventDeploy:
+		return p.handleDeploy(event)
+
+	// Trash events
+	case EventTrashPut:
+		return p.handleTrashPut(event)
+	case EventTrashRestore:
+		return p.handleTrashRestore(event)
+	case EventTrashDelete:
+		return p.handleTrashDelete(event)
+	case EventTrashEmpty:
+		return p.handleTrashEmpty(event)
+
+	// Audit events
+	case EventAuditRecord:
+		return p.handleAuditRecord(event)
+	case EventAuditExport:
+		return p.handleAuditExport(event)
+
+	// Plugin events
+	case EventPluginAction:
+		return p.handlePluginAction(event)
+	case EventPluginReload:
+		go p.reloadPlugins()
+		return nil
+
+	// Diagnostics events
+	case EventSetLogLevel:
+		level, _ := event.Value.(string)
+		p.log().SetLevelName(level)
+		p.log().Info("Log level set to %s", level)
+		return nil
+	case EventLowPower:
+		on, _ := event.Value.(bool)
+		p.setLowPower(on)
+		return nil
+
 	default:
 		return fmt.Errorf("unknown event type: %s", event.Type)
 	}
@@ -407,6 +785,14 @@ func (p *AppPresenter) GetViewModel(viewType ViewModelType) (ViewModel, error) {
 		return p.state.Claude, nil
 	case VMDatabase:
 		return p.state.Database, nil
+	case VMStorage:
+		return p.state.Storage, nil
+	case VMTrash:
+		return p.state.Trash, nil
+	case VMAudit:
+		return p.state.Audit, nil
+	case VMPlugins:
+		return p.state.Plugins, nil
 	default:
 		return nil, fmt.Errorf("unknown view type: %s", viewType)
 	}
@@ -544,11 +930,10 @@ func (p *AppPresenter) RefreshCurrentView() {
 	case VMDashboard:
 		p.refreshDashboard()
 	case VMProjects:
-		p.refreshProjects()
-	case VMProcesses:
-		p.refreshProcesses()
-	case VMGit:
-		p.refreshGitStatus()
+		// Git fields are kept up to date by the git poller
+		p.refreshProjectsFromGit()
+	case VMProcesses, VMGit:
+		// Refreshed by their own poller (see startPolling)
 	case VMClaude:
 		p.refreshClaude()
 	case VMDatabase:
@@ -557,6 +942,14 @@ func (p *AppPresenter) RefreshCurrentView() {
 		p.refreshShell()
 	case VMCodex:
 		p.refreshCodex()
+	case VMStorage:
+		p.refreshStorage()
+	case VMTrash:
+		p.refreshTrash()
+	case VMAudit:
+		p.refreshAudit()
+	case VMPlugins:
+		// Refreshed by their own poller (see startPolling)
 	case VMConfig:
 		// Config doesn't need refresh
 	case VMCockpit:
@@ -585,11 +978,24 @@ func (p *AppPresenter) Shutdown() error {
 	}
 	p.refreshMu.Unlock()
 
+	// Stop file watching
+	if p.watcher != nil {
+		p.watcher.Close()
+	}
+
 	// Shutdown Claude service
 	if p.claudeService != nil {
 		p.claudeService.Shutdown()
 	}
 
+	// Stop the reverse proxy and the capture proxy
+	if p.proxyServer != nil {
+		p.proxyServer.Stop()
+	}
+	if p.captureServer != nil {
+		p.captureServer.Stop()
+	}
+
 	return nil
 }
 
@@ -620,6 +1026,17 @@ func (p *AppPresenter) handleNavigate(event *Event) error {
 		p.refreshGitStatus()
 	case VMClaude:
 		p.refreshClaude()
+	case VMStorage:
+		// First visit: measure disk usage in background
+		if p.storageService != nil && p.storageService.GetReport() == nil && !p.storageService.IsScanning() {
+			go p.runStorageScan(false)
+		}
+	case VMTrash:
+		p.refreshTrash()
+	case VMAudit:
+		p.refreshAudit()
+	case VMPlugins:
+		p.refreshPlugins()
 	}
 
 	p.notifyStateUpdate(viewType, p.state.GetCurrentViewModel())
@@ -656,6 +1073,7 @@ func (p *AppPresenter) handleAddProject(event *Event) error {
 	}
 
 	p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Project '%s' added", project.Name))
+	p.watchProject(project)
 	return p.refreshProjects()
 }
 
@@ -677,6 +1095,7 @@ func (p *AppPresenter) handleStartBuild(event *Event) error {
 
 	// Create a new cancellable context for this build
 	p.buildCtx, p.buildCancel = context.WithCancel(p.ctx)
+	p.setBuildProfile(event.Data["profile"])
 
 	// Show build starting in header (persistent until build completes)
 	if event.Component != "" {
@@ -687,10 +1106,17 @@ func (p *AppPresenter) handleStartBuild(event *Event) error {
 
 	go func() {
 		var err error
-		buildCtx := p.buildCtx // Capture context
+		// Capture context
+		buildCtx, span := tracing.Start(p.buildCtx, "build",
+			tracing.String("project", event.ProjectID), tracing.String("component", string(event.Component)))
+		defer func() {
+			span.SetError(err)
+			span.End()
+		}()
 
 		if event.Component != "" {
-			result := p.buildOrch.BuildComponent(buildCtx, event.ProjectID, event.Component)
+			// Data["command"] replaces the build command for this build only
+			result := p.buildOrch.BuildComponentWithCommand(buildCtx, event.ProjectID, event.Component, event.Data["command"])
 			if result.Error != nil {
 				err = result.Error
 			}
@@ -708,6 +1134,7 @@ func (p *AppPresenter) handleStartBuild(event *Event) error {
 			p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Build failed: %s", event.ProjectID))
 		} else {
 			p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("%s built", event.ProjectID))
+			p.scanAfterBuild(event.ProjectID)
 		}
 	}()
 
@@ -722,12 +1149,32 @@ func (p *AppPresenter) handleBuildAll(event *Event) error {
 
 	// Create a new cancellable context for this build
 	p.buildCtx, p.buildCancel = context.WithCancel(p.ctx)
+	p.setBuildProfile(event.Data["profile"])
 
-	p.setPersistentHeaderEvent(HeaderEventInfo, "Building all projects...")
+	// Batch build of the projects marked in the tree
+	var selected []string
+	for _, projectID := range strings.Split(event.Data["projects"], "\n") {
+		if projectID != "" {
+			selected = append(selected, projectID)
+		}
+	}
+	if len(selected) > 0 {
+		p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("Building %d projects...", len(selected)))
+	} else {
+		p.setPersistentHeaderEvent(HeaderEventInfo, "Building all projects...")
+	}
 
 	go func() {
-		buildCtx := p.buildCtx // Capture context
-		results, err := p.buildOrch.BuildAll(buildCtx)
+		buildCtx, span := tracing.Start(p.buildCtx, "build.all") // Captures the context
+		defer span.End()
+		var results map[string][]*builds.BuildResult
+		var err error
+		if len(selected) > 0 {
+			results, err = p.buildOrch.BuildMultiple(buildCtx, selected)
+		} else {
+			results, err = p.buildOrch.BuildAll(buildCtx)
+		}
+		span.SetError(err)
 
 		// Check if cancelled
 		if buildCtx.Err() == context.Canceled {
@@ -748,6 +1195,13 @@ func (p *AppPresenter) handleBuildAll(event *Event) error {
 			p.setHeaderEvent(HeaderEventSuccess,
 				fmt.Sprintf("All %d projects built", summary.TotalProjects))
 		}
+
+		projectIDs := make([]string, 0, len(results))
+		for projectID := range results {
+			projectIDs = append(projectIDs, projectID)
+		}
+		sort.Strings(projectIDs)
+		p.scanAfterBuild(projectIDs...)
 	}()
 
 	return nil
@@ -772,11 +1226,95 @@ func (p *AppPresenter) handleCancelBuild(event *Event) error {
 	return nil
 }
 
+// handleSecurityScan runs vulnerability scans for a project (or all projects)
+func (p *AppPresenter) handleSecurityScan(event *Event) error {
+	var projectIDs []string
+	if event.ProjectID != "" {
+		projectIDs = []string{event.ProjectID}
+	} else {
+		for _, proj := range p.projectService.ListProjects() {
+			projectIDs = append(projectIDs, proj.ID)
+		}
+	}
+	if len(projectIDs) == 0 {
+		return fmt.Errorf("no project to scan")
+	}
+
+	go p.runSecurityScan(projectIDs)
+	return nil
+}
+
+// scanAfterBuild triggers a security scan if scan_after_build is enabled
+func (p *AppPresenter) scanAfterBuild(projectIDs ...string) {
+	if p.config == nil || p.config.Settings == nil || len(projectIDs) == 0 {
+		return
+	}
+	if !p.config.Settings.GetSecurityConfig().ScanAfterBuild {
+		return
+	}
+	p.runSecurityScan(projectIDs)
+}
+
+// runSecurityScan scans the given projects and updates the build view model
+func (p *AppPresenter) runSecurityScan(projectIDs []string) {
+	timeout := config.DefaultSecurityConfig().TimeoutSec
+	if p.config != nil && p.config.Settings != nil {
+		timeout = p.config.Settings.GetSecurityConfig().TimeoutSec
+	}
+
+	label := projectIDs[0]
+	if len(projectIDs) > 1 {
+		label = fmt.Sprintf("%d projects", len(projectIDs))
+	}
+	p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("Scanning %s for vulnerabilities...", label))
+
+	p.mu.Lock()
+	p.state.Builds.SecurityScanning = true
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMBuild, p.state.Builds)
+
+	var scanErr error
+	for _, projectID := range projectIDs {
+		_, err := p.securityService.ScanProject(p.ctx, projectID, time.Duration(timeout)*time.Second)
+		if err != nil && scanErr == nil {
+			scanErr = err
+		}
+	}
+
+	p.mu.Lock()
+	p.state.Builds.SecurityScanning = false
+	p.mu.Unlock()
+	p.refreshSecurity()
+	p.refreshDashboard()
+	p.notifyStateUpdate(VMBuild, p.state.Builds)
+	p.notifyStateUpdate(VMDashboard, p.state.Dashboard)
+
+	// Summarize findings for the scanned projects
+	found := 0
+	for _, projectID := range projectIDs {
+		for _, result := range p.securityService.GetResults(projectID) {
+			found += len(result.Findings)
+		}
+	}
+
+	switch {
+	case found > 0:
+		p.setHeaderEvent(HeaderEventWarning, fmt.Sprintf("%d vulnerabilities in %s", found, label))
+	case scanErr != nil && len(projectIDs) == 1:
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Scan failed: %v", scanErr))
+	default:
+		p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("No vulnerabilities in %s", label))
+	}
+}
+
 func (p *AppPresenter) handleStartProcess(event *Event) error {
 	processID := fmt.Sprintf("%s/%s", event.ProjectID, event.Component)
 	p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("Starting %s...", processID))
 	go func() {
-		err := p.processService.StartComponent(p.ctx, event.ProjectID, event.Component, p.processMgr)
+		ctx, span := tracing.Start(p.ctx, "process.start", tracing.String("process", processID))
+		err := p.processService.StartComponentWithCommand(ctx, event.ProjectID, event.Component, event.Data["command"], p.processMgr)
+		span.SetError(err)
+		span.End()
 		if err != nil {
 			p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Start failed: %s", processID))
 		} else {
@@ -886,6 +1424,7 @@ func (p *AppPresenter) handleGitLog(event *Event) error {
 	}
 
 	p.mu.Lock()
+	p.state.Git.SelectedProject = event.ProjectID
 	p.state.Git.Commits = make([]CommitVM, len(commits))
 	for i, c := range commits {
 		p.state.Git.Commits[i] = CommitVM{
@@ -895,6 +1434,7 @@ func (p *AppPresenter) handleGitLog(event *Event) error {
 			Date:      c.Date,
 			DateStr:   c.Date.Format("2006-01-02 15:04"),
 			Subject:   c.Subject,
+			Signature: c.Signature,
 		}
 	}
 	p.mu.Unlock()
@@ -903,6 +1443,33 @@ func (p *AppPresenter) handleGitLog(event *Event) error {
 	return nil
 }
 
+// handleGitStage stages the files marked in the Git tree
+func (p *AppPresenter) handleGitStage(event *Event) error {
+	if event.ProjectID == "" {
+		return nil
+	}
+	var paths []string
+	for _, path := range strings.Split(event.Data["paths"], "\n") {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	if err := p.gitService.Stage(event.ProjectID, paths); err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Stage failed: %v", err))
+		return err
+	}
+	p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("%d files staged in %s", len(paths), event.ProjectID))
+
+	if status, err := p.gitService.GetStatus(event.ProjectID); err == nil {
+		p.applyGitStatus(event.ProjectID, status)
+	}
+	return nil
+}
+
 func (p *AppPresenter) handleFilter(event *Event) error {
 	filterText, _ := event.Value.(string)
 
@@ -939,10 +1506,8 @@ func (p *AppPresenter) refreshProjects() error {
 		p.state.Projects.Projects[i] = p.projectToVM(proj)
 	}
 
-	// Sort by project name for consistent ordering
-	sort.Slice(p.state.Projects.Projects, func(i, j int) bool {
-		return p.state.Projects.Projects[i].Name < p.state.Projects.Projects[j].Name
-	})
+	// Favorites first, then by name, custom or recent order
+	p.sortProjects(p.state.Projects.Projects)
 
 	p.state.Projects.UpdatedAt = time.Now()
 	p.mu.Unlock()
@@ -961,10 +1526,8 @@ func (p *AppPresenter) refreshProjectsWithoutGit() error {
 		p.state.Projects.Projects[i] = p.projectToVM(proj)
 	}
 
-	// Sort by project name for consistent ordering
-	sort.Slice(p.state.Projects.Projects, func(i, j int) bool {
-		return p.state.Projects.Projects[i].Name < p.state.Projects.Projects[j].Name
-	})
+	// Favorites first, then by name, custom or recent order
+	p.sortProjects(p.state.Projects.Projects)
 
 	p.state.Projects.UpdatedAt = time.Now()
 	p.mu.Unlock()
@@ -983,10 +1546,8 @@ func (p *AppPresenter) refreshProjectsFromGit() {
 		p.state.Projects.Projects[i] = p.projectToVM(proj)
 	}
 
-	// Sort by project name for consistent ordering
-	sort.Slice(p.state.Projects.Projects, func(i, j int) bool {
-		return p.state.Projects.Projects[i].Name < p.state.Projects.Projects[j].Name
-	})
+	// Favorites first, then by name, custom or recent order
+	p.sortProjects(p.state.Projects.Projects)
 
 	p.state.Projects.UpdatedAt = time.Now()
 	p.mu.Unlock()
@@ -1036,43 +1597,163 @@ func (p *AppPresenter) refreshProcesses() {
 	p.notifyStateUpdate(VMProcesses, p.state.Processes)
 }
 
+// refreshGitStatus computes the git status of all projects in parallel.
+// Each changed project is published as soon as it is known; unchanged
+// repositories are served from the git service cache.
 func (p *AppPresenter) refreshGitStatus() {
-	allStatus := p.gitService.GetAllStatus()
+	ctx, span := tracing.Start(p.ctx, "git.status_all")
+	defer span.End()
 
-	p.mu.Lock()
-	p.state.Git.Projects = make([]GitStatusVM, 0, len(allStatus))
-	for projectID, status := range allStatus {
-		proj, _ := p.projectService.GetProject(projectID)
-		name := projectID
-		if proj != nil {
-			name = proj.Name
-		}
-
-		p.state.Git.Projects = append(p.state.Git.Projects, GitStatusVM{
-			ProjectID:   projectID,
-			ProjectName: name,
-			Branch:      status.Branch,
-			IsClean:     status.IsClean,
-			Ahead:       status.Ahead,
-			Behind:      status.Behind,
-			Staged:      status.Staged,
-			Modified:    status.Modified,
-			Untracked:   status.Untracked,
-			Deleted:     status.Deleted,
-		})
-	}
-
-	// Sort by project name for consistent ordering
-	sort.Slice(p.state.Git.Projects, func(i, j int) bool {
-		return p.state.Git.Projects[i].ProjectName < p.state.Git.Projects[j].ProjectName
+	seen := make(map[string]bool)
+	p.gitService.StatusAllContext(ctx, func(result git.StatusResult) {
+		if result.Err != nil {
+			return
+		}
+		seen[result.ProjectID] = true
+		p.applyGitStatus(result.ProjectID, result.Status)
 	})
 
+	// Drop projects that are no longer configured or no longer repositories
+	p.mu.Lock()
+	kept := make([]GitStatusVM, 0, len(seen))
+	for _, g := range p.state.Git.Projects {
+		if seen[g.ProjectID] {
+			kept = append(kept, g)
+		}
+	}
+	p.state.Git.Projects = kept
 	p.state.Git.UpdatedAt = time.Now()
 	p.mu.Unlock()
 
 	p.notifyStateUpdate(VMGit, p.state.Git)
 }
 
+// applyGitStatus publishes the status of one project to the Git and Projects views.
+// Slices are replaced rather than modified in place since subscribers may still hold them.
+func (p *AppPresenter) applyGitStatus(projectID string, status *git.Status) {
+	name, projectPath, large := projectID, "", false
+	var hidden *git.Status
+	var hidePatterns []string
+	if proj, err := p.projectService.GetProject(projectID); err == nil {
+		name, projectPath, large = proj.Name, proj.Path, proj.LargeRepo
+		// Files hidden by the display patterns do not make the project dirty
+		status, hidden = git.HideFiles(status, proj.GitHide)
+		hidePatterns = proj.GitHide
+		git.ApplyStatus(proj, status)
+	}
+
+	vm := GitStatusVM{
+		ProjectID:   projectID,
+		ProjectName: name,
+		Branch:      status.Branch,
+		IsClean:     status.IsClean,
+		Ahead:       status.Ahead,
+		Behind:      status.Behind,
+		Staged:      status.Staged,
+		Modified:    status.Modified,
+		Untracked:   status.Untracked,
+		Deleted:     status.Deleted,
+		AIEdits:     p.gitAIEdits(projectPath, status),
+		LargeRepo:   large,
+	}
+	if hidden != nil {
+		vm.Hidden, vm.HidePatterns = hidden, hidePatterns
+	}
+	if signing, err := p.gitService.GetSigningConfig(projectID); err == nil {
+		vm.Signing, vm.SigningWarning = signing.Summary(), signing.Warning()
+	}
+	if hooks, err := p.gitService.GetHooks(projectID); err == nil {
+		vm.Hooks = hooks
+	}
+
+	p.mu.Lock()
+	// Git view: insert or replace, keeping the projects sorted by name
+	gitChanged, known := true, false
+	gitProjects := make([]GitStatusVM, 0, len(p.state.Git.Projects)+1)
+	inserted := false
+	for _, g := range p.state.Git.Projects {
+		if g.ProjectID == projectID {
+			gitChanged, known = !reflect.DeepEqual(g, vm), true
+			continue
+		}
+		if !inserted && vm.ProjectName < g.ProjectName {
+			gitProjects = append(gitProjects, vm)
+			inserted = true
+		}
+		gitProjects = append(gitProjects, g)
+	}
+	if !inserted {
+		gitProjects = append(gitProjects, vm)
+	}
+	if gitChanged {
+		p.state.Git.Projects = gitProjects
+	}
+
+	// Projects view: update the git fields of the project
+	projectsChanged := false
+	for i, proj := range p.state.Projects.Projects {
+		if proj.ID != projectID {
+			continue
+		}
+		dirty := !status.IsClean || status.HasUntracked
+		if proj.GitBranch != status.Branch || proj.GitDirty != dirty ||
+			proj.GitAhead != status.Ahead || proj.GitBehind != status.Behind {
+			projectVMs := make([]ProjectVM, len(p.state.Projects.Projects))
+			copy(projectVMs, p.state.Projects.Projects)
+			projectVMs[i].GitBranch = status.Branch
+			projectVMs[i].GitDirty = dirty
+			projectVMs[i].GitAhead = status.Ahead
+			projectVMs[i].GitBehind = status.Behind
+			p.state.Projects.Projects = projectVMs
+			projectsChanged = true
+		}
+		break
+	}
+	p.mu.Unlock()
+
+	if gitChanged {
+		p.notifyStateChange(StateUpdate{ViewType: VMGit, ViewModel: p.state.Git, ChangedIDs: []string{projectID}})
+		if known {
+			p.publishGitEvent(projectID, status)
+		}
+	}
+	if projectsChanged {
+		p.notifyStateChange(StateUpdate{ViewType: VMProjects, ViewModel: p.state.Projects, ChangedIDs: []string{projectID}})
+	}
+
+	p.fireGitHooks(projectID, status)
+}
+
+// gitAIEdits returns the changed files of a project written by Claude
+// sessions, keyed by their path in the git status
+func (p *AppPresenter) gitAIEdits(projectPath string, status *git.Status) map[string][]AIEditVM {
+	if p.claudeService == nil || projectPath == "" || status.IsClean && !status.HasUntracked {
+		return nil
+	}
+	edits := p.claudeService.FileEdits(projectPath)
+	if len(edits) == 0 {
+		return nil
+	}
+	var result map[string][]AIEditVM
+	for _, files := range [][]string{status.Staged, status.Modified, status.Untracked, status.Deleted} {
+		for _, f := range files {
+			fileEdits := edits[filepath.Join(projectPath, f)]
+			if len(fileEdits) == 0 {
+				continue
+			}
+			if result == nil {
+				result = make(map[string][]AIEditVM)
+			}
+			vms := make([]AIEditVM, len(fileEdits))
+			for i, e := range fileEdits {
+				vms[i] = AIEditVM{SessionID: e.SessionID, SessionName: e.SessionName, Tool: e.Tool, Time: e.Time}
+			}
+			result[f] = vms
+		}
+	}
+	return result
+}
+
 func (p *AppPresenter) refreshDashboard() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -1093,9 +1774,82 @@ func (p *AppPresenter) refreshDashboard() {
 	// Git summary
 	p.state.Dashboard.GitSummary = p.state.Git.Projects
 
+	// Vulnerability badge (count + highest severity)
+	vulnCount := 0
+	vulnSeverity := ""
+	for _, scan := range p.state.Builds.SecurityScans {
+		vulnCount += len(scan.Findings)
+		for _, f := range scan.Findings {
+			if vulnSeverity == "" || security.Severity(f.Severity).Rank() < security.Severity(vulnSeverity).Rank() {
+				vulnSeverity = f.Severity
+			}
+		}
+	}
+	p.state.Dashboard.VulnCount = vulnCount
+	p.state.Dashboard.VulnSeverity = vulnSeverity
+
+	// Disk usage total (from last storage scan)
+	p.state.Dashboard.DiskUsage = ""
+	if p.state.Storage != nil && !p.state.Storage.ScannedAt.IsZero() {
+		p.state.Dashboard.DiskUsage = p.state.Storage.TotalSize
+	}
+
+	// Local TLS certificates to renew or generate
+	p.state.Dashboard.CertWarnings = certWarnings(p.state.Projects.Projects)
+
 	p.state.Dashboard.UpdatedAt = time.Now()
 }
 
+// refreshSecurity rebuilds the security scan view models from cached results
+func (p *AppPresenter) refreshSecurity() {
+	if p.securityService == nil {
+		return
+	}
+
+	all := p.securityService.GetAllResults()
+	projectIDs := make([]string, 0, len(all))
+	for projectID := range all {
+		projectIDs = append(projectIDs, projectID)
+	}
+	sort.Strings(projectIDs)
+
+	var scans []SecurityScanVM
+	for _, projectID := range projectIDs {
+		projectName := projectID
+		if proj, err := p.projectService.GetProject(projectID); err == nil {
+			projectName = proj.Name
+		}
+		for _, result := range all[projectID] {
+			scan := SecurityScanVM{
+				ProjectID:   result.ProjectID,
+				ProjectName: projectName,
+				Component:   result.Component,
+				Scanner:     string(result.Scanner),
+				ScannedAt:   result.ScannedAt,
+				Error:       result.Error,
+				Findings:    make([]VulnerabilityVM, 0, len(result.Findings)),
+			}
+			for _, f := range result.Findings {
+				scan.Findings = append(scan.Findings, VulnerabilityVM{
+					ID:           f.ID,
+					Title:        f.Title,
+					Severity:     string(f.Severity),
+					Package:      f.Package,
+					Version:      f.Version,
+					FixedVersion: f.FixedVersion,
+					Paths:        f.Paths,
+					URL:          f.URL,
+				})
+			}
+			scans = append(scans, scan)
+		}
+	}
+
+	p.mu.Lock()
+	p.state.Builds.SecurityScans = scans
+	p.mu.Unlock()
+}
+
 // ============================================
 // Converters
 // ============================================
@@ -1113,15 +1867,28 @@ func (p *AppPresenter) projectToVM(proj *projects.Project) ProjectVM {
 		GitBehind:  proj.GitBehind,
 		Components: make([]ComponentVM, 0),
 	}
+	for _, t := range proj.Deploy {
+		vm.DeployTargets = append(vm.DeployTargets, t.Name)
+	}
+	if _, err := os.Stat(imageDockerfile(proj)); err == nil {
+		vm.HasImage = true
+	}
 
 	for _, ct := range projects.AllComponentTypes() {
 		if comp := proj.GetComponent(ct); comp != nil && comp.Enabled {
 			cvm := ComponentVM{
-				Type:    comp.Type,
-				Path:    comp.Path,
-				Binary:  comp.Binary,
-				Port:    comp.Port,
-				Enabled: comp.Enabled,
+				Type:     comp.Type,
+				Path:     comp.Path,
+				Binary:   comp.Binary,
+				Port:     comp.Port,
+				Enabled:  comp.Enabled,
+				BuildCmd: comp.BuildCmd,
+				RunCmd:   comp.RunCmd,
+				HasAPI:   comp.API != nil && (comp.API.OpenAPI != "" || comp.API.Proto != ""),
+				Capture:  comp.Capture,
+			}
+			if comp.TLS != nil {
+				cvm.Certificate = certificateVM(comp.TLS)
 			}
 
 			// Check if running
@@ -1163,10 +1930,17 @@ func (p *AppPresenter) processToVM(proc *processes.Process) ProcessVM {
 		Restarts:    proc.Restarts,
 		LastError:   proc.LastError,
 		IsSelf:      isSelf,
+		ExitCode:    proc.ExitCode,
+		CrashLoop:   proc.IsCrashLooping(),
 	}
+	vm.Terminations = p.processService.GetTerminations(proc.ID)
 
 	if proc.State == processes.ProcessStateRunning && !proc.StartedAt.IsZero() {
 		vm.Uptime = time.Since(proc.StartedAt).Round(time.Second).String()
+		vm.DevServer = proc.GetDevServer()
+	}
+	if proc.State == processes.ProcessStateCrashed {
+		vm.StderrTail = proc.GetStderrTail()
 	}
 
 	return vm
@@ -1202,11 +1976,20 @@ func (p *AppPresenter) setPersistentHeaderEvent(etype HeaderEventType, message s
 }
 
 func (p *AppPresenter) notifyStateUpdate(viewType ViewModelType, vm ViewModel) {
-	update := StateUpdate{
+	p.deliverStateUpdate(StateUpdate{
 		ViewType:  viewType,
 		ViewModel: vm,
-	}
+	})
+}
+
+// notifyStateChange queues a differential update for high-frequency sources.
+// Updates are merged and delivered at most once per StateBatchInterval.
+func (p *AppPresenter) notifyStateChange(update StateUpdate) {
+	p.updateBatcher.Add(update)
+}
 
+// deliverStateUpdate sends an update to all subscribers
+func (p *AppPresenter) deliverStateUpdate(update StateUpdate) {
 	p.mu.RLock()
 	callbacks := p.stateCallbacks
 	p.mu.RUnlock()
@@ -1232,6 +2015,7 @@ func (p *AppPresenter) handleBuildEvent(event builds.BuildEvent) {
 
 	switch event.Type {
 	case builds.BuildEventStarted:
+		defer p.publishBuildEvent(event)
 		p.state.Builds.IsBuilding = true
 		p.state.Builds.CurrentBuild.Status = builds.BuildStatusRunning
 		p.state.Builds.CurrentBuild.Output = []string{}
@@ -1241,6 +2025,13 @@ func (p *AppPresenter) handleBuildEvent(event builds.BuildEvent) {
 		p.state.Builds.CurrentBuild.Errors = append(p.state.Builds.CurrentBuild.Errors, event.Message)
 	case builds.BuildEventFinished:
 		p.state.Builds.IsBuilding = false
+		if build := p.buildOrch.GetBuild(event.BuildID); build != nil {
+			p.state.Builds.CurrentBuild.Status = build.Status
+			p.state.Builds.CurrentBuild.Duration = build.Duration.Round(100 * time.Millisecond).String()
+		}
+		defer p.fireBuildHooks(event)
+		defer p.publishBuildEvent(event)
+		defer p.recordBuild(event)
 	}
 
 	// Also add to Logs view for persistence
@@ -1258,19 +2049,20 @@ func (p *AppPresenter) handleBuildEvent(event builds.BuildEvent) {
 	default:
 		logLine.Level = "info"
 	}
-	p.state.Logs.Lines = append(p.state.Logs.Lines, logLine)
-	if len(p.state.Logs.Lines) > p.state.Logs.MaxLines {
-		p.state.Logs.Lines = p.state.Logs.Lines[1:]
-	}
+	appended := p.appendLog(logLine)
 
 	p.mu.Unlock()
 
-	p.notifyStateUpdate(VMBuild, p.state.Builds)
-	p.notifyStateUpdate(VMLogs, p.state.Logs)
+	// Build output arrives line by line: batch the updates
+	p.notifyStateChange(StateUpdate{ViewType: VMBuild, ViewModel: p.state.Builds, ChangedIDs: []string{event.BuildID}})
+	if appended > 0 {
+		p.notifyStateChange(StateUpdate{ViewType: VMLogs, ViewModel: p.state.Logs, Appended: appended})
+	}
 }
 
 func (p *AppPresenter) handleProcessEvent(event processes.ProcessEvent) {
 	p.refreshProcesses()
+	p.publishProcessEvent(event)
 
 	// Add to logs
 	p.mu.Lock()
@@ -1286,17 +2078,18 @@ func (p *AppPresenter) handleProcessEvent(event processes.ProcessEvent) {
 		logLine.Level = "error"
 	case processes.ProcessEventCrashed:
 		logLine.Level = "error"
+		defer p.fireCrashHooks(event)
+		defer p.handleProcessCrash(event)
 	default:
 		logLine.Level = "info"
 	}
 
-	p.state.Logs.Lines = append(p.state.Logs.Lines, logLine)
-	if len(p.state.Logs.Lines) > p.state.Logs.MaxLines {
-		p.state.Logs.Lines = p.state.Logs.Lines[1:]
-	}
+	appended := p.appendLog(logLine)
 	p.mu.Unlock()
 
-	p.notifyStateUpdate(VMLogs, p.state.Logs)
+	if appended > 0 {
+		p.notifyStateChange(StateUpdate{ViewType: VMLogs, ViewModel: p.state.Logs, Appended: appended})
+	}
 }
 
 // ============================================
@@ -1369,29 +2162,86 @@ func (p *AppPresenter) handleClaudeSelectSession(event *Event) error {
 	p.mu.Lock()
 	p.state.Claude.ActiveSessionID = sessionID
 	p.state.Claude.ActiveSession = p.sessionToVM(session)
-	p.state.Claude.Messages = p.messagesToVM(session.Messages)
+	p.claudeMessageLimit = claudeMessagePage
+	p.setClaudeMessages(session.Messages)
 	p.mu.Unlock()
 
 	p.notifyStateUpdate(VMClaude, p.state.Claude)
 	return nil
 }
 
+// trashClaudeSession deletes a session, keeping its file in the trash so the
+// deletion can be undone. Returns false if it could not be kept.
+func (p *AppPresenter) trashClaudeSession(sessionID string) (bool, error) {
+	trashed := false
+	if sess, err := p.claudeService.GetSession(sessionID); err == nil && sess.SessionFile != "" && p.trashService != nil {
+		payload := claudeTrashPayload{SessionFile: sess.SessionFile, CustomName: sess.CustomName}
+		if _, err := p.trashService.Put(trash.KindClaudeSession, sess.DisplayName(), payload, []string{sess.SessionFile}); err == nil {
+			trashed = true
+		}
+	}
+	return trashed, p.claudeService.DeleteSession(sessionID)
+}
+
 func (p *AppPresenter) handleClaudeDeleteSession(event *Event) error {
 	sessionID, ok := event.Value.(string)
 	if !ok || sessionID == "" {
 		return fmt.Errorf("session ID required")
 	}
 
-	if err := p.claudeService.DeleteSession(sessionID); err != nil {
+	trashed, err := p.trashClaudeSession(sessionID)
+	if err != nil {
 		p.setHeaderEvent(HeaderEventError, "Session deletion failed")
 		return err
 	}
 
-	p.setHeaderEvent(HeaderEventSuccess, "Session deleted")
+	if trashed {
+		p.setHeaderEvent(HeaderEventSuccess, "Session deleted (Ctrl+Z to undo)")
+		p.refreshTrash()
+		p.notifyStateUpdate(VMTrash, p.state.Trash)
+	} else {
+		p.setHeaderEvent(HeaderEventSuccess, "Session deleted")
+	}
 	p.refreshClaude()
 	return nil
 }
 
+// handleClaudeForkSession duplicates a session into a new one continuing the
+// same conversation, and selects it
+func (p *AppPresenter) handleClaudeForkSession(event *Event) error {
+	sessionID := event.Data["session_id"]
+	if sessionID == "" {
+		return fmt.Errorf("session ID required")
+	}
+
+	fork, err := p.claudeService.ForkSession(sessionID, event.Data["session_name"])
+	if fork == nil {
+		p.setHeaderEvent(HeaderEventError, "Fork failed: "+err.Error())
+		return err
+	}
+
+	// Select the fork, like a new session
+	p.mu.Lock()
+	p.state.Claude.NewlyCreatedSessionID = fork.ID
+	p.state.Claude.NewlyCreatedSessionProjectID = fork.ProjectID
+	p.mu.Unlock()
+
+	if err != nil {
+		p.setHeaderEvent(HeaderEventWarning, "Session forked, "+err.Error())
+	} else {
+		p.setHeaderEvent(HeaderEventSuccess, "Session forked: "+fork.DisplayName())
+	}
+	p.refreshClaude()
+
+	// Clear after first refresh so it's not sent again
+	p.mu.Lock()
+	p.state.Claude.NewlyCreatedSessionID = ""
+	p.state.Claude.NewlyCreatedSessionProjectID = ""
+	p.mu.Unlock()
+
+	return err
+}
+
 func (p *AppPresenter) handleClaudeRenameSession(event *Event) error {
 	sessionID := event.Data["session_id"]
 	newName := event.Data["new_name"]
@@ -1776,7 +2626,7 @@ func (p *AppPresenter) loadSessionMessages(sessionID string) {
 
 	p.mu.Lock()
 	p.state.Claude.ActiveSession = p.sessionToVM(session)
-	p.state.Claude.Messages = p.messagesToVM(session.Messages)
+	p.setClaudeMessages(session.Messages)
 	p.mu.Unlock()
 
 	p.notifyStateUpdate(VMClaude, p.state.Claude)
@@ -1807,27 +2657,12 @@ func (p *AppPresenter) refreshClaude() {
 	sessions := p.claudeService.ListSessions("")
 	p.state.Claude.Sessions = make([]ClaudeSessionVM, len(sessions))
 	for i, s := range sessions {
-		p.state.Claude.Sessions[i] = ClaudeSessionVM{
-			ID:               s.ID,
-			Name:             s.Name,
-			ProjectID:        s.ProjectID,
-			ProjectName:      s.ProjectName,
-			WorkDir:          s.WorkDir,
-			ClaudeProjectDir: s.ClaudeProjectDir,
-			State:            string(s.State),
-			MessageCount:     s.MessageCount,
-			CreatedAt:        s.CreatedAt,
-			LastActive:       s.LastActiveAt.Format("2006-01-02 15:04"),
-			LastActiveAt:     s.LastActiveAt,
-			IsActive:         s.ID == p.state.Claude.ActiveSessionID,
-			IsPersistent:     persistentSessions[s.ID],
-		}
+		p.state.Claude.Sessions[i] = p.claudeSessionVM(s, persistentSessions[s.ID])
 	}
 
 	// Sort sessions by LastActiveAt descending (most recent first)
-	sort.Slice(p.state.Claude.Sessions, func(i, j int) bool {
-		return p.state.Claude.Sessions[i].LastActiveAt.After(p.state.Claude.Sessions[j].LastActiveAt)
-	})
+	sortClaudeSessions(p.state.Claude.Sessions)
+	p.state.Claude.Tasks = p.sessionTasks()
 
 	p.mu.Unlock()
 
@@ -1835,22 +2670,92 @@ func (p *AppPresenter) refreshClaude() {
 	p.notifyStateUpdate(VMClaude, p.state.Claude)
 }
 
-// refreshClaudeMessages syncs messages from the service for a specific session
-func (p *AppPresenter) refreshClaudeMessages(sessionID string) {
-	if p.claudeService == nil || sessionID == "" {
-		return
+// updateClaudeSessions applies the sessions added, updated or removed on
+// disk to the Claude view model, instead of rebuilding the whole list
+func (p *AppPresenter) updateClaudeSessions(changes claude.SessionChanges) {
+	summaries := make(map[string]claude.SessionSummary)
+	for _, id := range append(changes.Added, changes.Updated...) {
+		if summary, ok := p.claudeService.GetSessionSummary(id); ok {
+			summaries[id] = summary
+		}
 	}
-
-	session, err := p.claudeService.GetSession(sessionID)
-	if err != nil {
-		return
+	removed := make(map[string]bool)
+	for _, id := range changes.Removed {
+		removed[id] = true
 	}
-
-	p.mu.Lock()
-	defer p.mu.Unlock()
+	persistentSessions := make(map[string]bool)
+	for _, id := range p.claudeService.GetPersistentProcessSessions() {
+		persistentSessions[id] = true
+	}
+
+	p.mu.Lock()
+	sessions := make([]ClaudeSessionVM, 0, len(p.state.Claude.Sessions)+len(changes.Added))
+	for _, vm := range p.state.Claude.Sessions {
+		if removed[vm.ID] {
+			continue
+		}
+		if summary, ok := summaries[vm.ID]; ok {
+			vm = p.claudeSessionVM(summary, persistentSessions[vm.ID])
+			delete(summaries, vm.ID)
+		}
+		sessions = append(sessions, vm)
+	}
+	for _, summary := range summaries {
+		sessions = append(sessions, p.claudeSessionVM(summary, persistentSessions[summary.ID]))
+	}
+	sortClaudeSessions(sessions)
+	p.state.Claude.Sessions = sessions
+	p.state.Claude.Tasks = p.sessionTasks()
+	p.mu.Unlock()
+
+	p.notifyStateUpdate(VMClaude, p.state.Claude)
+}
+
+// claudeSessionVM returns the view model of a session.
+// Must be called with p.mu held.
+func (p *AppPresenter) claudeSessionVM(s claude.SessionSummary, persistent bool) ClaudeSessionVM {
+	return ClaudeSessionVM{
+		ID:               s.ID,
+		Name:             s.Name,
+		ProjectID:        s.ProjectID,
+		ProjectName:      s.ProjectName,
+		WorkDir:          s.WorkDir,
+		ClaudeProjectDir: s.ClaudeProjectDir,
+		State:            string(s.State),
+		MessageCount:     s.MessageCount,
+		CreatedAt:        s.CreatedAt,
+		LastActive:       s.LastActiveAt.Format("2006-01-02 15:04"),
+		LastActiveAt:     s.LastActiveAt,
+		IsActive:         s.ID == p.state.Claude.ActiveSessionID,
+		IsPersistent:     persistent,
+		ParentID:         s.ParentID,
+		GitBranch:        s.GitBranch,
+	}
+}
+
+// sortClaudeSessions sorts sessions by LastActiveAt, most recent first
+func sortClaudeSessions(sessions []ClaudeSessionVM) {
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastActiveAt.After(sessions[j].LastActiveAt)
+	})
+}
+
+// refreshClaudeMessages syncs messages from the service for a specific session
+func (p *AppPresenter) refreshClaudeMessages(sessionID string) {
+	if p.claudeService == nil || sessionID == "" {
+		return
+	}
+
+	session, err := p.claudeService.GetSession(sessionID)
+	if err != nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
 	// Update messages from the service (authoritative source)
-	p.state.Claude.Messages = p.messagesToVM(session.Messages)
+	p.setClaudeMessages(session.Messages)
 
 	// Update active session info
 	p.state.Claude.ActiveSession = p.sessionToVM(session)
@@ -1919,11 +2824,13 @@ func (p *AppPresenter) refreshCodex() {
 	sort.Slice(p.state.Codex.Sessions, func(i, j int) bool {
 		return p.state.Codex.Sessions[i].LastActiveAt.After(p.state.Codex.Sessions[j].LastActiveAt)
 	})
+	p.state.Claude.Tasks = p.sessionTasks()
 
 	p.mu.Unlock()
 
-	// Notify UI of the update
+	// Notify UI of the update (tasks are shown with the Claude sessions)
 	p.notifyStateUpdate(VMCodex, p.state.Codex)
+	p.notifyStateUpdate(VMClaude, p.state.Claude)
 }
 
 // formatToolOutput formats tool usage for display in chat
@@ -2266,6 +3173,7 @@ func (p *AppPresenter) refreshDatabase() {
 	databases, _ := p.databaseService.DiscoverDatabases()
 
 	p.mu.Lock()
+	p.state.Database.Loaded = true
 
 	// Convert databases to view models
 	p.state.Database.Databases = make([]DatabaseInfoVM, len(databases))
@@ -2282,6 +3190,10 @@ func (p *AppPresenter) refreshDatabase() {
 			User:         db.User,
 			URL:          db.URL,
 		}
+		if project, err := p.projectService.GetProject(db.ProjectID); err == nil && project.Database != nil {
+			p.state.Database.Databases[i].ResetCommand = project.Database.Reset
+			p.state.Database.Databases[i].SeedCommand = project.Database.Seed
+		}
 	}
 
 	// Get sessions
@@ -2347,20 +3259,28 @@ func (p *AppPresenter) refreshCapabilities() {
 		if info == nil {
 			return CapabilityVM{Name: string(cap), Available: false}
 		}
-		return CapabilityVM{
+		vm := CapabilityVM{
 			Name:      string(cap),
 			Available: info.Available,
 			Path:      info.Path,
 			Version:   info.Version,
 		}
+		if !info.Available {
+			vm.Install = capabilities.InstallCommand(cap)
+		}
+		return vm
 	}
 
 	p.state.Capabilities = &CapabilitiesVM{
 		Tmux:   toVM(capabilities.CapTmux),
+		PTY:    toVM(capabilities.CapPTY),
 		Claude: toVM(capabilities.CapClaude),
 		Codex:  toVM(capabilities.CapCodex),
 		Shell:  toVM(capabilities.CapShell),
 		Sudo:   toVM(capabilities.CapSudo),
+		SSH:    toVM(capabilities.CapSSH),
+		Rsync:  toVM(capabilities.CapRsync),
+		SCP:    toVM(capabilities.CapSCP),
 		Psql:   toVM(capabilities.CapPsql),
 		Mysql:  toVM(capabilities.CapMysql),
 		Sqlite: toVM(capabilities.CapSqlite),
@@ -2368,6 +3288,16 @@ func (p *AppPresenter) refreshCapabilities() {
 		Go:     toVM(capabilities.CapGo),
 		Node:   toVM(capabilities.CapNode),
 		Npm:    toVM(capabilities.CapNpm),
+
+		Govulncheck: toVM(capabilities.CapGovulncheck),
+		Grpcurl:     toVM(capabilities.CapGrpcurl),
+		Docker:      toVM(capabilities.CapDocker),
+
+		CheckedAt: time.Now(),
+	}
+	for _, sh := range p.capService.GetAvailableShells() {
+		p.state.Capabilities.Shells = append(p.state.Capabilities.Shells,
+			CapabilityVM{Name: sh.Name, Available: true, Path: sh.Path})
 	}
 }
 
@@ -2419,6 +3349,19 @@ func (p *AppPresenter) refreshShell() {
 		return p.state.Shell.Sessions[i].LastActiveAt.After(p.state.Shell.Sessions[j].LastActiveAt)
 	})
 
+	// SSH targets (configured hosts, then ~/.ssh/config)
+	hosts := p.sshHosts()
+	p.state.Shell.SSHHosts = make([]SSHHostVM, len(hosts))
+	for i, h := range hosts {
+		p.state.Shell.SSHHosts[i] = SSHHostVM{
+			Name:   h.Name,
+			Group:  h.Group,
+			Target: h.Target(),
+			Source: h.Source,
+			Args:   h.Args(),
+		}
+	}
+
 	p.mu.Unlock()
 
 	// Notify UI of the update
@@ -2468,6 +3411,15 @@ func (p *AppPresenter) handleShellDeleteSession(event *Event) error {
 		return err
 	}
 
+	message := fmt.Sprintf("Shell session '%s' deleted", name)
+	if session != nil && p.trashService != nil {
+		if _, err := p.trashService.Put(trash.KindShellSession, name, session, nil); err == nil {
+			message += " (Ctrl+Z to undo)"
+			p.refreshTrash()
+			p.notifyStateUpdate(VMTrash, p.state.Trash)
+		}
+	}
+
 	// Clear active session if it was deleted
 	p.mu.Lock()
 	if p.state.Shell.ActiveSessionID == sessionID {
@@ -2477,7 +3429,7 @@ func (p *AppPresenter) handleShellDeleteSession(event *Event) error {
 	p.mu.Unlock()
 
 	p.refreshShell()
-	p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Shell session '%s' deleted", name))
+	p.setHeaderEvent(HeaderEventSuccess, message)
 	return nil
 }
 
@@ -2515,3 +3467,1308 @@ func (p *AppPresenter) handleShellRefresh(event *Event) error {
 	p.refreshShell()
 	return nil
 }
+
+// ============================================
+// Storage handlers
+// ============================================
+
+func (p *AppPresenter) handleStorageScan(event *Event) error {
+	if p.storageService == nil {
+		return fmt.Errorf("storage service not initialized")
+	}
+	go p.runStorageScan(true)
+	return nil
+}
+
+func (p *AppPresenter) handleStorageClean(event *Event) error {
+	if p.storageService == nil {
+		return fmt.Errorf("storage service not initialized")
+	}
+	category := storage.Category(event.Target)
+	label := category.Label()
+	if event.ProjectID != "" {
+		label = fmt.Sprintf("%s/%s", event.ProjectID, label)
+	}
+
+	p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("Cleaning %s...", label))
+	go func() {
+		freed, err := p.storageService.Clean(p.ctx, event.ProjectID, category)
+		if err != nil {
+			p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Cleanup failed: %v", err))
+			return
+		}
+		switch category {
+		case storage.CategoryArtifacts, storage.CategoryNodeModules:
+			p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Moved %s to the trash (%s, Ctrl+Z to undo)", storage.FormatBytes(freed), label))
+			p.refreshTrash()
+			p.notifyStateUpdate(VMTrash, p.state.Trash)
+		default:
+			p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Freed %s (%s)", storage.FormatBytes(freed), label))
+		}
+		p.runStorageScan(false)
+	}()
+	return nil
+}
+
+// runStorageScan measures disk usage and updates the storage view model.
+// When announce is true, progress and result are reported in the header.
+func (p *AppPresenter) runStorageScan(announce bool) {
+	if announce {
+		p.setPersistentHeaderEvent(HeaderEventInfo, "Scanning disk usage...")
+	}
+
+	p.mu.Lock()
+	p.state.Storage.Scanning = true
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMStorage, p.state.Storage)
+
+	_, err := p.storageService.Scan(p.ctx)
+
+	p.mu.Lock()
+	p.state.Storage.Scanning = false
+	p.mu.Unlock()
+	if err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Storage scan failed: %v", err))
+	} else if announce {
+		if report := p.storageService.GetReport(); report != nil {
+			p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Disk usage: %s (%s)", storage.FormatBytes(report.TotalBytes), report.Duration.Round(time.Millisecond)))
+		}
+	}
+
+	p.refreshStorage()
+	p.refreshDashboard()
+	p.notifyStateUpdate(VMStorage, p.state.Storage)
+	p.notifyStateUpdate(VMDashboard, p.state.Dashboard)
+}
+
+// refreshStorage updates the storage view model from the last report
+func (p *AppPresenter) refreshStorage() {
+	if p.storageService == nil {
+		return
+	}
+	report := p.storageService.GetReport()
+	if report == nil {
+		return
+	}
+
+	toVM := func(e storage.Entry) StorageEntryVM {
+		return StorageEntryVM{
+			Category: string(e.Category),
+			Label:    e.Category.Label(),
+			Bytes:    e.Bytes,
+			Size:     storage.FormatBytes(e.Bytes),
+			Files:    e.Files,
+			Paths:    e.Paths,
+		}
+	}
+
+	projectVMs := make([]StorageProjectVM, 0, len(report.Projects))
+	for _, usage := range report.Projects {
+		pvm := StorageProjectVM{
+			ProjectID:   usage.ProjectID,
+			ProjectName: usage.ProjectName,
+			TotalBytes:  usage.TotalBytes,
+			TotalSize:   storage.FormatBytes(usage.TotalBytes),
+		}
+		for _, e := range usage.Entries {
+			pvm.Entries = append(pvm.Entries, toVM(e))
+		}
+		projectVMs = append(projectVMs, pvm)
+	}
+	// Largest projects first
+	sort.SliceStable(projectVMs, func(i, j int) bool {
+		return projectVMs[i].TotalBytes > projectVMs[j].TotalBytes
+	})
+
+	shared := make([]StorageEntryVM, 0, len(report.Shared))
+	for _, e := range report.Shared {
+		shared = append(shared, toVM(e))
+	}
+
+	p.mu.Lock()
+	p.state.Storage.Projects = projectVMs
+	p.state.Storage.Shared = shared
+	p.state.Storage.TotalBytes = report.TotalBytes
+	p.state.Storage.TotalSize = storage.FormatBytes(report.TotalBytes)
+	p.state.Storage.ScannedAt = report.ScannedAt
+	p.state.Storage.UpdatedAt = time.Now()
+	p.mu.Unlock()
+}
+
+// ============================================
+// Transfer handlers
+// ============================================
+
+// sshHosts returns the SSH targets: configured hosts, then ~/.ssh/config
+func (p *AppPresenter) sshHosts() []shell.SSHHost {
+	sshConfig := &config.SSHConfig{}
+	if p.config != nil && p.config.Settings != nil {
+		sshConfig = p.config.Settings.GetSSHConfig()
+	}
+	configured := make([]shell.SSHHost, len(sshConfig.Hosts))
+	for i, h := range sshConfig.Hosts {
+		configured[i] = shell.SSHHost{
+			Name:         h.Name,
+			HostName:     h.Host,
+			User:         h.User,
+			Port:         h.Port,
+			IdentityFile: h.IdentityFile,
+			Group:        h.Group,
+		}
+	}
+	return p.shellService.SSHHosts(configured, !sshConfig.DisableSSHConfig)
+}
+
+func (p *AppPresenter) handleTransferStart(event *Event) error {
+	if p.transferService == nil || !p.transferService.IsAvailable() {
+		return fmt.Errorf("rsync or scp is required for file transfers")
+	}
+	proj, err := p.projectService.GetProject(event.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	var host *shell.SSHHost
+	for _, h := range p.sshHosts() {
+		if h.Name == event.Data["host"] {
+			host = &h
+			break
+		}
+	}
+	if host == nil {
+		return fmt.Errorf("unknown SSH host: %s", event.Data["host"])
+	}
+
+	// Local paths are relative to the project
+	localPath := event.Data["local"]
+	if !filepath.IsAbs(localPath) {
+		localPath = filepath.Join(proj.Path, localPath)
+		// Keep a trailing separator: rsync copies the directory contents
+		if strings.HasSuffix(event.Data["local"], "/") {
+			localPath += "/"
+		}
+	}
+
+	req := transfer.Request{
+		ProjectID:  proj.ID,
+		Direction:  transfer.Direction(event.Data["direction"]),
+		Host:       *host,
+		LocalPath:  localPath,
+		RemotePath: event.Data["remote"],
+	}
+	t, err := p.transferService.Start(p.ctx, req, p.onTransferUpdate)
+	if err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Transfer failed: %v", err))
+		return err
+	}
+	p.onTransferUpdate(*t)
+	return nil
+}
+
+// onTransferUpdate reports transfer progress in the header and the projects view model
+func (p *AppPresenter) onTransferUpdate(t transfer.Transfer) {
+	verb, preposition := "Pushing", "to"
+	if t.Direction == transfer.DirectionPull {
+		verb, preposition = "Pulling", "from"
+	}
+	label := fmt.Sprintf("%s %s", preposition, t.HostName)
+
+	switch t.State {
+	case transfer.StateRunning:
+		progress := ""
+		if t.Percent >= 0 {
+			progress = fmt.Sprintf(" %d%%", t.Percent)
+			if t.Speed != "" {
+				progress += " " + t.Speed
+			}
+		}
+		p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("%s %s...%s", verb, label, progress))
+	case transfer.StateDone:
+		p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Transfer %s done (%s)", label, time.Since(t.StartedAt).Round(time.Second)))
+	case transfer.StateFailed:
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Transfer %s failed: %s", label, t.Error))
+	}
+
+	transfers := p.transferService.GetTransfers()
+	p.mu.Lock()
+	p.state.Projects.Transfers = make([]TransferVM, len(transfers))
+	for i, tr := range transfers {
+		p.state.Projects.Transfers[i] = TransferVM{
+			ID:          tr.ID,
+			ProjectID:   tr.ProjectID,
+			Direction:   string(tr.Direction),
+			HostName:    tr.HostName,
+			Source:      tr.Source,
+			Destination: tr.Destination,
+			Tool:        tr.Tool,
+			State:       string(tr.State),
+			Percent:     tr.Percent,
+			Transferred: tr.Transferred,
+			Speed:       tr.Speed,
+			Error:       tr.Error,
+			StartedAt:   tr.StartedAt,
+			FinishedAt:  tr.FinishedAt,
+		}
+	}
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMProjects, p.state.Projects)
+}
+
+// ============================================
+// Deploy handlers
+// ============================================
+
+func (p *AppPresenter) handleDeploy(event *Event) error {
+	if p.deployService == nil {
+		return fmt.Errorf("deploy not available")
+	}
+	proj, err := p.projectService.GetProject(event.ProjectID)
+	if err != nil {
+		return err
+	}
+	target := proj.GetDeployTarget(event.Target)
+	if target == nil {
+		return fmt.Errorf("unknown deploy target: %s", event.Target)
+	}
+
+	// Paths are relative to the project
+	dir := proj.Path
+	if target.Dir != "" {
+		dir = target.Dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(proj.Path, dir)
+		}
+	}
+	script := target.Script
+	if script != "" && !filepath.IsAbs(script) {
+		script = filepath.Join(proj.Path, script)
+	}
+	targetEnv, err := config.ResolveSecretsMap(target.Env)
+	if err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Deploy failed: env %v", err))
+		return err
+	}
+	env := []string{"DEVTRACK_PROJECT=" + proj.ID, "DEVTRACK_DEPLOY_TARGET=" + target.Name}
+	for k, v := range targetEnv {
+		env = append(env, k+"="+v)
+	}
+
+	req := deploy.Request{
+		ProjectID: proj.ID,
+		Target:    target.Name,
+		Command:   target.Command,
+		Script:    script,
+		Dir:       dir,
+		Env:       env,
+	}
+	d, err := p.deployService.Start(p.ctx, req, p.onDeployOutput, p.onDeployUpdate)
+	if err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Deploy failed: %v", err))
+		return err
+	}
+	p.onDeployUpdate(*d)
+	return nil
+}
+
+// onDeployOutput streams deployment output into the logs
+func (p *AppPresenter) onDeployOutput(d deploy.Deployment, line string, isError bool) {
+	now := time.Now()
+	logLine := LogLineVM{
+		Timestamp: now,
+		TimeStr:   now.Format("15:04:05"),
+		Source:    fmt.Sprintf("deploy:%s/%s", d.ProjectID, d.Target),
+		Level:     "info",
+		Message:   line,
+	}
+	if isError {
+		logLine.Level = "error"
+	}
+
+	p.mu.Lock()
+	p.state.Logs.Append(logLine)
+	p.mu.Unlock()
+
+	p.notifyStateChange(StateUpdate{ViewType: VMLogs, ViewModel: p.state.Logs, Appended: 1})
+}
+
+// onDeployUpdate reports deployment status in the header and the projects view model
+func (p *AppPresenter) onDeployUpdate(d deploy.Deployment) {
+	label := fmt.Sprintf("%s to %s", d.ProjectID, d.Target)
+	switch d.State {
+	case deploy.StateRunning:
+		p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("Deploying %s...", label))
+	case deploy.StateSuccess:
+		p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Deployed %s (%s)", label, d.Duration().Round(time.Second)))
+		p.notify(NotifySuccess, "Deploy Succeeded", fmt.Sprintf("%s deployed to %s", d.ProjectID, d.Target))
+	case deploy.StateFailed:
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Deploy %s failed: %s", label, d.Error))
+		p.notify(NotifyError, "Deploy Failed", fmt.Sprintf("%s to %s: %s", d.ProjectID, d.Target, d.Error))
+	}
+
+	p.refreshDeployments()
+	p.notifyStateUpdate(VMProjects, p.state.Projects)
+}
+
+// refreshDeployments copies the deploy history into the projects view model
+func (p *AppPresenter) refreshDeployments() {
+	if p.deployService == nil {
+		return
+	}
+	deployments := p.deployService.GetDeployments()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state.Projects.Deployments = make([]DeploymentVM, len(deployments))
+	for i, d := range deployments {
+		p.state.Projects.Deployments[i] = DeploymentVM{
+			ID:         d.ID,
+			ProjectID:  d.ProjectID,
+			Target:     d.Target,
+			Command:    d.Command,
+			State:      string(d.State),
+			ExitCode:   d.ExitCode,
+			Error:      d.Error,
+			StartedAt:  d.StartedAt,
+			FinishedAt: d.FinishedAt,
+		}
+	}
+}
+
+// ============================================
+// Build history
+// ============================================
+
+// setBuildProfile sets the profile recorded for the builds being started
+func (p *AppPresenter) setBuildProfile(profile string) {
+	p.mu.Lock()
+	p.buildProfile = profile
+	p.mu.Unlock()
+}
+
+// recordBuild appends a finished build to the persisted history (canceled
+// builds are not recorded, they would skew durations and failure rates)
+func (p *AppPresenter) recordBuild(event builds.BuildEvent) {
+	if p.buildHistory == nil {
+		return
+	}
+	build := p.buildOrch.GetBuild(event.BuildID)
+	if build == nil || !build.IsComplete() || build.Status == builds.BuildStatusCanceled {
+		return
+	}
+
+	p.mu.RLock()
+	profile := p.buildProfile
+	p.mu.RUnlock()
+
+	record := buildhistory.Record{
+		Time:       build.StartedAt,
+		BuildID:    build.ID,
+		ProjectID:  build.ProjectID,
+		Component:  string(build.Component),
+		Profile:    profile,
+		Status:     string(build.Status),
+		DurationMs: build.Duration.Milliseconds(),
+		ExitCode:   build.ExitCode,
+	}
+	for _, step := range build.Steps {
+		record.Steps = append(record.Steps, buildhistory.Step{Name: step.Name, DurationMs: step.Duration.Milliseconds()})
+	}
+	if head, err := p.gitService.GetHead(build.ProjectID); err == nil && head != nil {
+		record.GitHash = head.ShortHash
+	}
+	if err := p.buildHistory.Record(record); err != nil {
+		p.log().Warn("Failed to record build: %v", err)
+	}
+
+	p.refreshBuildHistory()
+	p.notifyStateUpdate(VMBuild, p.state.Builds)
+}
+
+// refreshBuildHistory loads the recent builds and the analytics of the
+// persisted history into the build view model
+func (p *AppPresenter) refreshBuildHistory() {
+	const maxShown = 100
+
+	if p.buildHistory == nil {
+		return
+	}
+	records := p.buildHistory.List(maxShown)
+	analytics := p.buildHistory.Analyze()
+
+	projectName := func(projectID string) string {
+		if project, err := p.projectService.GetProject(projectID); err == nil {
+			return project.Name
+		}
+		return projectID
+	}
+
+	history := make([]BuildVM, len(records))
+	for i, r := range records {
+		history[i] = BuildVM{
+			ID:          r.BuildID,
+			ProjectID:   r.ProjectID,
+			ProjectName: projectName(r.ProjectID),
+			Component:   projects.ComponentType(r.Component),
+			Status:      builds.BuildStatus(r.Status),
+			Progress:    100,
+			Duration:    r.Duration().Round(100 * time.Millisecond).String(),
+			StartedAt:   r.Time,
+			Profile:     r.Profile,
+			GitHash:     r.GitHash,
+		}
+	}
+
+	vm := &BuildAnalyticsVM{
+		Builds:   analytics.Builds,
+		Failures: analytics.Failures,
+		Since:    analytics.Since,
+		File:     p.buildHistory.File(),
+	}
+	for _, c := range analytics.Components {
+		vm.Components = append(vm.Components, BuildComponentStatsVM{
+			ProjectID:    c.ProjectID,
+			ProjectName:  projectName(c.ProjectID),
+			Component:    c.Component,
+			Builds:       c.Builds,
+			Failures:     c.Failures,
+			FailureRate:  c.FailureRate(),
+			AvgDuration:  time.Duration(c.AvgMs) * time.Millisecond,
+			LastDuration: time.Duration(c.LastMs) * time.Millisecond,
+			Trend:        c.Trend,
+			Regression:   c.Regression,
+			LastBuild:    c.LastBuild,
+		})
+	}
+	for _, st := range analytics.SlowestSteps {
+		vm.SlowestSteps = append(vm.SlowestSteps, BuildStepStatsVM{
+			ProjectName: projectName(st.ProjectID),
+			Component:   st.Component,
+			Name:        st.Name,
+			Runs:        st.Runs,
+			AvgDuration: time.Duration(st.AvgMs) * time.Millisecond,
+			MaxDuration: time.Duration(st.MaxMs) * time.Millisecond,
+		})
+	}
+
+	p.mu.Lock()
+	p.state.Builds.BuildHistory = history
+	p.state.Builds.Analytics = vm
+	p.mu.Unlock()
+}
+
+// ============================================
+// Trash handlers
+// ============================================
+
+// claudeTrashPayload holds what is needed to restore a deleted Claude session
+type claudeTrashPayload struct {
+	SessionFile string `json:"session_file"`
+	CustomName  string `json:"custom_name,omitempty"`
+}
+
+// TrashCockpitProfile is the payload recorded when a cockpit profile is deleted
+type TrashCockpitProfile struct {
+	Name    string                `json:"name"`
+	Profile *config.WidgetProfile `json:"profile"`
+}
+
+// handleTrashPut records an item deleted directly by the UI (config-backed items)
+func (p *AppPresenter) handleTrashPut(event *Event) error {
+	if p.trashService == nil {
+		return fmt.Errorf("trash not available")
+	}
+	kind := trash.Kind(event.Target)
+	label := event.Data["label"]
+
+	var payload interface{}
+	if raw := event.Data["payload"]; raw != "" {
+		payload = json.RawMessage(raw)
+	}
+
+	if _, err := p.trashService.Put(kind, label, payload, nil); err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Failed to keep %s in trash: %v", kind.Label(), err))
+		return err
+	}
+
+	p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("%s '%s' deleted (Ctrl+Z to undo)", kind.Label(), label))
+	p.refreshTrash()
+	p.notifyStateUpdate(VMTrash, p.state.Trash)
+	return nil
+}
+
+// handleTrashRestore restores a trash item (the most recent one if no ID is given)
+func (p *AppPresenter) handleTrashRestore(event *Event) error {
+	if p.trashService == nil {
+		return fmt.Errorf("trash not available")
+	}
+
+	id, _ := event.Value.(string)
+	var item *trash.Item
+	if id == "" {
+		item = p.trashService.Latest()
+	} else {
+		for _, it := range p.trashService.List() {
+			if it.ID == id {
+				it := it
+				item = &it
+				break
+			}
+		}
+	}
+	if item == nil {
+		p.setHeaderEvent(HeaderEventWarning, "Nothing to undo")
+		return nil
+	}
+
+	if err := p.checkTrashRestore(item); err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Cannot restore: %v", err))
+		return err
+	}
+
+	restored, err := p.trashService.Restore(item.ID)
+	if err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Restore failed: %v", err))
+		return err
+	}
+
+	if err := p.applyTrashRestore(restored); err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Restore failed: %v", err))
+		return err
+	}
+
+	p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("%s '%s' restored", restored.Kind.Label(), restored.Label))
+	p.refreshTrash()
+	p.notifyStateUpdate(VMTrash, p.state.Trash)
+	return nil
+}
+
+// checkTrashRestore verifies an item can be restored without overwriting anything
+func (p *AppPresenter) checkTrashRestore(item *trash.Item) error {
+	switch item.Kind {
+	case trash.KindProject:
+		var project projects.Project
+		if err := item.DecodePayload(&project); err != nil {
+			return err
+		}
+		for _, existing := range config.GetGlobal().Projects {
+			if existing.Path == project.Path {
+				return fmt.Errorf("project %s is already configured", project.Path)
+			}
+		}
+	case trash.KindShellSession:
+		var session shell.Session
+		if err := item.DecodePayload(&session); err != nil {
+			return err
+		}
+		if p.shellService.GetSession(session.ID) != nil {
+			return fmt.Errorf("shell session already exists")
+		}
+	case trash.KindCockpitProfile:
+		var payload TrashCockpitProfile
+		if err := item.DecodePayload(&payload); err != nil {
+			return err
+		}
+		if payload.Profile == nil {
+			return fmt.Errorf("profile data missing")
+		}
+	}
+	return nil
+}
+
+// applyTrashRestore rebuilds the state of a restored item
+func (p *AppPresenter) applyTrashRestore(item *trash.Item) error {
+	switch item.Kind {
+	case trash.KindProject:
+		var project projects.Project
+		if err := item.DecodePayload(&project); err != nil {
+			return err
+		}
+		cfg := config.GetGlobal()
+		cfg.Projects = append(cfg.Projects, project)
+		if err := config.SaveGlobal(); err != nil {
+			return err
+		}
+		p.projectService.Load()
+		p.bumpTrashConfigRevision()
+		return p.refreshProjects()
+
+	case trash.KindCockpitProfile:
+		var payload TrashCockpitProfile
+		if err := item.DecodePayload(&payload); err != nil {
+			return err
+		}
+		cfg := config.GetGlobal()
+		if cfg.WidgetProfiles == nil {
+			cfg.WidgetProfiles = make(map[string]*config.WidgetProfile)
+		}
+		name := payload.Name
+		if _, exists := cfg.WidgetProfiles[name]; exists {
+			name += " (restored)"
+		}
+		payload.Profile.Name = name
+		cfg.WidgetProfiles[name] = payload.Profile
+		if err := config.SaveGlobal(); err != nil {
+			return err
+		}
+		p.bumpTrashConfigRevision()
+		return nil
+
+	case trash.KindClaudeSession:
+		var payload claudeTrashPayload
+		if err := item.DecodePayload(&payload); err != nil {
+			return err
+		}
+		if _, err := p.claudeService.RestoreSession(payload.SessionFile, payload.CustomName); err != nil {
+			return err
+		}
+		p.refreshClaude()
+		p.notifyStateUpdate(VMClaude, p.state.Claude)
+		return nil
+
+	case trash.KindShellSession:
+		var session shell.Session
+		if err := item.DecodePayload(&session); err != nil {
+			return err
+		}
+		if err := p.shellService.RestoreSession(&session); err != nil {
+			return err
+		}
+		p.refreshShell()
+		p.notifyStateUpdate(VMShell, p.state.Shell)
+		return nil
+
+	case trash.KindBuildOutput:
+		// Files are moved back by the trash, only the disk usage changes
+		if p.storageService != nil && p.storageService.GetReport() != nil {
+			go p.runStorageScan(false)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown item kind: %s", item.Kind)
+}
+
+// bumpTrashConfigRevision tells UIs that the config file changed and must be reloaded
+func (p *AppPresenter) bumpTrashConfigRevision() {
+	p.mu.Lock()
+	p.state.Trash.ConfigRevision++
+	p.mu.Unlock()
+}
+
+func (p *AppPresenter) handleTrashDelete(event *Event) error {
+	if p.trashService == nil {
+		return fmt.Errorf("trash not available")
+	}
+	id, _ := event.Value.(string)
+	if err := p.trashService.Delete(id); err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Delete failed: %v", err))
+		return err
+	}
+
+	p.setHeaderEvent(HeaderEventSuccess, "Item permanently deleted")
+	p.refreshTrash()
+	p.notifyStateUpdate(VMTrash, p.state.Trash)
+	return nil
+}
+
+func (p *AppPresenter) handleTrashEmpty(event *Event) error {
+	if p.trashService == nil {
+		return fmt.Errorf("trash not available")
+	}
+	count, err := p.trashService.Empty()
+	if err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Empty trash failed: %v", err))
+		return err
+	}
+
+	p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Trash emptied (%d items)", count))
+	p.refreshTrash()
+	p.notifyStateUpdate(VMTrash, p.state.Trash)
+	return nil
+}
+
+// refreshTrash updates the trash view model
+func (p *AppPresenter) refreshTrash() {
+	if p.trashService == nil {
+		return
+	}
+	items := p.trashService.List()
+	retention := p.trashService.Retention()
+
+	itemVMs := make([]TrashItemVM, 0, len(items))
+	for _, item := range items {
+		vm := TrashItemVM{
+			ID:        item.ID,
+			Kind:      string(item.Kind),
+			KindLabel: item.Kind.Label(),
+			Label:     item.Label,
+			DeletedAt: item.DeletedAt,
+			ExpiresAt: item.ExpiresAt(retention),
+		}
+		for _, f := range item.Files {
+			vm.Files = append(vm.Files, f.Original)
+		}
+		itemVMs = append(itemVMs, vm)
+	}
+
+	p.mu.Lock()
+	p.state.Trash.Items = itemVMs
+	p.state.Trash.RetentionDays = int(retention.Hours() / 24)
+	p.state.Trash.UpdatedAt = time.Now()
+	p.mu.Unlock()
+}
+
+// ============================================
+// Audit handlers
+// ============================================
+
+// auditedEvents are the state-changing events recorded in the audit log
+var auditedEvents = map[EventType]bool{
+	EventAddProject:            true,
+	EventRemoveProject:         true,
+	EventStartBuild:            true,
+	EventCancelBuild:           true,
+	EventBuildAll:              true,
+	EventGitStage:              true,
+	EventStartProcess:          true,
+	EventStopProcess:           true,
+	EventRestartProcess:        true,
+	EventKillProcess:           true,
+	EventPauseProcess:          true,
+	EventRunCommand:            true,
+	EventCancelCommand:         true,
+	EventAPIRequest:            true,
+	EventContainerAction:       true,
+	EventServiceAction:         true,
+	EventServicesUp:            true,
+	EventGenerateCert:          true,
+	EventHostsApply:            true,
+	EventProxyToggle:           true,
+	EventCaptureToggle:         true,
+	EventCaptureComponent:      true,
+	EventCaptureClear:          true,
+	EventImageBuild:            true,
+	EventCancelImageBuild:      true,
+	EventSaveConfig:            true,
+	EventReloadConfig:          true,
+	EventClaudeCreateSession:   true,
+	EventClaudeDeleteSession:   true,
+	EventClaudeForkSession:     true,
+	EventClaudeCleanupSessions: true,
+	EventSessionDeleteTask:     true,
+	EventClaudeStopSession:     true,
+	EventClaudeClearHistory:    true,
+	EventDatabaseDeleteSession: true,
+	EventDatabaseStopSession:   true,
+	EventDatabaseMigrate:       true,
+	EventDatabaseReset:         true,
+	EventDatabaseSeed:          true,
+	EventShellDeleteSession:    true,
+	EventShellStopSession:      true,
+	EventStorageClean:          true,
+	EventBackupCreate:          true,
+	EventTransferStart:         true,
+	EventDeploy:                true,
+	EventTrashRestore:          true,
+	EventTrashDelete:           true,
+	EventTrashEmpty:            true,
+	EventPluginAction:          true,
+}
+
+// recordAudit appends an event and its result to the audit log
+func (p *AppPresenter) recordAudit(event *Event, err error) {
+	if p.auditService == nil {
+		return
+	}
+	entry := audit.Entry{
+		Action:    string(event.Type),
+		ProjectID: event.ProjectID,
+		Component: string(event.Component),
+		Target:    event.Target,
+		Details:   auditDetails(event),
+		Origin:    event.Origin,
+		User:      event.User,
+	}
+	if entry.Origin == "" {
+		entry.Origin = audit.OriginRemote
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if err := p.auditService.Record(entry); err != nil {
+		return
+	}
+
+	p.refreshAudit()
+	p.notifyStateUpdate(VMAudit, p.state.Audit)
+}
+
+// auditDetails summarizes the payload of an event (value, then data sorted by key)
+func auditDetails(event *Event) string {
+	var parts []string
+	if event.Value != nil {
+		if s := fmt.Sprint(event.Value); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	keys := make([]string, 0, len(event.Data))
+	for k := range event.Data {
+		// Trash payloads are serialized items, not details
+		if k != "payload" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, k+"="+event.Data[k])
+	}
+	details := strings.Join(parts, " ")
+	if len(details) > 200 {
+		details = details[:200] + "..."
+	}
+	return details
+}
+
+// handleAuditRecord records an action performed by the UI itself (e.g. config edits)
+func (p *AppPresenter) handleAuditRecord(event *Event) error {
+	if event.Target == "" {
+		return fmt.Errorf("audit action required")
+	}
+	p.recordAudit(&Event{
+		Type:      EventType(event.Target),
+		ProjectID: event.ProjectID,
+		Component: event.Component,
+		Value:     event.Value,
+		Data:      event.Data,
+		Origin:    event.Origin,
+		User:      event.User,
+	}, nil)
+	return nil
+}
+
+func (p *AppPresenter) handleAuditExport(event *Event) error {
+	if p.auditService == nil {
+		return fmt.Errorf("audit log is disabled")
+	}
+	path, _ := event.Value.(string)
+	if path == "" {
+		return fmt.Errorf("export path required")
+	}
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+
+	count, err := p.auditService.Export(path, audit.Filter{ProjectID: event.ProjectID})
+	if err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Audit export failed: %v", err))
+		return err
+	}
+	p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Exported %d audit entries to %s", count, path))
+	return nil
+}
+
+// refreshAudit loads the most recent audit entries into the view model
+func (p *AppPresenter) refreshAudit() {
+	const maxShown = 500
+
+	var entries []audit.Entry
+	file := ""
+	if p.auditService != nil {
+		entries = p.auditService.List(audit.Filter{Limit: maxShown})
+		file = p.auditService.File()
+	}
+
+	entryVMs := make([]AuditEntryVM, len(entries))
+	for i, e := range entries {
+		entryVMs[i] = AuditEntryVM{
+			Time:      e.Time,
+			Action:    e.Action,
+			ProjectID: e.ProjectID,
+			Component: e.Component,
+			Target:    e.Target,
+			Details:   e.Details,
+			Origin:    e.Origin,
+			User:      e.User,
+			PID:       e.PID,
+			Error:     e.Error,
+		}
+	}
+
+	p.mu.Lock()
+	p.state.Audit.Entries = entryVMs
+	p.state.Audit.File = file
+	p.state.Audit.Enabled = p.auditService != nil
+	p.state.Audit.UpdatedAt = time.Now()
+	p.mu.Unlock()
+}
+
+// ============================================
+// Plugin handlers
+// ============================================
+
+// pluginProjects returns the projects sent to plugin data providers and actions
+func (p *AppPresenter) pluginProjects() []plugins.ProjectInfo {
+	var result []plugins.ProjectInfo
+	for _, proj := range p.projectService.ListProjects() {
+		result = append(result, plugins.ProjectInfo{ID: proj.ID, Name: proj.Name, Path: proj.Path})
+	}
+	return result
+}
+
+// reloadPlugins rediscovers the plugin executables and fetches their content
+func (p *AppPresenter) reloadPlugins() {
+	if p.pluginService == nil {
+		return
+	}
+	if err := p.pluginService.Discover(p.ctx); err != nil {
+		p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Plugins: %v", err))
+	}
+	p.refreshPlugins()
+	p.notifyStateUpdate(VMPlugins, p.state.Plugins)
+	p.pollPlugins()
+}
+
+// pollPlugins refreshes the plugin views and project data
+func (p *AppPresenter) pollPlugins() {
+	if p.pluginService == nil || len(p.pluginService.GetPlugins()) == 0 {
+		return
+	}
+	p.pluginService.RefreshViews(p.ctx)
+	p.pluginService.RefreshData(p.ctx, p.pluginProjects())
+	p.refreshPlugins()
+	p.notifyStateUpdate(VMPlugins, p.state.Plugins)
+}
+
+func (p *AppPresenter) handlePluginAction(event *Event) error {
+	if p.pluginService == nil {
+		return fmt.Errorf("plugins not available")
+	}
+	name, actionID, ok := strings.Cut(event.Target, "/")
+	if !ok {
+		return fmt.Errorf("invalid plugin action: %s", event.Target)
+	}
+	plugin, ok := p.pluginService.GetPlugin(name)
+	if !ok {
+		return fmt.Errorf("unknown plugin: %s", name)
+	}
+	action, ok := plugin.Action(actionID)
+	if !ok {
+		return fmt.Errorf("plugin %s has no action %s", name, actionID)
+	}
+	if p.pluginService.IsRunning(name, actionID) {
+		return fmt.Errorf("%s is already running", action.Title)
+	}
+
+	var project *plugins.ProjectInfo
+	if action.Project {
+		proj, err := p.projectService.GetProject(event.ProjectID)
+		if err != nil {
+			return err
+		}
+		project = &plugins.ProjectInfo{ID: proj.ID, Name: proj.Name, Path: proj.Path}
+	}
+
+	title := action.Title
+	if title == "" {
+		title = actionID
+	}
+	p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("%s: %s...", plugin.Title(), title))
+
+	go func() {
+		result := p.pluginService.RunAction(p.ctx, name, actionID, project)
+		if result.Error != "" {
+			p.setHeaderEvent(HeaderEventError, fmt.Sprintf("%s failed: %s", title, result.Error))
+			p.notify(NotifyError, "Plugin Action Failed", fmt.Sprintf("%s: %s", title, result.Error))
+		} else {
+			message := result.Message
+			if message == "" {
+				message = title + " done"
+			}
+			p.setHeaderEvent(HeaderEventSuccess, message)
+			p.notify(NotifySuccess, "Plugin Action Done", message)
+		}
+		// Actions usually change what the plugin views display
+		p.pollPlugins()
+	}()
+
+	p.refreshPlugins()
+	p.notifyStateUpdate(VMPlugins, p.state.Plugins)
+	return nil
+}
+
+// refreshPlugins copies the plugins, their cached content and the action
+// results into the view model
+func (p *AppPresenter) refreshPlugins() {
+	if p.pluginService == nil {
+		return
+	}
+
+	var pluginVMs []PluginVM
+	for _, plugin := range p.pluginService.GetPlugins() {
+		vm := PluginVM{
+			Name:        plugin.Name,
+			Title:       plugin.Title(),
+			Description: plugin.Manifest.Description,
+			Path:        plugin.Path,
+			Error:       plugin.Error,
+		}
+		for _, v := range plugin.Manifest.Views {
+			view := PluginViewVM{ID: v.ID, Title: v.Title}
+			if view.Title == "" {
+				view.Title = v.ID
+			}
+			if result, ok := p.pluginService.GetView(plugin.Name, v.ID); ok {
+				view.Items = pluginItemsToVM(result.Items)
+				view.Error = result.Error
+				view.UpdatedAt = result.UpdatedAt
+			}
+			vm.Views = append(vm.Views, view)
+		}
+		for _, a := range plugin.Manifest.Actions {
+			action := PluginActionVM{ID: a.ID, Title: a.Title, Description: a.Description, Project: a.Project, Confirm: a.Confirm}
+			if action.Title == "" {
+				action.Title = a.ID
+			}
+			vm.Actions = append(vm.Actions, action)
+		}
+		for _, provider := range plugin.Manifest.Providers {
+			title := provider.Title
+			if title == "" {
+				title = provider.ID
+			}
+			vm.Providers = append(vm.Providers, title)
+		}
+		pluginVMs = append(pluginVMs, vm)
+	}
+
+	projectData := make(map[string][]PluginDataVM)
+	for _, proj := range p.projectService.ListProjects() {
+		for _, group := range p.pluginService.GetProjectData(proj.ID) {
+			projectData[proj.ID] = append(projectData[proj.ID], PluginDataVM{
+				Plugin: group.Plugin,
+				Title:  group.Title,
+				Items:  pluginItemsToVM(group.Items),
+				Error:  group.Error,
+			})
+		}
+	}
+
+	results := p.pluginService.GetResults()
+	resultVMs := make([]PluginResultVM, 0, len(results))
+	for i := len(results) - 1; i >= 0; i-- {
+		r := results[i]
+		resultVMs = append(resultVMs, PluginResultVM{
+			Plugin:     r.Plugin,
+			Action:     r.Action,
+			Title:      r.Title,
+			ProjectID:  r.ProjectID,
+			Message:    r.Message,
+			Error:      r.Error,
+			FinishedAt: r.FinishedAt,
+		})
+	}
+
+	p.mu.Lock()
+	p.state.Plugins.Dir = p.pluginService.Dir()
+	p.state.Plugins.Plugins = pluginVMs
+	p.state.Plugins.ProjectData = projectData
+	p.state.Plugins.Results = resultVMs
+	p.state.Plugins.Running = p.pluginService.GetRunning()
+	p.state.Plugins.UpdatedAt = time.Now()
+	p.mu.Unlock()
+}
+
+// pluginItemsToVM converts plugin items to view models
+func pluginItemsToVM(items []plugins.Item) []PluginItemVM {
+	result := make([]PluginItemVM, len(items))
+	for i, item := range items {
+		result[i] = PluginItemVM{Label: item.Label, Value: item.Value, Status: item.Status, Detail: item.Detail}
+	}
+	return result
+}
+
+// ============================================
+// Hook handlers
+// ============================================
+
+// hooksFromConfig converts the enabled hooks of the config
+func hooksFromConfig(configs []config.HookConfig) []hooks.Hook {
+	var result []hooks.Hook
+	for _, c := range configs {
+		if c.Disabled || strings.TrimSpace(c.Command) == "" {
+			continue
+		}
+		h := hooks.Hook{
+			Event:     c.Event,
+			Command:   c.Command,
+			Project:   c.Project,
+			Component: c.Component,
+			Threshold: c.Threshold,
+			Timeout:   time.Duration(c.Timeout) * time.Second,
+		}
+		if h.Threshold <= 0 {
+			h.Threshold = 20
+		}
+		if h.Timeout <= 0 {
+			h.Timeout = 60 * time.Second
+		}
+		result = append(result, h)
+	}
+	return result
+}
+
+// hookContext returns the context of a hook event for a project
+func (p *AppPresenter) hookContext(event, projectID, component string, vars map[string]string) hooks.Context {
+	hc := hooks.Context{Event: event, ProjectID: projectID, Component: component, Vars: vars}
+	if proj, err := p.projectService.GetProject(projectID); err == nil {
+		hc.Dir = proj.Path
+	}
+	return hc
+}
+
+// fireBuildHooks runs the on-build-success / on-build-failure hooks of a finished build
+func (p *AppPresenter) fireBuildHooks(event builds.BuildEvent) {
+	if p.hookService == nil {
+		return
+	}
+	build := p.buildOrch.GetBuild(event.BuildID)
+	if build == nil || build.Status == builds.BuildStatusCanceled {
+		return
+	}
+
+	hookEvent := config.HookBuildFailure
+	if build.IsSuccess() {
+		hookEvent = config.HookBuildSuccess
+	}
+	vars := map[string]string{
+		"BUILD_ID":       build.ID,
+		"BUILD_STATUS":   string(build.Status),
+		"BUILD_DURATION": fmt.Sprintf("%.1f", build.Duration.Seconds()),
+		"EXIT_CODE":      strconv.Itoa(build.ExitCode),
+		"ARTIFACT":       build.Artifact,
+	}
+	if len(build.Errors) > 0 {
+		vars["BUILD_ERROR"] = build.Errors[len(build.Errors)-1]
+	}
+	p.hookService.Fire(p.ctx, p.hookContext(hookEvent, event.ProjectID, event.Component, vars), p.onHookOutput, p.onHookDone)
+}
+
+// fireCrashHooks runs the on-process-crash hooks of a crashed process
+func (p *AppPresenter) fireCrashHooks(event processes.ProcessEvent) {
+	if p.hookService == nil {
+		return
+	}
+	vars := map[string]string{
+		"PROCESS_ID":    event.ProcessID,
+		"PROCESS_ERROR": event.Message,
+	}
+	if proc := p.processService.GetProcess(event.ProcessID); proc != nil && proc.ExitCode != nil {
+		vars["EXIT_CODE"] = strconv.Itoa(*proc.ExitCode)
+	}
+	p.hookService.Fire(p.ctx, p.hookContext(config.HookProcessCrash, event.ProjectID, event.Component, vars), p.onHookOutput, p.onHookDone)
+}
+
+// fireGitHooks runs the on-git-dirty-threshold hooks whose threshold the
+// number of changed files just crossed
+func (p *AppPresenter) fireGitHooks(projectID string, status *git.Status) {
+	if p.hookService == nil || !p.hookService.HasHooks(config.HookGitDirtyThreshold) {
+		return
+	}
+	changes := len(status.Staged) + len(status.Modified) + len(status.Untracked) + len(status.Deleted)
+	vars := map[string]string{
+		"GIT_BRANCH":  status.Branch,
+		"GIT_CHANGES": strconv.Itoa(changes),
+	}
+	p.hookService.FireThreshold(p.ctx, p.hookContext(config.HookGitDirtyThreshold, projectID, "", vars), changes, p.onHookOutput, p.onHookDone)
+}
+
+// onHookOutput streams hook output into the logs
+func (p *AppPresenter) onHookOutput(h hooks.Hook, line string, isError bool) {
+	p.appendHookLog(h, line, isError)
+}
+
+// onHookDone reports failed hooks in the logs and the header
+func (p *AppPresenter) onHookDone(result hooks.Result) {
+	if result.Error == "" {
+		return
+	}
+	msg := fmt.Sprintf("Hook %s failed: %s", result.Hook.Event, result.Error)
+	if result.Context.ProjectID != "" {
+		msg = fmt.Sprintf("Hook %s failed for %s: %s", result.Hook.Event, result.Context.ProjectID, result.Error)
+	}
+	p.appendHookLog(result.Hook, msg, true)
+	p.setHeaderEvent(HeaderEventError, msg)
+}
+
+// appendHookLog adds a hook line to the logs
+func (p *AppPresenter) appendHookLog(h hooks.Hook, line string, isError bool) {
+	now := time.Now()
+	logLine := LogLineVM{
+		Timestamp: now,
+		TimeStr:   now.Format("15:04:05"),
+		Source:    "hook:" + h.Event,
+		Level:     "info",
+		Message:   line,
+	}
+	if isError {
+		logLine.Level = "error"
+	}
+
+	p.mu.Lock()
+	p.state.Logs.Append(logLine)
+	p.mu.Unlock()
+
+	p.notifyStateChange(StateUpdate{ViewType: VMLogs, ViewModel: p.state.Logs, Appended: 1})
+}
+
+// ============================================
+// Diagnostics
+// ============================================
+
+// SetLogger sets the logger receiving DevTrack's own diagnostics
+func (p *AppPresenter) SetLogger(l Logger) {
+	p.logger = l
+}
+
+// log returns the diagnostics logger
+func (p *AppPresenter) log() Logger {
+	if p.logger == nil {
+		return nopLogger{}
+	}
+	return p.logger
+}
+
+// BroadcastLog adds a diagnostics line to the logs (logger broadcaster
+// when the presenter runs in the TUI process)
+func (p *AppPresenter) BroadcastLog(line LogLineVM) {
+	p.mu.Lock()
+	p.state.Logs.Append(line)
+	p.mu.Unlock()
+
+	p.notifyStateChange(StateUpdate{ViewType: VMLogs, ViewModel: p.state.Logs, Appended: 1})
+}
+
+// LogsSince returns a copy of the lines appended after line number seq (the
+// kept ones) and the number of the last line, for the log stream
+func (p *AppPresenter) LogsSince(seq int64) ([]LogLineVM, int64) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	logs := p.state.Logs
+	n := int(min(logs.Total-seq, int64(len(logs.Lines))))
+	if n <= 0 {
+		return nil, logs.Total
+	}
+	lines := make([]LogLineVM, n)
+	copy(lines, logs.Lines[len(logs.Lines)-n:])
+	return lines, logs.Total
+}
+
+// nopLogger discards the diagnostics
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+func (nopLogger) SetLevelName(string)          {}
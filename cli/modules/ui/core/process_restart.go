@@ -0,0 +1,67 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"csd-devtrack/cli/modules/core/processes"
+	"csd-devtrack/cli/modules/platform/config"
+)
+
+// maxRestartBackoff is the longest delay before an automatic restart
+const maxRestartBackoff = time.Minute
+
+// restartConfig returns the automatic restart config
+func (p *AppPresenter) restartConfig() *config.RestartConfig {
+	if p.config == nil || p.config.Settings == nil {
+		return config.DefaultRestartConfig()
+	}
+	return p.config.Settings.GetRestartConfig()
+}
+
+// handleProcessCrash restarts a crashed process after a back-off delay
+// (restart setting). A process crashing in a loop is left stopped, marked
+// crash-looping, with a notification: no silent restart storm.
+func (p *AppPresenter) handleProcessCrash(event processes.ProcessEvent) {
+	cfg := p.restartConfig()
+	if !cfg.Enabled {
+		return
+	}
+
+	window := time.Duration(cfg.WindowMinutes) * time.Minute
+	delay, restart := p.processService.RecordCrash(event.ProcessID, processes.RestartPolicy{
+		MaxCrashes: cfg.MaxCrashes,
+		Window:     window,
+		Backoff:    time.Duration(cfg.BackoffSeconds) * time.Second,
+		MaxBackoff: maxRestartBackoff,
+	})
+	if !restart {
+		message := fmt.Sprintf("%s crashed %d times in %d min: no longer restarted", event.ProcessID, cfg.MaxCrashes+1, cfg.WindowMinutes)
+		p.log().Warn("Crash loop: %s", message)
+		p.refreshProcesses()
+		p.setHeaderEvent(HeaderEventError, "Crash loop: "+event.ProcessID)
+		p.notify(NotifyError, "Crash loop", message)
+		return
+	}
+
+	p.log().Info("Restarting %s in %s", event.ProcessID, delay)
+	p.setHeaderEvent(HeaderEventWarning, fmt.Sprintf("%s crashed, restarting in %s", event.ProcessID, delay))
+	go func() {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		// Started or removed by hand meanwhile
+		proc := p.processService.GetProcess(event.ProcessID)
+		if proc == nil || proc.GetState() != processes.ProcessStateCrashed {
+			return
+		}
+		if err := p.processService.AutoRestart(p.ctx, event.ProcessID, p.processMgr); err != nil {
+			p.log().Error("Automatic restart of %s failed: %v", event.ProcessID, err)
+			p.setHeaderEvent(HeaderEventError, fmt.Sprintf("Restart failed: %s", event.ProcessID))
+		}
+		p.refreshProcesses()
+	}()
+}
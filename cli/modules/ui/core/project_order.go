@@ -0,0 +1,213 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/fileutil"
+)
+
+// projectOrderConfig returns the favorites and order of the project trees
+func projectOrderConfig() *config.ProjectOrderConfig {
+	cfg := config.GetGlobal()
+	if cfg == nil || cfg.Settings == nil {
+		return &config.ProjectOrderConfig{}
+	}
+	return cfg.Settings.GetProjectOrderConfig()
+}
+
+// sortProjects orders projects as configured: favorites first, then by name,
+// custom order or most recent use. The caller holds p.mu.
+func (p *AppPresenter) sortProjects(vms []ProjectVM) {
+	order := projectOrderConfig()
+	position := make(map[string]int, len(order.Order))
+	for i, id := range order.Order {
+		position[id] = i
+	}
+	for i := range vms {
+		vms[i].Favorite = order.IsFavorite(vms[i].ID)
+	}
+	p.state.Projects.OrderMode = order.Mode
+	if p.state.Projects.OrderMode == "" {
+		p.state.Projects.OrderMode = config.ProjectOrderName
+	}
+
+	sort.SliceStable(vms, func(i, j int) bool {
+		a, b := vms[i], vms[j]
+		if a.Favorite != b.Favorite {
+			return a.Favorite
+		}
+		switch order.Mode {
+		case config.ProjectOrderCustom:
+			// Projects missing from the order (added since) last, by name
+			pa, oka := position[a.ID]
+			pb, okb := position[b.ID]
+			if oka != okb {
+				return oka
+			}
+			if oka && pa != pb {
+				return pa < pb
+			}
+		case config.ProjectOrderRecent:
+			if ua, ub := p.projectUsage[a.ID], p.projectUsage[b.ID]; !ua.Equal(ub) {
+				return ua.After(ub)
+			}
+		}
+		return a.Name < b.Name
+	})
+}
+
+// resortProjects sorts the projects again after an order change, without
+// reloading them. The slice is replaced: the UI may be reading the current one.
+func (p *AppPresenter) resortProjects() {
+	p.mu.Lock()
+	vms := append([]ProjectVM(nil), p.state.Projects.Projects...)
+	p.sortProjects(vms)
+	p.state.Projects.Projects = vms
+	p.state.Projects.UpdatedAt = time.Now()
+	p.mu.Unlock()
+
+	p.refreshDashboard()
+	p.notifyStateUpdate(VMProjects, p.state.Projects)
+	p.notifyStateUpdate(VMDashboard, p.state.Dashboard)
+}
+
+// saveProjectOrder applies a change to the project order config and saves it
+func (p *AppPresenter) saveProjectOrder(change func(order *config.ProjectOrderConfig)) error {
+	cfg := config.GetGlobal()
+	if cfg.Settings == nil {
+		cfg.Settings = config.DefaultConfig().Settings
+	}
+	order := cfg.Settings.GetProjectOrderConfig()
+	change(order)
+	cfg.Settings.ProjectOrder = order
+	if err := config.SaveGlobal(); err != nil {
+		return err
+	}
+	p.resortProjects()
+	return nil
+}
+
+// handleToggleFavorite pins or unpins a project at the top of the trees
+func (p *AppPresenter) handleToggleFavorite(event *Event) error {
+	if event.ProjectID == "" {
+		return fmt.Errorf("no project selected")
+	}
+	favorite := false
+	err := p.saveProjectOrder(func(order *config.ProjectOrderConfig) {
+		favorites := make([]string, 0, len(order.Favorites)+1)
+		for _, id := range order.Favorites {
+			if id != event.ProjectID {
+				favorites = append(favorites, id)
+			}
+		}
+		if favorite = len(favorites) == len(order.Favorites); favorite {
+			favorites = append(favorites, event.ProjectID)
+		}
+		order.Favorites = favorites
+	})
+	if err != nil {
+		return err
+	}
+	if favorite {
+		p.setHeaderEvent(HeaderEventSuccess, "Pinned "+event.ProjectID+" to the top")
+	} else {
+		p.setHeaderEvent(HeaderEventInfo, "Unpinned "+event.ProjectID)
+	}
+	return nil
+}
+
+// handleMoveProject moves a project up or down among the projects of its
+// group (favorites or others), switching to the custom order
+func (p *AppPresenter) handleMoveProject(event *Event) error {
+	direction, _ := event.Value.(string)
+	step := 1
+	if direction == "up" {
+		step = -1
+	}
+
+	p.mu.RLock()
+	ids := make([]string, len(p.state.Projects.Projects))
+	index := -1
+	for i, proj := range p.state.Projects.Projects {
+		ids[i] = proj.ID
+		if proj.ID == event.ProjectID {
+			index = i
+		}
+	}
+	target := index + step
+	movable := index >= 0 && target >= 0 && target < len(ids) &&
+		p.state.Projects.Projects[index].Favorite == p.state.Projects.Projects[target].Favorite
+	p.mu.RUnlock()
+	if !movable {
+		return nil // Already first or last of its group
+	}
+
+	ids[index], ids[target] = ids[target], ids[index]
+	return p.saveProjectOrder(func(order *config.ProjectOrderConfig) {
+		order.Mode = config.ProjectOrderCustom
+		order.Order = ids
+	})
+}
+
+// handleSetProjectOrder switches the order of the project trees
+func (p *AppPresenter) handleSetProjectOrder(event *Event) error {
+	mode, _ := event.Value.(string)
+	switch mode {
+	case config.ProjectOrderName, config.ProjectOrderCustom, config.ProjectOrderRecent:
+	default:
+		return fmt.Errorf("unknown project order: %s", mode)
+	}
+	err := p.saveProjectOrder(func(order *config.ProjectOrderConfig) {
+		order.Mode = mode
+		if mode == config.ProjectOrderName {
+			order.Mode = "" // Default
+		}
+	})
+	if err != nil {
+		return err
+	}
+	p.setHeaderEvent(HeaderEventInfo, "Projects ordered by "+mode)
+	return nil
+}
+
+// touchProject records the use of a project (an action on it) for the
+// recent order
+func (p *AppPresenter) touchProject(projectID string) {
+	p.mu.Lock()
+	p.projectUsage[projectID] = time.Now()
+	data, err := json.MarshalIndent(p.projectUsage, "", "  ")
+	p.mu.Unlock()
+
+	// Held while writing: concurrent saves share the temporary file
+	p.usageMu.Lock()
+	defer p.usageMu.Unlock()
+	if err == nil && p.usageFile != "" {
+		if err := os.MkdirAll(filepath.Dir(p.usageFile), 0755); err == nil {
+			fileutil.WriteFileAtomic(p.usageFile, data, 0600)
+		}
+	}
+	if projectOrderConfig().Mode == config.ProjectOrderRecent {
+		p.resortProjects()
+	}
+}
+
+// loadProjectUsage reads the last use of each project (none if the file is
+// missing or unreadable)
+func loadProjectUsage(file string) map[string]time.Time {
+	usage := make(map[string]time.Time)
+	if file == "" {
+		return usage
+	}
+	if data, err := os.ReadFile(file); err == nil {
+		if json.Unmarshal(data, &usage) != nil || usage == nil {
+			return make(map[string]time.Time)
+		}
+	}
+	return usage
+}
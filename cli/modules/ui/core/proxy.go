@@ -0,0 +1,164 @@
+package core
+
+import (
+	"fmt"
+	"net/url"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/config"
+)
+
+// proxyConfig returns the reverse proxy config
+func (p *AppPresenter) proxyConfig() *config.ProxyConfig {
+	if p.config == nil || p.config.Settings == nil {
+		return config.DefaultProxyConfig()
+	}
+	return p.config.Settings.GetProxyConfig()
+}
+
+// proxyUpstream returns the URL the proxy forwards to for a component: its
+// port, else the local URL printed by its dev server. The component must be
+// running.
+func (p *AppPresenter) proxyUpstream(projectID, component string) (string, error) {
+	project, err := p.projectService.GetProject(projectID)
+	if err != nil {
+		return "", fmt.Errorf("unknown project %s", projectID)
+	}
+	ct := projects.ComponentType(component)
+	comp := project.GetComponent(ct)
+	if comp == nil {
+		return "", fmt.Errorf("%s has no %s component", projectID, component)
+	}
+	proc := p.processService.GetProcessForComponent(projectID, ct)
+	if proc == nil || !proc.IsRunning() {
+		return "", fmt.Errorf("not running")
+	}
+
+	if comp.Port > 0 {
+		scheme := "http"
+		if comp.TLS != nil && comp.TLS.Cert != "" {
+			scheme = "https"
+		}
+		return fmt.Sprintf("%s://localhost:%d", scheme, comp.Port), nil
+	}
+	if devServer := proc.GetDevServer(); devServer != nil && devServer.LocalURL != "" {
+		if u, err := url.Parse(devServer.LocalURL); err == nil && u.Host != "" {
+			return u.Scheme + "://" + u.Host, nil
+		}
+	}
+	return "", fmt.Errorf("no port: set port: on the component")
+}
+
+// refreshProxy updates the routes and the live status of the reverse proxy
+func (p *AppPresenter) refreshProxy() {
+	if p.proxyServer == nil {
+		return
+	}
+	cfg := p.proxyConfig()
+	vm := &ProxyVM{
+		URL:     p.proxyServer.URL(),
+		Port:    cfg.Port,
+		Enabled: cfg.Enabled,
+		Routes:  p.proxyServer.Statuses(),
+	}
+
+	p.mu.Lock()
+	if previous := p.state.Processes.Proxy; previous != nil && vm.URL == "" {
+		vm.Error = previous.Error // Why it could not start
+	}
+	p.state.Processes.Proxy = vm
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMProcesses, p.state.Processes)
+}
+
+// pollProxy refreshes the live status of the running proxy, at once when
+// requests were forwarded since the last refresh
+func (p *AppPresenter) pollProxy() {
+	if p.proxyDirty.Swap(false) || p.proxyServer.URL() != "" {
+		p.refreshProxy()
+	}
+}
+
+// startProxy starts the reverse proxy with the routes of the config
+func (p *AppPresenter) startProxy() error {
+	err := p.proxyServer.Start(p.proxyConfig())
+
+	p.mu.Lock()
+	if p.state.Processes.Proxy == nil {
+		p.state.Processes.Proxy = &ProxyVM{}
+	}
+	proxyVM := *p.state.Processes.Proxy
+	proxyVM.Error = ""
+	if err != nil {
+		proxyVM.Error = err.Error()
+	}
+	p.state.Processes.Proxy = &proxyVM
+	p.mu.Unlock()
+
+	p.refreshProxy()
+	return err
+}
+
+// handleProxyToggle starts ("start") or stops ("stop") the reverse proxy,
+// or toggles it without a value. The choice is kept in the config for the
+// next launches.
+func (p *AppPresenter) handleProxyToggle(event *Event) error {
+	action, _ := event.Value.(string)
+	running := p.proxyServer.URL() != ""
+	if action == "" {
+		action = "start"
+		if running {
+			action = "stop"
+		}
+	}
+
+	switch action {
+	case "start":
+		if running {
+			return nil
+		}
+		if err := p.startProxy(); err != nil {
+			p.setHeaderEvent(HeaderEventError, err.Error())
+			return nil
+		}
+		cfg := p.proxyConfig()
+		message := fmt.Sprintf("Proxy listening on %s (%d routes)", p.proxyServer.URL(), len(cfg.Routes))
+		if len(cfg.Routes) == 0 {
+			message = fmt.Sprintf("Proxy listening on %s: no routes, add them to settings.proxy.routes", p.proxyServer.URL())
+		}
+		p.setHeaderEvent(HeaderEventSuccess, message)
+	case "stop":
+		if err := p.proxyServer.Stop(); err != nil {
+			p.setHeaderEvent(HeaderEventWarning, fmt.Sprintf("Proxy stopped: %v", err))
+		} else {
+			p.setHeaderEvent(HeaderEventInfo, "Proxy stopped")
+		}
+		p.refreshProxy()
+	default:
+		return fmt.Errorf("unknown proxy action: %s", action)
+	}
+	return p.saveProxyEnabled(action == "start")
+}
+
+// saveProxyEnabled stores whether the proxy starts with DevTrack
+func (p *AppPresenter) saveProxyEnabled(enabled bool) error {
+	cfg := config.GetGlobal()
+	if cfg.Settings == nil {
+		cfg.Settings = config.DefaultConfig().Settings
+	}
+	if cfg.Settings.Proxy == nil {
+		if !enabled {
+			return nil
+		}
+		cfg.Settings.Proxy = &config.ProxyConfig{}
+	}
+	if cfg.Settings.Proxy.Enabled == enabled {
+		return nil
+	}
+	cfg.Settings.Proxy.Enabled = enabled
+	if err := config.SaveGlobal(); err != nil {
+		return err
+	}
+	p.refreshProxy()
+	return nil
+}
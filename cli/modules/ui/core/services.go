@@ -0,0 +1,151 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/containers"
+	"csd-devtrack/cli/modules/platform/services"
+)
+
+// serviceVMs returns the services of the catalog with their state and the
+// components depending on them
+func (p *AppPresenter) serviceVMs(list []containers.Container, listed bool) []ServiceVM {
+	statuses := p.serviceMgr.Statuses(list, listed)
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	dependents := make(map[string][]string)
+	for _, project := range p.projectService.ListProjects() {
+		for _, comp := range project.Components {
+			if comp == nil {
+				continue
+			}
+			for _, name := range comp.Services {
+				dependents[name] = append(dependents[name], project.ID+"/"+string(comp.Type))
+			}
+		}
+	}
+	vms := make([]ServiceVM, len(statuses))
+	for i, st := range statuses {
+		sort.Strings(dependents[st.Name])
+		vms[i] = ServiceVM{Status: st, Dependents: dependents[st.Name]}
+	}
+	return vms
+}
+
+// refreshServices updates the services of the Processes view (list: the
+// containers of the engine, if listed)
+func (p *AppPresenter) refreshServices(list []containers.Container, listed bool) {
+	if p.serviceMgr == nil {
+		return
+	}
+	vms := p.serviceVMs(list, listed)
+
+	p.mu.Lock()
+	p.state.Processes.Services = vms
+	p.mu.Unlock()
+	p.notifyStateUpdate(VMProcesses, p.state.Processes)
+}
+
+// handleServiceAction starts, stops or restarts a service of the catalog in
+// the background; a start waits until the service is ready
+func (p *AppPresenter) handleServiceAction(event *Event) error {
+	action, _ := event.Value.(string)
+	name := event.Target
+	verbs := map[string][2]string{
+		"start":   {"Starting", "ready"},
+		"stop":    {"Stopping", "stopped"},
+		"restart": {"Restarting", "ready"},
+	}
+	verb, known := verbs[action]
+	if !known {
+		return fmt.Errorf("unknown service action: %s", action)
+	}
+	p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("%s service %s...", verb[0], name))
+
+	go func() {
+		start := time.Now()
+		var err error
+		switch action {
+		case "start":
+			err = p.serviceMgr.Start(p.ctx, name)
+		case "stop":
+			err = p.serviceMgr.Stop(p.ctx, name)
+		default:
+			err = p.serviceMgr.Restart(p.ctx, name)
+		}
+		if err != nil {
+			p.setHeaderEvent(HeaderEventError, err.Error())
+		} else {
+			p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Service %s %s (%s)", name, verb[1], time.Since(start).Round(100*time.Millisecond)))
+		}
+	}()
+	return nil
+}
+
+// handleServicesUp starts the services the components of a project depend
+// on, or all the services of the catalog without a project
+func (p *AppPresenter) handleServicesUp(event *Event) error {
+	var names []string
+	if event.ProjectID != "" {
+		project, err := p.projectService.GetProject(event.ProjectID)
+		if err != nil {
+			return fmt.Errorf("project not found: %s", event.ProjectID)
+		}
+		seen := make(map[string]bool)
+		for _, comp := range project.GetEnabledComponents() {
+			for _, name := range comp.Services {
+				if !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			}
+		}
+		if len(names) == 0 {
+			p.setHeaderEvent(HeaderEventWarning, fmt.Sprintf("%s: no component depends on a service", project.Name))
+			return nil
+		}
+	} else {
+		names = p.serviceMgr.Names()
+		if len(names) == 0 {
+			p.setHeaderEvent(HeaderEventWarning, "No services: add them to services: in the configuration")
+			return nil
+		}
+	}
+
+	p.setPersistentHeaderEvent(HeaderEventInfo, fmt.Sprintf("Starting %s...", strings.Join(names, ", ")))
+	go func() {
+		start := time.Now()
+		if err := p.serviceMgr.Ensure(p.ctx, names); err != nil {
+			p.setHeaderEvent(HeaderEventError, err.Error())
+			return
+		}
+		p.setHeaderEvent(HeaderEventSuccess, fmt.Sprintf("Services ready: %s (%s)", strings.Join(names, ", "), time.Since(start).Round(100*time.Millisecond)))
+	}()
+	return nil
+}
+
+// handleServiceLogs streams the logs of a container service into the Logs
+// view; the output of local services is always there
+func (p *AppPresenter) handleServiceLogs(event *Event) error {
+	p.serviceMgr.FollowLogs(p.ctx, event.Target)
+	return nil
+}
+
+// serviceContainer returns true if a container runs a service of the catalog
+func (p *AppPresenter) serviceContainer(c containers.Container) bool {
+	if p.serviceMgr == nil || !strings.HasPrefix(c.Name, services.ContainerPrefix) {
+		return false
+	}
+	name := strings.TrimPrefix(c.Name, services.ContainerPrefix)
+	for _, known := range p.serviceMgr.Names() {
+		if known == name {
+			return true
+		}
+	}
+	return false
+}
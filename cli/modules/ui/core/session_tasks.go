@@ -0,0 +1,153 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"csd-devtrack/cli/modules/platform/sessiontasks"
+)
+
+// sessionTasks builds the tasks view models from the Claude and Codex
+// sessions of the state, with their combined activity (p.mu held)
+func (p *AppPresenter) sessionTasks() []SessionTaskVM {
+	if p.taskService == nil {
+		return nil
+	}
+	tasks := p.taskService.List()
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	members := make(map[sessiontasks.SessionRef]SessionTaskMemberVM)
+	for _, sess := range p.state.Claude.Sessions {
+		members[sessiontasks.SessionRef{Tool: sessiontasks.ToolClaude, ID: sess.ID}] = SessionTaskMemberVM{
+			Tool:         string(sessiontasks.ToolClaude),
+			ID:           sess.ID,
+			Name:         sess.Name,
+			ProjectName:  sess.ProjectName,
+			State:        sess.State,
+			MessageCount: sess.MessageCount,
+			LastActiveAt: sess.LastActiveAt,
+		}
+	}
+	for _, sess := range p.state.Codex.Sessions {
+		members[sessiontasks.SessionRef{Tool: sessiontasks.ToolCodex, ID: sess.ID}] = SessionTaskMemberVM{
+			Tool:         string(sessiontasks.ToolCodex),
+			ID:           sess.ID,
+			Name:         sess.Name,
+			ProjectName:  sess.ProjectName,
+			State:        sess.State,
+			MessageCount: sess.MessageCount,
+			LastActiveAt: sess.LastActiveAt,
+		}
+	}
+
+	// Sessions linked to a task are not grouped by the branch of another
+	linked := make(map[sessiontasks.SessionRef]bool)
+	for _, task := range tasks {
+		for _, ref := range task.Sessions {
+			linked[ref] = true
+		}
+	}
+
+	vms := make([]SessionTaskVM, 0, len(tasks))
+	for _, task := range tasks {
+		vm := SessionTaskVM{ID: task.ID, Name: task.Name, Branch: task.Branch}
+		for _, ref := range task.Sessions {
+			if member, ok := members[ref]; ok { // Deleted sessions are skipped
+				vm.Sessions = append(vm.Sessions, member)
+			}
+		}
+		if task.Branch != "" {
+			for _, sess := range p.state.Claude.Sessions {
+				ref := sessiontasks.SessionRef{Tool: sessiontasks.ToolClaude, ID: sess.ID}
+				if sess.GitBranch != task.Branch || linked[ref] || task.Excludes(ref) ||
+					filepath.Clean(sess.WorkDir) != filepath.Clean(task.WorkDir) {
+					continue
+				}
+				member := members[ref]
+				member.ByBranch = true
+				vm.Sessions = append(vm.Sessions, member)
+			}
+		}
+
+		sort.Slice(vm.Sessions, func(i, j int) bool {
+			return vm.Sessions[i].LastActiveAt.After(vm.Sessions[j].LastActiveAt)
+		})
+		for _, member := range vm.Sessions {
+			vm.MessageCount += member.MessageCount
+			if member.LastActiveAt.After(vm.LastActiveAt) {
+				vm.LastActiveAt = member.LastActiveAt
+			}
+		}
+		vms = append(vms, vm)
+	}
+
+	// Most recently active first
+	sort.SliceStable(vms, func(i, j int) bool {
+		return vms[i].LastActiveAt.After(vms[j].LastActiveAt)
+	})
+	return vms
+}
+
+// handleSessionLinkTask links a Claude or Codex session to a task by name
+// (created if needed), or unlinks it with an empty name. A task named after
+// the git branch of a Claude session also groups the other sessions of the
+// branch.
+func (p *AppPresenter) handleSessionLinkTask(event *Event) error {
+	if p.taskService == nil {
+		return fmt.Errorf("session tasks not available")
+	}
+	ref := sessiontasks.SessionRef{Tool: sessiontasks.Tool(event.Data["tool"]), ID: event.Data["session_id"]}
+	if ref.ID == "" || (ref.Tool != sessiontasks.ToolClaude && ref.Tool != sessiontasks.ToolCodex) {
+		return fmt.Errorf("tool and session ID required")
+	}
+
+	name := event.Data["task"]
+	if name == "" {
+		if err := p.taskService.Unlink(ref); err != nil {
+			p.setHeaderEvent(HeaderEventError, "Unlink failed: "+err.Error())
+			return err
+		}
+		p.setHeaderEvent(HeaderEventSuccess, "Session unlinked from its task")
+	} else {
+		var branch, workDir string
+		if ref.Tool == sessiontasks.ToolClaude {
+			p.mu.RLock()
+			for _, sess := range p.state.Claude.Sessions {
+				if sess.ID == ref.ID && sess.GitBranch == name {
+					branch, workDir = sess.GitBranch, sess.WorkDir
+				}
+			}
+			p.mu.RUnlock()
+		}
+		task, err := p.taskService.Link(name, ref, branch, workDir)
+		if err != nil {
+			p.setHeaderEvent(HeaderEventError, "Link failed: "+err.Error())
+			return err
+		}
+		p.setHeaderEvent(HeaderEventSuccess, "Session linked to task: "+task.Name)
+	}
+
+	p.refreshClaude()
+	return nil
+}
+
+// handleSessionDeleteTask deletes a task, keeping its sessions
+func (p *AppPresenter) handleSessionDeleteTask(event *Event) error {
+	if p.taskService == nil {
+		return fmt.Errorf("session tasks not available")
+	}
+	taskID := event.Data["task_id"]
+	if taskID == "" {
+		return fmt.Errorf("task ID required")
+	}
+	if err := p.taskService.Delete(taskID); err != nil {
+		p.setHeaderEvent(HeaderEventError, "Task deletion failed: "+err.Error())
+		return err
+	}
+	p.setHeaderEvent(HeaderEventSuccess, "Task deleted, its sessions are kept")
+	p.refreshClaude()
+	return nil
+}
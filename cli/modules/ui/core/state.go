@@ -25,6 +25,10 @@ type AppState struct {
 	Cockpit      *CockpitVM
 	Database     *DatabaseVM
 	Shell        *ShellVM
+	Storage      *StorageVM
+	Trash        *TrashVM
+	Audit        *AuditVM
+	Plugins      *PluginsVM
 	Capabilities *CapabilitiesVM
 
 	// Global state
@@ -55,6 +59,10 @@ func NewAppState() *AppState {
 		Cockpit:       &CockpitVM{BaseViewModel: BaseViewModel{VMType: VMCockpit}},
 		Database:      &DatabaseVM{BaseViewModel: BaseViewModel{VMType: VMDatabase}},
 		Shell:         &ShellVM{BaseViewModel: BaseViewModel{VMType: VMShell}},
+		Storage:       &StorageVM{BaseViewModel: BaseViewModel{VMType: VMStorage}},
+		Trash:         &TrashVM{BaseViewModel: BaseViewModel{VMType: VMTrash}},
+		Audit:         &AuditVM{BaseViewModel: BaseViewModel{VMType: VMAudit}},
+		Plugins:       &PluginsVM{BaseViewModel: BaseViewModel{VMType: VMPlugins}},
 		Capabilities:  &CapabilitiesVM{},
 		Notifications: make([]*Notification, 0),
 	}
@@ -90,6 +98,14 @@ func (s *AppState) GetCurrentViewModel() ViewModel {
 		return s.Database
 	case VMShell:
 		return s.Shell
+	case VMStorage:
+		return s.Storage
+	case VMTrash:
+		return s.Trash
+	case VMAudit:
+		return s.Audit
+	case VMPlugins:
+		return s.Plugins
 	default:
 		return s.Dashboard
 	}
@@ -132,6 +148,14 @@ func (s *AppState) UpdateViewModel(vm ViewModel) {
 		s.Database = v
 	case *ShellVM:
 		s.Shell = v
+	case *StorageVM:
+		s.Storage = v
+	case *TrashVM:
+		s.Trash = v
+	case *AuditVM:
+		s.Audit = v
+	case *PluginsVM:
+		s.Plugins = v
 	}
 }
 
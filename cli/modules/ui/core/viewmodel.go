@@ -6,7 +6,14 @@ import (
 	"csd-devtrack/cli/modules/core/builds"
 	"csd-devtrack/cli/modules/core/processes"
 	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/apiexplorer"
+	"csd-devtrack/cli/modules/platform/capture"
+	"csd-devtrack/cli/modules/platform/certs"
+	"csd-devtrack/cli/modules/platform/containers"
 	"csd-devtrack/cli/modules/platform/git"
+	"csd-devtrack/cli/modules/platform/migrations"
+	"csd-devtrack/cli/modules/platform/proxy"
+	"csd-devtrack/cli/modules/platform/services"
 )
 
 // ViewModelType identifies the type of view model
@@ -25,6 +32,11 @@ const (
 	VMCockpit   ViewModelType = "cockpit"
 	VMDatabase  ViewModelType = "database"
 	VMShell     ViewModelType = "shell"
+	VMStorage   ViewModelType = "storage"
+	VMTrash     ViewModelType = "trash"
+	VMAudit     ViewModelType = "audit"
+	VMPlugins   ViewModelType = "plugins"
+	VMInternals ViewModelType = "internals"
 )
 
 // ViewModel is the base interface for all view models
@@ -59,6 +71,9 @@ type ProjectVM struct {
 	RunningCount   int                 `json:"running_count"`
 	LastBuildTime  *time.Time          `json:"last_build_time,omitempty"`
 	LastBuildOK    bool                `json:"last_build_ok"`
+	DeployTargets  []string            `json:"deploy_targets,omitempty"` // Deploy target (environment) names
+	Favorite       bool                `json:"favorite,omitempty"`       // Pinned to the top of the trees
+	HasImage       bool                `json:"has_image,omitempty"`      // Dockerfile found: the image can be built
 }
 
 // ComponentVM represents a component for display
@@ -72,6 +87,19 @@ type ComponentVM struct {
 	PID         int                    `json:"pid,omitempty"`
 	Uptime      string                 `json:"uptime,omitempty"`
 	LastBuildOK bool                   `json:"last_build_ok"`
+	BuildCmd    string                 `json:"build_cmd,omitempty"` // Configured build command override
+	RunCmd      string                 `json:"run_cmd,omitempty"`   // Configured run command override
+	HasAPI      bool                   `json:"has_api,omitempty"`   // API descriptor configured (API explorer)
+	Capture     bool                   `json:"capture,omitempty"`   // Outbound requests recorded by the capture proxy
+
+	// Local TLS certificate, nil without tls: in the component config
+	Certificate *CertificateVM `json:"certificate,omitempty"`
+}
+
+// CertificateVM is the local TLS certificate of a component
+type CertificateVM struct {
+	certs.Certificate
+	Error string `json:"error,omitempty"` // Not generated yet, or unreadable
 }
 
 // ProcessVM represents a process for display
@@ -87,6 +115,13 @@ type ProcessVM struct {
 	LastError   string                 `json:"last_error,omitempty"`
 	LogLines    []string               `json:"log_lines,omitempty"`
 	IsSelf      bool                   `json:"is_self,omitempty"` // Is this csd-devtrack itself?
+	ExitCode    *int                   `json:"exit_code,omitempty"`
+	CrashLoop   bool                   `json:"crash_loop,omitempty"`  // Crashed too often: no longer restarted
+	StderrTail  []string               `json:"stderr_tail,omitempty"` // Last stderr lines of a crashed process
+	DevServer   *processes.DevServer   `json:"dev_server,omitempty"` // URLs, HMR status and compile errors of a frontend dev server
+
+	// How it ended the last times (exit code, signal, OOM kill), newest first
+	Terminations []processes.Termination `json:"terminations,omitempty"`
 }
 
 // BuildVM represents a build for display
@@ -103,6 +138,67 @@ type BuildVM struct {
 	Errors      []string               `json:"errors"`
 	Warnings    []string               `json:"warnings"`
 	Artifact    string                 `json:"artifact,omitempty"`
+	Profile     string                 `json:"profile,omitempty"`
+	GitHash     string                 `json:"git_hash,omitempty"`
+}
+
+// BuildAnalyticsVM summarizes the persisted build history
+type BuildAnalyticsVM struct {
+	Builds       int                     `json:"builds"`
+	Failures     int                     `json:"failures"`
+	Since        time.Time               `json:"since"`
+	Components   []BuildComponentStatsVM `json:"components"`
+	SlowestSteps []BuildStepStatsVM      `json:"slowest_steps"`
+	File         string                  `json:"file"`
+}
+
+// BuildComponentStatsVM is the build statistics of one project component
+
+type BuildComponentStatsVM struct {
+	ProjectID    string        `json:"project_id"`
+	ProjectName  string        `json:"project_name"`
+	Component    string        `json:"component"`
+	Builds       int           `json:"builds"`
+	Failures     int           `json:"failures"`
+	FailureRate  float64       `json:"failure_rate"` // 0-1
+	AvgDuration  time.Duration `json:"avg_duration"`
+	LastDuration time.Duration `json:"last_duration"`
+	Trend        []int64       `json:"trend"`      // Latest successful build durations (ms), oldest first
+	Regression   float64       `json:"regression"` // Last build vs the previous ones (0.3 = 30% slower)
+	LastBuild    time.Time     `json:"last_build"`
+}
+
+// BuildStepStatsVM is the timing of one build step
+type BuildStepStatsVM struct {
+	ProjectName string        `json:"project_name"`
+	Component   string        `json:"component"`
+	Name        string        `json:"name"`
+	Runs        int           `json:"runs"`
+	AvgDuration time.Duration `json:"avg_duration"`
+	MaxDuration time.Duration `json:"max_duration"`
+}
+
+// VulnerabilityVM represents a vulnerability finding for display
+type VulnerabilityVM struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title"`
+	Severity     string   `json:"severity"` // critical, high, moderate, low, info
+	Package      string   `json:"package"`
+	Version      string   `json:"version,omitempty"`
+	FixedVersion string   `json:"fixed_version,omitempty"`
+	Paths        []string `json:"paths,omitempty"`
+	URL          string   `json:"url,omitempty"`
+}
+
+// SecurityScanVM represents the latest vulnerability scan of a component
+type SecurityScanVM struct {
+	ProjectID   string                 `json:"project_id"`
+	ProjectName string                 `json:"project_name"`
+	Component   projects.ComponentType `json:"component"`
+	Scanner     string                 `json:"scanner"` // govulncheck, npm-audit
+	ScannedAt   time.Time              `json:"scanned_at"`
+	Error       string                 `json:"error,omitempty"`
+	Findings    []VulnerabilityVM      `json:"findings"`
 }
 
 // GitStatusVM represents git status for display
@@ -117,6 +213,26 @@ type GitStatusVM struct {
 	Modified    []string `json:"modified"`
 	Untracked   []string `json:"untracked"`
 	Deleted     []string `json:"deleted"`
+
+	AIEdits map[string][]AIEditVM `json:"ai_edits,omitempty"` // Changed files written by Claude sessions
+
+	Signing        string   `json:"signing,omitempty"`         // Commit signing configuration ("off", "ssh (commits) key ...")
+	SigningWarning string   `json:"signing_warning,omitempty"` // Why signing would fail or block (no agent, no GPG_TTY)
+	Hooks          []string `json:"hooks,omitempty"`           // Commit and push hooks installed (pre-commit, pre-push...)
+	LargeRepo      bool     `json:"large_repo,omitempty"`      // Large repository mode: untracked files not listed
+
+	// Changed files matching the git_hide patterns of the project, left out
+	// of the lists above and of the change counts
+	Hidden       *git.Status `json:"hidden,omitempty"`
+	HidePatterns []string    `json:"hide_patterns,omitempty"`
+}
+
+// AIEditVM is the last change of a file by a Claude session
+type AIEditVM struct {
+	SessionID   string    `json:"session_id"`
+	SessionName string    `json:"session_name"`
+	Tool        string    `json:"tool"`
+	Time        time.Time `json:"time"`
 }
 
 // CommitVM represents a commit for display
@@ -127,6 +243,7 @@ type CommitVM struct {
 	Date      time.Time `json:"date"`
 	DateStr   string    `json:"date_str"`
 	Subject   string    `json:"subject"`
+	Signature string    `json:"signature,omitempty"` // Signature status of git log %G? (G good, N none...)
 }
 
 // LogLineVM represents a log line for display
@@ -136,6 +253,8 @@ type LogLineVM struct {
 	Source    string    `json:"source"` // project/component
 	Level     string    `json:"level"`  // info, warn, error
 	Message   string    `json:"message"`
+	EmittedAt time.Time `json:"emitted_at,omitempty"` // Timestamp printed by the process (zero if none)
+	StampLen  int       `json:"stamp_len,omitempty"`  // Length of that timestamp at the start of Message
 }
 
 // ============================================
@@ -149,10 +268,16 @@ type DashboardVM struct {
 	RunningCount    int          `json:"running_count"`
 	BuildingCount   int          `json:"building_count"`
 	ErrorCount      int          `json:"error_count"`
+	VulnCount       int          `json:"vuln_count"`    // Vulnerabilities from the latest scans
+	VulnSeverity    string       `json:"vuln_severity"` // Highest severity found (empty = none)
+	DiskUsage       string       `json:"disk_usage"`    // Total reclaimable disk usage (empty = not scanned)
 	Projects        []ProjectVM  `json:"projects"`
 	RecentBuilds    []BuildVM    `json:"recent_builds"`
 	RunningProcesses []ProcessVM `json:"running_processes"`
 	GitSummary      []GitStatusVM `json:"git_summary"`
+
+	// Local TLS certificates expired, expiring soon or missing
+	CertWarnings []string `json:"cert_warnings,omitempty"`
 }
 
 // ProjectsVM is the view model for the projects list
@@ -161,6 +286,127 @@ type ProjectsVM struct {
 	Projects       []ProjectVM `json:"projects"`
 	SelectedIndex  int         `json:"selected_index"`
 	FilterText     string      `json:"filter_text"`
+	OrderMode      string      `json:"order_mode"` // name, custom, recent (favorites always first)
+	Transfers      []TransferVM `json:"transfers"` // Recent file transfers, most recent last
+	Deployments    []DeploymentVM `json:"deployments"` // Deploy history, most recent last
+	DryRun         *DryRunVM      `json:"dry_run,omitempty"` // Last dry run of a build, run or stop
+	CommandRuns    []CommandRunVM `json:"command_runs"` // Ad-hoc commands, most recent last
+	APIExplorer    *APIExplorerVM `json:"api_explorer,omitempty"` // API explorer of a component
+	Hosts          *HostsVM       `json:"hosts,omitempty"`        // Dev hostnames and the hosts file
+}
+
+// HostsVM is the dev hostnames panel: the hostnames of the components, how
+// they resolve and the block of the hosts file managed by DevTrack
+type HostsVM struct {
+	Path     string          `json:"path"`             // Hosts file
+	Mappings []HostMappingVM `json:"mappings"`         // Sorted by hostname
+	Block    string          `json:"block"`            // Managed block of the hostnames (empty = none to map)
+	UpToDate bool            `json:"up_to_date"`       // The hosts file has this block
+	Writable bool            `json:"writable"`         // Writable without sudo
+	Sudo     string          `json:"sudo,omitempty"`   // sudo path (empty = unavailable)
+	Staged   string          `json:"staged,omitempty"` // Updated hosts file waiting for "sudo cp" (sudo asked a password)
+	Checking bool            `json:"checking"`         // Resolving the hostnames
+	Error    string          `json:"error,omitempty"`
+}
+
+// HostMappingVM is a dev hostname of a component
+type HostMappingVM struct {
+	Host     string `json:"host"`
+	Target   string `json:"target"`        // project/component
+	URL      string `json:"url,omitempty"` // On the component port
+	Wildcard bool   `json:"wildcard"`      // *.domain: needs a local DNS (dnsmasq)
+	Builtin  bool   `json:"builtin"`       // *.localhost: resolved without any entry
+	Managed  bool   `json:"managed"`       // In the managed block of the hosts file
+	Resolves bool   `json:"resolves"`      // Resolves to the loopback
+}
+
+// APIExplorerVM lists the endpoints of a component's API and the last test
+// request fired at it
+type APIExplorerVM struct {
+	ProjectID  string                 `json:"project_id"`
+	Component  projects.ComponentType `json:"component"`
+	Kind       apiexplorer.Kind       `json:"kind"`
+	Descriptor string                 `json:"descriptor"`
+	Target     string                 `json:"target"` // HTTP base URL, or host:port of the gRPC server
+	Endpoints  []apiexplorer.Endpoint `json:"endpoints"`
+	Error      string                 `json:"error,omitempty"` // Descriptor or config error
+	Sending    bool                   `json:"sending"`
+	Request    string                 `json:"request,omitempty"`  // "POST /users" of the last request
+	Response   *apiexplorer.Response  `json:"response,omitempty"` // Nil while sending
+}
+
+// DryRunVM is what a build, run or stop action would execute, without
+// executing it
+type DryRunVM struct {
+	Action    string            `json:"action"` // build, run or stop
+	ProjectID string            `json:"project_id"`
+	Component string            `json:"component,omitempty"` // Empty = all components (build)
+	Commands  []DryRunCommandVM `json:"commands"`
+	Hooks     []DryRunHookVM    `json:"hooks,omitempty"`
+	Notes     []string          `json:"notes,omitempty"` // e.g. automatic restart on crash
+	Error     string            `json:"error,omitempty"` // Why the action would fail before running anything
+}
+
+// DryRunCommandVM is a command an action would execute
+type DryRunCommandVM struct {
+	Step    string   `json:"step"`
+	Command string   `json:"command"` // Shell-quoted command line
+	Dir     string   `json:"dir,omitempty"`
+	Env     []string `json:"env,omitempty"` // Set on top of DevTrack's environment
+	Warning string   `json:"warning,omitempty"`
+}
+
+// DryRunHookVM is a hook an action would fire
+type DryRunHookVM struct {
+	Event   string `json:"event"`
+	Command string `json:"command"`
+	Dir     string `json:"dir"`
+}
+
+// DeploymentVM represents a run of a project deploy target
+type DeploymentVM struct {
+	ID         string    `json:"id"`
+	ProjectID  string    `json:"project_id"`
+	Target     string    `json:"target"`
+	Command    string    `json:"command"`
+	State      string    `json:"state"` // running, success, failed
+	ExitCode   int       `json:"exit_code"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+}
+
+// CommandRunVM represents an ad-hoc command run in a project or component
+// directory
+type CommandRunVM struct {
+	ID         string    `json:"id"`
+	ProjectID  string    `json:"project_id"`
+	Component  string    `json:"component,omitempty"` // Empty = project directory
+	Command    string    `json:"command"`
+	Dir        string    `json:"dir"`
+	State      string    `json:"state"` // running, success, failed, canceled
+	ExitCode   int       `json:"exit_code"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+}
+
+// TransferVM represents a file transfer between a project and a remote host
+type TransferVM struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"project_id"`
+	Direction   string    `json:"direction"` // push, pull
+	HostName    string    `json:"host_name"`
+	Source      string    `json:"source"`
+	Destination string    `json:"destination"`
+	Tool        string    `json:"tool"`  // rsync, scp
+	State       string    `json:"state"` // running, done, failed
+	Percent     int       `json:"percent"` // -1 = unknown
+	Transferred string    `json:"transferred,omitempty"`
+	Speed       string    `json:"speed,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+	FinishedAt  time.Time `json:"finished_at,omitempty"`
 }
 
 // BuildsVM is the view model for the build view
@@ -170,8 +416,26 @@ type BuildsVM struct {
 	SelectedProject string     `json:"selected_project"`
 	SelectedComponents []projects.ComponentType `json:"selected_components"`
 	CurrentBuild   *BuildVM    `json:"current_build,omitempty"`
-	BuildHistory   []BuildVM   `json:"build_history"`
+	BuildHistory   []BuildVM   `json:"build_history"` // Persisted builds, most recent first
+	Analytics      *BuildAnalyticsVM `json:"analytics,omitempty"` // Nil until the history is loaded
 	IsBuilding     bool        `json:"is_building"`
+
+	// Security scans (govulncheck, npm audit)
+	SecurityScans    []SecurityScanVM `json:"security_scans"`
+	SecurityScanning bool             `json:"security_scanning"`
+
+	// Container image builds (docker or podman build)
+	ImageBuild   *ImageBuildVM  `json:"image_build,omitempty"` // Running or last image build
+	ImageHistory []ImageBuildVM `json:"image_history"`         // Most recent first
+}
+
+// ImageBuildVM is a container image build of a project, its steps (layers)
+// parsed from the build output
+type ImageBuildVM struct {
+	containers.ImageBuild
+	ProjectName string   `json:"project_name"`
+	Output      []string `json:"output,omitempty"`     // Last output lines (running or last build)
+	SizeDelta   int64    `json:"size_delta,omitempty"` // Size change since the previous build of the project and profile
 }
 
 // ProcessesVM is the view model for the processes view
@@ -180,6 +444,69 @@ type ProcessesVM struct {
 	Processes      []ProcessVM `json:"processes"`
 	SelectedIndex  int         `json:"selected_index"`
 	FilterProject  string      `json:"filter_project"`
+
+	// Containers of the projects (Docker or Podman), empty without an engine
+	Containers      []ContainerVM `json:"containers,omitempty"`
+	ContainerEngine string        `json:"container_engine,omitempty"` // docker or podman
+	ContainersError string        `json:"containers_error,omitempty"` // Engine unreachable (daemon down)
+
+	// Services of the catalog (postgres, redis...) the components depend on
+	Services []ServiceVM `json:"services,omitempty"`
+
+	// Built-in reverse proxy (nil until initialized)
+	Proxy *ProxyVM `json:"proxy,omitempty"`
+
+	// Capture proxy and the requests it recorded (nil until initialized)
+	Capture *CaptureVM `json:"capture,omitempty"`
+}
+
+// ProxyVM is the built-in reverse proxy: its entrypoint and the live status
+// of its routes
+type ProxyVM struct {
+	URL     string              `json:"url,omitempty"`   // Entrypoint, empty when stopped
+	Port    int                 `json:"port"`            // Configured port
+	Enabled bool                `json:"enabled"`         // Started with DevTrack
+	Routes  []proxy.RouteStatus `json:"routes"`          // Routes of the running proxy
+	Error   string              `json:"error,omitempty"` // Why it could not start
+}
+
+// CaptureVM is the capture proxy: the outbound requests recorded from the
+// components marked capture: true
+type CaptureVM struct {
+	URL          string          `json:"url,omitempty"`     // Proxy address, empty when stopped
+	Port         int             `json:"port"`              // Configured port
+	Enabled      bool            `json:"enabled"`           // Started with DevTrack
+	InterceptTLS bool            `json:"intercept_tls"`     // HTTPS decrypted with the local CA
+	CAFile       string          `json:"ca_file,omitempty"` // CA the components trust
+	Components   []string        `json:"components,omitempty"`
+	Entries      []capture.Entry `json:"entries"`          // Summaries, oldest first
+	Detail       *capture.Entry  `json:"detail,omitempty"` // Request opened with its headers and bodies
+	Error        string          `json:"error,omitempty"`  // Why it could not start
+}
+
+// ContainerVM is a container matched to a project: by its compose project
+// (label or working directory) or its image name
+type ContainerVM struct {
+	containers.Container
+	ProjectID   string `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	Following   bool   `json:"following,omitempty"` // Logs streamed to the Logs view
+}
+
+// LogsSource returns the Logs view source of the container's logs
+func (c ContainerVM) LogsSource() string {
+	return "docker:" + c.Name
+}
+
+// ServiceVM is a service of the catalog with the components depending on it
+type ServiceVM struct {
+	services.Status
+	Dependents []string `json:"dependents,omitempty"` // "project/component"
+}
+
+// LogsSource returns the Logs view source of the service's output
+func (s ServiceVM) LogsSource() string {
+	return "service:" + s.Name
 }
 
 // LogsVM is the view model for the logs view
@@ -191,6 +518,19 @@ type LogsVM struct {
 	FilterLevel    string      `json:"filter_level"`
 	AutoScroll     bool        `json:"auto_scroll"`
 	MaxLines       int         `json:"max_lines"`
+	ErrorGroups    []LogErrorGroupVM `json:"error_groups,omitempty"` // Repeated errors, most recently seen first
+	Total          int64             `json:"-"`                      // Lines appended since the start (the last line is number Total)
+}
+
+// LogErrorGroupVM aggregates the error lines of a source with the same
+// message once numbers and identifiers are masked
+type LogErrorGroupVM struct {
+	Source    string    `json:"source"`
+	Pattern   string    `json:"pattern"` // Normalized message
+	Message   string    `json:"message"` // Latest message of the group
+	Count     int       `json:"count"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
 }
 
 // GitVM is the view model for the git view
@@ -227,6 +567,8 @@ type ClaudeSessionVM struct {
 	LastActiveAt    time.Time `json:"last_active_at"`    // Raw time for relative calculation
 	IsActive     bool `json:"is_active"`     // Currently selected session
 	IsPersistent bool `json:"is_persistent"` // Has active persistent process (fast mode)
+	ParentID     string `json:"parent_id,omitempty"` // Session it was forked from
+	GitBranch    string `json:"git_branch,omitempty"` // Git branch the session started on
 }
 
 // ClaudeMessageVM represents a message for display
@@ -287,7 +629,8 @@ type ClaudeVM struct {
 	ActiveSession          *ClaudeSessionVM  `json:"active_session,omitempty"`
 	NewlyCreatedSessionID        string `json:"newly_created_session_id,omitempty"`         // Set when a new session is created
 	NewlyCreatedSessionProjectID string `json:"newly_created_session_project_id,omitempty"` // Project ID of newly created session
-	Messages               []ClaudeMessageVM `json:"messages,omitempty"`
+	Messages               []ClaudeMessageVM `json:"messages,omitempty"` // Last messages of the active session
+	OlderMessages          int               `json:"older_messages"`     // Messages before the loaded ones (see EventClaudeLoadOlder)
 	InputText              string            `json:"input_text"`      // Current input being typed
 	IsTyping               bool              `json:"is_typing"`       // User is typing
 	IsProcessing           bool              `json:"is_processing"`   // Claude is processing
@@ -304,6 +647,44 @@ type ClaudeVM struct {
 
 	// Usage stats (for current session)
 	Usage           *ClaudeUsageVM    `json:"usage,omitempty"`
+
+	// Stale sessions a cleanup would remove (nil = no preview requested)
+	Cleanup *ClaudeCleanupVM `json:"cleanup,omitempty"`
+
+	// Tasks grouping Claude and Codex sessions
+	Tasks []SessionTaskVM `json:"tasks,omitempty"`
+}
+
+// SessionTaskVM is a task grouping sessions of different AI tools, with
+// their combined activity
+type SessionTaskVM struct {
+	ID           string                `json:"id"`
+	Name         string                `json:"name"`
+	Branch       string                `json:"branch,omitempty"`
+	Sessions     []SessionTaskMemberVM `json:"sessions"` // Most recent first
+	MessageCount int                   `json:"message_count"`
+	LastActiveAt time.Time             `json:"last_active_at"`
+}
+
+// SessionTaskMemberVM is a session of a task
+type SessionTaskMemberVM struct {
+	Tool         string    `json:"tool"` // claude, codex
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	ProjectName  string    `json:"project_name"`
+	State        string    `json:"state"`
+	MessageCount int       `json:"message_count"`
+	LastActiveAt time.Time `json:"last_active_at"`
+	ByBranch     bool      `json:"by_branch,omitempty"` // Grouped by the task branch, not linked
+}
+
+// ClaudeCleanupVM is the dry run of a session cleanup: the policy and the
+// sessions it would remove
+type ClaudeCleanupVM struct {
+	MaxAgeDays   int               `json:"max_age_days"`
+	MinMessages  int               `json:"min_messages"`
+	IncludeNamed bool              `json:"include_named"`
+	Sessions     []ClaudeSessionVM `json:"sessions"`
 }
 
 // CodexSessionVM represents a Codex session for display
@@ -344,6 +725,10 @@ type DatabaseInfoVM struct {
 	Port         int    `json:"port"`
 	User         string `json:"user"`
 	URL          string `json:"url"` // Full connection URL for CLI
+
+	// Project commands (database.reset, database.seed), empty if not configured
+	ResetCommand string `json:"reset_command,omitempty"`
+	SeedCommand  string `json:"seed_command,omitempty"`
 }
 
 // DatabaseSessionVM represents a database session for display
@@ -367,10 +752,59 @@ type DatabaseSessionVM struct {
 type DatabaseVM struct {
 	BaseViewModel
 	Databases         []DatabaseInfoVM    `json:"databases"`
+	Loaded            bool                `json:"loaded"` // Databases detected (on first open of the view)
 	Sessions          []DatabaseSessionVM `json:"sessions"`
 	ActiveSessionID   string              `json:"active_session_id,omitempty"`
 	ActiveSession     *DatabaseSessionVM  `json:"active_session,omitempty"`
 	FilterProject     string              `json:"filter_project"`
+
+	// Migrations of the database open in the migrations panel
+	Migrations *MigrationsVM `json:"migrations,omitempty"`
+	// Reset or seed command, running or last run
+	Task *DatabaseTaskVM `json:"task,omitempty"`
+}
+
+// DatabaseTaskVM is a reset or seed command of a database, running or last
+// run, with its output
+type DatabaseTaskVM struct {
+	RunID        string    `json:"run_id"`
+	DatabaseID   string    `json:"database_id"`
+	DatabaseName string    `json:"database_name"`
+	ProjectID    string    `json:"project_id"`
+	Action       string    `json:"action"` // reset or seed
+	Command      string    `json:"command"`
+	Dir          string    `json:"dir"`
+	State        string    `json:"state"` // running, success, failed, canceled
+	ExitCode     int       `json:"exit_code"`
+	Error        string    `json:"error,omitempty"`
+	Output       []string  `json:"output,omitempty"` // Last lines
+	StartedAt    time.Time `json:"started_at"`
+	FinishedAt   time.Time `json:"finished_at,omitempty"`
+}
+
+// MigrationsVM is the migration state of a database: the migration setups
+// detected in its project, the status of the selected one and the output of
+// the running or last action
+type MigrationsVM struct {
+	DatabaseID   string             `json:"database_id"`
+	DatabaseName string             `json:"database_name"`
+	ProjectName  string             `json:"project_name"`
+	Setups       []migrations.Setup `json:"setups"`
+	Setup        int                `json:"setup"`            // Index of the selected setup
+	Status       *migrations.Status `json:"status,omitempty"` // Nil while checking
+	Checking     bool               `json:"checking"`
+	Running      migrations.Action  `json:"running,omitempty"` // Action in progress
+	Output       []string           `json:"output,omitempty"`  // Output of the running action
+	Result       *migrations.Result `json:"result,omitempty"`  // Last action
+	Error        string             `json:"error,omitempty"`   // No setup, database not found...
+}
+
+// SelectedSetup returns the selected migration setup, nil if none
+func (m *MigrationsVM) SelectedSetup() *migrations.Setup {
+	if m == nil || m.Setup < 0 || m.Setup >= len(m.Setups) {
+		return nil
+	}
+	return &m.Setups[m.Setup]
 }
 
 // ShellSessionType represents the type of shell session
@@ -415,6 +849,154 @@ type ShellVM struct {
 	ActiveSessionID string           `json:"active_session_id,omitempty"`
 	ActiveSession   *ShellSessionVM  `json:"active_session,omitempty"`
 	FilterProject   string           `json:"filter_project"`
+	SSHHosts        []SSHHostVM      `json:"ssh_hosts"` // Remote shell targets (config + ~/.ssh/config)
+}
+
+// SSHHostVM represents a remote shell target
+type SSHHostVM struct {
+	Name   string   `json:"name"`
+	Group  string   `json:"group"`
+	Target string   `json:"target"` // user@host:port
+	Source string   `json:"source"` // "config" or "ssh_config"
+	Args   []string `json:"args"`   // ssh arguments
+}
+
+// StorageEntryVM represents disk usage of one category
+type StorageEntryVM struct {
+	Category string   `json:"category"` // artifacts, node_modules, go_cache, logs
+	Label    string   `json:"label"`
+	Bytes    int64    `json:"bytes"`
+	Size     string   `json:"size"` // Human-readable size
+	Files    int      `json:"files"`
+	Paths    []string `json:"paths,omitempty"`
+}
+
+// StorageProjectVM represents disk usage of a project
+type StorageProjectVM struct {
+	ProjectID   string           `json:"project_id"`
+	ProjectName string           `json:"project_name"`
+	Entries     []StorageEntryVM `json:"entries"`
+	TotalBytes  int64            `json:"total_bytes"`
+	TotalSize   string           `json:"total_size"`
+}
+
+// StorageVM is the view model for the storage view
+type StorageVM struct {
+	BaseViewModel
+	Projects   []StorageProjectVM `json:"projects"`
+	Shared     []StorageEntryVM   `json:"shared"` // Go build cache, DevTrack logs
+	TotalBytes int64              `json:"total_bytes"`
+	TotalSize  string             `json:"total_size"`
+	Scanning   bool               `json:"scanning"`
+	ScannedAt  time.Time          `json:"scanned_at"`
+}
+
+// TrashItemVM represents a recoverable deleted item
+type TrashItemVM struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"` // project, claude_session, cockpit_profile, shell_session
+	KindLabel string    `json:"kind_label"`
+	Label     string    `json:"label"`
+	Files     []string  `json:"files,omitempty"` // Original paths of kept files
+	DeletedAt time.Time `json:"deleted_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TrashVM is the view model for the trash view
+type TrashVM struct {
+	BaseViewModel
+	Items          []TrashItemVM `json:"items"` // Most recent first
+	RetentionDays  int           `json:"retention_days"`
+	ConfigRevision int           `json:"config_revision"` // Bumped when a restore modified the config file
+}
+
+// AuditEntryVM represents a recorded state-changing action
+type AuditEntryVM struct {
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"`
+	ProjectID string    `json:"project_id,omitempty"`
+	Component string    `json:"component,omitempty"`
+	Target    string    `json:"target,omitempty"`
+	Details   string    `json:"details,omitempty"`
+	Origin    string    `json:"origin"`
+	User      string    `json:"user,omitempty"`
+	PID       int       `json:"pid"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// AuditVM is the view model for the audit log view
+type AuditVM struct {
+	BaseViewModel
+	Entries []AuditEntryVM `json:"entries"` // Most recent first
+	File    string         `json:"file"`    // Path of the audit log
+	Enabled bool           `json:"enabled"`
+}
+
+// PluginItemVM is a line of a plugin view or data provider
+type PluginItemVM struct {
+	Label  string `json:"label"`
+	Value  string `json:"value,omitempty"`
+	Status string `json:"status,omitempty"` // ok, warning, error, info
+	Detail string `json:"detail,omitempty"`
+}
+
+// PluginViewVM is a custom panel provided by a plugin
+type PluginViewVM struct {
+	ID        string         `json:"id"`
+	Title     string         `json:"title"`
+	Items     []PluginItemVM `json:"items,omitempty"`
+	Error     string         `json:"error,omitempty"`
+	UpdatedAt time.Time      `json:"updated_at,omitempty"` // Zero until first fetched
+}
+
+// PluginActionVM is a command provided by a plugin
+type PluginActionVM struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Project     bool   `json:"project"` // Runs on a project
+	Confirm     bool   `json:"confirm"`
+}
+
+// PluginVM represents a plugin executable
+type PluginVM struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title"`
+	Description string           `json:"description,omitempty"`
+	Path        string           `json:"path"`
+	Error       string           `json:"error,omitempty"` // Describe failure
+	Views       []PluginViewVM   `json:"views,omitempty"`
+	Actions     []PluginActionVM `json:"actions,omitempty"`
+	Providers   []string         `json:"providers,omitempty"` // Data provider titles
+}
+
+// PluginDataVM is the content of a plugin data provider for a project
+type PluginDataVM struct {
+	Plugin string         `json:"plugin"`
+	Title  string         `json:"title"`
+	Items  []PluginItemVM `json:"items,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// PluginResultVM is the outcome of a plugin action run
+type PluginResultVM struct {
+	Plugin     string    `json:"plugin"`
+	Action     string    `json:"action"`
+	Title      string    `json:"title"`
+	ProjectID  string    `json:"project_id,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// PluginsVM is the view model for the plugins view
+type PluginsVM struct {
+	BaseViewModel
+	Dir         string                    `json:"dir"`
+	Plugins     []PluginVM                `json:"plugins"`
+	ProjectData map[string][]PluginDataVM `json:"project_data,omitempty"` // By project ID
+	Results     []PluginResultVM          `json:"results,omitempty"`      // Most recent first
+	Running     []string                  `json:"running,omitempty"`      // "plugin/action" being run
 }
 
 // CapabilityVM represents a single capability status
@@ -423,15 +1005,20 @@ type CapabilityVM struct {
 	Available bool   `json:"available"`
 	Path      string `json:"path,omitempty"`
 	Version   string `json:"version,omitempty"`
+	Install   string `json:"install,omitempty"` // Command installing the tool (when missing)
 }
 
 // CapabilitiesVM represents external tool capabilities
 type CapabilitiesVM struct {
 	Tmux   CapabilityVM `json:"tmux"`
+	PTY    CapabilityVM `json:"pty"`
 	Claude CapabilityVM `json:"claude"`
 	Codex  CapabilityVM `json:"codex"`
 	Shell  CapabilityVM `json:"shell"`
 	Sudo   CapabilityVM `json:"sudo"`
+	SSH    CapabilityVM `json:"ssh"`
+	Rsync  CapabilityVM `json:"rsync"`
+	SCP    CapabilityVM `json:"scp"`
 	Psql   CapabilityVM `json:"psql"`
 	Mysql  CapabilityVM `json:"mysql"`
 	Sqlite CapabilityVM `json:"sqlite"`
@@ -439,26 +1026,46 @@ type CapabilitiesVM struct {
 	Go     CapabilityVM `json:"go"`
 	Node   CapabilityVM `json:"node"`
 	Npm    CapabilityVM `json:"npm"`
+
+	Govulncheck CapabilityVM `json:"govulncheck"`
+	Grpcurl     CapabilityVM `json:"grpcurl"`
+	Docker      CapabilityVM `json:"docker"`
+
+	Shells    []CapabilityVM `json:"shells,omitempty"` // All the shells found (Shell is the default one)
+	CheckedAt time.Time      `json:"checked_at"`       // Last detection
+}
+
+// List returns the capabilities in display order: terminal, AI tools,
+// database clients, remote access, then development tools
+func (c *CapabilitiesVM) List() []CapabilityVM {
+	return []CapabilityVM{
+		c.Tmux, c.PTY, c.Shell, c.Sudo,
+		c.Claude, c.Codex,
+		c.Psql, c.Mysql, c.Sqlite,
+		c.SSH, c.Rsync, c.SCP,
+		c.Git, c.Go, c.Node, c.Npm, c.Govulncheck, c.Grpcurl, c.Docker,
+	}
 }
 
-// HasTerminal returns true if tmux is available (required for Claude/Database views)
+// HasTerminal returns true if a terminal backend is available (required for Claude/Database views):
+// tmux, or a native PTY (Unix PTY / Windows ConPTY)
 func (c *CapabilitiesVM) HasTerminal() bool {
-	return c.Tmux.Available
+	return c.Tmux.Available || c.PTY.Available
 }
 
-// HasClaude returns true if both tmux and claude are available
+// HasClaude returns true if both a terminal backend and claude are available
 func (c *CapabilitiesVM) HasClaude() bool {
-	return c.Tmux.Available && c.Claude.Available
+	return c.HasTerminal() && c.Claude.Available
 }
 
-// HasCodex returns true if both tmux and codex are available
+// HasCodex returns true if both a terminal backend and codex are available
 func (c *CapabilitiesVM) HasCodex() bool {
-	return c.Tmux.Available && c.Codex.Available
+	return c.HasTerminal() && c.Codex.Available
 }
 
-// HasDatabase returns true if tmux and at least one database client is available
+// HasDatabase returns true if a terminal backend and at least one database client is available
 func (c *CapabilitiesVM) HasDatabase() bool {
-	return c.Tmux.Available && (c.Psql.Available || c.Mysql.Available || c.Sqlite.Available)
+	return c.HasTerminal() && (c.Psql.Available || c.Mysql.Available || c.Sqlite.Available)
 }
 
 // HasGit returns true if git is available
@@ -466,12 +1073,32 @@ func (c *CapabilitiesVM) HasGit() bool {
 	return c.Git.Available
 }
 
-// HasShell returns true if tmux and shell are available
+// HasDocker returns true if a container engine (docker or podman) is available
+func (c *CapabilitiesVM) HasDocker() bool {
+	return c.Docker.Available
+}
+
+// HasShell returns true if a terminal backend and shell are available
 func (c *CapabilitiesVM) HasShell() bool {
-	return c.Tmux.Available && c.Shell.Available
+	return c.HasTerminal() && c.Shell.Available
+}
+
+// HasSecurityScan returns true if at least one vulnerability scanner is available
+func (c *CapabilitiesVM) HasSecurityScan() bool {
+	return c.Govulncheck.Available || c.Npm.Available
 }
 
 // HasSudo returns true if sudo is available
 func (c *CapabilitiesVM) HasSudo() bool {
 	return c.Sudo.Available
 }
+
+// HasSSH returns true if the ssh client is available
+func (c *CapabilitiesVM) HasSSH() bool {
+	return c.SSH.Available
+}
+
+// HasTransfer returns true if files can be transferred to remote hosts (rsync or scp)
+func (c *CapabilitiesVM) HasTransfer() bool {
+	return c.Rsync.Available || c.SCP.Available
+}
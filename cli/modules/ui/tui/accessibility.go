@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// screenReader is true when the screen-reader friendly rendering is enabled
+var screenReader bool
+
+// SetScreenReaderMode enables the screen-reader friendly rendering: no colors,
+// box-drawing characters or emoji, plain-text status labels, and a status line
+// announcing the current view and focus
+func SetScreenReaderMode(enabled bool) {
+	screenReader = enabled
+}
+
+// IsScreenReaderMode returns true if the screen-reader friendly rendering is enabled
+func IsScreenReaderMode() bool {
+	return screenReader
+}
+
+// screenReaderWords replaces the symbols carrying meaning with words.
+// Status icons are already words (see StatusIcon): these are the remaining ones.
+var screenReaderWords = strings.NewReplacer(
+	IconSuccess, " ok ",
+	IconError, " error ",
+	IconWarning, " warning ",
+	IconBuilding, " building ",
+	IconBranch, " branch ",
+	IconFolder, " dir ",
+	IconFile, " file ",
+	IconGear, " ",
+	IconRefresh, " ",
+	IconRunning, "*",
+	IconStopped, "-",
+	IconPlay, ">",
+	IconStop, " ",
+	"↑", " up ",
+	"↓", " down ",
+	"←", " left ",
+	"→", " right ",
+	"…", "...",
+)
+
+// screenReaderText turns a rendered frame into plain text: styles removed,
+// symbols replaced with words, decorations removed, lines cut to width
+func screenReaderText(frame string, width int) string {
+	frame = screenReaderWords.Replace(ansi.Strip(frame))
+
+	lines := strings.Split(frame, "\n")
+	for i, line := range lines {
+		var b strings.Builder
+		spaces := 0
+		for _, r := range line {
+			if isDecoration(r) {
+				r = ' '
+			}
+			if r == utf8.RuneError || isSymbol(r) {
+				continue
+			}
+			// Collapse the padding of the layout (columns stay apart)
+			if r == ' ' {
+				spaces++
+				if spaces > 2 {
+					continue
+				}
+			} else {
+				spaces = 0
+			}
+			b.WriteRune(r)
+		}
+		line = strings.TrimSpace(b.String())
+		if width > 0 {
+			line = ansi.Truncate(line, width, "")
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isDecoration returns true for the characters drawing borders and bars
+func isDecoration(r rune) bool {
+	return (r >= 0x2500 && r <= 0x259F) || // Box drawing, block elements
+		(r >= 0x25A0 && r <= 0x25FF) // Geometric shapes
+}
+
+// isSymbol returns true for the emoji and pictographs not read meaningfully
+func isSymbol(r rune) bool {
+	return (r >= 0x2300 && r <= 0x23FF) || // Miscellaneous technical
+		(r >= 0x2600 && r <= 0x27BF) || // Miscellaneous symbols, dingbats
+		(r >= 0x2800 && r <= 0x28FF) || // Braille (spinners)
+		(r >= 0xFE00 && r <= 0xFE0F) || // Variation selectors
+		r == 0x200D || // Zero width joiner
+		r >= 0x1F000 // Emoji
+}
+
+// renderAnnouncement renders the status line announcing the current view,
+// focus, selection and the last error (read by screen readers on change)
+func (m *Model) renderAnnouncement() string {
+	parts := []string{m.viewName() + " view"}
+
+	switch {
+	case m.showDialog:
+		if warning := dialogWarnings[m.dialogType]; warning != "" {
+			parts = append(parts, "warning: "+warning)
+		}
+		parts = append(parts, "dialog: "+m.dialogMessage)
+	case m.showHelp:
+		parts = append(parts, "help open, press ? to close")
+	case m.focusArea == FocusSidebar:
+		parts = append(parts, "focus menu")
+		if item := m.sidebarMenu.SelectedItem(); item != nil {
+			label, _ := StripShortcutBrackets(item.Label)
+			parts = append(parts, "on "+label)
+		}
+	default:
+		area := "main panel"
+		if m.focusArea == FocusDetail {
+			area = "detail panel"
+		}
+		if m.currentView == core.VMConfig {
+			area = m.configView().mode + " tab, " + area
+		}
+		parts = append(parts, "focus "+area)
+		if m.currentView == core.VMProjects {
+			if item := m.projectsView().menu.SelectedItem(); item != nil {
+				parts = append(parts, "on "+item.Label)
+			}
+		} else if m.focusArea == FocusMain && m.maxMainItems > 0 {
+			parts = append(parts, fmt.Sprintf("item %d of %d", m.mainIndex+1, m.maxMainItems))
+		}
+	}
+
+	if m.lastError != "" {
+		parts = append(parts, "error: "+m.lastError)
+	}
+	if m.readOnly {
+		parts = append(parts, "read-only")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// viewName returns the name of the current view as shown in the menu
+func (m *Model) viewName() string {
+	for _, v := range m.getSidebarViews() {
+		if v.vtype == m.currentView {
+			name, _ := StripShortcutBrackets(v.name)
+			return name
+		}
+	}
+	return string(m.currentView)
+}
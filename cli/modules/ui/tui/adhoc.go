@@ -0,0 +1,221 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Dialog types of the one-off command dialogs
+const (
+	dialogBuildCommand     = "build_command"     // Build with a command replacing the configured one
+	dialogRunCommand       = "run_command"       // Run with a command replacing the configured one
+	dialogComponentCommand = "component_command" // Run any command in the component directory
+)
+
+// findComponentVM returns the view model of a project component, or nil
+func (m *Model) findComponentVM(projectID string, component projects.ComponentType) *core.ComponentVM {
+	proj := m.findProjectVM(projectID)
+	if proj == nil {
+		return nil
+	}
+	for i := range proj.Components {
+		if proj.Components[i].Type == component {
+			return &proj.Components[i]
+		}
+	}
+	return nil
+}
+
+// openBuildCommandDialog asks for the command building the selected
+// component, this time only
+func (m *Model) openBuildCommandDialog() tea.Cmd {
+	return m.openCommandDialog(dialogBuildCommand)
+}
+
+// openRunCommandDialog asks for the command running the selected component,
+// this time only
+func (m *Model) openRunCommandDialog() tea.Cmd {
+	return m.openCommandDialog(dialogRunCommand)
+}
+
+// openComponentCommandDialog asks for a command to run in the directory of
+// the selected component (of the project without one)
+func (m *Model) openComponentCommandDialog() tea.Cmd {
+	return m.openCommandDialog(dialogComponentCommand)
+}
+
+// openCommandDialog opens a one-off command dialog for the selected project
+// or component. The overrides are prefilled with the configured command;
+// ↑/↓ recall the previous ones.
+func (m *Model) openCommandDialog(dialogType string) tea.Cmd {
+	projectID := m.getSelectedProjectID()
+	if projectID == "" {
+		m.lastError = "No project selected"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	if m.blockReadOnly("command") {
+		return nil
+	}
+	component := m.getSelectedComponent()
+	target := projectID
+	if component != "" {
+		target += "/" + string(component)
+	}
+
+	command := ""
+	switch dialogType {
+	case dialogBuildCommand, dialogRunCommand:
+		if component == "" {
+			m.lastError = "Select a component to override its command"
+			m.lastErrorTime = time.Now()
+			return nil
+		}
+		if dialogType == dialogRunCommand && m.isSelectedProjectSelf() {
+			m.lastError = "Cannot run csd-devtrack (already running as self)"
+			m.lastErrorTime = time.Now()
+			return nil
+		}
+		if comp := m.findComponentVM(projectID, component); comp != nil {
+			command = comp.BuildCmd
+			if dialogType == dialogRunCommand {
+				command = comp.RunCmd
+			}
+		}
+	}
+
+	m.pendingCommandProjectID = projectID
+	m.pendingCommandComponent = component
+	m.dialogType = dialogType
+	switch dialogType {
+	case dialogBuildCommand:
+		m.dialogMessage = fmt.Sprintf("Build %s with (this time only):", target)
+	case dialogRunCommand:
+		m.dialogMessage = fmt.Sprintf("Run %s with (this time only):", target)
+	default:
+		m.dialogMessage = fmt.Sprintf("Command to run in %s:", target)
+	}
+	m.dialogInput.SetValue(command)
+	m.dialogInput.CursorEnd()
+	m.dialogInput.Focus()
+	m.dialogInputActive = true
+	m.showDialog = true
+	return m.dialogInput.Cursor.BlinkCmd()
+}
+
+// confirmCommandDialog starts the build, run or command entered in a one-off
+// command dialog
+func (m *Model) confirmCommandDialog() tea.Cmd {
+	projectID, component := m.pendingCommandProjectID, m.pendingCommandComponent
+	m.pendingCommandProjectID = ""
+	m.pendingCommandComponent = ""
+	command := strings.TrimSpace(m.dialogInput.Value())
+	if projectID == "" || command == "" {
+		return nil
+	}
+
+	switch m.dialogType {
+	case dialogBuildCommand:
+		// Build view shows the output, as for b
+		m.currentView = core.VMBuild
+		m.sidebarIndex = 2 // Build view index
+		m.sidebarMenu.SetSelectedIndex(2)
+		return m.sendEvent(core.NewEvent(core.EventStartBuild).WithProject(projectID).WithComponent(component).
+			WithData("profile", m.buildView().profile).WithData("command", command))
+	case dialogRunCommand:
+		return m.sendEvent(core.NewEvent(core.EventStartProcess).WithProject(projectID).WithComponent(component).
+			WithData("command", command))
+	}
+
+	// The output is captured in the logs, under its own source
+	source := "cmd:" + projectID
+	if component != "" {
+		source += "/" + string(component)
+	}
+	m.currentView = core.VMLogs
+	m.sidebarIndex = 4 // Logs view index
+	m.sidebarMenu.SetSelectedIndex(4)
+	m.setLogSourceFilter(source)
+	m.logsView().searchText = ""
+	return m.sendEvent(core.NewEvent(core.EventRunCommand).WithProject(projectID).WithComponent(component).WithValue(command))
+}
+
+// runningCommand returns the running ad-hoc command of a project component,
+// or nil
+func (m *Model) runningCommand(projectID string, component projects.ComponentType) *core.CommandRunVM {
+	if m.state.Projects == nil {
+		return nil
+	}
+	for i := range m.state.Projects.CommandRuns {
+		r := &m.state.Projects.CommandRuns[i]
+		if r.ProjectID == projectID && r.Component == string(component) && r.State == "running" {
+			return r
+		}
+	}
+	return nil
+}
+
+// commandToCancel returns the running ad-hoc command Ctrl+C cancels: the
+// one of the selected component or, in the Logs view, of the "cmd:" source
+// shown
+func (m *Model) commandToCancel() (string, projects.ComponentType, bool) {
+	projectID, component := m.getSelectedProjectID(), m.getSelectedComponent()
+	if m.currentView == core.VMLogs {
+		source, ok := strings.CutPrefix(m.logsView().sourceFilter, "cmd:")
+		if !ok {
+			return "", "", false
+		}
+		project, comp, _ := strings.Cut(source, "/")
+		projectID, component = project, projects.ComponentType(comp)
+	}
+	if projectID == "" || m.runningCommand(projectID, component) == nil {
+		return "", "", false
+	}
+	return projectID, component, true
+}
+
+// renderProjectCommandRuns renders the recent ad-hoc commands of a project
+// with their result (detail panel)
+func (m *Model) renderProjectCommandRuns(projectID string, width int) []string {
+	if m.state.Projects == nil {
+		return nil
+	}
+
+	const shown = 5
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	var lines []string
+	runs := m.state.Projects.CommandRuns
+	for i := len(runs) - 1; i >= 0 && len(lines) < shown; i-- {
+		r := runs[i]
+		if r.ProjectID != projectID {
+			continue
+		}
+		var status string
+		switch r.State {
+		case "running":
+			status = mutedStyle.Render(m.spinner.View() + " " + formatDuration(r.StartedAt, time.Now()))
+		case "success":
+			status = StatusSuccess.Render("✓") + mutedStyle.Render(" "+formatRelativeTime(r.FinishedAt))
+		case "failed":
+			status = StatusError.Render(fmt.Sprintf("✗ exit %d", r.ExitCode)) + mutedStyle.Render(" "+formatRelativeTime(r.FinishedAt))
+		default:
+			status = StatusWarning.Render("canceled") + mutedStyle.Render(" "+formatRelativeTime(r.FinishedAt))
+		}
+		where := ""
+		if r.Component != "" {
+			where = mutedStyle.Render(r.Component + " ")
+		}
+		lines = append(lines, truncateANSI("  "+where+"$ "+truncate(r.Command, max(width-26, 10))+"  "+status, width))
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return append([]string{"", SubtitleStyle.Render("Commands:")}, lines...)
+}
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// aiEditsShown is the number of sessions named in the AI-modified badge
+const aiEditsShown = 2
+
+// aiEditsSummary describes the Claude sessions that wrote a file, most
+// recent first ("" if none)
+func aiEditsSummary(edits []core.AIEditVM) string {
+	if len(edits) == 0 {
+		return ""
+	}
+	var sessions []string
+	for i, edit := range edits {
+		if i == aiEditsShown {
+			sessions = append(sessions, fmt.Sprintf("+%d more", len(edits)-aiEditsShown))
+			break
+		}
+		session := edit.SessionName
+		if !edit.Time.IsZero() {
+			session += SubtitleStyle.Render(fmt.Sprintf(" (%s %s)", edit.Tool, formatAge(time.Since(edit.Time))))
+		}
+		sessions = append(sessions, session)
+	}
+	badge := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true).Render("✦ AI-modified")
+	return badge + " by " + strings.Join(sessions, ", ")
+}
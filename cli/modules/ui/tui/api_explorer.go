@@ -0,0 +1,378 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/apiexplorer"
+	"csd-devtrack/cli/modules/ui/core"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// apiRequestDraft is the edited path and body of an endpoint
+type apiRequestDraft struct {
+	path string
+	body string
+}
+
+// apiExplorerPanel is the overlay listing the endpoints of a component's API
+// and firing test requests at them ("a")
+type apiExplorerPanel struct {
+	projectID string
+	component projects.ComponentType
+
+	selected   int // Selected endpoint
+	listScroll int // First visible endpoint
+
+	// Request editor (nil endpoint = endpoint list)
+	endpoint  *apiexplorer.Endpoint
+	path      textinput.Model
+	body      textarea.Model
+	focusBody bool
+	drafts    map[string]apiRequestDraft // Key: method + path of the endpoint
+
+	scroll int // First visible line of the response
+	height int // Visible response lines (set at render)
+}
+
+// openAPIExplorer opens the API explorer of the selected component
+func (m *Model) openAPIExplorer() tea.Cmd {
+	projectID := m.getSelectedProjectID()
+	component := m.getSelectedComponent()
+	if projectID == "" || component == "" {
+		m.lastError = "Select a component to explore its API"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	if comp := m.findComponentVM(projectID, component); comp == nil || !comp.HasAPI {
+		m.lastError = fmt.Sprintf("No API descriptor for %s (set api.openapi or api.proto in its config)", component)
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	m.apiExplorer = &apiExplorerPanel{
+		projectID: projectID,
+		component: component,
+		drafts:    make(map[string]apiRequestDraft),
+	}
+	if m.state.Projects != nil {
+		m.state.Projects.APIExplorer = nil // Loading until the endpoints arrive
+	}
+	return m.sendEvent(core.NewEvent(core.EventAPIExplore).WithProject(projectID).WithComponent(component))
+}
+
+// apiExplorerVM returns the explorer state of the open panel, nil while loading
+func (m *Model) apiExplorerVM() *core.APIExplorerVM {
+	if m.state.Projects == nil || m.state.Projects.APIExplorer == nil {
+		return nil
+	}
+	explorer := m.state.Projects.APIExplorer
+	if explorer.ProjectID != m.apiExplorer.projectID || explorer.Component != m.apiExplorer.component {
+		return nil
+	}
+	return explorer
+}
+
+// handleAPIExplorerKey handles the keys of the API explorer: the endpoint
+// list, or the request editor when an endpoint is open
+func (m *Model) handleAPIExplorerKey(msg tea.KeyMsg) tea.Cmd {
+	p := m.apiExplorer
+	switch msg.String() {
+	case "pgup":
+		p.scroll = max(p.scroll-p.height, 0)
+		return nil
+	case "pgdown":
+		p.scroll += p.height
+		return nil
+	case "ctrl+s":
+		return m.sendAPIRequest()
+	}
+	if p.endpoint != nil {
+		return m.handleAPIEditorKey(msg)
+	}
+
+	var endpoints []apiexplorer.Endpoint
+	if explorer := m.apiExplorerVM(); explorer != nil {
+		endpoints = explorer.Endpoints
+	}
+	switch msg.String() {
+	case "esc", "q":
+		m.apiExplorer = nil
+	case "up", "k":
+		p.selected = max(p.selected-1, 0)
+	case "down", "j":
+		p.selected = min(p.selected+1, max(len(endpoints)-1, 0))
+	case "home", "g":
+		p.selected = 0
+	case "end", "G":
+		p.selected = max(len(endpoints)-1, 0)
+	case "enter", "e":
+		if p.selected < len(endpoints) {
+			return p.editEndpoint(endpoints[p.selected])
+		}
+	case "y":
+		if explorer := m.apiExplorerVM(); explorer != nil && explorer.Response != nil {
+			return m.yankText("API response", explorer.Response.Body)
+		}
+		m.lastError = "No response to copy"
+		m.lastErrorTime = time.Now()
+	}
+	return nil
+}
+
+// handleAPIEditorKey handles the keys of the request editor: Tab switches
+// between the path and the body, Esc goes back to the endpoint list
+func (m *Model) handleAPIEditorKey(msg tea.KeyMsg) tea.Cmd {
+	p := m.apiExplorer
+	switch msg.String() {
+	case "esc":
+		p.saveDraft()
+		p.endpoint = nil
+		return nil
+	case "tab", "shift+tab":
+		p.focusBody = !p.focusBody
+		if p.focusBody {
+			p.path.Blur()
+			return p.body.Focus()
+		}
+		p.body.Blur()
+		return p.path.Focus()
+	}
+
+	var cmd tea.Cmd
+	if p.focusBody {
+		p.body, cmd = p.body.Update(msg)
+	} else {
+		p.path, cmd = p.path.Update(msg)
+	}
+	return cmd
+}
+
+// draftKey identifies an endpoint among the drafts
+func draftKey(endpoint apiexplorer.Endpoint) string {
+	return endpoint.Method + " " + endpoint.Path
+}
+
+// editEndpoint opens the request editor on an endpoint, with its last draft
+// or the path and example body of the descriptor
+func (p *apiExplorerPanel) editEndpoint(endpoint apiexplorer.Endpoint) tea.Cmd {
+	draft, ok := p.drafts[draftKey(endpoint)]
+	if !ok {
+		draft = apiRequestDraft{path: endpoint.Path, body: endpoint.Body}
+	}
+
+	p.endpoint = &endpoint
+	p.path = textinput.New()
+	p.path.Prompt = ""
+	p.path.CharLimit = 0
+	p.path.SetValue(draft.path)
+
+	p.body = textarea.New()
+	p.body.Placeholder = "JSON body (empty = none)"
+	p.body.ShowLineNumbers = false
+	p.body.Prompt = "┃ "
+	p.body.CharLimit = 0
+	p.body.MaxHeight = 0
+	p.body.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	p.body.FocusedStyle.Prompt = lipgloss.NewStyle().Foreground(ColorPrimary)
+	p.body.BlurredStyle.Prompt = lipgloss.NewStyle().Foreground(ColorMuted)
+	p.body.SetValue(draft.body)
+	p.body.Blur()
+
+	// Routes with parameters ({id}) are edited first, bodies otherwise
+	p.focusBody = draft.body != "" && !strings.Contains(draft.path, "{")
+	if p.focusBody {
+		return p.body.Focus()
+	}
+	return p.path.Focus()
+}
+
+// saveDraft keeps the edits of the open endpoint for when it is opened again
+func (p *apiExplorerPanel) saveDraft() {
+	if p.endpoint != nil {
+		p.drafts[draftKey(*p.endpoint)] = apiRequestDraft{path: p.path.Value(), body: p.body.Value()}
+	}
+}
+
+// sendAPIRequest fires the edited request, or the selected endpoint as
+// described from the endpoint list
+func (m *Model) sendAPIRequest() tea.Cmd {
+	p := m.apiExplorer
+	explorer := m.apiExplorerVM()
+	if explorer == nil || explorer.Error != "" {
+		return nil
+	}
+	if explorer.Sending {
+		m.lastError = "A request is already in flight"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	var method, path, body string
+	if p.endpoint != nil {
+		p.saveDraft()
+		method, path, body = p.endpoint.Method, strings.TrimSpace(p.path.Value()), p.body.Value()
+	} else if p.selected < len(explorer.Endpoints) {
+		endpoint := explorer.Endpoints[p.selected]
+		draft, ok := p.drafts[draftKey(endpoint)]
+		if !ok {
+			draft = apiRequestDraft{path: endpoint.Path, body: endpoint.Body}
+		}
+		method, path, body = endpoint.Method, draft.path, draft.body
+	} else {
+		return nil
+	}
+	if strings.Contains(path, "{") {
+		m.lastError = "Replace the {parameters} of the path first"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	p.scroll = 0
+	return m.sendEvent(core.NewEvent(core.EventAPIRequest).
+		WithProject(p.projectID).
+		WithComponent(p.component).
+		WithData("method", method).
+		WithData("path", path).
+		WithData("body", body))
+}
+
+// apiMethodStyle colors an HTTP method by what it does
+func apiMethodStyle(method string) lipgloss.Style {
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return StatusSuccess
+	case "DELETE":
+		return StatusError
+	case "RPC":
+		return HelpKeyStyle
+	}
+	return StatusWarning
+}
+
+// apiResponseLines renders the status and body of the last response
+func apiResponseLines(explorer *core.APIExplorerVM, width int) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	if explorer.Sending {
+		return []string{StatusWarning.Render("◐ Sending " + explorer.Request + "...")}
+	}
+	resp := explorer.Response
+	if resp == nil {
+		return []string{mutedStyle.Render("No request sent yet (Ctrl+S sends the selected endpoint)")}
+	}
+
+	timing := mutedStyle.Render(fmt.Sprintf("  %s · %s", explorer.Request, resp.Duration.Round(time.Millisecond)))
+	var lines []string
+	switch {
+	case resp.Error != "":
+		lines = append(lines, StatusError.Render("✗ "+resp.Error)+timing)
+	case resp.OK:
+		lines = append(lines, StatusSuccess.Render("✓ "+resp.Status)+timing)
+	default:
+		lines = append(lines, StatusError.Render("✗ "+resp.Status)+timing)
+	}
+	for _, header := range resp.Headers {
+		lines = append(lines, mutedStyle.Render(header))
+	}
+	if len(resp.Headers) > 0 {
+		lines = append(lines, "")
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(resp.Body, "\t", "    "), "\n") {
+		lines = append(lines, line)
+	}
+	for i, line := range lines {
+		lines[i] = linkURLs(truncateANSI(line, width), line)
+	}
+	return lines
+}
+
+// renderAPIExplorerOverlay renders the API explorer panel
+func (m *Model) renderAPIExplorerOverlay(width, height int) string {
+	p := m.apiExplorer
+	boxWidth := min(width-4, 140)
+	innerWidth := boxWidth - 6 // Border and padding
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	available := max(height-9, 6) // Title, target, footer and borders
+
+	title := fmt.Sprintf("API explorer: %s/%s", p.projectID, p.component)
+	lines := []string{DialogTitleStyle.MarginBottom(0).Render(truncate(title, innerWidth))}
+
+	explorer := m.apiExplorerVM()
+	var hints []KeyHint
+	switch {
+	case explorer == nil:
+		lines = append(lines, "", SubtitleStyle.Render("Loading the descriptor..."))
+	case explorer.Error != "":
+		lines = append(lines, mutedStyle.Render(truncate(explorer.Descriptor, innerWidth)), "",
+			StatusError.Render(truncate("✗ "+explorer.Error, innerWidth)))
+	default:
+		lines = append(lines, mutedStyle.Render(truncate("→ "+explorer.Target+"  ("+explorer.Descriptor+")", innerWidth)), "")
+
+		if p.endpoint == nil {
+			// Endpoint list
+			listHeight := min(len(explorer.Endpoints), max(available/2, 3))
+			p.selected = min(p.selected, max(len(explorer.Endpoints)-1, 0))
+			if p.selected < p.listScroll {
+				p.listScroll = p.selected
+			} else if p.selected >= p.listScroll+listHeight {
+				p.listScroll = p.selected - listHeight + 1
+			}
+			if len(explorer.Endpoints) == 0 {
+				lines = append(lines, mutedStyle.Render("No endpoints in the descriptor"))
+				listHeight = 1
+			}
+			for i := p.listScroll; i < min(p.listScroll+listHeight, len(explorer.Endpoints)); i++ {
+				endpoint := explorer.Endpoints[i]
+				summary := ""
+				if endpoint.Summary != "" {
+					summary = "  " + endpoint.Summary
+				}
+				methodColumn := fmt.Sprintf("%-7s", endpoint.Method)
+				var row string
+				if i == p.selected {
+					row = truncate(" "+methodColumn+" "+endpoint.Path+summary, innerWidth)
+					row = TableRowSelectedStyle.Render(row + strings.Repeat(" ", max(innerWidth-lipgloss.Width(row), 0)))
+				} else {
+					row = " " + apiMethodStyle(endpoint.Method).Render(methodColumn) + " " + endpoint.Path + mutedStyle.Render(summary)
+					row = truncateANSI(row, innerWidth)
+				}
+				lines = append(lines, row)
+			}
+			available -= listHeight
+			hints = []KeyHint{{"↑↓", "select"}, {"Enter", "edit"}, {"^S", "send"}, {"PgUp/PgDn", "scroll"}, {"y", "copy response"}, {"Esc", "close"}}
+		} else {
+			// Request editor
+			method := apiMethodStyle(p.endpoint.Method).Render(p.endpoint.Method)
+			p.path.Width = max(innerWidth-lipgloss.Width(p.endpoint.Method)-2, 10)
+			lines = append(lines, method+" "+p.path.View())
+			bodyHeight := min(max(p.body.LineCount(), 3), max(available/3, 3))
+			p.body.SetWidth(innerWidth)
+			p.body.SetHeight(bodyHeight)
+			lines = append(lines, p.body.View())
+			available -= bodyHeight + 1
+			hints = []KeyHint{{"Tab", "path/body"}, {"^S", "send"}, {"PgUp/PgDn", "scroll"}, {"Esc", "endpoints"}}
+		}
+
+		// Response
+		response := apiResponseLines(explorer, innerWidth)
+		p.height = max(available-2, 2)
+		p.scroll = min(p.scroll, max(len(response)-p.height, 0))
+		lines = append(lines, "", SubtitleStyle.Render("Response:"))
+		lines = append(lines, response[p.scroll:min(p.scroll+p.height, len(response))]...)
+	}
+	if hints == nil {
+		hints = []KeyHint{{"Esc", "close"}}
+	}
+	lines = append(lines, "", strings.Join(renderKeyHints(hints), ""))
+
+	box := DialogStyle.Padding(0, 2).Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Top,
+		lipgloss.NewStyle().MarginTop(1).Render(box),
+	)
+}
@@ -20,6 +20,7 @@ type TUIView struct {
 	ctx             context.Context
 	cancel          context.CancelFunc
 	detachable      bool              // If true, Ctrl+D detaches instead of quit
+	readOnly        bool              // If true, state-changing actions are blocked (observer)
 	detached        bool              // Set to true if user detached
 	pendingTUIState *daemon.TUIState  // Buffered TUI state if received before program starts
 	pendingUpdates  []core.StateUpdate // Buffered state updates if received before program starts
@@ -37,6 +38,13 @@ func (v *TUIView) SetDetachable(enabled bool) {
 	v.detachable = enabled
 }
 
+// SetReadOnly enables or disables read-only (observer) mode
+func (v *TUIView) SetReadOnly(enabled bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.readOnly = enabled
+}
+
 // WasDetached returns true if the user detached (Ctrl+D) instead of quit
 func (v *TUIView) WasDetached() bool {
 	v.mu.RLock()
@@ -90,6 +98,7 @@ func (v *TUIView) Initialize(presenter core.Presenter) error {
 	v.presenter = presenter
 	v.model = NewModel(presenter)
 	v.model.detachable = v.detachable
+	v.model.readOnly = v.readOnly
 	v.mu.Unlock()
 
 	// Subscribe to state updates (must be outside lock - callback may call UpdateState)
@@ -123,6 +132,7 @@ func (v *TUIView) Run(ctx context.Context) error {
 		v.model,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
+		tea.WithReportFocus(), // Low-power mode while unfocused
 	)
 	program := v.program
 	v.mu.Unlock()
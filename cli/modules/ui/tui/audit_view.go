@@ -0,0 +1,320 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// auditController is the submodel of the Audit view
+type auditController struct {
+	menu *TreeMenu // Tree menu for audit entries
+
+	projectFilter string // Project ID shown ("" = all projects)
+}
+
+// newAuditController creates the Audit view controller
+func newAuditController() *auditController {
+	menu := NewTreeMenu(nil)
+	menu.SetTitle("Audit log")
+	return &auditController{menu: menu}
+}
+
+// Init implements ViewController
+func (c *auditController) Init(m *Model) tea.Cmd {
+	c.updateMenu(m)
+	return nil
+}
+
+// Menu implements ViewController
+func (c *auditController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	if focus != FocusMain {
+		return nil
+	}
+	return c.menu
+}
+
+// Keys implements ViewController
+func (c *auditController) Keys(m *Model) []KeyHint {
+	return []KeyHint{
+		{"p", "project filter"},
+		{"e", "export"},
+		{"r", "refresh"},
+	}
+}
+
+// Update implements ViewController
+func (c *auditController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case stateUpdateMsg:
+		if msg.update.Affects(core.VMAudit) {
+			c.updateMenu(m)
+		}
+	case selectMsg:
+		// Entries have no action: Enter moves to the detail panel
+		m.focusArea = FocusDetail
+		return nil, true
+	case dialogConfirmMsg:
+		if msg.dialogType != "audit_export" {
+			return nil, false
+		}
+		path := strings.TrimSpace(m.dialogInput.Value())
+		if path == "" {
+			return nil, true
+		}
+		return m.sendEvent(core.NewEvent(core.EventAuditExport).WithProject(c.projectFilter).WithValue(path)), true
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "p":
+			c.cycleProjectFilter(m)
+			return nil, true
+		case "e":
+			return c.openExportDialog(m), true
+		case "r":
+			return m.sendEvent(core.NewEvent(core.EventNavigate).WithTarget(string(core.VMAudit))), true
+		}
+	}
+	return nil, false
+}
+
+// View implements ViewController
+// Layout: TreeMenu with audit entries on left, details on right
+func (c *auditController) View(m *Model, width, height int) string {
+	vm := m.state.Audit
+	if vm == nil {
+		return m.renderLoading()
+	}
+
+	// 2 panels side by side (TreeMenu + detail)
+	// Height: 1 × 2 = 2
+	// Width: 2 × 2 = 4
+	heightBorders := 2
+	widthBorders := 4
+	panelHeight := height - heightBorders
+	availableWidth := width - widthBorders - GapHorizontal
+
+	// Left panel - TreeMenu with entries
+	listWidth := c.menu.CalcWidth()
+	if listWidth < 40 {
+		listWidth = 40
+	}
+	if listWidth > availableWidth*2/3 {
+		listWidth = availableWidth * 2 / 3
+	}
+
+	c.menu.SetSize(listWidth, panelHeight)
+	c.menu.SetFocused(m.focusArea == FocusMain)
+	listPanel := c.menu.Render()
+
+	// Right panel - details
+	detailWidth := availableWidth - listWidth
+	detailContent := c.renderDetail(m, vm, detailWidth-4)
+
+	var detailStyle lipgloss.Style
+	if m.focusArea == FocusDetail {
+		detailStyle = FocusedBorderStyle
+	} else {
+		detailStyle = UnfocusedBorderStyle
+	}
+	detailPanel := detailStyle.Width(detailWidth - 2).Height(panelHeight).Render(detailContent)
+
+	gap := strings.Repeat(" ", GapHorizontal)
+	return lipgloss.JoinHorizontal(lipgloss.Top, listPanel, gap, detailPanel)
+}
+
+// renderDetail renders the details of the selected audit entry
+func (c *auditController) renderDetail(m *Model, vm *core.AuditVM, width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	if !vm.Enabled {
+		return strings.Join([]string{
+			PanelTitleStyle.Render("Audit log disabled"),
+			"",
+			mutedStyle.Render("Enable it with audit.disabled: false in the config."),
+		}, "\n")
+	}
+	if len(vm.Entries) == 0 {
+		return strings.Join([]string{
+			PanelTitleStyle.Render("No actions recorded"),
+			"",
+			mutedStyle.Render("Builds, process start/stop/kill, deployments and config changes are recorded here."),
+		}, "\n")
+	}
+
+	entry := c.selectedEntry()
+	if entry == nil {
+		return mutedStyle.Render("Select an entry")
+	}
+
+	lines := []string{
+		PanelTitleStyle.Render(entry.Action),
+		"",
+		fmt.Sprintf("Time:      %s", entry.Time.Format("2006-01-02 15:04:05")),
+		mutedStyle.Render(fmt.Sprintf("           %s", formatRelativeTime(entry.Time))),
+	}
+	if entry.ProjectID != "" {
+		lines = append(lines, fmt.Sprintf("Project:   %s", entry.ProjectID))
+	}
+	if entry.Component != "" {
+		lines = append(lines, fmt.Sprintf("Component: %s", entry.Component))
+	}
+	if entry.Target != "" {
+		lines = append(lines, fmt.Sprintf("Target:    %s", truncate(entry.Target, width-11)))
+	}
+	lines = append(lines,
+		fmt.Sprintf("Origin:    %s", entry.Origin),
+		fmt.Sprintf("By:        %s (pid %d)", entry.User, entry.PID),
+	)
+	if entry.Details != "" {
+		lines = append(lines, "", mutedStyle.Render("Details:"))
+		lines = append(lines, lipgloss.NewStyle().Width(width-2).PaddingLeft(2).Render(entry.Details))
+	}
+
+	lines = append(lines, "")
+	if entry.Error != "" {
+		lines = append(lines, StatusError.Render("✗ "+truncate(entry.Error, width-2)))
+	} else {
+		lines = append(lines, StatusSuccess.Render("✓ ok"))
+	}
+
+	lines = append(lines, "", mutedStyle.Render(truncate(vm.File, width)))
+	return strings.Join(lines, "\n")
+}
+
+// selectedEntry returns the audit entry selected in the menu
+func (c *auditController) selectedEntry() *core.AuditEntryVM {
+	selected := c.menu.SelectedItem()
+	if selected == nil {
+		return nil
+	}
+	if entry, ok := selected.Data.(core.AuditEntryVM); ok {
+		return &entry
+	}
+	return nil
+}
+
+// cycleProjectFilter switches to the next project found in the entries (then all)
+func (c *auditController) cycleProjectFilter(m *Model) {
+	if m.state.Audit == nil {
+		return
+	}
+	var projectIDs []string
+	seen := make(map[string]bool)
+	for _, e := range m.state.Audit.Entries {
+		if e.ProjectID != "" && !seen[e.ProjectID] {
+			seen[e.ProjectID] = true
+			projectIDs = append(projectIDs, e.ProjectID)
+		}
+	}
+
+	next := ""
+	for i, id := range projectIDs {
+		if c.projectFilter == "" {
+			next = id
+			break
+		}
+		if id == c.projectFilter && i+1 < len(projectIDs) {
+			next = projectIDs[i+1]
+			break
+		}
+	}
+	c.projectFilter = next
+	c.updateMenu(m)
+}
+
+// openExportDialog asks for the export file (.csv or .json)
+func (c *auditController) openExportDialog(m *Model) tea.Cmd {
+	if m.blockReadOnly("audit export") {
+		return nil
+	}
+	if m.state.Audit == nil || !m.state.Audit.Enabled {
+		m.lastError = "Audit log is disabled"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	name := "devtrack-audit"
+	if c.projectFilter != "" {
+		name += "-" + c.projectFilter
+	}
+	m.dialogType = "audit_export"
+	m.dialogMessage = "Export audit log to (.csv or .json):"
+	m.dialogInput.SetValue(fmt.Sprintf("~/%s-%s.csv", name, time.Now().Format("20060102-150405")))
+	m.dialogInput.CursorEnd()
+	m.dialogInput.Focus()
+	m.dialogInputActive = true
+	m.showDialog = true
+	return m.dialogInput.Cursor.BlinkCmd()
+}
+
+// updateMenu updates the audit TreeMenu with current entries
+func (c *auditController) updateMenu(m *Model) {
+	if m.state.Audit == nil {
+		return
+	}
+
+	title := "Audit log"
+	if c.projectFilter != "" {
+		title += " · " + c.projectFilter
+	}
+	c.menu.SetTitle(title)
+
+	items := make([]TreeMenuItem, 0, len(m.state.Audit.Entries))
+	for i, e := range m.state.Audit.Entries {
+		if c.projectFilter != "" && e.ProjectID != c.projectFilter {
+			continue
+		}
+		label := e.Time.Format("01-02 15:04:05") + " " + e.Action
+		if subject := auditSubject(e); subject != "" {
+			label += " " + subject
+		}
+		item := TreeMenuItem{
+			ID:            fmt.Sprintf("%d-%d", e.Time.UnixNano(), i),
+			Label:         label,
+			Icon:          "✓",
+			IconColor:     ColorSuccess,
+			TrailingIcon:  e.Origin,
+			TrailingColor: ColorMuted,
+			Data:          e,
+		}
+		if e.Error != "" {
+			item.Icon = "✗"
+			item.IconColor = ColorError
+		}
+		items = append(items, item)
+	}
+
+	c.menu.SetItems(items)
+}
+
+// auditSubject returns what an audit entry acted on (project/component or target)
+func auditSubject(e core.AuditEntryVM) string {
+	switch {
+	case e.ProjectID != "" && e.Component != "":
+		return e.ProjectID + "/" + e.Component
+	case e.ProjectID != "":
+		return e.ProjectID
+	default:
+		return e.Target
+	}
+}
+
+// setEventOrigin records the view an event is sent from (audit log)
+func (m *Model) setEventOrigin(event *core.Event) {
+	if event.Origin == "" {
+		event.Origin = string(m.currentView)
+	}
+}
+
+// auditEvent records an action the TUI performs itself (config file edits)
+func (m *Model) auditEvent(action, projectID, details string) tea.Cmd {
+	return m.sendEvent(core.NewEvent(core.EventAuditRecord).
+		WithTarget(action).
+		WithProject(projectID).
+		WithValue(details))
+}
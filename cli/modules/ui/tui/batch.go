@@ -0,0 +1,183 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// dialogBatch confirms a batch action on the items marked in a tree
+const dialogBatch = "batch"
+
+// batchAction is an action on the items marked in a tree, saved at dialog
+// open so that a refresh of the tree cannot change its targets
+type batchAction struct {
+	view  batchView
+	menu  *TreeMenu
+	items []TreeMenuItem
+}
+
+// handleBatchKey handles multi-select in the trees: space marks the selected
+// item, then the batch key of the view (see batchView) acts on the marked
+// items with a single confirmation. Without marks, the keys act on the
+// selected item as usual.
+func (m *Model) handleBatchKey(key string) (tea.Cmd, bool) {
+	view, ok := m.controller().(batchView)
+	if !ok {
+		return nil, false
+	}
+	menu := m.getActiveTreeMenu()
+	if menu == nil {
+		return nil, false
+	}
+	if key == " " {
+		if !menu.ToggleMark() {
+			return nil, false
+		}
+		menu.MoveDown()
+		return nil, true
+	}
+	if menu.MarkedCount() == 0 {
+		return nil, false
+	}
+
+	batchKey, _, prompt := view.batchAction()
+	if key != batchKey {
+		return nil, false
+	}
+	items := menu.Marked()
+	if len(items) == 0 {
+		return nil, false
+	}
+	m.pendingBatch = &batchAction{view: view, menu: menu, items: items}
+	return m.openConfirmDialog(config.ConfirmBatch, dialogBatch, fmt.Sprintf(prompt, len(items))), true
+}
+
+// clearMarks unmarks the items of the active tree, returns false if none
+// was marked
+func (m *Model) clearMarks() bool {
+	menu := m.getActiveTreeMenu()
+	if menu == nil || menu.MarkedCount() == 0 {
+		return false
+	}
+	menu.ClearMarks()
+	return true
+}
+
+// runBatch runs the batch action confirmed in the dialog and clears the marks
+func (m *Model) runBatch() tea.Cmd {
+	batch := m.pendingBatch
+	m.pendingBatch = nil
+	if batch == nil {
+		return nil
+	}
+	batch.menu.ClearMarks()
+	return batch.view.runBatch(m, batch.items)
+}
+
+// batchAction implements batchView (b builds the marked projects)
+func (c *projectsController) batchAction() (key, label, prompt string) {
+	return "b", "build", "Build %d marked projects?"
+}
+
+// runBatch implements batchView
+func (c *projectsController) runBatch(m *Model, items []TreeMenuItem) tea.Cmd {
+	var projectIDs []string
+	for _, item := range items {
+		if p, ok := item.Data.(core.ProjectVM); ok {
+			projectIDs = append(projectIDs, p.ID)
+		}
+	}
+	// Switch to Build view to show output
+	m.currentView = core.VMBuild
+	m.sidebarIndex = 2 // Build view index
+	m.sidebarMenu.SetSelectedIndex(2)
+	return m.sendEvent(core.NewEvent(core.EventBuildAll).
+		WithData("projects", strings.Join(projectIDs, "\n")).
+		WithData("profile", m.buildView().profile))
+}
+
+// batchAction implements batchView (s stops the marked processes)
+func (c *processesController) batchAction() (key, label, prompt string) {
+	return "s", "stop", "Stop %d marked processes?"
+}
+
+// runBatch implements batchView
+func (c *processesController) runBatch(m *Model, items []TreeMenuItem) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, item := range items {
+		if proc, ok := item.Data.(core.ProcessVM); ok && !proc.IsSelf {
+			cmds = append(cmds, m.sendEvent(core.NewEvent(core.EventStopProcess).
+				WithProject(proc.ProjectID).WithComponent(proc.Component)))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// batchAction implements batchView (a stages the marked files)
+func (c *gitController) batchAction() (key, label, prompt string) {
+	return "a", "stage", "Stage %d marked files?"
+}
+
+// runBatch implements batchView
+func (c *gitController) runBatch(m *Model, items []TreeMenuItem) tea.Cmd {
+	return m.stageFiles(items)
+}
+
+// batchAction implements batchView (x deletes the marked sessions)
+func (c *claudeController) batchAction() (key, label, prompt string) {
+	return "x", "delete", "Delete %d marked sessions?"
+}
+
+// runBatch implements batchView
+func (c *claudeController) runBatch(m *Model, items []TreeMenuItem) tea.Cmd {
+	var sessionIDs []string
+	for _, item := range items {
+		if sess, ok := item.Data.(core.ClaudeSessionVM); ok {
+			sessionIDs = append(sessionIDs, sess.ID)
+		}
+	}
+	return m.deleteClaudeSessions(sessionIDs...)
+}
+
+// stageSelected stages the file selected in the Git tree (a, without marks)
+func (m *Model) stageSelected() tea.Cmd {
+	if m.gitView().menu == nil || m.focusArea != FocusMain {
+		return nil
+	}
+	item := m.gitView().menu.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	if _, ok := item.Data.(GitFileEntry); !ok {
+		return nil
+	}
+	return m.stageFiles([]TreeMenuItem{*item})
+}
+
+// stageFiles stages files of the Git tree, one event per project
+func (m *Model) stageFiles(items []TreeMenuItem) tea.Cmd {
+	var projectIDs []string
+	paths := make(map[string][]string)
+	for _, item := range items {
+		entry, ok := item.Data.(GitFileEntry)
+		if !ok || entry.ProjectID == "" || entry.Status == "staged" {
+			continue
+		}
+		if _, exists := paths[entry.ProjectID]; !exists {
+			projectIDs = append(projectIDs, entry.ProjectID)
+		}
+		paths[entry.ProjectID] = append(paths[entry.ProjectID], entry.Path)
+	}
+
+	var cmds []tea.Cmd
+	for _, projectID := range projectIDs {
+		cmds = append(cmds, m.sendEvent(core.NewEvent(core.EventGitStage).WithProject(projectID).
+			WithData("paths", strings.Join(paths[projectID], "\n"))))
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// componentURL returns the URL a web component serves: the one its dev
+// server printed, else localhost on its configured port. Empty if unknown.
+func (m *Model) componentURL(projectID string, component projects.ComponentType) string {
+	if devServer := m.componentDevServer(projectID, component); devServer != nil && devServer.LocalURL != "" {
+		return browsableURL(devServer.LocalURL)
+	}
+	if comp := m.findComponentVM(projectID, component); comp != nil && comp.Port > 0 {
+		// Served over HTTPS once its local certificate is generated
+		if comp.Certificate != nil && comp.Certificate.Error == "" {
+			return fmt.Sprintf("https://localhost:%d/", comp.Port)
+		}
+		return fmt.Sprintf("http://localhost:%d/", comp.Port)
+	}
+	return ""
+}
+
+// selectedURL returns the URL of the selected component or, with a project
+// selected, of its first running component serving one
+func (m *Model) selectedURL() string {
+	projectID := m.getSelectedProjectID()
+	if projectID == "" {
+		return ""
+	}
+	if component := m.getSelectedComponent(); component != "" {
+		return m.componentURL(projectID, component)
+	}
+	proj := m.findProjectVM(projectID)
+	if proj == nil {
+		return ""
+	}
+	url := ""
+	for _, comp := range proj.Components {
+		if u := m.componentURL(projectID, comp.Type); u != "" {
+			if comp.IsRunning {
+				return u
+			}
+			if url == "" {
+				url = u
+			}
+		}
+	}
+	return url
+}
+
+// openInBrowser opens the URL of the selected web component in the browser.
+// Without a browser (SSH session, no display) the URL is copied instead.
+func (m *Model) openInBrowser() tea.Cmd {
+	url := m.selectedURL()
+	if url == "" {
+		m.lastError = "No URL known (set the component port or start its dev server)"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	args := browserCommand(url)
+	if args == nil {
+		return m.yankText("URL (no browser available)", url)
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	if err := cmd.Start(); err != nil {
+		m.lastError = "Cannot open the browser: " + err.Error()
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	go cmd.Wait() // Reap the opener
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess, "Opened "+url))
+	return nil
+}
+
+// browsableURL replaces the wildcard addresses a server listens on by
+// localhost, which browsers can open
+func browsableURL(url string) string {
+	for _, wildcard := range []string{"://0.0.0.0", "://[::]"} {
+		url = strings.Replace(url, wildcard, "://localhost", 1)
+	}
+	return url
+}
+
+// browserCommand returns the command opening a URL in the browser: $BROWSER,
+// else the desktop's default. Nil if none is available (no display).
+func browserCommand(url string) []string {
+	if browser := os.Getenv("BROWSER"); browser != "" {
+		return append(strings.Fields(browser), url)
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"open", url}
+	case "windows":
+		return []string{"rundll32", "url.dll,FileProtocolHandler", url}
+	}
+	if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return nil
+	}
+	if _, err := exec.LookPath("xdg-open"); err != nil {
+		return nil
+	}
+	return []string{"xdg-open", url}
+}
@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Build analytics thresholds
+const (
+	buildRegressionAlert = 0.2  // Last build 20% slower than usual
+	buildFailureAlert    = 0.25 // A quarter of the builds fail
+)
+
+// sparkBlocks are the levels of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// renderBuildAnalytics renders the analytics of the build history: failure
+// rate, duration trend and regressions per component, slowest steps
+func (m *Model) renderBuildAnalytics(a *core.BuildAnalyticsVM, width, height int) []string {
+	if a == nil || a.Builds == 0 {
+		return []string{
+			PanelTitleStyle.Render("Build analytics"),
+			SubtitleStyle.Render("No builds recorded yet. Finished builds are kept for analytics."),
+			"",
+			HelpKeyStyle.Render("a") + SubtitleStyle.Render(" back to builds"),
+		}
+	}
+
+	summary := fmt.Sprintf("%d builds since %s · %d failed (%.0f%%)",
+		a.Builds, a.Since.Local().Format("Jan 2 2006"), a.Failures, 100*float64(a.Failures)/float64(a.Builds))
+	lines := []string{
+		PanelTitleStyle.Render("Build analytics"),
+		SubtitleStyle.Render(truncate(summary, width)),
+		"",
+	}
+
+	// Per component: failure rate, durations and trend of the successful builds
+	nameWidth := min(max(width/4, 16), 32)
+	trendWidth := max(min(width-nameWidth-38, 20), 0)
+	lines = append(lines, TableHeaderStyle.Render(truncate(fmt.Sprintf("%-*s %6s %6s %8s %8s  %s",
+		nameWidth, "Component", "Builds", "Fail", "Avg", "Last", "Trend"), width)))
+	for _, c := range a.Components {
+		name := fmt.Sprintf("%-*s", nameWidth, truncate(c.ProjectName+"/"+c.Component, nameWidth))
+		failure := fmt.Sprintf("%5.0f%%", 100*c.FailureRate)
+		switch {
+		case c.FailureRate >= buildFailureAlert:
+			failure = StatusError.Render(failure)
+		case c.Failures > 0:
+			failure = StatusWarning.Render(failure)
+		}
+		row := fmt.Sprintf("%s %6d %s %8s %8s  %s", name, c.Builds, failure,
+			formatBuildDuration(c.AvgDuration), formatBuildDuration(c.LastDuration), renderSparkline(c.Trend, trendWidth))
+		switch {
+		case c.Regression >= buildRegressionAlert:
+			row += " " + StatusError.Render(fmt.Sprintf("▲ +%.0f%%", 100*c.Regression))
+		case c.Regression <= -buildRegressionAlert:
+			row += " " + StatusSuccess.Render(fmt.Sprintf("▼ %.0f%%", 100*c.Regression))
+		}
+		lines = append(lines, truncateANSI(row, width))
+	}
+
+	if len(a.SlowestSteps) > 0 {
+		lines = append(lines, "", TableHeaderStyle.Render(truncate(fmt.Sprintf("%-8s %8s %5s  %s",
+			"Avg", "Max", "Runs", "Slowest steps"), width)))
+		for _, st := range a.SlowestSteps {
+			row := fmt.Sprintf("%-8s %8s %5d  %s %s", formatBuildDuration(st.AvgDuration), formatBuildDuration(st.MaxDuration),
+				st.Runs, st.Name, SubtitleStyle.Render(st.ProjectName+"/"+st.Component))
+			lines = append(lines, truncateANSI(row, width))
+		}
+	}
+
+	footer := SubtitleStyle.Render(truncate("History: "+a.File, max(width-20, 10))) + "  " +
+		HelpKeyStyle.Render("a") + SubtitleStyle.Render(" back")
+	// Styled titles span several lines: count the rendered ones
+	lines = strings.Split(strings.Join(lines, "\n"), "\n")
+	if len(lines) > height-2 {
+		lines = append(lines[:max(height-3, 0)], SubtitleStyle.Render("..."))
+	}
+	return append(lines, "", footer)
+}
+
+// renderSparkline renders durations as a sparkline of at most width
+// characters (most recent last), scaled from the fastest to the slowest
+func renderSparkline(values []int64, width int) string {
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	lowest, highest := values[0], values[0]
+	for _, v := range values {
+		if v < lowest {
+			lowest = v
+		}
+		if v > highest {
+			highest = v
+		}
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if highest > lowest {
+			level = int((v - lowest) * int64(len(sparkBlocks)-1) / (highest - lowest))
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return lipgloss.NewStyle().Foreground(ColorSecondary).Render(b.String())
+}
+
+// formatBuildDuration formats a build duration compactly (12.3s, 2m05s)
+func formatBuildDuration(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "-"
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
@@ -0,0 +1,357 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// buildErrorsMax is the number of error locations kept for a build
+const buildErrorsMax = 100
+
+var (
+	// buildErrorPosition matches file:line[:col] (go, gcc, eslint, vite) and
+	// file(line,col) (tsc) positions in build output
+	buildErrorPosition = regexp.MustCompile(`((?:[A-Za-z]:)?[^\s:()'"]+\.[A-Za-z0-9]+)(?::(\d+)(?::(\d+))?|\((\d+),(\d+)\))`)
+	diffHunkHeader     = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+)
+
+// buildErrorLocation is a source position reported by a failed build
+type buildErrorLocation struct {
+	file        string // Relative to the project
+	line        int
+	column      int
+	message     string
+	fileChanged bool // The file has working tree changes
+	lineChanged bool // The line is in a changed hunk
+}
+
+// buildErrors are the error locations of the last failed build, correlated
+// with the working tree changes of its project
+type buildErrors struct {
+	buildID   string
+	projectID string
+	loading   bool
+	locations []buildErrorLocation
+}
+
+// buildErrorsMsg carries the correlated errors of a build
+type buildErrorsMsg struct {
+	errors *buildErrors
+}
+
+// workingTreeChange is a file changed in the working tree and its changed
+// line ranges (nil = the whole file is new)
+type workingTreeChange struct {
+	ranges [][2]int
+}
+
+// correlateBuildErrors starts the correlation of the errors of the current
+// build with the git diff of its project, once per failed build
+func (m *Model) correlateBuildErrors() tea.Cmd {
+	if m.state.Builds == nil || m.state.Builds.CurrentBuild == nil {
+		return nil
+	}
+	b := m.state.Builds.CurrentBuild
+	if string(b.Status) != "failed" || (m.buildView().errors != nil && m.buildView().errors.buildID == b.ID) {
+		return nil
+	}
+	proj := m.findProjectVM(b.ProjectID)
+	if proj == nil {
+		return nil
+	}
+
+	// Build tools report positions relative to the component directory
+	workDir := proj.Path
+	for _, c := range proj.Components {
+		if c.Type == b.Component && c.Path != "" {
+			workDir = filepath.Join(proj.Path, c.Path)
+		}
+	}
+	output := append(append([]string(nil), b.Errors...), b.Output...)
+	errors := &buildErrors{buildID: b.ID, projectID: proj.ID, loading: true}
+	m.buildView().errors = errors
+
+	projectPath := proj.Path
+	return func() tea.Msg {
+		locations := findBuildErrors(projectPath, workDir, output)
+		if len(locations) > 0 {
+			changes := workingTreeChanges(projectPath)
+			for i := range locations {
+				change, ok := changes[locations[i].file]
+				locations[i].fileChanged = ok
+				locations[i].lineChanged = ok && change.touches(locations[i].line)
+			}
+		}
+		return buildErrorsMsg{errors: &buildErrors{buildID: errors.buildID, projectID: errors.projectID, locations: locations}}
+	}
+}
+
+// findBuildErrors extracts the positions of project files from build output
+func findBuildErrors(projectPath, workDir string, output []string) []buildErrorLocation {
+	var locations []buildErrorLocation
+	seen := make(map[string]bool)
+	for _, text := range output {
+		for _, line := range strings.Split(text, "\n") {
+			match := buildErrorPosition.FindStringSubmatchIndex(line)
+			if match == nil {
+				continue
+			}
+			file := line[match[2]:match[3]]
+			lineNo, column := submatchInt(line, match, 2), submatchInt(line, match, 3)
+			if match[8] >= 0 {
+				lineNo, column = submatchInt(line, match, 4), submatchInt(line, match, 5)
+			}
+			if lineNo == 0 {
+				continue
+			}
+
+			path := file
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(workDir, path)
+			}
+			rel, err := filepath.Rel(projectPath, path)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				continue
+			}
+			if info, err := os.Stat(path); err != nil || info.IsDir() {
+				continue // Not a source file (URL, module path...)
+			}
+			rel = filepath.ToSlash(rel)
+			key := rel + ":" + strconv.Itoa(lineNo)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			message := strings.TrimLeft(line[match[1]:], ": ")
+			if message == "" {
+				message = strings.TrimSpace(line[:match[0]])
+			}
+			locations = append(locations, buildErrorLocation{file: rel, line: lineNo, column: column, message: message})
+			if len(locations) >= buildErrorsMax {
+				return locations
+			}
+		}
+	}
+	return locations
+}
+
+// submatchInt returns the nth group of a regexp match as a number, 0 if absent
+func submatchInt(s string, match []int, n int) int {
+	if match[2*n] < 0 {
+		return 0
+	}
+	value, _ := strconv.Atoi(s[match[2*n]:match[2*n+1]])
+	return value
+}
+
+// workingTreeChanges returns the files changed since HEAD (staged or not)
+// with their changed lines, by path relative to the project
+func workingTreeChanges(projectPath string) map[string]*workingTreeChange {
+	changes := make(map[string]*workingTreeChange)
+
+	cmd := exec.Command("git", "diff", "HEAD", "--relative", "--no-color", "--no-ext-diff", "-U0")
+	cmd.Dir = projectPath
+	output, err := cmd.Output()
+	if err != nil {
+		// No commit yet: unstaged changes only
+		cmd = exec.Command("git", "diff", "--relative", "--no-color", "--no-ext-diff", "-U0")
+		cmd.Dir = projectPath
+		output, _ = cmd.Output()
+	}
+	var current *workingTreeChange
+	for _, line := range strings.Split(string(output), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			current = nil
+			if path, ok := strings.CutPrefix(line, "+++ b/"); ok {
+				current = &workingTreeChange{ranges: [][2]int{}}
+				changes[path] = current
+			}
+		case current != nil && strings.HasPrefix(line, "@@"):
+			match := diffHunkHeader.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			start, _ := strconv.Atoi(match[1])
+			count := 1
+			if match[2] != "" {
+				count, _ = strconv.Atoi(match[2])
+			}
+			if count == 0 {
+				// Deletion after line start: the lines around it changed
+				current.ranges = append(current.ranges, [2]int{start, start + 1})
+			} else {
+				current.ranges = append(current.ranges, [2]int{start, start + count - 1})
+			}
+		}
+	}
+
+	// Untracked files are new as a whole
+	cmd = exec.Command("git", "ls-files", "--others", "--exclude-standard")
+	cmd.Dir = projectPath
+	if output, err := cmd.Output(); err == nil {
+		for _, path := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+			if path != "" {
+				changes[path] = &workingTreeChange{}
+			}
+		}
+	}
+	return changes
+}
+
+// touches returns true if a line is in a changed range
+func (c *workingTreeChange) touches(line int) bool {
+	if c.ranges == nil {
+		return true // New file
+	}
+	for _, r := range c.ranges {
+		if line >= r[0] && line <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// currentBuildErrors returns the correlated errors of the current build, nil
+// if it did not fail or they are not known yet
+func (m *Model) currentBuildErrors() *buildErrors {
+	if m.buildView().errors == nil || m.state.Builds == nil || m.state.Builds.CurrentBuild == nil ||
+		m.buildView().errors.buildID != m.state.Builds.CurrentBuild.ID {
+		return nil
+	}
+	return m.buildView().errors
+}
+
+// jumpToBuildError shows the diff of the file of the selected build error in
+// the Git view, scrolled to the error line
+func (m *Model) jumpToBuildError() tea.Cmd {
+	errors := m.currentBuildErrors()
+	if errors == nil || m.mainIndex < 0 || m.mainIndex >= len(errors.locations) {
+		return nil
+	}
+	location := errors.locations[m.mainIndex]
+	if !location.fileChanged {
+		m.lastError = location.file + " is not changed in the working tree"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	return m.showFileInGit(errors.projectID, location.file, location.line)
+}
+
+// showFileInGit selects a changed file of a project in the Git view and
+// loads its diff, scrolled to a line of the new version (0 = top)
+func (m *Model) showFileInGit(projectID, file string, line int) tea.Cmd {
+	cmd := m.showProjectInGit(projectID)
+	item := m.gitView().menu.SelectedItem()
+	if item == nil {
+		return cmd
+	}
+	if status, ok := item.Data.(core.GitStatusVM); !ok || status.ProjectID != projectID || !m.gitView().menu.DrillDown() {
+		return cmd
+	}
+
+	selected := -1
+	for i, item := range m.gitView().menu.VisibleItems() {
+		// The working tree diff first when the file is also staged
+		if entry, ok := item.Data.(GitFileEntry); ok && entry.Path == file && (selected < 0 || entry.Status == "modified") {
+			selected = i
+		}
+	}
+	if selected < 0 {
+		return cmd
+	}
+	m.gitView().menu.SetSelectedIndex(selected)
+	m.gitView().menu.ensureSelectionVisible()
+	m.focusArea = FocusDetail
+	m.gitView().lastSelectedFile = "" // Reload even if it was the shown diff
+	m.gitView().diffJumpLine = line
+	return tea.Batch(cmd, m.loadGitDiffForSelection())
+}
+
+// diffLineIndex returns the index of the diff line showing a line of the new
+// version of the file, or of the closest hunk before it
+func diffLineIndex(lines []string, target int) int {
+	index, newLine := 0, 0
+	for i, line := range lines {
+		if match := diffHunkHeader.FindStringSubmatch(line); match != nil {
+			newLine, _ = strconv.Atoi(match[1])
+			if newLine <= target {
+				index = i
+			}
+			continue
+		}
+		if newLine == 0 || strings.HasPrefix(line, "-") || strings.HasPrefix(line, "\\") {
+			continue
+		}
+		if newLine == target {
+			return i
+		}
+		newLine++
+	}
+	return index
+}
+
+// renderBuildErrors renders the error locations of a failed build with their
+// working tree markers, nil while they are not known
+func (m *Model) renderBuildErrors(width, maxLines int) []string {
+	errors := m.currentBuildErrors()
+	if errors == nil || errors.loading || len(errors.locations) == 0 {
+		return nil
+	}
+
+	changed := 0
+	for _, l := range errors.locations {
+		if l.lineChanged {
+			changed++
+		}
+	}
+	title := fmt.Sprintf("Errors (%d)", len(errors.locations))
+	if changed > 0 {
+		title += fmt.Sprintf(" · %d in lines changed in the working tree", changed)
+	}
+	lines := []string{SubtitleStyle.Render(title)}
+
+	// Keep the selection visible
+	visible := max(maxLines-2, 1)
+	selected := min(max(m.mainIndex, 0), len(errors.locations)-1)
+	start := max(selected-visible+1, 0)
+	end := min(start+visible, len(errors.locations))
+
+	lineChangedStyle := lipgloss.NewStyle().Foreground(ColorError).Bold(true)
+	for i := start; i < end; i++ {
+		l := errors.locations[i]
+		marker, note := SubtitleStyle.Render("○"), ""
+		switch {
+		case l.lineChanged:
+			marker, note = lineChangedStyle.Render("●"), lineChangedStyle.Render(" changed here")
+		case l.fileChanged:
+			marker, note = StatusWarning.Render("◐"), StatusWarning.Render(" file changed")
+		}
+		position := fmt.Sprintf("%s:%d", l.file, l.line)
+		if l.column > 0 {
+			position += fmt.Sprintf(":%d", l.column)
+		}
+		row := fmt.Sprintf("%s %s %s%s", marker, GitBranchStyle.Render(position), l.message, note)
+		if i == selected && m.focusArea == FocusMain {
+			row = FocusIndicator + " " + row
+		} else {
+			row = "  " + row
+		}
+		lines = append(lines, truncateANSI(row, width))
+	}
+	lines = append(lines, SubtitleStyle.Render(truncate(
+		"● changed line  ◐ changed file  ○ unchanged   ↑↓ select  Enter show diff", width)))
+	return lines
+}
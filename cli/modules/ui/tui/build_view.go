@@ -0,0 +1,405 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// buildController is the submodel of the Build view
+type buildController struct {
+	profile       string       // "dev", "test", "prod"
+	showAnalytics bool         // Show the history analytics
+	errors        *buildErrors // Error locations of the last failed build
+}
+
+// newBuildController creates the Build view controller
+func newBuildController() *buildController {
+	return &buildController{profile: "dev"}
+}
+
+// buildView returns the Build view submodel
+func (m *Model) buildView() *buildController {
+	return m.controllers[core.VMBuild].(*buildController)
+}
+
+// Init implements ViewController
+func (c *buildController) Init(m *Model) tea.Cmd {
+	return nil
+}
+
+// Menu implements ViewController (the build history is index-based)
+func (c *buildController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	return nil
+}
+
+// Keys implements ViewController
+func (c *buildController) Keys(m *Model) []KeyHint {
+	// Profile shortcuts
+	hints := []KeyHint{
+		{"d", "dev"},
+		{"t", "test"},
+		{"p", "prod"},
+		{"←→", "cycle"},
+	}
+	if m.state.Builds != nil && m.state.Builds.IsBuilding {
+		hints = append(hints, KeyHint{"CTRL+c", "cancel"})
+	} else {
+		hints = append(hints, KeyHint{"b", "build"}, KeyHint{"CTRL+b", "all"})
+	}
+	if m.state.Builds != nil && !m.state.Builds.SecurityScanning {
+		hints = append(hints, KeyHint{"v", "vuln scan"})
+	}
+	if m.currentBuildErrors() != nil {
+		hints = append(hints, KeyHint{"Enter", "error diff"})
+	}
+	return append(hints, KeyHint{"a", "analytics"})
+}
+
+// Update implements ViewController
+func (c *buildController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case stateUpdateMsg:
+		if msg.update.Affects(core.VMBuild) {
+			return m.correlateBuildErrors(), false
+		}
+	case buildErrorsMsg:
+		if c.errors != nil && c.errors.buildID == msg.errors.buildID {
+			c.errors = msg.errors
+			if m.currentView == core.VMBuild {
+				m.mainIndex = 0
+			}
+		}
+	case itemCountMsg:
+		if errors := m.currentBuildErrors(); errors != nil && !c.showAnalytics {
+			m.maxMainItems = len(errors.locations)
+		} else if m.state.Builds != nil {
+			m.maxMainItems = len(m.state.Builds.BuildHistory)
+		}
+	case selectMsg:
+		return m.jumpToBuildError(), true
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "d", "1":
+			c.profile = "dev"
+			return nil, true
+		case "t", "2":
+			c.profile = "test"
+			return nil, true
+		case "p", "3":
+			c.profile = "prod"
+			return nil, true
+		case "left":
+			// Cycle profiles backward: dev <- test <- prod <- dev
+			switch c.profile {
+			case "dev":
+				c.profile = "prod"
+			case "test":
+				c.profile = "dev"
+			case "prod":
+				c.profile = "test"
+			}
+			return nil, true
+		case "right":
+			// Cycle profiles forward: dev -> test -> prod -> dev
+			switch c.profile {
+			case "dev":
+				c.profile = "test"
+			case "test":
+				c.profile = "prod"
+			case "prod":
+				c.profile = "dev"
+			}
+			return nil, true
+		case "b":
+			return m.buildSelected(), true
+		case "v":
+			return m.scanSecurity(), true
+		case "i":
+			return m.rebuildImage(), true
+		case "a":
+			c.showAnalytics = !c.showAnalytics
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// View implements ViewController
+func (c *buildController) View(m *Model, width, height int) string {
+	return m.renderBuild(width, height)
+}
+
+// renderBuild renders the build view
+func (m *Model) renderBuild(width, height int) string {
+	vm := m.state.Builds
+	if vm == nil {
+		return m.renderLoading()
+	}
+
+	// Profile selector bar
+	profiles := []struct {
+		key  string
+		name string
+		desc string
+	}{
+		{"dev", "DEV", "Debug symbols, verbose"},
+		{"test", "TEST", "Race detection"},
+		{"prod", "PROD", "Optimized, stripped"},
+	}
+
+	var profileButtons []string
+	for _, p := range profiles {
+		if m.buildView().profile == p.key {
+			profileButtons = append(profileButtons, ButtonActiveStyle.Render(p.name))
+		} else {
+			profileButtons = append(profileButtons, ButtonStyle.Render(p.name))
+		}
+	}
+	profileBar := lipgloss.JoinHorizontal(lipgloss.Center,
+		SubtitleStyle.Render("Profile: "),
+		strings.Join(profileButtons, " "),
+		"  ",
+		SubtitleStyle.Render(m.getProfileDescription()),
+	)
+
+	var style lipgloss.Style
+	if m.focusArea == FocusMain {
+		style = FocusedBorderStyle
+	} else {
+		style = UnfocusedBorderStyle
+	}
+
+	if m.buildView().showAnalytics {
+		return style.Width(width - 2).Height(height - 2).Render(
+			lipgloss.JoinVertical(lipgloss.Left,
+				profileBar,
+				"",
+				strings.Join(m.renderBuildAnalytics(vm.Analytics, width-6, height-5), "\n"),
+			),
+		)
+	}
+
+	// Recent container images, when some were built
+	imageLines := m.renderImageHistory(vm)
+	imageRoom := 0
+	if len(imageLines) > 0 {
+		imageRoom = len(imageLines) + 2
+	}
+
+	// Current build status: the image build if started last
+	imageBuild := vm.ImageBuild
+	if imageBuild != nil && vm.CurrentBuild != nil && vm.CurrentBuild.StartedAt.After(imageBuild.StartedAt) {
+		imageBuild = nil
+	}
+	var buildStatus string
+	if imageBuild != nil {
+		maxLines := height - 16 - imageRoom
+		if len(vm.SecurityScans) > 0 || vm.SecurityScanning {
+			maxLines -= (height - 16) / 2
+		}
+		buildStatus = strings.Join(m.renderImageBuild(imageBuild, width-6, max(maxLines, 2)), "\n") + "\n"
+	} else if vm.CurrentBuild != nil {
+		b := vm.CurrentBuild
+		switch string(b.Status) {
+		case "failed":
+			buildStatus = fmt.Sprintf("%s Build failed: %s/%s (%s)\n",
+				StatusError.Render(IconError), b.ProjectID, b.Component, b.Duration)
+		case "success":
+			buildStatus = fmt.Sprintf("%s Built %s/%s (%s)\n",
+				StatusSuccess.Render(IconSuccess), b.ProjectID, b.Component, b.Duration)
+		default:
+			progress := renderProgressBar(b.Progress, 20)
+			buildStatus = fmt.Sprintf(
+				"%s Building %s/%s [%s] %s\n",
+				m.spinner.View(),
+				b.ProjectName,
+				b.Component,
+				strings.ToUpper(m.buildView().profile),
+				progress,
+			)
+		}
+
+		// Build output (last lines) - leave room for the vulnerabilities panel
+		outputLines := b.Output
+		maxLines := height - 16 - imageRoom
+		if len(vm.SecurityScans) > 0 || vm.SecurityScanning {
+			maxLines -= (height - 16) / 2
+		}
+		if errorLines := m.renderBuildErrors(width-6, maxLines); errorLines != nil {
+			// Failed build: error locations marked against the working tree
+			buildStatus += strings.Join(errorLines, "\n") + "\n"
+			outputLines = nil
+		}
+		if len(outputLines) > maxLines {
+			outputLines = outputLines[len(outputLines)-maxLines:]
+		}
+		for _, line := range outputLines {
+			buildStatus += LogInfoStyle.Render(truncate(line, width-10)) + "\n"
+		}
+	} else if vm.IsBuilding {
+		buildStatus = m.spinner.View() + " Building..."
+	} else {
+		buildStatus = SubtitleStyle.Render("No active build. Press 'b' to build, 'B' for all.")
+	}
+
+	// Build history
+	historyTitle := SubtitleStyle.Render("Recent Builds")
+	var historyLines []string
+	for i, b := range vm.BuildHistory {
+		if i >= 5 {
+			break
+		}
+		statusIcon := StatusSuccess.Render(IconSuccess)
+		if string(b.Status) == "failed" {
+			statusIcon = StatusError.Render(IconError)
+		}
+		line := fmt.Sprintf("  %s %s/%s %s",
+			statusIcon, truncate(b.ProjectName, 10), b.Component, b.Duration)
+		if details := strings.TrimSpace(strings.ToUpper(b.Profile) + " " + b.GitHash); details != "" {
+			line += " " + SubtitleStyle.Render(details)
+		}
+		historyLines = append(historyLines, line)
+	}
+	if len(historyLines) == 0 {
+		historyLines = append(historyLines, SubtitleStyle.Render("  No build history"))
+	}
+
+	images := ""
+	if len(imageLines) > 0 {
+		images = "\n" + SubtitleStyle.Render("Recent Images") + "\n" + strings.Join(imageLines, "\n") + "\n"
+	}
+
+	// Vulnerabilities (latest security scans), next to build status
+	vulnTitle := SubtitleStyle.Render("Vulnerabilities")
+	if vm.SecurityScanning {
+		vulnTitle += " " + m.spinner.View() + SubtitleStyle.Render(" scanning...")
+	}
+	vulnLines := m.renderVulnerabilities(vm, width-6, max(3, (height-16)/2))
+
+	// 1 panel: width 1 × 2 = 2
+	return style.Width(width - 2).Height(height - 2).Render(
+		lipgloss.JoinVertical(lipgloss.Left,
+			profileBar,
+			"",
+			buildStatus,
+			"",
+			historyTitle,
+			strings.Join(historyLines, "\n"),
+			images,
+			vulnTitle,
+			strings.Join(vulnLines, "\n"),
+		),
+	)
+}
+
+// renderVulnerabilities renders security scan findings grouped by severity
+func (m *Model) renderVulnerabilities(vm *core.BuildsVM, width, maxLines int) []string {
+	if len(vm.SecurityScans) == 0 {
+		if vm.SecurityScanning {
+			return nil
+		}
+		hint := "  No scan yet. Press 'v' to scan."
+		if m.state.Capabilities != nil && !m.state.Capabilities.HasSecurityScan() {
+			hint = "  No scanner found (install govulncheck or npm)"
+		}
+		return []string{SubtitleStyle.Render(hint)}
+	}
+
+	var lines []string
+
+	// Per-component summary line
+	for _, scan := range vm.SecurityScans {
+		label := fmt.Sprintf("%s/%s", truncate(scan.ProjectName, 12), scan.Component)
+		switch {
+		case scan.Error != "":
+			lines = append(lines, fmt.Sprintf("  %s %s %s",
+				StatusError.Render(IconError), label, LogErrorStyle.Render(truncate(scan.Error, width-len(label)-6))))
+		case len(scan.Findings) == 0:
+			lines = append(lines, fmt.Sprintf("  %s %s %s",
+				StatusSuccess.Render(IconSuccess), label, SubtitleStyle.Render(scan.Scanner+" · clean")))
+		default:
+			lines = append(lines, fmt.Sprintf("  %s %s %s",
+				StatusWarning.Render(IconWarning), label,
+				SubtitleStyle.Render(fmt.Sprintf("%s · %d found", scan.Scanner, len(scan.Findings)))))
+		}
+	}
+
+	// Findings grouped by severity (most severe first)
+	for _, sev := range []string{"critical", "high", "moderate", "low", "info"} {
+		var group []string
+		for _, scan := range vm.SecurityScans {
+			for _, f := range scan.Findings {
+				if f.Severity != sev {
+					continue
+				}
+				fix := LogErrorStyle.Render("no fix")
+				if f.FixedVersion != "" {
+					fix = StatusSuccess.Render("fix " + f.FixedVersion)
+				}
+				group = append(group, fmt.Sprintf("    %s %s %s  %s",
+					truncate(f.ID, 20), truncate(f.Package, 30), fix,
+					SubtitleStyle.Render(truncate(f.Title, max(10, width-70)))))
+				if len(f.Paths) > 0 {
+					group = append(group, SubtitleStyle.Render("      "+truncate(f.Paths[0], width-8)))
+				}
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+		header := lipgloss.NewStyle().Foreground(severityColor(sev)).Bold(true).
+			Render(fmt.Sprintf("  %s (%d)", strings.ToUpper(sev), countFindingsBySeverity(vm.SecurityScans, sev)))
+		lines = append(lines, header)
+		lines = append(lines, group...)
+	}
+
+	if len(lines) > maxLines {
+		more := len(lines) - maxLines + 1
+		lines = append(lines[:maxLines-1], SubtitleStyle.Render(fmt.Sprintf("  ... %d more lines", more)))
+	}
+	return lines
+}
+
+// countFindingsBySeverity counts findings of a severity across scans
+func countFindingsBySeverity(scans []core.SecurityScanVM, severity string) int {
+	count := 0
+	for _, scan := range scans {
+		for _, f := range scan.Findings {
+			if f.Severity == severity {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// severityColor returns the display color for a vulnerability severity
+func severityColor(severity string) lipgloss.Color {
+	switch severity {
+	case "critical", "high":
+		return ColorError
+	case "moderate":
+		return ColorWarning
+	default:
+		return ColorInfo
+	}
+}
+
+// getProfileDescription returns description for current build profile
+func (m *Model) getProfileDescription() string {
+	switch m.buildView().profile {
+	case "dev":
+		return "Debug symbols, verbose output"
+	case "test":
+		return "Race detection enabled"
+	case "prod":
+		return "Optimized, symbols stripped"
+	default:
+		return ""
+	}
+}
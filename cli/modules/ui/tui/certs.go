@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/certs"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// generateCertSelected generates (or renews) the local TLS certificate of the
+// selected component
+func (m *Model) generateCertSelected() tea.Cmd {
+	projectID := m.getSelectedProjectID()
+	component := m.getSelectedComponent()
+	if projectID == "" || component == "" {
+		m.lastError = "Select a component to generate its TLS certificate"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	return m.sendEvent(core.NewEvent(core.EventGenerateCert).WithProject(projectID).WithComponent(component))
+}
+
+// certActionLabel returns the context menu label of the certificate action
+// of a component
+func certActionLabel(comp *core.ComponentVM) string {
+	cert := comp.Certificate
+	if cert == nil || cert.Error != "" {
+		return "Generate TLS certificate"
+	}
+	return "Renew TLS certificate (expires " + certExpiry(cert.NotAfter) + ")"
+}
+
+// certExpiry describes when a certificate expires ("in 12 days", "expired")
+func certExpiry(notAfter time.Time) string {
+	left := time.Until(notAfter)
+	if left <= 0 {
+		return "expired"
+	}
+	return fmt.Sprintf("in %d days", int(left.Hours()/24)+1)
+}
+
+// certificateLines renders the certificate of a component in its detail panel
+func certificateLines(cert *core.CertificateVM, width int) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	if cert.Error != "" {
+		return []string{
+			StatusWarning.Render(truncate("TLS: certificate "+cert.Error, width)),
+			mutedStyle.Render("  m → Generate TLS certificate"),
+		}
+	}
+
+	status := StatusSuccess.Render("TLS: valid until " + cert.NotAfter.Format("2006-01-02"))
+	left := cert.ExpiresIn()
+	if left <= 0 {
+		status = StatusError.Render("TLS: expired on " + cert.NotAfter.Format("2006-01-02"))
+	} else if left < certs.ExpiryWarning {
+		status = StatusWarning.Render("TLS: expires " + certExpiry(cert.NotAfter))
+	}
+	if cert.Generator != "" {
+		status += mutedStyle.Render(" (" + string(cert.Generator) + ")")
+	}
+	return []string{
+		status,
+		mutedStyle.Render(truncate("  "+strings.Join(cert.Hosts, ", "), width)),
+		mutedStyle.Render(truncate("  "+cert.CertFile, width)),
+	}
+}
+
+// renderCertWarnings renders the certificate warnings of the Dashboard on one
+// line, empty without any
+func renderCertWarnings(warnings []string, width int) string {
+	if len(warnings) == 0 {
+		return ""
+	}
+	line := "⚠ " + warnings[0]
+	if len(warnings) > 1 {
+		line += fmt.Sprintf(" (+%d more, see the components)", len(warnings)-1)
+	}
+	return StatusWarning.Render(truncate(line, width))
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openClaudeCleanup shows the dry run of the session cleanup in place of the
+// chat: the stale sessions the retention policy would remove
+func (m *Model) openClaudeCleanup() tea.Cmd {
+	m.claudeView().cleanup = true
+	if m.state.Claude != nil {
+		m.state.Claude.Cleanup = nil // Scanning until the preview arrives
+	}
+	m.focusArea = FocusMain
+	m.terminalMode = false
+	m.claudeView().inputActive = false
+	m.claudeView().textInput.Blur()
+	m.claudeView().chatScroll = 999999 // Read from the top, clamped on render
+	return m.sendEvent(core.NewEvent(core.EventClaudeCleanupPreview))
+}
+
+// cleanupPreview returns the cleanup dry run, nil while scanning
+func (m *Model) cleanupPreview() *core.ClaudeCleanupVM {
+	if m.state.Claude == nil {
+		return nil
+	}
+	return m.state.Claude.Cleanup
+}
+
+// handleClaudeCleanupKey handles the keys of the cleanup preview: x moves
+// the listed sessions to the trash, p closes it. Returns false if not handled.
+func (m *Model) handleClaudeCleanupKey(key string) (tea.Cmd, bool) {
+	switch key {
+	case "p":
+		m.claudeView().cleanup = false
+		return nil, true
+	case "x":
+		preview := m.cleanupPreview()
+		if preview == nil || len(preview.Sessions) == 0 {
+			return nil, true
+		}
+		return m.openConfirmDialog(config.ConfirmDeleteSession, "claude_cleanup",
+			fmt.Sprintf("Move %d stale session(s) to the trash?", len(preview.Sessions))), true
+	}
+	return nil, false
+}
+
+// cleanupPolicy describes the retention policy of a cleanup preview
+func cleanupPolicy(preview *core.ClaudeCleanupVM) string {
+	sessions := "Unnamed sessions"
+	if preview.IncludeNamed {
+		sessions = "Sessions"
+	}
+	policy := fmt.Sprintf("%s inactive for %d+ days", sessions, preview.MaxAgeDays)
+	if preview.MinMessages > 0 {
+		policy += fmt.Sprintf(" with fewer than %d messages", preview.MinMessages)
+	}
+	return policy
+}
+
+// renderClaudeCleanup renders the cleanup preview in a bordered panel of the
+// given outer size
+func (m *Model) renderClaudeCleanup(width, height int) string {
+	innerWidth := width - 2 // Padding (the border is outside the width)
+	visible := height - 2   // Title and key hints lines
+	if visible < 1 {
+		visible = 1
+	}
+
+	title := StatusWarning.Render("🧹 Session cleanup")
+	var body []string
+	preview := m.cleanupPreview()
+	if preview == nil {
+		body = append(body, mdMutedStyle.Render("Scanning sessions..."))
+	} else {
+		title += mdMutedStyle.Render(fmt.Sprintf("  %d to remove (dry run)", len(preview.Sessions)))
+		body = append(body, mdMutedStyle.Render(cleanupPolicy(preview)), "")
+		if len(preview.Sessions) == 0 {
+			body = append(body, StatusSuccess.Render("No session matches the policy"))
+		}
+		nameWidth := max(innerWidth-42, 12)
+		for _, sess := range preview.Sessions {
+			age := formatAge(time.Since(sess.LastActiveAt))
+			body = append(body, fmt.Sprintf("%-*s %-20s %8s %5d msg",
+				nameWidth, truncate(sess.Name, nameWidth),
+				truncate(sess.ProjectName, 20), age, sess.MessageCount))
+		}
+	}
+
+	lines := []string{truncateANSI(title, innerWidth)}
+	// Scroll counts lines from the bottom, like the transcript
+	scroll := min(m.claudeView().chatScroll, max(len(body)-visible, 0))
+	end := len(body) - scroll
+	for i := max(end-visible, 0); i < end; i++ {
+		lines = append(lines, truncateANSI(body[i], innerWidth))
+	}
+	for len(lines) < visible+1 {
+		lines = append(lines, "")
+	}
+
+	hints := []KeyHint{{"x", "move to trash"}, {"p/Esc", "close"}, {"↑↓/PgUp/PgDn", "scroll"}}
+	lines = append(lines, truncateANSI(strings.Join(renderKeyHints(hints), ""), innerWidth))
+
+	style := UnfocusedBorderStyle
+	if m.focusArea == FocusMain {
+		style = FocusedBorderStyle
+	}
+	return style.
+		Width(width).
+		Height(height).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
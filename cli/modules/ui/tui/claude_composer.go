@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"csd-devtrack/cli/modules/platform/config"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// claudeComposerMaxLines is the height the composer grows to, longer
+// messages scroll inside it
+const claudeComposerMaxLines = 8
+
+// Keys sending a Claude message (claude.send_key setting)
+const (
+	claudeSendEnter    = "enter"     // Enter sends, Shift+Enter / Alt+Enter insert a newline
+	claudeSendAltEnter = "alt+enter" // Alt+Enter sends, Enter inserts a newline
+)
+
+// newClaudeComposer creates the multi-line input of the Claude chat. The
+// newline keys are handled by handleClaudeInput, depending on the send key.
+func newClaudeComposer() textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = "Type a message..."
+	ta.ShowLineNumbers = false
+	ta.Prompt = "┃ "
+	ta.CharLimit = 0 // Pasted files can be long
+	ta.MaxHeight = 0
+	ta.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	ta.FocusedStyle.Prompt = lipgloss.NewStyle().Foreground(ColorPrimary)
+	ta.BlurredStyle.Prompt = lipgloss.NewStyle().Foreground(ColorMuted)
+	ta.KeyMap.InsertNewline.SetEnabled(false)
+	ta.SetWidth(80)
+	ta.SetHeight(1)
+	return ta
+}
+
+// claudeEnterSends returns true if Enter sends the message (the default),
+// false if it inserts a newline and Alt+Enter sends
+func claudeEnterSends() bool {
+	cfg := config.GetGlobal()
+	if cfg == nil || cfg.Settings == nil || cfg.Settings.Claude == nil {
+		return true
+	}
+	return cfg.Settings.Claude.SendKey != claudeSendAltEnter
+}
+
+// claudeComposerKeys returns the send and newline keys shown in the hints
+func claudeComposerKeys() (send, newline string) {
+	if claudeEnterSends() {
+		return "Enter", "Shift+Enter"
+	}
+	return "Alt+Enter", "Enter"
+}
+
+// isClaudeSendKey returns true if an Enter key sends the message rather
+// than inserting a newline. Terminals set up for it send Shift+Enter as
+// Alt+Enter, the only modifier they report on Enter.
+func isClaudeSendKey(msg tea.KeyMsg) bool {
+	return msg.Alt != claudeEnterSends()
+}
+
+// insertIntoClaudeComposer inserts text at the cursor, keeping its line
+// breaks and indentation (tabs are expanded, the composer cannot render them)
+func (m *Model) insertIntoClaudeComposer(text string) {
+	m.claudeView().textInput.InsertString(strings.ReplaceAll(text, "\t", "    "))
+	m.resizeClaudeComposer()
+}
+
+// setClaudeComposerValue replaces the composer text (history recall)
+func (m *Model) setClaudeComposerValue(text string) {
+	m.claudeView().textInput.SetValue(text)
+	m.resizeClaudeComposer()
+}
+
+// claudeComposerOnFirstRow returns true if Up leaves the text: the cursor is
+// on the first visual row, where Up recalls the previous message
+func (m *Model) claudeComposerOnFirstRow() bool {
+	return m.claudeView().textInput.Line() == 0 && m.claudeView().textInput.LineInfo().RowOffset == 0
+}
+
+// claudeComposerOnLastRow returns true if the cursor is on the last visual
+// row, where Down recalls the next message
+func (m *Model) claudeComposerOnLastRow() bool {
+	info := m.claudeView().textInput.LineInfo()
+	return m.claudeView().textInput.Line() == m.claudeView().textInput.LineCount()-1 && info.RowOffset >= info.Height-1
+}
+
+// focusClaudeComposer starts typing a Claude message
+func (m *Model) focusClaudeComposer() tea.Cmd {
+	m.claudeView().textInput.Focus()
+	m.resizeClaudeComposer()
+	return textarea.Blink
+}
+
+// resizeClaudeComposer fits the composer to the chat panel width and grows
+// it with its text, up to claudeComposerMaxLines rows
+func (m *Model) resizeClaudeComposer() {
+	chatWidth, _ := claudePanelWidths(m.width - m.sidebarWidth() - GapHorizontal)
+	m.claudeView().textInput.SetWidth(max(chatWidth, 20))
+
+	width := max(m.claudeView().textInput.Width(), 1)
+	rows := 0
+	for _, line := range strings.Split(m.claudeView().textInput.Value(), "\n") {
+		rows += max((lipgloss.Width(line)+width)/width, 1)
+	}
+	m.claudeView().textInput.SetHeight(min(rows, claudeComposerMaxLines))
+}
+
+// renderClaudeComposer renders the composer below the chat, with the line
+// count of long messages
+func (m *Model) renderClaudeComposer(width int) string {
+	send, newline := claudeComposerKeys()
+	info := send + " send · " + newline + " newline"
+	if lines := m.claudeView().textInput.LineCount(); lines > 1 {
+		info += fmt.Sprintf(" · %d lines", lines)
+	}
+	footer := lipgloss.NewStyle().Foreground(ColorMuted).Render(info)
+
+	return FocusedBorderStyle.
+		Width(width).
+		Render(m.claudeView().textInput.View() + "\n" + footer)
+}
@@ -0,0 +1,341 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// planPathPattern matches a file path mentioned in a plan (dir/file.go,
+// file.go:12, ./cmd/main.go)
+var planPathPattern = regexp.MustCompile(`^[\w.~@-]*(/[\w.@-]+)*\.[A-Za-z][A-Za-z0-9]{0,7}(:\d+)?$`)
+
+// planReview is the structured rendering of a plan awaiting approval: its
+// steps as a checklist and the files it affects, shown in place of the chat
+type planReview struct {
+	sessionID string
+	content   string
+	steps     []planStep
+	files     []planFile
+
+	width  int      // Width the plan was rendered for
+	lines  []string // Rendered plan
+	height int      // Visible lines (set on render)
+}
+
+// planStep is a step of a plan (a list item, or a heading without lists)
+type planStep struct {
+	text  string
+	done  bool
+	depth int // 0 = top-level step, 1 = sub-step
+}
+
+// planFile is a file affected by a plan
+type planFile struct {
+	path   string
+	change byte // '+' created, '-' deleted, '~' modified
+}
+
+// planEditedMsg is sent when the editor of a plan exits
+type planEditedMsg struct {
+	sessionID string
+	path      string
+	original  string
+	err       error
+}
+
+// pendingPlan returns the plan of the active Claude session awaiting
+// approval, "" if none
+func (m *Model) pendingPlan() string {
+	c := m.state.Claude
+	if c == nil || !c.PlanPending || c.Interactive == nil || c.Interactive.Type != "plan" ||
+		c.ActiveSessionID != m.claudeView().activeSession {
+		return ""
+	}
+	return c.Interactive.PlanContent
+}
+
+// syncPlanReview shows the review panel when a plan awaits approval, and
+// closes it once answered
+func (m *Model) syncPlanReview() {
+	content := m.pendingPlan()
+	if content == "" {
+		m.claudeView().planReview = nil
+		return
+	}
+	if r := m.claudeView().planReview; r != nil && r.sessionID == m.claudeView().activeSession && r.content == content {
+		if r.lines != nil {
+			m.claudeView().chatScroll = min(m.claudeView().chatScroll, max(len(r.lines)-r.height, 0))
+		}
+		return
+	}
+	steps, files := parsePlan(content)
+	m.claudeView().planReview = &planReview{sessionID: m.claudeView().activeSession, content: content, steps: steps, files: files}
+
+	// Read from the top, keys go to the review
+	m.claudeView().chatScroll = 999999 // Clamped on render
+	m.terminalMode = false
+	m.claudeView().inputActive = false
+	m.claudeView().textInput.Blur()
+}
+
+// parsePlan extracts the steps and the affected files of a plan
+func parsePlan(content string) ([]planStep, []planFile) {
+	var steps, headings []planStep
+	var files []planFile
+	seen := make(map[string]bool)
+	addFile := func(path string, change byte) {
+		path = strings.TrimPrefix(path, "./")
+		if i := strings.LastIndexByte(path, ':'); i > 0 && strings.Trim(path[i+1:], "0123456789") == "" {
+			path = path[:i] // Line number
+		}
+		if path == "" || seen[path] {
+			return
+		}
+		seen[path] = true
+		files = append(files, planFile{path: path, change: change})
+	}
+
+	baseIndent := -1
+	inCode, inDiff := false, false
+	removed := "" // Path of the last "---" line of a diff
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCode = !inCode
+			lang := strings.TrimSpace(trimmed[3:])
+			inDiff = inCode && (lang == "diff" || lang == "patch")
+			continue
+		}
+		if inCode {
+			// Diff blocks name their files in the headers
+			switch {
+			case !inDiff:
+			case strings.HasPrefix(line, "--- "):
+				removed = strings.TrimPrefix(strings.Fields(line[4:] + " ")[0], "a/")
+			case strings.HasPrefix(line, "+++ "):
+				added := strings.TrimPrefix(strings.Fields(line[4:] + " ")[0], "b/")
+				switch {
+				case added == "/dev/null":
+					addFile(removed, '-')
+				case removed == "/dev/null":
+					addFile(added, '+')
+				default:
+					addFile(added, '~')
+				}
+			}
+			continue
+		}
+
+		// Files: code spans and paths with a directory
+		change := planFileChange(trimmed)
+		for i, part := range strings.Split(trimmed, "`") {
+			for _, word := range strings.Fields(part) {
+				word = strings.Trim(word, `.,;:()[]{}"'*`)
+				if i%2 == 1 || strings.Contains(word, "/") {
+					if !strings.Contains(word, "://") && planPathPattern.MatchString(word) {
+						addFile(word, change)
+					}
+				}
+			}
+		}
+
+		if bullet, text, ok := markdownListItem(line); ok {
+			indent := len(line) - len(strings.TrimLeft(line, " \t"))
+			if baseIndent < 0 || indent < baseIndent {
+				baseIndent = indent
+			}
+			step := planStep{text: text, done: strings.Contains(bullet, "☑")}
+			if indent > baseIndent {
+				step.depth = 1
+			}
+			steps = append(steps, step)
+		} else if strings.HasPrefix(trimmed, "##") {
+			if text := strings.TrimSpace(strings.TrimLeft(trimmed, "#")); text != "" {
+				headings = append(headings, planStep{text: text})
+			}
+		}
+	}
+	if len(steps) == 0 {
+		steps = headings
+	}
+	return steps, files
+}
+
+// planFileChange guesses how a plan line changes the files it names
+func planFileChange(line string) byte {
+	lower := strings.ToLower(line)
+	switch {
+	case strings.Contains(lower, "delete"), strings.Contains(lower, "remove"):
+		return '-'
+	case strings.Contains(lower, "create"), strings.Contains(lower, "new file"), strings.HasPrefix(lower, "add "):
+		return '+'
+	}
+	return '~'
+}
+
+// render renders the review for a width: checklist of steps, affected files
+// diff-style, then the plan itself
+func (r *planReview) render(width int) {
+	r.lines = nil
+	r.width = width
+
+	r.lines = append(r.lines, mdH2Style.Render("Steps")+mdMutedStyle.Render(fmt.Sprintf(" (%d)", len(r.steps))))
+	number := 0
+	for _, step := range r.steps {
+		box := mdMutedStyle.Render("☐")
+		if step.done {
+			box = StatusSuccess.Render("☑")
+		}
+		first := "  " + box + " "
+		if step.depth == 0 {
+			number++
+			first += mdMutedStyle.Render(fmt.Sprintf("%d. ", number))
+		} else {
+			first = "    " + first
+		}
+		r.lines = append(r.lines, wrapStyled(renderInline(step.text), width, first, strings.Repeat(" ", ansi.StringWidth(first)))...)
+	}
+	if len(r.steps) == 0 {
+		r.lines = append(r.lines, mdMutedStyle.Render("  No steps found, see the plan below"))
+	}
+
+	r.lines = append(r.lines, "", mdH2Style.Render("Affected files")+mdMutedStyle.Render(fmt.Sprintf(" (%d)", len(r.files))))
+	for _, file := range r.files {
+		style := StatusWarning
+		switch file.change {
+		case '+':
+			style = mdAddedStyle
+		case '-':
+			style = mdRemovedStyle
+		}
+		r.lines = append(r.lines, "  "+style.Render(string(file.change)+" "+file.path))
+	}
+	if len(r.files) == 0 {
+		r.lines = append(r.lines, mdMutedStyle.Render("  None mentioned"))
+	}
+
+	r.lines = append(r.lines, "", mdMutedStyle.Render(strings.Repeat("─", width)))
+	r.lines = append(r.lines, renderMarkdown(r.content, width)...)
+}
+
+// handlePlanReviewKey handles the keys of the plan review: e edits the plan
+// and sends it back. Approval and rejection (y/n) go through the interactive
+// responses. Returns false if not handled.
+func (m *Model) handlePlanReviewKey(key string) (tea.Cmd, bool) {
+	switch key {
+	case "e":
+		return m.editPlan(), true
+	}
+	return nil, false
+}
+
+// editPlan opens the pending plan in the editor; the edited plan is sent
+// back to Claude in place of the rejected one
+func (m *Model) editPlan() tea.Cmd {
+	r := m.claudeView().planReview
+	if r == nil || m.blockReadOnly("plan edit") {
+		return nil
+	}
+	file, err := os.CreateTemp("", "devtrack-plan-*.md")
+	if err == nil {
+		_, err = file.WriteString(r.content)
+		file.Close()
+	}
+	if err != nil {
+		m.lastError = "Cannot edit the plan: " + err.Error()
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	path, sessionID, original := file.Name(), r.sessionID, r.content
+	return tea.ExecProcess(editorCommand(path, 0), func(err error) tea.Msg {
+		return planEditedMsg{sessionID: sessionID, path: path, original: original, err: err}
+	})
+}
+
+// handlePlanEdited rejects the pending plan and sends the edited one, unless
+// it was left unchanged
+func (m *Model) handlePlanEdited(msg planEditedMsg) tea.Cmd {
+	data, err := os.ReadFile(msg.path)
+	os.Remove(msg.path)
+	if msg.err == nil {
+		msg.err = err
+	}
+	if msg.err != nil {
+		m.lastError = "Plan edit failed: " + msg.err.Error()
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	edited := strings.TrimSpace(string(data))
+	if edited == "" || edited == strings.TrimSpace(msg.original) {
+		m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventInfo, "Plan unchanged, nothing sent"))
+		return nil
+	}
+	if m.pendingPlan() == "" || m.claudeView().activeSession != msg.sessionID {
+		m.lastError = "The plan is no longer awaiting approval"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	message := "I edited your plan. Follow this version instead:\n\n" + edited
+	m.claudeView().inputActive = true
+	m.claudeView().textInput.Focus()
+	return tea.Batch(
+		tea.Sequence(
+			m.sendEventSync(core.NewEvent(core.EventClaudeRejectPlan).WithData("session_id", msg.sessionID)),
+			m.sendEventSync(core.NewEvent(core.EventClaudeSendMessage).
+				WithData("session_id", msg.sessionID).
+				WithData("message", message)),
+		),
+		claudeRefreshCmd(),
+	)
+}
+
+// renderPlanReview renders the plan review in a bordered panel of the given
+// outer size
+func (m *Model) renderPlanReview(width, height int) string {
+	r := m.claudeView().planReview
+	innerWidth := width - 2 // Padding (the border is outside the width)
+	visible := height - 2   // Title and key hints lines
+	if visible < 1 {
+		visible = 1
+	}
+	if r.lines == nil || r.width != innerWidth {
+		r.render(innerWidth)
+	}
+	r.height = visible
+
+	title := StatusWarning.Render("📋 Plan awaiting approval") +
+		mdMutedStyle.Render(fmt.Sprintf("  %d steps, %d files", len(r.steps), len(r.files)))
+	lines := []string{truncateANSI(title, innerWidth)}
+
+	// Scroll counts lines from the bottom, like the transcript
+	scroll := min(m.claudeView().chatScroll, max(len(r.lines)-visible, 0))
+	end := len(r.lines) - scroll
+	for i := max(end-visible, 0); i < end; i++ {
+		lines = append(lines, truncateANSI(r.lines[i], innerWidth))
+	}
+	for len(lines) < visible+1 {
+		lines = append(lines, "")
+	}
+
+	hints := []KeyHint{{"y", "approve"}, {"n", "reject"}, {"e", "edit & resend"}, {"↑↓/PgUp/PgDn", "scroll"}}
+	lines = append(lines, truncateANSI(strings.Join(renderKeyHints(hints), ""), innerWidth))
+
+	style := UnfocusedBorderStyle
+	if m.focusArea == FocusMain {
+		style = FocusedBorderStyle
+	}
+	return style.
+		Width(width).
+		Height(height).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
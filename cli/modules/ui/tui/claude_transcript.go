@@ -0,0 +1,305 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/claude"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// claudeTranscriptPage is the number of messages rendered at a time, older
+// ones are rendered when scrolling past the top
+const claudeTranscriptPage = 200
+
+// claudeTranscript is the markdown rendering of the messages of a Claude
+// session, read from its JSONL file (shown in place of the terminal)
+type claudeTranscript struct {
+	sessionID string
+	reader    *claude.MessageReader // Parses the lines appended to the session file
+	reading   bool                  // A read is in progress (off the UI loop)
+	loaded    bool                  // The file was read once
+	messages  []claude.Message
+	shown     int // Last messages rendered (grows by a page on scroll to the top)
+	err       string
+
+	width    int                 // Width the messages were rendered for
+	lines    []string            // Rendered messages
+	blocks   []markdownCodeBlock // Code blocks (headers index lines)
+	selected int                 // Selected code block (-1 = last)
+	height   int                 // Visible lines (set on render)
+}
+
+// toggleClaudeTranscript shows the transcript of the active Claude session
+// in place of its terminal, or hides it
+func (m *Model) toggleClaudeTranscript() tea.Cmd {
+	if m.claudeView().transcript != nil {
+		m.claudeView().transcript = nil
+		return nil
+	}
+	if m.currentView != core.VMClaude || m.claudeView().activeSession == "" {
+		m.lastError = "No active Claude session"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	claudeProjectDir := ""
+	if m.state.Claude != nil {
+		for _, sess := range m.state.Claude.Sessions {
+			if sess.ID == m.claudeView().activeSession {
+				claudeProjectDir = sess.ClaudeProjectDir
+				break
+			}
+		}
+	}
+	path := getClaudeSessionFile(claudeProjectDir, m.claudeView().activeSession)
+	m.claudeView().transcript = &claudeTranscript{
+		sessionID: m.claudeView().activeSession,
+		reader:    claude.NewMessageReader(path, m.claudeView().activeSession),
+		shown:     claudeTranscriptPage,
+		selected:  -1,
+	}
+
+	// Keys now scroll the transcript
+	m.terminalMode = false
+	m.claudeView().inputActive = false
+	m.focusArea = FocusMain
+	m.claudeView().chatScroll = 0
+	return m.claudeView().transcript.read()
+}
+
+// syncClaudeTranscript follows the session file while the transcript is
+// shown, keeps the scroll within the content, and closes the transcript when
+// another session becomes active
+func (m *Model) syncClaudeTranscript() tea.Cmd {
+	t := m.claudeView().transcript
+	if t == nil {
+		return nil
+	}
+	if t.sessionID != m.claudeView().activeSession {
+		m.claudeView().transcript = nil
+		return nil
+	}
+	if t.lines != nil {
+		top := max(len(t.lines)-t.height, 0)
+		if m.claudeView().chatScroll > top && t.olderMessages() > 0 {
+			// Scrolled past the top: the previous page is rendered above,
+			// the scroll (from the bottom) keeps the current lines in place
+			m.claudeView().chatScroll = top
+			t.shown += claudeTranscriptPage
+			t.lines = nil
+			return tea.Batch(t.read(), m.sendEvent(core.NewEvent(core.EventClaudeLoadOlder)))
+		}
+		m.claudeView().chatScroll = min(m.claudeView().chatScroll, top)
+	}
+	return t.read()
+}
+
+// olderMessages returns the number of messages not rendered yet
+func (t *claudeTranscript) olderMessages() int {
+	return max(len(t.messages)-t.shown, 0)
+}
+
+// claudeTranscriptMsg carries the messages of a transcript after a read
+type claudeTranscriptMsg struct {
+	transcript *claudeTranscript
+	messages   []claude.Message
+	changed    bool
+	err        error
+}
+
+// read parses the lines appended to the session file in the background
+// (long sessions would stall the UI), nil if a read is in progress
+func (t *claudeTranscript) read() tea.Cmd {
+	if t.reading {
+		return nil
+	}
+	t.reading = true
+	reader := t.reader
+	return func() tea.Msg {
+		messages, changed, err := reader.Read()
+		return claudeTranscriptMsg{transcript: t, messages: messages, changed: changed, err: err}
+	}
+}
+
+// handleClaudeTranscriptMsg shows the messages read, and keeps the scroll
+// within the content
+func (m *Model) handleClaudeTranscriptMsg(msg claudeTranscriptMsg) {
+	t := msg.transcript
+	t.reading = false
+	if t != m.claudeView().transcript {
+		return // Closed meanwhile
+	}
+	if msg.err != nil {
+		if os.IsNotExist(msg.err) {
+			t.err = "No messages yet"
+		} else {
+			t.err = "Cannot read session: " + msg.err.Error()
+		}
+		return
+	}
+	if !msg.changed && t.loaded {
+		return
+	}
+	t.messages = msg.messages
+	t.loaded = true
+	t.err = ""
+	t.lines = nil
+}
+
+// render renders the messages for a width: user messages as plain text,
+// assistant messages as markdown
+func (t *claudeTranscript) render(width int) {
+	t.lines, t.blocks = nil, nil
+	t.width = width
+
+	userStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorSecondary)
+	assistantStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorPrimary)
+	timeStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	if older := t.olderMessages(); older > 0 {
+		t.lines = append(t.lines, SubtitleStyle.Render(fmt.Sprintf("↑ %d older messages, scroll up to load", older)))
+	}
+	for _, msg := range t.messages[t.olderMessages():] {
+		if len(t.lines) > 0 {
+			t.lines = append(t.lines, "")
+		}
+		header := assistantStyle.Render("● Claude")
+		if msg.Role == "user" {
+			header = userStyle.Render("> You")
+		}
+		if !msg.Timestamp.IsZero() {
+			header += " " + timeStyle.Render(msg.Timestamp.Local().Format("Jan 2 15:04"))
+		}
+		t.lines = append(t.lines, header)
+
+		if msg.Role == "user" {
+			for _, line := range strings.Split(strings.TrimSpace(msg.Content), "\n") {
+				t.lines = append(t.lines, wrapStyled(line, width, "  ", "  ")...)
+			}
+			continue
+		}
+		lines, blocks := renderMarkdownBlocks(msg.Content, width-2)
+		for _, block := range blocks {
+			block.header += len(t.lines)
+			t.blocks = append(t.blocks, block)
+		}
+		for _, line := range lines {
+			t.lines = append(t.lines, "  "+line)
+		}
+	}
+	if t.selected >= len(t.blocks) {
+		t.selected = -1
+	}
+}
+
+// selectedBlock returns the index of the selected code block, -1 if none
+func (t *claudeTranscript) selectedBlock() int {
+	if t.selected >= 0 && t.selected < len(t.blocks) {
+		return t.selected
+	}
+	return len(t.blocks) - 1
+}
+
+// handleClaudeTranscriptKey handles the transcript keys: code block selection
+// and copy, message copy, Esc to go back to the terminal. Returns false if
+// not handled.
+func (m *Model) handleClaudeTranscriptKey(key string) (tea.Cmd, bool) {
+	t := m.claudeView().transcript
+	switch key {
+	case "esc", "m":
+		m.claudeView().transcript = nil
+		return nil, true
+
+	case "[", "]":
+		if len(t.blocks) == 0 {
+			return nil, true
+		}
+		selected := t.selectedBlock()
+		if key == "[" {
+			selected = max(selected-1, 0)
+		} else {
+			selected = min(selected+1, len(t.blocks)-1)
+		}
+		t.selected = selected
+		// Scroll so the block header is at the top (scroll counts from the bottom)
+		m.claudeView().chatScroll = max(len(t.lines)-t.height-t.blocks[selected].header, 0)
+		return nil, true
+
+	case "y":
+		// Selected code block, the last message if there is none
+		selected := t.selectedBlock()
+		if selected < 0 {
+			return m.yankClaudeMessage(), true
+		}
+		code := t.blocks[selected].code
+		return m.yankText(fmt.Sprintf("code block %d (%d lines)", selected+1, strings.Count(code, "\n")+1), code), true
+
+	case "Y":
+		return m.yankClaudeMessage(), true
+	}
+	return nil, false
+}
+
+// renderClaudeTranscript renders the transcript in a bordered panel of the
+// given outer size
+func (m *Model) renderClaudeTranscript(width, height int) string {
+	t := m.claudeView().transcript
+	innerWidth := width - 2 // Padding (the border is outside the width)
+	visible := height - 1   // Key hints line
+	if visible < 1 {
+		visible = 1
+	}
+	if t.lines == nil || t.width != innerWidth {
+		t.render(innerWidth)
+	}
+	t.height = visible
+
+	var lines []string
+	if len(t.lines) == 0 {
+		message := t.err
+		if message == "" && !t.loaded {
+			message = "Loading transcript..."
+		} else if message == "" {
+			message = "No messages yet"
+		}
+		lines = append(lines, SubtitleStyle.Render(message))
+	} else {
+		// Scroll counts lines from the bottom (0 = most recent)
+		scroll := min(m.claudeView().chatScroll, max(len(t.lines)-visible, 0))
+		end := len(t.lines) - scroll
+		start := max(end-visible, 0)
+		selected := t.selectedBlock()
+		for i := start; i < end; i++ {
+			line := t.lines[i]
+			if selected >= 0 && i == t.blocks[selected].header {
+				line = "  " + mdMutedStyle.Render("▎ ") + TableRowSelectedStyle.Render(
+					fmt.Sprintf(" %d/%d %s ", selected+1, len(t.blocks), codeBlockLabel(t.blocks[selected].lang)))
+			}
+			lines = append(lines, truncateANSI(line, innerWidth))
+		}
+	}
+	for len(lines) < visible {
+		lines = append(lines, "")
+	}
+
+	hints := []KeyHint{{"↑↓/^U^D", "scroll"}, {"Esc", "terminal"}}
+	if len(t.blocks) > 0 {
+		hints = append([]KeyHint{{"[ ]", "code block"}, {"y", "copy"}, {"Y", "copy message"}}, hints...)
+	}
+	lines = append(lines, truncateANSI(strings.Join(renderKeyHints(hints), ""), innerWidth))
+
+	style := UnfocusedBorderStyle
+	if m.focusArea == FocusMain {
+		style = FocusedBorderStyle
+	}
+	return style.
+		Width(width).
+		Height(height).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
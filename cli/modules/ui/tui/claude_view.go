@@ -2,11 +2,18 @@ package tui
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"csd-devtrack/cli/modules/platform/config"
 	"csd-devtrack/cli/modules/ui/core"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -23,6 +30,613 @@ type claudeTreeItem struct {
 	SessionID string // Session ID (empty for projects)
 }
 
+// claudeController is the submodel of the Claude view
+type claudeController struct {
+	installed           bool             // Is Claude CLI installed
+	mode                string           // "sessions", "chat", "settings"
+	activeSession       string           // Active session ID
+	sessionLoading      bool             // Loading session data
+	deletingSessions    map[string]bool  // Sessions being deleted (for visual feedback)
+	showAllSessions     bool             // Show all sessions (default: only 10 most recent per project)
+	inputText           string           // Current input text (deprecated, use textInput)
+	inputActive         bool             // User is typing
+	chatScroll          int              // Scroll offset for chat messages
+	sessionScroll       int              // Scroll offset for session list
+	renameActive        bool             // Renaming a session
+	renameText          string           // New name for session
+	filterProject       string           // Filter sessions by project ID
+	projectSelectActive bool             // Project selection mode for new session
+	projectSelectIndex  int              // Selected project index
+	treeItemCount       int              // Total items in the tree (projects + sessions)
+	treeItems           []claudeTreeItem // Flattened tree for navigation
+	textInput           textarea.Model   // Multi-line message composer
+	lastEscTime         time.Time        // For double-ESC detection
+	treeMenu            *TreeMenu        // Tree menu for sessions panel
+	busy                map[string]bool  // Sessions seen producing output (for finished notification)
+
+	transcript *claudeTranscript // Markdown transcript shown in place of the terminal (nil = terminal)
+	planReview *planReview       // Plan awaiting approval, shown in place of the chat
+	cleanup    bool              // Session cleanup preview shown in place of the chat
+
+	pendingDeleteSessionID     string // Session ID to delete (saved at dialog open to avoid race condition)
+	pendingNewSessionProjectID string // Project ID for new session dialog
+	pendingTaskTool            string // Tool of the session to link to a task (claude, codex)
+	pendingTaskSessionID       string // Session to link to a task (saved at dialog open)
+	pendingDeleteTaskID        string // Task to delete (saved at dialog open)
+}
+
+// newClaudeController creates the Claude view controller
+func newClaudeController() *claudeController {
+	// Sessions tree menu (right-side panel)
+	menu := NewTreeMenu(nil)
+	menu.SetTitle("Sessions")
+	menu.SetRightSidePanel(true)
+	menu.SetMarkable(func(item *TreeMenuItem) bool {
+		_, ok := item.Data.(core.ClaudeSessionVM)
+		return ok
+	})
+
+	return &claudeController{
+		mode:             ClaudeModeChat, // Initialize to avoid empty mode issues
+		deletingSessions: make(map[string]bool),
+		textInput:        newClaudeComposer(),
+		treeMenu:         menu,
+	}
+}
+
+// claudeView returns the Claude view submodel
+func (m *Model) claudeView() *claudeController {
+	return m.controllers[core.VMClaude].(*claudeController)
+}
+
+// Init implements ViewController
+func (c *claudeController) Init(m *Model) tea.Cmd {
+	if c.mode == "" {
+		c.mode = ClaudeModeChat
+	}
+	// Default focus to sessions panel so user can select/create a session
+	if c.activeSession == "" {
+		m.focusArea = FocusDetail
+	}
+	return nil
+}
+
+// Menu implements ViewController (the chat panel is scrolled)
+func (c *claudeController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	if focus == FocusDetail && c.mode == ClaudeModeChat && !c.inputActive {
+		return c.treeMenu
+	}
+	return nil
+}
+
+// Keys implements ViewController
+func (c *claudeController) Keys(m *Model) []KeyHint {
+	// Terminal mode has its own shortcuts
+	if m.terminalMode {
+		return []KeyHint{
+			{"^G Esc", "exit"},
+			{"PgUp/Dn", "scroll"},
+			{"^G [", "copy/search"},
+			{"^G m", "transcript"},
+			{"^G o", "pop out"},
+			{"^G r", "record"},
+		}
+	}
+	var hints []KeyHint
+	switch {
+	case m.focusArea == FocusDetail:
+		// Sessions panel shortcuts (right side)
+		allLabel := "all"
+		if c.showAllSessions {
+			allLabel = "less"
+		}
+		hints = []KeyHint{
+			{"n", "new"},
+			{"Enter", "open"},
+			{"r", "rename"},
+			{"b", "fork"},
+			{"t", "task"},
+			{"x", "delete"},
+			{"Space", "mark"},
+			{"p", "cleanup"},
+			{"a", allLabel},
+		}
+	case c.inputActive:
+		send, newline := claudeComposerKeys()
+		hints = []KeyHint{
+			{send, "send"},
+			{newline + "/^J", "newline"},
+			{"Esc", "cancel"},
+		}
+	case c.cleanup:
+		hints = []KeyHint{
+			{"x", "move to trash"},
+			{"p/Esc", "close"},
+		}
+	case c.planReview != nil:
+		hints = []KeyHint{
+			{"y", "approve plan"},
+			{"n", "reject"},
+			{"e", "edit & resend"},
+		}
+	case c.transcript != nil:
+		hints = []KeyHint{
+			{"[ ]", "code block"},
+			{"y", "copy code"},
+			{"Esc", "terminal"},
+		}
+	default:
+		hints = []KeyHint{
+			{"i", "input"},
+			{"m", "transcript"},
+			{"Esc", "back"},
+		}
+	}
+	if m.state.Claude != nil && m.state.Claude.IsProcessing {
+		hints = append(hints, KeyHint{"CTRL+c", "stop"})
+	}
+	return hints
+}
+
+// Update implements ViewController
+func (c *claudeController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case viewOpenedMsg:
+		m.updateClaudeTree()
+	case stateUpdateMsg:
+		c.refresh(m)
+		if msg.update.Affects(core.VMClaude) {
+			m.syncPlanReview()
+		}
+	case itemCountMsg:
+		// Count depends on current tab
+		switch c.mode {
+		case ClaudeModeSession:
+			if m.state.Claude != nil {
+				// Count filtered sessions
+				count := 0
+				for _, sess := range m.state.Claude.Sessions {
+					if c.filterProject == "" || sess.ProjectID == c.filterProject {
+						count++
+					}
+				}
+				m.maxMainItems = count
+			}
+		case ClaudeModeChat:
+			m.maxMainItems = 0 // No list navigation in chat
+		}
+		return nil, true
+	case tickMsg:
+		// Follow the session shown as a transcript
+		return m.syncClaudeTranscript(), true
+	case keyPressMsg:
+		return c.handleKeyPress(m, msg.key)
+	case detailSelectMsg:
+		// Sessions panel: use TreeMenu to select/drill-down
+		if c.mode != ClaudeModeChat {
+			return nil, false
+		}
+		if item := c.treeMenu.Select(); item != nil {
+			// Verify it's actually a session (not a project without children)
+			if sess, isSession := item.Data.(core.ClaudeSessionVM); isSession {
+				// Leaf item selected (session) - switch to it
+				return tea.Batch(m.switchToSessionByID(sess.ID), m.syncClaudeTranscript()), true
+			}
+			// Codex session of a task - show it in the Codex view
+			if sess, isCodex := item.Data.(core.CodexSessionVM); isCodex {
+				return m.openCodexSession(sess.ID), true
+			}
+			// It's a project with no sessions - do nothing
+		}
+		// If Select() returned nil, it drilled down - nothing more to do
+		return nil, true
+	case dialogConfirmMsg:
+		switch msg.dialogType {
+		case "delete_claude_session":
+			// Delete the Claude session saved at dialog open (avoids race condition)
+			sessionID := c.pendingDeleteSessionID
+			c.pendingDeleteSessionID = "" // Clear pending ID
+			if sessionID != "" {
+				return m.deleteClaudeSessions(sessionID), true
+			}
+			return nil, true
+		case "link_session_task":
+			return m.linkSessionTask(), true
+		case "delete_session_task":
+			taskID := c.pendingDeleteTaskID
+			c.pendingDeleteTaskID = ""
+			if taskID == "" {
+				return nil, true
+			}
+			return m.sendEvent(core.NewEvent(core.EventSessionDeleteTask).WithData("task_id", taskID)), true
+		case "claude_cleanup":
+			// Move the stale sessions of the cleanup preview to the trash
+			c.cleanup = false
+			return m.sendEvent(core.NewEvent(core.EventClaudeCleanupSessions)), true
+		case "new_claude_session":
+			// Create a new Claude session with the entered name
+			if c.pendingNewSessionProjectID != "" {
+				sessionName := strings.TrimSpace(m.dialogInput.Value())
+				if sessionName == "" {
+					sessionName = m.generateDefaultSessionName(c.pendingNewSessionProjectID)
+				}
+				projectID := c.pendingNewSessionProjectID
+				c.pendingNewSessionProjectID = ""
+				return m.createClaudeSessionWithName(projectID, sessionName), true
+			}
+			return nil, true
+		}
+	case tea.KeyMsg:
+		cmd, handled := c.handleKey(m, msg.String())
+		return tea.Batch(cmd, m.syncClaudeTranscript()), handled
+	}
+	return nil, false
+}
+
+// View implements ViewController
+func (c *claudeController) View(m *Model, width, height int) string {
+	// Follow the pending plan of the session
+	m.syncPlanReview()
+	return m.renderClaude(width, height)
+}
+
+// terminalSession implements terminalView (the active Claude session)
+func (c *claudeController) terminalSession() string {
+	return c.activeSession
+}
+
+// refresh follows the Claude view model: the sessions tree, the session
+// just created and the interactive prompts
+func (c *claudeController) refresh(m *Model) {
+	// Update Claude tree for navigation (must persist across Update calls)
+	if m.viewOpened(core.VMClaude) {
+		m.updateClaudeTree()
+	}
+
+	// Handle newly created session - just select it, don't start terminal yet
+	if m.state.Claude != nil && m.state.Claude.NewlyCreatedSessionID != "" {
+		// Set as active session (shows in chat panel)
+		c.activeSession = m.state.Claude.NewlyCreatedSessionID
+		c.mode = ClaudeModeChat
+
+		// Update tree again so IsActive reflects the new session
+		m.updateClaudeTree()
+
+		// Don't start terminal automatically - user will start it when they want to interact
+		// Terminal is started when user presses Enter or types in the session
+	}
+
+	// Clear session loading state when the requested session data is received
+	if c.sessionLoading && m.state.Claude != nil && m.state.Claude.ActiveSessionID == c.activeSession {
+		c.sessionLoading = false
+	}
+
+	// Auto-exit input mode when Claude is waiting for interactive response
+	// This allows y/n/1-9 keys to work for permission/question/plan dialogs
+	if m.state.Claude != nil && m.state.Claude.WaitingForInput && c.inputActive {
+		c.inputActive = false
+		c.textInput.Blur()
+	}
+}
+
+// handleKeyPress handles the keys the Claude view takes over from the global
+// handling: chat scroll and Tab back to the terminal
+func (c *claudeController) handleKeyPress(m *Model, msg tea.KeyMsg) (tea.Cmd, bool) {
+	// Close the session cleanup preview
+	if msg.String() == "esc" && c.cleanup && m.focusArea == FocusMain {
+		c.cleanup = false
+		return nil, true
+	}
+
+	// Detail -> Main: re-enter terminal mode if there's an active terminal
+	if key.Matches(msg, m.keys.Tab) && m.focusArea == FocusDetail {
+		m.focusArea = FocusMain
+		if c.activeSession != "" {
+			if t := m.terminalManager.Get(c.activeSession); t != nil && t.IsRunning() {
+				m.terminalMode = true
+				c.inputActive = false
+				c.renameActive = false
+				m.commandMode = false
+			}
+		}
+		return nil, true
+	}
+
+	// Chat scroll
+	if c.mode != ClaudeModeChat || m.focusArea != FocusMain || c.inputActive {
+		return nil, false
+	}
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		c.chatScroll++
+	case key.Matches(msg, m.keys.Down):
+		if c.chatScroll > 0 {
+			c.chatScroll--
+		}
+	case key.Matches(msg, m.keys.PageUp):
+		c.chatScroll += 10
+	case key.Matches(msg, m.keys.PageDown):
+		c.chatScroll -= 10
+		if c.chatScroll < 0 {
+			c.chatScroll = 0
+		}
+	case key.Matches(msg, m.keys.Home):
+		c.chatScroll = 999999 // Will be clamped in render
+	case key.Matches(msg, m.keys.End):
+		// End goes to bottom (most recent)
+		c.chatScroll = 0
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// handleKey handles the action keys of the chat and sessions panels
+func (c *claudeController) handleKey(m *Model, key string) (tea.Cmd, bool) {
+	// Cleanup preview keys, plan review keys, then transcript keys (code
+	// blocks, back to the terminal)
+	if c.cleanup && m.focusArea == FocusMain && !c.inputActive {
+		if cmd, handled := m.handleClaudeCleanupKey(key); handled {
+			return cmd, true
+		}
+	} else if c.planReview != nil && m.focusArea == FocusMain && !c.inputActive {
+		if cmd, handled := m.handlePlanReviewKey(key); handled {
+			return cmd, true
+		}
+	} else if c.transcript != nil && m.focusArea == FocusMain && !c.inputActive {
+		if cmd, handled := m.handleClaudeTranscriptKey(key); handled {
+			return cmd, true
+		}
+	}
+
+	// PRIORITY: Handle interactive responses first (when Claude is waiting for input)
+	// Only handle y/n for interactive when NOT in the sessions panel
+	if c.mode == ClaudeModeChat && m.state.Claude != nil && m.state.Claude.WaitingForInput && m.focusArea != FocusDetail {
+		switch key {
+		case "y", "Y":
+			// Approve permission/plan, then return to input mode
+			var cmd tea.Cmd
+			if m.state.Claude.Interactive != nil {
+				switch m.state.Claude.Interactive.Type {
+				case "permission":
+					cmd = m.sendEvent(core.NewEvent(core.EventClaudeApprovePermission).
+						WithData("session_id", c.activeSession))
+				case "plan":
+					cmd = m.sendEvent(core.NewEvent(core.EventClaudeApprovePlan).
+						WithData("session_id", c.activeSession))
+				}
+			}
+			if m.state.Claude.PlanPending {
+				cmd = m.sendEvent(core.NewEvent(core.EventClaudeApprovePlan).
+					WithData("session_id", c.activeSession))
+			}
+			// Return to input mode after response
+			c.inputActive = true
+			return tea.Batch(cmd, m.focusClaudeComposer(), claudeRefreshCmd()), true
+		case "n", "N":
+			// Deny permission/plan, then return to input mode
+			var cmd tea.Cmd
+			if m.state.Claude.Interactive != nil {
+				switch m.state.Claude.Interactive.Type {
+				case "permission":
+					cmd = m.sendEvent(core.NewEvent(core.EventClaudeDenyPermission).
+						WithData("session_id", c.activeSession))
+				case "plan":
+					cmd = m.sendEvent(core.NewEvent(core.EventClaudeRejectPlan).
+						WithData("session_id", c.activeSession))
+				}
+			}
+			if m.state.Claude.PlanPending {
+				cmd = m.sendEvent(core.NewEvent(core.EventClaudeRejectPlan).
+					WithData("session_id", c.activeSession))
+			}
+			// Return to input mode after response
+			c.inputActive = true
+			return tea.Batch(cmd, m.focusClaudeComposer(), claudeRefreshCmd()), true
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			// Select option when Claude asks a question with options
+			if m.state.Claude.Interactive != nil && m.state.Claude.Interactive.Type == "question" {
+				optIdx := int(key[0] - '1')
+				if optIdx >= 0 && optIdx < len(m.state.Claude.Interactive.Options) {
+					answer := m.state.Claude.Interactive.Options[optIdx]
+					cmd := m.sendEvent(core.NewEvent(core.EventClaudeAnswerQuestion).
+						WithData("session_id", c.activeSession).
+						WithData("answer", answer))
+					// Return to input mode after answering
+					c.inputActive = true
+					return tea.Batch(cmd, m.focusClaudeComposer(), claudeRefreshCmd()), true
+				}
+			}
+			return nil, true
+		case "i":
+			// Start input mode to type custom answer
+			if m.blockReadOnly("claude input") {
+				return nil, true
+			}
+			c.inputActive = true
+			return m.focusClaudeComposer(), true
+		}
+	}
+
+	// Sessions panel: Enter to select (up/down handled in handleKeyPress)
+	if c.mode == ClaudeModeChat && m.focusArea == FocusDetail && !c.inputActive {
+		if key == "enter" {
+			return m.switchToSelectedSession(), true
+		}
+	}
+
+	// Chat mode vim-style scroll controls (ctrl+u/d, g/G)
+	// Note: pgup/pgdown/home/end handled in handleKeyPress
+	if c.mode == ClaudeModeChat && m.focusArea == FocusMain && !c.inputActive {
+		switch key {
+		case "ctrl+u":
+			// Half page up (vim style)
+			c.chatScroll += 10
+			return nil, true
+		case "ctrl+d":
+			// Half page down (vim style)
+			c.chatScroll -= 10
+			if c.chatScroll < 0 {
+				c.chatScroll = 0
+			}
+			return nil, true
+		case "g":
+			// Go to top (vim style)
+			c.chatScroll = 999999
+			return nil, true
+		case "G":
+			// Go to bottom (vim style)
+			c.chatScroll = 0
+			return nil, true
+		}
+	}
+
+	switch key {
+	case "a":
+		// Toggle show all sessions (vs 10 most recent per project)
+		c.showAllSessions = !c.showAllSessions
+		m.updateClaudeTree()
+		return nil, true
+	case "n":
+		// New session - when focused on sessions panel and on/in a project
+		if c.mode == ClaudeModeChat && m.focusArea == FocusDetail {
+			projectID, _, isProject, _ := m.getSelectedTreeItem()
+
+			// If drilled down into a project, get project ID from drill path
+			if !isProject && projectID == "" {
+				drillPath := c.treeMenu.DrillDownPath()
+				if len(drillPath) > 0 && !strings.HasPrefix(drillPath[0], taskItemPrefix) {
+					projectID = drillPath[0]
+				}
+			}
+
+			// Create new session if we have a project (either selected or drilled into)
+			if projectID != "" {
+				return m.openNewClaudeSessionDialog(projectID), true
+			}
+		}
+		return nil, true
+	case "x":
+		// Delete selected session (when focus is on sessions panel)
+		if c.mode == ClaudeModeChat && m.focusArea == FocusDetail {
+			if _, isTask := m.selectedTask(); isTask {
+				return m.deleteSelectedTask(), true
+			}
+			_, sessionID, isProject, _ := m.getSelectedTreeItem()
+			if !isProject && sessionID != "" {
+				// Save sessionID NOW to avoid race condition when tree updates between dialog open and confirm
+				c.pendingDeleteSessionID = sessionID
+				// Get session name from selected item
+				sessionName := sessionID
+				if item := c.treeMenu.SelectedItem(); item != nil {
+					sessionName = item.Label
+				}
+				return m.openConfirmDialog(config.ConfirmDeleteSession, "delete_claude_session",
+					fmt.Sprintf("Delete session \"%s\"?", sessionName)), true
+			}
+		}
+		return nil, true
+	case "r":
+		// Rename selected session (when focus is on sessions panel)
+		if c.mode == ClaudeModeChat && m.focusArea == FocusDetail {
+			_, sessionID, isProject, _ := m.getSelectedTreeItem()
+			if !isProject && sessionID != "" {
+				if m.blockReadOnly("rename session") {
+					return nil, true
+				}
+				c.treeMenu.SetRenameActive(true)
+				c.renameActive = true
+			}
+		}
+		return nil, true
+	case "b":
+		// Fork (branch) the selected session into a new one
+		if c.mode == ClaudeModeChat && m.focusArea == FocusDetail {
+			return m.forkSelectedSession(), true
+		}
+		return nil, true
+	case "t":
+		// Link the selected session to a task (Claude and Codex sessions)
+		if c.mode == ClaudeModeChat && m.focusArea == FocusDetail {
+			return m.linkSelectedSession(), true
+		}
+		return nil, true
+	case "p":
+		// Preview the cleanup (prune) of stale sessions
+		if c.mode == ClaudeModeChat && m.focusArea == FocusDetail {
+			return m.openClaudeCleanup(), true
+		}
+		return nil, true
+	case "d":
+		// Disconnect tmux session (when focus is on sessions panel and session has terminal)
+		if c.mode == ClaudeModeChat && m.focusArea == FocusDetail {
+			_, sessionID, isProject, hasTerminal := m.getSelectedTreeItem()
+			if !isProject && sessionID != "" && hasTerminal {
+				return m.stopClaudeTerminal(sessionID), true
+			}
+		}
+		return nil, true
+	case "i":
+		// Start input mode (in chat mode) - only if a session is selected
+		if c.mode == ClaudeModeChat && c.activeSession != "" {
+			if m.blockReadOnly("claude input") {
+				return nil, true
+			}
+			c.inputActive = true
+			return m.focusClaudeComposer(), true
+		}
+		return nil, true
+	case "esc":
+		// Exit input mode or switch focus
+		if c.inputActive {
+			c.inputActive = false
+			return nil, true
+		}
+		// If in sessions panel, go back to chat
+		if m.focusArea == FocusDetail {
+			m.focusArea = FocusMain
+			return nil, true
+		}
+		return nil, true
+	case "c":
+		// Clear filter
+		c.filterProject = ""
+		return nil, true
+	case "m":
+		// Show the session messages rendered as markdown
+		if m.focusArea == FocusMain {
+			return m.toggleClaudeTranscript(), true
+		}
+		return nil, true
+	case "y":
+		// Copy the last Claude message
+		if m.focusArea == FocusMain {
+			return m.yankClaudeMessage(), true
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// contextMenu implements contextMenuView (right-click: m shows the transcript)
+func (c *claudeController) contextMenu(m *Model) *contextMenu {
+	return m.claudeContextMenu()
+}
+
+// claudePanelWidths returns the widths of the chat and sessions panels of
+// the Claude view (inside their borders)
+func claudePanelWidths(width int) (chatWidth, sessionsWidth int) {
+	widthBorders := 4
+	availableWidth := width - widthBorders - GapHorizontal
+
+	// Fixed sessions panel width for predictable layout
+	sessionsWidth = 35
+	if sessionsWidth > availableWidth/2 {
+		sessionsWidth = availableWidth / 2
+	}
+	return availableWidth - sessionsWidth, sessionsWidth
+}
+
 // renderClaude renders the Claude AI view
 // Layout: Chat on left (70%), Sessions panel on right (30%)
 func (m *Model) renderClaude(width, height int) string {
@@ -32,36 +646,28 @@ func (m *Model) renderClaude(width, height int) string {
 	}
 
 	// Always chat mode now (no more tabs)
-	if m.claudeMode == "" || m.claudeMode == ClaudeModeSession {
-		m.claudeMode = ClaudeModeChat
+	if m.claudeView().mode == "" || m.claudeView().mode == ClaudeModeSession {
+		m.claudeView().mode = ClaudeModeChat
 	}
 
 	// Layout: chat (1 panel) + sessions (2 stacked panels)
 	// Height: max(1×2, 2×2) = 4
 	// Width: 2 panels × 2 = 4
 	heightBorders := 4
-	widthBorders := 4
 	contentHeight := height - heightBorders
-	availableWidth := width - widthBorders - GapHorizontal
-
-	// Fixed sessions panel width for predictable layout
-	sessionsWidth := 35
-	if sessionsWidth > availableWidth/2 {
-		sessionsWidth = availableWidth / 2
-	}
-	chatWidth := availableWidth - sessionsWidth
+	chatWidth, sessionsWidth := claudePanelWidths(width)
 
 	// Session info takes some space at bottom
 	infoHeight := 8
 	treeHeight := contentHeight - infoHeight
 
 	// Configure and render TreeMenu (will truncate if too wide for panel)
-	m.sessionsTreeMenu.SetSize(sessionsWidth, treeHeight)
-	m.sessionsTreeMenu.SetFocused(m.focusArea == FocusDetail)
+	m.claudeView().treeMenu.SetSize(sessionsWidth, treeHeight)
+	m.claudeView().treeMenu.SetFocused(m.focusArea == FocusDetail)
 
 	// Chat panel has only 1 panel (not 2 stacked like sessions), so add +2
 	chatPanel := m.renderClaudeChatPanel(chatWidth, contentHeight+2)
-	treePanel := m.sessionsTreeMenu.Render()
+	treePanel := m.claudeView().treeMenu.Render()
 	infoPanel := m.renderSessionInfo(sessionsWidth, infoHeight)
 	// Wrap in fixed-width container so both panels align
 	sessionsPanel := lipgloss.NewStyle().Width(sessionsWidth).Render(
@@ -79,8 +685,8 @@ func (m *Model) renderClaude(width, height int) string {
 func (m *Model) renderSessionInfo(width, height int) string {
 	// Get selected session from TreeMenu
 	var sess *core.ClaudeSessionVM
-	if m.sessionsTreeMenu != nil {
-		if item := m.sessionsTreeMenu.SelectedItem(); item != nil {
+	if m.claudeView().treeMenu != nil {
+		if item := m.claudeView().treeMenu.SelectedItem(); item != nil {
 			if s, ok := item.Data.(core.ClaudeSessionVM); ok {
 				sess = &s
 			}
@@ -127,6 +733,15 @@ func (m *Model) renderSessionInfo(width, height int) string {
 	durationLine := "Duration: " + duration
 	lines = append(lines, valueStyle.Render(durationLine))
 
+	// Fork: the session it continues
+	if sess.ParentID != "" {
+		parent := m.claudeSessionName(sess.ParentID)
+		lines = append(lines, mutedStyle.Render(truncate("Forked from: "+parent, contentWidth)))
+	}
+	if task := m.sessionTaskName("claude", sess.ID); task != "" {
+		lines = append(lines, mutedStyle.Render(truncate("Task: "+task, contentWidth)))
+	}
+
 	// Pad each line to exact width
 	for i, line := range lines {
 		lineWidth := lipgloss.Width(line)
@@ -237,11 +852,36 @@ func (m *Model) renderClaudeNotInstalled(width, height int) string {
 
 	return style.Render(content)
 }
+
 // renderClaudeChatPanel renders the main chat area (terminal or placeholder)
 func (m *Model) renderClaudeChatPanel(width, height int) string {
+	// Session cleanup preview, plan awaiting approval, then the markdown
+	// transcript of the active session, in place of its terminal
+	if m.claudeView().cleanup {
+		return m.renderClaudeCleanup(width, height)
+	}
+	if m.claudeView().planReview != nil && m.claudeView().planReview.sessionID == m.claudeView().activeSession {
+		return m.renderPlanReview(width, height)
+	}
+	if m.claudeView().transcript != nil && m.claudeView().transcript.sessionID == m.claudeView().activeSession {
+		return m.renderClaudeTranscript(width, height)
+	}
+
+	// Composer below the session while typing a message
+	if m.claudeView().inputActive {
+		composer := m.renderClaudeComposer(width)
+		return lipgloss.JoinVertical(lipgloss.Left,
+			m.renderClaudeSessionPanel(width, height-lipgloss.Height(composer)), composer)
+	}
+	return m.renderClaudeSessionPanel(width, height)
+}
+
+// renderClaudeSessionPanel renders the terminal of the active session, or a
+// placeholder when it is not running
+func (m *Model) renderClaudeSessionPanel(width, height int) string {
 	// Show terminal panel if there's an active session with a running terminal
-	if m.claudeActiveSession != "" && m.terminalManager != nil {
-		if t := m.terminalManager.Get(m.claudeActiveSession); t != nil && t.IsRunning() {
+	if m.claudeView().activeSession != "" && m.terminalManager != nil {
+		if t := m.terminalManager.Get(m.claudeView().activeSession); t != nil && t.IsRunning() {
 			return m.renderTerminalPanel(t, width, height)
 		}
 	}
@@ -253,10 +893,14 @@ func (m *Model) renderClaudeChatPanel(width, height int) string {
 	}
 
 	var message string
-	if m.claudeActiveSession == "" {
+	if m.claudeView().activeSession == "" {
 		message = "Select a session or press 'n' to create one"
+	} else if m.isSuspended(m.claudeView().activeSession) {
+		message = "Session suspended while idle\n\nPress Enter to resume, m to read the transcript"
+	} else if lost := m.lostTerminalMessage(m.claudeView().activeSession); lost != "" {
+		message = lost + ", m to read the transcript"
 	} else {
-		message = "Press Enter to start Claude"
+		message = "Press Enter to start Claude, m to read the transcript"
 	}
 
 	content := lipgloss.NewStyle().
@@ -292,8 +936,13 @@ func (m *Model) renderTerminalPanel(t TerminalInterface, width, height int) stri
 	// Update terminal size
 	t.SetSize(termWidth, termHeight)
 
-	// Get terminal content and split into lines
-	content := t.View()
+	// Get terminal content (or the copy mode buffer) and split into lines
+	var content string
+	if m.copyMode != nil && m.copyMode.terminal == t {
+		content = m.copyMode.View(termWidth, termHeight)
+	} else {
+		content = t.View()
+	}
 	contentLines := strings.Split(content, "\n")
 
 	// Truncate to exact height
@@ -329,7 +978,9 @@ func (m *Model) renderTerminalPanel(t TerminalInterface, width, height int) stri
 
 	// Apply border style with vertical centering
 	var style lipgloss.Style
-	if m.terminalMode {
+	if m.copyMode != nil && m.copyMode.terminal == t {
+		style = FocusedBorderStyle.Copy().BorderForeground(ColorWarning)
+	} else if m.terminalMode {
 		style = FocusedBorderStyle.Copy()
 	} else {
 		style = UnfocusedBorderStyle.Copy()
@@ -408,3 +1059,860 @@ func truncateANSI(s string, maxWidth int) string {
 }
 
 // Legacy chat functions removed - now using tmux terminal
+
+// updateClaudeTree builds the flattened tree structure for Claude sessions navigation
+func (m *Model) updateClaudeTree() {
+	// Clean up deletingSessions map: remove IDs that are no longer in the sessions list
+	if m.state.Claude != nil && len(m.claudeView().deletingSessions) > 0 {
+		existingIDs := make(map[string]bool)
+		for _, sess := range m.state.Claude.Sessions {
+			existingIDs[sess.ID] = true
+		}
+		for id := range m.claudeView().deletingSessions {
+			if !existingIDs[id] {
+				delete(m.claudeView().deletingSessions, id)
+			}
+		}
+	}
+
+	// Build tree: group sessions by project
+	// Show ALL registered projects, even those without sessions
+	type projectNode struct {
+		ID       string
+		Name     string
+		Path     string
+		Sessions []core.ClaudeSessionVM
+	}
+
+	projectMap := make(map[string]*projectNode)
+	var projectOrder []string
+
+	// Add ALL registered projects to the tree
+	if m.state.Projects != nil {
+		for _, proj := range m.state.Projects.Projects {
+			node := &projectNode{
+				ID:       proj.ID,
+				Name:     proj.Name,
+				Path:     proj.Path,
+				Sessions: []core.ClaudeSessionVM{},
+			}
+			projectMap[proj.ID] = node
+			projectOrder = append(projectOrder, proj.ID)
+		}
+	}
+
+	// Add sessions to their matching projects
+	// Sessions in subdirectories should be matched to their parent project
+	if m.state.Claude != nil {
+		for _, sess := range m.state.Claude.Sessions {
+			// Try to match session with a registered project (exact match only)
+			matched := false
+			var bestMatch *projectNode
+
+			for _, node := range projectMap {
+				// First, try exact ProjectID match (most reliable)
+				if sess.ProjectID != "" && sess.ProjectID == node.ID {
+					node.Sessions = append(node.Sessions, sess)
+					matched = true
+					break
+				}
+
+				// Primary: Use WorkDir (cwd from JSONL) for matching
+				// This is the most reliable source as it's the actual directory where the session was used
+				if sess.WorkDir != "" && node.Path != "" {
+					// Resolve symlinks on both paths for comparison
+					realWorkDir := sess.WorkDir
+					if resolved, err := filepath.EvalSymlinks(sess.WorkDir); err == nil {
+						realWorkDir = resolved
+					}
+					realNodePath := node.Path
+					if resolved, err := filepath.EvalSymlinks(node.Path); err == nil {
+						realNodePath = resolved
+					}
+
+					// Exact match takes priority
+					if realWorkDir == realNodePath {
+						bestMatch = node
+						break // Exact match found, stop searching
+					}
+
+					// Also match if session is from a subdirectory of this project
+					// Use the most specific (longest path) parent project
+					if strings.HasPrefix(realWorkDir, realNodePath+string(filepath.Separator)) {
+						if bestMatch == nil || len(realNodePath) > len(bestMatch.Path) {
+							bestMatch = node
+							// Don't break - continue searching for a more specific match
+						}
+					}
+				}
+			}
+
+			// Use best path match if no exact ProjectID match
+			if !matched && bestMatch != nil {
+				bestMatch.Sessions = append(bestMatch.Sessions, sess)
+				matched = true
+			}
+
+			// Fallback: try name-based matching
+			if !matched {
+				for _, node := range projectMap {
+					// Match if project path ends with session's project name
+					if sess.ProjectName != "" && strings.HasSuffix(node.Path, string(filepath.Separator)+sess.ProjectName) {
+						node.Sessions = append(node.Sessions, sess)
+						matched = true
+						break
+					}
+					// Match if project name equals session's project name
+					if node.Name == sess.ProjectName {
+						node.Sessions = append(node.Sessions, sess)
+						matched = true
+						break
+					}
+				}
+			}
+
+			// Sessions not matching any registered project are simply ignored
+			// We only show sessions for known projects
+		}
+	}
+
+	// Sort projects alphabetically by name
+	sort.Slice(projectOrder, func(i, j int) bool {
+		return strings.ToLower(projectMap[projectOrder[i]].Name) < strings.ToLower(projectMap[projectOrder[j]].Name)
+	})
+
+	// Sort sessions within each project alphabetically by name
+	forkDepth := make(map[string]int)
+	for _, node := range projectMap {
+		sort.Slice(node.Sessions, func(i, j int) bool {
+			// Sort by LastActiveAt descending (most recent first)
+			return node.Sessions[i].LastActiveAt.After(node.Sessions[j].LastActiveAt)
+		})
+		// Forks right after the session they come from
+		node.Sessions = orderSessionForks(node.Sessions, forkDepth)
+	}
+
+	// Build flattened tree for navigation (legacy)
+	m.claudeView().treeItems = nil
+	for _, projID := range projectOrder {
+		node := projectMap[projID]
+
+		// Add project to tree
+		m.claudeView().treeItems = append(m.claudeView().treeItems, claudeTreeItem{
+			IsProject: true,
+			ProjectID: node.ID,
+		})
+
+		// Add sessions under project
+		for _, sess := range node.Sessions {
+			m.claudeView().treeItems = append(m.claudeView().treeItems, claudeTreeItem{
+				IsProject: false,
+				ProjectID: sess.ProjectID,
+				SessionID: sess.ID,
+			})
+		}
+	}
+	m.claudeView().treeItemCount = len(m.claudeView().treeItems)
+
+	// Build TreeMenu items
+	// List all tmux sessions once for efficient lookup
+	var tmuxSessions map[string]bool
+	if m.terminalManager != nil && m.terminalManager.Backend() == TerminalBackendTmux {
+		tmuxSessions = ListTmuxSessions()
+	}
+
+	var treeItems []TreeMenuItem
+	const maxSessionsPerProject = 10
+	for _, projID := range projectOrder {
+		node := projectMap[projID]
+
+		// Limit sessions unless showAllClaudeSessions is enabled
+		sessionsToShow := node.Sessions
+		hiddenCount := 0
+		if !m.claudeView().showAllSessions && len(node.Sessions) > maxSessionsPerProject {
+			sessionsToShow = node.Sessions[:maxSessionsPerProject]
+			hiddenCount = len(node.Sessions) - maxSessionsPerProject
+		}
+
+		// Build session children for this project
+		var sessionItems []TreeMenuItem
+		for _, sess := range sessionsToShow {
+			// Get display name (remove project prefix if present)
+			displayName := sess.Name
+			if idx := strings.Index(displayName, "-"); idx > 0 && strings.HasPrefix(displayName, sess.ProjectID) {
+				displayName = displayName[idx+1:]
+			}
+			if depth := forkDepth[sess.ID]; depth > 0 {
+				displayName = strings.Repeat("  ", depth-1) + "↳ " + displayName
+			}
+
+			// Check if terminal is attached (in memory or persistent tmux)
+			hasTmux := false
+			if m.terminalManager != nil {
+				if t := m.terminalManager.Get(sess.ID); t != nil && t.State() == TerminalRunning {
+					hasTmux = true
+				}
+			}
+			// Also check for persistent tmux sessions (using cached list)
+			if !hasTmux {
+				shortID := sess.ID
+				if len(shortID) > 8 {
+					shortID = shortID[:8]
+				}
+				hasTmux = tmuxSessions[shortID]
+			}
+
+			// Check if session is being deleted
+			isDeleting := m.claudeView().deletingSessions[sess.ID]
+
+			// Use filled circle for tmux sessions, empty otherwise
+			icon := "○"
+			if isDeleting {
+				icon = "●" // Red filled circle for deleting
+			} else if hasTmux {
+				icon = "●"
+			}
+
+			// Check if this is the active session
+			isActive := sess.ID == m.claudeView().activeSession
+
+			item := TreeMenuItem{
+				ID:       sess.ID,
+				Label:    displayName,
+				Icon:     icon,
+				IsActive: isActive,
+				Data:     sess, // Store the full session data
+			}
+			// Set icon color based on state
+			if isDeleting {
+				item.IconColor = ColorError
+				item.Blink = true    // Enable blinking for deleting sessions
+				item.Disabled = true // Can't select deleting sessions
+			} else if hasTmux {
+				item.IconColor = ColorSuccess
+				item = m.withActivityBadge(item, sess.ID)
+			}
+			sessionItems = append(sessionItems, item)
+		}
+
+		// Add "show more" indicator if sessions are hidden
+		if hiddenCount > 0 {
+			sessionItems = append(sessionItems, TreeMenuItem{
+				ID:       "more:" + node.ID,
+				Label:    fmt.Sprintf("+%d more (press 'a' to show all)", hiddenCount),
+				Icon:     "…",
+				Disabled: true,
+			})
+		}
+
+		// Add project with its sessions as children
+		projIcon := "📁"
+		if len(node.Sessions) > 0 {
+			projIcon = "📂"
+		}
+
+		treeItems = append(treeItems, TreeMenuItem{
+			ID:       node.ID,
+			Label:    node.Name,
+			Icon:     projIcon,
+			Children: sessionItems,
+			Count:    len(node.Sessions), // Total count, not just visible
+			Data:     "project",          // Mark as project for identification
+		})
+	}
+
+	// Tasks first: sessions of several tools grouped across projects
+	treeItems = append(m.sessionTaskItems(), treeItems...)
+
+	// Update the TreeMenu
+	if m.claudeView().treeMenu != nil {
+		m.claudeView().treeMenu.SetItems(treeItems)
+	}
+}
+
+// getSelectedTreeItem returns the selected item from the sessions TreeMenu
+// Returns (projectID, sessionID, isProject, hasTerminal)
+func (m *Model) getSelectedTreeItem() (string, string, bool, bool) {
+	if m.claudeView().treeMenu == nil {
+		return "", "", false, false
+	}
+
+	item := m.claudeView().treeMenu.SelectedItem()
+	if item == nil {
+		return "", "", false, false
+	}
+
+	// Tasks group sessions of several projects and tools
+	if _, isTask := item.Data.(core.SessionTaskVM); isTask {
+		return "", "", true, false
+	}
+
+	// Check if it's a project (marked with "project" in Data, or has children)
+	if item.Data == "project" || len(item.Children) > 0 {
+		// It's a project
+		return item.ID, "", true, false
+	}
+
+	// Get session and project IDs from session data (the item ID of a
+	// session listed under a task is not the session ID)
+	sess, ok := item.Data.(core.ClaudeSessionVM)
+	if !ok {
+		return "", "", false, false // Codex session of a task
+	}
+
+	// It's a session - check if it has terminal attached
+	hasTerminal := false
+	if m.terminalManager != nil {
+		if t := m.terminalManager.Get(sess.ID); t != nil && t.IsRunning() {
+			hasTerminal = true
+		}
+	}
+
+	return sess.ProjectID, sess.ID, false, hasTerminal
+}
+
+// openNewClaudeSessionDialog asks the name of a new Claude session for a project
+func (m *Model) openNewClaudeSessionDialog(projectID string) tea.Cmd {
+	if m.blockReadOnly("new session") {
+		return nil
+	}
+	// Generate default session name
+	defaultName := m.generateDefaultSessionName(projectID)
+	m.claudeView().pendingNewSessionProjectID = projectID
+	m.dialogType = "new_claude_session"
+	m.dialogMessage = "New session name:"
+	m.dialogInput.SetValue(defaultName)
+	m.dialogInput.Focus()
+	m.dialogInputActive = true
+	m.showDialog = true
+	return m.dialogInput.Cursor.BlinkCmd()
+}
+
+// generateDefaultSessionName generates a default session name for a project
+func (m *Model) generateDefaultSessionName(projectID string) string {
+	// Count existing sessions for this project
+	count := 0
+	if m.state.Claude != nil {
+		for _, sess := range m.state.Claude.Sessions {
+			if sess.ProjectID == projectID {
+				count++
+			}
+		}
+	}
+	return fmt.Sprintf("session-%d", count+1)
+}
+
+// createClaudeSessionWithName creates a new Claude session with a specific name
+func (m *Model) createClaudeSessionWithName(projectID, name string) tea.Cmd {
+	event := core.NewEvent(core.EventClaudeCreateSession).WithProject(projectID)
+	event.Data["session_name"] = name
+	return m.sendEvent(event)
+}
+
+// createClaudeSession creates a new Claude session for the selected project in tree
+func (m *Model) createClaudeSession() tea.Cmd {
+	projectID, _, isProject, _ := m.getSelectedTreeItem()
+	if projectID != "" {
+		return m.sendEvent(core.NewEvent(core.EventClaudeCreateSession).WithProject(projectID))
+	}
+
+	// Legacy fallback
+	if m.mainIndex >= 0 && m.mainIndex < len(m.claudeView().treeItems) {
+		item := m.claudeView().treeItems[m.mainIndex]
+		return m.sendEvent(core.NewEvent(core.EventClaudeCreateSession).WithProject(item.ProjectID))
+	}
+	_ = isProject // unused
+
+	// Fallback to filter project
+	if m.claudeView().filterProject != "" {
+		return m.sendEvent(core.NewEvent(core.EventClaudeCreateSession).WithProject(m.claudeView().filterProject))
+	}
+
+	// No project context - select first project if available
+	if m.state.Projects != nil && len(m.state.Projects.Projects) > 0 {
+		return m.sendEvent(core.NewEvent(core.EventClaudeCreateSession).WithProject(m.state.Projects.Projects[0].ID))
+	}
+
+	m.lastError = "No projects available"
+	m.lastErrorTime = time.Now()
+	return nil
+}
+
+// selectSessionInTree finds and selects a session in the sessions tree menu
+func (m *Model) selectSessionInTree(sessionID string) {
+	if m.claudeView().treeMenu == nil || sessionID == "" {
+		return
+	}
+
+	// Find the session's project ID
+	var projectID string
+	if m.state.Claude != nil {
+		for _, sess := range m.state.Claude.Sessions {
+			if sess.ID == sessionID {
+				projectID = sess.ProjectID
+				break
+			}
+		}
+	}
+
+	if projectID == "" {
+		return
+	}
+
+	m.selectSessionInTreeWithProject(sessionID, projectID)
+}
+
+// selectSessionInTreeWithProject finds and selects a session in the tree using known project ID
+func (m *Model) selectSessionInTreeWithProject(sessionID, projectID string) {
+	if m.claudeView().treeMenu == nil || sessionID == "" || projectID == "" {
+		return
+	}
+
+	// Navigate to the session:
+	// 1. First, go to root level
+	m.claudeView().treeMenu.DrillUp()
+	for len(m.claudeView().treeMenu.DrillDownPath()) > 0 {
+		m.claudeView().treeMenu.DrillUp()
+	}
+
+	// 2. Find and select the project
+	items := m.claudeView().treeMenu.Items()
+	for i, item := range items {
+		if item.ID == projectID {
+			m.claudeView().treeMenu.SetSelectedIndex(i)
+			// 3. Drill into the project
+			m.claudeView().treeMenu.DrillDown()
+			break
+		}
+	}
+
+	// 4. Find and select the session within the project
+	sessionItems := m.claudeView().treeMenu.VisibleItems()
+	for i, item := range sessionItems {
+		if item.ID == sessionID {
+			// Account for back item (index 0)
+			m.claudeView().treeMenu.SetSelectedIndex(i + 1)
+			break
+		}
+	}
+}
+
+// openClaudeSession opens the selected session in chat mode
+func (m *Model) openClaudeSession() tea.Cmd {
+	if m.state.Claude == nil || len(m.state.Claude.Sessions) == 0 {
+		return nil
+	}
+	if m.mainIndex >= 0 && m.mainIndex < len(m.state.Claude.Sessions) {
+		sess := m.state.Claude.Sessions[m.mainIndex]
+		m.claudeView().activeSession = sess.ID
+		m.claudeView().mode = ClaudeModeChat
+		m.claudeView().sessionLoading = true
+		m.claudeView().chatScroll = 0
+
+		// Update tree immediately so IsActive is set correctly
+		m.updateClaudeTree()
+
+		// Automatically activate input mode when opening a session (not for observers)
+		m.claudeView().inputActive = !m.readOnly
+
+		// Send select event, start cursor blink, and trigger spinner
+		return tea.Batch(
+			m.sendEvent(core.NewEvent(core.EventClaudeSelectSession).WithValue(sess.ID)),
+			m.focusClaudeComposer(),
+			m.spinner.Tick,
+		)
+	}
+	return nil
+}
+
+// switchToSelectedSession switches to the session selected in the tree
+// Uses TreeMenu for navigation: Enter on project drills in, Enter on session opens it
+func (m *Model) switchToSelectedSession() tea.Cmd {
+	if m.claudeView().treeMenu == nil {
+		return nil
+	}
+
+	// Check if back item is selected
+	if m.claudeView().treeMenu.IsBackSelected() {
+		m.claudeView().treeMenu.DrillUp()
+		return nil
+	}
+
+	// Get selected item from TreeMenu
+	treeItem := m.claudeView().treeMenu.SelectedItem()
+	if treeItem == nil {
+		return nil
+	}
+
+	// Check if it's a project (has "project" marker in Data or has children)
+	isProject := false
+	if dataStr, ok := treeItem.Data.(string); ok && dataStr == "project" {
+		isProject = true
+	}
+	if isProject || len(treeItem.Children) > 0 {
+		// Project selected - drill into it to show sessions
+		// Only drill if there are children (sessions)
+		if len(treeItem.Children) > 0 {
+			m.claudeView().treeMenu.DrillDown()
+		}
+		// Either way, don't open a session
+		return nil
+	}
+
+	// Codex session of a task - show it in the Codex view
+	if sess, isCodex := treeItem.Data.(core.CodexSessionVM); isCodex {
+		return m.openCodexSession(sess.ID)
+	}
+
+	// Verify it's actually a session (Data should be ClaudeSessionVM, not a string)
+	sess, isSession := treeItem.Data.(core.ClaudeSessionVM)
+	if !isSession {
+		// Not a session, do nothing
+		return nil
+	}
+
+	// Session selected - switch to it and start terminal
+	sessionID := sess.ID
+	m.claudeView().activeSession = sessionID
+	m.claudeView().mode = ClaudeModeChat
+	m.claudeView().transcript = nil // Back to the terminal
+
+	// Update tree immediately so IsActive is set correctly
+	m.updateClaudeTree()
+
+	// Switch focus to terminal
+	m.focusArea = FocusMain
+
+	// Get or create terminal for this session
+	workDir := ""
+	claudeProjectDir := ""
+	if m.state.Claude != nil {
+		for _, sess := range m.state.Claude.Sessions {
+			if sess.ID == sessionID {
+				workDir = sess.WorkDir
+				claudeProjectDir = sess.ClaudeProjectDir
+				break
+			}
+		}
+	}
+	if workDir == "" {
+		// Default to current dir
+		workDir, _ = os.Getwd()
+	}
+
+	t := m.terminalManager.GetOrCreate(sessionID, workDir, claudeProjectDir)
+
+	// Set terminal size
+	headerHeight := 1
+	footerHeight := 1
+	sidebarWidth := m.sidebarWidth()
+	termWidth := m.width - sidebarWidth - 6
+	termHeight := m.height - headerHeight - footerHeight
+	if termWidth > 20 && termHeight > 5 {
+		t.SetSize(termWidth, termHeight)
+	}
+
+	// Start Claude if not already running
+	if !t.IsRunning() {
+		if err := t.Start(sessionID); err != nil {
+			m.lastError = "Failed to start Claude: " + err.Error()
+			m.lastErrorTime = time.Now()
+			return nil
+		}
+	}
+
+	// Enter terminal mode - reset conflicting input modes
+	m.terminalMode = true
+	m.claudeView().inputActive = false
+	m.claudeView().renameActive = false
+	m.commandMode = false
+
+	// Start terminal refresh loop
+	return m.scheduleTerminalRefresh()
+}
+
+// switchToSessionByID switches to a specific session by ID (used by TreeMenu)
+func (m *Model) switchToSessionByID(sessionID string) tea.Cmd {
+	// Session selected - switch to it and start terminal
+	m.claudeView().activeSession = sessionID
+	m.claudeView().mode = ClaudeModeChat
+	m.claudeView().transcript = nil // Back to the terminal
+
+	// Update tree immediately so IsActive is set correctly
+	m.updateClaudeTree()
+
+	// Switch focus to terminal
+	m.focusArea = FocusMain
+
+	// Get work directory and ClaudeProjectDir for this session
+	workDir := ""
+	claudeProjectDir := ""
+	if m.state.Claude != nil {
+		for _, sess := range m.state.Claude.Sessions {
+			if sess.ID == sessionID {
+				workDir = sess.WorkDir
+				claudeProjectDir = sess.ClaudeProjectDir
+				break
+			}
+		}
+	}
+	if workDir == "" {
+		// Default to current dir
+		workDir, _ = os.Getwd()
+	}
+
+	t := m.terminalManager.GetOrCreate(sessionID, workDir, claudeProjectDir)
+
+	// Set terminal size
+	headerHeight := 1
+	footerHeight := 1
+	sidebarWidth := m.sidebarWidth()
+	termWidth := m.width - sidebarWidth - 6
+	termHeight := m.height - headerHeight - footerHeight
+	if termWidth > 20 && termHeight > 5 {
+		t.SetSize(termWidth, termHeight)
+	}
+
+	// Start Claude if not already running
+	if !t.IsRunning() {
+		if err := t.Start(sessionID); err != nil {
+			m.lastError = "Failed to start Claude: " + err.Error()
+			m.lastErrorTime = time.Now()
+			return nil
+		}
+	}
+
+	// Enter terminal mode - reset conflicting input modes
+	m.terminalMode = true
+	m.claudeView().inputActive = false
+	m.claudeView().renameActive = false
+	m.commandMode = false
+
+	// Start terminal refresh loop
+	return m.scheduleTerminalRefresh()
+}
+
+// stopClaudeTerminal stops the tmux terminal for a session
+func (m *Model) stopClaudeTerminal(sessionID string) tea.Cmd {
+	if m.terminalManager == nil {
+		return nil
+	}
+
+	t := m.terminalManager.Get(sessionID)
+	if t == nil {
+		return nil
+	}
+
+	// Stop the terminal in goroutine to avoid blocking UI
+	go t.Stop()
+
+	// If this was the active terminal, exit terminal mode
+	if m.claudeView().activeSession == sessionID && m.terminalMode {
+		m.terminalMode = false
+	}
+
+	// Show feedback
+	m.lastError = "Terminal stopped"
+	m.lastErrorTime = time.Now()
+
+	return nil
+}
+
+// handleClaudeInput handles text input in Claude chat mode
+// Controls:
+//   - Enter: send message, stay in input mode (Alt+Enter with claude.send_key: alt+enter)
+//   - Shift+Enter (sent as Alt+Enter), Ctrl+J: insert a newline (Enter with alt+enter)
+//   - Up/Down on the first/last row: recall the previous messages
+//   - Escape: interrupt current Claude request (if processing)
+//   - Double-Escape (within 500ms): exit input mode
+func (m *Model) handleClaudeInput(msg tea.KeyMsg) tea.Cmd {
+	keyStr := msg.String()
+
+	// Ctrl+C: exit input mode (always)
+	if keyStr == "ctrl+c" {
+		m.claudeView().inputActive = false
+		m.claudeView().textInput.Blur()
+		m.inputHistory.Reset()
+		return nil
+	}
+
+	if msg.Paste {
+		return m.handlePaste("", string(msg.Runes))
+	}
+
+	switch msg.Type {
+	case tea.KeyEscape:
+		now := time.Now()
+		// Double-ESC detection: if last ESC was within 500ms, exit input mode
+		if now.Sub(m.claudeView().lastEscTime) < 500*time.Millisecond {
+			m.claudeView().inputActive = false
+			m.claudeView().textInput.Blur()
+			m.focusArea = FocusDetail                // Switch to sessions panel
+			m.claudeView().lastEscTime = time.Time{} // Reset
+			return nil
+		}
+		m.claudeView().lastEscTime = now
+
+		// Single ESC: interrupt current request if processing
+		if m.state.Claude != nil && m.state.Claude.IsProcessing {
+			return m.sendEvent(core.NewEvent(core.EventClaudeStopSession).WithValue(m.claudeView().activeSession))
+		}
+		// Not processing - wait for potential second ESC
+		return nil
+	case tea.KeyCtrlJ:
+		m.insertIntoClaudeComposer("\n")
+		return nil
+	case tea.KeyEnter:
+		if !isClaudeSendKey(msg) {
+			m.insertIntoClaudeComposer("\n")
+			return nil
+		}
+		message := m.claudeView().textInput.Value()
+		if strings.TrimSpace(message) == "" {
+			return nil
+		}
+		m.inputHistory.Add(historyClaude, message)
+		// Clear input immediately for responsiveness
+		m.claudeView().textInput.Reset()
+		m.resizeClaudeComposer()
+
+		// Add user message to UI state IMMEDIATELY (before event processing)
+		// This gives instant visual feedback
+		if m.state.Claude != nil {
+			now := time.Now()
+			userMsg := core.ClaudeMessageVM{
+				ID:        "user-" + now.Format("20060102150405.000"),
+				Role:      "user",
+				Content:   message,
+				Timestamp: now,
+				TimeStr:   now.Format("060102 - 15:04:05"),
+			}
+			m.state.Claude.Messages = append(m.state.Claude.Messages, userMsg)
+
+			// Add placeholder for assistant response
+			assistantMsg := core.ClaudeMessageVM{
+				ID:        "assistant-" + now.Format("20060102150405.000"),
+				Role:      "assistant",
+				Content:   "",
+				Timestamp: now,
+				TimeStr:   now.Format("060102 - 15:04:05"),
+				IsPartial: true,
+			}
+			m.state.Claude.Messages = append(m.state.Claude.Messages, assistantMsg)
+			m.state.Claude.IsProcessing = true
+
+			// Reset scroll to bottom to show new messages
+			m.claudeView().chatScroll = 0
+		}
+
+		// Send event to presenter (async processing) and start refresh loop
+		return tea.Batch(
+			m.sendEvent(core.NewEvent(core.EventClaudeSendMessage).
+				WithData("session_id", m.claudeView().activeSession).
+				WithData("message", message)),
+			claudeRefreshCmd(),
+		)
+	case tea.KeyUp:
+		if m.claudeComposerOnFirstRow() {
+			if entry, ok := m.inputHistory.Prev(historyClaude, m.claudeView().textInput.Value()); ok {
+				m.setClaudeComposerValue(entry)
+			}
+			return nil
+		}
+	case tea.KeyDown:
+		if m.claudeComposerOnLastRow() {
+			if entry, ok := m.inputHistory.Next(historyClaude); ok {
+				m.setClaudeComposerValue(entry)
+			}
+			return nil
+		}
+	}
+
+	// Let the textarea handle all other keys (and Up/Down inside the text)
+	var cmd tea.Cmd
+	m.claudeView().textInput, cmd = m.claudeView().textInput.Update(msg)
+	m.resizeClaudeComposer()
+	return cmd
+}
+
+// handleClaudeRenameInput handles text input for renaming Claude sessions
+func (m *Model) handleClaudeRenameInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.claudeView().renameActive = false
+		m.claudeView().treeMenu.SetRenameActive(false)
+		return nil
+	case tea.KeyEnter:
+		newName := m.claudeView().treeMenu.RenameText()
+		if newName == "" {
+			m.claudeView().renameActive = false
+			m.claudeView().treeMenu.SetRenameActive(false)
+			return nil
+		}
+		// Rename session
+		m.claudeView().renameActive = false
+		m.claudeView().treeMenu.SetRenameActive(false)
+		// Get selected session ID from TreeMenu
+		_, sessionID, isProject, _ := m.getSelectedTreeItem()
+		if isProject || sessionID == "" {
+			return nil
+		}
+		return m.sendEvent(core.NewEvent(core.EventClaudeRenameSession).
+			WithData("session_id", sessionID).
+			WithData("new_name", newName))
+	case tea.KeyBackspace:
+		m.claudeView().treeMenu.BackspaceRenameText()
+		return nil
+	case tea.KeySpace:
+		m.claudeView().treeMenu.AppendRenameText(" ")
+		return nil
+	case tea.KeyRunes:
+		m.claudeView().treeMenu.AppendRenameText(string(msg.Runes))
+		return nil
+	}
+	return nil
+}
+
+// deleteClaudeSessions deletes Claude sessions: they are shown as deleting
+// until the presenter removes them, their terminals are stopped
+func (m *Model) deleteClaudeSessions(sessionIDs ...string) tea.Cmd {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+	for _, sessionID := range sessionIDs {
+		// Mark session as deleting for visual feedback
+		m.claudeView().deletingSessions[sessionID] = true
+		// Reset active session if deleting it
+		if m.claudeView().activeSession == sessionID {
+			m.claudeView().activeSession = ""
+		}
+	}
+
+	// Update tree immediately so the Disabled flag is set
+	m.updateClaudeTree()
+
+	// Move selection away from deleting session
+	if m.claudeView().treeMenu != nil {
+		m.claudeView().treeMenu.MoveAwayFromDisabled()
+	}
+
+	// Stop terminal and kill tmux in goroutine to avoid blocking UI
+	tm := m.terminalManager
+	go func() {
+		for _, sessionID := range sessionIDs {
+			if tm != nil {
+				if t := tm.Get(sessionID); t != nil {
+					t.Stop()
+				}
+			}
+			// Also kill any persistent tmux session
+			KillTmuxSession(sessionID)
+		}
+	}()
+
+	cmds := make([]tea.Cmd, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		cmds = append(cmds, m.sendEvent(core.NewEvent(core.EventClaudeDeleteSession).WithValue(sessionID)))
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/claude"
+	"csd-devtrack/cli/modules/ui/core"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// copyToClipboard copies text to the system clipboard with an OSC52 escape sequence.
+// It works over SSH; inside tmux the sequence is wrapped in a passthrough.
+// Terminals without OSC52 support are covered by the native clipboard tool
+// (pbcopy, wl-copy, xclip, xsel, clip) when one is available.
+func copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		seq := osc52.New(text)
+		if os.Getenv("TMUX") != "" {
+			seq = seq.Tmux()
+		} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+			seq = seq.Screen()
+		}
+		seq.WriteTo(os.Stdout)
+
+		if args := nativeClipboardCommand(); args != nil {
+			cmd := exec.Command(args[0], args[1:]...)
+			cmd.Stdin = strings.NewReader(text)
+			cmd.Run()
+		}
+		return nil
+	}
+}
+
+// nativeClipboardCommand returns the command writing its input to the system
+// clipboard, nil if none is available (no tool or no display)
+func nativeClipboardCommand() []string {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		if os.Getenv("DISPLAY") != "" {
+			candidates = append(candidates,
+				[]string{"xclip", "-selection", "clipboard"},
+				[]string{"xsel", "--clipboard", "--input"})
+		}
+	}
+	for _, args := range candidates {
+		if _, err := exec.LookPath(args[0]); err == nil {
+			return args
+		}
+	}
+	return nil
+}
+
+// yankText copies text to the clipboard and confirms it in the header
+func (m *Model) yankText(label, text string) tea.Cmd {
+	if text == "" {
+		m.lastError = "Nothing to copy"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess, "Copied "+label+" to clipboard"))
+	return copyToClipboard(text)
+}
+
+// yankSelectedProject copies the path of the project selected in the
+// current view
+func (m *Model) yankSelectedProject() tea.Cmd {
+	if proj := m.findProjectVM(m.getSelectedProjectID()); proj != nil {
+		return m.yankText("project path", proj.Path)
+	}
+	return m.yankText("", "")
+}
+
+// gitSelectedFilePath returns the absolute path and status of the file
+// selected in the Git view, "" if a project is selected
+func (m *Model) gitSelectedFilePath() (string, string) {
+	item := m.gitView().menu.SelectedItem()
+	if item == nil {
+		return "", ""
+	}
+	file, ok := item.Data.(GitFileEntry)
+	if !ok {
+		return "", ""
+	}
+	drillPath := m.gitView().menu.DrillDownPath()
+	if len(drillPath) == 0 {
+		return "", ""
+	}
+	projectPath := m.gitProjectPath(drillPath[0])
+	if projectPath == "" {
+		return "", ""
+	}
+	return filepath.Join(projectPath, file.Path), file.Status
+}
+
+// gitDiffHunkAt returns the diff hunk (from its @@ header) containing a line
+func gitDiffHunkAt(lines []string, index int) string {
+	if index >= len(lines) {
+		index = len(lines) - 1
+	}
+	start := -1
+	for i := index; i >= 0; i-- {
+		if strings.HasPrefix(lines[i], "@@") {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		// Above the first hunk (file header): take the first hunk
+		for i := max(index, 0); i < len(lines); i++ {
+			if strings.HasPrefix(lines[i], "@@") {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			return ""
+		}
+	}
+	end := start + 1
+	for end < len(lines) && !strings.HasPrefix(lines[end], "@@") && !strings.HasPrefix(lines[end], "diff ") {
+		end++
+	}
+	return strings.TrimRight(strings.Join(lines[start:end], "\n"), "\n") + "\n"
+}
+
+// yankClaudeMessage copies the last message of the active Claude session
+func (m *Model) yankClaudeMessage() tea.Cmd {
+	return m.yankText("Claude message", m.lastClaudeMessage())
+}
+
+// lastClaudeMessage returns the last assistant message of the active Claude
+// session (the transcript when shown, the session file otherwise)
+func (m *Model) lastClaudeMessage() string {
+	var messages []claude.Message
+	if t := m.claudeView().transcript; t != nil && t.sessionID == m.claudeView().activeSession {
+		messages = t.messages
+	} else if m.claudeView().activeSession != "" && m.state.Claude != nil {
+		for _, sess := range m.state.Claude.Sessions {
+			if sess.ID == m.claudeView().activeSession {
+				messages, _ = claude.ReadSessionMessages(getClaudeSessionFile(sess.ClaudeProjectDir, sess.ID), sess.ID)
+				break
+			}
+		}
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "assistant" {
+			return strings.TrimSpace(messages[i].Content)
+		}
+	}
+	return ""
+}
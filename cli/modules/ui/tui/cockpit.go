@@ -3,10 +3,14 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/trash"
 	"csd-devtrack/cli/modules/ui/core"
 
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -19,6 +23,230 @@ type WidgetLayout struct {
 	Height int
 }
 
+// cockpitController is the submodel of the Cockpit (widgets) view
+type cockpitController struct {
+	focusedIndex int       // Currently focused widget index
+	widgetActive bool      // True when inside a widget (tmux session active)
+	configMode   bool      // True when configuring cockpit
+	configStep   string    // "grid", "widgets", "filters", "profile_name"
+	configRows   int       // Grid rows being configured
+	configCols   int       // Grid cols being configured
+	configCell   int       // Current cell being configured
+	gridMenu     *TreeMenu // Menu for grid size selection
+	typeMenu     *TreeMenu // Menu for widget type selection
+	filterMenu   *TreeMenu // Menu for filter selection
+	profileMenu  *TreeMenu // Menu for profile selection
+	newName      string    // New profile name being entered
+	renaming     bool      // True when renaming profile
+	creatingNew  bool      // True when creating new profile
+	editMode     bool      // True when editing settings
+	editProfile  bool      // True when editing profile (rows/cols), false when editing widget
+	editField    string    // Current field being edited
+	editValue    string    // Current input value for the field being edited
+}
+
+// newCockpitController creates the Cockpit view controller
+func newCockpitController() *cockpitController {
+	return &cockpitController{}
+}
+
+// cockpitView returns the Cockpit view submodel
+func (m *Model) cockpitView() *cockpitController {
+	return m.controllers[core.VMCockpit].(*cockpitController)
+}
+
+// Init implements ViewController
+func (c *cockpitController) Init(m *Model) tea.Cmd {
+	// Focus on top-left widget, out of the config mode of a previous visit
+	c.focusedIndex = m.getTopLeftWidgetIndex()
+	c.configMode = false
+	return nil
+}
+
+// Menu implements ViewController: the config mode menus, whatever the focus
+// (the widgets are navigated by position)
+func (c *cockpitController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	if !c.configMode {
+		return nil
+	}
+	switch c.configStep {
+	case "grid":
+		return c.gridMenu
+	case "widgets":
+		return c.typeMenu
+	case "filters":
+		return c.filterMenu
+	case "profile":
+		return c.profileMenu
+	}
+	return nil
+}
+
+// Keys implements ViewController
+func (c *cockpitController) Keys(m *Model) []KeyHint {
+	if c.configMode {
+		return []KeyHint{
+			{"↑↓", "select"},
+			{"Enter", "confirm"},
+			{"Esc", "back"},
+		}
+	}
+	return []KeyHint{
+		{"↑↓←→", "navigate"},
+		{"1-9", "profile"},
+		{"c", "config"},
+		{"n", "new"},
+		{"r", "rename"},
+		{"x", "delete"},
+	}
+}
+
+// Update implements ViewController
+func (c *cockpitController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case itemCountMsg:
+		// Count widgets in active profile
+		profile := m.getCockpitProfile(m.getActiveCockpitProfile())
+		if profile != nil {
+			m.maxMainItems = len(profile.Widgets)
+		} else {
+			m.maxMainItems = 0
+		}
+		return nil, true
+	case keyPressMsg:
+		return c.handleKeyPress(m, msg.key)
+	case dialogConfirmMsg:
+		if msg.dialogType == "delete_cockpit_profile" {
+			// Delete the current cockpit profile, keeping a copy in the trash (undo)
+			name := m.getActiveCockpitProfile()
+			var cmd tea.Cmd
+			if cfg := config.GetGlobal(); cfg != nil && len(cfg.WidgetProfiles) > 1 && cfg.WidgetProfiles[name] != nil {
+				cmd = m.trashEvent(trash.KindCockpitProfile, name, core.TrashCockpitProfile{Name: name, Profile: cfg.WidgetProfiles[name]})
+			}
+			m.deleteCockpitProfile()
+			return cmd, true
+		}
+	case tea.KeyMsg:
+		return c.handleKey(m, msg.String())
+	}
+	return nil, false
+}
+
+// View implements ViewController
+func (c *cockpitController) View(m *Model, width, height int) string {
+	return m.renderCockpit(width, height)
+}
+
+// handleKeyPress handles the config and edit modes, then the navigation
+// between widgets
+func (c *cockpitController) handleKeyPress(m *Model, msg tea.KeyMsg) (tea.Cmd, bool) {
+	// Config mode and widget edit mode take their keys first
+	if c.configMode && m.handleCockpitConfigNavigation(msg) {
+		return nil, true
+	}
+	if c.editMode && m.handleWidgetEditNavigation(msg.String()) {
+		return nil, true
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Tab):
+		if c.configMode {
+			return nil, false
+		}
+		if m.focusArea == FocusSidebar {
+			// From sidebar, go to top-left widget
+			m.focusArea = FocusMain
+			c.focusedIndex = m.getTopLeftWidgetIndex()
+		} else {
+			// Cycle through widgets (never return to sidebar)
+			m.navigateCockpitNext()
+		}
+	case key.Matches(msg, m.keys.Up):
+		// The config menus are navigated as trees
+		if m.focusArea != FocusMain || c.Menu(m, FocusMain) != nil {
+			return nil, false
+		}
+		m.navigateCockpitUp()
+	case key.Matches(msg, m.keys.Down):
+		if m.focusArea != FocusMain || c.Menu(m, FocusMain) != nil {
+			return nil, false
+		}
+		m.navigateCockpitDown()
+	case key.Matches(msg, m.keys.Left):
+		m.navigateCockpitLeft()
+	case key.Matches(msg, m.keys.Right):
+		m.navigateCockpitRight()
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// handleKey handles the profile and config mode keys
+func (c *cockpitController) handleKey(m *Model, key string) (tea.Cmd, bool) {
+	switch key {
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if !c.configMode {
+			m.switchCockpitProfile(key)
+		}
+		return nil, true
+	case "c":
+		// Enter/exit config mode
+		if !c.configMode && m.blockReadOnly("cockpit configuration") {
+			return nil, true
+		}
+		c.configMode = !c.configMode
+		if c.configMode {
+			c.configStep = "grid"
+			m.initCockpitConfigMenus()
+		}
+		return nil, true
+	case "n":
+		// New profile
+		if !c.configMode {
+			m.startNewCockpitProfile()
+		}
+		return nil, true
+	case "x":
+		// Delete profile (with confirmation)
+		if !c.configMode {
+			cfg := config.GetGlobal()
+			if cfg != nil && len(cfg.WidgetProfiles) > 1 {
+				return m.openConfirmDialog(config.ConfirmDeleteProfile, "delete_cockpit_profile",
+					fmt.Sprintf("Delete profile '%s'?", m.getActiveCockpitProfile())), true
+			} else {
+				m.lastError = "Cannot delete the only profile"
+				m.lastErrorTime = time.Now()
+			}
+		}
+		return nil, true
+	case "r":
+		// Rename profile
+		if !c.configMode && !c.editMode {
+			m.startRenameCockpitProfile()
+		}
+		return nil, true
+	case "e":
+		// Edit profile grid settings (rows/cols)
+		if !c.configMode && !c.editMode {
+			m.startProfileEdit()
+		}
+		return nil, true
+	case "enter":
+		if c.configMode {
+			return m.handleCockpitConfigEnter(), true
+		}
+		return nil, true
+	case "esc":
+		if c.configMode {
+			c.configMode = false
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
 // renderCockpit renders the configurable widgets view
 func (m *Model) renderCockpit(width, height int) string {
 	cfg := config.GetGlobal()
@@ -39,12 +267,12 @@ func (m *Model) renderCockpit(width, height int) string {
 	}
 
 	// Add config overlay if in config mode
-	if m.cockpitConfigMode {
+	if m.cockpitView().configMode {
 		return m.renderCockpitConfigOverlay(width, height)
 	}
 
 	// Add edit overlay if in edit mode
-	if m.cockpitEditMode {
+	if m.cockpitView().editMode {
 		return m.renderWidgetEditOverlay(width, height)
 	}
 
@@ -61,7 +289,7 @@ func (m *Model) renderCockpit(width, height int) string {
 // renderCockpitEmpty renders the empty state when no profiles exist
 func (m *Model) renderCockpitEmpty(width, height int) string {
 	// Check for config overlay first (e.g., creating new profile)
-	if m.cockpitConfigMode {
+	if m.cockpitView().configMode {
 		return m.renderCockpitConfigOverlay(width, height)
 	}
 
@@ -341,7 +569,7 @@ func (m *Model) renderWidgetGrid(layouts []WidgetLayout, profile *config.WidgetP
 				Height: widgetHeight,
 			}
 
-			focused := m.focusArea == FocusMain && m.cockpitFocusedIndex == w.Index
+			focused := m.focusArea == FocusMain && m.cockpitView().focusedIndex == w.Index
 			content := m.renderWidgetContent(adjustedLayout, focused)
 			widgetStrings = append(widgetStrings, content)
 		}
@@ -450,19 +678,18 @@ func (m *Model) renderWidgetLogs(widget *config.WidgetConfig, width, height int)
 			Render("No logs")
 	}
 
-	// Filter logs based on widget config
-	var filtered []core.LogLineVM
-	for _, line := range m.state.Logs.Lines {
+	// Filter logs based on widget config (only the last lines that fit are collected)
+	filtered := tailLogLines(m.state.Logs.Lines, height, func(line *core.LogLineVM) bool {
 		// Project filter
 		if widget.ProjectFilter != "" && line.Source != widget.ProjectFilter {
-			continue
+			return false
 		}
 		// Level filter
 		if widget.LogLevelFilter != "" && line.Level != widget.LogLevelFilter {
-			continue
+			return false
 		}
-		filtered = append(filtered, line)
-	}
+		return true
+	})
 
 	if len(filtered) == 0 {
 		return lipgloss.NewStyle().
@@ -660,10 +887,10 @@ func (m *Model) renderWidgetGit(widget *config.WidgetConfig, width, height int)
 func (m *Model) renderWidgetClaude(widget *config.WidgetConfig, width, height int) string {
 	// Check capabilities
 	if m.state.Capabilities != nil {
-		if !m.state.Capabilities.Tmux.Available {
+		if !m.state.Capabilities.HasTerminal() {
 			return lipgloss.NewStyle().
 				Foreground(ColorWarning).
-				Render("tmux not installed")
+				Render("no terminal backend (tmux or PTY)")
 		}
 		if !m.state.Capabilities.Claude.Available {
 			return lipgloss.NewStyle().
@@ -732,10 +959,10 @@ func (m *Model) renderWidgetClaude(widget *config.WidgetConfig, width, height in
 func (m *Model) renderWidgetDatabase(widget *config.WidgetConfig, width, height int) string {
 	// Check capabilities
 	if m.state.Capabilities != nil {
-		if !m.state.Capabilities.Tmux.Available {
+		if !m.state.Capabilities.HasTerminal() {
 			return lipgloss.NewStyle().
 				Foreground(ColorWarning).
-				Render("tmux not installed")
+				Render("no terminal backend (tmux or PTY)")
 		}
 		if !m.state.Capabilities.HasDatabase() {
 			return lipgloss.NewStyle().
@@ -744,6 +971,11 @@ func (m *Model) renderWidgetDatabase(widget *config.WidgetConfig, width, height
 		}
 	}
 
+	if m.state.Database != nil && !m.state.Database.Loaded {
+		return lipgloss.NewStyle().
+			Foreground(ColorMuted).
+			Render("Detecting databases...")
+	}
 	if m.state.Database == nil || len(m.state.Database.Databases) == 0 {
 		return lipgloss.NewStyle().
 			Foreground(ColorMuted).
@@ -879,12 +1111,12 @@ func (m *Model) navigateCockpitUp() {
 	}
 
 	// Find current widget's row/col
-	if m.cockpitFocusedIndex < 0 || m.cockpitFocusedIndex >= len(profile.Widgets) {
-		m.cockpitFocusedIndex = 0
+	if m.cockpitView().focusedIndex < 0 || m.cockpitView().focusedIndex >= len(profile.Widgets) {
+		m.cockpitView().focusedIndex = 0
 		return
 	}
 
-	currentWidget := profile.Widgets[m.cockpitFocusedIndex]
+	currentWidget := profile.Widgets[m.cockpitView().focusedIndex]
 	currentRow := currentWidget.Row
 	currentCol := currentWidget.Col
 
@@ -908,7 +1140,7 @@ func (m *Model) navigateCockpitUp() {
 	}
 
 	if bestIdx >= 0 {
-		m.cockpitFocusedIndex = bestIdx
+		m.cockpitView().focusedIndex = bestIdx
 	}
 }
 
@@ -919,12 +1151,12 @@ func (m *Model) navigateCockpitDown() {
 		return
 	}
 
-	if m.cockpitFocusedIndex < 0 || m.cockpitFocusedIndex >= len(profile.Widgets) {
-		m.cockpitFocusedIndex = 0
+	if m.cockpitView().focusedIndex < 0 || m.cockpitView().focusedIndex >= len(profile.Widgets) {
+		m.cockpitView().focusedIndex = 0
 		return
 	}
 
-	currentWidget := profile.Widgets[m.cockpitFocusedIndex]
+	currentWidget := profile.Widgets[m.cockpitView().focusedIndex]
 	currentRow := currentWidget.Row
 	currentCol := currentWidget.Col
 
@@ -945,7 +1177,7 @@ func (m *Model) navigateCockpitDown() {
 	}
 
 	if bestIdx >= 0 {
-		m.cockpitFocusedIndex = bestIdx
+		m.cockpitView().focusedIndex = bestIdx
 	}
 }
 
@@ -956,12 +1188,12 @@ func (m *Model) navigateCockpitLeft() {
 		return
 	}
 
-	if m.cockpitFocusedIndex < 0 || m.cockpitFocusedIndex >= len(profile.Widgets) {
-		m.cockpitFocusedIndex = 0
+	if m.cockpitView().focusedIndex < 0 || m.cockpitView().focusedIndex >= len(profile.Widgets) {
+		m.cockpitView().focusedIndex = 0
 		return
 	}
 
-	currentWidget := profile.Widgets[m.cockpitFocusedIndex]
+	currentWidget := profile.Widgets[m.cockpitView().focusedIndex]
 	currentRow := currentWidget.Row
 	currentCol := currentWidget.Col
 
@@ -978,7 +1210,7 @@ func (m *Model) navigateCockpitLeft() {
 	}
 
 	if bestIdx >= 0 {
-		m.cockpitFocusedIndex = bestIdx
+		m.cockpitView().focusedIndex = bestIdx
 	}
 }
 
@@ -989,12 +1221,12 @@ func (m *Model) navigateCockpitRight() {
 		return
 	}
 
-	if m.cockpitFocusedIndex < 0 || m.cockpitFocusedIndex >= len(profile.Widgets) {
-		m.cockpitFocusedIndex = 0
+	if m.cockpitView().focusedIndex < 0 || m.cockpitView().focusedIndex >= len(profile.Widgets) {
+		m.cockpitView().focusedIndex = 0
 		return
 	}
 
-	currentWidget := profile.Widgets[m.cockpitFocusedIndex]
+	currentWidget := profile.Widgets[m.cockpitView().focusedIndex]
 	currentRow := currentWidget.Row
 	currentCol := currentWidget.Col
 
@@ -1011,7 +1243,7 @@ func (m *Model) navigateCockpitRight() {
 	}
 
 	if bestIdx >= 0 {
-		m.cockpitFocusedIndex = bestIdx
+		m.cockpitView().focusedIndex = bestIdx
 	}
 }
 
@@ -1054,7 +1286,7 @@ func (m *Model) navigateCockpitNext() {
 	// Find current position in sorted order
 	currentSortedIdx := 0
 	for i, pos := range positions {
-		if pos.index == m.cockpitFocusedIndex {
+		if pos.index == m.cockpitView().focusedIndex {
 			currentSortedIdx = i
 			break
 		}
@@ -1062,7 +1294,7 @@ func (m *Model) navigateCockpitNext() {
 
 	// Move to next in sorted order (wrap around)
 	nextSortedIdx := (currentSortedIdx + 1) % len(positions)
-	m.cockpitFocusedIndex = positions[nextSortedIdx].index
+	m.cockpitView().focusedIndex = positions[nextSortedIdx].index
 }
 
 // getTopLeftWidgetIndex returns the index of the widget at the top-left position
@@ -1118,7 +1350,7 @@ func (m *Model) switchCockpitProfile(key string) {
 		if cfg.Settings != nil {
 			cfg.Settings.ActiveWidgetProfile = profileNames[idx]
 			// Reset focused index when switching profiles
-			m.cockpitFocusedIndex = 0
+			m.cockpitView().focusedIndex = 0
 			// Save config to persist the change
 			_ = config.SaveGlobal()
 		}
@@ -30,11 +30,11 @@ func (m *Model) initCockpitConfigMenus() {
 	// Get current profile settings
 	profile := m.getCockpitProfile(m.getActiveCockpitProfile())
 	if profile != nil {
-		m.cockpitConfigRows = profile.Rows
-		m.cockpitConfigCols = profile.Cols
+		m.cockpitView().configRows = profile.Rows
+		m.cockpitView().configCols = profile.Cols
 	} else {
-		m.cockpitConfigRows = 2
-		m.cockpitConfigCols = 2
+		m.cockpitView().configRows = 2
+		m.cockpitView().configCols = 2
 	}
 
 	// Initialize grid size menu
@@ -48,8 +48,8 @@ func (m *Model) initCockpitConfigMenus() {
 			})
 		}
 	}
-	m.cockpitGridMenu = NewTreeMenu(gridItems)
-	m.cockpitGridMenu.SetTitle("Grid Size")
+	m.cockpitView().gridMenu = NewTreeMenu(gridItems)
+	m.cockpitView().gridMenu.SetTitle("Grid Size")
 
 	// Initialize widget type menu
 	typeItems := []TreeMenuItem{}
@@ -59,8 +59,8 @@ func (m *Model) initCockpitConfigMenus() {
 			Data:  opt.Type,
 		})
 	}
-	m.cockpitTypeMenu = NewTreeMenu(typeItems)
-	m.cockpitTypeMenu.SetTitle("Widget Type")
+	m.cockpitView().typeMenu = NewTreeMenu(typeItems)
+	m.cockpitView().typeMenu.SetTitle("Widget Type")
 
 	// Initialize profile menu
 	m.initCockpitProfileMenu()
@@ -84,8 +84,8 @@ func (m *Model) initCockpitProfileMenu() {
 		}
 	}
 
-	m.cockpitProfileMenu = NewTreeMenu(items)
-	m.cockpitProfileMenu.SetTitle("Profiles")
+	m.cockpitView().profileMenu = NewTreeMenu(items)
+	m.cockpitView().profileMenu.SetTitle("Profiles")
 }
 
 // initCockpitFilterMenu initializes the filter menu for current widget
@@ -130,68 +130,68 @@ func (m *Model) initCockpitFilterMenu(widgetType config.WidgetType) {
 		}
 	}
 
-	m.cockpitFilterMenu = NewTreeMenu(items)
-	m.cockpitFilterMenu.SetTitle("Filters")
+	m.cockpitView().filterMenu = NewTreeMenu(items)
+	m.cockpitView().filterMenu.SetTitle("Filters")
 }
 
 // handleCockpitConfigEnter handles Enter key in config mode
 func (m *Model) handleCockpitConfigEnter() tea.Cmd {
-	switch m.cockpitConfigStep {
+	switch m.cockpitView().configStep {
 	case "grid":
 		// Grid size selected, get the selection
-		if item := m.cockpitGridMenu.SelectedItem(); item != nil {
+		if item := m.cockpitView().gridMenu.SelectedItem(); item != nil {
 			if size, ok := item.Data.([2]int); ok {
-				m.cockpitConfigRows = size[0]
-				m.cockpitConfigCols = size[1]
+				m.cockpitView().configRows = size[0]
+				m.cockpitView().configCols = size[1]
 			}
 		}
 		// Move to widget configuration
-		m.cockpitConfigStep = "widgets"
-		m.cockpitConfigCell = 0
+		m.cockpitView().configStep = "widgets"
+		m.cockpitView().configCell = 0
 		return nil
 
 	case "widgets":
 		// Widget type selected for current cell
-		if item := m.cockpitTypeMenu.SelectedItem(); item != nil {
+		if item := m.cockpitView().typeMenu.SelectedItem(); item != nil {
 			if wtype, ok := item.Data.(config.WidgetType); ok {
-				m.setWidgetTypeForCell(m.cockpitConfigCell, wtype)
+				m.setWidgetTypeForCell(m.cockpitView().configCell, wtype)
 				// Move to filter configuration for this widget
 				m.initCockpitFilterMenu(wtype)
-				m.cockpitConfigStep = "filters"
+				m.cockpitView().configStep = "filters"
 			}
 		}
 		return nil
 
 	case "filters":
 		// Filter selected for current widget
-		if item := m.cockpitFilterMenu.SelectedItem(); item != nil {
+		if item := m.cockpitView().filterMenu.SelectedItem(); item != nil {
 			if filter, ok := item.Data.(string); ok {
-				m.setWidgetFilterForCell(m.cockpitConfigCell, filter)
+				m.setWidgetFilterForCell(m.cockpitView().configCell, filter)
 			}
 		}
 		// Move to next cell or finish
-		m.cockpitConfigCell++
-		totalCells := m.cockpitConfigRows * m.cockpitConfigCols
-		if m.cockpitConfigCell >= totalCells {
+		m.cockpitView().configCell++
+		totalCells := m.cockpitView().configRows * m.cockpitView().configCols
+		if m.cockpitView().configCell >= totalCells {
 			// Done configuring, save and exit
 			m.saveCockpitProfile()
-			m.cockpitConfigMode = false
+			m.cockpitView().configMode = false
 		} else {
-			m.cockpitConfigStep = "widgets"
+			m.cockpitView().configStep = "widgets"
 		}
 		return nil
 
 	case "profile_name":
 		// Profile name entered
-		if m.cockpitCreatingNew {
-			m.createNewCockpitProfile(m.cockpitNewName)
-			m.cockpitCreatingNew = false
-		} else if m.cockpitRenaming {
-			m.renameCurrentCockpitProfile(m.cockpitNewName)
-			m.cockpitRenaming = false
-		}
-		m.cockpitConfigStep = "grid"
-		m.cockpitNewName = ""
+		if m.cockpitView().creatingNew {
+			m.createNewCockpitProfile(m.cockpitView().newName)
+			m.cockpitView().creatingNew = false
+		} else if m.cockpitView().renaming {
+			m.renameCurrentCockpitProfile(m.cockpitView().newName)
+			m.cockpitView().renaming = false
+		}
+		m.cockpitView().configStep = "grid"
+		m.cockpitView().newName = ""
 		return nil
 	}
 	return nil
@@ -210,8 +210,8 @@ func (m *Model) setWidgetTypeForCell(cellIndex int, wtype config.WidgetType) {
 		// Create new profile
 		profile = &config.WidgetProfile{
 			Name: profileName,
-			Rows: m.cockpitConfigRows,
-			Cols: m.cockpitConfigCols,
+			Rows: m.cockpitView().configRows,
+			Cols: m.cockpitView().configCols,
 		}
 		if cfg.WidgetProfiles == nil {
 			cfg.WidgetProfiles = make(map[string]*config.WidgetProfile)
@@ -220,12 +220,12 @@ func (m *Model) setWidgetTypeForCell(cellIndex int, wtype config.WidgetType) {
 	}
 
 	// Update grid size
-	profile.Rows = m.cockpitConfigRows
-	profile.Cols = m.cockpitConfigCols
+	profile.Rows = m.cockpitView().configRows
+	profile.Cols = m.cockpitView().configCols
 
 	// Calculate row/col from cell index
-	row := cellIndex / m.cockpitConfigCols
-	col := cellIndex % m.cockpitConfigCols
+	row := cellIndex / m.cockpitView().configCols
+	col := cellIndex % m.cockpitView().configCols
 
 	// Find or create widget for this position
 	found := false
@@ -260,8 +260,8 @@ func (m *Model) setWidgetFilterForCell(cellIndex int, filter string) {
 		return
 	}
 
-	row := cellIndex / m.cockpitConfigCols
-	col := cellIndex % m.cockpitConfigCols
+	row := cellIndex / m.cockpitView().configCols
+	col := cellIndex % m.cockpitView().configCols
 
 	for i := range profile.Widgets {
 		if profile.Widgets[i].Row == row && profile.Widgets[i].Col == col {
@@ -290,10 +290,13 @@ func (m *Model) saveCockpitProfile() {
 
 // startNewCockpitProfile initiates new profile creation
 func (m *Model) startNewCockpitProfile() {
-	m.cockpitCreatingNew = true
-	m.cockpitNewName = ""
-	m.cockpitConfigStep = "profile_name"
-	m.cockpitConfigMode = true
+	if m.blockReadOnly("new cockpit profile") {
+		return
+	}
+	m.cockpitView().creatingNew = true
+	m.cockpitView().newName = ""
+	m.cockpitView().configStep = "profile_name"
+	m.cockpitView().configMode = true
 }
 
 // createNewCockpitProfile creates a new widget profile
@@ -363,10 +366,13 @@ func (m *Model) deleteCockpitProfile() {
 
 // startRenameCockpitProfile initiates profile rename
 func (m *Model) startRenameCockpitProfile() {
-	m.cockpitRenaming = true
-	m.cockpitNewName = m.getActiveCockpitProfile()
-	m.cockpitConfigStep = "profile_name"
-	m.cockpitConfigMode = true
+	if m.blockReadOnly("rename cockpit profile") {
+		return
+	}
+	m.cockpitView().renaming = true
+	m.cockpitView().newName = m.getActiveCockpitProfile()
+	m.cockpitView().configStep = "profile_name"
+	m.cockpitView().configMode = true
 }
 
 // renameCurrentCockpitProfile renames the current profile
@@ -412,20 +418,20 @@ func (m *Model) renderCockpitConfigOverlay(width, height int) string {
 	var content string
 	var menu *TreeMenu
 
-	switch m.cockpitConfigStep {
+	switch m.cockpitView().configStep {
 	case "grid":
 		title = "Select Grid Size"
-		menu = m.cockpitGridMenu
+		menu = m.cockpitView().gridMenu
 	case "widgets":
-		cellRow := m.cockpitConfigCell / m.cockpitConfigCols
-		cellCol := m.cockpitConfigCell % m.cockpitConfigCols
+		cellRow := m.cockpitView().configCell / m.cockpitView().configCols
+		cellCol := m.cockpitView().configCell % m.cockpitView().configCols
 		title = fmt.Sprintf("Widget for Cell [%d,%d]", cellRow+1, cellCol+1)
-		menu = m.cockpitTypeMenu
+		menu = m.cockpitView().typeMenu
 	case "filters":
 		title = "Select Filter"
-		menu = m.cockpitFilterMenu
+		menu = m.cockpitView().filterMenu
 	case "profile_name":
-		if m.cockpitCreatingNew {
+		if m.cockpitView().creatingNew {
 			title = "New Profile Name"
 		} else {
 			title = "Rename Profile"
@@ -463,7 +469,7 @@ func (m *Model) renderCockpitConfigOverlay(width, height int) string {
 		Padding(0, 1)
 
 	var footerText string
-	if m.cockpitConfigStep == "profile_name" {
+	if m.cockpitView().configStep == "profile_name" {
 		footerText = "Enter: confirm  Esc: cancel"
 	} else {
 		footerText = "↑↓: select  Enter: confirm  Esc: cancel"
@@ -501,52 +507,52 @@ func (m *Model) renderProfileNameInput() string {
 		Foreground(ColorText)
 
 	// Show input with cursor
-	display := m.cockpitNewName + cursorStyle.Render(" ")
+	display := m.cockpitView().newName + cursorStyle.Render(" ")
 	return inputStyle.Render(display)
 }
 
 // handleCockpitConfigNavigation handles special keys in config mode (Enter, Esc, text input)
 // Up/Down navigation is handled by getActiveTreeMenu() in the standard navigation flow
 func (m *Model) handleCockpitConfigNavigation(msg tea.KeyMsg) bool {
-	if !m.cockpitConfigMode {
+	if !m.cockpitView().configMode {
 		return false
 	}
 
 	key := msg.String()
 
 	// Handle text input for profile name
-	if m.cockpitConfigStep == "profile_name" {
+	if m.cockpitView().configStep == "profile_name" {
 		switch key {
 		case "enter":
 			// Confirm profile name
-			if m.cockpitNewName != "" {
-				if m.cockpitCreatingNew {
-					m.createNewCockpitProfile(m.cockpitNewName)
-					m.cockpitCreatingNew = false
-				} else if m.cockpitRenaming {
-					m.renameCurrentCockpitProfile(m.cockpitNewName)
-					m.cockpitRenaming = false
+			if m.cockpitView().newName != "" {
+				if m.cockpitView().creatingNew {
+					m.createNewCockpitProfile(m.cockpitView().newName)
+					m.cockpitView().creatingNew = false
+				} else if m.cockpitView().renaming {
+					m.renameCurrentCockpitProfile(m.cockpitView().newName)
+					m.cockpitView().renaming = false
 				}
 			}
-			m.cockpitConfigMode = false
-			m.cockpitConfigStep = ""
-			m.cockpitNewName = ""
+			m.cockpitView().configMode = false
+			m.cockpitView().configStep = ""
+			m.cockpitView().newName = ""
 			return true
 		case "backspace":
-			if len(m.cockpitNewName) > 0 {
-				m.cockpitNewName = m.cockpitNewName[:len(m.cockpitNewName)-1]
+			if len(m.cockpitView().newName) > 0 {
+				m.cockpitView().newName = m.cockpitView().newName[:len(m.cockpitView().newName)-1]
 			}
 			return true
 		case "esc":
-			m.cockpitConfigMode = false
-			m.cockpitCreatingNew = false
-			m.cockpitRenaming = false
-			m.cockpitNewName = ""
+			m.cockpitView().configMode = false
+			m.cockpitView().creatingNew = false
+			m.cockpitView().renaming = false
+			m.cockpitView().newName = ""
 			return true
 		default:
 			// Add character if printable
 			if len(key) == 1 && key[0] >= 32 && key[0] < 127 {
-				m.cockpitNewName += key
+				m.cockpitView().newName += key
 				return true
 			}
 		}
@@ -561,13 +567,13 @@ func (m *Model) handleCockpitConfigNavigation(msg tea.KeyMsg) bool {
 		return true
 	case "esc":
 		// Go back or exit
-		switch m.cockpitConfigStep {
+		switch m.cockpitView().configStep {
 		case "filters":
-			m.cockpitConfigStep = "widgets"
+			m.cockpitView().configStep = "widgets"
 		case "widgets":
-			m.cockpitConfigStep = "grid"
+			m.cockpitView().configStep = "grid"
 		default:
-			m.cockpitConfigMode = false
+			m.cockpitView().configMode = false
 		}
 		return true
 	}
@@ -627,16 +633,16 @@ func (m *Model) startWidgetEdit() {
 		return
 	}
 
-	if m.cockpitFocusedIndex < 0 || m.cockpitFocusedIndex >= len(profile.Widgets) {
+	if m.cockpitView().focusedIndex < 0 || m.cockpitView().focusedIndex >= len(profile.Widgets) {
 		return
 	}
 
 	// Start widget edit mode with first field
-	m.cockpitEditMode = true
-	m.cockpitEditProfile = false
-	m.cockpitEditField = "title"
-	widget := profile.Widgets[m.cockpitFocusedIndex]
-	m.cockpitEditValue = widget.Title
+	m.cockpitView().editMode = true
+	m.cockpitView().editProfile = false
+	m.cockpitView().editField = "title"
+	widget := profile.Widgets[m.cockpitView().focusedIndex]
+	m.cockpitView().editValue = widget.Title
 }
 
 // startProfileEdit starts editing the profile grid settings (rows/cols)
@@ -647,10 +653,10 @@ func (m *Model) startProfileEdit() {
 	}
 
 	// Start profile edit mode with first field
-	m.cockpitEditMode = true
-	m.cockpitEditProfile = true
-	m.cockpitEditField = "rows"
-	m.cockpitEditValue = fmt.Sprintf("%d", profile.Rows)
+	m.cockpitView().editMode = true
+	m.cockpitView().editProfile = true
+	m.cockpitView().editField = "rows"
+	m.cockpitView().editValue = fmt.Sprintf("%d", profile.Rows)
 }
 
 // getEditFieldValue returns the current value for the edit field
@@ -669,10 +675,10 @@ func (m *Model) getEditFieldValue(field string) string {
 	}
 
 	// Widget fields
-	if m.cockpitFocusedIndex >= len(profile.Widgets) {
+	if m.cockpitView().focusedIndex >= len(profile.Widgets) {
 		return ""
 	}
-	widget := profile.Widgets[m.cockpitFocusedIndex]
+	widget := profile.Widgets[m.cockpitView().focusedIndex]
 	switch field {
 	case "title":
 		return widget.Title
@@ -732,10 +738,10 @@ func (m *Model) setEditFieldValue(field string, value string) {
 	}
 
 	// Widget fields
-	if m.cockpitFocusedIndex >= len(profile.Widgets) {
+	if m.cockpitView().focusedIndex >= len(profile.Widgets) {
 		return
 	}
-	widget := &profile.Widgets[m.cockpitFocusedIndex]
+	widget := &profile.Widgets[m.cockpitView().focusedIndex]
 	switch field {
 	case "title":
 		widget.Title = value
@@ -762,7 +768,7 @@ func (m *Model) getEditFields() []struct {
 	Label string
 	Desc  string
 } {
-	if m.cockpitEditProfile {
+	if m.cockpitView().editProfile {
 		return profileEditFields
 	}
 	return widgetEditFields
@@ -771,14 +777,14 @@ func (m *Model) getEditFields() []struct {
 // nextEditField moves to the next field (cycles back to first)
 func (m *Model) nextEditField() {
 	// Save current field value to memory (not to disk yet)
-	m.setEditFieldValue(m.cockpitEditField, m.cockpitEditValue)
+	m.setEditFieldValue(m.cockpitView().editField, m.cockpitView().editValue)
 
 	fields := m.getEditFields()
 
 	// Find current field index
 	currentIdx := -1
 	for i, f := range fields {
-		if f.Field == m.cockpitEditField {
+		if f.Field == m.cockpitView().editField {
 			currentIdx = i
 			break
 		}
@@ -787,21 +793,21 @@ func (m *Model) nextEditField() {
 	// Move to next field (cycle back to first)
 	nextIdx := (currentIdx + 1) % len(fields)
 	nextField := fields[nextIdx].Field
-	m.cockpitEditField = nextField
-	m.cockpitEditValue = m.getEditFieldValue(nextField)
+	m.cockpitView().editField = nextField
+	m.cockpitView().editValue = m.getEditFieldValue(nextField)
 }
 
 // prevEditField moves to the previous field (cycles to last)
 func (m *Model) prevEditField() {
 	// Save current field value to memory
-	m.setEditFieldValue(m.cockpitEditField, m.cockpitEditValue)
+	m.setEditFieldValue(m.cockpitView().editField, m.cockpitView().editValue)
 
 	fields := m.getEditFields()
 
 	// Find current field index
 	currentIdx := -1
 	for i, f := range fields {
-		if f.Field == m.cockpitEditField {
+		if f.Field == m.cockpitView().editField {
 			currentIdx = i
 			break
 		}
@@ -813,29 +819,29 @@ func (m *Model) prevEditField() {
 		prevIdx = len(fields) - 1
 	}
 	prevField := fields[prevIdx].Field
-	m.cockpitEditField = prevField
-	m.cockpitEditValue = m.getEditFieldValue(prevField)
+	m.cockpitView().editField = prevField
+	m.cockpitView().editValue = m.getEditFieldValue(prevField)
 }
 
 // saveWidgetEdit saves changes and exits edit mode
 func (m *Model) saveWidgetEdit() {
 	// Save current field
-	m.setEditFieldValue(m.cockpitEditField, m.cockpitEditValue)
+	m.setEditFieldValue(m.cockpitView().editField, m.cockpitView().editValue)
 
 	// Save config to disk
 	_ = config.SaveGlobal()
 
 	// Exit edit mode
-	m.cockpitEditMode = false
-	m.cockpitEditField = ""
-	m.cockpitEditValue = ""
+	m.cockpitView().editMode = false
+	m.cockpitView().editField = ""
+	m.cockpitView().editValue = ""
 }
 
 // cancelWidgetEdit cancels editing without saving
 func (m *Model) cancelWidgetEdit() {
-	m.cockpitEditMode = false
-	m.cockpitEditField = ""
-	m.cockpitEditValue = ""
+	m.cockpitView().editMode = false
+	m.cockpitView().editField = ""
+	m.cockpitView().editValue = ""
 }
 
 // renderWidgetEditOverlay renders the widget or profile edit overlay
@@ -846,18 +852,18 @@ func (m *Model) renderWidgetEditOverlay(width, height int) string {
 	}
 
 	// For widget edit mode, verify widget index is valid
-	if !m.cockpitEditProfile {
-		if m.cockpitFocusedIndex >= len(profile.Widgets) {
+	if !m.cockpitView().editProfile {
+		if m.cockpitView().focusedIndex >= len(profile.Widgets) {
 			return ""
 		}
 	}
 
 	// Determine title based on edit mode
 	var overlayTitle string
-	if m.cockpitEditProfile {
+	if m.cockpitView().editProfile {
 		overlayTitle = "EDIT GRID: " + m.getActiveCockpitProfile()
 	} else {
-		widget := profile.Widgets[m.cockpitFocusedIndex]
+		widget := profile.Widgets[m.cockpitView().focusedIndex]
 		widgetTitle := widget.Title
 		if widgetTitle == "" {
 			widgetTitle = widget.Type
@@ -876,7 +882,7 @@ func (m *Model) renderWidgetEditOverlay(width, height int) string {
 	// Render each field
 	var fieldLines []string
 	for _, f := range fields {
-		isActive := f.Field == m.cockpitEditField
+		isActive := f.Field == m.cockpitView().editField
 
 		labelStyle := lipgloss.NewStyle().
 			Foreground(ColorMuted).
@@ -905,7 +911,7 @@ func (m *Model) renderWidgetEditOverlay(width, height int) string {
 			cursorStyle := lipgloss.NewStyle().
 				Background(ColorPrimary).
 				Foreground(ColorText)
-			displayValue = m.cockpitEditValue + cursorStyle.Render(" ")
+			displayValue = m.cockpitView().editValue + cursorStyle.Render(" ")
 		} else {
 			displayValue = m.getEditFieldValue(f.Field)
 			if displayValue == "" || displayValue == "0" {
@@ -963,7 +969,7 @@ func (m *Model) renderWidgetEditOverlay(width, height int) string {
 
 // handleWidgetEditNavigation handles keys in widget edit mode
 func (m *Model) handleWidgetEditNavigation(key string) bool {
-	if !m.cockpitEditMode {
+	if !m.cockpitView().editMode {
 		return false
 	}
 
@@ -983,18 +989,18 @@ func (m *Model) handleWidgetEditNavigation(key string) bool {
 		m.cancelWidgetEdit()
 		return true
 	case "backspace":
-		if len(m.cockpitEditValue) > 0 {
-			m.cockpitEditValue = m.cockpitEditValue[:len(m.cockpitEditValue)-1]
+		if len(m.cockpitView().editValue) > 0 {
+			m.cockpitView().editValue = m.cockpitView().editValue[:len(m.cockpitView().editValue)-1]
 		}
 		return true
 	default:
 		// Add character if printable (for title: any char, for numbers: digits only)
 		if len(key) == 1 && key[0] >= 32 && key[0] < 127 {
-			if m.cockpitEditField == "title" {
-				m.cockpitEditValue += key
+			if m.cockpitView().editField == "title" {
+				m.cockpitView().editValue += key
 				return true
 			} else if key[0] >= '0' && key[0] <= '9' {
-				m.cockpitEditValue += key
+				m.cockpitView().editValue += key
 				return true
 			}
 		}
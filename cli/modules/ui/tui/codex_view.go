@@ -4,9 +4,95 @@ import (
 	"fmt"
 	"strings"
 
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// codexController is the submodel of the Codex view
+type codexController struct {
+	activeSession string    // Active Codex session ID
+	treeMenu      *TreeMenu // Tree menu for sessions panel
+	filterProject string    // Filter by project ID
+}
+
+// newCodexController creates the Codex view controller
+func newCodexController() *codexController {
+	return &codexController{}
+}
+
+// codexView returns the Codex view submodel
+func (m *Model) codexView() *codexController {
+	return m.controllers[core.VMCodex].(*codexController)
+}
+
+// Init implements ViewController
+func (c *codexController) Init(m *Model) tea.Cmd {
+	return nil
+}
+
+// Menu implements ViewController (the sessions are in the detail panel)
+func (c *codexController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	if focus != FocusDetail || c.treeMenu == nil {
+		return nil
+	}
+	return c.treeMenu
+}
+
+// Keys implements ViewController
+func (c *codexController) Keys(m *Model) []KeyHint {
+	return nil
+}
+
+// Update implements ViewController
+func (c *codexController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case viewOpenedMsg:
+		m.updateCodexTree()
+	case stateUpdateMsg:
+		if msg.update.Affects(core.VMCodex) && m.viewOpened(core.VMCodex) {
+			m.updateCodexTree()
+		}
+	case detailSelectMsg:
+		// Sessions panel: use TreeMenu to select/drill-down
+		if c.treeMenu == nil {
+			return nil, false
+		}
+		if item := c.treeMenu.Select(); item != nil {
+			// Leaf item selected (session) - connect to it via ID
+			if len(item.Children) == 0 && strings.HasPrefix(item.ID, "codex-") {
+				c.activeSession = item.ID
+				if m.terminalManager != nil && m.terminalManager.LostReason(item.ID) != "" {
+					return m.recreateLostTerminal(item.ID), true
+				}
+			}
+		}
+		// If Select() returned nil, it drilled down/up - nothing more to do
+		return nil, true
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "t":
+			// Link the selected session to a task (grouped with Claude sessions)
+			if m.focusArea == FocusDetail {
+				return m.linkSelectedCodexSession(), true
+			}
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// View implements ViewController
+func (c *codexController) View(m *Model, width, height int) string {
+	return m.renderCodex(width, height)
+}
+
+// terminalSession implements terminalView (the active Codex session)
+func (c *codexController) terminalSession() string {
+	return c.activeSession
+}
+
 // renderCodex renders the Codex view
 func (m *Model) renderCodex(width, height int) string {
 	vm := m.state.Codex
@@ -57,8 +143,8 @@ func (m *Model) renderCodexNotInstalled(width, height int) string {
 // renderCodexMainPanel renders the main panel (terminal or placeholder)
 func (m *Model) renderCodexMainPanel(width, height int) string {
 	// Check if we have an active session with a terminal
-	if m.codexActiveSession != "" {
-		if t := m.terminalManager.Get(m.codexActiveSession); t != nil && t.IsRunning() {
+	if m.codexView().activeSession != "" {
+		if t := m.terminalManager.Get(m.codexView().activeSession); t != nil && t.IsRunning() {
 			return m.renderTerminalPanel(t, width, height)
 		}
 	}
@@ -69,11 +155,16 @@ func (m *Model) renderCodexMainPanel(width, height int) string {
 		style = FocusedBorderStyle
 	}
 
+	message := "Select or create a session to start Codex"
+	if lost := m.lostTerminalMessage(m.codexView().activeSession); lost != "" {
+		message = lost
+	}
+
 	content := lipgloss.NewStyle().
 		Foreground(ColorMuted).
 		Align(lipgloss.Center).
 		Width(width - 2).
-		Render("Select or create a session to start Codex")
+		Render(message)
 
 	return style.
 		Width(width).
@@ -99,8 +190,8 @@ func (m *Model) renderCodexSessionsPanel(width, height int) string {
 
 	// Session list using TreeMenu
 	var listContent string
-	if m.codexTreeMenu != nil {
-		listContent = m.codexTreeMenu.Render()
+	if m.codexView().treeMenu != nil {
+		listContent = m.codexView().treeMenu.Render()
 	} else {
 		listContent = lipgloss.NewStyle().
 			Foreground(ColorMuted).
@@ -147,3 +238,44 @@ func (m *Model) renderCodexInfoPanel(width int) string {
 
 	return style.Render(strings.Join(lines, "\n"))
 }
+
+// updateCodexTree updates the Codex sessions tree menu
+func (m *Model) updateCodexTree() {
+	vm := m.state.Codex
+	if vm == nil {
+		return
+	}
+
+	var items []TreeMenuItem
+	for _, sess := range vm.Sessions {
+		if m.codexView().filterProject != "" && sess.ProjectID != m.codexView().filterProject {
+			continue
+		}
+		sessionID := "codex-" + sess.ID
+
+		icon := "○"
+		var iconColor lipgloss.TerminalColor
+		if m.terminalManager != nil {
+			if t := m.terminalManager.Get(sessionID); t != nil && t.IsRunning() {
+				icon = "●"
+				iconColor = ColorSuccess
+			}
+		}
+
+		items = append(items, m.withActivityBadge(TreeMenuItem{
+			ID:        sessionID,
+			Label:     sess.Name,
+			Icon:      icon,
+			IconColor: iconColor,
+			IsActive:  sessionID == m.codexView().activeSession,
+			Data:      sess,
+		}, sessionID))
+	}
+
+	if m.codexView().treeMenu == nil {
+		m.codexView().treeMenu = NewTreeMenu(items)
+		m.codexView().treeMenu.SetSize(30, 20)
+	} else {
+		m.codexView().treeMenu.SetItems(items)
+	}
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// selectedCapability returns the tool selected in the Capabilities tab
+func (m *Model) selectedCapability() (core.CapabilityVM, bool) {
+	if m.state.Capabilities == nil {
+		return core.CapabilityVM{}, false
+	}
+	list := m.state.Capabilities.List()
+	if m.mainIndex < 0 || m.mainIndex >= len(list) {
+		return core.CapabilityVM{}, false
+	}
+	return list[m.mainIndex], true
+}
+
+// renderConfigCapabilities renders the external tools detected, with the
+// command installing the missing ones
+func (m *Model) renderConfigCapabilities(width, height int) string {
+	caps := m.state.Capabilities
+	if caps == nil {
+		return SubtitleStyle.Render("Tools not detected yet")
+	}
+
+	title := PanelTitleStyle.Render("Capabilities")
+	checked := "not detected yet"
+	if !caps.CheckedAt.IsZero() {
+		checked = "detected " + formatRelativeTime(caps.CheckedAt)
+	}
+	hint := SubtitleStyle.Render(checked + " · r to detect again · y/Enter to copy the path or install command")
+
+	list := caps.List()
+	m.maxMainItems = len(list)
+
+	renderRow := func(capability core.CapabilityVM, selected bool) string {
+		cursor := "  "
+		labelStyle := lipgloss.NewStyle().Foreground(ColorText)
+		if selected {
+			cursor = "▶ "
+			labelStyle = labelStyle.Bold(true).Foreground(ColorPrimary)
+		}
+		icon := lipgloss.NewStyle().Foreground(ColorSuccess).Render("✓")
+		version, versionColor := capability.Version, ColorText
+		detail := capability.Path
+		if !capability.Available {
+			icon = lipgloss.NewStyle().Foreground(ColorError).Render("✗")
+			version, versionColor = "missing", ColorMuted
+			detail = capability.Install
+			if detail == "" {
+				detail = "no known install command"
+			}
+		}
+		row := cursor + icon + " " + labelStyle.Render(fmt.Sprintf("%-13s", capability.Name)) +
+			lipgloss.NewStyle().Foreground(versionColor).Render(fmt.Sprintf("%-18s", truncate(version, 17)))
+		return row + lipgloss.NewStyle().Foreground(ColorMuted).Render(truncate(detail, max(width-38, 10)))
+	}
+
+	// Keep the selected tool visible
+	visible := max(height-4, 1)
+	start := 0
+	if m.mainIndex >= visible {
+		start = m.mainIndex - visible + 1
+	}
+
+	lines := []string{title, hint, ""}
+	for i := start; i < len(list) && i < start+visible; i++ {
+		lines = append(lines, renderRow(list[i], i == m.mainIndex && m.focusArea == FocusMain))
+	}
+
+	if len(caps.Shells) > 0 && len(lines)+len(caps.Shells)+2 <= height {
+		lines = append(lines, "", SubtitleStyle.Render("Shells"))
+		for _, shell := range caps.Shells {
+			lines = append(lines, "    "+lipgloss.NewStyle().Foreground(ColorText).Render(fmt.Sprintf("%-13s", shell.Name))+
+				lipgloss.NewStyle().Foreground(ColorMuted).Render(truncate(shell.Path, max(width-17, 10))))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
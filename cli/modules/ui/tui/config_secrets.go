@@ -0,0 +1,237 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/secrets"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Dialogs of the Secrets tab
+const (
+	dialogSecretName   = "secret_name"    // Name of a new secret
+	dialogSecretValue  = "secret_value"   // Value of a secret (masked, never in the input history)
+	dialogSecretDelete = "secret_delete"  // Confirm the deletion of a secret
+	dialogSecretRotate = "secrets_rotate" // Confirm the re-encryption with a new key
+)
+
+// selectedSecret returns the name of the secret selected in the Secrets tab
+func (m *Model) selectedSecret() (string, bool) {
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		return "", false
+	}
+	names := cfg.SecretNames()
+	if m.mainIndex < 0 || m.mainIndex >= len(names) {
+		return "", false
+	}
+	return names[m.mainIndex], true
+}
+
+// renderConfigSecrets renders the names of the encrypted secrets (never their
+// values), whether the config references them, and the key source
+func (m *Model) renderConfigSecrets(width, height int) string {
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		return SubtitleStyle.Render("No config file loaded")
+	}
+
+	title := PanelTitleStyle.Render("Secrets")
+	keyCfg := cfg.Settings.GetSecretsConfig()
+	source := keyCfg.KeySource
+	if source == secrets.SourceFile {
+		source += " " + keyCfg.KeyFile
+	}
+	hint := SubtitleStyle.Render("Referenced as ${secret:NAME} in config values · key: " + truncate(source, max(width-55, 20)))
+
+	names := cfg.SecretNames()
+	m.maxMainItems = len(names)
+	lines := []string{title, hint, ""}
+	if len(names) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorMuted).Render("No secrets: a to add one (tokens, passwords, webhook URLs)"))
+		return strings.Join(lines, "\n")
+	}
+
+	referenced := make(map[string]bool)
+	for _, name := range cfg.SecretReferences() {
+		referenced[name] = true
+	}
+
+	// Keep the selected secret visible
+	visible := max(height-4, 1)
+	start := 0
+	if m.mainIndex >= visible {
+		start = m.mainIndex - visible + 1
+	}
+	for i := start; i < len(names) && i < start+visible; i++ {
+		cursor := "  "
+		labelStyle := lipgloss.NewStyle().Foreground(ColorText)
+		if i == m.mainIndex && m.focusArea == FocusMain {
+			cursor = "▶ "
+			labelStyle = labelStyle.Bold(true).Foreground(ColorPrimary)
+		}
+		usage, usageColor := "unused", ColorMuted
+		if referenced[names[i]] {
+			usage, usageColor = "referenced", ColorSuccess
+		}
+		lines = append(lines, cursor+labelStyle.Render(fmt.Sprintf("%-32s", truncate(names[i], 31)))+
+			lipgloss.NewStyle().Foreground(ColorMuted).Render("••••••••  ")+
+			lipgloss.NewStyle().Foreground(usageColor).Render(usage))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// secretsLocked reports when the secrets need a passphrase the TUI does not
+// have (it is entered with the secrets command, or CSD_DEVTRACK_PASSPHRASE)
+func (m *Model) secretsLocked() bool {
+	cfg := config.GetGlobal()
+	if cfg == nil || !cfg.NeedsSecretsPassphrase() {
+		return false
+	}
+	m.lastError = fmt.Sprintf("Secrets encrypted with a passphrase: set %s or use csd-devtrack secrets", secrets.PassphraseEnv)
+	m.lastErrorTime = time.Now()
+	return true
+}
+
+// openSecretDialog asks the value of a secret, or the name of a new secret
+// first when name is empty
+func (m *Model) openSecretDialog(name string) tea.Cmd {
+	if m.blockReadOnly("secret change") || m.secretsLocked() {
+		return nil
+	}
+	m.configView().pendingSecretName = name
+	m.dialogType = dialogSecretName
+	m.dialogMessage = "New secret name:"
+	m.dialogInput.SetValue("")
+	if name != "" {
+		m.dialogType = dialogSecretValue
+		m.dialogMessage = fmt.Sprintf("Value of %s (hidden):", name)
+		m.dialogInput.EchoMode = textinput.EchoPassword
+	}
+	m.dialogInput.Focus()
+	m.dialogInputActive = true
+	m.showDialog = true
+	return m.dialogInput.Cursor.BlinkCmd()
+}
+
+// closeSecretDialog restores the dialog input after a secret dialog
+func (m *Model) closeSecretDialog() {
+	m.dialogInput.EchoMode = textinput.EchoNormal
+	m.dialogInput.SetValue("")
+}
+
+// confirmSecretDialog handles the confirmed dialogs of the Secrets tab
+func (m *Model) confirmSecretDialog() tea.Cmd {
+	cfg := config.GetGlobal()
+	name := m.configView().pendingSecretName
+	switch m.dialogType {
+	case dialogSecretName:
+		name = strings.TrimSpace(m.dialogInput.Value())
+		m.closeSecretDialog()
+		if !config.ValidSecretName(name) {
+			m.lastError = "Secret names use letters, digits, '_', '.' and '-'"
+			m.lastErrorTime = time.Now()
+			return nil
+		}
+		return m.openSecretDialog(name)
+
+	case dialogSecretValue:
+		value := m.dialogInput.Value()
+		m.closeSecretDialog()
+		m.configView().pendingSecretName = ""
+		if value == "" {
+			return nil
+		}
+		if err := cfg.SetSecret(name, value); err != nil {
+			return m.secretError(err)
+		}
+		if err := config.SaveGlobal(); err != nil {
+			return m.secretError(err)
+		}
+		m.selectSecret(name)
+		return m.auditEvent("secret_set", "", name)
+
+	case dialogSecretDelete:
+		m.configView().pendingSecretName = ""
+		if !cfg.DeleteSecret(name) {
+			return nil
+		}
+		if err := config.SaveGlobal(); err != nil {
+			return m.secretError(err)
+		}
+		if m.mainIndex > 0 && m.mainIndex >= len(cfg.SecretNames()) {
+			m.mainIndex--
+		}
+		return m.auditEvent("secret_delete", "", name)
+
+	case dialogSecretRotate:
+		if err := cfg.RotateSecretsKey("", ""); err != nil {
+			return m.secretError(err)
+		}
+		if err := config.SaveGlobal(); err != nil {
+			return m.secretError(fmt.Errorf("config not saved (previous key file kept as .old): %w", err))
+		}
+		return m.auditEvent("secrets_rotate", "", cfg.Settings.GetSecretsConfig().KeySource)
+	}
+	return nil
+}
+
+// deleteSelectedSecret asks to confirm the deletion of the selected secret
+func (m *Model) deleteSelectedSecret() tea.Cmd {
+	name, ok := m.selectedSecret()
+	if !ok || m.blockReadOnly("delete secret") {
+		return nil
+	}
+	m.configView().pendingSecretName = name
+	m.dialogType = dialogSecretDelete
+	m.dialogMessage = fmt.Sprintf("Delete secret '%s'?", name)
+	for _, ref := range config.GetGlobal().SecretReferences() {
+		if ref == name {
+			m.dialogMessage = fmt.Sprintf("Delete secret '%s'? The config still references it.", name)
+		}
+	}
+	m.dialogConfirm = false
+	m.showDialog = true
+	return nil
+}
+
+// rotateSecretsKey asks to confirm the re-encryption of the secrets with a
+// new key (the passphrase source is rotated from the command line)
+func (m *Model) rotateSecretsKey() tea.Cmd {
+	cfg := config.GetGlobal()
+	if cfg == nil || m.blockReadOnly("rotate secrets key") || m.secretsLocked() {
+		return nil
+	}
+	if cfg.Settings.GetSecretsConfig().KeySource == secrets.SourcePassphrase {
+		m.lastError = "A new passphrase is chosen with: csd-devtrack secrets rotate"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	m.dialogType = dialogSecretRotate
+	m.dialogMessage = fmt.Sprintf("Re-encrypt the %d secrets with a new %s key?", len(cfg.SecretNames()), cfg.Settings.GetSecretsConfig().KeySource)
+	m.dialogConfirm = false
+	m.showDialog = true
+	return nil
+}
+
+// selectSecret moves the selection to a secret
+func (m *Model) selectSecret(name string) {
+	for i, existing := range config.GetGlobal().SecretNames() {
+		if existing == name {
+			m.mainIndex = i
+		}
+	}
+}
+
+// secretError reports a failed secret change
+func (m *Model) secretError(err error) tea.Cmd {
+	m.lastError = fmt.Sprintf("Secrets: %v", err)
+	m.lastErrorTime = time.Now()
+	return nil
+}
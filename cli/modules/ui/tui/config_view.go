@@ -0,0 +1,1475 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/logger"
+	"csd-devtrack/cli/modules/platform/trash"
+	"csd-devtrack/cli/modules/ui/core"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// configController is the submodel of the Config view
+type configController struct {
+	mode            string               // "projects", "browser", "settings", "confirmations", "polling", "logging", "display", "capabilities", "secrets"
+	browserPath     string               // Current directory path
+	browserEntries  []BrowserEntry       // Directory entries (uses mainIndex for selection)
+	browserPreview  *filePreview         // Preview of the selected file (nil if a directory is selected)
+	detectedProject *DetectedProjectInfo // Detected project in current dir
+
+	pendingRemovePath string // Path of project to remove (for confirmation dialog)
+	pendingSecretName string // Secret being set or deleted (Secrets tab)
+}
+
+// newConfigController creates the Config view controller
+func newConfigController() *configController {
+	return &configController{
+		mode:        "projects", // Start with projects view
+		browserPath: initialBrowserPath(),
+	}
+}
+
+// configView returns the Config view submodel
+func (m *Model) configView() *configController {
+	return m.controllers[core.VMConfig].(*configController)
+}
+
+// initialBrowserPath returns the browser path from config, or falls back to
+// the home directory
+func initialBrowserPath() string {
+	homeDir, _ := os.UserHomeDir()
+	if homeDir == "" {
+		homeDir, _ = os.Getwd()
+	}
+	if cfg := config.GetGlobal(); cfg != nil && cfg.Settings != nil && cfg.Settings.BrowserPath != "" {
+		path := cfg.Settings.BrowserPath
+		// Expand ~ to home directory
+		if strings.HasPrefix(path, "~/") {
+			path = filepath.Join(homeDir, path[2:])
+		} else if path == "~" {
+			path = homeDir
+		}
+		// Verify path exists and is accessible
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return path
+		}
+	}
+	return homeDir
+}
+
+// Init implements ViewController
+func (c *configController) Init(m *Model) tea.Cmd {
+	if c.mode == "" {
+		c.mode = "projects"
+	}
+	if c.mode == "browser" {
+		m.loadBrowserEntries()
+	}
+	return nil
+}
+
+// Menu implements ViewController (the tabs are index-based lists)
+func (c *configController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	return nil
+}
+
+// Keys implements ViewController
+func (c *configController) Keys(m *Model) []KeyHint {
+	hints := []KeyHint{{"←→", "tabs"}}
+	switch c.mode {
+	case "projects":
+		hints = append(hints, KeyHint{"Enter", "browse"}, KeyHint{"x", "remove"})
+	case "browser":
+		hints = append(hints,
+			KeyHint{"Enter", "open"},
+			KeyHint{"Bksp", "back"},
+			KeyHint{"a", "add"},
+			KeyHint{"x", "remove"},
+		)
+		if c.browserPreview != nil {
+			hints = append(hints, KeyHint{"^U/^D", "preview"})
+		}
+	case "settings":
+		hints = append(hints, KeyHint{"↑↓", "scroll"}, KeyHint{"b", "backup"})
+	case "confirmations":
+		hints = append(hints, KeyHint{"Enter/Space", "toggle"})
+	case "polling":
+		hints = append(hints, KeyHint{"+/-", "interval"}, KeyHint{"Space", "toggle"})
+	case "logging", "display":
+		hints = append(hints, KeyHint{"Enter/Space", "toggle"})
+	case "capabilities":
+		hints = append(hints, KeyHint{"r", "detect again"}, KeyHint{"y/Enter", "copy install command"})
+	case "secrets":
+		hints = append(hints,
+			KeyHint{"a", "add"},
+			KeyHint{"Enter", "new value"},
+			KeyHint{"x", "delete"},
+			KeyHint{"r", "rotate key"},
+		)
+	}
+	return hints
+}
+
+// Update implements ViewController
+func (c *configController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case itemCountMsg:
+		c.countItems(m)
+	case keyPressMsg:
+		// Left/Right switch tabs, whatever the focus
+		switch {
+		case key.Matches(msg.key, m.keys.Left):
+			c.previousTab(m)
+			return nil, true
+		case key.Matches(msg.key, m.keys.Right):
+			c.nextTab(m)
+			return nil, true
+		}
+	case selectMsg:
+		return c.selectItem(m), true
+	case dialogConfirmMsg:
+		switch msg.dialogType {
+		case "remove_project":
+			return c.removeProject(m), true
+		case dialogSecretName, dialogSecretValue, dialogSecretDelete, dialogSecretRotate:
+			return m.confirmSecretDialog(), true
+		}
+	case tea.KeyMsg:
+		return c.handleKey(m, msg.String())
+	}
+	return nil, false
+}
+
+// View implements ViewController
+func (c *configController) View(m *Model, width, height int) string {
+	m.syncBrowserPreview()
+	return m.renderConfig(width, height)
+}
+
+// yank implements yankView (path of the selected directory or project)
+func (c *configController) yank(m *Model) tea.Cmd {
+	switch c.mode {
+	case "browser":
+		if m.mainIndex >= 0 && m.mainIndex < len(c.browserEntries) {
+			return m.yankText("path", c.browserEntries[m.mainIndex].Path)
+		}
+	case "projects":
+		if cfg := config.GetGlobal(); cfg != nil && m.mainIndex >= 0 && m.mainIndex < len(cfg.Projects) {
+			return m.yankText("project path", cfg.Projects[m.mainIndex].Path)
+		}
+	case "capabilities":
+		if capability, ok := m.selectedCapability(); ok {
+			if capability.Available {
+				return m.yankText(capability.Name+" path", capability.Path)
+			}
+			return m.yankText("install command", capability.Install)
+		}
+	}
+	return m.yankText("", "")
+}
+
+// contextMenu implements contextMenuView
+func (c *configController) contextMenu(m *Model) *contextMenu {
+	return m.browserContextMenu()
+}
+
+// countItems sets the number of rows of the current tab
+func (c *configController) countItems(m *Model) {
+	switch c.mode {
+	case "projects":
+		cfg := config.GetGlobal()
+		if cfg != nil {
+			m.maxMainItems = len(cfg.Projects)
+		}
+	case "browser":
+		m.maxMainItems = len(c.browserEntries)
+	case "settings":
+		m.maxMainItems = 0 // No navigation in settings
+	case "confirmations":
+		m.maxMainItems = len(config.ConfirmActions) + 1
+	case "polling":
+		m.maxMainItems = len(config.PollSubsystems) + 1
+	case "logging":
+		m.maxMainItems = 1
+	case "display":
+		m.maxMainItems = 2
+	case "capabilities":
+		if m.state.Capabilities != nil {
+			m.maxMainItems = len(m.state.Capabilities.List())
+		}
+	case "secrets":
+		if cfg := config.GetGlobal(); cfg != nil {
+			m.maxMainItems = len(cfg.SecretNames())
+		}
+	}
+}
+
+// previousTab moves to the tab on the left (Left key, no cycling)
+func (c *configController) previousTab(m *Model) {
+	switch c.mode {
+	case "browser":
+		c.mode = "projects"
+		m.mainIndex = 0
+	case "settings":
+		c.mode = "browser"
+		m.mainIndex = 0
+		m.loadBrowserEntries()
+	case "confirmations":
+		c.mode = "settings"
+		m.mainIndex = 0
+	case "polling":
+		c.mode = "confirmations"
+		m.mainIndex = 0
+	case "logging":
+		c.mode = "polling"
+		m.mainIndex = 0
+	case "display":
+		c.mode = "logging"
+		m.mainIndex = 0
+	case "capabilities":
+		c.mode = "display"
+		m.mainIndex = 0
+	case "secrets":
+		c.mode = "capabilities"
+		m.mainIndex = 0
+	}
+}
+
+// nextTab moves to the tab on the right (Right key, no cycling)
+func (c *configController) nextTab(m *Model) {
+	switch c.mode {
+	case "projects":
+		c.mode = "browser"
+		m.mainIndex = 0
+		m.loadBrowserEntries()
+	case "browser":
+		c.mode = "settings"
+		m.mainIndex = 0
+	case "settings":
+		c.mode = "confirmations"
+		m.mainIndex = 0
+	case "confirmations":
+		c.mode = "polling"
+		m.mainIndex = 0
+	case "polling":
+		c.mode = "logging"
+		m.mainIndex = 0
+	case "logging":
+		c.mode = "display"
+		m.mainIndex = 0
+	case "display":
+		c.mode = "capabilities"
+		m.mainIndex = 0
+	case "capabilities":
+		c.mode = "secrets"
+		m.mainIndex = 0
+	}
+}
+
+// selectItem handles Enter, depending on the current tab
+func (c *configController) selectItem(m *Model) tea.Cmd {
+	switch c.mode {
+	case "browser":
+		m.enterBrowserDirectory()
+	case "confirmations":
+		return m.toggleConfirmationSetting()
+	case "polling":
+		return m.adjustPollingSetting(1)
+	case "logging":
+		return m.toggleLoggingSetting()
+	case "display":
+		return m.toggleDisplaySetting()
+	case "capabilities":
+		return c.yank(m)
+	case "secrets":
+		if name, ok := m.selectedSecret(); ok {
+			return m.openSecretDialog(name)
+		}
+	case "projects":
+		// Navigate to project in browser
+		cfg := config.GetGlobal()
+		if cfg != nil && m.mainIndex >= 0 && m.mainIndex < len(cfg.Projects) {
+			proj := cfg.Projects[m.mainIndex]
+			c.browserPath = proj.Path
+			c.mode = "browser"
+			m.mainIndex = 0
+			m.loadBrowserEntries()
+		}
+	}
+	return nil
+}
+
+// handleKey handles the action keys of the current tab
+func (c *configController) handleKey(m *Model, key string) (tea.Cmd, bool) {
+	switch key {
+	case "]", "n", "shift+right":
+		// Switch to next tab (cycle)
+		m.focusArea = FocusMain // Ensure focus is on main content
+		if c.mode == "secrets" {
+			c.mode = "projects"
+			m.mainIndex = 0
+		} else {
+			c.nextTab(m)
+		}
+		return nil, true
+	case "[", "N", "shift+left":
+		// Switch to previous tab (cycle)
+		m.focusArea = FocusMain // Ensure focus is on main content
+		if c.mode == "projects" {
+			c.mode = "secrets"
+			m.mainIndex = 0
+		} else {
+			c.previousTab(m)
+		}
+		return nil, true
+	case " ":
+		if c.mode == "confirmations" {
+			return m.toggleConfirmationSetting(), true
+		}
+		if c.mode == "polling" {
+			return m.adjustPollingSetting(1), true
+		}
+		if c.mode == "logging" {
+			return m.toggleLoggingSetting(), true
+		}
+		if c.mode == "display" {
+			return m.toggleDisplaySetting(), true
+		}
+	case "r":
+		if c.mode == "capabilities" {
+			return m.sendEvent(core.NewEvent(core.EventCapabilitiesRefresh)), true
+		}
+		if c.mode == "secrets" {
+			return m.rotateSecretsKey(), true
+		}
+	case "b":
+		if c.mode == "settings" {
+			return m.sendEvent(core.NewEvent(core.EventBackupCreate)), true
+		}
+	case "+", "=":
+		if c.mode == "polling" {
+			return m.adjustPollingSetting(1), true
+		}
+	case "-":
+		if c.mode == "polling" {
+			return m.adjustPollingSetting(-1), true
+		}
+	case "ctrl+u", "ctrl+d":
+		// Scroll the preview of the selected file
+		if c.mode == "browser" && c.browserPreview != nil {
+			step := max(c.browserPreview.height/2, 1)
+			if key == "ctrl+u" {
+				step = -step
+			}
+			c.browserPreview.scrollBy(step)
+			return nil, true
+		}
+	case "backspace":
+		if c.mode == "browser" && !isRootPath(c.browserPath) {
+			c.browserPath = filepath.Dir(c.browserPath)
+			m.mainIndex = 0
+			m.loadBrowserEntries()
+			return nil, true
+		}
+	case "a", "A":
+		if c.mode == "secrets" {
+			return m.openSecretDialog(""), true
+		}
+		// Add project to config
+		if c.mode == "browser" && c.detectedProject != nil {
+			if m.blockReadOnly("add project") {
+				return nil, true
+			}
+			if !m.isProjectInConfig(c.detectedProject.Path) {
+				if err := m.addProjectToConfig(); err == nil {
+					m.loadBrowserEntries() // Refresh
+					return m.auditEvent("add_project", "", c.detectedProject.Path), true
+				}
+			}
+			return nil, true
+		}
+	case "x", "X":
+		if c.mode == "secrets" {
+			return m.deleteSelectedSecret(), true
+		}
+		// Remove project from config - ask for confirmation
+		if c.mode == "projects" {
+			return c.confirmRemoveProject(m), true
+		} else if c.mode == "browser" && c.detectedProject != nil {
+			if m.isProjectInConfig(c.detectedProject.Path) {
+				// Check if it's the self project
+				if m.isSelfProject(c.detectedProject.Path) {
+					m.lastError = "Cannot remove csd-devtrack (self)"
+					m.lastErrorTime = time.Now()
+					return nil, true
+				}
+				c.pendingRemovePath = c.detectedProject.Path
+				m.dialogConfirm = false
+				return m.openConfirmDialog(config.ConfirmRemoveProject, "remove_project", "Remove '"+c.detectedProject.Name+"' from config?"), true
+			}
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// confirmRemoveProject asks to remove the project selected in the Projects tab
+func (c *configController) confirmRemoveProject(m *Model) tea.Cmd {
+	cfg := config.GetGlobal()
+	if cfg == nil || m.mainIndex < 0 || m.mainIndex >= len(cfg.Projects) {
+		return nil
+	}
+	proj := cfg.Projects[m.mainIndex]
+	// Can't remove self project
+	if proj.Self {
+		m.lastError = "Cannot remove csd-devtrack (self)"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	c.pendingRemovePath = proj.Path
+	m.dialogConfirm = false
+	return m.openConfirmDialog(config.ConfirmRemoveProject, "remove_project", "Remove '"+proj.Name+"' from config?")
+}
+
+// removeProject removes the project confirmed in the dialog from the config
+func (c *configController) removeProject(m *Model) tea.Cmd {
+	if c.pendingRemovePath == "" {
+		return nil
+	}
+	// Record the project in the trash before removing it (undo)
+	var cmd tea.Cmd
+	projectID := ""
+	if proj := m.findConfigProject(c.pendingRemovePath); proj != nil {
+		projectID = proj.ID
+		cmd = m.trashEvent(trash.KindProject, proj.Name, proj)
+	}
+	if err := m.removeProjectFromConfig(c.pendingRemovePath); err != nil {
+		cmd = nil
+	} else {
+		cmd = tea.Batch(cmd, m.auditEvent("remove_project", projectID, c.pendingRemovePath))
+		// Adjust index if needed
+		cfg := config.GetGlobal()
+		if cfg != nil && m.mainIndex >= len(cfg.Projects) {
+			m.mainIndex = len(cfg.Projects) - 1
+			if m.mainIndex < 0 {
+				m.mainIndex = 0
+			}
+		}
+		// Refresh browser if in browser mode
+		if c.mode == "browser" {
+			m.loadBrowserEntries()
+		}
+	}
+	c.pendingRemovePath = ""
+	return cmd
+}
+
+// renderConfig renders the config view with tabs
+func (m *Model) renderConfig(width, height int) string {
+	// Load browser entries if not loaded
+	if m.configView().mode == "browser" && len(m.configView().browserEntries) == 0 {
+		m.loadBrowserEntries()
+	}
+
+	// Tab styles
+	tabActive := lipgloss.NewStyle().
+		Background(ColorPrimary).
+		Foreground(lipgloss.Color("#000")).
+		Padding(0, 2).
+		Bold(true)
+	tabInactive := lipgloss.NewStyle().
+		Background(lipgloss.Color("#444")).
+		Foreground(lipgloss.Color("#fff")).
+		Padding(0, 2)
+
+	// Render tabs
+	var tabs []string
+	modes := []struct {
+		key  string
+		name string
+	}{
+		{"projects", "Projects"},
+		{"browser", "Browser"},
+		{"settings", "Settings"},
+		{"confirmations", "Confirmations"},
+		{"polling", "Polling"},
+		{"logging", "Logging"},
+		{"display", "Display"},
+		{"capabilities", "Capabilities"},
+		{"secrets", "Secrets"},
+	}
+	for _, mode := range modes {
+		if m.configView().mode == mode.key {
+			tabs = append(tabs, tabActive.Render(mode.name))
+		} else {
+			tabs = append(tabs, tabInactive.Render(mode.name))
+		}
+	}
+	tabBar := lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+
+	// Narrow: only the active tab with its position
+	if lipgloss.Width(tabBar) > width-8 {
+		for i, mode := range modes {
+			if mode.key == m.configView().mode {
+				tabBar = tabActive.Render(fmt.Sprintf("%s %d/%d", mode.name, i+1, len(modes)))
+			}
+		}
+	}
+	tabHint := SubtitleStyle.Render("  ←/→")
+	tabBar = lipgloss.JoinHorizontal(lipgloss.Center, tabBar, tabHint)
+
+	// Render content based on mode
+	var content string
+	contentHeight := height - 6
+
+	switch m.configView().mode {
+	case "projects":
+		content = m.renderConfigProjects(width-4, contentHeight)
+	case "browser":
+		content = m.renderConfigBrowser(width-4, contentHeight)
+	case "settings":
+		content = m.renderConfigSettings(width-4, contentHeight)
+	case "confirmations":
+		content = m.renderConfigConfirmations(width-4, contentHeight)
+	case "polling":
+		content = m.renderConfigPolling(width-4, contentHeight)
+	case "logging":
+		content = m.renderConfigLogging(width-4, contentHeight)
+	case "display":
+		content = m.renderConfigDisplay(width-4, contentHeight)
+	case "capabilities":
+		content = m.renderConfigCapabilities(width-4, contentHeight)
+	case "secrets":
+		content = m.renderConfigSecrets(width-4, contentHeight)
+	default:
+		content = m.renderConfigProjects(width-4, contentHeight)
+	}
+
+	var style lipgloss.Style
+	if m.focusArea == FocusMain {
+		style = FocusedBorderStyle
+	} else {
+		style = UnfocusedBorderStyle
+	}
+
+	// 1 panel: height 1×2=2, width 1×2=2 (+1 for the right gap of the main area)
+	return style.Width(width - 3).Height(height - 2).Render(
+		lipgloss.JoinVertical(lipgloss.Left,
+			tabBar,
+			"",
+			content,
+		),
+	)
+}
+
+// renderConfigProjects renders the projects list in config view
+func (m *Model) renderConfigProjects(width, height int) string {
+	cfg := config.GetGlobal()
+	if cfg == nil || len(cfg.Projects) == 0 {
+		return SubtitleStyle.Render("No projects configured.\nUse [2] Browser to add projects.")
+	}
+
+	title := PanelTitleStyle.Render(fmt.Sprintf("Configured Projects (%d)", len(cfg.Projects)))
+
+	var rows []string
+	for i, proj := range cfg.Projects {
+		isSelected := i == m.mainIndex && m.focusArea == FocusMain
+
+		indicator := "  "
+		if isSelected {
+			indicator = "> "
+		}
+
+		// Check if path exists
+		pathWarning := ""
+		if _, err := os.Stat(proj.Path); os.IsNotExist(err) {
+			pathWarning = StatusError.Render(" " + IconWarning)
+		}
+
+		// Component badges - sort for consistent order
+		var compBadges []string
+		for compType := range proj.Components {
+			compBadges = append(compBadges, string(compType))
+		}
+		sort.Strings(compBadges)
+		compsText := ""
+		if len(compBadges) > 0 {
+			compsText = strings.Join(compBadges, ", ")
+		}
+
+		// Build the row with fixed columns
+		row := fmt.Sprintf("%s%-20s%s │ %s", indicator, truncate(proj.Name, 20), pathWarning, compsText)
+
+		if isSelected {
+			row = TableRowSelectedStyle.Width(width - 6).Render(row)
+		}
+		rows = append(rows, row)
+	}
+
+	// Show selected project details
+	var details string
+	if m.mainIndex >= 0 && m.mainIndex < len(cfg.Projects) {
+		proj := cfg.Projects[m.mainIndex]
+
+		// Check if path exists
+		pathLine := fmt.Sprintf("Path: %s", proj.Path)
+		if _, err := os.Stat(proj.Path); os.IsNotExist(err) {
+			pathLine = StatusError.Render(fmt.Sprintf("%s Path: %s (not found)", IconWarning, proj.Path))
+		}
+
+		details = "\n" + lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(ColorMuted).
+			Padding(0, 1).
+			Render(
+				lipgloss.JoinVertical(lipgloss.Left,
+					PanelTitleStyle.Render(proj.Name),
+					pathLine,
+					fmt.Sprintf("Type: %s", proj.Type),
+					"",
+					"[Enter] View details  [x] Remove from config",
+				),
+			)
+	}
+
+	m.maxMainItems = len(cfg.Projects)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		strings.Join(rows, "\n"),
+		details,
+	)
+}
+
+// renderConfigBrowser renders the file browser in config view
+func (m *Model) renderConfigBrowser(width, height int) string {
+	// Path display
+	pathStyle := lipgloss.NewStyle().
+		Foreground(ColorSecondary).
+		Bold(true)
+	pathDisplay := pathStyle.Render("📁 " + m.configView().browserPath)
+
+	// Detected project info
+	var projectInfo string
+	if m.configView().detectedProject != nil {
+		inConfig := m.isProjectInConfig(m.configView().detectedProject.Path)
+		var actionHint string
+		if inConfig {
+			actionHint = lipgloss.NewStyle().
+				Foreground(ColorWarning).
+				Render("[x] Remove from config")
+		} else {
+			actionHint = lipgloss.NewStyle().
+				Foreground(ColorSuccess).
+				Render("[a] Add to config")
+		}
+
+		projectInfo = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(ColorSuccess).
+			Padding(0, 1).
+			Render(
+				lipgloss.JoinVertical(lipgloss.Left,
+					PanelTitleStyle.Render("✓ Project Detected: "+m.configView().detectedProject.Name),
+					fmt.Sprintf("Type: %s", m.configView().detectedProject.Type),
+					fmt.Sprintf("Components: %s", strings.Join(m.configView().detectedProject.Components, ", ")),
+					"",
+					actionHint,
+				),
+			)
+	}
+
+	// Directory listing, with the preview of the selected file beside it
+	// (below on a narrow terminal)
+	var rows []string
+	visibleRows := height - 10
+	if projectInfo != "" {
+		visibleRows -= 6
+	}
+	listWidth := width
+	previewHeight := height - 1
+	if projectInfo != "" {
+		previewHeight -= 7
+	}
+	// The preview is loaded on selection change (see syncBrowserPreview)
+	selectedFile := m.selectedBrowserFile()
+	if selectedFile == nil || m.configView().browserPreview == nil || m.configView().browserPreview.path != selectedFile.Path {
+		selectedFile = nil
+	}
+	stacked := width < 80
+	if selectedFile != nil {
+		if stacked {
+			visibleRows = max(visibleRows/3, 3)
+			previewHeight -= visibleRows + 1
+		} else {
+			listWidth = width * 2 / 5
+		}
+	}
+
+	startIdx := 0
+	if m.mainIndex >= visibleRows {
+		startIdx = m.mainIndex - visibleRows + 1
+	}
+
+	for i, entry := range m.configView().browserEntries {
+		if i < startIdx || i >= startIdx+visibleRows {
+			continue
+		}
+
+		indicator := "  "
+		style := lipgloss.NewStyle()
+		if i == m.mainIndex && m.focusArea == FocusMain {
+			indicator = "> "
+			style = TableRowSelectedStyle
+		}
+
+		icon := IconFolder
+		if entry.Name == ".." {
+			icon = "⬆️"
+		} else if !entry.IsDir {
+			icon = IconFile
+		}
+
+		suffix := ""
+		if entry.IsProject {
+			if m.isProjectInConfig(entry.Path) {
+				suffix = lipgloss.NewStyle().
+					Foreground(ColorSuccess).
+					Render(" ✓ configured")
+			} else {
+				suffix = lipgloss.NewStyle().
+					Foreground(ColorWarning).
+					Render(" ★ project")
+			}
+		}
+
+		if !entry.IsDir {
+			suffix = lipgloss.NewStyle().
+				Foreground(ColorMuted).
+				Render(" " + formatSize(entry.Size))
+		}
+
+		row := fmt.Sprintf("%s%s %s%s", indicator, icon, entry.Name, suffix)
+		rows = append(rows, style.Width(listWidth-2).Render(truncateANSI(row, listWidth-2)))
+	}
+
+	// Scroll indicator
+	scrollInfo := ""
+	if len(m.configView().browserEntries) > visibleRows {
+		scrollInfo = SubtitleStyle.Render(
+			fmt.Sprintf(" [%d/%d]", m.mainIndex+1, len(m.configView().browserEntries)))
+	}
+
+	m.maxMainItems = len(m.configView().browserEntries)
+
+	listing := strings.Join(rows, "\n")
+	if selectedFile != nil && previewHeight >= 5 {
+		if stacked {
+			listing = lipgloss.JoinVertical(lipgloss.Left, listing, "",
+				m.renderFilePreview(width-2, previewHeight))
+		} else {
+			listing = lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.NewStyle().Width(listWidth).Render(listing), " ",
+				m.renderFilePreview(width-listWidth-1, previewHeight))
+		}
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		pathDisplay+scrollInfo,
+		"",
+		listing,
+	)
+
+	if projectInfo != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left,
+			content,
+			"",
+			projectInfo,
+		)
+	}
+
+	return content
+}
+
+// renderConfigSettings renders the settings in config view (raw YAML file)
+func (m *Model) renderConfigSettings(width, height int) string {
+	configPath := config.GetGlobalPath()
+	if configPath == "" {
+		return SubtitleStyle.Render("No config file loaded")
+	}
+
+	title := PanelTitleStyle.Render("Config File (read-only)")
+	pathInfo := SubtitleStyle.Render(fmt.Sprintf("📄 %s", configPath))
+
+	// Read the raw config file
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			title,
+			pathInfo,
+			"",
+			StatusError.Render(fmt.Sprintf("Error reading file: %v", err)),
+		)
+	}
+
+	// Split into lines
+	lines := strings.Split(string(data), "\n")
+	totalLines := len(lines)
+	visibleLines := height - 8
+	if visibleLines < 5 {
+		visibleLines = 5
+	}
+
+	// Update maxMainItems for scroll navigation
+	m.maxMainItems = totalLines
+
+	// Calculate scroll offset based on mainIndex
+	scrollOffset := m.mainIndex
+	if scrollOffset > totalLines-visibleLines {
+		scrollOffset = totalLines - visibleLines
+	}
+	if scrollOffset < 0 {
+		scrollOffset = 0
+	}
+
+	// Display lines with scroll
+	var displayLines []string
+	endIdx := scrollOffset + visibleLines
+	if endIdx > totalLines {
+		endIdx = totalLines
+	}
+
+	for i := scrollOffset; i < endIdx; i++ {
+		lineNum := lipgloss.NewStyle().Foreground(ColorMuted).Render(fmt.Sprintf("%3d │ ", i+1))
+		displayLines = append(displayLines, lineNum+lines[i])
+	}
+
+	// Scroll indicator
+	scrollInfo := ""
+	if totalLines > visibleLines {
+		scrollInfo = SubtitleStyle.Render(fmt.Sprintf("  [%d-%d of %d lines] ↑↓ to scroll", scrollOffset+1, endIdx, totalLines))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		pathInfo,
+		scrollInfo,
+		"",
+		strings.Join(displayLines, "\n"),
+	)
+}
+
+// renderConfigConfirmations renders the confirmation dialog settings
+// Row 0 is expert mode, following rows are the per-action overrides
+func (m *Model) renderConfigConfirmations(width, height int) string {
+	cfg := config.GetGlobal()
+	if cfg == nil || cfg.Settings == nil {
+		return SubtitleStyle.Render("No config file loaded")
+	}
+	confirm := cfg.Settings.GetConfirmationsConfig()
+
+	title := PanelTitleStyle.Render("Confirmation Dialogs")
+	hint := SubtitleStyle.Render("Expert mode skips confirmations, except for actions set to \"ask\"")
+
+	m.maxMainItems = len(config.ConfirmActions) + 1
+
+	renderRow := func(idx int, label, value string, valueColor lipgloss.Color, note string) string {
+		cursor := "  "
+		labelStyle := lipgloss.NewStyle().Foreground(ColorText)
+		if idx == m.mainIndex && m.focusArea == FocusMain {
+			cursor = "▶ "
+			labelStyle = labelStyle.Bold(true).Foreground(ColorPrimary)
+		}
+		row := cursor + labelStyle.Render(fmt.Sprintf("%-24s", label)) +
+			lipgloss.NewStyle().Foreground(valueColor).Bold(true).Render(fmt.Sprintf("%-6s", value))
+		if note != "" {
+			row += lipgloss.NewStyle().Foreground(ColorMuted).Render("  " + note)
+		}
+		return row
+	}
+
+	expert, expertColor := "off", ColorMuted
+	if confirm.ExpertMode {
+		expert, expertColor = "on", ColorWarning
+	}
+	rows := []string{renderRow(0, "Expert mode", expert, expertColor, ""), ""}
+
+	for i, action := range config.ConfirmActions {
+		value, color := "skip", ColorWarning
+		if confirm.ShouldConfirm(action.Key) {
+			value, color = "ask", ColorSuccess
+		}
+		note := "default"
+		if _, overridden := confirm.Actions[action.Key]; overridden {
+			note = "override"
+		}
+		rows = append(rows, renderRow(i+1, action.Label, value, color, note))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		hint,
+		"",
+		strings.Join(rows, "\n"),
+	)
+}
+
+// renderConfigPolling renders the refresh interval of each subsystem
+// Rows are the polling subsystems, the last row is file watching
+func (m *Model) renderConfigPolling(width, height int) string {
+	cfg := config.GetGlobal()
+	if cfg == nil || cfg.Settings == nil {
+		return SubtitleStyle.Render("No config file loaded")
+	}
+	polling := cfg.Settings.GetPollingConfig()
+
+	title := PanelTitleStyle.Render("Refresh Intervals")
+	hint := SubtitleStyle.Render("Applied from the next cycle (daemon: after restart)")
+
+	m.maxMainItems = len(config.PollSubsystems) + 1
+
+	renderRow := func(idx int, label, value string, valueColor lipgloss.Color, note string) string {
+		cursor := "  "
+		labelStyle := lipgloss.NewStyle().Foreground(ColorText)
+		if idx == m.mainIndex && m.focusArea == FocusMain {
+			cursor = "▶ "
+			labelStyle = labelStyle.Bold(true).Foreground(ColorPrimary)
+		}
+		row := cursor + labelStyle.Render(fmt.Sprintf("%-24s", label)) +
+			lipgloss.NewStyle().Foreground(valueColor).Bold(true).Render(fmt.Sprintf("%-6s", value))
+		if note != "" {
+			row += lipgloss.NewStyle().Foreground(ColorMuted).Render("  " + note)
+		}
+		return row
+	}
+
+	var rows []string
+	for i, sub := range config.PollSubsystems {
+		note := "default"
+		if cfg.Settings.Polling != nil && cfg.Settings.Polling.Get(sub.Key) > 0 {
+			note = "custom"
+		}
+		interval := polling.Interval(sub.Key)
+		rows = append(rows, renderRow(i, sub.Label, formatInterval(interval), ColorInfo, note))
+	}
+
+	watch, watchColor, watchNote := "on", ColorSuccess, "refresh git and Claude sessions on file change"
+	if polling.DisableWatch {
+		watch, watchColor, watchNote = "off", ColorMuted, "polling only"
+	}
+	rows = append(rows, "", renderRow(len(config.PollSubsystems), "File watching", watch, watchColor, watchNote))
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		hint,
+		"",
+		strings.Join(rows, "\n"),
+	)
+}
+
+// renderConfigLogging renders the DevTrack diagnostics settings
+func (m *Model) renderConfigLogging(width, height int) string {
+	cfg := config.GetGlobal()
+	if cfg == nil || cfg.Settings == nil {
+		return SubtitleStyle.Render("No config file loaded")
+	}
+	loggerConfig := cfg.Settings.GetLoggerConfig()
+
+	title := PanelTitleStyle.Render("DevTrack Diagnostics")
+	hint := SubtitleStyle.Render(fmt.Sprintf("Internal logs appear in the Logs view with the %q source", core.LogSourceDevTrack))
+
+	m.maxMainItems = 1
+
+	cursor := "  "
+	labelStyle := lipgloss.NewStyle().Foreground(ColorText)
+	if m.mainIndex == 0 && m.focusArea == FocusMain {
+		cursor = "▶ "
+		labelStyle = labelStyle.Bold(true).Foreground(ColorPrimary)
+	}
+	value, valueColor, note := "off", ColorMuted, "level "+loggerConfig.Level
+	if loggerConfig.Level == "debug" {
+		value, valueColor, note = "on", ColorSuccess, "daemon events, git poll timing, terminal lifecycle"
+	}
+	row := cursor + labelStyle.Render(fmt.Sprintf("%-24s", "Debug logging")) +
+		lipgloss.NewStyle().Foreground(valueColor).Bold(true).Render(fmt.Sprintf("%-6s", value)) +
+		lipgloss.NewStyle().Foreground(ColorMuted).Render("  "+note)
+
+	logFile := "~/.csd-devtrack/daemon.log (daemon)"
+	if loggerConfig.FilePath != "" {
+		logFile = loggerConfig.FilePath
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		hint,
+		"",
+		row,
+		"",
+		SubtitleStyle.Render("Log file: "+logFile),
+	)
+}
+
+// renderConfigDisplay renders the display settings
+func (m *Model) renderConfigDisplay(width, height int) string {
+	cfg := config.GetGlobal()
+	if cfg == nil || cfg.Settings == nil {
+		return SubtitleStyle.Render("No config file loaded")
+	}
+
+	title := PanelTitleStyle.Render("Display")
+	hint := SubtitleStyle.Render("Space/Enter to toggle the selected setting")
+
+	type displaySetting struct {
+		label   string
+		on      bool
+		onNote  string
+		offNote string
+	}
+	settings := []displaySetting{
+		{"Screen reader mode", cfg.Settings.ScreenReader, "no colors, borders or emoji", "colors, borders and icons"},
+		{"Syntax highlighting", !cfg.Settings.DisableSyntaxHighlight, "diffs and file previews colored", "plain diffs (slow terminals)"},
+		{"Vim keybindings", cfg.Settings.VimKeys, "h/l, gg/G and : added to j/k and /", "arrows, Home/End, ^G"},
+	}
+	m.maxMainItems = len(settings)
+
+	lines := []string{title, hint, ""}
+	for i, setting := range settings {
+		cursor := "  "
+		labelStyle := lipgloss.NewStyle().Foreground(ColorText)
+		if m.mainIndex == i && m.focusArea == FocusMain {
+			cursor = "▶ "
+			labelStyle = labelStyle.Bold(true).Foreground(ColorPrimary)
+		}
+		value, valueColor, note := "off", ColorMuted, setting.offNote
+		if setting.on {
+			value, valueColor, note = "on", ColorSuccess, setting.onNote
+		}
+		lines = append(lines, cursor+labelStyle.Render(fmt.Sprintf("%-24s", setting.label))+
+			lipgloss.NewStyle().Foreground(valueColor).Bold(true).Render(fmt.Sprintf("%-6s", value))+
+			lipgloss.NewStyle().Foreground(ColorMuted).Render("  "+note))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// formatInterval formats a polling interval (500ms, 30s, 2m)
+func formatInterval(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
+
+// toggleConfirmationSetting toggles the selected row of the confirmations tab.
+// Row 0 toggles expert mode; action rows flip between ask and skip, storing an
+// override only when the value differs from the expert mode default.
+func (m *Model) toggleConfirmationSetting() tea.Cmd {
+	if m.blockReadOnly("settings change") {
+		return nil
+	}
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Settings == nil {
+		cfg.Settings = config.DefaultSettings()
+	}
+	if cfg.Settings.Confirmations == nil {
+		cfg.Settings.Confirmations = &config.ConfirmationsConfig{}
+	}
+	confirm := cfg.Settings.Confirmations
+
+	details := ""
+	if m.mainIndex == 0 {
+		confirm.ExpertMode = !confirm.ExpertMode
+		details = fmt.Sprintf("confirmations.expert_mode=%v", confirm.ExpertMode)
+	} else if idx := m.mainIndex - 1; idx < len(config.ConfirmActions) {
+		action := config.ConfirmActions[idx].Key
+		ask := !confirm.ShouldConfirm(action)
+		if ask == !confirm.ExpertMode {
+			delete(confirm.Actions, action)
+		} else {
+			if confirm.Actions == nil {
+				confirm.Actions = make(map[string]bool)
+			}
+			confirm.Actions[action] = ask
+		}
+		details = fmt.Sprintf("confirmations.actions.%s=%v", action, ask)
+	}
+
+	if err := config.SaveGlobal(); err != nil {
+		m.lastError = fmt.Sprintf("Failed to save config: %v", err)
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	return m.auditEvent("config_change", "", details)
+}
+
+// pollIntervalSteps are the intervals offered in the polling tab (ms)
+var pollIntervalSteps = []int{500, 1000, 2000, 5000, 10000, 30000, 60000, 120000, 300000}
+
+// adjustPollingSetting changes the selected row of the polling tab.
+// Interval rows move to the next (delta > 0) or previous step; the last
+// row toggles file watching.
+func (m *Model) adjustPollingSetting(delta int) tea.Cmd {
+	if m.blockReadOnly("settings change") {
+		return nil
+	}
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Settings == nil {
+		cfg.Settings = config.DefaultSettings()
+	}
+	if cfg.Settings.Polling == nil {
+		cfg.Settings.Polling = &config.PollingConfig{}
+	}
+	polling := cfg.Settings.Polling
+
+	details := ""
+	if m.mainIndex < len(config.PollSubsystems) {
+		subsystem := config.PollSubsystems[m.mainIndex].Key
+		current := cfg.Settings.GetPollingConfig().Get(subsystem)
+		step := 0
+		for i, ms := range pollIntervalSteps {
+			if ms <= current {
+				step = i
+			}
+		}
+		step += delta
+		if step < 0 {
+			step = 0
+		}
+		if step >= len(pollIntervalSteps) {
+			step = len(pollIntervalSteps) - 1
+		}
+		polling.Set(subsystem, pollIntervalSteps[step])
+		details = fmt.Sprintf("polling.%s=%dms", subsystem, pollIntervalSteps[step])
+
+		if subsystem == config.PollMetrics && m.metricsCollector != nil {
+			m.metricsCollector.SetRefreshRate(metricsInterval(m.lowPower))
+		}
+	} else {
+		polling.DisableWatch = !polling.DisableWatch
+		details = fmt.Sprintf("polling.disable_watch=%v", polling.DisableWatch)
+	}
+
+	if err := config.SaveGlobal(); err != nil {
+		m.lastError = fmt.Sprintf("Failed to save config: %v", err)
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	return m.auditEvent("config_change", "", details)
+}
+
+// toggleLoggingSetting switches DevTrack's own logging between debug and info.
+// The level applies at once to this process and to the daemon.
+func (m *Model) toggleLoggingSetting() tea.Cmd {
+	if m.blockReadOnly("settings change") {
+		return nil
+	}
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Settings == nil {
+		cfg.Settings = config.DefaultSettings()
+	}
+	loggerConfig := cfg.Settings.GetLoggerConfig()
+	cfg.Settings.Logger = loggerConfig
+
+	if loggerConfig.Level == "debug" {
+		loggerConfig.Level = "info"
+	} else {
+		loggerConfig.Level = "debug"
+	}
+	logger.GetGlobalLogger().SetLevelName(loggerConfig.Level)
+	setLevel := m.sendEvent(core.NewEvent(core.EventSetLogLevel).WithValue(loggerConfig.Level))
+
+	if err := config.SaveGlobal(); err != nil {
+		m.lastError = fmt.Sprintf("Failed to save config: %v", err)
+		m.lastErrorTime = time.Now()
+		return setLevel
+	}
+	return tea.Batch(setLevel, m.auditEvent("config_change", "", "logger.level="+loggerConfig.Level))
+}
+
+// toggleDisplaySetting toggles the selected setting of the Display tab
+func (m *Model) toggleDisplaySetting() tea.Cmd {
+	if m.blockReadOnly("settings change") {
+		return nil
+	}
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Settings == nil {
+		cfg.Settings = config.DefaultSettings()
+	}
+
+	var details string
+	switch m.mainIndex {
+	case 0:
+		cfg.Settings.ScreenReader = !cfg.Settings.ScreenReader
+		SetScreenReaderMode(cfg.Settings.ScreenReader)
+		details = fmt.Sprintf("screen_reader=%v", cfg.Settings.ScreenReader)
+	case 1:
+		cfg.Settings.DisableSyntaxHighlight = !cfg.Settings.DisableSyntaxHighlight
+		details = fmt.Sprintf("syntax_highlight=%v", !cfg.Settings.DisableSyntaxHighlight)
+	case 2:
+		cfg.Settings.VimKeys = !cfg.Settings.VimKeys
+		m.keys = KeyMapFor(cfg.Settings.VimKeys)
+		details = fmt.Sprintf("vim_keys=%v", cfg.Settings.VimKeys)
+	default:
+		return nil
+	}
+
+	if err := config.SaveGlobal(); err != nil {
+		m.lastError = fmt.Sprintf("Failed to save config: %v", err)
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	return m.auditEvent("config_change", "", details)
+}
+
+// isRootPath returns true if path is a filesystem root ("/" or a drive root like "C:\")
+func isRootPath(path string) bool {
+	return filepath.Dir(path) == path
+}
+
+// loadBrowserEntries loads directory entries for the file browser
+func (m *Model) loadBrowserEntries() {
+	m.configView().browserEntries = make([]BrowserEntry, 0)
+
+	// Always add parent directory entry first (so user can navigate back)
+	if !isRootPath(m.configView().browserPath) {
+		m.configView().browserEntries = append(m.configView().browserEntries, BrowserEntry{
+			Name:  "..",
+			IsDir: true,
+			Path:  filepath.Dir(m.configView().browserPath),
+		})
+	}
+
+	entries, err := os.ReadDir(m.configView().browserPath)
+	if err != nil {
+		// Can't read directory, but we still have ".." to go back
+		return
+	}
+
+	detector := projects.NewDetector()
+	cfg := config.GetGlobal()
+
+	// Process directory entries
+	for _, entry := range entries {
+		name := entry.Name()
+		// Skip hidden entries
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		fullPath := filepath.Join(m.configView().browserPath, name)
+		if !entry.IsDir() {
+			// Files are listed for preview only
+			fileEntry := BrowserEntry{Name: name, Path: fullPath}
+			if info, err := entry.Info(); err == nil {
+				fileEntry.Size = info.Size()
+			}
+			m.configView().browserEntries = append(m.configView().browserEntries, fileEntry)
+			continue
+		}
+
+		browserEntry := BrowserEntry{
+			Name:  name,
+			IsDir: true,
+			Path:  fullPath,
+		}
+
+		// Check if we can read this directory (have permissions)
+		// before attempting project detection
+		if _, err := os.ReadDir(fullPath); err == nil {
+			// Check if this is a detectable project
+			if proj, err := detector.DetectProject(fullPath); err == nil && len(proj.Components) > 0 {
+				browserEntry.IsProject = true
+			}
+		}
+
+		// Check if already in config
+		if cfg != nil {
+			for _, p := range cfg.Projects {
+				if p.Path == fullPath {
+					browserEntry.IsProject = true
+					break
+				}
+			}
+		}
+
+		m.configView().browserEntries = append(m.configView().browserEntries, browserEntry)
+	}
+
+	// Sort: directories first, then by name
+	sort.Slice(m.configView().browserEntries, func(i, j int) bool {
+		if m.configView().browserEntries[i].Name == ".." {
+			return true
+		}
+		if m.configView().browserEntries[j].Name == ".." {
+			return false
+		}
+		if m.configView().browserEntries[i].IsDir != m.configView().browserEntries[j].IsDir {
+			return m.configView().browserEntries[i].IsDir
+		}
+		return m.configView().browserEntries[i].Name < m.configView().browserEntries[j].Name
+	})
+
+	// Update max items for navigation
+	m.maxMainItems = len(m.configView().browserEntries)
+
+	// Update detected project for current directory
+	m.updateDetectedProject()
+	m.syncBrowserPreview()
+}
+
+// updateDetectedProject checks if the current directory is a project
+func (m *Model) updateDetectedProject() {
+	detector := projects.NewDetector()
+	proj, err := detector.DetectProject(m.configView().browserPath)
+	if err != nil || len(proj.Components) == 0 {
+		m.configView().detectedProject = nil
+		return
+	}
+
+	// Build component list
+	var components []string
+	for compType := range proj.Components {
+		components = append(components, string(compType))
+	}
+
+	m.configView().detectedProject = &DetectedProjectInfo{
+		Name:       proj.Name,
+		Path:       proj.Path,
+		Type:       string(proj.Type),
+		Components: components,
+	}
+}
+
+// isProjectInConfig checks if a path is already in config
+func (m *Model) isProjectInConfig(path string) bool {
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		return false
+	}
+	for _, p := range cfg.Projects {
+		if p.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// isSelfProject checks if a path is the self project (csd-devtrack)
+func (m *Model) isSelfProject(path string) bool {
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		return false
+	}
+	for _, p := range cfg.Projects {
+		if p.Path == path && p.Self {
+			return true
+		}
+	}
+	return false
+}
+
+// getProjectFromConfig returns the project config for a path
+func (m *Model) getProjectFromConfig(path string) *projects.Project {
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		return nil
+	}
+	for i, p := range cfg.Projects {
+		if p.Path == path {
+			return &cfg.Projects[i]
+		}
+	}
+	return nil
+}
+
+// addProjectToConfig adds the detected project to config
+func (m *Model) addProjectToConfig() error {
+	if m.configView().detectedProject == nil {
+		return nil
+	}
+
+	detector := projects.NewDetector()
+	proj, err := detector.DetectProject(m.configView().detectedProject.Path)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+
+	// Check if already exists
+	for _, p := range cfg.Projects {
+		if p.Path == proj.Path {
+			return nil // Already in config
+		}
+	}
+
+	cfg.Projects = append(cfg.Projects, *proj)
+	return config.SaveGlobal()
+}
+
+// removeProjectFromConfig removes a project from config by path
+func (m *Model) removeProjectFromConfig(path string) error {
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		return nil
+	}
+
+	newProjects := make([]projects.Project, 0)
+	for _, p := range cfg.Projects {
+		// Never remove self project
+		if p.Self {
+			newProjects = append(newProjects, p)
+			continue
+		}
+		if p.Path != path {
+			newProjects = append(newProjects, p)
+		}
+	}
+	cfg.Projects = newProjects
+	return config.SaveGlobal()
+}
+
+// enterBrowserDirectory enters the selected directory
+func (m *Model) enterBrowserDirectory() {
+	if m.mainIndex < 0 || m.mainIndex >= len(m.configView().browserEntries) {
+		return
+	}
+
+	entry := m.configView().browserEntries[m.mainIndex]
+	if !entry.IsDir {
+		return
+	}
+
+	m.configView().browserPath = entry.Path
+	m.mainIndex = 0
+	m.loadBrowserEntries()
+}
@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/containers"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// containerItemPrefix prefixes the IDs of container items in the Processes
+// tree (process IDs are project/component)
+const containerItemPrefix = "container:"
+
+// containerMenuItem returns the Processes tree item of a container
+func containerMenuItem(c core.ContainerVM) TreeMenuItem {
+	statusIcon := ""
+	switch {
+	case c.Running():
+		statusIcon = "●"
+	case c.State == "restarting":
+		statusIcon = "↻"
+	}
+	return TreeMenuItem{
+		ID:           containerItemPrefix + c.ID,
+		Label:        c.Name,
+		Icon:         "▣",
+		IconColor:    ColorInfo,
+		TrailingIcon: statusIcon,
+		Data:         c,
+	}
+}
+
+// selectedContainer returns the container selected in the Processes view,
+// nil if a process or a project is selected
+func (c *processesController) selectedContainer() *core.ContainerVM {
+	if c.menu == nil {
+		return nil
+	}
+	if item := c.menu.SelectedItem(); item != nil {
+		if c, ok := item.Data.(core.ContainerVM); ok {
+			return &c
+		}
+	}
+	return nil
+}
+
+// handleContainerKey handles the action keys of the Processes view on a
+// container: r starts or restarts it, s stops it, l follows its logs. The
+// process actions without a container equivalent are refused.
+func (m *Model) handleContainerKey(key string, c *core.ContainerVM) (tea.Cmd, bool) {
+	switch key {
+	case "r":
+		if c.Running() {
+			return m.containerAction(c, containers.ActionRestart), true
+		}
+		return m.containerAction(c, containers.ActionStart), true
+	case "s":
+		if !c.Running() {
+			m.lastError = "Container " + c.Name + " is not running"
+			m.lastErrorTime = time.Now()
+			return nil, true
+		}
+		return m.containerAction(c, containers.ActionStop), true
+	case "l":
+		return m.viewContainerLogs(c), true
+	case "b", "k", "p", ".", "x", "a":
+		m.lastError = "Not available for containers: use r, s or l"
+		m.lastErrorTime = time.Now()
+		return nil, true
+	}
+	return nil, false
+}
+
+// containerAction starts, stops or restarts a container
+func (m *Model) containerAction(c *core.ContainerVM, action containers.Action) tea.Cmd {
+	return m.sendEvent(core.NewEvent(core.EventContainerAction).WithTarget(c.ID).WithValue(string(action)))
+}
+
+// viewContainerLogs follows the logs of a container and shows them in the
+// Logs view
+func (m *Model) viewContainerLogs(c *core.ContainerVM) tea.Cmd {
+	cmd := m.sendEvent(core.NewEvent(core.EventContainerLogs).WithTarget(c.ID))
+
+	m.currentView = core.VMLogs
+	m.sidebarIndex = 4 // Logs view index
+	m.sidebarMenu.SetSelectedIndex(4)
+	m.logsView().sourceFilter = c.LogsSource()
+	m.logsView().searchText = ""
+	return cmd
+}
+
+// containerDetailLines renders the detail panel of a container
+func containerDetailLines(c core.ContainerVM, engine string, width int) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	title := PanelTitleStyle.Render(c.ProjectName + "/" + c.Name)
+	if engine != "" {
+		title += mutedStyle.Render(" (" + engine + " container)")
+	}
+	lines := []string{title, ""}
+
+	switch {
+	case c.Running():
+		lines = append(lines, StatusSuccess.Render("● "+c.Status))
+	case c.State == "paused" || c.State == "restarting":
+		lines = append(lines, StatusWarning.Render("◐ "+c.Status))
+	default:
+		lines = append(lines, StatusError.Render("○ "+c.Status))
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, "Image: "+truncate(c.Image, width-8))
+	lines = append(lines, "ID: "+c.ID)
+	if c.ComposeProject != "" {
+		compose := "Compose: " + c.ComposeProject
+		if c.ComposeService != "" {
+			compose += " / " + c.ComposeService
+		}
+		lines = append(lines, truncate(compose, width))
+	}
+	if c.Ports != "" {
+		lines = append(lines, "", SubtitleStyle.Render("Ports:"))
+		for _, port := range strings.Split(c.Ports, ", ") {
+			lines = append(lines, "  "+truncate(port, width-2))
+		}
+	}
+
+	if st := c.Stats; st != nil {
+		lines = append(lines, "", SubtitleStyle.Render("Resources:"))
+		lines = append(lines, fmt.Sprintf("  CPU:    %s", st.CPUPercent))
+		lines = append(lines, fmt.Sprintf("  Memory: %s (%s)", st.MemUsage, st.MemPercent))
+		if st.NetIO != "" {
+			lines = append(lines, fmt.Sprintf("  Net:    %s", st.NetIO))
+		}
+		if st.BlockIO != "" {
+			lines = append(lines, fmt.Sprintf("  Disk:   %s", st.BlockIO))
+		}
+		if st.PIDs != "" {
+			lines = append(lines, fmt.Sprintf("  PIDs:   %s", st.PIDs))
+		}
+	}
+	if c.Following {
+		lines = append(lines, "", mutedStyle.Render("Logs followed in the Logs view ("+c.LogsSource()+")"))
+	}
+
+	lines = append(lines, "", SubtitleStyle.Render("Actions:"))
+	if c.Running() {
+		lines = append(lines, HelpKeyStyle.Render("s")+" stop  "+HelpKeyStyle.Render("r")+" restart  "+HelpKeyStyle.Render("l")+" logs")
+	} else {
+		lines = append(lines, HelpKeyStyle.Render("r")+" start  "+HelpKeyStyle.Render("l")+" logs")
+	}
+	return lines
+}
+
+// containerContextMenu returns the actions of the selected container
+func (m *Model) containerContextMenu(c *core.ContainerVM) *contextMenu {
+	menu := &contextMenu{title: c.Name + " (" + c.ProjectName + ")"}
+	if c.Running() {
+		menu.actions = append(menu.actions,
+			contextAction{"r", "Restart", func(m *Model) tea.Cmd { return m.containerAction(c, containers.ActionRestart) }},
+			contextAction{"s", "Stop", func(m *Model) tea.Cmd { return m.containerAction(c, containers.ActionStop) }},
+		)
+	} else {
+		menu.actions = append(menu.actions,
+			contextAction{"r", "Start", func(m *Model) tea.Cmd { return m.containerAction(c, containers.ActionStart) }})
+	}
+	menu.actions = append(menu.actions,
+		contextAction{"l", "Follow logs", func(m *Model) tea.Cmd { return m.viewContainerLogs(c) }},
+		contextAction{"y", "Copy container ID", func(m *Model) tea.Cmd { return m.yankText("container ID", c.ID) }},
+	)
+	return menu
+}
@@ -0,0 +1,362 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// contextAction is an entry of the context menu. Its key is, where one
+// exists, the shortcut of the same action in the view, so the menu also
+// teaches the shortcuts.
+type contextAction struct {
+	key   string
+	label string
+	run   func(m *Model) tea.Cmd
+}
+
+// contextMenu lists the actions applicable to the selected item (m key or
+// right-click)
+type contextMenu struct {
+	title    string
+	actions  []contextAction
+	selected int
+}
+
+// gitFileActionMsg is sent when a git action on a file (stage, ignore) ends
+type gitFileActionMsg struct {
+	message string
+	err     error
+}
+
+// openContextMenu opens the context menu of the selected item, false if
+// nothing in the current view has actions
+func (m *Model) openContextMenu() bool {
+	v, ok := m.controller().(contextMenuView)
+	if !ok {
+		return false
+	}
+	menu := v.contextMenu(m)
+	if menu == nil || len(menu.actions) == 0 {
+		return false
+	}
+	m.contextMenu = menu
+	return true
+}
+
+// projectContextMenu returns the actions of the selected project or component.
+// extra are the actions of the view itself, listed after the build, run and
+// stop actions.
+func (m *Model) projectContextMenu(extra ...contextAction) *contextMenu {
+	projectID := m.getSelectedProjectID()
+	proj := m.findProjectVM(projectID)
+	if proj == nil {
+		return nil
+	}
+	menu := &contextMenu{title: proj.Name}
+	if component := m.getSelectedComponent(); component != "" {
+		menu.title += " / " + string(component)
+	}
+
+	menu.actions = []contextAction{
+		{"b", "Build", (*Model).buildSelected},
+		{"r", "Run / restart", (*Model).runSelected},
+		{"s", "Stop", (*Model).stopSelected},
+		{".", "Dry run (then b, r or s)", (*Model).armDryRun},
+	}
+	if m.getSelectedComponent() != "" {
+		menu.actions = append(menu.actions,
+			contextAction{"", "Build with another command", (*Model).openBuildCommandDialog},
+			contextAction{"", "Run with another command", (*Model).openRunCommandDialog},
+		)
+	}
+	menu.actions = append(menu.actions, contextAction{"x", "Run a command here", (*Model).openComponentCommandDialog})
+	if url := m.selectedURL(); url != "" {
+		menu.actions = append(menu.actions, contextAction{"w", "Open " + url + " in the browser", (*Model).openInBrowser})
+	}
+	if comp := m.findComponentVM(projectID, m.getSelectedComponent()); comp != nil && comp.HasAPI {
+		menu.actions = append(menu.actions, contextAction{"a", "API explorer", (*Model).openAPIExplorer})
+	}
+	if comp := m.findComponentVM(projectID, m.getSelectedComponent()); comp != nil {
+		menu.actions = append(menu.actions, contextAction{"", certActionLabel(comp), (*Model).generateCertSelected})
+		capture := "Capture outbound requests"
+		if comp.Capture {
+			capture = "Stop capturing outbound requests"
+		}
+		menu.actions = append(menu.actions, contextAction{"", capture, (*Model).toggleCaptureSelected})
+	}
+	if m.projectHasServices(projectID) {
+		menu.actions = append(menu.actions, contextAction{"u", "Start the services it depends on", (*Model).servicesUpSelected})
+	}
+	if proj.HasImage {
+		menu.actions = append(menu.actions, contextAction{"i", "Build image (" + m.buildView().profile + ")", (*Model).buildImageSelected})
+	}
+	if m.projectHasServices(projectID) {
+		menu.actions = append(menu.actions, contextAction{"u", "Start the services it depends on", (*Model).servicesUpSelected})
+	}
+	menu.actions = append(menu.actions, extra...)
+	menu.actions = append(menu.actions,
+		contextAction{"l", "View logs", (*Model).viewLogsForSelected},
+		contextAction{"g", "Git status", func(m *Model) tea.Cmd { return m.showProjectInGit(projectID) }},
+	)
+	if m.state.Claude != nil && m.state.Claude.IsInstalled {
+		menu.actions = append(menu.actions, contextAction{"c", "New Claude session", func(m *Model) tea.Cmd {
+			return tea.Batch(m.selectViewByType(core.VMClaude), m.openNewClaudeSessionDialog(projectID))
+		}})
+	}
+	path := proj.Path
+	menu.actions = append(menu.actions, contextAction{"y", "Copy path", func(m *Model) tea.Cmd {
+		return m.yankText("project path", path)
+	}})
+	return menu
+}
+
+// gitContextMenu returns the actions of the file or project selected in the Git view
+func (m *Model) gitContextMenu() *contextMenu {
+	item := m.gitView().menu.SelectedItem()
+	if item == nil {
+		return nil
+	}
+
+	if status, ok := item.Data.(core.GitStatusVM); ok {
+		projectID := status.ProjectID
+		return &contextMenu{title: status.ProjectName, actions: []contextAction{
+			{"Enter", "Show files", func(m *Model) tea.Cmd {
+				m.gitView().menu.DrillDown()
+				return m.loadGitDiffForSelection()
+			}},
+			{"D", "Diff summary", func(m *Model) tea.Cmd {
+				return m.sendEvent(core.NewEvent(core.EventGitDiff).WithProject(projectID))
+			}},
+			{"H", "History", func(m *Model) tea.Cmd {
+				return m.sendEvent(core.NewEvent(core.EventGitLog).WithProject(projectID))
+			}},
+			{"r", "Refresh status", func(m *Model) tea.Cmd {
+				return m.sendEvent(core.NewEvent(core.EventGitStatus).WithProject(projectID))
+			}},
+		}}
+	}
+
+	file, ok := item.Data.(GitFileEntry)
+	if !ok {
+		return nil
+	}
+	path, _ := m.gitSelectedFilePath()
+	if path == "" {
+		return nil
+	}
+	projectPath := m.gitProjectPath(m.gitView().menu.DrillDownPath()[0])
+
+	menu := &contextMenu{title: file.Path}
+	menu.actions = append(menu.actions, contextAction{"d", "Show diff", func(m *Model) tea.Cmd {
+		m.focusArea = FocusDetail
+		return m.loadGitDiffForSelection()
+	}})
+	if file.Status == "staged" {
+		menu.actions = append(menu.actions, contextAction{"u", "Unstage", func(m *Model) tea.Cmd {
+			return m.gitFileAction("unstage", projectPath, file.Path)
+		}})
+	} else {
+		menu.actions = append(menu.actions, contextAction{"a", "Stage", func(m *Model) tea.Cmd {
+			return m.gitFileAction("stage", projectPath, file.Path)
+		}})
+	}
+	if file.Status != "deleted" {
+		menu.actions = append(menu.actions, contextAction{"o", "Open in editor", func(m *Model) tea.Cmd {
+			return m.openInEditor(path)
+		}})
+	}
+	if file.Status == "untracked" {
+		menu.actions = append(menu.actions, contextAction{"i", "Add to .gitignore", func(m *Model) tea.Cmd {
+			return m.gitFileAction("ignore", projectPath, file.Path)
+		}})
+	}
+	menu.actions = append(menu.actions, contextAction{"y", "Copy path", func(m *Model) tea.Cmd {
+		return m.yankText("file path", path)
+	}})
+	return menu
+}
+
+// browserContextMenu returns the actions of the entry selected in the Config browser
+func (m *Model) browserContextMenu() *contextMenu {
+	if m.configView().mode != "browser" || m.mainIndex < 0 || m.mainIndex >= len(m.configView().browserEntries) {
+		return nil
+	}
+	entry := m.configView().browserEntries[m.mainIndex]
+	menu := &contextMenu{title: entry.Name}
+	if !entry.IsDir {
+		menu.actions = append(menu.actions, contextAction{"o", "Open in editor", func(m *Model) tea.Cmd {
+			return m.openInEditor(entry.Path)
+		}})
+	}
+	menu.actions = append(menu.actions, contextAction{"y", "Copy path", func(m *Model) tea.Cmd {
+		return m.yankText("path", entry.Path)
+	}})
+	return menu
+}
+
+// claudeContextMenu returns the actions of the active Claude session
+func (m *Model) claudeContextMenu() *contextMenu {
+	if m.claudeView().activeSession == "" {
+		return nil
+	}
+	label := "Show transcript"
+	if m.claudeView().transcript != nil {
+		label = "Back to the terminal"
+	}
+	return &contextMenu{title: "Claude session", actions: []contextAction{
+		{"m", label, (*Model).toggleClaudeTranscript},
+		{"y", "Copy last message", (*Model).yankClaudeMessage},
+		{"^G [", "Copy / search terminal", (*Model).enterCopyMode},
+		{"^G o", "Pop out to a terminal window", (*Model).popOutTerminal},
+		{"b", "Fork into a new session", (*Model).forkActiveSession},
+	}}
+}
+
+// showProjectInGit switches to the Git view with a project selected
+func (m *Model) showProjectInGit(projectID string) tea.Cmd {
+	cmd := m.selectViewByType(core.VMGit)
+	for m.gitView().menu.DrillUp() {
+	}
+	m.gitView().menu.ClearSearch()
+	for i, item := range m.gitView().menu.VisibleItems() {
+		if status, ok := item.Data.(core.GitStatusVM); ok && status.ProjectID == projectID {
+			m.gitView().menu.SetSelectedIndex(i)
+			m.gitView().menu.ensureSelectionVisible()
+			break
+		}
+	}
+	return tea.Batch(cmd, m.loadGitDiffForSelection())
+}
+
+// gitFileAction stages, unstages or ignores a file of a project, then
+// refreshes the git status
+func (m *Model) gitFileAction(action, projectPath, file string) tea.Cmd {
+	if m.blockReadOnly(action) {
+		return nil
+	}
+	return func() tea.Msg {
+		var err error
+		switch action {
+		case "stage":
+			err = runGit(projectPath, "add", "--", file)
+		case "unstage":
+			err = runGit(projectPath, "restore", "--staged", "--", file)
+		case "ignore":
+			err = appendGitignore(projectPath, "/"+filepath.ToSlash(file))
+		}
+		if err != nil {
+			return gitFileActionMsg{err: fmt.Errorf("%s %s: %w", action, file, err)}
+		}
+		past := map[string]string{"stage": "Staged", "unstage": "Unstaged", "ignore": "Ignored"}[action]
+		return gitFileActionMsg{message: past + " " + file}
+	}
+}
+
+// runGit runs a git command in a directory, the error includes its output
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if text := strings.TrimSpace(string(output)); text != "" {
+			return fmt.Errorf("%s", text)
+		}
+		return err
+	}
+	return nil
+}
+
+// appendGitignore adds a pattern at the end of the .gitignore of a project
+func appendGitignore(projectPath, pattern string) error {
+	path := filepath.Join(projectPath, ".gitignore")
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	entry := pattern + "\n"
+	if len(existing) > 0 && existing[len(existing)-1] != '\n' {
+		entry = "\n" + entry
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(entry)
+	return err
+}
+
+// handleContextMenuKey handles a key while the context menu is open: move,
+// run the selected action or the action of a shortcut, close
+func (m *Model) handleContextMenuKey(msg tea.KeyMsg) tea.Cmd {
+	menu := m.contextMenu
+	key := msg.String()
+	switch key {
+	case "esc", "q":
+		m.contextMenu = nil
+		return nil
+	case "up", "shift+tab", "ctrl+p":
+		menu.selected = (menu.selected - 1 + len(menu.actions)) % len(menu.actions)
+		return nil
+	case "down", "tab", "ctrl+n":
+		menu.selected = (menu.selected + 1) % len(menu.actions)
+		return nil
+	case "enter":
+		return m.runContextAction(menu.actions[menu.selected])
+	}
+	for _, action := range menu.actions {
+		if action.key == key {
+			return m.runContextAction(action)
+		}
+	}
+	if key == "m" {
+		// Same key as opening (unless an action uses it)
+		m.contextMenu = nil
+	}
+	return nil
+}
+
+// runContextAction closes the menu and runs an action
+func (m *Model) runContextAction(action contextAction) tea.Cmd {
+	m.contextMenu = nil
+	return action.run(m)
+}
+
+// renderContextMenuOverlay renders the context menu centered in the content area
+func (m *Model) renderContextMenuOverlay(width, height int) string {
+	menu := m.contextMenu
+
+	keyWidth := 0
+	labelWidth := lipgloss.Width(menu.title)
+	for _, action := range menu.actions {
+		keyWidth = max(keyWidth, lipgloss.Width(action.key))
+		labelWidth = max(labelWidth, lipgloss.Width(action.label)+keyWidth+3)
+	}
+	innerWidth := min(labelWidth+2, width-8)
+
+	lines := []string{
+		DialogTitleStyle.MarginBottom(0).Render(truncateANSI(menu.title, innerWidth)),
+		"",
+	}
+	for i, action := range menu.actions {
+		row := fmt.Sprintf(" %-*s  %s", keyWidth, action.key, action.label)
+		row = truncateANSI(row, innerWidth)
+		if i == menu.selected {
+			row = TableRowSelectedStyle.Render(row + strings.Repeat(" ", max(innerWidth-lipgloss.Width(row), 0)))
+		} else {
+			row = HelpKeyStyle.Render(fmt.Sprintf(" %-*s", keyWidth, action.key)) + "  " + action.label
+		}
+		lines = append(lines, row)
+	}
+	lines = append(lines, "", strings.Join(renderKeyHints([]KeyHint{{"↑↓", "select"}, {"Enter", "run"}, {"Esc", "close"}}), ""))
+
+	box := DialogStyle.Padding(0, 2).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
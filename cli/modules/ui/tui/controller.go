@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ViewController is a per-view submodel.
+// The root Model keeps the global state (sidebar, focus, dialogs, terminal)
+// and routes messages to the controller of the current view: every view of
+// the sidebar has a controller registered in newControllers(), which owns
+// the state of the view.
+//
+// Bubble Tea copies the Model on every Update, so controllers are stored by
+// pointer (shared between copies) and receive the root model on each call
+// instead of keeping a reference to it.
+type ViewController interface {
+	// Init is called each time the view becomes the current view
+	Init(m *Model) tea.Cmd
+
+	// Update handles a message routed by the root model.
+	// handled reports whether the controller consumed the message.
+	Update(m *Model, msg tea.Msg) (cmd tea.Cmd, handled bool)
+
+	// View renders the main content of the view
+	View(m *Model, width, height int) string
+
+	// Keys returns the footer shortcuts of the view
+	Keys(m *Model) []KeyHint
+
+	// Menu returns the TreeMenu navigated in a panel (nil if none)
+	Menu(m *Model, focus FocusArea) *TreeMenu
+}
+
+// Optional interfaces of a ViewController
+type (
+	// selectionView is implemented by the views whose selection is a
+	// project, a component or a process (build, run, logs... act on it)
+	selectionView interface {
+		selection(m *Model) viewSelection
+	}
+
+	// terminalView is implemented by the views showing an embedded terminal
+	terminalView interface {
+		terminalSession() string // Session of the terminal shown ("" if none)
+	}
+
+	// detailPanelView is implemented by the views with a detail panel
+	detailPanelView interface {
+		hasDetailPanel() bool
+	}
+
+	// yankView is implemented by the views whose selection can be copied
+	// to the clipboard with y (see yankText)
+	yankView interface {
+		yank(m *Model) tea.Cmd
+	}
+
+	// contextMenuView is implemented by the views with actions on their
+	// selection (m key or right-click, see openContextMenu)
+	contextMenuView interface {
+		contextMenu(m *Model) *contextMenu
+	}
+
+	// locationView is implemented by the views whose location in the
+	// navigation history is more than the selection of their main tree: a
+	// side panel tree, a session, filters (see navLocation)
+	locationView interface {
+		saveLocation(m *Model, location *navLocation)
+		restoreLocation(m *Model, location navLocation) tea.Cmd
+	}
+
+	// batchView is implemented by the views whose tree items can be marked
+	// with space for a batch action (see handleBatchKey)
+	batchView interface {
+		// batchAction returns the key of the batch action, its footer label
+		// and its confirmation (a %d format of the number of items)
+		batchAction() (key, label, prompt string)
+		runBatch(m *Model, items []TreeMenuItem) tea.Cmd
+	}
+)
+
+// viewSelection is the project or component selected in a view
+type viewSelection struct {
+	projectID string
+	component projects.ComponentType // Empty when a whole project is selected
+	self      bool                   // The project is csd-devtrack itself
+}
+
+// KeyHint is a shortcut displayed in the footer
+type KeyHint struct {
+	Key  string
+	Desc string
+}
+
+// Messages routed to view controllers by the root model
+type (
+	// selectMsg is sent when Enter is pressed in the main panel
+	selectMsg struct{}
+
+	// detailSelectMsg is sent when Enter is pressed in the detail panel
+	detailSelectMsg struct{}
+
+	// keyPressMsg is sent before the global keys (focus, navigation, Enter),
+	// so a view can take over a key that has a global meaning. Other keys
+	// are sent as tea.KeyMsg after the global handling.
+	keyPressMsg struct {
+		key tea.KeyMsg
+	}
+
+	// viewOpenedMsg is sent the first time a view is opened, so a view can
+	// defer building its menus until they are shown
+	viewOpenedMsg struct{}
+
+	// itemCountMsg is sent after a state change so the view updates the
+	// counts of its index-based lists (maxMainItems, maxDetailItems)
+	itemCountMsg struct{}
+
+	// dialogConfirmMsg is sent when a confirmation dialog is accepted
+	dialogConfirmMsg struct {
+		dialogType string
+	}
+)
+
+// newControllers creates the view controllers
+func newControllers() map[core.ViewModelType]ViewController {
+	return map[core.ViewModelType]ViewController{
+		core.VMDashboard: newDashboardController(),
+		core.VMProjects:  newProjectsController(),
+		core.VMBuild:     newBuildController(),
+		core.VMProcesses: newProcessesController(),
+		core.VMLogs:      newLogsController(),
+		core.VMGit:       newGitController(),
+		core.VMConfig:    newConfigController(),
+		core.VMCockpit:   newCockpitController(),
+		core.VMClaude:    newClaudeController(),
+		core.VMCodex:     newCodexController(),
+		core.VMDatabase:  newDatabaseController(),
+		core.VMShell:     newShellController(),
+		core.VMStorage:   newStorageController(),
+		core.VMTrash:     newTrashController(),
+		core.VMAudit:     newAuditController(),
+		core.VMPlugins:   newPluginsController(),
+		core.VMInternals: newInternalsController(),
+	}
+}
+
+// controller returns the controller of the current view (nil if none)
+func (m *Model) controller() ViewController {
+	return m.controllers[m.currentView]
+}
+
+// routeToController sends a message to the current view controller
+func (m *Model) routeToController(msg tea.Msg) (tea.Cmd, bool) {
+	c := m.controller()
+	if c == nil {
+		return nil, false
+	}
+	return c.Update(m, msg)
+}
+
+// broadcastToControllers sends a message to every controller (state updates)
+func (m *Model) broadcastToControllers(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, c := range m.controllers {
+		if cmd, _ := c.Update(m, msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// currentSelection returns the project or component selected in the current view
+func (m *Model) currentSelection() viewSelection {
+	if v, ok := m.controller().(selectionView); ok {
+		return v.selection(m)
+	}
+	return viewSelection{}
+}
+
+// renderKeyHints renders footer shortcuts
+func renderKeyHints(hints []KeyHint) []string {
+	shortcuts := make([]string, 0, len(hints))
+	for _, h := range hints {
+		shortcuts = append(shortcuts, HelpKeyStyle.Render(h.Key)+HelpDescStyle.Render(" "+h.Desc+"  "))
+	}
+	return shortcuts
+}
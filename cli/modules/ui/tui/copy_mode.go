@@ -0,0 +1,526 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// scrollbackProvider is implemented by terminals that keep history beyond the screen
+type scrollbackProvider interface {
+	ScrollbackLines() []string
+}
+
+// copyMatch is a search match in the copy mode buffer
+type copyMatch struct {
+	y, x, length int
+}
+
+// copyMode is the scrollback copy/search mode of an embedded terminal (^G [).
+// It works on a plain text snapshot of the terminal history taken on entry,
+// with vi-like keys (like tmux copy-mode-vi).
+type copyMode struct {
+	terminal TerminalInterface // Terminal the snapshot was taken from
+	lines    [][]rune          // Snapshot of the scrollback (plain text)
+
+	cursorY, cursorX int
+	top              int // First visible line
+	height           int // Visible lines (set at render)
+
+	// Selection (from anchor to cursor, inclusive)
+	selecting        bool
+	lineSelect       bool // V: whole lines
+	anchorY, anchorX int
+
+	// Search
+	searchActive  bool   // Typing a search query
+	searchForward bool   // / (forward) or ? (backward)
+	searchInput   string // Query being typed
+	query         string // Last submitted query (lowercased)
+	matches       []copyMatch
+	message       string // Status message (e.g. "Pattern not found")
+}
+
+// newCopyMode creates a copy mode on the terminal scrollback, cursor on the last line
+func newCopyMode(t TerminalInterface) *copyMode {
+	var raw []string
+	if p, ok := t.(scrollbackProvider); ok {
+		raw = p.ScrollbackLines()
+	}
+	if len(raw) == 0 {
+		raw = t.GetLines()
+	}
+
+	// Drop trailing blank lines (unused screen rows)
+	for len(raw) > 0 && strings.TrimSpace(raw[len(raw)-1]) == "" {
+		raw = raw[:len(raw)-1]
+	}
+	if len(raw) == 0 {
+		raw = []string{""}
+	}
+
+	c := &copyMode{terminal: t, height: t.Height()}
+	c.lines = make([][]rune, len(raw))
+	for i, line := range raw {
+		c.lines[i] = []rune(strings.TrimRight(line, " \x00"))
+	}
+	c.cursorY = len(c.lines) - 1
+	c.scrollToCursor()
+	return c
+}
+
+// HandleKey processes a key in copy mode.
+// It returns exit=true when copy mode ends, with the yanked text if any.
+func (c *copyMode) HandleKey(key string) (exit bool, yanked string) {
+	if c.searchActive {
+		c.handleSearchKey(key)
+		return false, ""
+	}
+	c.message = ""
+
+	half := c.height / 2
+	if half < 1 {
+		half = 1
+	}
+
+	switch key {
+	case "esc", "escape":
+		// Esc cancels the selection first
+		if c.selecting {
+			c.selecting = false
+			return false, ""
+		}
+		return true, ""
+	case "q", "ctrl+c":
+		return true, ""
+
+	// Movement
+	case "up", "k":
+		c.moveTo(c.cursorY-1, c.cursorX)
+	case "down", "j":
+		c.moveTo(c.cursorY+1, c.cursorX)
+	case "left", "h":
+		c.moveTo(c.cursorY, c.cursorX-1)
+	case "right", "l":
+		c.moveTo(c.cursorY, c.cursorX+1)
+	case "pgup", "ctrl+u":
+		c.moveTo(c.cursorY-half, c.cursorX)
+	case "pgdown", "ctrl+d":
+		c.moveTo(c.cursorY+half, c.cursorX)
+	case "ctrl+b":
+		c.moveTo(c.cursorY-c.height, c.cursorX)
+	case "ctrl+f":
+		c.moveTo(c.cursorY+c.height, c.cursorX)
+	case "g", "home":
+		c.moveTo(0, 0)
+	case "G", "end":
+		c.moveTo(len(c.lines)-1, 0)
+	case "0":
+		c.moveTo(c.cursorY, 0)
+	case "$":
+		c.moveTo(c.cursorY, len(c.lines[c.cursorY])-1)
+	case "w":
+		c.nextWord()
+	case "b":
+		c.prevWord()
+
+	// Selection
+	case "v", " ", "space":
+		c.toggleSelection(false)
+	case "V":
+		c.toggleSelection(true)
+	case "y", "enter":
+		return true, c.yank()
+
+	// Search
+	case "/":
+		c.searchActive = true
+		c.searchForward = true
+		c.searchInput = ""
+	case "?":
+		c.searchActive = true
+		c.searchForward = false
+		c.searchInput = ""
+	case "n":
+		c.jumpToMatch(c.searchForward)
+	case "N":
+		c.jumpToMatch(!c.searchForward)
+	}
+	return false, ""
+}
+
+// handleSearchKey handles typing of the search query
+func (c *copyMode) handleSearchKey(key string) {
+	switch key {
+	case "esc", "escape", "ctrl+c":
+		c.searchActive = false
+	case "enter":
+		c.searchActive = false
+		if c.searchInput != "" {
+			c.setQuery(c.searchInput)
+			c.jumpToMatch(c.searchForward)
+		}
+	case "backspace":
+		if r := []rune(c.searchInput); len(r) > 0 {
+			c.searchInput = string(r[:len(r)-1])
+		}
+	case "space":
+		c.searchInput += " "
+	default:
+		if len([]rune(key)) == 1 {
+			c.searchInput += key
+		}
+	}
+}
+
+// setQuery sets the search query and finds all matches (case-insensitive)
+func (c *copyMode) setQuery(query string) {
+	c.query = strings.ToLower(query)
+	c.matches = c.matches[:0]
+	needle := []rune(c.query)
+	for y, line := range c.lines {
+		lower := []rune(strings.ToLower(string(line)))
+		for x := 0; x+len(needle) <= len(lower); x++ {
+			if string(lower[x:x+len(needle)]) == c.query {
+				c.matches = append(c.matches, copyMatch{y: y, x: x, length: len(needle)})
+				x += len(needle) - 1
+			}
+		}
+	}
+}
+
+// jumpToMatch moves the cursor to the next match in the given direction (wraps around)
+func (c *copyMode) jumpToMatch(forward bool) {
+	if c.query == "" {
+		return
+	}
+	if len(c.matches) == 0 {
+		c.message = "Pattern not found: " + c.query
+		return
+	}
+
+	if forward {
+		for _, match := range c.matches {
+			if match.y > c.cursorY || (match.y == c.cursorY && match.x > c.cursorX) {
+				c.moveTo(match.y, match.x)
+				return
+			}
+		}
+		c.message = "Search wrapped to top"
+		c.moveTo(c.matches[0].y, c.matches[0].x)
+		return
+	}
+
+	for i := len(c.matches) - 1; i >= 0; i-- {
+		match := c.matches[i]
+		if match.y < c.cursorY || (match.y == c.cursorY && match.x < c.cursorX) {
+			c.moveTo(match.y, match.x)
+			return
+		}
+	}
+	c.message = "Search wrapped to bottom"
+	last := c.matches[len(c.matches)-1]
+	c.moveTo(last.y, last.x)
+}
+
+// moveTo moves the cursor, clamped to the buffer, and scrolls to keep it visible
+func (c *copyMode) moveTo(y, x int) {
+	if y < 0 {
+		y = 0
+	}
+	if y >= len(c.lines) {
+		y = len(c.lines) - 1
+	}
+	if x >= len(c.lines[y]) {
+		x = len(c.lines[y]) - 1
+	}
+	if x < 0 {
+		x = 0
+	}
+	c.cursorY, c.cursorX = y, x
+	c.scrollToCursor()
+}
+
+// scrollToCursor adjusts top so the cursor line is visible
+func (c *copyMode) scrollToCursor() {
+	height := c.height
+	if height < 1 {
+		height = 1
+	}
+	if c.cursorY < c.top {
+		c.top = c.cursorY
+	}
+	if c.cursorY >= c.top+height {
+		c.top = c.cursorY - height + 1
+	}
+	// Keep the viewport filled when the buffer is scrolled to the end
+	if maxTop := len(c.lines) - height; c.top > maxTop {
+		c.top = maxTop
+	}
+	if c.top < 0 {
+		c.top = 0
+	}
+}
+
+// nextWord moves to the start of the next word (crossing lines)
+func (c *copyMode) nextWord() {
+	y, x := c.cursorY, c.cursorX
+	line := c.lines[y]
+	// Skip the current word, then spaces
+	for x < len(line) && line[x] != ' ' {
+		x++
+	}
+	for {
+		for x < len(line) && line[x] == ' ' {
+			x++
+		}
+		if x < len(line) || y >= len(c.lines)-1 {
+			break
+		}
+		y++
+		x = 0
+		line = c.lines[y]
+	}
+	c.moveTo(y, x)
+}
+
+// prevWord moves to the start of the previous word (crossing lines)
+func (c *copyMode) prevWord() {
+	y, x := c.cursorY, c.cursorX-1
+	for {
+		line := c.lines[y]
+		for x >= 0 && x < len(line) && line[x] == ' ' {
+			x--
+		}
+		if x >= 0 || y == 0 {
+			break
+		}
+		y--
+		x = len(c.lines[y]) - 1
+	}
+	line := c.lines[y]
+	for x > 0 && x-1 < len(line) && line[x-1] != ' ' {
+		x--
+	}
+	c.moveTo(y, x)
+}
+
+// toggleSelection starts or stops a selection at the cursor
+func (c *copyMode) toggleSelection(lines bool) {
+	if c.selecting && c.lineSelect == lines {
+		c.selecting = false
+		return
+	}
+	if !c.selecting {
+		c.anchorY, c.anchorX = c.cursorY, c.cursorX
+	}
+	c.selecting = true
+	c.lineSelect = lines
+}
+
+// selectionBounds returns the ordered selection bounds
+func (c *copyMode) selectionBounds() (startY, startX, endY, endX int) {
+	startY, startX, endY, endX = c.anchorY, c.anchorX, c.cursorY, c.cursorX
+	if startY > endY || (startY == endY && startX > endX) {
+		startY, startX, endY, endX = endY, endX, startY, startX
+	}
+	return
+}
+
+// isSelected returns true if the cell is in the selection
+func (c *copyMode) isSelected(y, x int) bool {
+	if !c.selecting {
+		return false
+	}
+	startY, startX, endY, endX := c.selectionBounds()
+	if y < startY || y > endY {
+		return false
+	}
+	if c.lineSelect {
+		return true
+	}
+	if y == startY && x < startX {
+		return false
+	}
+	if y == endY && x > endX {
+		return false
+	}
+	return true
+}
+
+// yank returns the selected text (the cursor line without selection)
+func (c *copyMode) yank() string {
+	if !c.selecting {
+		return string(c.lines[c.cursorY])
+	}
+
+	startY, startX, endY, endX := c.selectionBounds()
+	var parts []string
+	for y := startY; y <= endY; y++ {
+		line := c.lines[y]
+		from, to := 0, len(line)
+		if !c.lineSelect {
+			if y == startY {
+				from = startX
+			}
+			if y == endY && endX+1 < to {
+				to = endX + 1
+			}
+		}
+		if from > to {
+			from = to
+		}
+		parts = append(parts, strings.TrimRight(string(line[from:to]), " "))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// matchAt returns true if the cell is part of a search match
+func (c *copyMode) matchAt(y, x int) bool {
+	for _, match := range c.matches {
+		if match.y > y {
+			break
+		}
+		if match.y == y && x >= match.x && x < match.x+match.length {
+			return true
+		}
+	}
+	return false
+}
+
+// Cell classes for rendering
+const (
+	copyCellNormal = iota
+	copyCellMatch
+	copyCellSelected
+	copyCellCursor
+)
+
+// View renders the visible part of the buffer and a status line
+func (c *copyMode) View(width, height int) string {
+	// Last row is the status line
+	c.height = height - 1
+	if c.height < 1 {
+		c.height = 1
+	}
+	c.scrollToCursor()
+
+	styles := map[int]lipgloss.Style{
+		copyCellNormal:   lipgloss.NewStyle(),
+		copyCellMatch:    lipgloss.NewStyle().Background(ColorWarning).Foreground(ColorBg),
+		copyCellSelected: lipgloss.NewStyle().Background(ColorSecondary).Foreground(ColorBg),
+		copyCellCursor:   lipgloss.NewStyle().Reverse(true),
+	}
+
+	rows := make([]string, 0, height)
+	for y := c.top; y < c.top+c.height && y < len(c.lines); y++ {
+		line := c.lines[y]
+		cells := width
+		if y == c.cursorY && len(line) < width {
+			// Room for the cursor after the end of the line
+			cells = len(line) + 1
+		} else if len(line) < width {
+			cells = len(line)
+		}
+
+		var row strings.Builder
+		var segment []rune
+		class := -1
+		flush := func() {
+			if len(segment) > 0 {
+				row.WriteString(styles[class].Render(string(segment)))
+				segment = segment[:0]
+			}
+		}
+		for x := 0; x < cells; x++ {
+			r := ' '
+			if x < len(line) {
+				r = line[x]
+			}
+			cellClass := copyCellNormal
+			switch {
+			case y == c.cursorY && x == c.cursorX:
+				cellClass = copyCellCursor
+			case c.isSelected(y, x):
+				cellClass = copyCellSelected
+			case c.matchAt(y, x):
+				cellClass = copyCellMatch
+			}
+			if cellClass != class {
+				flush()
+				class = cellClass
+			}
+			segment = append(segment, r)
+		}
+		flush()
+		rows = append(rows, row.String())
+	}
+	for len(rows) < c.height {
+		rows = append(rows, "")
+	}
+
+	return strings.Join(append(rows, c.statusLine(width)), "\n")
+}
+
+// statusLine renders the copy mode status (position, search prompt, messages)
+func (c *copyMode) statusLine(width int) string {
+	var status string
+	switch {
+	case c.searchActive:
+		prompt := "/"
+		if !c.searchForward {
+			prompt = "?"
+		}
+		status = StatusWarning.Render(prompt) + c.searchInput + "█"
+	case c.message != "":
+		status = StatusWarning.Render(c.message)
+	default:
+		mode := "COPY"
+		if c.selecting && c.lineSelect {
+			mode = "VISUAL LINE"
+		} else if c.selecting {
+			mode = "VISUAL"
+		}
+		status = StatusRunning.Render(mode) + HelpDescStyle.Render(fmt.Sprintf("  [%d/%d]", c.cursorY+1, len(c.lines)))
+		if c.query != "" {
+			status += HelpDescStyle.Render(fmt.Sprintf("  /%s (%d)", c.query, len(c.matches)))
+		}
+	}
+	return truncateANSI(status, width)
+}
+
+// enterCopyMode enters the copy/search mode of the terminal shown in the current view
+func (m *Model) enterCopyMode() tea.Cmd {
+	sessionID := m.activeTerminalSession()
+	var t TerminalInterface
+	if sessionID != "" && m.terminalManager != nil {
+		t = m.terminalManager.Get(sessionID)
+	}
+	if t == nil || !t.IsRunning() {
+		m.lastError = "No active terminal"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	m.copyMode = newCopyMode(t)
+	return nil
+}
+
+// handleCopyModeKey handles a key while copy mode is active
+func (m *Model) handleCopyModeKey(msg tea.KeyMsg) tea.Cmd {
+	exit, yanked := m.copyMode.HandleKey(msg.String())
+	if !exit {
+		return nil
+	}
+	m.copyMode = nil
+	if yanked == "" {
+		return nil
+	}
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess,
+		fmt.Sprintf("Copied %d characters to clipboard", len([]rune(yanked)))))
+	return copyToClipboard(yanked)
+}
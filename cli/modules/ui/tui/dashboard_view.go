@@ -0,0 +1,484 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dashboardController is the submodel of the Dashboard view
+type dashboardController struct{}
+
+// newDashboardController creates the Dashboard view controller
+func newDashboardController() *dashboardController {
+	return &dashboardController{}
+}
+
+// Init implements ViewController
+func (c *dashboardController) Init(m *Model) tea.Cmd {
+	return nil
+}
+
+// Menu implements ViewController (the project list is index-based)
+func (c *dashboardController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	return nil
+}
+
+// Keys implements ViewController
+func (c *dashboardController) Keys(m *Model) []KeyHint {
+	hints := []KeyHint{
+		{"b", "build"},
+		{"r", "run"},
+		{"s", "stop"},
+		{"p", "pause"},
+		{"k", "kill"},
+		{"l", "logs"},
+		{"f", "favorite"},
+	}
+	// Show AI shortcut if Claude is installed
+	if m.state.Claude != nil && m.state.Claude.IsInstalled {
+		hints = append(hints, KeyHint{"a", "ai"})
+	}
+	return hints
+}
+
+// Update implements ViewController
+func (c *dashboardController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case itemCountMsg:
+		if m.state.Dashboard != nil {
+			m.maxMainItems = len(m.state.Dashboard.Projects)
+		}
+	case tea.KeyMsg:
+		key := msg.String()
+		if key == "[" || key == "]" {
+			return c.moveProject(m, key), true
+		}
+		if cmd, handled := m.handleProjectOrderKey(key); handled {
+			return cmd, true
+		}
+		return m.handleComponentKey(key)
+	}
+	return nil, false
+}
+
+// moveProject moves the selected project up or down within its group, the
+// list selection following it (the Projects tree keeps it by itself)
+func (c *dashboardController) moveProject(m *Model, key string) tea.Cmd {
+	step := 1
+	if key == "[" {
+		step = -1
+	}
+	cmd := m.moveSelectedProject(step)
+	if cmd == nil {
+		return nil
+	}
+	projects := core.SelectProjects(m.state)
+	target := m.mainIndex + step
+	if target >= 0 && target < len(projects) && m.mainIndex < len(projects) &&
+		projects[target].Favorite == projects[m.mainIndex].Favorite {
+		m.mainIndex = target
+	}
+	return cmd
+}
+
+// View implements ViewController
+func (c *dashboardController) View(m *Model, width, height int) string {
+	m.syncLogFollow()
+	return m.renderDashboard(width, height)
+}
+
+// selection implements selectionView
+func (c *dashboardController) selection(m *Model) viewSelection {
+	projects := core.SelectProjects(m.state)
+	if m.mainIndex >= 0 && m.mainIndex < len(projects) {
+		return viewSelection{projectID: projects[m.mainIndex].ID, self: projects[m.mainIndex].IsSelf}
+	}
+	return viewSelection{}
+}
+
+// yank implements yankView
+func (c *dashboardController) yank(m *Model) tea.Cmd {
+	return m.yankSelectedProject()
+}
+
+// contextMenu implements contextMenuView
+func (c *dashboardController) contextMenu(m *Model) *contextMenu {
+	return m.projectContextMenu(favoriteAction(m.findProjectVM(m.getSelectedProjectID())))
+}
+
+// renderDashboard renders the dashboard view with split panes
+func (m *Model) renderDashboard(width, height int) string {
+	vm := m.state.Dashboard
+	if vm == nil {
+		return m.renderLoading()
+	}
+
+	// Vulnerability badge color follows the highest severity found
+	vulnColor := ColorSuccess
+	if vm.VulnCount > 0 {
+		vulnColor = severityColor(vm.VulnSeverity)
+	}
+
+	diskUsage := vm.DiskUsage
+	if diskUsage == "" {
+		diskUsage = "-"
+	}
+
+	// Narrow: stats on one line, the 4 panels stacked at full width
+	if width < stackedLayoutWidth {
+		stat := func(label, value string, color lipgloss.Color) string {
+			return lipgloss.NewStyle().Foreground(color).Bold(true).Render(value) + SubtitleStyle.Render(" "+label+"  ")
+		}
+		stats := truncateANSI(stat("projects", fmt.Sprintf("%d", vm.ProjectCount), ColorSecondary)+
+			stat("running", fmt.Sprintf("%d", vm.RunningCount), ColorSuccess)+
+			stat("building", fmt.Sprintf("%d", vm.BuildingCount), ColorWarning)+
+			stat("errors", fmt.Sprintf("%d", vm.ErrorCount), ColorError)+
+			stat("vulns", fmt.Sprintf("%d", vm.VulnCount), vulnColor)+
+			stat("disk", diskUsage, ColorInfo), width-2)
+
+		// TLS certificates to renew, on their own line
+		if warning := renderCertWarnings(vm.CertWarnings, width-2); warning != "" {
+			stats += "\n" + warning
+			height--
+		}
+
+		// 4 panels × 2 border lines
+		panelWidth := width - 4
+		panelHeight := height - 1 - 8
+		quarterHeight := panelHeight / 4
+		return lipgloss.JoinVertical(lipgloss.Left,
+			stats,
+			m.renderProjectsList(vm.Projects, panelWidth, quarterHeight, m.focusArea == FocusMain),
+			m.renderProcessesList(vm.RunningProcesses, panelWidth, quarterHeight, false),
+			m.renderMiniGit(vm.GitSummary, panelWidth, quarterHeight),
+			m.renderMiniLogs(panelWidth, panelHeight-3*quarterHeight),
+		)
+	}
+
+	// Stats row (compact) - divide width by 6 for each box, accounting for gaps
+	numStats := 6
+	totalGaps := (numStats - 1) * GapHorizontal
+	statBoxWidth := (width - totalGaps) / numStats
+	gap := strings.Repeat(" ", GapHorizontal)
+	stats := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		m.renderStatBox("Projects", fmt.Sprintf("%d", vm.ProjectCount), ColorSecondary, statBoxWidth),
+		gap,
+		m.renderStatBox("Running", fmt.Sprintf("%d", vm.RunningCount), ColorSuccess, statBoxWidth),
+		gap,
+		m.renderStatBox("Building", fmt.Sprintf("%d", vm.BuildingCount), ColorWarning, statBoxWidth),
+		gap,
+		m.renderStatBox("Errors", fmt.Sprintf("%d", vm.ErrorCount), ColorError, statBoxWidth),
+		gap,
+		m.renderStatBox("Vulns", fmt.Sprintf("%d", vm.VulnCount), vulnColor, statBoxWidth),
+		gap,
+		m.renderStatBox("Disk", diskUsage, ColorInfo, statBoxWidth),
+	)
+
+	// Calculate panel sizes
+	// Stats: 4 lines (2 content + 2 border)
+	// Left: 3 stacked panels × 2 border lines = 6
+	statsHeight := 4
+	panelBorders := 6
+
+
+	// Width: simple split (1/3 left, 2/3 right)
+	// 3 panels × 2 border chars = 6
+	widthBorders := 6
+	availableWidth := width - widthBorders - GapHorizontal
+	leftWidth := availableWidth / 3
+	if leftWidth < 30 {
+		leftWidth = 30
+	}
+	rightWidth := availableWidth - leftWidth
+
+	// TLS certificates to renew, below the stats
+	if warning := renderCertWarnings(vm.CertWarnings, width); warning != "" {
+		stats = lipgloss.JoinVertical(lipgloss.Left, stats, warning)
+		statsHeight++
+	}
+
+	// Height for left side (3 stacked panels)
+	panelHeight := height - statsHeight - panelBorders
+
+	// Left: 3 panels, distribute height
+	thirdHeight := panelHeight / 3
+	lastPanelHeight := panelHeight - (thirdHeight * 2)
+
+	projectsPanel := m.renderProjectsList(vm.Projects, leftWidth, thirdHeight, m.focusArea == FocusMain)
+	processesPanel := m.renderProcessesList(vm.RunningProcesses, leftWidth, thirdHeight, false)
+	gitPanel := m.renderMiniGit(vm.GitSummary, leftWidth, lastPanelHeight)
+
+	// Stack left panels
+	leftPane := lipgloss.JoinVertical(lipgloss.Left, projectsPanel, processesPanel, gitPanel)
+
+	// Right: Logs - add back border difference (6 - 2 = 4) so both sides align
+	logsHeight := panelHeight + 4
+	logsPanel := m.renderMiniLogs(rightWidth, logsHeight)
+
+	// Combine with horizontal gap
+	panels := lipgloss.JoinHorizontal(lipgloss.Top,
+		leftPane,
+		gap,
+		logsPanel,
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		stats,
+		panels,
+	)
+}
+
+// renderMiniGit renders a compact git status panel for dashboard
+func (m *Model) renderMiniGit(gitSummary []core.GitStatusVM, width, height int) string {
+	header := SubtitleStyle.Render("─ Git Changes ─")
+
+	// Show loading state until the first repositories are scanned
+	if m.state.GitLoading && len(gitSummary) == 0 {
+		loadingMsg := lipgloss.NewStyle().Foreground(ColorWarning).Render(
+			"  " + m.spinner.View() + " Loading git status...",
+		)
+		return UnfocusedBorderStyle.Width(width).Height(height).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, loadingMsg),
+		)
+	}
+
+	var rows []string
+	for _, g := range gitSummary {
+		if g.IsClean {
+			continue // Skip clean repos
+		}
+
+		// Count changes
+		changes := len(g.Modified) + len(g.Untracked) + len(g.Staged) + len(g.Deleted)
+		if changes == 0 {
+			continue
+		}
+
+		// Format: projectName M:x U:y S:z
+		var parts []string
+		if len(g.Modified) > 0 {
+			parts = append(parts, StatusWarning.Render(fmt.Sprintf("M:%d", len(g.Modified))))
+		}
+		if len(g.Untracked) > 0 {
+			parts = append(parts, SubtitleStyle.Render(fmt.Sprintf("?:%d", len(g.Untracked))))
+		}
+		if len(g.Staged) > 0 {
+			parts = append(parts, StatusSuccess.Render(fmt.Sprintf("S:%d", len(g.Staged))))
+		}
+		if len(g.Deleted) > 0 {
+			parts = append(parts, StatusError.Render(fmt.Sprintf("D:%d", len(g.Deleted))))
+		}
+
+		row := fmt.Sprintf("  %-12s %s", truncate(g.ProjectName, 12), strings.Join(parts, " "))
+		rows = append(rows, row)
+
+		if len(rows) >= height-3 {
+			rows = append(rows, SubtitleStyle.Render(fmt.Sprintf("  ... and %d more", len(gitSummary)-len(rows))))
+			break
+		}
+	}
+
+	if len(rows) == 0 {
+		rows = append(rows, SubtitleStyle.Render("  All clean ✓"))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
+
+	return UnfocusedBorderStyle.Width(width).Height(height).Render(
+		lipgloss.JoinVertical(lipgloss.Left, header, content),
+	)
+}
+
+// renderMiniLogs renders a compact logs panel for dashboard (run logs only)
+func (m *Model) renderMiniLogs(width, height int) string {
+	header := SubtitleStyle.Render("─ Run Logs ─")
+
+	// Inner width accounting for border (2 chars total)
+	innerWidth := width - 2
+
+	var lines []string
+	if m.state.Logs != nil && len(m.state.Logs.Lines) > 0 {
+		maxLines := height - 4 // header + border
+
+		// Filter to only show run logs (not build logs)
+		// Build logs have source like "build:project/component"
+		// Only the last N lines that fit are collected
+		runLogs := tailLogLines(m.state.Logs.Lines, maxLines, func(line *core.LogLineVM) bool {
+			return !strings.HasPrefix(line.Source, "build:")
+		})
+		start := 0
+
+		// Calculate max source width from visible logs
+		maxSourceLen := 12 // minimum width
+		for _, line := range runLogs[start:] {
+			if len(line.Source) > maxSourceLen {
+				maxSourceLen = len(line.Source)
+			}
+		}
+		// Cap at reasonable max
+		if maxSourceLen > 20 {
+			maxSourceLen = 20
+		}
+
+		for _, line := range runLogs[start:] {
+			// Compact format: [source] message - show full source name
+			var levelStyle lipgloss.Style
+			switch line.Level {
+			case "error":
+				levelStyle = LogErrorStyle
+			case "warn":
+				levelStyle = LogWarnStyle
+			default:
+				levelStyle = LogInfoStyle
+			}
+			sourceWidth := maxSourceLen + 2 // for brackets
+			msgWidth := innerWidth - sourceWidth - 2
+			if msgWidth < 20 {
+				msgWidth = 20
+			}
+			logLine := fmt.Sprintf("%s %s",
+				LogSourceStyle.Render(fmt.Sprintf("[%-*s]", maxSourceLen, line.Source)),
+				levelStyle.Render(truncate(line.Message, msgWidth)))
+			lines = append(lines, logLine)
+		}
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, SubtitleStyle.Render("  No recent logs"))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+
+	return UnfocusedBorderStyle.Width(width).Height(height).Render(
+		lipgloss.JoinVertical(lipgloss.Left, header, content),
+	)
+}
+
+// renderStatBox renders a stat box
+func (m *Model) renderStatBox(label, value string, color lipgloss.Color, boxWidth int) string {
+	// Account for border (2) and padding (4) and margin (2)
+	contentWidth := boxWidth - 8
+	if contentWidth < 5 {
+		contentWidth = 5
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(color).
+		Padding(0, 2).
+		Width(contentWidth).
+		Render(
+			lipgloss.JoinVertical(lipgloss.Center,
+				lipgloss.NewStyle().Foreground(color).Bold(true).Width(contentWidth).Align(lipgloss.Center).Render(value),
+				SubtitleStyle.Width(contentWidth).Align(lipgloss.Center).Render(label),
+			),
+		)
+}
+
+// renderProjectsList renders a list of projects
+func (m *Model) renderProjectsList(projects []core.ProjectVM, width, height int, focused bool) string {
+	title := "─ Projects ─"
+	if label := m.projectOrderLabel(); label != "" {
+		title = "─ Projects (" + label + ") ─"
+	}
+	header := SubtitleStyle.Render(title)
+
+	var rows []string
+	for i, p := range projects {
+		if i >= height-3 {
+			rows = append(rows, SubtitleStyle.Render(fmt.Sprintf("  ... and %d more", len(projects)-i)))
+			break
+		}
+
+		status := StatusIcon(m.getProjectStatus(p))
+		git := ""
+		if p.GitDirty {
+			git = GitDirtyStyle.Render(" *")
+		}
+
+		// Check if path exists
+		pathWarning := ""
+		if _, err := os.Stat(p.Path); os.IsNotExist(err) {
+			pathWarning = StatusError.Render(" " + IconWarning)
+		}
+
+		name := truncate(p.Name, width-10)
+		if p.Favorite {
+			name = StatusWarning.Render("★") + " " + truncate(p.Name, width-12)
+		}
+		row := fmt.Sprintf("%s %s%s%s", status, name, pathWarning, git)
+
+		if i == m.mainIndex && focused {
+			row = TableRowSelectedStyle.Width(width - 4).Render(FocusIndicator + " " + row)
+		} else if i == m.mainIndex {
+			row = TableRowSelectedStyle.Width(width - 4).Render("› " + row)
+		} else {
+			row = "  " + row
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		rows = append(rows, SubtitleStyle.Render("  No projects"))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
+
+	var style lipgloss.Style
+	if focused {
+		style = FocusedBorderStyle
+	} else {
+		style = UnfocusedBorderStyle
+	}
+
+	return style.Width(width).Height(height).Render(
+		lipgloss.JoinVertical(lipgloss.Left, header, content),
+	)
+}
+
+// renderProcessesList renders a list of processes
+func (m *Model) renderProcessesList(processes []core.ProcessVM, width, height int, focused bool) string {
+	header := SubtitleStyle.Render("─ Processes ─")
+
+	var rows []string
+	for i, p := range processes {
+		if i >= height-3 {
+			rows = append(rows, SubtitleStyle.Render(fmt.Sprintf("  ... and %d more", len(processes)-i)))
+			break
+		}
+
+		// Show paused state
+		var stateIcon string
+		if p.State == "paused" {
+			stateIcon = StatusWarning.Render("⏸")
+		} else {
+			stateIcon = StatusRunning.Render(IconRunning)
+		}
+		row := fmt.Sprintf("%s %s/%s", stateIcon, truncate(p.ProjectName, 12), p.Component)
+		rows = append(rows, "  "+row)
+	}
+
+	if len(rows) == 0 {
+		rows = append(rows, SubtitleStyle.Render("  No running processes"))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
+
+	var style lipgloss.Style
+	if focused {
+		style = FocusedBorderStyle
+	} else {
+		style = UnfocusedBorderStyle
+	}
+
+	return style.Width(width).Height(height).Render(
+		lipgloss.JoinVertical(lipgloss.Left, header, content),
+	)
+}
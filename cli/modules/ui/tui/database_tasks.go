@@ -0,0 +1,232 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Confirmation dialogs of the database tasks
+const (
+	dialogDatabaseReset = "db_reset"
+	dialogDatabaseSeed  = "db_seed"
+)
+
+// databaseTaskPanel is the overlay showing the output of the reset or seed
+// command of a database ("z" / "e" in the Database view)
+type databaseTaskPanel struct {
+	scroll int  // First visible line
+	height int  // Visible lines (set at render)
+	follow bool // Keep the last lines visible as output arrives
+}
+
+// selectedDatabase returns the selected database of the Database view (the
+// database of the selected session), nil if none
+func (m *Model) selectedDatabase() *core.DatabaseInfoVM {
+	id := m.selectedDatabaseID()
+	if id == "" || m.state.Database == nil {
+		return nil
+	}
+	for i := range m.state.Database.Databases {
+		if m.state.Database.Databases[i].ID == id {
+			return &m.state.Database.Databases[i]
+		}
+	}
+	return nil
+}
+
+// databaseTaskRunning returns true while a reset or seed command runs
+func (m *Model) databaseTaskRunning() bool {
+	return m.state.Database != nil && m.state.Database.Task != nil && m.state.Database.Task.State == "running"
+}
+
+// confirmDatabaseTask asks for confirmation before resetting or seeding the
+// selected database with the project's command. While a command runs, its
+// output is shown instead.
+func (m *Model) confirmDatabaseTask(action string) tea.Cmd {
+	if m.databaseTaskRunning() {
+		m.openDatabaseTask()
+		return nil
+	}
+	db := m.selectedDatabase()
+	if db == nil {
+		m.lastError = "Select a database to " + action
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	command, dialogType, verb := db.ResetCommand, dialogDatabaseReset, "Reset"
+	if action == core.DatabaseTaskSeed {
+		command, dialogType, verb = db.SeedCommand, dialogDatabaseSeed, "Seed"
+	}
+	if command == "" {
+		m.lastError = fmt.Sprintf("No database.%s command in the config of %s", action, db.ProjectName)
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	m.databaseView().pendingTask = action
+	m.databaseView().pendingDatabaseID = db.ID
+	m.dialogConfirm = false
+	return m.openConfirmDialog(config.ConfirmDatabaseReset, dialogType,
+		fmt.Sprintf("%s %s (%s) with: %s ?", verb, db.DatabaseName, db.ProjectName, truncate(command, 60)))
+}
+
+// startDatabaseTask runs the confirmed reset or seed command and shows its
+// output
+func (m *Model) startDatabaseTask() tea.Cmd {
+	action, databaseID := m.databaseView().pendingTask, m.databaseView().pendingDatabaseID
+	m.databaseView().pendingTask = ""
+	m.databaseView().pendingDatabaseID = ""
+	if action == "" || databaseID == "" {
+		return nil
+	}
+	eventType := core.EventDatabaseReset
+	if action == core.DatabaseTaskSeed {
+		eventType = core.EventDatabaseSeed
+	}
+	m.openDatabaseTask()
+	return m.sendEvent(core.NewEvent(eventType).WithTarget(databaseID))
+}
+
+// openDatabaseTask opens the output panel of the database tasks
+func (m *Model) openDatabaseTask() {
+	m.databaseTask = &databaseTaskPanel{follow: true}
+}
+
+// handleDatabaseTaskKey handles the keys of the output panel: scroll, cancel,
+// copy, close (the command keeps running)
+func (m *Model) handleDatabaseTaskKey(msg tea.KeyMsg) tea.Cmd {
+	p := m.databaseTask
+	switch msg.String() {
+	case "esc", "q":
+		m.databaseTask = nil
+	case "up", "k":
+		p.scroll = max(p.scroll-1, 0)
+		p.follow = false
+	case "down", "j":
+		p.scroll++
+	case "pgup", "shift+up":
+		p.scroll = max(p.scroll-p.height, 0)
+		p.follow = false
+	case "pgdown", "shift+down":
+		p.scroll += p.height
+	case "G", "end":
+		p.follow = true
+	case "c", "ctrl+c":
+		if m.databaseTaskRunning() {
+			task := m.state.Database.Task
+			return m.sendEvent(core.NewEvent(core.EventCancelCommand).WithProject(task.ProjectID).
+				WithComponent(projects.ComponentType(core.DatabaseTaskComponent)))
+		}
+	case "y":
+		if m.state.Database != nil && m.state.Database.Task != nil && len(m.state.Database.Task.Output) > 0 {
+			return m.yankText("database "+m.state.Database.Task.Action+" output", strings.Join(m.state.Database.Task.Output, "\n"))
+		}
+	}
+	return nil
+}
+
+// databaseTaskStatus renders the state of a database task
+func (m *Model) databaseTaskStatus(task *core.DatabaseTaskVM) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	switch task.State {
+	case "running":
+		return StatusWarning.Render(m.spinner.View()+" running") + mutedStyle.Render(" "+formatDuration(task.StartedAt, time.Now()))
+	case "success":
+		return StatusSuccess.Render("✓ done") + mutedStyle.Render(" in "+formatDuration(task.StartedAt, task.FinishedAt))
+	case "failed":
+		status := fmt.Sprintf("✗ failed (exit %d)", task.ExitCode)
+		if task.Error != "" {
+			status += ": " + task.Error
+		}
+		return StatusError.Render(status)
+	}
+	return StatusWarning.Render("canceled")
+}
+
+// renderDatabaseTaskOverlay renders the output panel of the database tasks
+func (m *Model) renderDatabaseTaskOverlay(width, height int) string {
+	p := m.databaseTask
+	boxWidth := min(width-4, 130)
+	innerWidth := boxWidth - 6 // Border and padding
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	title := "Database"
+	var header, body []string
+	var task *core.DatabaseTaskVM
+	if m.state.Database != nil {
+		task = m.state.Database.Task
+	}
+	if task == nil {
+		body = append(body, SubtitleStyle.Render("Starting..."))
+	} else {
+		title = fmt.Sprintf("Database %s: %s", task.Action, task.DatabaseName)
+		header = append(header,
+			"$ "+task.Command,
+			mutedStyle.Render("in "+task.Dir),
+			m.databaseTaskStatus(task),
+			"",
+		)
+		body = append(body, task.Output...)
+		if len(body) == 0 {
+			body = append(body, mutedStyle.Render("No output yet"))
+		}
+	}
+
+	p.height = max(height-8-len(header), 3)
+	if p.follow {
+		p.scroll = len(body)
+	}
+	p.scroll = min(p.scroll, max(len(body)-p.height, 0))
+	if p.scroll == max(len(body)-p.height, 0) {
+		p.follow = true // Back at the end
+	}
+
+	lines := []string{DialogTitleStyle.MarginBottom(0).Render(truncate(title, innerWidth)), ""}
+	for _, line := range header {
+		lines = append(lines, truncateANSI(line, innerWidth))
+	}
+	for _, line := range body[p.scroll:min(p.scroll+p.height, len(body))] {
+		lines = append(lines, truncateANSI(line, innerWidth))
+	}
+	hints := []KeyHint{{"↑↓", "scroll"}}
+	if m.databaseTaskRunning() {
+		hints = append(hints, KeyHint{"c", "cancel"})
+	}
+	hints = append(hints, KeyHint{"y", "copy output"}, KeyHint{"Esc", "close"})
+	lines = append(lines, "", strings.Join(renderKeyHints(hints), ""))
+
+	box := DialogStyle.Padding(0, 2).Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Top,
+		lipgloss.NewStyle().MarginTop(1).Render(box),
+	)
+}
+
+// databaseContextMenu returns the actions of the selected database
+func (m *Model) databaseContextMenu() *contextMenu {
+	db := m.selectedDatabase()
+	if db == nil {
+		return nil
+	}
+	menu := &contextMenu{title: db.DatabaseName + " (" + db.ProjectName + ")", actions: []contextAction{
+		{"v", "Migrations", (*Model).openMigrations},
+	}}
+	if db.ResetCommand != "" {
+		menu.actions = append(menu.actions, contextAction{"z", "Reset database", func(m *Model) tea.Cmd {
+			return m.confirmDatabaseTask(core.DatabaseTaskReset)
+		}})
+	}
+	if db.SeedCommand != "" {
+		menu.actions = append(menu.actions, contextAction{"e", "Seed database", func(m *Model) tea.Cmd {
+			return m.confirmDatabaseTask(core.DatabaseTaskSeed)
+		}})
+	}
+	return menu
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"csd-devtrack/cli/modules/ui/core"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -22,9 +24,189 @@ type databaseTreeItem struct {
 	DatabaseID string // Database ID (empty for projects)
 }
 
+// databaseController is the submodel of the Database view
+type databaseController struct {
+	activeSession     string    // Active database session ID
+	treeMenu          *TreeMenu // Tree menu for database/sessions panel
+	filterProject     string    // Filter by project ID
+	pendingMigration  string    // Migration action awaiting confirmation
+	pendingTask       string    // Database reset or seed awaiting confirmation
+	pendingDatabaseID string    // Database of the pending reset or seed
+}
+
+// newDatabaseController creates the Database view controller
+func newDatabaseController() *databaseController {
+	menu := NewTreeMenu(nil)
+	menu.SetTitle("Databases")
+	menu.SetRightSidePanel(true)
+	return &databaseController{treeMenu: menu}
+}
+
+// databaseView returns the Database view submodel
+func (m *Model) databaseView() *databaseController {
+	return m.controllers[core.VMDatabase].(*databaseController)
+}
+
+// Init implements ViewController
+func (c *databaseController) Init(m *Model) tea.Cmd {
+	// Update TreeMenu with current data
+	m.updateDatabaseMenu()
+	// Default focus to sessions panel so user can select/create a session
+	if c.activeSession == "" {
+		m.focusArea = FocusDetail
+	}
+	return nil
+}
+
+// Menu implements ViewController (the databases are in the detail panel)
+func (c *databaseController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	if focus != FocusDetail {
+		return nil
+	}
+	return c.treeMenu
+}
+
+// Keys implements ViewController
+func (c *databaseController) Keys(m *Model) []KeyHint {
+	switch {
+	case m.terminalMode:
+		return []KeyHint{
+			{"^G Esc", "exit"},
+			{"PgUp/Dn", "scroll"},
+			{"^G [", "copy/search"},
+		}
+	case m.focusArea == FocusDetail:
+		return []KeyHint{
+			{"n", "new"},
+			{"Enter", "connect"},
+			{"r", "rename"},
+			{"x", "delete"},
+			{"s", "stop"},
+			{"v", "migrations"},
+			{"z", "reset"},
+			{"e", "seed"},
+		}
+	}
+	return []KeyHint{
+		{"Enter", "terminal"},
+		{"Tab", "databases"},
+	}
+}
+
+// Update implements ViewController
+func (c *databaseController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case viewOpenedMsg:
+		m.updateDatabaseMenu()
+	case stateUpdateMsg:
+		// Update Database menu for navigation
+		if m.viewOpened(core.VMDatabase) {
+			m.updateDatabaseMenu()
+		}
+	case detailSelectMsg:
+		// Use TreeMenu to select/drill-down
+		if item := c.treeMenu.Select(); item != nil {
+			// Leaf item selected (database) - connect directly
+			if _, isDB := item.Data.(core.DatabaseInfoVM); isDB {
+				dbID := strings.TrimPrefix(item.ID, "db:")
+				return m.connectToDatabase(dbID), true
+			}
+		}
+		// If Select() returned nil, it drilled down - nothing more to do
+		return nil, true
+	case dialogConfirmMsg:
+		switch msg.dialogType {
+		case "migrate":
+			return m.startMigration(), true
+		case dialogDatabaseReset, dialogDatabaseSeed:
+			return m.startDatabaseTask(), true
+		}
+	case tea.KeyMsg:
+		return c.handleKey(m, msg.String())
+	}
+	return nil, false
+}
+
+// View implements ViewController
+func (c *databaseController) View(m *Model, width, height int) string {
+	return m.renderDatabase(width, height)
+}
+
+// hasDetailPanel implements detailPanelView
+func (c *databaseController) hasDetailPanel() bool {
+	return true
+}
+
+// terminalSession implements terminalView (the client of the selected database)
+func (c *databaseController) terminalSession() string {
+	return c.activeSession
+}
+
+// contextMenu implements contextMenuView
+func (c *databaseController) contextMenu(m *Model) *contextMenu {
+	return m.databaseContextMenu()
+}
+
+// handleKey handles the action keys of the terminal and databases panels
+func (c *databaseController) handleKey(m *Model, key string) (tea.Cmd, bool) {
+	switch key {
+	case "v":
+		// Migrations of the selected database
+		return m.openMigrations(), true
+	case "z":
+		// Reset the selected database (project's database.reset command)
+		return m.confirmDatabaseTask(core.DatabaseTaskReset), true
+	case "e":
+		// Seed the selected database (project's database.seed command)
+		return m.confirmDatabaseTask(core.DatabaseTaskSeed), true
+	case "d":
+		// Disconnect database terminal
+		if c.activeSession != "" {
+			return m.stopDatabaseTerminal(c.activeSession), true
+		}
+		return nil, true
+	case "enter":
+		// Enter terminal mode when focused on terminal panel
+		if m.focusArea == FocusMain && c.activeSession != "" {
+			if t := m.terminalManager.Get(c.activeSession); t != nil && t.IsRunning() {
+				m.terminalMode = true
+				m.claudeView().inputActive = false
+				m.claudeView().renameActive = false
+				m.commandMode = false
+			}
+		}
+		return nil, true
+	case "esc":
+		// Exit terminal mode or switch focus
+		if m.terminalMode {
+			m.terminalMode = false
+			return nil, true
+		}
+		if m.focusArea == FocusDetail {
+			m.focusArea = FocusMain
+			return nil, true
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// hasDatabases returns true if databases were found in the projects, or are
+// not detected yet (they are detected when the view is first opened)
+func (m *Model) hasDatabases() bool {
+	if m.state.Database == nil {
+		return false
+	}
+	return !m.state.Database.Loaded || len(m.state.Database.Databases) > 0
+}
+
 // renderDatabase renders the Database view
 // Layout: Terminal on left (70%), Sessions panel on right (30%)
 func (m *Model) renderDatabase(width, height int) string {
+	if m.state.Database != nil && !m.state.Database.Loaded {
+		return m.renderLoading()
+	}
+
 	// Check if database service found any databases
 	if m.state.Database == nil || len(m.state.Database.Databases) == 0 {
 		return m.renderDatabaseNoDatabases(width, height)
@@ -39,7 +221,7 @@ func (m *Model) renderDatabase(width, height int) string {
 	availableWidth := width - widthBorders - GapHorizontal
 
 	// Calculate sessions panel width using TreeMenu
-	sessionsWidth := m.databaseTreeMenu.CalcWidth()
+	sessionsWidth := m.databaseView().treeMenu.CalcWidth()
 	termWidth := availableWidth - sessionsWidth
 
 	// Session info takes some space at bottom
@@ -47,12 +229,12 @@ func (m *Model) renderDatabase(width, height int) string {
 	treeHeight := contentHeight - infoHeight
 
 	// Configure and render TreeMenu
-	m.databaseTreeMenu.SetSize(sessionsWidth, treeHeight)
-	m.databaseTreeMenu.SetFocused(m.focusArea == FocusDetail)
+	m.databaseView().treeMenu.SetSize(sessionsWidth, treeHeight)
+	m.databaseView().treeMenu.SetFocused(m.focusArea == FocusDetail)
 
 	// Terminal panel has only 1 panel (not 2 stacked like sessions), so add +2
 	termPanel := m.renderDatabaseTerminalPanel(termWidth, contentHeight+2)
-	treePanel := m.databaseTreeMenu.Render()
+	treePanel := m.databaseView().treeMenu.Render()
 	infoPanel := m.renderDatabaseSessionInfo(sessionsWidth, infoHeight)
 	sessionsPanel := lipgloss.JoinVertical(lipgloss.Left, treePanel, infoPanel)
 
@@ -68,8 +250,8 @@ func (m *Model) renderDatabaseSessionInfo(width, height int) string {
 	// Get selected item from TreeMenu
 	var sess *core.DatabaseSessionVM
 	var db *core.DatabaseInfoVM
-	if m.databaseTreeMenu != nil {
-		if item := m.databaseTreeMenu.SelectedItem(); item != nil {
+	if m.databaseView().treeMenu != nil {
+		if item := m.databaseView().treeMenu.SelectedItem(); item != nil {
 			if s, ok := item.Data.(core.DatabaseSessionVM); ok {
 				sess = &s
 			} else if d, ok := item.Data.(core.DatabaseInfoVM); ok {
@@ -131,6 +313,12 @@ func (m *Model) renderDatabaseSessionInfo(width, height int) string {
 			lines = append(lines, mutedStyle.Render(fmt.Sprintf("User: %s", db.User)))
 		}
 		lines = append(lines, mutedStyle.Render(fmt.Sprintf("Source: %s", db.Source)))
+		// Migration state, once the migrations panel was opened on it
+		if vm := m.state.Database.Migrations; vm != nil && vm.DatabaseID == db.ID {
+			if summary := migrationsSummary(vm); summary != "" {
+				lines = append(lines, valueStyle.Render("Migrations: "+summary))
+			}
+		}
 	}
 
 	// Pad each line to exact width
@@ -190,8 +378,8 @@ func (m *Model) renderDatabaseNoDatabases(width, height int) string {
 // renderDatabaseTerminalPanel renders the main terminal area (terminal or placeholder)
 func (m *Model) renderDatabaseTerminalPanel(width, height int) string {
 	// Show terminal panel if there's an active session with a running terminal
-	if m.databaseActiveSession != "" && m.terminalManager != nil {
-		if t := m.terminalManager.Get(m.databaseActiveSession); t != nil && t.IsRunning() {
+	if m.databaseView().activeSession != "" && m.terminalManager != nil {
+		if t := m.terminalManager.Get(m.databaseView().activeSession); t != nil && t.IsRunning() {
 			return m.renderTerminalPanel(t, width, height)
 		}
 	}
@@ -203,8 +391,12 @@ func (m *Model) renderDatabaseTerminalPanel(width, height int) string {
 	}
 
 	var message string
-	if m.databaseActiveSession == "" {
+	if m.databaseView().activeSession == "" {
 		message = "Select a database and press 'n' to create a session"
+	} else if m.isSuspended(m.databaseView().activeSession) {
+		message = "Session suspended while idle\n\nPress Enter to resume"
+	} else if lost := m.lostTerminalMessage(m.databaseView().activeSession); lost != "" {
+		message = lost
 	} else {
 		message = "Press Enter to connect to database"
 	}
@@ -279,7 +471,7 @@ func (m *Model) buildDatabaseTreeItems() []TreeMenuItem {
 			iconColor := ColorMuted
 
 			// Show active state if this database has running terminal
-			if db.ID == m.databaseActiveSession {
+			if db.ID == m.databaseView().activeSession {
 				dbIcon = "●"
 				iconColor = ColorSuccess
 			}
@@ -289,10 +481,10 @@ func (m *Model) buildDatabaseTreeItems() []TreeMenuItem {
 				Label:     db.DatabaseName,
 				Icon:      dbIcon,
 				IconColor: iconColor,
-				IsActive:  db.ID == m.databaseActiveSession,
+				IsActive:  db.ID == m.databaseView().activeSession,
 				Data:      db,
 			}
-			projectItem.Children = append(projectItem.Children, dbItem)
+			projectItem.Children = append(projectItem.Children, m.withActivityBadge(dbItem, db.ID))
 		}
 
 		items = append(items, projectItem)
@@ -300,3 +492,138 @@ func (m *Model) buildDatabaseTreeItems() []TreeMenuItem {
 
 	return items
 }
+
+// updateDatabaseMenu updates the database TreeMenu with current database data
+func (m *Model) updateDatabaseMenu() {
+	if m.databaseView().treeMenu == nil || m.state.Database == nil {
+		return
+	}
+
+	items := m.buildDatabaseTreeItems()
+	m.databaseView().treeMenu.SetItems(items)
+}
+
+// connectToDatabase starts a terminal directly for a database config
+func (m *Model) connectToDatabase(databaseID string) tea.Cmd {
+	if m.terminalManager == nil || m.state.Database == nil {
+		return nil
+	}
+
+	// Find the database info
+	var db *core.DatabaseInfoVM
+	for i := range m.state.Database.Databases {
+		if m.state.Database.Databases[i].ID == databaseID {
+			db = &m.state.Database.Databases[i]
+			break
+		}
+	}
+
+	if db == nil {
+		m.lastError = "Database not found: " + databaseID
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	// Get the CLI command based on database type
+	cliCmd, cliArgs := m.getDatabaseCLICommand(db)
+	if cliCmd == "" {
+		m.lastError = "Unknown database type: " + db.Type
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	// Use database ID as the terminal session ID
+	m.databaseView().activeSession = databaseID
+
+	// Update tree immediately so IsActive is set correctly
+	m.updateDatabaseMenu()
+
+	// Switch focus to terminal
+	m.focusArea = FocusMain
+
+	// Get or create terminal
+	t := m.terminalManager.GetOrCreateWithCommand(databaseID, cliCmd, cliArgs)
+
+	// Size the terminal appropriately
+	headerHeight := 1
+	footerHeight := 1
+	sidebarWidth := m.sidebarWidth()
+	termWidth := m.width - sidebarWidth - 6
+	termHeight := m.height - headerHeight - footerHeight
+	if termWidth > 20 && termHeight > 5 {
+		t.SetSize(termWidth, termHeight)
+	}
+
+	// Start if not already running
+	if !t.IsRunning() {
+		if err := t.Start(databaseID); err != nil {
+			m.lastError = "Failed to start database CLI: " + err.Error()
+			m.lastErrorTime = time.Now()
+			return nil
+		}
+	}
+
+	// Enter terminal mode - reset conflicting input modes
+	m.terminalMode = true
+	m.claudeView().inputActive = false
+	m.claudeView().renameActive = false
+	m.commandMode = false
+
+	// Header event
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess, "Connected to "+db.DatabaseName))
+
+	return m.scheduleTerminalRefresh()
+}
+
+// stopDatabaseTerminal stops the database CLI terminal
+func (m *Model) stopDatabaseTerminal(databaseID string) tea.Cmd {
+	if m.terminalManager == nil {
+		return nil
+	}
+
+	t := m.terminalManager.Get(databaseID)
+	if t == nil {
+		return nil
+	}
+
+	// Stop the terminal
+	go t.Stop()
+
+	// Exit terminal mode if this was active
+	if m.databaseView().activeSession == databaseID && m.terminalMode {
+		m.terminalMode = false
+	}
+
+	// Clear active session
+	if m.databaseView().activeSession == databaseID {
+		m.databaseView().activeSession = ""
+		// Update tree immediately so IsActive is cleared
+		m.updateDatabaseMenu()
+	}
+
+	// Header event
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventInfo, "Database disconnected"))
+
+	return nil
+}
+
+// getDatabaseCLICommand returns the CLI command and args for a database type
+func (m *Model) getDatabaseCLICommand(db *core.DatabaseInfoVM) (string, []string) {
+	switch db.Type {
+	case "postgres":
+		// Build postgres URL from components
+		url := fmt.Sprintf("postgres://%s@%s:%d/%s", db.User, db.Host, db.Port, db.DatabaseName)
+		return "psql", []string{url}
+	case "mysql":
+		return "mysql", []string{
+			"-h", db.Host,
+			"-P", fmt.Sprintf("%d", db.Port),
+			"-u", db.User,
+			db.DatabaseName,
+		}
+	case "sqlite":
+		return "sqlite3", []string{db.DatabaseName}
+	default:
+		return "", nil
+	}
+}
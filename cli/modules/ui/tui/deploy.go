@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// findProjectVM returns the project view model with the given ID
+func (m *Model) findProjectVM(projectID string) *core.ProjectVM {
+	if m.state.Projects == nil {
+		return nil
+	}
+	for i := range m.state.Projects.Projects {
+		if m.state.Projects.Projects[i].ID == projectID {
+			return &m.state.Projects.Projects[i]
+		}
+	}
+	return nil
+}
+
+// lastDeployment returns the most recent deployment of a project target, or nil
+func (m *Model) lastDeployment(projectID, target string) *core.DeploymentVM {
+	if m.state.Projects == nil {
+		return nil
+	}
+	deployments := m.state.Projects.Deployments
+	for i := len(deployments) - 1; i >= 0; i-- {
+		d := &deployments[i]
+		if d.ProjectID == projectID && (target == "" || d.Target == target) {
+			return d
+		}
+	}
+	return nil
+}
+
+// openDeployDialog asks for the environment to deploy the selected project to.
+// Projects with a single deploy target go straight to the confirmation.
+func (m *Model) openDeployDialog() tea.Cmd {
+	proj := m.findProjectVM(m.getSelectedProjectID())
+	if proj == nil || m.blockReadOnly("deploy") {
+		return nil
+	}
+	if len(proj.DeployTargets) == 0 {
+		m.lastError = "No deploy target configured for " + proj.Name + " (projects[].deploy in config)"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	if len(proj.DeployTargets) == 1 {
+		return m.confirmDeploy(proj.ID, proj.DeployTargets[0])
+	}
+
+	// Default to the last deployed environment
+	target := proj.DeployTargets[0]
+	if last := m.lastDeployment(proj.ID, ""); last != nil {
+		target = last.Target
+	}
+
+	m.projectsView().pendingDeployProjectID = proj.ID
+	m.dialogType = "deploy_target"
+	m.dialogMessage = fmt.Sprintf("Deploy environment (%s):", strings.Join(proj.DeployTargets, ", "))
+	m.dialogInput.SetValue(target)
+	m.dialogInput.CursorEnd()
+	m.dialogInput.Focus()
+	m.dialogInputActive = true
+	m.showDialog = true
+	return m.dialogInput.Cursor.BlinkCmd()
+}
+
+// selectDeployTarget validates the environment entered in the deploy dialog
+func (m *Model) selectDeployTarget() tea.Cmd {
+	proj := m.findProjectVM(m.projectsView().pendingDeployProjectID)
+	m.projectsView().pendingDeployProjectID = ""
+	if proj == nil {
+		return nil
+	}
+
+	target := strings.TrimSpace(m.dialogInput.Value())
+	for _, t := range proj.DeployTargets {
+		if strings.EqualFold(t, target) {
+			return m.confirmDeploy(proj.ID, t)
+		}
+	}
+	m.lastError = fmt.Sprintf("Unknown deploy target: %s", target)
+	m.lastErrorTime = time.Now()
+	return nil
+}
+
+// confirmDeploy asks for confirmation before deploying a project target
+func (m *Model) confirmDeploy(projectID, target string) tea.Cmd {
+	name := projectID
+	if proj := m.findProjectVM(projectID); proj != nil {
+		name = proj.Name
+	}
+	m.projectsView().pendingDeployProjectID = projectID
+	m.projectsView().pendingDeployTarget = target
+	m.dialogConfirm = false
+	return m.openConfirmDialog(config.ConfirmDeploy, "deploy", fmt.Sprintf("Deploy %s to %s?", name, target))
+}
+
+// startDeploy starts the confirmed deployment
+func (m *Model) startDeploy() tea.Cmd {
+	projectID, target := m.projectsView().pendingDeployProjectID, m.projectsView().pendingDeployTarget
+	m.projectsView().pendingDeployProjectID = ""
+	m.projectsView().pendingDeployTarget = ""
+	if projectID == "" || target == "" {
+		return nil
+	}
+	return m.sendEvent(core.NewEvent(core.EventDeploy).WithProject(projectID).WithTarget(target))
+}
+
+// renderProjectDeployments renders the deploy targets of a project with the
+// status of their last deployment and their recent results (detail panel)
+func (m *Model) renderProjectDeployments(proj core.ProjectVM, width int) []string {
+	if len(proj.DeployTargets) == 0 || m.state.Projects == nil {
+		return nil
+	}
+
+	const historyShown = 5
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	lines := []string{"", SubtitleStyle.Render("Deploy targets:")}
+	for _, target := range proj.DeployTargets {
+		// Recent results, oldest first
+		var history []string
+		var last *core.DeploymentVM
+		deployments := m.state.Projects.Deployments
+		for i := len(deployments) - 1; i >= 0 && len(history) < historyShown; i-- {
+			d := &deployments[i]
+			if d.ProjectID != proj.ID || d.Target != target {
+				continue
+			}
+			if last == nil {
+				last = d
+			}
+			switch d.State {
+			case "success":
+				history = append([]string{StatusSuccess.Render("✓")}, history...)
+			case "failed":
+				history = append([]string{StatusError.Render("✗")}, history...)
+			default:
+				history = append([]string{mutedStyle.Render("•")}, history...)
+			}
+		}
+
+		line := "  " + target
+		switch {
+		case last == nil:
+			line += mutedStyle.Render("  never deployed")
+		case last.State == "running":
+			line += "  " + mutedStyle.Render(m.spinner.View()+" deploying "+formatDuration(last.StartedAt, time.Now()))
+		default:
+			line += "  " + strings.Join(history, "") + mutedStyle.Render(" "+formatRelativeTime(last.FinishedAt))
+		}
+		lines = append(lines, line)
+		if last != nil && last.State == "failed" && last.Error != "" {
+			lines = append(lines, "    "+StatusError.Render(truncate(last.Error, width-4)))
+		}
+	}
+	return lines
+}
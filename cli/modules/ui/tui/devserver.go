@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"csd-devtrack/cli/modules/core/processes"
+	"csd-devtrack/cli/modules/core/projects"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// componentDevServer returns the dev server of a project component, or nil
+func (m *Model) componentDevServer(projectID string, component projects.ComponentType) *processes.DevServer {
+	if m.state.Processes == nil {
+		return nil
+	}
+	for _, proc := range m.state.Processes.Processes {
+		if proc.ProjectID == projectID && proc.Component == component {
+			return proc.DevServer
+		}
+	}
+	return nil
+}
+
+// renderDevServer renders the URLs, HMR status and compile error of a dev
+// server (detail panels)
+func renderDevServer(devServer *processes.DevServer, width int) []string {
+	if devServer == nil {
+		return nil
+	}
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	title := "Dev server:"
+	if devServer.Tool != "" {
+		title = "Dev server (" + devServer.Tool + "):"
+	}
+	lines := []string{"", SubtitleStyle.Render(title)}
+	if devServer.LocalURL != "" {
+		lines = append(lines, "  Local:   "+hyperlink(browsableURL(devServer.LocalURL), truncate(devServer.LocalURL, width-11)))
+	}
+	if devServer.NetworkURL != "" {
+		lines = append(lines, "  Network: "+hyperlink(devServer.NetworkURL, truncate(devServer.NetworkURL, width-11)))
+	}
+
+	var hmr string
+	switch devServer.HMR {
+	case processes.HMRReady:
+		hmr = StatusSuccess.Render("✓ up to date")
+	case processes.HMRCompiling:
+		hmr = StatusWarning.Render("◐ compiling")
+	case processes.HMRError:
+		hmr = StatusError.Render("✗ compile error")
+	}
+	if hmr != "" {
+		if !devServer.UpdatedAt.IsZero() {
+			hmr += mutedStyle.Render(" " + formatRelativeTime(devServer.UpdatedAt))
+		}
+		lines = append(lines, "  HMR:     "+hmr)
+	}
+	if devServer.LastUpdate != "" && devServer.HMR == processes.HMRReady {
+		lines = append(lines, mutedStyle.Render("  Updated: ")+truncate(devServer.LastUpdate, width-11))
+	}
+	for _, line := range devServer.Errors {
+		lines = append(lines, "  "+StatusError.Render(truncate(line, width-2)))
+	}
+	return lines
+}
@@ -0,0 +1,196 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dryRunPanel is the overlay previewing what a build, run or stop would
+// execute ("." then b, r or s)
+type dryRunPanel struct {
+	action string
+	scroll int // First visible line
+	height int // Visible lines (set at render)
+}
+
+// armDryRun makes the next b, r or s key preview its action instead of
+// running it
+func (m *Model) armDryRun() tea.Cmd {
+	m.dryRunArmed = true
+	return nil
+}
+
+// handleDryRunModifier handles the key following ".": b, r or s opens the
+// dry run of the action, any other key cancels
+func (m *Model) handleDryRunModifier(key string) tea.Cmd {
+	m.dryRunArmed = false
+	switch key {
+	case "b":
+		return m.openDryRun(core.DryRunBuild)
+	case "r":
+		return m.openDryRun(core.DryRunRun)
+	case "s":
+		return m.openDryRun(core.DryRunStop)
+	}
+	return nil
+}
+
+// openDryRun asks the presenter what an action on the selected component
+// would execute and shows it in the preview panel
+func (m *Model) openDryRun(action string) tea.Cmd {
+	projectID := m.getSelectedProjectID()
+	if projectID == "" {
+		m.lastError = "No project selected"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	component := m.getSelectedComponent()
+	if component == "" && action != core.DryRunBuild {
+		m.lastError = "Select a component to preview its " + action
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	m.dryRun = &dryRunPanel{action: action}
+	if m.state.Projects != nil {
+		m.state.Projects.DryRun = nil // Planning until the preview arrives
+	}
+	return m.sendEvent(core.NewEvent(core.EventDryRun).WithTarget(action).WithProject(projectID).WithComponent(component))
+}
+
+// handleDryRunKey handles the keys of the preview panel: scroll, copy, close
+func (m *Model) handleDryRunKey(msg tea.KeyMsg) tea.Cmd {
+	p := m.dryRun
+	switch msg.String() {
+	case "esc", "q", ".":
+		m.dryRun = nil
+	case "up", "k":
+		p.scroll = max(p.scroll-1, 0)
+	case "down", "j":
+		p.scroll++
+	case "pgup", "shift+up":
+		p.scroll = max(p.scroll-p.height, 0)
+	case "pgdown", "shift+down":
+		p.scroll += p.height
+	case "y":
+		if dryRun := m.dryRunPreview(); dryRun != nil {
+			return m.yankText("dry run commands", dryRunScript(dryRun))
+		}
+	}
+	return nil
+}
+
+// dryRunPreview returns the preview of the open panel, nil while planning
+func (m *Model) dryRunPreview() *core.DryRunVM {
+	if m.state.Projects == nil || m.state.Projects.DryRun == nil || m.state.Projects.DryRun.Action != m.dryRun.action {
+		return nil
+	}
+	return m.state.Projects.DryRun
+}
+
+// dryRunScript returns the commands of a dry run as shell lines, to run them
+// by hand outside DevTrack
+func dryRunScript(dryRun *core.DryRunVM) string {
+	var lines []string
+	for _, c := range dryRun.Commands {
+		if c.Dir == "" {
+			continue // Not a command line (stop)
+		}
+		line := "(cd " + core.ShellQuote(c.Dir) + " && "
+		for _, env := range c.Env {
+			line += core.ShellQuote(env) + " "
+		}
+		lines = append(lines, line+c.Command+")")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// dryRunLines renders the body of a dry run
+func dryRunLines(dryRun *core.DryRunVM, width int) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	var lines []string
+	if dryRun.Error != "" {
+		lines = append(lines, StatusError.Render("✗ "+dryRun.Error), "")
+	}
+	for i, c := range dryRun.Commands {
+		lines = append(lines, HelpKeyStyle.Render(fmt.Sprintf("%d. %s", i+1, c.Step)))
+		if c.Dir == "" {
+			lines = append(lines, "   "+c.Command)
+		} else {
+			lines = append(lines, "   $ "+c.Command, mutedStyle.Render("   in ")+c.Dir)
+		}
+		if len(c.Env) > 0 {
+			lines = append(lines, mutedStyle.Render("   environment (on top of DevTrack's):"))
+			for _, env := range c.Env {
+				lines = append(lines, "     "+env)
+			}
+		}
+		if c.Warning != "" {
+			lines = append(lines, StatusWarning.Render("   ⚠ "+c.Warning))
+		}
+		lines = append(lines, "")
+	}
+	if dryRun.Error == "" && len(dryRun.Commands) == 0 && len(dryRun.Notes) == 0 {
+		lines = append(lines, mutedStyle.Render("Nothing would be executed"), "")
+	}
+
+	lines = append(lines, SubtitleStyle.Render("Hooks:"))
+	if len(dryRun.Hooks) == 0 {
+		lines = append(lines, mutedStyle.Render("   None"))
+	}
+	for _, h := range dryRun.Hooks {
+		lines = append(lines, HelpKeyStyle.Render("   "+h.Event)+"  $ "+h.Command, mutedStyle.Render("   in ")+h.Dir)
+	}
+	for _, note := range dryRun.Notes {
+		lines = append(lines, "", "• "+note)
+	}
+
+	for i, line := range lines {
+		lines[i] = truncateANSI(line, width)
+	}
+	return lines
+}
+
+// renderDryRunOverlay renders the dry run preview panel
+func (m *Model) renderDryRunOverlay(width, height int) string {
+	p := m.dryRun
+	boxWidth := min(width-4, 130)
+	innerWidth := boxWidth - 6 // Border and padding
+
+	title := "Dry run: " + p.action
+	var body []string
+	if dryRun := m.dryRunPreview(); dryRun == nil {
+		body = append(body, SubtitleStyle.Render("Planning..."))
+	} else {
+		target := dryRun.ProjectID
+		if dryRun.Component != "" {
+			target += "/" + dryRun.Component
+		}
+		title += " " + target
+		body = dryRunLines(dryRun, innerWidth)
+	}
+
+	p.height = max(height-8, 3)
+	p.scroll = min(p.scroll, max(len(body)-p.height, 0))
+	lines := []string{
+		DialogTitleStyle.MarginBottom(0).Render(truncate(title, innerWidth)),
+		SubtitleStyle.Render("Nothing is executed"),
+		"",
+	}
+	lines = append(lines, body[p.scroll:min(p.scroll+p.height, len(body))]...)
+	lines = append(lines, "", strings.Join(renderKeyHints([]KeyHint{
+		{"↑↓", "scroll"}, {"y", "copy commands"}, {"Esc", "close"},
+	}), ""))
+
+	box := DialogStyle.Padding(0, 2).Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Top,
+		lipgloss.NewStyle().MarginTop(1).Render(box),
+	)
+}
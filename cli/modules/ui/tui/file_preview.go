@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// File preview limits: larger files are previewed partially
+const (
+	previewMaxBytes = 256 * 1024
+	previewMaxLines = 2000
+)
+
+// filePreview is the read-only preview of a file selected in the Config browser
+type filePreview struct {
+	path   string
+	size   int64
+	lines  []string
+	syntax map[int][]syntaxSpan // Syntax colors by line (see highlightDiff)
+	note   string               // Why the content is partial or not shown
+	mark   int                  // Line to highlight (1-based, 0 = none)
+	scroll int                  // First visible line
+	height int                  // Visible lines (set on render)
+}
+
+// loadFilePreview reads the beginning of a file for the preview: text only,
+// tabs expanded, cut to the size limits
+func loadFilePreview(path string) filePreview {
+	preview := filePreview{path: path}
+
+	f, err := os.Open(path)
+	if err != nil {
+		preview.note = "Cannot read file: " + err.Error()
+		return preview
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil {
+		preview.size = info.Size()
+	}
+
+	data, err := io.ReadAll(io.LimitReader(f, previewMaxBytes))
+	if err != nil {
+		preview.note = "Cannot read file: " + err.Error()
+		return preview
+	}
+	// Same heuristic as git: a NUL byte in the first 8000 bytes means binary
+	if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+		preview.note = "Binary file, no preview"
+		return preview
+	}
+
+	text := strings.ToValidUTF8(string(data), "�")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\t", "    ")
+	preview.lines = strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+
+	if len(preview.lines) > previewMaxLines {
+		preview.lines = preview.lines[:previewMaxLines]
+		preview.note = fmt.Sprintf("First %d lines", previewMaxLines)
+	} else if preview.size > previewMaxBytes {
+		// The last line may be cut: drop it
+		preview.lines = preview.lines[:len(preview.lines)-1]
+		preview.note = fmt.Sprintf("First %s of %s", formatSize(previewMaxBytes), formatSize(preview.size))
+	}
+	preview.syntax = highlightDiff(path, preview.lines, 0, false)
+	return preview
+}
+
+// scrollBy moves the preview by n lines (negative = up), within the content
+func (p *filePreview) scrollBy(n int) {
+	p.scroll += n
+	if last := len(p.lines) - p.height; p.scroll > last {
+		p.scroll = last
+	}
+	if p.scroll < 0 {
+		p.scroll = 0
+	}
+}
+
+// selectedBrowserFile returns the file selected in the Config browser, nil if
+// the selection is a directory
+func (m *Model) selectedBrowserFile() *BrowserEntry {
+	if m.mainIndex < 0 || m.mainIndex >= len(m.configView().browserEntries) {
+		return nil
+	}
+	if entry := &m.configView().browserEntries[m.mainIndex]; !entry.IsDir {
+		return entry
+	}
+	return nil
+}
+
+// syncBrowserPreview loads the preview of the file selected in the Config
+// browser when the selection changes (checked on each render of the view)
+func (m *Model) syncBrowserPreview() {
+	if m.configView().mode != "browser" {
+		return
+	}
+	entry := m.selectedBrowserFile()
+	if entry == nil {
+		m.configView().browserPreview = nil
+		return
+	}
+	if m.configView().browserPreview == nil || m.configView().browserPreview.path != entry.Path {
+		preview := loadFilePreview(entry.Path)
+		m.configView().browserPreview = &preview
+	}
+}
+
+// renderFilePreview renders the preview of the selected file in a bordered
+// panel of the given outer size
+func (m *Model) renderFilePreview(width, height int) string {
+	return renderPreviewPanel(m.configView().browserPreview, width, height)
+}
+
+// renderPreviewPanel renders a file preview in a bordered panel of the given
+// outer size, its marked line highlighted
+func renderPreviewPanel(p *filePreview, width, height int) string {
+	innerWidth := width - 4 // Border and padding
+	visible := height - 4   // Border, title and info lines
+	if visible < 1 {
+		visible = 1
+	}
+	p.height = visible
+	p.scrollBy(0)
+
+	title := PanelTitleStyle.MarginBottom(0).Render(filepath.Base(p.path)) + " " +
+		SubtitleStyle.Render(formatSize(p.size))
+	lines := []string{truncateANSI(title, innerWidth)}
+
+	highlight := isSyntaxHighlightEnabled()
+	numberWidth := len(fmt.Sprint(len(p.lines)))
+	numberStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	textStyle := lipgloss.NewStyle().Foreground(ColorText)
+	end := min(p.scroll+visible, len(p.lines))
+	for i := p.scroll; i < end; i++ {
+		text := p.lines[i]
+		if highlight {
+			text = highlightLine(text, p.syntax[i], textStyle)
+		} else {
+			text = textStyle.Render(text)
+		}
+		number := numberStyle.Render(fmt.Sprintf("%*d ", numberWidth, i+1))
+		if i+1 == p.mark {
+			number = FocusIndicator + TableRowSelectedStyle.Render(fmt.Sprintf("%*d", numberWidth, i+1)) + " "
+		} else if p.mark > 0 {
+			number = " " + number
+		}
+		lines = append(lines, truncateANSI(number+text, innerWidth))
+	}
+
+	var info []string
+	if len(p.lines) > visible {
+		info = append(info, fmt.Sprintf("[%d-%d/%d lines] ^U/^D scroll", p.scroll+1, end, len(p.lines)))
+	}
+	if p.note != "" {
+		info = append(info, p.note)
+	}
+	if len(info) > 0 {
+		if len(lines) == 1 && len(p.lines) == 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, SubtitleStyle.Render(truncateANSI(strings.Join(info, "  "), innerWidth)))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBorder).
+		Padding(0, 1).
+		Width(width - 2).
+		Height(height - 2).
+		Render(strings.Join(lines, "\n"))
+}
+
+// formatSize formats a file size (512 B, 1.5 KB, 3.2 MB)
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value, suffix := float64(size)/unit, "KB"
+	for _, s := range []string{"MB", "GB", "TB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, s
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
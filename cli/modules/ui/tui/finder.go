@@ -0,0 +1,522 @@
+package tui
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// File finder limits
+const (
+	finderMaxFiles   = 20000 // Files indexed per project
+	finderMaxResults = 200   // Matches listed
+)
+
+// finderSkipDirs are the directories not walked in projects without git
+// (with git, .gitignore decides)
+var finderSkipDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "dist": true, "build": true,
+	"target": true, "__pycache__": true, "venv": true,
+}
+
+// finderFile is a file of a registered project
+type finderFile struct {
+	root    string // Project path
+	display string // Project name / path relative to the project
+	lower   string // Display path, ASCII lowercased (same offsets)
+	rel     int    // Start of the project-relative path in display
+	base    int    // Start of the file name in display
+}
+
+// path returns the absolute path of the file
+func (f finderFile) path() string {
+	return filepath.Join(f.root, filepath.FromSlash(f.display[f.rel:]))
+}
+
+// finderMatch is a file matching the query, with the matched positions
+type finderMatch struct {
+	file      *finderFile
+	score     int
+	positions []int // Byte offsets of the matched characters in display
+}
+
+// fileFinder is the fuzzy file finder overlay (Ctrl+T, ^G t) over the files
+// of all registered projects
+type fileFinder struct {
+	query    string
+	files    []finderFile
+	projects int
+	loading  bool
+	matches  []finderMatch
+	selected int
+	top      int // First visible match
+	height   int // Visible matches (set at render)
+
+	// History mode: git log of the selected file
+	history      []string
+	historyTitle string
+}
+
+// finderFilesMsg carries the files listed in the background
+type finderFilesMsg struct {
+	files    []finderFile
+	projects int
+}
+
+// finderHistoryMsg carries the git log of a file
+type finderHistoryMsg struct {
+	title string
+	lines []string
+}
+
+// finderEditorMsg is sent when the editor started from the finder exits
+type finderEditorMsg struct {
+	err error
+}
+
+// openFinder opens the file finder and lists the project files in the background
+func (m *Model) openFinder() tea.Cmd {
+	m.finder = &fileFinder{loading: true}
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		m.finder.loading = false
+		return nil
+	}
+	projects := cfg.Projects
+	return func() tea.Msg {
+		var files []finderFile
+		for _, p := range projects {
+			for _, rel := range listProjectFiles(p.Path) {
+				display := p.Name + "/" + rel
+				files = append(files, finderFile{
+					root:    p.Path,
+					display: display,
+					lower:   asciiLower(display),
+					rel:     len(p.Name) + 1,
+					base:    strings.LastIndexByte(display, '/') + 1,
+				})
+			}
+		}
+		return finderFilesMsg{files: files, projects: len(projects)}
+	}
+}
+
+// listProjectFiles lists the files of a project (slash-separated, relative):
+// tracked and untracked files not ignored by git, or a walk of the tree
+// outside git repositories
+func listProjectFiles(root string) []string {
+	cmd := exec.Command("git", "ls-files", "--cached", "--others", "--exclude-standard")
+	cmd.Dir = root
+	if output, err := cmd.Output(); err == nil {
+		files := strings.Split(strings.TrimSpace(string(output)), "\n")
+		if len(files) == 1 && files[0] == "" {
+			return nil
+		}
+		if len(files) > finderMaxFiles {
+			files = files[:finderMaxFiles]
+		}
+		return files
+	}
+
+	var files []string
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Unreadable entries are skipped
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(name, ".") || finderSkipDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(name, ".") {
+			return nil
+		}
+		if rel, err := filepath.Rel(root, path); err == nil {
+			files = append(files, filepath.ToSlash(rel))
+		}
+		if len(files) >= finderMaxFiles {
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return files
+}
+
+// filter matches the files against the query, best matches first
+func (f *fileFinder) filter() {
+	f.matches = f.matches[:0]
+	f.selected, f.top = 0, 0
+
+	query := asciiLower(strings.ReplaceAll(f.query, " ", ""))
+	if query == "" {
+		for i := 0; i < len(f.files) && i < finderMaxResults; i++ {
+			f.matches = append(f.matches, finderMatch{file: &f.files[i]})
+		}
+		return
+	}
+
+	for i := range f.files {
+		if score, positions, ok := fuzzyMatch(&f.files[i], query); ok {
+			f.matches = append(f.matches, finderMatch{file: &f.files[i], score: score, positions: positions})
+		}
+	}
+	sort.SliceStable(f.matches, func(i, j int) bool {
+		a, b := f.matches[i], f.matches[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		return len(a.file.display) < len(b.file.display)
+	})
+	if len(f.matches) > finderMaxResults {
+		f.matches = f.matches[:finderMaxResults]
+	}
+}
+
+// fuzzyMatch matches a lowercased query as a subsequence of the file path.
+// The shortest window ending at the first complete match is kept (as fzf v1),
+// scored for consecutive characters, word starts and matches in the file name.
+func fuzzyMatch(f *finderFile, query string) (int, []int, bool) {
+	text := f.lower
+
+	// Forward: end of the first complete match
+	qi, end := 0, -1
+	for i := 0; i < len(text) && qi < len(query); i++ {
+		if text[i] == query[qi] {
+			qi++
+			if qi == len(query) {
+				end = i
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	// Backward: latest start of a match ending there
+	positions := make([]int, len(query))
+	qi = len(query) - 1
+	for i := end; i >= 0 && qi >= 0; i-- {
+		if text[i] == query[qi] {
+			positions[qi] = i
+			qi--
+		}
+	}
+
+	score := 0
+	for k, pos := range positions {
+		score += 16
+		if k > 0 && positions[k-1] == pos-1 {
+			score += 12 // Consecutive
+		}
+		if pos == 0 || strings.IndexByte("/_-. ", text[pos-1]) >= 0 {
+			score += 10 // Start of a word
+		}
+		if pos >= f.base {
+			score += 6 // In the file name
+		}
+	}
+	score -= (positions[len(positions)-1] - positions[0] + 1) - len(query) // Gaps
+	score -= len(text) / 16                                                // Long paths
+	return score, positions, true
+}
+
+// handleFinderKey processes a key while the finder is open
+func (m *Model) handleFinderKey(msg tea.KeyMsg) tea.Cmd {
+	f := m.finder
+	keyStr := msg.String()
+
+	if f.history != nil {
+		switch keyStr {
+		case "esc", "ctrl+l":
+			f.history = nil
+		}
+		return nil
+	}
+
+	switch keyStr {
+	case "esc", "ctrl+c":
+		m.finder = nil
+	case "up", "ctrl+p", "shift+tab":
+		f.move(-1)
+	case "down", "ctrl+n", "tab":
+		f.move(1)
+	case "pgup":
+		f.move(-f.height)
+	case "pgdown":
+		f.move(f.height)
+	case "enter":
+		if file := f.selectedFile(); file != nil {
+			return m.openInEditor(file.path())
+		}
+	case "ctrl+l":
+		if file := f.selectedFile(); file != nil {
+			return loadFileHistory(*file)
+		}
+	case "ctrl+y":
+		if file := f.selectedFile(); file != nil {
+			return m.insertPathInClaude(file.path())
+		}
+	case "backspace":
+		if f.query != "" {
+			runes := []rune(f.query)
+			f.query = string(runes[:len(runes)-1])
+			f.filter()
+		}
+	case "ctrl+u":
+		f.query = ""
+		f.filter()
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			f.query += string(msg.Runes)
+			f.filter()
+		}
+	}
+	return nil
+}
+
+// move moves the selection by n matches
+func (f *fileFinder) move(n int) {
+	f.selected += n
+	if f.selected >= len(f.matches) {
+		f.selected = len(f.matches) - 1
+	}
+	if f.selected < 0 {
+		f.selected = 0
+	}
+}
+
+// selectedFile returns the selected file, nil if there are no matches
+func (f *fileFinder) selectedFile() *finderFile {
+	if f.selected < 0 || f.selected >= len(f.matches) {
+		return nil
+	}
+	return f.matches[f.selected].file
+}
+
+// openInEditor suspends the TUI and edits a file with $VISUAL or $EDITOR
+func (m *Model) openInEditor(path string) tea.Cmd {
+	return m.openInEditorAt(path, 0)
+}
+
+// openInEditorAt edits a file at a line (0 = start), with the line argument
+// of the editor
+func (m *Model) openInEditorAt(path string, line int) tea.Cmd {
+	if m.blockReadOnly("open in editor") {
+		return nil
+	}
+	return tea.ExecProcess(editorCommand(path, line), func(err error) tea.Msg {
+		return finderEditorMsg{err: err}
+	})
+}
+
+// editorCommand returns the command editing a file with $VISUAL or $EDITOR,
+// at a line (0 = start)
+func editorCommand(path string, line int) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+	args := strings.Fields(editor)
+	if line <= 0 {
+		args = append(args, path)
+	} else {
+		switch strings.TrimSuffix(filepath.Base(args[0]), ".exe") {
+		case "code", "codium", "cursor":
+			args = append(args, "-g", fmt.Sprintf("%s:%d", path, line))
+		case "subl", "zed", "hx":
+			args = append(args, fmt.Sprintf("%s:%d", path, line))
+		case "notepad":
+			args = append(args, path)
+		default: // vi, vim, nvim, emacs, nano, micro...
+			args = append(args, fmt.Sprintf("+%d", line), path)
+		}
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = filepath.Dir(path)
+	return cmd
+}
+
+// loadFileHistory loads the commits of a file (renames followed), with the
+// signature status of each commit
+func loadFileHistory(file finderFile) tea.Cmd {
+	return func() tea.Msg {
+		path := file.path()
+		cmd := exec.Command("git", "log", "--follow", "-n", "100", "--date=short",
+			"--format=%h %G? %ad %an: %s", "--", filepath.Base(path))
+		cmd.Dir = filepath.Dir(path)
+		output, err := cmd.Output()
+		if err != nil {
+			return finderHistoryMsg{title: file.display, lines: []string{"No git history: " + err.Error()}}
+		}
+		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+		if len(lines) == 1 && lines[0] == "" {
+			lines = []string{"No commits (untracked file)"}
+		}
+		return finderHistoryMsg{title: file.display, lines: lines}
+	}
+}
+
+// insertPathInClaude types a file path in the active Claude session
+func (m *Model) insertPathInClaude(path string) tea.Cmd {
+	if m.blockReadOnly("terminal input") {
+		return nil
+	}
+	t := m.terminalManager.Get(m.claudeView().activeSession)
+	if m.claudeView().activeSession == "" || t == nil || !t.IsRunning() {
+		m.lastError = "No active Claude session"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	if strings.ContainsAny(path, " '\"") {
+		path = strconv.Quote(path)
+	}
+	if err := t.WriteString(path + " "); err != nil {
+		m.lastError = fmt.Sprintf("Failed to insert path: %v", err)
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	m.finder = nil
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess, "Path inserted in Claude session"))
+	return nil
+}
+
+// renderFinderOverlay renders the finder over the main area
+func (m *Model) renderFinderOverlay(width, height int) string {
+	f := m.finder
+	boxWidth := min(width-4, 110)
+	innerWidth := boxWidth - 6 // Border and padding
+
+	var lines []string
+	if f.history != nil {
+		lines = append(lines,
+			DialogTitleStyle.MarginBottom(0).Render(truncate("History: "+f.historyTitle, innerWidth)), "")
+		visible := max(height-10, 1)
+		for i, line := range f.history {
+			if i == visible {
+				lines = append(lines, SubtitleStyle.Render(fmt.Sprintf("... %d more commits", len(f.history)-visible)))
+				break
+			}
+			hash, rest, _ := strings.Cut(line, " ")
+			signature, rest, _ := strings.Cut(rest, " ")
+			if len(signature) != 1 {
+				lines = append(lines, truncate(line, innerWidth)) // No history
+				continue
+			}
+			lines = append(lines, truncateANSI(signatureMark(signature)+" "+GitBranchStyle.Render(hash)+" "+rest, innerWidth))
+		}
+		lines = append(lines, "", SubtitleStyle.Render("Esc back"))
+	} else {
+		title := "Find file"
+		switch {
+		case f.loading:
+			title += " " + m.spinner.View() + " indexing..."
+		default:
+			title += fmt.Sprintf(" (%d files, %d projects)", len(f.files), f.projects)
+		}
+		lines = append(lines,
+			DialogTitleStyle.MarginBottom(0).Render(title),
+			InputFocusedStyle.Width(innerWidth-2).Render("> "+f.query+"█"),
+		)
+
+		f.height = max(height-12, 3)
+		if f.selected < f.top {
+			f.top = f.selected
+		}
+		if f.selected >= f.top+f.height {
+			f.top = f.selected - f.height + 1
+		}
+		matchStyle := lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true)
+		dirStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+		for i := f.top; i < len(f.matches) && i < f.top+f.height; i++ {
+			match := f.matches[i]
+			row := highlightPositions(match.file.display, match.positions, match.file.base, dirStyle, matchStyle)
+			cursor := "  "
+			if i == f.selected {
+				cursor = FocusIndicator + " "
+			}
+			lines = append(lines, truncateANSI(cursor+row, innerWidth))
+		}
+		if !f.loading && len(f.matches) == 0 {
+			lines = append(lines, SubtitleStyle.Render("  No matching files"))
+		}
+		for len(lines) < f.height+2 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "", renderFinderHints())
+	}
+
+	box := DialogStyle.Padding(0, 2).Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Top,
+		lipgloss.NewStyle().MarginTop(1).Render(box),
+	)
+}
+
+// renderFinderHints renders the key hints of the finder
+func renderFinderHints() string {
+	return strings.Join(renderKeyHints([]KeyHint{
+		{"↑↓", "select"}, {"Enter", "edit"}, {"^L", "history"}, {"^Y", "insert in Claude"}, {"Esc", "close"},
+	}), "")
+}
+
+// highlightPositions renders a path with the matched characters emphasized,
+// the directory part dimmed
+func highlightPositions(text string, positions []int, base int, dirStyle, matchStyle lipgloss.Style) string {
+	styles := []lipgloss.Style{lipgloss.NewStyle(), dirStyle, matchStyle}
+	var b strings.Builder
+	start, current, next := 0, -1, 0
+	for i, r := range text {
+		kind := 0
+		if i < base {
+			kind = 1
+		}
+		for next < len(positions) && positions[next] < i+utf8.RuneLen(r) {
+			if positions[next] >= i {
+				kind = 2
+			}
+			next++
+		}
+		if kind != current {
+			if current >= 0 {
+				b.WriteString(styles[current].Render(text[start:i]))
+			}
+			start, current = i, kind
+		}
+	}
+	if current >= 0 {
+		b.WriteString(styles[current].Render(text[start:]))
+	}
+	return b.String()
+}
+
+// asciiLower lowercases the ASCII letters only, keeping byte offsets
+func asciiLower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
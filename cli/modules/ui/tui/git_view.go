@@ -0,0 +1,780 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/git"
+	"csd-devtrack/cli/modules/ui/core"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// gitController is the submodel of the Git view
+type gitController struct {
+	diffContent      []string             // Diff content lines
+	diffHScroll      hScroll              // Horizontal scroll/wrap of long diff lines
+	diffWords        map[int][]wordSpan   // Changed words of the modified diff lines, by line
+	diffSyntax       map[int][]syntaxSpan // Syntax colors of the diff code lines, by line
+	diffLoading      bool                 // Loading diff content
+	diffJumpLine     int                  // Line of the new file to scroll the next diff to (0 = top)
+	lastSelectedFile string               // Last selected file ID (for auto-load detection)
+	files            []GitFileEntry       // Flat list of all files for current project
+	filesProjectID   string               // Project ID for which files was built
+	menu             *TreeMenu            // Tree menu for git projects and files
+}
+
+// newGitController creates the Git view controller
+func newGitController() *gitController {
+	// Files can be marked for staging
+	menu := NewTreeMenu(nil)
+	menu.SetTitle("Git")
+	menu.SetMarkable(func(item *TreeMenuItem) bool {
+		_, ok := item.Data.(GitFileEntry)
+		return ok
+	})
+	return &gitController{menu: menu}
+}
+
+// gitView returns the Git view submodel
+func (m *Model) gitView() *gitController {
+	return m.controllers[core.VMGit].(*gitController)
+}
+
+// Init implements ViewController
+func (c *gitController) Init(m *Model) tea.Cmd {
+	return nil
+}
+
+// Menu implements ViewController (the detail panel scrolls the diff)
+func (c *gitController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	if focus != FocusMain {
+		return nil
+	}
+	return c.menu
+}
+
+// Keys implements ViewController
+func (c *gitController) Keys(m *Model) []KeyHint {
+	switch m.focusArea {
+	case FocusDetail:
+		// Focused on diff panel - show scroll hints
+		return []KeyHint{
+			{"↑↓", "scroll"},
+			{"S-↑↓", "page"},
+			{"←→", "h-scroll"},
+			{"W", "wrap"},
+			{"Esc", "back"},
+		}
+	case FocusMain:
+		selectedItem := c.menu.SelectedItem()
+		if c.menu.IsAtRoot() {
+			// At project level
+			if selectedItem != nil && len(selectedItem.Children) > 0 {
+				return []KeyHint{{"→/Enter", "files"}}
+			}
+			return nil
+		}
+		// Drilled down into a project - show files
+		if selectedItem == nil {
+			return nil
+		}
+		if _, isFile := selectedItem.Data.(GitFileEntry); isFile {
+			return []KeyHint{
+				{"←", "back"},
+				{"Enter", "focus diff"},
+				{"a", "stage"},
+				{"Space", "mark"},
+			}
+		}
+		return []KeyHint{{"←", "back"}}
+	}
+	return nil
+}
+
+// Update implements ViewController
+func (c *gitController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case viewOpenedMsg:
+		m.updateGitMenu()
+	case stateUpdateMsg:
+		if msg.update.Affects(core.VMGit) && m.viewOpened(core.VMGit) {
+			m.updateGitMenu()
+		}
+	case itemCountMsg:
+		if m.state.Git != nil {
+			m.maxMainItems = len(m.state.Git.Projects)
+			// Also update maxDetailItems if in detail panel
+			if m.focusArea == FocusDetail && m.mainIndex >= 0 && m.mainIndex < len(m.state.Git.Projects) {
+				p := m.state.Git.Projects[m.mainIndex]
+				m.buildGitFileList(&p)
+			}
+		}
+	case keyPressMsg:
+		return c.handleKeyPress(m, msg.key)
+	case selectMsg:
+		// Select() handles back item, drill-down, and leaf selection
+		if item := c.menu.Select(); item != nil {
+			// Leaf item selected (file) - focus detail panel
+			if _, ok := item.Data.(GitFileEntry); ok {
+				m.focusArea = FocusDetail
+			}
+		}
+		return m.loadGitDiffForSelection(), true
+	case tea.KeyMsg:
+		// Uppercase keys avoid conflicts with navigation
+		switch msg.String() {
+		case "D": // Shift+D for diff
+			return m.sendEvent(core.NewEvent(core.EventGitDiff).WithProject(m.getSelectedProjectID())), true
+		case "H": // H for history (log)
+			return m.sendEvent(core.NewEvent(core.EventGitLog).WithProject(m.getSelectedProjectID())), true
+		case "a": // Stage the selected file (marked files: see handleBatchKey)
+			return m.stageSelected(), true
+		}
+	}
+	return nil, false
+}
+
+// handleKeyPress handles the navigation keys of the file tree (the diff of
+// the selected file follows the selection) and of the diff panel
+func (c *gitController) handleKeyPress(m *Model, msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch m.focusArea {
+	case FocusMain:
+		switch {
+		case key.Matches(msg, m.keys.Up):
+			c.menu.MoveUp()
+			return m.loadGitDiffForSelection(), true
+		case key.Matches(msg, m.keys.Down):
+			c.menu.MoveDown()
+			return m.loadGitDiffForSelection(), true
+		case msg.String() == " " && c.menu.ToggleMark():
+			// Mark for a batch stage (see handleBatchKey), the diff follows
+			c.menu.MoveDown()
+			return m.loadGitDiffForSelection(), true
+		}
+	case FocusDetail:
+		switch msg.String() {
+		case "shift+up":
+			m.gitDiffPageUp()
+			return nil, true
+		case "shift+down":
+			m.gitDiffPageDown()
+			return nil, true
+		case "left", "h":
+			c.diffHScroll.scroll(-1)
+			return nil, true
+		case "right", "l":
+			c.diffHScroll.scroll(1)
+			return nil, true
+		case "W":
+			c.diffHScroll.toggleWrap()
+			return nil, true
+		}
+		switch {
+		case key.Matches(msg, m.keys.Up):
+			if m.detailScrollOffset > 0 {
+				m.detailScrollOffset--
+			}
+			return nil, true
+		case key.Matches(msg, m.keys.Down):
+			maxScroll := len(c.diffContent) - m.visibleDetailRows
+			if maxScroll < 0 {
+				maxScroll = 0
+			}
+			if m.detailScrollOffset < maxScroll {
+				m.detailScrollOffset++
+			}
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// View implements ViewController
+func (c *gitController) View(m *Model, width, height int) string {
+	return m.renderGit(width, height)
+}
+
+// hasDetailPanel implements detailPanelView
+func (c *gitController) hasDetailPanel() bool {
+	return true
+}
+
+// selection implements selectionView
+func (c *gitController) selection(m *Model) viewSelection {
+	selectedItem := c.menu.SelectedItem()
+	if selectedItem == nil {
+		return viewSelection{}
+	}
+	if proj, ok := selectedItem.Data.(core.GitStatusVM); ok {
+		return viewSelection{projectID: proj.ProjectID}
+	}
+	// If it's a file, get project from drill-down path
+	if drillPath := c.menu.DrillDownPath(); len(drillPath) > 0 {
+		for _, p := range m.state.Git.Projects {
+			if p.ProjectName == drillPath[0] {
+				return viewSelection{projectID: p.ProjectID}
+			}
+		}
+	}
+	return viewSelection{}
+}
+
+// yank implements yankView (diff hunk at the scroll position in the detail
+// panel, path of the selected file otherwise)
+func (c *gitController) yank(m *Model) tea.Cmd {
+	path, status := m.gitSelectedFilePath()
+	if path == "" {
+		return m.yankText("", "")
+	}
+	if m.focusArea == FocusDetail && status != "untracked" {
+		if hunk := gitDiffHunkAt(c.diffContent, m.detailScrollOffset); hunk != "" {
+			return m.yankText("diff hunk", hunk)
+		}
+	}
+	return m.yankText("file path", path)
+}
+
+// contextMenu implements contextMenuView
+func (c *gitController) contextMenu(m *Model) *contextMenu {
+	return m.gitContextMenu()
+}
+
+// renderGit renders the git view using TreeMenu
+func (m *Model) renderGit(width, height int) string {
+	vm := m.state.Git
+	if vm == nil {
+		return m.renderLoading()
+	}
+
+	// 2 panels side by side, stacked when narrow
+	layout := layoutPanels(width, height, m.gitView().menu.CalcWidth(), 35)
+
+	// Left panel - TreeMenu with projects and files
+	m.gitView().menu.SetSize(layout.listWidth, layout.listHeight)
+	m.gitView().menu.SetFocused(m.focusArea == FocusMain)
+	listPanel := m.gitView().menu.Render()
+
+	// Right panel - diff/file preview
+	detailWidth := layout.detailWidth
+	detailHeight := layout.detailHeight
+	var detailContent string
+
+	// Get selected item from TreeMenu
+	selectedItem := m.gitView().menu.SelectedItem()
+	if selectedItem != nil {
+		// Check if it's a file (has GitFileEntry data) or project (has GitStatusVM data)
+		if fileEntry, ok := selectedItem.Data.(GitFileEntry); ok {
+			// Show diff content for file
+			if m.gitView().diffLoading {
+				detailContent = lipgloss.NewStyle().Foreground(ColorWarning).Render(
+					m.spinner.View() + " Loading diff...")
+			} else if len(m.gitView().diffContent) > 0 {
+				// Render diff with scrolling
+				contentHeight := detailHeight - 2
+				m.visibleDetailRows = contentHeight
+
+				// Calculate scroll bounds
+				maxScroll := len(m.gitView().diffContent) - m.visibleDetailRows
+				if maxScroll < 0 {
+					maxScroll = 0
+				}
+				if m.detailScrollOffset > maxScroll {
+					m.detailScrollOffset = maxScroll
+				}
+
+				endIdx := m.detailScrollOffset + m.visibleDetailRows
+				if endIdx > len(m.gitView().diffContent) {
+					endIdx = len(m.gitView().diffContent)
+				}
+
+				var lines []string
+				// Header with file path and status
+				header := PanelTitleStyle.Render(fileEntry.Path) + " " +
+					SubtitleStyle.Render("("+fileEntry.Status+")")
+				lines = append(lines, header)
+				if badge := aiEditsSummary(fileEntry.AIEdits); badge != "" {
+					lines = append(lines, truncateANSI(badge, detailWidth-4))
+				}
+
+				// Long lines are scrolled horizontally (or wrapped) rather than cut
+				longest := 0
+				for i := m.detailScrollOffset; i < endIdx; i++ {
+					if w := ansi.StringWidth(m.gitView().diffContent[i]); w > longest {
+						longest = w
+					}
+				}
+				m.gitView().diffHScroll.clamp(longest, detailWidth-8)
+
+				highlight := isSyntaxHighlightEnabled()
+
+				for i := m.detailScrollOffset; i < endIdx && len(lines) <= m.visibleDetailRows; i++ {
+					line := m.gitView().diffContent[i]
+					// Color diff lines
+					style := lipgloss.NewStyle()
+					if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
+						style = style.Background(lipgloss.Color("#002200")).Foreground(lipgloss.Color("#00ff00"))
+					} else if strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---") {
+						style = style.Background(lipgloss.Color("#220000")).Foreground(lipgloss.Color("#ff0000"))
+					} else if strings.HasPrefix(line, "@@") {
+						style = style.Foreground(lipgloss.Color("#00ffff"))
+					} else if strings.HasPrefix(line, "diff ") || strings.HasPrefix(line, "index ") {
+						style = style.Foreground(lipgloss.Color("#888888"))
+					}
+					// Modified lines: the changed words are emphasized within the line
+					words := m.gitView().diffWords[i]
+					var syntax []syntaxSpan
+					if highlight {
+						syntax = m.gitView().diffSyntax[i]
+					}
+					if len(words) > 0 || len(syntax) > 0 {
+						changed := style.Bold(true).Background(lipgloss.Color("#005500"))
+						if strings.HasPrefix(line, "-") {
+							changed = style.Bold(true).Background(lipgloss.Color("#660000"))
+						}
+						lines = append(lines, m.gitView().diffHScroll.view(renderDiffLine(line, words, syntax, style, changed), detailWidth-8)...)
+						continue
+					}
+					for _, row := range m.gitView().diffHScroll.view(line, detailWidth-8) {
+						lines = append(lines, style.Render(row))
+					}
+				}
+				if len(lines) > m.visibleDetailRows+1 {
+					lines = lines[:m.visibleDetailRows+1]
+				}
+
+				// Scroll indicator
+				if len(m.gitView().diffContent) > m.visibleDetailRows || m.gitView().diffHScroll.status() != "" {
+					info := fmt.Sprintf(" [%d-%d/%d lines]", m.detailScrollOffset+1, endIdx, len(m.gitView().diffContent))
+					if status := m.gitView().diffHScroll.status(); status != "" {
+						info += " " + status
+					}
+					lines = append(lines, SubtitleStyle.Render(info))
+				}
+
+				detailContent = strings.Join(lines, "\n")
+			} else {
+				// No diff content yet
+				detailLines := []string{
+					PanelTitleStyle.Render(fileEntry.Path),
+					fmt.Sprintf("Status: %s", fileEntry.Status),
+				}
+				detailContent = strings.Join(detailLines, "\n")
+			}
+		} else if hidden, ok := selectedItem.Data.(gitHiddenFiles); ok {
+			detailContent = strings.Join([]string{
+				PanelTitleStyle.Render("Hidden files"),
+				fmt.Sprintf("%d changed files match the git_hide patterns of the project:", hidden.Count),
+				"",
+				truncate(strings.Join(hidden.Patterns, "  "), detailWidth-4),
+				"",
+				SubtitleStyle.Render("They are left out of the change counts (.gitignore is not involved)"),
+				SubtitleStyle.Render("Press → or Enter to see them"),
+			}, "\n")
+		} else if project, ok := selectedItem.Data.(core.GitStatusVM); ok {
+			// Show project info
+			branchDisplay := project.Branch
+			if branchDisplay == "" && m.state.GitLoading {
+				branchDisplay = m.spinner.View() + " loading..."
+			} else if branchDisplay == "" {
+				branchDisplay = "(unknown)"
+			}
+
+			var syncInfo string
+			if project.Ahead > 0 {
+				syncInfo += GitAheadStyle.Render(fmt.Sprintf("↑%d ahead", project.Ahead))
+			}
+			if project.Behind > 0 {
+				if syncInfo != "" {
+					syncInfo += " "
+				}
+				syncInfo += GitBehindStyle.Render(fmt.Sprintf("↓%d behind", project.Behind))
+			}
+
+			detailLines := []string{
+				PanelTitleStyle.Render(project.ProjectName),
+				fmt.Sprintf("Branch: %s", GitBranchStyle.Render(branchDisplay)),
+			}
+			if syncInfo != "" {
+				detailLines = append(detailLines, syncInfo)
+			}
+			if project.Signing != "" {
+				detailLines = append(detailLines, truncate("Signing: "+project.Signing, detailWidth-4))
+			}
+			if project.SigningWarning != "" {
+				detailLines = append(detailLines, StatusWarning.Render(truncate("⚠ "+project.SigningWarning, detailWidth-4)))
+			}
+			if project.LargeRepo {
+				detailLines = append(detailLines, SubtitleStyle.Render("Large repository mode: untracked files not listed"))
+			}
+			if len(project.Hooks) > 0 {
+				detailLines = append(detailLines, truncate("Hooks: "+strings.Join(project.Hooks, ", "), detailWidth-4))
+			}
+			detailLines = append(detailLines, "")
+
+			changeCount := len(project.Staged) + len(project.Modified) +
+				len(project.Deleted) + len(project.Untracked)
+			if changeCount == 0 {
+				detailLines = append(detailLines, StatusSuccess.Render("✓ Working tree clean"))
+			} else {
+				detailLines = append(detailLines, fmt.Sprintf("%d changes", changeCount))
+				if len(project.Staged) > 0 {
+					detailLines = append(detailLines, StatusSuccess.Render(fmt.Sprintf("  %d staged", len(project.Staged))))
+				}
+				if len(project.Modified) > 0 {
+					detailLines = append(detailLines, StatusWarning.Render(fmt.Sprintf("  %d modified", len(project.Modified))))
+				}
+				if len(project.Deleted) > 0 {
+					detailLines = append(detailLines, StatusError.Render(fmt.Sprintf("  %d deleted", len(project.Deleted))))
+				}
+				if len(project.Untracked) > 0 {
+					detailLines = append(detailLines, SubtitleStyle.Render(fmt.Sprintf("  %d untracked", len(project.Untracked))))
+				}
+				detailLines = append(detailLines, "")
+				detailLines = append(detailLines, SubtitleStyle.Render("Press → or Enter to see files"))
+			}
+			if project.Hidden != nil {
+				detailLines = append(detailLines, SubtitleStyle.Render(fmt.Sprintf("%d changed files hidden by git_hide", project.Hidden.ChangeCount())))
+			}
+
+			// History loaded with H, with the signature status of each commit
+			if vm.SelectedProject == project.ProjectID && len(vm.Commits) > 0 {
+				detailLines = append(detailLines, "", PanelTitleStyle.Render("History"))
+				for _, c := range vm.Commits {
+					if len(detailLines) >= detailHeight-1 {
+						break
+					}
+					line := signatureMark(c.Signature) + " " + GitBranchStyle.Render(c.ShortHash) + " " +
+						SubtitleStyle.Render(c.DateStr) + " " + c.Author + ": " + c.Subject
+					detailLines = append(detailLines, truncateANSI(line, detailWidth-4))
+				}
+			} else {
+				detailLines = append(detailLines, "", SubtitleStyle.Render("Press H to see the history"))
+			}
+			detailContent = strings.Join(detailLines, "\n")
+		}
+	} else if m.state.GitLoading {
+		detailContent = lipgloss.NewStyle().Foreground(ColorWarning).Render(
+			m.spinner.View() + " Loading git status...")
+	} else if len(vm.Projects) == 0 {
+		detailContent = SubtitleStyle.Render("No git repositories")
+	}
+
+	// Build detail panel
+	var detailStyle lipgloss.Style
+	if m.focusArea == FocusDetail {
+		detailStyle = FocusedBorderStyle
+	} else {
+		detailStyle = UnfocusedBorderStyle
+	}
+	detailPanel := detailStyle.Width(detailWidth - 2).Height(detailHeight).Render(detailContent)
+
+	return layout.join(listPanel, detailPanel)
+}
+
+// signatureMark renders the signature status of a commit (git log %G?) as
+// a one-cell mark: ✓ good, ? not checkable or unknown validity, ! expired,
+// ✗ bad or revoked, blank when not signed
+func signatureMark(status string) string {
+	switch status {
+	case git.SignatureGood:
+		return StatusSuccess.Render("✓")
+	case git.SignatureUnknown, git.SignatureCannotCheck:
+		return StatusWarning.Render("?")
+	case git.SignatureExpired, git.SignatureExpiredKey:
+		return StatusWarning.Render("!")
+	case git.SignatureBad, git.SignatureRevokedKey:
+		return StatusError.Render("✗")
+	}
+	return " "
+}
+
+// buildGitFileList builds a flat list of all files from git status
+// Only rebuilds if the project or file count changed
+func (m *Model) buildGitFileList(p *core.GitStatusVM) {
+	// Calculate expected file count
+	expectedCount := len(p.Staged) + len(p.Modified) + len(p.Deleted) + len(p.Untracked)
+
+	// Only rebuild if project changed or file count changed
+	if m.gitView().filesProjectID == p.ProjectID && len(m.gitView().files) == expectedCount {
+		// Still update maxDetailItems for navigation
+		m.maxDetailItems = len(m.gitView().files)
+		return
+	}
+
+	m.gitView().files = make([]GitFileEntry, 0, expectedCount)
+	m.gitView().filesProjectID = p.ProjectID
+
+	for _, f := range p.Staged {
+		m.gitView().files = append(m.gitView().files, GitFileEntry{Path: f, Status: "staged"})
+	}
+	for _, f := range p.Modified {
+		m.gitView().files = append(m.gitView().files, GitFileEntry{Path: f, Status: "modified"})
+	}
+	for _, f := range p.Deleted {
+		m.gitView().files = append(m.gitView().files, GitFileEntry{Path: f, Status: "deleted"})
+	}
+	for _, f := range p.Untracked {
+		m.gitView().files = append(m.gitView().files, GitFileEntry{Path: f, Status: "untracked"})
+	}
+
+	// Update maxDetailItems for navigation
+	m.maxDetailItems = len(m.gitView().files)
+
+	// Reset detail index only if out of bounds
+	if m.detailIndex >= len(m.gitView().files) {
+		m.detailIndex = 0
+		m.detailScrollOffset = 0
+	}
+}
+
+// gitDiffPageUp scrolls the git diff view up by a page
+func (m *Model) gitDiffPageUp() {
+	m.detailScrollOffset -= m.visibleDetailRows
+	if m.detailScrollOffset < 0 {
+		m.detailScrollOffset = 0
+	}
+}
+
+// gitDiffPageDown scrolls the git diff view down by a page
+func (m *Model) gitDiffPageDown() {
+	maxScroll := len(m.gitView().diffContent) - m.visibleDetailRows
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	m.detailScrollOffset += m.visibleDetailRows
+	if m.detailScrollOffset > maxScroll {
+		m.detailScrollOffset = maxScroll
+	}
+}
+
+// updateGitMenu updates the Git view TreeMenu with projects and their files
+func (m *Model) updateGitMenu() {
+	if m.gitView().menu == nil || m.state.Git == nil {
+		return
+	}
+
+	var items []TreeMenuItem
+
+	for _, p := range m.state.Git.Projects {
+		// Create children for each file status category
+		var children []TreeMenuItem
+
+		// Staged files
+		for _, f := range p.Staged {
+			children = append(children, TreeMenuItem{
+				ID:    p.ProjectName + ":staged:" + f,
+				Label: f,
+				Icon:  "A",
+				Data:  GitFileEntry{ProjectID: p.ProjectID, Path: f, Status: "staged"},
+			})
+		}
+
+		// Modified files
+		for _, f := range p.Modified {
+			children = append(children, TreeMenuItem{
+				ID:    p.ProjectName + ":modified:" + f,
+				Label: f,
+				Icon:  "M",
+				Data:  GitFileEntry{ProjectID: p.ProjectID, Path: f, Status: "modified"},
+			})
+		}
+
+		// Deleted files
+		for _, f := range p.Deleted {
+			children = append(children, TreeMenuItem{
+				ID:    p.ProjectName + ":deleted:" + f,
+				Label: f,
+				Icon:  "D",
+				Data:  GitFileEntry{ProjectID: p.ProjectID, Path: f, Status: "deleted"},
+			})
+		}
+
+		// Untracked files
+		for _, f := range p.Untracked {
+			children = append(children, TreeMenuItem{
+				ID:    p.ProjectName + ":untracked:" + f,
+				Label: f,
+				Icon:  "?",
+				Data:  GitFileEntry{ProjectID: p.ProjectID, Path: f, Status: "untracked"},
+			})
+		}
+
+		// Files written by Claude sessions get a badge
+		for i := range children {
+			entry := children[i].Data.(GitFileEntry)
+			if edits := p.AIEdits[entry.Path]; len(edits) > 0 {
+				entry.AIEdits = edits
+				children[i].Data = entry
+				children[i].TrailingIcon = "✦ AI"
+				children[i].TrailingColor = ColorSecondary
+			}
+		}
+
+		// Files hidden by the git_hide patterns, collapsed under one row
+		if p.Hidden != nil {
+			var hidden []TreeMenuItem
+			for _, group := range []struct {
+				files  []string
+				status string
+				icon   string
+			}{
+				{p.Hidden.Staged, "staged", "A"},
+				{p.Hidden.Modified, "modified", "M"},
+				{p.Hidden.Deleted, "deleted", "D"},
+				{p.Hidden.Untracked, "untracked", "?"},
+			} {
+				for _, f := range group.files {
+					hidden = append(hidden, TreeMenuItem{
+						ID:    p.ProjectName + ":hidden:" + group.status + ":" + f,
+						Label: f,
+						Icon:  group.icon,
+						Data:  GitFileEntry{ProjectID: p.ProjectID, Path: f, Status: group.status},
+					})
+				}
+			}
+			children = append(children, TreeMenuItem{
+				ID:        p.ProjectName + ":hidden",
+				Label:     "Hidden files",
+				Icon:      "…",
+				IconColor: ColorMuted,
+				Children:  hidden,
+				Count:     len(hidden),
+				Data:      gitHiddenFiles{Count: len(hidden), Patterns: p.HidePatterns},
+			})
+		}
+
+		// Build project status indicator
+		statusIcon := "●"
+		if p.IsClean {
+			statusIcon = "✓"
+		}
+		aiIcon := ""
+		if len(p.AIEdits) > 0 {
+			aiIcon = fmt.Sprintf("✦ %d", len(p.AIEdits))
+		}
+
+		// Count of changes
+		changeCount := len(p.Staged) + len(p.Modified) + len(p.Deleted) + len(p.Untracked)
+
+		items = append(items, TreeMenuItem{
+			ID:            p.ProjectName,
+			Label:         p.ProjectName,
+			Icon:          statusIcon,
+			TrailingIcon:  aiIcon,
+			TrailingColor: ColorSecondary,
+			Children:      children,
+			Count:         changeCount,
+			Data:          p,
+		})
+	}
+
+	m.gitView().menu.SetItems(items)
+}
+
+// loadGitDiffForSelection loads the diff if the selection changed to a file
+// Returns a command to load the diff, or nil if no change needed
+func (m *Model) loadGitDiffForSelection() tea.Cmd {
+	if m.gitView().menu == nil || m.state.Git == nil {
+		return nil
+	}
+
+	selectedItem := m.gitView().menu.SelectedItem()
+	if selectedItem == nil {
+		// Clear diff if nothing selected
+		if m.gitView().lastSelectedFile != "" {
+			m.gitView().lastSelectedFile = ""
+			m.gitView().diffContent = nil
+			m.gitView().diffWords = nil
+			m.gitView().diffSyntax = nil
+		}
+		return nil
+	}
+
+	// Check if it's a file
+	fileEntry, ok := selectedItem.Data.(GitFileEntry)
+	if !ok {
+		// Selected a project, not a file - clear diff
+		if m.gitView().lastSelectedFile != "" {
+			m.gitView().lastSelectedFile = ""
+			m.gitView().diffContent = nil
+			m.gitView().diffWords = nil
+			m.gitView().diffSyntax = nil
+		}
+		return nil
+	}
+
+	// Same file? No need to reload
+	if selectedItem.ID == m.gitView().lastSelectedFile {
+		return nil
+	}
+
+	// New file selected - load diff
+	m.gitView().lastSelectedFile = selectedItem.ID
+	m.gitView().diffLoading = true
+	m.detailScrollOffset = 0
+
+	// Get project from drill-down path
+	drillPath := m.gitView().menu.DrillDownPath()
+	if len(drillPath) == 0 {
+		return nil
+	}
+	projectPath := m.gitProjectPath(drillPath[0])
+	if projectPath == "" {
+		return nil
+	}
+
+	f := fileEntry
+	return func() tea.Msg {
+		var cmd *exec.Cmd
+		if f.Status == "staged" {
+			cmd = exec.Command("git", "diff", "--cached", "--", f.Path)
+		} else if f.Status == "untracked" {
+			// For untracked files, show file content
+			cmd = exec.Command("cat", f.Path)
+		} else {
+			cmd = exec.Command("git", "diff", "--", f.Path)
+		}
+		cmd.Dir = projectPath
+
+		output, err := cmd.Output()
+		if err != nil {
+			return gitDiffMsg{lines: []string{"Error getting diff: " + err.Error()}}
+		}
+
+		lines := strings.Split(string(output), "\n")
+		if f.Status == "untracked" {
+			// Add header for untracked files
+			lines = append([]string{
+				"New file: " + f.Path,
+				"---",
+			}, lines...)
+			return gitDiffMsg{lines: lines, syntax: highlightDiff(f.Path, lines, 2, false)}
+		}
+		return gitDiffMsg{lines: lines, words: computeWordDiff(lines), syntax: highlightDiff(f.Path, lines, 0, true)}
+	}
+}
+
+// gitProjectPath returns the path of a project of the Git view, by name
+func (m *Model) gitProjectPath(projectName string) string {
+	cfg := config.GetGlobal()
+	if cfg == nil || m.state.Git == nil {
+		return ""
+	}
+	for _, p := range m.state.Git.Projects {
+		if p.ProjectName == projectName {
+			for _, proj := range cfg.Projects {
+				if proj.ID == p.ProjectID {
+					return proj.Path
+				}
+			}
+			break
+		}
+	}
+	return ""
+}
@@ -0,0 +1,410 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Global search limits
+const (
+	searchGroupMax = 8  // Results listed per group (logs: searchLogsMax)
+	searchLogsMax  = 20 // Log lines listed, most recent first
+)
+
+// Global search result groups, in display order
+const (
+	searchGroupProjects  = "Projects"
+	searchGroupProcesses = "Processes"
+	searchGroupSessions  = "Sessions"
+	searchGroupLogs      = "Logs"
+	searchGroupGit       = "Git files"
+)
+
+// searchResult is an item matching the global search query
+type searchResult struct {
+	group  string
+	label  string // Matched text (highlighted)
+	detail string // Context shown dimmed: project, state, source...
+	rank   int    // Lower first within the group
+	jump   func(m *Model) tea.Cmd
+}
+
+// globalSearch is the search overlay (Ctrl+F, ^G /) over the projects,
+// processes, sessions, logs and git files of DevTrack
+type globalSearch struct {
+	query    string
+	results  []searchResult
+	counts   map[string]int // Matches per group (results are capped)
+	selected int
+	height   int // Visible rows (set at render)
+}
+
+// openGlobalSearch opens the global search overlay
+func (m *Model) openGlobalSearch() tea.Cmd {
+	m.globalSearch = &globalSearch{}
+	return nil
+}
+
+// handleGlobalSearchKey processes a key while the global search is open
+func (m *Model) handleGlobalSearchKey(msg tea.KeyMsg) tea.Cmd {
+	s := m.globalSearch
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.globalSearch = nil
+	case "up", "ctrl+p", "shift+tab":
+		s.move(-1)
+	case "down", "ctrl+n", "tab":
+		s.move(1)
+	case "pgup":
+		s.move(-max(s.height/2, 1))
+	case "pgdown":
+		s.move(max(s.height/2, 1))
+	case "enter":
+		if s.selected < len(s.results) {
+			result := s.results[s.selected]
+			m.globalSearch = nil
+			m.focusArea = FocusMain
+			return result.jump(m)
+		}
+	case "backspace":
+		if s.query != "" {
+			runes := []rune(s.query)
+			s.query = string(runes[:len(runes)-1])
+			m.runGlobalSearch()
+		}
+	case "ctrl+u":
+		s.query = ""
+		m.runGlobalSearch()
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			s.query += string(msg.Runes)
+			m.runGlobalSearch()
+		}
+	}
+	return nil
+}
+
+// move moves the selection by n results
+func (s *globalSearch) move(n int) {
+	s.selected = min(max(s.selected+n, 0), max(len(s.results)-1, 0))
+}
+
+// searchTerms splits a query in lowercased terms, all of them must match
+func searchTerms(query string) []string {
+	return strings.Fields(asciiLower(query))
+}
+
+// matchTerms returns true if every term is in one of the texts
+// (lowercased), and a rank: 0 for a label starting with the first term,
+// 1 for a match in the label, 2 for a match in the details only
+func matchTerms(terms []string, label string, details ...string) (int, bool) {
+	lowerLabel := asciiLower(label)
+	all := lowerLabel + "\x00" + asciiLower(strings.Join(details, "\x00"))
+	for _, term := range terms {
+		if !strings.Contains(all, term) {
+			return 0, false
+		}
+	}
+	switch {
+	case strings.HasPrefix(lowerLabel, terms[0]):
+		return 0, true
+	case strings.Contains(lowerLabel, terms[0]):
+		return 1, true
+	}
+	return 2, true
+}
+
+// runGlobalSearch searches the query in the current state
+func (m *Model) runGlobalSearch() {
+	s := m.globalSearch
+	s.results, s.counts, s.selected = nil, make(map[string]int), 0
+	terms := searchTerms(s.query)
+	if len(terms) == 0 {
+		return
+	}
+
+	add := func(r searchResult) {
+		s.counts[r.group]++
+		s.results = append(s.results, r)
+	}
+
+	if m.state.Projects != nil {
+		for _, p := range m.state.Projects.Projects {
+			if rank, ok := matchTerms(terms, p.Name, p.Path, p.GitBranch); ok {
+				id := p.ID
+				add(searchResult{group: searchGroupProjects, label: p.Name, detail: p.Path, rank: rank,
+					jump: func(m *Model) tea.Cmd {
+						cmd := m.selectViewByType(core.VMProjects)
+						m.projectsView().menu.SelectMatching(func(item *TreeMenuItem) bool { return item.ID == id })
+						return cmd
+					}})
+			}
+		}
+	}
+
+	if m.state.Processes != nil {
+		for _, proc := range m.state.Processes.Processes {
+			label := proc.ProjectName + "/" + string(proc.Component)
+			if rank, ok := matchTerms(terms, label, string(proc.State), proc.LastError); ok {
+				id := proc.ID
+				add(searchResult{group: searchGroupProcesses, label: label, detail: string(proc.State), rank: rank,
+					jump: func(m *Model) tea.Cmd {
+						cmd := m.selectViewByType(core.VMProcesses)
+						m.processesView().menu.SelectMatching(func(item *TreeMenuItem) bool { return item.ID == id })
+						return cmd
+					}})
+			}
+		}
+	}
+
+	m.searchSessions(terms, add)
+
+	if m.state.Logs != nil {
+		// Most recent first
+		lines := m.state.Logs.Lines
+		for i := len(lines) - 1; i >= 0; i-- {
+			line := &lines[i]
+			if _, ok := matchTerms(terms, line.Text(), line.Source); ok {
+				source := line.Source
+				add(searchResult{group: searchGroupLogs, label: strings.TrimSpace(ansi.Strip(line.Text())),
+					detail: line.Time().Local().Format("15:04:05") + " " + line.Source, rank: len(lines) - i,
+					jump: func(m *Model) tea.Cmd {
+						cmd := m.selectViewByType(core.VMLogs)
+						m.showLogSearch(source, terms)
+						return cmd
+					}})
+			}
+		}
+	}
+
+	if m.state.Git != nil {
+		for _, p := range m.state.Git.Projects {
+			for _, files := range []struct {
+				status string
+				paths  []string
+			}{{"staged", p.Staged}, {"modified", p.Modified}, {"deleted", p.Deleted}, {"untracked", p.Untracked}} {
+				for _, path := range files.paths {
+					if rank, ok := matchTerms(terms, path, p.ProjectName); ok {
+						itemID := p.ProjectName + ":" + files.status + ":" + path
+						add(searchResult{group: searchGroupGit, label: path, detail: p.ProjectName + " · " + files.status, rank: rank,
+							jump: func(m *Model) tea.Cmd {
+								cmd := m.selectViewByType(core.VMGit)
+								m.gitView().menu.SelectMatching(func(item *TreeMenuItem) bool { return item.ID == itemID })
+								return tea.Batch(cmd, m.loadGitDiffForSelection())
+							}})
+					}
+				}
+			}
+		}
+	}
+
+	// Groups in display order, best matches first, capped
+	order := map[string]int{searchGroupProjects: 0, searchGroupProcesses: 1, searchGroupSessions: 2, searchGroupLogs: 3, searchGroupGit: 4}
+	sort.SliceStable(s.results, func(i, j int) bool {
+		a, b := s.results[i], s.results[j]
+		if a.group != b.group {
+			return order[a.group] < order[b.group]
+		}
+		return a.rank < b.rank
+	})
+	kept, shown := s.results[:0], make(map[string]int)
+	for _, r := range s.results {
+		limit := searchGroupMax
+		if r.group == searchGroupLogs {
+			limit = searchLogsMax
+		}
+		if shown[r.group] < limit {
+			shown[r.group]++
+			kept = append(kept, r)
+		}
+	}
+	s.results = kept
+}
+
+// searchSessions adds the Claude, Codex, database and shell sessions
+// matching the terms
+func (m *Model) searchSessions(terms []string, add func(searchResult)) {
+	if m.state.Claude != nil {
+		for _, sess := range m.state.Claude.Sessions {
+			if rank, ok := matchTerms(terms, sess.Name, sess.ProjectName, "claude"); ok {
+				id := sess.ID
+				add(searchResult{group: searchGroupSessions, label: sess.Name, detail: "Claude · " + sess.ProjectName, rank: rank,
+					jump: func(m *Model) tea.Cmd {
+						cmd := m.selectViewByType(core.VMClaude)
+						m.selectSessionInTree(id)
+						return cmd
+					}})
+			}
+		}
+	}
+	if m.state.Codex != nil {
+		for _, sess := range m.state.Codex.Sessions {
+			if rank, ok := matchTerms(terms, sess.Name, sess.ProjectName, "codex"); ok {
+				id := "codex-" + sess.ID
+				add(searchResult{group: searchGroupSessions, label: sess.Name, detail: "Codex · " + sess.ProjectName, rank: rank,
+					jump: func(m *Model) tea.Cmd {
+						cmd := m.selectViewByType(core.VMCodex)
+						m.codexView().filterProject = ""
+						m.updateCodexTree()
+						m.codexView().treeMenu.SelectMatching(func(item *TreeMenuItem) bool { return item.ID == id })
+						return cmd
+					}})
+			}
+		}
+	}
+	if m.state.Database != nil {
+		for _, sess := range m.state.Database.Sessions {
+			if rank, ok := matchTerms(terms, sess.Name, sess.DatabaseName, sess.ProjectName, "database"); ok {
+				id := "db:" + sess.DatabaseID
+				add(searchResult{group: searchGroupSessions, label: sess.Name, detail: "Database · " + sess.DatabaseName, rank: rank,
+					jump: func(m *Model) tea.Cmd {
+						cmd := m.selectViewByType(core.VMDatabase)
+						m.databaseView().treeMenu.SelectMatching(func(item *TreeMenuItem) bool { return item.ID == id })
+						return cmd
+					}})
+			}
+		}
+	}
+	if m.state.Shell != nil {
+		for _, sess := range m.state.Shell.Sessions {
+			if rank, ok := matchTerms(terms, sess.Name, sess.ProjectName, sess.WorkDir, "shell"); ok {
+				id := sess.ID
+				add(searchResult{group: searchGroupSessions, label: sess.Name, detail: "Shell · " + sess.WorkDir, rank: rank,
+					jump: func(m *Model) tea.Cmd {
+						cmd := m.selectViewByType(core.VMShell)
+						if m.shellView().treeMenu != nil {
+							m.shellView().treeMenu.SelectMatching(func(item *TreeMenuItem) bool { return item.ID == id })
+						}
+						return cmd
+					}})
+			}
+		}
+	}
+}
+
+// showLogSearch filters the Logs view on a source and the longest search
+// term (the Logs search is a single substring)
+func (m *Model) showLogSearch(source string, terms []string) {
+	m.logsView().follow = false
+	m.logsView().sourceFilter = source
+	m.logsView().typeFilter = ""
+	m.logsView().levelFilter = ""
+	m.logsView().searchText = ""
+	for _, term := range terms {
+		if len(term) > len(m.logsView().searchText) {
+			m.logsView().searchText = term
+		}
+	}
+	m.logsView().scrollOffset = 0
+	m.logsView().autoScroll = true
+}
+
+// renderGlobalSearchOverlay renders the global search over the main area
+func (m *Model) renderGlobalSearchOverlay(width, height int) string {
+	s := m.globalSearch
+	boxWidth := min(width-4, 110)
+	innerWidth := boxWidth - 6 // Border and padding
+
+	title := "Search projects, processes, sessions, logs and git files"
+	if len(s.results) > 0 {
+		total := 0
+		for _, n := range s.counts {
+			total += n
+		}
+		title = fmt.Sprintf("Search (%d matches)", total)
+	}
+	lines := []string{
+		DialogTitleStyle.MarginBottom(0).Render(truncate(title, innerWidth)),
+		InputFocusedStyle.Width(innerWidth - 2).Render("> " + s.query + "█"),
+	}
+
+	// Rows: group headers, then their results
+	var rows []string
+	selectedRow := 0
+	matchStyle := lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true)
+	detailStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	terms := searchTerms(s.query)
+	for i, r := range s.results {
+		if i == 0 || s.results[i-1].group != r.group {
+			header := fmt.Sprintf("%s (%d)", r.group, s.counts[r.group])
+			if shown := countGroup(s.results, r.group); shown < s.counts[r.group] {
+				header = fmt.Sprintf("%s (%d, %d shown)", r.group, s.counts[r.group], shown)
+			}
+			rows = append(rows, TableHeaderStyle.Render(truncate(header, innerWidth)))
+		}
+		cursor := "  "
+		if i == s.selected {
+			cursor = FocusIndicator + " "
+			selectedRow = len(rows)
+		}
+		label := truncate(r.label, innerWidth*2/3)
+		rows = append(rows, truncateANSI(cursor+highlightTerms(label, terms, matchStyle)+"  "+detailStyle.Render(r.detail), innerWidth))
+	}
+
+	// Keep the selection visible
+	s.height = max(height-12, 3)
+	start := 0
+	if selectedRow >= s.height {
+		start = selectedRow - s.height + 1
+	}
+	end := min(start+s.height, len(rows))
+	lines = append(lines, rows[start:end]...)
+	switch {
+	case len(terms) == 0:
+		lines = append(lines, SubtitleStyle.Render("  Type to search (all words must match)"))
+	case len(s.results) == 0:
+		lines = append(lines, SubtitleStyle.Render("  No matches"))
+	}
+	for len(lines) < s.height+2 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, "", strings.Join(renderKeyHints([]KeyHint{
+		{"↑↓", "select"}, {"Enter", "go to"}, {"^U", "clear"}, {"Esc", "close"},
+	}), ""))
+
+	box := DialogStyle.Padding(0, 2).Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Top,
+		lipgloss.NewStyle().MarginTop(1).Render(box),
+	)
+}
+
+// countGroup returns the number of results of a group
+func countGroup(results []searchResult, group string) int {
+	n := 0
+	for _, r := range results {
+		if r.group == group {
+			n++
+		}
+	}
+	return n
+}
+
+// highlightTerms renders a text with the occurrences of the terms emphasized
+func highlightTerms(text string, terms []string, matchStyle lipgloss.Style) string {
+	lower := asciiLower(text)
+	var positions []int
+	for _, term := range terms {
+		for from := 0; ; {
+			i := strings.Index(lower[from:], term)
+			if i < 0 {
+				break
+			}
+			for k := from + i; k < from+i+len(term); k++ {
+				positions = append(positions, k)
+			}
+			from += i + len(term)
+		}
+	}
+	if len(positions) == 0 {
+		return text
+	}
+	sort.Ints(positions)
+	return highlightPositions(text, positions, 0, lipgloss.NewStyle(), matchStyle)
+}
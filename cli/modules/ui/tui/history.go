@@ -0,0 +1,236 @@
+package tui
+
+import (
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// navHistoryMax is the number of locations kept to go back to
+const navHistoryMax = 50
+
+// navLocation is a place the navigation history returns to: a view with its
+// focus, the item selected in its tree, the Claude session shown and the
+// Logs filters
+type navLocation struct {
+	view     core.ViewModelType
+	focus    FocusArea
+	selected string // ID of the item selected in the tree of the view
+	session  string // Claude session shown (Claude view)
+
+	// Logs view
+	logSource string
+	logType   string
+	logLevel  string
+	logSearch string
+	logScroll int
+}
+
+// sameEntry returns true if both locations are one entry of the history:
+// moving inside a view only updates its entry, changing view or Claude
+// session adds one (opening a first session does not)
+func (l navLocation) sameEntry(other navLocation) bool {
+	return l.view == other.view && (l.session == other.session || l.session == "" || other.session == "")
+}
+
+// navHistory is the back/forward history of the views, like a browser.
+// Shared between the copies of the Model (Bubble Tea copies it on Update).
+type navHistory struct {
+	back    []navLocation
+	forward []navLocation
+	current navLocation // Location before the message being handled
+	started bool
+}
+
+// trackNavigation records the location left by the previous message. It is
+// called at the start of every Update, so view changes are recorded whatever
+// switched the view (keys, mouse, global search, actions opening Build or
+// Logs).
+func (m *Model) trackNavigation() {
+	if m.nav == nil {
+		return
+	}
+	location := m.currentLocation()
+	if m.nav.started && !location.sameEntry(m.nav.current) {
+		m.nav.back = append(m.nav.back, m.nav.current)
+		if len(m.nav.back) > navHistoryMax {
+			m.nav.back = m.nav.back[len(m.nav.back)-navHistoryMax:]
+		}
+		m.nav.forward = nil
+	}
+	m.nav.current, m.nav.started = location, true
+}
+
+// currentLocation returns the location shown
+func (m *Model) currentLocation() navLocation {
+	location := navLocation{view: m.currentView, focus: m.focusArea}
+	if v, ok := m.controller().(locationView); ok {
+		v.saveLocation(m, &location)
+	} else {
+		location.selected = treeLocation(m.controller().Menu(m, FocusMain))
+	}
+	return location
+}
+
+// treeLocation returns the ID of the item selected in a tree ("" if none)
+func treeLocation(tm *TreeMenu) string {
+	if tm == nil {
+		return ""
+	}
+	if item := tm.SelectedItem(); item != nil {
+		return item.ID
+	}
+	return ""
+}
+
+// selectLocation selects the item of a location in a tree, if it still exists
+func selectLocation(tm *TreeMenu, selected string) {
+	if tm != nil && selected != "" {
+		tm.SelectMatching(func(item *TreeMenuItem) bool { return item.ID == selected })
+	}
+}
+
+// navigateBack returns to the previous location (Alt+Left, ^G b)
+func (m *Model) navigateBack() tea.Cmd {
+	m.trackNavigation()
+	if m.nav == nil || len(m.nav.back) == 0 {
+		m.lastError = "No previous location"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	target, left := m.nav.back[len(m.nav.back)-1], m.nav.current
+	m.nav.back = m.nav.back[:len(m.nav.back)-1]
+	cmd, ok := m.goToLocation(target)
+	if ok {
+		m.nav.forward = append(m.nav.forward, left)
+	}
+	return cmd
+}
+
+// navigateForward goes to the location left by going back (Alt+Right, ^G f)
+func (m *Model) navigateForward() tea.Cmd {
+	m.trackNavigation()
+	if m.nav == nil || len(m.nav.forward) == 0 {
+		m.lastError = "No next location"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	target, left := m.nav.forward[len(m.nav.forward)-1], m.nav.current
+	m.nav.forward = m.nav.forward[:len(m.nav.forward)-1]
+	cmd, ok := m.goToLocation(target)
+	if ok {
+		m.nav.back = append(m.nav.back, left)
+	}
+	return cmd
+}
+
+// goToLocation shows a location of the history. Items and sessions that no
+// longer exist are skipped: the view is shown as it is. Returns false if the
+// view is no longer available (the location is dropped).
+func (m *Model) goToLocation(location navLocation) (tea.Cmd, bool) {
+	var cmds []tea.Cmd
+	if location.view != m.currentView {
+		cmds = append(cmds, m.selectViewByType(location.view))
+		if m.currentView != location.view {
+			m.lastError = "View no longer available"
+			m.lastErrorTime = time.Now()
+			return nil, false
+		}
+	}
+
+	if v, ok := m.controller().(locationView); ok {
+		cmds = append(cmds, v.restoreLocation(m, location))
+	} else {
+		selectLocation(m.controller().Menu(m, FocusMain), location.selected)
+	}
+	m.focusArea = location.focus
+
+	// The location reached is not a new entry of the history
+	m.nav.current = m.currentLocation()
+	return tea.Batch(cmds...), true
+}
+
+// saveLocation implements locationView (the sessions tree and the session shown)
+func (c *claudeController) saveLocation(m *Model, location *navLocation) {
+	location.selected = treeLocation(c.treeMenu)
+	location.session = c.activeSession
+}
+
+// restoreLocation implements locationView
+func (c *claudeController) restoreLocation(m *Model, location navLocation) tea.Cmd {
+	selectLocation(c.treeMenu, location.selected)
+	if location.session != "" && location.session != c.activeSession &&
+		!c.deletingSessions[location.session] && m.claudeSessionExists(location.session) {
+		return m.switchToSessionByID(location.session)
+	}
+	return nil
+}
+
+// saveLocation implements locationView (the sessions tree)
+func (c *codexController) saveLocation(m *Model, location *navLocation) {
+	location.selected = treeLocation(c.treeMenu)
+}
+
+// restoreLocation implements locationView
+func (c *codexController) restoreLocation(m *Model, location navLocation) tea.Cmd {
+	selectLocation(c.treeMenu, location.selected)
+	return nil
+}
+
+// saveLocation implements locationView (the databases tree)
+func (c *databaseController) saveLocation(m *Model, location *navLocation) {
+	location.selected = treeLocation(c.treeMenu)
+}
+
+// restoreLocation implements locationView
+func (c *databaseController) restoreLocation(m *Model, location navLocation) tea.Cmd {
+	selectLocation(c.treeMenu, location.selected)
+	return nil
+}
+
+// saveLocation implements locationView (the shells tree)
+func (c *shellController) saveLocation(m *Model, location *navLocation) {
+	location.selected = treeLocation(c.treeMenu)
+}
+
+// restoreLocation implements locationView
+func (c *shellController) restoreLocation(m *Model, location navLocation) tea.Cmd {
+	selectLocation(c.treeMenu, location.selected)
+	return nil
+}
+
+// saveLocation implements locationView (the file tree)
+func (c *gitController) saveLocation(m *Model, location *navLocation) {
+	location.selected = treeLocation(c.menu)
+}
+
+// restoreLocation implements locationView (the diff follows the selection)
+func (c *gitController) restoreLocation(m *Model, location navLocation) tea.Cmd {
+	selectLocation(c.menu, location.selected)
+	return m.loadGitDiffForSelection()
+}
+
+// saveLocation implements locationView (the filters and the scroll)
+func (c *logsController) saveLocation(m *Model, location *navLocation) {
+	location.logSource = c.sourceFilter
+	location.logType = c.typeFilter
+	location.logLevel = c.levelFilter
+	location.logSearch = c.searchText
+	location.logScroll = c.scrollOffset
+}
+
+// restoreLocation implements locationView
+func (c *logsController) restoreLocation(m *Model, location navLocation) tea.Cmd {
+	if c.sourceFilter != location.logSource {
+		c.follow = false
+	}
+	c.sourceFilter = location.logSource
+	c.typeFilter = location.logType
+	c.levelFilter = location.logLevel
+	c.searchText = location.logSearch
+	c.scrollOffset = location.logScroll
+	c.autoScroll = location.logScroll == 0
+	return nil
+}
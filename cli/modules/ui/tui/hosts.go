@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/hosts"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// hostsPanel is the overlay of the dev hostnames of the components and the
+// block of the hosts file managed by DevTrack (^G h)
+type hostsPanel struct {
+	scroll int // First visible line
+	height int // Visible lines (set at render)
+}
+
+// hostsSudoMsg is sent when the interactive sudo copy of the hosts file exits
+type hostsSudoMsg struct {
+	err error
+}
+
+// openHosts opens the dev hostnames panel
+func (m *Model) openHosts() tea.Cmd {
+	m.hosts = &hostsPanel{}
+	if m.state.Projects != nil {
+		m.state.Projects.Hosts = nil // Loading until the check arrives
+	}
+	return m.sendEvent(core.NewEvent(core.EventHostsCheck))
+}
+
+// hostsVM returns the state of the open panel, nil while loading
+func (m *Model) hostsVM() *core.HostsVM {
+	if m.state.Projects == nil {
+		return nil
+	}
+	return m.state.Projects.Hosts
+}
+
+// handleHostsKey handles the keys of the hostnames panel: apply or remove
+// the managed block, sudo, recheck, copy, scroll, close
+func (m *Model) handleHostsKey(msg tea.KeyMsg) tea.Cmd {
+	p := m.hosts
+	vm := m.hostsVM()
+	switch msg.String() {
+	case "esc", "q":
+		m.hosts = nil
+	case "up", "k":
+		p.scroll = max(p.scroll-1, 0)
+	case "down", "j":
+		p.scroll++
+	case "pgup", "shift+up":
+		p.scroll = max(p.scroll-p.height, 0)
+	case "pgdown", "shift+down":
+		p.scroll += p.height
+	case "r":
+		return m.sendEvent(core.NewEvent(core.EventHostsCheck))
+	case "a":
+		if vm == nil || vm.Block == "" {
+			m.lastError = "No hostnames to map: add hostnames: to a component"
+			m.lastErrorTime = time.Now()
+			return nil
+		}
+		return m.sendEvent(core.NewEvent(core.EventHostsApply).WithValue("apply"))
+	case "d":
+		return m.sendEvent(core.NewEvent(core.EventHostsApply).WithValue("remove"))
+	case "S":
+		if vm == nil || vm.Staged == "" || vm.Sudo == "" {
+			return nil
+		}
+		if m.blockReadOnly("sudo") {
+			return nil
+		}
+		return tea.ExecProcess(hosts.SudoCopyCommand(vm.Sudo, vm.Staged, vm.Path), func(err error) tea.Msg {
+			return hostsSudoMsg{err: err}
+		})
+	case "y":
+		if vm != nil && vm.Block != "" {
+			return m.yankText("hosts block", vm.Block)
+		}
+	}
+	return nil
+}
+
+// handleHostsSudoDone reports the interactive sudo copy, then checks the
+// hosts file again
+func (m *Model) handleHostsSudoDone(msg hostsSudoMsg) tea.Cmd {
+	if msg.err != nil {
+		m.lastError = fmt.Sprintf("sudo failed: %v", msg.err)
+		m.lastErrorTime = time.Now()
+	}
+	return m.sendEvent(core.NewEvent(core.EventHostsCheck))
+}
+
+// hostsLines renders the body of the hostnames panel
+func hostsLines(vm *core.HostsVM, spinner string, width int) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	var lines []string
+	if vm.Error != "" {
+		lines = append(lines, StatusError.Render("✗ "+vm.Error), "")
+	}
+	if len(vm.Mappings) == 0 {
+		lines = append(lines,
+			mutedStyle.Render("No hostnames: add them to the components, e.g."),
+			"  hostnames: [app.test, acme.app.test, \"*.app.test\"]",
+			"")
+	}
+
+	var wildcards []string
+	for _, mapping := range vm.Mappings {
+		status := StatusError.Render("✗ not resolving")
+		switch {
+		case vm.Checking:
+			status = mutedStyle.Render(spinner + " resolving")
+		case mapping.Resolves:
+			status = StatusSuccess.Render("✓ resolves")
+		case mapping.Wildcard:
+			status = StatusWarning.Render("⚠ needs a local DNS")
+		case mapping.Builtin:
+			status = StatusSuccess.Render("✓ in the browsers")
+		}
+		host := mapping.Host
+		if mapping.Managed {
+			host += mutedStyle.Render(" (hosts)")
+		} else if mapping.Builtin {
+			host += mutedStyle.Render(" (.localhost)")
+		}
+		host += strings.Repeat(" ", max(34-lipgloss.Width(host), 2))
+		lines = append(lines, host+status+"  "+mutedStyle.Render(mapping.Target))
+		if mapping.URL != "" {
+			lines = append(lines, "   "+hyperlink(mapping.URL, mapping.URL))
+		}
+		if mapping.Wildcard {
+			wildcards = append(wildcards, hosts.DNSHint(mapping.Host))
+		}
+	}
+
+	if vm.Block != "" {
+		lines = append(lines, "", SubtitleStyle.Render("Block of "+vm.Path+":"))
+		if vm.UpToDate {
+			lines = append(lines, StatusSuccess.Render("✓ up to date"))
+		} else {
+			lines = append(lines, StatusWarning.Render("⚠ missing or outdated: a to write it"))
+		}
+		for _, line := range strings.Split(vm.Block, "\n") {
+			lines = append(lines, "  "+line)
+		}
+		switch {
+		case vm.Staged != "":
+			lines = append(lines, "", StatusWarning.Render("sudo needs your password: S to enter it in the terminal"))
+		case vm.Writable:
+			lines = append(lines, mutedStyle.Render("Writable: written directly"))
+		case vm.Sudo != "":
+			lines = append(lines, mutedStyle.Render("Written with sudo (password asked in the terminal if needed)"))
+		default:
+			lines = append(lines, StatusWarning.Render("Not writable and no sudo: y to copy the block and add it by hand"))
+		}
+	}
+
+	if len(wildcards) > 0 {
+		lines = append(lines, "",
+			SubtitleStyle.Render("Wildcards (a hosts file cannot map them):"),
+			mutedStyle.Render("  with dnsmasq, add to /etc/dnsmasq.d/devtrack.conf:"))
+		for _, hint := range wildcards {
+			lines = append(lines, "  "+hint)
+		}
+		lines = append(lines, mutedStyle.Render("  or use *.localhost names, resolved by the browsers without any entry"))
+	}
+
+	for i, line := range lines {
+		lines[i] = truncateANSI(line, width)
+	}
+	return lines
+}
+
+// renderHostsOverlay renders the hostnames panel
+func (m *Model) renderHostsOverlay(width, height int) string {
+	p := m.hosts
+	boxWidth := min(width-4, 110)
+	innerWidth := boxWidth - 6 // Border and padding
+
+	var body []string
+	if vm := m.hostsVM(); vm == nil {
+		body = append(body, SubtitleStyle.Render("Checking..."))
+	} else {
+		body = hostsLines(vm, m.spinner.View(), innerWidth)
+	}
+
+	p.height = max(height-8, 3)
+	p.scroll = min(p.scroll, max(len(body)-p.height, 0))
+	lines := []string{
+		DialogTitleStyle.MarginBottom(0).Render("Dev hostnames"),
+		SubtitleStyle.Render("Mapped to " + hosts.LoopbackIP + " in a block of the hosts file managed by DevTrack"),
+		"",
+	}
+	lines = append(lines, body[p.scroll:min(p.scroll+p.height, len(body))]...)
+	lines = append(lines, "", strings.Join(renderKeyHints([]KeyHint{
+		{"a", "write block"}, {"d", "remove block"}, {"r", "recheck"}, {"y", "copy block"}, {"Esc", "close"},
+	}), ""))
+
+	box := DialogStyle.Padding(0, 2).Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Top,
+		lipgloss.NewStyle().MarginTop(1).Render(box),
+	)
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// hScrollStep is the number of columns scrolled per left/right key
+const hScrollStep = 8
+
+// hScroll is the horizontal view of long lines in a panel: scrolled by an
+// offset, or wrapped
+type hScroll struct {
+	offset int  // First visible column
+	wrap   bool // Lines wrapped instead of scrolled
+}
+
+// scroll moves the view by steps columns (negative = left)
+func (h *hScroll) scroll(steps int) {
+	h.offset += steps * hScrollStep
+	if h.offset < 0 {
+		h.offset = 0
+	}
+}
+
+// toggleWrap switches between wrapping and scrolling
+func (h *hScroll) toggleWrap() {
+	h.wrap = !h.wrap
+	h.offset = 0
+}
+
+// clamp limits the offset so the end of the longest line stays visible
+func (h *hScroll) clamp(longest, width int) {
+	max := 0
+	if longest > width {
+		max = longest - width + 1 // Room for the left marker
+	}
+	if h.offset > max {
+		h.offset = max
+	}
+}
+
+// view returns the rows of a plain text line in a width: one row at the
+// scroll offset (with ‹ › markers where text is hidden), or the wrapped rows
+func (h *hScroll) view(line string, width int) []string {
+	if width <= 0 {
+		return []string{""}
+	}
+	if h.wrap {
+		return strings.Split(ansi.Hardwrap(line, width, true), "\n")
+	}
+
+	total := ansi.StringWidth(line)
+	if h.offset == 0 && total <= width {
+		return []string{line}
+	}
+	left, right := "", ""
+	if h.offset > 0 {
+		left = "‹"
+		width--
+	}
+	if total-h.offset > width {
+		right = "›"
+		width--
+	}
+	if width < 0 {
+		width = 0
+	}
+	return []string{left + ansi.Cut(line, h.offset, h.offset+width) + right}
+}
+
+// status returns the scroll position shown next to the line counts
+func (h *hScroll) status() string {
+	switch {
+	case h.wrap:
+		return "wrap"
+	case h.offset > 0:
+		return fmt.Sprintf("col %d", h.offset+1)
+	}
+	return ""
+}
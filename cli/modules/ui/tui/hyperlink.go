@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hyperlinks is true when URLs are rendered as clickable OSC 8 hyperlinks
+var hyperlinks bool
+
+// SetHyperlinks sets the hyperlinks setting: "on", "off", or "auto" (also
+// empty) to use them when the terminal is known to support them
+func SetHyperlinks(mode string) {
+	switch mode {
+	case "on":
+		hyperlinks = true
+	case "off":
+		hyperlinks = false
+	default:
+		hyperlinks = terminalSupportsHyperlinks()
+	}
+}
+
+// terminalSupportsHyperlinks detects the terminals rendering OSC 8
+// hyperlinks. Multiplexers are left out: tmux and screen drop them unless
+// configured to pass them through (hyperlinks: on).
+func terminalSupportsHyperlinks() bool {
+	if os.Getenv("TMUX") != "" || strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		return false
+	}
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper", "Tabby":
+		return true
+	}
+	if os.Getenv("KITTY_WINDOW_ID") != "" || os.Getenv("WT_SESSION") != "" {
+		return true
+	}
+	if v, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && v >= 5000 {
+		return true // GNOME Terminal, Tilix, Terminator...
+	}
+	if v, err := strconv.Atoi(os.Getenv("KONSOLE_VERSION")); err == nil && v >= 201200 {
+		return true
+	}
+	term := os.Getenv("TERM")
+	for _, name := range []string{"kitty", "foot", "alacritty", "wezterm", "ghostty"} {
+		if strings.Contains(term, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// hyperlink makes text a link to url when hyperlinks are enabled
+func hyperlink(url, text string) string {
+	if !hyperlinks || url == "" {
+		return text
+	}
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// urlPattern matches the URLs of a rendered line (escape sequences and the
+// truncation ellipsis end them)
+var urlPattern = regexp.MustCompile(`https?://[^\s\x1b'"<>…]+`)
+
+// linkURLs makes the URLs of a rendered line clickable. A URL cut by the
+// truncation links to the full URL of the source text.
+func linkURLs(rendered, source string) string {
+	if !hyperlinks || !strings.Contains(rendered, "://") {
+		return rendered
+	}
+	full := urlPattern.FindAllString(source, -1)
+	return urlPattern.ReplaceAllStringFunc(rendered, func(fragment string) string {
+		// Closing punctuation is part of the sentence, not of the URL
+		text := strings.TrimRight(fragment, ".,;:!?)]}")
+		target := text
+		for _, url := range full {
+			if strings.HasPrefix(url, text) {
+				target = strings.TrimRight(url, ".,;:!?)]}")
+				break
+			}
+		}
+		return hyperlink(target, text) + fragment[len(text):]
+	})
+}
@@ -0,0 +1,213 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/containers"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// imageHistoryShown is the number of recent images listed in the Build view
+const imageHistoryShown = 5
+
+// buildImageSelected builds the container image of the selected project
+// with the current build profile, followed in the Build view
+func (m *Model) buildImageSelected() tea.Cmd {
+	projectID := m.getSelectedProjectID()
+	if projectID == "" {
+		m.lastError = "No project selected"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	return m.buildImage(projectID)
+}
+
+// buildImage builds the container image of a project
+func (m *Model) buildImage(projectID string) tea.Cmd {
+	if proj := m.findProjectVM(projectID); proj == nil || !proj.HasImage {
+		m.lastError = "No Dockerfile in this project"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	if m.state.Capabilities != nil && !m.state.Capabilities.HasDocker() {
+		m.lastError = "Image build: docker or podman not found"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	// Switch to Build view to show the layers
+	m.currentView = core.VMBuild
+	m.sidebarIndex = 2 // Build view index
+	m.sidebarMenu.SetSelectedIndex(2)
+
+	return m.sendEvent(core.NewEvent(core.EventImageBuild).WithProject(projectID).
+		WithData("profile", m.buildView().profile))
+}
+
+// rebuildImage builds again the image of the Build view (the last image
+// built, or else the project of the current build)
+func (m *Model) rebuildImage() tea.Cmd {
+	vm := m.state.Builds
+	switch {
+	case vm == nil:
+		return nil
+	case vm.ImageBuild != nil:
+		return m.buildImage(vm.ImageBuild.ProjectID)
+	case vm.CurrentBuild != nil:
+		return m.buildImage(vm.CurrentBuild.ProjectID)
+	}
+	m.lastError = "No image built yet: press 'i' on a project"
+	m.lastErrorTime = time.Now()
+	return nil
+}
+
+// imageBuildToCancel returns the project of the running image build the
+// current view is about, empty if none
+func (m *Model) imageBuildToCancel() string {
+	if m.state.Builds == nil || m.state.Builds.ImageBuild == nil {
+		return ""
+	}
+	b := m.state.Builds.ImageBuild
+	if b.State != containers.BuildRunning {
+		return ""
+	}
+	if m.currentView == core.VMBuild || m.getSelectedProjectID() == b.ProjectID {
+		return b.ProjectID
+	}
+	return ""
+}
+
+// renderImageBuild renders the image build of the Build view: its status,
+// its steps (layers) and the last output lines
+func (m *Model) renderImageBuild(b *core.ImageBuildVM, width, maxLines int) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	tags := mutedStyle.Render(strings.Join(b.Tags, " "))
+	duration := b.Duration().Round(time.Second).String()
+	var lines []string
+	switch b.State {
+	case containers.BuildRunning:
+		lines = append(lines, fmt.Sprintf("%s Building image of %s [%s] %s %s",
+			m.spinner.View(), b.ProjectName, strings.ToUpper(b.Profile), renderProgressBar(b.Progress(), 20), tags))
+	case containers.BuildSuccess:
+		summary := fmt.Sprintf("%s Built image of %s (%s", StatusSuccess.Render(IconSuccess), b.ProjectName, duration)
+		if b.Size > 0 {
+			summary += ", " + formatSize(b.Size) + imageSizeDelta(b.SizeDelta)
+		}
+		lines = append(lines, summary+") "+tags)
+	case containers.BuildCanceled:
+		lines = append(lines, fmt.Sprintf("%s Image build of %s canceled (%s) %s",
+			StatusWarning.Render(IconWarning), b.ProjectName, duration, tags))
+	default:
+		lines = append(lines, fmt.Sprintf("%s Image build of %s failed (%s) %s",
+			StatusError.Render(IconError), b.ProjectName, duration, tags))
+		if b.Error != "" {
+			lines = append(lines, LogErrorStyle.Render("  "+truncate(b.Error, width-4)))
+		}
+	}
+
+	// Steps, the last ones if they don't fit: half of the room, the other
+	// half is for the output while building
+	var steps []string
+	for _, step := range b.Steps {
+		icon := m.spinner.View()
+		switch step.State {
+		case containers.StepDone:
+			icon = StatusSuccess.Render("✓")
+		case containers.StepCached:
+			icon = mutedStyle.Render("↺")
+		case containers.StepFailed:
+			icon = StatusError.Render("✗")
+		case containers.StepCanceled:
+			icon = mutedStyle.Render("○")
+		}
+		detail := ""
+		switch {
+		case step.State == containers.StepCached:
+			detail = "cached"
+		case step.DurationMs > 0:
+			detail = (time.Duration(step.DurationMs) * time.Millisecond).Round(100 * time.Millisecond).String()
+		}
+		steps = append(steps, fmt.Sprintf("  %s %s %s", icon,
+			truncate(step.Instruction, width-len(detail)-6), mutedStyle.Render(detail)))
+	}
+	room := max(maxLines-len(lines), 1)
+	if b.State == containers.BuildRunning {
+		room = max(room/2, 1)
+	}
+	if len(steps) > room {
+		steps = append([]string{mutedStyle.Render(fmt.Sprintf("  ... %d steps before", len(steps)-room+1))}, steps[len(steps)-room+1:]...)
+	}
+	lines = append(lines, steps...)
+
+	if b.State == containers.BuildRunning {
+		output := b.Output
+		if room := maxLines - len(lines); len(output) > room {
+			output = output[len(output)-max(room, 0):]
+		}
+		for _, line := range output {
+			lines = append(lines, LogInfoStyle.Render(truncate(line, width-4)))
+		}
+	}
+	return lines
+}
+
+// renderImageHistory renders the recent image builds with their size and
+// its change since the previous image of the project and profile
+func (m *Model) renderImageHistory(vm *core.BuildsVM) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	var lines []string
+	for i, b := range vm.ImageHistory {
+		if i >= imageHistoryShown {
+			break
+		}
+		var line string
+		switch b.State {
+		case containers.BuildSuccess:
+			line = fmt.Sprintf("  %s %s %s", StatusSuccess.Render(IconSuccess), truncate(b.Image(), 30), b.Duration().Round(time.Second))
+			if b.Size > 0 {
+				line += " " + formatSize(b.Size) + imageSizeDelta(b.SizeDelta)
+			}
+		case containers.BuildCanceled:
+			line = fmt.Sprintf("  %s %s %s", mutedStyle.Render("○"), truncate(b.Image(), 30), mutedStyle.Render("canceled"))
+		default:
+			line = fmt.Sprintf("  %s %s %s", StatusError.Render(IconError), truncate(b.Image(), 30), mutedStyle.Render("failed"))
+		}
+		line += " " + SubtitleStyle.Render(strings.ToUpper(b.Profile)+" "+b.FinishedAt.Format("01-02 15:04"))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// imageSizeDelta renders the change of an image size, empty if none
+func imageSizeDelta(delta int64) string {
+	switch {
+	case delta > 0:
+		return StatusWarning.Render(" +" + formatSize(delta))
+	case delta < 0:
+		return StatusSuccess.Render(" -" + formatSize(-delta))
+	}
+	return ""
+}
+
+// projectImageLine summarizes the last image built of a project
+func (m *Model) projectImageLine(projectID string) string {
+	if m.state.Builds != nil {
+		for _, b := range m.state.Builds.ImageHistory {
+			if b.ProjectID == projectID && b.State == containers.BuildSuccess {
+				line := "Image: " + b.Image()
+				if b.Size > 0 {
+					line += " " + formatSize(b.Size)
+				}
+				return line + SubtitleStyle.Render(" (i to rebuild)")
+			}
+		}
+	}
+	return "Dockerfile: " + SubtitleStyle.Render("i to build the image")
+}
@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"csd-devtrack/cli/modules/platform/fileutil"
+	"csd-devtrack/cli/modules/platform/logger"
+)
+
+// inputHistoryLimit is the number of entries kept per field
+const inputHistoryLimit = 100
+
+// Fields with an input history
+const (
+	historyClaude    = "claude"     // Claude chat input
+	historyLogSearch = "log_search" // Logs view search
+	historyFilter    = "filter"     // Filter overlay
+	historyDialog    = "dialog:"    // Dialog inputs, followed by the dialog type
+)
+
+// inputHistory keeps the entries submitted in the text fields, per field,
+// persisted in a JSON file of the data dir. Up/Down recall them like a shell
+// history.
+type inputHistory struct {
+	file    string              // Store file (empty = not persisted)
+	entries map[string][]string // Field -> entries, oldest first
+
+	// Recall in progress
+	field string // Field recalled ("" = none)
+	pos   int    // Index of the recalled entry (len = back to the draft)
+	draft string // Text typed before recalling
+}
+
+// newInputHistory creates a history persisted in file
+func newInputHistory(file string) *inputHistory {
+	h := &inputHistory{file: file, entries: make(map[string][]string)}
+	h.load()
+	return h
+}
+
+// Add records an entry submitted in a field (a repeated entry moves to the
+// end) and ends the recall
+func (h *inputHistory) Add(field, entry string) {
+	h.Reset()
+	if strings.TrimSpace(entry) == "" {
+		return
+	}
+
+	entries := h.entries[field]
+	for i, existing := range entries {
+		if existing == entry {
+			entries = append(entries[:i], entries[i+1:]...)
+			break
+		}
+	}
+	entries = append(entries, entry)
+	if len(entries) > inputHistoryLimit {
+		entries = entries[len(entries)-inputHistoryLimit:]
+	}
+	h.entries[field] = entries
+
+	if err := h.save(); err != nil {
+		logger.Warn("Failed to save the input history: %v", err)
+	}
+}
+
+// Prev returns the entry before the recalled one, starting from the newest
+// (false at the oldest). current is kept as the draft Next returns to.
+func (h *inputHistory) Prev(field, current string) (string, bool) {
+	entries := h.entries[field]
+	if h.field != field {
+		h.field, h.pos, h.draft = field, len(entries), current
+	}
+	if h.pos == 0 {
+		return "", false
+	}
+	h.pos--
+	return entries[h.pos], true
+}
+
+// Next returns the entry after the recalled one, then the draft (false when
+// not recalling)
+func (h *inputHistory) Next(field string) (string, bool) {
+	entries := h.entries[field]
+	if h.field != field || h.pos >= len(entries) {
+		return "", false
+	}
+	h.pos++
+	if h.pos == len(entries) {
+		return h.draft, true
+	}
+	return entries[h.pos], true
+}
+
+// Reset ends the recall (the field was submitted or left)
+func (h *inputHistory) Reset() {
+	h.field, h.pos, h.draft = "", 0, ""
+}
+
+// load reads the history file
+func (h *inputHistory) load() {
+	if h.file == "" {
+		return
+	}
+	data, err := os.ReadFile(h.file)
+	if err != nil {
+		return // Nothing typed yet
+	}
+	if err := json.Unmarshal(data, &h.entries); err != nil || h.entries == nil {
+		h.entries = make(map[string][]string)
+	}
+}
+
+// save writes the history to disk atomically
+func (h *inputHistory) save() error {
+	if h.file == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(h.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.file), 0755); err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(h.file, data, 0600)
+}
@@ -0,0 +1,676 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/logger"
+	"csd-devtrack/cli/modules/platform/system"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// internalsRefreshInterval is the refresh interval of the Internals view
+const internalsRefreshInterval = 3 * time.Second
+
+// Kinds of the resources spawned by DevTrack
+const (
+	internalsTmux      = "tmux"      // Tmux session (cdt-*)
+	internalsPTY       = "pty"       // Native PTY terminal
+	internalsComponent = "component" // Managed project component
+	internalsChild     = "child"     // Other child process (builds, plugins, editors...)
+)
+
+// Status of a tmux session
+const (
+	internalsOpen      = "open"      // Shown by an embedded terminal
+	internalsSuspended = "suspended" // Detached while idle, resumed when opened
+	internalsOrphan    = "orphan"    // Not tracked (left by a previous run)
+)
+
+// internalsKinds lists the kinds in display order with their group label
+var internalsKinds = []struct {
+	kind  string
+	label string
+	icon  string
+}{
+	{internalsTmux, "Tmux sessions", "▣"},
+	{internalsPTY, "PTY terminals", "▢"},
+	{internalsComponent, "Components", "⚙"},
+	{internalsChild, "Child processes", "◇"},
+}
+
+// internalsItem is a tmux session or process spawned by DevTrack
+type internalsItem struct {
+	Kind      string
+	Name      string // Tmux session, terminal session, component or executable
+	SessionID string // Terminal session (empty for orphans and processes)
+	Status    string
+	ProjectID string                 // Component project
+	Component projects.ComponentType // Component type
+	PIDs      []int                  // Root processes (the panes of a tmux session)
+	Attached  int                    // Tmux clients attached (pop-outs)
+	Created   time.Time
+	Usage     system.ProcessUsage // Summed over the process trees
+	CPU       float64             // CPU % since the previous scan (-1 = unknown)
+}
+
+// key identifies an item between scans
+func (i internalsItem) key() string {
+	if i.Kind == internalsTmux {
+		return i.Kind + ":" + i.Name
+	}
+	return fmt.Sprintf("%s:%d", i.Kind, i.PIDs[0])
+}
+
+// internalsOwners is what the TUI tracks, captured before a scan
+type internalsOwners struct {
+	tmux       map[string]string // Tmux session -> terminal session ID
+	suspended  map[string]string // Detached tmux session -> suspended terminal ID
+	ptys       map[int]string    // PID -> PTY terminal session ID
+	components []core.ProcessVM
+}
+
+// Messages of the Internals view
+type (
+	// internalsScanMsg carries the resources found by a scan
+	internalsScanMsg struct {
+		items []internalsItem
+		at    time.Time
+		err   error
+	}
+
+	// internalsTickMsg refreshes the Internals view while it is shown
+	internalsTickMsg struct{}
+
+	// orphanTmuxMsg reports the tmux sessions left by a previous run
+	orphanTmuxMsg struct {
+		count int
+	}
+)
+
+// countOrphanTmuxSessions counts the DevTrack tmux sessions not in keep
+func countOrphanTmuxSessions(keep map[string]bool) tea.Cmd {
+	return func() tea.Msg {
+		output, err := exec.Command("tmux", "ls", "-F", "#{session_name}").Output()
+		if err != nil {
+			return orphanTmuxMsg{} // No tmux server
+		}
+		count := 0
+		for _, name := range strings.Fields(string(output)) {
+			if strings.HasPrefix(name, "cdt-") && !keep[name] {
+				count++
+			}
+		}
+		return orphanTmuxMsg{count: count}
+	}
+}
+
+// internalsController is the submodel of the Internals view
+type internalsController struct {
+	menu *TreeMenu // Tree menu grouping the resources by kind
+
+	items     []internalsItem
+	scannedAt time.Time
+	scanning  bool
+	ticking   bool               // Refresh loop running
+	cpuTimes  map[string]float64 // Item key -> CPU seconds at the previous scan
+
+	pendingKill []internalsItem // Items to kill (saved at dialog open)
+}
+
+// newInternalsController creates the Internals view controller
+func newInternalsController() *internalsController {
+	menu := NewTreeMenu(nil)
+	menu.SetTitle("Internals")
+	return &internalsController{menu: menu, cpuTimes: make(map[string]float64)}
+}
+
+// Init implements ViewController
+func (c *internalsController) Init(m *Model) tea.Cmd {
+	cmd := c.scan(m)
+	if c.ticking {
+		return cmd
+	}
+	c.ticking = true
+	return tea.Batch(cmd, internalsTick())
+}
+
+// Menu implements ViewController
+func (c *internalsController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	if focus != FocusMain {
+		return nil
+	}
+	return c.menu
+}
+
+// Keys implements ViewController
+func (c *internalsController) Keys(m *Model) []KeyHint {
+	return []KeyHint{
+		{"Enter", "expand"},
+		{"x", "kill"},
+		{"c", "clean orphans"},
+		{"r", "refresh"},
+	}
+}
+
+// Update implements ViewController
+func (c *internalsController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case internalsScanMsg:
+		c.handleScan(msg)
+		return nil, true
+	case internalsTickMsg:
+		if m.currentView != core.VMInternals {
+			c.ticking = false
+			return nil, true
+		}
+		return tea.Batch(c.scan(m), internalsTick()), true
+	case selectMsg:
+		c.menu.Select()
+		return nil, true
+	case dialogConfirmMsg:
+		if msg.dialogType != "internals_kill" {
+			return nil, false
+		}
+		items := c.pendingKill
+		c.pendingKill = nil
+		return c.kill(m, items), true
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			return c.scan(m), true
+		case "x":
+			return c.confirmKill(m), true
+		case "c":
+			return c.confirmCleanOrphans(m), true
+		}
+	}
+	return nil, false
+}
+
+// internalsTick schedules the next refresh of the Internals view
+func internalsTick() tea.Cmd {
+	return tea.Tick(internalsRefreshInterval, func(time.Time) tea.Msg {
+		return internalsTickMsg{}
+	})
+}
+
+// scan lists the resources in background (nil if a scan is running)
+func (c *internalsController) scan(m *Model) tea.Cmd {
+	if c.scanning {
+		return nil
+	}
+	c.scanning = true
+	owners := m.internalsOwners()
+	return func() tea.Msg {
+		return scanInternals(owners)
+	}
+}
+
+// handleScan stores the scanned items and computes their CPU usage
+func (c *internalsController) handleScan(msg internalsScanMsg) {
+	c.scanning = false
+	if msg.err != nil {
+		logger.Warn("Failed to list the processes: %v", msg.err)
+		return
+	}
+
+	elapsed := msg.at.Sub(c.scannedAt).Seconds()
+	cpuTimes := make(map[string]float64, len(msg.items))
+	for i := range msg.items {
+		item := &msg.items[i]
+		item.CPU = -1
+		if prev, ok := c.cpuTimes[item.key()]; ok && elapsed > 0 && item.Usage.CPUTime >= prev {
+			item.CPU = (item.Usage.CPUTime - prev) / elapsed * 100
+		}
+		cpuTimes[item.key()] = item.Usage.CPUTime
+	}
+	c.items = msg.items
+	c.cpuTimes = cpuTimes
+	c.scannedAt = msg.at
+	c.updateMenu()
+}
+
+// internalsOwners captures the terminals and components the TUI tracks
+func (m *Model) internalsOwners() internalsOwners {
+	owners := internalsOwners{
+		tmux:      make(map[string]string),
+		suspended: make(map[string]string),
+		ptys:      make(map[int]string),
+	}
+	if m.terminalManager != nil {
+		for _, sessionID := range m.terminalManager.GetRunning() {
+			switch t := m.terminalManager.Get(sessionID).(type) {
+			case *TerminalTmux:
+				owners.tmux[t.tmuxName] = sessionID
+			case interface{ PID() int }:
+				if pid := t.PID(); pid > 0 {
+					owners.ptys[pid] = sessionID
+				}
+			}
+		}
+	}
+	if m.suspended != nil {
+		for _, sess := range m.suspended.List() {
+			if !sess.Stopped {
+				owners.suspended[sess.TmuxName] = sess.ID
+			}
+		}
+	}
+	if m.state.Processes != nil {
+		owners.components = append(owners.components, m.state.Processes.Processes...)
+	}
+	return owners
+}
+
+// scanInternals lists the DevTrack tmux sessions and child processes with
+// the resource usage of their process trees
+func scanInternals(owners internalsOwners) internalsScanMsg {
+	table, err := system.ReadProcessTable()
+	if err != nil {
+		return internalsScanMsg{err: err}
+	}
+	var items []internalsItem
+
+	// Tmux sessions, whose processes are children of the tmux server
+	output, _ := exec.Command("tmux", "list-panes", "-a", "-F",
+		"#{session_name}\t#{pane_pid}\t#{session_attached}\t#{session_created}").Output()
+	sessions := make(map[string]*internalsItem)
+	var names []string
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "cdt-") {
+			continue
+		}
+		name := fields[0]
+		item, ok := sessions[name]
+		if !ok {
+			item = &internalsItem{Kind: internalsTmux, Name: name, Status: internalsOrphan}
+			if id, ok := owners.tmux[name]; ok {
+				item.SessionID, item.Status = id, internalsOpen
+			} else if id, ok := owners.suspended[name]; ok {
+				item.SessionID, item.Status = id, internalsSuspended
+			}
+			item.Attached, _ = strconv.Atoi(fields[2])
+			if created, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+				item.Created = time.Unix(created, 0)
+			}
+			sessions[name] = item
+			names = append(names, name)
+		}
+		if pid, err := strconv.Atoi(fields[1]); err == nil {
+			item.PIDs = append(item.PIDs, pid)
+		}
+	}
+	for _, name := range names {
+		item := sessions[name]
+		for _, pid := range item.PIDs {
+			if usage, ok := table.Usage(pid); ok {
+				addUsage(&item.Usage, usage)
+			}
+		}
+		items = append(items, *item)
+	}
+
+	// Processes spawned by DevTrack itself
+	covered := make(map[int]bool)
+	for pid, sessionID := range owners.ptys {
+		covered[pid] = true
+		if usage, ok := table.Usage(pid); ok {
+			items = append(items, internalsItem{Kind: internalsPTY, Name: sessionID, SessionID: sessionID,
+				Status: internalsOpen, PIDs: []int{pid}, Created: usage.Started, Usage: usage})
+		}
+	}
+	for _, proc := range owners.components {
+		if proc.PID <= 0 || proc.IsSelf {
+			continue
+		}
+		covered[proc.PID] = true
+		if usage, ok := table.Usage(proc.PID); ok {
+			items = append(items, internalsItem{Kind: internalsComponent,
+				Name:   fmt.Sprintf("%s/%s", proc.ProjectName, proc.Component),
+				Status: string(proc.State), ProjectID: proc.ProjectID, Component: proc.Component,
+				PIDs: []int{proc.PID}, Created: usage.Started, Usage: usage})
+		}
+	}
+	for _, pid := range table.Children(os.Getpid()) {
+		if covered[pid] {
+			continue
+		}
+		usage, ok := table.Usage(pid)
+		// Short-lived tmux clients (capture-pane, list-panes...) are not resources
+		if !ok || usage.Name == "tmux" {
+			continue
+		}
+		items = append(items, internalsItem{Kind: internalsChild, Name: usage.Name,
+			Status: "running", PIDs: []int{pid}, Created: usage.Started, Usage: usage})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Kind != items[j].Kind {
+			return internalsKindIndex(items[i].Kind) < internalsKindIndex(items[j].Kind)
+		}
+		return items[i].Name < items[j].Name
+	})
+	return internalsScanMsg{items: items, at: time.Now()}
+}
+
+// addUsage adds the usage of a process tree to a total
+func addUsage(total *system.ProcessUsage, usage system.ProcessUsage) {
+	if total.PID == 0 {
+		total.PID, total.Name, total.Command, total.Started = usage.PID, usage.Name, usage.Command, usage.Started
+	}
+	total.Procs += usage.Procs
+	total.RSS += usage.RSS
+	total.CPUTime += usage.CPUTime
+}
+
+// internalsKindIndex returns the display position of a kind
+func internalsKindIndex(kind string) int {
+	for i, k := range internalsKinds {
+		if k.kind == kind {
+			return i
+		}
+	}
+	return len(internalsKinds)
+}
+
+// updateMenu rebuilds the tree of resources grouped by kind
+func (c *internalsController) updateMenu() {
+	var groups []TreeMenuItem
+	for _, k := range internalsKinds {
+		group := TreeMenuItem{
+			ID:        "internals:" + k.kind,
+			Icon:      k.icon,
+			IconColor: ColorSecondary,
+			Data:      k.kind,
+		}
+		var rss uint64
+		for _, item := range c.items {
+			if item.Kind != k.kind {
+				continue
+			}
+			rss += item.Usage.RSS
+			group.Children = append(group.Children, TreeMenuItem{
+				ID:        "internals:" + item.key(),
+				Label:     fmt.Sprintf("%s  %s  %s", item.Name, formatSize(int64(item.Usage.RSS)), formatCPU(item.CPU)),
+				Icon:      "●",
+				IconColor: internalsStatusColor(item.Status),
+				Data:      item,
+			})
+		}
+		if len(group.Children) == 0 {
+			continue
+		}
+		group.Label = fmt.Sprintf("%s  %s", k.label, formatSize(int64(rss)))
+		group.Count = len(group.Children)
+		groups = append(groups, group)
+	}
+	c.menu.SetItems(groups)
+}
+
+// internalsStatusColor returns the color of a status
+func internalsStatusColor(status string) lipgloss.TerminalColor {
+	switch status {
+	case internalsOrphan:
+		return ColorWarning
+	case internalsSuspended:
+		return ColorMuted
+	default:
+		return ColorSuccess
+	}
+}
+
+// formatCPU formats a CPU percentage ("-" until measured)
+func formatCPU(cpu float64) string {
+	if cpu < 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", cpu)
+}
+
+// selectedItems returns the selected item, or the items of the selected group
+func (c *internalsController) selectedItems() []internalsItem {
+	selected := c.menu.SelectedItem()
+	if selected == nil {
+		return nil
+	}
+	switch data := selected.Data.(type) {
+	case internalsItem:
+		return []internalsItem{data}
+	case string:
+		var items []internalsItem
+		for _, item := range c.items {
+			if item.Kind == data {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	return nil
+}
+
+// orphans returns the tmux sessions not tracked by any terminal
+func (c *internalsController) orphans() []internalsItem {
+	var items []internalsItem
+	for _, item := range c.items {
+		if item.Kind == internalsTmux && item.Status == internalsOrphan {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// confirmKill opens the confirmation dialog for the selected item or group
+func (c *internalsController) confirmKill(m *Model) tea.Cmd {
+	if m.blockReadOnly("kill processes") {
+		return nil
+	}
+	items := c.selectedItems()
+	if len(items) == 0 {
+		m.lastError = "Nothing to kill"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	c.pendingKill = items
+	message := fmt.Sprintf("Kill %s (%d process(es), %s)?", items[0].Name,
+		items[0].Usage.Procs, formatSize(int64(items[0].Usage.RSS)))
+	if len(items) > 1 {
+		message = fmt.Sprintf("Kill all %d %s?", len(items), internalsKindLabel(items[0].Kind))
+	}
+	return m.openConfirmDialog(config.ConfirmInternalsKill, "internals_kill", message)
+}
+
+// confirmCleanOrphans opens the confirmation dialog to kill the orphan
+// tmux sessions
+func (c *internalsController) confirmCleanOrphans(m *Model) tea.Cmd {
+	if m.blockReadOnly("kill processes") {
+		return nil
+	}
+	orphans := c.orphans()
+	if len(orphans) == 0 {
+		m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventInfo, "No orphan tmux session"))
+		return nil
+	}
+	c.pendingKill = orphans
+	var rss uint64
+	for _, item := range orphans {
+		rss += item.Usage.RSS
+	}
+	return m.openConfirmDialog(config.ConfirmInternalsKill, "internals_kill",
+		fmt.Sprintf("Kill %d orphan tmux session(s) (%s)?", len(orphans), formatSize(int64(rss))))
+}
+
+// internalsKindLabel returns the lowercase group label of a kind
+func internalsKindLabel(kind string) string {
+	for _, k := range internalsKinds {
+		if k.kind == kind {
+			return strings.ToLower(k.label)
+		}
+	}
+	return kind
+}
+
+// kill stops the given items, then rescans
+func (c *internalsController) kill(m *Model, items []internalsItem) tea.Cmd {
+	var cmds []tea.Cmd
+	killed := 0
+	for _, item := range items {
+		var err error
+		switch {
+		case item.Kind == internalsComponent:
+			cmds = append(cmds, m.sendEvent(core.NewEvent(core.EventKillProcess).WithProject(item.ProjectID).WithComponent(item.Component)))
+		case item.Status == internalsOpen:
+			// Stopping the terminal kills its tmux session or process
+			m.terminalManager.Remove(item.SessionID)
+		case item.Kind == internalsTmux:
+			if item.Status == internalsSuspended {
+				if err := m.suspended.Remove(item.SessionID); err != nil {
+					logger.Warn("Failed to save the suspended terminals: %v", err)
+				}
+				delete(m.shellView().sshSessions, item.SessionID)
+			}
+			err = exec.Command("tmux", "kill-session", "-t", item.Name).Run()
+		default:
+			err = system.TerminateProcess(item.PIDs[0])
+		}
+		if err != nil {
+			m.lastError = fmt.Sprintf("Failed to kill %s: %v", item.Name, err)
+			m.lastErrorTime = time.Now()
+			continue
+		}
+		killed++
+	}
+
+	if killed > 0 {
+		logger.Info("Killed %d DevTrack resource(s) from the Internals view", killed)
+		m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess, fmt.Sprintf("Killed %d item(s)", killed)))
+		m.refreshSessionActivity()
+		m.updateShellTree()
+	}
+	c.scanning = false
+	return tea.Batch(append(cmds, c.scan(m))...)
+}
+
+// View implements ViewController
+// Layout: TreeMenu with resources grouped by kind on left, details on right
+func (c *internalsController) View(m *Model, width, height int) string {
+	// 2 panels side by side (TreeMenu + detail)
+	// Height: 1 × 2 = 2
+	// Width: 2 × 2 = 4
+	heightBorders := 2
+	widthBorders := 4
+	panelHeight := height - heightBorders
+	availableWidth := width - widthBorders - GapHorizontal
+
+	// Left panel - TreeMenu with the resources
+	listWidth := c.menu.CalcWidth()
+	if listWidth < 30 {
+		listWidth = 30
+	}
+	if listWidth > availableWidth/2 {
+		listWidth = availableWidth / 2
+	}
+
+	c.menu.SetSize(listWidth, panelHeight)
+	c.menu.SetFocused(m.focusArea == FocusMain)
+	listPanel := c.menu.Render()
+
+	// Right panel - details
+	detailWidth := availableWidth - listWidth
+	detailContent := c.renderDetail(m, detailWidth-4)
+
+	var detailStyle lipgloss.Style
+	if m.focusArea == FocusDetail {
+		detailStyle = FocusedBorderStyle
+	} else {
+		detailStyle = UnfocusedBorderStyle
+	}
+	detailPanel := detailStyle.Width(detailWidth - 2).Height(panelHeight).Render(detailContent)
+
+	gap := strings.Repeat(" ", GapHorizontal)
+	return lipgloss.JoinHorizontal(lipgloss.Top, listPanel, gap, detailPanel)
+}
+
+// renderDetail renders the totals and the details of the selected item
+func (c *internalsController) renderDetail(m *Model, width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	// Summary header (always shown)
+	lines := []string{PanelTitleStyle.Render("DevTrack Resources"), ""}
+	if c.scannedAt.IsZero() {
+		lines = append(lines, StatusWarning.Render(m.spinner.View()+" Scanning..."))
+		return strings.Join(lines, "\n")
+	}
+	var total system.ProcessUsage
+	cpu := 0.0
+	for _, item := range c.items {
+		addUsage(&total, item.Usage)
+		if item.CPU > 0 {
+			cpu += item.CPU
+		}
+	}
+	lines = append(lines,
+		fmt.Sprintf("Total: %s", lipgloss.NewStyle().Foreground(ColorInfo).Bold(true).Render(formatSize(int64(total.RSS)))),
+		fmt.Sprintf("Processes: %d  CPU: %.1f%%", total.Procs, cpu),
+		mutedStyle.Render("Scanned "+formatRelativeTime(c.scannedAt)))
+	if orphans := c.orphans(); len(orphans) > 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorWarning).Render(
+			fmt.Sprintf("%d orphan tmux session(s) - press c to kill them", len(orphans))))
+	}
+	lines = append(lines, "")
+
+	selected := c.menu.SelectedItem()
+	if selected == nil {
+		lines = append(lines, mutedStyle.Render("No tmux session or child process"))
+		return strings.Join(lines, "\n")
+	}
+	item, ok := selected.Data.(internalsItem)
+	if !ok {
+		lines = append(lines, SubtitleStyle.Render(selected.Label),
+			fmt.Sprintf("Items: %d", selected.Count),
+			"", mutedStyle.Render("Press → or Enter to see the items, x to kill them all"))
+		return strings.Join(lines, "\n")
+	}
+
+	lines = append(lines, SubtitleStyle.Render(item.Name))
+	lines = append(lines, fmt.Sprintf("Status:    %s", lipgloss.NewStyle().Foreground(internalsStatusColor(item.Status)).Render(item.Status)))
+	if item.SessionID != "" && item.SessionID != item.Name {
+		lines = append(lines, "Session:   "+truncate(item.SessionID, width-11))
+	}
+	pids := make([]string, len(item.PIDs))
+	for i, pid := range item.PIDs {
+		pids[i] = strconv.Itoa(pid)
+	}
+	lines = append(lines,
+		"PID:       "+strings.Join(pids, ", "),
+		fmt.Sprintf("Processes: %d", item.Usage.Procs),
+		"Memory:    "+formatSize(int64(item.Usage.RSS)),
+		fmt.Sprintf("CPU:       %s (%.1fs total)", formatCPU(item.CPU), item.Usage.CPUTime))
+	if !item.Created.IsZero() {
+		lines = append(lines, "Started:   "+formatRelativeTime(item.Created))
+	}
+	if item.Kind == internalsTmux {
+		lines = append(lines, fmt.Sprintf("Clients:   %d", item.Attached))
+	}
+	if item.Usage.Command != "" {
+		lines = append(lines, "", mutedStyle.Render("Command:"), "  "+truncate(item.Usage.Command, width-2))
+	}
+	if item.Status == internalsOrphan {
+		lines = append(lines, "", mutedStyle.Render("Not tracked by any terminal (left by a previous run?)"))
+	}
+
+	lines = append(lines, "", SubtitleStyle.Render("Actions:"))
+	lines = append(lines, HelpKeyStyle.Render("x")+" kill")
+	return strings.Join(lines, "\n")
+}
@@ -17,6 +17,7 @@ type KeyMap struct {
 	End      key.Binding
 	Tab      key.Binding
 	ShiftTab key.Binding
+	Top      key.Binding // Pressed twice (gg), vim scheme only
 
 	// Actions
 	Enter   key.Binding
@@ -48,6 +49,7 @@ type KeyMap struct {
 	// Command mode (like screen/tmux)
 	CommandPrefix key.Binding // Ctrl+Space to enter command mode
 	ExitTerminal  key.Binding // Ctrl+Tab to exit terminal mode
+	CommandLine   key.Binding // ":" enters command mode, vim scheme only
 }
 
 // DefaultKeyMap returns the default key bindings
@@ -94,6 +96,11 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("shift+tab"),
 			key.WithHelp("S-Tab", "prev panel"),
 		),
+		Top: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("gg", "go to start"),
+			key.WithDisabled(),
+		),
 
 		// Actions
 		Enter: key.NewBinding(
@@ -189,7 +196,45 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("esc"),
 			key.WithHelp("^G Esc", "exit terminal"),
 		),
+		// Command line (vim scheme): ":" then the command key, like ^G
+		CommandLine: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "command mode"),
+			key.WithDisabled(),
+		),
+	}
+}
+
+// VimKeyMap returns the default key bindings with vim-style navigation added:
+// h/l left/right, gg/G start/end, ":" command mode. j/k and / are already
+// part of the default scheme. h, l and G take precedence over the view
+// shortcuts bound to them (new home shell, view logs, Git view): use the
+// actions menu and the sidebar instead.
+func VimKeyMap() KeyMap {
+	k := DefaultKeyMap()
+	k.Left = key.NewBinding(
+		key.WithKeys("left", "h"),
+		key.WithHelp("←/h", "left"),
+	)
+	k.Right = key.NewBinding(
+		key.WithKeys("right", "l"),
+		key.WithHelp("→/l", "right"),
+	)
+	k.End = key.NewBinding(
+		key.WithKeys("end", "G"),
+		key.WithHelp("End/G", "go to end"),
+	)
+	k.Top.SetEnabled(true)
+	k.CommandLine.SetEnabled(true)
+	return k
+}
+
+// KeyMapFor returns the key bindings of a scheme (vim_keys setting)
+func KeyMapFor(vim bool) KeyMap {
+	if vim {
+		return VimKeyMap()
 	}
+	return DefaultKeyMap()
 }
 
 // ShortHelp returns a brief help display
@@ -205,7 +250,7 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		// Navigation
-		{k.Up, k.Down, k.Left, k.Right, k.PageUp, k.PageDown, k.Tab, k.ShiftTab},
+		{k.Up, k.Down, k.Left, k.Right, k.PageUp, k.PageDown, k.Top, k.End, k.Tab, k.ShiftTab},
 		// Actions
 		{k.Enter, k.Space, k.Escape, k.Refresh},
 		// Project
@@ -213,6 +258,6 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		// Git
 		{k.GitStatus, k.GitDiff, k.GitLog},
 		// Other
-		{k.Filter, k.Cancel, k.CommandPrefix, k.Help, k.Quit},
+		{k.Filter, k.Cancel, k.CommandPrefix, k.CommandLine, k.Help, k.Quit},
 	}
 }
@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Layout breakpoints (terminal size)
+const (
+	compactLayoutWidth  = 100 // Below: icon-only sidebar without context panel
+	stackedLayoutWidth  = 70  // Main area below: list and detail panels stacked
+	compactSidebarWidth = 6   // Icon-only sidebar (borders included)
+	minTerminalWidth    = 40  // Below: "too small" message instead of the UI
+	minTerminalHeight   = 12
+)
+
+// sidebarWidth returns the width of the sidebar: the full menu, or the
+// icon-only menu when collapsed (^G s) or on a narrow terminal
+func (m *Model) sidebarWidth() int {
+	if m.isSidebarCompact() {
+		return compactSidebarWidth
+	}
+	return getSidebarWidth()
+}
+
+// isSidebarCompact returns true if the sidebar shows icons only
+func (m *Model) isSidebarCompact() bool {
+	return m.sidebarCollapsed || (m.width > 0 && m.width < compactLayoutWidth)
+}
+
+// isTooSmall returns true if the terminal cannot show the UI at all
+func (m *Model) isTooSmall() bool {
+	return m.width < minTerminalWidth || m.height < minTerminalHeight
+}
+
+// renderTooSmall renders the message shown instead of the UI on a tiny terminal
+func (m *Model) renderTooSmall() string {
+	msg := lipgloss.JoinVertical(lipgloss.Center,
+		StatusWarning.Render("Terminal too small"),
+		SubtitleStyle.Render(fmt.Sprintf("%dx%d, need %dx%d", m.width, m.height, minTerminalWidth, minTerminalHeight)),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, msg)
+}
+
+// renderCompactSidebar renders the icon-only sidebar: the shortcut letter of
+// each view, the active view highlighted
+func (m *Model) renderCompactSidebar(height int) string {
+	focused := m.focusArea == FocusSidebar
+	selected := m.sidebarMenu.SelectedIndex()
+
+	var lines []string
+	for i, item := range m.sidebarMenu.Items() {
+		label, pos := StripShortcutBrackets(item.Label)
+		if label == "" {
+			continue
+		}
+		letter := label[:1]
+		if pos >= 0 {
+			letter = label[pos : pos+1]
+		}
+
+		cursor := " "
+		if focused && i == selected {
+			cursor = "▶"
+		}
+		style := lipgloss.NewStyle().Foreground(ColorTextAlt)
+		if item.IsActive {
+			style = style.Foreground(ColorPrimary).Bold(true)
+		}
+		lines = append(lines, cursor+style.Render(letter))
+	}
+
+	style := UnfocusedBorderStyle
+	if focused {
+		style = FocusedBorderStyle
+	}
+	return style.Width(compactSidebarWidth - 2).Height(height - 2).Render(strings.Join(lines, "\n"))
+}
+
+// panelLayout is the size of the list and detail panels of a view
+type panelLayout struct {
+	listWidth    int // Borders included
+	listHeight   int // Borders excluded
+	detailWidth  int // Borders included
+	detailHeight int // Borders excluded
+	stacked      bool
+}
+
+// layoutPanels sizes the list and detail panels of a main area: side by side
+// with the list at its preferred width (at least minList, at most half), or
+// stacked when the area is narrow
+func layoutPanels(width, height, preferredList, minList int) panelLayout {
+	if width < stackedLayoutWidth {
+		// 2 panels × 2 border lines
+		available := height - 4
+		listHeight := available / 2
+		return panelLayout{
+			listWidth:    width - 4,
+			listHeight:   listHeight,
+			detailWidth:  width - 4,
+			detailHeight: available - listHeight,
+			stacked:      true,
+		}
+	}
+
+	// Height: 1 level × 2 border lines = 2
+	// Width: 2 panels × 2 border chars = 4
+	panelHeight := height - 2
+	availableWidth := width - 4 - GapHorizontal
+	listWidth := preferredList
+	if listWidth < minList {
+		listWidth = minList
+	}
+	if listWidth > availableWidth/2 {
+		listWidth = availableWidth / 2
+	}
+	return panelLayout{
+		listWidth:    listWidth,
+		listHeight:   panelHeight,
+		detailWidth:  availableWidth - listWidth,
+		detailHeight: panelHeight,
+	}
+}
+
+// join places the rendered list and detail panels
+func (l panelLayout) join(list, detail string) string {
+	if l.stacked {
+		return lipgloss.JoinVertical(lipgloss.Left, list, detail)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, list, strings.Repeat(" ", GapHorizontal), detail)
+}
@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// logErrorsPanel is the state of the error groups panel of the Logs view
+type logErrorsPanel struct {
+	selected int
+}
+
+// visibleErrorGroups returns the error groups of the sources and type shown
+// in the Logs view, most recently seen first
+func (m *Model) visibleErrorGroups() []core.LogErrorGroupVM {
+	if m.state.Logs == nil {
+		return nil
+	}
+	filter := m.currentLogFilter()
+	filter.level, filter.search = "", ""
+	var groups []core.LogErrorGroupVM
+	for _, g := range m.state.Logs.ErrorGroups {
+		if filter.matches(&core.LogLineVM{Source: g.Source, Message: g.Message}) {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// toggleLogErrors shows or hides the error groups panel (in place of the
+// stack trace panel)
+func (m *Model) toggleLogErrors() {
+	if m.logsView().errors != nil {
+		m.logsView().errors = nil
+		return
+	}
+	m.logsView().traces = nil
+	m.logsView().errors = &logErrorsPanel{}
+}
+
+// handleLogErrorsKey handles the keys of the error groups panel.
+// It returns false for the keys it leaves to the Logs view.
+func (m *Model) handleLogErrorsKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	panel := m.logsView().errors
+	groups := m.visibleErrorGroups()
+	last := max(len(groups)-1, 0)
+	panel.selected = min(max(panel.selected, 0), last)
+
+	switch msg.String() {
+	case "g", "esc":
+		m.logsView().errors = nil
+	case "up", "k":
+		panel.selected = max(panel.selected-1, 0)
+	case "down", "j":
+		panel.selected = min(panel.selected+1, last)
+	case "pgup":
+		panel.selected = max(panel.selected-10, 0)
+	case "pgdown":
+		panel.selected = min(panel.selected+10, last)
+	case "home":
+		panel.selected = 0
+	case "end":
+		panel.selected = last
+	case "enter":
+		if len(groups) > 0 {
+			m.showErrorGroupLines(groups[panel.selected])
+		}
+	case "y":
+		if len(groups) > 0 {
+			return m.yankText("error", groups[panel.selected].Message), true
+		}
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// showErrorGroupLines closes the panel and filters the logs on the lines of
+// an error group: its source, errors only, the longest constant part of the
+// message
+func (m *Model) showErrorGroupLines(g core.LogErrorGroupVM) {
+	m.logsView().errors = nil
+	m.logsView().sourceFilter = g.Source
+	m.logsView().typeFilter = ""
+	m.logsView().levelFilter = "error"
+	m.logsView().searchText = ""
+	for _, part := range strings.FieldsFunc(g.Pattern, func(r rune) bool { return r == '<' || r == '>' }) {
+		if part = strings.TrimSpace(part); len(part) > len(m.logsView().searchText) && part != "n" && part != "id" && part != "hex" {
+			m.logsView().searchText = part
+		}
+	}
+	m.logsView().scrollOffset = 0
+	m.logsView().autoScroll = true
+}
+
+// renderLogErrors renders the error groups panel in place of the log lines
+func (m *Model) renderLogErrors(width, height int) []string {
+	panel := m.logsView().errors
+	groups := m.visibleErrorGroups()
+	total := 0
+	for _, g := range groups {
+		total += g.Count
+	}
+	lines := []string{SubtitleStyle.Render(truncate(fmt.Sprintf(
+		"Errors: %d lines in %d groups (counted since DevTrack started)", total, len(groups)), width))}
+	if len(groups) == 0 {
+		lines = append(lines, "", SubtitleStyle.Render("No error lines"))
+	} else {
+		lines = append(lines, TableHeaderStyle.Render(truncate(fmt.Sprintf("  %7s  %-8s  %-8s  %-16s %s",
+			"Count", "First", "Last", "Source", "Message"), width)))
+	}
+
+	// Keep the selection visible
+	visible := max(height-3, 1)
+	panel.selected = min(max(panel.selected, 0), max(len(groups)-1, 0))
+	start := max(panel.selected-visible+1, 0)
+	end := min(start+visible, len(groups))
+
+	now := time.Now()
+	for i := start; i < end; i++ {
+		g := groups[i]
+		count := fmt.Sprintf("%7d", g.Count)
+		switch {
+		case g.Count >= 100:
+			count = LogErrorStyle.Render(count)
+		case g.Count > 1:
+			count = StatusWarning.Render(count)
+		}
+		last := g.LastSeen.Format("15:04:05")
+		if now.Sub(g.LastSeen) < time.Minute {
+			last = LogErrorStyle.Render(last) // Still happening
+		}
+		row := fmt.Sprintf("%s  %s  %s  %s %s", count, LogTimestampStyle.Render(g.FirstSeen.Format("15:04:05")), last,
+			LogSourceStyle.Render(fmt.Sprintf("%-16s", truncate(g.Source, 16))), g.Message)
+		if i == panel.selected && m.focusArea == FocusMain {
+			row = FocusIndicator + " " + row
+		} else {
+			row = "  " + row
+		}
+		lines = append(lines, truncateANSI(row, width))
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	return append(lines, SubtitleStyle.Render(truncate("↑↓ select  Enter show lines  y copy  Esc close", width)))
+}
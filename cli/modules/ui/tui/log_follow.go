@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"csd-devtrack/cli/modules/ui/core"
+)
+
+// toggleLogFollow turns the follow mode of the Logs view on or off: the
+// source filter follows the project or component selected in the Dashboard,
+// Projects and Processes views
+func (m *Model) toggleLogFollow() {
+	m.logsView().follow = !m.logsView().follow
+	if !m.logsView().follow {
+		m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventInfo, "Logs no longer follow the selection"))
+		return
+	}
+	if m.logsView().followSource != "" {
+		m.setLogSourceFilter(m.logsView().followSource)
+	}
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventInfo, "Logs follow the selected project or component"))
+}
+
+// syncLogFollow updates the Logs source filter from the selection when the
+// follow mode is on (called by the views that select projects or components)
+func (m *Model) syncLogFollow() {
+	projectID := m.getSelectedProjectID()
+	if projectID == "" {
+		return
+	}
+	source := projectID
+	if component := m.getSelectedComponent(); component != "" {
+		source += "/" + string(component)
+	}
+	m.logsView().followSource = source
+	if m.logsView().follow && m.logsView().sourceFilter != source {
+		m.setLogSourceFilter(source)
+	}
+}
+
+// setLogSourceFilter shows the logs of a source from the newest line
+func (m *Model) setLogSourceFilter(source string) {
+	m.logsView().sourceFilter = source
+	m.logsView().scrollOffset = 0
+	m.logsView().autoScroll = true
+}
@@ -0,0 +1,227 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	"csd-devtrack/cli/modules/ui/core"
+)
+
+// logFilter holds the Logs view filters
+type logFilter struct {
+	source string // Project/component prefix ("" = all)
+	kind   string // "build", "process" or "" (all)
+	level  string // Log level ("" = all)
+	search string // Lowercased search text
+}
+
+// matches returns true if the line passes the filter
+func (f logFilter) matches(line *core.LogLineVM) bool {
+	if f.source != "" && !strings.HasPrefix(line.Source, f.source) {
+		return false
+	}
+	// Type filter (build: starts with "build:", process: doesn't start with "build:")
+	if f.kind != "" {
+		isBuild := strings.HasPrefix(line.Source, "build:")
+		if f.kind == "build" && !isBuild {
+			return false
+		}
+		if f.kind == "process" && isBuild {
+			return false
+		}
+	}
+	if f.level != "" && line.Level != f.level {
+		return false
+	}
+	if f.search != "" {
+		if !strings.Contains(strings.ToLower(line.Message), f.search) &&
+			!strings.Contains(strings.ToLower(line.Source), f.search) {
+			return false
+		}
+	}
+	return true
+}
+
+// logIndex keeps the filtered view of the log buffer between frames.
+// The buffer is append-only with lines trimmed from the front, so each
+// update only scans the new lines instead of the whole buffer, and the
+// renderer only formats the visible window.
+type logIndex struct {
+	lines   []core.LogLineVM // Buffer the index was built from
+	filter  logFilter
+	matches []int          // Buffer indices of lines passing the filter
+	sources map[string]int // Line count per source (without "build:" prefix)
+	options []string       // Sorted sources (nil = rebuild from sources)
+}
+
+// newLogIndex creates an empty log index
+func newLogIndex() *logIndex {
+	return &logIndex{sources: make(map[string]int)}
+}
+
+// update syncs the index with the current buffer and filter
+func (ix *logIndex) update(lines []core.LogLineVM, filter logFilter) {
+	appendFrom, trimmed, ok := ix.diff(lines)
+	if !ok {
+		ix.rebuild(lines, filter)
+		return
+	}
+
+	// Sources of trimmed and appended lines
+	for i := 0; i < trimmed; i++ {
+		ix.removeSource(ix.lines[i].Source)
+	}
+	for i := appendFrom; i < len(lines); i++ {
+		ix.addSource(lines[i].Source)
+	}
+
+	if filter != ix.filter {
+		ix.lines = lines
+		ix.filter = filter
+		ix.refilter()
+		return
+	}
+
+	// Shift the matches of the lines still in the buffer
+	kept := ix.matches[:0]
+	for _, idx := range ix.matches {
+		if idx >= trimmed {
+			kept = append(kept, idx-trimmed)
+		}
+	}
+	ix.matches = kept
+	for i := appendFrom; i < len(lines); i++ {
+		if filter.matches(&lines[i]) {
+			ix.matches = append(ix.matches, i)
+		}
+	}
+	ix.lines = lines
+}
+
+// diff locates the previously indexed lines in the new buffer (see diffLogBuffer)
+func (ix *logIndex) diff(lines []core.LogLineVM) (appendFrom, trimmed int, ok bool) {
+	return diffLogBuffer(ix.lines, lines)
+}
+
+// diffLogBuffer locates the lines of a previous log buffer in the new one.
+// It returns where the new lines start and how many old lines were trimmed;
+// ok is false when the buffer was replaced and a full rebuild is needed.
+func diffLogBuffer(old, lines []core.LogLineVM) (appendFrom, trimmed int, ok bool) {
+	if len(old) == 0 || len(lines) == 0 {
+		return 0, 0, false
+	}
+
+	// Find the last indexed line, scanning back from the end (new lines are few)
+	last := old[len(old)-1]
+	pos := -1
+	for i := len(lines) - 1; i >= 0; i-- {
+		if sameLogLine(&lines[i], &last) {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		return 0, 0, false
+	}
+
+	trimmed = len(old) - 1 - pos
+	if trimmed < 0 {
+		return 0, 0, false
+	}
+	// The first kept line must be where the shift says it is
+	if !sameLogLine(&lines[0], &old[trimmed]) {
+		return 0, 0, false
+	}
+	return pos + 1, trimmed, true
+}
+
+// rebuild indexes the whole buffer
+func (ix *logIndex) rebuild(lines []core.LogLineVM, filter logFilter) {
+	ix.lines = lines
+	ix.filter = filter
+	ix.sources = make(map[string]int)
+	ix.options = nil
+	for i := range lines {
+		ix.addSource(lines[i].Source)
+	}
+	ix.refilter()
+}
+
+// refilter rebuilds the matches for the current filter
+func (ix *logIndex) refilter() {
+	ix.matches = ix.matches[:0]
+	for i := range ix.lines {
+		if ix.filter.matches(&ix.lines[i]) {
+			ix.matches = append(ix.matches, i)
+		}
+	}
+}
+
+// window returns the filtered lines in [start, end)
+func (ix *logIndex) window(start, end int) []core.LogLineVM {
+	result := make([]core.LogLineVM, 0, end-start)
+	for _, idx := range ix.matches[start:end] {
+		result = append(result, ix.lines[idx])
+	}
+	return result
+}
+
+// count returns the number of lines passing the filter
+func (ix *logIndex) count() int {
+	return len(ix.matches)
+}
+
+// sourceOptions returns the sorted list of sources in the buffer
+func (ix *logIndex) sourceOptions() []string {
+	if ix.options == nil {
+		ix.options = make([]string, 0, len(ix.sources))
+		for source := range ix.sources {
+			ix.options = append(ix.options, source)
+		}
+		sort.Strings(ix.options)
+	}
+	return ix.options
+}
+
+// addSource counts a line of the given source
+func (ix *logIndex) addSource(source string) {
+	source = strings.TrimPrefix(source, "build:")
+	if ix.sources[source] == 0 {
+		ix.options = nil
+	}
+	ix.sources[source]++
+}
+
+// removeSource uncounts a line of the given source
+func (ix *logIndex) removeSource(source string) {
+	source = strings.TrimPrefix(source, "build:")
+	ix.sources[source]--
+	if ix.sources[source] <= 0 {
+		delete(ix.sources, source)
+		ix.options = nil
+	}
+}
+
+// sameLogLine returns true if both entries are the same log line
+func sameLogLine(a, b *core.LogLineVM) bool {
+	return a.Timestamp.Equal(b.Timestamp) && a.Source == b.Source && a.Message == b.Message
+}
+
+// tailLogLines returns the last n lines passing match, in buffer order.
+// It scans backwards and stops once n lines are found.
+func tailLogLines(lines []core.LogLineVM, n int, match func(line *core.LogLineVM) bool) []core.LogLineVM {
+	if n <= 0 {
+		return nil
+	}
+	var tail []core.LogLineVM
+	for i := len(lines) - 1; i >= 0 && len(tail) < n; i-- {
+		if match(&lines[i]) {
+			tail = append(tail, lines[i])
+		}
+	}
+	// Restore chronological order
+	for i, j := 0, len(tail)-1; i < j; i, j = i+1, j-1 {
+		tail[i], tail[j] = tail[j], tail[i]
+	}
+	return tail
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+)
+
+// Log time display modes (cycled with d in the Logs view)
+const (
+	logTimeAbsolute = ""         // Clock time
+	logTimeRelative = "relative" // Age of the line ("3s ago")
+	logTimeDelta    = "delta"    // Time since the previous line shown
+)
+
+// logTimeWidth is the width of the time column in every mode
+const logTimeWidth = 8
+
+// cycleLogTimeMode switches to the next time display mode
+func (m *Model) cycleLogTimeMode() {
+	switch m.logsView().timeMode {
+	case logTimeAbsolute:
+		m.logsView().timeMode = logTimeRelative
+	case logTimeRelative:
+		m.logsView().timeMode = logTimeDelta
+	default:
+		m.logsView().timeMode = logTimeAbsolute
+	}
+}
+
+// formatLogTime formats the time of a log line in the current display mode.
+// previous is the line shown before it (nil for the first one).
+func (m *Model) formatLogTime(line, previous *core.LogLineVM, now time.Time) string {
+	switch m.logsView().timeMode {
+	case logTimeRelative:
+		return fmt.Sprintf("%*s", logTimeWidth, formatAge(now.Sub(line.Time())))
+	case logTimeDelta:
+		if previous == nil {
+			return fmt.Sprintf("%*s", logTimeWidth, "")
+		}
+		return fmt.Sprintf("%*s", logTimeWidth, formatLogDelta(line.Time().Sub(previous.Time())))
+	}
+	if !line.EmittedAt.IsZero() {
+		return line.EmittedAt.Local().Format("15:04:05")
+	}
+	return line.TimeStr
+}
+
+// formatAge formats the age of a line compactly (now, 12s ago, 3m ago)
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return "now"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+}
+
+// formatLogDelta formats the time between two lines (+0.003s, +12.35s, +3m02s)
+func formatLogDelta(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign, d = "-", -d // Printed timestamps can go back (clock skew, buffering)
+	}
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%s%.3fs", sign, d.Seconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%s%.2fs", sign, d.Seconds())
+	case d < time.Hour:
+		return fmt.Sprintf("%s%dm%02ds", sign, int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%s%dh%02dm", sign, int(d.Hours()), int(d.Minutes())%60)
+}
@@ -0,0 +1,713 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// logsController is the submodel of the Logs view
+type logsController struct {
+	levelFilter   string // "", "error", "warn", "info", "debug"
+	sourceFilter  string // "", "project-id", "project-id/component"
+	typeFilter    string // "", "build", "process"
+	searchText    string
+	searchActive  bool
+	sourceOptions []string         // Available sources for selection
+	scrollOffset  int              // Scroll offset from bottom (0 = auto-scroll to bottom)
+	hscroll       hScroll          // Horizontal scroll/wrap of long log lines
+	timeMode      string           // Time column: clock (""), "relative" or "delta"
+	follow        bool             // Source filter follows the selected project/component
+	followSource  string           // Source of the last selection (applied when follow is turned on)
+	index         *logIndex        // Filtered view of the log buffer (kept between frames)
+	autoScroll    bool             // Auto-scroll to bottom on new logs
+	paused        bool             // Pause log display updates
+	stackTraces   *stackTraceIndex // Panics and exceptions found in the log buffer
+	traces        *logTracesPanel  // Stack trace panel of the Logs view (nil = hidden)
+	errors        *logErrorsPanel  // Error groups panel of the Logs view (nil = hidden)
+}
+
+// newLogsController creates the Logs view controller
+func newLogsController() *logsController {
+	return &logsController{autoScroll: true, index: newLogIndex(), stackTraces: newStackTraceIndex()}
+}
+
+// logsView returns the Logs view submodel
+func (m *Model) logsView() *logsController {
+	return m.controllers[core.VMLogs].(*logsController)
+}
+
+// Init implements ViewController
+func (c *logsController) Init(m *Model) tea.Cmd {
+	return nil
+}
+
+// Menu implements ViewController (the log lines are scrolled, not selected)
+func (c *logsController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	return nil
+}
+
+// Keys implements ViewController
+func (c *logsController) Keys(m *Model) []KeyHint {
+	var hints []KeyHint
+	// Show cancel if a build is running
+	if m.state.Builds != nil && m.state.Builds.IsBuilding {
+		hints = append(hints, KeyHint{"CTRL+c", "cancel"})
+	}
+	if c.searchActive {
+		return append(hints, KeyHint{"Esc", "exit"}, KeyHint{"Bksp", "del"})
+	}
+	if c.traces != nil {
+		return append(hints,
+			KeyHint{"↑↓", "select"},
+			KeyHint{"Enter", "expand/open"},
+			KeyHint{"o", "editor"},
+			KeyHint{"p", "preview"},
+			KeyHint{"z", "all"},
+			KeyHint{"y", "copy"},
+			KeyHint{"Esc", "close"},
+		)
+	}
+	if c.errors != nil {
+		return append(hints,
+			KeyHint{"↑↓", "select"},
+			KeyHint{"Enter", "show lines"},
+			KeyHint{"y", "copy"},
+			KeyHint{"Esc", "close"},
+		)
+	}
+	return append(hints,
+		KeyHint{"↑↓", "scroll"},
+		KeyHint{"S-↑↓", "page"},
+		KeyHint{"End", "bottom"},
+		KeyHint{"Space", "pause"},
+		KeyHint{"←→", "h-scroll"},
+		KeyHint{"W", "wrap"},
+		KeyHint{"s", "source"},
+		KeyHint{"f", "follow"},
+		KeyHint{"t", "type"},
+		KeyHint{"d", "time"},
+		KeyHint{"/", "search"},
+		KeyHint{"E", "traces"},
+		KeyHint{"g", "errors"},
+	)
+}
+
+// Update implements ViewController
+func (c *logsController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case stateUpdateMsg:
+		// Update log source options (only when new log lines arrived)
+		if msg.update.Affects(core.VMLogs) {
+			m.updateLogSourceOptions()
+		}
+	case keyPressMsg:
+		// The stack trace and error panels take the keys while they are open
+		if c.errors != nil {
+			if cmd, handled := m.handleLogErrorsKey(msg.key); handled {
+				return cmd, true
+			}
+		}
+		if c.traces != nil {
+			if cmd, handled := m.handleLogTracesKey(msg.key); handled {
+				return cmd, true
+			}
+		}
+		// Filter and scroll shortcuts, regardless of focus area for the filters
+		if m.handleLogsShortcuts(msg.key) {
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// yank implements yankView (bottom line of the log window, the newest line
+// when following; the trace and error panels copy their own selection)
+func (c *logsController) yank(m *Model) tea.Cmd {
+	if c.traces != nil || c.errors != nil {
+		return nil
+	}
+	total := c.index.count()
+	index := total - 1 - c.scrollOffset
+	if index < 0 || index >= total {
+		return m.yankText("", "")
+	}
+	line := c.index.window(index, index+1)[0]
+	return m.yankText("log line", fmt.Sprintf("%s [%s] %s", line.TimeStr, line.Source, line.Message))
+}
+
+// View implements ViewController
+func (c *logsController) View(m *Model, width, height int) string {
+	return m.renderLogs(width, height)
+}
+
+// renderLogs renders the logs view with filtering
+func (m *Model) renderLogs(width, height int) string {
+	vm := m.state.Logs
+	if vm == nil {
+		return m.renderLoading()
+	}
+
+	// Build source options from log lines
+	m.updateLogSourceOptions()
+
+	// Source filter (project/component) with status
+	sourceLabel := SubtitleStyle.Render("Source:")
+	if m.logsView().follow {
+		sourceLabel = SubtitleStyle.Render("Source") + StatusRunning.Render(" ⇄ following") + SubtitleStyle.Render(":")
+	}
+	var sourceValue string
+	var sourceStatus string
+	if m.logsView().sourceFilter == "" {
+		sourceValue = "ALL"
+	} else {
+		sourceValue = truncate(m.logsView().sourceFilter, 15)
+		// Get status for this source
+		sourceStatus = m.getSourceStatus(m.logsView().sourceFilter)
+	}
+
+	var sourceBox string
+	if sourceStatus != "" {
+		// Color based on status
+		var statusStyle lipgloss.Style
+		switch sourceStatus {
+		case "running":
+			statusStyle = StatusRunning
+		case "building":
+			statusStyle = StatusBuilding
+		case "stopped":
+			statusStyle = StatusStopped
+		default:
+			statusStyle = SubtitleStyle
+		}
+		sourceBox = ButtonActiveStyle.Render(" "+sourceValue+" ") + statusStyle.Render(sourceStatus) + ButtonActiveStyle.Render(" ◂▸")
+	} else {
+		sourceBox = ButtonActiveStyle.Render(" " + sourceValue + " ◂▸")
+	}
+
+	// Type filter (build/process)
+	typeLabel := SubtitleStyle.Render("Type:")
+	typeButtons := []string{}
+	for _, t := range []struct{ lbl, val string }{{"ALL", ""}, {"BUILD", "build"}, {"RUN", "process"}} {
+		if m.logsView().typeFilter == t.val {
+			typeButtons = append(typeButtons, ButtonActiveStyle.Render(t.lbl))
+		} else {
+			typeButtons = append(typeButtons, ButtonStyle.Render(t.lbl))
+		}
+	}
+	typeBar := strings.Join(typeButtons, " ")
+
+	// Level filter
+	levelLabel := SubtitleStyle.Render("Level:")
+	levelButtons := []string{}
+	for _, l := range []struct{ lbl, val string }{{"ALL", ""}, {"ERR", "error"}, {"WRN", "warn"}, {"INF", "info"}} {
+		if m.logsView().levelFilter == l.val {
+			levelButtons = append(levelButtons, ButtonActiveStyle.Render(l.lbl))
+		} else {
+			levelButtons = append(levelButtons, ButtonStyle.Render(l.lbl))
+		}
+	}
+	levelBar := strings.Join(levelButtons, " ")
+
+	// Search box
+	searchLabel := SubtitleStyle.Render("Search:")
+	var searchBox string
+	if m.logsView().searchActive {
+		searchBox = InputFocusedStyle.Width(20).Render(m.logsView().searchText + "█")
+	} else if m.logsView().searchText != "" {
+		searchBox = InputStyle.Width(20).Render(m.logsView().searchText)
+	} else {
+		searchBox = InputStyle.Width(20).Render(SubtitleStyle.Render("/ to search"))
+	}
+
+	// Filter bar row 1: Source and Type
+	filterBar1 := lipgloss.JoinHorizontal(lipgloss.Center,
+		sourceLabel, " ", sourceBox,
+		"   ",
+		typeLabel, " ", typeBar,
+	)
+
+	// Filter bar row 2: Level and Search
+	filterBar2 := lipgloss.JoinHorizontal(lipgloss.Center,
+		levelLabel, " ", levelBar,
+		"   ",
+		searchLabel, " ", searchBox,
+	)
+
+	// Filter log lines (incremental, see logIndex)
+	m.logsView().index.update(vm.Lines, m.currentLogFilter())
+	m.logsView().stackTraces.update(vm.Lines)
+
+	// Display log lines with scroll support
+	var logLines []string
+	maxLines := height - 10 // Account for 2 filter rows + stats line
+	if maxLines < 1 {
+		maxLines = 1
+	}
+
+	// Calculate scroll position
+	totalLines := m.logsView().index.count()
+
+	// Clamp scroll offset
+	maxOffset := totalLines - maxLines
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+	if m.logsView().scrollOffset > maxOffset {
+		m.logsView().scrollOffset = maxOffset
+	}
+
+	// Calculate start position (from the end, offset by scroll)
+	start := totalLines - maxLines - m.logsView().scrollOffset
+	if start < 0 {
+		start = 0
+	}
+	end := start + maxLines
+	if end > totalLines {
+		end = totalLines
+	}
+
+	// Long messages are scrolled horizontally (or wrapped) rather than cut
+	window := m.logsView().index.window(start, end)
+	msgWidth := width - 40
+	longest := 0
+	for _, line := range window {
+		if w := ansi.StringWidth(line.Text()); w > longest {
+			longest = w
+		}
+	}
+	m.logsView().hscroll.clamp(longest, msgWidth)
+	indent := strings.Repeat(" ", 26) // Width of time, level and source
+
+	// Deltas of the first line are from the line before the window
+	var previous *core.LogLineVM
+	if start > 0 && m.logsView().timeMode == logTimeDelta {
+		previous = &m.logsView().index.window(start-1, start)[0]
+	}
+	now := time.Now()
+
+	// Only the visible window is formatted
+	for i := range window {
+		line := window[i]
+		timestamp := LogTimestampStyle.Render(m.formatLogTime(&line, previous, now))
+		previous = &window[i]
+		source := LogSourceStyle.Render(fmt.Sprintf("[%-12s]", truncate(line.Source, 12)))
+
+		var levelStyle lipgloss.Style
+		var levelIcon string
+		switch line.Level {
+		case "error":
+			levelStyle = LogErrorStyle
+			levelIcon = "E"
+		case "warn":
+			levelStyle = LogWarnStyle
+			levelIcon = "W"
+		case "debug":
+			levelStyle = LogDebugStyle
+			levelIcon = "D"
+		default:
+			levelStyle = LogInfoStyle
+			levelIcon = "I"
+		}
+
+		for i, message := range m.logsView().hscroll.view(line.Text(), msgWidth) {
+			// Highlight search matches
+			if m.logsView().searchText != "" {
+				message = highlightMatch(message, m.logsView().searchText, len(message))
+			}
+			if i > 0 {
+				logLines = append(logLines, linkURLs(indent+levelStyle.Render(message), line.Text()))
+				continue
+			}
+			logLine := fmt.Sprintf("%s %s %s %s",
+				timestamp,
+				levelStyle.Render(levelIcon),
+				source,
+				levelStyle.Render(message))
+			logLines = append(logLines, linkURLs(truncateANSI(logLine+m.stackTraceBadge(&line), width-4), line.Text()))
+		}
+	}
+	// Wrapped lines: the last rows fit
+	if len(logLines) > maxLines {
+		logLines = logLines[len(logLines)-maxLines:]
+	}
+
+	// Stats line with scroll info
+	var scrollInfo string
+	if m.logsView().paused {
+		scrollInfo = " │ " + StatusWarning.Render("⏸ PAUSED") + SubtitleStyle.Render(" (Space to resume)")
+	} else if m.logsView().scrollOffset > 0 {
+		scrollInfo = fmt.Sprintf(" │ ↑%d lines (End to resume)", m.logsView().scrollOffset)
+	} else if m.logsView().autoScroll {
+		scrollInfo = " │ Auto-scroll"
+	}
+	if status := m.logsView().hscroll.status(); status != "" {
+		scrollInfo += " │ " + status
+	}
+	if m.logsView().timeMode != logTimeAbsolute {
+		scrollInfo += " │ Time: " + m.logsView().timeMode
+	}
+	if groups := len(vm.ErrorGroups); groups > 0 && m.logsView().errors == nil {
+		scrollInfo += " │ " + LogErrorStyle.Render(fmt.Sprintf("%d error groups", groups)) + SubtitleStyle.Render(" (g)")
+	}
+	statsLine := SubtitleStyle.Render(fmt.Sprintf(
+		"Lines %d-%d of %d",
+		start+1, end, totalLines)) + scrollInfo
+
+	if len(logLines) == 0 {
+		logLines = append(logLines, SubtitleStyle.Render("No logs matching filters"))
+	}
+	// The stack trace and error panels replace the log lines
+	if m.logsView().traces != nil {
+		logLines = m.renderLogTraces(width-4, maxLines)
+	} else if m.logsView().errors != nil {
+		logLines = m.renderLogErrors(width-4, maxLines)
+	}
+
+	content := strings.Join(logLines, "\n")
+
+	var style lipgloss.Style
+	if m.focusArea == FocusMain {
+		style = FocusedBorderStyle
+	} else {
+		style = UnfocusedBorderStyle
+	}
+
+	// 1 panel: height 1×2=2, width 1×2=2
+	return style.Width(width - 2).Height(height - 2).Render(
+		lipgloss.JoinVertical(lipgloss.Left, filterBar1, filterBar2, statsLine, "", content),
+	)
+}
+
+// highlightMatch truncates and highlights matching text
+func highlightMatch(text, search string, maxLen int) string {
+	text = truncate(text, maxLen)
+	if search == "" {
+		return text
+	}
+
+	lower := strings.ToLower(text)
+	searchLower := strings.ToLower(search)
+	idx := strings.Index(lower, searchLower)
+	if idx == -1 {
+		return text
+	}
+
+	// Simple highlight by making the match bold/colored
+	before := text[:idx]
+	match := text[idx : idx+len(search)]
+	after := text[idx+len(search):]
+
+	highlighted := lipgloss.NewStyle().
+		Background(ColorWarning).
+		Foreground(ColorBg).
+		Render(match)
+
+	return before + highlighted + after
+}
+
+// handleLogsShortcuts handles shortcuts in Logs view when NOT in search mode
+// Returns true if the key was handled
+func (m *Model) handleLogsShortcuts(msg tea.KeyMsg) bool {
+	key := msg.String()
+
+	switch key {
+	case "/":
+		// Enter search mode
+		m.logsView().searchActive = true
+		return true
+	case "e":
+		m.toggleLogLevel("error")
+		return true
+	case "w":
+		m.toggleLogLevel("warn")
+		return true
+	case "i":
+		m.toggleLogLevel("info")
+		return true
+	case "a":
+		m.logsView().levelFilter = "" // All levels
+		return true
+	case "x":
+		m.logsView().searchText = "" // Clear search
+		return true
+	case "s", "S":
+		// Cycle source filter (a manual choice stops following the selection)
+		m.logsView().follow = false
+		m.cycleLogSource(key == "S")
+		return true
+	case "f":
+		m.toggleLogFollow()
+		return true
+	case "left", "h":
+		m.logsView().hscroll.scroll(-1)
+		return true
+	case "right", "l":
+		m.logsView().hscroll.scroll(1)
+		return true
+	case "W":
+		m.logsView().hscroll.toggleWrap()
+		return true
+	case "E":
+		m.toggleLogTraces()
+		return true
+	case "g":
+		m.toggleLogErrors()
+		return true
+	case "d":
+		m.cycleLogTimeMode()
+		return true
+	case "t":
+		// Cycle type filter
+		m.cycleLogType()
+		return true
+	case "c":
+		// Clear all filters
+		m.logsView().follow = false
+		m.logsView().sourceFilter = ""
+		m.logsView().typeFilter = ""
+		m.logsView().levelFilter = ""
+		m.logsView().searchText = ""
+		return true
+	}
+
+	// Scroll controls - only when focus is on Main panel
+	if m.focusArea != FocusMain {
+		return false
+	}
+
+	switch key {
+	case "up", "k":
+		// Scroll up one line
+		m.logsView().scrollOffset++
+		m.logsView().autoScroll = false
+		return true
+	case "down", "j":
+		// Scroll down one line
+		if m.logsView().scrollOffset > 0 {
+			m.logsView().scrollOffset--
+		}
+		if m.logsView().scrollOffset == 0 {
+			m.logsView().autoScroll = true
+		}
+		return true
+	case "shift+up", "pgup":
+		// Page up
+		m.logsView().scrollOffset += 10
+		m.logsView().autoScroll = false
+		return true
+	case "shift+down", "pgdown":
+		// Page down
+		m.logsView().scrollOffset -= 10
+		if m.logsView().scrollOffset < 0 {
+			m.logsView().scrollOffset = 0
+		}
+		if m.logsView().scrollOffset == 0 {
+			m.logsView().autoScroll = true
+		}
+		return true
+	case "home":
+		// Go to top
+		m.logsView().scrollOffset = 999999 // Will be clamped in render
+		m.logsView().autoScroll = false
+		return true
+	case "end":
+		// Go to bottom (resume auto-scroll)
+		m.logsView().scrollOffset = 0
+		m.logsView().autoScroll = true
+		m.logsView().paused = false
+		return true
+	case " ":
+		// Toggle pause
+		m.logsView().paused = !m.logsView().paused
+		if m.logsView().paused {
+			// When pausing, disable auto-scroll
+			m.logsView().autoScroll = false
+		}
+		return true
+	}
+
+	return false
+}
+
+// toggleLogLevel toggles or sets the log level filter
+func (m *Model) toggleLogLevel(level string) {
+	if m.logsView().levelFilter == level {
+		m.logsView().levelFilter = "" // Toggle off
+	} else {
+		m.logsView().levelFilter = level
+	}
+}
+
+// updateLogSourceOptions builds the list of available sources from log lines
+func (m *Model) updateLogSourceOptions() {
+	if m.state.Logs == nil {
+		return
+	}
+	m.logsView().index.update(m.state.Logs.Lines, m.currentLogFilter())
+	m.logsView().sourceOptions = m.logsView().index.sourceOptions()
+}
+
+// currentLogFilter returns the active Logs view filters
+func (m *Model) currentLogFilter() logFilter {
+	return logFilter{
+		source: m.logsView().sourceFilter,
+		kind:   m.logsView().typeFilter,
+		level:  m.logsView().levelFilter,
+		search: strings.ToLower(m.logsView().searchText),
+	}
+}
+
+// cycleLogSource cycles through source options
+func (m *Model) cycleLogSource(reverse bool) {
+	if len(m.logsView().sourceOptions) == 0 {
+		return
+	}
+
+	// Add "all" option at the beginning
+	options := append([]string{""}, m.logsView().sourceOptions...)
+
+	// Find current index
+	currentIdx := 0
+	for i, opt := range options {
+		if opt == m.logsView().sourceFilter {
+			currentIdx = i
+			break
+		}
+	}
+
+	// Cycle
+	if reverse {
+		currentIdx--
+		if currentIdx < 0 {
+			currentIdx = len(options) - 1
+		}
+	} else {
+		currentIdx++
+		if currentIdx >= len(options) {
+			currentIdx = 0
+		}
+	}
+
+	m.logsView().sourceFilter = options[currentIdx]
+}
+
+// cycleLogType cycles through type options
+func (m *Model) cycleLogType() {
+	switch m.logsView().typeFilter {
+	case "":
+		m.logsView().typeFilter = "build"
+	case "build":
+		m.logsView().typeFilter = "process"
+	case "process":
+		m.logsView().typeFilter = ""
+	}
+}
+
+// getSourceStatus returns the status of a source (running/building/stopped)
+func (m *Model) getSourceStatus(source string) string {
+	// Check if currently building
+	if m.state.Builds != nil && m.state.Builds.IsBuilding && m.state.Builds.CurrentBuild != nil {
+		buildSource := m.state.Builds.CurrentBuild.ProjectID + "/" + string(m.state.Builds.CurrentBuild.Component)
+		if strings.HasPrefix(buildSource, source) || strings.HasPrefix(source, m.state.Builds.CurrentBuild.ProjectID) {
+			return "building"
+		}
+	}
+
+	// Check if running process
+	if m.state.Processes != nil {
+		for _, p := range m.state.Processes.Processes {
+			if p.IsSelf {
+				continue
+			}
+			procSource := p.ProjectID + "/" + string(p.Component)
+			if strings.HasPrefix(procSource, source) || strings.HasPrefix(source, p.ProjectID) {
+				if p.State == "running" {
+					return "running"
+				}
+			}
+		}
+	}
+
+	return "stopped"
+}
+
+// handleLogsSearchInput handles typing in Logs search mode
+// Returns true if the key was handled
+func (m *Model) handleLogsSearchInput(msg tea.KeyMsg) bool {
+	key := msg.String()
+
+	switch key {
+	case "esc", "enter":
+		// Exit search mode (keep text)
+		m.logsView().searchActive = false
+		m.inputHistory.Add(historyLogSearch, m.logsView().searchText)
+		return true
+	case "up":
+		if entry, ok := m.inputHistory.Prev(historyLogSearch, m.logsView().searchText); ok {
+			m.logsView().searchText = entry
+		}
+		return true
+	case "down":
+		if entry, ok := m.inputHistory.Next(historyLogSearch); ok {
+			m.logsView().searchText = entry
+		}
+		return true
+	case "shift+backspace", "ctrl+u":
+		// Clear all text
+		m.logsView().searchText = ""
+		return true
+	case "backspace":
+		// Delete last char
+		if len(m.logsView().searchText) > 0 {
+			m.logsView().searchText = m.logsView().searchText[:len(m.logsView().searchText)-1]
+		}
+		return true
+	case "delete", "ctrl+k":
+		// Clear all text
+		m.logsView().searchText = ""
+		return true
+	}
+
+	// Printable characters - add to search
+	if len(key) == 1 && key[0] >= 32 && key[0] < 127 {
+		m.logsView().searchText += key
+		return true
+	}
+
+	// Space
+	if key == " " {
+		m.logsView().searchText += " "
+		return true
+	}
+
+	return false
+}
+
+// handleLogSearchInput handles typing in log search mode (legacy, for '/' activation)
+func (m *Model) handleLogSearchInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		m.logsView().searchActive = false
+		return nil
+	case "esc":
+		m.logsView().searchActive = false
+		m.logsView().searchText = ""
+		return nil
+	case "backspace":
+		if len(m.logsView().searchText) > 0 {
+			m.logsView().searchText = m.logsView().searchText[:len(m.logsView().searchText)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			m.logsView().searchText += msg.String()
+		}
+	}
+	return nil
+}
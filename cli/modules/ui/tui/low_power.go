@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"sync/atomic"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Low-power mode: while the terminal is unfocused (focus reporting, not
+// supported by every terminal; tmux needs focus-events on), DevTrack only
+// refreshes every lowPowerRefreshInterval, so a background pane does not
+// keep a laptop busy. The presenter slows its polling down too.
+const (
+	lowPowerRefreshInterval = 30 * time.Second // Views, ticks and system metrics
+	lowPowerCaptureEvery    = 20               // tmux pane captures: every 3s (150ms ticks)
+)
+
+// terminalsLowPower slows the capture and monitoring of tmux terminals down
+// (read by their goroutines)
+var terminalsLowPower atomic.Bool
+
+// metricsInterval returns the system metrics collection interval
+func metricsInterval(lowPower bool) time.Duration {
+	interval := config.DefaultPollingConfig().Interval(config.PollMetrics)
+	if cfg := config.GetGlobal(); cfg != nil && cfg.Settings != nil {
+		interval = cfg.Settings.GetPollingConfig().Interval(config.PollMetrics)
+	}
+	if lowPower && interval < lowPowerRefreshInterval {
+		interval = lowPowerRefreshInterval
+	}
+	return interval
+}
+
+// setLowPower enters low-power mode when the terminal loses focus and leaves
+// it when the focus returns, refreshing at once what was left stale
+func (m *Model) setLowPower(on bool) tea.Cmd {
+	if m.lowPower == on {
+		return nil
+	}
+	m.lowPower = on
+	terminalsLowPower.Store(on)
+	if m.metricsCollector != nil {
+		m.metricsCollector.SetRefreshRate(metricsInterval(on))
+	}
+
+	event := core.NewEvent(core.EventLowPower)
+	event.Value = on
+	cmds := []tea.Cmd{m.sendEvent(event)}
+	if !on {
+		cmds = append(cmds, m.refreshData)
+	}
+	return tea.Batch(cmds...)
+}
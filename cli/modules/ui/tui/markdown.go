@@ -0,0 +1,303 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Markdown styles
+var (
+	mdH1Style     = lipgloss.NewStyle().Bold(true).Foreground(ColorPrimary).Underline(true)
+	mdH2Style     = lipgloss.NewStyle().Bold(true).Foreground(ColorPrimary)
+	mdH3Style     = lipgloss.NewStyle().Bold(true).Foreground(ColorTextAlt)
+	mdCodeStyle   = lipgloss.NewStyle().Foreground(ColorWarning)
+	mdBoldStyle   = lipgloss.NewStyle().Bold(true)
+	mdItalicStyle = lipgloss.NewStyle().Italic(true)
+	mdStrikeStyle = lipgloss.NewStyle().Strikethrough(true)
+	mdLinkStyle   = lipgloss.NewStyle().Foreground(ColorSecondary).Underline(true)
+	mdMutedStyle  = lipgloss.NewStyle().Foreground(ColorMuted)
+
+	mdAddedStyle   = lipgloss.NewStyle().Foreground(ColorSuccess)   // Diff blocks
+	mdRemovedStyle = lipgloss.NewStyle().Foreground(ColorError)     // Diff blocks
+	mdHunkStyle    = lipgloss.NewStyle().Foreground(ColorSecondary) // Diff blocks
+)
+
+var (
+	mdOrderedItem = regexp.MustCompile(`^(\d+)[.)]\s+`)
+	mdInline      = regexp.MustCompile("`[^`]+`|\\*\\*[^*]+\\*\\*|__[^_]+__|~~[^~]+~~|\\*[^*\\s][^*]*\\*|\\b_[^_\\s][^_]*_\\b|!?\\[[^\\]]*\\]\\([^)]*\\)")
+)
+
+// markdownCodeBlock is a fenced code block of a rendered markdown text
+type markdownCodeBlock struct {
+	lang   string
+	code   string
+	header int // Index of the block header in the rendered lines
+}
+
+// renderMarkdown renders markdown as styled lines wrapped to width: headings,
+// emphasis, inline code, links, lists, task lists, quotes, rules, tables and
+// fenced code blocks (syntax highlighted)
+func renderMarkdown(src string, width int) []string {
+	lines, _ := renderMarkdownBlocks(src, width)
+	return lines
+}
+
+// renderMarkdownBlocks renders markdown like renderMarkdown and also returns
+// its code blocks
+func renderMarkdownBlocks(src string, width int) ([]string, []markdownCodeBlock) {
+	if width < 10 {
+		width = 10
+	}
+	var out []string
+	var blocks []markdownCodeBlock
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			out = append(out, wrapStyled(renderInline(strings.Join(paragraph, " ")), width, "", "")...)
+			paragraph = nil
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.ReplaceAll(lines[i], "\t", "    ")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			flush()
+			fence := trimmed[:3]
+			lang := strings.TrimSpace(trimmed[3:])
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, strings.ReplaceAll(lines[i], "\t", "    "))
+			}
+			blocks = append(blocks, markdownCodeBlock{lang: lang, code: strings.Join(code, "\n"), header: len(out)})
+			out = append(out, renderCodeBlock(code, lang, width)...)
+
+		case trimmed == "":
+			flush()
+			if len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+
+		case strings.HasPrefix(trimmed, "#"):
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			text := strings.TrimSpace(trimmed[level:])
+			if level > 6 || (text != "" && trimmed[level] != ' ') {
+				paragraph = append(paragraph, trimmed)
+				continue
+			}
+			flush()
+			style := mdH3Style
+			switch level {
+			case 1:
+				style = mdH1Style
+			case 2:
+				style = mdH2Style
+			}
+			out = append(out, wrapStyled(styleWords(text, style), width, "", "")...)
+
+		case isMarkdownRule(trimmed):
+			flush()
+			out = append(out, mdMutedStyle.Render(strings.Repeat("─", width)))
+
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			text := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			bar := mdMutedStyle.Render("│ ")
+			out = append(out, wrapStyled(renderInline(text), width, bar, bar)...)
+
+		case strings.HasPrefix(trimmed, "|"):
+			flush()
+			out = append(out, renderTableRow(trimmed, width))
+
+		default:
+			if bullet, text, ok := markdownListItem(line); ok {
+				flush()
+				indent := strings.Repeat(" ", len(line)-len(strings.TrimLeft(line, " ")))
+				first := indent + bullet + " "
+				out = append(out, wrapStyled(renderInline(text), width, first, strings.Repeat(" ", ansi.StringWidth(first)))...)
+				continue
+			}
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flush()
+
+	// No trailing blank line
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return out, blocks
+}
+
+// isMarkdownRule returns true for a horizontal rule (---, ***, ___)
+func isMarkdownRule(s string) bool {
+	if len(s) < 3 {
+		return false
+	}
+	compact := strings.ReplaceAll(s, " ", "")
+	for _, c := range "-*_" {
+		if strings.Trim(compact, string(c)) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// markdownListItem returns the rendered bullet and the text of a list item
+func markdownListItem(line string) (string, string, bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		if !strings.HasPrefix(trimmed, marker) {
+			continue
+		}
+		text := trimmed[2:]
+		switch {
+		case strings.HasPrefix(text, "[ ] "):
+			return mdMutedStyle.Render("☐"), text[4:], true
+		case strings.HasPrefix(text, "[x] "), strings.HasPrefix(text, "[X] "):
+			return StatusSuccess.Render("☑"), text[4:], true
+		}
+		return mdMutedStyle.Render("•"), text, true
+	}
+	if loc := mdOrderedItem.FindStringSubmatchIndex(trimmed); loc != nil {
+		return mdMutedStyle.Render(trimmed[loc[2]:loc[3]] + "."), trimmed[loc[1]:], true
+	}
+	return "", "", false
+}
+
+// renderInline renders the inline markup of a text: code, bold, italic,
+// strikethrough and links. Styles are applied word by word so wrapping never
+// splits a styled sequence.
+func renderInline(text string) string {
+	var b strings.Builder
+	pos := 0
+	for _, loc := range mdInline.FindAllStringIndex(text, -1) {
+		b.WriteString(text[pos:loc[0]])
+		token := text[loc[0]:loc[1]]
+		switch {
+		case strings.HasPrefix(token, "`"):
+			b.WriteString(styleWords(token[1:len(token)-1], mdCodeStyle))
+		case strings.HasPrefix(token, "**"), strings.HasPrefix(token, "__"):
+			b.WriteString(styleWords(token[2:len(token)-2], mdBoldStyle))
+		case strings.HasPrefix(token, "~~"):
+			b.WriteString(styleWords(token[2:len(token)-2], mdStrikeStyle))
+		case strings.HasPrefix(token, "["), strings.HasPrefix(token, "!["):
+			label, url, _ := strings.Cut(strings.TrimPrefix(token, "!")[1:], "](")
+			url = strings.TrimSuffix(url, ")")
+			if label == "" {
+				label = url
+			}
+			b.WriteString(styleWords(label, mdLinkStyle))
+			if url != label {
+				b.WriteString(" " + mdMutedStyle.Render("("+url+")"))
+			}
+		default:
+			b.WriteString(styleWords(token[1:len(token)-1], mdItalicStyle))
+		}
+		pos = loc[1]
+	}
+	b.WriteString(text[pos:])
+	return b.String()
+}
+
+// styleWords renders each word of a text in a style, spaces unstyled
+func styleWords(text string, style lipgloss.Style) string {
+	words := strings.Split(text, " ")
+	for i, w := range words {
+		if w != "" {
+			words[i] = style.Render(w)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// wrapStyled wraps a styled text to width, with a prefix on the first line
+// and another on the continuation lines
+func wrapStyled(text string, width int, first, rest string) []string {
+	limit := width - ansi.StringWidth(first)
+	if limit < 1 {
+		limit = 1
+	}
+	lines := strings.Split(ansi.Wrap(text, limit, ""), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+		if i == 0 {
+			lines[i] = first + lines[i]
+		} else {
+			lines[i] = rest + lines[i]
+		}
+	}
+	return lines
+}
+
+// renderCodeBlock renders a fenced code block, highlighted when the language is known
+func renderCodeBlock(code []string, lang string, width int) []string {
+	base := lipgloss.NewStyle().Foreground(ColorTextAlt)
+	var spans map[int][]syntaxSpan
+	if lang != "" && isSyntaxHighlightEnabled() {
+		spans = highlightDiff("code."+lang, code, 0, false)
+	}
+	isDiff := lang == "diff" || lang == "patch"
+	bar := mdMutedStyle.Render("▎ ")
+	out := make([]string, 0, len(code)+1)
+	out = append(out, bar+mdMutedStyle.Render(codeBlockLabel(lang)))
+	for i, line := range code {
+		rendered := highlightLine(line, spans[i], base)
+		if isDiff {
+			rendered = diffLineStyle(line, base).Render(line)
+		}
+		out = append(out, bar+truncateANSI(rendered, width-2))
+	}
+	return out
+}
+
+// diffLineStyle returns the style of a unified diff line: added, removed,
+// hunk header or file header
+func diffLineStyle(line string, base lipgloss.Style) lipgloss.Style {
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"),
+		strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "index "):
+		return mdMutedStyle
+	case strings.HasPrefix(line, "+"):
+		return mdAddedStyle
+	case strings.HasPrefix(line, "-"):
+		return mdRemovedStyle
+	case strings.HasPrefix(line, "@@"):
+		return mdHunkStyle
+	}
+	return base
+}
+
+// codeBlockLabel returns the header label of a code block
+func codeBlockLabel(lang string) string {
+	if lang == "" {
+		return "code"
+	}
+	return lang
+}
+
+// renderTableRow renders a table row with box-drawing separators (the
+// header separator row becomes a rule)
+func renderTableRow(row string, width int) string {
+	cells := strings.Split(strings.Trim(row, "|"), "|")
+	separator := true
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+		if strings.Trim(cells[i], "-: ") != "" {
+			separator = false
+		}
+	}
+	if separator {
+		return mdMutedStyle.Render(strings.Repeat("─", min(width, 40)))
+	}
+	for i, cell := range cells {
+		cells[i] = renderInline(cell)
+	}
+	return truncateANSI(strings.Join(cells, mdMutedStyle.Render(" │ ")), width)
+}
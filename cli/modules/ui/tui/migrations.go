@@ -0,0 +1,300 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/migrations"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// migrationsPanel is the overlay showing the migration state of a database
+// and applying or rolling back its migrations ("v" in the Database view)
+type migrationsPanel struct {
+	databaseID string
+	scroll     int // First visible line
+	height     int // Visible lines (set at render)
+}
+
+// selectedDatabaseID returns the database of the selected item of the
+// Database view: the database itself, or the database of a session
+func (m *Model) selectedDatabaseID() string {
+	if m.databaseView().treeMenu == nil {
+		return ""
+	}
+	item := m.databaseView().treeMenu.SelectedItem()
+	if item == nil {
+		return ""
+	}
+	switch data := item.Data.(type) {
+	case core.DatabaseInfoVM:
+		return data.ID
+	case core.DatabaseSessionVM:
+		return data.DatabaseID
+	}
+	return ""
+}
+
+// openMigrations opens the migrations panel of the selected database
+func (m *Model) openMigrations() tea.Cmd {
+	databaseID := m.selectedDatabaseID()
+	if databaseID == "" {
+		m.lastError = "Select a database to see its migrations"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	m.migrations = &migrationsPanel{databaseID: databaseID}
+	return m.sendEvent(core.NewEvent(core.EventDatabaseMigrations).WithTarget(databaseID))
+}
+
+// migrationsState returns the migration state of the open panel, nil while
+// loading
+func (m *Model) migrationsState() *core.MigrationsVM {
+	if m.state.Database == nil || m.state.Database.Migrations == nil ||
+		m.state.Database.Migrations.DatabaseID != m.migrations.databaseID {
+		return nil
+	}
+	return m.state.Database.Migrations
+}
+
+// handleMigrationsKey handles the keys of the migrations panel
+func (m *Model) handleMigrationsKey(msg tea.KeyMsg) tea.Cmd {
+	p := m.migrations
+	switch msg.String() {
+	case "esc", "q":
+		m.migrations = nil
+	case "up", "k":
+		p.scroll = max(p.scroll-1, 0)
+	case "down", "j":
+		p.scroll++
+	case "pgup", "shift+up":
+		p.scroll = max(p.scroll-p.height, 0)
+	case "pgdown", "shift+down":
+		p.scroll += p.height
+	case "r":
+		return m.sendEvent(core.NewEvent(core.EventDatabaseMigrations).WithTarget(p.databaseID).
+			WithData("dir", m.migrationsDir()))
+	case "t":
+		// Next migration setup (a project may have several)
+		if vm := m.migrationsState(); vm != nil && len(vm.Setups) > 1 {
+			next := vm.Setups[(vm.Setup+1)%len(vm.Setups)]
+			return m.sendEvent(core.NewEvent(core.EventDatabaseMigrations).WithTarget(p.databaseID).
+				WithData("dir", next.Dir))
+		}
+	case "u":
+		return m.confirmMigration(migrations.ActionUp)
+	case "d":
+		return m.confirmMigration(migrations.ActionDown)
+	case "R":
+		return m.confirmMigration(migrations.ActionRedo)
+	case "y":
+		if vm := m.migrationsState(); vm != nil {
+			output := vm.Output
+			if vm.Running == "" && vm.Result != nil {
+				output = vm.Result.Output
+			}
+			if len(output) > 0 {
+				return m.yankText("migration output", strings.Join(output, "\n"))
+			}
+		}
+	}
+	return nil
+}
+
+// migrationsDir returns the migration directory selected in the panel
+func (m *Model) migrationsDir() string {
+	if setup := m.migrationsState().SelectedSetup(); setup != nil {
+		return setup.Dir
+	}
+	return ""
+}
+
+// confirmMigration asks for confirmation before a migration action on the
+// database of the panel
+func (m *Model) confirmMigration(action migrations.Action) tea.Cmd {
+	vm := m.migrationsState()
+	setup := vm.SelectedSetup()
+	switch {
+	case setup == nil:
+		m.lastError = "No migrations to apply"
+	case vm.Running != "":
+		m.lastError = "A migration is already running"
+	case setup.Tool == migrations.ToolPrisma && action != migrations.ActionUp:
+		m.lastError = "Prisma Migrate does not roll back migrations"
+	default:
+		var message string
+		switch action {
+		case migrations.ActionUp:
+			message = fmt.Sprintf("Apply pending migrations to %s?", vm.DatabaseName)
+			if vm.Status != nil && vm.Status.Error == "" {
+				message = fmt.Sprintf("Apply %d pending migration(s) to %s?", vm.Status.Pending, vm.DatabaseName)
+			}
+		case migrations.ActionDown:
+			message = fmt.Sprintf("Roll back the last migration of %s?", vm.DatabaseName)
+		case migrations.ActionRedo:
+			message = fmt.Sprintf("Roll back and re-apply the last migration of %s?", vm.DatabaseName)
+		}
+		m.databaseView().pendingMigration = string(action)
+		m.dialogConfirm = false
+		return m.openConfirmDialog(config.ConfirmMigration, "migrate", message)
+	}
+	m.lastErrorTime = time.Now()
+	return nil
+}
+
+// startMigration runs the confirmed migration action
+func (m *Model) startMigration() tea.Cmd {
+	action := m.databaseView().pendingMigration
+	m.databaseView().pendingMigration = ""
+	if action == "" || m.migrations == nil {
+		return nil
+	}
+	return m.sendEvent(core.NewEvent(core.EventDatabaseMigrate).WithTarget(m.migrations.databaseID).
+		WithValue(action).WithData("dir", m.migrationsDir()))
+}
+
+// migrationsSummary returns the one-line migration state of a database
+// ("goose v3 · 2 pending"), empty if unknown
+func migrationsSummary(vm *core.MigrationsVM) string {
+	setup := vm.SelectedSetup()
+	switch {
+	case setup == nil || vm.Status == nil:
+		return ""
+	case vm.Running != "":
+		return fmt.Sprintf("%s %s running...", setup.Tool, vm.Running)
+	case vm.Status.Error != "":
+		return string(setup.Tool) + ": status unavailable"
+	}
+	current := "none applied"
+	if vm.Status.Current != "" {
+		current = "v" + vm.Status.Current
+	}
+	summary := fmt.Sprintf("%s %s · %d pending", setup.Tool, current, vm.Status.Pending)
+	if vm.Status.Dirty {
+		summary += " · dirty"
+	}
+	return summary
+}
+
+// migrationsLines renders the body of the migrations panel
+func migrationsLines(vm *core.MigrationsVM, width int) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	var lines []string
+	if vm.Error != "" {
+		lines = append(lines, StatusError.Render("✗ "+vm.Error))
+	}
+	if setup := vm.SelectedSetup(); setup != nil {
+		line := HelpKeyStyle.Render(string(setup.Tool)) + "  " + setup.Dir
+		if len(vm.Setups) > 1 {
+			line += mutedStyle.Render(fmt.Sprintf("  (%d/%d)", vm.Setup+1, len(vm.Setups)))
+		}
+		lines = append(lines, line)
+		if setup.URLEnv != "" {
+			lines = append(lines, mutedStyle.Render("URL passed in $"+setup.URLEnv))
+		}
+		lines = append(lines, "")
+	}
+
+	switch status := vm.Status; {
+	case vm.Checking:
+		lines = append(lines, SubtitleStyle.Render("Checking status..."))
+	case status == nil:
+	case status.Error != "":
+		lines = append(lines, StatusError.Render("✗ "+status.Error))
+	default:
+		current := "none applied"
+		if status.Current != "" {
+			current = status.Current
+		}
+		line := "Current version: " + HelpKeyStyle.Render(current) + "  "
+		if status.Pending > 0 {
+			line += StatusWarning.Render(fmt.Sprintf("%d pending", status.Pending))
+		} else {
+			line += StatusSuccess.Render("up to date")
+		}
+		lines = append(lines, line)
+		if status.Dirty {
+			lines = append(lines, StatusWarning.Render("⚠ Dirty: the last migration failed halfway, fix the database then force its version"))
+		}
+		lines = append(lines, mutedStyle.Render("Checked "+formatRelativeTime(status.CheckedAt)))
+	}
+	if status := vm.Status; status != nil && len(status.Migrations) > 0 {
+		lines = append(lines, "", SubtitleStyle.Render("Migrations:"))
+		for _, mig := range status.Migrations {
+			line := mutedStyle.Render("○ ") + mig.Version + "  " + mig.Name
+			if mig.Applied {
+				line = StatusSuccess.Render("✓ ") + mig.Version + "  " + mig.Name
+			}
+			if mig.Version == status.Current {
+				line += HelpKeyStyle.Render("  ← current")
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	output := vm.Output
+	switch {
+	case vm.Running != "":
+		lines = append(lines, "", StatusWarning.Render(fmt.Sprintf("Running %s...", vm.Running)))
+	case vm.Result != nil:
+		output = vm.Result.Output
+		header := fmt.Sprintf("✓ %s done in %s", vm.Result.Action, vm.Result.Duration.Round(time.Millisecond))
+		if vm.Result.Error != "" {
+			lines = append(lines, "", StatusError.Render(fmt.Sprintf("✗ %s failed: %s", vm.Result.Action, vm.Result.Error)))
+		} else {
+			lines = append(lines, "", StatusSuccess.Render(header))
+		}
+	}
+	for _, line := range output {
+		lines = append(lines, "  "+line)
+	}
+
+	for i, line := range lines {
+		lines[i] = truncateANSI(line, width)
+	}
+	return lines
+}
+
+// renderMigrationsOverlay renders the migrations panel
+func (m *Model) renderMigrationsOverlay(width, height int) string {
+	p := m.migrations
+	boxWidth := min(width-4, 130)
+	innerWidth := boxWidth - 6 // Border and padding
+
+	title := "Migrations"
+	var body []string
+	if vm := m.migrationsState(); vm == nil {
+		body = append(body, SubtitleStyle.Render("Detecting migrations..."))
+	} else {
+		title += ": " + vm.DatabaseName
+		if vm.ProjectName != "" {
+			title += " (" + vm.ProjectName + ")"
+		}
+		body = migrationsLines(vm, innerWidth)
+	}
+
+	p.height = max(height-7, 3)
+	p.scroll = min(p.scroll, max(len(body)-p.height, 0))
+	lines := []string{
+		DialogTitleStyle.MarginBottom(0).Render(truncate(title, innerWidth)),
+		"",
+	}
+	lines = append(lines, body[p.scroll:min(p.scroll+p.height, len(body))]...)
+	hints := []KeyHint{{"u", "up"}, {"d", "down"}, {"R", "redo"}, {"r", "refresh"}}
+	if vm := m.migrationsState(); vm != nil && len(vm.Setups) > 1 {
+		hints = append(hints, KeyHint{"t", "next setup"})
+	}
+	hints = append(hints, KeyHint{"y", "copy output"}, KeyHint{"↑↓", "scroll"}, KeyHint{"Esc", "close"})
+	lines = append(lines, "", strings.Join(renderKeyHints(hints), ""))
+
+	box := DialogStyle.Padding(0, 2).Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Top,
+		lipgloss.NewStyle().MarginTop(1).Render(box),
+	)
+}
@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// projectNotes is the notes of the project selected in the Projects view
+type projectNotes struct {
+	projectID string
+	content   string
+	exists    bool
+	err       string
+	width     int      // Width the content was rendered for
+	lines     []string // Rendered markdown
+	scroll    int      // First visible line
+	height    int      // Visible lines (set on render)
+}
+
+// syncProjectNotes loads the notes of the selected project when the notes
+// panel is shown and the selection changes
+func (m *Model) syncProjectNotes() {
+	if !m.projectsView().showNotes {
+		return
+	}
+	projectID := m.getSelectedProjectID()
+	if m.projectsView().projectNotes == nil || m.projectsView().projectNotes.projectID != projectID {
+		m.loadProjectNotes(projectID)
+	}
+}
+
+// loadProjectNotes (re)reads the notes of a project
+func (m *Model) loadProjectNotes(projectID string) {
+	notes := &projectNotes{projectID: projectID}
+	if note, err := m.projectsView().notes.Load(projectID); err != nil {
+		notes.err = err.Error()
+	} else if note != nil {
+		notes.content = note.Content
+		notes.exists = true
+	}
+	m.projectsView().projectNotes = notes
+}
+
+// toggleProjectNotes shows or hides the notes panel of the Projects view
+func (m *Model) toggleProjectNotes() tea.Cmd {
+	m.projectsView().showNotes = !m.projectsView().showNotes
+	m.projectsView().projectNotes = nil
+	m.syncProjectNotes()
+	return nil
+}
+
+// editProjectNotes opens the notes of the selected project in $EDITOR,
+// creating the file on first use
+func (m *Model) editProjectNotes() tea.Cmd {
+	proj := m.findProjectVM(m.getSelectedProjectID())
+	if proj == nil || m.blockReadOnly("edit notes") {
+		return nil
+	}
+	path, err := m.projectsView().notes.Ensure(proj.ID, proj.Name)
+	if err != nil {
+		m.lastError = err.Error()
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	// Show the notes when back from the editor
+	m.projectsView().showNotes = true
+	m.projectsView().projectNotes = nil
+	return m.openInEditor(path)
+}
+
+// scrollBy moves the notes by n lines (negative = up), within the content
+func (n *projectNotes) scrollBy(delta int) {
+	n.scroll += delta
+	if last := len(n.lines) - n.height; n.scroll > last {
+		n.scroll = last
+	}
+	if n.scroll < 0 {
+		n.scroll = 0
+	}
+}
+
+// renderProjectNotes renders the notes of a project as the content of the
+// detail panel
+func (m *Model) renderProjectNotes(project core.ProjectVM, width, height int) string {
+	lines := []string{
+		PanelTitleStyle.Render(project.Name + " — Notes"),
+	}
+
+	n := m.projectsView().projectNotes
+	switch {
+	case n == nil || n.projectID != project.ID:
+		lines = append(lines, SubtitleStyle.Render("Loading..."))
+		return strings.Join(lines, "\n")
+	case n.err != "":
+		lines = append(lines, StatusError.Render(n.err))
+		return strings.Join(lines, "\n")
+	case !n.exists || strings.TrimSpace(n.content) == "":
+		lines = append(lines,
+			SubtitleStyle.Render("No notes for this project yet."),
+			"",
+			HelpKeyStyle.Render("e")+" edit notes  "+HelpKeyStyle.Render("n")+" back to details")
+		return strings.Join(lines, "\n")
+	}
+
+	if n.width != width || n.lines == nil {
+		n.lines = renderMarkdown(n.content, width)
+		n.width = width
+	}
+
+	visible := height - 3 // Title and its margin, info line
+	if visible < 1 {
+		visible = 1
+	}
+	n.height = visible
+	n.scrollBy(0)
+
+	end := min(n.scroll+visible, len(n.lines))
+	lines = append(lines, n.lines[n.scroll:end]...)
+	for len(lines) < visible+1 {
+		lines = append(lines, "")
+	}
+
+	info := HelpKeyStyle.Render("e") + " edit  " + HelpKeyStyle.Render("n") + " details"
+	if len(n.lines) > visible {
+		info = fmt.Sprintf("[%d-%d/%d] ^U/^D scroll  ", n.scroll+1, end, len(n.lines)) + info
+	}
+	lines = append(lines, SubtitleStyle.Render(info))
+	return strings.Join(lines, "\n")
+}
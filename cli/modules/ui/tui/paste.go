@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Pastes larger than this ask for a confirmation: a wrong clipboard could
+// flood a shell with commands
+const (
+	largePasteBytes = 16 * 1024
+	largePasteLines = 200
+)
+
+// pasteTerminal is implemented by terminals receiving a paste as a whole
+// (bracketed when the application supports it)
+type pasteTerminal interface {
+	Paste(text string) error
+}
+
+// pendingPaste is a large paste waiting for its confirmation
+type pendingPaste struct {
+	sessionID string // Terminal pasted into (empty = Claude input)
+	text      string
+}
+
+// handlePaste sends a paste (bracketed paste of the outer terminal) to a
+// terminal or to the Claude input if empty, after a confirmation if large
+func (m *Model) handlePaste(sessionID, text string) tea.Cmd {
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+	if text == "" {
+		return nil
+	}
+
+	lines := strings.Count(text, "\n") + 1
+	if len(text) > largePasteBytes || lines > largePasteLines {
+		m.pendingPaste = &pendingPaste{sessionID: sessionID, text: text}
+		return m.openConfirmDialog(config.ConfirmLargePaste, "large_paste",
+			fmt.Sprintf("Paste %s (%d lines)?", formatSize(int64(len(text))), lines))
+	}
+	return m.applyPaste(sessionID, text)
+}
+
+// confirmPaste applies the paste confirmed in the dialog
+func (m *Model) confirmPaste() tea.Cmd {
+	paste := m.pendingPaste
+	m.pendingPaste = nil
+	if paste == nil {
+		return nil
+	}
+	return m.applyPaste(paste.sessionID, paste.text)
+}
+
+// applyPaste sends a paste to its terminal or to the Claude input
+func (m *Model) applyPaste(sessionID, text string) tea.Cmd {
+	if sessionID == "" {
+		m.insertIntoClaudeComposer(text)
+		return nil
+	}
+
+	t := m.terminalManager.Get(sessionID)
+	if t == nil || !t.IsRunning() {
+		return nil
+	}
+	var err error
+	if p, ok := t.(pasteTerminal); ok {
+		err = p.Paste(text)
+	} else {
+		err = t.WriteString(text)
+	}
+	if err != nil {
+		m.lastError = "Paste failed: " + err.Error()
+		m.lastErrorTime = time.Now()
+	}
+	return nil
+}
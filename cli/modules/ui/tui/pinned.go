@@ -0,0 +1,215 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Contents of the pinned panel
+const (
+	pinnedLogs  = "logs"  // Tail of the logs of a source
+	pinnedBuild = "build" // Progress and output of the current build
+)
+
+// pinnedPanelHeight is the height of the pinned panel at the bottom of the
+// views, borders included
+const pinnedPanelHeight = 9
+
+// pinnedPanel is a small panel kept over the bottom or the right side of
+// every view (picture-in-picture), to watch a deploy, a build or a process
+// while working in the Git or Claude view. The view under it keeps its size:
+// the panel hides its last rows (or right columns).
+type pinnedPanel struct {
+	kind   string
+	side   bool      // Right side instead of the bottom
+	source string    // Log source prefix, build: and deploy: lines included ("" = all)
+	filter logFilter // Other log filters (type, level, search)
+}
+
+// matches returns true if a log line is shown in the panel
+func (p *pinnedPanel) matches(line *core.LogLineVM) bool {
+	source := strings.TrimPrefix(strings.TrimPrefix(line.Source, "build:"), "deploy:")
+	return strings.HasPrefix(source, p.source) && p.filter.matches(line)
+}
+
+// togglePinnedLogs pins the log tail of the selection: the filters of the
+// Logs view, or the project or component selected in the other views (its
+// builds and deploys included). Unpins it if already pinned.
+func (m *Model) togglePinnedLogs() {
+	if m.pinned != nil && m.pinned.kind == pinnedLogs {
+		m.pinned = nil
+		return
+	}
+
+	panel := &pinnedPanel{kind: pinnedLogs, side: m.pinned != nil && m.pinned.side}
+	if m.currentView == core.VMLogs {
+		panel.filter = m.currentLogFilter()
+		panel.source, panel.filter.source = panel.filter.source, ""
+	} else if projectID := m.getSelectedProjectID(); projectID != "" {
+		panel.source = projectID
+		if component := m.getSelectedComponent(); component != "" {
+			panel.source += "/" + string(component)
+		}
+	}
+	m.pinned = panel
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventInfo,
+		"Pinned the logs of "+orDefault(panel.source, "all sources")+" (^G l to unpin)"))
+}
+
+// togglePinnedBuild pins the build progress, or unpins it
+func (m *Model) togglePinnedBuild() {
+	if m.pinned != nil && m.pinned.kind == pinnedBuild {
+		m.pinned = nil
+		return
+	}
+	m.pinned = &pinnedPanel{kind: pinnedBuild, side: m.pinned != nil && m.pinned.side}
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventInfo, "Pinned the build progress (^G u to unpin)"))
+}
+
+// movePinnedPanel moves the pinned panel between the bottom and the right side
+func (m *Model) movePinnedPanel() {
+	if m.pinned == nil {
+		m.lastError = "No pinned panel (^G l: logs, ^G u: build)"
+		m.lastErrorTime = time.Now()
+		return
+	}
+	m.pinned.side = !m.pinned.side
+}
+
+// renderPinnedPanel draws the pinned panel over the content of the main area
+func (m *Model) renderPinnedPanel(content string, width, height int) string {
+	panelWidth, panelHeight := width, min(pinnedPanelHeight, height/2)
+	if m.pinned.side {
+		panelWidth, panelHeight = max(36, width/3), height
+	}
+	if panelWidth > width || panelHeight < 3 {
+		return content
+	}
+
+	// Border and padding
+	innerWidth, innerHeight := panelWidth-4, panelHeight-2
+	var title string
+	var lines []string
+	switch m.pinned.kind {
+	case pinnedBuild:
+		title = "📌 Build"
+		lines = m.pinnedBuildLines(innerWidth, innerHeight-1)
+	default:
+		title = "📌 Logs: " + orDefault(m.pinned.source, "all sources")
+		lines = m.pinnedLogLines(innerHeight - 1)
+	}
+	body := []string{lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true).Render(truncate(title, innerWidth))}
+	for _, line := range lines {
+		body = append(body, truncateANSI(line, innerWidth))
+	}
+	panel := UnfocusedBorderStyle.
+		BorderForeground(ColorSecondary).
+		Padding(0, 1).
+		Width(panelWidth - 2).
+		Height(innerHeight).
+		MaxHeight(panelHeight).
+		Render(strings.Join(body, "\n"))
+
+	rows := strings.Split(content, "\n")
+	for len(rows) < height {
+		rows = append(rows, "")
+	}
+	rows = rows[:height]
+	panelRows := strings.Split(panel, "\n")
+	if m.pinned.side {
+		x := width - panelWidth
+		for i, row := range panelRows {
+			left := ansi.Truncate(rows[i], x, "")
+			rows[i] = left + strings.Repeat(" ", max(0, x-ansi.StringWidth(left))) + row
+		}
+	} else {
+		copy(rows[height-len(panelRows):], panelRows)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// pinnedLogLines renders the last log lines of the pinned source
+func (m *Model) pinnedLogLines(maxLines int) []string {
+	if m.state.Logs == nil {
+		return nil
+	}
+	tail := tailLogLines(m.state.Logs.Lines, maxLines, m.pinned.matches)
+	if len(tail) == 0 {
+		return []string{SubtitleStyle.Render("No logs yet")}
+	}
+
+	lines := make([]string, 0, len(tail))
+	for _, line := range tail {
+		levelStyle := LogInfoStyle
+		switch line.Level {
+		case "error":
+			levelStyle = LogErrorStyle
+		case "warn":
+			levelStyle = LogWarnStyle
+		case "debug":
+			levelStyle = LogDebugStyle
+		}
+		text := LogTimestampStyle.Render(line.Time().Local().Format("15:04:05")) + " "
+		if m.pinned.source == "" || line.Source != m.pinned.source {
+			text += LogSourceStyle.Render("["+truncate(line.Source, 16)+"]") + " "
+		}
+		lines = append(lines, text+levelStyle.Render(ansi.Strip(line.Text())))
+	}
+	return lines
+}
+
+// pinnedBuildLines renders the status and last output lines of the current
+// build (the image build if started last)
+func (m *Model) pinnedBuildLines(width, maxLines int) []string {
+	vm := m.state.Builds
+	if vm == nil {
+		return nil
+	}
+	imageBuild := vm.ImageBuild
+	if imageBuild != nil && vm.CurrentBuild != nil && vm.CurrentBuild.StartedAt.After(imageBuild.StartedAt) {
+		imageBuild = nil
+	}
+	if imageBuild != nil {
+		return m.renderImageBuild(imageBuild, width, maxLines)
+	}
+
+	b := vm.CurrentBuild
+	if b == nil {
+		if vm.IsBuilding {
+			return []string{m.spinner.View() + " Building..."}
+		}
+		return []string{SubtitleStyle.Render("No build running")}
+	}
+	var lines []string
+	switch string(b.Status) {
+	case "failed":
+		lines = append(lines, fmt.Sprintf("%s Build failed: %s/%s (%s)",
+			StatusError.Render(IconError), b.ProjectName, b.Component, b.Duration))
+		for _, e := range b.Errors {
+			lines = append(lines, LogErrorStyle.Render(e))
+		}
+	case "success":
+		lines = append(lines, fmt.Sprintf("%s Built %s/%s (%s)",
+			StatusSuccess.Render(IconSuccess), b.ProjectName, b.Component, b.Duration))
+	default:
+		lines = append(lines, fmt.Sprintf("%s Building %s/%s %s",
+			m.spinner.View(), b.ProjectName, b.Component, renderProgressBar(b.Progress, 20)))
+	}
+	output := b.Output
+	if room := maxLines - len(lines); len(output) > room {
+		output = output[len(output)-max(room, 0):]
+	}
+	for _, line := range output {
+		lines = append(lines, LogInfoStyle.Render(line))
+	}
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+	}
+	return lines
+}
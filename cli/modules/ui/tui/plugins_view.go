@@ -0,0 +1,392 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pluginsController is the submodel of the Plugins view
+type pluginsController struct {
+	menu *TreeMenu // Plugins > views and actions (> projects for project actions)
+
+	pendingAction  string // "plugin/action" to run (saved at dialog open)
+	pendingProject string
+}
+
+// pluginViewItem is the menu data of a plugin view
+type pluginViewItem struct {
+	plugin string
+	view   core.PluginViewVM
+}
+
+// pluginActionItem is the menu data of a plugin action (on a project if projectID is set)
+type pluginActionItem struct {
+	plugin    string
+	action    core.PluginActionVM
+	projectID string
+}
+
+// newPluginsController creates the Plugins view controller
+func newPluginsController() *pluginsController {
+	menu := NewTreeMenu(nil)
+	menu.SetTitle("Plugins")
+	return &pluginsController{menu: menu}
+}
+
+// Init implements ViewController
+func (c *pluginsController) Init(m *Model) tea.Cmd {
+	c.updateMenu(m)
+	return nil
+}
+
+// Menu implements ViewController
+func (c *pluginsController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	if focus != FocusMain {
+		return nil
+	}
+	return c.menu
+}
+
+// Keys implements ViewController
+func (c *pluginsController) Keys(m *Model) []KeyHint {
+	return []KeyHint{
+		{"Enter", "open/run"},
+		{"r", "reload"},
+	}
+}
+
+// Update implements ViewController
+func (c *pluginsController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case stateUpdateMsg:
+		if msg.update.Affects(core.VMPlugins) {
+			c.updateMenu(m)
+		}
+	case selectMsg:
+		// Select() handles back item and drill-down
+		item := c.menu.Select()
+		if item == nil {
+			return nil, true
+		}
+		if action, ok := item.Data.(pluginActionItem); ok {
+			return c.runAction(m, action), true
+		}
+		return nil, true
+	case dialogConfirmMsg:
+		if msg.dialogType != "plugin_action" {
+			return nil, false
+		}
+		target, projectID := c.pendingAction, c.pendingProject
+		c.pendingAction, c.pendingProject = "", ""
+		if target == "" {
+			return nil, true
+		}
+		return m.sendEvent(core.NewEvent(core.EventPluginAction).WithTarget(target).WithProject(projectID)), true
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			return m.sendEvent(core.NewEvent(core.EventPluginReload)), true
+		}
+	}
+	return nil, false
+}
+
+// View implements ViewController
+// Layout: TreeMenu with plugins on left, selected view/action on right
+func (c *pluginsController) View(m *Model, width, height int) string {
+	vm := m.state.Plugins
+	if vm == nil {
+		return m.renderLoading()
+	}
+
+	// 2 panels side by side (TreeMenu + detail)
+	// Height: 1 × 2 = 2
+	// Width: 2 × 2 = 4
+	heightBorders := 2
+	widthBorders := 4
+	panelHeight := height - heightBorders
+	availableWidth := width - widthBorders - GapHorizontal
+
+	// Left panel - TreeMenu with plugins
+	listWidth := c.menu.CalcWidth()
+	if listWidth < 30 {
+		listWidth = 30
+	}
+	if listWidth > availableWidth/2 {
+		listWidth = availableWidth / 2
+	}
+
+	c.menu.SetSize(listWidth, panelHeight)
+	c.menu.SetFocused(m.focusArea == FocusMain)
+	listPanel := c.menu.Render()
+
+	// Right panel - details
+	detailWidth := availableWidth - listWidth
+	detailContent := c.renderDetail(m, vm, detailWidth-4)
+
+	var detailStyle lipgloss.Style
+	if m.focusArea == FocusDetail {
+		detailStyle = FocusedBorderStyle
+	} else {
+		detailStyle = UnfocusedBorderStyle
+	}
+	detailPanel := detailStyle.Width(detailWidth - 2).Height(panelHeight).Render(detailContent)
+
+	gap := strings.Repeat(" ", GapHorizontal)
+	return lipgloss.JoinHorizontal(lipgloss.Top, listPanel, gap, detailPanel)
+}
+
+// renderDetail renders the selected plugin, view or action
+func (c *pluginsController) renderDetail(m *Model, vm *core.PluginsVM, width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	if len(vm.Plugins) == 0 {
+		lines := []string{
+			PanelTitleStyle.Render("No plugins"),
+			"",
+			mutedStyle.Render("Executables in " + vm.Dir + " are loaded as plugins."),
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	selected := c.menu.SelectedItem()
+	if selected == nil {
+		return mutedStyle.Render("Select a plugin")
+	}
+
+	var lines []string
+	switch data := selected.Data.(type) {
+	case core.PluginVM:
+		lines = append(lines, PanelTitleStyle.Render(data.Title), "")
+		if data.Description != "" {
+			lines = append(lines, data.Description, "")
+		}
+		lines = append(lines, mutedStyle.Render(truncate(data.Path, width)))
+		if data.Error != "" {
+			lines = append(lines, "", StatusError.Render(truncate("✗ "+data.Error, width)))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("Views: %d  Actions: %d", len(data.Views), len(data.Actions)))
+		if len(data.Providers) > 0 {
+			lines = append(lines, "Project data: "+strings.Join(data.Providers, ", "))
+		}
+		lines = append(lines, renderPluginResults(vm.Results, data.Name, "", width)...)
+
+	case pluginViewItem:
+		view := data.view
+		lines = append(lines, PanelTitleStyle.Render(view.Title))
+		if view.UpdatedAt.IsZero() {
+			lines = append(lines, mutedStyle.Render("Loading..."))
+		} else {
+			lines = append(lines, mutedStyle.Render("Updated "+formatRelativeTime(view.UpdatedAt)))
+		}
+		lines = append(lines, "")
+		if view.Error != "" {
+			lines = append(lines, StatusError.Render(truncate("✗ "+view.Error, width)))
+		}
+		lines = append(lines, renderPluginItems(view.Items, width)...)
+
+	case pluginActionItem:
+		action := data.action
+		title := action.Title
+		if data.projectID != "" {
+			title += " · " + data.projectID
+		}
+		lines = append(lines, PanelTitleStyle.Render(title), "")
+		if action.Description != "" {
+			lines = append(lines, action.Description, "")
+		}
+		running := false
+		for _, key := range vm.Running {
+			if key == data.plugin+"/"+action.ID {
+				running = true
+			}
+		}
+		if running {
+			lines = append(lines, mutedStyle.Render(m.spinner.View()+" running..."))
+		} else if action.Project && data.projectID == "" {
+			lines = append(lines, mutedStyle.Render("Runs on a project: select one"))
+		} else {
+			lines = append(lines, HelpKeyStyle.Render("Enter")+" run")
+		}
+		lines = append(lines, renderPluginResults(vm.Results, data.plugin, action.ID, width)...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// runAction runs a plugin action, after confirmation if the plugin asks for it
+func (c *pluginsController) runAction(m *Model, item pluginActionItem) tea.Cmd {
+	target := item.plugin + "/" + item.action.ID
+	if !item.action.Confirm {
+		return m.sendEvent(core.NewEvent(core.EventPluginAction).WithTarget(target).WithProject(item.projectID))
+	}
+	c.pendingAction, c.pendingProject = target, item.projectID
+	message := fmt.Sprintf("Run '%s'?", item.action.Title)
+	if item.projectID != "" {
+		message = fmt.Sprintf("Run '%s' on %s?", item.action.Title, item.projectID)
+	}
+	return m.openConfirmDialog(config.ConfirmPluginAction, "plugin_action", message)
+}
+
+// updateMenu updates the plugins TreeMenu with current plugins
+func (c *pluginsController) updateMenu(m *Model) {
+	if m.state.Plugins == nil {
+		return
+	}
+
+	var projectIDs []string
+	if m.state.Projects != nil {
+		for _, p := range m.state.Projects.Projects {
+			projectIDs = append(projectIDs, p.ID)
+		}
+	}
+
+	items := make([]TreeMenuItem, 0, len(m.state.Plugins.Plugins))
+	for _, plugin := range m.state.Plugins.Plugins {
+		group := TreeMenuItem{
+			ID:    plugin.Name,
+			Label: plugin.Title,
+			Icon:  "◇",
+			Data:  plugin,
+		}
+		if plugin.Error != "" {
+			group.Icon = "✗"
+			group.IconColor = ColorError
+		}
+
+		for _, view := range plugin.Views {
+			group.Children = append(group.Children, TreeMenuItem{
+				ID:    plugin.Name + "/view/" + view.ID,
+				Label: view.Title,
+				Icon:  "▤",
+				Data:  pluginViewItem{plugin: plugin.Name, view: view},
+			})
+		}
+		for _, action := range plugin.Actions {
+			item := TreeMenuItem{
+				ID:        plugin.Name + "/action/" + action.ID,
+				Label:     action.Title,
+				Icon:      "▶",
+				IconColor: ColorSecondary,
+				Data:      pluginActionItem{plugin: plugin.Name, action: action},
+			}
+			// Project actions: pick the project in a sub-level
+			if action.Project {
+				for _, id := range projectIDs {
+					item.Children = append(item.Children, TreeMenuItem{
+						ID:    item.ID + "/" + id,
+						Label: id,
+						Icon:  "📁",
+						Data:  pluginActionItem{plugin: plugin.Name, action: action, projectID: id},
+					})
+				}
+				item.Count = len(item.Children)
+			}
+			group.Children = append(group.Children, item)
+		}
+		group.Count = len(group.Children)
+		items = append(items, group)
+	}
+
+	c.menu.SetItems(items)
+}
+
+// renderPluginItems renders the lines of a plugin view or data provider
+func renderPluginItems(items []core.PluginItemVM, width int) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	var lines []string
+	for _, item := range items {
+		var icon string
+		switch item.Status {
+		case "ok":
+			icon = StatusSuccess.Render("●")
+		case "warning":
+			icon = StatusWarning.Render("●")
+		case "error":
+			icon = StatusError.Render("●")
+		default:
+			icon = mutedStyle.Render("○")
+		}
+		line := icon + " " + item.Label
+		if item.Value != "" {
+			line += ": " + item.Value
+		}
+		lines = append(lines, truncate(line, width))
+		if item.Detail != "" {
+			lines = append(lines, "  "+mutedStyle.Render(truncate(item.Detail, width-2)))
+		}
+	}
+	return lines
+}
+
+// renderPluginResults renders the recent runs of a plugin (of one action if set)
+func renderPluginResults(results []core.PluginResultVM, plugin, action string, width int) []string {
+	const maxShown = 5
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	var lines []string
+	for _, r := range results {
+		if r.Plugin != plugin || (action != "" && r.Action != action) {
+			continue
+		}
+		if len(lines) == maxShown {
+			break
+		}
+		label := r.Title
+		if r.ProjectID != "" {
+			label += " · " + r.ProjectID
+		}
+		when := mutedStyle.Render(" " + formatRelativeTime(r.FinishedAt))
+		if r.Error != "" {
+			lines = append(lines, StatusError.Render(truncate("✗ "+label+": "+r.Error, width-12))+when)
+		} else {
+			text := label
+			if r.Message != "" {
+				text += ": " + r.Message
+			}
+			lines = append(lines, StatusSuccess.Render("✓ ")+truncate(text, width-14)+when)
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+	return append([]string{"", SubtitleStyle.Render("Recent runs:")}, lines...)
+}
+
+// renderProjectPluginData renders the plugin data providers of a project (detail panel)
+func (m *Model) renderProjectPluginData(projectID string, width int) []string {
+	if m.state.Plugins == nil {
+		return nil
+	}
+
+	var lines []string
+	for _, group := range m.state.Plugins.ProjectData[projectID] {
+		lines = append(lines, "", SubtitleStyle.Render(group.Title+":"))
+		if group.Error != "" {
+			lines = append(lines, StatusError.Render(truncate("✗ "+group.Error, width)))
+			continue
+		}
+		lines = append(lines, renderPluginItems(group.Items, width)...)
+	}
+	return lines
+}
+
+// pluginsUnavailable explains why the Plugins view cannot be opened
+func (m *Model) pluginsUnavailable() {
+	dir := "the plugins directory"
+	if m.state.Plugins != nil && m.state.Plugins.Dir != "" {
+		dir = m.state.Plugins.Dir
+	}
+	m.lastError = "No plugins found in " + dir
+	m.lastErrorTime = time.Now()
+}
@@ -0,0 +1,412 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/core/processes"
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// processesController is the submodel of the Processes view
+type processesController struct {
+	menu *TreeMenu // Tree menu for processes
+}
+
+// newProcessesController creates the Processes view controller
+func newProcessesController() *processesController {
+	// Processes can be marked for stopping
+	menu := NewTreeMenu(nil)
+	menu.SetTitle("Processes")
+	menu.SetMarkable(func(item *TreeMenuItem) bool {
+		proc, ok := item.Data.(core.ProcessVM)
+		return ok && !proc.IsSelf
+	})
+	return &processesController{menu: menu}
+}
+
+// processesView returns the Processes view submodel
+func (m *Model) processesView() *processesController {
+	return m.controllers[core.VMProcesses].(*processesController)
+}
+
+// Init implements ViewController
+func (c *processesController) Init(m *Model) tea.Cmd {
+	return nil
+}
+
+// Menu implements ViewController
+func (c *processesController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	if focus != FocusMain {
+		return nil
+	}
+	return c.menu
+}
+
+// Keys implements ViewController
+func (c *processesController) Keys(m *Model) []KeyHint {
+	var hints []KeyHint
+	// TreeMenu navigation hints
+	if m.focusArea == FocusMain {
+		if !c.menu.IsAtRoot() {
+			hints = append(hints, KeyHint{"←", "back"})
+		}
+		selectedItem := c.menu.SelectedItem()
+		if selectedItem != nil && len(selectedItem.Children) > 0 {
+			hints = append(hints, KeyHint{"→/Enter", "processes"})
+		}
+	}
+	// Action shortcuts
+	return append(hints,
+		KeyHint{"r", "run"},
+		KeyHint{"s", "stop"},
+		KeyHint{"k", "kill"},
+		KeyHint{"l", "logs"},
+		KeyHint{"Space", "mark"},
+	)
+}
+
+// Update implements ViewController
+func (c *processesController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case stateUpdateMsg:
+		if msg.update.Affects(core.VMProcesses) {
+			m.updateProcessesMenu()
+		}
+	case itemCountMsg:
+		if m.state.Processes != nil {
+			m.maxMainItems = len(m.state.Processes.Processes)
+		}
+	case selectMsg:
+		// Select() handles back item, drill-down, and leaf selection
+		if item := c.menu.Select(); item != nil {
+			// Leaf item selected (process) - focus detail panel
+			if _, ok := item.Data.(core.ProcessVM); ok {
+				m.focusArea = FocusDetail
+			}
+		}
+		return nil, true
+	case tea.KeyMsg:
+		// Container actions
+		if container := c.selectedContainer(); container != nil {
+			if cmd, handled := m.handleContainerKey(msg.String(), container); handled {
+				return cmd, true
+			}
+		}
+		// Service actions
+		if s := c.selectedService(); s != nil || c.servicesGroupSelected() {
+			if cmd, handled := m.handleServiceKey(msg.String(), s); handled {
+				return cmd, true
+			}
+		}
+		return m.handleComponentKey(msg.String())
+	}
+	return nil, false
+}
+
+// View implements ViewController
+func (c *processesController) View(m *Model, width, height int) string {
+	m.syncLogFollow()
+	return m.renderProcesses(width, height)
+}
+
+// hasDetailPanel implements detailPanelView
+func (c *processesController) hasDetailPanel() bool {
+	return true
+}
+
+// selection implements selectionView
+func (c *processesController) selection(m *Model) viewSelection {
+	if selectedItem := c.menu.SelectedItem(); selectedItem != nil {
+		if proc, ok := selectedItem.Data.(core.ProcessVM); ok {
+			return viewSelection{projectID: proc.ProjectID, component: proc.Component, self: proc.IsSelf}
+		}
+	}
+	return viewSelection{}
+}
+
+// yank implements yankView (PID of the selected process, or container ID)
+func (c *processesController) yank(m *Model) tea.Cmd {
+	if item := c.menu.SelectedItem(); item != nil {
+		if proc, ok := item.Data.(core.ProcessVM); ok && proc.PID > 0 {
+			return m.yankText(fmt.Sprintf("PID %d", proc.PID), fmt.Sprint(proc.PID))
+		}
+		if container, ok := item.Data.(core.ContainerVM); ok {
+			return m.yankText("container ID", container.ID)
+		}
+	}
+	return m.yankText("", "")
+}
+
+// contextMenu implements contextMenuView
+func (c *processesController) contextMenu(m *Model) *contextMenu {
+	if container := c.selectedContainer(); container != nil {
+		return m.containerContextMenu(container)
+	}
+	if s := c.selectedService(); s != nil {
+		return m.serviceContextMenu(s)
+	}
+	if m.isSelectedProjectSelf() {
+		return m.projectContextMenu()
+	}
+	return m.projectContextMenu(
+		contextAction{"p", "Pause / resume", (*Model).pauseResumeSelected},
+		contextAction{"k", "Kill", func(m *Model) tea.Cmd {
+			return m.openConfirmDialog(config.ConfirmKillProcess, "kill", "Kill the selected process?")
+		}},
+	)
+}
+
+// renderProcesses renders the processes view
+func (m *Model) renderProcesses(width, height int) string {
+	vm := m.state.Processes
+	if vm == nil {
+		return m.renderLoading()
+	}
+
+	// 2 panels side by side, stacked when narrow
+	layout := layoutPanels(width, height, m.processesView().menu.CalcWidth(), 30)
+
+	// Left panel - TreeMenu with projects and processes
+	m.processesView().menu.SetSize(layout.listWidth, layout.listHeight)
+	m.processesView().menu.SetFocused(m.focusArea == FocusMain)
+	listPanel := m.processesView().menu.Render()
+
+	// Right panel - process details
+	detailWidth := layout.detailWidth
+	detailHeight := layout.detailHeight
+	var detailContent string
+
+	// Get selected item from TreeMenu
+	selectedItem := m.processesView().menu.SelectedItem()
+	if selectedItem != nil {
+		if proc, ok := selectedItem.Data.(core.ProcessVM); ok {
+			// Show process details
+			detailLines := []string{
+				PanelTitleStyle.Render(fmt.Sprintf("%s/%s", proc.ProjectName, proc.Component)),
+				"",
+			}
+
+			// Self indicator
+			if proc.IsSelf {
+				detailLines = append(detailLines, lipgloss.NewStyle().Foreground(ColorSecondary).Render("* This is csd-devtrack"))
+				detailLines = append(detailLines, "")
+			}
+
+			// State
+			stateIcon := StatusIcon(string(proc.State))
+			if proc.State == "running" {
+				detailLines = append(detailLines, StatusSuccess.Render(stateIcon+" Running"))
+			} else if proc.CrashLoop {
+				detailLines = append(detailLines, StatusError.Render(stateIcon+" Crash-looping: no longer restarted"))
+			} else if proc.State == "stopped" {
+				detailLines = append(detailLines, StatusError.Render(stateIcon+" Stopped"))
+			} else {
+				detailLines = append(detailLines, fmt.Sprintf("%s %s", stateIcon, proc.State))
+			}
+
+			detailLines = append(detailLines, "")
+
+			// Process info
+			if proc.PID > 0 {
+				detailLines = append(detailLines, fmt.Sprintf("PID: %d", proc.PID))
+			}
+			if proc.Uptime != "" {
+				detailLines = append(detailLines, fmt.Sprintf("Uptime: %s", proc.Uptime))
+			}
+			if proc.Restarts > 0 {
+				detailLines = append(detailLines, fmt.Sprintf("Restarts: %d", proc.Restarts))
+			}
+
+			if len(proc.Terminations) > 0 && proc.State != "running" {
+				// How it ended: exit code, signal, OOM kill
+				detailLines = append(detailLines, "")
+				detailLines = append(detailLines, StatusError.Render("Exit: ")+proc.Terminations[0].Describe())
+			} else if proc.LastError != "" {
+				detailLines = append(detailLines, "")
+				detailLines = append(detailLines, StatusError.Render("Last error:"))
+				detailLines = append(detailLines, truncate(proc.LastError, detailWidth-10))
+			}
+			if len(proc.Terminations) > 0 {
+				// Last runs, kept across restarts: spot intermittent failures
+				detailLines = append(detailLines, "")
+				detailLines = append(detailLines, SubtitleStyle.Render("Run history:"))
+				for _, t := range proc.Terminations[:min(len(proc.Terminations), 8)] {
+					detailLines = append(detailLines, truncate(formatRun(t), detailWidth-4))
+				}
+			}
+			detailLines = append(detailLines, renderDevServer(proc.DevServer, detailWidth-4)...)
+			if len(proc.StderrTail) > 0 {
+				detailLines = append(detailLines, "")
+				detailLines = append(detailLines, SubtitleStyle.Render("Last stderr lines:"))
+				for _, line := range proc.StderrTail[max(len(proc.StderrTail)-8, 0):] {
+					detailLines = append(detailLines, truncate(line, detailWidth-4))
+				}
+			}
+
+			detailLines = append(detailLines, "")
+			detailLines = append(detailLines, SubtitleStyle.Render("Actions:"))
+			if proc.State == "running" {
+				actions := HelpKeyStyle.Render("s") + " stop  " + HelpKeyStyle.Render("r") + " restart  " + HelpKeyStyle.Render("l") + " logs"
+				if m.componentURL(proc.ProjectID, proc.Component) != "" {
+					actions += "  " + HelpKeyStyle.Render("w") + " browser"
+				}
+				detailLines = append(detailLines, actions)
+			} else {
+				detailLines = append(detailLines, HelpKeyStyle.Render("r")+" run  "+HelpKeyStyle.Render("l")+" logs")
+			}
+
+			detailContent = strings.Join(detailLines, "\n")
+		} else if c, ok := selectedItem.Data.(core.ContainerVM); ok {
+			detailContent = strings.Join(containerDetailLines(c, vm.ContainerEngine, detailWidth-4), "\n")
+		} else if svc, ok := selectedItem.Data.(core.ServiceVM); ok {
+			detailContent = strings.Join(serviceDetailLines(svc, detailWidth-4), "\n")
+		} else if _, ok := selectedItem.Data.(servicesGroup); ok {
+			detailContent = strings.Join(servicesSummaryLines(vm.Services, detailWidth-4), "\n")
+		} else {
+			// Selected a project group - show summary
+			drillPath := m.processesView().menu.DrillDownPath()
+			if len(drillPath) == 0 && selectedItem.Label != "" {
+				// At project level
+				containerCount := 0
+				for _, child := range selectedItem.Children {
+					if _, ok := child.Data.(core.ContainerVM); ok {
+						containerCount++
+					}
+				}
+				detailLines := []string{
+					PanelTitleStyle.Render(selectedItem.Label),
+					"",
+					fmt.Sprintf("Processes: %d", selectedItem.Count-containerCount),
+				}
+				if containerCount > 0 {
+					detailLines = append(detailLines, fmt.Sprintf("Containers: %d", containerCount))
+				}
+				detailLines = append(detailLines, "", SubtitleStyle.Render("Press → or Enter to see processes"))
+				if vm.ContainersError != "" {
+					detailLines = append(detailLines, "", StatusWarning.Render(truncate(vm.ContainerEngine+": "+vm.ContainersError, detailWidth-4)))
+				}
+				detailContent = strings.Join(detailLines, "\n")
+			}
+		}
+	} else if len(vm.Processes) == 0 {
+		detailContent = SubtitleStyle.Render("No processes running")
+	}
+
+	// Build detail panel
+	var detailStyle lipgloss.Style
+	if m.focusArea == FocusDetail {
+		detailStyle = FocusedBorderStyle
+	} else {
+		detailStyle = UnfocusedBorderStyle
+	}
+	detailPanel := detailStyle.Width(detailWidth - 2).Height(detailHeight).Render(detailContent)
+
+	return layout.join(listPanel, detailPanel)
+}
+
+// formatRun formats a run of the run history: start, duration, how it ended
+// and its peak memory
+func formatRun(t processes.Termination) string {
+	layout := "15:04:05"
+	if t.StartedAt.Format("2006-01-02") != time.Now().Format("2006-01-02") {
+		layout = "Jan 02 15:04"
+	}
+	line := fmt.Sprintf("%s  %s  %s", t.StartedAt.Format(layout), t.Uptime.Round(time.Second), t.Describe())
+	if t.PeakRSS > 0 {
+		line += "  peak " + formatSize(int64(t.PeakRSS))
+	}
+	return line
+}
+
+// updateProcessesMenu updates the processes TreeMenu with current process data
+func (m *Model) updateProcessesMenu() {
+	if m.processesView().menu == nil || m.state.Processes == nil {
+		return
+	}
+
+	var items []TreeMenuItem
+
+	// Group processes by project
+	projectProcesses := make(map[string][]core.ProcessVM)
+	var projectOrder []string
+
+	for _, proc := range m.state.Processes.Processes {
+		if _, exists := projectProcesses[proc.ProjectName]; !exists {
+			projectOrder = append(projectOrder, proc.ProjectName)
+		}
+		projectProcesses[proc.ProjectName] = append(projectProcesses[proc.ProjectName], proc)
+	}
+
+	// Containers of the projects, listed after their processes
+	projectContainers := make(map[string][]core.ContainerVM)
+	for _, c := range m.state.Processes.Containers {
+		if _, exists := projectProcesses[c.ProjectName]; !exists {
+			if _, exists := projectContainers[c.ProjectName]; !exists {
+				projectOrder = append(projectOrder, c.ProjectName)
+			}
+		}
+		projectContainers[c.ProjectName] = append(projectContainers[c.ProjectName], c)
+	}
+
+	for _, projectName := range projectOrder {
+		procs := projectProcesses[projectName]
+		var children []TreeMenuItem
+
+		for _, proc := range procs {
+			// Status indicator
+			statusIcon := ""
+			if proc.State == "running" {
+				statusIcon = "●"
+			} else if proc.CrashLoop {
+				statusIcon = "↻✗"
+			}
+
+			children = append(children, TreeMenuItem{
+				ID:           proc.ID,
+				Label:        string(proc.Component),
+				TrailingIcon: statusIcon,
+				Data:         proc,
+			})
+		}
+
+		// Count running
+		runningCount := 0
+		for _, proc := range procs {
+			if proc.State == "running" {
+				runningCount++
+			}
+		}
+		for _, c := range projectContainers[projectName] {
+			children = append(children, containerMenuItem(c))
+			if c.Running() {
+				runningCount++
+			}
+		}
+
+		trailingIcon := ""
+		if runningCount > 0 {
+			trailingIcon = "●"
+		}
+
+		items = append(items, TreeMenuItem{
+			ID:           projectName,
+			Label:        projectName,
+			TrailingIcon: trailingIcon,
+			Children:     children,
+			Count:        len(children),
+		})
+	}
+
+	// Services of the catalog, after the projects depending on them
+	if group := servicesMenuItem(m.state.Processes.Services); group != nil {
+		items = append(items, *group)
+	}
+
+	m.processesView().menu.SetItems(items)
+}
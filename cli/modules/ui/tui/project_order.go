@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toggleFavoriteSelected pins or unpins the selected project at the top of
+// the Projects and Dashboard trees
+func (m *Model) toggleFavoriteSelected() tea.Cmd {
+	projectID := m.getSelectedProjectID()
+	if projectID == "" || m.blockReadOnly("favorites") {
+		return nil
+	}
+	return m.sendEvent(core.NewEvent(core.EventToggleFavorite).WithProject(projectID))
+}
+
+// moveSelectedProject moves the selected project up or down among the
+// projects of its group (favorites or others), switching to the custom order
+func (m *Model) moveSelectedProject(step int) tea.Cmd {
+	projectID := m.getSelectedProjectID()
+	if projectID == "" || m.blockReadOnly("project order") {
+		return nil
+	}
+	direction := "down"
+	if step < 0 {
+		direction = "up"
+	}
+	return m.sendEvent(core.NewEvent(core.EventMoveProject).WithProject(projectID).WithValue(direction))
+}
+
+// handleProjectOrderKey handles the favorite and order keys shared by the
+// Dashboard and Projects views
+func (m *Model) handleProjectOrderKey(key string) (tea.Cmd, bool) {
+	switch key {
+	case "f":
+		return m.toggleFavoriteSelected(), true
+	case "[", "]":
+		step := 1
+		if key == "[" {
+			step = -1
+		}
+		return m.moveSelectedProject(step), true
+	case "o":
+		return m.cycleProjectOrder(), true
+	}
+	return nil, false
+}
+
+// favoriteAction is the context menu action pinning or unpinning a project
+func favoriteAction(proj *core.ProjectVM) contextAction {
+	label := "Pin to the top"
+	if proj != nil && proj.Favorite {
+		label = "Unpin"
+	}
+	return contextAction{"f", label, (*Model).toggleFavoriteSelected}
+}
+
+// cycleProjectOrder switches the order of the project trees: by name, custom
+// order, most recently used
+func (m *Model) cycleProjectOrder() tea.Cmd {
+	if m.blockReadOnly("project order") {
+		return nil
+	}
+	mode := config.ProjectOrderName
+	if m.state.Projects != nil {
+		switch m.state.Projects.OrderMode {
+		case config.ProjectOrderName, "":
+			mode = config.ProjectOrderCustom
+		case config.ProjectOrderCustom:
+			mode = config.ProjectOrderRecent
+		}
+	}
+	return m.sendEvent(core.NewEvent(core.EventSetProjectOrder).WithValue(mode))
+}
+
+// projectOrderLabel describes the order of the project trees in their
+// titles (empty for the default order by name)
+func (m *Model) projectOrderLabel() string {
+	if m.state.Projects == nil {
+		return ""
+	}
+	switch m.state.Projects.OrderMode {
+	case config.ProjectOrderCustom:
+		return "custom order"
+	case config.ProjectOrderRecent:
+		return "recently used"
+	}
+	return ""
+}
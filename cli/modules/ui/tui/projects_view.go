@@ -0,0 +1,525 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/notes"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// projectsController is the submodel of the Projects view
+type projectsController struct {
+	menu *TreeMenu // Tree menu for projects and components
+
+	// File transfers and deployments
+	pendingTransferProjectID string            // Project ID for transfer dialog
+	lastTransferSpecs        map[string]string // Project ID -> last transfer input (quick redeploy)
+	pendingDeployProjectID   string            // Project ID for deploy dialogs
+	pendingDeployTarget      string            // Deploy target awaiting confirmation
+
+	// Notes panel
+	notes        *notes.Service // Per-project markdown notes
+	showNotes    bool           // Detail panel shows the project notes
+	projectNotes *projectNotes  // Notes of the selected project (nil = not loaded)
+}
+
+// newProjectsController creates the Projects view controller
+func newProjectsController() *projectsController {
+	// Projects can be marked for building
+	menu := NewTreeMenu(nil)
+	menu.SetTitle("Projects")
+	menu.SetMarkable(func(item *TreeMenuItem) bool {
+		_, ok := item.Data.(core.ProjectVM)
+		return ok
+	})
+	// Project notes live in the data dir (no notes if it cannot be resolved)
+	notesDir := ""
+	if dataDir, err := config.GetDataDir(); err == nil {
+		notesDir = filepath.Join(dataDir, "notes")
+	}
+	return &projectsController{menu: menu, notes: notes.NewService(notesDir)}
+}
+
+// projectsView returns the Projects view submodel
+func (m *Model) projectsView() *projectsController {
+	return m.controllers[core.VMProjects].(*projectsController)
+}
+
+// Init implements ViewController
+func (c *projectsController) Init(m *Model) tea.Cmd {
+	return nil
+}
+
+// Menu implements ViewController
+func (c *projectsController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	if focus != FocusMain {
+		return nil
+	}
+	return c.menu
+}
+
+// Keys implements ViewController
+func (c *projectsController) Keys(m *Model) []KeyHint {
+	var hints []KeyHint
+	// TreeMenu navigation hints
+	if m.focusArea == FocusMain {
+		if !c.menu.IsAtRoot() {
+			hints = append(hints, KeyHint{"←", "back"})
+		}
+		selectedItem := c.menu.SelectedItem()
+		if selectedItem != nil && len(selectedItem.Children) > 0 {
+			hints = append(hints, KeyHint{"→/Enter", "components"})
+		}
+	}
+	// Action shortcuts
+	hints = append(hints,
+		KeyHint{"b", "build"},
+		KeyHint{"r", "run"},
+		KeyHint{"s", "stop"},
+	)
+	if m.state.Capabilities != nil && m.state.Capabilities.HasTransfer() {
+		hints = append(hints, KeyHint{"t", "transfer"})
+	}
+	if proj := m.findProjectVM(m.getSelectedProjectID()); proj != nil && len(proj.DeployTargets) > 0 {
+		hints = append(hints, KeyHint{"d", "deploy"})
+	}
+	return append(hints,
+		KeyHint{"n", "notes"},
+		KeyHint{"e", "edit notes"},
+		KeyHint{"f", "favorite"},
+		KeyHint{"[]", "move"},
+		KeyHint{"o", "order"},
+		KeyHint{"Space", "mark"},
+	)
+}
+
+// Update implements ViewController
+func (c *projectsController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case stateUpdateMsg:
+		if msg.update.Affects(core.VMProjects) {
+			m.updateProjectsMenu()
+		}
+	case itemCountMsg:
+		if m.state.Projects != nil {
+			// Count total component rows (one line per component)
+			count := 0
+			for _, p := range m.state.Projects.Projects {
+				if len(p.Components) == 0 {
+					count++ // Project with no components still shows 1 row
+				} else {
+					count += len(p.Components)
+				}
+			}
+			m.maxMainItems = count
+		}
+	case selectMsg:
+		// Select() handles back item, drill-down, and leaf selection
+		if item := c.menu.Select(); item != nil {
+			// Leaf item selected (component) - focus detail panel
+			if _, ok := item.Data.(core.ComponentVM); ok {
+				m.focusArea = FocusDetail
+			}
+		}
+		return nil, true
+	case finderEditorMsg:
+		// The notes may have been edited: reload them
+		c.projectNotes = nil
+	case dialogConfirmMsg:
+		switch msg.dialogType {
+		case "transfer":
+			return m.startTransfer(), true
+		case "deploy_target":
+			return m.selectDeployTarget(), true
+		case "deploy":
+			return m.startDeploy(), true
+		}
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "t":
+			return m.openTransferDialog(), true
+		case "d":
+			return m.openDeployDialog(), true
+		case "n":
+			return m.toggleProjectNotes(), true
+		case "e":
+			return m.editProjectNotes(), true
+		case "ctrl+u", "ctrl+d":
+			// Scroll the project notes
+			if c.showNotes && c.projectNotes != nil {
+				step := max(c.projectNotes.height/2, 1)
+				if msg.String() == "ctrl+u" {
+					step = -step
+				}
+				c.projectNotes.scrollBy(step)
+				return nil, true
+			}
+		}
+		if cmd, handled := m.handleProjectOrderKey(msg.String()); handled {
+			return cmd, true
+		}
+		return m.handleComponentKey(msg.String())
+	}
+	return nil, false
+}
+
+// View implements ViewController
+func (c *projectsController) View(m *Model, width, height int) string {
+	m.syncProjectNotes()
+	m.syncLogFollow()
+	return m.renderProjects(width, height)
+}
+
+// hasDetailPanel implements detailPanelView
+func (c *projectsController) hasDetailPanel() bool {
+	return true
+}
+
+// yank implements yankView
+func (c *projectsController) yank(m *Model) tea.Cmd {
+	return m.yankSelectedProject()
+}
+
+// contextMenu implements contextMenuView
+func (c *projectsController) contextMenu(m *Model) *contextMenu {
+	proj := m.findProjectVM(m.getSelectedProjectID())
+	extra := []contextAction{
+		favoriteAction(proj),
+		{"n", "Show / hide notes", (*Model).toggleProjectNotes},
+		{"e", "Edit notes", (*Model).editProjectNotes},
+	}
+	if m.state.Capabilities != nil && m.state.Capabilities.HasTransfer() {
+		extra = append(extra, contextAction{"t", "Transfer (push/pull)", (*Model).openTransferDialog})
+	}
+	if proj != nil && len(proj.DeployTargets) > 0 {
+		extra = append(extra, contextAction{"d", "Deploy", (*Model).openDeployDialog})
+	}
+	return m.projectContextMenu(extra...)
+}
+
+// selection implements selectionView
+func (c *projectsController) selection(m *Model) viewSelection {
+	selectedItem := c.menu.SelectedItem()
+	if selectedItem == nil {
+		return viewSelection{}
+	}
+	switch data := selectedItem.Data.(type) {
+	case core.ProjectVM:
+		return viewSelection{projectID: data.ID, self: data.IsSelf}
+	case core.ComponentVM:
+		// Get the project from the drill-down path
+		drillPath := c.menu.DrillDownPath()
+		if len(drillPath) == 0 {
+			return viewSelection{component: data.Type}
+		}
+		sel := viewSelection{projectID: drillPath[0], component: data.Type}
+		for _, p := range m.state.Projects.Projects {
+			if p.ID == drillPath[0] {
+				sel.self = p.IsSelf
+			}
+		}
+		return sel
+	}
+	return viewSelection{}
+}
+
+// handleComponentKey handles the action keys on the selected project or
+// component, shared by the Dashboard, Projects and Processes views
+func (m *Model) handleComponentKey(key string) (tea.Cmd, bool) {
+	switch key {
+	case ".":
+		return m.armDryRun(), true
+	case "x":
+		return m.openComponentCommandDialog(), true
+	case "w":
+		return m.openInBrowser(), true
+	case "a":
+		return m.openAPIExplorer(), true
+	case "i":
+		return m.buildImageSelected(), true
+	case "u":
+		return m.servicesUpSelected(), true
+	case "b":
+		return m.buildSelected(), true
+	case "r":
+		return m.runSelected(), true
+	case "s":
+		return m.stopSelected(), true
+	case "k":
+		if m.isSelectedProjectSelf() {
+			m.lastError = "Cannot kill self"
+			m.lastErrorTime = time.Now()
+			return nil, true
+		}
+		return m.openConfirmDialog(config.ConfirmKillProcess, "kill", "Kill the selected process?"), true
+	case "p":
+		if m.isSelectedProjectSelf() {
+			m.lastError = "Cannot pause self"
+			m.lastErrorTime = time.Now()
+			return nil, true
+		}
+		return m.pauseResumeSelected(), true
+	case "l":
+		return m.viewLogsForSelected(), true
+	}
+	return nil, false
+}
+
+// renderProjects renders the projects view with one line per component
+func (m *Model) renderProjects(width, height int) string {
+	vm := m.state.Projects
+	if vm == nil {
+		return m.renderLoading()
+	}
+
+	// 2 panels side by side (TreeMenu + detail), stacked when narrow
+	layout := layoutPanels(width, height, m.projectsView().menu.CalcWidth(), 30)
+
+	// Left panel - TreeMenu with projects and components
+	m.projectsView().menu.SetSize(layout.listWidth, layout.listHeight)
+	m.projectsView().menu.SetFocused(m.focusArea == FocusMain)
+	listPanel := m.projectsView().menu.Render()
+
+	// Right panel - project/component details
+	detailWidth := layout.detailWidth
+	detailHeight := layout.detailHeight
+	var detailContent string
+
+	// Get selected item from TreeMenu
+	selectedItem := m.projectsView().menu.SelectedItem()
+	if selectedItem != nil {
+		// Check if it's a component or project
+		if comp, ok := selectedItem.Data.(core.ComponentVM); ok {
+			// Show component details
+			detailLines := []string{
+				PanelTitleStyle.Render(string(comp.Type)),
+				"",
+			}
+
+			// Status
+			if comp.IsRunning {
+				detailLines = append(detailLines, StatusSuccess.Render("● Running"))
+				if comp.PID > 0 {
+					detailLines = append(detailLines, fmt.Sprintf("PID: %d", comp.PID))
+				}
+				if comp.Uptime != "" {
+					detailLines = append(detailLines, fmt.Sprintf("Uptime: %s", comp.Uptime))
+				}
+			} else {
+				detailLines = append(detailLines, StatusError.Render("○ Stopped"))
+			}
+
+			detailLines = append(detailLines, "")
+
+			// Path
+			if comp.Path != "" {
+				detailLines = append(detailLines, fmt.Sprintf("Path: %s", comp.Path))
+			}
+			if comp.Binary != "" {
+				detailLines = append(detailLines, fmt.Sprintf("Binary: %s", comp.Binary))
+			}
+			if comp.Port > 0 {
+				detailLines = append(detailLines, fmt.Sprintf("Port: %d", comp.Port))
+			}
+			url := m.componentURL(m.getSelectedProjectID(), comp.Type)
+			if url != "" {
+				detailLines = append(detailLines, "URL: "+hyperlink(url, truncate(url, detailWidth-9)))
+			}
+			if comp.Certificate != nil {
+				detailLines = append(detailLines, certificateLines(comp.Certificate, detailWidth-4)...)
+			}
+			if comp.IsRunning {
+				detailLines = append(detailLines, renderDevServer(m.componentDevServer(m.getSelectedProjectID(), comp.Type), detailWidth-4)...)
+			}
+
+			detailLines = append(detailLines, "")
+			detailLines = append(detailLines, SubtitleStyle.Render("Actions:"))
+			actions := HelpKeyStyle.Render("r") + " run  " + HelpKeyStyle.Render("b") + " build"
+			if comp.IsRunning {
+				actions = HelpKeyStyle.Render("s") + " stop  " + HelpKeyStyle.Render("r") + " restart"
+			}
+			if url != "" {
+				actions += "  " + HelpKeyStyle.Render("w") + " open in the browser"
+			}
+			if comp.HasAPI {
+				actions += "  " + HelpKeyStyle.Render("a") + " API explorer"
+			}
+			detailLines = append(detailLines, actions)
+
+			detailContent = strings.Join(detailLines, "\n")
+		} else if project, ok := selectedItem.Data.(core.ProjectVM); ok {
+			// Show project details
+			detailLines := []string{
+				PanelTitleStyle.Render(project.Name),
+				"",
+			}
+
+			// Self indicator
+			if project.IsSelf {
+				detailLines = append(detailLines, lipgloss.NewStyle().Foreground(ColorSecondary).Render("* This is csd-devtrack"))
+				detailLines = append(detailLines, "")
+			}
+
+			// Path
+			detailLines = append(detailLines, fmt.Sprintf("Path: %s", project.Path))
+
+			// Check if path exists
+			if _, err := os.Stat(project.Path); os.IsNotExist(err) {
+				detailLines = append(detailLines, StatusError.Render("⚠ Path does not exist"))
+			}
+
+			detailLines = append(detailLines, "")
+
+			// Git info
+			if project.GitBranch != "" {
+				branchLine := fmt.Sprintf("Branch: %s", GitBranchStyle.Render(project.GitBranch))
+				if project.GitDirty {
+					branchLine += GitDirtyStyle.Render(" *")
+				}
+				detailLines = append(detailLines, branchLine)
+				if project.GitAhead > 0 {
+					detailLines = append(detailLines, GitAheadStyle.Render(fmt.Sprintf("  ↑%d ahead", project.GitAhead)))
+				}
+				if project.GitBehind > 0 {
+					detailLines = append(detailLines, GitBehindStyle.Render(fmt.Sprintf("  ↓%d behind", project.GitBehind)))
+				}
+			} else if m.state.GitLoading {
+				detailLines = append(detailLines, lipgloss.NewStyle().Foreground(ColorMuted).Render(m.spinner.View()+" Loading git..."))
+			}
+
+			detailLines = append(detailLines, "")
+
+			// Components summary
+			runningCount := 0
+			for _, comp := range project.Components {
+				if comp.IsRunning {
+					runningCount++
+				}
+			}
+			detailLines = append(detailLines, fmt.Sprintf("Components: %d (%d running)", len(project.Components), runningCount))
+			if project.HasImage {
+				detailLines = append(detailLines, m.projectImageLine(project.ID))
+			}
+
+			if len(project.Components) > 0 {
+				detailLines = append(detailLines, "")
+				detailLines = append(detailLines, SubtitleStyle.Render("Press → or Enter to see components"))
+			}
+
+			// Deploy targets, then recent push/pull transfers and commands
+			detailLines = append(detailLines, m.renderProjectDeployments(project, detailWidth-4)...)
+			detailLines = append(detailLines, m.renderProjectTransfers(project.ID, detailWidth-4)...)
+			detailLines = append(detailLines, m.renderProjectCommandRuns(project.ID, detailWidth-4)...)
+			detailLines = append(detailLines, m.renderProjectPluginData(project.ID, detailWidth-4)...)
+
+			detailContent = strings.Join(detailLines, "\n")
+			if m.projectsView().showNotes {
+				detailContent = m.renderProjectNotes(project, detailWidth-4, detailHeight)
+			}
+		}
+	} else if len(vm.Projects) == 0 {
+		detailContent = SubtitleStyle.Render("No projects configured")
+	}
+
+	// Build detail panel
+	var detailStyle lipgloss.Style
+	if m.focusArea == FocusDetail {
+		detailStyle = FocusedBorderStyle
+	} else {
+		detailStyle = UnfocusedBorderStyle
+	}
+	detailPanel := detailStyle.Width(detailWidth - 2).Height(detailHeight).Render(detailContent)
+
+	return layout.join(listPanel, detailPanel)
+}
+
+// updateProjectsMenu updates the projects TreeMenu with current project data
+func (m *Model) updateProjectsMenu() {
+	if m.projectsView().menu == nil || m.state.Projects == nil {
+		return
+	}
+
+	var items []TreeMenuItem
+
+	for _, p := range m.state.Projects.Projects {
+		// Create children for each component
+		var children []TreeMenuItem
+
+		for _, comp := range p.Components {
+			// Status indicator
+			statusIcon := ""
+			if comp.IsRunning {
+				statusIcon = "●"
+			}
+
+			children = append(children, TreeMenuItem{
+				ID:           p.ID + ":" + string(comp.Type),
+				Label:        string(comp.Type),
+				TrailingIcon: statusIcon,
+				Data:         comp,
+			})
+		}
+
+		// Project status: count running components
+		runningCount := 0
+		for _, comp := range p.Components {
+			if comp.IsRunning {
+				runningCount++
+			}
+		}
+
+		// Project icon based on status (favorites are pinned first)
+		projectIcon := ""
+		if p.IsSelf {
+			projectIcon = "*"
+		}
+		if p.Favorite {
+			projectIcon = "★" + projectIcon
+		}
+
+		// Trailing icon for running indicator
+		trailingIcon := ""
+		if runningCount > 0 {
+			trailingIcon = "●"
+		}
+
+		items = append(items, TreeMenuItem{
+			ID:           p.ID,
+			Label:        p.Name,
+			Icon:         projectIcon,
+			TrailingIcon: trailingIcon,
+			Children:     children,
+			Count:        len(p.Components),
+			Data:         p,
+		})
+	}
+
+	title := "Projects"
+	if label := m.projectOrderLabel(); label != "" {
+		title += " (" + label + ")"
+	}
+	m.projectsView().menu.SetTitle(title)
+
+	// Keep the selection on the same project when the order changes
+	selectedID := ""
+	if item := m.projectsView().menu.SelectedItem(); item != nil && m.projectsView().menu.IsAtRoot() {
+		selectedID = item.ID
+	}
+	m.projectsView().menu.SetItems(items)
+	if selectedID != "" {
+		for i, item := range m.projectsView().menu.VisibleItems() {
+			if item.ID == selectedID {
+				m.projectsView().menu.SetSelectedIndex(i)
+				break
+			}
+		}
+	}
+}
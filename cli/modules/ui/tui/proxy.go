@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// proxyPanel is the overlay of the built-in reverse proxy: its entrypoint and
+// the live status of its routes (^G p)
+type proxyPanel struct {
+	scroll int // First visible line
+	height int // Visible lines (set at render)
+}
+
+// openProxy opens the reverse proxy panel
+func (m *Model) openProxy() tea.Cmd {
+	m.proxy = &proxyPanel{}
+	return nil
+}
+
+// proxyVM returns the state of the reverse proxy, nil until initialized
+func (m *Model) proxyVM() *core.ProxyVM {
+	if m.state.Processes == nil {
+		return nil
+	}
+	return m.state.Processes.Proxy
+}
+
+// handleProxyKey handles the keys of the proxy panel: start/stop, copy the
+// entrypoint, scroll, close
+func (m *Model) handleProxyKey(msg tea.KeyMsg) tea.Cmd {
+	p := m.proxy
+	vm := m.proxyVM()
+	switch msg.String() {
+	case "esc", "q":
+		m.proxy = nil
+	case "up", "k":
+		p.scroll = max(p.scroll-1, 0)
+	case "down", "j":
+		p.scroll++
+	case "pgup", "shift+up":
+		p.scroll = max(p.scroll-p.height, 0)
+	case "pgdown", "shift+down":
+		p.scroll += p.height
+	case "s":
+		return m.sendEvent(core.NewEvent(core.EventProxyToggle))
+	case "y":
+		if vm != nil && vm.URL != "" {
+			return m.yankText("proxy URL", vm.URL)
+		}
+	}
+	return nil
+}
+
+// proxyLines renders the body of the proxy panel
+func proxyLines(vm *core.ProxyVM, width int) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	var lines []string
+	switch {
+	case vm.URL != "":
+		lines = append(lines, StatusSuccess.Render("● listening on ")+hyperlink(vm.URL, vm.URL))
+	case vm.Error != "":
+		lines = append(lines, StatusError.Render("✗ "+vm.Error))
+	default:
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("○ stopped (port %d): s to start", vm.Port)))
+	}
+	if vm.Enabled {
+		lines = append(lines, mutedStyle.Render("Started with DevTrack"))
+	}
+	lines = append(lines, "")
+
+	if vm.URL == "" {
+		lines = append(lines,
+			mutedStyle.Render("Routes are read from the settings when the proxy starts, e.g."),
+			"  proxy:",
+			"    port: 8000",
+			"    routes:",
+			"      - {path: /app-a, project: app-a, component: frontend}",
+			"      - {path: /api, project: app-a, component: backend, strip_prefix: true}",
+			"      - {host: app-b.localhost, project: app-b, component: frontend}",
+		)
+	} else if len(vm.Routes) == 0 {
+		lines = append(lines, StatusWarning.Render("⚠ no routes: add them to settings.proxy.routes, then restart the proxy"))
+	}
+
+	for _, route := range vm.Routes {
+		status := StatusError.Render("✗ " + route.Error)
+		if route.Up() {
+			status = StatusSuccess.Render("✓ " + route.Upstream)
+		}
+		name := route.Name + strings.Repeat(" ", max(28-lipgloss.Width(route.Name), 2))
+		lines = append(lines, name+"→ "+route.Target+"  "+status)
+
+		counters := fmt.Sprintf("%d requests", route.Requests)
+		if route.Failures > 0 {
+			counters += ", " + StatusError.Render(fmt.Sprintf("%d failed", route.Failures))
+		}
+		if route.Requests > 0 {
+			last := fmt.Sprintf("last %s %d in %s, %s ago", route.LastPath, route.LastStatus,
+				route.LastLatency.Round(time.Millisecond), time.Since(route.LastRequest).Round(time.Second))
+			if route.LastStatus >= 500 {
+				last = StatusError.Render(last)
+			}
+			counters += mutedStyle.Render(" · ") + last
+		}
+		lines = append(lines, "   "+mutedStyle.Render(counters))
+	}
+
+	for i, line := range lines {
+		lines[i] = truncateANSI(line, width)
+	}
+	return lines
+}
+
+// renderProxyOverlay renders the reverse proxy panel
+func (m *Model) renderProxyOverlay(width, height int) string {
+	p := m.proxy
+	boxWidth := min(width-4, 110)
+	innerWidth := boxWidth - 6 // Border and padding
+
+	var body []string
+	if vm := m.proxyVM(); vm == nil {
+		body = append(body, SubtitleStyle.Render("Loading..."))
+	} else {
+		body = proxyLines(vm, innerWidth)
+	}
+
+	p.height = max(height-8, 3)
+	p.scroll = min(p.scroll, max(len(body)-p.height, 0))
+	lines := []string{
+		DialogTitleStyle.MarginBottom(0).Render("Reverse proxy"),
+		SubtitleStyle.Render("One local entrypoint routing paths and hostnames to the running components"),
+		"",
+	}
+	lines = append(lines, body[p.scroll:min(p.scroll+p.height, len(body))]...)
+	lines = append(lines, "", strings.Join(renderKeyHints([]KeyHint{
+		{"s", "start/stop"}, {"y", "copy URL"}, {"Esc", "close"},
+	}), ""))
+
+	box := DialogStyle.Padding(0, 2).Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Top,
+		lipgloss.NewStyle().MarginTop(1).Render(box),
+	)
+}
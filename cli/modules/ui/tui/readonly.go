@@ -0,0 +1,27 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+)
+
+// blockReadOnly reports whether an action is blocked by read-only mode,
+// and shows why in the status bar
+func (m *Model) blockReadOnly(action string) bool {
+	if !m.readOnly {
+		return false
+	}
+	m.lastError = "Read-only mode: " + action + " blocked"
+	m.lastErrorTime = time.Now()
+	return true
+}
+
+// blockReadOnlyEvent reports whether an event is blocked by read-only mode
+func (m *Model) blockReadOnlyEvent(event *core.Event) bool {
+	if core.ReadOnlyEvents[event.Type] {
+		return false
+	}
+	return m.blockReadOnly(strings.ReplaceAll(string(event.Type), "_", " "))
+}
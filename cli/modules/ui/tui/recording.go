@@ -0,0 +1,409 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/recording"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vito/vt100"
+)
+
+// Replay limits
+const (
+	replayMaxGap   = 2 * time.Second // Longer idle gaps of a recording are shortened
+	replayMaxSpeed = 16.0
+	replayMinSpeed = 0.25
+)
+
+// recordingsPanel is the overlay listing the terminal recordings (^G R),
+// with a player replaying the selected one
+type recordingsPanel struct {
+	dir        string
+	recordings []recording.Recording
+	loading    bool
+	selected   int
+	top        int // First visible recording
+	height     int // Visible recordings (set at render)
+	player     *castPlayer
+}
+
+// castPlayer replays a recording in a vt100 emulator, event by event
+type castPlayer struct {
+	title   string
+	cast    *recording.Cast
+	vt      *vt100.VT100
+	next    int // Next event to apply
+	playing bool
+	speed   float64
+	gen     int // Playback generation: ticks of an older one are dropped
+}
+
+// recordingsListMsg carries the recordings listed in the background
+type recordingsListMsg struct {
+	recordings []recording.Recording
+	err        error
+}
+
+// recordingLoadedMsg carries a recording loaded for replay
+type recordingLoadedMsg struct {
+	title string
+	cast  *recording.Cast
+	err   error
+}
+
+// replayTickMsg applies the next event of a playing recording
+type replayTickMsg struct {
+	gen int
+}
+
+// recordingsDir returns the configured recordings directory
+func recordingsDir() (string, error) {
+	var cfg *config.RecordingConfig
+	if global := config.GetGlobal(); global != nil && global.Settings != nil {
+		cfg = global.Settings.GetRecordingConfig()
+	}
+	return config.GetRecordingsDir(cfg)
+}
+
+// toggleRecording starts or stops recording the active terminal (^G r)
+func (m *Model) toggleRecording() tea.Cmd {
+	sessionID := m.activeTerminalSession()
+	t := m.terminalManager.Get(sessionID)
+	if sessionID == "" || t == nil || !t.IsRunning() {
+		m.lastError = "No running terminal session to record"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	tmuxTerminal, ok := t.(*TerminalTmux)
+	if !ok {
+		m.lastError = "Recording needs the tmux terminal backend"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	if m.blockReadOnly("record") {
+		return nil
+	}
+
+	if recording, path := tmuxTerminal.Recording(); recording {
+		if err := tmuxTerminal.StopRecording(); err != nil {
+			m.lastError = "Failed to stop recording: " + err.Error()
+			m.lastErrorTime = time.Now()
+			return nil
+		}
+		msg := "Recording stopped"
+		if path != "" {
+			msg += ": " + filepath.Base(path)
+		}
+		m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess, msg))
+		m.refreshSessionActivity()
+		return nil
+	}
+
+	dir, err := recordingsDir()
+	if err != nil {
+		m.lastError = "Recordings directory not available: " + err.Error()
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	title := tmuxTerminal.tmuxName
+	path := filepath.Join(dir, recording.FileName(title, time.Now()))
+	if err := tmuxTerminal.StartRecording(path, title); err != nil {
+		m.lastError = "Failed to start recording: " + err.Error()
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventInfo, "Recording to "+path+" (^G r to stop)"))
+	m.refreshSessionActivity()
+	return nil
+}
+
+// openRecordings opens the recordings overlay and lists them in the background
+func (m *Model) openRecordings() tea.Cmd {
+	dir, err := recordingsDir()
+	if err != nil {
+		m.lastError = "Recordings directory not available: " + err.Error()
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	m.recordings = &recordingsPanel{dir: dir, loading: true}
+	return func() tea.Msg {
+		recordings, err := recording.List(dir)
+		return recordingsListMsg{recordings: recordings, err: err}
+	}
+}
+
+// handleRecordingsMsg handles the messages of the recordings overlay
+func (m *Model) handleRecordingsMsg(msg tea.Msg) tea.Cmd {
+	r := m.recordings
+	if r == nil {
+		return nil
+	}
+	switch msg := msg.(type) {
+	case recordingsListMsg:
+		r.loading = false
+		r.recordings = msg.recordings
+		if msg.err != nil {
+			m.lastError = "Failed to list recordings: " + msg.err.Error()
+			m.lastErrorTime = time.Now()
+		}
+		r.selected = min(r.selected, max(len(r.recordings)-1, 0))
+
+	case recordingLoadedMsg:
+		if msg.err != nil {
+			m.lastError = "Failed to load recording: " + msg.err.Error()
+			m.lastErrorTime = time.Now()
+			return nil
+		}
+		r.player = newCastPlayer(msg.title, msg.cast)
+		return r.player.play()
+
+	case replayTickMsg:
+		if p := r.player; p != nil && p.playing && msg.gen == p.gen {
+			p.step()
+			if p.next >= len(p.cast.Events) {
+				p.playing = false
+				return nil
+			}
+			return p.schedule()
+		}
+	}
+	return nil
+}
+
+// handleRecordingsKey handles keys while the recordings overlay is open
+func (m *Model) handleRecordingsKey(msg tea.KeyMsg) tea.Cmd {
+	r := m.recordings
+	if p := r.player; p != nil {
+		return p.handleKey(r, msg.String())
+	}
+
+	switch msg.String() {
+	case "esc", "q", "ctrl+c":
+		m.recordings = nil
+	case "up", "k":
+		r.selected = max(r.selected-1, 0)
+	case "down", "j":
+		r.selected = max(min(r.selected+1, len(r.recordings)-1), 0)
+	case "pgup":
+		r.selected = max(r.selected-r.height, 0)
+	case "pgdown":
+		r.selected = max(min(r.selected+r.height, len(r.recordings)-1), 0)
+	case "enter":
+		if rec := r.selectedRecording(); rec != nil {
+			path, title := rec.Path, rec.Title
+			return func() tea.Msg {
+				cast, err := recording.Load(path)
+				return recordingLoadedMsg{title: title, cast: cast, err: err}
+			}
+		}
+	case "y":
+		if rec := r.selectedRecording(); rec != nil {
+			m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess, "Copied: "+rec.Path))
+			return copyToClipboard(rec.Path)
+		}
+	}
+	return nil
+}
+
+// selectedRecording returns the selected recording, nil if none
+func (r *recordingsPanel) selectedRecording() *recording.Recording {
+	if r.selected < 0 || r.selected >= len(r.recordings) {
+		return nil
+	}
+	return &r.recordings[r.selected]
+}
+
+// newCastPlayer creates a player at the start of a recording
+func newCastPlayer(title string, cast *recording.Cast) *castPlayer {
+	p := &castPlayer{title: title, cast: cast, speed: 1}
+	p.seek(0)
+	return p
+}
+
+// seek replays the recording up to event n (excluded) on a fresh screen
+func (p *castPlayer) seek(n int) {
+	width, height := p.cast.Header.Width, p.cast.Header.Height
+	if width <= 0 || height <= 0 {
+		width, height = 80, 24
+	}
+	p.vt = vt100.NewVT100(height, width)
+	p.next = 0
+	for p.next < n && p.next < len(p.cast.Events) {
+		p.step()
+	}
+}
+
+// step applies the next event to the screen
+func (p *castPlayer) step() {
+	if p.next < len(p.cast.Events) {
+		p.vt.Write([]byte(p.cast.Events[p.next].Data))
+		p.next++
+	}
+}
+
+// play starts the playback (from the start once finished)
+func (p *castPlayer) play() tea.Cmd {
+	if p.next >= len(p.cast.Events) {
+		p.seek(0)
+	}
+	p.playing = true
+	p.gen++
+	return p.schedule()
+}
+
+// schedule waits for the time of the next event at the playback speed
+func (p *castPlayer) schedule() tea.Cmd {
+	if p.next >= len(p.cast.Events) {
+		return nil
+	}
+	var gap float64
+	if p.next > 0 {
+		gap = p.cast.Events[p.next].Time - p.cast.Events[p.next-1].Time
+	}
+	delay := time.Duration(gap * float64(time.Second))
+	if delay > replayMaxGap {
+		delay = replayMaxGap
+	}
+	delay = time.Duration(float64(delay) / p.speed)
+	gen := p.gen
+	return tea.Tick(delay, func(time.Time) tea.Msg { return replayTickMsg{gen: gen} })
+}
+
+// handleKey handles the player keys
+func (p *castPlayer) handleKey(r *recordingsPanel, key string) tea.Cmd {
+	switch key {
+	case "esc", "q", "ctrl+c":
+		r.player = nil
+	case " ", "p":
+		if p.playing {
+			p.playing = false
+			return nil
+		}
+		return p.play()
+	case "right", "l":
+		p.playing = false
+		p.step()
+	case "left", "h":
+		p.playing = false
+		p.seek(max(p.next-1, 0))
+	case "home", "g":
+		p.playing = false
+		p.seek(0)
+	case "end", "G":
+		p.playing = false
+		p.seek(len(p.cast.Events))
+	case "+", "=":
+		if p.speed < replayMaxSpeed {
+			p.speed *= 2
+		}
+	case "-":
+		if p.speed > replayMinSpeed {
+			p.speed /= 2
+		}
+	}
+	return nil
+}
+
+// elapsed returns the recording time of the last applied event
+func (p *castPlayer) elapsed() time.Duration {
+	if p.next == 0 {
+		return 0
+	}
+	return time.Duration(p.cast.Events[p.next-1].Time * float64(time.Second))
+}
+
+// renderRecordingsOverlay renders the recordings list or the player
+func (m *Model) renderRecordingsOverlay(width, height int) string {
+	r := m.recordings
+	boxWidth := min(width-4, 130)
+	innerWidth := boxWidth - 6 // Border and padding
+
+	var lines []string
+	if p := r.player; p != nil {
+		state := "⏸ paused"
+		if p.playing {
+			state = "▶ playing"
+		} else if p.next >= len(p.cast.Events) {
+			state = "■ finished"
+		}
+		lines = append(lines,
+			DialogTitleStyle.MarginBottom(0).Render(truncate("Replay: "+p.title, innerWidth)),
+			SubtitleStyle.Render(fmt.Sprintf("%s · %s / %s · event %d/%d · x%g", state,
+				formatReplayTime(p.elapsed()), formatReplayTime(p.cast.Duration()),
+				p.next, len(p.cast.Events), p.speed)),
+			"",
+		)
+		screen := strings.Split(renderVT100(p.vt), "\n")
+		visible := max(height-10, 3)
+		if len(screen) > visible {
+			screen = screen[len(screen)-visible:] // Keeps the cursor area
+		}
+		for _, line := range screen {
+			lines = append(lines, truncateANSI(line, innerWidth))
+		}
+		lines = append(lines, "", strings.Join(renderKeyHints([]KeyHint{
+			{"Space", "play/pause"}, {"←→", "step"}, {"g/G", "start/end"}, {"+/-", "speed"}, {"Esc", "back"},
+		}), ""))
+	} else {
+		title := "Recordings"
+		if r.loading {
+			title += " " + m.spinner.View()
+		} else {
+			title += fmt.Sprintf(" (%d)", len(r.recordings))
+		}
+		lines = append(lines,
+			DialogTitleStyle.MarginBottom(0).Render(title),
+			SubtitleStyle.Render(truncate(r.dir, innerWidth)),
+			"",
+		)
+
+		r.height = max(height-12, 3)
+		if r.selected < r.top {
+			r.top = r.selected
+		}
+		if r.selected >= r.top+r.height {
+			r.top = r.selected - r.height + 1
+		}
+		mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+		for i := r.top; i < len(r.recordings) && i < r.top+r.height; i++ {
+			rec := r.recordings[i]
+			cursor := "  "
+			if i == r.selected {
+				cursor = FocusIndicator + " "
+			}
+			info := fmt.Sprintf("%s · %s", rec.Started.Format("2006-01-02 15:04"), formatSize(rec.Size))
+			if rec.Width > 0 {
+				info += fmt.Sprintf(" · %dx%d", rec.Width, rec.Height)
+			}
+			label := truncate(rec.Title, max(innerWidth-lipgloss.Width(info)-5, 10))
+			lines = append(lines, truncateANSI(cursor+label+"  "+mutedStyle.Render(info), innerWidth))
+		}
+		if !r.loading && len(r.recordings) == 0 {
+			lines = append(lines, SubtitleStyle.Render("  No recordings (^G r records the active terminal)"))
+		}
+		for len(lines) < r.height+3 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "", strings.Join(renderKeyHints([]KeyHint{
+			{"↑↓", "select"}, {"Enter", "replay"}, {"y", "copy path"}, {"Esc", "close"},
+		}), ""))
+	}
+
+	box := DialogStyle.Padding(0, 2).Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Top,
+		lipgloss.NewStyle().MarginTop(1).Render(box),
+	)
+}
+
+// formatReplayTime formats a replay position as m:ss
+func formatReplayTime(d time.Duration) string {
+	s := int(d.Seconds())
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
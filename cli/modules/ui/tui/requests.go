@@ -0,0 +1,416 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"csd-devtrack/cli/modules/platform/capture"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// requestsPanel is the overlay of the outbound requests recorded by the
+// capture proxy (^G n): the list, newest first, then a request with its
+// headers and bodies
+type requestsPanel struct {
+	selectedID int64  // Selected request (0 = the newest, following new ones)
+	filter     string // Words matching the method, URL, source or status (see capture.Match)
+	filtering  bool   // Typing the filter
+	detail     bool   // Request opened
+	scroll     int    // First visible line
+	height     int    // Visible lines (set at render)
+}
+
+// openRequests opens the Requests panel
+func (m *Model) openRequests() tea.Cmd {
+	m.requests = &requestsPanel{}
+	return nil
+}
+
+// toggleCaptureSelected marks or unmarks the selected component for capture
+func (m *Model) toggleCaptureSelected() tea.Cmd {
+	projectID := m.getSelectedProjectID()
+	component := m.getSelectedComponent()
+	if projectID == "" || component == "" {
+		m.lastError = "Select a component to capture its requests"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	return m.sendEvent(core.NewEvent(core.EventCaptureComponent).WithProject(projectID).WithComponent(component))
+}
+
+// captureVM returns the state of the capture proxy, nil until initialized
+func (m *Model) captureVM() *core.CaptureVM {
+	if m.state.Processes == nil {
+		return nil
+	}
+	return m.state.Processes.Capture
+}
+
+// requestsShown returns the recorded requests matching the filter, newest
+// first
+func (m *Model) requestsShown() []capture.Entry {
+	vm := m.captureVM()
+	if vm == nil {
+		return nil
+	}
+	matched := capture.Filter(vm.Entries, m.requests.filter)
+	shown := make([]capture.Entry, len(matched))
+	for i, e := range matched {
+		shown[len(matched)-1-i] = e
+	}
+	return shown
+}
+
+// selectedRequest returns the index of the selected request in the shown
+// ones (the newest when it is gone)
+func (p *requestsPanel) selectedRequest(shown []capture.Entry) int {
+	for i, e := range shown {
+		if e.ID == p.selectedID {
+			return i
+		}
+	}
+	return 0
+}
+
+// handleRequestsKey handles the keys of the Requests panel: filter, open a
+// request, start/stop the proxy, clear, export to HAR, copy, close
+func (m *Model) handleRequestsKey(msg tea.KeyMsg) tea.Cmd {
+	p := m.requests
+	if p.filtering {
+		switch msg.Type {
+		case tea.KeyEnter, tea.KeyEsc:
+			p.filtering = false
+		case tea.KeyBackspace:
+			if p.filter != "" {
+				_, size := utf8.DecodeLastRuneInString(p.filter)
+				p.filter = p.filter[:len(p.filter)-size]
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			p.filter += string(msg.Runes)
+		}
+		p.selectedID, p.scroll = 0, 0
+		return nil
+	}
+
+	if p.detail {
+		switch msg.String() {
+		case "esc", "q", "backspace":
+			p.detail, p.scroll = false, 0
+			return m.sendEvent(core.NewEvent(core.EventCaptureDetail))
+		case "up", "k":
+			p.scroll = max(p.scroll-1, 0)
+		case "down", "j":
+			p.scroll++
+		case "pgup", "shift+up":
+			p.scroll = max(p.scroll-p.height, 0)
+		case "pgdown", "shift+down":
+			p.scroll += p.height
+		case "y":
+			if vm := m.captureVM(); vm != nil && vm.Detail != nil {
+				return m.yankText("request URL", vm.Detail.URL)
+			}
+		case "c":
+			if vm := m.captureVM(); vm != nil && vm.Detail != nil {
+				return m.yankText("curl command", curlCommand(vm.Detail))
+			}
+		}
+		return nil
+	}
+
+	shown := m.requestsShown()
+	index := p.selectedRequest(shown)
+	selectAt := func(i int) {
+		if len(shown) == 0 {
+			return
+		}
+		i = max(min(i, len(shown)-1), 0)
+		p.selectedID = shown[i].ID
+		if i == 0 {
+			p.selectedID = 0 // Back on top: follow the new requests
+		}
+	}
+	switch msg.String() {
+	case "esc", "q":
+		if p.filter != "" {
+			p.filter, p.selectedID = "", 0
+			return nil
+		}
+		m.requests = nil
+	case "/":
+		p.filtering = true
+	case "up", "k":
+		selectAt(index - 1)
+	case "down", "j":
+		selectAt(index + 1)
+	case "pgup", "shift+up":
+		selectAt(index - p.height)
+	case "pgdown", "shift+down":
+		selectAt(index + p.height)
+	case "home", "g":
+		selectAt(0)
+	case "end", "G":
+		selectAt(len(shown) - 1)
+	case "enter":
+		if index < len(shown) {
+			p.selectedID = shown[index].ID
+			p.detail, p.scroll = true, 0
+			return m.sendEvent(core.NewEvent(core.EventCaptureDetail).WithTarget(strconv.FormatInt(shown[index].ID, 10)))
+		}
+	case "s":
+		return m.sendEvent(core.NewEvent(core.EventCaptureToggle))
+	case "x":
+		p.selectedID = 0
+		return m.sendEvent(core.NewEvent(core.EventCaptureClear))
+	case "e":
+		return m.sendEvent(core.NewEvent(core.EventCaptureExport).WithValue(p.filter))
+	case "y":
+		if index < len(shown) {
+			return m.yankText("request URL", shown[index].URL)
+		}
+	}
+	return nil
+}
+
+// requestStatusText returns the status of a request: its code, ERR without
+// a response, TLS for a tunnel
+func requestStatusText(e capture.Entry) string {
+	switch {
+	case e.Error != "":
+		return "ERR"
+	case e.Tunnel:
+		return "TLS"
+	}
+	return strconv.Itoa(e.Status)
+}
+
+// requestStatus renders the status of a request, colored by its class
+func requestStatus(e capture.Entry) string {
+	text := requestStatusText(e)
+	switch {
+	case e.Error != "" || e.Status >= 500:
+		return StatusError.Render(text)
+	case e.Status >= 400:
+		return StatusWarning.Render(text)
+	case e.Tunnel || e.Status >= 300:
+		return lipgloss.NewStyle().Foreground(ColorMuted).Render(text)
+	}
+	return StatusSuccess.Render(text)
+}
+
+// requestsLines renders the list of the Requests panel, the selected request
+// highlighted
+func (m *Model) requestsLines(vm *core.CaptureVM, width int) []string {
+	p := m.requests
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	var lines []string
+	switch {
+	case vm.URL != "":
+		status := StatusSuccess.Render("● capturing on " + vm.URL)
+		if vm.InterceptTLS {
+			status += mutedStyle.Render(" · HTTPS decrypted (CA " + vm.CAFile + ")")
+		} else {
+			status += mutedStyle.Render(" · HTTPS tunneled (hosts only)")
+		}
+		lines = append(lines, status)
+	case vm.Error != "":
+		lines = append(lines, StatusError.Render("✗ "+vm.Error))
+	default:
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("○ stopped (port %d): s to start", vm.Port)))
+	}
+	if len(vm.Components) == 0 {
+		lines = append(lines, StatusWarning.Render("No component captured: mark one in its actions menu, or set capture: true"))
+	} else {
+		lines = append(lines, mutedStyle.Render("Captured: "+strings.Join(vm.Components, ", ")+" (HTTP_PROXY from their next start)"))
+	}
+
+	filter := mutedStyle.Render("/ to filter (words, 4xx, 5xx, err)")
+	if p.filtering || p.filter != "" {
+		filter = "Filter: " + p.filter
+		if p.filtering {
+			filter += "█"
+		}
+	}
+	shown := m.requestsShown()
+	lines = append(lines, filter+mutedStyle.Render(fmt.Sprintf("  %d/%d requests", len(shown), len(vm.Entries))), "")
+	if len(shown) == 0 {
+		lines = append(lines, mutedStyle.Render("No requests yet"))
+	}
+
+	selected := p.selectedRequest(shown)
+	for i, e := range shown {
+		source := e.Source
+		if source == "" {
+			source = "?"
+		}
+		columns := fmt.Sprintf("%-7s %-18s %6s %8s  %s", e.Method, truncate(source, 18),
+			e.Duration.Round(time.Millisecond), formatSize(e.ResponseSize), e.URL)
+		if i == selected {
+			row := truncate(" "+e.Started.Format("15:04:05")+" "+requestStatusText(e)+" "+columns, width)
+			lines = append(lines, TableRowSelectedStyle.Render(row+strings.Repeat(" ", max(width-lipgloss.Width(row), 0))))
+		} else {
+			lines = append(lines, " "+mutedStyle.Render(e.Started.Format("15:04:05"))+" "+requestStatus(e)+" "+columns)
+		}
+	}
+
+	for i, line := range lines {
+		lines[i] = truncateANSI(line, width)
+	}
+	return lines
+}
+
+// requestDetailLines renders a request with its headers and bodies
+func requestDetailLines(e *capture.Entry, width int) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	lines := []string{
+		e.Method + " " + e.URL,
+		requestStatus(*e) + " " + http.StatusText(e.Status) + mutedStyle.Render(fmt.Sprintf("  %s · %s · %s",
+			e.Duration.Round(time.Millisecond), e.Started.Format("15:04:05.000"), e.Source)),
+	}
+	if e.Error != "" {
+		lines = append(lines, StatusError.Render("✗ "+e.Error))
+	}
+	if e.Tunnel {
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("HTTPS tunneled without decryption: %s sent, %s received",
+			formatSize(e.RequestSize), formatSize(e.ResponseSize))))
+	}
+
+	section := func(title string, header http.Header, body []byte, size int64) {
+		if len(header) == 0 && size == 0 {
+			return
+		}
+		lines = append(lines, "", SubtitleStyle.Render(title))
+		names := make([]string, 0, len(header))
+		for name := range header {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, value := range header[name] {
+				lines = append(lines, mutedStyle.Render(name+": ")+value)
+			}
+		}
+		if size > 0 {
+			lines = append(lines, "")
+			lines = append(lines, bodyLines(body, size, header.Get("Content-Type"))...)
+		}
+	}
+	section("Request", e.RequestHeaders, e.RequestBody, e.RequestSize)
+	section("Response", e.ResponseHeaders, e.ResponseBody, e.ResponseSize)
+
+	var wrapped []string
+	for _, line := range lines {
+		wrapped = append(wrapped, truncateANSI(line, width))
+	}
+	return wrapped
+}
+
+// bodyLines renders a body: JSON indented, binary summarized, truncation
+// noted
+func bodyLines(body []byte, size int64, contentType string) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	if !utf8.Valid(body) {
+		return []string{mutedStyle.Render(fmt.Sprintf("(binary, %s)", formatSize(size)))}
+	}
+	text := string(body)
+	var pretty bytes.Buffer
+	if strings.Contains(contentType, "json") && json.Indent(&pretty, bytes.TrimSpace(body), "", "  ") == nil {
+		text = pretty.String()
+	}
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if size > int64(len(body)) {
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("(truncated: %s of %s)", formatSize(int64(len(body))), formatSize(size))))
+	}
+	return lines
+}
+
+// curlCommand returns the curl command replaying a request
+func curlCommand(e *capture.Entry) string {
+	quote := func(s string) string { return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'" }
+	parts := []string{"curl", "-X", e.Method, quote(e.URL)}
+	names := make([]string, 0, len(e.RequestHeaders))
+	for name := range e.RequestHeaders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range e.RequestHeaders[name] {
+			parts = append(parts, "-H", quote(name+": "+value))
+		}
+	}
+	if len(e.RequestBody) > 0 {
+		parts = append(parts, "--data-binary", quote(string(e.RequestBody)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// renderRequestsOverlay renders the Requests panel
+func (m *Model) renderRequestsOverlay(width, height int) string {
+	p := m.requests
+	boxWidth := min(width-4, 140)
+	innerWidth := boxWidth - 6 // Border and padding
+	p.height = max(height-8, 3)
+
+	vm := m.captureVM()
+	var body []string
+	var hints []KeyHint
+	switch {
+	case vm == nil:
+		body = append(body, SubtitleStyle.Render("Loading..."))
+		hints = []KeyHint{{"Esc", "close"}}
+	case p.detail:
+		if vm.Detail == nil {
+			body = append(body, SubtitleStyle.Render("Loading..."))
+		} else {
+			body = requestDetailLines(vm.Detail, innerWidth)
+		}
+		p.scroll = min(p.scroll, max(len(body)-p.height, 0))
+		body = body[p.scroll:min(p.scroll+p.height, len(body))]
+		hints = []KeyHint{{"y", "copy URL"}, {"c", "copy as curl"}, {"Esc", "back"}}
+	default:
+		body = m.requestsLines(vm, innerWidth)
+		// Keep the selected request visible (the list starts after the
+		// status, components and filter lines)
+		const header = 4
+		index := p.selectedRequest(m.requestsShown())
+		selected := header + index
+		if index == 0 {
+			p.scroll = 0
+		} else if selected < p.scroll {
+			p.scroll = selected
+		}
+		if selected >= p.scroll+p.height {
+			p.scroll = selected - p.height + 1
+		}
+		p.scroll = min(p.scroll, max(len(body)-p.height, 0))
+		body = body[p.scroll:min(p.scroll+p.height, len(body))]
+		hints = []KeyHint{
+			{"Enter", "open"}, {"/", "filter"}, {"s", "start/stop"}, {"e", "export HAR"},
+			{"x", "clear"}, {"y", "copy URL"}, {"Esc", "close"},
+		}
+	}
+
+	lines := []string{
+		DialogTitleStyle.MarginBottom(0).Render("Requests"),
+		SubtitleStyle.Render("Outbound requests of the components, recorded by the capture proxy"),
+		"",
+	}
+	lines = append(lines, body...)
+	lines = append(lines, "", strings.Join(renderKeyHints(hints), ""))
+
+	box := DialogStyle.Padding(0, 2).Width(boxWidth).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Top,
+		lipgloss.NewStyle().MarginTop(1).Render(box),
+	)
+}
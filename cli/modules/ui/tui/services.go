@@ -0,0 +1,299 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/services"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// serviceItemPrefix prefixes the IDs of service items in the Processes tree
+const serviceItemPrefix = "service:"
+
+// servicesGroup is the Data of the Services group of the Processes tree
+type servicesGroup struct{}
+
+// servicesMenuItem returns the Services group of the Processes tree, nil
+// without a catalog
+func servicesMenuItem(vms []core.ServiceVM) *TreeMenuItem {
+	if len(vms) == 0 {
+		return nil
+	}
+	var children []TreeMenuItem
+	running := 0
+	for _, s := range vms {
+		children = append(children, TreeMenuItem{
+			ID:           serviceItemPrefix + s.Name,
+			Label:        s.Name,
+			Icon:         "◆",
+			IconColor:    ColorSecondary,
+			TrailingIcon: serviceStateIcon(s.State),
+			Data:         s,
+		})
+		if s.Running() {
+			running++
+		}
+	}
+	trailingIcon := ""
+	if running > 0 {
+		trailingIcon = "●"
+	}
+	return &TreeMenuItem{
+		ID:           "services",
+		Label:        "Services",
+		TrailingIcon: trailingIcon,
+		Children:     children,
+		Count:        len(children),
+		Data:         servicesGroup{},
+	}
+}
+
+// serviceStateIcon returns the tree icon of a service state
+func serviceStateIcon(state services.State) string {
+	switch state {
+	case services.StateRunning:
+		return "●"
+	case services.StateStarting:
+		return "◐"
+	case services.StateUnhealthy, services.StateFailed:
+		return "✗"
+	}
+	return ""
+}
+
+// selectedService returns the service selected in the Processes view, nil
+// if something else is selected
+func (c *processesController) selectedService() *core.ServiceVM {
+	if c.menu == nil {
+		return nil
+	}
+	if item := c.menu.SelectedItem(); item != nil {
+		if s, ok := item.Data.(core.ServiceVM); ok {
+			return &s
+		}
+	}
+	return nil
+}
+
+// servicesGroupSelected returns true if the Services group is selected in
+// the Processes view
+func (c *processesController) servicesGroupSelected() bool {
+	if c.menu == nil {
+		return false
+	}
+	item := c.menu.SelectedItem()
+	if item == nil {
+		return false
+	}
+	_, ok := item.Data.(servicesGroup)
+	return ok
+}
+
+// handleServiceKey handles the action keys of the Processes view on the
+// services: r starts (or restarts) a service, s stops it, l shows its
+// output, u starts them all. The process actions without a service
+// equivalent are refused.
+func (m *Model) handleServiceKey(key string, s *core.ServiceVM) (tea.Cmd, bool) {
+	switch key {
+	case "u":
+		return m.servicesUp(""), true
+	case "r":
+		if s == nil {
+			return m.servicesUp(""), true
+		}
+		if s.Running() {
+			return m.serviceAction(s, "restart"), true
+		}
+		return m.serviceAction(s, "start"), true
+	case "s":
+		if s == nil {
+			return nil, false
+		}
+		if !s.Running() {
+			m.lastError = "Service " + s.Name + " is not running"
+			m.lastErrorTime = time.Now()
+			return nil, true
+		}
+		return m.serviceAction(s, "stop"), true
+	case "l":
+		if s == nil {
+			return nil, false
+		}
+		return m.viewServiceLogs(s), true
+	case "b", "k", "p", ".", "x", "a":
+		m.lastError = "Not available for services: use r, s, l or u"
+		m.lastErrorTime = time.Now()
+		return nil, true
+	}
+	return nil, false
+}
+
+// serviceAction starts, stops or restarts a service
+func (m *Model) serviceAction(s *core.ServiceVM, action string) tea.Cmd {
+	return m.sendEvent(core.NewEvent(core.EventServiceAction).WithTarget(s.Name).WithValue(action))
+}
+
+// servicesUp starts the services a project depends on, all the services of
+// the catalog without a project
+func (m *Model) servicesUp(projectID string) tea.Cmd {
+	if m.state.Processes != nil && len(m.state.Processes.Services) == 0 {
+		m.lastError = "No services: add them to services: in the configuration"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	return m.sendEvent(core.NewEvent(core.EventServicesUp).WithProject(projectID))
+}
+
+// servicesUpSelected starts the services the selected project depends on
+func (m *Model) servicesUpSelected() tea.Cmd {
+	projectID := m.getSelectedProjectID()
+	if projectID == "" {
+		m.lastError = "No project selected"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	return m.servicesUp(projectID)
+}
+
+// projectHasServices returns true if a component of the project depends on a
+// service of the catalog
+func (m *Model) projectHasServices(projectID string) bool {
+	if m.state.Processes == nil {
+		return false
+	}
+	prefix := projectID + "/"
+	for _, s := range m.state.Processes.Services {
+		for _, dep := range s.Dependents {
+			if strings.HasPrefix(dep, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// viewServiceLogs shows the output of a service in the Logs view
+func (m *Model) viewServiceLogs(s *core.ServiceVM) tea.Cmd {
+	cmd := m.sendEvent(core.NewEvent(core.EventServiceLogs).WithTarget(s.Name))
+
+	m.currentView = core.VMLogs
+	m.sidebarIndex = 4 // Logs view index
+	m.sidebarMenu.SetSelectedIndex(4)
+	m.logsView().sourceFilter = s.LogsSource()
+	m.logsView().searchText = ""
+	return cmd
+}
+
+// serviceDetailLines renders the detail panel of a service
+func serviceDetailLines(s core.ServiceVM, width int) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	title := PanelTitleStyle.Render(s.Name)
+	if s.Kind == services.KindContainer {
+		title += mutedStyle.Render(" (container service)")
+	} else {
+		title += mutedStyle.Render(" (local service)")
+	}
+	lines := []string{title, ""}
+	if s.Description != "" {
+		lines = append(lines, truncate(s.Description, width), "")
+	}
+
+	state := string(s.State)
+	if !s.Since.IsZero() {
+		state += " since " + s.Since.Format("15:04:05")
+	}
+	switch s.State {
+	case services.StateRunning:
+		lines = append(lines, StatusSuccess.Render("● "+state))
+	case services.StateStarting:
+		lines = append(lines, StatusWarning.Render("◐ "+state))
+	case services.StateUnhealthy, services.StateFailed:
+		lines = append(lines, StatusError.Render("✗ "+state))
+	default:
+		lines = append(lines, mutedStyle.Render("○ "+state))
+	}
+	if s.Error != "" {
+		lines = append(lines, StatusError.Render(truncate(s.Error, width)))
+	}
+	if s.Container != "" {
+		lines = append(lines, mutedStyle.Render(truncate(services.ContainerName(s.Name)+": "+s.Container, width)))
+	}
+	lines = append(lines, "")
+
+	if s.Kind == services.KindContainer {
+		lines = append(lines, "Image: "+truncate(s.Target, width-7))
+	} else {
+		lines = append(lines, "Command: "+truncate(s.Target, width-9))
+	}
+	if len(s.Ports) > 0 {
+		lines = append(lines, "Ports: "+truncate(strings.Join(s.Ports, ", "), width-7))
+	}
+
+	lines = append(lines, "", SubtitleStyle.Render("Used by:"))
+	if len(s.Dependents) == 0 {
+		lines = append(lines, mutedStyle.Render("  No component (services: of a component)"))
+	}
+	for _, dep := range s.Dependents {
+		lines = append(lines, "  "+truncate(dep, width-2))
+	}
+
+	lines = append(lines, "", SubtitleStyle.Render("Actions:"))
+	if s.Running() {
+		lines = append(lines, HelpKeyStyle.Render("s")+" stop  "+HelpKeyStyle.Render("r")+" restart  "+HelpKeyStyle.Render("l")+" logs")
+	} else {
+		lines = append(lines, HelpKeyStyle.Render("r")+" start  "+HelpKeyStyle.Render("l")+" logs  "+HelpKeyStyle.Render("u")+" start all")
+	}
+	return lines
+}
+
+// servicesSummaryLines renders the detail panel of the Services group
+func servicesSummaryLines(vms []core.ServiceVM, width int) []string {
+	running := 0
+	for _, s := range vms {
+		if s.Running() {
+			running++
+		}
+	}
+	lines := []string{
+		PanelTitleStyle.Render("Services"),
+		"",
+		fmt.Sprintf("Services: %d (%d running)", len(vms), running),
+		"",
+	}
+	for _, s := range vms {
+		line := fmt.Sprintf("  %-12s %s", s.Name, s.State)
+		if s.Error != "" {
+			line += " " + StatusError.Render(s.Error)
+		}
+		lines = append(lines, truncate(line, width))
+	}
+	lines = append(lines, "", SubtitleStyle.Render("Press → or Enter to see the services"))
+	lines = append(lines, HelpKeyStyle.Render("u")+" start all (waits until they are ready)")
+	return lines
+}
+
+// serviceContextMenu returns the actions of the selected service
+func (m *Model) serviceContextMenu(s *core.ServiceVM) *contextMenu {
+	menu := &contextMenu{title: s.Name + " (service)"}
+	if s.Running() {
+		menu.actions = append(menu.actions,
+			contextAction{"r", "Restart", func(m *Model) tea.Cmd { return m.serviceAction(s, "restart") }},
+			contextAction{"s", "Stop", func(m *Model) tea.Cmd { return m.serviceAction(s, "stop") }},
+		)
+	} else {
+		menu.actions = append(menu.actions,
+			contextAction{"r", "Start", func(m *Model) tea.Cmd { return m.serviceAction(s, "start") }})
+	}
+	menu.actions = append(menu.actions,
+		contextAction{"l", "View logs", func(m *Model) tea.Cmd { return m.viewServiceLogs(s) }},
+		contextAction{"u", "Start all services", func(m *Model) tea.Cmd { return m.servicesUp("") }},
+		contextAction{"y", "Copy " + string(s.Kind) + " target", func(m *Model) tea.Cmd { return m.yankText("service target", s.Target) }},
+	)
+	return menu
+}
@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	activityActiveWindow = 10 * time.Second // Output within this window = active now
+	activityIdleAfter    = 5 * time.Minute  // No output for this long = idle badge
+	claudeFinishedAfter  = 15 * time.Second // Quiet time after which a busy Claude session is finished
+)
+
+// activityBadge returns the tree badge for a terminal's last output time.
+// Sessions that were quiet for less than activityIdleAfter get no badge.
+func activityBadge(lastOutput time.Time) (string, lipgloss.TerminalColor) {
+	if lastOutput.IsZero() {
+		return "", nil
+	}
+	idle := time.Since(lastOutput)
+	switch {
+	case idle < activityActiveWindow:
+		return "active", ColorSuccess
+	case idle < activityIdleAfter:
+		return "", nil
+	case idle < time.Hour:
+		return fmt.Sprintf("idle %dm", int(idle.Minutes())), ColorMuted
+	default:
+		return fmt.Sprintf("idle %dh", int(idle.Hours())), ColorMuted
+	}
+}
+
+// withActivityBadge sets the activity badge of a tree item backed by a
+// running terminal (or marks it popped out to a terminal window, suspended
+// while idle, or lost with its tmux session)
+func (m *Model) withActivityBadge(item TreeMenuItem, sessionID string) TreeMenuItem {
+	if m.terminalManager == nil || item.TrailingIcon != "" {
+		return item
+	}
+	if m.isSuspended(sessionID) {
+		item.TrailingIcon, item.TrailingColor = "💤 suspended", ColorMuted
+		return item
+	}
+	if m.terminalManager.LostReason(sessionID) != "" {
+		item.TrailingIcon, item.TrailingColor = "⚠ lost", ColorError
+		return item
+	}
+	if t := m.terminalManager.Get(sessionID); t == nil || !t.IsRunning() {
+		return item
+	}
+	if _, ok := m.externalSessions[sessionID]; ok {
+		item.TrailingIcon, item.TrailingColor = "⇱ external", ColorSecondary
+		return item
+	}
+	item.TrailingIcon, item.TrailingColor = activityBadge(m.terminalManager.LastOutput(sessionID))
+	if tmuxTerminal, ok := m.terminalManager.Get(sessionID).(*TerminalTmux); ok {
+		if recording, _ := tmuxTerminal.Recording(); recording {
+			item.TrailingIcon = strings.TrimSpace("⏺ rec " + item.TrailingIcon)
+			item.TrailingColor = ColorError
+		}
+	}
+	return item
+}
+
+// refreshSessionActivity rebuilds the tree of the current terminal view so
+// badges age, and reports Claude sessions that finished processing
+func (m *Model) refreshSessionActivity() {
+	switch m.currentView {
+	case core.VMClaude:
+		m.updateClaudeTree()
+	case core.VMCodex:
+		m.updateCodexTree()
+	case core.VMShell:
+		m.updateShellTree()
+	case core.VMDatabase:
+		m.updateDatabaseMenu()
+	}
+	m.checkClaudeFinished()
+}
+
+// checkClaudeFinished notifies when a Claude session that was producing
+// output goes quiet while the user is not looking at it (opt-in)
+func (m *Model) checkClaudeFinished() {
+	if m.terminalManager == nil || m.state.Claude == nil {
+		return
+	}
+	if m.claudeView().busy == nil {
+		m.claudeView().busy = make(map[string]bool)
+	}
+
+	running := make(map[string]bool)
+	for _, sessionID := range m.terminalManager.GetRunning() {
+		if !isValidUUID(sessionID) {
+			continue // Shell, database and Codex terminals
+		}
+		running[sessionID] = true
+
+		idle := time.Since(m.terminalManager.LastOutput(sessionID))
+		if idle < activityActiveWindow {
+			m.claudeView().busy[sessionID] = true
+			continue
+		}
+		if !m.claudeView().busy[sessionID] || idle < claudeFinishedAfter {
+			continue
+		}
+		delete(m.claudeView().busy, sessionID)
+
+		watching := m.currentView == core.VMClaude && m.claudeView().activeSession == sessionID
+		if watching || !claudeNotifyOnIdle() {
+			continue
+		}
+		name := sessionID[:8]
+		for _, sess := range m.state.Claude.Sessions {
+			if sess.ID == sessionID {
+				name = sess.Name
+				break
+			}
+		}
+		m.handleNotification(core.NewNotification(core.NotifySuccess, "Claude finished", name))
+		m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess, "Claude session "+name+" finished"))
+	}
+
+	// Forget sessions whose terminal was closed
+	for sessionID := range m.claudeView().busy {
+		if !running[sessionID] {
+			delete(m.claudeView().busy, sessionID)
+		}
+	}
+}
+
+// claudeNotifyOnIdle returns true if finished Claude sessions should be notified
+func claudeNotifyOnIdle() bool {
+	cfg := config.GetGlobal()
+	return cfg != nil && cfg.Settings != nil && cfg.Settings.Claude != nil && cfg.Settings.Claude.NotifyOnIdle
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// forkClaudeSession duplicates a Claude session into a new one continuing the
+// same conversation (selected once created; Enter resumes it)
+func (m *Model) forkClaudeSession(sessionID string) tea.Cmd {
+	if sessionID == "" || m.blockReadOnly("fork session") {
+		return nil
+	}
+	return m.sendEvent(core.NewEvent(core.EventClaudeForkSession).WithData("session_id", sessionID))
+}
+
+// forkSelectedSession forks the session selected in the sessions panel
+func (m *Model) forkSelectedSession() tea.Cmd {
+	_, sessionID, isProject, _ := m.getSelectedTreeItem()
+	if isProject {
+		return nil
+	}
+	return m.forkClaudeSession(sessionID)
+}
+
+// forkActiveSession forks the active Claude session
+func (m *Model) forkActiveSession() tea.Cmd {
+	return m.forkClaudeSession(m.claudeView().activeSession)
+}
+
+// claudeSessionName returns the display name of a Claude session (its short
+// ID if unknown)
+func (m *Model) claudeSessionName(sessionID string) string {
+	if m.state.Claude != nil {
+		for _, sess := range m.state.Claude.Sessions {
+			if sess.ID == sessionID {
+				return sess.Name
+			}
+		}
+	}
+	if len(sessionID) > 8 {
+		return sessionID[:8]
+	}
+	return sessionID
+}
+
+// orderSessionForks moves the forks of a session right after it, keeping the
+// order of the sessions otherwise, and records the fork depth of each session
+// (0 for sessions whose parent is not in the list)
+func orderSessionForks(sessions []core.ClaudeSessionVM, depth map[string]int) []core.ClaudeSessionVM {
+	present := make(map[string]bool, len(sessions))
+	for _, sess := range sessions {
+		present[sess.ID] = true
+	}
+	children := make(map[string][]core.ClaudeSessionVM)
+	var roots []core.ClaudeSessionVM
+	for _, sess := range sessions {
+		if sess.ParentID != "" && sess.ParentID != sess.ID && present[sess.ParentID] {
+			children[sess.ParentID] = append(children[sess.ParentID], sess)
+		} else {
+			roots = append(roots, sess)
+		}
+	}
+	if len(children) == 0 {
+		return sessions
+	}
+
+	ordered := make([]core.ClaudeSessionVM, 0, len(sessions))
+	visited := make(map[string]bool, len(sessions))
+	var add func(sess core.ClaudeSessionVM, level int)
+	add = func(sess core.ClaudeSessionVM, level int) {
+		if visited[sess.ID] {
+			return
+		}
+		visited[sess.ID] = true
+		depth[sess.ID] = level
+		ordered = append(ordered, sess)
+		for _, child := range children[sess.ID] {
+			add(child, level+1)
+		}
+	}
+	for _, sess := range roots {
+		add(sess, 0)
+	}
+	// Sessions in a cycle of links (not reachable from a root)
+	for _, sess := range sessions {
+		add(sess, 0)
+	}
+	return ordered
+}
@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Pop-out timings
+const (
+	popOutGrace        = 10 * time.Second // Time for the terminal window to attach
+	popOutPollInterval = 5 * time.Second  // Check of the attached clients
+)
+
+// terminalEmulators are the emulators tried, in order, when none is configured
+var terminalEmulators = []string{
+	"x-terminal-emulator", "gnome-terminal", "konsole", "alacritty", "kitty", "wezterm", "foot", "xterm",
+}
+
+// externalSession is a terminal session popped out to a terminal window
+type externalSession struct {
+	tmuxName string
+	since    time.Time
+}
+
+// popOutMsg reports the launch of a terminal window attached to a session
+type popOutMsg struct {
+	sessionID string
+	tmuxName  string
+	attach    string // Attach command
+	launched  bool   // False if no terminal emulator could be started
+	err       error
+}
+
+// tmuxClientsMsg carries the tmux sessions with an attached client
+type tmuxClientsMsg struct {
+	attached map[string]bool
+}
+
+// popOutTerminal moves the session of the active terminal to a terminal
+// window attached to its tmux session (^G o). The embedded pane keeps
+// mirroring it.
+func (m *Model) popOutTerminal() tea.Cmd {
+	sessionID := m.activeTerminalSession()
+	t := m.terminalManager.Get(sessionID)
+	if sessionID == "" || t == nil || !t.IsRunning() {
+		m.lastError = "No running terminal session to pop out"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	tmuxTerminal, ok := t.(*TerminalTmux)
+	if !ok {
+		m.lastError = "Pop-out needs the tmux terminal backend"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	if m.blockReadOnly("pop out") {
+		return nil
+	}
+
+	m.terminalMode = false
+	return func() tea.Msg {
+		tmuxName := tmuxTerminal.Handoff()
+		attach := "tmux attach -t " + tmuxName
+		cmd := terminalEmulatorCommand([]string{"tmux", "attach", "-t", tmuxName})
+		if cmd == nil {
+			return popOutMsg{sessionID: sessionID, tmuxName: tmuxName, attach: attach}
+		}
+		if err := cmd.Start(); err != nil {
+			return popOutMsg{sessionID: sessionID, tmuxName: tmuxName, attach: attach, err: err}
+		}
+		go cmd.Wait() // Reap the launcher
+		return popOutMsg{sessionID: sessionID, tmuxName: tmuxName, attach: attach, launched: true}
+	}
+}
+
+// handlePopOut marks a popped-out session, or copies the attach command
+// when no terminal window could be opened
+func (m *Model) handlePopOut(msg popOutMsg) tea.Cmd {
+	if msg.err != nil {
+		m.lastError = fmt.Sprintf("Failed to open a terminal window: %v (run: %s)", msg.err, msg.attach)
+		m.lastErrorTime = time.Now()
+		return copyToClipboard(msg.attach)
+	}
+	if !msg.launched {
+		m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventInfo, "No terminal emulator found, run: "+msg.attach+" (copied)"))
+		return copyToClipboard(msg.attach)
+	}
+	if m.externalSessions == nil {
+		m.externalSessions = make(map[string]externalSession)
+	}
+	m.externalSessions[msg.sessionID] = externalSession{tmuxName: msg.tmuxName, since: time.Now()}
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess, "Session popped out: "+msg.attach))
+	m.refreshSessionActivity()
+	return nil
+}
+
+// checkExternalSessions lists the attached tmux clients, every
+// popOutPollInterval while sessions are popped out
+func (m *Model) checkExternalSessions() tea.Cmd {
+	if len(m.externalSessions) == 0 || time.Since(m.externalCheckTime) < popOutPollInterval {
+		return nil
+	}
+	m.externalCheckTime = time.Now()
+	return func() tea.Msg {
+		output, _ := exec.Command("tmux", "list-clients", "-F", "#{session_name}").Output()
+		attached := make(map[string]bool)
+		for _, name := range strings.Fields(string(output)) {
+			attached[name] = true
+		}
+		return tmuxClientsMsg{attached: attached}
+	}
+}
+
+// handleTmuxClients unmarks the popped-out sessions whose terminal window
+// detached, sizing them for the embedded pane again
+func (m *Model) handleTmuxClients(msg tmuxClientsMsg) {
+	changed := false
+	for sessionID, ext := range m.externalSessions {
+		if msg.attached[ext.tmuxName] || time.Since(ext.since) < popOutGrace {
+			continue
+		}
+		delete(m.externalSessions, sessionID)
+		changed = true
+		if t, ok := m.terminalManager.Get(sessionID).(*TerminalTmux); ok && t.IsRunning() {
+			go t.Reclaim()
+		}
+	}
+	if changed {
+		m.refreshSessionActivity()
+	}
+}
+
+// terminalEmulatorCommand returns the command opening a terminal window
+// running args, nil if no terminal emulator is available
+func terminalEmulatorCommand(args []string) *exec.Cmd {
+	emulator := ""
+	if cfg := config.GetGlobal(); cfg != nil && cfg.Settings != nil && cfg.Settings.Executables != nil {
+		emulator = cfg.Settings.Executables.Terminal
+	}
+
+	// Configured template: {cmd} stands for the command
+	if strings.Contains(emulator, "{cmd}") {
+		var fields []string
+		for _, field := range strings.Fields(emulator) {
+			if field == "{cmd}" {
+				fields = append(fields, args...)
+			} else {
+				fields = append(fields, strings.ReplaceAll(field, "{cmd}", strings.Join(args, " ")))
+			}
+		}
+		return exec.Command(fields[0], fields[1:]...)
+	}
+
+	if emulator == "" && runtime.GOOS == "darwin" {
+		script := fmt.Sprintf("tell application \"Terminal\"\n do script %s\n activate\nend tell", strconv.Quote(strings.Join(args, " ")))
+		return exec.Command("osascript", "-e", script)
+	}
+
+	// Without a display, a window cannot be opened
+	if runtime.GOOS == "linux" && os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return nil
+	}
+	candidates := terminalEmulators
+	if emulator != "" {
+		candidates = []string{emulator}
+	} else if env := os.Getenv("TERMINAL"); env != "" {
+		candidates = append([]string{env}, candidates...)
+	}
+	for _, candidate := range candidates {
+		fields := strings.Fields(candidate)
+		path, err := exec.LookPath(fields[0])
+		if err != nil {
+			continue
+		}
+		switch filepath.Base(fields[0]) {
+		case "gnome-terminal":
+			fields = append(fields, "--")
+		case "wezterm":
+			fields = append(fields, "start", "--")
+		case "kitty", "foot":
+		default: // xterm, konsole, alacritty, x-terminal-emulator, urxvt...
+			fields = append(fields, "-e")
+		}
+		return exec.Command(path, append(fields[1:], args...)...)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// taskItemPrefix prefixes the IDs of the task nodes of the sessions tree and
+// of their sessions (the same sessions are listed under their project)
+const taskItemPrefix = "task:"
+
+// sessionTaskItems builds the task nodes of the Claude sessions tree: the
+// Claude and Codex sessions of each task with their combined activity
+func (m *Model) sessionTaskItems() []TreeMenuItem {
+	if m.state.Claude == nil || len(m.state.Claude.Tasks) == 0 {
+		return nil
+	}
+	claudeSessions := make(map[string]core.ClaudeSessionVM)
+	for _, sess := range m.state.Claude.Sessions {
+		claudeSessions[sess.ID] = sess
+	}
+	codexSessions := make(map[string]core.CodexSessionVM)
+	if m.state.Codex != nil {
+		for _, sess := range m.state.Codex.Sessions {
+			codexSessions[sess.ID] = sess
+		}
+	}
+
+	var items []TreeMenuItem
+	for _, task := range m.state.Claude.Tasks {
+		var children []TreeMenuItem
+		var lastOutput time.Time
+		for _, member := range task.Sessions {
+			terminalID := member.ID
+			var data interface{} = claudeSessions[member.ID]
+			if member.Tool == "codex" {
+				terminalID = "codex-" + member.ID
+				data = codexSessions[member.ID]
+			}
+
+			item := TreeMenuItem{
+				ID:            taskItemPrefix + task.ID + ":" + member.Tool + ":" + member.ID,
+				Label:         member.Name,
+				Icon:          "○",
+				TrailingIcon:  member.Tool,
+				TrailingColor: ColorMuted,
+				IsActive:      terminalID == m.claudeView().activeSession || terminalID == m.codexView().activeSession,
+				Data:          data,
+			}
+			if m.terminalManager != nil {
+				if t := m.terminalManager.Get(terminalID); t != nil && t.IsRunning() {
+					item.Icon, item.IconColor = "●", ColorSuccess
+					if out := m.terminalManager.LastOutput(terminalID); out.After(lastOutput) {
+						lastOutput = out
+					}
+				}
+			}
+			children = append(children, item)
+		}
+
+		label := task.Name
+		if task.Branch != "" && task.Branch != task.Name {
+			label += " (" + task.Branch + ")"
+		}
+		node := TreeMenuItem{
+			ID:       taskItemPrefix + task.ID,
+			Label:    label,
+			Icon:     "🔗",
+			Children: children,
+			Count:    len(children),
+			Data:     task,
+		}
+		// Combined activity: live output of a session, else the last message
+		if badge, color := activityBadge(lastOutput); badge != "" {
+			node.TrailingIcon, node.TrailingColor = badge, color
+		} else if !task.LastActiveAt.IsZero() {
+			node.TrailingIcon = fmt.Sprintf("%d msg · %s", task.MessageCount, formatAge(time.Since(task.LastActiveAt)))
+			node.TrailingColor = ColorMuted
+		}
+		items = append(items, node)
+	}
+	return items
+}
+
+// selectedTask returns the task node selected in the sessions panel
+func (m *Model) selectedTask() (core.SessionTaskVM, bool) {
+	if m.claudeView().treeMenu == nil {
+		return core.SessionTaskVM{}, false
+	}
+	item := m.claudeView().treeMenu.SelectedItem()
+	if item == nil {
+		return core.SessionTaskVM{}, false
+	}
+	task, ok := item.Data.(core.SessionTaskVM)
+	return task, ok
+}
+
+// sessionTaskName returns the task a session is linked to, "" if none
+func (m *Model) sessionTaskName(tool, sessionID string) string {
+	if m.state.Claude == nil {
+		return ""
+	}
+	for _, task := range m.state.Claude.Tasks {
+		for _, member := range task.Sessions {
+			if member.Tool == tool && member.ID == sessionID {
+				return task.Name
+			}
+		}
+	}
+	return ""
+}
+
+// openLinkTaskDialog asks the task to link a session to: an existing task
+// groups them, a new name creates it, an empty name unlinks the session
+func (m *Model) openLinkTaskDialog(tool, sessionID, branch string) tea.Cmd {
+	if sessionID == "" || m.blockReadOnly("link session") {
+		return nil
+	}
+	name := m.sessionTaskName(tool, sessionID)
+	if name == "" {
+		name = branch // Groups the other sessions of the branch
+	}
+	m.claudeView().pendingTaskTool, m.claudeView().pendingTaskSessionID = tool, sessionID
+	m.dialogType = "link_session_task"
+	m.dialogMessage = "Link to task (empty to unlink):"
+	m.dialogInput.SetValue(name)
+	m.dialogInput.Focus()
+	m.dialogInputActive = true
+	m.showDialog = true
+	return m.dialogInput.Cursor.BlinkCmd()
+}
+
+// linkSelectedSession links the session selected in the sessions panel (a
+// Codex one if listed under a task) to a task
+func (m *Model) linkSelectedSession() tea.Cmd {
+	if m.claudeView().treeMenu == nil {
+		return nil
+	}
+	item := m.claudeView().treeMenu.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	switch sess := item.Data.(type) {
+	case core.ClaudeSessionVM:
+		return m.openLinkTaskDialog("claude", sess.ID, sess.GitBranch)
+	case core.CodexSessionVM:
+		return m.openLinkTaskDialog("codex", sess.ID, "")
+	}
+	return nil
+}
+
+// linkSelectedCodexSession links the session selected in the Codex view to a
+// task
+func (m *Model) linkSelectedCodexSession() tea.Cmd {
+	if m.codexView().treeMenu == nil {
+		return nil
+	}
+	if item := m.codexView().treeMenu.SelectedItem(); item != nil {
+		if sess, ok := item.Data.(core.CodexSessionVM); ok {
+			return m.openLinkTaskDialog("codex", sess.ID, "")
+		}
+	}
+	return nil
+}
+
+// linkSessionTask sends the task entered for the pending session
+func (m *Model) linkSessionTask() tea.Cmd {
+	tool, sessionID := m.claudeView().pendingTaskTool, m.claudeView().pendingTaskSessionID
+	m.claudeView().pendingTaskTool, m.claudeView().pendingTaskSessionID = "", ""
+	if sessionID == "" {
+		return nil
+	}
+	return m.sendEvent(core.NewEvent(core.EventSessionLinkTask).
+		WithData("tool", tool).
+		WithData("session_id", sessionID).
+		WithData("task", strings.TrimSpace(m.dialogInput.Value())))
+}
+
+// deleteSelectedTask asks to delete the selected task (its sessions are kept)
+func (m *Model) deleteSelectedTask() tea.Cmd {
+	task, ok := m.selectedTask()
+	if !ok || m.blockReadOnly("delete task") {
+		return nil
+	}
+	m.claudeView().pendingDeleteTaskID = task.ID
+	m.dialogType = "delete_session_task"
+	m.dialogMessage = fmt.Sprintf("Delete task \"%s\"? Its sessions are kept.", task.Name)
+	m.showDialog = true
+	return nil
+}
+
+// openCodexSession shows a Codex session in the Codex view
+func (m *Model) openCodexSession(sessionID string) tea.Cmd {
+	id := "codex-" + sessionID
+	cmd := m.selectViewByType(core.VMCodex)
+	m.codexView().filterProject = ""
+	m.codexView().activeSession = id
+	m.updateCodexTree()
+	if m.codexView().treeMenu != nil {
+		m.codexView().treeMenu.SelectMatching(func(item *TreeMenuItem) bool { return item.ID == id })
+	}
+	return cmd
+}
@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// findSSHHost returns the SSH host with the given name
+func (m *Model) findSSHHost(name string) *core.SSHHostVM {
+	if m.state.Shell == nil {
+		return nil
+	}
+	for i := range m.state.Shell.SSHHosts {
+		if m.state.Shell.SSHHosts[i].Name == name {
+			return &m.state.Shell.SSHHosts[i]
+		}
+	}
+	return nil
+}
+
+// createSSHSession opens a new remote shell session on an SSH host
+func (m *Model) createSSHSession(host core.SSHHostVM) tea.Cmd {
+	if m.terminalManager == nil {
+		return nil
+	}
+	if m.state.Capabilities == nil || !m.state.Capabilities.HasSSH() {
+		m.lastError = "ssh client not available"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	sessionID := fmt.Sprintf("shell-ssh-%d", time.Now().UnixNano())
+	if m.shellView().sshSessions == nil {
+		m.shellView().sshSessions = make(map[string]string)
+	}
+	m.shellView().sshSessions[sessionID] = host.Name
+
+	return m.startSSHTerminal(sessionID, host, "SSH connected: ")
+}
+
+// reconnectSSHSession restarts a disconnected SSH session with the same ID
+func (m *Model) reconnectSSHSession(sessionID string) tea.Cmd {
+	if m.terminalManager == nil {
+		return nil
+	}
+	host := m.findSSHHost(m.shellView().sshSessions[sessionID])
+	if host == nil {
+		m.lastError = "SSH host not found: " + m.shellView().sshSessions[sessionID]
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	if t := m.terminalManager.Get(sessionID); t != nil && t.IsRunning() {
+		return m.switchToShellSession(sessionID)
+	}
+
+	// Drop the exited terminal: a terminal can't be restarted
+	m.terminalManager.Remove(sessionID)
+	return m.startSSHTerminal(sessionID, *host, "SSH reconnected: ")
+}
+
+// startSSHTerminal starts the ssh terminal of a session and switches to it
+func (m *Model) startSSHTerminal(sessionID string, host core.SSHHostVM, eventPrefix string) tea.Cmd {
+	sshPath := m.state.Capabilities.SSH.Path
+	if sshPath == "" {
+		sshPath = "ssh"
+	}
+
+	t := m.terminalManager.GetOrCreateCommandWithPrefix(sessionID, sshPath, host.Args, TmuxPrefixShell)
+	if t == nil {
+		m.lastError = "Failed to create SSH terminal"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	if err := t.Start(sessionID); err != nil {
+		m.lastError = fmt.Sprintf("Failed to start ssh: %v", err)
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	m.shellView().activeSession = sessionID
+	m.focusArea = FocusMain
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess, eventPrefix+host.Name))
+	m.updateShellTree()
+
+	return m.scheduleTerminalRefresh()
+}
+
+// isSSHDisconnected returns true if the session is an SSH session whose connection ended
+func (m *Model) isSSHDisconnected(sessionID string) bool {
+	if _, ok := m.shellView().sshSessions[sessionID]; !ok || m.terminalManager == nil || m.isSuspended(sessionID) {
+		return false
+	}
+	t := m.terminalManager.Get(sessionID)
+	return t == nil || !t.IsRunning()
+}
+
+// buildSSHTreeItems returns the SSH groups of the Shell tree: group > host > sessions.
+// Disconnected sessions stay listed so they can be reconnected.
+func (m *Model) buildSSHTreeItems() []TreeMenuItem {
+	if m.state.Shell == nil || len(m.state.Shell.SSHHosts) == 0 {
+		return nil
+	}
+
+	// Sessions per host (sorted by ID = creation time)
+	var sessionIDs []string
+	for sessionID := range m.shellView().sshSessions {
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	sort.Strings(sessionIDs)
+	hostSessions := make(map[string][]TreeMenuItem)
+	for _, sessionID := range sessionIDs {
+		hostName := m.shellView().sshSessions[sessionID]
+		item := TreeMenuItem{
+			ID:       sessionID,
+			Label:    hostName,
+			Icon:     "$",
+			IsActive: sessionID == m.shellView().activeSession,
+		}
+		if m.isSSHDisconnected(sessionID) {
+			item.Icon = "✗"
+			item.IconColor = ColorError
+			item.TrailingIcon = "disconnected"
+			item.TrailingColor = ColorMuted
+		} else {
+			item = m.withActivityBadge(item, sessionID)
+		}
+		hostSessions[hostName] = append(hostSessions[hostName], item)
+	}
+
+	// Groups in order of first appearance
+	var groups []string
+	groupHosts := make(map[string][]TreeMenuItem)
+	for _, host := range m.state.Shell.SSHHosts {
+		if _, ok := groupHosts[host.Group]; !ok {
+			groups = append(groups, host.Group)
+		}
+		groupHosts[host.Group] = append(groupHosts[host.Group], TreeMenuItem{
+			ID:            "ssh:" + host.Name,
+			Label:         host.Name,
+			Icon:          "@",
+			IconColor:     ColorSecondary,
+			TrailingIcon:  host.Target,
+			TrailingColor: ColorMuted,
+			Children:      hostSessions[host.Name],
+			Data:          host,
+		})
+	}
+
+	var items []TreeMenuItem
+	for _, group := range groups {
+		items = append(items, TreeMenuItem{
+			ID:       "_ssh:" + group,
+			Label:    group,
+			Icon:     "⇄",
+			Children: groupHosts[group],
+		})
+	}
+	return items
+}
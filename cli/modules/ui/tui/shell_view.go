@@ -2,11 +2,259 @@ package tui
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// shellController is the submodel of the Terminal view
+type shellController struct {
+	activeSession          string    // Active shell session ID
+	treeMenu               *TreeMenu // Tree menu for sessions panel
+	filterProject          string    // Filter by project ID
+	pendingDeleteSessionID string    // Session ID to delete (saved at dialog open)
+
+	sshSessions map[string]string // Shell session ID -> SSH host name (kept after disconnect for reconnect)
+}
+
+// newShellController creates the Terminal view controller
+func newShellController() *shellController {
+	return &shellController{}
+}
+
+// shellView returns the Terminal view submodel
+func (m *Model) shellView() *shellController {
+	return m.controllers[core.VMShell].(*shellController)
+}
+
+// Init implements ViewController
+func (c *shellController) Init(m *Model) tea.Cmd {
+	return nil
+}
+
+// Menu implements ViewController (the sessions are in the detail panel)
+func (c *shellController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	if focus != FocusDetail || c.treeMenu == nil {
+		return nil
+	}
+	return c.treeMenu
+}
+
+// Keys implements ViewController
+func (c *shellController) Keys(m *Model) []KeyHint {
+	return nil
+}
+
+// Update implements ViewController
+func (c *shellController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case detailSelectMsg:
+		// Sessions panel: use TreeMenu to select/drill-down
+		if c.treeMenu == nil {
+			return nil, false
+		}
+		if item := c.treeMenu.Select(); item != nil {
+			// SSH host without sessions - open one
+			if host, isHost := item.Data.(core.SSHHostVM); isHost {
+				return m.createSSHSession(host), true
+			}
+			// Suspended session - reattach
+			if m.isSuspended(item.ID) {
+				return m.resumeShellTerminal(item.ID), true
+			}
+			// Disconnected SSH session - reconnect
+			if m.isSSHDisconnected(item.ID) {
+				return m.reconnectSSHSession(item.ID), true
+			}
+			// Session lost with its tmux session - recreate
+			if m.terminalManager.LostReason(item.ID) != "" {
+				c.activeSession = item.ID
+				return m.recreateLostTerminal(item.ID), true
+			}
+			// Leaf item selected (session) - connect to it via ID
+			if len(item.Children) == 0 && strings.HasPrefix(item.ID, "shell-") {
+				c.activeSession = item.ID
+				return nil, true
+			}
+		}
+		// If Select() returned nil, it drilled down/up - nothing more to do
+		return nil, true
+	case dialogConfirmMsg:
+		if msg.dialogType == "delete_shell_session" {
+			return c.deleteSession(m), true
+		}
+	case tea.KeyMsg:
+		return c.handleKey(m, msg.String())
+	}
+	return nil, false
+}
+
+// View implements ViewController
+func (c *shellController) View(m *Model, width, height int) string {
+	return m.renderShell(width, height)
+}
+
+// terminalSession implements terminalView (the active shell)
+func (c *shellController) terminalSession() string {
+	return c.activeSession
+}
+
+// deleteSession deletes the session confirmed in the dialog
+func (c *shellController) deleteSession(m *Model) tea.Cmd {
+	sessionID := c.pendingDeleteSessionID
+	c.pendingDeleteSessionID = ""
+	if sessionID == "" {
+		return nil
+	}
+	// Stop the terminal before deleting the session
+	m.stopShellTerminal(sessionID)
+	if _, isSSH := c.sshSessions[sessionID]; isSSH {
+		// SSH sessions only live in the TUI
+		delete(c.sshSessions, sessionID)
+		go m.terminalManager.Remove(sessionID)
+		m.updateShellTree()
+		return nil
+	}
+	return m.sendEvent(core.NewEvent(core.EventShellDeleteSession).WithData("session_id", sessionID))
+}
+
+// handleKey handles the action keys of the terminal and sessions panels
+func (c *shellController) handleKey(m *Model, key string) (tea.Cmd, bool) {
+	switch key {
+	case "n":
+		// New project shell session - when focused on sessions panel and on a project
+		if m.focusArea == FocusDetail && c.treeMenu != nil {
+			item := c.treeMenu.SelectedItem()
+			// New remote session on the selected SSH host
+			if item != nil {
+				if host, isHost := item.Data.(core.SSHHostVM); isHost {
+					return m.createSSHSession(host), true
+				}
+			}
+			if item != nil && len(item.Children) > 0 {
+				// Create new session for selected project (has children = category)
+				return m.createShellSession("project", item.ID, item.Label), true
+			}
+		}
+		return nil, true
+	case "h":
+		// New home directory shell session
+		return m.createShellSession("home", "", ""), true
+	case "s":
+		// New sudo root shell session (if sudo is available)
+		if m.state.Capabilities != nil && m.state.Capabilities.HasSudo() {
+			return m.createShellSession("sudo", "", ""), true
+		} else {
+			m.lastError = "sudo not available"
+			m.lastErrorTime = time.Now()
+		}
+		return nil, true
+	case "x":
+		// Delete selected session
+		if m.focusArea == FocusDetail && c.treeMenu != nil {
+			item := c.treeMenu.SelectedItem()
+			if item != nil && len(item.Children) == 0 && item.ID != "" {
+				// Save session ID now (tree may change before confirmation)
+				c.pendingDeleteSessionID = item.ID
+				return m.openConfirmDialog(config.ConfirmDeleteSession, "delete_shell_session",
+					fmt.Sprintf("Delete session \"%s\"?", item.Label)), true
+			}
+		}
+		return nil, true
+	case "d":
+		// Disconnect shell terminal
+		if c.activeSession != "" {
+			return m.stopShellTerminal(c.activeSession), true
+		}
+		return nil, true
+	case "r":
+		// Reconnect SSH session, or recreate a lost one (selected in tree, or active)
+		sessionID := c.activeSession
+		if m.focusArea == FocusDetail && c.treeMenu != nil {
+			if item := c.treeMenu.SelectedItem(); item != nil && len(item.Children) == 0 {
+				sessionID = item.ID
+			}
+		}
+		if _, isSSH := c.sshSessions[sessionID]; isSSH {
+			return m.reconnectSSHSession(sessionID), true
+		}
+		if m.terminalManager.LostReason(sessionID) != "" {
+			c.activeSession = sessionID
+			return m.recreateLostTerminal(sessionID), true
+		}
+		return nil, true
+	case "e":
+		// Edit shell for selected session (cycle through available shells)
+		// Only if there's no terminal running for this session
+		if m.focusArea == FocusDetail && c.treeMenu != nil {
+			item := c.treeMenu.SelectedItem()
+			if item != nil && len(item.Children) == 0 && item.ID != "" {
+				// Check if terminal is running
+				if t := m.terminalManager.Get(item.ID); t != nil && t.IsRunning() {
+					m.lastError = "Cannot change shell while terminal is running"
+					m.lastErrorTime = time.Now()
+					return nil, true
+				}
+				// Check if we have more than one shell available
+				if m.state.Shell != nil && len(m.state.Shell.AvailableShells) <= 1 {
+					m.lastError = "Only one shell available"
+					m.lastErrorTime = time.Now()
+					return nil, true
+				}
+				// Cycle shell via presenter event
+				event := core.NewEvent(core.EventShellCycleShell).
+					WithData("session_id", item.ID)
+				if m.blockReadOnlyEvent(event) {
+					return nil, true
+				}
+				return func() tea.Msg {
+					m.presenter.HandleEvent(event)
+					return refreshMsg{}
+				}, true
+			}
+		}
+		return nil, true
+	case "enter":
+		// Enter terminal mode when focused on terminal panel
+		if m.focusArea == FocusMain && c.activeSession != "" {
+			if m.isSuspended(c.activeSession) {
+				return m.resumeShellTerminal(c.activeSession), true
+			}
+			if t := m.terminalManager.Get(c.activeSession); t != nil && t.IsRunning() {
+				m.terminalMode = true
+				m.commandMode = false
+			}
+		}
+		// Select session from tree menu
+		if m.focusArea == FocusDetail && c.treeMenu != nil {
+			item := c.treeMenu.SelectedItem()
+			if item != nil && len(item.Children) == 0 && item.ID != "" {
+				return m.switchToShellSession(item.ID), true
+			}
+		}
+		return nil, true
+	case "esc":
+		// Exit terminal mode or switch focus
+		if m.terminalMode {
+			m.terminalMode = false
+			return nil, true
+		}
+		if m.focusArea == FocusDetail {
+			m.focusArea = FocusMain
+			return nil, true
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
 // renderShell renders the Shell view
 func (m *Model) renderShell(width, height int) string {
 	vm := m.state.Shell
@@ -57,8 +305,8 @@ func (m *Model) renderShellNotInstalled(width, height int) string {
 // renderShellMainPanel renders the main panel (terminal or placeholder)
 func (m *Model) renderShellMainPanel(width, height int) string {
 	// Check if we have an active session with a terminal
-	if m.shellActiveSession != "" {
-		if t := m.terminalManager.Get(m.shellActiveSession); t != nil && t.IsRunning() {
+	if m.shellView().activeSession != "" {
+		if t := m.terminalManager.Get(m.shellView().activeSession); t != nil && t.IsRunning() {
 			return m.renderTerminalPanel(t, width, height)
 		}
 	}
@@ -69,11 +317,22 @@ func (m *Model) renderShellMainPanel(width, height int) string {
 		style = FocusedBorderStyle
 	}
 
+	message := "Select or create a session to start Shell\n\nn = new | h = home | s = sudo root | e = change shell"
+	if m.isSuspended(m.shellView().activeSession) {
+		message = "Session suspended while idle\n\nEnter = resume"
+	} else if lost := m.lostTerminalMessage(m.shellView().activeSession); lost != "" {
+		message = lost
+	} else if m.isSSHDisconnected(m.shellView().activeSession) {
+		message = fmt.Sprintf("Connection to %s closed\n\nr = reconnect | x = delete", m.shellView().sshSessions[m.shellView().activeSession])
+	} else if m.state.Shell != nil && len(m.state.Shell.SSHHosts) > 0 {
+		message += "\nEnter/n on an SSH host = remote shell"
+	}
+
 	content := lipgloss.NewStyle().
 		Foreground(ColorMuted).
 		Align(lipgloss.Center).
 		Width(width - 2).
-		Render("Select or create a session to start Shell\n\nn = new | h = home | s = sudo root | e = change shell")
+		Render(message)
 
 	return style.
 		Width(width).
@@ -99,8 +358,8 @@ func (m *Model) renderShellSessionsPanel(width, height int) string {
 
 	// Session list using TreeMenu
 	var listContent string
-	if m.shellTreeMenu != nil {
-		listContent = m.shellTreeMenu.Render()
+	if m.shellView().treeMenu != nil {
+		listContent = m.shellView().treeMenu.Render()
 	} else {
 		listContent = lipgloss.NewStyle().
 			Foreground(ColorMuted).
@@ -144,8 +403,7 @@ func (m *Model) renderShellInfoPanel(width int) string {
 	shellName := sess.Shell
 	if shellName == "" && vm.ShellPath != "" {
 		// Extract shell name from path
-		parts := strings.Split(vm.ShellPath, "/")
-		shellName = parts[len(parts)-1]
+		shellName = filepath.Base(vm.ShellPath)
 	}
 	if shellName != "" {
 		lines = append(lines, fmt.Sprintf("Shell: %s", shellName))
@@ -167,3 +425,238 @@ func (m *Model) renderShellInfoPanel(width int) string {
 
 	return style.Render(strings.Join(lines, "\n"))
 }
+
+// updateShellTree updates the shell sessions tree menu
+func (m *Model) updateShellTree() {
+	if m.state.Shell == nil {
+		return
+	}
+
+	// Create root items for special sessions and projects
+	var items []TreeMenuItem
+
+	// Special sessions category (Home, Root)
+	var specialItems []TreeMenuItem
+
+	// Check for active home and sudo sessions in terminal manager
+	if m.terminalManager != nil {
+		for _, sessionID := range m.listedShellSessions() {
+			if strings.HasPrefix(sessionID, "shell-home-") {
+				specialItems = append(specialItems, m.withActivityBadge(TreeMenuItem{
+					ID:       sessionID,
+					Label:    "Home",
+					Icon:     "~",
+					IsActive: sessionID == m.shellView().activeSession,
+				}, sessionID))
+			} else if strings.HasPrefix(sessionID, "shell-sudo-") {
+				specialItems = append(specialItems, m.withActivityBadge(TreeMenuItem{
+					ID:       sessionID,
+					Label:    "Root (sudo)",
+					Icon:     "#",
+					IsActive: sessionID == m.shellView().activeSession,
+				}, sessionID))
+			}
+		}
+	}
+
+	if len(specialItems) > 0 {
+		items = append(items, TreeMenuItem{
+			ID:       "_special",
+			Label:    "Special",
+			Children: specialItems,
+		})
+	}
+
+	// SSH hosts grouped, with their sessions
+	items = append(items, m.buildSSHTreeItems()...)
+
+	// Project sessions
+	projectSessionMap := make(map[string][]TreeMenuItem)
+
+	if m.terminalManager != nil {
+		for _, sessionID := range m.listedShellSessions() {
+			if strings.HasPrefix(sessionID, "shell-project-") {
+				// Extract project from session (need to track this better)
+				parts := strings.Split(sessionID, "-")
+				if len(parts) >= 3 {
+					projectID := parts[2] // This is simplified, may need improvement
+					projectSessionMap[projectID] = append(projectSessionMap[projectID], m.withActivityBadge(TreeMenuItem{
+						ID:       sessionID,
+						Label:    "Shell",
+						Icon:     "$",
+						IsActive: sessionID == m.shellView().activeSession,
+					}, sessionID))
+				}
+			}
+		}
+	}
+
+	// Add projects with their sessions
+	if m.state.Projects != nil {
+		for _, proj := range m.state.Projects.Projects {
+			projSessions := projectSessionMap[proj.ID]
+			projItem := TreeMenuItem{
+				ID:       proj.ID,
+				Label:    proj.Name,
+				Children: projSessions,
+			}
+			items = append(items, projItem)
+		}
+	}
+
+	// Create or update tree menu
+	if m.shellView().treeMenu == nil {
+		m.shellView().treeMenu = NewTreeMenu(items)
+		m.shellView().treeMenu.SetSize(30, 20)
+	} else {
+		m.shellView().treeMenu.SetItems(items)
+	}
+}
+
+// createShellSession creates a new shell session
+func (m *Model) createShellSession(sessionType, projectID, projectName string) tea.Cmd {
+	if m.terminalManager == nil {
+		return nil
+	}
+
+	// Get shell path
+	shellPath := defaultShellPath
+	if m.state.Capabilities != nil && m.state.Capabilities.Shell.Path != "" {
+		shellPath = m.state.Capabilities.Shell.Path
+	}
+
+	// Determine work directory
+	var workDir string
+	var sessionName string
+	var args []string
+
+	switch sessionType {
+	case "home":
+		workDir, _ = os.UserHomeDir()
+		sessionName = "Home"
+	case "sudo":
+		workDir = "/root"
+		sessionName = "Root (sudo)"
+		// Use sudo -i to get root shell
+		args = []string{"-i"}
+		shellPath = "sudo"
+	case "project":
+		// Get project path from state
+		if m.state.Projects != nil {
+			for _, proj := range m.state.Projects.Projects {
+				if proj.ID == projectID {
+					workDir = proj.Path
+					sessionName = proj.Name
+					break
+				}
+			}
+		}
+		if workDir == "" {
+			workDir, _ = os.UserHomeDir()
+			sessionName = projectName
+		}
+	default:
+		workDir, _ = os.UserHomeDir()
+		sessionName = "Shell"
+	}
+
+	// Create session ID
+	sessionID := fmt.Sprintf("shell-%s-%d", sessionType, time.Now().UnixNano())
+
+	// Create terminal using TmuxPrefixShell prefix
+	var t TerminalInterface
+	if sessionType == "sudo" {
+		t = m.terminalManager.GetOrCreateCommandWithPrefix(sessionID, shellPath, args, TmuxPrefixShell)
+	} else {
+		// For regular shell, use a command-based approach
+		t = m.terminalManager.GetOrCreateCommandWithPrefix(sessionID, shellPath, []string{}, TmuxPrefixShell)
+	}
+
+	if t == nil {
+		m.lastError = "Failed to create shell terminal"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	// Start the terminal
+	if err := t.Start(sessionID); err != nil {
+		m.lastError = fmt.Sprintf("Failed to start shell: %v", err)
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	// Switch to this session
+	m.shellView().activeSession = sessionID
+	m.focusArea = FocusMain
+
+	// Header event
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess, "Shell started: "+sessionName))
+
+	// Update tree menu
+	m.updateShellTree()
+
+	return m.scheduleTerminalRefresh()
+}
+
+// stopShellTerminal stops the shell terminal
+func (m *Model) stopShellTerminal(sessionID string) tea.Cmd {
+	if m.terminalManager == nil {
+		return nil
+	}
+
+	t := m.terminalManager.Get(sessionID)
+	if t == nil {
+		return nil
+	}
+
+	// Stop the terminal
+	go t.Stop()
+
+	// Exit terminal mode if this was active
+	if m.shellView().activeSession == sessionID && m.terminalMode {
+		m.terminalMode = false
+	}
+
+	// Clear active session
+	if m.shellView().activeSession == sessionID {
+		m.shellView().activeSession = ""
+	}
+
+	// Header event
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventInfo, "Shell disconnected"))
+
+	// Update tree menu
+	m.updateShellTree()
+
+	return nil
+}
+
+// switchToShellSession switches to an existing shell session
+func (m *Model) switchToShellSession(sessionID string) tea.Cmd {
+	if m.terminalManager == nil {
+		return nil
+	}
+
+	if m.isSuspended(sessionID) {
+		return m.resumeShellTerminal(sessionID)
+	}
+	t := m.terminalManager.Get(sessionID)
+	if t == nil || !t.IsRunning() {
+		m.lastError = "Session not running"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	m.shellView().activeSession = sessionID
+	m.focusArea = FocusMain
+	m.updateShellTree()
+
+	return m.scheduleTerminalRefresh()
+}
+
+// listedShellSessions returns the terminals listed in the Shell tree: the
+// running ones, and the suspended or lost ones that can be resumed
+func (m *Model) listedShellSessions() []string {
+	sessions := append(m.terminalManager.GetRunning(), m.suspendedShellSessions()...)
+	return append(sessions, m.terminalManager.GetLost()...)
+}
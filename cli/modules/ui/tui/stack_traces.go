@@ -0,0 +1,536 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Stack trace limits
+const (
+	stackTracesMax = 200 // Traces kept, oldest dropped first
+	stackFramesMax = 100 // Frames kept per trace
+)
+
+var (
+	// goStackFile matches the position line under a Go function: "\t/path/file.go:12 +0x1d"
+	goStackFile = regexp.MustCompile(`^\s*(\S+\.go):(\d+)(?: \+0x[0-9a-f]+)?$`)
+	// nodeStackFrame matches "    at fn (/path/file.js:12:5)" and "    at /path/file.js:12:5"
+	nodeStackFrame = regexp.MustCompile(`^\s*at (?:(.+?) \()?(.+?):(\d+):(\d+)\)?$`)
+	// pythonStackFrame matches `  File "/path/file.py", line 12, in fn`
+	pythonStackFrame = regexp.MustCompile(`^\s*File "(.+)", line (\d+)(?:, in (.+))?$`)
+)
+
+// stackFrame is a call of a stack trace
+type stackFrame struct {
+	function string
+	file     string // As printed (absolute or relative to the process directory)
+	line     int
+	column   int
+	internal bool // Runtime, standard library or dependency frame
+}
+
+// position returns file:line of the frame
+func (f stackFrame) position() string {
+	return fmt.Sprintf("%s:%d", f.file, f.line)
+}
+
+// stackTrace is a panic or exception found in the logs, innermost frame first
+type stackTrace struct {
+	kind    string // go, node, python
+	start   core.LogLineVM
+	message string
+	frames  []stackFrame
+	raw     []string // Log messages of the trace
+}
+
+// key identifies the trace by its first log line
+func (t *stackTrace) key() string {
+	return stackTraceKey(&t.start)
+}
+
+// topFrame returns the innermost frame of the application code (the
+// innermost frame if all are internal), nil without frames
+func (t *stackTrace) topFrame() *stackFrame {
+	for i := range t.frames {
+		if !t.frames[i].internal {
+			return &t.frames[i]
+		}
+	}
+	if len(t.frames) > 0 {
+		return &t.frames[0]
+	}
+	return nil
+}
+
+// stackTraceKey identifies a log line starting a trace
+func stackTraceKey(line *core.LogLineVM) string {
+	return strconv.FormatInt(line.Timestamp.UnixNano(), 36) + "|" + line.Source + "|" + line.Message
+}
+
+// stackParser follows the trace being printed by one log source
+type stackParser struct {
+	trace    *stackTrace
+	function string // Go: function waiting for its position line
+	previous string // Previous message (Node: the error of the first frame)
+}
+
+// stackTraceIndex detects the stack traces of the log buffer between frames.
+// Like logIndex, it only parses the lines appended since the last update.
+type stackTraceIndex struct {
+	lines   []core.LogLineVM
+	parsers map[string]*stackParser // By source
+	traces  []*stackTrace           // Oldest first
+	starts  map[string]*stackTrace  // By key of their first line
+}
+
+// newStackTraceIndex creates an empty stack trace index
+func newStackTraceIndex() *stackTraceIndex {
+	return &stackTraceIndex{
+		parsers: make(map[string]*stackParser),
+		starts:  make(map[string]*stackTrace),
+	}
+}
+
+// update parses the lines added to the buffer since the last update
+func (ix *stackTraceIndex) update(lines []core.LogLineVM) {
+	appendFrom, trimmed, ok := diffLogBuffer(ix.lines, lines)
+	if !ok {
+		ix.parsers = make(map[string]*stackParser)
+		ix.traces = nil
+		ix.starts = make(map[string]*stackTrace)
+		appendFrom = 0
+	} else if trimmed > 0 && len(lines) > 0 {
+		// Drop the traces whose first line left the buffer
+		kept := ix.traces[:0]
+		for _, t := range ix.traces {
+			if t.start.Timestamp.Before(lines[0].Timestamp) {
+				delete(ix.starts, t.key())
+				continue
+			}
+			kept = append(kept, t)
+		}
+		ix.traces = kept
+	}
+	ix.lines = lines
+
+	for i := appendFrom; i < len(lines); i++ {
+		line := &lines[i]
+		p := ix.parsers[line.Source]
+		if p == nil {
+			p = &stackParser{}
+			ix.parsers[line.Source] = p
+		}
+		if t := p.feed(line); t != nil {
+			ix.add(t)
+		}
+	}
+}
+
+// add indexes a new trace, dropping the oldest beyond the limit
+func (ix *stackTraceIndex) add(t *stackTrace) {
+	ix.traces = append(ix.traces, t)
+	ix.starts[t.key()] = t
+	if len(ix.traces) > stackTracesMax {
+		delete(ix.starts, ix.traces[0].key())
+		ix.traces = ix.traces[1:]
+	}
+}
+
+// feed parses a line of the source. It returns the trace the line starts, to
+// be indexed: its frames keep being added while the next lines continue it.
+func (p *stackParser) feed(line *core.LogLineVM) *stackTrace {
+	text := strings.TrimRight(line.Message, "\r")
+	defer func() { p.previous = text }()
+
+	if t := p.trace; t != nil && p.continueTrace(text) {
+		t.raw = append(t.raw, text)
+		return nil
+	}
+	p.trace, p.function = nil, ""
+
+	switch {
+	case strings.HasPrefix(text, "panic: ") || strings.HasPrefix(text, "fatal error: "):
+		p.trace = &stackTrace{kind: "go", start: *line, message: text}
+	case strings.HasPrefix(text, "Traceback (most recent call last):"):
+		p.trace = &stackTrace{kind: "python", start: *line, message: "Traceback"}
+	case nodeStackFrame.MatchString(text) && strings.HasPrefix(strings.TrimSpace(text), "at "):
+		// The error message is the line printed before the first frame
+		p.trace = &stackTrace{kind: "node", start: *line, message: strings.TrimSpace(p.previous)}
+		if p.trace.message == "" {
+			p.trace.message = "Error"
+		}
+		p.continueTrace(text)
+	default:
+		return nil
+	}
+	p.trace.raw = []string{text}
+	return p.trace
+}
+
+// continueTrace adds a line to the current trace, false if it ends it
+func (p *stackParser) continueTrace(text string) bool {
+	t := p.trace
+	trimmed := strings.TrimSpace(text)
+	switch t.kind {
+	case "go":
+		if match := goStackFile.FindStringSubmatch(text); match != nil && p.function != "" {
+			lineNo, _ := strconv.Atoi(match[2])
+			function := strings.TrimPrefix(p.function, "created by ")
+			if i := strings.LastIndex(function, "("); i > 0 {
+				function = function[:i] // Arguments
+			}
+			t.addFrame(stackFrame{function: function, file: match[1], line: lineNo,
+				internal: strings.HasPrefix(function, "runtime.") || strings.Contains(match[1], "/pkg/mod/") ||
+					strings.Contains(match[1], "/go/src/") || strings.Contains(match[1], "/libexec/src/")})
+			p.function = ""
+			return true
+		}
+		switch {
+		case trimmed == "", strings.HasPrefix(trimmed, "goroutine "), strings.HasPrefix(trimmed, "[signal "):
+			return true
+		case p.function == "" && (strings.HasSuffix(trimmed, ")") || strings.HasPrefix(trimmed, "created by ")):
+			p.function = trimmed // Its position comes next
+			return true
+		}
+		return false
+
+	case "node":
+		if !strings.HasPrefix(trimmed, "at ") {
+			return false
+		}
+		if match := nodeStackFrame.FindStringSubmatch(text); match != nil {
+			lineNo, _ := strconv.Atoi(match[3])
+			column, _ := strconv.Atoi(match[4])
+			file := strings.TrimPrefix(match[2], "file://")
+			t.addFrame(stackFrame{function: match[1], file: file, line: lineNo, column: column,
+				internal: strings.HasPrefix(file, "node:") || strings.Contains(file, "node_modules")})
+		}
+		return true // Frames without a position ("at new Promise (<anonymous>)")
+
+	case "python":
+		if match := pythonStackFrame.FindStringSubmatch(text); match != nil {
+			lineNo, _ := strconv.Atoi(match[2])
+			// Python prints the innermost frame last
+			t.frames = append([]stackFrame{{function: match[3], file: match[1], line: lineNo,
+				internal: strings.Contains(match[1], "site-packages") || strings.Contains(match[1], "/lib/python")}}, t.frames...)
+			if len(t.frames) > stackFramesMax {
+				t.frames = t.frames[:stackFramesMax]
+			}
+			return true
+		}
+		if text != "" && (text[0] == ' ' || text[0] == '\t') {
+			return true // Source line of the previous frame
+		}
+		if t.message == "Traceback" && trimmed != "" {
+			// The exception ends the trace
+			t.message = trimmed
+			p.trace = nil
+			return true
+		}
+		return false
+	}
+	return false
+}
+
+// addFrame appends a frame up to the limit
+func (t *stackTrace) addFrame(f stackFrame) {
+	if len(t.frames) < stackFramesMax {
+		t.frames = append(t.frames, f)
+	}
+}
+
+// logTracesPanel is the state of the stack trace panel of the Logs view
+type logTracesPanel struct {
+	selected int             // Selected row
+	expanded map[string]bool // Expanded traces by key
+	preview  *filePreview    // Source of the previewed frame (nil = hidden)
+}
+
+// logTraceRow is a row of the stack trace panel: a trace or one of its frames
+type logTraceRow struct {
+	trace *stackTrace
+	frame int // -1 for the trace header
+}
+
+// visibleStackTraces returns the traces of the sources and type shown in the
+// Logs view, most recent first
+func (m *Model) visibleStackTraces() []*stackTrace {
+	filter := m.currentLogFilter()
+	filter.level, filter.search = "", ""
+	var traces []*stackTrace
+	for i := len(m.logsView().stackTraces.traces) - 1; i >= 0; i-- {
+		if t := m.logsView().stackTraces.traces[i]; len(t.frames) > 0 && filter.matches(&t.start) {
+			traces = append(traces, t)
+		}
+	}
+	return traces
+}
+
+// logTraceRows returns the rows of the stack trace panel
+func (m *Model) logTraceRows() []logTraceRow {
+	var rows []logTraceRow
+	for _, t := range m.visibleStackTraces() {
+		rows = append(rows, logTraceRow{trace: t, frame: -1})
+		if m.logsView().traces.expanded[t.key()] {
+			for i := range t.frames {
+				rows = append(rows, logTraceRow{trace: t, frame: i})
+			}
+		}
+	}
+	return rows
+}
+
+// toggleLogTraces shows or hides the stack trace panel of the Logs view
+func (m *Model) toggleLogTraces() {
+	if m.logsView().traces != nil {
+		m.logsView().traces = nil
+		return
+	}
+	if m.state.Logs != nil {
+		m.logsView().stackTraces.update(m.state.Logs.Lines)
+	}
+	m.logsView().errors = nil
+	m.logsView().traces = &logTracesPanel{expanded: make(map[string]bool)}
+}
+
+// handleLogTracesKey handles the keys of the stack trace panel.
+// It returns false for the keys it leaves to the Logs view.
+func (m *Model) handleLogTracesKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	panel := m.logsView().traces
+	rows := m.logTraceRows()
+	panel.selected = min(max(panel.selected, 0), max(len(rows)-1, 0))
+	var row *logTraceRow
+	if panel.selected < len(rows) {
+		row = &rows[panel.selected]
+	}
+
+	switch msg.String() {
+	case "E":
+		m.logsView().traces = nil
+	case "esc":
+		if panel.preview != nil {
+			panel.preview = nil
+		} else {
+			m.logsView().traces = nil
+		}
+	case "up", "k":
+		panel.selected = max(panel.selected-1, 0)
+	case "down", "j":
+		panel.selected = min(panel.selected+1, max(len(rows)-1, 0))
+	case "pgup":
+		panel.selected = max(panel.selected-10, 0)
+	case "pgdown":
+		panel.selected = min(panel.selected+10, max(len(rows)-1, 0))
+	case "home":
+		panel.selected = 0
+	case "end":
+		panel.selected = max(len(rows)-1, 0)
+	case "right", "l":
+		if row != nil {
+			panel.expanded[row.trace.key()] = true
+		}
+	case "left", "h":
+		if row != nil && panel.expanded[row.trace.key()] {
+			delete(panel.expanded, row.trace.key())
+			panel.selected -= max(row.frame+1, 0) // Back to the header
+		}
+	case "z":
+		// Expand all, or collapse all when all are expanded
+		traces := m.visibleStackTraces()
+		all := len(traces) > 0
+		for _, t := range traces {
+			all = all && panel.expanded[t.key()]
+		}
+		panel.expanded = make(map[string]bool)
+		if !all {
+			for _, t := range traces {
+				panel.expanded[t.key()] = true
+			}
+		}
+		panel.selected = 0
+	case "enter", " ":
+		if row == nil {
+			break
+		}
+		if row.frame < 0 {
+			key := row.trace.key()
+			panel.expanded[key] = !panel.expanded[key]
+			break
+		}
+		return m.openStackFrame(row.trace, &row.trace.frames[row.frame]), true
+	case "o":
+		if row != nil {
+			return m.openStackFrame(row.trace, m.rowFrame(row)), true
+		}
+	case "p":
+		if row != nil {
+			m.previewStackFrame(row.trace, m.rowFrame(row))
+		}
+	case "y":
+		if row != nil {
+			return m.yankText("stack trace", strings.Join(row.trace.raw, "\n")), true
+		}
+	case "ctrl+u", "ctrl+d":
+		// Scroll the preview
+		if panel.preview != nil {
+			step := max(panel.preview.height/2, 1)
+			if msg.String() == "ctrl+u" {
+				step = -step
+			}
+			panel.preview.scrollBy(step)
+		}
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// rowFrame returns the frame of a row, the top application frame for a header
+func (m *Model) rowFrame(row *logTraceRow) *stackFrame {
+	if row.frame >= 0 {
+		return &row.trace.frames[row.frame]
+	}
+	return row.trace.topFrame()
+}
+
+// resolveStackFrame returns the path of the file of a frame. Relative paths
+// are looked up in the component, then the project of the log source.
+func (m *Model) resolveStackFrame(t *stackTrace, f *stackFrame) (string, bool) {
+	if f == nil {
+		return "", false
+	}
+	candidates := []string{f.file}
+	if !filepath.IsAbs(f.file) {
+		candidates = nil
+		projectID, component, _ := strings.Cut(strings.TrimPrefix(t.start.Source, "build:"), "/")
+		if proj := m.findProjectVM(projectID); proj != nil {
+			for _, c := range proj.Components {
+				if string(c.Type) == component && c.Path != "" {
+					candidates = append(candidates, filepath.Join(proj.Path, c.Path, f.file))
+				}
+			}
+			candidates = append(candidates, filepath.Join(proj.Path, f.file))
+		}
+	}
+	for _, path := range candidates {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	m.lastError = "Source not found: " + f.file
+	m.lastErrorTime = time.Now()
+	return "", false
+}
+
+// openStackFrame opens the file of a frame in the editor at its line
+func (m *Model) openStackFrame(t *stackTrace, f *stackFrame) tea.Cmd {
+	path, ok := m.resolveStackFrame(t, f)
+	if !ok {
+		return nil
+	}
+	return m.openInEditorAt(path, f.line)
+}
+
+// previewStackFrame shows the file of a frame in the preview panel, centered
+// on its line
+func (m *Model) previewStackFrame(t *stackTrace, f *stackFrame) {
+	path, ok := m.resolveStackFrame(t, f)
+	if !ok {
+		return
+	}
+	preview := loadFilePreview(path)
+	preview.mark = f.line
+	preview.scroll = max(f.line-1-m.height/4, 0)
+	m.logsView().traces.preview = &preview
+}
+
+// stackTraceBadge returns the marker of a log line starting a stack trace,
+// "" for other lines
+func (m *Model) stackTraceBadge(line *core.LogLineVM) string {
+	t := m.logsView().stackTraces.starts[stackTraceKey(line)]
+	if t == nil || len(t.frames) == 0 {
+		return ""
+	}
+	return StatusWarning.Render(fmt.Sprintf(" ⚠ %d frames (E)", len(t.frames)))
+}
+
+// renderLogTraces renders the stack trace panel in place of the log lines,
+// with the previewed source on the right
+func (m *Model) renderLogTraces(width, height int) []string {
+	panel := m.logsView().traces
+	listWidth := width
+	var preview []string
+	if panel.preview != nil && width >= 100 {
+		listWidth = width / 2
+		preview = strings.Split(renderPreviewPanel(panel.preview, width-listWidth-1, height), "\n")
+	}
+
+	rows := m.logTraceRows()
+	lines := []string{SubtitleStyle.Render(truncate(fmt.Sprintf("Stack traces (%d)", len(m.visibleStackTraces())), listWidth))}
+	if len(rows) == 0 {
+		lines = append(lines, "", SubtitleStyle.Render("No panics or exceptions in the logs"))
+	}
+
+	// Keep the selection visible
+	visible := max(height-3, 1)
+	panel.selected = min(max(panel.selected, 0), max(len(rows)-1, 0))
+	start := max(panel.selected-visible+1, 0)
+	end := min(start+visible, len(rows))
+
+	internalStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	for i := start; i < end; i++ {
+		row := rows[i]
+		t := row.trace
+		var text string
+		if row.frame < 0 {
+			arrow := "▸"
+			if panel.expanded[t.key()] {
+				arrow = "▾"
+			}
+			text = fmt.Sprintf("%s %s %s %s", arrow, LogTimestampStyle.Render(t.start.TimeStr),
+				LogSourceStyle.Render("["+t.start.Source+"]"), LogErrorStyle.Render(t.message))
+			if top := t.topFrame(); top != nil {
+				text += SubtitleStyle.Render(fmt.Sprintf("  %d frames · ", len(t.frames))) + GitBranchStyle.Render(top.position())
+			}
+		} else {
+			f := t.frames[row.frame]
+			function := f.function
+			if function == "" {
+				function = "<anonymous>"
+			}
+			text = fmt.Sprintf("    %s %s", GitBranchStyle.Render(f.position()), function)
+			if f.internal {
+				text = "    " + internalStyle.Render(fmt.Sprintf("%s %s", f.position(), function))
+			}
+		}
+		if i == panel.selected && m.focusArea == FocusMain {
+			text = FocusIndicator + " " + text
+		} else {
+			text = "  " + text
+		}
+		lines = append(lines, truncateANSI(text, listWidth))
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, SubtitleStyle.Render(truncate(
+		"Enter expand/open  o editor  p preview  z expand all  y copy  Esc close", listWidth)))
+
+	if preview == nil {
+		return lines
+	}
+	for i := range lines {
+		lines[i] = lipgloss.NewStyle().Width(listWidth).Render(truncateANSI(lines[i], listWidth))
+	}
+	return strings.Split(lipgloss.JoinHorizontal(lipgloss.Top, strings.Join(lines, "\n"), " ", strings.Join(preview, "\n")), "\n")
+}
@@ -0,0 +1,306 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// storageTreeItem represents an item in the storage tree
+type storageTreeItem struct {
+	ProjectID   string               // Project ID ("" = shared locations)
+	ProjectName string               // Display name of the group
+	Entry       *core.StorageEntryVM // Category entry (nil for project groups)
+}
+
+// storageController is the submodel of the Storage view
+type storageController struct {
+	menu *TreeMenu // Tree menu for projects and storage categories
+
+	pendingProject  string // Project ID to clean ("" = shared, saved at dialog open)
+	pendingCategory string // Category to clean (saved at dialog open)
+}
+
+// newStorageController creates the Storage view controller
+func newStorageController() *storageController {
+	menu := NewTreeMenu(nil)
+	menu.SetTitle("Storage")
+	return &storageController{menu: menu}
+}
+
+// Init implements ViewController
+func (c *storageController) Init(m *Model) tea.Cmd {
+	c.updateMenu(m)
+	return nil
+}
+
+// Menu implements ViewController
+func (c *storageController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	if focus != FocusMain {
+		return nil
+	}
+	return c.menu
+}
+
+// Keys implements ViewController
+func (c *storageController) Keys(m *Model) []KeyHint {
+	return []KeyHint{
+		{"Enter", "expand"},
+		{"c", "clean"},
+		{"r", "rescan"},
+	}
+}
+
+// Update implements ViewController
+func (c *storageController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case stateUpdateMsg:
+		if msg.update.Affects(core.VMStorage) {
+			c.updateMenu(m)
+		}
+	case selectMsg:
+		// Select() handles back item and drill-down
+		c.menu.Select()
+		return nil, true
+	case dialogConfirmMsg:
+		if msg.dialogType != "storage_clean" {
+			return nil, false
+		}
+		projectID, category := c.pendingProject, c.pendingCategory
+		c.pendingProject, c.pendingCategory = "", ""
+		if category == "" {
+			return nil, true
+		}
+		return m.sendEvent(core.NewEvent(core.EventStorageClean).WithProject(projectID).WithTarget(category)), true
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			if m.state.Storage != nil && m.state.Storage.Scanning {
+				return nil, true
+			}
+			return m.sendEvent(core.NewEvent(core.EventStorageScan)), true
+		case "c", "x":
+			return c.confirmClean(m), true
+		}
+	}
+	return nil, false
+}
+
+// View implements ViewController
+// Layout: TreeMenu with projects/categories on left, details on right
+func (c *storageController) View(m *Model, width, height int) string {
+	vm := m.state.Storage
+	if vm == nil {
+		return m.renderLoading()
+	}
+
+	// 2 panels side by side (TreeMenu + detail)
+	// Height: 1 × 2 = 2
+	// Width: 2 × 2 = 4
+	heightBorders := 2
+	widthBorders := 4
+	panelHeight := height - heightBorders
+	availableWidth := width - widthBorders - GapHorizontal
+
+	// Left panel - TreeMenu with projects and categories
+	listWidth := c.menu.CalcWidth()
+	if listWidth < 30 {
+		listWidth = 30
+	}
+	if listWidth > availableWidth/2 {
+		listWidth = availableWidth / 2
+	}
+
+	c.menu.SetSize(listWidth, panelHeight)
+	c.menu.SetFocused(m.focusArea == FocusMain)
+	listPanel := c.menu.Render()
+
+	// Right panel - details
+	detailWidth := availableWidth - listWidth
+	detailContent := c.renderDetail(m, vm, detailWidth-4)
+
+	var detailStyle lipgloss.Style
+	if m.focusArea == FocusDetail {
+		detailStyle = FocusedBorderStyle
+	} else {
+		detailStyle = UnfocusedBorderStyle
+	}
+	detailPanel := detailStyle.Width(detailWidth - 2).Height(panelHeight).Render(detailContent)
+
+	gap := strings.Repeat(" ", GapHorizontal)
+	return lipgloss.JoinHorizontal(lipgloss.Top, listPanel, gap, detailPanel)
+}
+
+// renderDetail renders the details of the selected storage item
+func (c *storageController) renderDetail(m *Model, vm *core.StorageVM, width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	// Summary header (always shown)
+	lines := []string{PanelTitleStyle.Render("Disk Usage"), ""}
+	switch {
+	case vm.Scanning:
+		lines = append(lines, StatusWarning.Render(m.spinner.View()+" Scanning..."))
+	case vm.ScannedAt.IsZero():
+		lines = append(lines, mutedStyle.Render("Not scanned yet - press r to scan"))
+		return strings.Join(lines, "\n")
+	default:
+		lines = append(lines, fmt.Sprintf("Total: %s", lipgloss.NewStyle().Foreground(ColorInfo).Bold(true).Render(vm.TotalSize)))
+		lines = append(lines, mutedStyle.Render("Scanned "+formatRelativeTime(vm.ScannedAt)))
+	}
+	lines = append(lines, "")
+
+	var item storageTreeItem
+	if selected := c.menu.SelectedItem(); selected != nil {
+		item, _ = selected.Data.(storageTreeItem)
+	}
+
+	if item.Entry == nil && item.ProjectName == "" {
+		lines = append(lines, mutedStyle.Render("Select a project or category"))
+		return strings.Join(lines, "\n")
+	}
+
+	if item.Entry == nil {
+		// Project group - show breakdown by category
+		lines = append(lines, SubtitleStyle.Render(item.ProjectName))
+		for _, entry := range c.entriesFor(m, item.ProjectID) {
+			lines = append(lines, fmt.Sprintf("  %-16s %10s", entry.Label, entry.Size))
+		}
+		lines = append(lines, "", mutedStyle.Render("Press → or Enter to see categories"))
+		return strings.Join(lines, "\n")
+	}
+
+	// Category entry
+	entry := item.Entry
+	lines = append(lines, SubtitleStyle.Render(item.ProjectName+" · "+entry.Label))
+	lines = append(lines, fmt.Sprintf("Size:  %s", entry.Size))
+	lines = append(lines, fmt.Sprintf("Files: %d", entry.Files))
+	if len(entry.Paths) > 0 {
+		lines = append(lines, "", mutedStyle.Render("Paths:"))
+		for i, path := range entry.Paths {
+			if i >= 10 {
+				lines = append(lines, mutedStyle.Render(fmt.Sprintf("  ... and %d more", len(entry.Paths)-i)))
+				break
+			}
+			lines = append(lines, "  "+truncate(path, width-2))
+		}
+	}
+
+	if entry.Bytes > 0 {
+		lines = append(lines, "", SubtitleStyle.Render("Actions:"))
+		lines = append(lines, HelpKeyStyle.Render("c")+" "+storageCleanVerb(entry.Category))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// entriesFor returns the entries of a project ("" = shared locations)
+func (c *storageController) entriesFor(m *Model, projectID string) []core.StorageEntryVM {
+	if m.state.Storage == nil {
+		return nil
+	}
+	if projectID == "" {
+		return m.state.Storage.Shared
+	}
+	for _, project := range m.state.Storage.Projects {
+		if project.ProjectID == projectID {
+			return project.Entries
+		}
+	}
+	return nil
+}
+
+// storageCleanVerb describes what cleaning a category does
+func storageCleanVerb(category string) string {
+	switch category {
+	case "go_cache":
+		return "go clean -cache"
+	case "logs":
+		return "truncate logs"
+	default:
+		return "move to trash"
+	}
+}
+
+// confirmClean opens the confirmation dialog for the selected entry
+func (c *storageController) confirmClean(m *Model) tea.Cmd {
+	selected := c.menu.SelectedItem()
+	if selected == nil {
+		return nil
+	}
+	item, ok := selected.Data.(storageTreeItem)
+	if !ok || item.Entry == nil {
+		m.lastError = "Select a category to clean"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	if item.Entry.Bytes == 0 {
+		m.lastError = "Nothing to clean"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	c.pendingProject = item.ProjectID
+	c.pendingCategory = item.Entry.Category
+	var message string
+	switch item.Entry.Category {
+	case "go_cache":
+		message = fmt.Sprintf("Run go clean -cache (%s)?", item.Entry.Size)
+	case "logs":
+		message = fmt.Sprintf("Truncate %d log file(s) of %s (%s)?", item.Entry.Files, item.ProjectName, item.Entry.Size)
+	default:
+		message = fmt.Sprintf("Move %s of %s (%s) to the trash?", item.Entry.Label, item.ProjectName, item.Entry.Size)
+	}
+	return m.openConfirmDialog(config.ConfirmStorageClean, "storage_clean", message)
+}
+
+// updateMenu updates the storage TreeMenu with current disk usage data
+func (c *storageController) updateMenu(m *Model) {
+	if m.state.Storage == nil {
+		return
+	}
+
+	buildGroup := func(id, name, totalSize string, entries []core.StorageEntryVM) TreeMenuItem {
+		group := TreeMenuItem{
+			ID:        "storage:" + id,
+			Label:     fmt.Sprintf("%s  %s", name, totalSize),
+			Icon:      "📁",
+			IconColor: ColorSecondary,
+			Data:      storageTreeItem{ProjectID: id, ProjectName: name},
+		}
+		for i := range entries {
+			entry := entries[i]
+			iconColor := ColorMuted
+			if entry.Bytes > 0 {
+				iconColor = ColorInfo
+			}
+			group.Children = append(group.Children, TreeMenuItem{
+				ID:        "storage:" + id + ":" + entry.Category,
+				Label:     fmt.Sprintf("%s  %s", entry.Label, entry.Size),
+				Icon:      "○",
+				IconColor: iconColor,
+				Data:      storageTreeItem{ProjectID: id, ProjectName: name, Entry: &entry},
+			})
+		}
+		group.Count = len(group.Children)
+		return group
+	}
+
+	var items []TreeMenuItem
+	for _, project := range m.state.Storage.Projects {
+		items = append(items, buildGroup(project.ProjectID, project.ProjectName, project.TotalSize, project.Entries))
+	}
+	if len(m.state.Storage.Shared) > 0 {
+		shared := buildGroup("", "Shared", "", m.state.Storage.Shared)
+		shared.Label = "Shared"
+		shared.Icon = "◆"
+		items = append(items, shared)
+	}
+
+	c.menu.SetItems(items)
+}
@@ -242,6 +242,13 @@ const (
 
 // Helper functions
 func StatusIcon(state string) string {
+	// Screen readers get the state itself
+	if screenReader {
+		if state == "" {
+			state = "stopped"
+		}
+		return "[" + state + "]"
+	}
 	switch state {
 	case "running":
 		return StatusRunning.Render(IconRunning)
@@ -257,6 +264,12 @@ func StatusIcon(state string) string {
 }
 
 func GitStatusIcon(clean bool) string {
+	if screenReader {
+		if clean {
+			return "[clean]"
+		}
+		return "[dirty]"
+	}
 	if clean {
 		return GitCleanStyle.Render(IconGitClean)
 	}
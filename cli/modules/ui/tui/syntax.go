@@ -0,0 +1,371 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"csd-devtrack/cli/modules/platform/config"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// syntaxKind is the class of a highlighted token
+type syntaxKind uint8
+
+const (
+	synNone syntaxKind = iota
+	synKeyword
+	synString
+	synComment
+	synNumber
+	synFunc
+	synKey // Mapping key (YAML, JSON, TOML)
+)
+
+// syntaxColors are the foreground colors of the token classes
+var syntaxColors = map[syntaxKind]lipgloss.Color{
+	synKeyword: lipgloss.Color("#c678dd"),
+	synString:  lipgloss.Color("#e5c07b"),
+	synComment: ColorMuted,
+	synNumber:  lipgloss.Color("#d19a66"),
+	synFunc:    lipgloss.Color("#61afef"),
+	synKey:     ColorPrimary,
+}
+
+// syntaxSpan is a highlighted byte range [start, end) of a line
+type syntaxSpan struct {
+	start, end int
+	kind       syntaxKind
+}
+
+// syntaxLang describes the lexical rules of a language, enough to color
+// keywords, strings, comments and numbers line by line
+type syntaxLang struct {
+	keywords     map[string]bool
+	lineComments []string // Line comment prefixes ("#" only after a space or at line start)
+	blockStart   string   // Block comment delimiters (empty if none)
+	blockEnd     string
+	quotes       string // String delimiters
+	keys         bool   // Tokens followed by ':' are mapping keys
+}
+
+// words builds a keyword set
+func words(list string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(list) {
+		set[w] = true
+	}
+	return set
+}
+
+var (
+	langGo = &syntaxLang{
+		keywords: words(`break case chan const continue default defer else fallthrough for func go goto if
+			import interface map package range return select struct switch type var
+			true false nil iota bool byte error int int8 int16 int32 int64 uint uint8 uint16 uint32 uint64
+			float32 float64 rune string any`),
+		lineComments: []string{"//"},
+		blockStart:   "/*", blockEnd: "*/",
+		quotes: "\"'`",
+	}
+	langJS = &syntaxLang{
+		keywords: words(`break case catch class const continue debugger default delete do else export extends
+			finally for from function if import in instanceof let new of return super switch this throw try
+			typeof var void while with yield async await true false null undefined
+			interface type enum implements private public protected readonly as string number boolean any`),
+		lineComments: []string{"//"},
+		blockStart:   "/*", blockEnd: "*/",
+		quotes: "\"'`",
+	}
+	langPython = &syntaxLang{
+		keywords: words(`and as assert async await break class continue def del elif else except finally for
+			from global if import in is lambda nonlocal not or pass raise return try while with yield
+			True False None self`),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	}
+	langRust = &syntaxLang{
+		keywords: words(`as async await break const continue crate dyn else enum extern false fn for if impl in
+			let loop match mod move mut pub ref return self Self static struct super trait true type unsafe
+			use where while i8 i16 i32 i64 u8 u16 u32 u64 usize isize f32 f64 bool char str String Option Result`),
+		lineComments: []string{"//"},
+		blockStart:   "/*", blockEnd: "*/",
+		quotes: "\"",
+	}
+	langC = &syntaxLang{
+		keywords: words(`auto break case char class const continue default delete do double else enum extends
+			extern final finally float for goto if implements import int long namespace new package private
+			protected public return short signed sizeof static struct super switch template this throw throws
+			try typedef union unsigned using virtual void volatile while bool true false null nullptr
+			var val fun override internal object String`),
+		lineComments: []string{"//"},
+		blockStart:   "/*", blockEnd: "*/",
+		quotes: "\"'",
+	}
+	langShell = &syntaxLang{
+		keywords: words(`if then else elif fi for while until do done case esac in function return local
+			export readonly set unset shift exit echo source`),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	}
+	langSQL = &syntaxLang{
+		keywords: words(`select from where insert into values update set delete create table drop alter add
+			index primary key foreign references not null unique default join left right inner outer on and
+			or as order by group having limit offset distinct union all case when then else end begin commit
+			SELECT FROM WHERE INSERT INTO VALUES UPDATE SET DELETE CREATE TABLE DROP ALTER ADD
+			INDEX PRIMARY KEY FOREIGN REFERENCES NOT NULL UNIQUE DEFAULT JOIN LEFT RIGHT INNER OUTER ON AND
+			OR AS ORDER BY GROUP HAVING LIMIT OFFSET DISTINCT UNION ALL CASE WHEN THEN ELSE END BEGIN COMMIT`),
+		lineComments: []string{"--"},
+		blockStart:   "/*", blockEnd: "*/",
+		quotes: "'\"",
+	}
+	langYAML = &syntaxLang{
+		keywords:     words(`true false null yes no on off True False Null`),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+		keys:         true,
+	}
+	langJSON = &syntaxLang{
+		keywords: words(`true false null`),
+		quotes:   "\"",
+		keys:     true,
+	}
+	langMarkup = &syntaxLang{
+		blockStart: "<!--", blockEnd: "-->",
+		quotes: "\"",
+	}
+	langCSS = &syntaxLang{
+		keywords:   words(`important inherit initial none auto`),
+		blockStart: "/*", blockEnd: "*/",
+		quotes: "\"'",
+	}
+)
+
+// syntaxLangs maps file extensions (and names without extension) to languages
+var syntaxLangs = map[string]*syntaxLang{
+	".go": langGo,
+	".js": langJS, ".mjs": langJS, ".cjs": langJS, ".jsx": langJS, ".ts": langJS, ".tsx": langJS, ".vue": langJS,
+	".py": langPython,
+	".rs": langRust,
+	".c":  langC, ".h": langC, ".cpp": langC, ".cc": langC, ".hpp": langC, ".cs": langC,
+	".java": langC, ".kt": langC, ".kts": langC, ".swift": langC, ".php": langC, ".dart": langC,
+	".sh": langShell, ".bash": langShell, ".zsh": langShell, ".env": langShell,
+	"makefile": langShell, "dockerfile": langShell,
+	".sql":  langSQL,
+	".yaml": langYAML, ".yml": langYAML, ".toml": langYAML, ".ini": langYAML, ".conf": langYAML,
+	".json": langJSON,
+	".html": langMarkup, ".htm": langMarkup, ".xml": langMarkup, ".svg": langMarkup, ".md": langMarkup,
+	".css": langCSS, ".scss": langCSS, ".less": langCSS,
+}
+
+// detectLanguage returns the language of a file from its extension (or its
+// name, e.g. Makefile), nil if unknown
+func detectLanguage(path string) *syntaxLang {
+	base := strings.ToLower(filepath.Base(path))
+	if lang, ok := syntaxLangs[strings.ToLower(filepath.Ext(base))]; ok {
+		return lang
+	}
+	return syntaxLangs[base]
+}
+
+// isSyntaxHighlightEnabled returns true unless syntax highlighting is disabled
+// in the settings (slow terminals)
+func isSyntaxHighlightEnabled() bool {
+	cfg := config.GetGlobal()
+	return cfg == nil || cfg.Settings == nil || !cfg.Settings.DisableSyntaxHighlight
+}
+
+// highlighter colors the lines of a file in order: the state of a block
+// comment is kept from one line to the next
+type highlighter struct {
+	lang    *syntaxLang
+	inBlock bool
+}
+
+// spans returns the highlighted ranges of the next line
+func (h *highlighter) spans(line string) []syntaxSpan {
+	lang := h.lang
+	var spans []syntaxSpan
+	add := func(start, end int, kind syntaxKind) {
+		if end > start {
+			spans = append(spans, syntaxSpan{start, end, kind})
+		}
+	}
+
+	i := 0
+	if h.inBlock {
+		end := strings.Index(line, lang.blockEnd)
+		if end < 0 {
+			add(0, len(line), synComment)
+			return spans
+		}
+		i = end + len(lang.blockEnd)
+		add(0, i, synComment)
+		h.inBlock = false
+	}
+
+	for i < len(line) {
+		rest := line[i:]
+		c := line[i]
+
+		if lang.blockStart != "" && strings.HasPrefix(rest, lang.blockStart) {
+			end := strings.Index(rest[len(lang.blockStart):], lang.blockEnd)
+			if end < 0 {
+				add(i, len(line), synComment)
+				h.inBlock = true
+				return spans
+			}
+			end += i + len(lang.blockStart) + len(lang.blockEnd)
+			add(i, end, synComment)
+			i = end
+			continue
+		}
+		if h.isLineComment(line, i) {
+			add(i, len(line), synComment)
+			return spans
+		}
+
+		switch {
+		case strings.IndexByte(lang.quotes, c) >= 0:
+			end := stringEnd(line, i)
+			kind := synString
+			if lang.keys && strings.HasPrefix(strings.TrimLeft(line[end:], " \t"), ":") {
+				kind = synKey
+			}
+			add(i, end, kind)
+			i = end
+		case c >= '0' && c <= '9':
+			end := i + 1
+			for end < len(line) && (isIdentByte(line[end]) || line[end] == '.') {
+				end++
+			}
+			add(i, end, synNumber)
+			i = end
+		case isIdentStart(line, i):
+			end := i
+			for end < len(line) {
+				r, size := utf8.DecodeRuneInString(line[end:])
+				if !(r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || (lang.keys && r == '-')) {
+					break
+				}
+				end += size
+			}
+			switch word := line[i:end]; {
+			case lang.keys && isKeyAt(line, end):
+				add(i, end, synKey)
+			case lang.keywords[word]:
+				add(i, end, synKeyword)
+			case end < len(line) && line[end] == '(':
+				add(i, end, synFunc)
+			}
+			i = end
+		default:
+			_, size := utf8.DecodeRuneInString(rest)
+			i += size
+		}
+	}
+	return spans
+}
+
+// isLineComment returns true if a line comment starts at i
+func (h *highlighter) isLineComment(line string, i int) bool {
+	for _, prefix := range h.lang.lineComments {
+		if !strings.HasPrefix(line[i:], prefix) {
+			continue
+		}
+		// '#' also appears in words ($#, a#b, URLs): a comment starts a word
+		if prefix == "#" && i > 0 && line[i-1] != ' ' && line[i-1] != '\t' {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// stringEnd returns the end of the string literal starting at i (the end of
+// the line if it is not closed)
+func stringEnd(line string, i int) int {
+	quote := line[i]
+	for j := i + 1; j < len(line); j++ {
+		switch line[j] {
+		case '\\':
+			if quote != '`' {
+				j++
+			}
+		case quote:
+			return j + 1
+		}
+	}
+	return len(line)
+}
+
+// isKeyAt returns true if a ':' ending the line or followed by a space comes
+// after position i (spaces skipped): "key: value", not "http://"
+func isKeyAt(line string, i int) bool {
+	rest := strings.TrimLeft(line[i:], " \t")
+	return rest == ":" || strings.HasPrefix(rest, ": ") || strings.HasPrefix(rest, ":\t")
+}
+
+// isIdentStart returns true if an identifier starts at i
+func isIdentStart(line string, i int) bool {
+	if i > 0 && isIdentByte(line[i-1]) {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(line[i:])
+	return r == '_' || unicode.IsLetter(r)
+}
+
+// isIdentByte returns true for an ASCII identifier byte
+func isIdentByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// highlightDiff returns the highlighted ranges of the code lines of a unified
+// diff (or of a file preview starting at line from), keyed by line index.
+// Diff lines keep their +/-/space prefix: ranges start after it.
+func highlightDiff(path string, lines []string, from int, isDiff bool) map[int][]syntaxSpan {
+	lang := detectLanguage(path)
+	if lang == nil {
+		return nil
+	}
+	h := &highlighter{lang: lang}
+	result := make(map[int][]syntaxSpan)
+	inHunk := !isDiff
+	for i := from; i < len(lines); i++ {
+		line := lines[i]
+		if !isDiff {
+			if spans := h.spans(line); len(spans) > 0 {
+				result[i] = spans
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+			h.inBlock = false
+			continue
+		case strings.HasPrefix(line, "diff "):
+			inHunk = false
+			continue
+		case !inHunk || line == "" || strings.IndexByte(" +-", line[0]) < 0:
+			continue
+		}
+		spans := h.spans(line[1:])
+		for k := range spans {
+			spans[k].start++
+			spans[k].end++
+		}
+		if len(spans) > 0 {
+			result[i] = spans
+		}
+	}
+	return result
+}
+
+// highlightLine renders a line with its syntax colors over a base style
+func highlightLine(line string, spans []syntaxSpan, base lipgloss.Style) string {
+	return renderDiffLine(line, nil, spans, base, base)
+}
@@ -1,3 +1,5 @@
+// +build !windows
+
 package tui
 
 import (
@@ -9,16 +11,6 @@ import (
 	"github.com/taigrr/bubbleterm/emulator"
 )
 
-// TerminalState represents the state of a terminal session
-type TerminalState int
-
-const (
-	TerminalIdle TerminalState = iota
-	TerminalRunning
-	TerminalWaiting // Claude waiting for input
-	TerminalExited
-)
-
 // Terminal represents an embedded terminal for a Claude session
 type Terminal struct {
 	mu sync.RWMutex
@@ -224,68 +216,6 @@ func (t *Terminal) HandleKey(key string) (consumed bool, exitTerminal bool) {
 	return true, false
 }
 
-// keyToBytes converts a bubbletea key to bytes
-func keyToBytes(key string) []byte {
-	switch key {
-	case "esc":
-		return []byte{0x1b}
-	case "enter":
-		return []byte{'\r'}
-	case "backspace":
-		return []byte{0x7f}
-	case "tab":
-		return []byte{'\t'}
-	case "space":
-		return []byte{' '}
-	case "up":
-		return []byte{0x1b, '[', 'A'}
-	case "down":
-		return []byte{0x1b, '[', 'B'}
-	case "right":
-		return []byte{0x1b, '[', 'C'}
-	case "left":
-		return []byte{0x1b, '[', 'D'}
-	case "home":
-		return []byte{0x1b, '[', 'H'}
-	case "end":
-		return []byte{0x1b, '[', 'F'}
-	case "pgup":
-		return []byte{0x1b, '[', '5', '~'}
-	case "pgdown":
-		return []byte{0x1b, '[', '6', '~'}
-	case "delete":
-		return []byte{0x1b, '[', '3', '~'}
-	case "ctrl+c":
-		return []byte{0x03}
-	case "ctrl+d":
-		return []byte{0x04}
-	case "ctrl+z":
-		return []byte{0x1a}
-	case "ctrl+l":
-		return []byte{0x0c}
-	case "ctrl+a":
-		return []byte{0x01}
-	case "ctrl+e":
-		return []byte{0x05}
-	case "ctrl+k":
-		return []byte{0x0b}
-	case "ctrl+u":
-		return []byte{0x15}
-	case "ctrl+w":
-		return []byte{0x17}
-	default:
-		// Regular characters
-		if len(key) == 1 {
-			return []byte(key)
-		}
-		// Runes (UTF-8)
-		if len(key) > 1 {
-			return []byte(key)
-		}
-	}
-	return nil
-}
-
 // View returns the current terminal view as a string with ANSI colors
 func (t *Terminal) View() string {
 	t.mu.RLock()
@@ -322,44 +252,6 @@ func (t *Terminal) View() string {
 	return strings.Join(result, "\n")
 }
 
-// truncateANSIString truncates a string with ANSI codes to visible width
-func truncateANSIString(s string, maxWidth int) string {
-	if maxWidth <= 0 {
-		return ""
-	}
-
-	var result strings.Builder
-	visibleLen := 0
-	inEscape := false
-
-	for _, r := range s {
-		if r == '\x1b' {
-			inEscape = true
-			result.WriteRune(r)
-			continue
-		}
-
-		if inEscape {
-			result.WriteRune(r)
-			// End of escape sequence
-			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
-				inEscape = false
-			}
-			continue
-		}
-
-		// Visible character
-		if visibleLen >= maxWidth {
-			break
-		}
-		result.WriteRune(r)
-		visibleLen++
-	}
-
-	return result.String()
-}
-
-
 // GetLines returns all lines from the terminal
 func (t *Terminal) GetLines() []string {
 	t.mu.RLock()
@@ -439,23 +331,3 @@ func (t *Terminal) Height() int {
 	defer t.mu.RUnlock()
 	return t.height
 }
-
-// isValidUUID checks if a string is a valid UUID format
-func isValidUUID(s string) bool {
-	// UUID format: xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
-	if len(s) != 36 {
-		return false
-	}
-	for i, c := range s {
-		if i == 8 || i == 13 || i == 18 || i == 23 {
-			if c != '-' {
-				return false
-			}
-		} else {
-			if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')) {
-				return false
-			}
-		}
-	}
-	return true
-}
@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"csd-devtrack/cli/modules/ui/core"
+)
+
+// TerminalBackend identifies the implementation of embedded terminals
+type TerminalBackend string
+
+const (
+	TerminalBackendTmux TerminalBackend = "tmux" // tmux sessions (persistent across restarts)
+	TerminalBackendPTY  TerminalBackend = "pty"  // In-process PTY + vt100 emulator (ConPTY on Windows)
+)
+
+// SelectTerminalBackend chooses the terminal backend from detected capabilities.
+// tmux is preferred (sessions survive DevTrack restarts), the native PTY is used without it.
+func SelectTerminalBackend(caps *core.CapabilitiesVM) TerminalBackend {
+	if caps != nil && !caps.Tmux.Available && caps.PTY.Available {
+		return TerminalBackendPTY
+	}
+	return TerminalBackendTmux
+}
+
+// newClaudeTerminal creates a terminal for a Claude session with the given backend
+func newClaudeTerminal(backend TerminalBackend, sessionID, workDir, claudeProjectDir, claudePath, prefix string) TerminalInterface {
+	if backend == TerminalBackendPTY {
+		return newPTYTerminal(sessionID, workDir, claudeProjectDir, claudePath)
+	}
+	return NewTerminalTmuxWithPrefix(sessionID, workDir, claudeProjectDir, claudePath, prefix)
+}
+
+// newCommandTerminal creates a terminal for a custom command with the given backend
+func newCommandTerminal(backend TerminalBackend, sessionID, command string, args []string, prefix string) TerminalInterface {
+	if backend == TerminalBackendPTY {
+		return newPTYCommandTerminal(sessionID, command, args)
+	}
+	return NewTerminalTmuxCommandWithPrefix(sessionID, command, args, prefix)
+}
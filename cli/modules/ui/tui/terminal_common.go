@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TerminalState represents the state of a terminal session
+type TerminalState int
+
+const (
+	TerminalIdle TerminalState = iota
+	TerminalRunning
+	TerminalWaiting // Claude waiting for input
+	TerminalExited
+)
+
+// keyToBytes converts a bubbletea key to bytes
+func keyToBytes(key string) []byte {
+	switch key {
+	case "esc":
+		return []byte{0x1b}
+	case "enter":
+		return []byte{'\r'}
+	case "backspace":
+		return []byte{0x7f}
+	case "tab":
+		return []byte{'\t'}
+	case "space":
+		return []byte{' '}
+	case "up":
+		return []byte{0x1b, '[', 'A'}
+	case "down":
+		return []byte{0x1b, '[', 'B'}
+	case "right":
+		return []byte{0x1b, '[', 'C'}
+	case "left":
+		return []byte{0x1b, '[', 'D'}
+	case "home":
+		return []byte{0x1b, '[', 'H'}
+	case "end":
+		return []byte{0x1b, '[', 'F'}
+	case "pgup":
+		return []byte{0x1b, '[', '5', '~'}
+	case "pgdown":
+		return []byte{0x1b, '[', '6', '~'}
+	case "delete":
+		return []byte{0x1b, '[', '3', '~'}
+	case "ctrl+c":
+		return []byte{0x03}
+	case "ctrl+d":
+		return []byte{0x04}
+	case "ctrl+z":
+		return []byte{0x1a}
+	case "ctrl+l":
+		return []byte{0x0c}
+	case "ctrl+a":
+		return []byte{0x01}
+	case "ctrl+e":
+		return []byte{0x05}
+	case "ctrl+k":
+		return []byte{0x0b}
+	case "ctrl+u":
+		return []byte{0x15}
+	case "ctrl+w":
+		return []byte{0x17}
+	default:
+		// Regular characters
+		if len(key) == 1 {
+			return []byte(key)
+		}
+		// Runes (UTF-8)
+		if len(key) > 1 {
+			return []byte(key)
+		}
+	}
+	return nil
+}
+
+// Bracketed paste: applications enable it to tell pasted text from typing
+// (a pasted newline does not submit)
+var (
+	bracketedPasteOn  = []byte("\x1b[?2004h")
+	bracketedPasteOff = []byte("\x1b[?2004l")
+)
+
+// bracketedPasteMode returns the bracketed paste mode set by the last
+// sequence enabling or disabling it in an output chunk (current if none)
+func bracketedPasteMode(output []byte, current bool) bool {
+	on := bytes.LastIndex(output, bracketedPasteOn)
+	off := bytes.LastIndex(output, bracketedPasteOff)
+	if on < 0 && off < 0 {
+		return current
+	}
+	return on > off
+}
+
+// pasteBytes returns the input of a paste: line ends sent as Enter (CR) like
+// a terminal does, wrapped in the paste markers if the application enabled
+// bracketed paste
+func pasteBytes(text string, bracketed bool) []byte {
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\r"), "\n", "\r")
+	if !bracketed {
+		return []byte(text)
+	}
+	return []byte("\x1b[200~" + text + "\x1b[201~")
+}
+
+// truncateANSIString truncates a string with ANSI codes to visible width
+func truncateANSIString(s string, maxWidth int) string {
+	if maxWidth <= 0 {
+		return ""
+	}
+
+	var result strings.Builder
+	visibleLen := 0
+	inEscape := false
+
+	for _, r := range s {
+		if r == '\x1b' {
+			inEscape = true
+			result.WriteRune(r)
+			continue
+		}
+
+		if inEscape {
+			result.WriteRune(r)
+			// End of escape sequence
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				inEscape = false
+			}
+			continue
+		}
+
+		// Visible character
+		if visibleLen >= maxWidth {
+			break
+		}
+		result.WriteRune(r)
+		visibleLen++
+	}
+
+	return result.String()
+}
+
+// isValidUUID checks if a string is a valid UUID format
+func isValidUUID(s string) bool {
+	// UUID format: xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+	if len(s) != 36 {
+		return false
+	}
+	for i, c := range s {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if c != '-' {
+				return false
+			}
+		} else {
+			if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// getClaudeSessionFile returns the path to the Claude session file
+func getClaudeSessionFile(claudeProjectDir, sessionID string) string {
+	// Claude stores sessions in ~/.claude/projects/<encoded-path>/<session-id>.jsonl
+	// claudeProjectDir is already in the correct format (e.g., -data-devel-infra-csd-devtrack)
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".claude", "projects", claudeProjectDir, sessionID+".jsonl")
+}
+
+// claudeResumeArgs returns the Claude arguments to resume a session.
+// Only use --resume if the session file exists and has content,
+// otherwise start fresh (Claude will use the workDir).
+func claudeResumeArgs(claudeProjectDir, sessionID string) []string {
+	if sessionID == "" || !isValidUUID(sessionID) || claudeProjectDir == "" {
+		return nil
+	}
+	sessionFile := getClaudeSessionFile(claudeProjectDir, sessionID)
+	if info, err := os.Stat(sessionFile); err == nil && info.Size() > 0 {
+		return []string{"--resume", sessionID}
+	}
+	return nil
+}
@@ -0,0 +1,475 @@
+// +build windows
+
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf16"
+	"unsafe"
+
+	"github.com/vito/vt100"
+	"golang.org/x/sys/windows"
+)
+
+// TerminalConPTY represents a terminal using a Windows pseudo console (ConPTY).
+// It replaces tmux on Windows: output is parsed with vito/vt100 and the session
+// lives as long as DevTrack (no detach/reattach).
+type TerminalConPTY struct {
+	mu sync.RWMutex
+
+	// Session info
+	SessionID        string
+	WorkDir          string
+	ClaudeProjectDir string // Original Claude project directory
+	ClaudePath       string
+
+	// Custom command support (for non-Claude terminals like psql)
+	customCmd  string   // Custom command to run (empty = use Claude)
+	customArgs []string // Arguments for custom command
+
+	// vt100 screen
+	vt *vt100.VT100
+
+	// Pseudo console and process
+	console windows.Handle
+	process windows.Handle
+	input   *os.File // Write end of the console input pipe
+	output  *os.File // Read end of the console output pipe
+
+	// Terminal state
+	width        int
+	height       int
+	state        TerminalState
+	pendingStart bool   // true if Start() was called but console not yet created
+	startSession string // session ID to resume when actually starting
+
+	// ESC ESC detection
+	lastEscTime time.Time
+
+	// Bracketed paste enabled by the application
+	bracketedPaste bool
+
+	// Callbacks
+	onOutput func()
+	onExit   func()
+}
+
+// NewTerminalConPTY creates a new ConPTY-based terminal for Claude
+func NewTerminalConPTY(sessionID, workDir, claudeProjectDir, claudePath string) *TerminalConPTY {
+	return &TerminalConPTY{
+		SessionID:        sessionID,
+		WorkDir:          workDir,
+		ClaudeProjectDir: claudeProjectDir,
+		ClaudePath:       claudePath,
+		width:            80,
+		height:           24,
+		state:            TerminalIdle,
+	}
+}
+
+// NewTerminalConPTYCommand creates a new ConPTY-based terminal with a custom command
+func NewTerminalConPTYCommand(sessionID, command string, args []string) *TerminalConPTY {
+	return &TerminalConPTY{
+		SessionID:  sessionID,
+		customCmd:  command,
+		customArgs: args,
+		width:      80,
+		height:     24,
+		state:      TerminalIdle,
+	}
+}
+
+// SetSize sets the terminal size
+func (t *TerminalConPTY) SetSize(width, height int) {
+	t.mu.Lock()
+
+	if width < 10 {
+		width = 10
+	}
+	if height < 5 {
+		height = 5
+	}
+
+	if t.width == width && t.height == height {
+		t.mu.Unlock()
+		return
+	}
+
+	t.width = width
+	t.height = height
+
+	// If we have a pending start and now have real dimensions, start the console
+	if t.pendingStart && (width != 80 || height != 24) {
+		sessionID := t.startSession
+		t.mu.Unlock()
+		t.doStart(sessionID)
+		return
+	}
+
+	if t.vt != nil {
+		t.vt.Resize(height, width)
+	}
+	if t.console != 0 {
+		windows.ResizePseudoConsole(t.console, windows.Coord{X: int16(width), Y: int16(height)})
+	}
+	t.mu.Unlock()
+}
+
+// Start starts the process in a pseudo console
+func (t *TerminalConPTY) Start(sessionID string) error {
+	t.mu.Lock()
+
+	if t.state == TerminalRunning {
+		t.mu.Unlock()
+		return nil
+	}
+
+	// If dimensions are still default (80x24), defer actual start until SetSize is called
+	if t.width == 80 && t.height == 24 {
+		t.pendingStart = true
+		t.startSession = sessionID
+		t.mu.Unlock()
+		return nil
+	}
+
+	t.mu.Unlock()
+	return t.doStart(sessionID)
+}
+
+// doStart creates the pseudo console and starts the process
+// Note: caller must NOT hold the lock
+func (t *TerminalConPTY) doStart(sessionID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state == TerminalRunning {
+		return nil
+	}
+	t.pendingStart = false
+
+	var argv []string
+	if t.customCmd != "" {
+		argv = append([]string{t.customCmd}, t.customArgs...)
+	} else {
+		argv = append([]string{t.ClaudePath}, claudeResumeArgs(t.ClaudeProjectDir, sessionID)...)
+	}
+
+	commandLine, err := conptyCommandLine(argv)
+	if err != nil {
+		return err
+	}
+
+	// Pipes: console reads input from inRead and writes output to outWrite
+	var inRead, inWrite, outRead, outWrite windows.Handle
+	if err := windows.CreatePipe(&inRead, &inWrite, nil, 0); err != nil {
+		return fmt.Errorf("failed to create input pipe: %w", err)
+	}
+	if err := windows.CreatePipe(&outRead, &outWrite, nil, 0); err != nil {
+		windows.CloseHandle(inRead)
+		windows.CloseHandle(inWrite)
+		return fmt.Errorf("failed to create output pipe: %w", err)
+	}
+
+	var console windows.Handle
+	size := windows.Coord{X: int16(t.width), Y: int16(t.height)}
+	err = windows.CreatePseudoConsole(size, inRead, outWrite, 0, &console)
+	// The console duplicates its ends of the pipes
+	windows.CloseHandle(inRead)
+	windows.CloseHandle(outWrite)
+	if err != nil {
+		windows.CloseHandle(inWrite)
+		windows.CloseHandle(outRead)
+		return fmt.Errorf("failed to create pseudo console: %w", err)
+	}
+
+	process, err := startConPTYProcess(console, commandLine, t.WorkDir)
+	if err != nil {
+		windows.ClosePseudoConsole(console)
+		windows.CloseHandle(inWrite)
+		windows.CloseHandle(outRead)
+		return err
+	}
+
+	t.vt = vt100.NewVT100(t.height, t.width)
+	t.bracketedPaste = false
+	t.console = console
+	t.process = process
+	t.input = os.NewFile(uintptr(inWrite), "conpty-in")
+	t.output = os.NewFile(uintptr(outRead), "conpty-out")
+	t.state = TerminalRunning
+
+	// Read loop
+	go t.readLoop(t.output)
+
+	// Wait for process exit
+	go t.waitLoop(process)
+
+	return nil
+}
+
+// conptyCommandLine builds the command line of a ConPTY process.
+// The executable is resolved with PATH/PATHEXT; batch files need cmd.exe.
+func conptyCommandLine(argv []string) (string, error) {
+	path, err := exec.LookPath(argv[0])
+	if err != nil {
+		return "", fmt.Errorf("%s not found: %w", argv[0], err)
+	}
+	argv = append([]string{path}, argv[1:]...)
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".cmd", ".bat":
+		argv = append([]string{"cmd.exe", "/c"}, argv...)
+	}
+	return windows.ComposeCommandLine(argv), nil
+}
+
+// startConPTYProcess starts a process attached to a pseudo console
+func startConPTYProcess(console windows.Handle, commandLine, workDir string) (windows.Handle, error) {
+	attrs, err := windows.NewProcThreadAttributeList(1)
+	if err != nil {
+		return 0, err
+	}
+	defer attrs.Delete()
+
+	// The attribute value is the console handle itself, not a pointer to it
+	if err := attrs.Update(windows.PROC_THREAD_ATTRIBUTE_PSEUDOCONSOLE,
+		*(*unsafe.Pointer)(unsafe.Pointer(&console)), unsafe.Sizeof(console)); err != nil {
+		return 0, err
+	}
+
+	si := windows.StartupInfoEx{ProcThreadAttributeList: attrs.List()}
+	si.Cb = uint32(unsafe.Sizeof(si))
+
+	cmdLine, err := windows.UTF16PtrFromString(commandLine)
+	if err != nil {
+		return 0, err
+	}
+	var dir *uint16
+	if workDir != "" {
+		if dir, err = windows.UTF16PtrFromString(workDir); err != nil {
+			return 0, err
+		}
+	}
+	env := conptyEnvironment()
+
+	var pi windows.ProcessInformation
+	flags := uint32(windows.EXTENDED_STARTUPINFO_PRESENT | windows.CREATE_UNICODE_ENVIRONMENT)
+	if err := windows.CreateProcess(nil, cmdLine, nil, nil, false, flags, &env[0], dir, &si.StartupInfo, &pi); err != nil {
+		return 0, fmt.Errorf("failed to start process: %w", err)
+	}
+	windows.CloseHandle(pi.Thread)
+	return pi.Process, nil
+}
+
+// conptyEnvironment returns the UTF-16 environment block of terminal processes
+func conptyEnvironment() []uint16 {
+	env := append(os.Environ(),
+		"TERM=xterm-256color",
+		"COLORTERM=truecolor",
+		"FORCE_COLOR=1",
+		"CLICOLOR_FORCE=1",
+	)
+
+	var block []uint16
+	for _, kv := range env {
+		block = append(block, utf16.Encode([]rune(kv))...)
+		block = append(block, 0)
+	}
+	return append(block, 0)
+}
+
+// readLoop reads from the console output and updates vt100
+func (t *TerminalConPTY) readLoop(output *os.File) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := output.Read(buf)
+		if n > 0 {
+			t.mu.Lock()
+			if t.vt != nil {
+				t.vt.Write(buf[:n])
+			}
+			t.bracketedPaste = bracketedPasteMode(buf[:n], t.bracketedPaste)
+			onOutput := t.onOutput
+			t.mu.Unlock()
+
+			if onOutput != nil {
+				onOutput()
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// waitLoop waits for process exit and releases the console
+func (t *TerminalConPTY) waitLoop(process windows.Handle) {
+	windows.WaitForSingleObject(process, windows.INFINITE)
+
+	t.mu.Lock()
+	t.state = TerminalExited
+	console, input, output := t.console, t.input, t.output
+	t.console, t.input, t.output = 0, nil, nil
+	t.process = 0
+	onExit := t.onExit
+	t.mu.Unlock()
+
+	// Close outside the lock: ClosePseudoConsole waits for readLoop to drain the output
+	windows.ClosePseudoConsole(console)
+	input.Close()
+	output.Close()
+	windows.CloseHandle(process)
+
+	if onExit != nil {
+		onExit()
+	}
+}
+
+// Write sends input to the terminal
+func (t *TerminalConPTY) Write(data []byte) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.input == nil || t.state != TerminalRunning {
+		return nil
+	}
+
+	_, err := t.input.Write(data)
+	return err
+}
+
+// Paste sends pasted text to the terminal as a single paste
+func (t *TerminalConPTY) Paste(text string) error {
+	t.mu.RLock()
+	bracketed := t.bracketedPaste
+	t.mu.RUnlock()
+	return t.Write(pasteBytes(text, bracketed))
+}
+
+// WriteString sends a string to the terminal
+func (t *TerminalConPTY) WriteString(s string) error {
+	return t.Write([]byte(s))
+}
+
+// HandleKey processes a key press
+func (t *TerminalConPTY) HandleKey(key string) (consumed bool, exitTerminal bool) {
+	// Check for ESC ESC
+	if key == "esc" {
+		now := time.Now()
+		if now.Sub(t.lastEscTime) < 300*time.Millisecond {
+			t.lastEscTime = time.Time{}
+			return true, true
+		}
+		t.lastEscTime = now
+		go func() {
+			time.Sleep(350 * time.Millisecond)
+			t.mu.RLock()
+			lastEsc := t.lastEscTime
+			t.mu.RUnlock()
+			if !lastEsc.IsZero() && time.Since(lastEsc) >= 300*time.Millisecond {
+				t.Write([]byte{0x1b})
+				t.mu.Lock()
+				t.lastEscTime = time.Time{}
+				t.mu.Unlock()
+			}
+		}()
+		return true, false
+	}
+
+	if !t.lastEscTime.IsZero() {
+		t.Write([]byte{0x1b})
+		t.lastEscTime = time.Time{}
+	}
+
+	data := keyToBytes(key)
+	if data != nil {
+		t.Write(data)
+	}
+
+	return true, false
+}
+
+// View returns the terminal view as a string with ANSI colors
+func (t *TerminalConPTY) View() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.vt == nil {
+		return "[No terminal initialized]"
+	}
+
+	return renderVT100(t.vt)
+}
+
+// State returns the current terminal state
+func (t *TerminalConPTY) State() TerminalState {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.state
+}
+
+// IsRunning returns true if the terminal is running
+func (t *TerminalConPTY) IsRunning() bool {
+	return t.State() == TerminalRunning
+}
+
+// Stop stops the terminal process
+func (t *TerminalConPTY) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// waitLoop releases the console once the process is gone
+	if t.process != 0 {
+		windows.TerminateProcess(t.process, 1)
+	}
+	t.pendingStart = false
+	t.state = TerminalExited
+}
+
+// SetCallbacks sets the callback functions
+func (t *TerminalConPTY) SetCallbacks(onOutput, onExit func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onOutput = onOutput
+	t.onExit = onExit
+}
+
+// LineCount returns the total number of lines
+func (t *TerminalConPTY) LineCount() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.vt != nil {
+		return t.vt.UsedHeight()
+	}
+	return t.height
+}
+
+// Width returns the terminal width
+func (t *TerminalConPTY) Width() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.width
+}
+
+// Height returns the terminal height
+func (t *TerminalConPTY) Height() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.height
+}
+
+// GetLines returns all lines from the terminal
+func (t *TerminalConPTY) GetLines() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.vt == nil {
+		return nil
+	}
+	return vt100Lines(t.vt)
+}
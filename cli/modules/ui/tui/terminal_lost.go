@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleLostTerminals reports the terminals whose tmux session disappeared
+// (server crash, session killed from outside) once, and leaves terminal mode
+// if the shown one is among them: its keys would go nowhere
+func (m *Model) handleLostTerminals() {
+	if m.terminalManager == nil {
+		return
+	}
+	lost := m.terminalManager.TakeLost()
+	if len(lost) == 0 {
+		return
+	}
+
+	shown := m.lostTerminalShown()
+	reason := ""
+	for _, sessionID := range lost {
+		if sessionID == shown {
+			m.terminalMode = false
+			m.commandMode = false
+		}
+		if reason == "" {
+			reason = m.terminalManager.LostReason(sessionID)
+		}
+	}
+
+	msg := fmt.Sprintf("Terminal session lost (%s), Enter to recreate it", reason)
+	if len(lost) > 1 {
+		msg = fmt.Sprintf("%d terminal sessions lost (%s), Enter to recreate them", len(lost), reason)
+	}
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventError, msg))
+	m.refreshSessionActivity()
+}
+
+// lostTerminalShown returns the session of the terminal view's active
+// terminal if its tmux session was lost
+func (m *Model) lostTerminalShown() string {
+	if m.terminalManager == nil {
+		return ""
+	}
+	if _, ok := m.controller().(terminalView); !ok {
+		return ""
+	}
+	sessionID := m.activeTerminalSession()
+	if sessionID == "" || m.terminalManager.LostReason(sessionID) == "" {
+		return ""
+	}
+	return sessionID
+}
+
+// lostTerminalMessage returns the placeholder of a terminal whose tmux
+// session was lost, empty if it was not
+func (m *Model) lostTerminalMessage(sessionID string) string {
+	if m.terminalManager == nil || sessionID == "" {
+		return ""
+	}
+	reason := m.terminalManager.LostReason(sessionID)
+	if reason == "" {
+		return ""
+	}
+	return fmt.Sprintf("Session lost: %s\n\nPress Enter to recreate it", reason)
+}
+
+// recreateLostTerminal starts a new tmux session for a lost terminal, with
+// the same command (a Claude conversation is resumed)
+func (m *Model) recreateLostTerminal(sessionID string) tea.Cmd {
+	if m.terminalManager == nil {
+		return nil
+	}
+	if _, isSSH := m.shellView().sshSessions[sessionID]; isSSH {
+		return m.reconnectSSHSession(sessionID)
+	}
+	t := m.terminalManager.Get(sessionID)
+	if t == nil {
+		return nil
+	}
+
+	if err := t.Start(sessionID); err != nil {
+		m.lastError = "Failed to recreate the terminal: " + err.Error()
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	m.focusArea = FocusMain
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess, "Terminal session recreated"))
+	m.refreshSessionActivity()
+	return m.scheduleTerminalRefresh()
+}
@@ -2,9 +2,13 @@ package tui
 
 import (
 	"sync"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/logger"
 )
 
 // TerminalInterface defines the interface for terminal implementations
+// (tmux sessions, native PTY, ConPTY on Windows)
 type TerminalInterface interface {
 	SetSize(width, height int)
 	Start(sessionID string) error
@@ -27,6 +31,19 @@ type TerminalManager struct {
 	mu         sync.RWMutex
 	terminals  map[string]TerminalInterface // sessionID -> Terminal
 	claudePath string
+	backend    TerminalBackend // Backend of new terminals (existing ones keep theirs)
+	output     chan struct{}   // Signaled when any terminal has new output (coalesced)
+	recordDir  string          // Auto-record directory of AI terminals (empty = off)
+
+	activityMu sync.Mutex
+	lastOutput map[string]time.Time // sessionID -> time of last output
+	newlyLost  []string             // Sessions lost since the last TakeLost
+}
+
+// lostTerminal is implemented by terminals whose session can disappear
+// under them (a tmux server crash or a session killed from outside)
+type lostTerminal interface {
+	LostReason() string
 }
 
 // NewTerminalManager creates a new terminal manager
@@ -34,9 +51,57 @@ func NewTerminalManager(claudePath string) *TerminalManager {
 	return &TerminalManager{
 		terminals:  make(map[string]TerminalInterface),
 		claudePath: claudePath,
+		backend:    TerminalBackendTmux,
+		output:     make(chan struct{}, 1),
+		lastOutput: make(map[string]time.Time),
 	}
 }
 
+// Output returns a channel signaled when a terminal has new output or exits.
+// Signals are coalesced: one pending signal covers any number of outputs.
+func (tm *TerminalManager) Output() <-chan struct{} {
+	return tm.output
+}
+
+// signalOutput notifies that a terminal has new output (never blocks)
+func (tm *TerminalManager) signalOutput() {
+	select {
+	case tm.output <- struct{}{}:
+	default:
+	}
+}
+
+// track registers a new terminal and subscribes to its output
+func (tm *TerminalManager) track(sessionID string, t TerminalInterface) {
+	onOutput := func() {
+		tm.activityMu.Lock()
+		tm.lastOutput[sessionID] = time.Now()
+		tm.activityMu.Unlock()
+		tm.signalOutput()
+	}
+	onExit := func() {
+		if lt, ok := t.(lostTerminal); ok && lt.LostReason() != "" {
+			logger.Warn("Terminal %s lost: %s", sessionID, lt.LostReason())
+			tm.activityMu.Lock()
+			tm.newlyLost = append(tm.newlyLost, sessionID)
+			tm.activityMu.Unlock()
+		} else {
+			logger.Info("Terminal %s exited", sessionID)
+		}
+		tm.signalOutput()
+	}
+	t.SetCallbacks(onOutput, onExit)
+	tm.terminals[sessionID] = t
+	logger.Debug("Terminal %s created (%s backend)", sessionID, tm.backend)
+}
+
+// LastOutput returns when a terminal last produced output (zero if never)
+func (tm *TerminalManager) LastOutput(sessionID string) time.Time {
+	tm.activityMu.Lock()
+	defer tm.activityMu.Unlock()
+	return tm.lastOutput[sessionID]
+}
+
 // GetOrCreate gets an existing terminal or creates a new one (for Claude)
 func (tm *TerminalManager) GetOrCreate(sessionID, workDir, claudeProjectDir string) TerminalInterface {
 	return tm.GetOrCreateWithPrefix(sessionID, workDir, claudeProjectDir, TmuxPrefixClaude)
@@ -51,9 +116,11 @@ func (tm *TerminalManager) GetOrCreateWithPrefix(sessionID, workDir, claudeProje
 		return t
 	}
 
-	// Use tmux-based terminal (persistent, captures ANSI colors with capture-pane -e)
-	t := NewTerminalTmuxWithPrefix(sessionID, workDir, claudeProjectDir, tm.claudePath, prefix)
-	tm.terminals[sessionID] = t
+	t := newClaudeTerminal(tm.backend, sessionID, workDir, claudeProjectDir, tm.claudePath, prefix)
+	if tt, ok := t.(*TerminalTmux); ok && tm.recordDir != "" && (prefix == TmuxPrefixClaude || prefix == TmuxPrefixCodex) {
+		tt.SetAutoRecord(tm.recordDir)
+	}
+	tm.track(sessionID, t)
 	return t
 }
 
@@ -71,9 +138,9 @@ func (tm *TerminalManager) GetOrCreateCommandWithPrefix(sessionID, command strin
 		return t
 	}
 
-	// Use generic tmux-based terminal with custom command
-	t := NewTerminalTmuxCommandWithPrefix(sessionID, command, args, prefix)
-	tm.terminals[sessionID] = t
+	// Use generic terminal with custom command
+	t := newCommandTerminal(tm.backend, sessionID, command, args, prefix)
+	tm.track(sessionID, t)
 	return t
 }
 
@@ -84,6 +151,43 @@ func (tm *TerminalManager) Get(sessionID string) TerminalInterface {
 	return tm.terminals[sessionID]
 }
 
+// LostReason returns why the session of a terminal was lost, empty if the
+// terminal is running, ended normally or does not exist
+func (tm *TerminalManager) LostReason(sessionID string) string {
+	t := tm.Get(sessionID)
+	if t == nil || t.IsRunning() {
+		return ""
+	}
+	if lt, ok := t.(lostTerminal); ok {
+		return lt.LostReason()
+	}
+	return ""
+}
+
+// GetLost returns the IDs of the terminals whose session was lost
+func (tm *TerminalManager) GetLost() []string {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	var lost []string
+	for id, t := range tm.terminals {
+		if lt, ok := t.(lostTerminal); ok && !t.IsRunning() && lt.LostReason() != "" {
+			lost = append(lost, id)
+		}
+	}
+	return lost
+}
+
+// TakeLost returns the terminals lost since the last call (each loss is
+// reported once)
+func (tm *TerminalManager) TakeLost() []string {
+	tm.activityMu.Lock()
+	defer tm.activityMu.Unlock()
+	lost := tm.newlyLost
+	tm.newlyLost = nil
+	return lost
+}
+
 // Remove removes a terminal
 func (tm *TerminalManager) Remove(sessionID string) {
 	tm.mu.Lock()
@@ -92,7 +196,35 @@ func (tm *TerminalManager) Remove(sessionID string) {
 	if t, exists := tm.terminals[sessionID]; exists {
 		t.Stop()
 		delete(tm.terminals, sessionID)
+		logger.Debug("Terminal %s closed", sessionID)
 	}
+
+	tm.activityMu.Lock()
+	delete(tm.lastOutput, sessionID)
+	tm.activityMu.Unlock()
+}
+
+// Suspend removes an idle terminal: a tmux one is detached (its session keeps
+// running) unless stop is set, other backends are stopped
+func (tm *TerminalManager) Suspend(sessionID string, stop bool) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	t, exists := tm.terminals[sessionID]
+	if !exists {
+		return
+	}
+	if tmuxTerminal, ok := t.(*TerminalTmux); ok && !stop {
+		tmuxTerminal.Detach()
+	} else {
+		t.Stop()
+	}
+	delete(tm.terminals, sessionID)
+	logger.Info("Terminal %s suspended (stopped: %v)", sessionID, stop)
+
+	tm.activityMu.Lock()
+	delete(tm.lastOutput, sessionID)
+	tm.activityMu.Unlock()
 }
 
 // GetRunning returns all running terminal session IDs
@@ -117,7 +249,14 @@ func (tm *TerminalManager) StopAll() {
 	for _, t := range tm.terminals {
 		t.Stop()
 	}
+	if len(tm.terminals) > 0 {
+		logger.Debug("Stopped %d terminals", len(tm.terminals))
+	}
 	tm.terminals = make(map[string]TerminalInterface)
+
+	tm.activityMu.Lock()
+	tm.lastOutput = make(map[string]time.Time)
+	tm.activityMu.Unlock()
 }
 
 // SetClaudePath updates the claude path for new terminals
@@ -127,6 +266,28 @@ func (tm *TerminalManager) SetClaudePath(path string) {
 	tm.claudePath = path
 }
 
+// SetBackend sets the backend used for new terminals
+func (tm *TerminalManager) SetBackend(backend TerminalBackend) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.backend = backend
+}
+
+// SetAutoRecord records the new Claude and Codex terminals to dir (empty =
+// off; tmux backend only)
+func (tm *TerminalManager) SetAutoRecord(dir string) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.recordDir = dir
+}
+
+// Backend returns the backend used for new terminals
+func (tm *TerminalManager) Backend() TerminalBackend {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.backend
+}
+
 // Count returns the number of terminals
 func (tm *TerminalManager) Count() int {
 	tm.mu.RLock()
@@ -0,0 +1,16 @@
+// +build !windows
+
+package tui
+
+// defaultShellPath is the shell used when no shell was detected
+const defaultShellPath = "/bin/bash"
+
+// newPTYTerminal creates a native PTY terminal for Claude (creack/pty + vt100)
+func newPTYTerminal(sessionID, workDir, claudeProjectDir, claudePath string) TerminalInterface {
+	return NewTerminalVT100WithProject(sessionID, workDir, claudeProjectDir, claudePath)
+}
+
+// newPTYCommandTerminal creates a native PTY terminal with a custom command
+func newPTYCommandTerminal(sessionID, command string, args []string) TerminalInterface {
+	return NewTerminalVT100Command(sessionID, command, args)
+}
@@ -0,0 +1,16 @@
+// +build windows
+
+package tui
+
+// defaultShellPath is the shell used when no shell was detected
+const defaultShellPath = "cmd.exe"
+
+// newPTYTerminal creates a ConPTY terminal for Claude
+func newPTYTerminal(sessionID, workDir, claudeProjectDir, claudePath string) TerminalInterface {
+	return NewTerminalConPTY(sessionID, workDir, claudeProjectDir, claudePath)
+}
+
+// newPTYCommandTerminal creates a ConPTY terminal with a custom command
+func newPTYCommandTerminal(sessionID, command string, args []string) TerminalInterface {
+	return NewTerminalConPTYCommand(sessionID, command, args)
+}
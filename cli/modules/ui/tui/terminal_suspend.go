@@ -0,0 +1,219 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/logger"
+	"csd-devtrack/cli/modules/platform/terminal"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// suspendCheckInterval is the interval of the idle terminals check
+const suspendCheckInterval = 30 * time.Second
+
+// tmuxSessionsMsg carries the names of the running tmux sessions
+type tmuxSessionsMsg struct {
+	names map[string]bool
+}
+
+// checkIdleTerminals suspends the terminals idle for longer than configured,
+// then lists the tmux sessions to forget the suspended ones that ended
+func (m *Model) checkIdleTerminals() tea.Cmd {
+	if m.suspended == nil || m.terminalManager == nil || time.Since(m.suspendCheckTime) < suspendCheckInterval {
+		return nil
+	}
+	m.suspendCheckTime = time.Now()
+	m.suspendIdleTerminals()
+
+	if len(m.suspended.List()) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		output, _ := exec.Command("tmux", "ls", "-F", "#{session_name}").Output()
+		names := make(map[string]bool)
+		for _, name := range strings.Fields(string(output)) {
+			names[name] = true
+		}
+		return tmuxSessionsMsg{names: names}
+	}
+}
+
+// suspendIdleTerminals detaches (or stops) the tmux terminals without output
+// for the configured time, except the one shown, popped out or in copy mode
+func (m *Model) suspendIdleTerminals() {
+	cfg := config.DefaultSuspendConfig()
+	if global := config.GetGlobal(); global != nil && global.Settings != nil {
+		cfg = global.Settings.GetSuspendConfig()
+	}
+	if cfg.IdleMinutes <= 0 {
+		return
+	}
+	idleAfter := time.Duration(cfg.IdleMinutes) * time.Minute
+
+	shown := m.activeTerminalSession()
+	count := 0
+	for _, sessionID := range m.terminalManager.GetRunning() {
+		t, ok := m.terminalManager.Get(sessionID).(*TerminalTmux)
+		if !ok || sessionID == shown || (m.copyMode != nil && m.copyMode.terminal == t) {
+			continue
+		}
+		if _, external := m.externalSessions[sessionID]; external {
+			continue
+		}
+		last := m.terminalManager.LastOutput(sessionID)
+		if last.IsZero() || time.Since(last) < idleAfter {
+			continue
+		}
+
+		// Claude and Codex sessions resume their conversation once stopped,
+		// shells and database clients would lose their state
+		prefix := terminal.PrefixOf(t.tmuxName)
+		stop := cfg.Action == config.SuspendStop && (prefix == TmuxPrefixClaude || prefix == TmuxPrefixCodex)
+		sess := terminal.SuspendedSession{
+			ID:          sessionID,
+			Label:       m.shellView().sshSessions[sessionID],
+			TmuxName:    t.tmuxName,
+			Command:     t.customCmd,
+			Args:        t.customArgs,
+			WorkDir:     t.WorkDir,
+			Stopped:     stop,
+			SuspendedAt: time.Now(),
+		}
+		if err := m.suspended.Add(sess); err != nil {
+			logger.Warn("Terminal %s not suspended: %v", sessionID, err)
+			continue
+		}
+		m.terminalManager.Suspend(sessionID, stop)
+		count++
+	}
+
+	if count > 0 {
+		m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventInfo,
+			fmt.Sprintf("Suspended %d idle terminal(s), open them to resume", count)))
+		m.refreshSessionActivity()
+	}
+}
+
+// handleTmuxSessions forgets the suspended terminals resumed since, or whose
+// detached tmux session ended
+func (m *Model) handleTmuxSessions(msg tmuxSessionsMsg) {
+	var gone []string
+	for _, sess := range m.suspended.List() {
+		if t := m.terminalManager.Get(sess.ID); t != nil && t.IsRunning() {
+			gone = append(gone, sess.ID)
+		} else if !sess.Stopped && !msg.names[sess.TmuxName] {
+			gone = append(gone, sess.ID)
+			delete(m.shellView().sshSessions, sess.ID)
+		} else if sess.Stopped && !m.claudeSessionExists(sess.ID) {
+			gone = append(gone, sess.ID)
+		}
+	}
+	if len(gone) == 0 {
+		return
+	}
+	if err := m.suspended.Remove(gone...); err != nil {
+		logger.Warn("Failed to save the suspended terminals: %v", err)
+	}
+	m.refreshSessionActivity()
+}
+
+// claudeSessionExists returns false if a Claude session is no longer listed
+// (true for other terminals and while the sessions are not loaded)
+func (m *Model) claudeSessionExists(sessionID string) bool {
+	if !isValidUUID(sessionID) || m.state.Claude == nil || len(m.state.Claude.Sessions) == 0 {
+		return true
+	}
+	for _, sess := range m.state.Claude.Sessions {
+		if sess.ID == sessionID {
+			return true
+		}
+	}
+	return false
+}
+
+// isSuspended returns true if a terminal is suspended and not resumed yet
+func (m *Model) isSuspended(sessionID string) bool {
+	if m.suspended == nil {
+		return false
+	}
+	if _, ok := m.suspended.Get(sessionID); !ok {
+		return false
+	}
+	t := m.terminalManager.Get(sessionID)
+	return t == nil || !t.IsRunning()
+}
+
+// resumeShellTerminal reattaches a suspended shell or SSH terminal and
+// switches to it (Claude and database terminals resume when opened)
+func (m *Model) resumeShellTerminal(sessionID string) tea.Cmd {
+	sess, ok := m.suspended.Get(sessionID)
+	if !ok {
+		return nil
+	}
+
+	// The detached tmux session is reattached (a new one started if it ended)
+	t := m.terminalManager.GetOrCreateCommandWithPrefix(sessionID, sess.Command, sess.Args, terminal.PrefixOf(sess.TmuxName))
+	if err := t.Start(sessionID); err != nil {
+		m.lastError = fmt.Sprintf("Failed to resume terminal: %v", err)
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	if err := m.suspended.Remove(sessionID); err != nil {
+		logger.Warn("Failed to save the suspended terminals: %v", err)
+	}
+
+	m.shellView().activeSession = sessionID
+	m.focusArea = FocusMain
+	m.state.SetHeaderEvent(core.NewHeaderEvent(core.HeaderEventSuccess, "Terminal resumed"))
+	m.updateShellTree()
+	return m.scheduleTerminalRefresh()
+}
+
+// suspendedShellSessions returns the IDs of the suspended shell terminals
+func (m *Model) suspendedShellSessions() []string {
+	if m.suspended == nil {
+		return nil
+	}
+	var ids []string
+	for _, sess := range m.suspended.List() {
+		if !sess.Stopped && strings.HasPrefix(sess.ID, "shell-") && m.isSuspended(sess.ID) {
+			ids = append(ids, sess.ID)
+		}
+	}
+	return ids
+}
+
+// restoreSuspendedSSH lists the suspended SSH sessions of a previous run
+// under their host again
+func (m *Model) restoreSuspendedSSH() {
+	for _, sess := range m.suspended.List() {
+		if sess.Label == "" || !strings.HasPrefix(sess.ID, "shell-ssh-") {
+			continue
+		}
+		if m.shellView().sshSessions == nil {
+			m.shellView().sshSessions = make(map[string]string)
+		}
+		m.shellView().sshSessions[sess.ID] = sess.Label
+	}
+}
+
+// suspendedTmuxSessions returns the detached tmux sessions of the suspended
+// terminals (not reported as orphans)
+func (m *Model) suspendedTmuxSessions() map[string]bool {
+	keep := make(map[string]bool)
+	if m.suspended == nil {
+		return keep
+	}
+	for _, sess := range m.suspended.List() {
+		if !sess.Stopped {
+			keep[sess.TmuxName] = true
+		}
+	}
+	return keep
+}
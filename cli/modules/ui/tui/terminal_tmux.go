@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"csd-devtrack/cli/modules/platform/logger"
+	"csd-devtrack/cli/modules/platform/recording"
 	"csd-devtrack/cli/modules/platform/terminal"
 )
 
@@ -38,6 +41,18 @@ type TerminalTmux struct {
 	scrollOffset int // 0 = at bottom, positive = scrolled up
 	totalLines   int
 
+	// Capture rate
+	idleCaptures int // Consecutive captures without change (slows down capture)
+
+	// Set when the tmux session disappeared while its command was running
+	// (server crashed, session killed from outside)
+	lostReason string
+
+	// Recording (tmux pipe-pane)
+	recording     bool
+	recordingPath string // Empty if started before a DevTrack restart
+	autoRecordDir string // Records the session when it starts (empty = off)
+
 	// Callbacks
 	onOutput func()
 	onExit   func()
@@ -158,13 +173,25 @@ func (t *TerminalTmux) Start(sessionID string) error {
 		// Session exists, reuse it - batch: set-option + resize-window
 		exec.Command("tmux",
 			"set-option", "-t", t.tmuxName, "window-size", "manual", ";",
+			"set-window-option", "-t", t.tmuxName, "remain-on-exit", "on", ";",
 			"resize-window", "-t", t.tmuxName, "-x", fmt.Sprintf("%d", t.width), "-y", fmt.Sprintf("%d", t.height),
 		).Run()
 		t.state = TerminalRunning
+		t.lostReason = ""
 		t.stopCh = make(chan struct{})
+		stopCh := t.stopCh
 		t.mu.Unlock()
-		go t.captureLoop()
-		go t.monitorLoop()
+		// A recording survives DevTrack restarts with its session
+		pipeOut, _ := exec.Command("tmux", "display-message", "-t", t.tmuxName, "-p", "#{pane_pipe}").Output()
+		if strings.TrimSpace(string(pipeOut)) == "1" {
+			t.mu.Lock()
+			t.recording = true
+			t.mu.Unlock()
+		} else {
+			t.autoRecord()
+		}
+		go t.captureLoop(stopCh)
+		go t.monitorLoop(stopCh)
 		return nil
 	}
 
@@ -208,16 +235,7 @@ func (t *TerminalTmux) doStart(sessionID string) error {
 		// Claude mode
 		// Build command for Claude
 		// Only use --resume if the session file exists and has content
-		claudeArgs := []string{}
-		if sessionID != "" && isValidUUID(sessionID) && claudeProjectDir != "" {
-			// Check if session file exists and has content
-			sessionFile := getClaudeSessionFile(claudeProjectDir, sessionID)
-			if info, err := os.Stat(sessionFile); err == nil && info.Size() > 0 {
-				// Session has content, resume it
-				claudeArgs = append(claudeArgs, "--resume", sessionID)
-			}
-			// If file is empty or doesn't exist, start fresh (Claude will use the workDir)
-		}
+		claudeArgs := claudeResumeArgs(claudeProjectDir, sessionID)
 
 		// Create new tmux session with Claude
 		// -d: detached
@@ -245,9 +263,11 @@ func (t *TerminalTmux) doStart(sessionID string) error {
 		return fmt.Errorf("failed to start tmux session: %w", err)
 	}
 
-	// Batch: set-option + resize-window in one call
+	// Batch: set-option + resize-window in one call. The pane is kept when
+	// its command exits, so that an exit can be told from a lost session.
 	exec.Command("tmux",
 		"set-option", "-t", tmuxName, "window-size", "manual", ";",
+		"set-window-option", "-t", tmuxName, "remain-on-exit", "on", ";",
 		"resize-window", "-t", tmuxName, "-x", fmt.Sprintf("%d", width), "-y", fmt.Sprintf("%d", height),
 	).Run()
 
@@ -263,33 +283,63 @@ func (t *TerminalTmux) doStart(sessionID string) error {
 	t.mu.Lock()
 	t.state = TerminalRunning
 	t.pendingStart = false
+	t.lostReason = ""
+	t.content = "" // Not the pane of a previous (lost) session
 	t.stopCh = make(chan struct{})
+	stopCh := t.stopCh
 	t.mu.Unlock()
 
+	t.autoRecord()
+
 	// Start capture loop
-	go t.captureLoop()
+	go t.captureLoop(stopCh)
 
 	// Monitor for exit
-	go t.monitorLoop()
+	go t.monitorLoop(stopCh)
 
 	return nil
 }
 
-// captureLoop periodically captures tmux pane content
-func (t *TerminalTmux) captureLoop() {
+// captureLoop periodically captures tmux pane content until stopCh is closed
+func (t *TerminalTmux) captureLoop(stopCh <-chan struct{}) {
 	ticker := time.NewTicker(150 * time.Millisecond)
 	defer ticker.Stop()
 
+	skipped := 0
 	for {
 		select {
-		case <-t.stopCh:
+		case <-stopCh:
 			return
 		case <-ticker.C:
+			// Back off while the pane is idle: capture-pane spawns a process,
+			// which adds up with several terminals open
+			skipped++
+			if skipped < t.captureEvery() {
+				continue
+			}
+			skipped = 0
 			t.capture()
 		}
 	}
 }
 
+// captureEvery returns every how many ticks the pane is captured
+// (every tick while active, slowing down to ~1s when idle, 3s in low-power mode)
+func (t *TerminalTmux) captureEvery() int {
+	t.mu.RLock()
+	idle := t.idleCaptures
+	t.mu.RUnlock()
+
+	every := 1 + idle/5
+	if every > 6 {
+		every = 6
+	}
+	if terminalsLowPower.Load() {
+		every = lowPowerCaptureEvery
+	}
+	return every
+}
+
 // capture captures the current tmux pane content with ANSI codes
 func (t *TerminalTmux) capture() {
 	// capture-pane -p: print to stdout
@@ -306,6 +356,11 @@ func (t *TerminalTmux) capture() {
 	newContent := string(output)
 	changed := newContent != t.content
 	t.content = newContent
+	if changed {
+		t.idleCaptures = 0
+	} else {
+		t.idleCaptures++
+	}
 	t.totalLines = len(strings.Split(newContent, "\n"))
 	t.mu.Unlock()
 
@@ -314,40 +369,85 @@ func (t *TerminalTmux) capture() {
 	}
 }
 
-// monitorLoop monitors the tmux session
-func (t *TerminalTmux) monitorLoop() {
+// monitorLoop monitors the tmux session: the command exiting leaves a dead
+// pane (remain-on-exit), which is closed; the session disappearing while the
+// command runs means it was lost
+func (t *TerminalTmux) monitorLoop(stopCh chan struct{}) {
 	ticker := time.NewTicker(500 * time.Millisecond)
 	defer ticker.Stop()
 
+	skipped := 0
 	for {
 		select {
-		case <-t.stopCh:
+		case <-stopCh:
 			return
 		case <-ticker.C:
-			// Check if tmux session still exists
-			cmd := exec.Command("tmux", "has-session", "-t", t.tmuxName)
-			if err := cmd.Run(); err != nil {
-				// Session no longer exists
-				t.mu.Lock()
-				t.state = TerminalExited
+			// Every 3s in low-power mode
+			if skipped++; terminalsLowPower.Load() && skipped < 6 {
+				continue
+			}
+			skipped = 0
+
+			// display-message alone falls back to another session
+			output, err := exec.Command("tmux",
+				"has-session", "-t", t.tmuxName, ";",
+				"display-message", "-t", t.tmuxName, "-p", "#{pane_dead}",
+			).Output()
+			lostReason := ""
+			if err != nil {
+				lostReason = tmuxLostReason(err)
+			} else if strings.TrimSpace(string(output)) == "1" {
+				exec.Command("tmux", "kill-session", "-t", t.tmuxName).Run()
+			} else {
+				continue
+			}
+
+			t.mu.Lock()
+			if t.stopCh != stopCh {
+				// Stopped or detached meanwhile
 				t.mu.Unlock()
-				if t.onExit != nil {
-					t.onExit()
-				}
 				return
 			}
+			close(stopCh) // Ends the capture loop
+			t.stopCh = nil
+			t.state = TerminalExited
+			t.lostReason = lostReason
+			onExit := t.onExit
+			t.mu.Unlock()
+			if onExit != nil {
+				onExit()
+			}
+			return
 		}
 	}
 }
 
+// tmuxLostReason describes why a tmux command could not find the session
+func tmuxLostReason(err error) string {
+	stderr := ""
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		stderr = string(exitErr.Stderr)
+	}
+	switch {
+	case strings.Contains(stderr, "no server running"), strings.Contains(stderr, "error connecting"),
+		strings.Contains(stderr, "server exited"):
+		return "tmux server stopped"
+	case strings.Contains(stderr, "can't find"):
+		return "tmux session killed"
+	default:
+		return "tmux unreachable: " + strings.TrimSpace(stderr+" "+err.Error())
+	}
+}
+
 // Write sends input to the terminal
 func (t *TerminalTmux) Write(data []byte) error {
-	t.mu.RLock()
+	t.mu.Lock()
 	if t.state != TerminalRunning {
-		t.mu.RUnlock()
+		t.mu.Unlock()
 		return nil
 	}
-	t.mu.RUnlock()
+	t.idleCaptures = 0 // Input usually produces output: capture at full rate
+	t.mu.Unlock()
 
 	// Send keys using tmux send-keys
 	// -l: literal (don't interpret special characters)
@@ -419,6 +519,27 @@ func (t *TerminalTmux) Write(data []byte) error {
 	return cmd.Run()
 }
 
+// Paste sends pasted text to the terminal as a single paste: through a tmux
+// buffer, bracketed if the application enabled bracketed paste (-p)
+func (t *TerminalTmux) Paste(text string) error {
+	t.mu.Lock()
+	if t.state != TerminalRunning {
+		t.mu.Unlock()
+		return nil
+	}
+	t.idleCaptures = 0
+	tmuxName := t.tmuxName
+	t.mu.Unlock()
+
+	buffer := "devtrack-paste-" + tmuxName
+	load := exec.Command("tmux", "load-buffer", "-b", buffer, "-")
+	load.Stdin = strings.NewReader(text)
+	if err := load.Run(); err != nil {
+		return fmt.Errorf("failed to load the paste: %w", err)
+	}
+	return exec.Command("tmux", "paste-buffer", "-p", "-d", "-b", buffer, "-t", tmuxName).Run()
+}
+
 // WriteString sends a string to the terminal
 func (t *TerminalTmux) WriteString(s string) error {
 	return t.Write([]byte(s))
@@ -578,6 +699,16 @@ func lipglossStyle(s string) string {
 	return "\x1b[90m" + s + "\x1b[0m" // Gray/muted color
 }
 
+// ScrollbackLines returns the whole pane history as plain text (for copy mode).
+// -J joins wrapped lines so selections copy logical lines.
+func (t *TerminalTmux) ScrollbackLines() []string {
+	output, err := exec.Command("tmux", "capture-pane", "-t", t.tmuxName, "-p", "-J", "-S", "-").Output()
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.TrimRight(string(output), "\n"), "\n")
+}
+
 // State returns the current terminal state
 func (t *TerminalTmux) State() TerminalState {
 	t.mu.RLock()
@@ -590,6 +721,36 @@ func (t *TerminalTmux) IsRunning() bool {
 	return t.State() == TerminalRunning
 }
 
+// LostReason returns why the tmux session disappeared under the terminal,
+// empty if it is running or ended normally
+func (t *TerminalTmux) LostReason() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.lostReason
+}
+
+// Handoff lets a client attached from outside DevTrack size the session
+// (the embedded pane follows) and returns the tmux session name
+func (t *TerminalTmux) Handoff() string {
+	t.mu.RLock()
+	tmuxName := t.tmuxName
+	t.mu.RUnlock()
+	exec.Command("tmux", "set-option", "-t", tmuxName, "window-size", "latest").Run()
+	return tmuxName
+}
+
+// Reclaim sizes the session for the embedded pane again once the outside
+// client detached
+func (t *TerminalTmux) Reclaim() {
+	t.mu.RLock()
+	tmuxName, width, height := t.tmuxName, t.width, t.height
+	t.mu.RUnlock()
+	exec.Command("tmux",
+		"set-option", "-t", tmuxName, "window-size", "manual", ";",
+		"resize-window", "-t", tmuxName, "-x", fmt.Sprintf("%d", width), "-y", fmt.Sprintf("%d", height),
+	).Run()
+}
+
 // Stop stops the terminal
 func (t *TerminalTmux) Stop() {
 	t.mu.Lock()
@@ -607,6 +768,98 @@ func (t *TerminalTmux) Stop() {
 	t.state = TerminalExited
 }
 
+// SetAutoRecord records the session to a new file of dir when it starts
+func (t *TerminalTmux) SetAutoRecord(dir string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.autoRecordDir = dir
+}
+
+// autoRecord starts recording a started session if auto-record is on
+func (t *TerminalTmux) autoRecord() {
+	t.mu.RLock()
+	dir, title := t.autoRecordDir, t.tmuxName
+	t.mu.RUnlock()
+	if dir == "" {
+		return
+	}
+	path := filepath.Join(dir, recording.FileName(title, time.Now()))
+	if err := t.StartRecording(path, title); err != nil {
+		logger.Warn("Auto-record of %s failed: %v", title, err)
+	}
+}
+
+// StartRecording pipes the output of the running session to a recording
+// file (written by the hidden record command of this executable)
+func (t *TerminalTmux) StartRecording(path, title string) error {
+	t.mu.RLock()
+	tmuxName, width, height, running := t.tmuxName, t.width, t.height, t.state == TerminalRunning
+	t.mu.RUnlock()
+	if !running {
+		return fmt.Errorf("terminal not running")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("executable not found: %w", err)
+	}
+	command := fmt.Sprintf("exec %s %s %s %d %d %s", shellQuote(exe), recording.Command,
+		shellQuote(path), width, height, shellQuote(title))
+	// -o: only opens a pipe if none is open
+	if out, err := exec.Command("tmux", "pipe-pane", "-o", "-t", tmuxName, command).CombinedOutput(); err != nil {
+		return fmt.Errorf("pipe-pane failed: %s", strings.TrimSpace(string(out)))
+	}
+
+	t.mu.Lock()
+	t.recording, t.recordingPath = true, path
+	t.mu.Unlock()
+	return nil
+}
+
+// StopRecording closes the pipe of the session, ending its recording
+func (t *TerminalTmux) StopRecording() error {
+	t.mu.RLock()
+	tmuxName := t.tmuxName
+	t.mu.RUnlock()
+
+	// pipe-pane without a command closes the current pipe
+	if out, err := exec.Command("tmux", "pipe-pane", "-t", tmuxName).CombinedOutput(); err != nil {
+		return fmt.Errorf("pipe-pane failed: %s", strings.TrimSpace(string(out)))
+	}
+
+	t.mu.Lock()
+	t.recording, t.recordingPath = false, ""
+	t.mu.Unlock()
+	return nil
+}
+
+// Recording reports whether the session is recorded, and to which file
+// (empty if the recording was started before a DevTrack restart)
+func (t *TerminalTmux) Recording() (bool, string) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.recording && t.state == TerminalRunning, t.recordingPath
+}
+
+// shellQuote quotes s as a single POSIX shell word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Detach stops mirroring the session, which keeps running in tmux (a
+// terminal with the same ID reattaches to it on start)
+func (t *TerminalTmux) Detach() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopCh != nil && t.state == TerminalRunning {
+		close(t.stopCh)
+		t.stopCh = nil
+	}
+	t.content = ""
+	t.state = TerminalExited
+}
+
 // SetCallbacks sets the callback functions
 func (t *TerminalTmux) SetCallbacks(onOutput, onExit func()) {
 	t.mu.Lock()
@@ -695,33 +948,3 @@ const (
 	TmuxPrefixDatabase = terminal.PrefixDatabase // Database clients
 	TmuxPrefixShell    = terminal.PrefixShell    // Terminal/Shell
 )
-
-// CleanupOrphanTmuxSessions kills all cdt-* tmux sessions
-// Call this on startup to clean up sessions from previous runs
-func CleanupOrphanTmuxSessions() int {
-	cmd := exec.Command("tmux", "ls", "-F", "#{session_name}")
-	output, err := cmd.Output()
-	if err != nil {
-		return 0
-	}
-
-	count := 0
-	for _, line := range strings.Split(string(output), "\n") {
-		line = strings.TrimSpace(line)
-		// Match any cdt-* session
-		if strings.HasPrefix(line, "cdt-") {
-			if exec.Command("tmux", "kill-session", "-t", line).Run() == nil {
-				count++
-			}
-		}
-	}
-	return count
-}
-
-// getClaudeSessionFile returns the path to the Claude session file
-func getClaudeSessionFile(claudeProjectDir, sessionID string) string {
-	// Claude stores sessions in ~/.claude/projects/<encoded-path>/<session-id>.jsonl
-	// claudeProjectDir is already in the correct format (e.g., -data-devel-infra-csd-devtrack)
-	homeDir, _ := os.UserHomeDir()
-	return fmt.Sprintf("%s/.claude/projects/%s/%s.jsonl", homeDir, claudeProjectDir, sessionID)
-}
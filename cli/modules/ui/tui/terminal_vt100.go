@@ -1,7 +1,6 @@
 package tui
 
 import (
-	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -13,15 +12,22 @@ import (
 	"github.com/vito/vt100"
 )
 
-// TerminalVT100 represents an embedded terminal using vito/vt100
+// TerminalVT100 represents an embedded terminal using a native PTY (creack/pty)
+// and vito/vt100. It is the terminal backend when tmux is not installed:
+// the process lives as long as DevTrack (no detach/reattach).
 type TerminalVT100 struct {
 	mu sync.RWMutex
 
 	// Session info
-	SessionID   string
-	WorkDir     string
-	ClaudePath  string
-	SessionFile string
+	SessionID        string
+	WorkDir          string
+	ClaudeProjectDir string // Original Claude project directory (for --resume)
+	ClaudePath       string
+	SessionFile      string
+
+	// Custom command support (for non-Claude terminals like psql)
+	customCmd  string   // Custom command to run (empty = use Claude)
+	customArgs []string // Arguments for custom command
 
 	// vt100 terminal
 	vt *vt100.VT100
@@ -31,13 +37,18 @@ type TerminalVT100 struct {
 	cmd  *exec.Cmd
 
 	// Terminal state
-	width  int
-	height int
-	state  TerminalState
+	width        int
+	height       int
+	state        TerminalState
+	pendingStart bool   // true if Start() was called but process not yet started
+	startSession string // session ID to resume when actually starting
 
 	// ESC ESC detection
 	lastEscTime time.Time
 
+	// Bracketed paste enabled by the application
+	bracketedPaste bool
+
 	// Callbacks
 	onOutput func()
 	onExit   func()
@@ -55,10 +66,29 @@ func NewTerminalVT100(sessionID, workDir, claudePath string) *TerminalVT100 {
 	}
 }
 
+// NewTerminalVT100WithProject creates a new PTY terminal for Claude that resumes
+// sessions of the given Claude project directory
+func NewTerminalVT100WithProject(sessionID, workDir, claudeProjectDir, claudePath string) *TerminalVT100 {
+	t := NewTerminalVT100(sessionID, workDir, claudePath)
+	t.ClaudeProjectDir = claudeProjectDir
+	return t
+}
+
+// NewTerminalVT100Command creates a new PTY terminal with a custom command (for database/shell)
+func NewTerminalVT100Command(sessionID, command string, args []string) *TerminalVT100 {
+	return &TerminalVT100{
+		SessionID:  sessionID,
+		customCmd:  command,
+		customArgs: args,
+		width:      80,
+		height:     24,
+		state:      TerminalIdle,
+	}
+}
+
 // SetSize sets the terminal size
 func (t *TerminalVT100) SetSize(width, height int) {
 	t.mu.Lock()
-	defer t.mu.Unlock()
 
 	if width < 10 {
 		width = 10
@@ -68,12 +98,22 @@ func (t *TerminalVT100) SetSize(width, height int) {
 	}
 
 	if t.width == width && t.height == height {
+		t.mu.Unlock()
 		return
 	}
 
 	t.width = width
 	t.height = height
 
+	// If we have a pending start and now have real dimensions, start the process
+	if t.pendingStart && (width != 80 || height != 24) {
+		sessionID := t.startSession
+		t.mu.Unlock()
+		t.doStart(sessionID)
+		return
+	}
+	defer t.mu.Unlock()
+
 	// Resize vt100 terminal
 	if t.vt != nil {
 		t.vt.Resize(height, width)
@@ -91,28 +131,45 @@ func (t *TerminalVT100) SetSize(width, height int) {
 // Start starts the process in the terminal
 func (t *TerminalVT100) Start(sessionID string) error {
 	t.mu.Lock()
+
+	if t.state == TerminalRunning {
+		t.mu.Unlock()
+		return nil
+	}
+
+	// If dimensions are still default (80x24), defer actual start until SetSize is called
+	if t.width == 80 && t.height == 24 {
+		t.pendingStart = true
+		t.startSession = sessionID
+		t.mu.Unlock()
+		return nil
+	}
+
+	t.mu.Unlock()
+	return t.doStart(sessionID)
+}
+
+// doStart starts the process in a PTY (called when dimensions are known)
+// Note: caller must NOT hold the lock
+func (t *TerminalVT100) doStart(sessionID string) error {
+	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	if t.state == TerminalRunning {
 		return nil
 	}
+	t.pendingStart = false
 
 	// Create vt100 terminal
 	t.vt = vt100.NewVT100(t.height, t.width)
-
-	// Enable debug logging to see what's happening
-	debugFile, _ := os.OpenFile("/tmp/vt100_debug.log", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-	if debugFile != nil {
-		t.vt.DebugLogs = debugFile
-	}
+	t.bracketedPaste = false
 
 	// Build command
-	args := []string{}
-	if sessionID != "" && isValidUUID(sessionID) {
-		args = append(args, "--resume", sessionID)
+	if t.customCmd != "" {
+		t.cmd = exec.Command(t.customCmd, t.customArgs...)
+	} else {
+		t.cmd = exec.Command(t.ClaudePath, claudeResumeArgs(t.ClaudeProjectDir, sessionID)...)
 	}
-
-	t.cmd = exec.Command(t.ClaudePath, args...)
 	t.cmd.Dir = t.WorkDir
 	t.cmd.Env = append(os.Environ(),
 		"TERM=xterm-256color",
@@ -137,52 +194,54 @@ func (t *TerminalVT100) Start(sessionID string) error {
 	t.state = TerminalRunning
 
 	// Read loop
-	go t.readLoop()
+	go t.readLoop(t.ptmx)
 
 	// Wait for process exit
-	go t.waitLoop()
+	go t.waitLoop(t.cmd)
 
 	return nil
 }
 
 // readLoop reads from PTY and updates vt100
-func (t *TerminalVT100) readLoop() {
+func (t *TerminalVT100) readLoop(ptmx *os.File) {
 	buf := make([]byte, 4096)
 	for {
-		n, err := t.ptmx.Read(buf)
-		if err != nil {
-			if err != io.EOF {
-				// Log error if needed
-			}
-			return
-		}
-
+		n, err := ptmx.Read(buf)
 		if n > 0 {
 			t.mu.Lock()
 			if t.vt != nil {
 				t.vt.Write(buf[:n])
 			}
+			t.bracketedPaste = bracketedPasteMode(buf[:n], t.bracketedPaste)
+			onOutput := t.onOutput
 			t.mu.Unlock()
 
-			if t.onOutput != nil {
-				t.onOutput()
+			if onOutput != nil {
+				onOutput()
 			}
 		}
+		if err != nil {
+			// io.EOF or EIO when the process exits
+			return
+		}
 	}
 }
 
 // waitLoop waits for process to exit
-func (t *TerminalVT100) waitLoop() {
-	if t.cmd != nil {
-		t.cmd.Wait()
-	}
+func (t *TerminalVT100) waitLoop(cmd *exec.Cmd) {
+	cmd.Wait()
 
 	t.mu.Lock()
 	t.state = TerminalExited
+	if t.ptmx != nil {
+		t.ptmx.Close()
+		t.ptmx = nil
+	}
+	onExit := t.onExit
 	t.mu.Unlock()
 
-	if t.onExit != nil {
-		t.onExit()
+	if onExit != nil {
+		onExit()
 	}
 }
 
@@ -199,6 +258,14 @@ func (t *TerminalVT100) Write(data []byte) error {
 	return err
 }
 
+// Paste sends pasted text to the terminal as a single paste
+func (t *TerminalVT100) Paste(text string) error {
+	t.mu.RLock()
+	bracketed := t.bracketedPaste
+	t.mu.RUnlock()
+	return t.Write(pasteBytes(text, bracketed))
+}
+
 // WriteString sends a string to the terminal
 func (t *TerminalVT100) WriteString(s string) error {
 	return t.Write([]byte(s))
@@ -251,15 +318,20 @@ func (t *TerminalVT100) View() string {
 		return "[No terminal initialized]"
 	}
 
+	return renderVT100(t.vt)
+}
+
+// renderVT100 renders the screen of a vt100 terminal with ANSI colors
+func renderVT100(vt *vt100.VT100) string {
 	var lines []string
-	for y := 0; y < t.vt.Height && y < len(t.vt.Content); y++ {
+	for y := 0; y < vt.Height && y < len(vt.Content); y++ {
 		var line strings.Builder
 		var lastFormat vt100.Format
 		hasFormat := false
 
-		for x := 0; x < t.vt.Width && x < len(t.vt.Content[y]); x++ {
-			r := t.vt.Content[y][x]
-			f := t.vt.Format[y][x]
+		for x := 0; x < vt.Width && x < len(vt.Content[y]); x++ {
+			r := vt.Content[y][x]
+			f := vt.Format[y][x]
 
 			// Check if format changed
 			formatChanged := !hasFormat || !formatsEqual(f, lastFormat)
@@ -369,9 +441,20 @@ func (t *TerminalVT100) Stop() {
 	if t.cmd != nil && t.cmd.Process != nil {
 		t.cmd.Process.Kill()
 	}
+	t.pendingStart = false
 	t.state = TerminalExited
 }
 
+// PID returns the process ID of the command (0 if not running)
+func (t *TerminalVT100) PID() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.cmd == nil || t.cmd.Process == nil || t.state != TerminalRunning {
+		return 0
+	}
+	return t.cmd.Process.Pid
+}
+
 // SetCallbacks sets the callback functions
 func (t *TerminalVT100) SetCallbacks(onOutput, onExit func()) {
 	t.mu.Lock()
@@ -411,9 +494,14 @@ func (t *TerminalVT100) GetLines() []string {
 	if t.vt == nil {
 		return nil
 	}
+	return vt100Lines(t.vt)
+}
+
+// vt100Lines returns the plain text lines of a vt100 terminal
+func vt100Lines(vt *vt100.VT100) []string {
 	var lines []string
-	for y := 0; y < t.vt.Height && y < len(t.vt.Content); y++ {
-		lines = append(lines, string(t.vt.Content[y]))
+	for y := 0; y < vt.Height && y < len(vt.Content); y++ {
+		lines = append(lines, string(vt.Content[y]))
 	}
 	return lines
 }
@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// transferSpec is a parsed transfer dialog input
+type transferSpec struct {
+	direction string // push, pull
+	host      string
+	local     string // Relative to the project (or absolute)
+	remote    string
+}
+
+// parseTransferSpec parses an scp-style "<source> <destination>" input where
+// exactly one side is "host:path" with a known SSH host
+func parseTransferSpec(input string, hosts []core.SSHHostVM) (transferSpec, error) {
+	fields := strings.Fields(input)
+	if len(fields) != 2 {
+		return transferSpec{}, fmt.Errorf("expected \"<source> <destination>\"")
+	}
+
+	remoteSide := func(arg string) (string, string, bool) {
+		name, path, ok := strings.Cut(arg, ":")
+		if !ok || path == "" {
+			return "", "", false
+		}
+		for _, h := range hosts {
+			if h.Name == name {
+				return name, path, true
+			}
+		}
+		return "", "", false
+	}
+
+	srcHost, srcPath, srcRemote := remoteSide(fields[0])
+	dstHost, dstPath, dstRemote := remoteSide(fields[1])
+	switch {
+	case srcRemote && dstRemote:
+		return transferSpec{}, fmt.Errorf("one side must be local")
+	case dstRemote:
+		return transferSpec{direction: "push", host: dstHost, local: fields[0], remote: dstPath}, nil
+	case srcRemote:
+		return transferSpec{direction: "pull", host: srcHost, local: fields[1], remote: srcPath}, nil
+	default:
+		return transferSpec{}, fmt.Errorf("one side must be host:path (known SSH host)")
+	}
+}
+
+// openTransferDialog opens the push/pull dialog for the selected project
+func (m *Model) openTransferDialog() tea.Cmd {
+	projectID := m.getSelectedProjectID()
+	if projectID == "" || m.blockReadOnly("transfer") {
+		return nil
+	}
+	if m.state.Capabilities == nil || !m.state.Capabilities.HasTransfer() {
+		m.lastError = "rsync or scp required for file transfers"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	if m.state.Shell == nil || len(m.state.Shell.SSHHosts) == 0 {
+		m.lastError = "No SSH host configured (config ssh.hosts or ~/.ssh/config)"
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+
+	// Reuse the last transfer of the project (quick redeploy)
+	spec := m.projectsView().lastTransferSpecs[projectID]
+	if spec == "" {
+		spec = fmt.Sprintf("targets/ %s:%s/", m.state.Shell.SSHHosts[0].Name, projectID)
+	}
+
+	m.projectsView().pendingTransferProjectID = projectID
+	m.dialogType = "transfer"
+	m.dialogMessage = "Transfer <source> <destination> (remote side = host:path):"
+	m.dialogInput.SetValue(spec)
+	m.dialogInput.CursorEnd()
+	m.dialogInput.Focus()
+	m.dialogInputActive = true
+	m.showDialog = true
+	return m.dialogInput.Cursor.BlinkCmd()
+}
+
+// startTransfer starts the transfer entered in the transfer dialog
+func (m *Model) startTransfer() tea.Cmd {
+	projectID := m.projectsView().pendingTransferProjectID
+	m.projectsView().pendingTransferProjectID = ""
+	if projectID == "" || m.state.Shell == nil {
+		return nil
+	}
+
+	input := strings.TrimSpace(m.dialogInput.Value())
+	spec, err := parseTransferSpec(input, m.state.Shell.SSHHosts)
+	if err != nil {
+		m.lastError = "Transfer: " + err.Error()
+		m.lastErrorTime = time.Now()
+		return nil
+	}
+	if m.projectsView().lastTransferSpecs == nil {
+		m.projectsView().lastTransferSpecs = make(map[string]string)
+	}
+	m.projectsView().lastTransferSpecs[projectID] = input
+
+	return m.sendEvent(core.NewEvent(core.EventTransferStart).
+		WithProject(projectID).
+		WithData("host", spec.host).
+		WithData("direction", spec.direction).
+		WithData("local", spec.local).
+		WithData("remote", spec.remote))
+}
+
+// renderProjectTransfers renders the recent transfers of a project (detail panel)
+func (m *Model) renderProjectTransfers(projectID string, width int) []string {
+	if m.state.Projects == nil {
+		return nil
+	}
+
+	const maxShown = 3
+	var lines []string
+	shown := 0
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	transfers := m.state.Projects.Transfers
+	for i := len(transfers) - 1; i >= 0 && shown < maxShown; i-- {
+		t := transfers[i]
+		if t.ProjectID != projectID {
+			continue
+		}
+		shown++
+
+		arrow := "↑"
+		if t.Direction == "pull" {
+			arrow = "↓"
+		}
+		header := fmt.Sprintf("%s %s → %s", arrow, t.Source, t.Destination)
+		lines = append(lines, truncate(header, width))
+
+		switch t.State {
+		case "running":
+			status := mutedStyle.Render(m.spinner.View() + " " + t.Tool)
+			if t.Percent >= 0 {
+				status = renderProgressBar(t.Percent, 20) + fmt.Sprintf(" %3d%%", t.Percent)
+				if t.Speed != "" {
+					status += mutedStyle.Render("  " + t.Transferred + " · " + t.Speed)
+				}
+			}
+			lines = append(lines, "  "+status)
+		case "done":
+			lines = append(lines, "  "+StatusSuccess.Render("✓ done")+mutedStyle.Render(" "+formatRelativeTime(t.FinishedAt)))
+		case "failed":
+			lines = append(lines, "  "+StatusError.Render(truncate("✗ "+t.Error, width-2)))
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+	return append([]string{"", SubtitleStyle.Render("Transfers:")}, lines...)
+}
@@ -0,0 +1,299 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"csd-devtrack/cli/modules/core/projects"
+	"csd-devtrack/cli/modules/platform/config"
+	"csd-devtrack/cli/modules/platform/trash"
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// trashController is the submodel of the Trash view
+type trashController struct {
+	menu *TreeMenu // Tree menu for deleted items
+
+	pendingItemID  string // Trash item to delete permanently (saved at dialog open)
+	configRevision int    // Last seen config revision (reload config when it changes)
+}
+
+// newTrashController creates the Trash view controller
+func newTrashController() *trashController {
+	menu := NewTreeMenu(nil)
+	menu.SetTitle("Trash")
+	return &trashController{menu: menu}
+}
+
+// Init implements ViewController
+func (c *trashController) Init(m *Model) tea.Cmd {
+	c.updateMenu(m)
+	return nil
+}
+
+// Menu implements ViewController
+func (c *trashController) Menu(m *Model, focus FocusArea) *TreeMenu {
+	if focus != FocusMain {
+		return nil
+	}
+	return c.menu
+}
+
+// Keys implements ViewController
+func (c *trashController) Keys(m *Model) []KeyHint {
+	return []KeyHint{
+		{"u/Enter", "restore"},
+		{"d", "delete"},
+		{"e", "empty"},
+	}
+}
+
+// Update implements ViewController
+func (c *trashController) Update(m *Model, msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case stateUpdateMsg:
+		if !msg.update.Affects(core.VMTrash) {
+			return nil, false
+		}
+		// Update menu, reloading config if a restore changed it
+		c.updateMenu(m)
+		if m.state.Trash != nil && m.state.Trash.ConfigRevision != c.configRevision {
+			c.configRevision = m.state.Trash.ConfigRevision
+			m.reloadConfigAfterRestore()
+		}
+	case selectMsg:
+		// Enter restores the selected item
+		return c.restoreSelected(m), true
+	case dialogConfirmMsg:
+		switch msg.dialogType {
+		case "trash_delete":
+			id := c.pendingItemID
+			c.pendingItemID = ""
+			if id == "" {
+				return nil, true
+			}
+			return m.sendEvent(core.NewEvent(core.EventTrashDelete).WithValue(id)), true
+		case "trash_empty":
+			return m.sendEvent(core.NewEvent(core.EventTrashEmpty)), true
+		}
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "u":
+			return c.restoreSelected(m), true
+		case "d", "x":
+			return c.confirmDelete(m), true
+		case "e":
+			if m.state.Trash != nil && len(m.state.Trash.Items) > 0 {
+				return m.openConfirmDialog(config.ConfirmTrashDelete, "trash_empty",
+					fmt.Sprintf("Permanently delete all %d items?", len(m.state.Trash.Items))), true
+			}
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// View implements ViewController
+// Layout: TreeMenu with deleted items on left, details on right
+func (c *trashController) View(m *Model, width, height int) string {
+	vm := m.state.Trash
+	if vm == nil {
+		return m.renderLoading()
+	}
+
+	// 2 panels side by side (TreeMenu + detail)
+	// Height: 1 × 2 = 2
+	// Width: 2 × 2 = 4
+	heightBorders := 2
+	widthBorders := 4
+	panelHeight := height - heightBorders
+	availableWidth := width - widthBorders - GapHorizontal
+
+	// Left panel - TreeMenu with deleted items
+	listWidth := c.menu.CalcWidth()
+	if listWidth < 30 {
+		listWidth = 30
+	}
+	if listWidth > availableWidth/2 {
+		listWidth = availableWidth / 2
+	}
+
+	c.menu.SetSize(listWidth, panelHeight)
+	c.menu.SetFocused(m.focusArea == FocusMain)
+	listPanel := c.menu.Render()
+
+	// Right panel - details
+	detailWidth := availableWidth - listWidth
+	detailContent := c.renderDetail(m, vm, detailWidth-4)
+
+	var detailStyle lipgloss.Style
+	if m.focusArea == FocusDetail {
+		detailStyle = FocusedBorderStyle
+	} else {
+		detailStyle = UnfocusedBorderStyle
+	}
+	detailPanel := detailStyle.Width(detailWidth - 2).Height(panelHeight).Render(detailContent)
+
+	gap := strings.Repeat(" ", GapHorizontal)
+	return lipgloss.JoinHorizontal(lipgloss.Top, listPanel, gap, detailPanel)
+}
+
+// renderDetail renders the details of the selected trash item
+func (c *trashController) renderDetail(m *Model, vm *core.TrashVM, width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	if len(vm.Items) == 0 {
+		lines := []string{
+			PanelTitleStyle.Render("Trash is empty"),
+			"",
+			mutedStyle.Render(fmt.Sprintf("Deleted projects, sessions and cockpit profiles are kept %d days.", vm.RetentionDays)),
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	item := c.selectedItem()
+	if item == nil {
+		return mutedStyle.Render("Select an item")
+	}
+
+	lines := []string{
+		PanelTitleStyle.Render(item.Label),
+		"",
+		fmt.Sprintf("Type:    %s", item.KindLabel),
+		fmt.Sprintf("Deleted: %s", formatRelativeTime(item.DeletedAt)),
+	}
+
+	remaining := time.Until(item.ExpiresAt)
+	expiry := fmt.Sprintf("Expires: in %d day(s)", int(remaining.Hours()/24)+1)
+	if remaining < 24*time.Hour {
+		lines = append(lines, StatusWarning.Render(expiry))
+	} else {
+		lines = append(lines, mutedStyle.Render(expiry))
+	}
+
+	if len(item.Files) > 0 {
+		lines = append(lines, "", mutedStyle.Render("Files:"))
+		for _, path := range item.Files {
+			lines = append(lines, "  "+truncate(path, width-2))
+		}
+	}
+
+	lines = append(lines, "", SubtitleStyle.Render("Actions:"))
+	lines = append(lines, HelpKeyStyle.Render("u")+" restore  "+HelpKeyStyle.Render("d")+" delete permanently")
+
+	return strings.Join(lines, "\n")
+}
+
+// selectedItem returns the trash item selected in the menu
+func (c *trashController) selectedItem() *core.TrashItemVM {
+	selected := c.menu.SelectedItem()
+	if selected == nil {
+		return nil
+	}
+	if item, ok := selected.Data.(core.TrashItemVM); ok {
+		return &item
+	}
+	return nil
+}
+
+// restoreSelected restores the selected trash item
+func (c *trashController) restoreSelected(m *Model) tea.Cmd {
+	item := c.selectedItem()
+	if item == nil {
+		return nil
+	}
+	return m.sendEvent(core.NewEvent(core.EventTrashRestore).WithValue(item.ID))
+}
+
+// confirmDelete opens the confirmation dialog to delete the selected item permanently
+func (c *trashController) confirmDelete(m *Model) tea.Cmd {
+	item := c.selectedItem()
+	if item == nil {
+		return nil
+	}
+	c.pendingItemID = item.ID
+	return m.openConfirmDialog(config.ConfirmTrashDelete, "trash_delete",
+		fmt.Sprintf("Permanently delete %s '%s'?", strings.ToLower(item.KindLabel), item.Label))
+}
+
+// trashEvent records a config-backed item deleted by the TUI so it can be restored
+func (m *Model) trashEvent(kind trash.Kind, label string, payload interface{}) tea.Cmd {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil
+	}
+	return m.sendEvent(core.NewEvent(core.EventTrashPut).
+		WithTarget(string(kind)).
+		WithData("label", label).
+		WithData("payload", string(data)))
+}
+
+// findConfigProject returns a copy of the configured (non-self) project at path
+func (m *Model) findConfigProject(path string) *projects.Project {
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		return nil
+	}
+	for _, p := range cfg.Projects {
+		if p.Path == path && !p.Self {
+			proj := p
+			return &proj
+		}
+	}
+	return nil
+}
+
+// reloadConfigAfterRestore reloads the config file after a restore changed it.
+// Only needed in daemon mode: locally the presenter shares the global config.
+func (m *Model) reloadConfigAfterRestore() {
+	if !m.detachable {
+		return
+	}
+	if err := config.LoadGlobal(config.GetGlobalPath()); err != nil {
+		return
+	}
+	m.initCockpitProfileMenu()
+}
+
+// updateMenu updates the trash TreeMenu with current items
+func (c *trashController) updateMenu(m *Model) {
+	if m.state.Trash == nil {
+		return
+	}
+
+	items := make([]TreeMenuItem, 0, len(m.state.Trash.Items))
+	for _, item := range m.state.Trash.Items {
+		items = append(items, TreeMenuItem{
+			ID:        item.ID,
+			Label:     item.Label,
+			Icon:      trashKindIcon(item.Kind),
+			IconColor: ColorMuted,
+			Data:      item,
+		})
+	}
+
+	c.menu.SetItems(items)
+}
+
+// trashKindIcon returns the icon for a trash item kind
+func trashKindIcon(kind string) string {
+	switch trash.Kind(kind) {
+	case trash.KindProject:
+		return "📁"
+	case trash.KindClaudeSession:
+		return "◆"
+	case trash.KindCockpitProfile:
+		return "▦"
+	case trash.KindShellSession:
+		return "$"
+	case trash.KindBuildOutput:
+		return "⚙"
+	default:
+		return "○"
+	}
+}
@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"strings"
+
+	"csd-devtrack/cli/modules/ui/core"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// jumpAlphabet holds the quick-select label characters, home row first
+const jumpAlphabet = "asdfghjklqwertyuiopzxcvbnm"
+
+// jumpTarget is a row of a tree menu labelled for quick-select
+type jumpTarget struct {
+	index int    // Display index (the back item is 0 when drilled down)
+	label string // Characters to type
+}
+
+// jumpLabels returns n distinct labels of the same length: one character
+// while they fit in the alphabet, two beyond
+func jumpLabels(n int) []string {
+	labels := make([]string, 0, n)
+	if n <= len(jumpAlphabet) {
+		for i := 0; i < n; i++ {
+			labels = append(labels, jumpAlphabet[i:i+1])
+		}
+		return labels
+	}
+	for i := 0; i < len(jumpAlphabet) && len(labels) < n; i++ {
+		for j := 0; j < len(jumpAlphabet) && len(labels) < n; j++ {
+			labels = append(labels, string([]byte{jumpAlphabet[i], jumpAlphabet[j]}))
+		}
+	}
+	return labels
+}
+
+// StartJump labels the selectable rows on screen (like avy/easymotion):
+// typing a label then selects its row. Returns false if there is none.
+func (tm *TreeMenu) StartJump() bool {
+	start, end := tm.rowRange()
+	var rows []int
+	if tm.hasBackItem() {
+		rows = append(rows, 0) // Always shown above the scroll window
+	}
+	for i := start; i < end; i++ {
+		if (i == 0 && tm.hasBackItem()) || tm.isIndexDisabled(i) {
+			continue
+		}
+		rows = append(rows, i)
+	}
+	if len(rows) == 0 {
+		return false
+	}
+
+	labels := jumpLabels(len(rows))
+	tm.jumpTargets = make([]jumpTarget, len(rows))
+	for i, row := range rows {
+		tm.jumpTargets[i] = jumpTarget{index: row, label: labels[i]}
+	}
+	tm.jumpTyped = ""
+	return true
+}
+
+// IsJumping returns true while the quick-select labels are shown
+func (tm *TreeMenu) IsJumping() bool {
+	return tm.jumpTargets != nil
+}
+
+// CancelJump hides the quick-select labels
+func (tm *TreeMenu) CancelJump() {
+	tm.jumpTargets = nil
+	tm.jumpTyped = ""
+}
+
+// JumpKey adds a typed character to the label: the row is selected once its
+// label is complete (true returned). A character matching no label cancels.
+func (tm *TreeMenu) JumpKey(key string) bool {
+	typed := tm.jumpTyped + key
+	partial := false
+	for _, target := range tm.jumpTargets {
+		if target.label == typed {
+			tm.CancelJump()
+			tm.SetSelectedIndex(target.index)
+			return true
+		}
+		if strings.HasPrefix(target.label, typed) {
+			partial = true
+		}
+	}
+	if !partial {
+		tm.CancelJump()
+		return false
+	}
+	tm.jumpTyped = typed
+	return false
+}
+
+// jumpLabel returns the label shown in place of the cursor of a row while
+// jumping (blank once the typed characters exclude it), false if unlabelled
+func (tm *TreeMenu) jumpLabel(displayIndex int) (string, bool) {
+	for _, target := range tm.jumpTargets {
+		if target.index != displayIndex {
+			continue
+		}
+		if !strings.HasPrefix(target.label, tm.jumpTyped) {
+			return "  ", true
+		}
+		rest := strings.TrimPrefix(target.label, tm.jumpTyped)
+		label := lipgloss.NewStyle().Foreground(ColorWarning).Bold(true).Render(rest)
+		return label + strings.Repeat(" ", max(2-len(rest), 0)), true
+	}
+	return "", false
+}
+
+// startTreeJump shows the quick-select labels of the focused tree (' or ^G j)
+func (m *Model) startTreeJump() tea.Cmd {
+	tm := m.getActiveTreeMenu()
+	if tm == nil || !tm.StartJump() {
+		return nil
+	}
+	m.jumpMenu = tm
+	return nil
+}
+
+// handleJumpKey types a quick-select label: a complete label selects its
+// row, Esc or any other key cancels
+func (m *Model) handleJumpKey(msg tea.KeyMsg) tea.Cmd {
+	tm := m.jumpMenu
+	m.jumpMenu = nil
+	if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 || !tm.IsJumping() {
+		tm.CancelJump()
+		return nil
+	}
+
+	jumped := tm.JumpKey(string(msg.Runes))
+	if tm.IsJumping() {
+		m.jumpMenu = tm // Label partially typed
+		return nil
+	}
+	if jumped && m.currentView == core.VMGit && m.focusArea == FocusMain {
+		return m.loadGitDiffForSelection()
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package tui
+
+import "github.com/charmbracelet/lipgloss"
+
+// SetMarkable enables multi-select: space marks the items accepted by
+// markable for a batch action (nil disables marking)
+func (tm *TreeMenu) SetMarkable(markable func(item *TreeMenuItem) bool) {
+	tm.markable = markable
+}
+
+// ToggleMark marks or unmarks the selected item. Returns false if it cannot
+// be marked (marking disabled, back item, disabled item).
+func (tm *TreeMenu) ToggleMark() bool {
+	item := tm.SelectedItem()
+	if tm.markable == nil || item == nil || item.Disabled || !tm.markable(item) {
+		return false
+	}
+	if tm.marked == nil {
+		tm.marked = make(map[string]bool)
+	}
+	if tm.marked[item.ID] {
+		delete(tm.marked, item.ID)
+	} else {
+		tm.marked[item.ID] = true
+	}
+	tm.generation++
+	return true
+}
+
+// Marked returns the marked items still in the tree, at any depth, in tree
+// order. Marks of items that disappeared are dropped.
+func (tm *TreeMenu) Marked() []TreeMenuItem {
+	if len(tm.marked) == 0 {
+		return nil
+	}
+	var marked []TreeMenuItem
+	seen := make(map[string]bool)
+	var walk func(items []TreeMenuItem)
+	walk = func(items []TreeMenuItem) {
+		for _, item := range items {
+			if tm.marked[item.ID] && !item.Disabled && !seen[item.ID] {
+				marked = append(marked, item)
+				seen[item.ID] = true
+			}
+			walk(item.Children)
+		}
+	}
+	walk(tm.items)
+	if len(seen) != len(tm.marked) {
+		tm.marked = seen
+		tm.generation++
+	}
+	return marked
+}
+
+// MarkedCount returns the number of marked items
+func (tm *TreeMenu) MarkedCount() int {
+	return len(tm.marked)
+}
+
+// ClearMarks unmarks all items
+func (tm *TreeMenu) ClearMarks() {
+	if len(tm.marked) > 0 {
+		tm.marked = nil
+		tm.generation++
+	}
+}
+
+// markIndicator returns the cursor column of a marked row: ✓ after the
+// cursor arrow when the row is also selected
+func markIndicator(selected bool, bg lipgloss.TerminalColor) string {
+	style := lipgloss.NewStyle().Foreground(ColorSuccess).Bold(true)
+	if bg != nil {
+		style = style.Background(bg)
+	}
+	if selected {
+		return style.Render("▶✓")
+	}
+	return style.Render("✓ ")
+}
@@ -13,6 +13,7 @@ type TreeMenuItem struct {
 	Icon         string              // emoji or character (left side)
 	IconColor    lipgloss.TerminalColor // optional color for the icon
 	TrailingIcon string              // emoji or character (right side, e.g., ⚡ for attached)
+	TrailingColor lipgloss.TerminalColor // optional color for the trailing icon
 	Children     []TreeMenuItem
 	Data         interface{}         // arbitrary data attached to the item
 	Count        int                 // optional count to display (e.g., number of children)
@@ -37,6 +38,14 @@ type TreeMenu struct {
 	renameActive bool
 	renameText   string
 
+	// Multi-select (see SetMarkable)
+	markable func(item *TreeMenuItem) bool
+	marked   map[string]bool // IDs of the marked items
+
+	// Quick-select labels (see StartJump)
+	jumpTargets []jumpTarget // Labelled rows, nil when not jumping
+	jumpTyped   string       // Label characters typed so far
+
 	// Styling
 	width           int
 	height          int
@@ -47,6 +56,35 @@ type TreeMenu struct {
 
 	// Animation
 	blinkState bool // Toggles for blinking items
+
+	// Caches (invalidated when items, drill-down path or search change)
+	visibleCache []TreeMenuItem // Filtered items of the current level
+	visibleValid bool
+	widthCache   int // CalcWidth result (0 = not computed)
+
+	// Render cache: the last frame is reused while nothing it depends on changes
+	generation  int // Incremented each time the content changes
+	renderKey   treeMenuRenderKey
+	renderCache string
+}
+
+// treeMenuRenderKey holds everything a rendered frame depends on
+type treeMenuRenderKey struct {
+	generation     int
+	title          string
+	width          int
+	height         int
+	selectedIndex  int
+	scrollOffset   int
+	focused        bool
+	blinkState     bool
+	rightSidePanel bool
+	searchQuery    string
+	searchActive   bool
+	renameActive   bool
+	renameText     string
+	jumping        bool
+	jumpTyped      string
 }
 
 // NewTreeMenu creates a new tree menu
@@ -67,6 +105,8 @@ func (tm *TreeMenu) SetTitle(title string) {
 // SetItems updates the menu items
 func (tm *TreeMenu) SetItems(items []TreeMenuItem) {
 	tm.items = items
+	tm.widthCache = 0
+	tm.invalidate()
 	// Reset selection if out of bounds
 	total := tm.TotalVisibleCount()
 	if tm.selectedIndex >= total {
@@ -111,6 +151,7 @@ func (tm *TreeMenu) SetRightSidePanel(isRight bool) {
 func (tm *TreeMenu) SetSearchQuery(query string) {
 	tm.searchQuery = query
 	tm.selectedIndex = 0 // Reset selection when search changes
+	tm.invalidate()
 }
 
 // SearchQuery returns the current search query
@@ -213,15 +254,29 @@ func (tm *TreeMenu) filterItems(items []TreeMenuItem, query string) []TreeMenuIt
 	return result
 }
 
-// visibleItems returns the items currently visible (filtered if searching)
+// visibleItems returns the items currently visible (filtered if searching).
+// The result is cached: navigation calls it for every key press and lists
+// can hold hundreds of items.
 func (tm *TreeMenu) visibleItems() []TreeMenuItem {
+	if tm.visibleValid {
+		return tm.visibleCache
+	}
 	items := tm.currentLevelItems()
 	if tm.searchQuery != "" {
 		items = tm.filterItems(items, tm.searchQuery)
 	}
+	tm.visibleCache = items
+	tm.visibleValid = true
 	return items
 }
 
+// invalidate drops the cached visible items and rendered frame
+func (tm *TreeMenu) invalidate() {
+	tm.visibleValid = false
+	tm.visibleCache = nil
+	tm.generation++
+}
+
 // currentParent returns the parent item if we're drilled down, nil otherwise
 func (tm *TreeMenu) currentParent() *TreeMenuItem {
 	if len(tm.drillDownPath) == 0 {
@@ -267,16 +322,57 @@ func (tm *TreeMenu) SelectedIndex() int {
 	return tm.selectedIndex
 }
 
-// SetSelectedIndex sets the selection index
+// SetSelectedIndex sets the selection index (the back item counts as index 0)
 func (tm *TreeMenu) SetSelectedIndex(index int) {
-	items := tm.visibleItems()
+	total := tm.TotalVisibleCount()
+	if index >= total {
+		index = total - 1
+	}
 	if index < 0 {
 		index = 0
 	}
-	if index >= len(items) {
-		index = len(items) - 1
-	}
 	tm.selectedIndex = index
+	tm.ensureSelectionVisible()
+}
+
+// SelectMatching selects the first item matching at any depth, drilling down
+// from the root into its parents. Returns false if no item matches.
+func (tm *TreeMenu) SelectMatching(match func(item *TreeMenuItem) bool) bool {
+	var find func(items []TreeMenuItem) []string
+	find = func(items []TreeMenuItem) []string {
+		for i := range items {
+			if match(&items[i]) {
+				return []string{items[i].ID}
+			}
+			if path := find(items[i].Children); path != nil {
+				return append([]string{items[i].ID}, path...)
+			}
+		}
+		return nil
+	}
+	path := find(tm.items)
+	if path == nil {
+		return false
+	}
+
+	for tm.DrillUp() {
+	}
+	tm.ClearSearch()
+	for depth, id := range path {
+		for i, item := range tm.visibleItems() {
+			if item.ID == id {
+				if tm.hasBackItem() {
+					i++
+				}
+				tm.SetSelectedIndex(i)
+				break
+			}
+		}
+		if depth < len(path)-1 && !tm.DrillDown() {
+			break
+		}
+	}
+	return true
 }
 
 // IsAtRoot returns true if we're at the root level
@@ -385,6 +481,35 @@ func (tm *TreeMenu) ensureSelectionVisible() {
 	}
 }
 
+// rowRange returns the display indices rendered in the scroll window (the
+// back item is shown above them when drilled down), clamping the scroll offset
+func (tm *TreeMenu) rowRange() (start, end int) {
+	total := tm.TotalVisibleCount()
+	visibleRows := tm.visibleRowCount()
+
+	// Ensure scrollOffset is valid
+	if tm.scrollOffset < 0 {
+		tm.scrollOffset = 0
+	}
+	maxScroll := total - visibleRows
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if tm.scrollOffset > maxScroll {
+		tm.scrollOffset = maxScroll
+	}
+
+	start = tm.scrollOffset
+	end = tm.scrollOffset + visibleRows
+	if tm.scrollOffset > 0 {
+		end-- // Account for scroll-up indicator
+	}
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
 // visibleRowCount returns the number of item rows that can be displayed
 func (tm *TreeMenu) visibleRowCount() int {
 	if tm.height <= 0 {
@@ -460,6 +585,7 @@ func (tm *TreeMenu) DrillDown() bool {
 	tm.selectedIndex = 0
 	tm.scrollOffset = 0 // Reset scroll when drilling down
 	tm.searchQuery = "" // Clear search when drilling down
+	tm.invalidate()
 	return true
 }
 
@@ -473,6 +599,7 @@ func (tm *TreeMenu) DrillUp() bool {
 	tm.selectedIndex = 0
 	tm.scrollOffset = 0 // Reset scroll when drilling up
 	tm.searchQuery = "" // Clear search when drilling up
+	tm.invalidate()
 	return true
 }
 
@@ -506,10 +633,20 @@ func (tm *TreeMenu) Select() *TreeMenuItem {
 func (tm *TreeMenu) ClearSearch() {
 	tm.searchQuery = ""
 	tm.selectedIndex = 0
+	tm.invalidate()
 }
 
 // CalcWidth calculates the optimal width based on item labels
 func (tm *TreeMenu) CalcWidth() int {
+	if tm.widthCache > 0 {
+		return tm.widthCache
+	}
+	tm.widthCache = tm.calcWidth()
+	return tm.widthCache
+}
+
+// calcWidth walks all items to find the longest label
+func (tm *TreeMenu) calcWidth() int {
 	const minWidth = 36
 	const maxWidth = 60
 	const padding = 14 // icons, cursor, borders, count suffix
@@ -567,8 +704,42 @@ func (tm *TreeMenu) TotalVisibleCount() int {
 	return count
 }
 
-// Render renders the tree menu
+// Render renders the tree menu.
+// Only the rows inside the scroll window are formatted, and the previous
+// frame is returned as-is when nothing changed since it was rendered.
 func (tm *TreeMenu) Render() string {
+	if tm.renderCache != "" && tm.renderKey == tm.currentRenderKey() {
+		return tm.renderCache
+	}
+	tm.renderCache = tm.render()
+	// Key taken after rendering: render() clamps the scroll offset
+	tm.renderKey = tm.currentRenderKey()
+	return tm.renderCache
+}
+
+// currentRenderKey returns the render key for the current state
+func (tm *TreeMenu) currentRenderKey() treeMenuRenderKey {
+	return treeMenuRenderKey{
+		generation:     tm.generation,
+		title:          tm.title,
+		width:          tm.width,
+		height:         tm.height,
+		selectedIndex:  tm.selectedIndex,
+		scrollOffset:   tm.scrollOffset,
+		focused:        tm.focused,
+		blinkState:     tm.blinkState,
+		rightSidePanel: tm.rightSidePanel,
+		searchQuery:    tm.searchQuery,
+		searchActive:   tm.searchActive,
+		renameActive:   tm.renameActive,
+		renameText:     tm.renameText,
+		jumping:        tm.IsJumping(),
+		jumpTyped:      tm.jumpTyped,
+	}
+}
+
+// render formats the visible rows of the tree menu
+func (tm *TreeMenu) render() string {
 	width := tm.width
 	if width == 0 {
 		width = tm.CalcWidth()
@@ -632,6 +803,9 @@ func (tm *TreeMenu) Render() string {
 		if isBackSelected {
 			cursor = "▶ "
 		}
+		if label, ok := tm.jumpLabel(0); ok {
+			cursor = label
+		}
 		backLine := cursor + "← " + truncate(parent.Label, contentWidth-6)
 
 		var backStyle lipgloss.Style
@@ -656,19 +830,7 @@ func (tm *TreeMenu) Render() string {
 	if parent != nil {
 		totalItems++ // Account for back item
 	}
-	visibleRows := tm.visibleRowCount()
-
-	// Ensure scrollOffset is valid
-	if tm.scrollOffset < 0 {
-		tm.scrollOffset = 0
-	}
-	maxScroll := totalItems - visibleRows
-	if maxScroll < 0 {
-		maxScroll = 0
-	}
-	if tm.scrollOffset > maxScroll {
-		tm.scrollOffset = maxScroll
-	}
+	startIdx, endIdx := tm.rowRange()
 
 	// Show scroll-up indicator
 	if tm.scrollOffset > 0 {
@@ -685,16 +847,6 @@ func (tm *TreeMenu) Render() string {
 			lines = append(lines, emptyStyle.Render("No items"))
 		}
 	} else {
-		// Determine which items to render based on scroll
-		startIdx := tm.scrollOffset
-		endIdx := tm.scrollOffset + visibleRows
-		if tm.scrollOffset > 0 {
-			endIdx-- // Account for scroll-up indicator
-		}
-		if endIdx > totalItems {
-			endIdx = totalItems
-		}
-
 		for displayIndex := startIdx; displayIndex < endIdx; displayIndex++ {
 			// Handle back item at index 0
 			if parent != nil && displayIndex == 0 {
@@ -754,6 +906,12 @@ func (tm *TreeMenu) Render() string {
 			} else if item.IsActive {
 				indicator = withBg("▶ ")
 			}
+			if tm.marked[item.ID] {
+				indicator = markIndicator(isSelected && tm.focused, selectedBg)
+			}
+			if label, ok := tm.jumpLabel(displayIndex); ok {
+				indicator = label
+			}
 
 			// Label with optional count
 			label := item.Label
@@ -773,7 +931,15 @@ func (tm *TreeMenu) Render() string {
 			// Trailing icon (e.g., ⚡ for attached terminal)
 			trailingIcon := ""
 			if item.TrailingIcon != "" {
-				trailingIcon = withBg(" " + item.TrailingIcon)
+				if item.TrailingColor != nil {
+					trailingStyle := lipgloss.NewStyle().Foreground(item.TrailingColor)
+					if selectedBg != nil {
+						trailingStyle = trailingStyle.Background(selectedBg)
+					}
+					trailingIcon = trailingStyle.Render(" " + item.TrailingIcon)
+				} else {
+					trailingIcon = withBg(" " + item.TrailingIcon)
+				}
 			}
 
 			// Arrow for items with children
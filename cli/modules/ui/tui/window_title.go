@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"csd-devtrack/cli/modules/core/processes"
+	"csd-devtrack/cli/modules/platform/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultWindowTitleFormat is used when the window_title setting is empty
+const defaultWindowTitleFormat = "devtrack: {status}"
+
+// windowTitleFormat returns the window title format, empty if the title is
+// left alone (window_title: off)
+func windowTitleFormat() string {
+	cfg := config.GetGlobal()
+	if cfg == nil || cfg.Settings == nil || cfg.Settings.WindowTitle == "" {
+		return defaultWindowTitleFormat
+	}
+	if cfg.Settings.WindowTitle == "off" {
+		return ""
+	}
+	return cfg.Settings.WindowTitle
+}
+
+// windowTitleStatus summarizes what DevTrack is doing: the build in
+// progress and the crashed processes first, then the running ones
+func (m *Model) windowTitleStatus() (status string, running, crashed int) {
+	if m.state.Processes != nil {
+		for _, p := range m.state.Processes.Processes {
+			switch p.State {
+			case processes.ProcessStateRunning:
+				running++
+			case processes.ProcessStateCrashed:
+				crashed++
+			}
+		}
+	}
+
+	var parts []string
+	if b := m.state.Builds; b != nil && b.IsBuilding && b.CurrentBuild != nil {
+		parts = append(parts, "building "+b.CurrentBuild.ProjectID)
+	}
+	if crashed > 0 {
+		parts = append(parts, itoa(crashed)+" crashed")
+	}
+	if len(parts) == 0 && running > 0 {
+		parts = append(parts, itoa(running)+" running")
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "idle")
+	}
+	return strings.Join(parts, ", "), running, crashed
+}
+
+// windowTitle returns the window title for the current state, empty if the
+// title is left alone
+func (m *Model) windowTitle() string {
+	format := windowTitleFormat()
+	if format == "" {
+		return ""
+	}
+	status, running, crashed := m.windowTitleStatus()
+	return strings.NewReplacer(
+		"{status}", status,
+		"{view}", m.viewName(),
+		"{running}", itoa(running),
+		"{crashed}", itoa(crashed),
+	).Replace(format)
+}
+
+// syncWindowTitle sets the terminal window title when the state it shows
+// changed, so it is visible from the OS window switcher. Inside tmux the
+// pane title is set too (shown by pane-border-format and choose-tree); a
+// daemon's pane is not the one of its attached client, it is skipped.
+func (m *Model) syncWindowTitle() tea.Cmd {
+	title := m.windowTitle()
+	if title == "" || title == m.windowTitleShown {
+		return nil
+	}
+	m.windowTitleShown = title
+
+	cmds := []tea.Cmd{tea.SetWindowTitle(title)}
+	if pane := os.Getenv("TMUX_PANE"); pane != "" && !m.detachable {
+		cmds = append(cmds, func() tea.Msg {
+			exec.Command("tmux", "select-pane", "-t", pane, "-T", title).Run()
+			return nil
+		})
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,205 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Word-level diff limits: longer lines, or pairs changed beyond the ratio,
+// are shown without intra-line highlighting (it would be noise)
+const (
+	wordDiffMaxTokens   = 300
+	wordDiffMaxChangedP = 60 // Percent of the tokens of the line
+)
+
+// wordSpan is a changed byte range [start, end) of a diff line
+type wordSpan struct {
+	start, end int
+}
+
+// computeWordDiff pairs the removed and added lines of each change block of a
+// unified diff and returns the changed token ranges of each paired line,
+// keyed by line index
+func computeWordDiff(lines []string) map[int][]wordSpan {
+	result := make(map[int][]wordSpan)
+	for i := 0; i < len(lines); {
+		if !isRemovedLine(lines[i]) {
+			i++
+			continue
+		}
+		removedStart := i
+		for i < len(lines) && isRemovedLine(lines[i]) {
+			i++
+		}
+		addedStart := i
+		for i < len(lines) && isAddedLine(lines[i]) {
+			i++
+		}
+
+		removed := addedStart - removedStart
+		added := i - addedStart
+		for k := 0; k < removed && k < added; k++ {
+			oldIdx, newIdx := removedStart+k, addedStart+k
+			oldSpans, newSpans, ok := diffWords(lines[oldIdx][1:], lines[newIdx][1:])
+			if !ok {
+				continue
+			}
+			result[oldIdx] = shiftSpans(oldSpans, 1)
+			result[newIdx] = shiftSpans(newSpans, 1)
+		}
+	}
+	return result
+}
+
+// isRemovedLine returns true for a removed line of a unified diff
+func isRemovedLine(line string) bool {
+	return strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---")
+}
+
+// isAddedLine returns true for an added line of a unified diff
+func isAddedLine(line string) bool {
+	return strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++")
+}
+
+// diffWords returns the changed ranges of two versions of a line, computed on
+// tokens (words, whitespace runs, punctuation). ok is false when the lines are
+// too long or too different to highlight.
+func diffWords(oldLine, newLine string) (oldSpans, newSpans []wordSpan, ok bool) {
+	oldTokens, newTokens := tokenize(oldLine), tokenize(newLine)
+	n, m := len(oldTokens), len(newTokens)
+	if n == 0 || m == 0 || n > wordDiffMaxTokens || m > wordDiffMaxTokens {
+		return nil, nil, false
+	}
+
+	// Longest common subsequence table (suffix lengths)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if oldLine[oldTokens[i].start:oldTokens[i].end] == newLine[newTokens[j].start:newTokens[j].end] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	common := lcs[0][0]
+	changed := (n - common) + (m - common)
+	if common == 0 || changed*100 > (n+m)*wordDiffMaxChangedP {
+		return nil, nil, false
+	}
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && oldLine[oldTokens[i].start:oldTokens[i].end] == newLine[newTokens[j].start:newTokens[j].end]:
+			i++
+			j++
+		case j < m && (i == n || lcs[i][j+1] >= lcs[i+1][j]):
+			newSpans = appendSpan(newSpans, newTokens[j])
+			j++
+		default:
+			oldSpans = appendSpan(oldSpans, oldTokens[i])
+			i++
+		}
+	}
+	return oldSpans, newSpans, true
+}
+
+// tokenize splits a line into words, whitespace runs and punctuation characters
+func tokenize(line string) []wordSpan {
+	var tokens []wordSpan
+	kind := func(r rune) int {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			return 1
+		case unicode.IsSpace(r):
+			return 2
+		}
+		return 0 // Punctuation: one token per character
+	}
+
+	start, startKind := -1, -1
+	for idx, r := range line {
+		k := kind(r)
+		if start >= 0 && (k != startKind || k == 0) {
+			tokens = append(tokens, wordSpan{start, idx})
+			start = -1
+		}
+		if start < 0 {
+			start, startKind = idx, k
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, wordSpan{start, len(line)})
+	}
+	return tokens
+}
+
+// appendSpan adds a token to the spans, merging it with the previous one when adjacent
+func appendSpan(spans []wordSpan, token wordSpan) []wordSpan {
+	if len(spans) > 0 && spans[len(spans)-1].end == token.start {
+		spans[len(spans)-1].end = token.end
+		return spans
+	}
+	return append(spans, token)
+}
+
+// shiftSpans moves the spans by offset bytes
+func shiftSpans(spans []wordSpan, offset int) []wordSpan {
+	for i := range spans {
+		spans[i].start += offset
+		spans[i].end += offset
+	}
+	return spans
+}
+
+// renderDiffLine renders a diff line: the changed word ranges in the changed
+// style, the rest in the base style, with the syntax colors as foreground
+func renderDiffLine(line string, words []wordSpan, syntax []syntaxSpan, base, changed lipgloss.Style) string {
+	if len(words) == 0 && len(syntax) == 0 {
+		return base.Render(line)
+	}
+
+	// Segment boundaries: every start and end of a range
+	cuts := []int{0, len(line)}
+	for _, w := range words {
+		cuts = append(cuts, w.start, w.end)
+	}
+	for _, s := range syntax {
+		cuts = append(cuts, s.start, s.end)
+	}
+	sort.Ints(cuts)
+
+	var b strings.Builder
+	wi, si := 0, 0
+	for k := 0; k+1 < len(cuts); k++ {
+		start, end := cuts[k], cuts[k+1]
+		if start == end || end > len(line) {
+			continue
+		}
+		for wi < len(words) && words[wi].end <= start {
+			wi++
+		}
+		for si < len(syntax) && syntax[si].end <= start {
+			si++
+		}
+		style := base
+		if wi < len(words) && words[wi].start <= start {
+			style = changed
+		}
+		if si < len(syntax) && syntax[si].start <= start {
+			style = style.Foreground(syntaxColors[syntax[si].kind])
+		}
+		b.WriteString(style.Render(line[start:end]))
+	}
+	return b.String()
+}